@@ -20,12 +20,12 @@ func removeCRDValidation(filename string) {
 		panic(err)
 	}
 	spec := crd["spec"].(obj)
-	versions := spec["versions"].([]interface{})
-	version := versions[0].(obj)
-	properties := version["schema"].(obj)["openAPIV3Schema"].(obj)["properties"].(obj)
-	for k := range properties {
-		if k == "spec" || k == "status" {
-			properties[k] = obj{"type": "object", "x-kubernetes-preserve-unknown-fields": true, "x-kubernetes-map-type": "atomic"}
+	for _, version := range spec["versions"].([]interface{}) {
+		properties := version.(obj)["schema"].(obj)["openAPIV3Schema"].(obj)["properties"].(obj)
+		for k := range properties {
+			if k == "spec" || k == "status" {
+				properties[k] = obj{"type": "object", "x-kubernetes-preserve-unknown-fields": true, "x-kubernetes-map-type": "atomic"}
+			}
 		}
 	}
 	data, err = yaml.Marshal(crd)
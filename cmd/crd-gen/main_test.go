@@ -127,3 +127,51 @@ func TestMain_RemoveCRDValidation(t *testing.T) {
 	os.Args = []string{"cmd"}
 	assert.Panics(t, func() { main() })
 }
+
+func TestRemoveCRDValidation_AllVersions(t *testing.T) {
+	version := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"schema": map[string]interface{}{
+				"openAPIV3Schema": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"type":       "object",
+							"properties": map[string]interface{}{"foo": map[string]interface{}{"type": "string"}},
+						},
+					},
+				},
+			},
+		}
+	}
+	testCRD := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"versions": []interface{}{version("v1beta1"), version("v1beta2")},
+		},
+	}
+
+	tmpFile := filepath.Join(t.TempDir(), "test-crd.yaml")
+	data, err := yaml.Marshal(testCRD)
+	assert.NoError(t, err)
+	err = os.WriteFile(tmpFile, data, 0600)
+	assert.NoError(t, err)
+
+	removeCRDValidation(tmpFile)
+
+	resultData, err := os.ReadFile(tmpFile)
+	assert.NoError(t, err)
+	var result map[string]interface{}
+	err = yaml.Unmarshal(resultData, &result)
+	assert.NoError(t, err)
+
+	versions := result["spec"].(map[string]interface{})["versions"].([]interface{})
+	assert.Len(t, versions, 2)
+	for _, v := range versions {
+		properties := v.(map[string]interface{})["schema"].(map[string]interface{})["openAPIV3Schema"].(map[string]interface{})["properties"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"type":                                 "object",
+			"x-kubernetes-preserve-unknown-fields": true,
+			"x-kubernetes-map-type":                "atomic",
+		}, properties["spec"])
+	}
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"time"
 
 	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	ray "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -25,12 +28,14 @@ import (
 	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 	kueuev1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
 	schedulerpluginsv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/scheduling/v1alpha1"
@@ -38,16 +43,27 @@ import (
 	volcano "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta2"
+	"github.com/sgl-project/ome/pkg/auditsink"
 	"github.com/sgl-project/ome/pkg/constants"
 	v1beta1acceleratorclasscontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/acceleratorclass"
 	v1beta1basemodelcontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/basemodel"
 	v1beta1benchmarkjobcontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/benchmark"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerhealth"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/eventrecorder"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/finalizer"
 	v1beta1isvccontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/sharding"
+	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/runtimeselector"
+	"github.com/sgl-project/ome/pkg/tracing"
 	"github.com/sgl-project/ome/pkg/utils"
 	"github.com/sgl-project/ome/pkg/version"
+	"github.com/sgl-project/ome/pkg/webhook/admission/audit"
+	"github.com/sgl-project/ome/pkg/webhook/admission/basemodel"
 	"github.com/sgl-project/ome/pkg/webhook/admission/benchmark"
+	"github.com/sgl-project/ome/pkg/webhook/admission/finetunedweight"
 	"github.com/sgl-project/ome/pkg/webhook/admission/isvc"
 	"github.com/sgl-project/ome/pkg/webhook/admission/pod"
 	"github.com/sgl-project/ome/pkg/webhook/admission/servingruntime"
@@ -56,6 +72,10 @@ import (
 const (
 	LeaderLockName          = "ome-controller-manager-leader-lock"
 	LeaderElectionNamespace = "ome"
+	TracingServiceName      = "ome-controller-manager"
+
+	// tracingShutdownTimeout bounds the export of the spans left when the manager stops
+	tracingShutdownTimeout = 10 * time.Second
 )
 
 var (
@@ -85,6 +105,7 @@ func init() {
 	istionetworking.GatewayUnmarshaler.AllowUnknownFields = true
 
 	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(v1beta2.AddToScheme(scheme))
 	utilruntime.Must(schedulerpluginsv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(kueuev1beta1.AddToScheme(scheme))
@@ -92,15 +113,27 @@ func init() {
 
 // Options defines the program-configurable options that may be passed on the command line.
 type Options struct {
-	metricsAddr             string
-	secureMetrics           bool
-	enableHTTP2             bool
-	webhookPort             int
-	enableLeaderElection    bool
-	enableWebhook           bool
-	probeAddr               string
-	leaderElectionNamespace string
-	zapOpts                 zap.Options
+	metricsAddr                string
+	secureMetrics              bool
+	enableHTTP2                bool
+	webhookPort                int
+	enableLeaderElection       bool
+	enableWebhook              bool
+	probeAddr                  string
+	leaderElectionNamespace    string
+	enableAcceleratorDiscovery bool
+	webhookAuditLog            bool
+	auditSink                  string
+	tracingEndpoint            string
+	tracingInsecure            bool
+	tracingSampleRatio         float64
+	shard                      sharding.Shard
+	controllerHealth           controllerhealth.Thresholds
+	eventDedupWindow           time.Duration
+	finalizerMaxWait           time.Duration
+	modelMetadataImage         string
+	zapOpts                    zap.Options
+	logOpts                    logging.FlagOptions
 }
 
 // DefaultOptions returns the default values for the program options.
@@ -114,10 +147,14 @@ func DefaultOptions() Options {
 		secureMetrics:           false,
 		probeAddr:               ":8081",
 		leaderElectionNamespace: LeaderElectionNamespace,
+		tracingSampleRatio:      0.1,
+		eventDedupWindow:        eventrecorder.DefaultWindow,
+		finalizerMaxWait:        finalizer.DefaultMaxWait,
 		zapOpts: zap.Options{
 			TimeEncoder: zapcore.RFC3339TimeEncoder,
 			ZapOpts:     []zaplog.Option{zaplog.AddCaller()},
 		},
+		logOpts: logging.DefaultFlagOptions(),
 	}
 }
 
@@ -137,20 +174,116 @@ func GetOptions() Options {
 	flag.StringVar(&opts.leaderElectionNamespace, "leader-election-namespace", opts.leaderElectionNamespace, "The namespace in which the leader election configmap will be created.")
 	flag.BoolVar(&opts.enableWebhook, "webhook", opts.enableWebhook, "Enable the webhook server.")
 	flag.StringVar(&opts.probeAddr, "health-probe-addr", opts.probeAddr, "The address the probe endpoint binds to.")
+	flag.BoolVar(&opts.enableAcceleratorDiscovery, "enable-accelerator-discovery", opts.enableAcceleratorDiscovery,
+		"If set, AcceleratorClasses are created and updated from the GPU labels published on nodes by Node Feature Discovery and the GPU operator.")
+	flag.BoolVar(&opts.webhookAuditLog, "webhook-audit-log", opts.webhookAuditLog,
+		"If set, the webhooks log a structured audit entry with the user, object and verdict of every admission request they review.")
+	flag.StringVar(&opts.auditSink, "audit-sink", opts.auditSink,
+		"Where the audit events on the changes and transitions of the OME resources are written: stdout, an absolute file path or an http(s) webhook URL. "+
+			"No events are recorded if empty.")
+	flag.StringVar(&opts.tracingEndpoint, "tracing-endpoint", opts.tracingEndpoint,
+		"The host:port of the OTLP gRPC collector the traces of the reconciles, webhooks and API calls are exported to. "+
+			"Tracing is disabled if empty, unless OTEL_EXPORTER_OTLP_ENDPOINT is set.")
+	flag.BoolVar(&opts.tracingInsecure, "tracing-insecure", opts.tracingInsecure, "If set, the traces are exported without TLS.")
+	flag.Float64Var(&opts.tracingSampleRatio, "tracing-sample-ratio", opts.tracingSampleRatio,
+		"The fraction of the traces started by the manager that are sampled. The traces propagated by the API server follow its sampling decision.")
+	flag.IntVar(&opts.shard.Count, "shard-count", opts.shard.Count,
+		"The number of shards the InferenceServices, BaseModels and ClusterBaseModels are partitioned into, each reconciled by "+
+			"its own manager with its own leader election. Sharding is disabled if not greater than 1.")
+	flag.IntVar(&opts.shard.Index, "shard-index", opts.shard.Index,
+		"The shard reconciled by this manager, from 0 to --shard-count - 1. The controllers that are not sharded run in shard 0.")
+	flag.IntVar(&opts.controllerHealth.MaxErrorStreak, "controller-max-error-streak", opts.controllerHealth.MaxErrorStreak,
+		"The number of consecutive failed reconciles of a controller after which the liveness probe fails. Not enforced if 0.")
+	flag.DurationVar(&opts.controllerHealth.MaxReconcileDuration, "controller-max-reconcile-duration", opts.controllerHealth.MaxReconcileDuration,
+		"How long a reconcile of a controller can run before the liveness probe fails, e.g. 10m. Not enforced if 0.")
+	flag.DurationVar(&opts.eventDedupWindow, "event-dedup-window", opts.eventDedupWindow,
+		"The window within which the Warning events identical to one recorded for the same object are dropped. Every event is recorded if 0.")
+	flag.DurationVar(&opts.finalizerMaxWait, "finalizer-max-wait", opts.finalizerMaxWait,
+		"How long the deletion of an InferenceService, BaseModel or ClusterBaseModel waits for its cleanup, e.g. for the model agents to remove a model "+
+			"from the nodes, before its finalizer is forcibly removed. The deletion waits forever if 0.")
+	flag.StringVar(&opts.modelMetadataImage, "model-metadata-image", opts.modelMetadataImage,
+		"The ome-agent image of the Jobs extracting the metadata of the BaseModels and ClusterBaseModels stored in PVCs. No Job is launched if empty.")
+	flag.StringVar((*string)(&opts.shard.Key), "shard-key", string(sharding.KeyName),
+		"What the resources are assigned to a shard by: name, or namespace to keep the resources of a namespace on one shard.")
 	opts.zapOpts.BindFlags(flag.CommandLine)
+	opts.logOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 	return opts
 }
 
 func main() {
 	options := GetOptions()
+	logFile := options.logOpts.FileWriter()
+	if logFile != nil {
+		options.zapOpts.DestWriter = io.MultiWriter(os.Stderr, logFile)
+	}
+	// The level is shared with the logger rather than created by it, so that it can be changed at runtime
+	if options.zapOpts.Level == nil && options.zapOpts.Development {
+		options.zapOpts.Level = zapcore.DebugLevel
+	}
+	logLevel := logging.NewAtomicLevel(options.zapOpts.Level)
+	options.zapOpts.Level = logLevel
+	options.zapOpts.ZapOpts = append(options.zapOpts.ZapOpts,
+		options.logOpts.ControllerRuntimeZapOptions(options.zapOpts.Development, options.zapOpts.Level)...)
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&options.zapOpts)))
 
 	setupLog.Info("Initializing", "gitVersion", version.GitVersion, "gitCommit", version.GitCommit)
+	ctx := signals.SetupSignalHandler()
+
+	// SIGHUP restores the configured log level and rotates the log file
+	configuredLevel := logLevel.Level()
+	logging.OnSIGHUP(ctx, func() {
+		logLevel.SetLevel(configuredLevel)
+		if logFile != nil {
+			if err := logFile.Rotate(); err != nil {
+				setupLog.Error(err, "Failed to rotate log file")
+			}
+		}
+		setupLog.Info("Reloaded logging on SIGHUP", "level", configuredLevel)
+	})
+	if options.logOpts.LevelEndpoint {
+		levelHandler := logging.LevelHandler(logLevel, func(from, to zapcore.Level) {
+			setupLog.Info("Log level changed", "from", from, "to", to)
+		})
+		// Served by every replica rather than by the leader alone, unlike the runnables of the manager
+		go func() {
+			if err := logging.ServeLevel(ctx, options.logOpts.LevelAddress, levelHandler); err != nil {
+				setupLog.Error(err, "Failed to serve log level endpoint")
+				os.Exit(1)
+			}
+		}()
+	}
+
+	tracingConfig := tracing.Config{
+		ServiceName: TracingServiceName,
+		Endpoint:    options.tracingEndpoint,
+		Insecure:    options.tracingInsecure,
+		SampleRatio: options.tracingSampleRatio,
+	}
+	shutdownTracing, err := tracing.Setup(ctx, tracingConfig)
+	if err != nil {
+		setupLog.Error(err, "Failed to set up tracing")
+		os.Exit(1)
+	}
+	if tracingConfig.Enabled() {
+		setupLog.Info("Tracing enabled", "endpoint", options.tracingEndpoint, "sampleRatio", options.tracingSampleRatio)
+	}
+
+	if err := options.shard.Validate(); err != nil {
+		setupLog.Error(err, "Invalid sharding options")
+		os.Exit(1)
+	}
+	if options.shard.Enabled() {
+		setupLog.Info("Sharding enabled", "shard", options.shard.String(), "key", options.shard.Key)
+	}
 
 	// Get a config to talk to the apiserver
 	setupLog.Info("Configuring API client connection")
 	cfg := ctrl.GetConfigOrDie()
+	if tracingConfig.Enabled() {
+		// The calls of the clients to the API server are traced as children of the reconciles and reviews
+		cfg.Wrap(tracing.WrapTransport)
+	}
 
 	// Setup clientset to directly talk to the api server
 	setupLog.Info("Creating Kubernetes client set")
@@ -178,6 +311,25 @@ func main() {
 		"metricsAddr", options.metricsAddr,
 		"webhookPort", options.webhookPort,
 		"leaderElection", options.enableLeaderElection)
+	var webhookServer webhook.Server = webhook.NewServer(webhook.Options{
+		Port:    options.webhookPort,
+		TLSOpts: tlsOpts,
+	})
+	if tracingConfig.Enabled() {
+		webhookServer = tracing.NewWebhookServer(webhookServer)
+	}
+	var auditEvents *auditsink.Recorder
+	if options.auditSink != "" {
+		sink, err := auditsink.NewSink(options.auditSink)
+		if err != nil {
+			setupLog.Error(err, "Failed to create audit sink")
+			os.Exit(1)
+		}
+		auditEvents = auditsink.NewRecorder(sink, auditsink.DefaultBufferSize)
+		setupLog.Info("Audit events enabled", "sink", options.auditSink)
+	}
+	auditServer := audit.NewServer(webhookServer, options.webhookAuditLog)
+	auditServer.Events = auditEvents
 	mgr, err := manager.New(cfg, manager.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -185,20 +337,28 @@ func main() {
 			TLSOpts:       tlsOpts,
 			SecureServing: options.secureMetrics,
 		},
-		WebhookServer: webhook.NewServer(webhook.Options{
-			Port:    options.webhookPort,
-			TLSOpts: tlsOpts,
-		}),
+		// The admission webhooks report their decisions, latency and rule hits on the metrics endpoint
+		WebhookServer:           auditServer,
 		LeaderElection:          options.enableLeaderElection,
-		LeaderElectionID:        LeaderLockName,
+		LeaderElectionID:        options.shard.LeaderElectionID(LeaderLockName),
 		LeaderElectionNamespace: options.leaderElectionNamespace,
-		HealthProbeBindAddress:  options.probeAddr,
+		// The probes are served by controllerhealth to report the health of every controller
+		HealthProbeBindAddress: "0",
+		// A sharded manager caches the InferenceServices of its shard only
+		Cache: cache.Options{ByObject: options.shard.CacheByObject()},
 	})
 	if err != nil {
 		setupLog.Error(err, "Failed to initialize controller manager")
 		os.Exit(1)
 	}
 
+	if auditEvents != nil {
+		if err := mgr.Add(auditEvents); err != nil {
+			setupLog.Error(err, "Failed to add audit event recorder")
+			os.Exit(1)
+		}
+	}
+
 	deployConfig, err := controllerconfig.NewDeployConfig(clientSet)
 	if err != nil {
 		setupLog.Error(err, "Failed to initialize deployment configuration")
@@ -209,6 +369,11 @@ func main() {
 		setupLog.Error(err, "Failed to initialize ingress configuration")
 		os.Exit(1)
 	}
+	runtimeSelectorConfig, err := controllerconfig.NewRuntimeSelectorConfig(clientSet)
+	if err != nil {
+		setupLog.Error(err, "Failed to initialize runtime selector configuration")
+		os.Exit(1)
+	}
 
 	// Register optional schemes based on CRD availability
 	setupLog.Info("Registering optional CRD schemes")
@@ -247,11 +412,13 @@ func main() {
 	setupLog.Info("Setting up InferenceService controller")
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
 	if err = (&v1beta1isvccontroller.InferenceServiceReconciler{
-		Client:    mgr.GetClient(),
-		Clientset: clientSet,
-		Log:       ctrl.Log.WithName("InferenceService"),
-		Scheme:    mgr.GetScheme(),
-		Recorder:  eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
+		Client:           mgr.GetClient(),
+		Clientset:        clientSet,
+		Log:              ctrl.Log.WithName("InferenceService"),
+		Scheme:           mgr.GetScheme(),
+		Recorder:         eventrecorder.NewDeduplicating(eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}), options.eventDedupWindow),
+		Audit:            auditEvents,
+		FinalizerMaxWait: options.finalizerMaxWait,
 	}).SetupWithManager(mgr, deployConfig, ingressConfig); err != nil {
 		setupLog.Error(err, "Failed to create InferenceService controller")
 		os.Exit(1)
@@ -260,9 +427,14 @@ func main() {
 	// Setup BaseModel and ClusterBaseModel controllers with the manager
 	setupLog.Info("Setting up BaseModel controller")
 	if err = (&v1beta1basemodelcontroller.BaseModelReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("BaseModel"),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		Log:              ctrl.Log.WithName("BaseModel"),
+		Scheme:           mgr.GetScheme(),
+		Audit:            auditEvents,
+		Shard:            options.shard,
+		Recorder:         eventrecorder.NewDeduplicating(eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}), options.eventDedupWindow),
+		FinalizerMaxWait: options.finalizerMaxWait,
+		MetadataImage:    options.modelMetadataImage,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create BaseModel controller")
 		os.Exit(1)
@@ -270,40 +442,74 @@ func main() {
 
 	setupLog.Info("Setting up ClusterBaseModel controller")
 	if err = (&v1beta1basemodelcontroller.ClusterBaseModelReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("ClusterBaseModel"),
-		Scheme: mgr.GetScheme(),
+		Client:           mgr.GetClient(),
+		Log:              ctrl.Log.WithName("ClusterBaseModel"),
+		Scheme:           mgr.GetScheme(),
+		Audit:            auditEvents,
+		Shard:            options.shard,
+		Recorder:         eventrecorder.NewDeduplicating(eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}), options.eventDedupWindow),
+		FinalizerMaxWait: options.finalizerMaxWait,
+		MetadataImage:    options.modelMetadataImage,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create ClusterBaseModel controller")
 		os.Exit(1)
 	}
 
-	benchmarkJobEventBroadcaster := record.NewBroadcaster()
-	setupLog.Info("Setting up BenchmarkJob controller")
-	benchmarkJobEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
-	if err = (&v1beta1benchmarkjobcontroller.BenchmarkJobReconciler{
-		Client:    mgr.GetClient(),
-		Clientset: clientSet,
-		Log:       ctrl.Log.WithName("BenchmarkJob"),
-		Scheme:    mgr.GetScheme(),
-		Recorder:  benchmarkJobEventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "Failed to create Benchmark Job controller")
-		os.Exit(1)
-	}
+	// The controllers that are not sharded run in the first shard only
+	if options.shard.Primary() {
+		if options.shard.Enabled() {
+			setupLog.Info("Setting up shard assigner")
+			if err = (&sharding.Assigner{
+				Client: mgr.GetClient(),
+				Shard:  options.shard,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "Failed to create shard assigner")
+				os.Exit(1)
+			}
+		}
 
-	// Setup AcceleratorClass controller
-	acceleratorClassEventBroadcaster := record.NewBroadcaster()
-	setupLog.Info("Setting up AcceleratorClass controller")
-	acceleratorClassEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
-	if err = (&v1beta1acceleratorclasscontroller.AcceleratorClassReconciler{
-		Client:   mgr.GetClient(),
-		Log:      ctrl.Log.WithName("AcceleratorClass"),
-		Scheme:   mgr.GetScheme(),
-		Recorder: acceleratorClassEventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "Failed to create AcceleratorClass controller")
-		os.Exit(1)
+		benchmarkJobEventBroadcaster := record.NewBroadcaster()
+		setupLog.Info("Setting up BenchmarkJob controller")
+		benchmarkJobEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
+		if err = (&v1beta1benchmarkjobcontroller.BenchmarkJobReconciler{
+			Client:    mgr.GetClient(),
+			Clientset: clientSet,
+			Log:       ctrl.Log.WithName("BenchmarkJob"),
+			Scheme:    mgr.GetScheme(),
+			Recorder:  eventrecorder.NewDeduplicating(benchmarkJobEventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}), options.eventDedupWindow),
+			// The cache of a sharded manager only holds the InferenceServices of its shard
+			InferenceServiceReader: mgr.GetAPIReader(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to create Benchmark Job controller")
+			os.Exit(1)
+		}
+
+		// Setup AcceleratorClass controller
+		acceleratorClassEventBroadcaster := record.NewBroadcaster()
+		setupLog.Info("Setting up AcceleratorClass controller")
+		acceleratorClassEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
+		if err = (&v1beta1acceleratorclasscontroller.AcceleratorClassReconciler{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("AcceleratorClass"),
+			Scheme:   mgr.GetScheme(),
+			Recorder: eventrecorder.NewDeduplicating(acceleratorClassEventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}), options.eventDedupWindow),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Failed to create AcceleratorClass controller")
+			os.Exit(1)
+		}
+
+		if options.enableAcceleratorDiscovery {
+			setupLog.Info("Setting up AcceleratorClass discovery controller")
+			if err = (&v1beta1acceleratorclasscontroller.AcceleratorDiscoveryReconciler{
+				Client:   mgr.GetClient(),
+				Log:      ctrl.Log.WithName("AcceleratorDiscovery"),
+				Scheme:   mgr.GetScheme(),
+				Recorder: eventrecorder.NewDeduplicating(acceleratorClassEventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}), options.eventDedupWindow),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "Failed to create AcceleratorClass discovery controller")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if options.enableWebhook {
@@ -311,8 +517,15 @@ func main() {
 		hookServer := mgr.GetWebhookServer()
 
 		setupLog.Info("Registering InferenceService webhook to the webhook server")
+		// The pod mutator reads the namespace ConfigMaps customizing it from an informer
+		namespaceConfigMaps := controllerconfig.NewConfigMapCache(clientSet, "", constants.PodMutatorNamespaceConfigMapName)
+		if err := mgr.Add(namespaceConfigMaps); err != nil {
+			setupLog.Error(err, "Failed to add pod mutator ConfigMap cache")
+			os.Exit(1)
+		}
 		hookServer.Register("/mutate-pods", &webhook.Admission{
-			Handler: &pod.Mutator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+			Handler: &pod.Mutator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme()),
+				NamespaceConfigMaps: namespaceConfigMaps},
 		})
 
 		setupLog.Info("Registering cluster serving runtime validator webhook to the webhook server")
@@ -327,9 +540,41 @@ func main() {
 
 		setupLog.Info("Registering benchmark job validator webhook to the webhook server")
 		hookServer.Register("/validate-ome-io-v1beta1-benchmarkjob", &webhook.Admission{
-			Handler: &benchmark.BenchmarkJobValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
+			Handler: &benchmark.BenchmarkJobValidator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+		})
+
+		setupLog.Info("Registering base model validator webhooks to the webhook server")
+		hookServer.Register("/validate-ome-io-v1beta1-basemodel", &webhook.Admission{
+			Handler: &basemodel.BaseModelValidator{Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+		})
+		hookServer.Register("/validate-ome-io-v1beta1-clusterbasemodel", &webhook.Admission{
+			Handler: &basemodel.ClusterBaseModelValidator{Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
+		})
+
+		setupLog.Info("Registering fine tuned weight validator webhook to the webhook server")
+		hookServer.Register("/validate-ome-io-v1beta1-finetunedweight", &webhook.Admission{
+			Handler: &finetunedweight.FineTunedWeightValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
 		})
 
+		// Converts the InferenceServices, BaseModels and ClusterBaseModels between v1beta2 and the stored v1beta1
+		setupLog.Info("Registering conversion webhook to the webhook server")
+		hookServer.Register("/convert", conversion.NewWebhookHandler(mgr.GetScheme()))
+
+		// The InferenceService policies are read from an informer and compiled when the ConfigMap changes
+		policyStore, err := isvc.NewPolicyStore(clientSet)
+		if err != nil {
+			setupLog.Error(err, "Failed to create InferenceService policy store")
+			os.Exit(1)
+		}
+		if err := mgr.Add(policyStore); err != nil {
+			setupLog.Error(err, "Failed to add InferenceService policy store")
+			os.Exit(1)
+		}
+
+		selectorConfig := runtimeselector.NewConfig(mgr.GetClient())
+		selectorConfig.ScorerWeights = runtimeSelectorConfig.ScorerWeights
+		runtimeSelector := runtimeselector.NewWithConfig(selectorConfig)
+
 		if err = ctrl.NewWebhookManagedBy(mgr).
 			For(&v1beta1.InferenceService{}).
 			WithDefaulter(&isvc.InferenceServiceDefaulter{
@@ -338,7 +583,8 @@ func main() {
 			}).
 			WithValidator(&isvc.InferenceServiceValidator{
 				Client:          mgr.GetClient(),
-				RuntimeSelector: runtimeselector.New(mgr.GetClient()),
+				RuntimeSelector: runtimeSelector,
+				Policies:        policyStore,
 			}).
 			Complete(); err != nil {
 			setupLog.Error(err, "Failed to create InferenceService webhook", "webhook", "v1beta1")
@@ -346,23 +592,30 @@ func main() {
 		}
 	}
 
-	if err := mgr.AddHealthzCheck("healthz", func(req *http.Request) error {
+	probes := controllerhealth.NewProbes(controllerhealth.Default, mgr.GetCache(), options.controllerHealth)
+	probes.AddHealthzCheck("healthz", func(req *http.Request) error {
 		return mgr.GetWebhookServer().StartedChecker()(req)
-	}); err != nil {
-		setupLog.Error(err, "Unable to set up health check")
-		os.Exit(1)
-	}
-	if err := mgr.AddReadyzCheck("readyz", func(req *http.Request) error {
+	})
+	probes.AddReadyzCheck("readyz", func(req *http.Request) error {
 		return mgr.GetWebhookServer().StartedChecker()(req)
-	}); err != nil {
-		setupLog.Error(err, "Unable to set up ready check")
-		os.Exit(1)
+	})
+	if options.probeAddr != "0" {
+		if err := mgr.Add(probes.Server(options.probeAddr)); err != nil {
+			setupLog.Error(err, "Unable to set up health probes")
+			os.Exit(1)
+		}
 	}
 
 	// Start the Cmd
 	setupLog.Info("Starting manager")
-	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "Failed to start manager")
 		os.Exit(1)
 	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "Failed to flush traces")
+	}
 }
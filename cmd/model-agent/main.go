@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 	kubeapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/kubernetes"
@@ -56,8 +58,9 @@ var (
 		Run:              runCommand,
 		PersistentPreRun: initConfig,
 	}
-	cfg = &config{}
-	v   = viper.New() // Global viper instance for configuration
+	cfg     = &config{}
+	logOpts = logging.DefaultFlagOptions()
+	v       = viper.New() // Global viper instance for configuration
 )
 
 // init sets up command line flags and binds them to Viper
@@ -73,6 +76,9 @@ func init() {
 	rootCmd.PersistentFlags().IntVar(&cfg.numDownloadWorker, "num-download-worker", 5, "Number of download workers")
 	rootCmd.PersistentFlags().StringVar(&cfg.namespace, "namespace", "ome", "Kubernetes namespace to use")
 	rootCmd.PersistentFlags().StringVar(&cfg.logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+	logFlags := flag.NewFlagSet("logging", flag.ExitOnError)
+	logOpts.BindFlags(logFlags)
+	rootCmd.PersistentFlags().AddGoFlagSet(logFlags)
 
 	_ = v.BindPFlags(rootCmd.PersistentFlags())
 	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
@@ -91,11 +97,12 @@ func initConfig(_ *cobra.Command, _ []string) {
 	cfg.nodeName = nodeName
 }
 
-// initializeLogger creates and configures a zap logger with the specified settings
-func initializeLogger() (*Logger, error) {
+// initializeLogger creates and configures a zap logger with the specified settings, also writing to the log file if
+// not nil. The returned level can be changed at runtime.
+func initializeLogger(file *lumberjack.Logger) (*Logger, zap.AtomicLevel, error) {
 	level, err := zapcore.ParseLevel(v.GetString("log-level"))
 	if err != nil {
-		return nil, fmt.Errorf("invalid log level %q: %w", "info", err)
+		return nil, zap.AtomicLevel{}, fmt.Errorf("invalid log level %q: %w", "info", err)
 	}
 
 	config := zap.Config{
@@ -113,12 +120,25 @@ func initializeLogger() (*Logger, error) {
 		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
 
-	logger, err := config.Build()
+	var options []zap.Option
+	if file != nil {
+		// The file is read by tools rather than people, without the colors of the console
+		fileEncoderConfig := zap.NewProductionEncoderConfig()
+		fileEncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		fileCore := zapcore.NewCore(zapcore.NewJSONEncoder(fileEncoderConfig), zapcore.AddSync(file), config.Level)
+		options = append(options, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, fileCore)
+		}))
+	}
+	// The sampling wraps the file too
+	options = append(options, logOpts.ZapOptions()...)
+
+	logger, err := config.Build(options...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build logger: %w", err)
+		return nil, zap.AtomicLevel{}, fmt.Errorf("failed to build logger: %w", err)
 	}
 
-	return logger.Sugar(), nil
+	return logger.Sugar(), config.Level, nil
 }
 
 // setupServer configures an HTTP server for health checks and metrics
@@ -242,9 +262,10 @@ func initializeComponents(
 	// Use log-only mode for cleaner logs in production
 	xetHubConfig, err := xet.NewConfig(
 		xet.WithDefaults(),
-		xet.WithViper(v),                          // Apply viper config first to set defaults
-		xet.WithLogger(logging.ForZap(zapLogger)), // Then set the logger
-		xet.WithEnableProgressReporting(true),     // Enable progress reporting
+		xet.WithViper(v),                              // Apply viper config first to set defaults
+		xet.WithLogger(logging.ForZap(zapLogger)),     // Then set the logger
+		xet.WithEnableProgressReporting(true),         // Enable progress reporting
+		xet.WithMetrics(prometheus.DefaultRegisterer), // Export the HF transfers on /metrics
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create HuggingFace hub config: %w", err)
@@ -279,7 +300,8 @@ func initializeComponents(
 // runCommand is the main entry point executed by Cobra
 func runCommand(cmd *cobra.Command, args []string) {
 	// Initialize logger
-	logger, err := initializeLogger()
+	logFile := logOpts.FileWriter()
+	logger, logLevel, err := initializeLogger(logFile)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "failed to initialize logger: %v\n", err)
 		os.Exit(1)
@@ -317,6 +339,29 @@ func runCommand(cmd *cobra.Command, args []string) {
 		}
 	}()
 
+	// SIGHUP restores the configured log level and rotates the log file
+	configuredLevel := logLevel.Level()
+	logging.OnSIGHUP(ctx, func() {
+		logLevel.SetLevel(configuredLevel)
+		if logFile != nil {
+			if err := logFile.Rotate(); err != nil {
+				logger.Errorf("Failed to rotate log file: %v", err)
+			}
+		}
+		logger.Infow("Reloaded logging on SIGHUP", "level", configuredLevel)
+	})
+	if logOpts.LevelEndpoint {
+		levelHandler := logging.LevelHandler(logLevel, func(from, to zapcore.Level) {
+			logger.Infow("Log level changed", "from", from, "to", to)
+		})
+		go func() {
+			logger.Infof("Starting log level endpoint at %s%s", logOpts.LevelAddress, logging.LevelPath)
+			if err := logging.ServeLevel(ctx, logOpts.LevelAddress, levelHandler); err != nil {
+				logger.Fatalf("Failed to serve log level endpoint: %v", err)
+			}
+		}()
+	}
+
 	// Create a download task communication channel
 	gopherTaskChan := make(chan *modelagent.GopherTask)
 
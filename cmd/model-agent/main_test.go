@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -12,7 +13,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"gopkg.in/natefinch/lumberjack.v2"
 	"k8s.io/client-go/rest"
 )
 
@@ -140,7 +143,7 @@ func TestInitializeLogger(t *testing.T) {
 	v = testViper
 
 	// Initialize logger
-	logger, err := initializeLogger()
+	logger, _, err := initializeLogger(nil)
 	require.NoError(t, err)
 	require.NotNil(t, logger)
 
@@ -150,9 +153,22 @@ func TestInitializeLogger(t *testing.T) {
 	testViper.Set("log.development", false)
 
 	// Re-initialize logger
-	logger, err = initializeLogger()
+	logger, _, err = initializeLogger(nil)
 	require.NoError(t, err)
 	require.NotNil(t, logger)
+
+	// Log to a file too
+	logFile := &lumberjack.Logger{Filename: filepath.Join(t.TempDir(), "model-agent.log")}
+	logger, level, err := initializeLogger(logFile)
+	require.NoError(t, err)
+	logger.Debug("not logged")
+	level.SetLevel(zapcore.DebugLevel)
+	logger.Debug("logged once the level changed")
+	require.NoError(t, logFile.Close())
+	data, err := os.ReadFile(logFile.Filename)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "not logged")
+	assert.Contains(t, string(data), "logged once the level changed")
 }
 
 func TestSetupServer(t *testing.T) {
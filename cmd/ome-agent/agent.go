@@ -2,15 +2,21 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+
+	"github.com/sgl-project/ome/pkg/agentprogress"
+	"github.com/sgl-project/ome/pkg/configutils"
 )
 
 var configFilePath string
 var debug bool
+var printConfig bool
+var strictConfig bool
 
 // AgentModule represents a module that can be run by the agent framework
 type AgentModule interface {
@@ -22,6 +28,9 @@ type AgentModule interface {
 	// ConfigureCommand Allow agents to configure their commands (add subcommands, custom flags, etc.)
 	ConfigureCommand(*cobra.Command)
 
+	// ConfigSchema returns the keys the agent reads from its configuration
+	ConfigSchema() *configutils.Schema
+
 	// Start is the default action when no subcommand is specified
 	Start() error
 }
@@ -38,6 +47,8 @@ func CreateAgentCommand(module AgentModule) *cobra.Command {
 	// Add common flags to persistent flags so they're available to subcommands
 	cmd.PersistentFlags().StringVarP(&configFilePath, "config", "c", "", "path to config file")
 	cmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "enable debug mode")
+	cmd.PersistentFlags().BoolVar(&printConfig, "print-config", false, "print the effective configuration and exit")
+	cmd.PersistentFlags().BoolVar(&strictConfig, "strict-config", false, "fail on the config keys the agent doesn't read instead of ignoring them with a warning")
 
 	// Let the module configure its command (add subcommands, set Run function, etc.)
 	module.ConfigureCommand(cmd)
@@ -47,21 +58,36 @@ func CreateAgentCommand(module AgentModule) *cobra.Command {
 
 // runAgentCommand runs a specific command action for an agent
 func runAgentCommand(cmd *cobra.Command, module AgentModule, action func() error) {
+	if printConfig {
+		if err := printEffectiveConfig(cmd, module); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	options := []fx.Option{
 		// Set up all config variables to viper
 		configProvider(cmd, module),
+		// Publish the progress of the agent, agents report their own phases through the reporter
+		progressProvider(module),
 	}
 
 	// Add module-specific options
 	options = append(options, module.FxModules()...)
 
 	// Add lifecycle hooks
-	options = append(options, fx.Invoke(func(lc fx.Lifecycle, l *zap.Logger, sh fx.Shutdowner) {
+	options = append(options, fx.Invoke(func(lc fx.Lifecycle, l *zap.Logger, sh fx.Shutdowner, progress *agentprogress.Reporter) {
 		lc.Append(
 			fx.Hook{
 				OnStart: func(context.Context) error {
 					go func() {
-						if err := action(); err != nil {
+						ctx, stopHeartbeats := context.WithCancel(context.Background())
+						go progress.Run(ctx)
+						err := action()
+						stopHeartbeats()
+						progress.Finish(context.Background(), err)
+						if err != nil {
 							l.Error(module.Name()+" encountered an error during execution", zap.Error(err))
 							os.Exit(1)
 						}
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/pkg/configutils"
 )
 
 // MockAgentModule is a mock implementation of the AgentModule interface for testing
@@ -39,6 +41,11 @@ func (m *MockAgentModule) ConfigureCommand(cmd *cobra.Command) {
 	m.Called(cmd)
 }
 
+func (m *MockAgentModule) ConfigSchema() *configutils.Schema {
+	args := m.Called()
+	return args.Get(0).(*configutils.Schema)
+}
+
 func (m *MockAgentModule) Start() error {
 	args := m.Called()
 	return args.Error(0)
@@ -51,6 +58,7 @@ func TestCreateAgentCommand(t *testing.T) {
 
 	// Set up expectations
 	mockModule.On("Name").Return("mock-agent")
+	mockModule.On("ConfigSchema").Return(configutils.NewSchema().AddKeys("model_path"))
 	mockModule.On("ShortDescription").Return("Mock Agent Short Description")
 	mockModule.On("LongDescription").Return("Mock Agent Long Description")
 	mockModule.On("ConfigureCommand", mock.AnythingOfType("*cobra.Command")).Run(func(args mock.Arguments) {
@@ -119,6 +127,7 @@ func TestAgentWithSubcommands(t *testing.T) {
 
 	// Set up expectations
 	mockModule.On("Name").Return("mock-agent")
+	mockModule.On("ConfigSchema").Return(configutils.NewSchema().AddKeys("model_path"))
 	mockModule.On("ShortDescription").Return("Mock Agent Short Description")
 	mockModule.On("LongDescription").Return("Mock Agent Long Description")
 	mockModule.On("ConfigureCommand", mock.AnythingOfType("*cobra.Command")).Run(func(args mock.Arguments) {
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/internal/ome-agent/benchmark"
+	"github.com/sgl-project/ome/pkg/configutils"
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// BenchmarkAgent implements the AgentModule interface for benchmarking inference endpoints
+type BenchmarkAgent struct {
+	agent *benchmark.BenchmarkAgent
+}
+
+// Name returns the name of the agent
+func (b *BenchmarkAgent) Name() string {
+	return "benchmark"
+}
+
+// ShortDescription returns a short description of the agent
+func (b *BenchmarkAgent) ShortDescription() string {
+	return "Benchmark an inference endpoint"
+}
+
+// LongDescription returns a detailed description of the agent
+func (b *BenchmarkAgent) LongDescription() string {
+	return "Benchmark agent generates load against an OpenAI compatible endpoint, measures token latencies and uploads the results of a BenchmarkJob"
+}
+
+// ConfigureCommand configures the agent command
+func (b *BenchmarkAgent) ConfigureCommand(cmd *cobra.Command) {
+	// These flags match the ones of genai-bench and are provided by the BenchmarkJob controller when running as a Job
+	flags := cmd.Flags()
+	flags.String("api-backend", benchmark.OpenAIBackend, "API backend of the endpoint")
+	flags.String("api-base", "", "Base URL of the endpoint")
+	flags.String("api-model-name", "", "Model name sent in the requests")
+	flags.String("api-key", "", "API key of the endpoint")
+	flags.String("model-tokenizer", "", "Tokenizer of the model")
+	flags.String("task", benchmark.TextToTextTask, "Benchmark task")
+	flags.StringArray("traffic-scenario", nil, "Traffic scenario, may be repeated")
+	flags.IntSlice("num-concurrency", nil, "Number of concurrent requests, may be repeated")
+	flags.Int("max-time-per-run", 0, "Maximum duration of an iteration in minutes")
+	flags.Int("max-requests-per-run", 0, "Maximum number of requests of an iteration")
+	flags.Int("warmup-duration", 0, "Duration of the warmup in seconds")
+	flags.Int("warmup-requests", 0, "Number of warmup requests")
+	flags.Bool("stream", true, "Send streaming requests")
+	flags.Bool("no-stream", false, "Send non-streaming requests")
+	flags.String("experiment-base-dir", "", "Directory the experiment folder is created in")
+	flags.String("experiment-folder-name", "", "Name of the experiment folder")
+	flags.Bool("save-request-records", false, "Save the record of every request")
+	flags.String("metrics-report-path", "", "Path the metrics report is written to")
+	flags.String("server-engine", "", "Serving engine of the endpoint")
+	flags.String("server-gpu-type", "", "GPU type of the endpoint")
+	flags.String("server-version", "", "Serving engine version of the endpoint")
+	flags.Int("server-gpu-count", 0, "Number of GPUs of the endpoint")
+	flags.Bool("upload-results", false, "Upload the results to OCI Object Storage")
+	flags.String("namespace", "", "Object Storage namespace")
+	flags.String("storage-bucket", "", "Object Storage bucket")
+	flags.String("storage-prefix", "", "Object Storage prefix")
+	flags.String("auth", "", "OCI authentication type")
+	flags.String("region", "", "OCI region")
+	flags.String("config-file", "", "OCI config file, not supported")
+	flags.String("profile", "", "OCI config profile, not supported")
+	flags.String("security-token", "", "OCI security token, not supported")
+
+	_ = cmd.MarkFlagRequired("api-base")
+	_ = cmd.MarkFlagRequired("experiment-folder-name")
+
+	// Bind flags to viper with underscore keys to match mapstructure tags
+	for _, name := range []string{
+		"api-backend", "api-base", "api-model-name", "api-key", "model-tokenizer", "task",
+		"traffic-scenario", "num-concurrency", "max-time-per-run", "max-requests-per-run",
+		"warmup-duration", "warmup-requests", "no-stream",
+		"experiment-base-dir", "experiment-folder-name", "save-request-records", "metrics-report-path",
+		"server-engine", "server-gpu-type", "server-version", "server-gpu-count",
+		"upload-results", "namespace", "storage-bucket", "storage-prefix", "auth", "region",
+		"config-file", "profile", "security-token",
+	} {
+		_ = viper.BindPFlag(strings.ReplaceAll(name, "-", "_"), flags.Lookup(name))
+	}
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		runAgentCommand(cmd, b, b.Start)
+	}
+}
+
+// ConfigSchema returns the keys the agent reads from its configuration
+func (b *BenchmarkAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().Add("", &benchmark.Config{})
+}
+
+// FxModules returns the fx modules needed by this agent
+func (b *BenchmarkAgent) FxModules() []fx.Option {
+	return []fx.Option{
+		logging.Module,
+		benchmark.Module,
+		fx.Populate(&b.agent),
+	}
+}
+
+// Start runs the agent
+func (b *BenchmarkAgent) Start() error {
+	return b.agent.Start()
+}
+
+// NewBenchmarkAgent creates a new benchmark agent
+func NewBenchmarkAgent() *BenchmarkAgent {
+	return &BenchmarkAgent{}
+}
@@ -3,40 +3,133 @@ package main
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
 
+	"github.com/sgl-project/ome/pkg/agentprogress"
 	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/logging"
 )
 
+// The configuration of an agent is resolved from, in order of precedence:
+//
+//  1. the flags of its command, e.g. --model-path for model_path
+//  2. the environment, OME_AGENT_ followed by the key in upper case with dots replaced by underscores, e.g.
+//     OME_AGENT_SOURCE_OCI_REGION for source.oci.region
+//  3. the configuration file given with --config, then the files it imports, the importing file taking precedence
+//  4. the defaults of the agent
+//
+// The keys of the configuration file are validated against the schema of the agent: a key the agent doesn't read, e.g.
+// a typo or a key of another agent, is ignored with a warning, or fails the agent with --strict-config.
+
+// legacyConfigKeys are no longer read by any agent, they are ignored with a warning
+var legacyConfigKeys = map[string]bool{
+	"skip_sha":                  true,
+	"retry_internal_in_seconds": true,
+}
+
+// commonConfigSchema returns the schema of the configuration shared by all the agents
+func commonConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().
+		AddKeys("debug", configutils.ImportKey).
+		Add(logging.ConfigKey, &logging.Config{}).
+		Add("another_log", &logging.Config{}).
+		Add("hub_logger", &logging.Config{}).
+		Add(agentprogress.ConfigKey, &agentprogress.Config{})
+}
+
+// agentConfigSchema returns the schema of the keys an agent reads
+func agentConfigSchema(module AgentModule) *configutils.Schema {
+	return commonConfigSchema().Merge(module.ConfigSchema())
+}
+
 func configProvider(cli *cobra.Command, module AgentModule) fx.Option {
 	return fx.Provide(func() (*viper.Viper, error) {
-		v := viper.GetViper()
+		return loadConfig(cli, module)
+	})
+}
 
-		v.SetDefault("OME_AGENT", constants.AgentAppName)
-		v.SetEnvPrefix(constants.AgentAppName)
-		v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-		v.AutomaticEnv()
+// progressProvider provides the reporter publishing the progress heartbeats of an agent, to the progress volume and
+// the pod annotation the webhook configures through the environment
+func progressProvider(module AgentModule) fx.Option {
+	return fx.Provide(func(v *viper.Viper, l *zap.Logger) *agentprogress.Reporter {
+		return agentprogress.New(module.Name(), agentprogress.NewConfig(v), logging.ForZap(l))
+	})
+}
 
-		if err := v.BindPFlag("debug", cli.Flags().Lookup("debug")); err != nil {
-			panic(err)
-		}
-		if configFilePath == "" {
-			return nil, errors.New("no config file provided")
-		}
+// loadConfig validates the configuration file and loads it, with the environment and the flags, into Viper
+func loadConfig(cli *cobra.Command, module AgentModule) (*viper.Viper, error) {
+	v := viper.GetViper()
 
-		if err := configutils.ResolveAndMergeFile(v, configFilePath); err != nil {
-			return nil, fmt.Errorf("cannot read config file: %w", err)
-		}
+	v.SetDefault("OME_AGENT", constants.AgentAppName)
+	v.SetEnvPrefix(constants.AgentAppName)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if err := v.BindPFlag("debug", cli.Flags().Lookup("debug")); err != nil {
+		panic(err)
+	}
+	if configFilePath == "" {
+		return nil, errors.New("no config file provided")
+	}
+
+	if err := validateConfigFile(configFilePath, agentConfigSchema(module), strictConfig); err != nil {
+		return nil, err
+	}
+	if err := configutils.ResolveAndMergeFile(v, configFilePath); err != nil {
+		return nil, fmt.Errorf("cannot read config file: %w", err)
+	}
+
+	// Fix the issue where viper.UnmarshalKey only uses read config, neglects environment variables
+	for _, key := range v.AllKeys() {
+		v.Set(key, v.Get(key))
+	}
+	return v, nil
+}
 
-		// Fix the issue where viper.UnmarshalKey only uses read config, neglects environment variables
-		for _, key := range v.AllKeys() {
-			v.Set(key, v.Get(key))
+// validateConfigFile checks that the schema accepts every key of the configuration file and of the files it imports.
+// The keys it doesn't accept are an error in strict mode, and a warning otherwise.
+func validateConfigFile(path string, schema *configutils.Schema, strict bool) error {
+	file := viper.New()
+	if err := configutils.ResolveAndMergeFile(file, path); err != nil {
+		return fmt.Errorf("cannot read config file: %w", err)
+	}
+
+	var keys []string
+	for _, key := range file.AllKeys() {
+		if legacyConfigKeys[key] {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: ignoring config key %s of %s, it is no longer used\n", key, path)
+			continue
 		}
-		return v, nil
-	})
+		keys = append(keys, key)
+	}
+	if err := schema.Validate(keys); err != nil {
+		if strict {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "WARNING: ignoring config keys of %s: %v\n", path, err)
+	}
+	return nil
+}
+
+// printEffectiveConfig prints the configuration of an agent as YAML, once resolved from the flags, the environment
+// and the configuration file, with the credentials redacted
+func printEffectiveConfig(cli *cobra.Command, module AgentModule) error {
+	v, err := loadConfig(cli, module)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(agentConfigSchema(module).Effective(v))
+	if err != nil {
+		return fmt.Errorf("failed to marshal effective config: %w", err)
+	}
+	_, err = cli.OutOrStdout().Write(data)
+	return err
 }
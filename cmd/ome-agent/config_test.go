@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	schema := agentConfigSchema(NewHFDownloadAgent())
+
+	// The keys of the agent and legacy keys are accepted
+	valid := write("valid.yaml", `
+model_name: meta-llama/Llama-3.2-1B
+local_path: /opt/ml/model
+skip_sha: false
+logging:
+  level: debug
+`)
+	assert.NoError(t, validateConfigFile(valid, schema, true))
+
+	// Keys of imported files are validated too, and fail only in strict mode
+	write("base.yaml", "max_workrs: 8\n")
+	typo := write("typo.yaml", "imports:\n  - base.yaml\nmodel_nme: meta-llama/Llama-3.2-1B\n")
+	assert.NoError(t, validateConfigFile(typo, schema, false))
+	err := validateConfigFile(typo, schema, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_workrs (did you mean max_workers?)")
+	assert.Contains(t, err.Error(), "model_nme (did you mean model_name?)")
+
+	// The keys of other agents are not the keys of the agent
+	other := write("other.yaml", "warmup:\n  enabled: true\n")
+	assert.NoError(t, validateConfigFile(other, schema, false))
+	assert.Error(t, validateConfigFile(other, schema, true))
+}
+
+func TestShippedConfigFiles(t *testing.T) {
+	// The image ships one file read by all the agents
+	schema := commonConfigSchema()
+	for _, module := range agentModules {
+		schema.Merge(module.ConfigSchema())
+	}
+	assert.NoError(t, validateConfigFile("../../config/ome-agent/ome-agent.yaml", schema, true))
+	assert.NoError(t, validateConfigFile("../../config/ome-agent/model-metadata.yaml", agentConfigSchema(NewModelMetadataAgent()), true))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sgl-project/ome/internal/ome-agent/enigma"
 	"github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/vault/kmscrypto"
 	"github.com/sgl-project/ome/pkg/vault/kmsmgm"
@@ -40,6 +41,30 @@ func (e *EnigmaAgent) ConfigureCommand(cmd *cobra.Command) {
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		runAgentCommand(cmd, e, e.Start)
 	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "rotate",
+		Short: "Re-encrypt the model weights with a new data encryption key",
+		Long: "Re-encrypts the encrypted model weights of the model store in place with a data encryption key " +
+			"generated with the current master encryption key version. An interrupted rotation is resumed.",
+		Run: func(cmd *cobra.Command, args []string) {
+			runAgentCommand(cmd, e, e.Rotate)
+		},
+	})
+}
+
+// ConfigSchema returns the keys the agent reads from its configuration
+func (e *EnigmaAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().
+		Add("", &enigma.Config{}).
+		Add("", &kmsmgm.KeyMetadata{}).
+		Add("", &kmsvault.Config{}).
+		Add("", &kmscrypto.Config{}).
+		Add("", &kmsmgm.Config{}).
+		Add("", &ocisecret.Config{}).
+		Add("", &ocivault.Config{}).
+		// Read individually for TensorRT-LLM models
+		AddKeys("tensorrtllm_version", "node_shape_alias", "num_of_gpu")
 }
 
 // FxModules returns the fx modules needed by this agent
@@ -63,6 +88,11 @@ func (e *EnigmaAgent) Start() error {
 	return e.agent.Start()
 }
 
+// Rotate rotates the data encryption key of the model weights
+func (e *EnigmaAgent) Rotate() error {
+	return e.agent.Rotate()
+}
+
 // NewEnigmaAgent creates a new enigma agent
 func NewEnigmaAgent() *EnigmaAgent {
 	return &EnigmaAgent{}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/internal/ome-agent/export"
+	"github.com/sgl-project/ome/pkg/configutils"
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// ExportAgent implements the AgentModule interface for the model image export agent
+type ExportAgent struct {
+	exporter *export.Exporter
+}
+
+// Name returns the name of the agent
+func (e *ExportAgent) Name() string {
+	return "export"
+}
+
+// ShortDescription returns a short description of the agent
+func (e *ExportAgent) ShortDescription() string {
+	return "Package a local model directory into an OCI image and push it"
+}
+
+// LongDescription returns a detailed description of the agent
+func (e *ExportAgent) LongDescription() string {
+	return "OME Agent Export Agent packages a local model directory into an OCI image, a modelcar holding the model files below /models, and pushes it to a registry. The large files get layers of their own, so that they are deduplicated across images. The model agent pulls the image from a modelcar:// storage URI. It prints a JSON result with the digest of the image."
+}
+
+// ConfigureCommand configures the agent command
+func (e *ExportAgent) ConfigureCommand(cmd *cobra.Command) {
+	cmd.Flags().String("local-path", "", "Path to the model directory to export")
+	cmd.Flags().String("image", "", "Image the model is pushed to, e.g. modelcar://ghcr.io/acme/llama-3.2-1b:v1")
+	cmd.Flags().Bool("plain-http", false, "Push to the registry over HTTP instead of HTTPS")
+	cmd.Flags().String("report-path", "", "Path the JSON result is written to instead of the standard output")
+
+	// Bind flags to viper with underscore keys to match mapstructure tags
+	_ = viper.BindPFlag("local_path", cmd.Flags().Lookup("local-path"))
+	_ = viper.BindPFlag("image", cmd.Flags().Lookup("image"))
+	_ = viper.BindPFlag("registry.plain_http", cmd.Flags().Lookup("plain-http"))
+	_ = viper.BindPFlag("report_path", cmd.Flags().Lookup("report-path"))
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		runAgentCommand(cmd, e, e.Start)
+	}
+}
+
+// ConfigSchema returns the keys the agent reads from its configuration
+func (e *ExportAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().Add("", &export.Config{})
+}
+
+// FxModules returns the fx modules needed by this agent
+func (e *ExportAgent) FxModules() []fx.Option {
+	return []fx.Option{
+		logging.Module,
+		export.Module,
+		fx.Populate(&e.exporter),
+	}
+}
+
+// Start exports the model directory and writes the result
+func (e *ExportAgent) Start() error {
+	result, err := e.exporter.Export(context.Background())
+	if err != nil {
+		return fmt.Errorf("model export failed: %w", err)
+	}
+	return e.exporter.WriteResult(result)
+}
+
+// NewExportAgent creates a new model image export agent
+func NewExportAgent() *ExportAgent {
+	return &ExportAgent{}
+}
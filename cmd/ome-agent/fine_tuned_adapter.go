@@ -6,6 +6,7 @@ import (
 
 	finetunedadapter "github.com/sgl-project/ome/internal/ome-agent/fine-tuned-adapter"
 	"github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/ociobjectstore"
 )
@@ -38,6 +39,13 @@ func (m *FineTunedAdapterAgent) ConfigureCommand(cmd *cobra.Command) {
 	}
 }
 
+// ConfigSchema returns the keys the agent reads from its configuration
+func (m *FineTunedAdapterAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().
+		Add("", &finetunedadapter.Config{}).
+		Add("", &ociobjectstore.Config{})
+}
+
 // FxModules returns the fx modules needed by this agent
 func (m *FineTunedAdapterAgent) FxModules() []fx.Option {
 	return []fx.Option{
@@ -2,13 +2,19 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
 
 	"github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/agentprogress"
+	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/hfutil/hub"
 	"github.com/sgl-project/ome/pkg/logging"
 )
@@ -16,7 +22,8 @@ import (
 // hfDownloadAgentParams represents the parameters for dependency injection
 type hfDownloadAgentParams struct {
 	fx.In
-	Logger logging.Interface `name:"another_log"`
+	Logger   logging.Interface `name:"another_log"`
+	Progress *agentprogress.Reporter
 }
 
 // HFDownloadAgent implements the AgentModule interface for HuggingFace download agent
@@ -24,6 +31,7 @@ type HFDownloadAgent struct {
 	hubClient *hub.HubClient
 	viper     *viper.Viper
 	logger    logging.Interface
+	progress  *agentprogress.Reporter
 }
 
 // Name returns the name of the agent
@@ -38,17 +46,31 @@ func (h *HFDownloadAgent) ShortDescription() string {
 
 // LongDescription returns a detailed description of the agent
 func (h *HFDownloadAgent) LongDescription() string {
-	return "OME Agent HuggingFace Download Agent downloads models from HuggingFace Hub using the comprehensive hub client with enterprise features like progress tracking, resume capability, and concurrent downloads."
+	return "OME Agent HuggingFace Download Agent downloads models from HuggingFace Hub using the comprehensive hub client with enterprise features like progress tracking, resume capability, and concurrent downloads. With a manifest, it downloads several repositories concurrently and reports the outcome of each of them."
 }
 
 // ConfigureCommand configures the agent command
 func (h *HFDownloadAgent) ConfigureCommand(cmd *cobra.Command) {
+	cmd.Flags().String("manifest", "", "Path to a YAML or JSON manifest of the repositories to download in batch mode")
+	cmd.Flags().Int("max-concurrent-repos", hub.DefaultMaxConcurrentRepos, "Number of repositories downloaded at a time in batch mode")
+	cmd.Flags().String("report-path", "", "Path the JSON report of batch mode is written to instead of the standard output")
+
+	// Bind flags to viper with underscore keys to match the configuration file
+	_ = viper.BindPFlag("manifest", cmd.Flags().Lookup("manifest"))
+	_ = viper.BindPFlag("max_concurrent_repos", cmd.Flags().Lookup("max-concurrent-repos"))
+	_ = viper.BindPFlag("report_path", cmd.Flags().Lookup("report-path"))
+
 	// Set the default action for this command
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		runAgentCommand(cmd, h, h.Start)
 	}
 }
 
+// ConfigSchema returns the keys the agent reads from its configuration
+func (h *HFDownloadAgent) ConfigSchema() *configutils.Schema {
+	return hfRepoConfigSchema().AddKeys("manifest", "max_concurrent_repos", "report_path")
+}
+
 // FxModules returns the fx modules needed by this agent
 func (h *HFDownloadAgent) FxModules() []fx.Option {
 	return []fx.Option{
@@ -61,18 +83,24 @@ func (h *HFDownloadAgent) FxModules() []fx.Option {
 			h.hubClient = hubClient
 			h.viper = v
 			h.logger = params.Logger
+			h.progress = params.Progress
 		}),
 	}
 }
 
 // Start starts the agent
 func (h *HFDownloadAgent) Start() error {
+	if manifest := h.viper.GetString("manifest"); manifest != "" {
+		return h.startBatch(manifest)
+	}
 
 	// Get configuration values directly from viper (no validation here - let hub handle it)
 	modelName := h.viper.GetString("model_name")
 	localPath := h.viper.GetString("local_path")
 	revision := h.viper.GetString("revision")
 	repoType := h.viper.GetString("repo_type")
+	allowPatterns := h.viper.GetStringSlice("allow_patterns")
+	ignorePatterns := h.viper.GetStringSlice("ignore_patterns")
 
 	ctx := context.Background()
 
@@ -83,8 +111,13 @@ func (h *HFDownloadAgent) Start() error {
 		h.logger.Infof("   Revision: %s (defaults to 'main' if empty)", revision)
 		h.logger.Infof("   Target: %s", localPath)
 		h.logger.Infof("   Repository Type: %s (defaults to 'model' if empty)", repoType)
+		if len(allowPatterns) > 0 || len(ignorePatterns) > 0 {
+			h.logger.Infof("   Allow Patterns: %v, Ignore Patterns: %v", allowPatterns, ignorePatterns)
+		}
 	}
 
+	h.progress.SetPhase(ctx, agentprogress.PhaseDownloading, modelName)
+
 	// Build download options - let hub module handle defaults and validation
 	var opts []hub.DownloadOption
 	if revision != "" {
@@ -93,6 +126,9 @@ func (h *HFDownloadAgent) Start() error {
 	if repoType != "" {
 		opts = append(opts, hub.WithRepoType(repoType))
 	}
+	if len(allowPatterns) > 0 || len(ignorePatterns) > 0 {
+		opts = append(opts, hub.WithPatterns(allowPatterns, ignorePatterns))
+	}
 
 	// Perform snapshot download using the hub client
 	downloadPath, err := h.hubClient.SnapshotDownload(
@@ -117,6 +153,76 @@ func (h *HFDownloadAgent) Start() error {
 	return nil
 }
 
+// startBatch downloads the repositories of the manifest concurrently, writes the report of every repository and fails
+// if any of them failed
+func (h *HFDownloadAgent) startBatch(manifestPath string) error {
+	manifest, err := hub.LoadBatchManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if h.logger != nil {
+		h.logger.Infof("🤗 Starting HuggingFace batch download of %d repositories from %s", len(manifest.Repos), manifestPath)
+	}
+
+	ctx := context.Background()
+	h.progress.SetPhase(ctx, agentprogress.PhaseDownloading, fmt.Sprintf("%d repositories from %s", len(manifest.Repos), manifestPath))
+	report, err := h.hubClient.BatchSnapshotDownload(ctx, manifest.Repos, h.viper.GetInt("max_concurrent_repos"))
+	if err != nil {
+		return fmt.Errorf("batch download failed: %w", err)
+	}
+
+	files, bytes := report.Totals()
+	downloadedFiles, downloadedBytes := report.DownloadedTotals()
+	failures := report.Failures()
+	if h.logger != nil {
+		h.logger.Infof("Downloaded %d of %d repositories, %d files and %d bytes, of which %d files and %d bytes transferred, in %s",
+			len(report.Repos)-len(failures), len(report.Repos), files, bytes, downloadedFiles, downloadedBytes,
+			time.Duration(report.Duration*float64(time.Second)).Round(time.Second))
+		for _, repo := range report.Repos {
+			h.logger.Infof("   %-9s %s -> %s", repo.Status, repo.ModelName, repo.LocalPath)
+		}
+	}
+
+	if err := writeBatchReport(report, h.viper.GetString("report_path")); err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		names := make([]string, len(failures))
+		for i, repo := range failures {
+			names[i] = repo.ModelName
+		}
+		return fmt.Errorf("%d of %d repositories failed to download: %s",
+			len(failures), len(report.Repos), strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// writeBatchReport writes the report as JSON to the report path, or to the standard output if it is not set
+func writeBatchReport(report *hub.BatchReport, reportPath string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch report: %w", err)
+	}
+	data = append(data, '\n')
+	if reportPath == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(reportPath, data, 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write batch report: %w", err)
+	}
+	return nil
+}
+
+// hfRepoConfigSchema returns the schema of the configuration of the hub client and of the repository to download or
+// verify
+func hfRepoConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().
+		Add("", &hub.HubConfig{}).
+		AddKeys("model_name", "local_path", "revision", "repo_type", "allow_patterns", "ignore_patterns")
+}
+
 // NewHFDownloadAgent creates a new HuggingFace download agent
 func NewHFDownloadAgent() *HFDownloadAgent {
 	return &HFDownloadAgent{}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/configutils"
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// HFVerifyAgent implements the AgentModule interface for the HuggingFace snapshot verification agent
+type HFVerifyAgent struct {
+	hubClient *hub.HubClient
+	viper     *viper.Viper
+	logger    logging.Interface
+}
+
+// Name returns the name of the agent
+func (h *HFVerifyAgent) Name() string {
+	return "hf-verify"
+}
+
+// ShortDescription returns a short description of the agent
+func (h *HFVerifyAgent) ShortDescription() string {
+	return "Verify a HuggingFace model snapshot on disk"
+}
+
+// LongDescription returns a detailed description of the agent
+func (h *HFVerifyAgent) LongDescription() string {
+	return "OME Agent HuggingFace Verify Agent re-computes the digests of the files of a downloaded model and compares them with the ones HuggingFace Hub lists for its revision, e.g. after a disk corruption is suspected. It prints a JSON report and fails when a file is missing or corrupted."
+}
+
+// ConfigureCommand configures the agent command
+func (h *HFVerifyAgent) ConfigureCommand(cmd *cobra.Command) {
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		runAgentCommand(cmd, h, h.Start)
+	}
+}
+
+// ConfigSchema returns the keys the agent reads from its configuration
+func (h *HFVerifyAgent) ConfigSchema() *configutils.Schema {
+	return hfRepoConfigSchema()
+}
+
+// FxModules returns the fx modules needed by this agent
+func (h *HFVerifyAgent) FxModules() []fx.Option {
+	return []fx.Option{
+		afero.Module,
+		logging.Module,
+		logging.ModuleNamed("another_log"),
+		logging.ModuleNamed("hub_logger"),
+		hub.Module,
+		fx.Invoke(func(params hfDownloadAgentParams, hubClient *hub.HubClient, v *viper.Viper) {
+			h.hubClient = hubClient
+			h.viper = v
+			h.logger = params.Logger
+		}),
+	}
+}
+
+// Start verifies the snapshot and prints its report
+func (h *HFVerifyAgent) Start() error {
+	// The snapshot is selected like the one of hf-download
+	modelName := h.viper.GetString("model_name")
+	localPath := h.viper.GetString("local_path")
+	revision := h.viper.GetString("revision")
+	repoType := h.viper.GetString("repo_type")
+	allowPatterns := h.viper.GetStringSlice("allow_patterns")
+	ignorePatterns := h.viper.GetStringSlice("ignore_patterns")
+
+	var opts []hub.DownloadOption
+	if revision != "" {
+		opts = append(opts, hub.WithRevision(revision))
+	}
+	if repoType != "" {
+		opts = append(opts, hub.WithRepoType(repoType))
+	}
+	if len(allowPatterns) > 0 || len(ignorePatterns) > 0 {
+		opts = append(opts, hub.WithPatterns(allowPatterns, ignorePatterns))
+	}
+
+	if h.logger != nil {
+		h.logger.Infof("Verifying snapshot of %s in %s", modelName, localPath)
+	}
+	report, err := h.hubClient.VerifySnapshot(context.Background(), modelName, localPath, opts...)
+	if err != nil {
+		return fmt.Errorf("snapshot verification failed: %w", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to print verification report: %w", err)
+	}
+
+	if failures := report.Failures(); len(failures) > 0 {
+		return fmt.Errorf("%d of %d files of %s at commit %s are missing or corrupted", len(failures), len(report.Files), modelName, report.Commit)
+	}
+	if h.logger != nil {
+		h.logger.Infof("All %d files of %s at commit %s are intact", len(report.Files), modelName, report.Commit)
+	}
+	return nil
+}
+
+// NewHFVerifyAgent creates a new HuggingFace snapshot verification agent
+func NewHFVerifyAgent() *HFVerifyAgent {
+	return &HFVerifyAgent{}
+}
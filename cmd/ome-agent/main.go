@@ -23,12 +23,23 @@ func main() {
 	}
 }
 
+// agentModules are the agents run by the subcommands
+var agentModules = []AgentModule{
+	NewEnigmaAgent(),
+	NewHFDownloadAgent(),
+	NewHFVerifyAgent(),
+	NewReplicaAgent(),
+	NewServingAgent(),
+	NewFineTunedAdapterAgent(),
+	NewModelMetadataAgent(),
+	NewBenchmarkAgent(),
+	NewVerifyAgent(),
+	NewExportAgent(),
+}
+
 func init() {
 	// Register all agent commands
-	rootCmd.AddCommand(CreateAgentCommand(NewEnigmaAgent()))
-	rootCmd.AddCommand(CreateAgentCommand(NewHFDownloadAgent()))
-	rootCmd.AddCommand(CreateAgentCommand(NewReplicaAgent()))
-	rootCmd.AddCommand(CreateAgentCommand(NewServingAgent()))
-	rootCmd.AddCommand(CreateAgentCommand(NewFineTunedAdapterAgent()))
-	rootCmd.AddCommand(CreateAgentCommand(NewModelMetadataAgent()))
+	for _, module := range agentModules {
+		rootCmd.AddCommand(CreateAgentCommand(module))
+	}
 }
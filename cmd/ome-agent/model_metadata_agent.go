@@ -7,6 +7,7 @@ import (
 
 	modelmetadata "github.com/sgl-project/ome/internal/ome-agent/model-metadata"
 	aferoModule "github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/logging"
 )
 
@@ -38,6 +39,8 @@ func (m *ModelMetadataAgent) ConfigureCommand(cmd *cobra.Command) {
 	cmd.Flags().String("basemodel-name", "", "Name of the BaseModel CR")
 	cmd.Flags().String("basemodel-namespace", "", "Namespace of the BaseModel CR")
 	cmd.Flags().Bool("cluster-scoped", false, "Whether this is a ClusterBaseModel")
+	cmd.Flags().Bool("verify-integrity", false, "Verify the model directory and record its digest before extracting metadata")
+	cmd.Flags().String("public-key", "", "Path to the public key the manifest of the model directory must be signed with")
 
 	_ = cmd.MarkFlagRequired("model-path")
 	_ = cmd.MarkFlagRequired("basemodel-name")
@@ -47,12 +50,19 @@ func (m *ModelMetadataAgent) ConfigureCommand(cmd *cobra.Command) {
 	_ = viper.BindPFlag("basemodel_name", cmd.Flags().Lookup("basemodel-name"))
 	_ = viper.BindPFlag("basemodel_namespace", cmd.Flags().Lookup("basemodel-namespace"))
 	_ = viper.BindPFlag("cluster_scoped", cmd.Flags().Lookup("cluster-scoped"))
+	_ = viper.BindPFlag("verify_integrity", cmd.Flags().Lookup("verify-integrity"))
+	_ = viper.BindPFlag("public_key_path", cmd.Flags().Lookup("public-key"))
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		runAgentCommand(cmd, m, m.Start)
 	}
 }
 
+// ConfigSchema returns the keys the agent reads from its configuration
+func (m *ModelMetadataAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().Add("", &modelmetadata.Config{})
+}
+
 // FxModules returns the fx modules needed by this agent
 func (m *ModelMetadataAgent) FxModules() []fx.Option {
 	return []fx.Option{
@@ -30,6 +30,10 @@ func TestModelMetadataAgent_ConfigureCommand(t *testing.T) {
 	clusterScopedFlag := cmd.Flags().Lookup("cluster-scoped")
 	assert.NotNil(t, clusterScopedFlag)
 	assert.Equal(t, "cluster-scoped", clusterScopedFlag.Name)
+
+	verifyIntegrityFlag := cmd.Flags().Lookup("verify-integrity")
+	assert.NotNil(t, verifyIntegrityFlag)
+	assert.Equal(t, "false", verifyIntegrityFlag.DefValue)
 }
 
 func TestModelMetadataAgent_Name(t *testing.T) {
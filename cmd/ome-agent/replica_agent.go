@@ -11,6 +11,7 @@ import (
 
 	"github.com/sgl-project/ome/internal/ome-agent/replica"
 	"github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/ociobjectstore"
 )
@@ -43,6 +44,16 @@ func (r *ReplicaAgent) ConfigureCommand(cmd *cobra.Command) {
 	}
 }
 
+// ConfigSchema returns the keys the agent reads from its configuration
+func (r *ReplicaAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().
+		Add("", &replica.Config{}).
+		Add("", &xet.Config{}).
+		Add("source.oci", &ociobjectstore.Config{}).
+		Add("target.oci", &ociobjectstore.Config{}).
+		AddKeys("source.oci.enabled", "source.pvc.enabled", "target.oci.enabled", "target.pvc.enabled")
+}
+
 // FxModules returns the fx modules needed by this agent
 func (r *ReplicaAgent) FxModules() []fx.Option {
 	return []fx.Option{
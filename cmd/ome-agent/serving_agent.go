@@ -6,6 +6,7 @@ import (
 
 	servingAgent "github.com/sgl-project/ome/internal/ome-agent/serving-agent"
 	"github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/ociobjectstore"
 )
@@ -38,6 +39,13 @@ func (s *ServingAgent) ConfigureCommand(cmd *cobra.Command) {
 	}
 }
 
+// ConfigSchema returns the keys the agent reads from its configuration
+func (s *ServingAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().
+		Add("", &servingAgent.Config{}).
+		Add("", &ociobjectstore.Config{})
+}
+
 // FxModules returns the fx modules needed by this agent
 func (s *ServingAgent) FxModules() []fx.Option {
 	return []fx.Option{
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/internal/ome-agent/verify"
+	"github.com/sgl-project/ome/pkg/configutils"
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// VerifyAgent implements the AgentModule interface for the model directory verification agent
+type VerifyAgent struct {
+	verifier *verify.Verifier
+}
+
+// Name returns the name of the agent
+func (v *VerifyAgent) Name() string {
+	return "verify"
+}
+
+// ShortDescription returns a short description of the agent
+func (v *VerifyAgent) ShortDescription() string {
+	return "Verify the integrity of a local model directory"
+}
+
+// LongDescription returns a detailed description of the agent
+func (v *VerifyAgent) LongDescription() string {
+	return "OME Agent Verify Agent hashes the files of a local model directory, compares them with its signed manifest, verifies the signature of the manifest and validates the safetensors files. It prints a JSON report and fails when a file is missing, modified or corrupted, or the signature is invalid."
+}
+
+// ConfigureCommand configures the agent command
+func (v *VerifyAgent) ConfigureCommand(cmd *cobra.Command) {
+	cmd.Flags().String("model-path", "", "Path to the model directory")
+	cmd.Flags().String("public-key", "", "Path to the PEM encoded public key the manifest is signed with")
+	cmd.Flags().Bool("require-signature", false, "Fail unless the manifest is signed with the public key")
+	cmd.Flags().Bool("skip-safetensors", false, "Skip the validation of the safetensors files")
+	cmd.Flags().String("report-path", "", "Path the JSON report is written to instead of the standard output")
+
+	// Bind flags to viper with underscore keys to match mapstructure tags
+	_ = viper.BindPFlag("model_path", cmd.Flags().Lookup("model-path"))
+	_ = viper.BindPFlag("public_key_path", cmd.Flags().Lookup("public-key"))
+	_ = viper.BindPFlag("require_signature", cmd.Flags().Lookup("require-signature"))
+	_ = viper.BindPFlag("skip_safetensors", cmd.Flags().Lookup("skip-safetensors"))
+	_ = viper.BindPFlag("report_path", cmd.Flags().Lookup("report-path"))
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		runAgentCommand(cmd, v, v.Start)
+	}
+}
+
+// ConfigSchema returns the keys the agent reads from its configuration
+func (v *VerifyAgent) ConfigSchema() *configutils.Schema {
+	return configutils.NewSchema().Add("", &verify.Config{})
+}
+
+// FxModules returns the fx modules needed by this agent
+func (v *VerifyAgent) FxModules() []fx.Option {
+	return []fx.Option{
+		logging.Module,
+		verify.Module,
+		fx.Populate(&v.verifier),
+	}
+}
+
+// Start verifies the model directory and writes its report
+func (v *VerifyAgent) Start() error {
+	report, err := v.verifier.Verify()
+	if err != nil {
+		return fmt.Errorf("model verification failed: %w", err)
+	}
+	if err := v.verifier.WriteReport(report); err != nil {
+		return err
+	}
+	if !report.OK() {
+		return fmt.Errorf("%d of %d files of %s failed the verification, signature %s",
+			len(report.Failures()), len(report.Files), report.ModelPath, report.Signature.Status)
+	}
+	return nil
+}
+
+// NewVerifyAgent creates a new model directory verification agent
+func NewVerifyAgent() *VerifyAgent {
+	return &VerifyAgent{}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/sgl-project/ome/pkg/storage"
+)
+
+const (
+	configEnv      = "OME_STORAGE_CONFIG"
+	profileEnv     = "OME_STORAGE_PROFILE"
+	defaultProfile = "default"
+)
+
+// configFile is the configuration file of ome-storage, of profiles of settings by storage provider, e.g.
+//
+//	profiles:
+//	  default:
+//	    oci:
+//	      authType: OCIUserPrincipal
+//	      region: us-chicago-1
+//	      extra:
+//	        user_principal:
+//	          config_path: ~/.oci/config
+//	    s3:
+//	      authType: access_key
+//	      region: us-east-1
+//	      endpoint: https://minio.example.com
+//	      extra:
+//	        access_key:
+//	          access_key_id: ...
+//	          secret_access_key: ...
+type configFile struct {
+	Profiles map[string]map[storage.Provider]providerSettings `json:"profiles"`
+}
+
+// providerSettings configures a storage provider, the auth type and extra settings being those the provider passes
+// to pkg/auth
+type providerSettings struct {
+	AuthType string                 `json:"authType,omitempty"`
+	Region   string                 `json:"region,omitempty"`
+	Endpoint string                 `json:"endpoint,omitempty"`
+	Extra    map[string]interface{} `json:"extra,omitempty"`
+}
+
+// loadProfile returns the settings of the profile. Without a configuration file or a default profile in it, the
+// providers use their default authentication, e.g. the instance principal on OCI.
+func loadProfile(path, profile string) (map[storage.Provider]providerSettings, error) {
+	explicitPath := path != "" || os.Getenv(configEnv) != ""
+	if path == "" {
+		path = os.Getenv(configEnv)
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the home directory: %w", err)
+		}
+		path = filepath.Join(home, ".ome", "storage.yaml")
+	}
+	if profile == "" {
+		profile = os.Getenv(profileEnv)
+	}
+	explicitProfile := profile != ""
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) && !explicitPath && !explicitProfile {
+		return map[storage.Provider]providerSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	var config configFile
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the configuration file %s: %w", path, err)
+	}
+	settings, ok := config.Profiles[profile]
+	if !ok && explicitProfile {
+		return nil, fmt.Errorf("profile %s not found in the configuration file %s", profile, path)
+	}
+	if settings == nil {
+		settings = map[storage.Provider]providerSettings{}
+	}
+	return settings, nil
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sgl-project/ome/pkg/storage"
+)
+
+func newCpCommand(o *omeStorage) *cobra.Command {
+	var recursive bool
+	cmd := &cobra.Command{
+		Use:   "cp SOURCE DESTINATION",
+		Short: "Copy a file or object, or with -r the files or objects under a directory or prefix",
+		Long: "Copy a file or object to a local path or object storage URI. A destination ending with a slash, or an " +
+			"existing directory, receives the file or object under its name. With -r, the files or objects under " +
+			"the source directory or prefix are copied under the destination directory or prefix.",
+		Example: "  ome-storage cp ./config.json oci://n/mytenancy/b/models/o/llama-3/\n" +
+			"  ome-storage cp -r s3://models/llama-3 ./llama-3",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.cp(cmd.Context(), args[0], args[1], recursive)
+		},
+	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "copy the files or objects under the source directory or prefix")
+	return cmd
+}
+
+func newSyncCommand(o *omeStorage) *cobra.Command {
+	var deleteExtra, dryRun bool
+	cmd := &cobra.Command{
+		Use:   "sync SOURCE DESTINATION",
+		Short: "Copy the files or objects under a directory or prefix that are missing or differ at the destination",
+		Long: "Copy the files or objects under the source directory or prefix that are missing under the destination, " +
+			"or whose size or MD5 differs. The MD5 of an object is only known for S3 objects uploaded in one part, " +
+			"from their ETag: the other objects are compared by size only. With --delete, the files or objects " +
+			"of the destination that are not in the source are removed.",
+		Example: "  ome-storage sync ./llama-3 oci://n/mytenancy/b/models/o/llama-3",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.sync(cmd.Context(), args[0], args[1], deleteExtra, dryRun)
+		},
+	}
+	cmd.Flags().BoolVar(&deleteExtra, "delete", false, "remove the files or objects of the destination that are not in the source")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print what would be copied and removed without doing it")
+	return cmd
+}
+
+func (o *omeStorage) cp(ctx context.Context, sourceArg, destinationArg string, recursive bool) error {
+	source, destination, err := parseTransfer(sourceArg, destinationArg)
+	if err != nil {
+		return err
+	}
+	if !recursive {
+		if !source.isLocal() && (source.key == "" || strings.HasSuffix(source.key, "/")) {
+			return fmt.Errorf("%s is a prefix, copy the objects under it with -r", source)
+		}
+		return o.transfer(ctx, source, target(source, destination))
+	}
+
+	entries, err := o.entries(ctx, source)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no files or objects under %s", source)
+	}
+	for _, e := range entries {
+		if err := o.transfer(ctx, source.child(e.rel), destination.child(e.rel)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *omeStorage) sync(ctx context.Context, sourceArg, destinationArg string, deleteExtra, dryRun bool) error {
+	source, destination, err := parseTransfer(sourceArg, destinationArg)
+	if err != nil {
+		return err
+	}
+	sourceEntries, err := o.entries(ctx, source)
+	if err != nil {
+		return err
+	}
+	destinationEntries, err := o.entries(ctx, destination)
+	if err != nil {
+		return err
+	}
+	destinationByPath := make(map[string]entry, len(destinationEntries))
+	for _, e := range destinationEntries {
+		destinationByPath[e.rel] = e
+	}
+
+	var copied, upToDate, deleted int
+	inSource := make(map[string]bool, len(sourceEntries))
+	for _, e := range sourceEntries {
+		inSource[e.rel] = true
+		if copied, ok := destinationByPath[e.rel]; ok {
+			same, err := sameContent(source.child(e.rel), destination.child(e.rel), e, copied)
+			if err != nil {
+				return err
+			}
+			if same {
+				upToDate++
+				continue
+			}
+		}
+		copied++
+		if dryRun {
+			_, _ = fmt.Fprintf(o.out, "would copy %s -> %s\n", source.child(e.rel), destination.child(e.rel))
+			continue
+		}
+		if err := o.transfer(ctx, source.child(e.rel), destination.child(e.rel)); err != nil {
+			return err
+		}
+	}
+	if deleteExtra {
+		for _, e := range destinationEntries {
+			if inSource[e.rel] {
+				continue
+			}
+			deleted++
+			if dryRun {
+				_, _ = fmt.Fprintf(o.out, "would delete %s\n", destination.child(e.rel))
+				continue
+			}
+			if err := o.remove(ctx, destination.child(e.rel)); err != nil {
+				return err
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(o.out, "%d copied, %d up to date, %d deleted\n", copied, upToDate, deleted)
+	return nil
+}
+
+// parseTransfer parses the source and destination of a transfer, at least one of which is an object storage URI
+func parseTransfer(sourceArg, destinationArg string) (location, location, error) {
+	source, err := parseLocation(sourceArg)
+	if err != nil {
+		return location{}, location{}, err
+	}
+	destination, err := parseLocation(destinationArg)
+	if err != nil {
+		return location{}, location{}, err
+	}
+	if source.isLocal() && destination.isLocal() {
+		return location{}, location{}, fmt.Errorf("the source or the destination must be an object storage URI")
+	}
+	return source, destination, nil
+}
+
+// target returns the location a single file or object is copied to: the destination, or the source name under it
+// when the destination is a directory or a prefix
+func target(source, destination location) location {
+	name := source.baseName()
+	if source.isLocal() {
+		name = filepath.Base(source.path)
+	}
+	if destination.isLocal() {
+		if strings.HasSuffix(destination.path, string(os.PathSeparator)) {
+			destination.path = filepath.Join(destination.path, name)
+		} else if info, err := os.Stat(destination.path); err == nil && info.IsDir() {
+			destination.path = filepath.Join(destination.path, name)
+		}
+		return destination
+	}
+	if destination.key == "" || strings.HasSuffix(destination.key, "/") {
+		return destination.withKey(destination.key + name)
+	}
+	return destination
+}
+
+// entry is a file under a local directory or an object under a prefix, by its slash separated path relative to them
+type entry struct {
+	rel  string
+	size int64
+	// md5 is the hex encoded MD5 of an object, empty when its storage doesn't expose it
+	md5 string
+}
+
+// sameContent reports whether a file or object and its copy have the same content. Their sizes are compared, and
+// their MD5s when the storage of an object exposes it. The MD5 of a local file is only computed to be compared with
+// the MD5 of an object.
+func sameContent(source, destination location, sourceEntry, destinationEntry entry) (bool, error) {
+	if sourceEntry.size != destinationEntry.size {
+		return false, nil
+	}
+	if sourceEntry.md5 == "" && destinationEntry.md5 == "" {
+		return true, nil
+	}
+	sourceMD5, destinationMD5 := sourceEntry.md5, destinationEntry.md5
+	var err error
+	if source.isLocal() {
+		sourceMD5, err = fileMD5(source.path)
+	} else if destination.isLocal() {
+		destinationMD5, err = fileMD5(destination.path)
+	}
+	if err != nil {
+		return false, err
+	}
+	if sourceMD5 == "" || destinationMD5 == "" {
+		return true, nil
+	}
+	return sourceMD5 == destinationMD5, nil
+}
+
+// fileMD5 returns the hex encoded MD5 of the content of a file
+func fileMD5(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to compute the MD5 of %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// objectMD5 returns the hex encoded MD5 of an object, from its ETag when it is one. Only S3 uses the MD5 of the
+// content as the ETag of the objects uploaded in one part, the ETag of a multipart upload ends with the number of its
+// parts, and the other storages don't derive their ETags from the content.
+func objectMD5(provider storage.Provider, etag string) string {
+	etag = strings.ToLower(strings.Trim(etag, `"`))
+	if provider != storage.ProviderS3 || len(etag) != md5.Size*2 {
+		return ""
+	}
+	if _, err := hex.DecodeString(etag); err != nil {
+		return ""
+	}
+	return etag
+}
+
+// entries returns the files under the directory, or the objects under the prefix of the location, by path. A missing
+// directory has no entries.
+func (o *omeStorage) entries(ctx context.Context, l location) ([]entry, error) {
+	var entries []entry
+	if l.isLocal() {
+		err := filepath.WalkDir(l.path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(l.path, path)
+			if err != nil {
+				return err
+			}
+			// The location is a file rather than a directory
+			if rel == "." {
+				rel = filepath.Base(path)
+			}
+			entries = append(entries, entry{rel: filepath.ToSlash(rel), size: info.Size()})
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to list the files under %s: %w", l, err)
+		}
+		return entries, nil
+	}
+
+	s, err := o.storageOf(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+	prefix := l.dirKey()
+	objects, err := s.List(ctx, l.object(prefix), l.listOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the objects under %s: %w", l.withKey(prefix), err)
+	}
+	for _, object := range objects {
+		// Skip the objects marking directories
+		if object.IsDir || strings.HasSuffix(object.Name, "/") || !strings.HasPrefix(object.Name, prefix) {
+			continue
+		}
+		entries = append(entries, entry{
+			rel:  strings.TrimPrefix(object.Name, prefix),
+			size: object.Size,
+			md5:  objectMD5(s.Provider(), object.ETag),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].rel < entries[j].rel })
+	return entries, nil
+}
+
+// child returns the location of the file or object at the relative path under the directory or prefix of the location
+func (l location) child(rel string) location {
+	if l.isLocal() {
+		l.path = filepath.Join(l.path, filepath.FromSlash(rel))
+		return l
+	}
+	return l.withKey(l.dirKey() + rel)
+}
+
+// transfer copies a single file or object, uploading, downloading or copying it between object storages
+func (o *omeStorage) transfer(ctx context.Context, source, destination location) error {
+	start := time.Now()
+	progress := o.newProgress(source.String())
+	size, err := o.transferObject(ctx, source, destination, progress)
+	// The progress line is cleared before anything else is printed
+	if progress != nil {
+		progress.Done()
+	}
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(o.out, "%s -> %s (%s in %s)\n", source, destination, formatBytes(size), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// transferObject copies a single file or object and returns its size
+func (o *omeStorage) transferObject(ctx context.Context, source, destination location, progress *progress) (int64, error) {
+	switch {
+	case source.isLocal():
+		info, err := os.Stat(source.path)
+		if err != nil {
+			return 0, err
+		}
+		s, err := o.storageOf(ctx, destination)
+		if err != nil {
+			return 0, err
+		}
+		var opts []storage.UploadOption
+		if progress != nil {
+			opts = append(opts, storage.WithUploadProgress(progress))
+		}
+		if err := s.Upload(ctx, source.path, destination.object(destination.key), opts...); err != nil {
+			return 0, fmt.Errorf("failed to upload %s to %s: %w", source, destination, err)
+		}
+		return info.Size(), nil
+	case destination.isLocal():
+		s, err := o.storageOf(ctx, source)
+		if err != nil {
+			return 0, err
+		}
+		if err := os.MkdirAll(filepath.Dir(destination.path), 0o755); err != nil {
+			return 0, fmt.Errorf("failed to create the directory of %s: %w", destination, err)
+		}
+		var opts []storage.DownloadOption
+		if progress != nil {
+			opts = append(opts, storage.WithDownloadProgress(progress))
+		}
+		if err := s.Download(ctx, source.object(source.key), destination.path, opts...); err != nil {
+			return 0, fmt.Errorf("failed to download %s to %s: %w", source, destination, err)
+		}
+		info, err := os.Stat(destination.path)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	default:
+		size, err := o.copyObject(ctx, source, destination, progress)
+		if err != nil {
+			return 0, fmt.Errorf("failed to copy %s to %s: %w", source, destination, err)
+		}
+		return size, nil
+	}
+}
+
+// copyObject copies an object to another object storage location, within the storage when they share it, else by
+// streaming it from one to the other. It returns the size of the object.
+func (o *omeStorage) copyObject(ctx context.Context, source, destination location, progress *progress) (int64, error) {
+	sourceStorage, err := o.storageOf(ctx, source)
+	if err != nil {
+		return 0, err
+	}
+	metadata, err := sourceStorage.Stat(ctx, source.object(source.key))
+	if err != nil {
+		return 0, err
+	}
+	if source.storageKey() == destination.storageKey() {
+		return metadata.Size, sourceStorage.Copy(ctx, source.object(source.key), destination.object(destination.key))
+	}
+
+	destinationStorage, err := o.storageOf(ctx, destination)
+	if err != nil {
+		return 0, err
+	}
+	reader, err := sourceStorage.Get(ctx, source.object(source.key))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = reader.Close() }()
+	var r io.Reader = reader
+	if progress != nil {
+		r = &progressReader{Reader: reader, progress: progress, size: metadata.Size}
+	}
+	return metadata.Size, destinationStorage.Put(ctx, destination.object(destination.key), r, metadata.Size)
+}
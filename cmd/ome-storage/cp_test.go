@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/storage"
+)
+
+func TestCp(t *testing.T) {
+	models := newMemStorage(storage.ProviderS3, map[string]string{
+		"llama-3/config.json":              "{}",
+		"llama-3/original/consolidated.pt": "weights",
+	})
+	backup := newMemStorage(storage.ProviderS3, nil)
+	o, out, _ := fakeOmeStorage(t, map[string]*memStorage{"models": models, "backup": backup})
+	dir := t.TempDir()
+
+	// Download into an existing directory
+	require.NoError(t, run(o, "cp", "s3://models/llama-3/config.json", dir))
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+	assert.Regexp(t, `^s3://models/llama-3/config.json -> .*/config.json \(2 B in .*\)\n$`, out.String())
+
+	// Upload under a prefix
+	require.NoError(t, run(o, "cp", filepath.Join(dir, "config.json"), "s3://models/mistral/"))
+	assert.Equal(t, []byte("{}"), models.objects["mistral/config.json"])
+
+	// Copy within the bucket, then to another bucket
+	require.NoError(t, run(o, "cp", "s3://models/mistral/config.json", "s3://models/mistral/config.json.bak"))
+	assert.Equal(t, 1, models.copies)
+	require.NoError(t, run(o, "cp", "s3://models/mistral/config.json", "s3://backup/mistral/"))
+	assert.Equal(t, []byte("{}"), backup.objects["mistral/config.json"])
+	assert.Equal(t, 1, models.copies)
+
+	// Recursive download
+	require.NoError(t, run(o, "cp", "-r", "s3://models/llama-3", filepath.Join(dir, "llama-3")))
+	data, err = os.ReadFile(filepath.Join(dir, "llama-3", "original", "consolidated.pt"))
+	require.NoError(t, err)
+	assert.Equal(t, "weights", string(data))
+
+	// Recursive upload
+	require.NoError(t, run(o, "cp", "-r", filepath.Join(dir, "llama-3"), "s3://backup/llama-3"))
+	assert.Equal(t, []byte("weights"), backup.objects["llama-3/original/consolidated.pt"])
+	assert.Equal(t, []byte("{}"), backup.objects["llama-3/config.json"])
+
+	assert.EqualError(t, run(o, "cp", "s3://models/llama-3/", dir), "s3://models/llama-3/ is a prefix, copy the objects under it with -r")
+	assert.EqualError(t, run(o, "cp", "-r", "s3://models/phi", dir), "no files or objects under s3://models/phi")
+	assert.EqualError(t, run(o, "cp", dir, dir), "the source or the destination must be an object storage URI")
+}
+
+func TestSync(t *testing.T) {
+	models := newMemStorage(storage.ProviderS3, map[string]string{
+		"llama-3/config.json":       "{}",
+		"llama-3/model.safetensors": "old",
+		"llama-3/stale.bin":         "stale",
+	})
+	o, out, _ := fakeOmeStorage(t, map[string]*memStorage{"models": models})
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.safetensors"), []byte("weights"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "original"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "original", "consolidated.pt"), []byte("weights"), 0o600))
+
+	require.NoError(t, run(o, "sync", "--delete", "--dry-run", dir, "s3://models/llama-3"))
+	assert.Equal(t, ""+
+		"would copy "+filepath.Join(dir, "model.safetensors")+" -> s3://models/llama-3/model.safetensors\n"+
+		"would copy "+filepath.Join(dir, "original", "consolidated.pt")+" -> s3://models/llama-3/original/consolidated.pt\n"+
+		"would delete s3://models/llama-3/stale.bin\n"+
+		"2 copied, 1 up to date, 1 deleted\n", out.String())
+	assert.Equal(t, []byte("old"), models.objects["llama-3/model.safetensors"])
+
+	out.Reset()
+	require.NoError(t, run(o, "sync", "--delete", dir, "s3://models/llama-3"))
+	assert.Contains(t, out.String(), "deleted s3://models/llama-3/stale.bin\n2 copied, 1 up to date, 1 deleted\n")
+	assert.Equal(t, map[string][]byte{
+		"llama-3/config.json":              []byte("{}"),
+		"llama-3/model.safetensors":        []byte("weights"),
+		"llama-3/original/consolidated.pt": []byte("weights"),
+	}, models.objects)
+
+	// Into a local directory that does not exist yet
+	out.Reset()
+	restored := filepath.Join(t.TempDir(), "llama-3")
+	require.NoError(t, run(o, "sync", "s3://models/llama-3", restored))
+	assert.Contains(t, out.String(), "3 copied, 0 up to date, 0 deleted\n")
+	assert.FileExists(t, filepath.Join(restored, "original", "consolidated.pt"))
+
+	// A file of the same size is compared by the MD5 in the ETag of the S3 object
+	require.NoError(t, os.WriteFile(filepath.Join(restored, "config.json"), []byte("[]"), 0o600))
+	out.Reset()
+	require.NoError(t, run(o, "sync", "s3://models/llama-3", restored))
+	assert.Contains(t, out.String(), "1 copied, 2 up to date, 0 deleted\n")
+	data, err := os.ReadFile(filepath.Join(restored, "config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+}
+
+func TestObjectMD5(t *testing.T) {
+	assert.Equal(t, "99914b932bd37a50b983c5e7c90ae93b", objectMD5(storage.ProviderS3, `"99914B932BD37A50B983C5E7C90AE93B"`))
+	// The ETag of a multipart upload, and the ETags of the other storages, are not MD5s
+	assert.Empty(t, objectMD5(storage.ProviderS3, `"99914b932bd37a50b983c5e7c90ae93b-4"`))
+	assert.Empty(t, objectMD5(storage.ProviderOCI, "99914b932bd37a50b983c5e7c90ae93b"))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/storage"
+	utilstorage "github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+// location is a local path or an object storage URI of the command line
+type location struct {
+	// path of a local location, empty for an object storage location
+	path string
+
+	// config of the storage of an object storage location, without the settings of the profile
+	config storage.Config
+	// uriPrefix is the URI of the location without its key, e.g. s3://bucket/
+	uriPrefix string
+	// key is the name or prefix of the objects
+	key string
+}
+
+// parseLocation parses a local path, or a local://, oci://, s3:// or gs:// URI
+func parseLocation(arg string) (location, error) {
+	if !strings.Contains(arg, "://") {
+		return location{path: arg}, nil
+	}
+	storageType, err := utilstorage.GetStorageType(arg)
+	if err != nil {
+		return location{}, err
+	}
+	switch storageType {
+	case utilstorage.StorageTypeLocal:
+		components, err := utilstorage.ParseLocalStorageURI(arg)
+		if err != nil {
+			return location{}, err
+		}
+		return location{path: components.Path}, nil
+	case utilstorage.StorageTypeOCI:
+		components, err := utilstorage.ParseOCIStorageURI(arg)
+		if err != nil {
+			return location{}, err
+		}
+		return location{
+			config:    storage.Config{Provider: storage.ProviderOCI, Namespace: components.Namespace, Bucket: components.Bucket},
+			uriPrefix: fmt.Sprintf("%sn/%s/b/%s/o/", utilstorage.OCIStoragePrefix, components.Namespace, components.Bucket),
+			key:       components.Prefix,
+		}, nil
+	case utilstorage.StorageTypeS3:
+		components, err := utilstorage.ParseS3StorageURI(arg)
+		if err != nil {
+			return location{}, err
+		}
+		bucket := components.Bucket
+		if components.Region != "" {
+			bucket += "@" + components.Region
+		}
+		return location{
+			config:    storage.Config{Provider: storage.ProviderS3, Bucket: components.Bucket, Region: components.Region},
+			uriPrefix: utilstorage.S3StoragePrefix + bucket + "/",
+			key:       components.Prefix,
+		}, nil
+	case utilstorage.StorageTypeGCS:
+		components, err := utilstorage.ParseGCSStorageURI(arg)
+		if err != nil {
+			return location{}, err
+		}
+		return location{
+			config:    storage.Config{Provider: storage.ProviderGCS, Bucket: components.Bucket},
+			uriPrefix: utilstorage.GCSStoragePrefix + components.Bucket + "/",
+			key:       components.Object,
+		}, nil
+	default:
+		return location{}, fmt.Errorf("%s URIs are not supported, only %s, %s, %s and %s URIs and local paths", storageType,
+			utilstorage.OCIStoragePrefix, utilstorage.S3StoragePrefix, utilstorage.GCSStoragePrefix, utilstorage.LocalStoragePrefix)
+	}
+}
+
+func (l location) isLocal() bool {
+	return l.config.Provider == ""
+}
+
+func (l location) String() string {
+	if l.isLocal() {
+		return l.path
+	}
+	return l.uri(l.key)
+}
+
+// uri returns the URI of the object of the key
+func (l location) uri(key string) string {
+	return l.uriPrefix + key
+}
+
+// object returns the object of the key in the form its storage provider parses it
+func (l location) object(key string) string {
+	switch l.config.Provider {
+	case storage.ProviderOCI:
+		// With the bucket, else the provider takes the first segment of a nested key for the bucket
+		return "/" + l.config.Bucket + "/" + key
+	case storage.ProviderS3:
+		return key
+	default:
+		return l.uri(key)
+	}
+}
+
+// dirKey returns the key as the prefix of a directory, ending with a slash unless it is the whole bucket
+func (l location) dirKey() string {
+	if l.key == "" || strings.HasSuffix(l.key, "/") {
+		return l.key
+	}
+	return l.key + "/"
+}
+
+// withKey returns the location of the key, in the same storage
+func (l location) withKey(key string) location {
+	l.key = key
+	return l
+}
+
+// baseName returns the last segment of the key
+func (l location) baseName() string {
+	return path.Base(l.key)
+}
+
+// storageKey identifies the storage of the location, shared by the locations of the same bucket
+func (l location) storageKey() string {
+	return strings.Join([]string{string(l.config.Provider), l.config.Namespace, l.config.Bucket, l.config.Region}, "/")
+}
+
+// listOptions returns the options to list all the objects of a prefix, without limit
+func (l location) listOptions() []storage.ListOption {
+	// OCI pages through the objects until the end without a limit, while S3 would return no objects
+	if l.config.Provider == storage.ProviderOCI {
+		return []storage.ListOption{storage.WithMaxResults(0)}
+	}
+	return []storage.ListOption{storage.WithMaxResults(math.MaxInt32)}
+}
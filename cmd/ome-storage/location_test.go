@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/storage"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		arg      string
+		config   storage.Config
+		path     string
+		key      string
+		object   string
+		children string
+	}{
+		{
+			arg:      "oci://n/tenancy/b/models/o/llama-3/config.json",
+			config:   storage.Config{Provider: storage.ProviderOCI, Namespace: "tenancy", Bucket: "models"},
+			key:      "llama-3/config.json",
+			object:   "/models/llama-3/config.json",
+			children: "oci://n/tenancy/b/models/o/llama-3/config.json/a",
+		},
+		{
+			arg:      "oci://n/tenancy/b/models/o/",
+			config:   storage.Config{Provider: storage.ProviderOCI, Namespace: "tenancy", Bucket: "models"},
+			object:   "/models/",
+			children: "oci://n/tenancy/b/models/o/a",
+		},
+		{
+			arg:      "s3://models@eu-west-1/llama-3/",
+			config:   storage.Config{Provider: storage.ProviderS3, Bucket: "models", Region: "eu-west-1"},
+			key:      "llama-3/",
+			object:   "llama-3/",
+			children: "s3://models@eu-west-1/llama-3/a",
+		},
+		{
+			arg:      "gs://models/llama-3",
+			config:   storage.Config{Provider: storage.ProviderGCS, Bucket: "models"},
+			key:      "llama-3",
+			object:   "gs://models/llama-3",
+			children: "gs://models/llama-3/a",
+		},
+		{arg: "local:///models/llama-3", path: "/models/llama-3"},
+		{arg: "./llama-3", path: "./llama-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			l, err := parseLocation(tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.config, l.config)
+			assert.Equal(t, tt.path, l.path)
+			if l.isLocal() {
+				return
+			}
+			assert.Equal(t, tt.arg, l.String())
+			assert.Equal(t, tt.key, l.key)
+			assert.Equal(t, tt.object, l.object(l.key))
+			assert.Equal(t, tt.children, l.child("a").String())
+		})
+	}
+
+	_, err := parseLocation("oci://models/llama-3")
+	assert.ErrorContains(t, err, "Expected: oci://n/{namespace}/b/{bucket}/o/{object_path}")
+	_, err = parseLocation("pvc://models/llama-3")
+	assert.EqualError(t, err, "PVC URIs are not supported, only oci://, s3://, gs:// and local:// URIs and local paths")
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Setenv(configEnv, "")
+	t.Setenv(profileEnv, "")
+	t.Setenv("HOME", t.TempDir())
+
+	// Without a configuration file, the providers use their default authentication
+	settings, err := loadProfile("", "")
+	require.NoError(t, err)
+	assert.Empty(t, settings)
+	_, err = loadProfile("", "dev")
+	assert.ErrorContains(t, err, "failed to read the configuration file")
+
+	path := filepath.Join(t.TempDir(), "storage.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  default:
+    oci:
+      authType: OCIInstancePrincipal
+  dev:
+    oci:
+      authType: OCIUserPrincipal
+      region: us-chicago-1
+      extra:
+        user_principal:
+          config_path: /home/me/.oci/config
+          profile: DEV
+`), 0o600))
+
+	settings, err = loadProfile(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[storage.Provider]providerSettings{storage.ProviderOCI: {AuthType: "OCIInstancePrincipal"}}, settings)
+
+	t.Setenv(profileEnv, "dev")
+	settings, err = loadProfile(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, providerSettings{
+		AuthType: "OCIUserPrincipal",
+		Region:   "us-chicago-1",
+		Extra: map[string]interface{}{
+			"user_principal": map[string]interface{}{"config_path": "/home/me/.oci/config", "profile": "DEV"},
+		},
+	}, settings[storage.ProviderOCI])
+
+	_, err = loadProfile(path, "prod")
+	assert.EqualError(t, err, "profile prod not found in the configuration file "+path)
+}
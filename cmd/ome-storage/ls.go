@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newLsCommand(o *omeStorage) *cobra.Command {
+	var recursive bool
+	cmd := &cobra.Command{
+		Use:   "ls URI",
+		Short: "List the objects and directories of a prefix",
+		Long: "List the objects whose name starts with the key of the URI, and the directories under it. With -r, the " +
+			"objects of the directories are listed instead.",
+		Example: "  ome-storage ls oci://n/mytenancy/b/models/o/\n  ome-storage ls -r s3://models/llama-3/",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.ls(cmd.Context(), args[0], recursive)
+		},
+	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "list the objects of the directories")
+	return cmd
+}
+
+func (o *omeStorage) ls(ctx context.Context, arg string, recursive bool) error {
+	l, err := parseLocation(arg)
+	if err != nil {
+		return err
+	}
+	if l.isLocal() {
+		return fmt.Errorf("ls lists object storage URIs, not local paths")
+	}
+	s, err := o.storageOf(ctx, l)
+	if err != nil {
+		return err
+	}
+	objects, err := s.List(ctx, l.object(l.key), l.listOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to list the objects of %s: %w", l, err)
+	}
+
+	// The directories are listed relative to the directory of the key, which is itself a prefix of the names
+	dir := l.key[:strings.LastIndex(l.key, "/")+1]
+	type row struct {
+		uri      string
+		size     string
+		modified string
+	}
+	var rows []row
+	dirs := map[string]bool{}
+	for _, object := range objects {
+		rel := strings.TrimPrefix(object.Name, dir)
+		if i := strings.Index(rel, "/"); !recursive && i >= 0 {
+			dirs[dir+rel[:i+1]] = true
+			continue
+		}
+		if object.IsDir {
+			dirs[object.Name] = true
+			continue
+		}
+		rows = append(rows, row{uri: l.uri(object.Name), size: formatBytes(object.Size), modified: object.LastModified.UTC().Format(time.RFC3339)})
+	}
+	for d := range dirs {
+		rows = append(rows, row{uri: l.uri(d), size: "DIR", modified: ""})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].uri < rows[j].uri })
+
+	w := tabwriter.NewWriter(o.out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SIZE\tLAST MODIFIED\tURI")
+	for _, r := range rows {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.size, orNone(r.modified), r.uri)
+	}
+	return w.Flush()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,124 @@
+// Command ome-storage copies, lists, removes and syncs the objects of the object storages OME downloads models from,
+// with the storage providers and authentication of pkg/storage and pkg/auth.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/storage"
+	_ "github.com/sgl-project/ome/pkg/storage/providers/gcs"
+	_ "github.com/sgl-project/ome/pkg/storage/providers/oci"
+	_ "github.com/sgl-project/ome/pkg/storage/providers/s3"
+	"github.com/sgl-project/ome/pkg/version"
+)
+
+// omeStorage holds the flags and storages shared by the commands
+type omeStorage struct {
+	configFile string
+	profile    string
+	verbose    bool
+	noProgress bool
+
+	out    io.Writer
+	errOut io.Writer
+
+	// open creates the storage of a location, with the global storage factory unless set by the tests
+	open func(ctx context.Context, config storage.Config) (storage.Storage, error)
+
+	// The profile is loaded and the storages created on first use
+	settings map[storage.Provider]providerSettings
+	storages map[string]storage.Storage
+}
+
+func newRootCommand(o *omeStorage) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ome-storage",
+		Short: "Copy, list, remove and sync objects of the object storages of OME",
+		Long: "ome-storage copies, lists, removes and syncs the objects of oci://, s3:// and gs:// URIs, and local " +
+			"paths, with the same storage providers and authentication as OME. The credentials of each provider " +
+			"are read from a profile of the configuration file.",
+		Version:       fmt.Sprintf("gitVersion=%s, gitCommit=%s", version.GitVersion, version.GitCommit),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&o.configFile, "config", "", "configuration file of the profiles, the OME_STORAGE_CONFIG environment variable or ~/.ome/storage.yaml if empty")
+	cmd.PersistentFlags().StringVarP(&o.profile, "profile", "p", "", "profile of the credentials, the OME_STORAGE_PROFILE environment variable or default if empty")
+	cmd.PersistentFlags().BoolVarP(&o.verbose, "verbose", "v", false, "log the operations of the storage providers to stderr")
+	cmd.PersistentFlags().BoolVar(&o.noProgress, "no-progress", false, "do not show the progress of the transfers")
+
+	cmd.AddCommand(newCpCommand(o))
+	cmd.AddCommand(newSyncCommand(o))
+	cmd.AddCommand(newLsCommand(o))
+	cmd.AddCommand(newStatCommand(o))
+	cmd.AddCommand(newRmCommand(o))
+	return cmd
+}
+
+// storageOf returns the storage of an object storage location, created with the settings of the profile for its
+// provider
+func (o *omeStorage) storageOf(ctx context.Context, l location) (storage.Storage, error) {
+	if o.settings == nil {
+		settings, err := loadProfile(o.configFile, o.profile)
+		if err != nil {
+			return nil, err
+		}
+		o.settings = settings
+		o.storages = map[string]storage.Storage{}
+	}
+	if s, ok := o.storages[l.storageKey()]; ok {
+		return s, nil
+	}
+
+	settings := o.settings[l.config.Provider]
+	config := l.config
+	if config.Region == "" {
+		config.Region = settings.Region
+	}
+	config.Endpoint = settings.Endpoint
+	config.AuthConfig = &storage.AuthConfig{
+		Type:   settings.AuthType,
+		Region: config.Region,
+		Extra:  settings.Extra,
+	}
+	s, err := o.open(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the %s storage of %s: %w", l.config.Provider, l, err)
+	}
+	o.storages[l.storageKey()] = s
+	return s, nil
+}
+
+func (o *omeStorage) logger() logging.Interface {
+	if !o.verbose {
+		return logging.Discard()
+	}
+	logger := logrus.New()
+	logger.SetOutput(o.errOut)
+	logger.SetLevel(logrus.DebugLevel)
+	return logging.ForLogrus(logrus.NewEntry(logger))
+}
+
+func main() {
+	o := &omeStorage{out: os.Stdout, errOut: os.Stderr}
+	o.open = func(ctx context.Context, config storage.Config) (storage.Storage, error) {
+		storage.InitGlobalFactory(o.logger())
+		return storage.GetGlobalFactory().CreateStorage(ctx, config)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := newRootCommand(o).ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
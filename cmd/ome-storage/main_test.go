@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/storage"
+)
+
+// memStorage is an in-memory storage of a bucket, whose objects are named by the keys passed to it
+type memStorage struct {
+	provider storage.Provider
+	objects  map[string][]byte
+	copies   int
+}
+
+var _ storage.Storage = (*memStorage)(nil)
+
+func newMemStorage(provider storage.Provider, objects map[string]string) *memStorage {
+	s := &memStorage{provider: provider, objects: map[string][]byte{}}
+	for name, data := range objects {
+		s.objects[name] = []byte(data)
+	}
+	return s
+}
+
+func (s *memStorage) Provider() storage.Provider { return s.provider }
+
+func (s *memStorage) Download(_ context.Context, source string, target string, _ ...storage.DownloadOption) error {
+	data, ok := s.objects[source]
+	if !ok {
+		return fmt.Errorf("object %s not found", source)
+	}
+	return os.WriteFile(target, data, 0o600)
+}
+
+func (s *memStorage) Upload(_ context.Context, source string, target string, _ ...storage.UploadOption) error {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	s.objects[target] = data
+	return nil
+}
+
+func (s *memStorage) Get(_ context.Context, uri string) (io.ReadCloser, error) {
+	data, ok := s.objects[uri]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", uri)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memStorage) Put(_ context.Context, uri string, reader io.Reader, _ int64, _ ...storage.UploadOption) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	s.objects[uri] = data
+	return nil
+}
+
+func (s *memStorage) Delete(_ context.Context, uri string) error {
+	delete(s.objects, uri)
+	return nil
+}
+
+func (s *memStorage) Exists(_ context.Context, uri string) (bool, error) {
+	_, ok := s.objects[uri]
+	return ok, nil
+}
+
+func (s *memStorage) List(_ context.Context, uri string, _ ...storage.ListOption) ([]storage.ObjectInfo, error) {
+	var objects []storage.ObjectInfo
+	for name, data := range s.objects {
+		if strings.HasPrefix(name, uri) {
+			// Like the ETag of an S3 object uploaded in one part
+			etag := fmt.Sprintf(`"%x"`, md5.Sum(data))
+			objects = append(objects, storage.ObjectInfo{Name: name, Size: int64(len(data)), ETag: etag, LastModified: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+	return objects, nil
+}
+
+func (s *memStorage) Stat(_ context.Context, uri string) (*storage.Metadata, error) {
+	data, ok := s.objects[uri]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", uri)
+	}
+	return &storage.Metadata{
+		Name:         uri,
+		Size:         int64(len(data)),
+		ContentType:  "application/json",
+		ETag:         "etag-1",
+		LastModified: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Metadata:     map[string]string{"sha256": "abc"},
+	}, nil
+}
+
+func (s *memStorage) Copy(_ context.Context, source string, target string) error {
+	data, ok := s.objects[source]
+	if !ok {
+		return fmt.Errorf("object %s not found", source)
+	}
+	s.objects[target] = data
+	s.copies++
+	return nil
+}
+
+// fakeOmeStorage returns an ome-storage whose storages are the in-memory storages by bucket, with a default profile
+// configuring S3
+func fakeOmeStorage(t *testing.T, buckets map[string]*memStorage) (*omeStorage, *bytes.Buffer, *[]storage.Config) {
+	out := &bytes.Buffer{}
+	var configs []storage.Config
+	o := &omeStorage{
+		configFile: filepath.Join(t.TempDir(), "storage.yaml"),
+		out:        out,
+		errOut:     io.Discard,
+	}
+	require.NoError(t, os.WriteFile(o.configFile, []byte("profiles:\n  default:\n    s3:\n      authType: access_key\n      region: us-east-1\n"), 0o600))
+	o.open = func(_ context.Context, config storage.Config) (storage.Storage, error) {
+		configs = append(configs, config)
+		s, ok := buckets[config.Bucket]
+		if !ok {
+			return nil, fmt.Errorf("bucket %s not found", config.Bucket)
+		}
+		return s, nil
+	}
+	return o, out, &configs
+}
+
+// run runs the ome-storage command with the arguments
+func run(o *omeStorage, args ...string) error {
+	configFile := o.configFile
+	cmd := newRootCommand(o)
+	cmd.SetArgs(append(args, "--config", configFile))
+	return cmd.Execute()
+}
+
+func TestLs(t *testing.T) {
+	models := newMemStorage(storage.ProviderS3, map[string]string{
+		"llama-3/config.json":              "{}",
+		"llama-3/model.safetensors":        "weights",
+		"llama-3/original/consolidated.pt": "weights",
+		"mistral/config.json":              "{}",
+	})
+	o, out, configs := fakeOmeStorage(t, map[string]*memStorage{"models": models})
+
+	require.NoError(t, run(o, "ls", "s3://models/llama-3/"))
+	assert.Equal(t, ""+
+		"SIZE  LAST MODIFIED         URI\n"+
+		"2 B   2026-10-01T12:00:00Z  s3://models/llama-3/config.json\n"+
+		"7 B   2026-10-01T12:00:00Z  s3://models/llama-3/model.safetensors\n"+
+		"DIR   <none>                s3://models/llama-3/original/\n", out.String())
+	require.Len(t, *configs, 1)
+	assert.Equal(t, "us-east-1", (*configs)[0].Region)
+	assert.Equal(t, "access_key", (*configs)[0].AuthConfig.Type)
+
+	out.Reset()
+	require.NoError(t, run(o, "ls", "-r", "s3://models/"))
+	assert.Contains(t, out.String(), "s3://models/llama-3/original/consolidated.pt\n")
+	assert.Contains(t, out.String(), "s3://models/mistral/config.json\n")
+
+	assert.EqualError(t, run(o, "ls", "./models"), "ls lists object storage URIs, not local paths")
+	assert.ErrorContains(t, run(o, "ls", "hf://meta-llama/Llama-3.1-8B"), "HUGGINGFACE URIs are not supported")
+}
+
+func TestStat(t *testing.T) {
+	models := newMemStorage(storage.ProviderS3, map[string]string{"llama-3/config.json": "{}"})
+	o, out, _ := fakeOmeStorage(t, map[string]*memStorage{"models": models})
+
+	require.NoError(t, run(o, "stat", "s3://models/llama-3/config.json"))
+	assert.Equal(t, ""+
+		"URI:            s3://models/llama-3/config.json\n"+
+		"Size:           2 B (2 bytes)\n"+
+		"Content type:   application/json\n"+
+		"ETag:           etag-1\n"+
+		"Last modified:  2026-10-01T12:00:00Z\n"+
+		"Storage class:  <none>\n"+
+		"Metadata:\n"+
+		"  sha256: abc\n", out.String())
+
+	assert.ErrorContains(t, run(o, "stat", "s3://models/missing"), "failed to get the metadata of s3://models/missing")
+}
+
+func TestRm(t *testing.T) {
+	models := newMemStorage(storage.ProviderS3, map[string]string{
+		"llama-3/config.json":       "{}",
+		"llama-3/model.safetensors": "weights",
+		"llama-3-old/config.json":   "{}",
+	})
+	o, out, _ := fakeOmeStorage(t, map[string]*memStorage{"models": models})
+
+	assert.EqualError(t, run(o, "rm", "s3://models/llama-3/"), "s3://models/llama-3/ is a prefix, remove the objects under it with -r")
+
+	require.NoError(t, run(o, "rm", "-r", "--dry-run", "s3://models/llama-3"))
+	assert.Equal(t, "would delete s3://models/llama-3/config.json\nwould delete s3://models/llama-3/model.safetensors\n", out.String())
+	assert.Len(t, models.objects, 3)
+
+	out.Reset()
+	require.NoError(t, run(o, "rm", "-r", "s3://models/llama-3"))
+	assert.Equal(t, "deleted s3://models/llama-3/config.json\ndeleted s3://models/llama-3/model.safetensors\n", out.String())
+	// Only the objects under the directory, not those of another directory with the same prefix
+	assert.Equal(t, map[string][]byte{"llama-3-old/config.json": []byte("{}")}, models.objects)
+
+	out.Reset()
+	require.NoError(t, run(o, "rm", "s3://models/llama-3-old/config.json"))
+	assert.Empty(t, models.objects)
+}
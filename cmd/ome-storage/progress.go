@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/storage"
+)
+
+// progress reports the progress of a transfer on a line of the terminal, rewritten at most every tenth of a second
+type progress struct {
+	out   io.Writer
+	name  string
+	start time.Time
+
+	mu         sync.Mutex
+	lastUpdate time.Time
+}
+
+var _ storage.ProgressReporter = (*progress)(nil)
+
+// newProgress returns the progress reporter of a transfer, nil when the progress is not shown
+func (o *omeStorage) newProgress(name string) *progress {
+	if o.noProgress || !isTerminal(o.errOut) {
+		return nil
+	}
+	return &progress{out: o.errOut, name: name, start: time.Now()}
+}
+
+func (p *progress) Update(bytesTransferred, totalBytes int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if time.Since(p.lastUpdate) < 100*time.Millisecond && bytesTransferred < totalBytes {
+		return
+	}
+	p.lastUpdate = time.Now()
+	line := fmt.Sprintf("%s: %s", p.name, formatBytes(bytesTransferred))
+	if totalBytes > 0 {
+		line += fmt.Sprintf(" / %s (%d%%)", formatBytes(totalBytes), bytesTransferred*100/totalBytes)
+	}
+	if elapsed := time.Since(p.start).Seconds(); elapsed > 0 {
+		line += fmt.Sprintf(", %s/s", formatBytes(int64(float64(bytesTransferred)/elapsed)))
+	}
+	_, _ = fmt.Fprintf(p.out, "\r\033[K%s", line)
+}
+
+func (p *progress) Done() {
+	p.clear()
+}
+
+func (p *progress) Error(error) {
+	p.clear()
+}
+
+func (p *progress) clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = fmt.Fprint(p.out, "\r\033[K")
+}
+
+// progressReader reports the bytes read through it
+type progressReader struct {
+	io.Reader
+	progress *progress
+	read     int64
+	size     int64
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	r.read += int64(n)
+	r.progress.Update(r.read, r.size)
+	return n, err
+}
+
+// isTerminal reports whether the writer is a terminal, on which the progress lines can be rewritten
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newRmCommand(o *omeStorage) *cobra.Command {
+	var recursive, dryRun bool
+	cmd := &cobra.Command{
+		Use:     "rm URI",
+		Short:   "Remove an object, or with -r the objects under a prefix",
+		Example: "  ome-storage rm oci://n/mytenancy/b/models/o/llama-3/config.json\n  ome-storage rm -r s3://models/llama-3",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.rm(cmd.Context(), args[0], recursive, dryRun)
+		},
+	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "remove the objects under the prefix")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print what would be removed without removing it")
+	return cmd
+}
+
+func (o *omeStorage) rm(ctx context.Context, arg string, recursive, dryRun bool) error {
+	l, err := parseLocation(arg)
+	if err != nil {
+		return err
+	}
+	if l.isLocal() {
+		return fmt.Errorf("rm removes the objects of object storage URIs, not local files")
+	}
+	if !recursive {
+		if l.key == "" || strings.HasSuffix(l.key, "/") {
+			return fmt.Errorf("%s is a prefix, remove the objects under it with -r", l)
+		}
+		if dryRun {
+			_, _ = fmt.Fprintf(o.out, "would delete %s\n", l)
+			return nil
+		}
+		return o.remove(ctx, l)
+	}
+
+	entries, err := o.entries(ctx, l)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no objects under %s", l)
+	}
+	for _, e := range entries {
+		if dryRun {
+			_, _ = fmt.Fprintf(o.out, "would delete %s\n", l.child(e.rel))
+			continue
+		}
+		if err := o.remove(ctx, l.child(e.rel)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remove removes the file or object of the location
+func (o *omeStorage) remove(ctx context.Context, l location) error {
+	if l.isLocal() {
+		if err := os.Remove(l.path); err != nil {
+			return err
+		}
+	} else {
+		s, err := o.storageOf(ctx, l)
+		if err != nil {
+			return err
+		}
+		if err := s.Delete(ctx, l.object(l.key)); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", l, err)
+		}
+	}
+	_, _ = fmt.Fprintf(o.out, "deleted %s\n", l)
+	return nil
+}
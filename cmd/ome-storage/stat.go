@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newStatCommand(o *omeStorage) *cobra.Command {
+	return &cobra.Command{
+		Use:     "stat URI",
+		Short:   "Show the metadata of an object",
+		Example: "  ome-storage stat oci://n/mytenancy/b/models/o/llama-3/config.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.stat(cmd.Context(), args[0])
+		},
+	}
+}
+
+func (o *omeStorage) stat(ctx context.Context, arg string) error {
+	l, err := parseLocation(arg)
+	if err != nil {
+		return err
+	}
+	if l.isLocal() {
+		return fmt.Errorf("stat shows the objects of object storage URIs, not local files")
+	}
+	s, err := o.storageOf(ctx, l)
+	if err != nil {
+		return err
+	}
+	metadata, err := s.Stat(ctx, l.object(l.key))
+	if err != nil {
+		return fmt.Errorf("failed to get the metadata of %s: %w", l, err)
+	}
+
+	_, _ = fmt.Fprintf(o.out, "URI:            %s\n", l)
+	_, _ = fmt.Fprintf(o.out, "Size:           %s (%d bytes)\n", formatBytes(metadata.Size), metadata.Size)
+	_, _ = fmt.Fprintf(o.out, "Content type:   %s\n", orNone(metadata.ContentType))
+	_, _ = fmt.Fprintf(o.out, "ETag:           %s\n", orNone(metadata.ETag))
+	_, _ = fmt.Fprintf(o.out, "Last modified:  %s\n", metadata.LastModified.UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(o.out, "Storage class:  %s\n", orNone(metadata.StorageClass))
+	if len(metadata.Metadata) > 0 {
+		_, _ = fmt.Fprintln(o.out, "Metadata:")
+		keys := make([]string, 0, len(metadata.Metadata))
+		for k := range metadata.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			_, _ = fmt.Fprintf(o.out, "  %s: %s\n", k, metadata.Metadata[k])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+)
+
+func newExplainCommand(o *omectl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain the decisions of the controller",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "runtime-selection ISVC",
+		Short: "Explain how the runtimes are ranked for the model of an InferenceService, and why the others are rejected",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.explainRuntimeSelection(cmd.Context(), args[0])
+		},
+	})
+	return cmd
+}
+
+// explainRuntimeSelection evaluates the runtimes with the same selector and scorer weights as the controller
+func (o *omectl) explainRuntimeSelection(ctx context.Context, name string) error {
+	isvc, err := o.ome.OmeV1beta1().InferenceServices(o.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get InferenceService %s/%s: %w", o.namespace, name, err)
+	}
+	model, err := o.getModel(ctx, isvc)
+	if err != nil {
+		return err
+	}
+
+	selectorConfig := runtimeselector.NewConfig(o.runtimeClient)
+	runtimeSelectorConfig, err := controllerconfig.NewRuntimeSelectorConfig(o.kube)
+	switch {
+	case err == nil:
+		selectorConfig.ScorerWeights = runtimeSelectorConfig.ScorerWeights
+	case apierrors.IsNotFound(err):
+		// The controller does not start without it, the default weights are the best guess
+		_, _ = fmt.Fprintf(o.out, "No ConfigMap %s/%s, using the default scorer weights\n",
+			constants.OMENamespace, constants.InferenceServiceConfigMapName)
+	default:
+		return fmt.Errorf("failed to load the runtime selector configuration: %w", err)
+	}
+	explanation, err := runtimeselector.NewWithConfig(selectorConfig).Explain(ctx, model.spec, isvc)
+	if err != nil {
+		return fmt.Errorf("failed to explain the runtime selection of InferenceService %s/%s: %w", isvc.Namespace, isvc.Name, err)
+	}
+
+	_, _ = fmt.Fprintf(o.out, "Model: %s %s\n", model.kind, model.name)
+	if runtime := isvc.Annotations[constants.RuntimeOverrideAnnotationKey]; runtime != "" {
+		_, _ = fmt.Fprintf(o.out, "Runtime %s is set by the %s annotation, the runtimes below are not selected from\n",
+			runtime, constants.RuntimeOverrideAnnotationKey)
+	} else if isvc.Spec.Runtime != nil && isvc.Spec.Runtime.Name != "" {
+		_, _ = fmt.Fprintf(o.out, "Runtime %s is set by spec.runtime, the runtimes below are not selected from\n", isvc.Spec.Runtime.Name)
+	} else if explanation.Selected == "" {
+		_, _ = fmt.Fprintln(o.out, "No runtime is compatible with the model")
+	} else {
+		_, _ = fmt.Fprintf(o.out, "Selected runtime: %s\n", explanation.Selected)
+	}
+	if len(explanation.Runtimes) == 0 {
+		_, _ = fmt.Fprintf(o.out, "No ServingRuntime in namespace %s and no ClusterServingRuntime\n", isvc.Namespace)
+		return nil
+	}
+	_, _ = fmt.Fprintln(o.out)
+	for _, line := range explanation.Lines() {
+		_, _ = fmt.Fprintln(o.out, line)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+type logsOptions struct {
+	component string
+	container string
+	follow    bool
+	tail      int64
+}
+
+func newLogsCommand(o *omectl) *cobra.Command {
+	options := &logsOptions{}
+	cmd := &cobra.Command{
+		Use:   "logs ISVC",
+		Short: "Print the logs of the pods of a component of an InferenceService, each line prefixed with its pod",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.logs(cmd.Context(), args[0], options)
+		},
+	}
+	cmd.Flags().StringVar(&options.component, "component", string(v1beta1.EngineComponent),
+		"component of the InferenceService: engine, decoder or router. predictor selects the engine, to which the deprecated predictor is migrated")
+	cmd.Flags().StringVarP(&options.container, "container", "c", "", "container of the pods, the "+constants.MainContainerName+" container if empty")
+	cmd.Flags().BoolVarP(&options.follow, "follow", "f", false, "stream the logs")
+	cmd.Flags().Int64Var(&options.tail, "tail", -1, "number of the most recent lines of each pod to print, all if negative")
+	return cmd
+}
+
+// logs prints the logs of the pods of the component, one pod after the other, or all of them at once when following
+func (o *omectl) logs(ctx context.Context, name string, options *logsOptions) error {
+	component, err := logsComponent(options.component)
+	if err != nil {
+		return err
+	}
+	isvc, err := o.ome.OmeV1beta1().InferenceServices(o.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get InferenceService %s/%s: %w", o.namespace, name, err)
+	}
+	pods, err := o.inferenceServicePods(ctx, isvc, component)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("no %s pods for InferenceService %s/%s", component, isvc.Namespace, isvc.Name)
+	}
+
+	out := &lockedWriter{w: o.out}
+	if !options.follow {
+		for i := range pods {
+			if err := o.podLogs(ctx, &pods[i], options, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(pods))
+	for i := range pods {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = o.podLogs(ctx, &pods[i], options, out)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// podLogs prints the logs of the container of the pod, each line prefixed with the pod and container
+func (o *omectl) podLogs(ctx context.Context, pod *corev1.Pod, options *logsOptions, out *lockedWriter) error {
+	container := options.container
+	if container == "" {
+		container = defaultContainer(pod)
+	}
+	logOptions := &corev1.PodLogOptions{Container: container, Follow: options.follow}
+	if options.tail >= 0 {
+		logOptions.TailLines = &options.tail
+	}
+	stream, err := o.kube.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the logs of container %s of pod %s: %w", container, pod.Name, err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	prefix := fmt.Sprintf("[%s/%s] ", pod.Name, container)
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		out.writeLine(prefix + scanner.Text())
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read the logs of container %s of pod %s: %w", container, pod.Name, err)
+	}
+	return nil
+}
+
+// logsComponent returns the component of the --component flag
+func logsComponent(component string) (v1beta1.ComponentType, error) {
+	switch v1beta1.ComponentType(component) {
+	case v1beta1.EngineComponent, v1beta1.PredictorComponent:
+		// The defaulting webhook migrates the deprecated predictor to the engine
+		return v1beta1.EngineComponent, nil
+	case v1beta1.DecoderComponent, v1beta1.RouterComponent:
+		return v1beta1.ComponentType(component), nil
+	default:
+		return "", fmt.Errorf("unknown component %q, must be engine, decoder, router or predictor", component)
+	}
+}
+
+// defaultContainer returns the container serving the model, else the first container
+func defaultContainer(pod *corev1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == constants.MainContainerName {
+			return container.Name
+		}
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return constants.MainContainerName
+}
+
+// lockedWriter writes whole lines of the logs of the pods followed at once
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) writeLine(line string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = fmt.Fprintln(l.w, line)
+}
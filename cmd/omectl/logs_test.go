@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestLogs(t *testing.T) {
+	o, out := fakeOmectl(t,
+		[]runtime.Object{
+			testPod("llama-engine-1", "llama", v1beta1.EngineComponent, true),
+			testPod("llama-engine-0", "llama", v1beta1.EngineComponent, true),
+			testPod("llama-router-0", "llama", v1beta1.RouterComponent, true),
+		},
+		[]runtime.Object{testInferenceService("llama", "llama-3")})
+
+	// The fake clientset returns "fake logs" for every container
+	require.NoError(t, run(o, "logs", "llama", "--component", "predictor"))
+	assert.Equal(t, "[llama-engine-0/ome-container] fake logs\n[llama-engine-1/ome-container] fake logs\n", out.String())
+
+	out.Reset()
+	require.NoError(t, run(o, "logs", "llama", "--component", "router", "-c", "sidecar", "-f"))
+	assert.Equal(t, "[llama-router-0/sidecar] fake logs\n", out.String())
+
+	err := run(o, "logs", "llama", "--component", "decoder")
+	assert.EqualError(t, err, "no decoder pods for InferenceService serving/llama")
+	err = run(o, "logs", "llama", "--component", "trainer")
+	assert.EqualError(t, err, `unknown component "trainer", must be engine, decoder, router or predictor`)
+}
@@ -0,0 +1,118 @@
+// Command omectl inspects the InferenceServices and models of OME. Installed as kubectl-ome on the PATH, it is also a
+// kubectl plugin run as kubectl ome.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	omeclientset "github.com/sgl-project/ome/pkg/client/clientset/versioned"
+	"github.com/sgl-project/ome/pkg/version"
+)
+
+// omectl holds the flags and clients shared by the commands
+type omectl struct {
+	kubeconfig string
+	context    string
+	namespace  string
+
+	out io.Writer
+
+	// Created from the kubeconfig before a command runs, unless set by the tests
+	kube kubernetes.Interface
+	ome  omeclientset.Interface
+	// runtimeClient is the client of the runtime selector, which reads the runtimes with controller-runtime
+	runtimeClient client.Client
+}
+
+func newRootCommand(o *omectl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "omectl",
+		Short: "Inspect OME InferenceServices and models",
+		Long: "omectl inspects the InferenceServices and models of OME, gathering what would otherwise take many kubectl " +
+			"queries. Installed as kubectl-ome on the PATH, it also runs as kubectl ome.",
+		Version:       fmt.Sprintf("gitVersion=%s, gitCommit=%s", version.GitVersion, version.GitCommit),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// The shell completion scripts are generated without a cluster
+			if cmd.Parent() != nil && cmd.Parent().Name() == "completion" {
+				return nil
+			}
+			return o.connect()
+		},
+	}
+	if strings.HasPrefix(filepath.Base(os.Args[0]), "kubectl-") {
+		cmd.Annotations = map[string]string{cobra.CommandDisplayNameAnnotation: "kubectl ome"}
+	}
+
+	cmd.PersistentFlags().StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig file, the KUBECONFIG environment variable or ~/.kube/config if empty")
+	cmd.PersistentFlags().StringVar(&o.context, "context", "", "kubeconfig context to use, the current context if empty")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "", "namespace of the InferenceServices, the namespace of the kubeconfig context if empty")
+
+	cmd.AddCommand(newStatusCommand(o))
+	cmd.AddCommand(newModelsCommand(o))
+	cmd.AddCommand(newExplainCommand(o))
+	cmd.AddCommand(newLogsCommand(o))
+	cmd.AddCommand(newTopCommand(o))
+	cmd.AddCommand(newRenderCommand(o))
+	return cmd
+}
+
+// connect creates the clients from the kubeconfig and resolves the namespace
+func (o *omectl) connect() error {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{CurrentContext: o.context})
+
+	if o.namespace == "" {
+		namespace, _, err := clientConfig.Namespace()
+		if err != nil {
+			return fmt.Errorf("failed to get the namespace of the kubeconfig context: %w", err)
+		}
+		o.namespace = namespace
+	}
+	if o.kube != nil {
+		return nil
+	}
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+	if o.kube, err = kubernetes.NewForConfig(config); err != nil {
+		return fmt.Errorf("failed to create the Kubernetes client: %w", err)
+	}
+	if o.ome, err = omeclientset.NewForConfig(config); err != nil {
+		return fmt.Errorf("failed to create the OME client: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add client-go scheme: %w", err)
+	}
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add OME v1beta1 scheme: %w", err)
+	}
+	if o.runtimeClient, err = client.New(config, client.Options{Scheme: scheme}); err != nil {
+		return fmt.Errorf("failed to create the runtime client: %w", err)
+	}
+	return nil
+}
+
+func main() {
+	if err := newRootCommand(&omectl{out: os.Stdout}).Execute(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	omefake "github.com/sgl-project/ome/pkg/client/clientset/versioned/fake"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// fakeOmectl returns an omectl with fake clients of the objects, in namespace serving
+func fakeOmectl(t *testing.T, kubeObjects []runtime.Object, omeObjects []runtime.Object, runtimeObjects ...client.Object) (*omectl, *bytes.Buffer) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	out := &bytes.Buffer{}
+	return &omectl{
+		namespace:     "serving",
+		out:           out,
+		kube:          kubefake.NewSimpleClientset(kubeObjects...),
+		ome:           omefake.NewSimpleClientset(omeObjects...),
+		runtimeClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(runtimeObjects...).Build(),
+	}, out
+}
+
+// run runs the omectl command with the arguments, in the namespace of the omectl
+func run(o *omectl, args ...string) error {
+	namespace := o.namespace
+	cmd := newRootCommand(o)
+	cmd.SetArgs(append(args, "--namespace", namespace))
+	return cmd.Execute()
+}
+
+func testInferenceService(name, model string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "serving", Name: name},
+		Spec:       v1beta1.InferenceServiceSpec{Model: &v1beta1.ModelRef{Name: model}},
+	}
+}
+
+func testClusterBaseModel(name, format string) *v1beta1.ClusterBaseModel {
+	return &v1beta1.ClusterBaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: format}},
+	}
+}
+
+func testPod(name, isvc string, component v1beta1.ComponentType, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "serving", Name: name, Labels: map[string]string{
+			constants.InferenceServicePodLabelKey: isvc,
+			constants.OMEComponentLabel:           string(component),
+			constants.ServingRuntimeLabelKey:      "srt-llama",
+		}},
+		Spec: corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "sidecar"}, {Name: constants.MainContainerName}}},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: constants.MainContainerName, Ready: ready, RestartCount: 2}},
+		},
+	}
+}
+
+func TestExplainRuntimeSelection(t *testing.T) {
+	llama := &v1beta1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "srt-llama"},
+		Spec: v1beta1.ServingRuntimeSpec{SupportedModelFormats: []v1beta1.SupportedModelFormat{
+			{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 1}, AutoSelect: ptr.To(true)},
+		}},
+	}
+	onnx := &v1beta1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "onnx"},
+		Spec: v1beta1.ServingRuntimeSpec{SupportedModelFormats: []v1beta1.SupportedModelFormat{
+			{ModelFormat: &v1beta1.ModelFormat{Name: "onnx", Weight: 1}, AutoSelect: ptr.To(true)},
+		}},
+	}
+	o, out := fakeOmectl(t, nil,
+		[]runtime.Object{testInferenceService("llama", "llama-3"), testClusterBaseModel("llama-3", "safetensors")},
+		llama, onnx)
+
+	require.NoError(t, run(o, "explain", "runtime-selection", "llama"))
+	lines := out.String()
+	assert.Contains(t, lines, "No ConfigMap "+constants.OMENamespace+"/"+constants.InferenceServiceConfigMapName+", using the default scorer weights\n")
+	assert.Contains(t, lines, "Model: ClusterBaseModel llama-3\nSelected runtime: srt-llama\n")
+	assert.Contains(t, lines, "ClusterServingRuntime srt-llama: score")
+	assert.Contains(t, lines, "[selected]\nClusterServingRuntime onnx: rejected: ")
+
+	err := run(o, "explain", "runtime-selection", "missing")
+	assert.ErrorContains(t, err, "failed to get InferenceService serving/missing")
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/modelagent"
+)
+
+func newModelsCommand(o *omectl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "models",
+		Short: "Inspect the models on the nodes",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "on-node NODE",
+		Short: "List the models of a node and their status, as reported by the model agent of the node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.modelsOnNode(cmd.Context(), args[0])
+		},
+	})
+	return cmd
+}
+
+// nodeModel is a model in the model status ConfigMap of a node
+type nodeModel struct {
+	kind      string
+	namespace string
+	entry     modelagent.ModelEntry
+}
+
+// modelsOnNode prints the models of the ConfigMap the model agent of the node keeps up to date
+func (o *omectl) modelsOnNode(ctx context.Context, node string) error {
+	configMap, err := o.kube.CoreV1().ConfigMaps(constants.OMENamespace).Get(ctx, node, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("no model status ConfigMap %s/%s, the model agent does not run on node %s or has no model yet",
+			constants.OMENamespace, node, node)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the model status ConfigMap of node %s: %w", node, err)
+	}
+
+	var models []nodeModel
+	for key, data := range configMap.Data {
+		namespace, name, isClusterBaseModel, ok := constants.ParseModelInfoFromConfigMapKey(key)
+		if !ok {
+			continue
+		}
+		model := nodeModel{kind: constants.BaseModel, namespace: namespace}
+		if isClusterBaseModel {
+			model.kind = constants.ClusterBaseModel
+		}
+		if err := json.Unmarshal([]byte(data), &model.entry); err != nil || model.entry.Status == "" {
+			continue
+		}
+		// The key is truncated for long names
+		if model.entry.Name == "" {
+			model.entry.Name = name
+		}
+		models = append(models, model)
+	}
+	if len(models) == 0 {
+		_, _ = fmt.Fprintf(o.out, "No models on node %s\n", node)
+		return nil
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].namespace != models[j].namespace {
+			return models[i].namespace < models[j].namespace
+		}
+		return models[i].entry.Name < models[j].entry.Name
+	})
+
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MODEL\tKIND\tNAMESPACE\tSTATUS\tPROGRESS")
+	for _, model := range models {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", model.entry.Name, model.kind, orNone(model.namespace),
+			model.entry.Status, formatProgress(model.entry.Progress))
+	}
+	return w.Flush()
+}
+
+// formatProgress formats the download progress of a model
+func formatProgress(progress *modelagent.DownloadProgress) string {
+	if progress == nil {
+		return "<none>"
+	}
+	if progress.TotalBytes == 0 {
+		return progress.Phase
+	}
+	return fmt.Sprintf("%s %.1f%% (%d/%d files)", progress.Phase, progress.Percentage(), progress.CompletedFiles, progress.TotalFiles)
+}
+
+// servedModel is the model an InferenceService serves
+type servedModel struct {
+	kind      string
+	namespace string
+	name      string
+	spec      *v1beta1.BaseModelSpec
+	status    v1beta1.ModelStatusSpec
+}
+
+// getModel gets the model of the InferenceService like the controller does: a BaseModel of the namespace of the
+// InferenceService, else a ClusterBaseModel of the same name
+func (o *omectl) getModel(ctx context.Context, isvc *v1beta1.InferenceService) (*servedModel, error) {
+	if isvc.Spec.Model == nil || isvc.Spec.Model.Name == "" {
+		return nil, fmt.Errorf("InferenceService %s/%s has no model", isvc.Namespace, isvc.Name)
+	}
+	name := isvc.Spec.Model.Name
+
+	baseModel, err := o.ome.OmeV1beta1().BaseModels(isvc.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return &servedModel{kind: constants.BaseModel, namespace: isvc.Namespace, name: name, spec: &baseModel.Spec, status: baseModel.Status}, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get BaseModel %s/%s: %w", isvc.Namespace, name, err)
+	}
+	clusterBaseModel, err := o.ome.OmeV1beta1().ClusterBaseModels().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return &servedModel{kind: constants.ClusterBaseModel, name: name, spec: &clusterBaseModel.Spec, status: clusterBaseModel.Status}, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get ClusterBaseModel %s: %w", name, err)
+	}
+	return nil, fmt.Errorf("no BaseModel %s/%s or ClusterBaseModel %s", isvc.Namespace, name, name)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestModelsOnNode(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.OMENamespace, Name: "node-1"},
+		Data: map[string]string{
+			"clusterbasemodel.llama-3":   `{"name":"llama-3","status":"Ready"}`,
+			"team-a.basemodel.mistral":   `{"name":"mistral","status":"Updating","progress":{"phase":"Downloading","totalBytes":200,"completedBytes":50,"totalFiles":4,"completedFiles":1}}`,
+			"clusterbasemodel.truncated": `{"status":"Failed"}`,
+			"not-a-model":                `plain text`,
+			// The name of a ClusterBaseModel may contain the infix of the keys of the BaseModels
+			"clusterbasemodel.qwen.basemodel.v2": `{"status":"Ready"}`,
+		},
+	}
+	o, out := fakeOmectl(t, []runtime.Object{configMap}, nil)
+
+	require.NoError(t, run(o, "models", "on-node", "node-1"))
+	assert.Equal(t, ""+
+		"MODEL              KIND              NAMESPACE  STATUS    PROGRESS\n"+
+		"llama-3            ClusterBaseModel  <none>     Ready     <none>\n"+
+		"qwen.basemodel.v2  ClusterBaseModel  <none>     Ready     <none>\n"+
+		"truncated          ClusterBaseModel  <none>     Failed    <none>\n"+
+		"mistral            BaseModel         team-a     Updating  Downloading 25.0% (1/4 files)\n", out.String())
+
+	err := run(o, "models", "on-node", "node-2")
+	assert.ErrorContains(t, err, "no model status ConfigMap "+constants.OMENamespace+"/node-2")
+}
+
+func TestGetModel(t *testing.T) {
+	baseModel := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "serving", Name: "llama-3"},
+		Spec:       v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}},
+	}
+	o, _ := fakeOmectl(t, nil, []runtime.Object{baseModel, testClusterBaseModel("llama-3", "safetensors"), testClusterBaseModel("mistral", "safetensors")})
+
+	// A BaseModel of the namespace comes before a ClusterBaseModel of the same name
+	model, err := o.getModel(t.Context(), testInferenceService("llama", "llama-3"))
+	require.NoError(t, err)
+	assert.Equal(t, constants.BaseModel, model.kind)
+	assert.Equal(t, "serving", model.namespace)
+
+	model, err = o.getModel(t.Context(), testInferenceService("mistral", "mistral"))
+	require.NoError(t, err)
+	assert.Equal(t, constants.ClusterBaseModel, model.kind)
+	assert.Empty(t, model.namespace)
+
+	_, err = o.getModel(t.Context(), testInferenceService("phi", "phi"))
+	assert.EqualError(t, err, "no BaseModel serving/phi or ClusterBaseModel phi")
+}
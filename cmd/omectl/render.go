@@ -0,0 +1,454 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	ray "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/spf13/cobra"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/sgl-project/ome/pkg/acceleratorclassselector"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	isvccontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+	"github.com/sgl-project/ome/pkg/utils"
+	isvcwebhook "github.com/sgl-project/ome/pkg/webhook/admission/isvc"
+)
+
+// maxRenderReconciles is how many times the InferenceService is reconciled at most, the controller requeueing it
+// until its components are created
+const maxRenderReconciles = 5
+
+// renderOptions are the flags of the render isvc command
+type renderOptions struct {
+	filename string
+	fixtures []string
+}
+
+func newRenderCommand(o *omectl) *cobra.Command {
+	var options renderOptions
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the resources the controller creates, without creating them",
+	}
+	isvcCmd := &cobra.Command{
+		Use:   "isvc -f FILE",
+		Short: "Print the resources the controller would create for an InferenceService, without applying anything",
+		Long: "Print the resources the controller would create for the InferenceService of the file: the Deployments or " +
+			"LeaderWorkerSets, Services, routes and autoscalers. The InferenceService is defaulted like by the webhook " +
+			"and reconciled by the controller in memory, with the runtimes, models, AcceleratorClasses and " +
+			"inferenceservice-config ConfigMap of the cluster, or with those of the fixture files when --fixtures is set.",
+		Example: "  omectl render isvc -f llama-3-1-8b.yaml -n serving\n" +
+			"  omectl render isvc -f llama-3-1-8b.yaml --fixtures ./runtimes --fixtures ./inferenceservice-config.yaml",
+		Args: cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// The fixtures replace the cluster, which is not needed then
+			if len(options.fixtures) > 0 {
+				return nil
+			}
+			return o.connect()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.renderInferenceService(cmd.Context(), options)
+		},
+	}
+	isvcCmd.Flags().StringVarP(&options.filename, "filename", "f", "", "file of the InferenceService, - for stdin")
+	isvcCmd.Flags().StringSliceVar(&options.fixtures, "fixtures", nil,
+		"files, or directories of .yaml, .yml and .json files, of the objects to render with instead of those of the cluster")
+	_ = isvcCmd.MarkFlagRequired("filename")
+	cmd.AddCommand(isvcCmd)
+	return cmd
+}
+
+// renderInferenceService prints the events of the reconcile of the InferenceService as comments, then the resources
+// the controller created as YAML documents
+func (o *omectl) renderInferenceService(ctx context.Context, options renderOptions) error {
+	scheme, err := renderScheme()
+	if err != nil {
+		return err
+	}
+	isvc, err := readInferenceService(scheme, options.filename)
+	if err != nil {
+		return err
+	}
+	if isvc.Namespace == "" {
+		isvc.Namespace = o.namespace
+	}
+	if isvc.Namespace == "" {
+		isvc.Namespace = metav1.NamespaceDefault
+	}
+	// The InferenceService may be the output of kubectl get, it is rendered as if it was created
+	isvc.ResourceVersion = ""
+
+	var objects []client.Object
+	if len(options.fixtures) > 0 {
+		objects, err = loadFixtures(scheme, options.fixtures)
+	} else {
+		objects, err = o.clusterObjects(ctx, isvc.Namespace)
+	}
+	if err != nil {
+		return err
+	}
+
+	rendered, events, err := render(ctx, scheme, isvc, objects)
+	for _, event := range events {
+		_, _ = fmt.Fprintf(o.out, "# %s\n", event)
+	}
+	if err != nil {
+		return err
+	}
+	for _, obj := range rendered {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		_, _ = fmt.Fprintf(o.out, "---\n%s", data)
+	}
+	return nil
+}
+
+// renderScheme returns the scheme of the InferenceServices and of all the resources the controller may create
+func renderScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, s := range []struct {
+		name        string
+		addToScheme func(*runtime.Scheme) error
+	}{
+		{"client-go", clientgoscheme.AddToScheme},
+		{"OME v1beta1", v1beta1.AddToScheme},
+		{"Knative Serving", knservingv1.AddToScheme},
+		{"Istio", istioclientv1beta1.AddToScheme},
+		{"Gateway API", gatewayapiv1.AddToScheme},
+		{"LeaderWorkerSet", lws.AddToScheme},
+		{"KEDA", kedav1.AddToScheme},
+		{"Ray", ray.AddToScheme},
+	} {
+		if err := s.addToScheme(scheme); err != nil {
+			return nil, fmt.Errorf("failed to add %s scheme: %w", s.name, err)
+		}
+	}
+	return scheme, nil
+}
+
+// readInferenceService reads the InferenceService of the file, or of stdin if it is -
+func readInferenceService(scheme *runtime.Scheme, filename string) (*v1beta1.InferenceService, error) {
+	var reader io.Reader = os.Stdin
+	if filename != "-" {
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the InferenceService: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+	objects, err := decodeObjects(scheme, reader, filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) != 1 {
+		return nil, fmt.Errorf("%s must hold a single InferenceService, found %d objects", filename, len(objects))
+	}
+	isvc, ok := objects[0].(*v1beta1.InferenceService)
+	if !ok {
+		return nil, fmt.Errorf("%s must hold an InferenceService, found a %s", filename, objects[0].GetObjectKind().GroupVersionKind().Kind)
+	}
+	return isvc, nil
+}
+
+// loadFixtures reads the objects of the files, and of the .yaml, .yml and .json files under the directories
+func loadFixtures(scheme *runtime.Scheme, paths []string) ([]client.Object, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// A file given explicitly is read whatever its extension
+			if file == path && !d.IsDir() {
+				files = append(files, file)
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".yaml", ".yml", ".json":
+				if !d.IsDir() {
+					files = append(files, file)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the fixtures: %w", err)
+		}
+	}
+
+	var objects []client.Object
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the fixtures: %w", err)
+		}
+		fileObjects, err := decodeObjects(scheme, bytes.NewReader(data), file)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return objects, nil
+}
+
+// decodeObjects decodes the objects of the YAML or JSON documents of the reader, and the items of the lists among
+// them, like the output of kubectl get -o yaml
+func decodeObjects(scheme *runtime.Scheme, reader io.Reader, source string) ([]client.Object, error) {
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	var objects []client.Object
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 {
+			continue
+		}
+		decoded, err := decodeObject(deserializer, raw.Raw, source)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+}
+
+// decodeObject decodes an object, or the items of a list
+func decodeObject(deserializer runtime.Decoder, data []byte, source string) ([]client.Object, error) {
+	obj, _, err := deserializer.Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode an object of %s: %w", source, err)
+	}
+	if list, ok := obj.(*corev1.List); ok {
+		var objects []client.Object
+		for _, item := range list.Items {
+			decoded, err := decodeObject(deserializer, item.Raw, source)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, decoded...)
+		}
+		return objects, nil
+	}
+	clientObject, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s holds a %s, which is not a Kubernetes object", source, obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return []client.Object{clientObject}, nil
+}
+
+// clusterObjects reads the objects the controller reads to reconcile an InferenceService of the namespace
+func (o *omectl) clusterObjects(ctx context.Context, namespace string) ([]client.Object, error) {
+	configMap, err := o.kube.CoreV1().ConfigMaps(constants.OMENamespace).Get(ctx, constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", constants.OMENamespace, constants.InferenceServiceConfigMapName, err)
+	}
+	objects := []client.Object{configMap}
+	for _, l := range []struct {
+		kind string
+		list client.ObjectList
+		opts []client.ListOption
+	}{
+		{"ClusterServingRuntimes", &v1beta1.ClusterServingRuntimeList{}, nil},
+		{"ServingRuntimes", &v1beta1.ServingRuntimeList{}, []client.ListOption{client.InNamespace(namespace)}},
+		{"ClusterBaseModels", &v1beta1.ClusterBaseModelList{}, nil},
+		{"BaseModels", &v1beta1.BaseModelList{}, []client.ListOption{client.InNamespace(namespace)}},
+		{"FineTunedWeights", &v1beta1.FineTunedWeightList{}, nil},
+		{"AcceleratorClasses", &v1beta1.AcceleratorClassList{}, nil},
+	} {
+		if err := o.runtimeClient.List(ctx, l.list, l.opts...); err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", l.kind, err)
+		}
+		items, err := meta.ExtractList(l.list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", l.kind, err)
+		}
+		for _, item := range items {
+			objects = append(objects, item.(client.Object))
+		}
+	}
+	return objects, nil
+}
+
+// render defaults the InferenceService like the webhook and reconciles it with the controller, against a fake client
+// of the objects. It returns the objects the controller created, in the order they were created, and the events
+// it recorded.
+func render(ctx context.Context, scheme *runtime.Scheme, isvc *v1beta1.InferenceService, objects []client.Object) ([]client.Object, []string, error) {
+	var configMaps []runtime.Object
+	for _, obj := range objects {
+		if configMap, ok := obj.(*corev1.ConfigMap); ok {
+			configMaps = append(configMaps, configMap)
+		}
+	}
+	// The controller reads its configuration with the clientset, and the rest with the client
+	kube := kubefake.NewClientset(configMaps...)
+	if _, err := kube.CoreV1().ConfigMaps(constants.OMENamespace).Get(ctx, constants.InferenceServiceConfigMapName, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("no ConfigMap %s/%s to configure the controller with", constants.OMENamespace, constants.InferenceServiceConfigMapName)
+	}
+
+	var created []client.Object
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&v1beta1.InferenceService{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, wrapped client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if err := wrapped.Create(ctx, obj, opts...); err != nil {
+					return err
+				}
+				created = append(created, obj.DeepCopyObject().(client.Object))
+				return nil
+			},
+		}).
+		Build()
+
+	ctx = ctrllog.IntoContext(ctx, logr.Discard())
+	deployConfig, err := controllerconfig.NewDeployConfig(kube)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the deploy configuration: %w", err)
+	}
+	if err := isvcwebhook.DefaultInferenceService(ctx, c, isvc, deployConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to default InferenceService %s/%s: %w", isvc.Namespace, isvc.Name, err)
+	}
+	if err := c.Create(ctx, isvc); err != nil {
+		return nil, nil, fmt.Errorf("failed to create InferenceService %s/%s: %w", isvc.Namespace, isvc.Name, err)
+	}
+	// Only the objects created by the controller are rendered
+	created = nil
+
+	runtimeSelectorConfig, err := controllerconfig.NewRuntimeSelectorConfig(kube)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the runtime selector configuration: %w", err)
+	}
+	selectorConfig := runtimeselector.NewConfig(c)
+	selectorConfig.ScorerWeights = runtimeSelectorConfig.ScorerWeights
+	// Nothing is created in the cluster, so Serverless InferenceServices are rendered whether Knative is installed or not
+	utils.SetAvailableResourcesForApi(knservingv1.SchemeGroupVersion.String(), &metav1.APIResourceList{
+		GroupVersion: knservingv1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "services", Kind: constants.KnativeServiceKind, Namespaced: true}},
+	})
+	recorder := &eventRecorder{}
+	r := &isvccontroller.InferenceServiceReconciler{
+		Client:                   c,
+		Clientset:                kube,
+		Log:                      logr.Discard(),
+		Scheme:                   scheme,
+		Recorder:                 recorder,
+		StatusManager:            status.NewStatusReconciler(),
+		RuntimeSelector:          runtimeselector.NewWithConfig(selectorConfig),
+		AcceleratorClassSelector: acceleratorclassselector.New(c),
+	}
+
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(isvc)}
+	for i := 0; i < maxRenderReconciles; i++ {
+		result, err := r.Reconcile(ctx, request)
+		if err != nil {
+			return nil, recorder.events, fmt.Errorf("failed to reconcile InferenceService %s/%s: %w", isvc.Namespace, isvc.Name, err)
+		}
+		if !result.Requeue && result.RequeueAfter == 0 {
+			break
+		}
+	}
+
+	rendered, err := currentObjects(ctx, c, scheme, created)
+	return rendered, recorder.events, err
+}
+
+// currentObjects returns the current version of the objects that were not deleted since their creation, without
+// the fields set by the API server
+func currentObjects(ctx context.Context, c client.Client, scheme *runtime.Scheme, created []client.Object) ([]client.Object, error) {
+	type objectKey struct {
+		gvk schema.GroupVersionKind
+		key client.ObjectKey
+	}
+	seen := map[objectKey]bool{}
+	var objects []client.Object
+	for _, obj := range created {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		key := objectKey{gvk: gvk, key: client.ObjectKeyFromObject(obj)}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		current := obj.DeepCopyObject().(client.Object)
+		if err := c.Get(ctx, key.key, current); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s %s: %w", gvk.Kind, key.key, err)
+		}
+		current.GetObjectKind().SetGroupVersionKind(gvk)
+		current.SetResourceVersion("")
+		current.SetManagedFields(nil)
+		objects = append(objects, current)
+	}
+	return objects, nil
+}
+
+// eventRecorder keeps the events the controller records, once each
+type eventRecorder struct {
+	events []string
+}
+
+func (e *eventRecorder) Event(_ runtime.Object, eventtype, reason, message string) {
+	event := fmt.Sprintf("%s %s: %s", eventtype, reason, message)
+	for _, existing := range e.events {
+		if existing == event {
+			return
+		}
+	}
+	e.events = append(e.events, event)
+}
+
+func (e *eventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (e *eventRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+const renderInferenceServiceYAML = `
+apiVersion: ome.io/v1beta1
+kind: InferenceService
+metadata:
+  name: llama
+spec:
+  model:
+    name: llama-3
+  engine:
+    minReplicas: 1
+`
+
+const renderConfigYAML = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  namespace: ome
+  name: inferenceservice-config
+data:
+  deploy: '{"defaultDeploymentMode": "RawDeployment"}'
+`
+
+// renderRuntimeAndModelYAML is a list, as printed by kubectl get -o yaml
+const renderRuntimeAndModelYAML = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: ome.io/v1beta1
+  kind: ClusterServingRuntime
+  metadata:
+    name: srt-llama
+  spec:
+    supportedModelFormats:
+    - modelFormat:
+        name: safetensors
+        weight: 1
+      autoSelect: true
+    engineConfig:
+      runner:
+        name: ome-container
+        image: sglang:v0.4
+- apiVersion: ome.io/v1beta1
+  kind: ClusterBaseModel
+  metadata:
+    name: llama-3
+  spec:
+    modelFormat:
+      name: safetensors
+`
+
+func writeFile(t *testing.T, dir, name, data string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestRenderWithFixtures(t *testing.T) {
+	dir := t.TempDir()
+	isvcFile := writeFile(t, dir, "llama.yaml", renderInferenceServiceYAML)
+	fixtures := t.TempDir()
+	writeFile(t, fixtures, "config.yaml", renderConfigYAML)
+	writeFile(t, fixtures, "runtimes.yml", renderRuntimeAndModelYAML)
+	writeFile(t, fixtures, "README.md", "not a fixture")
+	// Without the clients of a cluster
+	out := &bytes.Buffer{}
+	o := &omectl{namespace: "serving", out: out}
+
+	require.NoError(t, run(o, "render", "isvc", "-f", isvcFile, "--fixtures", fixtures))
+	rendered := out.String()
+	assert.Contains(t, rendered, "# Normal RuntimeSelected: Auto-selected ClusterServingRuntime srt-llama for model llama-3")
+	assert.Contains(t, rendered, "---\napiVersion: apps/v1\nkind: Deployment\n")
+	assert.Contains(t, rendered, "  name: llama-engine\n  namespace: serving\n")
+	assert.Contains(t, rendered, "image: sglang:v0.4\n")
+	assert.Contains(t, rendered, "kind: Service\n")
+	assert.NotContains(t, rendered, "kind: InferenceService\n")
+	assert.NotContains(t, rendered, "kind: ClusterServingRuntime\n")
+	assert.NotContains(t, rendered, "resourceVersion:")
+
+	// The configuration of the controller is required
+	require.NoError(t, os.Remove(filepath.Join(fixtures, "config.yaml")))
+	err := run(o, "render", "isvc", "-f", isvcFile, "--fixtures", fixtures)
+	assert.EqualError(t, err, "no ConfigMap ome/inferenceservice-config to configure the controller with")
+}
+
+func TestRenderFromCluster(t *testing.T) {
+	llama := &v1beta1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "srt-llama"},
+		Spec: v1beta1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1beta1.SupportedModelFormat{
+				{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 1}, AutoSelect: ptr.To(true)},
+			},
+			EngineConfig: &v1beta1.EngineSpec{Runner: &v1beta1.RunnerSpec{Container: corev1.Container{Name: constants.MainContainerName, Image: "sglang:v0.4"}}},
+		},
+	}
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.OMENamespace, Name: constants.InferenceServiceConfigMapName},
+		Data:       map[string]string{"deploy": `{"defaultDeploymentMode": "RawDeployment"}`},
+	}
+	o, out := fakeOmectl(t, []runtime.Object{config}, nil, llama, testClusterBaseModel("llama-3", "safetensors"))
+	isvcFile := writeFile(t, t.TempDir(), "llama.yaml", renderInferenceServiceYAML)
+
+	require.NoError(t, run(o, "render", "isvc", "-f", isvcFile))
+	assert.Contains(t, out.String(), "  name: llama-engine\n  namespace: serving\n")
+	assert.Contains(t, out.String(), "image: sglang:v0.4\n")
+
+	// The model is not in the cluster
+	o, _ = fakeOmectl(t, []runtime.Object{config}, nil, llama)
+	err := run(o, "render", "isvc", "-f", isvcFile)
+	assert.ErrorContains(t, err, "failed to reconcile InferenceService serving/llama")
+}
+
+func TestReadInferenceService(t *testing.T) {
+	scheme, err := renderScheme()
+	require.NoError(t, err)
+	dir := t.TempDir()
+
+	isvc, err := readInferenceService(scheme, writeFile(t, dir, "llama.yaml", renderInferenceServiceYAML))
+	require.NoError(t, err)
+	assert.Equal(t, "llama", isvc.Name)
+	assert.Equal(t, "llama-3", isvc.Spec.Model.Name)
+
+	path := writeFile(t, dir, "config.yaml", renderConfigYAML)
+	_, err = readInferenceService(scheme, path)
+	assert.EqualError(t, err, path+" must hold an InferenceService, found a ConfigMap")
+	path = writeFile(t, dir, "both.yaml", renderInferenceServiceYAML+"---\n"+renderConfigYAML)
+	_, err = readInferenceService(scheme, path)
+	assert.EqualError(t, err, path+" must hold a single InferenceService, found 2 objects")
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"knative.dev/pkg/apis"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// maxStatusEvents is the number of the most recent events of an InferenceService shown by status
+const maxStatusEvents = 10
+
+func newStatusCommand(o *omectl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of OME resources",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:     "isvc NAME",
+		Aliases: []string{"inferenceservice"},
+		Short:   "Show the conditions, model, runtime, components, pods and events of an InferenceService",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.statusInferenceService(cmd.Context(), args[0])
+		},
+	})
+	return cmd
+}
+
+func (o *omectl) statusInferenceService(ctx context.Context, name string) error {
+	isvc, err := o.ome.OmeV1beta1().InferenceServices(o.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get InferenceService %s/%s: %w", o.namespace, name, err)
+	}
+	pods, err := o.inferenceServicePods(ctx, isvc, "")
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", isvc.Name)
+	_, _ = fmt.Fprintf(w, "Namespace:\t%s\n", isvc.Namespace)
+	_, _ = fmt.Fprintf(w, "Ready:\t%s\n", conditionStatus(isvc.Status.GetCondition(apis.ConditionReady)))
+	url := "<none>"
+	if isvc.Status.URL != nil {
+		url = isvc.Status.URL.String()
+	}
+	_, _ = fmt.Fprintf(w, "URL:\t%s\n", url)
+	_, _ = fmt.Fprintf(w, "Model:\t%s\n", o.describeModel(ctx, isvc))
+	_, _ = fmt.Fprintf(w, "Runtime:\t%s\n", describeRuntime(isvc, pods))
+	if transition := isvc.Status.ModelStatus.TransitionStatus; transition != "" {
+		_, _ = fmt.Fprintf(w, "Model Transition:\t%s\n", transition)
+	}
+	if failure := isvc.Status.ModelStatus.LastFailureInfo; failure != nil {
+		_, _ = fmt.Fprintf(w, "Last Failure:\t%s: %s\n", failure.Reason, failure.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(o.out, "\nConditions:")
+	w = tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  TYPE\tSTATUS\tAGE\tREASON\tMESSAGE")
+	for _, condition := range isvc.Status.Conditions {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status,
+			age(condition.LastTransitionTime.Inner.Time), orNone(condition.Reason), condition.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(isvc.Status.Components) > 0 {
+		_, _ = fmt.Fprintln(o.out, "\nComponents:")
+		w = tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  COMPONENT\tLATEST READY REVISION\tLATEST CREATED REVISION\tURL")
+		components := make([]v1beta1.ComponentType, 0, len(isvc.Status.Components))
+		for component := range isvc.Status.Components {
+			components = append(components, component)
+		}
+		sort.Slice(components, func(i, j int) bool { return components[i] < components[j] })
+		for _, component := range components {
+			status := isvc.Status.Components[component]
+			url := "<none>"
+			if status.URL != nil {
+				url = status.URL.String()
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", component, orNone(status.LatestReadyRevision),
+				orNone(status.LatestCreatedRevision), url)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintln(o.out, "\nPods:")
+	if len(pods) == 0 {
+		_, _ = fmt.Fprintln(o.out, "  <none>")
+	} else {
+		w = tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  COMPONENT\tNAME\tREADY\tSTATUS\tRESTARTS\tNODE\tAGE")
+		for _, pod := range pods {
+			ready, restarts := podReadiness(&pod)
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%d\t%s\t%s\n", pod.Labels[constants.OMEComponentLabel], pod.Name,
+				ready, podStatus(&pod), restarts, orNone(pod.Spec.NodeName), age(pod.CreationTimestamp.Time))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return o.printEvents(ctx, isvc)
+}
+
+// describeModel describes the model of the InferenceService and its readiness on the nodes
+func (o *omectl) describeModel(ctx context.Context, isvc *v1beta1.InferenceService) string {
+	model, err := o.getModel(ctx, isvc)
+	if err != nil {
+		return err.Error()
+	}
+	name := model.name
+	if model.namespace != "" {
+		name = model.namespace + "/" + name
+	}
+	return fmt.Sprintf("%s %s (%s, ready on %d nodes, failed on %d)", model.kind, name, orNone(string(model.status.State)),
+		len(model.status.NodesReady), len(model.status.NodesFailed))
+}
+
+// describeRuntime describes the runtime of the InferenceService and how it was chosen
+func describeRuntime(isvc *v1beta1.InferenceService, pods []corev1.Pod) string {
+	if runtime := isvc.Annotations[constants.RuntimeOverrideAnnotationKey]; runtime != "" {
+		return runtime + " (set by the " + constants.RuntimeOverrideAnnotationKey + " annotation)"
+	}
+	if isvc.Spec.Runtime != nil && isvc.Spec.Runtime.Name != "" {
+		return isvc.Spec.Runtime.Name + " (set by spec.runtime)"
+	}
+	// The runtime auto-selected by the controller is only recorded on the pods
+	var runtimes []string
+	for _, pod := range pods {
+		if runtime := pod.Labels[constants.ServingRuntimeLabelKey]; runtime != "" && !slices.Contains(runtimes, runtime) {
+			runtimes = append(runtimes, runtime)
+		}
+	}
+	if len(runtimes) == 0 {
+		return "<unknown> (auto-selected, no pods)"
+	}
+	return strings.Join(runtimes, ", ") + " (auto-selected)"
+}
+
+// printEvents prints the most recent events of the InferenceService
+func (o *omectl) printEvents(ctx context.Context, isvc *v1beta1.InferenceService) error {
+	events, err := o.kube.CoreV1().Events(isvc.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=InferenceService,involvedObject.name=" + isvc.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the events of InferenceService %s/%s: %w", isvc.Namespace, isvc.Name, err)
+	}
+	var items []corev1.Event
+	for _, event := range events.Items {
+		// The field selector is ignored by some clients
+		if event.InvolvedObject.Kind == "InferenceService" && event.InvolvedObject.Name == isvc.Name {
+			items = append(items, event)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return eventTime(&items[i]).Before(eventTime(&items[j])) })
+	if len(items) > maxStatusEvents {
+		items = items[len(items)-maxStatusEvents:]
+	}
+
+	_, _ = fmt.Fprintln(o.out, "\nEvents:")
+	if len(items) == 0 {
+		_, _ = fmt.Fprintln(o.out, "  <none>")
+		return nil
+	}
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  TYPE\tREASON\tAGE\tMESSAGE")
+	for _, event := range items {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", event.Type, event.Reason, age(eventTime(&event)), event.Message)
+	}
+	return w.Flush()
+}
+
+// inferenceServicePods lists the pods of the InferenceService, of the component only unless empty
+func (o *omectl) inferenceServicePods(ctx context.Context, isvc *v1beta1.InferenceService, component v1beta1.ComponentType) ([]corev1.Pod, error) {
+	selector := labels.Set{constants.InferenceServicePodLabelKey: isvc.Name}
+	if component != "" {
+		selector[constants.OMEComponentLabel] = string(component)
+	}
+	pods, err := o.kube.CoreV1().Pods(isvc.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods of InferenceService %s/%s: %w", isvc.Namespace, isvc.Name, err)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		if ci, cj := pods.Items[i].Labels[constants.OMEComponentLabel], pods.Items[j].Labels[constants.OMEComponentLabel]; ci != cj {
+			return ci < cj
+		}
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	return pods.Items, nil
+}
+
+// podReadiness returns the ready containers of the pod, e.g. 1/2, and their restarts
+func podReadiness(pod *corev1.Pod) (string, int32) {
+	var ready int
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+		restarts += status.RestartCount
+	}
+	return fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers)), restarts
+}
+
+// podStatus returns the phase of the pod, or the reason a container is waiting or terminated, as kubectl get does
+func podStatus(pod *corev1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+				return status.State.Waiting.Reason
+			}
+			if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 && status.State.Terminated.Reason != "" {
+				return status.State.Terminated.Reason
+			}
+		}
+	}
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason
+	}
+	return string(pod.Status.Phase)
+}
+
+func conditionStatus(condition *apis.Condition) string {
+	if condition == nil {
+		return string(corev1.ConditionUnknown)
+	}
+	if condition.Status != corev1.ConditionTrue && condition.Reason != "" {
+		return fmt.Sprintf("%s (%s)", condition.Status, condition.Reason)
+	}
+	return string(condition.Status)
+}
+
+func eventTime(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// age formats the time elapsed since t as kubectl does
+func age(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestStatusInferenceService(t *testing.T) {
+	isvc := testInferenceService("llama", "llama-3")
+	isvc.Status = v1beta1.InferenceServiceStatus{
+		Status: duckv1.Status{Conditions: duckv1.Conditions{
+			{Type: apis.ConditionReady, Status: corev1.ConditionFalse, Reason: "EngineNotReady", Message: "engine pods are starting"},
+		}},
+		URL: &apis.URL{Scheme: "http", Host: "llama.serving.svc.cluster.local"},
+		Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+			v1beta1.EngineComponent: {LatestCreatedRevision: "llama-engine-1"},
+		},
+	}
+	model := testClusterBaseModel("llama-3", "safetensors")
+	model.Status = v1beta1.ModelStatusSpec{State: v1beta1.LifeCycleStateReady, NodesReady: []string{"node-1", "node-2"}}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "serving", Name: "llama.1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "InferenceService", Name: "llama"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "InternalError",
+		Message:        "failed to reconcile",
+	}
+	otherEvent := event.DeepCopy()
+	otherEvent.Name, otherEvent.InvolvedObject.Kind = "llama.2", "Deployment"
+	o, out := fakeOmectl(t,
+		[]runtime.Object{testPod("llama-engine-0", "llama", v1beta1.EngineComponent, false), testPod("other-engine-0", "other", v1beta1.EngineComponent, true), event, otherEvent},
+		[]runtime.Object{isvc, model})
+
+	require.NoError(t, run(o, "status", "isvc", "llama"))
+	status := out.String()
+	assert.Contains(t, status, "Ready:      False (EngineNotReady)\n")
+	assert.Contains(t, status, "URL:        http://llama.serving.svc.cluster.local\n")
+	assert.Contains(t, status, "Model:      ClusterBaseModel llama-3 (Ready, ready on 2 nodes, failed on 0)\n")
+	assert.Contains(t, status, "Runtime:    srt-llama (auto-selected)\n")
+	assert.Contains(t, status, "  Ready  False   <unknown>  EngineNotReady  engine pods are starting\n")
+	assert.Contains(t, status, "  engine     <none>                 llama-engine-1           <none>\n")
+	assert.Contains(t, status, "  engine     llama-engine-0  0/2    Running  2         node-1")
+	assert.NotContains(t, status, "other-engine-0")
+	assert.Contains(t, status, "  Warning  InternalError")
+	assert.Equal(t, 1, strings.Count(status, "InternalError"))
+}
+
+func TestDescribeRuntime(t *testing.T) {
+	isvc := testInferenceService("llama", "llama-3")
+	assert.Equal(t, "<unknown> (auto-selected, no pods)", describeRuntime(isvc, nil))
+
+	isvc.Spec.Runtime = &v1beta1.ServingRuntimeRef{Name: "srt-llama"}
+	assert.Equal(t, "srt-llama (set by spec.runtime)", describeRuntime(isvc, nil))
+
+	isvc.Annotations = map[string]string{constants.RuntimeOverrideAnnotationKey: "vllm"}
+	assert.Equal(t, "vllm (set by the "+constants.RuntimeOverrideAnnotationKey+" annotation)", describeRuntime(isvc, nil))
+}
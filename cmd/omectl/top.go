@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func newTopCommand(o *omectl) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Rank OME resources by usage",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "models",
+		Short: "List the models of all namespaces, the most served first, with the nodes they are ready on",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.topModels(cmd.Context())
+		},
+	})
+	return cmd
+}
+
+// modelUsage is the usage of a model by the InferenceServices
+type modelUsage struct {
+	servedModel
+	inferenceServices int
+	readyPods         int
+}
+
+func (o *omectl) topModels(ctx context.Context) error {
+	clusterBaseModels, err := o.ome.OmeV1beta1().ClusterBaseModels().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterBaseModels: %w", err)
+	}
+	baseModels, err := o.ome.OmeV1beta1().BaseModels(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list BaseModels: %w", err)
+	}
+	isvcs, err := o.ome.OmeV1beta1().InferenceServices(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list InferenceServices: %w", err)
+	}
+	pods, err := o.kube.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: constants.InferenceServicePodLabelKey})
+	if err != nil {
+		return fmt.Errorf("failed to list the pods of the InferenceServices: %w", err)
+	}
+
+	// Models by namespace and name, with an empty namespace for the ClusterBaseModels
+	usages := make(map[types.NamespacedName]*modelUsage)
+	for _, model := range clusterBaseModels.Items {
+		usages[types.NamespacedName{Name: model.Name}] = &modelUsage{servedModel: servedModel{
+			kind: constants.ClusterBaseModel, name: model.Name, spec: &model.Spec, status: model.Status}}
+	}
+	for _, model := range baseModels.Items {
+		usages[types.NamespacedName{Namespace: model.Namespace, Name: model.Name}] = &modelUsage{servedModel: servedModel{
+			kind: constants.BaseModel, namespace: model.Namespace, name: model.Name, spec: &model.Spec, status: model.Status}}
+	}
+
+	readyPods := make(map[types.NamespacedName]int)
+	for _, pod := range pods.Items {
+		if isPodReady(&pod) {
+			readyPods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[constants.InferenceServicePodLabelKey]}]++
+		}
+	}
+	for _, isvc := range isvcs.Items {
+		if isvc.Spec.Model == nil || isvc.Spec.Model.Name == "" {
+			continue
+		}
+		// Like the controller, a BaseModel of the namespace of the InferenceService comes before a ClusterBaseModel
+		usage, ok := usages[types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Spec.Model.Name}]
+		if !ok {
+			if usage, ok = usages[types.NamespacedName{Name: isvc.Spec.Model.Name}]; !ok {
+				continue
+			}
+		}
+		usage.inferenceServices++
+		usage.readyPods += readyPods[types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}]
+	}
+
+	if len(usages) == 0 {
+		_, _ = fmt.Fprintln(o.out, "No models")
+		return nil
+	}
+	ranked := make([]*modelUsage, 0, len(usages))
+	for _, usage := range usages {
+		ranked = append(ranked, usage)
+	}
+	sortModelUsages(ranked)
+
+	w := tabwriter.NewWriter(o.out, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MODEL\tKIND\tNAMESPACE\tSIZE\tSTATE\tNODES READY\tNODES FAILED\tINFERENCESERVICES\tREADY PODS")
+	for _, usage := range ranked {
+		size := "<none>"
+		if usage.spec.ModelParameterSize != nil {
+			size = *usage.spec.ModelParameterSize
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\n", usage.name, usage.kind, orNone(usage.namespace), size,
+			orNone(string(usage.status.State)), len(usage.status.NodesReady), len(usage.status.NodesFailed),
+			usage.inferenceServices, usage.readyPods)
+	}
+	return w.Flush()
+}
+
+// sortModelUsages sorts the models by ready pods, then InferenceServices, then ready nodes, the most first
+func sortModelUsages(usages []*modelUsage) {
+	sort.Slice(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if a.readyPods != b.readyPods {
+			return a.readyPods > b.readyPods
+		}
+		if a.inferenceServices != b.inferenceServices {
+			return a.inferenceServices > b.inferenceServices
+		}
+		if len(a.status.NodesReady) != len(b.status.NodesReady) {
+			return len(a.status.NodesReady) > len(b.status.NodesReady)
+		}
+		if a.namespace != b.namespace {
+			return a.namespace < b.namespace
+		}
+		return a.name < b.name
+	})
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestTopModels(t *testing.T) {
+	llama := testClusterBaseModel("llama-3", "safetensors")
+	llama.Spec.ModelParameterSize = ptr.To("8B")
+	llama.Status = v1beta1.ModelStatusSpec{State: v1beta1.LifeCycleStateReady, NodesReady: []string{"node-1", "node-2"}}
+	mistral := testClusterBaseModel("mistral", "safetensors")
+	mistral.Status = v1beta1.ModelStatusSpec{State: v1beta1.LifeCycleStateReady, NodesReady: []string{"node-1"}, NodesFailed: []string{"node-2"}}
+	// Served instead of the ClusterBaseModel of the same name in its namespace
+	namespacedLlama := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "llama-3"},
+		Spec:       v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}},
+	}
+	teamALlama := testInferenceService("llama", "llama-3")
+	teamALlama.Namespace = "team-a"
+
+	o, out := fakeOmectl(t,
+		[]runtime.Object{
+			testPod("llama-engine-0", "llama", v1beta1.EngineComponent, true),
+			testPod("llama-engine-1", "llama", v1beta1.EngineComponent, false),
+			testPod("mistral-engine-0", "mistral", v1beta1.EngineComponent, false),
+		},
+		[]runtime.Object{llama, mistral, namespacedLlama, testInferenceService("llama", "llama-3"), teamALlama,
+			testInferenceService("mistral", "mistral"), testInferenceService("unknown", "phi")})
+
+	require.NoError(t, run(o, "top", "models"))
+	assert.Equal(t, ""+
+		"MODEL    KIND              NAMESPACE  SIZE    STATE   NODES READY  NODES FAILED  INFERENCESERVICES  READY PODS\n"+
+		"llama-3  ClusterBaseModel  <none>     8B      Ready   2            0             1                  1\n"+
+		"mistral  ClusterBaseModel  <none>     <none>  Ready   1            1             1                  0\n"+
+		"llama-3  BaseModel         team-a     <none>  <none>  0            0             1                  0\n", out.String())
+}
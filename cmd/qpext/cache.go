@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// metricsCache serves the response of a metrics handler for a TTL after it was computed, so that several Prometheus
+// replicas or frequent scrapes don't each scrape the application. The requests arriving while the response is
+// computed wait for it instead of scraping again. Only complete responses are cached: a response missing metrics,
+// e.g. because the application did not answer, is served once, so that the next scrape sees its recovery.
+type metricsCache struct {
+	next http.HandlerFunc
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func newMetricsCache(next http.HandlerFunc, ttl time.Duration) *metricsCache {
+	return &metricsCache{next: next, ttl: ttl, now: time.Now}
+}
+
+// responseRecorder records the response of a handler
+type responseRecorder struct {
+	status int
+	header http.Header
+	body   bytes.Buffer
+	// scrapeFailed tells whether some metrics are missing from the response
+	scrapeFailed bool
+}
+
+// markScrapeFailed marks a response recorded by the cache as missing metrics, so that it is not cached
+func markScrapeFailed(w http.ResponseWriter) {
+	if recorder, ok := w.(*responseRecorder); ok {
+		recorder.scrapeFailed = true
+	}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (c *metricsCache) handle(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	status, header, body := c.status, c.header, c.body
+	if c.body == nil || !c.now().Before(c.expires) {
+		recorder := &responseRecorder{header: make(http.Header)}
+		c.next(recorder, r)
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		status, header, body = recorder.status, recorder.header, recorder.body.Bytes()
+		if status == http.StatusOK && !recorder.scrapeFailed && len(body) > 0 {
+			c.status, c.header, c.body = status, header, body
+			c.expires = c.now().Add(c.ttl)
+		} else {
+			c.body = nil
+		}
+	}
+	c.mu.Unlock()
+
+	for key, values := range header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsCache(t *testing.T) {
+	scrapes := 0
+	var mu sync.Mutex
+	next := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		scrapes++
+		count := scrapes
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = fmt.Fprintf(w, "scrapes %d\n", count)
+	}
+	now := time.Now()
+	cache := newMetricsCache(next, 5*time.Second)
+	cache.now = func() time.Time { return now }
+
+	get := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		cache.handle(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec
+	}
+
+	// Concurrent scrapes share a single scrape of the application
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := get()
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "scrapes 1\n", rec.Body.String())
+			assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+		}()
+	}
+	wg.Wait()
+
+	// The response is scraped again once it expires
+	now = now.Add(4 * time.Second)
+	assert.Equal(t, "scrapes 1\n", get().Body.String())
+	now = now.Add(time.Second)
+	assert.Equal(t, "scrapes 2\n", get().Body.String())
+}
+
+func TestMetricsCacheSkipsFailedScrapes(t *testing.T) {
+	scrapes := 0
+	fail := true
+	next := func(w http.ResponseWriter, r *http.Request) {
+		scrapes++
+		if fail {
+			markScrapeFailed(w)
+		}
+		_, _ = fmt.Fprintf(w, "scrapes %d\n", scrapes)
+	}
+	cache := newMetricsCache(next, 5*time.Second)
+	get := func() string {
+		rec := httptest.NewRecorder()
+		cache.handle(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	// A response missing metrics is served but not cached, so that the recovery is seen at the next scrape
+	assert.Equal(t, "scrapes 1\n", get())
+	assert.Equal(t, "scrapes 2\n", get())
+	fail = false
+	assert.Equal(t, "scrapes 3\n", get())
+	assert.Equal(t, "scrapes 3\n", get())
+
+	// So is an empty one
+	empty := newMetricsCache(func(w http.ResponseWriter, r *http.Request) { scrapes++ }, 5*time.Second)
+	empty.handle(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	empty.handle(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, 5, scrapes)
+}
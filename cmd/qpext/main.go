@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -28,17 +31,78 @@ const (
 	ContainerPrometheusMetricsPortEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PORT"
 	ContainerPrometheusMetricsPathEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PATH"
 	QueueProxyAggregatePrometheusMetricsPortEnvVarKey = "AGGREGATE_PROMETHEUS_METRICS_PORT"
+	ContainerPrometheusMetricsSchemeEnvVarKey         = "CONTAINER_PROMETHEUS_METRICS_SCHEME"
+	ContainerPrometheusMetricsCAFileEnvVarKey         = "CONTAINER_PROMETHEUS_METRICS_CA_FILE"
+	AggregatePrometheusMetricsTLSCertFileEnvVarKey    = "AGGREGATE_PROMETHEUS_METRICS_TLS_CERT_FILE"
+	AggregatePrometheusMetricsTLSKeyFileEnvVarKey     = "AGGREGATE_PROMETHEUS_METRICS_TLS_KEY_FILE"
+	AggregatePrometheusMetricsClientCAFileEnvVarKey   = "AGGREGATE_PROMETHEUS_METRICS_CLIENT_CA_FILE"
+	AggregatePrometheusMetricsTokenFileEnvVarKey      = "AGGREGATE_PROMETHEUS_METRICS_BEARER_TOKEN_FILE"
+	AggregatePrometheusMetricsCacheTTLEnvVarKey       = "AGGREGATE_PROMETHEUS_METRICS_CACHE_TTL"
 	QueueProxyMetricsPort                             = "9091"
 	DefaultQueueProxyMetricsPath                      = "/metrics"
 	prometheusTimeoutHeader                           = "X-Prometheus-Scrape-Timeout-Seconds"
+	// MetricsSourceLabel is added to the application metrics when several application ports are scraped, so the
+	// metrics of the engine, the router and the sidecars can be told apart
+	MetricsSourceLabel = "metrics_source"
 )
 
 type ScrapeConfigurations struct {
 	logger         *zap.Logger
 	QueueProxyPath string `json:"path"`
 	QueueProxyPort string `json:"port"`
-	AppPort        string
-	AppPath        string
+	// AppPort is a port or a comma-separated list of ports of the application containers, each optionally
+	// prefixed by the name of its source, e.g. "engine=8080,router=8081"
+	AppPort string
+	// AppPath is the metrics path of all the application ports, or a comma-separated list of one path per port
+	AppPath string
+	// AppScheme is the scheme the application ports are scraped with, http when empty
+	AppScheme string
+	// appClient scrapes the application ports, http.DefaultClient when nil
+	appClient *http.Client
+}
+
+// AppSource is an application endpoint whose metrics are merged into the aggregate metrics
+type AppSource struct {
+	Name string
+	Port string
+	Path string
+}
+
+// parseAppSources parses the application ports and paths of the scrape configuration. A source is named after its
+// port unless the port is prefixed by a name.
+func parseAppSources(ports string, paths string) ([]AppSource, error) {
+	if ports == "" {
+		return nil, nil
+	}
+	portList := strings.Split(ports, ",")
+	pathList := strings.Split(paths, ",")
+	if len(pathList) != 1 && len(pathList) != len(portList) {
+		return nil, fmt.Errorf("%d metrics paths don't match the %d metrics ports", len(pathList), len(portList))
+	}
+
+	var sources []AppSource
+	names := make(map[string]bool)
+	for i, entry := range portList {
+		name, port, named := strings.Cut(strings.TrimSpace(entry), "=")
+		if !named {
+			name, port = entry, entry
+		}
+		name, port = strings.TrimSpace(name), strings.TrimSpace(port)
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil || name == "" {
+			return nil, fmt.Errorf("invalid metrics port %q", entry)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate metrics source %q", name)
+		}
+		names[name] = true
+
+		path := pathList[0]
+		if len(pathList) > 1 {
+			path = pathList[i]
+		}
+		sources = append(sources, AppSource{Name: name, Port: port, Path: strings.TrimSpace(path)})
+	}
+	return sources, nil
 }
 
 type Logger = zap.Logger
@@ -49,7 +113,14 @@ func initializeLogger() *Logger {
 }
 
 func getURL(port string, path string) string {
-	return fmt.Sprintf("http://localhost:%s%s", port, path)
+	return getSchemeURL("http", port, path)
+}
+
+func getSchemeURL(scheme string, port string, path string) string {
+	if scheme == "" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://localhost:%s%s", scheme, port, path)
 }
 
 // getHeaderTimeout parse a string like (1.234) representing number of seconds
@@ -183,7 +254,7 @@ func scrapeAndWriteAppMetrics(mfs map[string]*ioprometheusclient.MetricFamily, w
 // scrape sends a request to the provided url to scrape metrics from
 // This will attempt to mimic some of Prometheus functionality by passing some headers through
 // scrape returns the scraped metrics reader as well as the response's "Content-Type" header to determine the metrics format
-func scrape(url string, header http.Header, logger *zap.Logger) (io.ReadCloser, context.CancelFunc, string, error) {
+func scrape(client *http.Client, url string, header http.Header, logger *zap.Logger) (io.ReadCloser, context.CancelFunc, string, error) {
 	var cancel context.CancelFunc
 	ctx := context.Background()
 	if timeoutString := header.Get(prometheusTimeoutHeader); timeoutString != "" {
@@ -204,7 +275,10 @@ func scrape(url string, header http.Header, logger *zap.Logger) (io.ReadCloser,
 		prometheusTimeoutHeader,
 	)
 
-	resp, err := http.DefaultClient.Do(req)
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, cancel, "", fmt.Errorf("error scraping %s: %v", url, err)
 	}
@@ -219,6 +293,52 @@ func scrape(url string, header http.Header, logger *zap.Logger) (io.ReadCloser,
 	return resp.Body, cancel, format, nil
 }
 
+// scrapeAppSource scrapes the metrics of an application source. The metric families parsed before an error are
+// returned along with it.
+func scrapeAppSource(client *http.Client, scheme string, source AppSource, header http.Header, logger *zap.Logger) (map[string]*ioprometheusclient.MetricFamily, error) {
+	application, cancel, _, err := scrape(client, getSchemeURL(scheme, source.Port, source.Path), header, logger)
+	if cancel != nil {
+		defer cancel()
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := application.Close(); err != nil {
+			logger.Error("application connection is not closed", zap.Error(err), zap.String("source", source.Name))
+		}
+	}()
+
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(application)
+}
+
+// mergeMetricFamilies adds the metrics of the families of a source to the families of the other sources. The
+// metrics are labeled with the source when it is set. A family whose type differs from the one of the same name
+// of another source is discarded, as Prometheus rejects a metric with two types.
+func mergeMetricFamilies(into, from map[string]*ioprometheusclient.MetricFamily, source string, logger *zap.Logger) {
+	for name, mf := range from {
+		if source != "" {
+			for _, metric := range mf.Metric {
+				addServerlessLabels(metric, []string{MetricsSourceLabel}, []string{source})
+			}
+		}
+
+		existing, ok := into[name]
+		if !ok {
+			into[name] = mf
+			continue
+		}
+		if existing.GetType() != mf.GetType() {
+			logger.Error("discarding metric with conflicting types", zap.String("metric name", name),
+				zap.String("source", source), zap.String("type", mf.GetType().String()),
+				zap.String("existing type", existing.GetType().String()))
+			continue
+		}
+		existing.Metric = append(existing.Metric, mf.Metric...)
+	}
+}
+
 func NewScrapeConfigs(logger *zap.Logger, queueProxyPort string, appPort string, appPath string) *ScrapeConfigurations {
 	return &ScrapeConfigurations{
 		logger:         logger,
@@ -231,46 +351,58 @@ func NewScrapeConfigs(logger *zap.Logger, queueProxyPort string, appPort string,
 
 func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Request) {
 	var err error
-	var queueProxy, application io.ReadCloser
-	var queueProxyCancel, appCancel context.CancelFunc
+	var queueProxy io.ReadCloser
+	var queueProxyCancel context.CancelFunc
+	// failed tells whether some metrics are missing from the response, which is then not cached
+	failed := false
 
 	defer func() {
+		if failed {
+			markScrapeFailed(w)
+		}
 		if queueProxy != nil {
 			err = queueProxy.Close()
 			if err != nil {
 				sc.logger.Error("queue proxy connection is not closed", zap.Error(err))
 			}
 		}
-		if application != nil {
-			err = application.Close()
-			if err != nil {
-				sc.logger.Error("application connection is not closed", zap.Error(err))
-			}
-		}
 		if queueProxyCancel != nil {
 			queueProxyCancel()
 		}
-		if appCancel != nil {
-			appCancel()
-		}
 	}()
 
 	// Gather all the metrics we will merge
 	if sc.QueueProxyPort != "" {
 		queueProxyURL := getURL(sc.QueueProxyPort, sc.QueueProxyPath)
-		if queueProxy, queueProxyCancel, _, err = scrape(queueProxyURL, r.Header, sc.logger); err != nil {
+		if queueProxy, queueProxyCancel, _, err = scrape(nil, queueProxyURL, r.Header, sc.logger); err != nil {
 			sc.logger.Error("failed scraping queue proxy metrics", zap.Error(err))
+			failed = true
 		}
 	}
 
-	// Scrape app metrics if defined
-	if sc.AppPort != "" {
-		containerURL := getURL(sc.AppPort, sc.AppPath)
-		var contentType string
-		if application, appCancel, contentType, err = scrape(containerURL, r.Header, sc.logger); err != nil {
-			sc.logger.Error("failed scraping application metrics", zap.Error(err), zap.String("content type", contentType))
-		}
+	// Scrape app metrics if defined, all the sources at once so they share the scrape timeout
+	sources, err := parseAppSources(sc.AppPort, sc.AppPath)
+	if err != nil {
+		sc.logger.Error("invalid application metrics sources", zap.Error(err))
+		failed = true
+	}
+	sourceMetrics := make([]map[string]*ioprometheusclient.MetricFamily, len(sources))
+	sourceFailed := make([]bool, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source AppSource) {
+			defer wg.Done()
+			mfs, err := scrapeAppSource(sc.appClient, sc.AppScheme, source, r.Header, sc.logger)
+			if err != nil {
+				sc.logger.Error("failed scraping application metrics", zap.Error(err), zap.String("source", source.Name))
+				sourceFailed[i] = true
+			}
+			sourceMetrics[i] = mfs
+		}(i, source)
 	}
+	wg.Wait()
+	failed = failed || slices.Contains(sourceFailed, true)
 
 	// Since we convert the scraped metrics to text, set the format as text even if
 	// the content type is originally open metrics.
@@ -281,19 +413,24 @@ func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Reque
 		_, err = io.Copy(w, queueProxy)
 		if err != nil {
 			sc.logger.Error("failed to scraping and writing queue proxy metrics", zap.Error(err))
+			failed = true
 		}
 	}
 
-	if application != nil {
-		var err error
-		var parser expfmt.TextParser
-		var mfs map[string]*ioprometheusclient.MetricFamily
-		mfs, err = parser.TextToMetricFamilies(application)
-		if err != nil {
-			sc.logger.Error("error converting text to metric families", zap.Error(err), zap.Any("metric families return value", mfs))
+	// The metrics of a single source are written as they are, the ones of several sources are labeled with their
+	// source and merged by name
+	mfs := make(map[string]*ioprometheusclient.MetricFamily)
+	for i, sourceMfs := range sourceMetrics {
+		sourceLabel := ""
+		if len(sources) > 1 {
+			sourceLabel = sources[i].Name
 		}
+		mergeMetricFamilies(mfs, sourceMfs, sourceLabel, sc.logger)
+	}
+	if len(mfs) > 0 {
 		if err = scrapeAndWriteAppMetrics(mfs, w, format, sc.logger); err != nil {
 			sc.logger.Error("failed scraping and writing metrics", zap.Error(err))
+			failed = true
 		}
 	}
 }
@@ -309,13 +446,51 @@ func main() {
 		os.Getenv(ContainerPrometheusMetricsPortEnvVarKey),
 		os.Getenv(ContainerPrometheusMetricsPathEnvVarKey),
 	)
-	mux.HandleFunc(`/metrics`, sc.handleStats)
+	if _, err := parseAppSources(sc.AppPort, sc.AppPath); err != nil {
+		zapLogger.Error("invalid application metrics sources", zap.Error(err))
+		os.Exit(1)
+	}
+	sc.AppScheme = os.Getenv(ContainerPrometheusMetricsSchemeEnvVarKey)
+	appClient, err := newAppClient(os.Getenv(ContainerPrometheusMetricsCAFileEnvVarKey))
+	if err != nil {
+		zapLogger.Error("failed to create application metrics client", zap.Error(err))
+		os.Exit(1)
+	}
+	sc.appClient = appClient
+
+	handler := sc.handleStats
+	// Serve the same merged metrics to the scrapes within the TTL, e.g. "2s"
+	if ttl := os.Getenv(AggregatePrometheusMetricsCacheTTLEnvVarKey); ttl != "" {
+		cacheTTL, err := time.ParseDuration(ttl)
+		if err != nil {
+			zapLogger.Error("invalid metrics cache TTL", zap.Error(err), zap.String("ttl", ttl))
+			os.Exit(1)
+		}
+		if cacheTTL > 0 {
+			handler = newMetricsCache(handler, cacheTTL).handle
+		}
+	}
+	if tokenFile := os.Getenv(AggregatePrometheusMetricsTokenFileEnvVarKey); tokenFile != "" {
+		handler = requireBearerToken(handler, tokenFile, zapLogger)
+	}
+	mux.HandleFunc(`/metrics`, handler)
 	l, err := net.Listen("tcp", fmt.Sprintf(":%v", aggregateMetricsPort))
 	if err != nil {
 		zapLogger.Error("error listening on status port", zap.Error(err))
 		return
 	}
 
+	// Serve the aggregate metrics over TLS when a certificate is set
+	if certFile := os.Getenv(AggregatePrometheusMetricsTLSCertFileEnvVarKey); certFile != "" {
+		tlsConfig, err := newServerTLSConfig(certFile, os.Getenv(AggregatePrometheusMetricsTLSKeyFileEnvVarKey),
+			os.Getenv(AggregatePrometheusMetricsClientCAFileEnvVarKey))
+		if err != nil {
+			zapLogger.Error("invalid TLS configuration of the stats server", zap.Error(err))
+			os.Exit(1)
+		}
+		l = tls.NewListener(l, tlsConfig)
+	}
+
 	errCh := make(chan error)
 	go func() {
 		zapLogger.Info(fmt.Sprintf("Starting stats server on port %v", aggregateMetricsPort))
@@ -105,7 +105,7 @@ func TestScrapeHeaders(t *testing.T) {
 			req := &http.Request{
 				Header: map[string][]string{timeoutHeader: {test.headerVal}},
 			}
-			queueProxy, queueProxyCancel, _, err := scrape(url, req.Header, zapLogger)
+			queueProxy, queueProxyCancel, _, err := scrape(nil, url, req.Header, zapLogger)
 			assert.NoError(t, err)
 			assert.NotNil(t, queueProxy)
 			if test.expectNilCancel {
@@ -131,7 +131,7 @@ func TestScrapeErr(t *testing.T) {
 	url := "not-a-real-url"
 
 	req := &http.Request{}
-	queueProxy, _, _, err := scrape(url, req.Header, zapLogger)
+	queueProxy, _, _, err := scrape(nil, url, req.Header, zapLogger)
 	assert.Error(t, err)
 	assert.Nil(t, queueProxy)
 }
@@ -431,3 +431,118 @@ func TestHandleStatsErr(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAppSources(t *testing.T) {
+	tests := []struct {
+		name      string
+		ports     string
+		paths     string
+		expected  []AppSource
+		expectErr bool
+	}{
+		{
+			name:  "single port",
+			ports: "8080",
+			paths: "/metrics",
+			expected: []AppSource{
+				{Name: "8080", Port: "8080", Path: "/metrics"},
+			},
+		},
+		{
+			name:  "named ports sharing a path",
+			ports: "engine=8080, router=8081",
+			paths: "/metrics",
+			expected: []AppSource{
+				{Name: "engine", Port: "8080", Path: "/metrics"},
+				{Name: "router", Port: "8081", Path: "/metrics"},
+			},
+		},
+		{
+			name:  "a path per port",
+			ports: "8080,adapters=9090",
+			paths: "/metrics,/stats/prometheus",
+			expected: []AppSource{
+				{Name: "8080", Port: "8080", Path: "/metrics"},
+				{Name: "adapters", Port: "9090", Path: "/stats/prometheus"},
+			},
+		},
+		{
+			name: "no port",
+		},
+		{
+			name:      "paths not matching the ports",
+			ports:     "8080,8081,8082",
+			paths:     "/metrics,/metrics",
+			expectErr: true,
+		},
+		{
+			name:      "invalid port",
+			ports:     "engine=http",
+			expectErr: true,
+		},
+		{
+			name:      "duplicate source",
+			ports:     "engine=8080,engine=8081",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sources, err := parseAppSources(test.ports, test.paths)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, sources)
+		})
+	}
+}
+
+func TestHandleStatsMultipleSources(t *testing.T) {
+	engineMetrics := `# TYPE num_requests_running gauge
+num_requests_running{model_name="llama"} 2
+# TYPE process_open_fds gauge
+process_open_fds 10
+`
+	routerMetrics := `# TYPE router_requests_total counter
+router_requests_total 5
+# TYPE process_open_fds gauge
+process_open_fds 20
+`
+	conflictingMetrics := `# TYPE process_open_fds counter
+process_open_fds 30
+`
+	setEnvVars(t)
+
+	newServer := func(body string) (*httptest.Server, string) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(body))
+			assert.NoError(t, err)
+		}))
+		t.Cleanup(server.Close)
+		return server, strings.Split(server.URL, ":")[2]
+	}
+	_, enginePort := newServer(engineMetrics)
+	_, routerPort := newServer(routerMetrics)
+	_, adaptersPort := newServer(conflictingMetrics)
+
+	zapLogger := initializeLogger()
+	sc := NewScrapeConfigs(zapLogger, "", "engine="+enginePort+",router="+routerPort+",adapters="+adaptersPort, "/metrics")
+	rec := httptest.NewRecorder()
+	sc.handleStats(rec, &http.Request{})
+	assert.Equal(t, 200, rec.Code)
+
+	// The families of the same name are merged, the one with another type is discarded
+	parser := expfmt.TextParser{}
+	mfMap, err := parser.TextToMetricFamilies(strings.NewReader(rec.Body.String()))
+	assert.NoError(t, err)
+	assert.Len(t, mfMap, 3)
+	assert.Len(t, mfMap["process_open_fds"].Metric, 2)
+	assert.Contains(t, rec.Body.String(),
+		`num_requests_running{model_name="llama",metrics_source="engine",service_name="something",configuration_name="something",revision_name="something"} 2`)
+	assert.Contains(t, rec.Body.String(),
+		`router_requests_total{metrics_source="router",service_name="something",configuration_name="something",revision_name="something"} 5`)
+	assert.NotContains(t, rec.Body.String(), `metrics_source="adapters"`)
+}
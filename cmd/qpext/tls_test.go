@@ -0,0 +1,173 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate writes a self-signed certificate of localhost, valid for servers and clients, and its key
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func TestRequireBearerToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret-token\n"), 0600))
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tests := []struct {
+		name          string
+		tokenFile     string
+		authorization string
+		expectedCode  int
+	}{
+		{"valid token", tokenFile, "Bearer secret-token", http.StatusOK},
+		{"missing token", tokenFile, "", http.StatusUnauthorized},
+		{"wrong token", tokenFile, "Bearer other-token", http.StatusUnauthorized},
+		{"basic auth", tokenFile, "Basic c2VjcmV0LXRva2Vu", http.StatusUnauthorized},
+		{"missing token file", filepath.Join(t.TempDir(), "missing"), "Bearer secret-token", http.StatusInternalServerError},
+	}
+	zapLogger := initializeLogger()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rec := httptest.NewRecorder()
+			requireBearerToken(next, test.tokenFile, zapLogger)(rec, req)
+			assert.Equal(t, test.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestScrapeHTTPSApp(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+
+	app := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte("# TYPE my_metric counter\nmy_metric 1\n"))
+		assert.NoError(t, err)
+	}))
+	app.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	app.StartTLS()
+	defer app.Close()
+	appPort := strings.Split(app.URL, ":")[2]
+
+	// The certificate of the application is only trusted with the CA file
+	client, err := newAppClient(certFile)
+	require.NoError(t, err)
+	zapLogger := initializeLogger()
+	for _, test := range []struct {
+		name     string
+		client   *http.Client
+		expected bool
+	}{
+		{"custom CA", client, true},
+		{"system CAs", nil, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sc := NewScrapeConfigs(zapLogger, "", appPort, "/metrics")
+			sc.AppScheme = "https"
+			sc.appClient = test.client
+			rec := httptest.NewRecorder()
+			sc.handleStats(rec, &http.Request{})
+			assert.Equal(t, test.expected, strings.Contains(rec.Body.String(), "my_metric"))
+		})
+	}
+
+	_, err = newAppClient(keyFile)
+	assert.Error(t, err)
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+	pool, err := loadCertPool(certFile)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		clientCAFile string
+		clientCert   bool
+		expectErr    bool
+	}{
+		{name: "server certificate"},
+		{name: "client certificate", clientCAFile: certFile, clientCert: true},
+		{name: "missing client certificate", clientCAFile: certFile, expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := newServerTLSConfig(certFile, keyFile, test.clientCAFile)
+			require.NoError(t, err)
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})}
+			go func() { _ = server.Serve(tls.NewListener(l, config)) }()
+			defer server.Close()
+
+			clientConfig := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+			if test.clientCert {
+				clientConfig.Certificates = []tls.Certificate{cert}
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+			port := strings.Split(l.Addr().String(), ":")[1]
+			resp, err := client.Get(getSchemeURL("https", port, "/metrics"))
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+
+	_, err = newServerTLSConfig(certFile, filepath.Join(t.TempDir(), "missing.key"), "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"maps"
+	"slices"
+
+	spec "k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// jsonSchema returns the standalone JSON Schema of the objects of the CRD kind, with the definitions it refers to.
+// The types that are not OME types, like the Kubernetes types, are accepted without validation.
+func jsonSchema(defs spec.Definitions, kind string) spec.Schema {
+	schema := defs[kind]
+	schema.Schema = jsonSchemaDraft
+	schema.Title = displayName(kind)
+	schema.Required = append(slices.Clone(schema.Required), "apiVersion", "kind")
+
+	// The properties are shared with the other outputs
+	schema.Properties = maps.Clone(schema.Properties)
+	schema.Properties["apiVersion"] = withEnum(schema.Properties["apiVersion"], groupVersion(kind))
+	schema.Properties["kind"] = withEnum(schema.Properties["kind"], displayName(kind))
+
+	schema.Definitions = spec.Definitions{}
+	for _, name := range reachable(defs, kind) {
+		for _, ref := range refs(defs[name]) {
+			if _, ok := defs[ref]; ok {
+				schema.Definitions[ref] = defs[ref]
+			} else {
+				schema.Definitions[ref] = spec.Schema{SchemaProps: spec.SchemaProps{
+					Description: ref + ", not validated by this schema.",
+				}}
+			}
+		}
+	}
+	return schema
+}
+
+func withEnum(schema spec.Schema, value string) spec.Schema {
+	schema.Enum = []interface{}{value}
+	return schema
+}
@@ -1,35 +1,113 @@
+// Command spec-gen generates the specifications of the OME API from the OpenAPI definitions of pkg/openapi: the
+// Swagger document of the Python SDK, and for every CRD a markdown reference and a standalone JSON Schema.
+//
+//	spec-gen [--formats swagger,markdown,jsonschema] [--output-dir DIR] [VERSION]
+//
+// The Swagger document of VERSION is printed to stdout, or written to DIR/swagger.json with --output-dir. The
+// markdown references are written to DIR/markdown/<kind>.md and the JSON Schemas to DIR/jsonschema/<kind>_v1beta1.json,
+// the layout kubeconform and the YAML language server look schemas up with.
 package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kube-openapi/pkg/common"
 	spec "k8s.io/kube-openapi/pkg/validation/spec"
 
+	"github.com/sgl-project/ome/pkg/constants"
 	omev1beta1 "github.com/sgl-project/ome/pkg/openapi"
 )
 
-// Generate OpenAPI spec definitions for InferenceService Resource
+const (
+	formatSwagger    = "swagger"
+	formatMarkdown   = "markdown"
+	formatJSONSchema = "jsonschema"
+
+	definitionsPrefix = "#/definitions/"
+)
+
+var formats = []string{formatSwagger, formatMarkdown, formatJSONSchema}
+
 func main() {
-	if len(os.Args) <= 1 {
-		klog.Fatal("Supply a version")
+	selected := flag.String("formats", formatSwagger, "comma separated output formats, of "+strings.Join(formats, ", "))
+	outputDir := flag.String("output-dir", "", "directory to write the outputs to, required by the markdown and jsonschema formats")
+	flag.Parse()
+	if err := run(strings.Split(*selected, ","), *outputDir, flag.Arg(0)); err != nil {
+		klog.Fatal(err.Error())
 	}
-	version := os.Args[1]
-	if !strings.HasPrefix(version, "v") {
-		version = "v" + version
+}
+
+func run(selected []string, outputDir string, version string) error {
+	for _, format := range selected {
+		if !slices.Contains(formats, format) {
+			return fmt.Errorf("unknown format %q, must be one of %s", format, strings.Join(formats, ", "))
+		}
+		if format != formatSwagger && outputDir == "" {
+			return fmt.Errorf("the %s format requires --output-dir", format)
+		}
+	}
+	defs := definitions()
+	if slices.Contains(selected, formatSwagger) {
+		if version == "" {
+			return fmt.Errorf("supply a version")
+		}
+		data, err := json.MarshalIndent(swagger(defs, version), "", "  ")
+		if err != nil {
+			return err
+		}
+		if outputDir == "" {
+			fmt.Println(string(data))
+		} else if err := writeFile(filepath.Join(outputDir, "swagger.json"), append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	for _, kind := range crdKinds(defs) {
+		if slices.Contains(selected, formatMarkdown) {
+			path := filepath.Join(outputDir, formatMarkdown, strings.ToLower(displayName(kind))+".md")
+			if err := writeFile(path, []byte(markdown(defs, kind))); err != nil {
+				return err
+			}
+		}
+		if slices.Contains(selected, formatJSONSchema) {
+			data, err := json.MarshalIndent(jsonSchema(defs, kind), "", "  ")
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(outputDir, formatJSONSchema, strings.ToLower(displayName(kind))+"_"+apiVersion(kind)+".json")
+			if err := writeFile(path, append(data, '\n')); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
+
+// definitions returns the OpenAPI definitions by their Swagger name
+func definitions() spec.Definitions {
 	oAPIDefs := omev1beta1.GetOpenAPIDefinitions(func(name string) spec.Ref {
-		return spec.MustCreateRef("#/definitions/" + common.EscapeJsonPointer(swaggify(name)))
+		return spec.MustCreateRef(definitionsPrefix + common.EscapeJsonPointer(swaggify(name)))
 	})
 	defs := spec.Definitions{}
 	for defName, val := range oAPIDefs {
 		defs[swaggify(defName)] = val.Schema
 	}
-	swagger := spec.Swagger{
+	return defs
+}
+
+// swagger returns the Swagger document of the definitions, which the Python SDK is generated from
+func swagger(defs spec.Definitions, version string) spec.Swagger {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return spec.Swagger{
 		SwaggerProps: spec.SwaggerProps{
 			Swagger:     "2.0",
 			Definitions: defs,
@@ -43,11 +121,92 @@ func main() {
 			},
 		},
 	}
-	jsonBytes, err := json.MarshalIndent(swagger, "", "  ")
-	if err != nil {
-		klog.Fatal(err.Error())
+}
+
+// crdKinds returns the definitions of the CRDs, the kinds that have a list
+func crdKinds(defs spec.Definitions) []string {
+	var kinds []string
+	for name, def := range defs {
+		if _, ok := defs[name+"List"]; ok {
+			if _, ok := def.Properties["kind"]; ok {
+				kinds = append(kinds, name)
+			}
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// reachable returns the definitions the definition refers to, directly or not, including itself, breadth first in the
+// order of the properties. The references to types that are not defined, like the Kubernetes types, are not followed.
+func reachable(defs spec.Definitions, name string) []string {
+	names := []string{name}
+	for i := 0; i < len(names); i++ {
+		for _, ref := range refs(defs[names[i]]) {
+			if _, ok := defs[ref]; ok && !slices.Contains(names, ref) {
+				names = append(names, ref)
+			}
+		}
+	}
+	return names
+}
+
+// refs returns the names of the definitions the schema refers to, in the order of its properties
+func refs(schema spec.Schema) []string {
+	var names []string
+	if ref := schema.Ref.String(); ref != "" {
+		names = append(names, strings.TrimPrefix(ref, definitionsPrefix))
+	}
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	for _, property := range properties {
+		names = append(names, refs(schema.Properties[property])...)
+	}
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			names = append(names, refs(*schema.Items.Schema)...)
+		}
+		for _, item := range schema.Items.Schemas {
+			names = append(names, refs(item)...)
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		names = append(names, refs(*schema.AdditionalProperties.Schema)...)
+	}
+	for _, composed := range [][]spec.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, s := range composed {
+			names = append(names, refs(s)...)
+		}
+	}
+	return names
+}
+
+// displayName returns the name of the Go type of the definition, without its package
+func displayName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// apiVersion returns the API version of the definition, the version of its package
+func apiVersion(name string) string {
+	return strings.TrimSuffix(name, "."+displayName(name))
+}
+
+// groupVersion returns the apiVersion of the objects of the CRD
+func groupVersion(kind string) string {
+	return constants.OMEAPIGroupName + "/" + apiVersion(kind)
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
 	}
-	fmt.Println(string(jsonBytes))
+	return nil
 }
 
 func swaggify(name string) string {
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRDKinds(t *testing.T) {
+	assert.Equal(t, []string{
+		"v1beta1.AcceleratorClass",
+		"v1beta1.BaseModel",
+		"v1beta1.BenchmarkJob",
+		"v1beta1.ClusterBaseModel",
+		"v1beta1.ClusterServingRuntime",
+		"v1beta1.FineTunedWeight",
+		"v1beta1.InferenceService",
+		"v1beta1.ServingRuntime",
+	}, crdKinds(definitions()))
+}
+
+func TestRunFormats(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, run([]string{formatSwagger, formatMarkdown, formatJSONSchema}, dir, "0.1"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "swagger.json"))
+	require.NoError(t, err)
+	var swaggerDoc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &swaggerDoc))
+	assert.Equal(t, "v0.1", swaggerDoc["info"].(map[string]interface{})["version"])
+
+	data, err = os.ReadFile(filepath.Join(dir, "markdown", "inferenceservice.md"))
+	require.NoError(t, err)
+	doc := string(data)
+	assert.Contains(t, doc, "# InferenceService\n\n`apiVersion: ome.io/v1beta1`\n\n## InferenceService\n")
+	assert.Contains(t, doc, "| `spec` | [InferenceServiceSpec](#inferenceservicespec) |")
+	assert.Contains(t, doc, "| `metadata` | `v1.ObjectMeta` |")
+	assert.Contains(t, doc, "\n## InferenceServiceSpec\n")
+	assert.NotContains(t, doc, "\n## BenchmarkJobSpec\n")
+
+	data, err = os.ReadFile(filepath.Join(dir, "jsonschema", "inferenceservice_v1beta1.json"))
+	require.NoError(t, err)
+	var schema struct {
+		Schema      string                            `json:"$schema"`
+		Required    []string                          `json:"required"`
+		Properties  map[string]map[string]interface{} `json:"properties"`
+		Definitions map[string]map[string]interface{} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, jsonSchemaDraft, schema.Schema)
+	assert.Subset(t, schema.Required, []string{"apiVersion", "kind"})
+	assert.Equal(t, []interface{}{"ome.io/v1beta1"}, schema.Properties["apiVersion"]["enum"])
+	assert.Equal(t, []interface{}{"InferenceService"}, schema.Properties["kind"]["enum"])
+	assert.Equal(t, "#/definitions/v1beta1.InferenceServiceSpec", schema.Properties["spec"]["$ref"])
+	assert.Contains(t, schema.Definitions, "v1beta1.InferenceServiceSpec")
+	assert.Equal(t, "v1.ObjectMeta, not validated by this schema.", schema.Definitions["v1.ObjectMeta"]["description"])
+	assert.NotContains(t, schema.Definitions, "v1beta1.BenchmarkJobSpec")
+
+	for _, kind := range crdKinds(definitions()) {
+		assert.FileExists(t, filepath.Join(dir, "markdown", strings.ToLower(displayName(kind))+".md"))
+	}
+}
+
+func TestJSONSchemaKeepsDefinitions(t *testing.T) {
+	defs := definitions()
+	jsonSchema(defs, "v1beta1.InferenceService")
+	isvc := defs["v1beta1.InferenceService"]
+	assert.Empty(t, isvc.Properties["kind"].Enum)
+	assert.NotContains(t, isvc.Required, "kind")
+	assert.Empty(t, isvc.Definitions)
+}
+
+func TestRunErrors(t *testing.T) {
+	assert.EqualError(t, run([]string{"yaml"}, t.TempDir(), "0.1"), `unknown format "yaml", must be one of swagger, markdown, jsonschema`)
+	assert.EqualError(t, run([]string{formatMarkdown}, "", ""), "the markdown format requires --output-dir")
+	assert.EqualError(t, run([]string{formatSwagger}, "", ""), "supply a version")
+	assert.NoError(t, run([]string{formatJSONSchema}, t.TempDir(), ""))
+}
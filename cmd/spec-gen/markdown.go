@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	spec "k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// markdown returns the reference of the CRD kind: a table of the fields of the kind, then of every OME type it refers
+// to, in the order they are first referred to.
+func markdown(defs spec.Definitions, kind string) string {
+	names := reachable(defs, kind)
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", displayName(kind))
+	fmt.Fprintf(&b, "`apiVersion: %s`\n", groupVersion(kind))
+	for _, name := range names {
+		def := defs[name]
+		fmt.Fprintf(&b, "\n## %s\n\n", displayName(name))
+		if def.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", def.Description)
+		}
+		if len(def.Properties) == 0 {
+			fmt.Fprintf(&b, "Type: %s\n", markdownType(def, names))
+			continue
+		}
+		properties := make([]string, 0, len(def.Properties))
+		for property := range def.Properties {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		b.WriteString("| Field | Type | Required | Description |\n")
+		b.WriteString("|-------|------|----------|-------------|\n")
+		for _, property := range properties {
+			schema := def.Properties[property]
+			required := ""
+			if slices.Contains(def.Required, property) {
+				required = "yes"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", property, markdownType(schema, names), required, tableCell(schema.Description))
+		}
+	}
+	return b.String()
+}
+
+// markdownType returns the type of the schema, linked to its section when it is one of the documented types
+func markdownType(schema spec.Schema, documented []string) string {
+	if ref := schema.Ref.String(); ref != "" {
+		name := strings.TrimPrefix(ref, definitionsPrefix)
+		if slices.Contains(documented, name) {
+			return fmt.Sprintf("[%s](#%s)", displayName(name), strings.ToLower(displayName(name)))
+		}
+		return "`" + name + "`"
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		return "[]" + markdownType(*schema.Items.Schema, documented)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		return "map[string]" + markdownType(*schema.AdditionalProperties.Schema, documented)
+	}
+	if len(schema.Type) == 0 {
+		return "any"
+	}
+	t := strings.Join(schema.Type, ", ")
+	if schema.Format != "" {
+		t += " (" + schema.Format + ")"
+	}
+	return t
+}
+
+// tableCell escapes the text for a cell of a markdown table, which holds a single line
+func tableCell(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "|", `\|`)
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
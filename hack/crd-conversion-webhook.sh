@@ -0,0 +1,30 @@
+#!/bin/bash
+# Adds the conversion webhook of the manager and the CA injection of cert-manager to the CRDs served in more than one
+# version, for the Helm chart. The kustomize manifests patch them instead, see config/default.
+set -eu -o pipefail
+
+cd "$(dirname "$0")/.."
+
+namespace="$1"
+shift
+
+for file in "$@"; do
+  if grep -q '^  conversion:' "$file"; then
+    continue
+  fi
+  echo "Adding conversion webhook to CRD file: ${file}"
+  perl -0pi -e "s|^  annotations:\n|  annotations:\n    cert-manager.io/inject-ca-from: ${namespace}/serving-cert\n|m" "$file"
+  cat >>"$file" <<EOF
+  conversion:
+    strategy: Webhook
+    webhook:
+      clientConfig:
+        caBundle: Cg==
+        service:
+          name: ome-webhook-server-service
+          namespace: ${namespace}
+          path: /convert
+      conversionReviewVersions:
+      - v1
+EOF
+done
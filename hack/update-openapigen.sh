@@ -64,7 +64,7 @@ if ! diff $CURRENT_VIOLATION_EXCEPTIONS $KNOWN_VIOLATION_EXCEPTIONS > /dev/null;
 fi
 
 # Generating swagger file
-go run cmd/spec-gen/main.go 0.1 > pkg/openapi/swagger.json 2>&1
+go run ./cmd/spec-gen 0.1 > pkg/openapi/swagger.json 2>&1
 
 # Return to the original directory
 if [[ "$CURRENT_DIR" != "$TARGET_DIR" ]]; then
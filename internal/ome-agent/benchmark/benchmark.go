@@ -0,0 +1,399 @@
+// Package benchmark implements the benchmark agent launched by the BenchmarkJob controller. It generates load
+// against an OpenAI compatible endpoint for every traffic scenario and concurrency level, measures the latency of
+// the streamed tokens and writes the results to the experiment folder, uploading them to Object Storage if asked.
+//
+// The agent accepts the flags of genai-bench, so the controller builds the same arguments for either client:
+//
+//	ome-agent benchmark \
+//	  --config /ome-agent.yaml \
+//	  --api-backend openai \
+//	  --api-base http://llama-7b.default.svc.cluster.local \
+//	  --task text-to-text \
+//	  --traffic-scenario "D(100,100)" \
+//	  --num-concurrency 1 --num-concurrency 8 \
+//	  --max-time-per-run 10 \
+//	  --max-requests-per-run 100 \
+//	  --experiment-folder-name my-benchmark
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/ociobjectstore"
+	"github.com/sgl-project/ome/pkg/principals"
+)
+
+const (
+	// SummaryFileName and RecordsFileName match the result files the BenchmarkJob controller reports in its status
+	SummaryFileName = "summary.json"
+	RecordsFileName = "requests.jsonl"
+
+	// maxMetricsReportSize is the size limit of a container termination message
+	maxMetricsReportSize = 4096
+)
+
+// uploader uploads a result file to Object Storage
+type uploader interface {
+	Upload(source string, target ociobjectstore.ObjectURI) error
+}
+
+// Summary is the content of the summary file of an experiment
+type Summary struct {
+	Model      string             `json:"model,omitempty"`
+	Task       string             `json:"task"`
+	Server     *ServerMetadata    `json:"server,omitempty"`
+	StartTime  time.Time          `json:"start_time"`
+	EndTime    time.Time          `json:"end_time"`
+	Iterations []IterationSummary `json:"iterations"`
+}
+
+// ServerMetadata describes the serving deployment that was benchmarked
+type ServerMetadata struct {
+	Engine   string `json:"engine,omitempty"`
+	GPUType  string `json:"gpu_type,omitempty"`
+	Version  string `json:"version,omitempty"`
+	GPUCount int    `json:"gpu_count,omitempty"`
+}
+
+type metricsReport struct {
+	Iterations []v1beta1.IterationMetrics `json:"iterations"`
+	// Truncated is set when the last iterations were left out to fit the report in a termination message
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// BenchmarkAgent runs the iterations of a benchmark and exports their results
+type BenchmarkAgent struct {
+	config   *Config
+	logger   logging.Interface
+	client   *OpenAIClient
+	uploader uploader
+}
+
+// NewBenchmarkAgent creates a benchmark agent from a validated configuration
+func NewBenchmarkAgent(config *Config) (*BenchmarkAgent, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	agent := &BenchmarkAgent{
+		config: config,
+		logger: config.Logger,
+		client: NewOpenAIClient(config.APIBase, config.APIModelName, config.APIKey, !config.NoStream,
+			time.Duration(config.RequestTimeout)*time.Second),
+	}
+	if config.UploadResults {
+		auth, _ := authType(config.Auth)
+		dataStore, err := ociobjectstore.NewOCIOSDataStore(&ociobjectstore.Config{
+			AnotherLogger: config.Logger,
+			Name:          "benchmark-results",
+			AuthType:      &auth,
+			Region:        config.Region,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create object storage client: %w", err)
+		}
+		agent.uploader = dataStore
+	}
+	if config.ModelTokenizer != "" {
+		agent.logger.Warnf("Ignoring tokenizer %s, prompt lengths are approximated by word count", config.ModelTokenizer)
+	}
+	return agent, nil
+}
+
+// Start runs the warmup and every iteration, then writes and uploads the results
+func (a *BenchmarkAgent) Start() error {
+	scenarios, concurrencies, err := a.plan()
+	if err != nil {
+		return err
+	}
+
+	outputDir := filepath.Join(a.config.ExperimentBaseDir, a.config.ExperimentFolderName)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create experiment folder %s: %w", outputDir, err)
+	}
+
+	summary := &Summary{
+		Model:     a.config.APIModelName,
+		Task:      a.config.Task,
+		StartTime: time.Now().UTC(),
+	}
+	if a.config.ServerEngine != "" {
+		summary.Server = &ServerMetadata{
+			Engine:   a.config.ServerEngine,
+			GPUType:  a.config.ServerGPUType,
+			Version:  a.config.ServerVersion,
+			GPUCount: a.config.ServerGPUCount,
+		}
+	}
+
+	a.warmup(scenarios[0], concurrencies[0])
+
+	var iterations []*Iteration
+	for _, scenario := range scenarios {
+		for _, concurrency := range concurrencies {
+			a.logger.Infof("Running scenario %s at concurrency %d", scenario.Name, concurrency)
+			iteration := a.runIteration(scenario, concurrency)
+			s := iteration.Summary()
+			a.logger.Infof("Completed %d requests with %d errors in %.1fs, output throughput %.1f tokens/s",
+				s.NumRequests, s.NumErrors, s.Duration, s.OutputThroughput)
+			if s.NumRequests == 0 {
+				return fmt.Errorf("no request succeeded for scenario %s at concurrency %d: %s",
+					scenario.Name, concurrency, firstError(iteration.Records))
+			}
+			iterations = append(iterations, iteration)
+			summary.Iterations = append(summary.Iterations, s)
+		}
+	}
+	summary.EndTime = time.Now().UTC()
+
+	files, err := a.writeResults(outputDir, summary, iterations)
+	if err != nil {
+		return err
+	}
+	if a.config.MetricsReportPath != "" {
+		if err := a.writeMetricsReport(iterations); err != nil {
+			return err
+		}
+	}
+	if a.uploader != nil {
+		return a.uploadResults(files)
+	}
+	return nil
+}
+
+// plan returns the scenarios and concurrency levels to run, defaulting to a single iteration
+func (a *BenchmarkAgent) plan() ([]*Scenario, []int, error) {
+	names := a.config.TrafficScenarios
+	if len(names) == 0 {
+		names = []string{DefaultScenario}
+	}
+	scenarios := make([]*Scenario, 0, len(names))
+	for _, name := range names {
+		scenario, err := ParseScenario(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		scenarios = append(scenarios, scenario)
+	}
+
+	concurrencies := a.config.NumConcurrency
+	if len(concurrencies) == 0 {
+		concurrencies = []int{1}
+	}
+	return scenarios, concurrencies, nil
+}
+
+// warmup sends requests until the warmup duration elapses or the warmup requests are sent, discarding them
+func (a *BenchmarkAgent) warmup(scenario *Scenario, concurrency int) {
+	if a.config.WarmupDuration <= 0 && a.config.WarmupRequests <= 0 {
+		return
+	}
+	ctx := context.Background()
+	if a.config.WarmupDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(a.config.WarmupDuration)*time.Second)
+		defer cancel()
+	}
+	a.logger.Infof("Warming up with scenario %s at concurrency %d", scenario.Name, concurrency)
+	records := a.sendRequests(ctx, scenario, concurrency, a.config.WarmupRequests)
+	a.logger.Infof("Warmup sent %d requests", len(records))
+}
+
+// runIteration sends requests until the maximum time or the maximum number of requests per run is reached
+func (a *BenchmarkAgent) runIteration(scenario *Scenario, concurrency int) *Iteration {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(a.config.MaxTimePerRun)*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	records := a.sendRequests(ctx, scenario, concurrency, a.config.MaxRequestsPerRun)
+	iteration := &Iteration{
+		Scenario:    scenario.Name,
+		Concurrency: concurrency,
+		Duration:    time.Since(start),
+	}
+	for _, r := range records {
+		r.Scenario = scenario.Name
+		r.Concurrency = concurrency
+		iteration.Records = append(iteration.Records, r)
+	}
+	return iteration
+}
+
+// sendRequests keeps concurrency requests in flight until maxRequests are sent or the context is done. A
+// maxRequests of zero doesn't limit the number of requests. Requests interrupted by the end of the context are
+// dropped.
+func (a *BenchmarkAgent) sendRequests(ctx context.Context, scenario *Scenario, concurrency, maxRequests int) []RequestRecord {
+	var (
+		sent    atomic.Int64
+		mu      sync.Mutex
+		records []RequestRecord
+		wg      sync.WaitGroup
+	)
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for ctx.Err() == nil {
+				if maxRequests > 0 && sent.Add(1) > int64(maxRequests) {
+					return
+				}
+				in, out := scenario.Sample(rng)
+				record := a.client.Send(ctx, in, out)
+				if record.Error != "" && ctx.Err() != nil {
+					return
+				}
+				mu.Lock()
+				records = append(records, record)
+				mu.Unlock()
+			}
+		}(time.Now().UnixNano() + int64(worker))
+	}
+	wg.Wait()
+	return records
+}
+
+// writeResults writes the summary and, if asked, the request records to the output folder, and returns the
+// written files
+func (a *BenchmarkAgent) writeResults(outputDir string, summary *Summary, iterations []*Iteration) ([]string, error) {
+	summaryPath := filepath.Join(outputDir, SummaryFileName)
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal summary: %w", err)
+	}
+	if err := os.WriteFile(summaryPath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write summary: %w", err)
+	}
+	files := []string{summaryPath}
+
+	if a.config.SaveRequestRecords {
+		recordsPath := filepath.Join(outputDir, RecordsFileName)
+		f, err := os.Create(recordsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request records: %w", err)
+		}
+		encoder := json.NewEncoder(f)
+		for _, iteration := range iterations {
+			for _, record := range iteration.Records {
+				if err := encoder.Encode(record); err != nil {
+					_ = f.Close()
+					return nil, fmt.Errorf("failed to write request records: %w", err)
+				}
+			}
+		}
+		if err := f.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write request records: %w", err)
+		}
+		files = append(files, recordsPath)
+	}
+
+	a.logger.Infof("Wrote benchmark results to %s", outputDir)
+	return files, nil
+}
+
+// writeMetricsReport writes the metrics of the iterations to the metrics report path
+func (a *BenchmarkAgent) writeMetricsReport(iterations []*Iteration) error {
+	var metrics []v1beta1.IterationMetrics
+	for _, iteration := range iterations {
+		metrics = append(metrics, iteration.Metrics())
+	}
+	data, kept, err := marshalMetricsReport(metrics)
+	if err != nil {
+		return err
+	}
+	if kept < len(metrics) {
+		a.logger.Warnf("Metrics report only holds %d of %d iterations to fit in a termination message", kept, len(metrics))
+	}
+	if err := os.WriteFile(a.config.MetricsReportPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metrics report: %w", err)
+	}
+	return nil
+}
+
+// marshalMetricsReport marshals the metrics report so that it fits in a termination message: the histograms are
+// dropped first, then the last iterations, which remain in the summary of the results. It returns the number of
+// iterations kept in the report.
+func marshalMetricsReport(metrics []v1beta1.IterationMetrics) ([]byte, int, error) {
+	report := metricsReport{Iterations: metrics}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal metrics report: %w", err)
+	}
+	if len(data) <= maxMetricsReportSize {
+		return data, len(metrics), nil
+	}
+
+	for i := range report.Iterations {
+		for _, d := range []*v1beta1.LatencyDistribution{
+			report.Iterations[i].TimeToFirstToken,
+			report.Iterations[i].InterTokenLatency,
+			report.Iterations[i].EndToEndLatency,
+		} {
+			if d != nil {
+				d.Histogram = nil
+			}
+		}
+	}
+	for {
+		if data, err = json.Marshal(report); err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal metrics report: %w", err)
+		}
+		if len(data) <= maxMetricsReportSize || len(report.Iterations) == 0 {
+			return data, len(report.Iterations), nil
+		}
+		report.Iterations = report.Iterations[:len(report.Iterations)-1]
+		report.Truncated = true
+	}
+}
+
+// uploadResults uploads the result files to <storage prefix>/<experiment folder>/ in the storage bucket
+func (a *BenchmarkAgent) uploadResults(files []string) error {
+	for _, file := range files {
+		target := ociobjectstore.ObjectURI{
+			Namespace:  a.config.Namespace,
+			BucketName: a.config.StorageBucket,
+			ObjectName: path.Join(a.config.StoragePrefix, a.config.ExperimentFolderName, filepath.Base(file)),
+		}
+		if err := a.uploader.Upload(file, target); err != nil {
+			return fmt.Errorf("failed to upload %s to %s/%s: %w", file, target.BucketName, target.ObjectName, err)
+		}
+		a.logger.Infof("Uploaded %s to %s/%s", filepath.Base(file), target.BucketName, target.ObjectName)
+	}
+	return nil
+}
+
+// authType maps the OCI auth flag of genai-bench to the authentication type of the object storage client
+func authType(auth string) (principals.AuthenticationType, error) {
+	switch auth {
+	case "user_principal":
+		return principals.UserPrincipal, nil
+	case "instance_principal":
+		return principals.InstancePrincipal, nil
+	case "resource_principal":
+		return principals.ResourcePrincipal, nil
+	case "oke_workload_identity":
+		return principals.OkeWorkloadIdentity, nil
+	default:
+		return "", fmt.Errorf("unsupported auth %q, use user_principal, instance_principal, resource_principal or oke_workload_identity", auth)
+	}
+}
+
+func firstError(records []RequestRecord) string {
+	for _, r := range records {
+		if r.Error != "" {
+			return r.Error
+		}
+	}
+	return "no request was sent"
+}
@@ -0,0 +1,308 @@
+package benchmark
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/ociobjectstore"
+)
+
+type fakeUploader struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func (f *fakeUploader) Upload(source string, target ociobjectstore.ObjectURI) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[target.BucketName+"/"+target.ObjectName] = source
+	return nil
+}
+
+// newFakeEngine serves chat completions with one token per stream event and counts the requests it received
+func newFakeEngine(t *testing.T, requests *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, chatCompletionsPath, r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var req chatCompletionRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "llama", req.Model)
+		assert.True(t, req.IgnoreEOS)
+		promptTokens := len(strings.Fields(req.Messages[0].Content))
+
+		if !req.Stream {
+			_, _ = fmt.Fprintf(w, `{"choices":[{"message":{"content":"hi"}}],"usage":{"prompt_tokens":%d,"completion_tokens":%d}}`,
+				promptTokens, req.MaxTokens)
+			return
+		}
+		require.NotNil(t, req.StreamOptions)
+		flusher := w.(http.Flusher)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for i := 0; i < req.MaxTokens; i++ {
+			_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\n")
+			flusher.Flush()
+		}
+		_, _ = fmt.Fprintf(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":%d,\"completion_tokens\":%d}}\n\n",
+			promptTokens, req.MaxTokens)
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+}
+
+func newTestConfig(t *testing.T, apiBase string) *Config {
+	config, err := NewConfig(WithLogger(logging.Discard()))
+	require.NoError(t, err)
+	config.APIBase = apiBase
+	config.APIModelName = "llama"
+	config.APIKey = "secret"
+	config.TrafficScenarios = []string{"D(10,5)", "U(5,10)/(1,3)"}
+	config.NumConcurrency = []int{1, 4}
+	config.MaxTimePerRun = 1
+	config.MaxRequestsPerRun = 8
+	config.WarmupRequests = 3
+	config.ExperimentBaseDir = t.TempDir()
+	config.ExperimentFolderName = "experiment"
+	config.SaveRequestRecords = true
+	config.MetricsReportPath = filepath.Join(t.TempDir(), "termination-log")
+	return config
+}
+
+func TestBenchmarkAgent(t *testing.T) {
+	var requests atomic.Int64
+	engine := newFakeEngine(t, &requests)
+	defer engine.Close()
+
+	config := newTestConfig(t, engine.URL)
+	config.ServerEngine = "sglang"
+	agent, err := NewBenchmarkAgent(config)
+	require.NoError(t, err)
+	uploader := &fakeUploader{objects: map[string]string{}}
+	agent.uploader = uploader
+	config.StorageBucket = "results"
+	config.StoragePrefix = "benchmarks"
+
+	require.NoError(t, agent.Start())
+
+	// Warmup requests are sent but not recorded
+	assert.Equal(t, int64(3+4*8), requests.Load())
+
+	outputDir := filepath.Join(config.ExperimentBaseDir, "experiment")
+	data, err := os.ReadFile(filepath.Join(outputDir, SummaryFileName))
+	require.NoError(t, err)
+	var summary Summary
+	require.NoError(t, json.Unmarshal(data, &summary))
+	assert.Equal(t, "llama", summary.Model)
+	require.NotNil(t, summary.Server)
+	assert.Equal(t, "sglang", summary.Server.Engine)
+	require.Len(t, summary.Iterations, 4)
+	first := summary.Iterations[0]
+	assert.Equal(t, "D(10,5)", first.Scenario)
+	assert.Equal(t, 1, first.Concurrency)
+	assert.Equal(t, 8, first.NumRequests)
+	assert.Zero(t, first.NumErrors)
+	assert.Equal(t, int64(80), first.InputTokens)
+	assert.Equal(t, int64(40), first.OutputTokens)
+	assert.Positive(t, first.MeanTTFT)
+	assert.Positive(t, first.MeanITL)
+
+	f, err := os.Open(filepath.Join(outputDir, RecordsFileName))
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	records := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record RequestRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		assert.Empty(t, record.Error)
+		records++
+	}
+	assert.Equal(t, 4*8, records)
+
+	data, err = os.ReadFile(config.MetricsReportPath)
+	require.NoError(t, err)
+	var report metricsReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Iterations, 4)
+	assert.Equal(t, "U(5,10)/(1,3)", report.Iterations[3].Scenario)
+	assert.Equal(t, 4, report.Iterations[3].Concurrency)
+	assert.Equal(t, int64(8), report.Iterations[3].NumRequests)
+	assert.NotNil(t, report.Iterations[3].TimeToFirstToken)
+
+	assert.Equal(t, map[string]string{
+		"results/benchmarks/experiment/summary.json":   filepath.Join(outputDir, SummaryFileName),
+		"results/benchmarks/experiment/requests.jsonl": filepath.Join(outputDir, RecordsFileName),
+	}, uploader.objects)
+}
+
+func TestBenchmarkAgentWithoutStreaming(t *testing.T) {
+	var requests atomic.Int64
+	engine := newFakeEngine(t, &requests)
+	defer engine.Close()
+
+	config := newTestConfig(t, engine.URL)
+	config.NoStream = true
+	config.TrafficScenarios = []string{"D(10,5)"}
+	config.NumConcurrency = []int{2}
+	config.WarmupRequests = 0
+	agent, err := NewBenchmarkAgent(config)
+	require.NoError(t, err)
+
+	require.NoError(t, agent.Start())
+
+	data, err := os.ReadFile(config.MetricsReportPath)
+	require.NoError(t, err)
+	var report metricsReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Iterations, 1)
+	assert.Nil(t, report.Iterations[0].TimeToFirstToken)
+	assert.NotNil(t, report.Iterations[0].EndToEndLatency)
+	assert.Equal(t, int64(8), report.Iterations[0].NumRequests)
+	assert.Equal(t, int64(8), requests.Load())
+}
+
+func TestMarshalMetricsReport(t *testing.T) {
+	histogram := make([]v1beta1.HistogramBucket, 8)
+	for i := range histogram {
+		histogram[i] = v1beta1.HistogramBucket{UpperBound: metav1.Duration{Duration: time.Duration(i+1) * 100 * time.Millisecond}, Count: 10}
+	}
+	newMetrics := func(n int) []v1beta1.IterationMetrics {
+		metrics := make([]v1beta1.IterationMetrics, n)
+		for i := range metrics {
+			metrics[i] = v1beta1.IterationMetrics{
+				Scenario:        "D(100,100)",
+				Concurrency:     i + 1,
+				NumRequests:     100,
+				EndToEndLatency: &v1beta1.LatencyDistribution{Histogram: slices.Clone(histogram)},
+			}
+		}
+		return metrics
+	}
+
+	// A small report is kept whole
+	data, kept, err := marshalMetricsReport(newMetrics(2))
+	require.NoError(t, err)
+	assert.Equal(t, 2, kept)
+	var report metricsReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.False(t, report.Truncated)
+	assert.Len(t, report.Iterations[1].EndToEndLatency.Histogram, 8)
+
+	// The histograms are dropped before the iterations
+	data, kept, err = marshalMetricsReport(newMetrics(20))
+	require.NoError(t, err)
+	assert.Equal(t, 20, kept)
+	report = metricsReport{}
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.False(t, report.Truncated)
+	assert.Empty(t, report.Iterations[19].EndToEndLatency.Histogram)
+
+	// The last iterations are left out of a report that still doesn't fit
+	data, kept, err = marshalMetricsReport(newMetrics(200))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(data), maxMetricsReportSize)
+	report = metricsReport{}
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.True(t, report.Truncated)
+	assert.Len(t, report.Iterations, kept)
+	assert.Less(t, kept, 200)
+	assert.Equal(t, 1, report.Iterations[0].Concurrency)
+}
+
+func TestBenchmarkAgentFailingEndpoint(t *testing.T) {
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model not loaded", http.StatusServiceUnavailable)
+	}))
+	defer engine.Close()
+
+	config := newTestConfig(t, engine.URL)
+	config.WarmupRequests = 0
+	agent, err := NewBenchmarkAgent(config)
+	require.NoError(t, err)
+
+	err = agent.Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 503: model not loaded")
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(c *Config) {},
+		},
+		{
+			name:    "unsupported backend",
+			modify:  func(c *Config) { c.APIBackend = "vllm" },
+			wantErr: "unsupported api backend",
+		},
+		{
+			name:    "unsupported task",
+			modify:  func(c *Config) { c.Task = "image-text-to-text" },
+			wantErr: "unsupported task",
+		},
+		{
+			name:    "invalid scenario",
+			modify:  func(c *Config) { c.TrafficScenarios = []string{"X(1)"} },
+			wantErr: "invalid traffic scenario",
+		},
+		{
+			name:    "missing bucket",
+			modify:  func(c *Config) { c.UploadResults = true },
+			wantErr: "StorageBucket",
+		},
+		{
+			name: "unsupported auth",
+			modify: func(c *Config) {
+				c.UploadResults = true
+				c.StorageBucket = "results"
+				c.Auth = "security_token"
+			},
+			wantErr: "unsupported auth",
+		},
+		{
+			name: "config file",
+			modify: func(c *Config) {
+				c.UploadResults = true
+				c.StorageBucket = "results"
+				c.ConfigFile = "/etc/oci/config"
+			},
+			wantErr: "not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newTestConfig(t, "http://localhost:8080")
+			tt.modify(config)
+			err := config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package benchmark
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const chatCompletionsPath = "/v1/chat/completions"
+
+// RequestRecord is the outcome of a single benchmark request, written to the request records of the iteration
+type RequestRecord struct {
+	Scenario     string `json:"scenario"`
+	Concurrency  int    `json:"concurrency"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+	// The latencies are in seconds, time to first token and inter token latency are only measured when streaming
+	TimeToFirstToken  float64 `json:"time_to_first_token,omitempty"`
+	InterTokenLatency float64 `json:"inter_token_latency,omitempty"`
+	EndToEndLatency   float64 `json:"end_to_end_latency"`
+	Error             string  `json:"error,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatCompletionRequest struct {
+	Model         string         `json:"model,omitempty"`
+	Messages      []chatMessage  `json:"messages"`
+	MaxTokens     int            `json:"max_tokens"`
+	IgnoreEOS     bool           `json:"ignore_eos"`
+	Stream        bool           `json:"stream"`
+	StreamOptions *streamOptions `json:"stream_options,omitempty"`
+}
+
+type usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *usage `json:"usage"`
+}
+
+// OpenAIClient sends chat completion requests to an OpenAI compatible endpoint and measures their latencies
+type OpenAIClient struct {
+	httpClient *http.Client
+	url        string
+	model      string
+	apiKey     string
+	stream     bool
+}
+
+// NewOpenAIClient creates a client for the chat completions API under apiBase
+func NewOpenAIClient(apiBase, model, apiKey string, stream bool, timeout time.Duration) *OpenAIClient {
+	return &OpenAIClient{
+		httpClient: &http.Client{Timeout: timeout},
+		url:        strings.TrimSuffix(apiBase, "/") + chatCompletionsPath,
+		model:      model,
+		apiKey:     apiKey,
+		stream:     stream,
+	}
+}
+
+// Send sends a request with a prompt of about inputTokens tokens asking for outputTokens tokens. Failed requests
+// are reported in the Error of the record rather than as an error, so they count toward the results.
+func (c *OpenAIClient) Send(ctx context.Context, inputTokens, outputTokens int) RequestRecord {
+	record := RequestRecord{InputTokens: inputTokens}
+
+	body := chatCompletionRequest{
+		Model:     c.model,
+		Messages:  []chatMessage{{Role: "user", Content: buildPrompt(inputTokens)}},
+		MaxTokens: outputTokens,
+		IgnoreEOS: true,
+		Stream:    c.stream,
+	}
+	if c.stream {
+		body.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		record.Error = err.Error()
+		return record
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		record.Error = err.Error()
+		return record
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		record.Error = err.Error()
+		return record
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		record.Error = fmt.Sprintf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+		return record
+	}
+
+	if c.stream {
+		err = c.readStream(resp.Body, start, &record)
+	} else {
+		err = readResponse(resp.Body, &record)
+	}
+	record.EndToEndLatency = time.Since(start).Seconds()
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+// readStream reads the server-sent events of a streaming response, timing the first token and the tokens after it
+func (c *OpenAIClient) readStream(body io.Reader, start time.Time, record *RequestRecord) error {
+	var firstToken, lastToken time.Time
+	chunks := 0
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk chatCompletionResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("invalid stream event: %w", err)
+		}
+		if chunk.Usage != nil {
+			record.OutputTokens = chunk.Usage.CompletionTokens
+			if chunk.Usage.PromptTokens > 0 {
+				record.InputTokens = chunk.Usage.PromptTokens
+			}
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		now := time.Now()
+		if firstToken.IsZero() {
+			firstToken = now
+		}
+		lastToken = now
+		chunks++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if firstToken.IsZero() {
+		return fmt.Errorf("no tokens received")
+	}
+
+	// Servers that don't report usage in the stream send one token per chunk
+	if record.OutputTokens == 0 {
+		record.OutputTokens = chunks
+	}
+	record.TimeToFirstToken = firstToken.Sub(start).Seconds()
+	if record.OutputTokens > 1 {
+		record.InterTokenLatency = lastToken.Sub(firstToken).Seconds() / float64(record.OutputTokens-1)
+	}
+	return nil
+}
+
+func readResponse(body io.Reader, record *RequestRecord) error {
+	var response chatCompletionResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	if response.Usage == nil {
+		return fmt.Errorf("response has no usage")
+	}
+	record.OutputTokens = response.Usage.CompletionTokens
+	if response.Usage.PromptTokens > 0 {
+		record.InputTokens = response.Usage.PromptTokens
+	}
+	return nil
+}
+
+// buildPrompt builds a prompt of about the given number of tokens, counting a short common word as one token
+func buildPrompt(tokens int) string {
+	return strings.TrimSpace(strings.Repeat("hello ", tokens))
+}
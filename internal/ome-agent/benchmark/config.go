@@ -0,0 +1,163 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+
+	"github.com/sgl-project/ome/pkg/configutils"
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+const (
+	// TextToTextTask is the only task the benchmark agent generates traffic for
+	TextToTextTask = "text-to-text"
+	// OpenAIBackend is the only API backend the benchmark agent speaks
+	OpenAIBackend = "openai"
+)
+
+// Config configures a benchmark run. The keys match the flags of genai-bench, so the BenchmarkJob controller builds
+// the same arguments for either benchmark client.
+type Config struct {
+	Logger logging.Interface
+
+	APIBackend     string `mapstructure:"api_backend" validate:"required"`
+	APIBase        string `mapstructure:"api_base" validate:"required,url"`
+	APIModelName   string `mapstructure:"api_model_name"`
+	APIKey         string `mapstructure:"api_key"`
+	ModelTokenizer string `mapstructure:"model_tokenizer"`
+	Task           string `mapstructure:"task" validate:"required"`
+
+	TrafficScenarios []string `mapstructure:"traffic_scenario"`
+	NumConcurrency   []int    `mapstructure:"num_concurrency"`
+	// MaxTimePerRun is in minutes and MaxRequestsPerRun bounds the requests of each iteration
+	MaxTimePerRun     int `mapstructure:"max_time_per_run" validate:"gt=0"`
+	MaxRequestsPerRun int `mapstructure:"max_requests_per_run" validate:"gt=0"`
+	// WarmupDuration is in seconds, the warmup ends when either limit is reached
+	WarmupDuration int `mapstructure:"warmup_duration" validate:"gte=0"`
+	WarmupRequests int `mapstructure:"warmup_requests" validate:"gte=0"`
+	// NoStream sends non-streaming requests, token counts then come from the usage of the responses
+	NoStream bool `mapstructure:"no_stream"`
+	// RequestTimeout is in seconds
+	RequestTimeout int `mapstructure:"request_timeout" validate:"gt=0"`
+
+	ExperimentBaseDir    string `mapstructure:"experiment_base_dir"`
+	ExperimentFolderName string `mapstructure:"experiment_folder_name" validate:"required"`
+	SaveRequestRecords   bool   `mapstructure:"save_request_records"`
+	MetricsReportPath    string `mapstructure:"metrics_report_path"`
+
+	ServerEngine   string `mapstructure:"server_engine"`
+	ServerGPUType  string `mapstructure:"server_gpu_type"`
+	ServerVersion  string `mapstructure:"server_version"`
+	ServerGPUCount int    `mapstructure:"server_gpu_count"`
+
+	// UploadResults uploads the result folder to OCI Object Storage
+	UploadResults bool   `mapstructure:"upload_results"`
+	Namespace     string `mapstructure:"namespace"`
+	StorageBucket string `mapstructure:"storage_bucket" validate:"required_if=UploadResults true"`
+	StoragePrefix string `mapstructure:"storage_prefix"`
+	Auth          string `mapstructure:"auth"`
+	Region        string `mapstructure:"region"`
+	ConfigFile    string `mapstructure:"config_file"`
+	Profile       string `mapstructure:"profile"`
+	SecurityToken string `mapstructure:"security_token"`
+}
+
+// Option defines a function that applies configuration options
+type Option func(*Config) error
+
+// Apply applies the given options to the configuration
+func (c *Config) Apply(opts ...Option) error {
+	for _, o := range opts {
+		if o != nil {
+			if err := o(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// defaultConfig returns a new configuration with default values
+func defaultConfig() *Config {
+	return &Config{
+		APIBackend:        OpenAIBackend,
+		Task:              TextToTextTask,
+		ExperimentBaseDir: filepath.Join(os.TempDir(), "experiments"),
+		RequestTimeout:    600,
+		Auth:              "instance_principal",
+	}
+}
+
+// NewConfig builds and returns a new configuration from the given options
+func NewConfig(opts ...Option) (*Config, error) {
+	c := defaultConfig()
+	if err := c.Apply(opts...); err != nil {
+		return nil, fmt.Errorf("failed to apply config options: %w", err)
+	}
+	return c, nil
+}
+
+// WithLogger sets the logger for the configuration
+func WithLogger(logger logging.Interface) Option {
+	return func(c *Config) error {
+		if logger == nil {
+			return errors.New("logger cannot be nil")
+		}
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithViper loads configuration using Viper
+func WithViper(v *viper.Viper) Option {
+	return func(c *Config) error {
+		*c = *defaultConfig()
+
+		if err := configutils.BindEnvsRecursive(v, c, ""); err != nil {
+			return fmt.Errorf("error binding envs: %w", err)
+		}
+		if err := v.Unmarshal(c); err != nil {
+			return fmt.Errorf("error unmarshalling config: %w", err)
+		}
+		return nil
+	}
+}
+
+// Validate checks if the configuration is valid and only uses features the benchmark agent supports
+func (c *Config) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(c); err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+
+	if c.APIBackend != OpenAIBackend {
+		return fmt.Errorf("unsupported api backend %q, only %q is supported", c.APIBackend, OpenAIBackend)
+	}
+	if c.Task != TextToTextTask {
+		return fmt.Errorf("unsupported task %q, only %q is supported", c.Task, TextToTextTask)
+	}
+	for _, scenario := range c.TrafficScenarios {
+		if _, err := ParseScenario(scenario); err != nil {
+			return err
+		}
+	}
+	for _, concurrency := range c.NumConcurrency {
+		if concurrency <= 0 {
+			return fmt.Errorf("invalid concurrency %d, it must be positive", concurrency)
+		}
+	}
+	if c.UploadResults {
+		if _, err := authType(c.Auth); err != nil {
+			return err
+		}
+		if c.ConfigFile != "" || c.Profile != "" || c.SecurityToken != "" {
+			return errors.New("config_file, profile and security_token are not supported, use auth to select the OCI principal")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package benchmark
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// histogramUpperBounds are the upper bounds of the latency histogram buckets, a last bucket holds the latencies
+// above them
+var histogramUpperBounds = []time.Duration{
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// IterationSummary is the summary of an iteration written to the summary file of the experiment
+type IterationSummary struct {
+	Scenario          string  `json:"scenario"`
+	Concurrency       int     `json:"concurrency"`
+	NumRequests       int     `json:"num_requests"`
+	NumErrors         int     `json:"num_errors"`
+	Duration          float64 `json:"duration"`
+	InputTokens       int64   `json:"total_input_tokens"`
+	OutputTokens      int64   `json:"total_output_tokens"`
+	OutputThroughput  float64 `json:"output_throughput"`
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	MeanTTFT          float64 `json:"mean_ttft,omitempty"`
+	P50TTFT           float64 `json:"p50_ttft,omitempty"`
+	MeanITL           float64 `json:"mean_itl,omitempty"`
+	P50ITL            float64 `json:"p50_itl,omitempty"`
+	MeanE2ELatency    float64 `json:"mean_e2e_latency"`
+	P50E2ELatency     float64 `json:"p50_e2e_latency"`
+}
+
+// Iteration holds the request records of an iteration of a scenario at a concurrency
+type Iteration struct {
+	Scenario    string
+	Concurrency int
+	Duration    time.Duration
+	Records     []RequestRecord
+}
+
+// Summary summarizes the iteration, latencies are in seconds
+func (it *Iteration) Summary() IterationSummary {
+	summary := IterationSummary{
+		Scenario:    it.Scenario,
+		Concurrency: it.Concurrency,
+		Duration:    it.Duration.Seconds(),
+	}
+	ttft, itl, e2e := it.latencies()
+	for _, r := range it.Records {
+		if r.Error != "" {
+			summary.NumErrors++
+			continue
+		}
+		summary.NumRequests++
+		summary.InputTokens += int64(r.InputTokens)
+		summary.OutputTokens += int64(r.OutputTokens)
+	}
+	if seconds := it.Duration.Seconds(); seconds > 0 {
+		summary.OutputThroughput = float64(summary.OutputTokens) / seconds
+		summary.RequestsPerSecond = float64(summary.NumRequests) / seconds
+	}
+	summary.MeanTTFT, summary.P50TTFT = mean(ttft), percentile(ttft, 50)
+	summary.MeanITL, summary.P50ITL = mean(itl), percentile(itl, 50)
+	summary.MeanE2ELatency, summary.P50E2ELatency = mean(e2e), percentile(e2e, 50)
+	return summary
+}
+
+// Metrics converts the iteration to the metrics surfaced in the status of the BenchmarkJob
+func (it *Iteration) Metrics() v1beta1.IterationMetrics {
+	summary := it.Summary()
+	ttft, itl, e2e := it.latencies()
+	return v1beta1.IterationMetrics{
+		Scenario:          it.Scenario,
+		Concurrency:       it.Concurrency,
+		NumRequests:       int64(summary.NumRequests),
+		OutputThroughput:  resource.NewMilliQuantity(int64(math.Round(summary.OutputThroughput*1000)), resource.DecimalSI),
+		TimeToFirstToken:  distribution(ttft),
+		InterTokenLatency: distribution(itl),
+		EndToEndLatency:   distribution(e2e),
+	}
+}
+
+// latencies returns the sorted latencies of the successful requests
+func (it *Iteration) latencies() (ttft, itl, e2e []float64) {
+	for _, r := range it.Records {
+		if r.Error != "" {
+			continue
+		}
+		if r.TimeToFirstToken > 0 {
+			ttft = append(ttft, r.TimeToFirstToken)
+		}
+		if r.InterTokenLatency > 0 {
+			itl = append(itl, r.InterTokenLatency)
+		}
+		e2e = append(e2e, r.EndToEndLatency)
+	}
+	slices.Sort(ttft)
+	slices.Sort(itl)
+	slices.Sort(e2e)
+	return ttft, itl, e2e
+}
+
+// distribution summarizes sorted latencies in seconds, it is nil when there are none
+func distribution(sorted []float64) *v1beta1.LatencyDistribution {
+	if len(sorted) == 0 {
+		return nil
+	}
+	return &v1beta1.LatencyDistribution{
+		Mean:      duration(mean(sorted)),
+		P50:       duration(percentile(sorted, 50)),
+		P90:       duration(percentile(sorted, 90)),
+		P95:       duration(percentile(sorted, 95)),
+		P99:       duration(percentile(sorted, 99)),
+		Max:       duration(sorted[len(sorted)-1]),
+		Histogram: histogram(sorted),
+	}
+}
+
+// histogram counts sorted latencies in seconds per bucket, omitting the empty buckets
+func histogram(sorted []float64) []v1beta1.HistogramBucket {
+	var buckets []v1beta1.HistogramBucket
+	i := 0
+	for _, bound := range histogramUpperBounds {
+		count := int64(0)
+		for i < len(sorted) && sorted[i] <= bound.Seconds() {
+			count++
+			i++
+		}
+		if count > 0 {
+			buckets = append(buckets, v1beta1.HistogramBucket{UpperBound: metav1.Duration{Duration: bound}, Count: count})
+		}
+	}
+	if i < len(sorted) {
+		buckets = append(buckets, v1beta1.HistogramBucket{
+			UpperBound: *duration(sorted[len(sorted)-1]),
+			Count:      int64(len(sorted) - i),
+		})
+	}
+	return buckets
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+func duration(seconds float64) *metav1.Duration {
+	return &metav1.Duration{Duration: time.Duration(math.Round(seconds * float64(time.Second)))}
+}
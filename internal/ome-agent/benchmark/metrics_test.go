@@ -0,0 +1,82 @@
+package benchmark
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, 5.0, percentile(sorted, 50))
+	assert.Equal(t, 9.0, percentile(sorted, 90))
+	assert.Equal(t, 10.0, percentile(sorted, 99))
+	assert.Equal(t, 1.0, percentile(sorted, 0))
+	assert.Equal(t, 0.0, percentile(nil, 50))
+}
+
+func TestHistogram(t *testing.T) {
+	buckets := histogram([]float64{0.005, 0.01, 0.2, 0.3, 120})
+	assert.Equal(t, []v1beta1.HistogramBucket{
+		{UpperBound: metav1.Duration{Duration: 10 * time.Millisecond}, Count: 2},
+		{UpperBound: metav1.Duration{Duration: 250 * time.Millisecond}, Count: 1},
+		{UpperBound: metav1.Duration{Duration: 500 * time.Millisecond}, Count: 1},
+		{UpperBound: metav1.Duration{Duration: 2 * time.Minute}, Count: 1},
+	}, buckets)
+}
+
+func TestIterationMetrics(t *testing.T) {
+	iteration := &Iteration{
+		Scenario:    "D(100,100)",
+		Concurrency: 2,
+		Duration:    2 * time.Second,
+		Records: []RequestRecord{
+			{InputTokens: 100, OutputTokens: 100, TimeToFirstToken: 0.1, InterTokenLatency: 0.01, EndToEndLatency: 1.1},
+			{InputTokens: 100, OutputTokens: 100, TimeToFirstToken: 0.3, InterTokenLatency: 0.03, EndToEndLatency: 3.3},
+			{InputTokens: 100, Error: "unexpected status 500"},
+		},
+	}
+
+	summary := iteration.Summary()
+	assert.Equal(t, 2, summary.NumRequests)
+	assert.Equal(t, 1, summary.NumErrors)
+	assert.Equal(t, int64(200), summary.OutputTokens)
+	assert.Equal(t, 100.0, summary.OutputThroughput)
+	assert.InDelta(t, 0.2, summary.MeanTTFT, 1e-9)
+	assert.InDelta(t, 2.2, summary.MeanE2ELatency, 1e-9)
+
+	metrics := iteration.Metrics()
+	assert.Equal(t, "D(100,100)", metrics.Scenario)
+	assert.Equal(t, 2, metrics.Concurrency)
+	assert.Equal(t, int64(2), metrics.NumRequests)
+	require.NotNil(t, metrics.OutputThroughput)
+	assert.Equal(t, "100", metrics.OutputThroughput.String())
+	require.NotNil(t, metrics.TimeToFirstToken)
+	assert.Equal(t, 200*time.Millisecond, metrics.TimeToFirstToken.Mean.Duration)
+	assert.Equal(t, 100*time.Millisecond, metrics.TimeToFirstToken.P50.Duration)
+	assert.Equal(t, 300*time.Millisecond, metrics.TimeToFirstToken.Max.Duration)
+	require.NotNil(t, metrics.InterTokenLatency)
+	assert.Equal(t, 30*time.Millisecond, metrics.InterTokenLatency.P99.Duration)
+	require.NotNil(t, metrics.EndToEndLatency)
+	assert.Equal(t, 3300*time.Millisecond, metrics.EndToEndLatency.P90.Duration)
+}
+
+func TestIterationMetricsWithoutStreaming(t *testing.T) {
+	iteration := &Iteration{
+		Scenario:    "D(100,100)",
+		Concurrency: 1,
+		Duration:    time.Second,
+		Records:     []RequestRecord{{InputTokens: 100, OutputTokens: 100, EndToEndLatency: 1}},
+	}
+
+	metrics := iteration.Metrics()
+	assert.Nil(t, metrics.TimeToFirstToken)
+	assert.Nil(t, metrics.InterTokenLatency)
+	require.NotNil(t, metrics.EndToEndLatency)
+	assert.Equal(t, time.Second, metrics.EndToEndLatency.Max.Duration)
+}
@@ -0,0 +1,29 @@
+package benchmark
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+type benchmarkParams struct {
+	fx.In
+
+	Logger logging.Interface
+}
+
+// Module provides the benchmark agent via fx
+var Module = fx.Provide(
+	func(v *viper.Viper, params benchmarkParams) (*BenchmarkAgent, error) {
+		config, err := NewConfig(
+			WithViper(v),
+			WithLogger(params.Logger),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating benchmark config: %+v", err)
+		}
+		return NewBenchmarkAgent(config)
+	})
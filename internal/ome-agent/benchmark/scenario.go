@@ -0,0 +1,84 @@
+package benchmark
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultScenario is used when no traffic scenario is configured
+const DefaultScenario = "D(100,100)"
+
+// Scenario describes how the input and output token counts of the requests of an iteration are drawn, using the
+// traffic scenario notation of genai-bench:
+//
+//	D(in,out)                             deterministic token counts
+//	N(in_mean,in_std)/(out_mean,out_std)  normally distributed token counts
+//	U(in_min,in_max)/(out_min,out_max)    uniformly distributed token counts
+//	E(tokens)                             embedding requests, which the text-to-text task doesn't support
+type Scenario struct {
+	Name string
+	Kind byte
+	// In and Out hold the parameters of the input and output token distributions
+	In  [2]int
+	Out [2]int
+}
+
+var (
+	deterministicScenarioRegex = regexp.MustCompile(`^D\((\d+),(\d+)\)$`)
+	distributedScenarioRegex   = regexp.MustCompile(`^([NU])\((\d+),(\d+)\)/\((\d+),(\d+)\)$`)
+)
+
+// ParseScenario parses a traffic scenario
+func ParseScenario(scenario string) (*Scenario, error) {
+	compact := strings.ReplaceAll(scenario, " ", "")
+	if m := deterministicScenarioRegex.FindStringSubmatch(compact); m != nil {
+		in, _ := strconv.Atoi(m[1])
+		out, _ := strconv.Atoi(m[2])
+		if in <= 0 || out <= 0 {
+			return nil, fmt.Errorf("invalid traffic scenario %q: token counts must be positive", scenario)
+		}
+		return &Scenario{Name: scenario, Kind: 'D', In: [2]int{in, 0}, Out: [2]int{out, 0}}, nil
+	}
+	if m := distributedScenarioRegex.FindStringSubmatch(compact); m != nil {
+		var params [4]int
+		for i := range params {
+			params[i], _ = strconv.Atoi(m[i+2])
+		}
+		s := &Scenario{Name: scenario, Kind: m[1][0], In: [2]int{params[0], params[1]}, Out: [2]int{params[2], params[3]}}
+		if s.Kind == 'U' && (s.In[0] > s.In[1] || s.Out[0] > s.Out[1]) {
+			return nil, fmt.Errorf("invalid traffic scenario %q: minimum exceeds maximum", scenario)
+		}
+		if s.In[0] <= 0 && s.In[1] <= 0 || s.Out[0] <= 0 && s.Out[1] <= 0 {
+			return nil, fmt.Errorf("invalid traffic scenario %q: token counts must be positive", scenario)
+		}
+		return s, nil
+	}
+	if strings.HasPrefix(compact, "E(") {
+		return nil, fmt.Errorf("unsupported traffic scenario %q: embedding scenarios require the text-to-embeddings task", scenario)
+	}
+	return nil, fmt.Errorf("invalid traffic scenario %q", scenario)
+}
+
+// Sample draws the input and output token counts of a request, both at least one token
+func (s *Scenario) Sample(rng *rand.Rand) (int, int) {
+	switch s.Kind {
+	case 'N':
+		return sampleNormal(rng, s.In), sampleNormal(rng, s.Out)
+	case 'U':
+		return sampleUniform(rng, s.In), sampleUniform(rng, s.Out)
+	default:
+		return s.In[0], s.Out[0]
+	}
+}
+
+func sampleNormal(rng *rand.Rand, params [2]int) int {
+	return max(1, int(math.Round(rng.NormFloat64()*float64(params[1])+float64(params[0]))))
+}
+
+func sampleUniform(rng *rand.Rand, params [2]int) int {
+	return max(1, params[0]+rng.Intn(params[1]-params[0]+1))
+}
@@ -0,0 +1,95 @@
+package benchmark
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScenario(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		expected *Scenario
+		wantErr  string
+	}{
+		{
+			name:     "deterministic",
+			scenario: "D(100,200)",
+			expected: &Scenario{Name: "D(100,200)", Kind: 'D', In: [2]int{100, 0}, Out: [2]int{200, 0}},
+		},
+		{
+			name:     "normal with spaces",
+			scenario: "N(480, 240)/(300, 150)",
+			expected: &Scenario{Name: "N(480, 240)/(300, 150)", Kind: 'N', In: [2]int{480, 240}, Out: [2]int{300, 150}},
+		},
+		{
+			name:     "uniform",
+			scenario: "U(50,100)/(200,250)",
+			expected: &Scenario{Name: "U(50,100)/(200,250)", Kind: 'U', In: [2]int{50, 100}, Out: [2]int{200, 250}},
+		},
+		{
+			name:     "uniform with minimum above maximum",
+			scenario: "U(100,50)/(200,250)",
+			wantErr:  "minimum exceeds maximum",
+		},
+		{
+			name:     "zero tokens",
+			scenario: "D(0,100)",
+			wantErr:  "token counts must be positive",
+		},
+		{
+			name:     "embedding",
+			scenario: "E(1024)",
+			wantErr:  "text-to-embeddings",
+		},
+		{
+			name:     "malformed",
+			scenario: "D(100)",
+			wantErr:  "invalid traffic scenario",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario, err := ParseScenario(tt.scenario)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, scenario)
+		})
+	}
+}
+
+func TestScenarioSample(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	deterministic, err := ParseScenario("D(100,200)")
+	require.NoError(t, err)
+	in, out := deterministic.Sample(rng)
+	assert.Equal(t, 100, in)
+	assert.Equal(t, 200, out)
+
+	uniform, err := ParseScenario("U(50,100)/(1,1)")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		in, out := uniform.Sample(rng)
+		assert.GreaterOrEqual(t, in, 50)
+		assert.LessOrEqual(t, in, 100)
+		assert.Equal(t, 1, out)
+	}
+
+	// Normally distributed token counts never drop below one token
+	normal, err := ParseScenario("N(1,100)/(1,100)")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		in, out := normal.Sample(rng)
+		assert.GreaterOrEqual(t, in, 1)
+		assert.GreaterOrEqual(t, out, 1)
+	}
+}
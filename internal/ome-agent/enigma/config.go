@@ -7,6 +7,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
 
+	"github.com/sgl-project/ome/pkg/agentprogress"
 	"github.com/sgl-project/ome/pkg/configutils"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/logging"
@@ -39,6 +40,7 @@ type Config struct {
 	KmsCryptoClient        *kmscrypto.KmsCrypto `validate:"required_if=DisableModelDecryption false"`
 	KmsManagement          *kmsmgm.KmsMgm       `validate:"required_if=DisableModelDecryption false"`
 	OCISecret              *ocisecret.Secret    `validate:"required_if=DisableModelDecryption false"`
+	Progress               *agentprogress.Reporter
 }
 
 type TensorrtLLMConfig struct {
@@ -157,6 +159,7 @@ func WithAppParams(params enigmaParams) Option {
 		c.OCISecret = params.Secret
 		c.KmsCryptoClient = params.KmsCryptoClient
 		c.KmsManagement = params.KmsManagement
+		c.Progress = params.Progress
 		return nil
 	}
 }
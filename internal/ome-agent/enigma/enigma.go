@@ -1,16 +1,18 @@
 package enigma
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/v65/keymanagement"
 	"github.com/otiai10/copy"
 
+	"github.com/sgl-project/ome/pkg/agentprogress"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/vault"
@@ -51,6 +53,7 @@ func (e *Enigma) Start() error {
 	}
 
 	e.logger.Infof("Copying model weights %s to temporary path %s", e.Config.ModelName, e.Config.TempPath)
+	e.Config.Progress.SetPhase(context.Background(), agentprogress.PhaseCopying, e.Config.ModelName)
 	if err := e.copyModelWeights(); err != nil {
 		return fmt.Errorf("failed to copy model weights: %w", err)
 	}
@@ -93,6 +96,10 @@ func (e *Enigma) decryptModelWeights() error {
 	}
 
 	modelStorePath := e.getModelTempPath()
+	e.Config.Progress.SetPhase(context.Background(), agentprogress.PhaseDecrypting, e.Config.ModelName)
+	if files, err := countModelFiles(modelStorePath); err == nil {
+		e.Config.Progress.SetTotal(files, "files")
+	}
 	err = filepath.Walk(modelStorePath, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return fmt.Errorf("error accessing path %s: %w", path, err)
@@ -109,6 +116,9 @@ func (e *Enigma) decryptModelWeights() error {
 			return err // Return the error to halt further processing
 		}
 		e.logger.Infof("File %s decrypted successfully", path)
+		if !info.IsDir() {
+			e.Config.Progress.Add(1)
+		}
 		return nil
 	})
 	if err != nil {
@@ -119,23 +129,37 @@ func (e *Enigma) decryptModelWeights() error {
 	return nil
 }
 
-// prepareDecryptionKey retrieves and decrypts the data encryption key (DEK) using the master encryption key (MEK)
+// prepareDecryptionKey retrieves and decrypts the data encryption key (DEK) using the master encryption key (MEK).
+// Once the key of the model weights was rotated, the DEK of the rotation metadata replaces the one of OCI Vault.
 func (e *Enigma) prepareDecryptionKey() (string, error) {
-	masterKeyID, err := e.getMasterKeyID()
+	metadata, err := readRotationMetadata(e.getModelStorePath())
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve master key ID: %w", err)
+		return "", err
 	}
-	e.logger.Infof("Master key ID retrieved: %s", *masterKeyID)
 
-	cipherDataKey, err := e.getCipherDataKey()
-	if err != nil {
-		return "", fmt.Errorf("failed to retrieve cipher data key: %w", err)
+	var keyID, cipherDataKey string
+	switch {
+	case metadata == nil:
+		masterKeyID, err := e.getMasterKeyID()
+		if err != nil {
+			return "", fmt.Errorf("failed to retrieve master key ID: %w", err)
+		}
+		e.logger.Infof("Master key ID retrieved: %s", *masterKeyID)
+
+		secretDataKey, err := e.getCipherDataKey()
+		if err != nil {
+			return "", fmt.Errorf("failed to retrieve cipher data key: %w", err)
+		}
+		keyID, cipherDataKey = *masterKeyID, *secretDataKey
+	case metadata.CompletedAt == nil:
+		return "", fmt.Errorf("key rotation started at %s is not complete, resume it before decrypting the model weights",
+			metadata.StartedAt.Format(time.RFC3339))
+	default:
+		e.logger.Infof("Using the DEK of the key rotation completed at %s", metadata.CompletedAt.Format(time.RFC3339))
+		keyID, cipherDataKey = metadata.KeyID, metadata.CipherDataKey
 	}
 
-	plainDataKey, err := e.Config.KmsCryptoClient.Decrypt(
-		*cipherDataKey, true, *masterKeyID,
-		keymanagement.DecryptDataDetailsEncryptionAlgorithmAes256Gcm,
-	)
+	plainDataKey, err := e.unwrapDataKey(cipherDataKey, keyID)
 	if err != nil {
 		return "", fmt.Errorf("failed to decrypt DEK using MEK: %w", err)
 	}
@@ -144,6 +168,14 @@ func (e *Enigma) prepareDecryptionKey() (string, error) {
 	return plainDataKey, nil
 }
 
+// unwrapDataKey decrypts a base64 encoded DEK wrapped by the MEK keyID
+func (e *Enigma) unwrapDataKey(cipherDataKey, keyID string) (string, error) {
+	return e.Config.KmsCryptoClient.Decrypt(
+		cipherDataKey, true, keyID,
+		keymanagement.DecryptDataDetailsEncryptionAlgorithmAes256Gcm,
+	)
+}
+
 // decryptFile decrypts an individual file if it is not marked as metadata
 func (e *Enigma) decryptFile(path string, info fs.FileInfo, plainDataKey string) error {
 	if info.IsDir() {
@@ -156,7 +188,7 @@ func (e *Enigma) decryptFile(path string, info fs.FileInfo, plainDataKey string)
 	}
 
 	var decryptedData []byte
-	if strings.Contains(info.Name(), exportMetadataFile) {
+	if isUnencryptedFile(info.Name()) {
 		e.logger.Infof("Skipping decryption for metadata file %s", info.Name())
 		decryptedData = data
 	} else {
@@ -226,6 +258,21 @@ func validateModelExistence(modelDirPath string) error {
 	return nil
 }
 
+// countModelFiles counts the files of the model weights to decrypt
+func countModelFiles(modelDirPath string) (int64, error) {
+	var files int64
+	err := filepath.Walk(modelDirPath, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !isIgnoredFile(info.Name()) {
+			files++
+		}
+		return nil
+	})
+	return files, err
+}
+
 // isIgnoredFile checks if a file should be ignored during processing
 func isIgnoredFile(fileName string) bool {
 	_, ignored := ignoredFiles[fileName]
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
 
+	"github.com/sgl-project/ome/pkg/agentprogress"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/vault/kmscrypto"
 	"github.com/sgl-project/ome/pkg/vault/kmsmgm"
@@ -19,6 +20,7 @@ type enigmaParams struct {
 	KmsCryptoClient *kmscrypto.KmsCrypto
 	KmsManagement   *kmsmgm.KmsMgm
 	Secret          *ocisecret.Secret
+	Progress        *agentprogress.Reporter `optional:"true"`
 }
 
 var Module = fx.Provide(
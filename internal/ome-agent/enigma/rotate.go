@@ -0,0 +1,349 @@
+package enigma
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/vault"
+)
+
+// rotationMetadataFile records the key rotations of the model weights in the model store, it is never encrypted
+const rotationMetadataFile = ".rotation.metadata"
+
+// rotatingFileSuffix is appended to the path a file is written to before it atomically replaces the original
+const rotatingFileSuffix = ".rotating"
+
+// RotationMetadata records the rotation of the data encryption key (DEK) of the model weights. The new DEK is
+// generated with the current version of the master encryption key (MEK) and stored wrapped by it, so the model
+// weights can be decrypted once the rotation completes without updating the OCI Vault secret.
+type RotationMetadata struct {
+	// KeyID is the MEK the new DEK is wrapped by
+	KeyID string `json:"key_id"`
+	// CipherDataKey is the new DEK wrapped by the MEK, base64 encoded like the DEK of the OCI Vault secret
+	CipherDataKey string `json:"cipher_data_key"`
+	// PreviousKeyID and PreviousCipherDataKey are the wrapped DEK the model weights were encrypted with
+	PreviousKeyID         string `json:"previous_key_id"`
+	PreviousCipherDataKey string `json:"previous_cipher_data_key"`
+
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// RotatedFiles are the paths, relative to the model store, of the files encrypted with the new DEK
+	RotatedFiles []string `json:"rotated_files"`
+	// PendingFile is the file being replaced by its re-encrypted copy
+	PendingFile string `json:"pending_file,omitempty"`
+}
+
+// Rotate re-encrypts the model weights of the model store in place with a new DEK. An interrupted rotation is
+// resumed from the last file rotated, and a completed one is followed by a new rotation.
+func (e *Enigma) Rotate() error {
+	e.logger.Infof("Starting key rotation for model %s", e.Config.ModelName)
+
+	if err := e.validateModelStore(); err != nil {
+		return fmt.Errorf("model store validation failed: %w", err)
+	}
+
+	if e.Config.DisableModelDecryption {
+		return errors.New("model decryption is disabled by configuration, there is no key to rotate")
+	}
+
+	modelStorePath := e.getModelStorePath()
+	metadata, err := readRotationMetadata(modelStorePath)
+	if err != nil {
+		return err
+	}
+
+	if metadata == nil || metadata.CompletedAt != nil {
+		if metadata, err = e.startRotation(metadata); err != nil {
+			return fmt.Errorf("failed to start key rotation: %w", err)
+		}
+		if err := writeRotationMetadata(modelStorePath, metadata); err != nil {
+			return err
+		}
+	} else {
+		e.logger.Infof("Resuming key rotation started at %s, %d files already rotated",
+			metadata.StartedAt.Format(time.RFC3339), len(metadata.RotatedFiles))
+	}
+
+	previousDataKey, err := e.unwrapDataKey(metadata.PreviousCipherDataKey, metadata.PreviousKeyID)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt previous DEK using MEK: %w", err)
+	}
+	plainDataKey, err := e.unwrapDataKey(metadata.CipherDataKey, metadata.KeyID)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt new DEK using MEK: %w", err)
+	}
+
+	if err := e.reencryptModelWeights(modelStorePath, metadata, previousDataKey, plainDataKey); err != nil {
+		return fmt.Errorf("error during model weights re-encryption: %w", err)
+	}
+
+	completedAt := time.Now().UTC()
+	metadata.CompletedAt = &completedAt
+	if err := writeRotationMetadata(modelStorePath, metadata); err != nil {
+		return err
+	}
+
+	e.logger.Infof("Key rotation completed successfully, %d files rotated", len(metadata.RotatedFiles))
+	return nil
+}
+
+// startRotation generates a new DEK with the current MEK. The DEK of a completed rotation is the one being
+// replaced, otherwise it is the DEK of the OCI Vault secret.
+func (e *Enigma) startRotation(previous *RotationMetadata) (*RotationMetadata, error) {
+	masterKeyID, err := e.getMasterKeyID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve master key ID: %w", err)
+	}
+	e.logger.Infof("Master key ID retrieved: %s", *masterKeyID)
+
+	metadata := &RotationMetadata{
+		KeyID:        *masterKeyID,
+		StartedAt:    time.Now().UTC(),
+		RotatedFiles: []string{},
+	}
+	if previous != nil {
+		metadata.PreviousKeyID, metadata.PreviousCipherDataKey = previous.KeyID, previous.CipherDataKey
+	} else {
+		cipherDataKey, err := e.getCipherDataKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve cipher data key: %w", err)
+		}
+		metadata.PreviousKeyID, metadata.PreviousCipherDataKey = *masterKeyID, *cipherDataKey
+	}
+
+	dataKey, err := e.Config.KmsCryptoClient.GenerateDEK(*masterKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate new DEK: %w", err)
+	}
+	if dataKey.Ciphertext == nil {
+		return nil, errors.New("failed to generate new DEK: no ciphertext returned")
+	}
+	metadata.CipherDataKey = vault.B64Encode(*dataKey.Ciphertext)
+
+	e.logger.Infof("Generated new DEK with master key %s", *masterKeyID)
+	return metadata, nil
+}
+
+// reencryptModelWeights re-encrypts the files of the model store that are not rotated yet, one file at a time.
+// The progress is recorded in the rotation metadata after each file.
+func (e *Enigma) reencryptModelWeights(modelStorePath string, metadata *RotationMetadata, previousDataKey, plainDataKey string) error {
+	if err := recoverPendingFile(modelStorePath, metadata); err != nil {
+		return err
+	}
+	if err := e.removeIncompleteCopies(modelStorePath); err != nil {
+		return err
+	}
+
+	rotated := make(map[string]struct{}, len(metadata.RotatedFiles))
+	for _, file := range metadata.RotatedFiles {
+		rotated[file] = struct{}{}
+	}
+
+	return filepath.Walk(modelStorePath, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("error accessing path %s: %w", path, err)
+		}
+		if info.IsDir() || isIgnoredFile(info.Name()) || isUnencryptedFile(info.Name()) {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(modelStorePath, path)
+		if err != nil {
+			return err
+		}
+		if _, ok := rotated[relPath]; ok {
+			e.logger.Debugf("Skipping rotated file %s", path)
+			return nil
+		}
+
+		e.logger.Infof("Re-encrypting file %s", path)
+		if err := reencryptFile(modelStorePath, relPath, info.Mode().Perm(), metadata, previousDataKey, plainDataKey); err != nil {
+			e.logger.Errorf("Error re-encrypting file %s: %v", path, err)
+			return err
+		}
+		return nil
+	})
+}
+
+// reencryptFile writes the file encrypted with the new DEK next to the original, then replaces the original with it.
+// The file is recorded as pending during the replacement, so the rotation knows which DEK it has when interrupted.
+//
+// A file is encrypted as a single AES-GCM message, the format decrypted by enigma, which can't be authenticated
+// before it is read whole. The file is therefore re-encrypted in memory, within the buffer it is read into: the
+// rotation needs as much memory as the largest file of the model weights, like their decryption.
+func reencryptFile(modelStorePath, relPath string, perm fs.FileMode, metadata *RotationMetadata, previousDataKey, plainDataKey string) error {
+	path := filepath.Join(modelStorePath, relPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	plainText, err := openInPlace(data, previousDataKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt file %s with the previous DEK: %w", path, err)
+	}
+	if err := sealInPlace(data, plainText, plainDataKey); err != nil {
+		return fmt.Errorf("failed to encrypt file %s with the new DEK: %w", path, err)
+	}
+
+	if err := writeFileSynced(path+rotatingFileSuffix, data, perm); err != nil {
+		return err
+	}
+
+	metadata.PendingFile = relPath
+	if err := writeRotationMetadata(modelStorePath, metadata); err != nil {
+		return err
+	}
+	if err := os.Rename(path+rotatingFileSuffix, path); err != nil {
+		return fmt.Errorf("failed to replace file %s: %w", path, err)
+	}
+	metadata.PendingFile = ""
+	metadata.RotatedFiles = append(metadata.RotatedFiles, relPath)
+	return writeRotationMetadata(modelStorePath, metadata)
+}
+
+// openInPlace decrypts data, the nonce followed by the sealed content like vault.GCMEncryptWithoutCopy writes it,
+// over the sealed content
+func openInPlace(data []byte, dataKey string) ([]byte, error) {
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(sealed[:0], nonce, sealed, nil)
+}
+
+// sealInPlace encrypts the content opened by openInPlace over itself with a new nonce, so that data holds the new
+// nonce followed by the new sealed content
+func sealInPlace(data, plainText []byte, dataKey string) error {
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return err
+	}
+	if len(data) != gcm.NonceSize()+len(plainText)+gcm.Overhead() {
+		return errors.New("the content was sealed with another cipher")
+	}
+	if _, err := io.ReadFull(rand.Reader, data[:gcm.NonceSize()]); err != nil {
+		return err
+	}
+	gcm.Seal(plainText[:0], data[:gcm.NonceSize()], plainText, nil)
+	return nil
+}
+
+// newGCM returns the AES-GCM cipher of a base64 encoded DEK
+func newGCM(dataKey string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher([]byte(vault.B64Decode(dataKey)))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// recoverPendingFile settles the file a rotation was interrupted on. The file was replaced by its re-encrypted copy
+// unless the copy is still there.
+func recoverPendingFile(modelStorePath string, metadata *RotationMetadata) error {
+	if metadata.PendingFile == "" {
+		return nil
+	}
+
+	copyPath := filepath.Join(modelStorePath, metadata.PendingFile) + rotatingFileSuffix
+	_, err := os.Stat(copyPath)
+	switch {
+	case err == nil:
+		if err := os.Remove(copyPath); err != nil {
+			return fmt.Errorf("failed to remove incomplete copy %s: %w", copyPath, err)
+		}
+	case os.IsNotExist(err):
+		metadata.RotatedFiles = append(metadata.RotatedFiles, metadata.PendingFile)
+	default:
+		return fmt.Errorf("failed to check copy %s: %w", copyPath, err)
+	}
+
+	metadata.PendingFile = ""
+	return writeRotationMetadata(modelStorePath, metadata)
+}
+
+// removeIncompleteCopies removes the copies left over by a rotation interrupted before their file was replaced
+func (e *Enigma) removeIncompleteCopies(modelStorePath string) error {
+	return filepath.Walk(modelStorePath, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("error accessing path %s: %w", path, err)
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), rotatingFileSuffix) || isUnencryptedFile(info.Name()) {
+			return nil
+		}
+		e.logger.Infof("Removing incomplete copy %s", path)
+		return os.Remove(path)
+	})
+}
+
+// readRotationMetadata reads the rotation metadata of the model store, nil when the key was never rotated
+func readRotationMetadata(modelStorePath string) (*RotationMetadata, error) {
+	path := filepath.Join(modelStorePath, rotationMetadataFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rotation metadata %s: %w", path, err)
+	}
+
+	var metadata RotationMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse rotation metadata %s: %w", path, err)
+	}
+	return &metadata, nil
+}
+
+// writeRotationMetadata atomically replaces the rotation metadata of the model store
+func writeRotationMetadata(modelStorePath string, metadata *RotationMetadata) error {
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode rotation metadata: %w", err)
+	}
+
+	path := filepath.Join(modelStorePath, rotationMetadataFile)
+	if err := writeFileSynced(path+rotatingFileSuffix, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(path+rotatingFileSuffix, path); err != nil {
+		return fmt.Errorf("failed to write rotation metadata %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeFileSynced writes a file and flushes it to disk before it is renamed
+func writeFileSynced(path string, data []byte, perm fs.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write file %s: %w", path, err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to sync file %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// isUnencryptedFile checks if a file is metadata stored in plain text next to the model weights
+func isUnencryptedFile(fileName string) bool {
+	return strings.Contains(fileName, exportMetadataFile) || strings.Contains(fileName, rotationMetadataFile)
+}
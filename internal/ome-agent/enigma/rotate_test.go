@@ -0,0 +1,186 @@
+package enigma
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testingPkg "github.com/sgl-project/ome/pkg/testing"
+	"github.com/sgl-project/ome/pkg/vault"
+)
+
+// newTestDataKey returns a random base64 encoded AES-256 key, like the plain DEKs returned by KMS
+func newTestDataKey(t *testing.T) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// writeEncryptedFile writes content encrypted with dataKey to relPath of dir
+func writeEncryptedFile(t *testing.T, dir, relPath, content, dataKey string) {
+	encrypted, err := vault.GCMEncryptWithoutCopy([]byte(content), dataKey)
+	require.NoError(t, err)
+	path := filepath.Join(dir, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, encrypted, 0644))
+}
+
+// readEncryptedFile reads relPath of dir decrypted with dataKey
+func readEncryptedFile(t *testing.T, dir, relPath, dataKey string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, relPath))
+	require.NoError(t, err)
+	decrypted, err := vault.GCMDecryptWithoutCopy(data, dataKey)
+	return string(decrypted), err
+}
+
+func TestReencryptModelWeights(t *testing.T) {
+	previousDataKey, plainDataKey := newTestDataKey(t), newTestDataKey(t)
+	files := map[string]string{
+		"config.json":               `{"model_type": "llama"}`,
+		"model-00001.safetensors":   "first shard",
+		"nested/model.safetensors":  "nested shard",
+		"rotated/model.safetensors": "rotated shard",
+		"pending/model.safetensors": "pending shard",
+		"replaced/model.bin":        "replaced shard",
+	}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string, metadata *RotationMetadata)
+	}{
+		{
+			name:  "new rotation",
+			setup: func(t *testing.T, dir string, metadata *RotationMetadata) {},
+		},
+		{
+			name: "resumed rotation",
+			setup: func(t *testing.T, dir string, metadata *RotationMetadata) {
+				// A rotated file, a file interrupted before its replacement and one interrupted after it
+				writeEncryptedFile(t, dir, "rotated/model.safetensors", files["rotated/model.safetensors"], plainDataKey)
+				metadata.RotatedFiles = []string{filepath.Join("rotated", "model.safetensors")}
+				writeEncryptedFile(t, dir, "pending/model.safetensors"+rotatingFileSuffix, "incomplete", plainDataKey)
+				metadata.PendingFile = filepath.Join("pending", "model.safetensors")
+				// An incomplete copy of a file left before the file was recorded as pending
+				writeEncryptedFile(t, dir, "model-00001.safetensors"+rotatingFileSuffix, "incomplete", plainDataKey)
+			},
+		},
+		{
+			name: "interrupted after the replacement",
+			setup: func(t *testing.T, dir string, metadata *RotationMetadata) {
+				writeEncryptedFile(t, dir, "replaced/model.bin", files["replaced/model.bin"], plainDataKey)
+				metadata.PendingFile = filepath.Join("replaced", "model.bin")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for relPath, content := range files {
+				writeEncryptedFile(t, dir, relPath, content, previousDataKey)
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(dir, exportMetadataFile), []byte("plain"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ".DS_Store"), []byte("plain"), 0644))
+
+			metadata := &RotationMetadata{RotatedFiles: []string{}}
+			tt.setup(t, dir, metadata)
+
+			e := &Enigma{logger: testingPkg.SetupMockLogger()}
+			require.NoError(t, e.reencryptModelWeights(dir, metadata, previousDataKey, plainDataKey))
+
+			assert.Len(t, metadata.RotatedFiles, len(files))
+			assert.Empty(t, metadata.PendingFile)
+			for relPath, content := range files {
+				decrypted, err := readEncryptedFile(t, dir, relPath, plainDataKey)
+				require.NoError(t, err, relPath)
+				assert.Equal(t, content, decrypted)
+				assert.NoFileExists(t, filepath.Join(dir, relPath+rotatingFileSuffix))
+			}
+
+			plain, err := os.ReadFile(filepath.Join(dir, exportMetadataFile))
+			require.NoError(t, err)
+			assert.Equal(t, "plain", string(plain))
+
+			// The progress is recorded on disk
+			stored, err := readRotationMetadata(dir)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, metadata.RotatedFiles, stored.RotatedFiles)
+		})
+	}
+}
+
+func TestReencryptModelWeightsWrongKey(t *testing.T) {
+	dir := t.TempDir()
+	writeEncryptedFile(t, dir, "model.safetensors", "shard", newTestDataKey(t))
+
+	metadata := &RotationMetadata{RotatedFiles: []string{}}
+	e := &Enigma{logger: testingPkg.SetupMockLogger()}
+	err := e.reencryptModelWeights(dir, metadata, newTestDataKey(t), newTestDataKey(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decrypt file")
+	assert.Empty(t, metadata.RotatedFiles)
+	assert.NoFileExists(t, filepath.Join(dir, "model.safetensors"+rotatingFileSuffix))
+}
+
+func TestReencryptInPlace(t *testing.T) {
+	previousDataKey, plainDataKey := newTestDataKey(t), newTestDataKey(t)
+	data, err := vault.GCMEncryptWithoutCopy([]byte("shard"), previousDataKey)
+	require.NoError(t, err)
+	size := len(data)
+
+	plainText, err := openInPlace(data, previousDataKey)
+	require.NoError(t, err)
+	assert.Equal(t, "shard", string(plainText))
+	require.NoError(t, sealInPlace(data, plainText, plainDataKey))
+	assert.Len(t, data, size)
+
+	decrypted, err := vault.GCMDecryptWithoutCopy(append([]byte(nil), data...), plainDataKey)
+	require.NoError(t, err)
+	assert.Equal(t, "shard", string(decrypted))
+	_, err = openInPlace(data, previousDataKey)
+	assert.Error(t, err)
+	_, err = openInPlace(data[:8], plainDataKey)
+	assert.ErrorContains(t, err, "ciphertext too short")
+}
+
+func TestRotationMetadata(t *testing.T) {
+	dir := t.TempDir()
+	metadata, err := readRotationMetadata(dir)
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+
+	written := &RotationMetadata{
+		KeyID:                 "ocid1.key.new",
+		CipherDataKey:         "new-cipher-key",
+		PreviousKeyID:         "ocid1.key.old",
+		PreviousCipherDataKey: "old-cipher-key",
+		RotatedFiles:          []string{"model.safetensors"},
+	}
+	require.NoError(t, writeRotationMetadata(dir, written))
+	assert.NoFileExists(t, filepath.Join(dir, rotationMetadataFile+rotatingFileSuffix))
+
+	metadata, err = readRotationMetadata(dir)
+	require.NoError(t, err)
+	assert.Equal(t, written.KeyID, metadata.KeyID)
+	assert.Equal(t, written.PreviousCipherDataKey, metadata.PreviousCipherDataKey)
+	assert.Equal(t, written.RotatedFiles, metadata.RotatedFiles)
+	assert.Nil(t, metadata.CompletedAt)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, rotationMetadataFile), []byte("{"), 0644))
+	_, err = readRotationMetadata(dir)
+	assert.Error(t, err)
+}
+
+func TestIsUnencryptedFile(t *testing.T) {
+	assert.True(t, isUnencryptedFile(exportMetadataFile))
+	assert.True(t, isUnencryptedFile("model"+exportMetadataFile))
+	assert.True(t, isUnencryptedFile(rotationMetadataFile))
+	assert.True(t, isUnencryptedFile(rotationMetadataFile+rotatingFileSuffix))
+	assert.False(t, isUnencryptedFile("model.safetensors"))
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+
+	"github.com/sgl-project/ome/pkg/agentprogress"
+	"github.com/sgl-project/ome/pkg/configutils"
+	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/modelcar"
+)
+
+// RegistryConfig defines how the exporter authenticates to and reaches the registry
+type RegistryConfig struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// Token is a bearer token sent to the registry, or exchanged for one when it challenges the requests
+	Token string `mapstructure:"token"`
+	// PlainHTTP talks to the registry over HTTP instead of HTTPS, e.g. to reach a local registry
+	PlainHTTP bool `mapstructure:"plain_http"`
+}
+
+// Config defines the configuration of a model export
+type Config struct {
+	Logger   logging.Interface
+	Progress *agentprogress.Reporter
+
+	// LocalPath is the model directory to export
+	LocalPath string `mapstructure:"local_path" validate:"required"`
+	// Image is the reference the image is pushed to, e.g. modelcar://ghcr.io/acme/llama-3.2-1b:v1
+	Image    string         `mapstructure:"image" validate:"required"`
+	Registry RegistryConfig `mapstructure:"registry"`
+	// LayerSizeThreshold is the size from which a model file gets a layer of its own
+	LayerSizeThreshold int64 `mapstructure:"layer_size_threshold" validate:"gt=0"`
+	// Annotations are added to the manifest of the image, e.g. org.opencontainers.image.source
+	Annotations map[string]string `mapstructure:"annotations"`
+	// ReportPath is where the JSON result is written, the standard output if empty
+	ReportPath string `mapstructure:"report_path"`
+}
+
+// Option defines a function that applies configuration options
+type Option func(*Config) error
+
+// Apply applies the given options to the configuration
+func (c *Config) Apply(opts ...Option) error {
+	for _, o := range opts {
+		if o != nil {
+			if err := o(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// defaultConfig returns a new configuration with default values
+func defaultConfig() *Config {
+	return &Config{
+		LayerSizeThreshold: modelcar.DefaultLayerSizeThreshold,
+	}
+}
+
+// NewConfig builds and returns a new configuration from the given options
+func NewConfig(opts ...Option) (*Config, error) {
+	c := defaultConfig()
+	if err := c.Apply(opts...); err != nil {
+		return nil, fmt.Errorf("failed to apply config options: %w", err)
+	}
+	return c, nil
+}
+
+// WithLogger sets the logger for the configuration
+func WithLogger(logger logging.Interface) Option {
+	return func(c *Config) error {
+		if logger == nil {
+			return errors.New("logger cannot be nil")
+		}
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithProgress sets the reporter the progress of the push is published with
+func WithProgress(progress *agentprogress.Reporter) Option {
+	return func(c *Config) error {
+		c.Progress = progress
+		return nil
+	}
+}
+
+// WithViper loads configuration using Viper
+func WithViper(v *viper.Viper) Option {
+	return func(c *Config) error {
+		*c = *defaultConfig()
+
+		if err := configutils.BindEnvsRecursive(v, c, ""); err != nil {
+			return fmt.Errorf("error binding envs: %w", err)
+		}
+		if err := v.Unmarshal(c); err != nil {
+			return fmt.Errorf("error unmarshalling config: %w", err)
+		}
+		return nil
+	}
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(c); err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+	if _, err := modelcar.ParseReference(c.Image); err != nil {
+		return fmt.Errorf("invalid image: %w", err)
+	}
+	return nil
+}
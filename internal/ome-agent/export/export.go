@@ -0,0 +1,112 @@
+// Package export packages a local model directory into an OCI image, a modelcar, and pushes it to a registry. The
+// model files are stored below /models of the image, so that the image can be pulled by the model agent with a
+// modelcar:// storage URI, mounted as an image volume or run as a modelcar sidecar.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sgl-project/ome/pkg/agentprogress"
+	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/modelcar"
+)
+
+// Result describes the image pushed by an export
+type Result struct {
+	// Image is the reference the image is pushed to
+	Image string `json:"image"`
+	// Digest is the digest of the manifest of the image, which pins the image in a storage URI
+	Digest string `json:"digest"`
+	Layers int    `json:"layers"`
+	Size   int64  `json:"size"`
+}
+
+// Exporter exports a model directory to a registry
+type Exporter struct {
+	config *Config
+	logger logging.Interface
+	client *modelcar.Client
+}
+
+// NewExporter creates an exporter from a validated configuration
+func NewExporter(config *Config) (*Exporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if config.Logger == nil {
+		config.Logger = logging.Discard()
+	}
+	client, err := modelcar.NewClient(
+		modelcar.WithLogger(config.Logger),
+		modelcar.WithLayerSizeThreshold(config.LayerSizeThreshold),
+		modelcar.WithPlainHTTP(config.Registry.PlainHTTP),
+		modelcar.WithToken(config.Registry.Token),
+		modelcar.WithBasicAuth(config.Registry.Username, config.Registry.Password),
+		modelcar.WithProgress(progressHandler(config.Progress)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{config: config, logger: config.Logger, client: client}, nil
+}
+
+// Export builds the image of the model directory and pushes it
+func (e *Exporter) Export(ctx context.Context) (*Result, error) {
+	if info, err := os.Stat(e.config.LocalPath); err != nil {
+		return nil, fmt.Errorf("failed to read local path: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("local path %s is not a directory", e.config.LocalPath)
+	}
+	ref, err := modelcar.ParseReference(e.config.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	e.config.Progress.SetPhase(ctx, agentprogress.PhaseUploading, ref.String())
+	image, err := e.client.Export(ctx, e.config.LocalPath, ref, e.config.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	e.logger.Infof("Exported %s to %s@%s", e.config.LocalPath, ref, image.Digest())
+	return &Result{
+		Image:  ref.String(),
+		Digest: image.Digest(),
+		Layers: len(image.Layers),
+		Size:   image.Manifest.TotalSize(),
+	}, nil
+}
+
+// WriteResult writes the JSON result of an export to the report path or the standard output
+func (e *Exporter) WriteResult(result *Result) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal export result: %w", err)
+	}
+	data = append(data, '\n')
+	if e.config.ReportPath == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(e.config.ReportPath, data, 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write export result: %w", err)
+	}
+	return nil
+}
+
+// progressHandler publishes the bytes of the layers pushed. The reporter only adds up, the handler adds the bytes
+// pushed since its last call.
+func progressHandler(progress *agentprogress.Reporter) func(modelcar.Progress) {
+	var reported int64
+	return func(p modelcar.Progress) {
+		if reported == 0 {
+			progress.SetTotal(p.TotalBytes, "bytes")
+		}
+		progress.Add(p.CompletedBytes - reported)
+		reported = p.CompletedBytes
+	}
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/agentprogress"
+)
+
+// newRegistry serves a registry accepting every push and records the manifests pushed
+func newRegistry(t *testing.T, manifests map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead:
+			http.NotFound(w, r)
+		case r.Method == http.MethodPost:
+			w.Header().Set("Location", r.URL.Path+"session")
+			w.WriteHeader(http.StatusAccepted)
+		case strings.Contains(r.URL.Path, "/manifests/"):
+			data, _ := io.ReadAll(r.Body)
+			manifests[r.URL.Path] = string(data)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExport(t *testing.T) {
+	modelDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(modelDir, "config.json"), []byte(`{"model_type":"llama"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(modelDir, "model.safetensors"), make([]byte, 2048), 0644))
+
+	manifests := map[string]string{}
+	server := newRegistry(t, manifests)
+	progressPath := filepath.Join(t.TempDir(), "export.json")
+	reportPath := filepath.Join(t.TempDir(), "result.json")
+
+	v := viper.New()
+	v.Set("local_path", modelDir)
+	v.Set("image", "modelcar://"+strings.TrimPrefix(server.URL, "http://")+"/acme/llama:v1")
+	v.Set("registry.plain_http", true)
+	v.Set("layer_size_threshold", 1024)
+	v.Set("report_path", reportPath)
+	config, err := NewConfig(WithViper(v), WithProgress(agentprogress.NewReporter("export", time.Second, nil,
+		&agentprogress.FilePublisher{Path: progressPath})))
+	require.NoError(t, err)
+	exporter, err := NewExporter(config)
+	require.NoError(t, err)
+
+	result, err := exporter.Export(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Layers)
+	assert.Contains(t, manifests, "/v2/acme/llama/manifests/v1")
+	require.NoError(t, exporter.WriteResult(result))
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var written Result
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, *result, written)
+	assert.True(t, strings.HasPrefix(written.Digest, "sha256:"))
+
+	config.Progress.Publish(context.Background())
+	heartbeat, err := agentprogress.ReadFile(progressPath)
+	require.NoError(t, err)
+	assert.Equal(t, agentprogress.PhaseUploading, heartbeat.Phase)
+	assert.Equal(t, result.Size, heartbeat.Completed)
+	assert.Equal(t, result.Size, heartbeat.Total)
+}
+
+func TestConfigValidate(t *testing.T) {
+	config, err := NewConfig(WithViper(viper.New()))
+	require.NoError(t, err)
+	assert.ErrorContains(t, config.Validate(), "LocalPath")
+
+	config.LocalPath = "/models/llama"
+	config.Image = "modelcar://ghcr.io/Acme/llama"
+	assert.ErrorContains(t, config.Validate(), "invalid image")
+
+	config.Image = "ghcr.io/acme/llama:v1"
+	assert.NoError(t, config.Validate())
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/pkg/agentprogress"
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+type exportParams struct {
+	fx.In
+
+	Logger   logging.Interface
+	Progress *agentprogress.Reporter `optional:"true"`
+}
+
+// Module provides the model exporter via fx
+var Module = fx.Provide(
+	func(v *viper.Viper, params exportParams) (*Exporter, error) {
+		config, err := NewConfig(
+			WithViper(v),
+			WithLogger(params.Logger),
+			WithProgress(params.Progress),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating export config: %+v", err)
+		}
+		return NewExporter(config)
+	})
@@ -19,6 +19,10 @@ type Config struct {
 	BaseModelName      string `mapstructure:"basemodel_name" validate:"required"`
 	BaseModelNamespace string `mapstructure:"basemodel_namespace"`
 	ClusterScoped      bool   `mapstructure:"cluster_scoped"`
+	// VerifyIntegrity verifies the model directory before extracting its metadata and records its digest
+	VerifyIntegrity bool `mapstructure:"verify_integrity"`
+	// PublicKeyPath is the public key the manifest of the model directory must be signed with when set
+	PublicKeyPath string `mapstructure:"public_key_path"`
 }
 
 // Option defines a function that applies configuration options
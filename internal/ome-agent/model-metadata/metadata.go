@@ -5,7 +5,7 @@
 // The controller will:
 // 1. Create a Job with the model PVC mounted
 // 2. Pass the model path and BaseModel details via command-line flags
-// 3. The agent will extract metadata and update the CR
+// 3. The agent will extract metadata and patch the spec, annotations and status of the CR
 //
 // Example invocation:
 //
@@ -18,15 +18,22 @@ package modelmetadata
 
 import (
 	"context"
+	"encoding/json"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/sgl-project/ome/internal/ome-agent/verify"
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/hfutil/modelconfig"
 	"github.com/sgl-project/ome/pkg/logging"
 )
@@ -36,6 +43,8 @@ type MetadataExtractor struct {
 	fs     afero.Fs
 	client client.Client
 	logger logging.Interface
+	// modelDigest is the digest of the model directory when its integrity is verified
+	modelDigest string
 }
 
 func NewMetadataExtractor(config *Config, fs afero.Fs, client client.Client) (*MetadataExtractor, error) {
@@ -54,6 +63,12 @@ func NewMetadataExtractor(config *Config, fs afero.Fs, client client.Client) (*M
 func (m *MetadataExtractor) Start() error {
 	m.logger.Infof("Starting model metadata extraction for model at %s", m.config.ModelPath)
 
+	if m.config.VerifyIntegrity {
+		if err := m.verifyModel(); err != nil {
+			return err
+		}
+	}
+
 	// Try different config file names
 	configFiles := []string{"config.json", "model_config.json", "configuration.json"}
 
@@ -71,8 +86,15 @@ func (m *MetadataExtractor) Start() error {
 		}
 	}
 
+	// llama.cpp models carry their configuration in the header of their GGUF file
+	if configPath == "" {
+		if ggufPath, err := modelconfig.FindGGUFFile(m.config.ModelPath); err == nil {
+			configPath = ggufPath
+		}
+	}
+
 	if configPath == "" {
-		return errors.New("no model config file found (tried: config.json, model_config.json, configuration.json)")
+		return errors.New("no model config file found (tried: config.json, model_config.json, configuration.json, *.gguf)")
 	}
 
 	m.logger.Infof("Found model config at %s", configPath)
@@ -85,12 +107,12 @@ func (m *MetadataExtractor) Start() error {
 
 	// Update the CR
 	if m.config.ClusterScoped {
-		return m.updateClusterBaseModel(model)
+		return m.updateClusterBaseModel(model, configPath)
 	}
-	return m.updateBaseModel(model)
+	return m.updateBaseModel(model, configPath)
 }
 
-func (m *MetadataExtractor) updateBaseModel(model modelconfig.HuggingFaceModel) error {
+func (m *MetadataExtractor) updateBaseModel(model modelconfig.HuggingFaceModel, configPath string) error {
 	ctx := context.Background()
 
 	// Fetch the BaseModel
@@ -103,24 +125,33 @@ func (m *MetadataExtractor) updateBaseModel(model modelconfig.HuggingFaceModel)
 		return errors.Wrapf(err, "failed to get BaseModel %s/%s", m.config.BaseModelNamespace, m.config.BaseModelName)
 	}
 
-	// Update spec with extracted metadata
+	// Patch the spec with extracted metadata and record the extraction in the annotations. A merge patch only
+	// carries the changed fields, so it doesn't conflict with the controller updating the BaseModel meanwhile.
+	original := baseModel.DeepCopy()
 	updated := m.updateSpec(&baseModel.Spec, model)
-	if !updated {
+	if m.setExtractionAnnotations(baseModel, configPath, storageURI(&baseModel.Spec), updated) {
+		if err := m.client.Patch(ctx, baseModel, client.MergeFrom(original)); err != nil {
+			return errors.Wrapf(err, "failed to update BaseModel %s/%s", m.config.BaseModelNamespace, m.config.BaseModelName)
+		}
+	}
+	if updated {
+		m.logger.Infof("Successfully updated BaseModel %s/%s", m.config.BaseModelNamespace, m.config.BaseModelName)
+	} else {
 		m.logger.Info("No updates needed for BaseModel spec")
-		return nil
 	}
 
-	// Update the BaseModel
-	err = m.client.Update(ctx, baseModel)
-	if err != nil {
-		return errors.Wrapf(err, "failed to update BaseModel %s/%s", m.config.BaseModelNamespace, m.config.BaseModelName)
+	// Surface the inconsistencies of the model config in the status
+	original = baseModel.DeepCopy()
+	if !m.updateConfigWarnings(&baseModel.Status, model) {
+		return nil
+	}
+	if err := m.client.Status().Patch(ctx, baseModel, client.MergeFrom(original)); err != nil {
+		return errors.Wrapf(err, "failed to update status of BaseModel %s/%s", m.config.BaseModelNamespace, m.config.BaseModelName)
 	}
-
-	m.logger.Infof("Successfully updated BaseModel %s/%s", m.config.BaseModelNamespace, m.config.BaseModelName)
 	return nil
 }
 
-func (m *MetadataExtractor) updateClusterBaseModel(model modelconfig.HuggingFaceModel) error {
+func (m *MetadataExtractor) updateClusterBaseModel(model modelconfig.HuggingFaceModel, configPath string) error {
 	ctx := context.Background()
 
 	// Fetch the ClusterBaseModel
@@ -130,23 +161,91 @@ func (m *MetadataExtractor) updateClusterBaseModel(model modelconfig.HuggingFace
 		return errors.Wrapf(err, "failed to get ClusterBaseModel %s", m.config.BaseModelName)
 	}
 
-	// Update spec with extracted metadata
+	// Patch the spec with extracted metadata and record the extraction in the annotations
+	original := clusterBaseModel.DeepCopy()
 	updated := m.updateSpec(&clusterBaseModel.Spec, model)
-	if !updated {
+	if m.setExtractionAnnotations(clusterBaseModel, configPath, storageURI(&clusterBaseModel.Spec), updated) {
+		if err := m.client.Patch(ctx, clusterBaseModel, client.MergeFrom(original)); err != nil {
+			return errors.Wrapf(err, "failed to update ClusterBaseModel %s", m.config.BaseModelName)
+		}
+	}
+	if updated {
+		m.logger.Infof("Successfully updated ClusterBaseModel %s", m.config.BaseModelName)
+	} else {
 		m.logger.Info("No updates needed for ClusterBaseModel spec")
+	}
+
+	// Surface the inconsistencies of the model config in the status
+	original = clusterBaseModel.DeepCopy()
+	if !m.updateConfigWarnings(&clusterBaseModel.Status, model) {
 		return nil
 	}
+	if err := m.client.Status().Patch(ctx, clusterBaseModel, client.MergeFrom(original)); err != nil {
+		return errors.Wrapf(err, "failed to update status of ClusterBaseModel %s", m.config.BaseModelName)
+	}
+	return nil
+}
 
-	// Update the ClusterBaseModel
-	err = m.client.Update(ctx, clusterBaseModel)
+// verifyModel verifies the integrity of the model directory and keeps its digest for the annotations
+func (m *MetadataExtractor) verifyModel() error {
+	config, err := verify.NewConfig(
+		verify.WithLogger(m.logger),
+		verify.WithModelPath(m.config.ModelPath),
+		verify.WithPublicKeyPath(m.config.PublicKeyPath),
+		func(c *verify.Config) error {
+			c.RequireSignature = m.config.PublicKeyPath != ""
+			return nil
+		},
+	)
 	if err != nil {
-		return errors.Wrapf(err, "failed to update ClusterBaseModel %s", m.config.BaseModelName)
+		return errors.Wrap(err, "failed to create verify config")
 	}
-
-	m.logger.Infof("Successfully updated ClusterBaseModel %s", m.config.BaseModelName)
+	verifier, err := verify.NewVerifier(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create verifier")
+	}
+	report, err := verifier.Verify()
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify model at %s", m.config.ModelPath)
+	}
+	if !report.OK() {
+		for _, failure := range report.Failures() {
+			m.logger.Errorf("File %s failed the verification: %s %s", failure.Path, failure.Status, failure.Error)
+		}
+		return errors.Errorf("model at %s failed the integrity verification: %d corrupted files, signature %s",
+			m.config.ModelPath, len(report.Failures()), report.Signature.Status)
+	}
+	m.modelDigest = report.Digest
 	return nil
 }
 
+// setExtractionAnnotations records when the metadata was extracted, from which config file and from which storage
+// URI, so the extraction result can be read from the model itself rather than from the Job that ran it, and the digest
+// of the verified model. The extraction time only changes with what was extracted, so that running the extraction
+// again doesn't modify the model. It reports whether the annotations changed.
+func (m *MetadataExtractor) setExtractionAnnotations(obj client.Object, configPath, storageURI string, specUpdated bool) bool {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	source := filepath.Base(configPath)
+	_, extracted := annotations[constants.ModelMetadataExtractedAtAnnotationKey]
+	if extracted && !specUpdated && annotations[constants.ModelMetadataSourceAnnotationKey] == source &&
+		annotations[constants.ModelMetadataStorageURIAnnotationKey] == storageURI &&
+		(m.modelDigest == "" || annotations[constants.ModelDigestAnnotationKey] == m.modelDigest) {
+		return false
+	}
+
+	annotations[constants.ModelMetadataExtractedAtAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+	annotations[constants.ModelMetadataSourceAnnotationKey] = source
+	annotations[constants.ModelMetadataStorageURIAnnotationKey] = storageURI
+	if m.modelDigest != "" {
+		annotations[constants.ModelDigestAnnotationKey] = m.modelDigest
+	}
+	obj.SetAnnotations(annotations)
+	return true
+}
+
 func (m *MetadataExtractor) updateSpec(spec *v1beta1.BaseModelSpec, model modelconfig.HuggingFaceModel) bool {
 	if spec == nil || model == nil {
 		return false
@@ -192,6 +291,24 @@ func (m *MetadataExtractor) updateSpec(spec *v1beta1.BaseModelSpec, model modelc
 		}
 	}
 
+	// GGUF models are served by llama.cpp from their GGUF file
+	if gguf, ok := model.(*modelconfig.GGUFModelConfig); ok {
+		if spec.ModelFramework == nil {
+			spec.ModelFramework = &v1beta1.ModelFrameworkSpec{
+				Name: "llama.cpp",
+			}
+			updated = true
+		}
+		if spec.ModelFormat.Name == "" {
+			version := strconv.FormatUint(uint64(gguf.Version), 10)
+			spec.ModelFormat = v1beta1.ModelFormat{
+				Name:    "gguf",
+				Version: &version,
+			}
+			updated = true
+		}
+	}
+
 	// Framework (default to pytorch for HF models)
 	if spec.ModelFramework == nil {
 		spec.ModelFramework = &v1beta1.ModelFrameworkSpec{
@@ -209,9 +326,58 @@ func (m *MetadataExtractor) updateSpec(spec *v1beta1.BaseModelSpec, model modelc
 		updated = true
 	}
 
+	// Normalized metadata document of the model. Unlike the fields above, it isn't set by users, so it is computed
+	// again on every extraction to follow the files of the model.
+	configJSON, err := modelconfig.Canonicalize(model).Marshal()
+	if err != nil {
+		m.logger.Warnf("Failed to marshal model configuration: %v", err)
+	} else if !sameJSON(spec.ModelConfiguration.Raw, configJSON) {
+		spec.ModelConfiguration = runtime.RawExtension{Raw: configJSON}
+		updated = true
+	}
+
 	return updated
 }
 
+// sameJSON reports whether two JSON documents hold the same values. The API server doesn't keep the order of the
+// keys of the documents it stores.
+func sameJSON(a, b []byte) bool {
+	var valueA, valueB any
+	if json.Unmarshal(a, &valueA) != nil || json.Unmarshal(b, &valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+// storageURI returns the storage URI of a model, empty when unset
+func storageURI(spec *v1beta1.BaseModelSpec) string {
+	if spec.Storage == nil || spec.Storage.StorageUri == nil {
+		return ""
+	}
+	return *spec.Storage.StorageUri
+}
+
+// updateConfigWarnings sets the config warnings of the status to the inconsistencies found in the config of the
+// model, and reports whether they changed
+func (m *MetadataExtractor) updateConfigWarnings(status *v1beta1.ModelStatusSpec, model modelconfig.HuggingFaceModel) bool {
+	found, err := modelconfig.ValidateModel(model)
+	if err != nil {
+		m.logger.Warnf("Failed to validate model config: %v", err)
+		return false
+	}
+
+	var warnings []v1beta1.ModelConfigWarning
+	for _, w := range found {
+		m.logger.Warnf("Inconsistent model config: %s", w)
+		warnings = append(warnings, v1beta1.ModelConfigWarning{Field: w.Field, Message: w.Message})
+	}
+	if reflect.DeepEqual(status.ConfigWarnings, warnings) {
+		return false
+	}
+	status.ConfigWarnings = warnings
+	return true
+}
+
 func (m *MetadataExtractor) inferCapabilities(model modelconfig.HuggingFaceModel) []string {
 	var capabilities []string
 
@@ -2,7 +2,11 @@ package modelmetadata
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,8 +15,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/hfutil/modelconfig"
 	"github.com/sgl-project/ome/pkg/logging"
 )
@@ -22,25 +28,31 @@ type mockHuggingFaceModel struct {
 	modelType          string
 	architecture       string
 	parameterCount     int64
+	activeParameters   int64
+	numExperts         int
 	contextLength      int
 	transformerVersion string
 	quantizationType   string
+	bitsPerWeight      float64
 	torchDtype         string
 	modelSizeBytes     int64
 	hasVision          bool
 	isEmbedding        bool
 }
 
-func (m *mockHuggingFaceModel) GetModelType() string          { return m.modelType }
-func (m *mockHuggingFaceModel) GetArchitecture() string       { return m.architecture }
-func (m *mockHuggingFaceModel) GetParameterCount() int64      { return m.parameterCount }
-func (m *mockHuggingFaceModel) GetContextLength() int         { return m.contextLength }
-func (m *mockHuggingFaceModel) GetTransformerVersion() string { return m.transformerVersion }
-func (m *mockHuggingFaceModel) GetQuantizationType() string   { return m.quantizationType }
-func (m *mockHuggingFaceModel) GetTorchDtype() string         { return m.torchDtype }
-func (m *mockHuggingFaceModel) GetModelSizeBytes() int64      { return m.modelSizeBytes }
-func (m *mockHuggingFaceModel) HasVision() bool               { return m.hasVision }
-func (m *mockHuggingFaceModel) IsEmbedding() bool             { return m.isEmbedding }
+func (m *mockHuggingFaceModel) GetModelType() string           { return m.modelType }
+func (m *mockHuggingFaceModel) GetArchitecture() string        { return m.architecture }
+func (m *mockHuggingFaceModel) GetParameterCount() int64       { return m.parameterCount }
+func (m *mockHuggingFaceModel) GetActiveParameterCount() int64 { return m.activeParameters }
+func (m *mockHuggingFaceModel) GetNumExperts() int             { return m.numExperts }
+func (m *mockHuggingFaceModel) GetContextLength() int          { return m.contextLength }
+func (m *mockHuggingFaceModel) GetTransformerVersion() string  { return m.transformerVersion }
+func (m *mockHuggingFaceModel) GetQuantizationType() string    { return m.quantizationType }
+func (m *mockHuggingFaceModel) GetBitsPerWeight() float64      { return m.bitsPerWeight }
+func (m *mockHuggingFaceModel) GetTorchDtype() string          { return m.torchDtype }
+func (m *mockHuggingFaceModel) GetModelSizeBytes() int64       { return m.modelSizeBytes }
+func (m *mockHuggingFaceModel) HasVision() bool                { return m.hasVision }
+func (m *mockHuggingFaceModel) IsEmbedding() bool              { return m.isEmbedding }
 
 func TestMetadataExtractor_updateSpec(t *testing.T) {
 	zapLogger, _ := zap.NewDevelopment()
@@ -75,6 +87,35 @@ func TestMetadataExtractor_updateSpec(t *testing.T) {
 				assert.Equal(t, "transformers", spec.ModelFramework.Name)
 				assert.Equal(t, "float16", spec.ModelFormat.Name)
 				assert.Contains(t, spec.ModelCapabilities, "text-generation")
+
+				var configuration modelconfig.CanonicalConfig
+				require.NoError(t, json.Unmarshal(spec.ModelConfiguration.Raw, &configuration))
+				assert.Equal(t, modelconfig.CanonicalConfigSchemaVersion, configuration.SchemaVersion)
+				assert.Equal(t, "7B", configuration.ParameterCount)
+				assert.Equal(t, modelconfig.ModelTaskGeneration, configuration.Task)
+			},
+		},
+		{
+			name: "refresh the model configuration",
+			initialSpec: &v1beta1.BaseModelSpec{
+				ModelType:          stringPtr("llama"),
+				ModelArchitecture:  stringPtr("LlamaForCausalLM"),
+				ModelParameterSize: stringPtr("7B"),
+				ModelConfiguration: runtime.RawExtension{Raw: []byte(`{"schema_version":"v1","parameter_count":"7B"}`)},
+			},
+			model: &mockHuggingFaceModel{
+				modelType:          "llama",
+				architecture:       "LlamaForCausalLM",
+				parameterCount:     8000000000,
+				transformerVersion: "4.43.0",
+			},
+			expectedUpdate: true,
+			validate: func(t *testing.T, spec *v1beta1.BaseModelSpec) {
+				// The parameter size set by the spec is kept, the configuration follows the files of the model
+				assert.Equal(t, "7B", *spec.ModelParameterSize)
+				var configuration modelconfig.CanonicalConfig
+				require.NoError(t, json.Unmarshal(spec.ModelConfiguration.Raw, &configuration))
+				assert.Equal(t, "8B", configuration.ParameterCount)
 			},
 		},
 		{
@@ -99,6 +140,23 @@ func TestMetadataExtractor_updateSpec(t *testing.T) {
 				assert.Equal(t, "7B", *spec.ModelParameterSize)
 			},
 		},
+		{
+			name:        "gguf model",
+			initialSpec: &v1beta1.BaseModelSpec{},
+			model: func() modelconfig.HuggingFaceModel {
+				model := &modelconfig.GGUFModelConfig{Version: 3, FileType: "Q4_K_M"}
+				model.ModelType = "llama"
+				model.Architectures = []string{"LlamaForCausalLM"}
+				return model
+			}(),
+			expectedUpdate: true,
+			validate: func(t *testing.T, spec *v1beta1.BaseModelSpec) {
+				assert.Equal(t, "llama.cpp", spec.ModelFramework.Name)
+				assert.Equal(t, "gguf", spec.ModelFormat.Name)
+				assert.Equal(t, "3", *spec.ModelFormat.Version)
+				assert.Contains(t, spec.ModelCapabilities, "text-generation")
+			},
+		},
 		{
 			name:        "vision model capabilities",
 			initialSpec: &v1beta1.BaseModelSpec{},
@@ -145,6 +203,12 @@ func TestMetadataExtractor_updateSpec(t *testing.T) {
 			if tt.validate != nil {
 				tt.validate(t, tt.initialSpec)
 			}
+
+			// Extracting the metadata again changes nothing, whatever the order of the keys of the stored configuration
+			var configuration map[string]any
+			require.NoError(t, json.Unmarshal(tt.initialSpec.ModelConfiguration.Raw, &configuration))
+			tt.initialSpec.ModelConfiguration.Raw, _ = json.Marshal(configuration)
+			assert.False(t, extractor.updateSpec(tt.initialSpec, tt.model))
 		})
 	}
 }
@@ -190,7 +254,7 @@ func TestMetadataExtractor_updateBaseModel(t *testing.T) {
 	}
 
 	// Execute
-	err := extractor.updateBaseModel(model)
+	err := extractor.updateBaseModel(model, "/model/config.json")
 	require.NoError(t, err)
 
 	// Verify
@@ -205,8 +269,186 @@ func TestMetadataExtractor_updateBaseModel(t *testing.T) {
 	assert.Equal(t, "LlamaForCausalLM", *updatedModel.Spec.ModelArchitecture)
 	assert.Equal(t, "7B", *updatedModel.Spec.ModelParameterSize)
 	assert.Equal(t, int32(4096), *updatedModel.Spec.MaxTokens)
+	assert.Equal(t, "config.json", updatedModel.Annotations[constants.ModelMetadataSourceAnnotationKey])
+	assert.NotEmpty(t, updatedModel.Annotations[constants.ModelMetadataExtractedAtAnnotationKey])
+}
+
+func TestMetadataExtractor_updateClusterBaseModel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+
+	maxTokens := int32(8192)
+	clusterBaseModel := &v1beta1.ClusterBaseModel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-model",
+			Annotations: map[string]string{"team": "inference"},
+		},
+		Spec: v1beta1.BaseModelSpec{MaxTokens: &maxTokens},
+	}
+	// The controller updates the model between the extractor reading and patching it
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(clusterBaseModel).
+		WithStatusSubresource(clusterBaseModel).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				current := &v1beta1.ClusterBaseModel{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+					return err
+				}
+				current.Spec.ApiCapabilities = []string{"OPENAI_V1_CHAT_COMPLETIONS"}
+				if err := c.Update(ctx, current); err != nil {
+					return err
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	logger := logging.Discard()
+	extractor := &MetadataExtractor{
+		config: &Config{
+			BaseModelName: "test-model",
+			ClusterScoped: true,
+			Logger:        logger,
+		},
+		client: fakeClient,
+		logger: logger,
+	}
+
+	model := &mockHuggingFaceModel{
+		modelType:      "llama",
+		architecture:   "LlamaForCausalLM",
+		parameterCount: 7000000000,
+		contextLength:  4096,
+		hasVision:      true,
+	}
+	require.NoError(t, extractor.updateClusterBaseModel(model, "/model/model.gguf"))
+
+	updatedModel := &v1beta1.ClusterBaseModel{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: "test-model"}, updatedModel))
+	assert.Equal(t, "llama", *updatedModel.Spec.ModelType)
+	assert.Equal(t, int32(8192), *updatedModel.Spec.MaxTokens)
+	assert.Equal(t, []string{"OPENAI_V1_CHAT_COMPLETIONS"}, updatedModel.Spec.ApiCapabilities)
+	assert.Equal(t, []string{"vision", "text-generation"}, updatedModel.Spec.ModelCapabilities)
+	assert.Equal(t, "inference", updatedModel.Annotations["team"])
+	assert.Equal(t, "model.gguf", updatedModel.Annotations[constants.ModelMetadataSourceAnnotationKey])
+	_, err := time.Parse(time.RFC3339, updatedModel.Annotations[constants.ModelMetadataExtractedAtAnnotationKey])
+	assert.NoError(t, err)
+}
+
+func TestMetadataExtractor_updateBaseModelConfigWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+
+	baseModel := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-model",
+			Namespace: "default",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(baseModel).
+		WithStatusSubresource(baseModel).
+		Build()
+
+	logger := logging.Discard()
+	extractor := &MetadataExtractor{
+		config: &Config{
+			BaseModelName:      "test-model",
+			BaseModelNamespace: "default",
+			Logger:             logger,
+		},
+		client: fakeClient,
+		logger: logger,
+	}
+
+	// 32 attention heads can't be grouped over 6 key-value heads
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "some_model",
+		"architectures": ["SomeModelForCausalLM"],
+		"hidden_size": 4096,
+		"num_hidden_layers": 32,
+		"num_attention_heads": 32,
+		"num_key_value_heads": 6,
+		"max_position_embeddings": 4096
+	}`
+	require.NoError(t, os.WriteFile(configPath, []byte(configJSON), 0644))
+	model, err := modelconfig.LoadModelConfig(configPath)
+	require.NoError(t, err)
+
+	require.NoError(t, extractor.updateBaseModel(model, configPath))
+
+	updatedModel := &v1beta1.BaseModel{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{
+		Name:      "test-model",
+		Namespace: "default",
+	}, updatedModel))
+	require.Len(t, updatedModel.Status.ConfigWarnings, 1)
+	assert.Equal(t, "num_key_value_heads", updatedModel.Status.ConfigWarnings[0].Field)
+	assert.Equal(t, "SomeModelForCausalLM", *updatedModel.Spec.ModelArchitecture)
+}
+
+func TestMetadataExtractor_setExtractionAnnotations(t *testing.T) {
+	logger := logging.Discard()
+	extractor := &MetadataExtractor{config: &Config{Logger: logger}, logger: logger}
+	extractedAt := "2026-01-02T03:04:05Z"
+	baseModel := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			constants.ModelMetadataExtractedAtAnnotationKey: extractedAt,
+			constants.ModelMetadataSourceAnnotationKey:      "config.json",
+			constants.ModelMetadataStorageURIAnnotationKey:  "pvc://weights/llama-3",
+		}},
+	}
+
+	// Extracting the same metadata again leaves the model unchanged
+	assert.False(t, extractor.setExtractionAnnotations(baseModel, "/model/config.json", "pvc://weights/llama-3", false))
+	assert.Equal(t, extractedAt, baseModel.Annotations[constants.ModelMetadataExtractedAtAnnotationKey])
+
+	// Extracting other metadata records the time of the extraction
+	assert.True(t, extractor.setExtractionAnnotations(baseModel, "/model/config.json", "pvc://weights/llama-3", true))
+	assert.NotEqual(t, extractedAt, baseModel.Annotations[constants.ModelMetadataExtractedAtAnnotationKey])
+	baseModel.Annotations[constants.ModelMetadataExtractedAtAnnotationKey] = extractedAt
+	assert.True(t, extractor.setExtractionAnnotations(baseModel, "/model/model.gguf", "pvc://weights/llama-3", false))
+	assert.Equal(t, "model.gguf", baseModel.Annotations[constants.ModelMetadataSourceAnnotationKey])
+	baseModel.Annotations[constants.ModelMetadataExtractedAtAnnotationKey] = extractedAt
+	assert.True(t, extractor.setExtractionAnnotations(baseModel, "/model/model.gguf", "pvc://weights/llama-3.1", false))
+	assert.Equal(t, "pvc://weights/llama-3.1", baseModel.Annotations[constants.ModelMetadataStorageURIAnnotationKey])
+	baseModel.Annotations[constants.ModelMetadataExtractedAtAnnotationKey] = extractedAt
+	extractor.modelDigest = "abc"
+	assert.True(t, extractor.setExtractionAnnotations(baseModel, "/model/model.gguf", "pvc://weights/llama-3.1", false))
+	assert.Equal(t, "abc", baseModel.Annotations[constants.ModelDigestAnnotationKey])
 }
 
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestMetadataExtractor_verifyModel(t *testing.T) {
+	modelPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(modelPath, "config.json"), []byte(`{"model_type": "llama"}`), 0644))
+
+	logger := logging.Discard()
+	extractor := &MetadataExtractor{
+		config: &Config{ModelPath: modelPath, VerifyIntegrity: true, Logger: logger},
+		logger: logger,
+	}
+	require.NoError(t, extractor.verifyModel())
+	assert.Len(t, extractor.modelDigest, 64)
+
+	// The digest is recorded with the extraction annotations
+	baseModel := &v1beta1.BaseModel{}
+	assert.True(t, extractor.setExtractionAnnotations(baseModel, filepath.Join(modelPath, "config.json"), "", false))
+	assert.Equal(t, extractor.modelDigest, baseModel.Annotations[constants.ModelDigestAnnotationKey])
+
+	// A model not matching its manifest is rejected
+	manifest := "0000000000000000000000000000000000000000000000000000000000000000  config.json\n"
+	require.NoError(t, os.WriteFile(filepath.Join(modelPath, "model.manifest"), []byte(manifest), 0644))
+	extractor.modelDigest = ""
+	err := extractor.verifyModel()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed the integrity verification")
+	assert.Empty(t, extractor.modelDigest)
+}
@@ -0,0 +1,144 @@
+package replica
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sgl-project/ome/internal/ome-agent/replica/replicator"
+	"github.com/sgl-project/ome/pkg/ociobjectstore"
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+// compareChecksums compares the checksums of the source objects with those of their copies of the same size in the
+// target, when the listings don't hold comparable MD5s: the MD5 of a multipart upload is read from the metadata of the
+// object, the MD5 of a file is computed, and the git object ID of a Hugging Face file is compared with those of the
+// content of its copy. The copies found to differ are removed from the target manifest.
+func (r *ReplicaAgent) compareChecksums(source, target Manifest) error {
+	for path, entry := range source {
+		copied, ok := target[path]
+		if !ok || entry.Size != copied.Size || (comparableMD5(entry.MD5) && comparableMD5(copied.MD5)) {
+			continue
+		}
+		same, known, err := r.sameContent(entry, copied)
+		if err != nil {
+			return fmt.Errorf("failed to compare the checksums of %s: %w", path, err)
+		}
+		if known && !same {
+			delete(target, path)
+		}
+	}
+	return nil
+}
+
+// sameContent reports whether an object and its copy have the same content, and whether their checksums were known
+func (r *ReplicaAgent) sameContent(entry, copied ManifestEntry) (same, known bool, err error) {
+	if entry.GitOID != "" {
+		oids, complete, err := r.targetGitOIDs(copied)
+		if err != nil {
+			return false, false, err
+		}
+		same = oids[entry.GitOID]
+		return same, same || complete, nil
+	}
+
+	sourceMD5, targetMD5 := entry.MD5, copied.MD5
+	if !comparableMD5(sourceMD5) {
+		if sourceMD5, err = r.checksumMD5(r.ReplicationInput.SourceStorageType, r.Config.Source.OCIOSDataStore, r.ReplicationInput.Source, entry.location); err != nil {
+			return false, false, err
+		}
+	}
+	if !comparableMD5(targetMD5) {
+		if targetMD5, err = r.checksumMD5(r.ReplicationInput.TargetStorageType, r.Config.Target.OCIOSDataStore, r.ReplicationInput.Target, copied.location); err != nil {
+			return false, false, err
+		}
+	}
+	if sourceMD5 == "" || targetMD5 == "" {
+		return false, false, nil
+	}
+	return sourceMD5 == targetMD5, true, nil
+}
+
+// checksumMD5 returns the base64 encoded MD5 of the content of an object, empty when unknown
+func (r *ReplicaAgent) checksumMD5(storageType storage.StorageType, dataStore *ociobjectstore.OCIOSDataStore, uri ociobjectstore.ObjectURI, location string) (string, error) {
+	switch storageType {
+	case storage.StorageTypeOCI:
+		if dataStore == nil {
+			return "", nil
+		}
+		head, err := dataStore.HeadObject(ociobjectstore.ObjectURI{Namespace: uri.Namespace, BucketName: uri.BucketName, ObjectName: location})
+		if err != nil {
+			return "", err
+		}
+		if head.ContentMd5 != nil && comparableMD5(*head.ContentMd5) {
+			return *head.ContentMd5, nil
+		}
+		// The MD5 of the content of a multipart upload is recorded in its metadata, see replicator.OCIObjectMD5MetadataKey
+		return head.OpcMeta["md5"], nil
+	case storage.StorageTypePVC:
+		return replicator.GetFileChecksum(location, replicator.MD5ChecksumAlgorithm)
+	default:
+		return "", nil
+	}
+}
+
+// targetGitOIDs returns the git object IDs the copy of a Hugging Face file may have: the ID of its content, and the ID
+// of the LFS pointer to its content. It reports whether they are complete, as only the ID of the LFS pointer is known
+// for an object whose SHA256 is recorded in its metadata.
+func (r *ReplicaAgent) targetGitOIDs(copied ManifestEntry) (map[string]bool, bool, error) {
+	switch r.ReplicationInput.TargetStorageType {
+	case storage.StorageTypePVC:
+		blobOID, sha256Sum, err := fileGitChecksums(copied.location, copied.Size)
+		if err != nil {
+			return nil, false, err
+		}
+		return map[string]bool{blobOID: true, lfsPointerOID(sha256Sum, copied.Size): true}, true, nil
+	case storage.StorageTypeOCI:
+		if r.Config.Target.OCIOSDataStore == nil {
+			return nil, false, nil
+		}
+		head, err := r.Config.Target.OCIOSDataStore.HeadObject(ociobjectstore.ObjectURI{
+			Namespace:  r.ReplicationInput.Target.Namespace,
+			BucketName: r.ReplicationInput.Target.BucketName,
+			ObjectName: copied.location,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		sha256Sum, err := base64.StdEncoding.DecodeString(head.OpcMeta["sha256"])
+		if err != nil || len(sha256Sum) != sha256.Size {
+			return nil, false, nil
+		}
+		return map[string]bool{lfsPointerOID(sha256Sum, copied.Size): true}, false, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// fileGitChecksums returns the git object ID and the SHA256 of the content of a file
+func fileGitChecksums(path string, size int64) (string, []byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	blobHash, sha256Hash := sha1.New(), sha256.New()
+	fmt.Fprintf(blobHash, "blob %d\x00", size)
+	if _, err := io.Copy(io.MultiWriter(blobHash, sha256Hash), file); err != nil {
+		return "", nil, err
+	}
+	return hex.EncodeToString(blobHash.Sum(nil)), sha256Hash.Sum(nil), nil
+}
+
+// lfsPointerOID returns the git object ID of the LFS pointer to a content
+func lfsPointerOID(sha256Sum []byte, size int64) string {
+	pointer := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", hex.EncodeToString(sha256Sum), size)
+	blobHash := sha1.New()
+	fmt.Fprintf(blobHash, "blob %d\x00%s", len(pointer), pointer)
+	return hex.EncodeToString(blobHash.Sum(nil))
+}
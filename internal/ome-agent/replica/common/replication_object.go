@@ -13,6 +13,9 @@ type ReplicationInput struct {
 	TargetStorageType storage.StorageType
 	Source            ociobjectstore.ObjectURI
 	Target            ociobjectstore.ObjectURI
+	// IncludedPaths restricts the replication to these paths relative to the replicated directory, all the source
+	// objects are replicated when nil
+	IncludedPaths []string
 }
 
 type ReplicationObject interface {
@@ -24,6 +24,9 @@ type Config struct {
 	DownloadSizeLimitGB  int    `mapstructure:"download_size_limit_gb"`
 	EnableSizeLimitCheck bool   `mapstructure:"enable_size_limit_check"`
 	NumConnections       int    `mapstructure:"num_connections"`
+	// IncrementalReplication only replicates the source objects missing or changed in the target, and verifies the
+	// target afterward
+	IncrementalReplication bool `mapstructure:"incremental_replication"`
 
 	Source struct {
 		StorageURIStr  string `mapstructure:"storage_uri" validate:"required"`
@@ -62,6 +65,8 @@ func defaultConfig() *Config {
 		NumConnections:       10,
 		DownloadSizeLimitGB:  650,
 		EnableSizeLimitCheck: true,
+
+		IncrementalReplication: true,
 	}
 }
 
@@ -0,0 +1,212 @@
+package replica
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sgl-project/ome/internal/ome-agent/replica/common"
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+// maxReportedMismatches bounds the number of mismatched objects listed in a verification error
+const maxReportedMismatches = 10
+
+// ManifestEntry describes a replicated object
+type ManifestEntry struct {
+	Size int64
+	// MD5 is the base64 encoded MD5 of the object, empty when unknown
+	MD5 string
+	// GitOID is the git object ID of a Hugging Face file, that of its LFS pointer for a file stored in LFS
+	GitOID string
+
+	// location is the object name or the file path of the object, where its checksums are read from
+	location string
+}
+
+// Manifest lists the objects of a storage by their path relative to the replicated directory
+type Manifest map[string]ManifestEntry
+
+// Matches reports whether the object of entry is the same as the one of other. The MD5s are only compared when both
+// are known and neither is the MD5 of a multipart upload, which depends on the size of the parts.
+func (entry ManifestEntry) Matches(other ManifestEntry) bool {
+	if entry.Size != other.Size {
+		return false
+	}
+	if !comparableMD5(entry.MD5) || !comparableMD5(other.MD5) {
+		return true
+	}
+	return entry.MD5 == other.MD5
+}
+
+// comparableMD5 reports whether an MD5 is known and is the MD5 of the content of an object, rather than the MD5 of
+// the MD5s of the parts of a multipart upload
+func comparableMD5(md5 string) bool {
+	return md5 != "" && !strings.Contains(md5, "-")
+}
+
+// Diff returns the paths of the objects of the manifest that are missing from target or differ from their copy in
+// target, sorted
+func (m Manifest) Diff(target Manifest) []string {
+	var paths []string
+	for path, entry := range m {
+		if targetEntry, ok := target[path]; !ok || !entry.Matches(targetEntry) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// filterChangedObjects returns the source objects missing from the target or different from their copy, and their
+// paths relative to the replicated directory
+func (r *ReplicaAgent) filterChangedObjects(sourceObjs []common.ReplicationObject) ([]common.ReplicationObject, []string, error) {
+	sourceManifest := r.sourceManifest(sourceObjs)
+	targetManifest, err := r.targetManifest()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list target objects: %w", err)
+	}
+	if err := r.compareChecksums(sourceManifest, targetManifest); err != nil {
+		return nil, nil, err
+	}
+
+	changedPaths := sourceManifest.Diff(targetManifest)
+	changed := make(map[string]struct{}, len(changedPaths))
+	for _, path := range changedPaths {
+		changed[path] = struct{}{}
+	}
+
+	var changedObjs []common.ReplicationObject
+	for _, obj := range sourceObjs {
+		path, ok := r.sourceRelativePath(obj)
+		if !ok {
+			continue
+		}
+		if _, ok := changed[path]; ok {
+			changedObjs = append(changedObjs, obj)
+		}
+	}
+	r.Logger.Infof("%d of %d source objects are missing or changed in the target", len(changedObjs), len(sourceManifest))
+	return changedObjs, changedPaths, nil
+}
+
+// verifyReplication checks that each source object has an identical copy in the target
+func (r *ReplicaAgent) verifyReplication(sourceObjs []common.ReplicationObject) error {
+	targetManifest, err := r.targetManifest()
+	if err != nil {
+		return fmt.Errorf("failed to list target objects for verification: %w", err)
+	}
+
+	sourceManifest := r.sourceManifest(sourceObjs)
+	if err := r.compareChecksums(sourceManifest, targetManifest); err != nil {
+		return err
+	}
+	mismatched := sourceManifest.Diff(targetManifest)
+	if len(mismatched) > 0 {
+		reported := mismatched
+		if len(reported) > maxReportedMismatches {
+			reported = reported[:maxReportedMismatches]
+		}
+		return fmt.Errorf("verification failed, %d objects are missing or differ in the target: %s",
+			len(mismatched), strings.Join(reported, ", "))
+	}
+	r.Logger.Infof("Verified the %d replicated objects in the target", len(sourceObjs))
+	return nil
+}
+
+// sourceManifest builds the manifest of the source objects
+func (r *ReplicaAgent) sourceManifest(sourceObjs []common.ReplicationObject) Manifest {
+	manifest := make(Manifest, len(sourceObjs))
+	for _, obj := range sourceObjs {
+		path, ok := r.sourceRelativePath(obj)
+		if !ok {
+			continue
+		}
+		entry := ManifestEntry{Size: obj.GetSize(), location: obj.GetPath()}
+		switch o := obj.(type) {
+		case common.ObjectSummaryReplicationObject:
+			if o.Md5 != nil {
+				entry.MD5 = *o.Md5
+			}
+		case common.HFRepoFileInfoReplicationObject:
+			entry.GitOID = o.Hash
+		}
+		manifest[path] = entry
+	}
+	return manifest
+}
+
+// sourceRelativePath returns the path of a source object relative to the replicated directory, the one of its copy
+// in the target. Directory placeholders have no path.
+func (r *ReplicaAgent) sourceRelativePath(obj common.ReplicationObject) (string, bool) {
+	switch r.ReplicationInput.SourceStorageType {
+	case storage.StorageTypeOCI:
+		name := obj.GetName()
+		if name == "" || strings.HasSuffix(name, "/") {
+			return "", false
+		}
+		// Objects are downloaded to a PVC with their full name, their prefix is replaced in an OCI target
+		if r.ReplicationInput.TargetStorageType == storage.StorageTypeOCI {
+			return strings.TrimPrefix(name, r.ReplicationInput.Source.Prefix), true
+		}
+		return name, true
+	case storage.StorageTypePVC:
+		relPath, err := filepath.Rel(filepath.Join(r.Config.LocalPath, r.ReplicationInput.Source.Prefix), obj.GetPath())
+		if err != nil {
+			return "", false
+		}
+		return filepath.ToSlash(relPath), true
+	default:
+		return obj.GetPath(), obj.GetPath() != ""
+	}
+}
+
+// targetManifest lists the objects of the target
+func (r *ReplicaAgent) targetManifest() (Manifest, error) {
+	manifest := make(Manifest)
+	switch r.ReplicationInput.TargetStorageType {
+	case storage.StorageTypeOCI:
+		summaries, err := r.Config.Target.OCIOSDataStore.ListObjects(r.ReplicationInput.Target)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range common.ConvertToReplicationObjectsFromObjectSummary(summaries) {
+			summary := obj.(common.ObjectSummaryReplicationObject)
+			entry := ManifestEntry{Size: summary.GetSize(), location: summary.GetName()}
+			if summary.Md5 != nil {
+				entry.MD5 = *summary.Md5
+			}
+			manifest[strings.TrimPrefix(summary.GetName(), r.ReplicationInput.Target.Prefix)] = entry
+		}
+	case storage.StorageTypePVC:
+		targetDirPath := r.targetDirPath()
+		files, err := r.Config.Target.PVCFileSystem.ListFiles(targetDirPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return manifest, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			relPath, err := filepath.Rel(targetDirPath, file.FilePath)
+			if err != nil {
+				return nil, err
+			}
+			manifest[filepath.ToSlash(relPath)] = ManifestEntry{Size: file.FileInfo.Size(), location: file.FilePath}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported target storage type: %s", string(r.ReplicationInput.TargetStorageType))
+	}
+	return manifest, nil
+}
+
+// targetDirPath returns the directory the objects are replicated to in a PVC target
+func (r *ReplicaAgent) targetDirPath() string {
+	if r.ReplicationInput.SourceStorageType == storage.StorageTypePVC {
+		return filepath.Join(r.Config.LocalPath, r.ReplicationInput.Target.BucketName, r.ReplicationInput.Target.Prefix)
+	}
+	return filepath.Join(r.Config.LocalPath, r.ReplicationInput.Target.Prefix)
+}
@@ -0,0 +1,173 @@
+package replica
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/internal/ome-agent/replica/common"
+	"github.com/sgl-project/ome/pkg/afero"
+	"github.com/sgl-project/ome/pkg/ociobjectstore"
+	testingPkg "github.com/sgl-project/ome/pkg/testing"
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+func TestManifestDiff(t *testing.T) {
+	source := Manifest{
+		"config.json":          {Size: 10},
+		"model.safetensors":    {Size: 100, MD5: "abc=="},
+		"resized.safetensors":  {Size: 100},
+		"modified.safetensors": {Size: 100, MD5: "abc=="},
+		"multipart.bin":        {Size: 100, MD5: "abc==-2"},
+		"missing.bin":          {Size: 1},
+	}
+	target := Manifest{
+		"config.json":          {Size: 10},
+		"model.safetensors":    {Size: 100, MD5: "abc=="},
+		"resized.safetensors":  {Size: 99},
+		"modified.safetensors": {Size: 100, MD5: "def=="},
+		"multipart.bin":        {Size: 100, MD5: "xyz==-5"},
+		"extra.bin":            {Size: 1},
+	}
+
+	assert.Equal(t, []string{"missing.bin", "modified.safetensors", "resized.safetensors"}, source.Diff(target))
+	assert.Empty(t, source.Diff(source))
+}
+
+func TestIncrementalReplication(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourceDir := filepath.Join(tmpDir, "models")
+	targetDir := filepath.Join(tmpDir, "target-pvc", "replicated")
+	files := map[string]string{
+		"config.json":              `{"model_type": "llama"}`,
+		"model.safetensors":        "weights",
+		"tokenizer/tokenizer.json": "tokens",
+	}
+	for name, content := range files {
+		path := filepath.Join(sourceDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	mockLogger := testingPkg.SetupMockLogger()
+	agent := &ReplicaAgent{
+		Logger: mockLogger,
+		Config: Config{
+			AnotherLogger:          mockLogger,
+			LocalPath:              tmpDir,
+			NumConnections:         1,
+			IncrementalReplication: true,
+		},
+		ReplicationInput: common.ReplicationInput{
+			SourceStorageType: storage.StorageTypePVC,
+			TargetStorageType: storage.StorageTypePVC,
+			Source:            ociobjectstore.ObjectURI{Namespace: "ns", BucketName: "source-pvc", Prefix: "models"},
+			Target:            ociobjectstore.ObjectURI{Namespace: "ns", BucketName: "target-pvc", Prefix: "replicated"},
+		},
+	}
+	agent.Config.Source.PVCFileSystem = afero.NewOsFs().(*afero.OsFs)
+	agent.Config.Target.PVCFileSystem = afero.NewOsFs().(*afero.OsFs)
+
+	// The first replication copies everything
+	require.NoError(t, agent.Start())
+	for name, content := range files {
+		copied, err := os.ReadFile(filepath.Join(targetDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(copied))
+	}
+
+	// Only the changed files are copied afterward
+	unchanged := filepath.Join(targetDir, "config.json")
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(unchanged, past, past))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "model.safetensors"), []byte("new weights"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(targetDir, "tokenizer", "tokenizer.json")))
+
+	sourceObjs, err := agent.listSourceObjects()
+	require.NoError(t, err)
+	changedObjs, changedPaths, err := agent.filterChangedObjects(sourceObjs)
+	require.NoError(t, err)
+	assert.Len(t, changedObjs, 2)
+	assert.Equal(t, []string{"model.safetensors", "tokenizer/tokenizer.json"}, changedPaths)
+
+	require.NoError(t, agent.Start())
+	copied, err := os.ReadFile(filepath.Join(targetDir, "model.safetensors"))
+	require.NoError(t, err)
+	assert.Equal(t, "new weights", string(copied))
+	assert.FileExists(t, filepath.Join(targetDir, "tokenizer", "tokenizer.json"))
+	info, err := os.Stat(unchanged)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Before(time.Now().Add(-time.Minute)), "unchanged file should not be copied again")
+
+	// The target is verified against the source
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "config.json"), []byte("truncated"), 0644))
+	sourceObjs, err = agent.listSourceObjects()
+	require.NoError(t, err)
+	err = agent.verifyReplication(sourceObjs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config.json")
+}
+
+func TestCompareChecksums(t *testing.T) {
+	tmpDir := t.TempDir()
+	targetDir := filepath.Join(tmpDir, "replicated")
+	require.NoError(t, os.MkdirAll(targetDir, 0755))
+	// A file stored in git, and one stored in LFS, identified by the ID of their LFS pointer
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "config.json"), []byte("config"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "model.safetensors"), []byte("weights"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "changed.safetensors"), []byte("weights"), 0644))
+	configOID, _, err := fileGitChecksums(filepath.Join(targetDir, "config.json"), 6)
+	require.NoError(t, err)
+	_, weightsSHA256, err := fileGitChecksums(filepath.Join(targetDir, "model.safetensors"), 7)
+	require.NoError(t, err)
+
+	agent := &ReplicaAgent{
+		Logger: testingPkg.SetupMockLogger(),
+		Config: Config{LocalPath: tmpDir},
+		ReplicationInput: common.ReplicationInput{
+			SourceStorageType: storage.StorageTypeHuggingFace,
+			TargetStorageType: storage.StorageTypePVC,
+			Target:            ociobjectstore.ObjectURI{Prefix: "replicated"},
+		},
+	}
+	agent.Config.Target.PVCFileSystem = afero.NewOsFs().(*afero.OsFs)
+	source := Manifest{
+		"config.json":         {Size: 6, GitOID: configOID},
+		"model.safetensors":   {Size: 7, GitOID: lfsPointerOID(weightsSHA256, 7)},
+		"changed.safetensors": {Size: 7, GitOID: "0123456789abcdef0123456789abcdef01234567"},
+	}
+	target, err := agent.targetManifest()
+	require.NoError(t, err)
+	require.NoError(t, agent.compareChecksums(source, target))
+	assert.Equal(t, []string{"changed.safetensors"}, source.Diff(target))
+
+	// The MD5s of the files of PVCs are compared
+	tmpDir = t.TempDir()
+	files := map[string]string{
+		"models/config.json":                      "config",
+		"models/model.safetensors":                "WEIGHTS",
+		"target-pvc/replicated/config.json":       "config",
+		"target-pvc/replicated/model.safetensors": "weights",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	agent.Config.LocalPath = tmpDir
+	agent.ReplicationInput.SourceStorageType = storage.StorageTypePVC
+	agent.ReplicationInput.Source = ociobjectstore.ObjectURI{Prefix: "models"}
+	agent.ReplicationInput.Target = ociobjectstore.ObjectURI{BucketName: "target-pvc", Prefix: "replicated"}
+	source = Manifest{
+		"config.json":       {Size: 6, location: filepath.Join(tmpDir, "models", "config.json")},
+		"model.safetensors": {Size: 7, location: filepath.Join(tmpDir, "models", "model.safetensors")},
+	}
+	target, err = agent.targetManifest()
+	require.NoError(t, err)
+	require.NoError(t, agent.compareChecksums(source, target))
+	assert.Equal(t, []string{"model.safetensors"}, source.Diff(target))
+}
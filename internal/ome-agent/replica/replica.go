@@ -90,13 +90,31 @@ func (r *ReplicaAgent) Start() error {
 
 	r.validateModelSize(sourceObjs)
 
+	changedObjs := sourceObjs
+	if r.Config.IncrementalReplication {
+		var changedPaths []string
+		changedObjs, changedPaths, err = r.filterChangedObjects(sourceObjs)
+		if err != nil {
+			r.writeTerminationLog(err.Error())
+			return err
+		}
+		if len(changedObjs) == 0 {
+			r.Logger.Info("Target is up to date, no replication needed")
+			return nil
+		}
+		r.ReplicationInput.IncludedPaths = changedPaths
+	}
+
 	replicatorImp, err := NewReplicator(r)
 	if err != nil {
 		r.writeTerminationLog(err.Error())
 		return err
 	}
 
-	err = replicatorImp.Replicate(sourceObjs)
+	err = replicatorImp.Replicate(changedObjs)
+	if err == nil && r.Config.IncrementalReplication {
+		err = r.verifyReplication(sourceObjs)
+	}
 	if err != nil {
 		r.writeTerminationLog(err.Error())
 	}
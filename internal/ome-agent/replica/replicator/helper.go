@@ -33,8 +33,10 @@ const (
 
 var (
 	downloadSnapHook                      = func(c *xet.Client, req *xet.SnapshotRequest) (string, error) { return c.DownloadSnapshot(req) }
+	downloadFileHook                      = func(c *xet.Client, req *xet.DownloadRequest) (string, error) { return c.DownloadFile(req) }
 	downloadFromHFFunc                    = downloadFromHF
 	uploadDirectoryToOCIOSDataStoreFunc   = uploadDirectoryToOCIOSDataStore
+	uploadFilesToOCIOSDataStoreFunc       = uploadFilesToOCIOSDataStore
 	downloadObjectsFromOCIOSDataStoreFunc = downloadObjectsFromOCIOSDataStore
 )
 
@@ -58,7 +60,9 @@ func DownloadObject(ociOSDataStore *ociobjectstore.OCIOSDataStore, srcObj ociobj
 
 	err := ociOSDataStore.MultipartDownload(srcObj, downloadPath,
 		ociobjectstore.WithChunkSize(DefaultDownloadChunkSizeInMB),
-		ociobjectstore.WithThreads(DefaultDownloadThreads))
+		ociobjectstore.WithThreads(DefaultDownloadThreads),
+		// An interrupted download of a large object resumes with the parts it didn't write
+		ociobjectstore.WithResume(true))
 	if err != nil {
 		ociOSDataStore.Config.AnotherLogger.Errorf("Failed to download object %s: %+v", srcObj.ObjectName, err)
 		return err
@@ -77,6 +81,18 @@ func PrepareObjectChannel(objects []common.ReplicationObject) chan common.Replic
 	return objChan
 }
 
+// includedPathSet returns the set of the included paths, nil when all the paths are included
+func includedPathSet(paths []string) map[string]struct{} {
+	if paths == nil {
+		return nil
+	}
+	set := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		set[path] = struct{}{}
+	}
+	return set
+}
+
 func LogProgress(successCount, errorCount, totalObjects int, startTime time.Time, logger logging.Interface) {
 	progress := float64(successCount+errorCount) / float64(totalObjects) * 100
 	elapsedTime := time.Since(startTime)
@@ -42,14 +42,26 @@ func (r *HFToOCIReplicator) Replicate(objects []common.ReplicationObject) error
 	r.Logger.Infof("Successfully downloaded model %s from HF to %s ", r.ReplicationInput.Source.BucketName, downloadPath)
 
 	// Upload
-	if err = uploadDirectoryToOCIOSDataStoreFunc(
-		r.Config.OCIOSDataStore,
-		r.ReplicationInput.Target,
-		tempDirPath,
-		r.Config.ChecksumConfig,
-		len(objects),
-		r.Config.NumConnections,
-	); err != nil {
+	if r.ReplicationInput.IncludedPaths != nil {
+		err = uploadFilesToOCIOSDataStoreFunc(
+			r.Config.OCIOSDataStore,
+			r.ReplicationInput.Target,
+			tempDirPath,
+			r.ReplicationInput.IncludedPaths,
+			r.Config.ChecksumConfig,
+			r.Config.NumConnections,
+		)
+	} else {
+		err = uploadDirectoryToOCIOSDataStoreFunc(
+			r.Config.OCIOSDataStore,
+			r.ReplicationInput.Target,
+			tempDirPath,
+			r.Config.ChecksumConfig,
+			len(objects),
+			r.Config.NumConnections,
+		)
+	}
+	if err != nil {
 		r.Logger.Errorf("Failed to upload files under %s to OCI Object Storage %v: %v", tempDirPath, r.ReplicationInput.Target, err)
 		return err
 	}
@@ -64,6 +76,25 @@ func (r *HFToOCIReplicator) Replicate(objects []common.ReplicationObject) error
 }
 
 func downloadFromHF(input common.ReplicationInput, hubClient *xet.Client, downloadDir string, logger logging.Interface) (string, error) {
+	// Only download the files missing or changed in the target. They are downloaded one by one, as the patterns of a
+	// snapshot download match any path containing them.
+	if input.IncludedPaths != nil {
+		for _, path := range input.IncludedPaths {
+			req := &xet.DownloadRequest{
+				RepoID:   input.Source.BucketName,
+				RepoType: hub.RepoTypeModel,
+				Revision: input.Source.Prefix,
+				Filename: path,
+				LocalDir: downloadDir,
+			}
+			if _, err := downloadFileHook(hubClient, req); err != nil {
+				logger.Errorf("Failed to download %s: %v", path, err)
+				return "", err
+			}
+		}
+		return downloadDir, nil
+	}
+
 	req := &xet.SnapshotRequest{
 		RepoID:   input.Source.BucketName,
 		RepoType: hub.RepoTypeModel,
@@ -102,20 +133,7 @@ func uploadDirectoryToOCIOSDataStore(
 
 	tasks := make(chan UploadTask, numberOfObjects)
 	errCh := make(chan error, numberOfObjects)
-
-	var wg sync.WaitGroup
-	// Start worker goroutines
-	for i := 0; i < numberOfConnections; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for task := range tasks {
-				if err := UploadObjectToOCIOSDataStore(ociOSDataStore, task.targetObj, task.filePath); err != nil {
-					errCh <- fmt.Errorf("upload failed for %s: %w", task.filePath, err)
-				}
-			}
-		}()
-	}
+	wg := startUploadWorkers(ociOSDataStore, tasks, errCh, numberOfConnections)
 
 	err := filepath.WalkDir(localDirectoryPath, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -133,26 +151,7 @@ func uploadDirectoryToOCIOSDataStore(
 			return err
 		}
 
-		// Normalize path to use "/" for OCI Object Storage
-		relPath = filepath.ToSlash(relPath)
-		filePath := filepath.Join(localDirectoryPath, relPath)
-
-		// Create the OCI ObjectURI with target prefix
-		objectName := strings.TrimSuffix(object.Prefix, "/") + "/" + relPath
-		// Handle the case when directly uploading to root directory in OCI bucket
-		if object.Prefix == "" {
-			objectName = relPath
-		}
-
-		metadata := GetObjectMetadatWithFileChecksum(checksumConfig, filePath, ociOSDataStore.Config.AnotherLogger)
-		targetObj := ociobjectstore.ObjectURI{
-			BucketName: object.BucketName,
-			Namespace:  object.Namespace,
-			ObjectName: objectName,
-			Metadata:   metadata,
-		}
-
-		tasks <- UploadTask{targetObj: targetObj, filePath: filePath}
+		tasks <- newUploadTask(ociOSDataStore, object, localDirectoryPath, relPath, checksumConfig)
 		return nil
 	})
 
@@ -164,7 +163,86 @@ func uploadDirectoryToOCIOSDataStore(
 		ociOSDataStore.Config.AnotherLogger.Errorf("Failed to upload files: %+v", err)
 		return err
 	}
+	return collectUploadErrors(ociOSDataStore, errCh)
+}
+
+// uploadFilesToOCIOSDataStore uploads the files at relPaths of localDirectoryPath under the prefix of object
+func uploadFilesToOCIOSDataStore(
+	ociOSDataStore *ociobjectstore.OCIOSDataStore,
+	object ociobjectstore.ObjectURI,
+	localDirectoryPath string,
+	relPaths []string,
+	checksumConfig *common.ChecksumConfig,
+	numberOfConnections int) error {
+	if ociOSDataStore == nil {
+		return fmt.Errorf("target ociOSDataStore is nil")
+	}
+
+	if len(relPaths) == 0 {
+		ociOSDataStore.Config.AnotherLogger.Infof("No files to upload, skipping upload")
+		return nil
+	}
+
+	tasks := make(chan UploadTask, len(relPaths))
+	errCh := make(chan error, len(relPaths))
+	wg := startUploadWorkers(ociOSDataStore, tasks, errCh, numberOfConnections)
+	for _, relPath := range relPaths {
+		tasks <- newUploadTask(ociOSDataStore, object, localDirectoryPath, relPath, checksumConfig)
+	}
+
+	close(tasks)
+	wg.Wait()
+	close(errCh)
+	return collectUploadErrors(ociOSDataStore, errCh)
+}
+
+// startUploadWorkers starts numberOfConnections workers uploading the tasks, their errors are sent to errCh
+func startUploadWorkers(ociOSDataStore *ociobjectstore.OCIOSDataStore, tasks <-chan UploadTask, errCh chan<- error, numberOfConnections int) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	for i := 0; i < numberOfConnections; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range tasks {
+				if err := UploadObjectToOCIOSDataStore(ociOSDataStore, task.targetObj, task.filePath); err != nil {
+					errCh <- fmt.Errorf("upload failed for %s: %w", task.filePath, err)
+				}
+			}
+		}()
+	}
+	return &wg
+}
+
+// newUploadTask returns the task uploading the file at relPath of localDirectoryPath under the prefix of object
+func newUploadTask(
+	ociOSDataStore *ociobjectstore.OCIOSDataStore,
+	object ociobjectstore.ObjectURI,
+	localDirectoryPath string,
+	relPath string,
+	checksumConfig *common.ChecksumConfig) UploadTask {
+	// Normalize path to use "/" for OCI Object Storage
+	relPath = filepath.ToSlash(relPath)
+	filePath := filepath.Join(localDirectoryPath, relPath)
+
+	// Create the OCI ObjectURI with target prefix
+	objectName := strings.TrimSuffix(object.Prefix, "/") + "/" + relPath
+	// Handle the case when directly uploading to root directory in OCI bucket
+	if object.Prefix == "" {
+		objectName = relPath
+	}
+
+	metadata := GetObjectMetadatWithFileChecksum(checksumConfig, filePath, ociOSDataStore.Config.AnotherLogger)
+	targetObj := ociobjectstore.ObjectURI{
+		BucketName: object.BucketName,
+		Namespace:  object.Namespace,
+		ObjectName: objectName,
+		Metadata:   metadata,
+	}
+	return UploadTask{targetObj: targetObj, filePath: filePath}
+}
 
+// collectUploadErrors logs the upload errors and fails when there is any
+func collectUploadErrors(ociOSDataStore *ociobjectstore.OCIOSDataStore, errCh <-chan error) error {
 	if len(errCh) > 0 {
 		for err := range errCh {
 			ociOSDataStore.Config.AnotherLogger.Errorf("error when uploading a file: %+v", err)
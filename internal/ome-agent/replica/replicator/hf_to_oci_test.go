@@ -109,3 +109,38 @@ func TestHFToOCIReplicator_Replicate_Failure(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorContains(t, err, "upload error")
 }
+
+func TestDownloadFromHF_IncludedPaths(t *testing.T) {
+	origDownloadFile := downloadFileHook
+	origDownloadSnap := downloadSnapHook
+	t.Cleanup(func() {
+		downloadFileHook = origDownloadFile
+		downloadSnapHook = origDownloadSnap
+	})
+
+	var downloaded []string
+	downloadFileHook = func(c *xet.Client, req *xet.DownloadRequest) (string, error) {
+		downloaded = append(downloaded, req.Filename)
+		return req.LocalDir + "/" + req.Filename, nil
+	}
+	downloadSnapHook = func(c *xet.Client, req *xet.SnapshotRequest) (string, error) {
+		t.Fatal("the snapshot should not be downloaded")
+		return "", nil
+	}
+
+	// The paths are downloaded as is, whatever the glob characters they hold
+	input := common.ReplicationInput{
+		Source:        ociobjectstore.ObjectURI{BucketName: "meta-llama/llama-3-70b-instruct", Prefix: "main"},
+		IncludedPaths: []string{"config.json", "weights/model[1].safetensors"},
+	}
+	path, err := downloadFromHF(input, nil, "/tmp/replica", testingPkg.SetupMockLogger())
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/replica", path)
+	assert.Equal(t, []string{"config.json", "weights/model[1].safetensors"}, downloaded)
+
+	downloadFileHook = func(c *xet.Client, req *xet.DownloadRequest) (string, error) {
+		return "", errors.New("not found")
+	}
+	_, err = downloadFromHF(input, nil, "/tmp/replica", testingPkg.SetupMockLogger())
+	assert.Error(t, err)
+}
@@ -25,6 +25,22 @@ func (r *PVCToOCIReplicator) Replicate(objects []common.ReplicationObject) error
 	r.Logger.Info("Starting replication to target")
 
 	sourceDirPath := filepath.Join(r.Config.LocalPath, r.ReplicationInput.Source.Prefix)
+	if r.ReplicationInput.IncludedPaths != nil {
+		if err := uploadFilesToOCIOSDataStoreFunc(
+			r.Config.OCIOSDataStore,
+			r.ReplicationInput.Target,
+			sourceDirPath,
+			r.ReplicationInput.IncludedPaths,
+			r.Config.ChecksumConfig,
+			r.Config.NumConnections,
+		); err != nil {
+			r.Logger.Errorf("Failed to upload changed files under %s to OCI Object Storage %v: %v", sourceDirPath, r.ReplicationInput.Target, err)
+			return err
+		}
+		r.Logger.Infof("%d changed files under %s uploaded successfully", len(r.ReplicationInput.IncludedPaths), sourceDirPath)
+		return nil
+	}
+
 	if err := uploadDirectoryToOCIOSDataStoreFunc(
 		r.Config.OCIOSDataStore,
 		r.ReplicationInput.Target,
@@ -146,3 +146,37 @@ func TestPVCToOCIReplicator_Replicate_WithNilOCIOSDataStore(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "OCIOSDataStore is nil")
 }
+
+func TestPVCToOCIReplicator_Replicate_IncludedPaths(t *testing.T) {
+	originalUploadFilesFunc := uploadFilesToOCIOSDataStoreFunc
+	defer func() {
+		uploadFilesToOCIOSDataStoreFunc = originalUploadFilesFunc
+	}()
+
+	var uploadedPaths []string
+	uploadFilesToOCIOSDataStoreFunc = func(ds *ociobjectstore.OCIOSDataStore, target ociobjectstore.ObjectURI, localPath string, relPaths []string, checksumConfig *common.ChecksumConfig, numConnections int) error {
+		assert.Equal(t, "/mnt/data/models", localPath)
+		uploadedPaths = relPaths
+		return nil
+	}
+
+	replicator := &PVCToOCIReplicator{
+		Logger: testingPkg.SetupMockLogger(),
+		Config: PVCToOCIReplicatorConfig{
+			LocalPath:      "/mnt/data",
+			NumConnections: 5,
+			OCIOSDataStore: &ociobjectstore.OCIOSDataStore{},
+		},
+		ReplicationInput: common.ReplicationInput{
+			SourceStorageType: storage.StorageTypePVC,
+			TargetStorageType: storage.StorageTypeOCI,
+			Source:            ociobjectstore.ObjectURI{Namespace: "default", BucketName: "model-pvc", Prefix: "models"},
+			Target:            ociobjectstore.ObjectURI{Namespace: "target-namespace", BucketName: "model-storage", Prefix: "models"},
+			IncludedPaths:     []string{"config.json", "weights/model.safetensors"},
+		},
+	}
+
+	err := replicator.Replicate(CreateCommonMockReplicationObjects(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"config.json", "weights/model.safetensors"}, uploadedPaths)
+}
@@ -2,9 +2,12 @@ package replicator
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
+	goafero "github.com/spf13/afero"
+
 	"github.com/sgl-project/ome/internal/ome-agent/replica/common"
 	"github.com/sgl-project/ome/pkg/afero"
 	"github.com/sgl-project/ome/pkg/logging"
@@ -38,6 +41,7 @@ func (r *PVCToPVCReplicator) Replicate(objects []common.ReplicationObject) error
 
 	sourceDirPath := filepath.Join(r.Config.LocalPath, r.ReplicationInput.Source.Prefix)
 	targetDirPath := filepath.Join(r.Config.LocalPath, r.ReplicationInput.Target.BucketName, r.ReplicationInput.Target.Prefix)
+	included := includedPathSet(r.ReplicationInput.IncludedPaths)
 
 	err := afero.Walk(r.Config.SourcePVCFileSystem, sourceDirPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -54,8 +58,11 @@ func (r *PVCToPVCReplicator) Replicate(objects []common.ReplicationObject) error
 		if info.IsDir() {
 			return r.Config.TargetPVCFileSystem.MkdirAll(destPath, info.Mode())
 		}
+		if _, ok := included[filepath.ToSlash(relPath)]; included != nil && !ok {
+			return nil
+		}
 
-		return afero.CopyFileBetweenFS(r.Config.SourcePVCFileSystem, r.Config.TargetPVCFileSystem, path, destPath, info.Mode())
+		return copyFileResumable(r.Config.SourcePVCFileSystem, r.Config.TargetPVCFileSystem, path, destPath, info)
 	})
 
 	if err != nil {
@@ -69,3 +76,48 @@ func (r *PVCToPVCReplicator) Replicate(objects []common.ReplicationObject) error
 		r.ReplicationInput.Target.Prefix)
 	return nil
 }
+
+// partialFileSuffix is the suffix of a file being copied to a PVC
+const partialFileSuffix = ".partial"
+
+// copyFileResumable copies a file to a partial file renamed to dstPath once complete. A partial file left by an
+// interrupted copy is resumed from its end, the verification of the replication catches a partial file of another
+// version of the file.
+func copyFileResumable(srcFs, dstFs goafero.Fs, srcPath, dstPath string, info os.FileInfo) error {
+	partialPath := dstPath + partialFileSuffix
+	var offset int64
+	if partial, statErr := dstFs.Stat(partialPath); statErr == nil && partial.Size() <= info.Size() {
+		offset = partial.Size()
+	}
+
+	in, err := srcFs.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer in.Close()
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if offset > 0 {
+		if _, err := in.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek source file: %w", err)
+		}
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+	out, err := dstFs.OpenFile(partialPath, flags, info.Mode())
+	if err != nil {
+		return fmt.Errorf("failed to open destination file: %w", err)
+	}
+	_, err = io.Copy(out, in)
+	if err == nil {
+		err = out.Sync()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy contents: %w", err)
+	}
+	if err := dstFs.Chmod(partialPath, info.Mode()); err != nil {
+		return fmt.Errorf("failed to chmod destination file: %w", err)
+	}
+	return dstFs.Rename(partialPath, dstPath)
+}
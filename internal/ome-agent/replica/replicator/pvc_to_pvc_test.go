@@ -278,3 +278,60 @@ func TestPVCToPVCReplicator_Replicate_FileSystemError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "replication failed")
 }
+
+func TestPVCToPVCReplicator_Replicate_IncludedPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourceDir := filepath.Join(tmpDir, "pvcPath1")
+	targetDir := filepath.Join(tmpDir, "targetPVCName", "pvcPath2")
+	for _, filename := range []string{"file1.txt", "file2.txt", "subdir/file3.txt"} {
+		filePath := filepath.Join(sourceDir, filename)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		require.NoError(t, os.WriteFile(filePath, []byte(filename), 0644))
+	}
+
+	replicator := &PVCToPVCReplicator{
+		Logger: testingPkg.SetupMockLogger(),
+		Config: PVCToPVCReplicatorConfig{
+			LocalPath:           tmpDir,
+			SourcePVCFileSystem: afero.NewOsFs().(*afero.OsFs),
+			TargetPVCFileSystem: afero.NewOsFs().(*afero.OsFs),
+		},
+		ReplicationInput: common.ReplicationInput{
+			Source:        ociobjectstore.ObjectURI{Namespace: "pvcNamespace", BucketName: "sourcePVCName", Prefix: "pvcPath1"},
+			Target:        ociobjectstore.ObjectURI{Namespace: "pvcNamespace", BucketName: "targetPVCName", Prefix: "pvcPath2"},
+			IncludedPaths: []string{"subdir/file3.txt"},
+		},
+	}
+
+	require.NoError(t, replicator.Replicate([]common.ReplicationObject{}))
+
+	assert.FileExists(t, filepath.Join(targetDir, "subdir", "file3.txt"))
+	assert.NoFileExists(t, filepath.Join(targetDir, "file1.txt"))
+	assert.NoFileExists(t, filepath.Join(targetDir, "file2.txt"))
+}
+
+func TestCopyFileResumable(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcPath := filepath.Join(tmpDir, "model.safetensors")
+	dstPath := filepath.Join(tmpDir, "copy", "model.safetensors")
+	require.NoError(t, os.WriteFile(srcPath, []byte("0123456789"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Dir(dstPath), 0755))
+	info, err := os.Stat(srcPath)
+	require.NoError(t, err)
+	fs := afero.NewOsFs().(*afero.OsFs)
+
+	// An interrupted copy is resumed from the end of its partial file
+	require.NoError(t, os.WriteFile(dstPath+partialFileSuffix, []byte("01234"), 0644))
+	require.NoError(t, copyFileResumable(fs, fs, srcPath, dstPath, info))
+	copied, err := os.ReadFile(dstPath)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(copied))
+	assert.NoFileExists(t, dstPath+partialFileSuffix)
+
+	// A partial file larger than the source is copied again
+	require.NoError(t, os.WriteFile(dstPath+partialFileSuffix, []byte("0123456789abc"), 0644))
+	require.NoError(t, copyFileResumable(fs, fs, srcPath, dstPath, info))
+	copied, err = os.ReadFile(dstPath)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(copied))
+}
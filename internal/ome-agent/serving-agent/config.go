@@ -18,6 +18,12 @@ type Config struct {
 	UnzippedFineTunedWeightDirectory string                         `mapstructure:"unzipped_fine_tuned_weight_directory" validate:"required"`
 	ZippedFineTunedWeightDirectory   string                         `mapstructure:"zipped_fine_tuned_weight_directory" validate:"required"`
 	ObjectStorageDataStore           *ociobjectstore.OCIOSDataStore `validate:"required"`
+
+	Warmup WarmupConfig `mapstructure:"warmup"`
+	// ReadyFilePath is created once the serving sidecar is ready, for exec readiness probes
+	ReadyFilePath string `mapstructure:"ready_file_path"`
+	// ReadinessPort serves /ready, answering 200 once the serving sidecar is ready, disabled when 0
+	ReadinessPort int `mapstructure:"readiness_port"`
 }
 
 type Option func(*Config) error
@@ -38,7 +44,9 @@ func (c *Config) Apply(opts ...Option) error {
 
 // defaultConfig returns a new configuration with default values.
 func defaultConfig() *Config {
-	return &Config{}
+	return &Config{
+		Warmup: defaultWarmupConfig(),
+	}
 }
 
 // NewServingSidecarConfig builds and returns a new configuration from the given options.
@@ -1,12 +1,14 @@
 package serving_agent
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -29,6 +31,9 @@ const (
 type ServingSidecar struct {
 	logger logging.Interface
 	Config Config
+
+	// ready is set once the engine is warmed up
+	ready atomic.Bool
 }
 
 // NewServingSidecar constructs a new replica agent from the given configuration.
@@ -42,9 +47,19 @@ func NewServingSidecar(config *Config) (*ServingSidecar, error) {
 func (s *ServingSidecar) Start() error {
 	s.logger.Info("Starting Serving Sidecar")
 
+	if s.Config.ReadinessPort > 0 {
+		readinessServer := s.serveReadiness()
+		defer readinessServer.Close()
+	}
+
 	// Initialize the finetuned model directory when app starts
 	s.applyFinetunedModelChanges()
 
+	// Warm up the engine with the finetuned models in place before reporting ready
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.warmupAndSignalReady(ctx)
+
 	// Create file change watcher and the channel to watch file changes
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -0,0 +1,261 @@
+package serving_agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const readinessPath = "/ready"
+
+// engineProbeInterval is the delay between two health checks of the engine while waiting for it to start
+var engineProbeInterval = 2 * time.Second
+
+// defaultWarmupPrompts are sent when no warmup prompt is configured
+var defaultWarmupPrompts = []string{
+	"Hello, who are you?",
+	"Summarize the plot of a famous novel in a few sentences.",
+}
+
+// WarmupConfig configures the prompts sent to the engine of the pod before the serving sidecar reports ready, so the
+// first user requests don't pay for the engine's cold start (CUDA graphs capture, JIT compilation, cache allocation).
+type WarmupConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// EngineURL is the base URL of the engine, e.g. http://127.0.0.1:8080
+	EngineURL      string `mapstructure:"engine_url" validate:"required_if=Enabled true"`
+	HealthPath     string `mapstructure:"health_path"`
+	CompletionPath string `mapstructure:"completion_path"`
+	// Model is the model name of the completion requests, the first model served by the engine when empty
+	Model     string   `mapstructure:"model"`
+	Prompts   []string `mapstructure:"prompts"`
+	MaxTokens int      `mapstructure:"max_tokens"`
+	// Rounds is the number of times each prompt is sent, by Concurrency requests at a time
+	Rounds      int `mapstructure:"rounds"`
+	Concurrency int `mapstructure:"concurrency"`
+
+	RequestTimeout     time.Duration `mapstructure:"request_timeout"`
+	EngineReadyTimeout time.Duration `mapstructure:"engine_ready_timeout"`
+}
+
+// defaultWarmupConfig returns the warmup configuration of an OpenAI compatible engine
+func defaultWarmupConfig() WarmupConfig {
+	return WarmupConfig{
+		HealthPath:         "/health",
+		CompletionPath:     "/v1/completions",
+		MaxTokens:          16,
+		Rounds:             1,
+		Concurrency:        1,
+		RequestTimeout:     60 * time.Second,
+		EngineReadyTimeout: 30 * time.Minute,
+	}
+}
+
+// warmupAndSignalReady warms up the engine when enabled, then reports the serving sidecar ready. The sidecar is not
+// reported ready when the engine does not start.
+func (s *ServingSidecar) warmupAndSignalReady(ctx context.Context) {
+	if s.Config.Warmup.Enabled {
+		if err := s.warmup(ctx); err != nil {
+			s.logger.Errorf("Warmup failed, the serving sidecar is not ready: %v", err)
+			return
+		}
+	}
+
+	if err := s.signalReady(); err != nil {
+		s.logger.Errorf("Error when signaling readiness: %v", err)
+	}
+}
+
+// warmup waits for the engine to be healthy and sends it the warmup prompts. Failed prompts are logged, warmup is
+// best effort once the engine is healthy.
+func (s *ServingSidecar) warmup(ctx context.Context) error {
+	config := s.Config.Warmup
+	client := &http.Client{Timeout: config.RequestTimeout}
+
+	s.logger.Infof("Waiting for the engine at %s to be healthy", config.EngineURL)
+	if err := waitForEngine(ctx, client, config.EngineURL+config.HealthPath, config.EngineReadyTimeout); err != nil {
+		return err
+	}
+
+	model := config.Model
+	if model == "" {
+		var err error
+		if model, err = getServedModel(ctx, client, config.EngineURL); err != nil {
+			s.logger.Warnf("Sending warmup prompts without a model name: %v", err)
+		}
+	}
+
+	prompts := config.Prompts
+	if len(prompts) == 0 {
+		prompts = defaultWarmupPrompts
+	}
+	requests := make(chan string)
+	go func() {
+		defer close(requests)
+		for round := 0; round < max(config.Rounds, 1); round++ {
+			for _, prompt := range prompts {
+				requests <- prompt
+			}
+		}
+	}()
+
+	start := time.Now()
+	var sent, failed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < max(config.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for prompt := range requests {
+				sent.Add(1)
+				if err := sendCompletion(ctx, client, config.EngineURL+config.CompletionPath, model, prompt, config.MaxTokens); err != nil {
+					failed.Add(1)
+					s.logger.Warnf("Warmup prompt failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.logger.Infof("Warmup completed in %s, %d of %d prompts succeeded",
+		time.Since(start).Round(time.Millisecond), sent.Load()-failed.Load(), sent.Load())
+	return nil
+}
+
+// signalReady reports the serving sidecar ready, to the readiness endpoint and with the ready file
+func (s *ServingSidecar) signalReady() error {
+	s.ready.Store(true)
+
+	if path := s.Config.ReadyFilePath; path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(time.Now().UTC().Format(time.RFC3339)), 0644); err != nil {
+			return err
+		}
+	}
+	s.logger.Info("Serving sidecar is ready")
+	return nil
+}
+
+// serveReadiness serves the readiness endpoint of the serving sidecar, for HTTP readiness probes
+func (s *ServingSidecar) serveReadiness() *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(readinessPath, s.handleReadiness)
+	server := &http.Server{
+		Addr:              ":" + strconv.Itoa(s.Config.ReadinessPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		s.logger.Infof("Serving readiness on port %d", s.Config.ReadinessPort)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Errorf("Error when serving readiness: %v", err)
+		}
+	}()
+	return server
+}
+
+// handleReadiness answers 200 once the serving sidecar is ready, 503 before
+func (s *ServingSidecar) handleReadiness(w http.ResponseWriter, _ *http.Request) {
+	if !s.ready.Load() {
+		http.Error(w, "warming up", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// waitForEngine polls the health endpoint of the engine until it answers 200 or the timeout expires
+func waitForEngine(ctx context.Context, client *http.Client, healthURL string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := client.Do(req); err == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("engine not healthy at %s after %s", healthURL, timeout)
+		case <-time.After(engineProbeInterval):
+		}
+	}
+}
+
+// getServedModel returns the first model listed by the OpenAI models endpoint of the engine
+func getServedModel(ctx context.Context, client *http.Client, engineURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, engineURL+"/v1/models", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to list the models of the engine: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to list the models of the engine: status %d", resp.StatusCode)
+	}
+
+	var models struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return "", fmt.Errorf("failed to decode the models of the engine: %w", err)
+	}
+	if len(models.Data) == 0 {
+		return "", errors.New("the engine serves no model")
+	}
+	return models.Data[0].ID, nil
+}
+
+// sendCompletion sends an OpenAI completion request and discards the completion
+func sendCompletion(ctx context.Context, client *http.Client, completionURL, model, prompt string, maxTokens int) error {
+	body, err := json.Marshal(struct {
+		Model     string `json:"model,omitempty"`
+		Prompt    string `json:"prompt"`
+		MaxTokens int    `json:"max_tokens,omitempty"`
+	}{model, prompt, maxTokens})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, completionURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package serving_agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	testingPkg "github.com/sgl-project/ome/pkg/testing"
+)
+
+// fakeEngine is an OpenAI compatible engine that becomes healthy after unhealthyChecks health checks
+type fakeEngine struct {
+	unhealthyChecks int64
+	healthChecks    atomic.Int64
+
+	mu      sync.Mutex
+	prompts []string
+	models  []string
+}
+
+func (f *fakeEngine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/health":
+		if f.healthChecks.Add(1) <= f.unhealthyChecks {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case "/v1/models":
+		_, _ = w.Write([]byte(`{"object": "list", "data": [{"id": "served-model"}]}`))
+	case "/v1/completions":
+		var body struct {
+			Model  string `json:"model"`
+			Prompt string `json:"prompt"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Prompt == "fail" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.prompts = append(f.prompts, body.Prompt)
+		f.models = append(f.models, body.Model)
+		f.mu.Unlock()
+		_, _ = w.Write([]byte(`{"choices": [{"text": "warm"}]}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestWarmupAndSignalReady(t *testing.T) {
+	engineProbeInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name            string
+		warmup          func(engineURL string) WarmupConfig
+		unhealthyChecks int64
+		expectReady     bool
+		expectPrompts   int
+		expectModel     string
+	}{
+		{
+			name: "warmup disabled",
+			warmup: func(engineURL string) WarmupConfig {
+				return defaultWarmupConfig()
+			},
+			expectReady: true,
+		},
+		{
+			name: "configured prompts and model",
+			warmup: func(engineURL string) WarmupConfig {
+				config := defaultWarmupConfig()
+				config.Enabled = true
+				config.EngineURL = engineURL
+				config.Model = "my-model"
+				config.Prompts = []string{"first", "second", "fail"}
+				config.Rounds = 3
+				config.Concurrency = 2
+				return config
+			},
+			unhealthyChecks: 2,
+			expectReady:     true,
+			expectPrompts:   6,
+			expectModel:     "my-model",
+		},
+		{
+			name: "default prompts and served model",
+			warmup: func(engineURL string) WarmupConfig {
+				config := defaultWarmupConfig()
+				config.Enabled = true
+				config.EngineURL = engineURL
+				return config
+			},
+			expectReady:   true,
+			expectPrompts: len(defaultWarmupPrompts),
+			expectModel:   "served-model",
+		},
+		{
+			name: "engine never healthy",
+			warmup: func(engineURL string) WarmupConfig {
+				config := defaultWarmupConfig()
+				config.Enabled = true
+				config.EngineURL = engineURL
+				config.EngineReadyTimeout = 100 * time.Millisecond
+				return config
+			},
+			unhealthyChecks: 1 << 20,
+			expectReady:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := &fakeEngine{unhealthyChecks: tt.unhealthyChecks}
+			server := httptest.NewServer(engine)
+			defer server.Close()
+
+			readyFile := filepath.Join(t.TempDir(), "ready", "warmup")
+			sidecar := &ServingSidecar{
+				logger: testingPkg.SetupMockLogger(),
+				Config: Config{
+					Warmup:        tt.warmup(server.URL),
+					ReadyFilePath: readyFile,
+				},
+			}
+
+			rec := httptest.NewRecorder()
+			sidecar.handleReadiness(rec, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+			sidecar.warmupAndSignalReady(context.Background())
+
+			assert.Equal(t, tt.expectReady, sidecar.ready.Load())
+			_, err := os.Stat(readyFile)
+			assert.Equal(t, tt.expectReady, err == nil)
+			rec = httptest.NewRecorder()
+			sidecar.handleReadiness(rec, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+			if tt.expectReady {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+			}
+
+			assert.Len(t, engine.prompts, tt.expectPrompts)
+			for _, model := range engine.models {
+				assert.Equal(t, tt.expectModel, model)
+			}
+		})
+	}
+}
+
+func TestGetServedModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	_, err := getServedModel(context.Background(), http.DefaultClient, server.URL)
+	assert.Error(t, err)
+
+	server = httptest.NewServer(&fakeEngine{})
+	defer server.Close()
+	model, err := getServedModel(context.Background(), http.DefaultClient, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "served-model", model)
+}
@@ -0,0 +1,125 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+
+	"github.com/sgl-project/ome/pkg/configutils"
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+const (
+	// DefaultManifestFile lists the SHA256 of every file of a model directory, in the format of sha256sum
+	DefaultManifestFile = "model.manifest"
+	// DefaultSignatureFile holds the base64 encoded signature of the manifest
+	DefaultSignatureFile = "model.manifest.sig"
+)
+
+// Config defines the configuration of a model directory verification
+type Config struct {
+	Logger logging.Interface
+
+	ModelPath string `mapstructure:"model_path" validate:"required"`
+	// ManifestFile and SignatureFile are relative to the model path and are not part of the verified files
+	ManifestFile  string `mapstructure:"manifest_file" validate:"required"`
+	SignatureFile string `mapstructure:"signature_file" validate:"required"`
+	// PublicKeyPath is the PEM encoded Ed25519, ECDSA or RSA public key the manifest is signed with
+	PublicKeyPath string `mapstructure:"public_key_path"`
+	// RequireSignature fails the verification unless the manifest is signed with the public key
+	RequireSignature bool `mapstructure:"require_signature"`
+	// SkipSafetensors skips the validation of the headers of the safetensors files
+	SkipSafetensors bool `mapstructure:"skip_safetensors"`
+	NumWorkers      int  `mapstructure:"num_workers" validate:"gt=0"`
+	// ReportPath is where the JSON report is written, the standard output if empty
+	ReportPath string `mapstructure:"report_path"`
+}
+
+// Option defines a function that applies configuration options
+type Option func(*Config) error
+
+// Apply applies the given options to the configuration
+func (c *Config) Apply(opts ...Option) error {
+	for _, o := range opts {
+		if o != nil {
+			if err := o(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// defaultConfig returns a new configuration with default values
+func defaultConfig() *Config {
+	return &Config{
+		ManifestFile:  DefaultManifestFile,
+		SignatureFile: DefaultSignatureFile,
+		NumWorkers:    4,
+	}
+}
+
+// NewConfig builds and returns a new configuration from the given options
+func NewConfig(opts ...Option) (*Config, error) {
+	c := defaultConfig()
+	if err := c.Apply(opts...); err != nil {
+		return nil, fmt.Errorf("failed to apply config options: %w", err)
+	}
+	return c, nil
+}
+
+// WithLogger sets the logger for the configuration
+func WithLogger(logger logging.Interface) Option {
+	return func(c *Config) error {
+		if logger == nil {
+			return errors.New("logger cannot be nil")
+		}
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithModelPath sets the model directory to verify
+func WithModelPath(modelPath string) Option {
+	return func(c *Config) error {
+		c.ModelPath = modelPath
+		return nil
+	}
+}
+
+// WithPublicKeyPath sets the public key the manifest is signed with
+func WithPublicKeyPath(publicKeyPath string) Option {
+	return func(c *Config) error {
+		c.PublicKeyPath = publicKeyPath
+		return nil
+	}
+}
+
+// WithViper loads configuration using Viper
+func WithViper(v *viper.Viper) Option {
+	return func(c *Config) error {
+		*c = *defaultConfig()
+
+		if err := configutils.BindEnvsRecursive(v, c, ""); err != nil {
+			return fmt.Errorf("error binding envs: %w", err)
+		}
+		if err := v.Unmarshal(c); err != nil {
+			return fmt.Errorf("error unmarshalling config: %w", err)
+		}
+		return nil
+	}
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(c); err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+	if c.RequireSignature && c.PublicKeyPath == "" {
+		return errors.New("public_key_path is required when require_signature is set")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"go.uber.org/fx"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+type verifyParams struct {
+	fx.In
+
+	Logger logging.Interface
+}
+
+// Module provides the model verifier via fx
+var Module = fx.Provide(
+	func(v *viper.Viper, params verifyParams) (*Verifier, error) {
+		config, err := NewConfig(
+			WithViper(v),
+			WithLogger(params.Logger),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating verify config: %+v", err)
+		}
+		return NewVerifier(config)
+	})
@@ -0,0 +1,153 @@
+package verify
+
+import (
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// maxSafetensorsHeaderSize bounds the header of a safetensors file, as the reference implementation does
+const maxSafetensorsHeaderSize = 100 * 1024 * 1024
+
+// safetensorsDTypeSizes are the sizes in bytes of the element types of safetensors
+var safetensorsDTypeSizes = map[string]int64{
+	"BOOL":    1,
+	"U8":      1,
+	"I8":      1,
+	"F8_E5M2": 1,
+	"F8_E4M3": 1,
+	"I16":     2,
+	"U16":     2,
+	"F16":     2,
+	"BF16":    2,
+	"I32":     4,
+	"U32":     4,
+	"F32":     4,
+	"I64":     8,
+	"U64":     8,
+	"F64":     8,
+}
+
+type safetensorsTensor struct {
+	DType       string   `json:"dtype"`
+	Shape       []int64  `json:"shape"`
+	DataOffsets [2]int64 `json:"data_offsets"`
+}
+
+// validateSafetensors validates the header of a safetensors file against its size, so truncated or overwritten
+// files are detected without loading their tensors, and returns the number of tensors. The tensors must cover the data
+// buffer without gaps or overlaps, and the tensors of a known type must have a size matching their shape. The size of
+// the tensors of other types, e.g. the packed F4 of newer releases of safetensors, is not checked.
+func validateSafetensors(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	var headerSize uint64
+	if err := binary.Read(file, binary.LittleEndian, &headerSize); err != nil {
+		return 0, fmt.Errorf("failed to read header size: %w", err)
+	}
+	if headerSize > maxSafetensorsHeaderSize || int64(headerSize) > info.Size()-8 {
+		return 0, fmt.Errorf("header size %d exceeds the file size %d", headerSize, info.Size())
+	}
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return 0, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(header, &entries); err != nil {
+		return 0, fmt.Errorf("invalid header: %w", err)
+	}
+	type namedTensor struct {
+		name string
+		safetensorsTensor
+	}
+	tensors := make([]namedTensor, 0, len(entries))
+	for name, raw := range entries {
+		if name == "__metadata__" {
+			continue
+		}
+		var tensor safetensorsTensor
+		if err := json.Unmarshal(raw, &tensor); err != nil {
+			return 0, fmt.Errorf("invalid tensor %s: %w", name, err)
+		}
+		begin, end := tensor.DataOffsets[0], tensor.DataOffsets[1]
+		if begin < 0 || end < begin {
+			return 0, fmt.Errorf("tensor %s has invalid data offsets [%d, %d]", name, begin, end)
+		}
+		size, known := safetensorsDTypeSizes[tensor.DType]
+		for _, dim := range tensor.Shape {
+			if dim < 0 {
+				return 0, fmt.Errorf("tensor %s has a negative dimension", name)
+			}
+			size *= dim
+		}
+		if known && end-begin != size {
+			return 0, fmt.Errorf("tensor %s has data offsets [%d, %d] not matching its %s%v size of %d bytes",
+				name, begin, end, tensor.DType, tensor.Shape, size)
+		}
+		tensors = append(tensors, namedTensor{name: name, safetensorsTensor: tensor})
+	}
+
+	// Empty tensors may share their offset with the next tensor
+	slices.SortFunc(tensors, func(a, b namedTensor) int {
+		return cmp.Or(cmp.Compare(a.DataOffsets[0], b.DataOffsets[0]), cmp.Compare(a.DataOffsets[1], b.DataOffsets[1]))
+	})
+	var offset int64
+	for _, tensor := range tensors {
+		if tensor.DataOffsets[0] != offset {
+			return 0, fmt.Errorf("tensor %s starts at %d instead of %d", tensor.name, tensor.DataOffsets[0], offset)
+		}
+		offset = tensor.DataOffsets[1]
+	}
+	if dataSize := info.Size() - 8 - int64(headerSize); offset != dataSize {
+		return 0, fmt.Errorf("tensors cover %d bytes of the %d bytes of data", offset, dataSize)
+	}
+	return len(tensors), nil
+}
+
+// validateSafetensorsIndex checks that the shards of a safetensors index exist next to it
+func validateSafetensorsIndex(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var index struct {
+		WeightMap map[string]string `json:"weight_map"`
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("invalid index: %w", err)
+	}
+	if len(index.WeightMap) == 0 {
+		return fmt.Errorf("index has no weight map")
+	}
+
+	shards := make(map[string]bool)
+	for _, shard := range index.WeightMap {
+		shards[shard] = true
+	}
+	var missing []string
+	for shard := range shards {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(path), shard)); err != nil {
+			missing = append(missing, shard)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("index references missing shards %v", missing)
+	}
+	return nil
+}
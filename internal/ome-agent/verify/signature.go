@@ -0,0 +1,185 @@
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// verifyManifest compares the files of the report with the manifest of the model directory, adding the missing
+// ones, and verifies the signature of the manifest
+func (v *Verifier) verifyManifest(report *Report) SignatureReport {
+	result := SignatureReport{Status: SignatureStatusUnsigned, Required: v.config.RequireSignature}
+
+	manifestPath := filepath.Join(v.config.ModelPath, v.config.ManifestFile)
+	manifest, err := readFileIfExists(manifestPath)
+	if err != nil {
+		result.Status = SignatureStatusInvalid
+		result.Error = fmt.Sprintf("failed to read manifest: %v", err)
+		return result
+	}
+	if manifest == nil {
+		return result
+	}
+	result.Manifest = v.config.ManifestFile
+
+	digests, err := parseManifest(manifest)
+	if err != nil {
+		result.Status = SignatureStatusInvalid
+		result.Error = err.Error()
+		return result
+	}
+	compareWithManifest(report, digests)
+
+	signature, err := readFileIfExists(filepath.Join(v.config.ModelPath, v.config.SignatureFile))
+	if err != nil {
+		result.Status = SignatureStatusInvalid
+		result.Error = fmt.Sprintf("failed to read signature: %v", err)
+		return result
+	}
+	if signature == nil {
+		return result
+	}
+	if v.config.PublicKeyPath == "" {
+		result.Status = SignatureStatusUnverified
+		return result
+	}
+
+	publicKey, err := loadPublicKey(v.config.PublicKeyPath)
+	if err != nil {
+		result.Status = SignatureStatusInvalid
+		result.Error = err.Error()
+		return result
+	}
+	result.KeyType = keyType(publicKey)
+	if err := verifySignature(publicKey, manifest, signature); err != nil {
+		result.Status = SignatureStatusInvalid
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = SignatureStatusVerified
+	return result
+}
+
+// parseManifest parses a manifest in the format of sha256sum into the digests by path
+func parseManifest(manifest []byte) (map[string]string, error) {
+	digests := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		digest, path, ok := strings.Cut(text, " ")
+		// sha256sum marks the files hashed in binary mode with a leading asterisk
+		path = strings.TrimPrefix(strings.TrimLeft(path, " "), "*")
+		path = strings.TrimPrefix(path, "./")
+		if _, err := hex.DecodeString(digest); !ok || err != nil || len(digest) != sha256.Size*2 || path == "" {
+			return nil, fmt.Errorf("invalid manifest line %d", line)
+		}
+		digests[path] = strings.ToLower(digest)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return digests, nil
+}
+
+// compareWithManifest marks the files that differ from the manifest or are not in it, and adds the files of the
+// manifest that are missing
+func compareWithManifest(report *Report, digests map[string]string) {
+	found := make(map[string]bool, len(report.Files))
+	for i := range report.Files {
+		file := &report.Files[i]
+		found[file.Path] = true
+		expected, ok := digests[file.Path]
+		if file.Status != FileStatusOK {
+			continue
+		}
+		if !ok {
+			file.Status = FileStatusUnlisted
+		} else if file.SHA256 != expected {
+			file.Status = FileStatusDigestMismatch
+			file.ExpectedSHA256 = expected
+		}
+	}
+
+	for path, digest := range digests {
+		if !found[path] {
+			report.Files = append(report.Files, FileReport{Path: path, Status: FileStatusMissing, ExpectedSHA256: digest})
+		}
+	}
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Path < report.Files[j].Path })
+}
+
+// loadPublicKey loads a PEM encoded public key
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("public key %s is not PEM encoded", path)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+// verifySignature verifies the base64 encoded signature of the manifest. Ed25519 signs the manifest itself, ECDSA
+// and RSA (PKCS #1 v1.5) its SHA256.
+func verifySignature(publicKey crypto.PublicKey, manifest, encoded []byte) error {
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return fmt.Errorf("signature is not base64 encoded: %w", err)
+	}
+
+	digest := sha256.Sum256(manifest)
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, manifest, signature) {
+			return errors.New("signature does not match the manifest")
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("signature does not match the manifest")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("signature does not match the manifest")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
+
+func keyType(publicKey crypto.PublicKey) string {
+	switch publicKey.(type) {
+	case ed25519.PublicKey:
+		return "ed25519"
+	case *ecdsa.PublicKey:
+		return "ecdsa"
+	case *rsa.PublicKey:
+		return "rsa"
+	default:
+		return fmt.Sprintf("%T", publicKey)
+	}
+}
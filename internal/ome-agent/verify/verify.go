@@ -0,0 +1,313 @@
+// Package verify verifies the integrity of a local model directory. Every file is hashed and compared with the
+// manifest of the directory, the signature of the manifest is checked against a public key, and the headers of the
+// safetensors files are validated against their size. The result is a machine-readable report, printed by the
+// verify subcommand of ome-agent and used by the model metadata agent to record the digest of a model.
+//
+// A manifest is created and signed next to the model files with, for instance:
+//
+//	cd /models/llama-7b
+//	find . -type f ! -name 'model.manifest*' ! -path './.cache/*' -printf '%P\0' | LC_ALL=C sort -z | xargs -0 sha256sum > model.manifest
+//	openssl pkeyutl -sign -inkey ed25519.pem -rawin -in model.manifest | base64 -w0 > model.manifest.sig
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// FileStatus is the outcome of the verification of a file
+type FileStatus string
+
+const (
+	// FileStatusOK means the file is intact
+	FileStatusOK FileStatus = "ok"
+	// FileStatusMissing means a file of the manifest is not in the model directory
+	FileStatusMissing FileStatus = "missing"
+	// FileStatusDigestMismatch means the content of the file differs from the manifest
+	FileStatusDigestMismatch FileStatus = "digest_mismatch"
+	// FileStatusUnlisted means the file is not in the manifest
+	FileStatusUnlisted FileStatus = "unlisted"
+	// FileStatusCorrupted means the file is not a valid safetensors file or index
+	FileStatusCorrupted FileStatus = "corrupted"
+	// FileStatusError means the file couldn't be read
+	FileStatusError FileStatus = "error"
+)
+
+// SignatureStatus is the outcome of the verification of the signature of the manifest
+type SignatureStatus string
+
+const (
+	// SignatureStatusVerified means the manifest is signed with the public key
+	SignatureStatusVerified SignatureStatus = "verified"
+	// SignatureStatusInvalid means the signature doesn't match the manifest or the public key
+	SignatureStatusInvalid SignatureStatus = "invalid"
+	// SignatureStatusUnverified means the manifest is signed but no public key is configured
+	SignatureStatusUnverified SignatureStatus = "unverified"
+	// SignatureStatusUnsigned means the model directory has no signed manifest
+	SignatureStatusUnsigned SignatureStatus = "unsigned"
+)
+
+// FileReport is the verification of a file of the model directory
+type FileReport struct {
+	Path   string     `json:"path"`
+	Status FileStatus `json:"status"`
+	Size   int64      `json:"size,omitempty"`
+	SHA256 string     `json:"sha256,omitempty"`
+	// ExpectedSHA256 is the digest of the manifest, when it differs from the actual one
+	ExpectedSHA256 string `json:"expectedSha256,omitempty"`
+	// Tensors is the number of tensors of a valid safetensors file
+	Tensors int    `json:"tensors,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SignatureReport is the verification of the manifest and its signature
+type SignatureReport struct {
+	Status   SignatureStatus `json:"status"`
+	Required bool            `json:"required"`
+	// Manifest is the manifest file, empty when the model directory has none
+	Manifest string `json:"manifest,omitempty"`
+	KeyType  string `json:"keyType,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Report is the result of the verification of a model directory
+type Report struct {
+	ModelPath string `json:"modelPath"`
+	// Digest is the SHA256 of the manifest of the actual files, so it identifies the content of the directory
+	Digest    string          `json:"digest"`
+	Files     []FileReport    `json:"files"`
+	Signature SignatureReport `json:"signature"`
+}
+
+// Failures returns the verifications of the files that are missing, modified, unlisted or corrupted
+func (r *Report) Failures() []FileReport {
+	var failures []FileReport
+	for _, file := range r.Files {
+		if file.Status != FileStatusOK {
+			failures = append(failures, file)
+		}
+	}
+	return failures
+}
+
+// OK returns whether every file is intact and the signature is valid, or not required and absent
+func (r *Report) OK() bool {
+	if len(r.Failures()) > 0 || r.Signature.Status == SignatureStatusInvalid {
+		return false
+	}
+	return !r.Signature.Required || r.Signature.Status == SignatureStatusVerified
+}
+
+// Verifier verifies a model directory
+type Verifier struct {
+	config *Config
+	logger logging.Interface
+}
+
+// NewVerifier creates a verifier from a validated configuration
+func NewVerifier(config *Config) (*Verifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return &Verifier{config: config, logger: config.Logger}, nil
+}
+
+// Verify verifies the model directory. It returns an error when the directory can't be read, and a report
+// otherwise.
+func (v *Verifier) Verify() (*Report, error) {
+	modelPath := v.config.ModelPath
+	if info, err := os.Stat(modelPath); err != nil {
+		return nil, fmt.Errorf("failed to read model path: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("model path %s is not a directory", modelPath)
+	}
+
+	paths, err := v.listFiles()
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		ModelPath: modelPath,
+		Files:     make([]FileReport, len(paths)),
+	}
+	v.logger.Infof("Verifying %d files in %s", len(paths), modelPath)
+
+	// Files are hashed and validated in parallel
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, v.config.NumWorkers)
+	for i, path := range paths {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			report.Files[i] = v.verifyFile(path)
+		}(i, path)
+	}
+	wg.Wait()
+
+	report.Digest = manifestDigest(report.Files)
+	report.Signature = v.verifyManifest(report)
+
+	if failures := report.Failures(); len(failures) > 0 {
+		v.logger.Warnf("%d of %d files of %s failed the verification", len(failures), len(report.Files), modelPath)
+	}
+	v.logger.Infof("Verified %s with digest %s, signature %s", modelPath, report.Digest, report.Signature.Status)
+	return report, nil
+}
+
+// WriteReport writes the report as JSON to the report path, or to the standard output if it is not set
+func (v *Verifier) WriteReport(report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal verification report: %w", err)
+	}
+	data = append(data, '\n')
+	if v.config.ReportPath == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(v.config.ReportPath, data, 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write verification report: %w", err)
+	}
+	return nil
+}
+
+// listFiles returns the sorted paths of the files of the model directory, relative to it and slash separated. The
+// manifest and its signature are skipped, like the metadata the hub client keeps under .cache.
+func (v *Verifier) listFiles() ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(v.config.ModelPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(v.config.ModelPath, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == ".cache" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel == v.config.ManifestFile || rel == v.config.SignatureFile {
+			return nil
+		}
+		// Snapshots of the hub cache link to their blobs, other special files are skipped
+		if !d.Type().IsRegular() {
+			if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk model path: %w", err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// verifyFile hashes a file and validates it if it is a safetensors file or index
+func (v *Verifier) verifyFile(path string) FileReport {
+	result := FileReport{Path: path}
+	localPath := filepath.Join(v.config.ModelPath, filepath.FromSlash(path))
+
+	size, digest, err := sha256File(localPath)
+	if err != nil {
+		result.Status = FileStatusError
+		result.Error = err.Error()
+		return result
+	}
+	result.Size = size
+	result.SHA256 = digest
+	result.Status = FileStatusOK
+
+	if v.config.SkipSafetensors {
+		return result
+	}
+	switch {
+	case strings.HasSuffix(path, ".safetensors"):
+		tensors, err := validateSafetensors(localPath)
+		if err != nil {
+			result.Status = FileStatusCorrupted
+			result.Error = err.Error()
+			return result
+		}
+		result.Tensors = tensors
+	case strings.HasSuffix(path, ".safetensors.index.json"):
+		if err := validateSafetensorsIndex(localPath); err != nil {
+			result.Status = FileStatusCorrupted
+			result.Error = err.Error()
+		}
+	}
+	return result
+}
+
+// sha256File returns the size and the SHA256 of a file
+func sha256File(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// formatManifest formats digests by path in the format of sha256sum, sorted by path
+func formatManifest(digests map[string]string) []byte {
+	paths := make([]string, 0, len(digests))
+	for path := range digests {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "%s  %s\n", digests[path], path)
+	}
+	return []byte(b.String())
+}
+
+// manifestDigest returns the SHA256 of the manifest of the hashed files
+func manifestDigest(files []FileReport) string {
+	digests := make(map[string]string, len(files))
+	for _, file := range files {
+		if file.SHA256 != "" {
+			digests[file.Path] = file.SHA256
+		}
+	}
+	sum := sha256.Sum256(formatManifest(digests))
+	return hex.EncodeToString(sum[:])
+}
+
+// readFileIfExists returns the content of a file, nil if it doesn't exist
+func readFileIfExists(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
@@ -0,0 +1,405 @@
+package verify
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// safetensorsFile builds a safetensors file with a F32 tensor of each of the given lengths
+func safetensorsFile(t *testing.T, lengths ...int64) []byte {
+	header := map[string]any{"__metadata__": map[string]string{"format": "pt"}}
+	var offset int64
+	for i, length := range lengths {
+		header[string(rune('a'+i))] = map[string]any{
+			"dtype":        "F32",
+			"shape":        []int64{length},
+			"data_offsets": []int64{offset, offset + 4*length},
+		}
+		offset += 4 * length
+	}
+	headerJSON, err := json.Marshal(header)
+	require.NoError(t, err)
+
+	data := binary.LittleEndian.AppendUint64(nil, uint64(len(headerJSON)))
+	data = append(data, headerJSON...)
+	return append(data, make([]byte, offset)...)
+}
+
+func writeModel(t *testing.T, files map[string][]byte) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, content, 0644))
+	}
+	return dir
+}
+
+func writeManifest(t *testing.T, dir string, files map[string][]byte) []byte {
+	digests := make(map[string]string, len(files))
+	for name, content := range files {
+		sum := sha256.Sum256(content)
+		digests[name] = hex.EncodeToString(sum[:])
+	}
+	manifest := formatManifest(digests)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultManifestFile), manifest, 0644))
+	return manifest
+}
+
+func writePublicKey(t *testing.T, publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	return path
+}
+
+func writeSignature(t *testing.T, dir string, signature []byte) {
+	encoded := base64.StdEncoding.EncodeToString(signature)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultSignatureFile), []byte(encoded+"\n"), 0644))
+}
+
+func newTestVerifier(t *testing.T, modelPath string, opts ...Option) *Verifier {
+	config, err := NewConfig(append([]Option{WithLogger(logging.Discard()), WithModelPath(modelPath)}, opts...)...)
+	require.NoError(t, err)
+	verifier, err := NewVerifier(config)
+	require.NoError(t, err)
+	return verifier
+}
+
+func modelFiles(t *testing.T) map[string][]byte {
+	return map[string][]byte{
+		"config.json":                        []byte(`{"model_type": "llama"}`),
+		"model-00001-of-00002.safetensors":   safetensorsFile(t, 4, 2),
+		"model-00002-of-00002.safetensors":   safetensorsFile(t, 8),
+		"model.safetensors.index.json":       []byte(`{"weight_map": {"a": "model-00001-of-00002.safetensors", "b": "model-00001-of-00002.safetensors", "c": "model-00002-of-00002.safetensors"}}`),
+		"tokenizer/tokenizer_config.json":    []byte(`{}`),
+		".cache/huggingface/download/x.lock": nil,
+	}
+}
+
+func TestVerifyUnsignedModel(t *testing.T) {
+	files := modelFiles(t)
+	dir := writeModel(t, files)
+
+	report, err := newTestVerifier(t, dir).Verify()
+	require.NoError(t, err)
+
+	assert.True(t, report.OK())
+	assert.Equal(t, SignatureStatusUnsigned, report.Signature.Status)
+	require.Len(t, report.Files, 5, "files under .cache are skipped")
+	assert.Equal(t, "config.json", report.Files[0].Path)
+	sum := sha256.Sum256(files["config.json"])
+	assert.Equal(t, hex.EncodeToString(sum[:]), report.Files[0].SHA256)
+	assert.Equal(t, int64(len(files["config.json"])), report.Files[0].Size)
+	assert.Equal(t, 2, report.Files[1].Tensors)
+	assert.Equal(t, 1, report.Files[2].Tensors)
+	assert.Len(t, report.Digest, 64)
+
+	// The digest only depends on the content of the files
+	again, err := newTestVerifier(t, writeModel(t, files)).Verify()
+	require.NoError(t, err)
+	assert.Equal(t, report.Digest, again.Digest)
+}
+
+func TestVerifyCorruptedSafetensors(t *testing.T) {
+	valid := safetensorsFile(t, 4, 2)
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr string
+	}{
+		{
+			name:    "truncated",
+			content: valid[:len(valid)-4],
+			wantErr: "tensors cover 24 bytes of the 20 bytes of data",
+		},
+		{
+			name:    "trailing data",
+			content: append(append([]byte{}, valid...), 0),
+			wantErr: "tensors cover 24 bytes of the 25 bytes of data",
+		},
+		{
+			name:    "header larger than file",
+			content: binary.LittleEndian.AppendUint64(nil, 1024),
+			wantErr: "exceeds the file size",
+		},
+		{
+			name:    "too short",
+			content: []byte{1, 2},
+			wantErr: "failed to read header size",
+		},
+		{
+			name: "offsets not matching shape",
+			content: func() []byte {
+				header := []byte(`{"a":{"dtype":"F16","shape":[4],"data_offsets":[0,16]}}`)
+				data := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+				return append(append(data, header...), make([]byte, 16)...)
+			}(),
+			wantErr: "not matching its F16[4] size of 8 bytes",
+		},
+		{
+			name: "overlapping tensors",
+			content: func() []byte {
+				header := []byte(`{"a":{"dtype":"F16","shape":[4],"data_offsets":[0,8]},"b":{"dtype":"F4","shape":[4],"data_offsets":[4,6]}}`)
+				data := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+				return append(append(data, header...), make([]byte, 8)...)
+			}(),
+			wantErr: "tensor b starts at 4 instead of 8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeModel(t, map[string][]byte{"model.safetensors": tt.content})
+
+			report, err := newTestVerifier(t, dir).Verify()
+			require.NoError(t, err)
+			assert.False(t, report.OK())
+			require.Len(t, report.Files, 1)
+			assert.Equal(t, FileStatusCorrupted, report.Files[0].Status)
+			assert.Contains(t, report.Files[0].Error, tt.wantErr)
+
+			// The validation can be skipped
+			report, err = newTestVerifier(t, dir, func(c *Config) error {
+				c.SkipSafetensors = true
+				return nil
+			}).Verify()
+			require.NoError(t, err)
+			assert.True(t, report.OK())
+		})
+	}
+}
+
+func TestVerifySafetensorsUncheckedTensors(t *testing.T) {
+	// Tensors of types added after this validator, whose size isn't checked, and empty tensors sharing an offset
+	header := []byte(`{
+		"scales":{"dtype":"F8_E8M0","shape":[4],"data_offsets":[0,4]},
+		"weights":{"dtype":"F4","shape":[8],"data_offsets":[4,8]},
+		"bias":{"dtype":"F32","shape":[2],"data_offsets":[8,16]},
+		"empty_b":{"dtype":"F32","shape":[0],"data_offsets":[8,8]},
+		"empty_a":{"dtype":"F16","shape":[0,4],"data_offsets":[8,8]}
+	}`)
+	data := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+	data = append(append(data, header...), make([]byte, 16)...)
+	dir := writeModel(t, map[string][]byte{"model.safetensors": data})
+
+	report, err := newTestVerifier(t, dir).Verify()
+	require.NoError(t, err)
+	assert.True(t, report.OK(), "%+v", report.Files)
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, 5, report.Files[0].Tensors)
+}
+
+func TestVerifySafetensorsIndexWithMissingShard(t *testing.T) {
+	files := modelFiles(t)
+	delete(files, "model-00002-of-00002.safetensors")
+	dir := writeModel(t, files)
+
+	report, err := newTestVerifier(t, dir).Verify()
+	require.NoError(t, err)
+	assert.False(t, report.OK())
+	failures := report.Failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "model.safetensors.index.json", failures[0].Path)
+	assert.Contains(t, failures[0].Error, "missing shards [model-00002-of-00002.safetensors]")
+}
+
+func TestVerifyManifest(t *testing.T) {
+	files := modelFiles(t)
+	delete(files, ".cache/huggingface/download/x.lock")
+	dir := writeModel(t, files)
+	writeManifest(t, dir, files)
+
+	report, err := newTestVerifier(t, dir).Verify()
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.Equal(t, SignatureStatusUnsigned, report.Signature.Status)
+	assert.Equal(t, DefaultManifestFile, report.Signature.Manifest)
+
+	// Modified, missing and unlisted files fail the verification
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"model_type": "mistral"}`), 0644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "tokenizer", "tokenizer_config.json")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "extra.bin"), []byte("extra"), 0644))
+
+	report, err = newTestVerifier(t, dir).Verify()
+	require.NoError(t, err)
+	assert.False(t, report.OK())
+	statuses := map[string]FileStatus{}
+	for _, file := range report.Failures() {
+		statuses[file.Path] = file.Status
+	}
+	assert.Equal(t, map[string]FileStatus{
+		"config.json":                     FileStatusDigestMismatch,
+		"extra.bin":                       FileStatusUnlisted,
+		"tokenizer/tokenizer_config.json": FileStatusMissing,
+	}, statuses)
+	assert.Equal(t, "config.json", report.Files[0].Path)
+	assert.NotEmpty(t, report.Files[0].ExpectedSHA256)
+	assert.Equal(t, "tokenizer/tokenizer_config.json", report.Files[len(report.Files)-1].Path)
+}
+
+func TestVerifySignature(t *testing.T) {
+	ed25519Public, ed25519Private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		publicKey crypto.PublicKey
+		sign      func(manifest []byte) []byte
+		required  bool
+		expected  SignatureStatus
+		keyType   string
+		ok        bool
+	}{
+		{
+			name:      "ed25519",
+			publicKey: ed25519Public,
+			sign:      func(manifest []byte) []byte { return ed25519.Sign(ed25519Private, manifest) },
+			required:  true,
+			expected:  SignatureStatusVerified,
+			keyType:   "ed25519",
+			ok:        true,
+		},
+		{
+			name:      "ecdsa",
+			publicKey: &ecdsaPrivate.PublicKey,
+			sign: func(manifest []byte) []byte {
+				digest := sha256.Sum256(manifest)
+				signature, err := ecdsa.SignASN1(rand.Reader, ecdsaPrivate, digest[:])
+				require.NoError(t, err)
+				return signature
+			},
+			expected: SignatureStatusVerified,
+			keyType:  "ecdsa",
+			ok:       true,
+		},
+		{
+			name:      "rsa",
+			publicKey: &rsaPrivate.PublicKey,
+			sign: func(manifest []byte) []byte {
+				digest := sha256.Sum256(manifest)
+				signature, err := rsa.SignPKCS1v15(rand.Reader, rsaPrivate, crypto.SHA256, digest[:])
+				require.NoError(t, err)
+				return signature
+			},
+			expected: SignatureStatusVerified,
+			keyType:  "rsa",
+			ok:       true,
+		},
+		{
+			name:      "signed with another key",
+			publicKey: otherPublic,
+			sign:      func(manifest []byte) []byte { return ed25519.Sign(ed25519Private, manifest) },
+			expected:  SignatureStatusInvalid,
+			keyType:   "ed25519",
+		},
+		{
+			name:      "unsigned but required",
+			publicKey: ed25519Public,
+			required:  true,
+			expected:  SignatureStatusUnsigned,
+		},
+		{
+			name:     "signed without public key",
+			sign:     func(manifest []byte) []byte { return ed25519.Sign(ed25519Private, manifest) },
+			expected: SignatureStatusUnverified,
+			ok:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string][]byte{"model.safetensors": safetensorsFile(t, 4)}
+			dir := writeModel(t, files)
+			manifest := writeManifest(t, dir, files)
+			if tt.sign != nil {
+				writeSignature(t, dir, tt.sign(manifest))
+			}
+
+			opts := []Option{func(c *Config) error {
+				c.RequireSignature = tt.required
+				return nil
+			}}
+			if tt.publicKey != nil {
+				opts = append(opts, WithPublicKeyPath(writePublicKey(t, tt.publicKey)))
+			}
+			report, err := newTestVerifier(t, dir, opts...).Verify()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, report.Signature.Status, report.Signature.Error)
+			assert.Equal(t, tt.keyType, report.Signature.KeyType)
+			assert.Equal(t, tt.required, report.Signature.Required)
+			assert.Equal(t, tt.ok, report.OK())
+		})
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	digest := hex.EncodeToString(make([]byte, 32))
+	digests, err := parseManifest([]byte(digest + "  config.json\n" + digest + " *./weights/model.bin\n\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"config.json": digest, "weights/model.bin": digest}, digests)
+
+	_, err = parseManifest([]byte("not-a-digest  config.json\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid manifest line 1")
+}
+
+func TestWriteReport(t *testing.T) {
+	dir := writeModel(t, map[string][]byte{"config.json": []byte("{}")})
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	verifier := newTestVerifier(t, dir, func(c *Config) error {
+		c.ReportPath = reportPath
+		return nil
+	})
+
+	report, err := verifier.Verify()
+	require.NoError(t, err)
+	require.NoError(t, verifier.WriteReport(report))
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var written Report
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, *report, written)
+}
+
+func TestConfigValidate(t *testing.T) {
+	_, err := NewVerifier(&Config{ManifestFile: DefaultManifestFile, SignatureFile: DefaultSignatureFile, NumWorkers: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ModelPath")
+
+	config, err := NewConfig(WithLogger(logging.Discard()), WithModelPath("/model"), func(c *Config) error {
+		c.RequireSignature = true
+		return nil
+	})
+	require.NoError(t, err)
+	err = config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "public_key_path is required")
+}
@@ -0,0 +1,31 @@
+package acceleratorclassselector
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// EstimateHourlyCost estimates the hourly cost of running the given number of pods on an accelerator class.
+// Each pod requests the accelerators of the class, priced at the spot price if set, otherwise at the on-demand price.
+// Only the resource counting accelerators is priced, not the companion resources such as RDMA devices.
+// Returns nil if the class has no hourly cost.
+func EstimateHourlyCost(ac *v1beta1.AcceleratorClass, pods int) *resource.Quantity {
+	if ac == nil || ac.Spec.Cost == nil {
+		return nil
+	}
+	hourly := getHourlyCost(*ac)
+	if hourly == nil {
+		return nil
+	}
+
+	var acceleratorsPerPod int64
+	if accelerator := AcceleratorResource(ac); accelerator != nil {
+		acceleratorsPerPod = accelerator.Quantity.Value()
+	}
+	if acceleratorsPerPod == 0 {
+		acceleratorsPerPod = 1
+	}
+
+	return resource.NewMilliQuantity(hourly.MilliValue()*acceleratorsPerPod*int64(pods), resource.DecimalSI)
+}
@@ -0,0 +1,93 @@
+package acceleratorclassselector
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// TestEstimateHourlyCost tests the hourly cost estimate of an accelerator class
+func TestEstimateHourlyCost(t *testing.T) {
+	perHour := resource.MustParse("2.5")
+	spotPerHour := resource.MustParse("1.2")
+
+	tests := []struct {
+		name     string
+		ac       *v1beta1.AcceleratorClass
+		pods     int
+		expected *resource.Quantity
+	}{
+		{
+			name:     "Nil accelerator class",
+			ac:       nil,
+			pods:     1,
+			expected: nil,
+		},
+		{
+			name:     "No cost information",
+			ac:       &v1beta1.AcceleratorClass{},
+			pods:     1,
+			expected: nil,
+		},
+		{
+			name: "Only tier cost",
+			ac: &v1beta1.AcceleratorClass{
+				Spec: v1beta1.AcceleratorClassSpec{Cost: &v1beta1.AcceleratorCost{Tier: "low"}},
+			},
+			pods:     1,
+			expected: nil,
+		},
+		{
+			name: "On-demand cost without resources counts one accelerator per pod",
+			ac: &v1beta1.AcceleratorClass{
+				Spec: v1beta1.AcceleratorClassSpec{Cost: &v1beta1.AcceleratorCost{PerHour: &perHour}},
+			},
+			pods:     2,
+			expected: resource.NewMilliQuantity(5000, resource.DecimalSI),
+		},
+		{
+			name: "Spot cost is preferred and scaled by accelerators per pod",
+			ac: &v1beta1.AcceleratorClass{
+				Spec: v1beta1.AcceleratorClassSpec{
+					Cost: &v1beta1.AcceleratorCost{PerHour: &perHour, SpotPerHour: &spotPerHour},
+					Resources: []v1beta1.AcceleratorResource{
+						{Name: "nvidia.com/gpu", Quantity: resource.MustParse("8")},
+					},
+				},
+			},
+			pods:     3,
+			expected: resource.NewMilliQuantity(28800, resource.DecimalSI),
+		},
+		{
+			name: "Companion resources are not priced",
+			ac: &v1beta1.AcceleratorClass{
+				Spec: v1beta1.AcceleratorClassSpec{
+					Cost: &v1beta1.AcceleratorCost{PerHour: &perHour},
+					Resources: []v1beta1.AcceleratorResource{
+						{Name: "rdma/hca_shared_devices", Quantity: resource.MustParse("1")},
+						{Name: "nvidia.com/gpu", Quantity: resource.MustParse("4")},
+					},
+				},
+			},
+			pods:     1,
+			expected: resource.NewMilliQuantity(10000, resource.DecimalSI),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EstimateHourlyCost(tt.ac, tt.pods)
+			if tt.expected == nil {
+				if result != nil {
+					t.Errorf("EstimateHourlyCost() = %s, want nil", result.String())
+				}
+				return
+			}
+			if result == nil || result.Cmp(*tt.expected) != 0 {
+				t.Errorf("EstimateHourlyCost() = %v, want %s", result, tt.expected.String())
+			}
+		})
+	}
+}
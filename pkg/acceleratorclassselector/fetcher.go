@@ -44,16 +44,25 @@ func (f *DefaultAcceleratorFetcher) FetchAcceleratorClasses(ctx context.Context)
 
 // GetAcceleratorClass fetches a specific accelerator class by name.
 // It first checks namespace-scoped accelerator classes, then cluster-scoped ones.
+// Names of the form "<class>/<profile>" resolve to the MIG sub-class of the given profile.
 func (f *DefaultAcceleratorFetcher) GetAcceleratorClass(ctx context.Context, name string) (*v1beta1.AcceleratorClass, bool, error) {
 	logger := log.FromContext(ctx)
 	logger.V(1).Info("Getting accelerator class", "name", name)
 
+	className, profile := v1beta1.ParseAcceleratorClassName(name)
+
 	// If not found in namespace, try cluster-scoped AcceleratorClass
 	var clusterAcceleratorClass v1beta1.AcceleratorClass
-	acceleratorClassName := client.ObjectKey{Name: name}
+	acceleratorClassName := client.ObjectKey{Name: className}
 	if err := f.client.Get(ctx, acceleratorClassName, &clusterAcceleratorClass); err == nil {
-		logger.V(1).Info("Found cluster-scoped accelerator class", "name", name)
-		return &clusterAcceleratorClass, true, nil
+		if profile == "" {
+			logger.V(1).Info("Found cluster-scoped accelerator class", "name", name)
+			return &clusterAcceleratorClass, true, nil
+		}
+		if sub := migProfileClass(&clusterAcceleratorClass, profile); sub != nil {
+			logger.V(1).Info("Found MIG profile of cluster-scoped accelerator class", "name", className, "profile", profile)
+			return sub, true, nil
+		}
 	}
 
 	// Not found in either scope
@@ -0,0 +1,57 @@
+package acceleratorclassselector
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// migProfileClass derives the AcceleratorClass of a MIG sub-class from its parent class.
+// The sub-class requests a single instance of the profile instead of full GPUs, reports the
+// memory of the profile, and scales the performance of the parent by the profile's share of
+// compute slices. Its capacity is the capacity of the profile, in instances of the profile. It returns nil if the
+// parent class has no such profile.
+func migProfileClass(parent *v1beta1.AcceleratorClass, profileName string) *v1beta1.AcceleratorClass {
+	profile := parent.Spec.GetMIGProfile(profileName)
+	if profile == nil {
+		return nil
+	}
+
+	sub := parent.DeepCopy()
+	sub.Name = parent.Name + v1beta1.MIGProfileSeparator + profile.Name
+	sub.Spec.MIGProfiles = nil
+	sub.Spec.Resources = []v1beta1.AcceleratorResource{
+		{Name: profile.ResourceName, Quantity: resource.MustParse("1")},
+	}
+	sub.Status.MIGProfiles = nil
+	sub.Status.TotalAccelerators, sub.Status.AllocatableAccelerators = 0, 0
+	sub.Status.UsedAccelerators, sub.Status.AvailableAccelerators = 0, 0
+	for _, status := range parent.Status.MIGProfiles {
+		if status.Name == profile.Name {
+			sub.Status.TotalAccelerators = status.TotalInstances
+			sub.Status.AllocatableAccelerators = status.AllocatableInstances
+			sub.Status.UsedAccelerators = status.UsedInstances
+			sub.Status.AvailableAccelerators = status.AvailableInstances
+		}
+	}
+	if profile.MemoryGB != nil {
+		memory := profile.MemoryGB.DeepCopy()
+		sub.Spec.Capabilities.MemoryGB = &memory
+	}
+
+	if perf := sub.Spec.Capabilities.Performance; perf != nil {
+		share := func(v *int64) *int64 {
+			if v == nil {
+				return nil
+			}
+			scaled := *v * int64(profile.ComputeSlices) / v1beta1.MaxMIGComputeSlices
+			return &scaled
+		}
+		perf.Fp32Tflops = share(perf.Fp32Tflops)
+		perf.Fp16Tflops = share(perf.Fp16Tflops)
+		perf.Int8Tops = share(perf.Int8Tops)
+		perf.Int4Tops = share(perf.Int4Tops)
+	}
+
+	return sub
+}
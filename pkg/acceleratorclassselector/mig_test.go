@@ -0,0 +1,78 @@
+package acceleratorclassselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestDefaultAcceleratorFetcher_GetAcceleratorClass_MIGProfile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	fp16 := int64(312)
+	memory := mustParseQuantity("80Gi")
+	profileMemory := mustParseQuantity("10Gi")
+	parent := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-a100-80gb"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Vendor: "nvidia",
+			Discovery: v1beta1.AcceleratorDiscovery{
+				NodeSelector: map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-80GB"},
+			},
+			Capabilities: v1beta1.AcceleratorCapabilities{
+				MemoryGB:    &memory,
+				Performance: &v1beta1.AcceleratorPerformance{Fp16Tflops: &fp16},
+			},
+			Resources: []v1beta1.AcceleratorResource{
+				{Name: "nvidia.com/gpu", Quantity: mustParseQuantity("1")},
+			},
+			MIGProfiles: []v1beta1.MIGProfile{
+				{Name: "1g.10gb", ResourceName: "nvidia.com/mig-1g.10gb", MemoryGB: &profileMemory, ComputeSlices: 1},
+			},
+		},
+		Status: v1beta1.AcceleratorClassStatus{
+			TotalAccelerators:     6,
+			AvailableAccelerators: 6,
+			MIGProfiles: []v1beta1.MIGProfileStatus{
+				{Name: "1g.10gb", TotalInstances: 14, AllocatableInstances: 14, UsedInstances: 3, AvailableInstances: 11},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).NotTo(gomega.HaveOccurred())
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(parent).
+		Build()
+	fetcher := NewDefaultAcceleratorFetcher(c)
+
+	sub, found, err := fetcher.GetAcceleratorClass(context.TODO(), "nvidia-a100-80gb/1g.10gb")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(sub.Name).To(gomega.Equal("nvidia-a100-80gb/1g.10gb"))
+	g.Expect(sub.Spec.Resources).To(gomega.HaveLen(1))
+	g.Expect(sub.Spec.Resources[0].Name).To(gomega.Equal("nvidia.com/mig-1g.10gb"))
+	g.Expect(sub.Spec.Resources[0].Quantity.Value()).To(gomega.Equal(int64(1)))
+	g.Expect(sub.Spec.Capabilities.MemoryGB.Cmp(profileMemory)).To(gomega.Equal(0))
+	g.Expect(*sub.Spec.Capabilities.Performance.Fp16Tflops).To(gomega.Equal(int64(44)))
+	g.Expect(sub.Spec.Discovery.NodeSelector).To(gomega.Equal(parent.Spec.Discovery.NodeSelector))
+	g.Expect(sub.Status.TotalAccelerators).To(gomega.Equal(int32(14)))
+	g.Expect(sub.Status.AvailableAccelerators).To(gomega.Equal(int32(11)))
+	g.Expect(sub.Status.MIGProfiles).To(gomega.BeEmpty())
+
+	// The parent class is left untouched
+	g.Expect(*parent.Spec.Capabilities.Performance.Fp16Tflops).To(gomega.Equal(int64(312)))
+
+	_, found, err = fetcher.GetAcceleratorClass(context.TODO(), "nvidia-a100-80gb/7g.80gb")
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(found).To(gomega.BeFalse())
+	var notFoundErr *AcceleratorNotFoundError
+	g.Expect(err).To(gomega.BeAssignableToTypeOf(notFoundErr))
+}
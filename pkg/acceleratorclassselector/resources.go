@@ -0,0 +1,33 @@
+package acceleratorclassselector
+
+import (
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// isAcceleratorResource reports whether a resource name is one the accelerator device plugins expose,
+// as opposed to companion resources such as RDMA devices that an AcceleratorClass may also list
+func isAcceleratorResource(name string) bool {
+	switch name {
+	case constants.NvidiaGPUResourceType, constants.AMDGPUResourceType, constants.IntelGaudiResourceType, constants.GoogleTPUResourceType:
+		return true
+	}
+	return strings.HasPrefix(name, constants.NvidiaMIGResourcePrefix) || strings.HasPrefix(name, constants.IntelGPUResourcePrefix)
+}
+
+// AcceleratorResource returns the resource of an accelerator class that counts accelerators.
+// It falls back to the first resource of the class if none has a known accelerator name,
+// and returns nil if the class lists no resources.
+func AcceleratorResource(ac *v1beta1.AcceleratorClass) *v1beta1.AcceleratorResource {
+	if ac == nil || len(ac.Spec.Resources) == 0 {
+		return nil
+	}
+	for i := range ac.Spec.Resources {
+		if isAcceleratorResource(ac.Spec.Resources[i].Name) {
+			return &ac.Spec.Resources[i]
+		}
+	}
+	return &ac.Spec.Resources[0]
+}
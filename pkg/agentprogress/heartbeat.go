@@ -0,0 +1,109 @@
+package agentprogress
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Version is the version of the heartbeat format. Readers ignore the heartbeats of other versions.
+const Version = "v1"
+
+// Phase is the step of its work an agent is at
+type Phase string
+
+const (
+	// PhaseStarting is reported as soon as the agent starts, before it reports a phase of its own
+	PhaseStarting Phase = "Starting"
+	// PhaseCopying is reported while the agent copies model files
+	PhaseCopying Phase = "Copying"
+	// PhaseDownloading is reported while the agent downloads model files
+	PhaseDownloading Phase = "Downloading"
+	// PhaseDecrypting is reported while the agent decrypts model files
+	PhaseDecrypting Phase = "Decrypting"
+	// PhaseVerifying is reported while the agent verifies model files
+	PhaseVerifying Phase = "Verifying"
+	// PhaseUploading is reported while the agent uploads model files, e.g. pushes a model image
+	PhaseUploading Phase = "Uploading"
+	// PhaseSucceeded is the last heartbeat of an agent that completed its work
+	PhaseSucceeded Phase = "Succeeded"
+	// PhaseFailed is the last heartbeat of an agent that failed
+	PhaseFailed Phase = "Failed"
+)
+
+// Heartbeat is the progress an agent publishes periodically, as JSON, to the progress ConfigMap of its component and
+// to the progress file it is configured to write
+type Heartbeat struct {
+	Version string `json:"version"`
+	Agent   string `json:"agent"`
+	Phase   Phase  `json:"phase"`
+	Message string `json:"message,omitempty"`
+	// Completed and Total count the units of work of the phase, e.g. files or bytes, Total is 0 when unknown
+	Completed int64  `json:"completed,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Unit      string `json:"unit,omitempty"`
+	// Percent is the percentage of the phase completed, absent when its total is unknown
+	Percent   *int      `json:"percent,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Done returns whether the heartbeat is the last one of the agent
+func (h *Heartbeat) Done() bool {
+	return h.Phase == PhaseSucceeded || h.Phase == PhaseFailed
+}
+
+// Summary describes the heartbeat in a line, e.g. "Downloading 42% (12/28 files): meta-llama/Llama-3.2-1B"
+func (h *Heartbeat) Summary() string {
+	summary := string(h.Phase)
+	switch {
+	case h.Percent != nil && h.Unit != "":
+		summary += fmt.Sprintf(" %d%% (%d/%d %s)", *h.Percent, h.Completed, h.Total, h.Unit)
+	case h.Percent != nil:
+		summary += fmt.Sprintf(" %d%%", *h.Percent)
+	case h.Completed > 0 && h.Unit != "":
+		summary += fmt.Sprintf(" (%d %s)", h.Completed, h.Unit)
+	}
+	switch {
+	case h.Error != "":
+		summary += ": " + h.Error
+	case h.Message != "":
+		summary += ": " + h.Message
+	}
+	return summary
+}
+
+// Parse decodes a heartbeat, failing for the heartbeats of other versions of the format
+func Parse(data []byte) (*Heartbeat, error) {
+	heartbeat := &Heartbeat{}
+	if err := json.Unmarshal(data, heartbeat); err != nil {
+		return nil, fmt.Errorf("invalid heartbeat: %w", err)
+	}
+	if heartbeat.Version != Version {
+		return nil, fmt.Errorf("unsupported heartbeat version %q, expected %q", heartbeat.Version, Version)
+	}
+	return heartbeat, nil
+}
+
+// ReadFile reads the heartbeat of a progress file
+func ReadFile(path string) (*Heartbeat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// percent returns the percentage of completed out of total, capped at 100, or nil if the total is unknown
+func percent(completed, total int64) *int {
+	if total <= 0 {
+		return nil
+	}
+	p := int(completed * 100 / total)
+	if p > 100 {
+		p = 100
+	}
+	return &p
+}
@@ -0,0 +1,324 @@
+package agentprogress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// DefaultInterval is the default interval between two heartbeats
+const DefaultInterval = 15 * time.Second
+
+// ConfigKey is the key of the progress configuration of the agents
+const ConfigKey = "progress"
+
+// Config configures where an agent publishes its heartbeats. The webhook sets the ConfigMap, pod and container through
+// the environment of the containers it injects, e.g. OME_AGENT_PROGRESS_POD_NAME for pod_name.
+type Config struct {
+	// Dir is the directory the progress file of the agent is written to, e.g. when it runs outside of the cluster,
+	// no file is written if empty
+	Dir string `mapstructure:"dir"`
+	// Interval is the interval between two heartbeats
+	Interval time.Duration `mapstructure:"interval"`
+	// ConfigMap is the ConfigMap the heartbeats are published to, in the namespace of the pod, none is published to if
+	// empty. The controller creates it and only allows the pods of the component to patch it.
+	ConfigMap string `mapstructure:"config_map"`
+	// PodName and PodNamespace are the pod of the agent, which keys its heartbeats in the ConfigMap
+	PodName      string `mapstructure:"pod_name"`
+	PodNamespace string `mapstructure:"pod_namespace"`
+	// ContainerName names the progress file and keys the heartbeats in the ConfigMap, the name of the agent is used if
+	// empty
+	ContainerName string `mapstructure:"container_name"`
+}
+
+// NewConfig reads the progress configuration from Viper. The keys are read one by one as the environment only
+// overrides the keys Viper is asked for.
+func NewConfig(v *viper.Viper) Config {
+	config := Config{
+		Dir:           v.GetString(ConfigKey + ".dir"),
+		Interval:      v.GetDuration(ConfigKey + ".interval"),
+		ConfigMap:     v.GetString(ConfigKey + ".config_map"),
+		PodName:       v.GetString(ConfigKey + ".pod_name"),
+		PodNamespace:  v.GetString(ConfigKey + ".pod_namespace"),
+		ContainerName: v.GetString(ConfigKey + ".container_name"),
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	return config
+}
+
+// FileName returns the name of the progress file of a container in the progress volume
+func FileName(containerName string) string {
+	return containerName + ".json"
+}
+
+// DataKey returns the key of the heartbeat of a container of a pod in the progress ConfigMap
+func DataKey(podName, containerName string) string {
+	return podName + "." + containerName
+}
+
+// Publisher publishes the heartbeats of an agent
+type Publisher interface {
+	Publish(ctx context.Context, heartbeat Heartbeat) error
+}
+
+// FilePublisher writes the heartbeats to a file, atomically so that readers never see a partial heartbeat
+type FilePublisher struct {
+	Path string
+}
+
+// Publish writes the heartbeat to the file
+func (p *FilePublisher) Publish(_ context.Context, heartbeat Heartbeat) error {
+	data, err := json.Marshal(heartbeat)
+	if err != nil {
+		return fmt.Errorf("failed to marshal heartbeat: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create progress directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p.Path), "."+filepath.Base(p.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create progress file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p.Path); err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+	return nil
+}
+
+// ConfigMapPublisher publishes the heartbeats to a key of a ConfigMap, where the controllers read them from. The
+// service account of the pod must be allowed to patch the ConfigMap.
+type ConfigMapPublisher struct {
+	Client    kubernetes.Interface
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// Publish patches the key of the ConfigMap with the heartbeat
+func (p *ConfigMapPublisher) Publish(ctx context.Context, heartbeat Heartbeat) error {
+	data, err := json.Marshal(heartbeat)
+	if err != nil {
+		return fmt.Errorf("failed to marshal heartbeat: %w", err)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{p.Key: string(data)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal ConfigMap patch: %w", err)
+	}
+	if _, err := p.Client.CoreV1().ConfigMaps(p.Namespace).Patch(ctx, p.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to publish progress to ConfigMap %s/%s: %w", p.Namespace, p.Name, err)
+	}
+	return nil
+}
+
+// Reporter keeps the progress of an agent and publishes it as a heartbeat on every interval and whenever its phase
+// changes. A nil Reporter discards the progress, so agents can report progress whether or not it is published.
+type Reporter struct {
+	mu        sync.Mutex
+	heartbeat Heartbeat
+	// publishMu orders the heartbeats, a stale heartbeat is never published after a newer one
+	publishMu  sync.Mutex
+	interval   time.Duration
+	publishers []Publisher
+	logger     logging.Interface
+	now        func() time.Time
+}
+
+// NewReporter creates a reporter publishing the heartbeats of an agent to publishers
+func NewReporter(agent string, interval time.Duration, logger logging.Interface, publishers ...Publisher) *Reporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if logger == nil {
+		logger = logging.Discard()
+	}
+	r := &Reporter{
+		interval:   interval,
+		publishers: publishers,
+		logger:     logger,
+		now:        time.Now,
+	}
+	now := r.now()
+	r.heartbeat = Heartbeat{
+		Version:   Version,
+		Agent:     agent,
+		Phase:     PhaseStarting,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	return r
+}
+
+// New creates the reporter of an agent from its progress configuration. The ConfigMap is only published to from
+// within the cluster, the progress file is published regardless.
+func New(agent string, config Config, logger logging.Interface) *Reporter {
+	if logger == nil {
+		logger = logging.Discard()
+	}
+	name := config.ContainerName
+	if name == "" {
+		name = agent
+	}
+
+	var publishers []Publisher
+	if config.Dir != "" {
+		publishers = append(publishers, &FilePublisher{Path: filepath.Join(config.Dir, FileName(name))})
+	}
+	if config.ConfigMap != "" && config.PodName != "" && config.PodNamespace != "" {
+		client, err := inClusterClient()
+		if err != nil {
+			logger.Warnf("Not publishing progress to ConfigMap %s/%s: %v", config.PodNamespace, config.ConfigMap, err)
+		} else {
+			publishers = append(publishers, &ConfigMapPublisher{
+				Client:    client,
+				Namespace: config.PodNamespace,
+				Name:      config.ConfigMap,
+				Key:       DataKey(config.PodName, name),
+			})
+		}
+	}
+	return NewReporter(agent, config.Interval, logger, publishers...)
+}
+
+// SetPhase starts a phase, resetting the progress of the previous one, and publishes it
+func (r *Reporter) SetPhase(ctx context.Context, phase Phase, message string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.heartbeat.Phase = phase
+	r.heartbeat.Message = message
+	r.heartbeat.Completed, r.heartbeat.Total, r.heartbeat.Unit = 0, 0, ""
+	r.mu.Unlock()
+	r.Publish(ctx)
+}
+
+// SetTotal sets the units of work of the phase, e.g. the number of files to download
+func (r *Reporter) SetTotal(total int64, unit string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.heartbeat.Total, r.heartbeat.Unit = total, unit
+}
+
+// Add adds completed units of work to the phase
+func (r *Reporter) Add(completed int64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.heartbeat.Completed += completed
+}
+
+// SetMessage sets the message of the phase, e.g. the file being processed
+func (r *Reporter) SetMessage(message string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.heartbeat.Message = message
+}
+
+// Heartbeat returns the current heartbeat
+func (r *Reporter) Heartbeat() Heartbeat {
+	if r == nil {
+		return Heartbeat{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.heartbeat.UpdatedAt = r.now()
+	r.heartbeat.Percent = percent(r.heartbeat.Completed, r.heartbeat.Total)
+	return r.heartbeat
+}
+
+// Publish publishes the current heartbeat. Failing to publish it is logged but doesn't fail the agent.
+func (r *Reporter) Publish(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+	heartbeat := r.Heartbeat()
+	for _, publisher := range r.publishers {
+		if err := publisher.Publish(ctx, heartbeat); err != nil {
+			r.logger.Warnf("Failed to publish progress: %v", err)
+		}
+	}
+}
+
+// Run publishes a heartbeat on every interval until the context is done
+func (r *Reporter) Run(ctx context.Context) {
+	if r == nil || len(r.publishers) == 0 {
+		return
+	}
+	r.Publish(ctx)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Publish(ctx)
+		}
+	}
+}
+
+// Finish publishes the last heartbeat of the agent, succeeded if err is nil and failed otherwise
+func (r *Reporter) Finish(ctx context.Context, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	if err != nil {
+		r.heartbeat.Phase = PhaseFailed
+		r.heartbeat.Error = err.Error()
+	} else {
+		// The progress of the last phase is kept, completed
+		r.heartbeat.Phase = PhaseSucceeded
+		if r.heartbeat.Total > 0 {
+			r.heartbeat.Completed = r.heartbeat.Total
+		}
+	}
+	r.mu.Unlock()
+	r.Publish(ctx)
+}
+
+// inClusterClient creates a Kubernetes client with the service account of the pod
+func inClusterClient() (kubernetes.Interface, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
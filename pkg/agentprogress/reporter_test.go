@@ -0,0 +1,111 @@
+package agentprogress
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReporterPublishesHeartbeats(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "progress", FileName("model-init"))
+	reporter := NewReporter("enigma", time.Second, nil, &FilePublisher{Path: path})
+
+	reporter.Publish(ctx)
+	heartbeat, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, Version, heartbeat.Version)
+	assert.Equal(t, "enigma", heartbeat.Agent)
+	assert.Equal(t, PhaseStarting, heartbeat.Phase)
+	assert.Nil(t, heartbeat.Percent)
+	assert.False(t, heartbeat.Done())
+
+	reporter.SetPhase(ctx, PhaseDecrypting, "")
+	reporter.SetTotal(8, "files")
+	reporter.Add(3)
+	reporter.SetMessage("model-00001-of-00008.safetensors")
+	reporter.Publish(ctx)
+	heartbeat, err = ReadFile(path)
+	require.NoError(t, err)
+	require.NotNil(t, heartbeat.Percent)
+	assert.Equal(t, 37, *heartbeat.Percent)
+	assert.Equal(t, "Decrypting 37% (3/8 files): model-00001-of-00008.safetensors", heartbeat.Summary())
+
+	reporter.Finish(ctx, nil)
+	heartbeat, err = ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, heartbeat.Done())
+	assert.Equal(t, "Succeeded 100% (8/8 files): model-00001-of-00008.safetensors", heartbeat.Summary())
+
+	reporter.Finish(ctx, errors.New("key not found"))
+	heartbeat, err = ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, PhaseFailed, heartbeat.Phase)
+	assert.Equal(t, "Failed 100% (8/8 files): key not found", heartbeat.Summary())
+}
+
+func TestNilReporter(t *testing.T) {
+	var reporter *Reporter
+	reporter.SetPhase(context.Background(), PhaseDownloading, "")
+	reporter.SetTotal(1, "files")
+	reporter.Add(1)
+	reporter.Finish(context.Background(), nil)
+	assert.Equal(t, Heartbeat{}, reporter.Heartbeat())
+}
+
+func TestConfigMapPublisher(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-engine-progress", Namespace: "models"},
+		Data:       map[string]string{DataKey("llama-engine-1", "model-init"): "{}"},
+	})
+	reporter := NewReporter("hf-download", time.Second, nil, &ConfigMapPublisher{
+		Client:    client,
+		Namespace: "models",
+		Name:      "llama-engine-progress",
+		Key:       DataKey("llama-engine-0", "model-init"),
+	})
+	reporter.SetPhase(ctx, PhaseDownloading, "meta-llama/Llama-3.2-1B")
+
+	configMap, err := client.CoreV1().ConfigMaps("models").Get(ctx, "llama-engine-progress", metav1.GetOptions{})
+	require.NoError(t, err)
+	heartbeat, err := Parse([]byte(configMap.Data["llama-engine-0.model-init"]))
+	require.NoError(t, err)
+	assert.Equal(t, "Downloading: meta-llama/Llama-3.2-1B", heartbeat.Summary())
+	// The heartbeats of the other pods are kept
+	assert.Equal(t, "{}", configMap.Data["llama-engine-1.model-init"])
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte(`{"version":"v2","agent":"enigma","phase":"Starting"}`))
+	assert.ErrorContains(t, err, `unsupported heartbeat version "v2"`)
+	_, err = Parse([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestNewConfig(t *testing.T) {
+	t.Setenv("OME_AGENT_PROGRESS_DIR", "/var/run/ome/progress")
+	t.Setenv("OME_AGENT_PROGRESS_CONFIG_MAP", "llama-engine-progress")
+	v := viper.New()
+	v.SetEnvPrefix("OME_AGENT")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	v.Set("progress.container_name", "model-init")
+
+	assert.Equal(t, Config{
+		Dir:           "/var/run/ome/progress",
+		Interval:      DefaultInterval,
+		ConfigMap:     "llama-engine-progress",
+		ContainerName: "model-init",
+	}, NewConfig(v))
+}
@@ -1,6 +1,8 @@
 package v1beta1
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,6 +59,18 @@ type AcceleratorClassSpec struct {
 	// +listType=atomic
 	Resources []AcceleratorResource `json:"resources,omitempty"`
 
+	// MIGProfiles describes the Multi-Instance GPU (MIG) profiles this accelerator can be partitioned into.
+	// Each profile can be referenced as a sub-class named "<class>/<profile>" (e.g., nvidia-a100-80gb/1g.10gb)
+	// wherever an AcceleratorClass name is accepted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	MIGProfiles []MIGProfile `json:"migProfiles,omitempty"`
+
+	// Scheduling hints injected into the pods of InferenceService components that use this accelerator class
+	// +optional
+	Scheduling *AcceleratorScheduling `json:"scheduling,omitempty"`
+
 	// Integration with external systems
 	// +optional
 	Integration *AcceleratorIntegration `json:"integration,omitempty"`
@@ -178,6 +192,37 @@ type AcceleratorResource struct {
 	Divisible bool `json:"divisible,omitempty"`
 }
 
+// MIGProfile describes a Multi-Instance GPU profile, a fractional GPU with dedicated memory and compute slices
+type MIGProfile struct {
+	// Name of the profile (e.g., 1g.10gb, 3g.40gb)
+	Name string `json:"name"`
+
+	// ResourceName is the extended resource exposed for this profile (e.g., nvidia.com/mig-1g.10gb)
+	ResourceName string `json:"resourceName"`
+
+	// Memory capacity of a single instance in GB
+	// +optional
+	MemoryGB *resource.Quantity `json:"memoryGB,omitempty"`
+
+	// ComputeSlices is the number of compute slices of a single instance, out of the seven slices of a GPU
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=7
+	ComputeSlices int32 `json:"computeSlices"`
+}
+
+// AcceleratorScheduling describes how pods are scheduled onto nodes with this accelerator.
+// Node affinity is taken from Discovery.Affinity.
+type AcceleratorScheduling struct {
+	// Tolerations added to pods, typically for taints on accelerator nodes (e.g., nvidia.com/gpu:NoSchedule)
+	// +optional
+	// +listType=atomic
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// RuntimeClassName set on pods that don't specify one (e.g., nvidia)
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+}
+
 type AcceleratorIntegration struct {
 	// KueueResourceFlavor name to sync with
 	// +optional
@@ -202,6 +247,14 @@ type AcceleratorClassStatus struct {
 	// +optional
 	AvailableAccelerators int32 `json:"availableAccelerators,omitempty"`
 
+	// Accelerators that can be allocated to pods, excluding those reserved for system daemons
+	// +optional
+	AllocatableAccelerators int32 `json:"allocatableAccelerators,omitempty"`
+
+	// Accelerators requested by running and pending pods
+	// +optional
+	UsedAccelerators int32 `json:"usedAccelerators,omitempty"`
+
 	// Last update time
 	// +optional
 	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
@@ -214,6 +267,59 @@ type AcceleratorClassStatus struct {
 	// AvailableNodes is the number of nodes that have this accelerator available
 	// +optional
 	AvailableNodes int32 `json:"availableNodes,omitempty"`
+
+	// MIGProfiles reports the capacity of each MIG profile of the accelerator, in instances of the profile
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	MIGProfiles []MIGProfileStatus `json:"migProfiles,omitempty"`
+}
+
+// MIGProfileStatus reports the capacity of a MIG profile, in instances of the profile
+type MIGProfileStatus struct {
+	// Name of the profile
+	Name string `json:"name"`
+
+	// Total number of instances of the profile in the cluster
+	// +optional
+	TotalInstances int32 `json:"totalInstances,omitempty"`
+
+	// Instances that can be allocated to pods
+	// +optional
+	AllocatableInstances int32 `json:"allocatableInstances,omitempty"`
+
+	// Instances requested by running and pending pods
+	// +optional
+	UsedInstances int32 `json:"usedInstances,omitempty"`
+
+	// Instances that are not allocated
+	// +optional
+	AvailableInstances int32 `json:"availableInstances,omitempty"`
+}
+
+const (
+	// MIGProfileSeparator separates the AcceleratorClass name from the MIG profile name in a sub-class reference
+	MIGProfileSeparator = "/"
+
+	// MaxMIGComputeSlices is the number of compute slices a MIG-capable GPU can be partitioned into
+	MaxMIGComputeSlices = 7
+)
+
+// ParseAcceleratorClassName splits an AcceleratorClass reference into the class name and,
+// for MIG sub-class references, the MIG profile name.
+func ParseAcceleratorClassName(name string) (className string, profile string) {
+	className, profile, _ = strings.Cut(name, MIGProfileSeparator)
+	return className, profile
+}
+
+// GetMIGProfile returns the MIG profile with the given name, or nil if the accelerator has no such profile.
+func (s *AcceleratorClassSpec) GetMIGProfile(name string) *MIGProfile {
+	for i := range s.MIGProfiles {
+		if s.MIGProfiles[i].Name == name {
+			return &s.MIGProfiles[i]
+		}
+	}
+	return nil
 }
 
 func init() {
@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,6 +36,11 @@ type BenchmarkJobSpec struct {
 	// +required
 	Endpoint EndpointSpec `json:"endpoint"`
 
+	// Comparison benchmarks a candidate endpoint with the same traffic as Endpoint, which acts as the baseline,
+	// and reports the relative change of the candidate in status (A/B mode).
+	// +optional
+	Comparison *ComparisonSpec `json:"comparison,omitempty"`
+
 	// ServiceMetadata records metadata about the backend model server or service being benchmarked.
 	// This includes details such as server engine, version, and GPU configuration for filtering experiments.
 	// +optional
@@ -57,6 +63,12 @@ type BenchmarkJobSpec struct {
 	// +optional
 	NumConcurrency []int `json:"numConcurrency,omitempty"`
 
+	// TrafficSchedule describes phases of request rate or concurrency over time, e.g. to test autoscaler
+	// behavior and burst handling. When set, the schedule replaces the NumConcurrency sweep and every
+	// traffic scenario is run through all phases of the schedule.
+	// +optional
+	TrafficSchedule *TrafficScheduleSpec `json:"trafficSchedule,omitempty"`
+
 	// MaxTimePerIteration specifies the maximum time (in minutes) for a single iteration.
 	// Each iteration runs for a specific combination of TrafficScenarios and NumConcurrency.
 	// +required
@@ -67,12 +79,42 @@ type BenchmarkJobSpec struct {
 	// +required
 	MaxRequestsPerIteration *int `json:"maxRequestsPerIteration"`
 
+	// WarmupDuration specifies the maximum time (in seconds) of the warmup phase that runs before the benchmark.
+	// Warmup requests prime compile and prefix caches and are excluded from the recorded results.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WarmupDuration *int `json:"warmupDuration,omitempty"`
+
+	// WarmupRequests specifies the maximum number of requests sent during the warmup phase.
+	// The warmup phase ends when either WarmupDuration or WarmupRequests is reached.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WarmupRequests *int `json:"warmupRequests,omitempty"`
+
 	// AdditionalRequestParams contains additional request parameters as a map.
 	// +optional
 	AdditionalRequestParams map[string]string `json:"additionalRequestParams,omitempty"`
 
+	// Streaming controls whether requests are sent as streaming requests, for tasks that generate text.
+	// With streaming, the benchmark client counts output tokens and measures the time to first token and
+	// the inter-token latency from the stream events, for both OpenAI-compatible and engine-native protocols.
+	// Without streaming, token counts are taken from the usage reported in the response. If not set, the
+	// default of the benchmark client applies, which is to stream.
+	// +optional
+	Streaming *bool `json:"streaming,omitempty"`
+
+	// Workload describes the shape of the requests sent to the endpoint.
+	// If not provided, every request is an independent single prompt.
+	// +optional
+	Workload *WorkloadSpec `json:"workload,omitempty"`
+
 	// Dataset is the dataset used for benchmarking.
-	// It is optional and only required for tasks other than "text-to-<output-modality>".
+	// It is optional and only required for tasks other than "text-to-<output-modality>",
+	// or for "Replay" workloads, where it holds the recorded request trace.
+	// The dataset can be pulled from any supported storage URI, e.g. hf://{dataset-id}[@{revision}],
+	// pvc://{pvc-name}/{sub-path}, s3://{bucket}/{prefix}, oci://n/{namespace}/b/{bucket}/o/{prefix},
+	// az://{account}/{container}/{path} or gs://{bucket}/{object}. Credentials are read from the
+	// Secret named by the storage key, which must reside in the same namespace as the BenchmarkJob.
 	// +optional
 	Dataset *StorageSpec `json:"dataset,omitempty"`
 
@@ -80,10 +122,24 @@ type BenchmarkJobSpec struct {
 	// +required
 	OutputLocation *StorageSpec `json:"outputLocation"`
 
-	// ResultFolderName specifies the name of the folder that stores the benchmark result. A default name will be assigned if not specified.
+	// ResultFolderName specifies the name of the folder that stores the benchmark result.
+	// Defaults to the name of the BenchmarkJob if not specified.
 	// +optional
 	ResultFolderName *string `json:"resultFolderName,omitempty"`
 
+	// SaveRequestRecords exports the raw record of every request next to the summary of the results.
+	// Defaults to false.
+	// +optional
+	SaveRequestRecords *bool `json:"saveRequestRecords,omitempty"`
+
+	// ReportMetrics reports the latency metrics of every iteration in the status of the BenchmarkJob.
+	// The report is passed through the termination message of the benchmark container, which is limited
+	// to 4096 bytes, so histograms and then the last iterations may be left out of the status; the summary
+	// of the results always holds all of them. Comparative benchmarks always report their metrics.
+	// Defaults to false.
+	// +optional
+	ReportMetrics *bool `json:"reportMetrics,omitempty"`
+
 	// Pod defines the pod configuration for the benchmark job. This is optional, if not provided, default values will be used.
 	// +optional
 	PodOverride *PodOverride `json:"podOverride,omitempty"`
@@ -142,6 +198,156 @@ type PodOverride struct {
 	Volumes []corev1.Volume `json:"volumes,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name" protobuf:"bytes,1,rep,name=volumes"`
 }
 
+// WorkloadType is the type of traffic generated by a BenchmarkJob.
+// +kubebuilder:validation:Enum=SingleTurn;Conversation;Replay
+type WorkloadType string
+
+const (
+	// SingleTurnWorkload sends independent single prompts.
+	SingleTurnWorkload WorkloadType = "SingleTurn"
+	// ConversationWorkload sends multi-turn chat sessions where each turn carries the conversation history.
+	ConversationWorkload WorkloadType = "Conversation"
+	// ReplayWorkload replays a recorded request trace, preserving its arrival pattern.
+	ReplayWorkload WorkloadType = "Replay"
+)
+
+// HistoryMode controls how the conversation history is carried across turns.
+// +kubebuilder:validation:Enum=Full;SlidingWindow
+type HistoryMode string
+
+const (
+	// FullHistory resends every previous turn with each request.
+	FullHistory HistoryMode = "Full"
+	// SlidingWindowHistory resends only the most recent HistoryWindow turns with each request.
+	SlidingWindowHistory HistoryMode = "SlidingWindow"
+)
+
+// WorkloadSpec defines the shape of the traffic generated by a BenchmarkJob.
+type WorkloadSpec struct {
+	// Type specifies the workload type. Defaults to "SingleTurn".
+	// +kubebuilder:default=SingleTurn
+	// +optional
+	Type WorkloadType `json:"type,omitempty"`
+
+	// Conversation configures multi-turn chat sessions.
+	// It is required when Type is "Conversation" and only supported for the "text-to-text" task.
+	// +optional
+	Conversation *ConversationSpec `json:"conversation,omitempty"`
+
+	// Replay configures the replay of a recorded request trace read from the dataset.
+	// It is only used when Type is "Replay" and only supported for the "text-to-text" task.
+	// +optional
+	Replay *ReplaySpec `json:"replay,omitempty"`
+}
+
+// ReplaySpec configures the replay of a recorded request trace. The trace is read from the
+// dataset and records, for every request, its arrival timestamp, prompt and output lengths, and
+// whether it was streamed. Requests are sent at the recorded arrival times, which is far more
+// representative of production traffic than synthetic arrival processes.
+type ReplaySpec struct {
+	// TimeScale scales the recorded inter-arrival times, e.g. 0.5 replays the trace twice as fast.
+	// Defaults to 1.
+	// +optional
+	TimeScale *resource.Quantity `json:"timeScale,omitempty"`
+
+	// MaxDuration is the maximum duration of the trace to replay, in seconds, starting from the first request.
+	// If not set, the whole trace is replayed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxDuration *int `json:"maxDuration,omitempty"`
+}
+
+// ConversationSpec configures multi-turn chat sessions so benchmarks reflect realistic chat
+// serving behavior, including prompt growth and KV-cache reuse across turns.
+type ConversationSpec struct {
+	// NumTurns is the number of user turns in each conversation.
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	NumTurns int `json:"numTurns"`
+
+	// HistoryMode controls how previous turns are resent with each request. Defaults to "Full".
+	// +kubebuilder:default=Full
+	// +optional
+	HistoryMode HistoryMode `json:"historyMode,omitempty"`
+
+	// HistoryWindow is the number of previous turns kept in the context.
+	// It is required when HistoryMode is "SlidingWindow".
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HistoryWindow *int `json:"historyWindow,omitempty"`
+
+	// SystemPrompt is prepended to every conversation.
+	// +optional
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+}
+
+// TrafficPhaseShape is the shape of the load within a traffic phase.
+// +kubebuilder:validation:Enum=Constant;Ramp
+type TrafficPhaseShape string
+
+const (
+	// ConstantPhase holds the phase target for the whole phase.
+	ConstantPhase TrafficPhaseShape = "Constant"
+	// RampPhase linearly ramps from the target of the previous phase, or from zero for the
+	// first phase, to the phase target over the phase duration.
+	RampPhase TrafficPhaseShape = "Ramp"
+)
+
+// TrafficScheduleSpec defines a load shape as an ordered list of phases.
+// Steps are expressed as consecutive constant phases, and spikes as a short constant phase
+// with a higher target surrounded by phases with a lower target.
+type TrafficScheduleSpec struct {
+	// Phases are run in order.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +required
+	Phases []TrafficPhase `json:"phases"`
+}
+
+// TrafficPhase defines the load of a single phase of a traffic schedule.
+// Exactly one of RequestRate or Concurrency must be set, and all phases of a schedule must use the same one.
+type TrafficPhase struct {
+	// Name is an optional name of the phase used in the results.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Duration is the duration of the phase, in seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	Duration int `json:"duration"`
+
+	// Shape is the shape of the load within the phase. Defaults to "Constant".
+	// +kubebuilder:default=Constant
+	// +optional
+	Shape TrafficPhaseShape `json:"shape,omitempty"`
+
+	// RequestRate is the target number of requests per second of the phase.
+	// +optional
+	RequestRate *resource.Quantity `json:"requestRate,omitempty"`
+
+	// Concurrency is the target number of concurrent requests of the phase.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Concurrency *int `json:"concurrency,omitempty"`
+}
+
+// ComparisonSpec defines the candidate endpoint of a comparative (A/B) benchmark.
+type ComparisonSpec struct {
+	// Candidate is the endpoint compared against the baseline endpoint, e.g. a canary InferenceService.
+	// When the candidate is an InferenceService, its base model must be ready on the node running the benchmark.
+	// +required
+	Candidate EndpointSpec `json:"candidate"`
+
+	// BaselineCostPerHour is the hourly cost of serving the baseline endpoint.
+	// Together with CandidateCostPerHour it is used to compare the cost per token.
+	// +optional
+	BaselineCostPerHour *resource.Quantity `json:"baselineCostPerHour,omitempty"`
+
+	// CandidateCostPerHour is the hourly cost of serving the candidate endpoint.
+	// +optional
+	CandidateCostPerHour *resource.Quantity `json:"candidateCostPerHour,omitempty"`
+}
+
 // HuggingFaceSecretReference defines a reference to a Kubernetes Secret containing the Hugging Face API key.
 // This secret must reside in the same namespace as the BenchmarkJob.
 // Cross-namespace references are not allowed for security and simplicity.
@@ -240,6 +446,157 @@ type BenchmarkJobStatus struct {
 	// Details provide additional information or metadata about the benchmark job.
 	// +optional
 	Details string `json:"details,omitempty"`
+
+	// Results points to the benchmark results exported to the output location.
+	// It is set once the benchmark job has completed successfully.
+	// +optional
+	Results *BenchmarkResults `json:"results,omitempty"`
+
+	// Metrics holds the latency breakdown of every iteration, one entry per combination of
+	// traffic scenario and concurrency level. It is set once the benchmark job has completed successfully.
+	// +listType=atomic
+	// +optional
+	Metrics []IterationMetrics `json:"metrics,omitempty"`
+
+	// Comparison reports how the candidate endpoint performed relative to the baseline endpoint.
+	// It is only set for comparative benchmarks once the benchmark job has completed successfully.
+	// +optional
+	Comparison *ComparisonReport `json:"comparison,omitempty"`
+}
+
+// ComparisonReport is the delta report of a comparative (A/B) benchmark.
+type ComparisonReport struct {
+	// CandidateMetrics holds the metrics of every iteration measured against the candidate endpoint.
+	// +listType=atomic
+	// +optional
+	CandidateMetrics []IterationMetrics `json:"candidateMetrics,omitempty"`
+
+	// Iterations holds the relative change of the candidate against the baseline for every iteration.
+	// +listType=atomic
+	// +optional
+	Iterations []IterationComparison `json:"iterations,omitempty"`
+}
+
+// IterationComparison contains the relative change, in percent, of the candidate endpoint against the
+// baseline endpoint for a single iteration. Positive values mean the candidate measured higher.
+type IterationComparison struct {
+	// Scenario is the traffic scenario of the iteration.
+	// +required
+	Scenario string `json:"scenario"`
+
+	// Concurrency is the number of concurrent requests of the iteration.
+	// +required
+	Concurrency int `json:"concurrency"`
+
+	// OutputThroughputChange is the relative change of the output token throughput.
+	// +optional
+	OutputThroughputChange *resource.Quantity `json:"outputThroughputChange,omitempty"`
+
+	// TimeToFirstTokenChange is the relative change of the median time to first token.
+	// +optional
+	TimeToFirstTokenChange *resource.Quantity `json:"timeToFirstTokenChange,omitempty"`
+
+	// InterTokenLatencyChange is the relative change of the median inter-token latency.
+	// +optional
+	InterTokenLatencyChange *resource.Quantity `json:"interTokenLatencyChange,omitempty"`
+
+	// EndToEndLatencyChange is the relative change of the median end-to-end latency.
+	// +optional
+	EndToEndLatencyChange *resource.Quantity `json:"endToEndLatencyChange,omitempty"`
+
+	// CostPerTokenChange is the relative change of the cost per output token.
+	// It is only set when the hourly cost of both endpoints is known.
+	// +optional
+	CostPerTokenChange *resource.Quantity `json:"costPerTokenChange,omitempty"`
+}
+
+// IterationMetrics contains the latency metrics measured for a single benchmark iteration.
+type IterationMetrics struct {
+	// Scenario is the traffic scenario of the iteration.
+	// +required
+	Scenario string `json:"scenario"`
+
+	// Concurrency is the number of concurrent requests of the iteration.
+	// +required
+	Concurrency int `json:"concurrency"`
+
+	// NumRequests is the number of requests completed during the iteration.
+	// +optional
+	NumRequests int64 `json:"numRequests,omitempty"`
+
+	// OutputThroughput is the number of output tokens generated per second.
+	// +optional
+	OutputThroughput *resource.Quantity `json:"outputThroughput,omitempty"`
+
+	// TimeToFirstToken is the distribution of the time to first token (TTFT).
+	// +optional
+	TimeToFirstToken *LatencyDistribution `json:"timeToFirstToken,omitempty"`
+
+	// InterTokenLatency is the distribution of the latency between consecutive output tokens.
+	// +optional
+	InterTokenLatency *LatencyDistribution `json:"interTokenLatency,omitempty"`
+
+	// EndToEndLatency is the distribution of the end-to-end request latency.
+	// +optional
+	EndToEndLatency *LatencyDistribution `json:"endToEndLatency,omitempty"`
+}
+
+// LatencyDistribution summarizes a latency distribution with its mean, percentiles and histogram.
+type LatencyDistribution struct {
+	// Mean is the mean latency.
+	// +optional
+	Mean *metav1.Duration `json:"mean,omitempty"`
+
+	// P50 is the median latency.
+	// +optional
+	P50 *metav1.Duration `json:"p50,omitempty"`
+
+	// P90 is the 90th percentile latency.
+	// +optional
+	P90 *metav1.Duration `json:"p90,omitempty"`
+
+	// P95 is the 95th percentile latency.
+	// +optional
+	P95 *metav1.Duration `json:"p95,omitempty"`
+
+	// P99 is the 99th percentile latency.
+	// +optional
+	P99 *metav1.Duration `json:"p99,omitempty"`
+
+	// Max is the maximum latency.
+	// +optional
+	Max *metav1.Duration `json:"max,omitempty"`
+
+	// Histogram is the number of requests in each latency bucket, ordered by upper bound.
+	// +listType=atomic
+	// +optional
+	Histogram []HistogramBucket `json:"histogram,omitempty"`
+}
+
+// HistogramBucket is a single bucket of a latency histogram.
+type HistogramBucket struct {
+	// UpperBound is the inclusive upper bound of the bucket.
+	// +required
+	UpperBound metav1.Duration `json:"upperBound"`
+
+	// Count is the number of requests whose latency falls into the bucket.
+	// +required
+	Count int64 `json:"count"`
+}
+
+// BenchmarkResults describes where the results of a completed benchmark job were exported.
+type BenchmarkResults struct {
+	// Location is the storage URI of the folder that holds the benchmark results.
+	// +required
+	Location string `json:"location"`
+
+	// Summary is the storage URI of the summary JSON file with the aggregated metrics of every iteration.
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// Records is the storage URI of the raw per-request records, set when they are saved.
+	// +optional
+	Records string `json:"records,omitempty"`
 }
 
 // BenchmarkJobList contains a list of BenchmarkJob
@@ -41,6 +41,12 @@ type InferenceServiceSpec struct {
 	// +optional
 	Runtime *ServingRuntimeRef `json:"runtime,omitempty"`
 
+	// RuntimeVersionConstraints restricts the engine versions of the runtimes that can be selected or
+	// referenced for this InferenceService, in addition to the constraints of the model
+	// +listType=atomic
+	// +optional
+	RuntimeVersionConstraints []RuntimeVersionConstraint `json:"runtimeVersionConstraints,omitempty"`
+
 	// Router defines the router spec
 	// +optional
 	Router *RouterSpec `json:"router,omitempty"`
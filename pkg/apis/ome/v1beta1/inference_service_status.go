@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -29,6 +30,20 @@ type InferenceServiceStatus struct {
 	Components map[ComponentType]ComponentStatusSpec `json:"components,omitempty"`
 	// Model related statuses
 	ModelStatus ModelStatus `json:"modelStatus,omitempty"`
+	// EstimatedHourlyCost is the sum of the estimated hourly costs of the accelerators selected for the components
+	// +optional
+	EstimatedHourlyCost *resource.Quantity `json:"estimatedHourlyCost,omitempty"`
+	// SelectedRuntime shows which runtime serves the model
+	// +optional
+	SelectedRuntime *RuntimeSelection `json:"selectedRuntime,omitempty"`
+}
+
+// RuntimeSelection shows what runtime was selected
+type RuntimeSelection struct {
+	// Name of the runtime
+	Name string `json:"name"`
+	// Kind of the runtime, ServingRuntime or ClusterServingRuntime
+	Kind string `json:"kind"`
 }
 
 // ComponentStatusSpec describes the state of the component
@@ -81,6 +96,11 @@ type AcceleratorSelection struct {
 	// ResourceRequests that were applied to pods
 	// +optional
 	ResourceRequests map[string]string `json:"resourceRequests,omitempty"`
+
+	// EstimatedHourlyCost of the accelerators of the component at its minimum replicas,
+	// based on the cost of the AcceleratorClass
+	// +optional
+	EstimatedHourlyCost *resource.Quantity `json:"estimatedHourlyCost,omitempty"`
 }
 
 // ComponentType contains the different types of components of the service
@@ -120,6 +140,9 @@ const (
 	RoutesReady apis.ConditionType = "RoutesReady"
 	// LatestDeploymentReady is set when underlying configurations for all components have reported readiness.
 	LatestDeploymentReady apis.ConditionType = "LatestDeploymentReady"
+	// ModelInitialized reports the progress of the agents of the init containers preparing the model, e.g. its
+	// phase and percent complete, and is set when all of them succeeded.
+	ModelInitialized apis.ConditionType = "ModelInitialized"
 )
 
 // RouterConditionType represents a Router condition value
@@ -333,3 +356,43 @@ func (ss *InferenceServiceStatus) SetCondition(conditionType apis.ConditionType,
 		conditionSet.Manage(ss).MarkFalse(conditionType, condition.Reason, condition.Message)
 	}
 }
+
+// SetSelectedAccelerator records the accelerator selected for a component. A nil selection clears it.
+func (ss *InferenceServiceStatus) SetSelectedAccelerator(component ComponentType, selection *AcceleratorSelection) {
+	if ss.Components == nil {
+		if selection == nil {
+			return
+		}
+		ss.Components = make(map[ComponentType]ComponentStatusSpec)
+	}
+	statusSpec, ok := ss.Components[component]
+	if !ok && selection == nil {
+		return
+	}
+	statusSpec.SelectedAccelerator = selection
+	ss.Components[component] = statusSpec
+}
+
+// SetSelectedRuntime records the runtime selected for the model, and reports whether it differs from the one recorded
+func (ss *InferenceServiceStatus) SetSelectedRuntime(selection RuntimeSelection) bool {
+	if ss.SelectedRuntime != nil && *ss.SelectedRuntime == selection {
+		return false
+	}
+	ss.SelectedRuntime = &selection
+	return true
+}
+
+// UpdateEstimatedHourlyCost sums the estimated hourly costs of the accelerators selected for the components
+func (ss *InferenceServiceStatus) UpdateEstimatedHourlyCost() {
+	var total *resource.Quantity
+	for _, statusSpec := range ss.Components {
+		if statusSpec.SelectedAccelerator == nil || statusSpec.SelectedAccelerator.EstimatedHourlyCost == nil {
+			continue
+		}
+		if total == nil {
+			total = resource.NewQuantity(0, resource.DecimalSI)
+		}
+		total.Add(*statusSpec.SelectedAccelerator.EstimatedHourlyCost)
+	}
+	ss.EstimatedHourlyCost = total
+}
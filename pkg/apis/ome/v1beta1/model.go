@@ -179,7 +179,7 @@ type BaseModelSpec struct {
 	ModelArchitecture *string `json:"modelArchitecture,omitempty"`
 
 	// Quantization defines the quantization scheme applied to the model weights,
-	// such as "fp8", "fbgemm_fp8", or "int4". This influences runtime compatibility and performance.
+	// such as "fp8", "fbgemm_fp8", "int4", "awq" or "gptq". This influences runtime compatibility and performance.
 	// +optional
 	Quantization *ModelQuantization `json:"quantization,omitempty"`
 
@@ -222,6 +222,12 @@ type BaseModelSpec struct {
 	// +optional
 	DiffusionPipeline *DiffusionPipelineSpec `json:"diffusionPipeline,omitempty"`
 
+	// RuntimeVersionConstraints restricts the engine versions of the runtimes that can serve the model,
+	// e.g. to keep a model off an engine release that breaks it
+	// +listType=atomic
+	// +optional
+	RuntimeVersionConstraints []RuntimeVersionConstraint `json:"runtimeVersionConstraints,omitempty"`
+
 	// Additional metadata for the model
 	// +optional
 	AdditionalMetadata map[string]string `json:"additionalMetadata,omitempty"`
@@ -265,6 +271,8 @@ const (
 	ModelQuantizationFP8       ModelQuantization = "fp8"
 	ModelQuantizationFbgemmFP8 ModelQuantization = "fbgemm_fp8"
 	ModelQuantizationINT4      ModelQuantization = "int4"
+	ModelQuantizationAWQ       ModelQuantization = "awq"
+	ModelQuantizationGPTQ      ModelQuantization = "gptq"
 )
 
 // ModelCapability enum
@@ -300,6 +308,16 @@ const (
 	ModelCapabilityUnknown          ModelCapability = ""
 )
 
+// LegacyModelCapabilities maps the legacy model capabilities to the capability replacing them
+var LegacyModelCapabilities = map[ModelCapability]ModelCapability{
+	ModelCapabilityTextGeneration:    ModelCapabilityTextToText,
+	ModelCapabilityTextSummarization: ModelCapabilityTextToText,
+	ModelCapabilityTextEmbeddings:    ModelCapabilityEmbedding,
+	ModelCapabilityTextRerank:        ModelCapabilityRerank,
+	ModelCapabilityChat:              ModelCapabilityTextToText,
+	ModelCapabilityVision:            ModelCapabilityImageTextToText,
+}
+
 // ModelAPICapability enum
 // +kubebuilder:validation:Enum=OPENAI_V1_CHAT_COMPLETIONS;OPENAI_V1_RESPONSES;OPENAI_V1_EMBEDDINGS;OPENAI_V1_IMAGES_GENERATIONS;OPENAI_V1_IMAGES_EDITS;OPENAI_V1_AUDIO_SPEECH;OPENAI_V1_AUDIO_TRANSCRIPTIONS;OPENAI_V1_AUDIO_TRANSLATIONS;OPENAI_V1_REALTIME
 type ModelAPICapability string
@@ -401,6 +419,21 @@ type ModelStatusSpec struct {
 
 	// +listType=atomic
 	NodesFailed []string `json:"nodesFailed,omitempty"`
+
+	// ConfigWarnings lists the inconsistencies the metadata extraction found in the configuration of the model
+	// +listType=atomic
+	// +optional
+	ConfigWarnings []ModelConfigWarning `json:"configWarnings,omitempty"`
+}
+
+// ModelConfigWarning is an inconsistency found in the configuration of a model, such as attention heads that
+// don't divide the hidden size. The model may still load, but runtimes may reject it.
+type ModelConfigWarning struct {
+	// Field is the configuration field the warning is about, e.g. "num_key_value_heads"
+	Field string `json:"field"`
+
+	// Message describes the inconsistency
+	Message string `json:"message"`
 }
 
 // BaseModel is the Schema for the basemodels API
@@ -420,6 +453,7 @@ type ModelStatusSpec struct {
 // +kubebuilder:printcolumn:name="CompartmentID",type="string",JSONPath=".spec.compartmentID"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.state"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
 type BaseModel struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -447,6 +481,7 @@ type BaseModel struct {
 // +kubebuilder:printcolumn:name="CompartmentID",type="string",JSONPath=".spec.compartmentID"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.state"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
 type ClusterBaseModel struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,5 @@
+package v1beta1
+
+func (*BaseModel) Hub() {}
+
+func (*ClusterBaseModel) Hub() {}
@@ -32,7 +32,15 @@ type SupportedModelFormat struct {
 	// +optional
 	ModelArchitecture *string `json:"modelArchitecture,omitempty"`
 
-	// Quantization of the model, e.g., "fp8", "fbgemm_fp8", "int4"
+	// SupportedArchitectures lists the model architectures this format can serve. Entries are architecture
+	// names such as "LlamaForCausalLM", glob patterns such as "Qwen3*ForCausalLM", or architecture families
+	// known to the model config parser such as "family:qwen2" or "family:qwen*".
+	// When set, it takes precedence over ModelArchitecture.
+	// +optional
+	// +listType=atomic
+	SupportedArchitectures []string `json:"supportedArchitectures,omitempty"`
+
+	// Quantization of the model, e.g., "fp8", "fbgemm_fp8", "int4", "awq", "gptq"
 	// +optional
 	Quantization *ModelQuantization `json:"quantization,omitempty"`
 
@@ -226,6 +234,34 @@ type ServingRuntimeSpec struct {
 	// AcceleratorRequirements specifies the accelerator requirements for this runtime
 	// +optional
 	AcceleratorRequirements *AcceleratorRequirements `json:"acceleratorRequirements,omitempty"`
+
+	// EngineVersion is the inference engine and version this runtime runs, e.g. sglang 0.4.6.
+	// It is matched against the runtime version constraints of models and InferenceServices.
+	// +optional
+	EngineVersion *RuntimeEngineVersion `json:"engineVersion,omitempty"`
+}
+
+// RuntimeEngineVersion identifies the inference engine of a runtime and its version
+// +k8s:openapi-gen=true
+type RuntimeEngineVersion struct {
+	// Name of the inference engine, e.g. "sglang" or "vllm"
+	Name string `json:"name"`
+
+	// Version of the inference engine, e.g. "0.4.6"
+	Version string `json:"version"`
+}
+
+// RuntimeVersionConstraint restricts the engine version of a runtime
+// +k8s:openapi-gen=true
+type RuntimeVersionConstraint struct {
+	// Engine is the name of the inference engine the constraint applies to, e.g. "sglang".
+	// Runtimes of other engines are not restricted. If empty, the constraint applies to every runtime.
+	// +optional
+	Engine string `json:"engine,omitempty"`
+
+	// Version is a version range made of space or comma separated comparisons that must all hold,
+	// e.g. ">=0.4.3 <0.5". Supported operators are =, !=, >, >=, < and <=; a bare version means =.
+	Version string `json:"version"`
 }
 
 // AcceleratorRequirements specifies the accelerator requirements for this runtime
@@ -127,6 +127,18 @@ func (in *AcceleratorClassSpec) DeepCopyInto(out *AcceleratorClassSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MIGProfiles != nil {
+		in, out := &in.MIGProfiles, &out.MIGProfiles
+		*out = make([]MIGProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(AcceleratorScheduling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Integration != nil {
 		in, out := &in.Integration, &out.Integration
 		*out = new(AcceleratorIntegration)
@@ -165,6 +177,11 @@ func (in *AcceleratorClassStatus) DeepCopyInto(out *AcceleratorClassStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MIGProfiles != nil {
+		in, out := &in.MIGProfiles, &out.MIGProfiles
+		*out = make([]MIGProfileStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorClassStatus.
@@ -472,6 +489,33 @@ func (in *AcceleratorResource) DeepCopy() *AcceleratorResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorScheduling) DeepCopyInto(out *AcceleratorScheduling) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorScheduling.
+func (in *AcceleratorScheduling) DeepCopy() *AcceleratorScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AcceleratorSelection) DeepCopyInto(out *AcceleratorSelection) {
 	*out = *in
@@ -489,6 +533,11 @@ func (in *AcceleratorSelection) DeepCopyInto(out *AcceleratorSelection) {
 			(*out)[key] = val
 		}
 	}
+	if in.EstimatedHourlyCost != nil {
+		in, out := &in.EstimatedHourlyCost, &out.EstimatedHourlyCost
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorSelection.
@@ -646,6 +695,11 @@ func (in *BaseModelSpec) DeepCopyInto(out *BaseModelSpec) {
 		*out = new(DiffusionPipelineSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuntimeVersionConstraints != nil {
+		in, out := &in.RuntimeVersionConstraints, &out.RuntimeVersionConstraints
+		*out = make([]RuntimeVersionConstraint, len(*in))
+		copy(*out, *in)
+	}
 	if in.AdditionalMetadata != nil {
 		in, out := &in.AdditionalMetadata, &out.AdditionalMetadata
 		*out = make(map[string]string, len(*in))
@@ -733,6 +787,11 @@ func (in *BenchmarkJobSpec) DeepCopyInto(out *BenchmarkJobSpec) {
 		**out = **in
 	}
 	in.Endpoint.DeepCopyInto(&out.Endpoint)
+	if in.Comparison != nil {
+		in, out := &in.Comparison, &out.Comparison
+		*out = new(ComparisonSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServiceMetadata != nil {
 		in, out := &in.ServiceMetadata, &out.ServiceMetadata
 		*out = new(ServiceMetadata)
@@ -748,6 +807,11 @@ func (in *BenchmarkJobSpec) DeepCopyInto(out *BenchmarkJobSpec) {
 		*out = make([]int, len(*in))
 		copy(*out, *in)
 	}
+	if in.TrafficSchedule != nil {
+		in, out := &in.TrafficSchedule, &out.TrafficSchedule
+		*out = new(TrafficScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MaxTimePerIteration != nil {
 		in, out := &in.MaxTimePerIteration, &out.MaxTimePerIteration
 		*out = new(int)
@@ -758,6 +822,16 @@ func (in *BenchmarkJobSpec) DeepCopyInto(out *BenchmarkJobSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.WarmupDuration != nil {
+		in, out := &in.WarmupDuration, &out.WarmupDuration
+		*out = new(int)
+		**out = **in
+	}
+	if in.WarmupRequests != nil {
+		in, out := &in.WarmupRequests, &out.WarmupRequests
+		*out = new(int)
+		**out = **in
+	}
 	if in.AdditionalRequestParams != nil {
 		in, out := &in.AdditionalRequestParams, &out.AdditionalRequestParams
 		*out = make(map[string]string, len(*in))
@@ -765,6 +839,16 @@ func (in *BenchmarkJobSpec) DeepCopyInto(out *BenchmarkJobSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Dataset != nil {
 		in, out := &in.Dataset, &out.Dataset
 		*out = new(StorageSpec)
@@ -780,6 +864,16 @@ func (in *BenchmarkJobSpec) DeepCopyInto(out *BenchmarkJobSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SaveRequestRecords != nil {
+		in, out := &in.SaveRequestRecords, &out.SaveRequestRecords
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReportMetrics != nil {
+		in, out := &in.ReportMetrics, &out.ReportMetrics
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PodOverride != nil {
 		in, out := &in.PodOverride, &out.PodOverride
 		*out = new(PodOverride)
@@ -812,6 +906,23 @@ func (in *BenchmarkJobStatus) DeepCopyInto(out *BenchmarkJobStatus) {
 		in, out := &in.LastReconcileTime, &out.LastReconcileTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(BenchmarkResults)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]IterationMetrics, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Comparison != nil {
+		in, out := &in.Comparison, &out.Comparison
+		*out = new(ComparisonReport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkJobStatus.
@@ -824,6 +935,21 @@ func (in *BenchmarkJobStatus) DeepCopy() *BenchmarkJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkResults) DeepCopyInto(out *BenchmarkResults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkResults.
+func (in *BenchmarkResults) DeepCopy() *BenchmarkResults {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkResults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBaseModel) DeepCopyInto(out *ClusterBaseModel) {
 	*out = *in
@@ -942,6 +1068,61 @@ func (in *ClusterServingRuntimeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComparisonReport) DeepCopyInto(out *ComparisonReport) {
+	*out = *in
+	if in.CandidateMetrics != nil {
+		in, out := &in.CandidateMetrics, &out.CandidateMetrics
+		*out = make([]IterationMetrics, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Iterations != nil {
+		in, out := &in.Iterations, &out.Iterations
+		*out = make([]IterationComparison, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComparisonReport.
+func (in *ComparisonReport) DeepCopy() *ComparisonReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ComparisonReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComparisonSpec) DeepCopyInto(out *ComparisonSpec) {
+	*out = *in
+	in.Candidate.DeepCopyInto(&out.Candidate)
+	if in.BaselineCostPerHour != nil {
+		in, out := &in.BaselineCostPerHour, &out.BaselineCostPerHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CandidateCostPerHour != nil {
+		in, out := &in.CandidateCostPerHour, &out.CandidateCostPerHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComparisonSpec.
+func (in *ComparisonSpec) DeepCopy() *ComparisonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComparisonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 	*out = *in
@@ -1063,6 +1244,26 @@ func (in *ComponentStatusSpec) DeepCopy() *ComponentStatusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversationSpec) DeepCopyInto(out *ConversationSpec) {
+	*out = *in
+	if in.HistoryWindow != nil {
+		in, out := &in.HistoryWindow, &out.HistoryWindow
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversationSpec.
+func (in *ConversationSpec) DeepCopy() *ConversationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DecoderSpec) DeepCopyInto(out *DecoderSpec) {
 	*out = *in
@@ -1352,6 +1553,21 @@ func (in *FineTunedWeightSpec) DeepCopy() *FineTunedWeightSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistogramBucket) DeepCopyInto(out *HistogramBucket) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistogramBucket.
+func (in *HistogramBucket) DeepCopy() *HistogramBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(HistogramBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HuggingFaceSecretReference) DeepCopyInto(out *HuggingFaceSecretReference) {
 	*out = *in
@@ -1465,6 +1681,11 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 		*out = new(ServingRuntimeRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuntimeVersionConstraints != nil {
+		in, out := &in.RuntimeVersionConstraints, &out.RuntimeVersionConstraints
+		*out = make([]RuntimeVersionConstraint, len(*in))
+		copy(*out, *in)
+	}
 	if in.Router != nil {
 		in, out := &in.Router, &out.Router
 		*out = new(RouterSpec)
@@ -1514,6 +1735,16 @@ func (in *InferenceServiceStatus) DeepCopyInto(out *InferenceServiceStatus) {
 		}
 	}
 	in.ModelStatus.DeepCopyInto(&out.ModelStatus)
+	if in.EstimatedHourlyCost != nil {
+		in, out := &in.EstimatedHourlyCost, &out.EstimatedHourlyCost
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.SelectedRuntime != nil {
+		in, out := &in.SelectedRuntime, &out.SelectedRuntime
+		*out = new(RuntimeSelection)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceStatus.
@@ -1526,6 +1757,81 @@ func (in *InferenceServiceStatus) DeepCopy() *InferenceServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IterationComparison) DeepCopyInto(out *IterationComparison) {
+	*out = *in
+	if in.OutputThroughputChange != nil {
+		in, out := &in.OutputThroughputChange, &out.OutputThroughputChange
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TimeToFirstTokenChange != nil {
+		in, out := &in.TimeToFirstTokenChange, &out.TimeToFirstTokenChange
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.InterTokenLatencyChange != nil {
+		in, out := &in.InterTokenLatencyChange, &out.InterTokenLatencyChange
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EndToEndLatencyChange != nil {
+		in, out := &in.EndToEndLatencyChange, &out.EndToEndLatencyChange
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CostPerTokenChange != nil {
+		in, out := &in.CostPerTokenChange, &out.CostPerTokenChange
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IterationComparison.
+func (in *IterationComparison) DeepCopy() *IterationComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(IterationComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IterationMetrics) DeepCopyInto(out *IterationMetrics) {
+	*out = *in
+	if in.OutputThroughput != nil {
+		in, out := &in.OutputThroughput, &out.OutputThroughput
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TimeToFirstToken != nil {
+		in, out := &in.TimeToFirstToken, &out.TimeToFirstToken
+		*out = new(LatencyDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InterTokenLatency != nil {
+		in, out := &in.InterTokenLatency, &out.InterTokenLatency
+		*out = new(LatencyDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndToEndLatency != nil {
+		in, out := &in.EndToEndLatency, &out.EndToEndLatency
+		*out = new(LatencyDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IterationMetrics.
+func (in *IterationMetrics) DeepCopy() *IterationMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(IterationMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KedaConfig) DeepCopyInto(out *KedaConfig) {
 	*out = *in
@@ -1546,6 +1852,56 @@ func (in *KedaConfig) DeepCopy() *KedaConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyDistribution) DeepCopyInto(out *LatencyDistribution) {
+	*out = *in
+	if in.Mean != nil {
+		in, out := &in.Mean, &out.Mean
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.P50 != nil {
+		in, out := &in.P50, &out.P50
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.P90 != nil {
+		in, out := &in.P90, &out.P90
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.P95 != nil {
+		in, out := &in.P95, &out.P95
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.P99 != nil {
+		in, out := &in.P99, &out.P99
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Histogram != nil {
+		in, out := &in.Histogram, &out.Histogram
+		*out = make([]HistogramBucket, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyDistribution.
+func (in *LatencyDistribution) DeepCopy() *LatencyDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeaderSpec) DeepCopyInto(out *LeaderSpec) {
 	*out = *in
@@ -1567,6 +1923,56 @@ func (in *LeaderSpec) DeepCopy() *LeaderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGProfile) DeepCopyInto(out *MIGProfile) {
+	*out = *in
+	if in.MemoryGB != nil {
+		in, out := &in.MemoryGB, &out.MemoryGB
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGProfile.
+func (in *MIGProfile) DeepCopy() *MIGProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MIGProfileStatus) DeepCopyInto(out *MIGProfileStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MIGProfileStatus.
+func (in *MIGProfileStatus) DeepCopy() *MIGProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MIGProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelConfigWarning) DeepCopyInto(out *ModelConfigWarning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfigWarning.
+func (in *ModelConfigWarning) DeepCopy() *ModelConfigWarning {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelConfigWarning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelCopies) DeepCopyInto(out *ModelCopies) {
 	*out = *in
@@ -1821,6 +2227,11 @@ func (in *ModelStatusSpec) DeepCopyInto(out *ModelStatusSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigWarnings != nil {
+		in, out := &in.ConfigWarnings, &out.ConfigWarnings
+		*out = make([]ModelConfigWarning, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatusSpec.
@@ -2153,6 +2564,31 @@ func (in *PredictorSpec) DeepCopy() *PredictorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplaySpec) DeepCopyInto(out *ReplaySpec) {
+	*out = *in
+	if in.TimeScale != nil {
+		in, out := &in.TimeScale, &out.TimeScale
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplaySpec.
+func (in *ReplaySpec) DeepCopy() *ReplaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouterSpec) DeepCopyInto(out *RouterSpec) {
 	*out = *in
@@ -2198,6 +2634,51 @@ func (in *RunnerSpec) DeepCopy() *RunnerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeEngineVersion) DeepCopyInto(out *RuntimeEngineVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeEngineVersion.
+func (in *RuntimeEngineVersion) DeepCopy() *RuntimeEngineVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeEngineVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeSelection) DeepCopyInto(out *RuntimeSelection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSelection.
+func (in *RuntimeSelection) DeepCopy() *RuntimeSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeVersionConstraint) DeepCopyInto(out *RuntimeVersionConstraint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeVersionConstraint.
+func (in *RuntimeVersionConstraint) DeepCopy() *RuntimeVersionConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeVersionConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalerAuthenticationRef) DeepCopyInto(out *ScalerAuthenticationRef) {
 	*out = *in
@@ -2430,6 +2911,11 @@ func (in *ServingRuntimeSpec) DeepCopyInto(out *ServingRuntimeSpec) {
 		*out = new(AcceleratorRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EngineVersion != nil {
+		in, out := &in.EngineVersion, &out.EngineVersion
+		*out = new(RuntimeEngineVersion)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingRuntimeSpec.
@@ -2548,6 +3034,11 @@ func (in *SupportedModelFormat) DeepCopyInto(out *SupportedModelFormat) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SupportedArchitectures != nil {
+		in, out := &in.SupportedArchitectures, &out.SupportedArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Quantization != nil {
 		in, out := &in.Quantization, &out.Quantization
 		*out = new(ModelQuantization)
@@ -2642,6 +3133,53 @@ func (in *TensorParallelismConfig) DeepCopy() *TensorParallelismConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficPhase) DeepCopyInto(out *TrafficPhase) {
+	*out = *in
+	if in.RequestRate != nil {
+		in, out := &in.RequestRate, &out.RequestRate
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPhase.
+func (in *TrafficPhase) DeepCopy() *TrafficPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficScheduleSpec) DeepCopyInto(out *TrafficScheduleSpec) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]TrafficPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficScheduleSpec.
+func (in *TrafficScheduleSpec) DeepCopy() *TrafficScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerPodSpec) DeepCopyInto(out *WorkerPodSpec) {
 	*out = *in
@@ -2688,3 +3226,28 @@ func (in *WorkerSpec) DeepCopy() *WorkerSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSpec) DeepCopyInto(out *WorkloadSpec) {
+	*out = *in
+	if in.Conversation != nil {
+		in, out := &in.Conversation, &out.Conversation
+		*out = new(ConversationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replay != nil {
+		in, out := &in.Replay, &out.Replay
+		*out = new(ReplaySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpec.
+func (in *WorkloadSpec) DeepCopy() *WorkloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
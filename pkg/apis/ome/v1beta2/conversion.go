@@ -0,0 +1,268 @@
+package v1beta2
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// v1beta1 is the hub version every v1beta2 resource converts to and from. The fields of v1beta1 that v1beta2 dropped
+// are kept in annotations of the v1beta2 resources, so that a v1beta1 resource read and written back as v1beta2 is
+// unchanged.
+var (
+	// PredictorAnnotationKey holds the JSON of the v1beta1 predictor of an InferenceService
+	PredictorAnnotationKey = constants.OMEAPIGroupName + "/v1beta1-predictor"
+	// ModelCapabilitiesAnnotationKey holds the JSON of the v1beta1 capabilities of a model that has legacy capabilities
+	ModelCapabilitiesAnnotationKey = constants.OMEAPIGroupName + "/v1beta1-model-capabilities"
+)
+
+var (
+	_ conversion.Convertible = &InferenceService{}
+	_ conversion.Convertible = &BaseModel{}
+	_ conversion.Convertible = &ClusterBaseModel{}
+)
+
+// ConvertTo converts the InferenceService to v1beta1
+func (src *InferenceService) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.InferenceService)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of InferenceService to %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	spec := src.Spec.DeepCopy()
+	dst.Spec = v1beta1.InferenceServiceSpec{
+		Engine:                    spec.Engine,
+		Decoder:                   spec.Decoder,
+		Model:                     spec.Model,
+		Runtime:                   spec.Runtime,
+		RuntimeVersionConstraints: spec.RuntimeVersionConstraints,
+		Router:                    spec.Router,
+		KedaConfig:                spec.KedaConfig,
+		AcceleratorSelector:       spec.AcceleratorSelector,
+	}
+	if raw, ok := popAnnotation(&dst.ObjectMeta, PredictorAnnotationKey); ok {
+		if err := json.Unmarshal([]byte(raw), &dst.Spec.Predictor); err != nil {
+			return fmt.Errorf("failed to restore the predictor of InferenceService %s/%s from annotation %s: %w",
+				src.Namespace, src.Name, PredictorAnnotationKey, err)
+		}
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 InferenceService to v1beta2, keeping its predictor in an annotation
+func (dst *InferenceService) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.InferenceService)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of %T to InferenceService", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	spec := src.Spec.DeepCopy()
+	dst.Spec = InferenceServiceSpec{
+		Engine:                    spec.Engine,
+		Decoder:                   spec.Decoder,
+		Model:                     spec.Model,
+		Runtime:                   spec.Runtime,
+		RuntimeVersionConstraints: spec.RuntimeVersionConstraints,
+		Router:                    spec.Router,
+		KedaConfig:                spec.KedaConfig,
+		AcceleratorSelector:       spec.AcceleratorSelector,
+	}
+	popAnnotation(&dst.ObjectMeta, PredictorAnnotationKey)
+	if !equality.Semantic.DeepEqual(spec.Predictor, v1beta1.PredictorSpec{}) {
+		raw, err := json.Marshal(spec.Predictor)
+		if err != nil {
+			return fmt.Errorf("failed to keep the predictor of InferenceService %s/%s: %w", src.Namespace, src.Name, err)
+		}
+		metav1.SetMetaDataAnnotation(&dst.ObjectMeta, PredictorAnnotationKey, string(raw))
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
+
+// ConvertTo converts the BaseModel to v1beta1
+func (src *BaseModel) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.BaseModel)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of BaseModel to %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertModelSpecTo(&src.Spec, &dst.Spec, &dst.ObjectMeta); err != nil {
+		return fmt.Errorf("failed to convert BaseModel %s/%s: %w", src.Namespace, src.Name, err)
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 BaseModel to v1beta2
+func (dst *BaseModel) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.BaseModel)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of %T to BaseModel", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertModelSpecFrom(&src.Spec, &dst.Spec, &dst.ObjectMeta); err != nil {
+		return fmt.Errorf("failed to convert BaseModel %s/%s: %w", src.Namespace, src.Name, err)
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
+
+// ConvertTo converts the ClusterBaseModel to v1beta1
+func (src *ClusterBaseModel) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.ClusterBaseModel)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of ClusterBaseModel to %T", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertModelSpecTo(&src.Spec, &dst.Spec, &dst.ObjectMeta); err != nil {
+		return fmt.Errorf("failed to convert ClusterBaseModel %s: %w", src.Name, err)
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 ClusterBaseModel to v1beta2
+func (dst *ClusterBaseModel) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.ClusterBaseModel)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of %T to ClusterBaseModel", hub)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertModelSpecFrom(&src.Spec, &dst.Spec, &dst.ObjectMeta); err != nil {
+		return fmt.Errorf("failed to convert ClusterBaseModel %s: %w", src.Name, err)
+	}
+	src.Status.DeepCopyInto(&dst.Status)
+	return nil
+}
+
+// convertModelSpecTo converts the spec to v1beta1, restoring the legacy capabilities kept in the annotations of
+// meta if they still match the capabilities of the spec
+func convertModelSpecTo(src *BaseModelSpec, dst *v1beta1.BaseModelSpec, meta *metav1.ObjectMeta) error {
+	spec := src.DeepCopy()
+	*dst = v1beta1.BaseModelSpec{
+		ModelFormat:               spec.ModelFormat,
+		ModelType:                 spec.ModelType,
+		ModelFramework:            spec.ModelFramework,
+		ModelArchitecture:         spec.ModelArchitecture,
+		Quantization:              spec.Quantization,
+		ModelParameterSize:        spec.ModelParameterSize,
+		ModelCapabilities:         spec.ModelCapabilities,
+		ApiCapabilities:           spec.ApiCapabilities,
+		ModelConfiguration:        spec.ModelConfiguration,
+		ModelExtensionSpec:        spec.ModelExtensionSpec,
+		ServingMode:               spec.ServingMode,
+		MaxTokens:                 spec.MaxTokens,
+		DiffusionPipeline:         spec.DiffusionPipeline,
+		RuntimeVersionConstraints: spec.RuntimeVersionConstraints,
+		AdditionalMetadata:        spec.AdditionalMetadata,
+	}
+	if spec.Storage != nil {
+		dst.Storage = &v1beta1.StorageSpec{
+			Path:           spec.Storage.NodePath,
+			SchemaPath:     spec.Storage.SchemaPath,
+			StorageKey:     spec.Storage.StorageKey,
+			StorageUri:     spec.Storage.StorageUri,
+			NodeSelector:   spec.Storage.NodeSelector,
+			NodeAffinity:   spec.Storage.NodeAffinity,
+			DownloadPolicy: spec.Storage.DownloadPolicy,
+		}
+		if spec.Storage.Parameters != nil {
+			dst.Storage.Parameters = &spec.Storage.Parameters
+		}
+	}
+	if raw, ok := popAnnotation(meta, ModelCapabilitiesAnnotationKey); ok {
+		var legacy []string
+		if err := json.Unmarshal([]byte(raw), &legacy); err != nil {
+			return fmt.Errorf("failed to restore the model capabilities from annotation %s: %w", ModelCapabilitiesAnnotationKey, err)
+		}
+		if slices.Equal(NormalizeModelCapabilities(legacy), spec.ModelCapabilities) {
+			dst.ModelCapabilities = legacy
+		}
+	}
+	return nil
+}
+
+// convertModelSpecFrom converts the v1beta1 spec, replacing the legacy capabilities and keeping them in the
+// annotations of meta
+func convertModelSpecFrom(src *v1beta1.BaseModelSpec, dst *BaseModelSpec, meta *metav1.ObjectMeta) error {
+	spec := src.DeepCopy()
+	*dst = BaseModelSpec{
+		ModelFormat:               spec.ModelFormat,
+		ModelType:                 spec.ModelType,
+		ModelFramework:            spec.ModelFramework,
+		ModelArchitecture:         spec.ModelArchitecture,
+		Quantization:              spec.Quantization,
+		ModelParameterSize:        spec.ModelParameterSize,
+		ModelCapabilities:         NormalizeModelCapabilities(spec.ModelCapabilities),
+		ApiCapabilities:           spec.ApiCapabilities,
+		ModelConfiguration:        spec.ModelConfiguration,
+		ModelExtensionSpec:        spec.ModelExtensionSpec,
+		ServingMode:               spec.ServingMode,
+		MaxTokens:                 spec.MaxTokens,
+		DiffusionPipeline:         spec.DiffusionPipeline,
+		RuntimeVersionConstraints: spec.RuntimeVersionConstraints,
+		AdditionalMetadata:        spec.AdditionalMetadata,
+	}
+	if spec.Storage != nil {
+		dst.Storage = &StorageSpec{
+			StorageUri:     spec.Storage.StorageUri,
+			NodePath:       spec.Storage.Path,
+			SchemaPath:     spec.Storage.SchemaPath,
+			StorageKey:     spec.Storage.StorageKey,
+			NodeSelector:   spec.Storage.NodeSelector,
+			NodeAffinity:   spec.Storage.NodeAffinity,
+			DownloadPolicy: spec.Storage.DownloadPolicy,
+		}
+		if spec.Storage.Parameters != nil {
+			dst.Storage.Parameters = *spec.Storage.Parameters
+		}
+	}
+	popAnnotation(meta, ModelCapabilitiesAnnotationKey)
+	if !slices.Equal(dst.ModelCapabilities, spec.ModelCapabilities) {
+		raw, err := json.Marshal(spec.ModelCapabilities)
+		if err != nil {
+			return fmt.Errorf("failed to keep the model capabilities: %w", err)
+		}
+		metav1.SetMetaDataAnnotation(meta, ModelCapabilitiesAnnotationKey, string(raw))
+	}
+	return nil
+}
+
+// NormalizeModelCapabilities replaces the legacy model capabilities of v1beta1 with the capabilities replacing them,
+// dropping the duplicates
+func NormalizeModelCapabilities(capabilities []string) []string {
+	if capabilities == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(capabilities))
+	for _, capability := range capabilities {
+		if replacement, ok := v1beta1.LegacyModelCapabilities[v1beta1.ModelCapability(capability)]; ok {
+			capability = string(replacement)
+		}
+		if !slices.Contains(normalized, capability) {
+			normalized = append(normalized, capability)
+		}
+	}
+	return normalized
+}
+
+// popAnnotation removes the annotation from meta and returns its value, if any
+func popAnnotation(meta *metav1.ObjectMeta, key string) (string, bool) {
+	value, ok := meta.Annotations[key]
+	if !ok {
+		return "", false
+	}
+	delete(meta.Annotations, key)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	return value, true
+}
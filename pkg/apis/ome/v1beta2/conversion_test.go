@@ -0,0 +1,219 @@
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestInferenceServiceRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		hub  *v1beta1.InferenceService
+	}{
+		{
+			name: "engine and model",
+			hub: &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "serving", Labels: map[string]string{"team": "a"}},
+				Spec: v1beta1.InferenceServiceSpec{
+					Model:   &v1beta1.ModelRef{Name: "llama-3-1-8b"},
+					Runtime: &v1beta1.ServingRuntimeRef{Name: "sglang"},
+					Engine: &v1beta1.EngineSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(1), MaxReplicas: 3},
+					},
+				},
+			},
+		},
+		{
+			name: "deprecated predictor",
+			hub: &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "serving"},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{BaseModel: ptr.To("llama-3-1-8b"), Runtime: ptr.To("sglang")},
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: ptr.To(2),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			spoke := &InferenceService{}
+			g.Expect(spoke.ConvertFrom(tc.hub)).To(gomega.Succeed())
+			_, hasPredictor := spoke.Annotations[PredictorAnnotationKey]
+			g.Expect(hasPredictor).To(gomega.Equal(tc.hub.Spec.Predictor.Model != nil))
+
+			hub := &v1beta1.InferenceService{}
+			g.Expect(spoke.ConvertTo(hub)).To(gomega.Succeed())
+			g.Expect(equality.Semantic.DeepEqual(hub, tc.hub)).To(gomega.BeTrue(), "got %+v, want %+v", hub, tc.hub)
+		})
+	}
+}
+
+func TestInferenceServiceSpokeRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	spoke := &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "serving"},
+		Spec: InferenceServiceSpec{
+			Model:  &v1beta1.ModelRef{Name: "llama-3-1-8b"},
+			Engine: &v1beta1.EngineSpec{},
+			Router: &v1beta1.RouterSpec{},
+		},
+	}
+	hub := &v1beta1.InferenceService{}
+	g.Expect(spoke.ConvertTo(hub)).To(gomega.Succeed())
+	g.Expect(hub.Spec.Predictor).To(gomega.Equal(v1beta1.PredictorSpec{}))
+
+	got := &InferenceService{}
+	g.Expect(got.ConvertFrom(hub)).To(gomega.Succeed())
+	g.Expect(got).To(gomega.Equal(spoke))
+}
+
+func TestInferenceServiceInvalidPredictorAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	spoke := &InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "llama",
+			Namespace:   "serving",
+			Annotations: map[string]string{PredictorAnnotationKey: "{"},
+		},
+	}
+	g.Expect(spoke.ConvertTo(&v1beta1.InferenceService{})).NotTo(gomega.Succeed())
+}
+
+func TestBaseModelRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name             string
+		hub              *v1beta1.BaseModel
+		wantCapabilities []string
+		wantAnnotation   bool
+	}{
+		{
+			name: "current capabilities",
+			hub: &v1beta1.BaseModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "models"},
+				Spec: v1beta1.BaseModelSpec{
+					ModelCapabilities: []string{"TEXT_TO_TEXT", "EMBEDDING"},
+					Storage: &v1beta1.StorageSpec{
+						StorageUri:   ptr.To("hf://meta-llama/Llama-3.1-8B-Instruct"),
+						Path:         ptr.To("/raid/models/llama"),
+						Parameters:   &map[string]string{"region": "us-ashburn-1"},
+						NodeSelector: map[string]string{"gpu": "true"},
+						NodeAffinity: &corev1.NodeAffinity{},
+					},
+				},
+				Status: v1beta1.ModelStatusSpec{State: v1beta1.LifeCycleStateReady, NodesReady: []string{"node-a"}},
+			},
+			wantCapabilities: []string{"TEXT_TO_TEXT", "EMBEDDING"},
+		},
+		{
+			name: "legacy capabilities",
+			hub: &v1beta1.BaseModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "models"},
+				Spec: v1beta1.BaseModelSpec{
+					ModelCapabilities: []string{"TEXT_GENERATION", "CHAT", "TEXT_EMBEDDINGS"},
+				},
+			},
+			wantCapabilities: []string{"TEXT_TO_TEXT", "EMBEDDING"},
+			wantAnnotation:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			spoke := &BaseModel{}
+			g.Expect(spoke.ConvertFrom(tc.hub)).To(gomega.Succeed())
+			g.Expect(spoke.Spec.ModelCapabilities).To(gomega.Equal(tc.wantCapabilities))
+			_, hasCapabilities := spoke.Annotations[ModelCapabilitiesAnnotationKey]
+			g.Expect(hasCapabilities).To(gomega.Equal(tc.wantAnnotation))
+			if tc.hub.Spec.Storage != nil {
+				g.Expect(spoke.Spec.Storage.NodePath).To(gomega.Equal(tc.hub.Spec.Storage.Path))
+				g.Expect(spoke.Spec.Storage.Parameters).To(gomega.Equal(*tc.hub.Spec.Storage.Parameters))
+			}
+
+			hub := &v1beta1.BaseModel{}
+			g.Expect(spoke.ConvertTo(hub)).To(gomega.Succeed())
+			g.Expect(equality.Semantic.DeepEqual(hub, tc.hub)).To(gomega.BeTrue(), "got %+v, want %+v", hub, tc.hub)
+		})
+	}
+}
+
+func TestBaseModelChangedCapabilities(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	spoke := &BaseModel{}
+	g.Expect(spoke.ConvertFrom(&v1beta1.BaseModel{
+		Spec: v1beta1.BaseModelSpec{ModelCapabilities: []string{"TEXT_GENERATION"}},
+	})).To(gomega.Succeed())
+
+	// The legacy capabilities are only restored while they still match the v1beta2 capabilities
+	spoke.Spec.ModelCapabilities = []string{"TEXT_TO_TEXT", "IMAGE_TEXT_TO_TEXT"}
+	hub := &v1beta1.BaseModel{}
+	g.Expect(spoke.ConvertTo(hub)).To(gomega.Succeed())
+	g.Expect(hub.Spec.ModelCapabilities).To(gomega.Equal([]string{"TEXT_TO_TEXT", "IMAGE_TEXT_TO_TEXT"}))
+	g.Expect(hub.Annotations).NotTo(gomega.HaveKey(ModelCapabilitiesAnnotationKey))
+}
+
+func TestClusterBaseModelSpokeRoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	spoke := &ClusterBaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Annotations: map[string]string{"owner": "platform"}},
+		Spec: BaseModelSpec{
+			ModelCapabilities: []string{"TEXT_TO_TEXT"},
+			Storage: &StorageSpec{
+				StorageUri: ptr.To("oci://n/ns/b/models/o/llama"),
+				NodePath:   ptr.To("/raid/models/llama"),
+				Parameters: map[string]string{"auth": "instance_principal"},
+			},
+		},
+	}
+	hub := &v1beta1.ClusterBaseModel{}
+	g.Expect(spoke.ConvertTo(hub)).To(gomega.Succeed())
+	g.Expect(hub.Spec.Storage.Path).To(gomega.Equal(ptr.To("/raid/models/llama")))
+	g.Expect(*hub.Spec.Storage.Parameters).To(gomega.Equal(map[string]string{"auth": "instance_principal"}))
+
+	got := &ClusterBaseModel{}
+	g.Expect(got.ConvertFrom(hub)).To(gomega.Succeed())
+	g.Expect(got).To(gomega.Equal(spoke))
+}
+
+func TestNormalizeModelCapabilities(t *testing.T) {
+	testCases := []struct {
+		name     string
+		in       []string
+		expected []string
+	}{
+		{name: "nil", in: nil, expected: nil},
+		{name: "current", in: []string{"RERANK", "EMBEDDING"}, expected: []string{"RERANK", "EMBEDDING"}},
+		{name: "legacy", in: []string{"TEXT_RERANK", "VISION"}, expected: []string{"RERANK", "IMAGE_TEXT_TO_TEXT"}},
+		{
+			name:     "duplicates after replacement",
+			in:       []string{"TEXT_GENERATION", "TEXT_TO_TEXT", "TEXT_SUMMARIZATION"},
+			expected: []string{"TEXT_TO_TEXT"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(NormalizeModelCapabilities(tc.in)).To(gomega.Equal(tc.expected))
+		})
+	}
+}
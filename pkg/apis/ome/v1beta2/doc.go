@@ -0,0 +1,7 @@
+// Package v1beta2 contains API Schema definitions for the OME v1beta2 API group. It drops the deprecated fields of
+// the v1beta1 InferenceService and models, which stay the storage version: the v1beta2 resources are converted from
+// and to v1beta1 by the conversion webhook of the manager.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+// +groupName=ome.io
+package v1beta2
@@ -0,0 +1,87 @@
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// InferenceServiceSpec is the top level type for this resource. Unlike v1beta1, it has no predictor: the model is
+// referenced by Model and served by Engine.
+type InferenceServiceSpec struct {
+	// Engine defines the serving engine spec
+	// This provides detailed container and pod specifications for model serving.
+	// Engine can also be configured for multi-node deployments using leader and worker specifications.
+	// +optional
+	Engine *v1beta1.EngineSpec `json:"engine,omitempty"`
+
+	// Decoder defines the decoder spec
+	// This is specifically used for PD (Prefill-Decode) disaggregated serving deployments.
+	// +optional
+	Decoder *v1beta1.DecoderSpec `json:"decoder,omitempty"`
+
+	// Model defines the model to be used for inference, referencing either a BaseModel or a ClusterBaseModel.
+	// +optional
+	Model *v1beta1.ModelRef `json:"model,omitempty"`
+
+	// Runtime defines the serving runtime environment that will be used to execute the model.
+	// Runtime is optional - if not defined, the operator will automatically select the best runtime
+	// based on the model's size, architecture, format, quantization, and framework.
+	// +optional
+	Runtime *v1beta1.ServingRuntimeRef `json:"runtime,omitempty"`
+
+	// RuntimeVersionConstraints restricts the engine versions of the runtimes that can be selected or
+	// referenced for this InferenceService, in addition to the constraints of the model
+	// +listType=atomic
+	// +optional
+	RuntimeVersionConstraints []v1beta1.RuntimeVersionConstraint `json:"runtimeVersionConstraints,omitempty"`
+
+	// Router defines the router spec
+	// +optional
+	Router *v1beta1.RouterSpec `json:"router,omitempty"`
+
+	// KedaConfig defines the autoscaling configuration for KEDA
+	// +optional
+	KedaConfig *v1beta1.KedaConfig `json:"kedaConfig,omitempty"`
+
+	// AcceleratorSelector specifies accelerator selection preferences
+	// +optional
+	AcceleratorSelector *v1beta1.AcceleratorSelector `json:"acceleratorSelector,omitempty"`
+}
+
+// InferenceService is the Schema for the InferenceServices API
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="BaseModel",type="string",JSONPath=".spec.model.name"
+// +kubebuilder:printcolumn:name="Runtime",type="string",JSONPath=".spec.runtime.name"
+// +kubebuilder:printcolumn:name="Prev",type="integer",JSONPath=".status.components.engine.traffic[?(@.tag=='prev')].percent"
+// +kubebuilder:printcolumn:name="Latest",type="integer",JSONPath=".status.components.engine.traffic[?(@.latestRevision==true)].percent"
+// +kubebuilder:printcolumn:name="PrevRolledoutRevision",type="string",JSONPath=".status.components.engine.traffic[?(@.tag=='prev')].revisionName"
+// +kubebuilder:printcolumn:name="LatestReadyRevision",type="string",JSONPath=".status.components.engine.traffic[?(@.latestRevision==true)].revisionName"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=inferenceservices,shortName=isvc
+type InferenceService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InferenceServiceSpec `json:"spec,omitempty"`
+
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Status v1beta1.InferenceServiceStatus `json:"status,omitempty"`
+}
+
+// InferenceServiceList contains a list of InferenceService
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type InferenceServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InferenceService `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InferenceService{}, &InferenceServiceList{})
+}
@@ -0,0 +1,199 @@
+package v1beta2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// StorageSpec defines where a model is stored and on which nodes it is downloaded
+type StorageSpec struct {
+	// StorageUri specifies the source URI of the model in a supported storage backend.
+	// Supported formats:
+	// - OCI Object Storage:   oci://n/{namespace}/b/{bucket}/o/{object_path}
+	// - Persistent Volume:    pvc://{pvc-name}/{sub-path}
+	// - Hugging Face:         hf://{owner}/{name}
+	// - Vendor-specific:      vendor://{vendor-name}/{resource-type}/{resource-path}
+	// The legacy OCI formats accepted by v1beta1, oci://{namespace}@{region}/{bucket}/{prefix} and
+	// oci://{bucket}/{prefix}, are rejected.
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('oci://') || self.startsWith('oci://n/')",message="storageUri must use the oci://n/{namespace}/b/{bucket}/o/{object_path} format"
+	// +required
+	StorageUri *string `json:"storageUri,omitempty"`
+
+	// NodePath is the absolute path on the nodes where the model is downloaded to by the model agent,
+	// and mounted from into the serving pods. It replaces the path field of v1beta1.
+	// +optional
+	NodePath *string `json:"nodePath,omitempty"`
+
+	// SchemaPath is the path to the model schema or configuration file within the storage system.
+	// +optional
+	SchemaPath *string `json:"schemaPath,omitempty"`
+
+	// Parameters contain key-value pairs to override default storage credentials or configuration.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// StorageKey is the name of the key in a Kubernetes Secret used to authenticate access to the model storage.
+	// +optional
+	StorageKey *string `json:"key,omitempty"`
+
+	// NodeSelector defines a set of key-value label pairs that must be present on a node
+	// for the model to be downloaded onto that node.
+	// +optional
+	// +mapType=atomic
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// NodeAffinity describes the node affinity rules that further constrain which nodes
+	// are eligible to download and store this model.
+	// +optional
+	NodeAffinity *v1.NodeAffinity `json:"nodeAffinity,omitempty"`
+
+	// DownloadPolicy describes the policy of downloading model artifacts
+	// +optional
+	DownloadPolicy *v1beta1.DownloadPolicy `json:"downloadPolicy,omitempty"`
+}
+
+// BaseModelSpec defines the desired state of BaseModel. Unlike v1beta1, the legacy model capabilities are not
+// accepted and the node path of the storage is explicit.
+type BaseModelSpec struct {
+	// +optional
+	ModelFormat v1beta1.ModelFormat `json:"modelFormat"`
+
+	// ModelType defines the architecture family of the model (e.g., "bert", "gpt2", "llama").
+	// +optional
+	ModelType *string `json:"modelType,omitempty"`
+
+	// ModelFramework specifies the underlying framework used by the model.
+	// +optional
+	ModelFramework *v1beta1.ModelFrameworkSpec `json:"modelFramework,omitempty"`
+
+	// ModelArchitecture specifies the concrete model implementation or head, such as "LlamaForCausalLM".
+	// +optional
+	ModelArchitecture *string `json:"modelArchitecture,omitempty"`
+
+	// Quantization defines the quantization scheme applied to the model weights.
+	// +optional
+	Quantization *v1beta1.ModelQuantization `json:"quantization,omitempty"`
+
+	// ModelParameterSize indicates the total number of parameters in the model, e.g. "7B".
+	// +optional
+	ModelParameterSize *string `json:"modelParameterSize,omitempty"`
+
+	// ModelCapabilities of the model, e.g., "TEXT_TO_TEXT", "EMBEDDING", "RERANK"
+	// +kubebuilder:validation:items:Enum=EMBEDDING;RERANK;TEXT_TO_TEXT;TEXT_TO_AUDIO;TEXT_TO_IMAGE;TEXT_TO_VIDEO;IMAGE_TEXT_TO_TEXT;IMAGE_TEXT_TO_AUDIO;IMAGE_TEXT_TO_IMAGE;IMAGE_TEXT_TO_VIDEO;VIDEO_TEXT_TO_AUDIO;AUDIO_TO_TEXT;AUDIO_TO_AUDIO;AUDIO_TRANSLATION
+	// +listType=atomic
+	// +optional
+	ModelCapabilities []string `json:"modelCapabilities,omitempty"`
+
+	// API capabilities supported by the model, e.g., "OPENAI_V1_CHAT_COMPLETIONS"
+	// +listType=atomic
+	// +optional
+	ApiCapabilities []string `json:"apiCapabilities,omitempty"`
+
+	// Configuration of the model, stored as generic JSON for flexibility.
+	// +optional
+	ModelConfiguration runtime.RawExtension `json:"modelConfiguration,omitempty"`
+
+	// Storage configuration for the model
+	// +required
+	Storage *StorageSpec `json:"storage,omitempty"`
+
+	// ModelExtension is the common extension of the model
+	v1beta1.ModelExtensionSpec `json:",inline"`
+
+	// Serving mode of the model, e.g., ["On-demand", "Dedicated"]
+	// +listType=atomic
+	// +optional
+	ServingMode []string `json:"servingMode,omitempty"`
+
+	// MaxTokens is the maximum number of tokens that can be processed by the model
+	// +optional
+	MaxTokens *int32 `json:"maxTokens,omitempty"`
+
+	// DiffusionPipeline captures pipeline-specific metadata for diffusion models (from model_index.json).
+	// +optional
+	DiffusionPipeline *v1beta1.DiffusionPipelineSpec `json:"diffusionPipeline,omitempty"`
+
+	// RuntimeVersionConstraints restricts the engine versions of the runtimes that can serve the model
+	// +listType=atomic
+	// +optional
+	RuntimeVersionConstraints []v1beta1.RuntimeVersionConstraint `json:"runtimeVersionConstraints,omitempty"`
+
+	// Additional metadata for the model
+	// +optional
+	AdditionalMetadata map[string]string `json:"additionalMetadata,omitempty"`
+}
+
+// BaseModel is the Schema for the basemodels API
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Disabled",type="boolean",JSONPath=".spec.disabled"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Vendor",type="string",JSONPath=".spec.vendor"
+// +kubebuilder:printcolumn:name="Framework",type=string,JSONPath=".spec.modelFramework.name"
+// +kubebuilder:printcolumn:name="FrameworkVersion",type=string,JSONPath=".spec.modelFramework.version"
+// +kubebuilder:printcolumn:name="ModelFormat",type="string",JSONPath=".spec.modelFormat.name"
+// +kubebuilder:printcolumn:name="Architecture",type="string",JSONPath=".spec.modelArchitecture"
+// +kubebuilder:printcolumn:name="Capabilities",type="string",JSONPath=".spec.modelCapabilities[*]"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.modelParameterSize"
+// +kubebuilder:printcolumn:name="CompartmentID",type="string",JSONPath=".spec.compartmentID"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type BaseModel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BaseModelSpec           `json:"spec,omitempty"`
+	Status v1beta1.ModelStatusSpec `json:"status,omitempty"`
+}
+
+// ClusterBaseModel is the Schema for the clusterbasemodels API
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope="Cluster"
+// +kubebuilder:printcolumn:name="Disabled",type="boolean",JSONPath=".spec.disabled"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Vendor",type="string",JSONPath=".spec.vendor"
+// +kubebuilder:printcolumn:name="Framework",type=string,JSONPath=".spec.modelFramework.name"
+// +kubebuilder:printcolumn:name="FrameworkVersion",type=string,JSONPath=".spec.modelFramework.version"
+// +kubebuilder:printcolumn:name="ModelFormat",type="string",JSONPath=".spec.modelFormat.name"
+// +kubebuilder:printcolumn:name="Architecture",type="string",JSONPath=".spec.modelArchitecture"
+// +kubebuilder:printcolumn:name="Capabilities",type="string",JSONPath=".spec.modelCapabilities[*]"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".spec.modelParameterSize"
+// +kubebuilder:printcolumn:name="CompartmentID",type="string",JSONPath=".spec.compartmentID"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterBaseModel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BaseModelSpec           `json:"spec,omitempty"`
+	Status v1beta1.ModelStatusSpec `json:"status,omitempty"`
+}
+
+// BaseModelList contains a list of BaseModel
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type BaseModelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BaseModel `json:"items"`
+}
+
+// ClusterBaseModelList contains a list of ClusterBaseModel
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type ClusterBaseModelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBaseModel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BaseModel{}, &BaseModelList{})
+	SchemeBuilder.Register(&ClusterBaseModel{}, &ClusterBaseModelList{})
+}
@@ -0,0 +1,123 @@
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testObject() *corev1.ConfigMap {
+	earlier, later := metav1.NewTime(time.Now().Add(-time.Hour)), metav1.NewTime(time.Now())
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Namespace:  "serving",
+		Name:       "llama",
+		UID:        "5c1e4d7b",
+		Generation: 3,
+		ManagedFields: []metav1.ManagedFieldsEntry{
+			{Manager: "helm", Operation: metav1.ManagedFieldsOperationUpdate, Time: &earlier},
+			{Manager: "ome-manager", Operation: metav1.ManagedFieldsOperationUpdate, Time: &later, Subresource: "status"},
+			{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate, Time: &later},
+		},
+	}}
+}
+
+func TestNewEvent(t *testing.T) {
+	event := NewEvent(testObject(), "InferenceService", EventReady, "inferenceservice", "InferenceService is Ready")
+	assert.Equal(t, EventReady, event.Type)
+	assert.Equal(t, "serving", event.Namespace)
+	assert.Equal(t, "llama", event.Name)
+	assert.Equal(t, "5c1e4d7b", event.UID)
+	assert.Equal(t, int64(3), event.Generation)
+	// The status updates of the controllers are not changes of the owners of the resource
+	assert.Equal(t, "kubectl-client-side-apply", event.Actor)
+	assert.Equal(t, "", LatestFieldManager(&corev1.ConfigMap{}))
+	// Who deleted the resource is not known
+	assert.Empty(t, NewEvent(testObject(), "InferenceService", EventDeleted, "inferenceservice", "deleted").Actor)
+}
+
+func TestNewSink(t *testing.T) {
+	for target, valid := range map[string]bool{
+		"stdout":                       true,
+		"https://audit.example.com/v1": true,
+		"relative/audit.log":           false,
+		"syslog":                       false,
+	} {
+		_, err := NewSink(target)
+		assert.Equal(t, valid, err == nil, target)
+	}
+
+	path := filepath.Join(t.TempDir(), "audit", "events.log")
+	sink, err := NewSink("file://" + path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), Event{Type: EventDeleted, Kind: "BaseModel", Name: "llama"}))
+	require.NoError(t, sink.Write(context.Background(), Event{Type: EventReady, Kind: "BaseModel", Name: "mistral"}))
+	require.NoError(t, sink.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	var event Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, "mistral", event.Name)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var mu sync.Mutex
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil || event.Name == "rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, nil)
+	require.NoError(t, sink.Write(context.Background(), Event{Type: EventFailed, Kind: "ClusterBaseModel", Name: "deepseek"}))
+	assert.ErrorContains(t, sink.Write(context.Background(), Event{Type: EventFailed, Name: "rejected"}), "400")
+	require.Len(t, received, 1)
+	assert.Equal(t, "deepseek", received[0].Name)
+}
+
+func TestRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	var nilRecorder *Recorder
+	nilRecorder.RecordObject(testObject(), "InferenceService", EventReady, "inferenceservice", "", nil)
+
+	recorder := NewRecorder(NewWriterSink(&buf), 2)
+	recorder.RecordObject(testObject(), "InferenceService", EventReady, "inferenceservice", "", map[string]string{"runtime": "srt-llama"})
+	recorder.RecordObject(testObject(), "InferenceService", EventNotReady, "inferenceservice", "", nil)
+	// The queue is full until the recorder starts
+	recorder.RecordObject(testObject(), "InferenceService", EventDeleted, "inferenceservice", "", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, recorder.Start(ctx))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var event Event
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	assert.Equal(t, EventReady, event.Type)
+	assert.Equal(t, map[string]string{"runtime": "srt-llama"}, event.Details)
+	assert.False(t, recorder.NeedLeaderElection())
+}
@@ -0,0 +1,92 @@
+// Package auditsink streams structured audit events on the significant transitions of the OME custom resources, e.g.
+// a model becoming ready or an InferenceService being deleted, to stdout, a file or a webhook for compliance reviews.
+package auditsink
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EventType is the transition an audit event records
+type EventType string
+
+const (
+	// EventChangeRequested records an admitted create or update of a resource by a user
+	EventChangeRequested EventType = "ChangeRequested"
+	// EventReady records a resource becoming ready, e.g. a model downloaded by its nodes or an InferenceService
+	// whose rollout completed
+	EventReady EventType = "Ready"
+	// EventNotReady records a ready resource that is no longer ready
+	EventNotReady EventType = "NotReady"
+	// EventFailed records a resource that failed, e.g. a model that no node could download
+	EventFailed EventType = "Failed"
+	// EventDeleted records the removal of the finalizer of a deleted resource, once its cleanup completed
+	EventDeleted EventType = "Deleted"
+)
+
+// Event is an audit event on a transition of a resource
+type Event struct {
+	// Time is when the transition was recorded
+	Time time.Time `json:"time"`
+	// Type is the transition
+	Type EventType `json:"type"`
+
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+	Generation int64  `json:"generation,omitempty"`
+
+	// Actor is who caused the transition: the user of an admitted change, else the field manager of the latest
+	// change of the resource, e.g. kubectl-client-side-apply. It is empty for a deletion.
+	Actor string `json:"actor,omitempty"`
+	// Controller is the controller or webhook that recorded the event
+	Controller string `json:"controller"`
+
+	Message string            `json:"message,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewEvent returns an event on a transition of the object recorded by the controller now. The actor is the field
+// manager of the latest change of the object, and unknown for a deletion since the field managers record the
+// changes of the object, not who deleted it.
+func NewEvent(obj client.Object, kind string, eventType EventType, controller, message string) Event {
+	actor := LatestFieldManager(obj)
+	if eventType == EventDeleted {
+		actor = ""
+	}
+	return Event{
+		Time:       time.Now().UTC(),
+		Type:       eventType,
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		UID:        string(obj.GetUID()),
+		Generation: obj.GetGeneration(),
+		Actor:      actor,
+		Controller: controller,
+		Message:    message,
+	}
+}
+
+// LatestFieldManager returns the field manager of the latest change of the object, or an empty string if the
+// object has no managed fields
+func LatestFieldManager(obj metav1.Object) string {
+	fields := obj.GetManagedFields()
+	var latest *metav1.ManagedFieldsEntry
+	for i, entry := range fields {
+		// The status is written by the controllers, not by the owners of the resource
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if latest == nil || entry.Time.After(latest.Time.Time) {
+			latest = &fields[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Manager
+}
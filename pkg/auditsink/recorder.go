@@ -0,0 +1,110 @@
+package auditsink
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DefaultBufferSize is the number of events a recorder holds before it drops new ones
+	DefaultBufferSize = 1024
+	// drainTimeout bounds the writing of the events left when the recorder stops
+	drainTimeout = 10 * time.Second
+)
+
+var log = logf.Log.WithName("audit-sink")
+
+var eventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ome_audit_events_total",
+	Help: "Number of audit events by type and result (written, failed, dropped)",
+}, []string{"type", "result"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(eventsTotal)
+}
+
+// Recorder queues the audit events of the controllers and writes them to a sink in the background, so that a slow
+// sink never blocks a reconcile. A nil recorder records nothing, which is how the controllers run without a sink.
+type Recorder struct {
+	sink   Sink
+	events chan Event
+}
+
+// NewRecorder returns a recorder writing to the sink and holding up to bufferSize events, DefaultBufferSize if not
+// positive. The events are written once the recorder is started, usually by adding it to the manager.
+func NewRecorder(sink Sink, bufferSize int) *Recorder {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Recorder{sink: sink, events: make(chan Event, bufferSize)}
+}
+
+// Record queues the event, or drops it when the queue is full
+func (r *Recorder) Record(event Event) {
+	if r == nil {
+		return
+	}
+	select {
+	case r.events <- event:
+	default:
+		eventsTotal.WithLabelValues(string(event.Type), "dropped").Inc()
+		log.Info("Audit event dropped, the sink is falling behind", "type", event.Type, "kind", event.Kind,
+			"namespace", event.Namespace, "name", event.Name)
+	}
+}
+
+// RecordObject queues an event on a transition of the object, see NewEvent
+func (r *Recorder) RecordObject(obj client.Object, kind string, eventType EventType, controller, message string, details map[string]string) {
+	if r == nil {
+		return
+	}
+	event := NewEvent(obj, kind, eventType, controller, message)
+	event.Details = details
+	r.Record(event)
+}
+
+// Start writes the queued events until the context is done, then writes the events left and closes the sink
+func (r *Recorder) Start(ctx context.Context) error {
+	defer func() {
+		if err := r.sink.Close(); err != nil {
+			log.Error(err, "Failed to close the audit sink")
+		}
+	}()
+	for {
+		select {
+		case event := <-r.events:
+			r.write(ctx, event)
+		case <-ctx.Done():
+			drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+			defer cancel()
+			for {
+				select {
+				case event := <-r.events:
+					r.write(drainCtx, event)
+				default:
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica of the manager write the events of its webhooks
+func (r *Recorder) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *Recorder) write(ctx context.Context, event Event) {
+	if err := r.sink.Write(ctx, event); err != nil {
+		eventsTotal.WithLabelValues(string(event.Type), "failed").Inc()
+		log.Error(err, "Failed to write audit event", "type", event.Type, "kind", event.Kind,
+			"namespace", event.Namespace, "name", event.Name)
+		return
+	}
+	eventsTotal.WithLabelValues(string(event.Type), "written").Inc()
+}
@@ -0,0 +1,129 @@
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultWebhookTimeout bounds the delivery of an event to a webhook
+const defaultWebhookTimeout = 10 * time.Second
+
+// Sink writes audit events
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+	Close() error
+}
+
+// NewSink returns the sink of a target: "stdout", a file path, either absolute or as a file:// URL, or an http(s)://
+// URL of a webhook the events are posted to
+func NewSink(target string) (Sink, error) {
+	switch {
+	case target == "stdout":
+		return NewWriterSink(os.Stdout), nil
+	case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+		if _, err := url.Parse(target); err != nil {
+			return nil, fmt.Errorf("invalid audit webhook URL %q: %w", target, err)
+		}
+		return NewWebhookSink(target, nil), nil
+	case strings.HasPrefix(target, "file://"):
+		return NewFileSink(strings.TrimPrefix(target, "file://"))
+	case filepath.IsAbs(target):
+		return NewFileSink(target)
+	default:
+		return nil, fmt.Errorf("invalid audit sink %q: expected stdout, an absolute file path or an http(s) URL", target)
+	}
+}
+
+// writerSink writes the events as JSON lines
+type writerSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterSink returns a sink writing the events to w as JSON lines
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+// NewFileSink returns a sink appending the events to the file as JSON lines, creating it if needed
+func NewFileSink(path string) (Sink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &writerSink{w: f, closer: f}, nil
+}
+
+func (s *writerSink) Write(_ context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+func (s *writerSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// webhookSink posts every event as JSON to a URL
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting every event as JSON to the URL with the client, or with a client timing out
+// after 10s if nil. Any 2xx response acknowledges the event.
+func NewWebhookSink(url string, client *http.Client) Sink {
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &webhookSink{url: url, client: client}
+}
+
+func (s *webhookSink) Write(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,259 @@
+package configutils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Schema is the set of keys a configuration accepts. It is derived from the mapstructure tags of the configuration
+// structs decoded from the configuration, so that a key no struct decodes, e.g. a typo, can be reported instead of
+// being ignored.
+type Schema struct {
+	keys map[string]bool
+	// sections accept any key below them, e.g. the keys of a map
+	sections map[string]bool
+}
+
+// NewSchema creates an empty schema
+func NewSchema() *Schema {
+	return &Schema{keys: map[string]bool{}, sections: map[string]bool{}}
+}
+
+// Add adds the keys of a configuration struct decoded from a key of the configuration, or from its root if the key
+// is empty. Fields are named by their mapstructure tag, squashed structs are flattened, nested structs add their keys
+// below the key of their field and maps accept any key.
+func (s *Schema) Add(key string, config interface{}) *Schema {
+	t := reflect.TypeOf(config)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s.addStruct(strings.ToLower(key), t)
+	return s
+}
+
+// AddKeys adds keys read individually from the configuration, e.g. with viper.GetString
+func (s *Schema) AddKeys(keys ...string) *Schema {
+	for _, key := range keys {
+		s.keys[strings.ToLower(key)] = true
+	}
+	return s
+}
+
+// AddSection adds a key that accepts any key below it
+func (s *Schema) AddSection(key string) *Schema {
+	s.sections[strings.ToLower(key)] = true
+	return s
+}
+
+// Merge adds the keys of other schemas
+func (s *Schema) Merge(others ...*Schema) *Schema {
+	for _, other := range others {
+		for key := range other.keys {
+			s.keys[key] = true
+		}
+		for key := range other.sections {
+			s.sections[key] = true
+		}
+	}
+	return s
+}
+
+// Keys returns the sorted keys of the schema, sections included
+func (s *Schema) Keys() []string {
+	keys := make([]string, 0, len(s.keys)+len(s.sections))
+	for key := range s.keys {
+		keys = append(keys, key)
+	}
+	for key := range s.sections {
+		if !s.keys[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Has returns whether the schema accepts a key. A key is accepted if it is a key of the schema, is below one of its
+// sections, or is the parent of one of its keys, e.g. a section left empty.
+func (s *Schema) Has(key string) bool {
+	key = strings.ToLower(key)
+	if s.keys[key] || s.sections[key] {
+		return true
+	}
+	for section := range s.sections {
+		if strings.HasPrefix(key, section+".") {
+			return true
+		}
+	}
+	for known := range s.keys {
+		if strings.HasPrefix(known, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error listing the keys the schema doesn't accept, with the known key each of them is most
+// likely a typo of
+func (s *Schema) Validate(keys []string) error {
+	var unknown []string
+	for _, key := range keys {
+		if s.Has(key) {
+			continue
+		}
+		if suggestion := s.Suggest(key); suggestion != "" {
+			unknown = append(unknown, fmt.Sprintf("%s (did you mean %s?)", key, suggestion))
+		} else {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown configuration keys: %s", strings.Join(unknown, ", "))
+}
+
+// Suggest returns the known key closest to a key, empty if none is close enough to be a typo of it
+func (s *Schema) Suggest(key string) string {
+	key = strings.ToLower(key)
+	best, bestDistance := "", len(key)/3+1
+	for _, known := range s.Keys() {
+		if d := editDistance(key, known); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
+
+// Effective returns the values of the keys of the schema that are set in Viper, from a flag, the environment, a
+// configuration file or a default, as nested maps. The values of the sensitive keys are redacted.
+func (s *Schema) Effective(v *viper.Viper) map[string]interface{} {
+	effective := map[string]interface{}{}
+	for _, key := range s.Keys() {
+		if !v.IsSet(key) {
+			continue
+		}
+		path := strings.Split(key, ".")
+		section := effective
+		for _, name := range path[:len(path)-1] {
+			child, ok := section[name].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				section[name] = child
+			}
+			section = child
+		}
+		// Sections sort before their keys, which overwrite the values of the section
+		section[path[len(path)-1]] = redact(key, v.Get(key))
+	}
+	return effective
+}
+
+// redact copies a value, replacing the strings of its sensitive keys
+func redact(key string, value interface{}) interface{} {
+	if values, ok := value.(map[string]interface{}); ok {
+		copied := make(map[string]interface{}, len(values))
+		for k, v := range values {
+			copied[k] = redact(key+"."+k, v)
+		}
+		return copied
+	}
+	if s, ok := value.(string); ok && s != "" && IsSensitiveKey(key) {
+		return "<redacted>"
+	}
+	return value
+}
+
+// IsSensitiveKey returns whether the value of a key is a credential that must not be printed
+func IsSensitiveKey(key string) bool {
+	name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	for _, suffix := range []string{"token", "password", "secret", "api_key", "private_key"} {
+		if name == suffix || strings.HasSuffix(name, "_"+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) addStruct(prefix string, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		name, options, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if strings.Contains(options, "squash") {
+			if fieldType.Kind() == reflect.Struct {
+				s.addStruct(prefix, fieldType)
+			}
+			continue
+		}
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			// Untagged fields hold dependencies, e.g. loggers and clients, unless they are plain values mapstructure
+			// matches by name
+			if !isPlainValue(fieldType) {
+				continue
+			}
+			name = field.Name
+		}
+
+		key := strings.ToLower(name)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch {
+		case fieldType.Kind() == reflect.Map || fieldType.Kind() == reflect.Interface:
+			s.sections[key] = true
+		case fieldType.Kind() == reflect.Struct && fieldType.PkgPath() != "time":
+			s.addStruct(key, fieldType)
+		default:
+			s.keys[key] = true
+		}
+	}
+}
+
+// isPlainValue returns whether a type holds a value rather than a dependency
+func isPlainValue(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return isPlainValue(t.Elem())
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
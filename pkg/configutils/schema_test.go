@@ -0,0 +1,113 @@
+package configutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaTestStorage struct {
+	URI      string            `mapstructure:"storage_uri"`
+	Metadata map[string]string `mapstructure:"metadata"`
+}
+
+type schemaTestEmbedded struct {
+	Filename string
+	MaxSize  int
+}
+
+type schemaTestConfig struct {
+	Logger   interface{ Info(string) }
+	Name     string        `mapstructure:"name"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	Patterns []string      `mapstructure:"patterns"`
+	APIKey   string        `mapstructure:"api_key"`
+	Source   schemaTestStorage
+	Target   *schemaTestStorage `mapstructure:"target"`
+	Client   *schemaTestStorage
+	Ignored  string `mapstructure:"-"`
+
+	schemaTestEmbedded `mapstructure:",squash"`
+}
+
+func TestSchemaKeys(t *testing.T) {
+	schema := NewSchema().
+		Add("", &schemaTestConfig{}).
+		Add("logging", schemaTestEmbedded{}).
+		AddKeys("source.OCI.enabled")
+
+	assert.Equal(t, []string{
+		"api_key",
+		"filename",
+		"logging.filename",
+		"logging.maxsize",
+		"maxsize",
+		"name",
+		"patterns",
+		"source.oci.enabled",
+		"target.metadata",
+		"target.storage_uri",
+		"timeout",
+	}, schema.Keys())
+
+	for _, key := range []string{"name", "NAME", "target", "target.metadata.owner", "source", "source.oci"} {
+		assert.True(t, schema.Has(key), key)
+	}
+	for _, key := range []string{"logger", "client.storage_uri", "ignored", "target.storage", "nam"} {
+		assert.False(t, schema.Has(key), key)
+	}
+
+	merged := NewSchema().AddSection("warmup").Merge(schema)
+	assert.True(t, merged.Has("warmup.prompts"))
+	assert.True(t, merged.Has("patterns"))
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := NewSchema().Add("", &schemaTestConfig{})
+
+	assert.NoError(t, schema.Validate([]string{"name", "target.storage_uri", "target.metadata.owner"}))
+
+	err := schema.Validate([]string{"name", "pattern", "target.storage_url", "region"})
+	require.Error(t, err)
+	assert.Equal(t, "unknown configuration keys: pattern (did you mean patterns?), region, "+
+		"target.storage_url (did you mean target.storage_uri?)", err.Error())
+}
+
+func TestSchemaEffective(t *testing.T) {
+	schema := NewSchema().Add("", &schemaTestConfig{})
+	v := viper.New()
+	v.Set("name", "llama")
+	v.Set("api_key", "sk-secret")
+	v.Set("target", map[string]interface{}{
+		"storage_uri": "oci://n/ns/b/bucket/o/model",
+		"metadata":    map[string]interface{}{"owner": "team"},
+	})
+	v.Set("unknown", "ignored")
+
+	assert.Equal(t, map[string]interface{}{
+		"name":    "llama",
+		"api_key": "<redacted>",
+		"target": map[string]interface{}{
+			"storage_uri": "oci://n/ns/b/bucket/o/model",
+			"metadata":    map[string]interface{}{"owner": "team"},
+		},
+	}, schema.Effective(v))
+}
+
+func TestIsSensitiveKey(t *testing.T) {
+	for key, sensitive := range map[string]bool{
+		"hf_token":              true,
+		"source.oci.obo_token":  true,
+		"api_key":               true,
+		"security_token":        true,
+		"hf_token_file":         false,
+		"secret_name":           false,
+		"source.oci.enabled":    false,
+		"model.metadata.secret": true,
+	} {
+		assert.Equal(t, sensitive, IsSensitiveKey(key), key)
+	}
+}
@@ -35,12 +35,16 @@ var (
 	InferenceServiceName          = "inferenceservice"
 	InferenceServiceAPIName       = "inferenceservices"
 	InferenceServicePodLabelKey   = OMEAPIGroupName + "/" + InferenceServiceName
+	InferenceServiceShardLabelKey = OMEAPIGroupName + "/shard"
 	InferenceServiceConfigMapName = "inferenceservice-config"
 	BaseModelFinalizer            = "basemodels.ome.io/finalizer"
 	ClusterBaseModelFinalizer     = "clusterbasemodels.ome.io/finalizer"
 	AcceleratorClassFinalizer     = "acceleratorclasses.ome.io/finalizer"
 )
 
+// PodMutatorNamespaceConfigMapName is the ConfigMap a namespace creates to customize the pod mutator for its pods
+var PodMutatorNamespaceConfigMapName = "ome-pod-mutator-config"
+
 // OME Agent Constants
 var (
 	AgentName                         = "ome-agent"
@@ -67,10 +71,19 @@ var (
 	AgentKeyNameEnvVarKey       = AgentAppName + "_" + "KEY_NAME"
 	AgentSecretNameEnvVarKey    = AgentAppName + "_" + "SECRET_NAME"
 
+	// Progress Reporting, see pkg/agentprogress
+	AgentProgressPodNameEnvVarKey       = AgentAppName + "_" + "PROGRESS_POD_NAME"
+	AgentProgressPodNamespaceEnvVarKey  = AgentAppName + "_" + "PROGRESS_POD_NAMESPACE"
+	AgentProgressContainerNameEnvVarKey = AgentAppName + "_" + "PROGRESS_CONTAINER_NAME"
+	AgentProgressConfigMapEnvVarKey     = AgentAppName + "_" + "PROGRESS_CONFIG_MAP"
+
 	// Serving Sidecar Configuration
 	AgentFineTunedWeightInfoFilePath      = AgentAppName + "_" + "FINE_TUNED_WEIGHT_INFO_FILE_PATH"
 	AgentUnzippedFineTunedWeightDirectory = AgentAppName + "_" + "UNZIPPED_FINE_TUNED_WEIGHT_DIRECTORY"
 	AgentZippedFineTunedWeightDirectory   = AgentAppName + "_" + "ZIPPED_FINE_TUNED_WEIGHT_DIRECTORY"
+	AgentReadinessPortEnvVarKey           = AgentAppName + "_" + "READINESS_PORT"
+	AgentWarmupEnabledEnvVarKey           = AgentAppName + "_" + "WARMUP_ENABLED"
+	AgentWarmupEngineURLEnvVarKey         = AgentAppName + "_" + "WARMUP_ENGINE_URL"
 )
 
 // InferenceService MultiModel Constants
@@ -108,6 +121,9 @@ var (
 	BaseModelName                            = OMEAPIGroupName + "/base-model-name"
 	BaseModelVendorAnnotationKey             = OMEAPIGroupName + "/base-model-vendor"
 	ServingRuntimeKeyName                    = OMEAPIGroupName + "/serving-runtime"
+	ExplainRuntimeSelectionAnnotationKey     = OMEAPIGroupName + "/explain-runtime-selection"
+	RuntimeOverrideAnnotationKey             = OMEAPIGroupName + "/runtime-override"
+	ModelDerivedResourcesAnnotationKey       = OMEAPIGroupName + "/model-derived-resources"
 	BaseModelFormat                          = OMEAPIGroupName + "/base-model-format"
 	BaseModelFormatVersion                   = OMEAPIGroupName + "/base-model-format-version"
 	FTServingWithMergedWeightsAnnotationKey  = OMEAPIGroupName + "/fine-tuned-serving-with-merged-weights"
@@ -116,8 +132,11 @@ var (
 	EntrypointComponent                      = OMEAPIGroupName + "/entrypoint-component"
 	ContainerPrometheusPortKey               = "prometheus.ome.io/port"
 	ContainerPrometheusPathKey               = "prometheus.ome.io/path"
+	ContainerPrometheusSchemeKey             = "prometheus.ome.io/scheme"
+	ContainerPrometheusCAFileKey             = "prometheus.ome.io/ca-file"
 	PrometheusPortAnnotationKey              = "prometheus.io/port"
 	PrometheusPathAnnotationKey              = "prometheus.io/path"
+	PrometheusSchemeAnnotationKey            = "prometheus.io/scheme"
 	PrometheusScrapeAnnotationKey            = "prometheus.io/scrape"
 	RDMAAutoInjectAnnotationKey              = "rdma.ome.io/auto-inject"
 	RDMAProfileAnnotationKey                 = "rdma.ome.io/profile"
@@ -127,6 +146,15 @@ var (
 	DefaultPodPrometheusPort                 = "9091"
 	ModelCategoryAnnotation                  = "models.ome.io/category"
 
+	// Model metadata extraction Annotations, set by the model metadata agent on the BaseModel/ClusterBaseModel
+	ModelMetadataExtractedAtAnnotationKey = OMEAPIGroupName + "/metadata-extracted-at"
+	ModelMetadataSourceAnnotationKey      = OMEAPIGroupName + "/metadata-source"
+	// ModelMetadataStorageURIAnnotationKey is the storage URI of the model the metadata was extracted from, the
+	// metadata is extracted again when the storage URI of the model changes
+	ModelMetadataStorageURIAnnotationKey = OMEAPIGroupName + "/metadata-storage-uri"
+	// ModelDigestAnnotationKey is the digest of the verified model directory, see ome-agent verify
+	ModelDigestAnnotationKey = OMEAPIGroupName + "/model-digest"
+
 	// Ingress Configuration Overrides
 	IngressDomainTemplate          = OMEAPIGroupName + "/ingress-domain-template"
 	IngressDomain                  = OMEAPIGroupName + "/ingress-domain"
@@ -161,6 +189,18 @@ var (
 	FineTunedWeightFTStrategyLabelKey     = "fine-tuned-weight-ft-strategy"
 )
 
+// ServingRuntime quantization capability labels, e.g. "quantization.ome.io/awq": "true" declares
+// that the runtime can load AWQ quantized models
+var (
+	RuntimeQuantizationLabelPrefix = "quantization." + OMEAPIGroupName + "/"
+)
+
+// ServingRuntime task capability labels, e.g. "task.ome.io/embedding": "true" declares that the
+// runtime can serve embedding models
+var (
+	RuntimeTaskLabelPrefix = "task." + OMEAPIGroupName + "/"
+)
+
 // PrioriryClass
 var (
 	DedicatedAiClusterPreemptionPriorityClass = "volcano-scheduling-high-priority"
@@ -248,14 +288,47 @@ var (
 
 // Webhook Constants
 var (
-	PodMutatorWebhookName              = OMEName + "-pod-mutator-webhook"
-	ServingRuntimeValidatorWebhookName = OMEName + "-servingRuntime-validator-webhook"
-	BenchmarkJobValidatorWebhookName   = OMEName + "-benchmark-job-validator-webhook"
+	PodMutatorWebhookName               = OMEName + "-pod-mutator-webhook"
+	ServingRuntimeValidatorWebhookName  = OMEName + "-servingRuntime-validator-webhook"
+	BenchmarkJobValidatorWebhookName    = OMEName + "-benchmark-job-validator-webhook"
+	BaseModelValidatorWebhookName       = OMEName + "-base-model-validator-webhook"
+	FineTunedWeightValidatorWebhookName = OMEName + "-fine-tuned-weight-validator-webhook"
 )
 
 // GPU/CPU resource constants
 const (
-	NvidiaGPUResourceType = "nvidia.com/gpu"
+	NvidiaGPUResourceType   = "nvidia.com/gpu"
+	NvidiaMIGResourcePrefix = "nvidia.com/mig-"
+	AMDGPUResourceType      = "amd.com/gpu"
+	IntelGPUResourcePrefix  = "gpu.intel.com/"
+	IntelGaudiResourceType  = "habana.ai/gaudi"
+	GoogleTPUResourceType   = "google.com/tpu"
+)
+
+// Node labels published by Node Feature Discovery and the accelerator vendor operators,
+// used to discover AcceleratorClasses
+const (
+	NvidiaGPUProductLabelKey      = "nvidia.com/gpu.product"
+	NvidiaGPUMemoryLabelKey       = "nvidia.com/gpu.memory"
+	NvidiaGPUFamilyLabelKey       = "nvidia.com/gpu.family"
+	NvidiaGPUComputeMajorLabelKey = "nvidia.com/gpu.compute.major"
+	NvidiaGPUComputeMinorLabelKey = "nvidia.com/gpu.compute.minor"
+	NvidiaMIGCapableLabelKey      = "nvidia.com/mig.capable"
+
+	// Published by the AMD GPU operator node labeller
+	AMDGPUProductLabelKey = "amd.com/gpu.product-name"
+	AMDGPUFamilyLabelKey  = "amd.com/gpu.family"
+	AMDGPUVRAMLabelKey    = "amd.com/gpu.vram"
+
+	// Published by the Node Feature Discovery rules of the Intel Gaudi operator
+	IntelGaudiProductLabelKey = "habana.ai/gaudi.product"
+
+	// Published by GKE on TPU node pools
+	GoogleTPUAcceleratorLabelKey = "cloud.google.com/gke-tpu-accelerator"
+	GoogleTPUTopologyLabelKey    = "cloud.google.com/gke-tpu-topology"
+
+	// AcceleratorClassDiscoveredLabelKey marks AcceleratorClasses managed by accelerator discovery
+	AcceleratorClassDiscoveredLabelKey = "ome.io/discovered"
 )
 
 // InferenceService Environment Variables
@@ -263,6 +336,13 @@ const (
 	ContainerPrometheusMetricsPortEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PORT"
 	ContainerPrometheusMetricsPathEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PATH"
 	QueueProxyAggregatePrometheusMetricsPortEnvVarKey = "AGGREGATE_PROMETHEUS_METRICS_PORT"
+	ContainerPrometheusMetricsSchemeEnvVarKey         = "CONTAINER_PROMETHEUS_METRICS_SCHEME"
+	ContainerPrometheusMetricsCAFileEnvVarKey         = "CONTAINER_PROMETHEUS_METRICS_CA_FILE"
+	AggregatePrometheusMetricsTLSCertFileEnvVarKey    = "AGGREGATE_PROMETHEUS_METRICS_TLS_CERT_FILE"
+	AggregatePrometheusMetricsTLSKeyFileEnvVarKey     = "AGGREGATE_PROMETHEUS_METRICS_TLS_KEY_FILE"
+	AggregatePrometheusMetricsClientCAFileEnvVarKey   = "AGGREGATE_PROMETHEUS_METRICS_CLIENT_CA_FILE"
+	AggregatePrometheusMetricsTokenFileEnvVarKey      = "AGGREGATE_PROMETHEUS_METRICS_BEARER_TOKEN_FILE"
+	AggregatePrometheusMetricsCacheTTLEnvVarKey       = "AGGREGATE_PROMETHEUS_METRICS_CACHE_TTL"
 
 	TFewWeightPathEnvVarKey = "TFEW_PATH"
 
@@ -279,6 +359,25 @@ const (
 
 const (
 	SentenceTransformersConfigFileName = "config_sentence_transformers.json"
+	// SentenceTransformersModulesFileName lists the modules of sentence-transformers models, and
+	// SentenceBertConfigFileName holds the settings of their Transformer module
+	SentenceTransformersModulesFileName = "modules.json"
+	SentenceBertConfigFileName          = "sentence_bert_config.json"
+
+	// Tokenizer files of Hugging Face models
+	TokenizerConfigFileName  = "tokenizer_config.json"
+	SpecialTokensMapFileName = "special_tokens_map.json"
+	TokenizerFileName        = "tokenizer.json"
+	// ChatTemplateFileName holds the chat template saved by transformers >= 4.47, and
+	// LegacyChatTemplateFileName the one saved by the earlier versions, outside tokenizer_config.json
+	ChatTemplateFileName       = "chat_template.jinja"
+	LegacyChatTemplateFileName = "chat_template.json"
+
+	// GenerationConfigFileName holds the default generation parameters of Hugging Face models
+	GenerationConfigFileName = "generation_config.json"
+
+	// PreprocessorConfigFileName holds the image processor settings of Hugging Face multimodal models
+	PreprocessorConfigFileName = "preprocessor_config.json"
 )
 
 type InferenceServiceComponent string
@@ -350,6 +449,7 @@ const (
 	FineTunedAdapterContainerName   = "fine-tuned-adapter"
 	ServingSidecarContainerName     = "serving-sidecar"
 	MultiNodeProberContainerPort    = 8080
+	ServingSidecarReadinessPort     = 8089
 )
 
 // Model Agents Constants
@@ -484,6 +584,7 @@ const (
 	VolcanoJobKind          = "Job"
 	LWSKind                 = "LeaderWorkerSet"
 	GatewayKind             = "Gateway"
+	HTTPRouteKind           = "HTTPRoute"
 	ServiceKind             = "Service"
 )
 
@@ -545,6 +646,10 @@ var (
 // FineTunedWeight related constants
 const (
 	FineTunedWeightMergedWeightsConfigKey = "merged_weights"
+	// FineTunedWeightModelArchitectureConfigKey and FineTunedWeightModelTypeConfigKey hold the architecture
+	// of the model the weight was trained on, e.g. "LlamaForCausalLM" and "llama"
+	FineTunedWeightModelArchitectureConfigKey = "model_architecture"
+	FineTunedWeightModelTypeConfigKey         = "model_type"
 )
 
 type ModelVendor string
@@ -677,6 +782,12 @@ func EngineServiceName(name string) string {
 	return name + "-engine"
 }
 
+// AgentProgressConfigMapName returns the name of the ConfigMap the agents of the pods of a component of an
+// InferenceService publish their progress heartbeats to
+func AgentProgressConfigMapName(name, component string) string {
+	return name + "-" + component + "-progress"
+}
+
 func DecoderPrefix() string {
 	return "^/v1/.*$"
 }
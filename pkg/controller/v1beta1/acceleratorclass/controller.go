@@ -3,7 +3,6 @@ package acceleratorclass
 import (
 	"context"
 	"sort"
-	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -19,16 +18,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerhealth"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/tracing"
 )
 
 // +kubebuilder:rbac:groups=ome.io,resources=acceleratorclasses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ome.io,resources=acceleratorclasses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ome.io,resources=acceleratorclasses/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 type AcceleratorClassReconciler struct {
@@ -52,6 +56,7 @@ func (r *AcceleratorClassReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	// Handle deletion
 	if !ac.DeletionTimestamp.IsZero() {
+		deleteCapacityMetrics(ac.Name)
 		if controllerutil.ContainsFinalizer(ac, constants.AcceleratorClassFinalizer) {
 			controllerutil.RemoveFinalizer(ac, constants.AcceleratorClassFinalizer)
 			if err := r.Update(ctx, ac); err != nil {
@@ -77,17 +82,43 @@ func (r *AcceleratorClassReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	matchedNodes := make([]string, 0, len(nodeList.Items))
-	for _, node := range nodeList.Items {
-		if !nodePassesDiscovery(ac, &node) {
+	matchedNodeSet := make(map[string]bool, len(nodeList.Items))
+	var nodes []*corev1.Node
+	var total, allocatable, used int64
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !nodePassesDiscovery(ac, node) {
 			continue
 		}
-		if !nodeMatchCapabilities(ac, &node) {
+		if !nodeMatchCapabilities(ac, node) {
 			continue
 		}
 		matchedNodes = append(matchedNodes, node.Name)
+		matchedNodeSet[node.Name] = true
+		nodes = append(nodes, node)
+		total += countAccelerators(ac, node.Status.Capacity)
+		allocatable += countAccelerators(ac, node.Status.Allocatable)
 	}
 	sort.Strings(matchedNodes)
 
+	// Accelerators are in use by every pod scheduled on a matching node that has not terminated
+	var pods []*corev1.Pod
+	if len(matchedNodes) > 0 {
+		podList := &corev1.PodList{}
+		if err := r.List(ctx, podList); err != nil {
+			log.Error(err, "failed to list pods")
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if !matchedNodeSet[pod.Spec.NodeName] || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			pods = append(pods, pod)
+			used += podAcceleratorRequests(ac, pod)
+		}
+	}
+
 	// In Reconcile, after computing desired fields (without setting LastUpdated yet):
 	latest := &v1beta1.AcceleratorClass{}
 	if err := r.Get(ctx, req.NamespacedName, latest); err != nil {
@@ -96,6 +127,12 @@ func (r *AcceleratorClassReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	desired := latest.DeepCopy()
 	desired.Status.Nodes = matchedNodes
 	desired.Status.AvailableNodes = int32(len(matchedNodes))
+	desired.Status.TotalAccelerators = int32(total)
+	desired.Status.AllocatableAccelerators = int32(allocatable)
+	desired.Status.UsedAccelerators = int32(used)
+	desired.Status.AvailableAccelerators = int32(max(allocatable-used, 0))
+	desired.Status.MIGProfiles = migProfileStatuses(ac, nodes, pods)
+	recordCapacityMetrics(desired.Name, &desired.Status)
 
 	// Only update status if something changed (except LastUpdated):
 	if !acceleratorClassStatusEqualIgnoreTime(latest.Status, desired.Status) {
@@ -117,21 +154,34 @@ func (r *AcceleratorClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&v1beta1.AcceleratorClass{}).
 		Watches(
 			&corev1.Node{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-				// Any node change could affect any AcceleratorClass; requeue all
-				acList := &v1beta1.AcceleratorClassList{}
-				if err := r.List(ctx, acList); err != nil {
-					return nil
-				}
-				requests := make([]reconcile.Request, 0, len(acList.Items))
-				for i := range acList.Items {
-					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&acList.Items[i])})
-				}
-				return requests
-			}),
+			// Any node change could affect any AcceleratorClass; requeue all
+			handler.EnqueueRequestsFromMapFunc(r.requeueAllAcceleratorClasses),
 			builder.WithPredicates(),
 		).
-		Complete(r)
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.requeueAllAcceleratorClasses),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				// Only pods requesting accelerators change the used capacity
+				pod, ok := obj.(*corev1.Pod)
+				return ok && podAcceleratorRequests(nil, pod) > 0
+			})),
+		).
+		Complete(tracing.Reconciler("acceleratorclass", controllermetrics.Reconciler("acceleratorclass",
+			controllerhealth.Reconciler("acceleratorclass", &v1beta1.AcceleratorClass{}, r))))
+}
+
+// requeueAllAcceleratorClasses enqueues every AcceleratorClass
+func (r *AcceleratorClassReconciler) requeueAllAcceleratorClasses(ctx context.Context, _ client.Object) []reconcile.Request {
+	acList := &v1beta1.AcceleratorClassList{}
+	if err := r.List(ctx, acList); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(acList.Items))
+	for i := range acList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&acList.Items[i])})
+	}
+	return requests
 }
 
 func nodePassesDiscovery(ac *v1beta1.AcceleratorClass, node *corev1.Node) bool {
@@ -169,41 +219,104 @@ func getGPUCapacity(node *corev1.Node) (total int64, byResource map[string]int64
 	}
 
 	for name, q := range res {
-		n := string(name)
-
-		// NVIDIA classic
-		if n == "nvidia.com/gpu" {
-			v := q.Value()
-			byResource[n] = v
-			total += v
+		if !isAcceleratorResource(name) {
 			continue
 		}
+		v := q.Value()
+		byResource[string(name)] += v
+		total += v
+	}
+	return total, byResource
+}
 
-		// NVIDIA MIG profiles (treat as accelerators; not equivalent to card count)
-		if strings.HasPrefix(n, "nvidia.com/mig-") {
-			v := q.Value()
-			byResource[n] += v
-			total += v
-			continue
+// isAcceleratorResource reports whether an extended resource is exposed by the device plugin of a registered vendor
+func isAcceleratorResource(name corev1.ResourceName) bool {
+	for _, v := range vendors {
+		if v.IsAcceleratorResource(name) {
+			return true
 		}
+	}
+	return false
+}
 
-		// AMD (common)
-		if n == "amd.com/gpu" {
-			v := q.Value()
-			byResource[n] = v
-			total += v
-			continue
+// isClassResource reports whether a resource counts towards the accelerators of the class.
+// Classes that declare their resources count only those; otherwise any accelerator resource counts.
+// A nil class matches any accelerator resource.
+func isClassResource(ac *v1beta1.AcceleratorClass, name corev1.ResourceName) bool {
+	if ac == nil || len(ac.Spec.Resources) == 0 {
+		return isAcceleratorResource(name)
+	}
+	for _, r := range ac.Spec.Resources {
+		if r.Name == string(name) {
+			return true
 		}
+	}
+	return false
+}
 
-		// Intel (common plugin exposes under gpu.intel.com/*; skip memory-only resources)
-		if strings.HasPrefix(n, "gpu.intel.com/") && !strings.Contains(n, "memory") {
-			v := q.Value()
-			byResource[n] += v
-			total += v
-			continue
+// countAccelerators sums the accelerators of the class in a node resource list
+func countAccelerators(ac *v1beta1.AcceleratorClass, res corev1.ResourceList) int64 {
+	var total int64
+	for name, q := range res {
+		if isClassResource(ac, name) {
+			total += q.Value()
 		}
 	}
-	return total, byResource
+	return total
+}
+
+// podAcceleratorRequests returns the accelerators of the class requested by a pod. Extended resources
+// cannot be overcommitted, so limits are used for resources without requests. As for the scheduler, the pod
+// needs the larger of the sum over its containers and the largest init container request.
+func podAcceleratorRequests(ac *v1beta1.AcceleratorClass, pod *corev1.Pod) int64 {
+	containerRequests := func(c *corev1.Container) int64 {
+		n := countAccelerators(ac, c.Resources.Requests)
+		for name, q := range c.Resources.Limits {
+			if _, requested := c.Resources.Requests[name]; !requested && isClassResource(ac, name) {
+				n += q.Value()
+			}
+		}
+		return n
+	}
+
+	var sum int64
+	for i := range pod.Spec.Containers {
+		sum += containerRequests(&pod.Spec.Containers[i])
+	}
+	for i := range pod.Spec.InitContainers {
+		sum = max(sum, containerRequests(&pod.Spec.InitContainers[i]))
+	}
+	return sum
+}
+
+// migProfileStatuses returns the capacity of the MIG profiles of a class on the matching nodes and the pods scheduled
+// on them. The instances of a profile are counted with the extended resource of the profile.
+func migProfileStatuses(ac *v1beta1.AcceleratorClass, nodes []*corev1.Node, pods []*corev1.Pod) []v1beta1.MIGProfileStatus {
+	if len(ac.Spec.MIGProfiles) == 0 {
+		return nil
+	}
+	statuses := make([]v1beta1.MIGProfileStatus, 0, len(ac.Spec.MIGProfiles))
+	for _, profile := range ac.Spec.MIGProfiles {
+		profileClass := &v1beta1.AcceleratorClass{Spec: v1beta1.AcceleratorClassSpec{
+			Resources: []v1beta1.AcceleratorResource{{Name: profile.ResourceName}},
+		}}
+		var total, allocatable, used int64
+		for _, node := range nodes {
+			total += countAccelerators(profileClass, node.Status.Capacity)
+			allocatable += countAccelerators(profileClass, node.Status.Allocatable)
+		}
+		for _, pod := range pods {
+			used += podAcceleratorRequests(profileClass, pod)
+		}
+		statuses = append(statuses, v1beta1.MIGProfileStatus{
+			Name:                 profile.Name,
+			TotalInstances:       int32(total),
+			AllocatableInstances: int32(allocatable),
+			UsedInstances:        int32(used),
+			AvailableInstances:   int32(max(allocatable-used, 0)),
+		})
+	}
+	return statuses
 }
 
 // returns true if equal when ignoring LastUpdated
@@ -222,6 +222,160 @@ func TestAcceleratorClass_Reconcile_DoesNotUpdateTimestampOnNoChange(t *testing.
 	g.Expect(post.Status.LastUpdated.Time.Equal(firstUpdate.Time)).To(BeTrue())
 }
 
+func TestAcceleratorClass_Reconcile_ComputesCapacity(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	ac := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ac"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Discovery: v1beta1.AcceleratorDiscovery{
+				NodeSelector: map[string]string{"accelerator": "nvidia"},
+			},
+			Resources: []v1beta1.AcceleratorResource{
+				{Name: constants.NvidiaGPUResourceType, Quantity: resource.MustParse("1")},
+			},
+		},
+	}
+
+	gpuNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("8"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("7"),
+				},
+			},
+		}
+	}
+	gpuPod := func(name, nodeName string, gpus string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse(gpus),
+						},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			ac,
+			gpuNode("node-1", map[string]string{"accelerator": "nvidia"}),
+			gpuNode("node-2", map[string]string{"accelerator": "nvidia"}),
+			gpuNode("other-node", nil),
+			gpuPod("running", "node-1", "4", corev1.PodRunning),
+			gpuPod("pending", "node-2", "2", corev1.PodPending),
+			gpuPod("succeeded", "node-2", "1", corev1.PodSucceeded),
+			gpuPod("elsewhere", "other-node", "8", corev1.PodRunning),
+		).
+		WithStatusSubresource(&v1beta1.AcceleratorClass{}).
+		Build()
+
+	reconciler := &AcceleratorClassReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorClassTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
+
+	ctx := context.TODO()
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: ac.Name}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	updated := &v1beta1.AcceleratorClass{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: ac.Name}, updated)).To(Succeed())
+	g.Expect(updated.Status.AvailableNodes).To(Equal(int32(2)))
+	g.Expect(updated.Status.TotalAccelerators).To(Equal(int32(16)))
+	g.Expect(updated.Status.AllocatableAccelerators).To(Equal(int32(14)))
+	g.Expect(updated.Status.UsedAccelerators).To(Equal(int32(6)))
+	g.Expect(updated.Status.AvailableAccelerators).To(Equal(int32(8)))
+}
+
+func TestAcceleratorClass_Reconcile_ComputesMIGProfileCapacity(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	const migResource = constants.NvidiaMIGResourcePrefix + "1g.10gb"
+	ac := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-a100-80gb"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Discovery: v1beta1.AcceleratorDiscovery{
+				NodeSelector: map[string]string{"accelerator": "nvidia"},
+			},
+			Resources: []v1beta1.AcceleratorResource{
+				{Name: constants.NvidiaGPUResourceType, Quantity: resource.MustParse("1")},
+			},
+			MIGProfiles: []v1beta1.MIGProfile{
+				{Name: "1g.10gb", ResourceName: migResource, ComputeSlices: 1},
+				{Name: "3g.40gb", ResourceName: constants.NvidiaMIGResourcePrefix + "3g.40gb", ComputeSlices: 3},
+			},
+		},
+	}
+
+	// A node with 6 full GPUs and 2 GPUs partitioned into 14 instances of the 1g.10gb profile
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "mig-node", Labels: map[string]string{"accelerator": "nvidia"}},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("6"),
+				corev1.ResourceName(migResource):                     resource.MustParse("14"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("6"),
+				corev1.ResourceName(migResource):                     resource.MustParse("14"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "small-model", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: node.Name,
+			Containers: []corev1.Container{{
+				Name: "main",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceName(migResource): resource.MustParse("3")},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ac, node, pod).
+		WithStatusSubresource(&v1beta1.AcceleratorClass{}).
+		Build()
+
+	reconciler := &AcceleratorClassReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorClassTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
+
+	ctx := context.TODO()
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: ac.Name}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	updated := &v1beta1.AcceleratorClass{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: ac.Name}, updated)).To(Succeed())
+	g.Expect(updated.Status.TotalAccelerators).To(Equal(int32(6)))
+	g.Expect(updated.Status.UsedAccelerators).To(Equal(int32(0)))
+	g.Expect(updated.Status.MIGProfiles).To(Equal([]v1beta1.MIGProfileStatus{
+		{Name: "1g.10gb", TotalInstances: 14, AllocatableInstances: 14, UsedInstances: 3, AvailableInstances: 11},
+		{Name: "3g.40gb"},
+	}))
+}
+
 func Test_getGPUCapacity_Helper(t *testing.T) {
 	g := NewWithT(t)
 
@@ -233,14 +387,18 @@ func Test_getGPUCapacity_Helper(t *testing.T) {
 				corev1.ResourceName("nvidia.com/mig-1g.10gb"): resource.MustParse("4"),
 				corev1.ResourceName("amd.com/gpu"):            resource.MustParse("1"),
 				corev1.ResourceName("gpu.intel.com/cards"):    resource.MustParse("3"),
+				corev1.ResourceName("google.com/tpu"):         resource.MustParse("4"),
+				corev1.ResourceName("example.com/foo"):        resource.MustParse("5"),
 			},
 		},
 	}
 
 	total, byRes := getGPUCapacity(n)
-	g.Expect(total).To(Equal(int64(10))) // 2 + 4 + 1 + 3
+	g.Expect(total).To(Equal(int64(14))) // 2 + 4 + 1 + 3 + 4
 	g.Expect(byRes).To(HaveKeyWithValue("nvidia.com/gpu", int64(2)))
 	g.Expect(byRes).To(HaveKeyWithValue("nvidia.com/mig-1g.10gb", int64(4)))
 	g.Expect(byRes).To(HaveKeyWithValue("amd.com/gpu", int64(1)))
 	g.Expect(byRes).To(HaveKeyWithValue("gpu.intel.com/cards", int64(3)))
+	g.Expect(byRes).To(HaveKeyWithValue("google.com/tpu", int64(4)))
+	g.Expect(byRes).NotTo(HaveKey("example.com/foo"))
 }
@@ -0,0 +1,146 @@
+package acceleratorclass
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerhealth"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/tracing"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// AcceleratorDiscoveryReconciler creates and updates AcceleratorClasses from the accelerator
+// labels published on nodes by Node Feature Discovery and the vendor operators, so operators don't
+// have to hand-author every accelerator type. Discovered classes are labeled with
+// constants.AcceleratorClassDiscoveredLabelKey; hand-authored classes with the same name are never modified.
+type AcceleratorDiscoveryReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+func (r *AcceleratorDiscoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("node", req.Name)
+
+	// Any node change may add or change an accelerator type, so rediscover from all nodes
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		log.Error(err, "failed to list nodes")
+		return ctrl.Result{}, err
+	}
+
+	for _, discovered := range discoverAcceleratorClasses(nodeList.Items) {
+		if err := r.upsertAcceleratorClass(ctx, discovered); err != nil {
+			if errors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "failed to apply discovered AcceleratorClass", "acceleratorclass", discovered.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// upsertAcceleratorClass creates the discovered class, or updates the discovered fields of an
+// existing class previously created by discovery. Fields not set by discovery are preserved.
+func (r *AcceleratorDiscoveryReconciler) upsertAcceleratorClass(ctx context.Context, discovered *v1beta1.AcceleratorClass) error {
+	existing := &v1beta1.AcceleratorClass{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(discovered), existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		r.Log.Info("Creating discovered AcceleratorClass", "acceleratorclass", discovered.Name)
+		return r.Create(ctx, discovered)
+	}
+
+	if existing.Labels[constants.AcceleratorClassDiscoveredLabelKey] != "true" {
+		r.Log.V(1).Info("Skipping hand-authored AcceleratorClass", "acceleratorclass", existing.Name)
+		return nil
+	}
+
+	desired := existing.DeepCopy()
+	desired.Spec.Vendor = discovered.Spec.Vendor
+	desired.Spec.Family = discovered.Spec.Family
+	desired.Spec.Model = discovered.Spec.Model
+	desired.Spec.Discovery.NodeSelector = discovered.Spec.Discovery.NodeSelector
+	desired.Spec.Capabilities.MemoryGB = discovered.Spec.Capabilities.MemoryGB
+	desired.Spec.Capabilities.ComputeCapability = discovered.Spec.Capabilities.ComputeCapability
+	desired.Spec.Capabilities.Features = discovered.Spec.Capabilities.Features
+	desired.Spec.Resources = discovered.Spec.Resources
+
+	if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return nil
+	}
+	r.Log.Info("Updating discovered AcceleratorClass", "acceleratorclass", existing.Name)
+	return r.Update(ctx, desired)
+}
+
+// SetupWithManager wires the discovery controller to node label changes
+func (r *AcceleratorDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("acceleratorclass-discovery").
+		For(&corev1.Node{}, builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Complete(tracing.Reconciler("acceleratorclass-discovery", controllermetrics.Reconciler("acceleratorclass-discovery",
+			controllerhealth.Reconciler("acceleratorclass-discovery", &corev1.Node{}, r))))
+}
+
+// discoverAcceleratorClasses builds one AcceleratorClass per accelerator product found on the nodes,
+// sorted by name. Nodes are visited in name order so that the result is deterministic.
+func discoverAcceleratorClasses(nodes []corev1.Node) []*v1beta1.AcceleratorClass {
+	sorted := make([]corev1.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	classes := make(map[string]*v1beta1.AcceleratorClass)
+	for i := range sorted {
+		ac := acceleratorClassFromNode(&sorted[i])
+		if ac == nil {
+			continue
+		}
+		if _, exists := classes[ac.Name]; !exists {
+			classes[ac.Name] = ac
+		}
+	}
+
+	result := make([]*v1beta1.AcceleratorClass, 0, len(classes))
+	for _, ac := range classes {
+		result = append(result, ac)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// acceleratorClassFromNode builds an AcceleratorClass from the accelerator labels of a node using the
+// first vendor that recognizes the node, or returns nil if no vendor publishes accelerators on the node.
+func acceleratorClassFromNode(node *corev1.Node) *v1beta1.AcceleratorClass {
+	for _, v := range vendors {
+		if ac := v.DiscoverClass(node); ac != nil {
+			return ac
+		}
+	}
+	return nil
+}
+
+// sanitizeName turns a product label into a valid lowercase resource name
+func sanitizeName(s string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(s), "-"), "-.")
+}
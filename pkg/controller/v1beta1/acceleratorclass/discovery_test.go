@@ -0,0 +1,201 @@
+package acceleratorclass
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func gpuNode(name, product string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				constants.NvidiaGPUProductLabelKey:      product,
+				constants.NvidiaGPUMemoryLabelKey:       "81920",
+				constants.NvidiaGPUFamilyLabelKey:       "Hopper",
+				constants.NvidiaGPUComputeMajorLabelKey: "9",
+				constants.NvidiaGPUComputeMinorLabelKey: "0",
+				constants.NvidiaMIGCapableLabelKey:      "true",
+			},
+		},
+	}
+}
+
+func TestAcceleratorDiscovery_Reconcile_CreatesClassFromNodeLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	nodeA := gpuNode("node-a", "NVIDIA-H100-80GB-HBM3")
+	nodeB := gpuNode("node-b", "NVIDIA-H100-80GB-HBM3")
+	cpuNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-node"}}
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nodeA, nodeB, cpuNode).
+		Build()
+
+	reconciler := &AcceleratorDiscoveryReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorDiscoveryTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
+
+	ctx := context.TODO()
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: nodeA.Name}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	acList := &v1beta1.AcceleratorClassList{}
+	g.Expect(c.List(ctx, acList)).To(Succeed())
+	g.Expect(acList.Items).To(HaveLen(1))
+
+	ac := acList.Items[0]
+	g.Expect(ac.Name).To(Equal("nvidia-h100-80gb-hbm3"))
+	g.Expect(ac.Labels).To(HaveKeyWithValue(constants.AcceleratorClassDiscoveredLabelKey, "true"))
+	g.Expect(ac.Spec.Vendor).To(Equal("nvidia"))
+	g.Expect(ac.Spec.Family).To(Equal("hopper"))
+	g.Expect(ac.Spec.Model).To(Equal("h100-80gb-hbm3"))
+	g.Expect(ac.Spec.Discovery.NodeSelector).To(HaveKeyWithValue(constants.NvidiaGPUProductLabelKey, "NVIDIA-H100-80GB-HBM3"))
+	g.Expect(ac.Spec.Capabilities.MemoryGB.Cmp(resource.MustParse("80Gi"))).To(Equal(0))
+	g.Expect(ac.Spec.Capabilities.ComputeCapability).To(Equal("9.0"))
+	g.Expect(ac.Spec.Capabilities.Features).To(ConsistOf("mig"))
+}
+
+func TestAcceleratorDiscovery_Reconcile_PreservesHandAuthoredClass(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	handAuthored := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-a100-sxm4-80gb"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Vendor: "nvidia",
+			Family: "ampere-custom",
+		},
+	}
+	node := gpuNode("node-a", "NVIDIA-A100-SXM4-80GB")
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(handAuthored, node).
+		Build()
+
+	reconciler := &AcceleratorDiscoveryReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorDiscoveryTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
+
+	ctx := context.TODO()
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: node.Name}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	curr := &v1beta1.AcceleratorClass{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: handAuthored.Name}, curr)).To(Succeed())
+	g.Expect(curr.Spec.Family).To(Equal("ampere-custom"))
+	g.Expect(curr.Spec.Discovery.NodeSelector).To(BeEmpty())
+}
+
+func TestAcceleratorDiscovery_Reconcile_UpdatesDiscoveredClass(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	perHour := resource.MustParse("4")
+	discovered := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "nvidia-h100-80gb-hbm3",
+			Labels: map[string]string{constants.AcceleratorClassDiscoveredLabelKey: "true"},
+		},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Vendor: "nvidia",
+			Cost:   &v1beta1.AcceleratorCost{PerHour: &perHour},
+		},
+	}
+	node := gpuNode("node-a", "NVIDIA-H100-80GB-HBM3")
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(discovered, node).
+		Build()
+
+	reconciler := &AcceleratorDiscoveryReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorDiscoveryTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
+
+	ctx := context.TODO()
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: node.Name}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	curr := &v1beta1.AcceleratorClass{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: discovered.Name}, curr)).To(Succeed())
+	g.Expect(curr.Spec.Family).To(Equal("hopper"))
+	g.Expect(curr.Spec.Capabilities.ComputeCapability).To(Equal("9.0"))
+	g.Expect(curr.Spec.Cost).NotTo(BeNil())
+	g.Expect(curr.Spec.Cost.PerHour.Cmp(perHour)).To(Equal(0))
+}
+
+func TestAcceleratorDiscovery_DiscoverNonNvidiaVendors(t *testing.T) {
+	g := NewWithT(t)
+
+	amdNode := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "amd-node",
+			Labels: map[string]string{
+				constants.AMDGPUProductLabelKey: "MI300X",
+				constants.AMDGPUFamilyLabelKey:  "AI",
+				constants.AMDGPUVRAMLabelKey:    "192G",
+			},
+		},
+	}
+	gaudiNode := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "gaudi-node",
+			Labels: map[string]string{constants.IntelGaudiProductLabelKey: "Gaudi3"},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceName(constants.IntelGaudiResourceType): resource.MustParse("8"),
+			},
+		},
+	}
+	tpuNode := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "tpu-node",
+			Labels: map[string]string{
+				constants.GoogleTPUAcceleratorLabelKey: "tpu-v5-lite-podslice",
+				constants.GoogleTPUTopologyLabelKey:    "2x4",
+			},
+		},
+	}
+
+	classes := discoverAcceleratorClasses([]corev1.Node{amdNode, gaudiNode, tpuNode})
+	g.Expect(classes).To(HaveLen(3))
+
+	amd := classes[0]
+	g.Expect(amd.Name).To(Equal("amd-mi300x"))
+	g.Expect(amd.Spec.Vendor).To(Equal("amd"))
+	g.Expect(amd.Spec.Family).To(Equal("ai"))
+	g.Expect(amd.Spec.Capabilities.MemoryGB.Cmp(resource.MustParse("192Gi"))).To(Equal(0))
+	g.Expect(amd.Spec.Resources[0].Name).To(Equal(constants.AMDGPUResourceType))
+
+	tpu := classes[1]
+	g.Expect(tpu.Name).To(Equal("google-tpu-v5-lite-podslice"))
+	g.Expect(tpu.Spec.Discovery.NodeSelector).To(HaveKeyWithValue(constants.GoogleTPUAcceleratorLabelKey, "tpu-v5-lite-podslice"))
+	g.Expect(tpu.Spec.Capabilities.Features).To(ConsistOf("topology-2x4"))
+	g.Expect(tpu.Spec.Resources[0].Name).To(Equal(constants.GoogleTPUResourceType))
+
+	gaudi := classes[2]
+	g.Expect(gaudi.Name).To(Equal("intel-gaudi3"))
+	g.Expect(gaudi.Spec.Family).To(Equal("gaudi"))
+	g.Expect(gaudi.Spec.Resources[0].Name).To(Equal(constants.IntelGaudiResourceType))
+}
@@ -0,0 +1,62 @@
+package acceleratorclass
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// Accelerator capacity states reported by the acceleratorsGauge
+const (
+	stateTotal       = "total"
+	stateAllocatable = "allocatable"
+	stateUsed        = "used"
+	stateAvailable   = "available"
+)
+
+var (
+	acceleratorsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ome_acceleratorclass_accelerators",
+		Help: "Number of accelerators of an AcceleratorClass by capacity state (total, allocatable, used, available)",
+	}, []string{"acceleratorclass", "state"})
+
+	migInstancesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ome_acceleratorclass_mig_instances",
+		Help: "Number of instances of a MIG profile of an AcceleratorClass by capacity state (total, allocatable, used, available)",
+	}, []string{"acceleratorclass", "profile", "state"})
+
+	nodesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ome_acceleratorclass_nodes",
+		Help: "Number of nodes that have the accelerators of an AcceleratorClass",
+	}, []string{"acceleratorclass"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(acceleratorsGauge, migInstancesGauge, nodesGauge)
+}
+
+// recordCapacityMetrics publishes the capacity reported in the status of an AcceleratorClass
+func recordCapacityMetrics(name string, status *v1beta1.AcceleratorClassStatus) {
+	acceleratorsGauge.WithLabelValues(name, stateTotal).Set(float64(status.TotalAccelerators))
+	acceleratorsGauge.WithLabelValues(name, stateAllocatable).Set(float64(status.AllocatableAccelerators))
+	acceleratorsGauge.WithLabelValues(name, stateUsed).Set(float64(status.UsedAccelerators))
+	acceleratorsGauge.WithLabelValues(name, stateAvailable).Set(float64(status.AvailableAccelerators))
+	nodesGauge.WithLabelValues(name).Set(float64(status.AvailableNodes))
+
+	// The profiles removed from the class are not reported anymore
+	migInstancesGauge.DeletePartialMatch(prometheus.Labels{"acceleratorclass": name})
+	for _, profile := range status.MIGProfiles {
+		migInstancesGauge.WithLabelValues(name, profile.Name, stateTotal).Set(float64(profile.TotalInstances))
+		migInstancesGauge.WithLabelValues(name, profile.Name, stateAllocatable).Set(float64(profile.AllocatableInstances))
+		migInstancesGauge.WithLabelValues(name, profile.Name, stateUsed).Set(float64(profile.UsedInstances))
+		migInstancesGauge.WithLabelValues(name, profile.Name, stateAvailable).Set(float64(profile.AvailableInstances))
+	}
+}
+
+// deleteCapacityMetrics removes the metrics of a deleted AcceleratorClass
+func deleteCapacityMetrics(name string) {
+	acceleratorsGauge.DeletePartialMatch(prometheus.Labels{"acceleratorclass": name})
+	migInstancesGauge.DeletePartialMatch(prometheus.Labels{"acceleratorclass": name})
+	nodesGauge.DeleteLabelValues(name)
+}
@@ -0,0 +1,192 @@
+package acceleratorclass
+
+import (
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// AcceleratorVendor describes how the accelerators of a vendor are published on nodes.
+// Vendors are consulted in registration order; the first vendor that discovers a class for a node wins.
+type AcceleratorVendor interface {
+	// Name is the vendor name used in AcceleratorClass names and spec.vendor
+	Name() string
+
+	// IsAcceleratorResource reports whether a node extended resource is one of the vendor's accelerators
+	IsAcceleratorResource(name corev1.ResourceName) bool
+
+	// DiscoverClass builds an AcceleratorClass from the labels and resources published on the node,
+	// or returns nil if the node has no accelerators of the vendor
+	DiscoverClass(node *corev1.Node) *v1beta1.AcceleratorClass
+}
+
+var vendors = []AcceleratorVendor{
+	nvidiaVendor{},
+	amdVendor{},
+	intelVendor{},
+	googleVendor{},
+}
+
+// RegisterAcceleratorVendor adds a vendor to accelerator discovery and capacity accounting.
+// It must be called before the AcceleratorClass controllers are started.
+func RegisterAcceleratorVendor(v AcceleratorVendor) {
+	vendors = append(vendors, v)
+}
+
+// newDiscoveredClass returns a discovered AcceleratorClass of the vendor for a product, selecting nodes by the
+// given label and requesting one accelerator of the given resource
+func newDiscoveredClass(vendor, product, resourceName string, nodeSelector map[string]string) *v1beta1.AcceleratorClass {
+	name := sanitizeName(product)
+	if !strings.HasPrefix(name, vendor+"-") {
+		name = vendor + "-" + name
+	}
+
+	return &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{constants.AcceleratorClassDiscoveredLabelKey: "true"},
+		},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Vendor: vendor,
+			Model:  strings.TrimPrefix(name, vendor+"-"),
+			Discovery: v1beta1.AcceleratorDiscovery{
+				NodeSelector: nodeSelector,
+			},
+			Resources: []v1beta1.AcceleratorResource{
+				{Name: resourceName, Quantity: resource.MustParse("1")},
+			},
+		},
+	}
+}
+
+// hasCapacity reports whether the node publishes a non-zero capacity of the resource
+func hasCapacity(node *corev1.Node, name corev1.ResourceName) bool {
+	q, ok := node.Status.Capacity[name]
+	return ok && !q.IsZero()
+}
+
+// nvidiaVendor discovers NVIDIA GPUs from the labels of the NVIDIA GPU operator
+type nvidiaVendor struct{}
+
+func (nvidiaVendor) Name() string { return "nvidia" }
+
+func (nvidiaVendor) IsAcceleratorResource(name corev1.ResourceName) bool {
+	// MIG profiles are treated as accelerators; they are not equivalent to card count
+	return string(name) == constants.NvidiaGPUResourceType || strings.HasPrefix(string(name), constants.NvidiaMIGResourcePrefix)
+}
+
+func (v nvidiaVendor) DiscoverClass(node *corev1.Node) *v1beta1.AcceleratorClass {
+	product := node.Labels[constants.NvidiaGPUProductLabelKey]
+	if product == "" {
+		return nil
+	}
+
+	ac := newDiscoveredClass(v.Name(), product, constants.NvidiaGPUResourceType,
+		map[string]string{constants.NvidiaGPUProductLabelKey: product})
+	ac.Spec.Family = strings.ToLower(node.Labels[constants.NvidiaGPUFamilyLabelKey])
+
+	// The GPU operator publishes the memory of a single GPU in MiB
+	if mib, err := strconv.ParseInt(node.Labels[constants.NvidiaGPUMemoryLabelKey], 10, 64); err == nil && mib > 0 {
+		ac.Spec.Capabilities.MemoryGB = resource.NewQuantity(mib*1024*1024, resource.BinarySI)
+	}
+
+	major := node.Labels[constants.NvidiaGPUComputeMajorLabelKey]
+	minor := node.Labels[constants.NvidiaGPUComputeMinorLabelKey]
+	if major != "" && minor != "" {
+		ac.Spec.Capabilities.ComputeCapability = major + "." + minor
+	}
+
+	if node.Labels[constants.NvidiaMIGCapableLabelKey] == "true" {
+		ac.Spec.Capabilities.Features = []string{"mig"}
+	}
+
+	return ac
+}
+
+// amdVendor discovers AMD ROCm GPUs from the labels of the AMD GPU operator node labeller
+type amdVendor struct{}
+
+func (amdVendor) Name() string { return "amd" }
+
+func (amdVendor) IsAcceleratorResource(name corev1.ResourceName) bool {
+	return string(name) == constants.AMDGPUResourceType
+}
+
+func (v amdVendor) DiscoverClass(node *corev1.Node) *v1beta1.AcceleratorClass {
+	product := node.Labels[constants.AMDGPUProductLabelKey]
+	if product == "" {
+		return nil
+	}
+
+	ac := newDiscoveredClass(v.Name(), product, constants.AMDGPUResourceType,
+		map[string]string{constants.AMDGPUProductLabelKey: product})
+	ac.Spec.Family = strings.ToLower(node.Labels[constants.AMDGPUFamilyLabelKey])
+
+	// The node labeller publishes the memory of a single GPU as a size such as "192G"
+	if vram := strings.TrimSuffix(node.Labels[constants.AMDGPUVRAMLabelKey], "B"); vram != "" {
+		if !strings.HasSuffix(vram, "i") {
+			vram += "i"
+		}
+		if q, err := resource.ParseQuantity(vram); err == nil && !q.IsZero() {
+			ac.Spec.Capabilities.MemoryGB = &q
+		}
+	}
+
+	return ac
+}
+
+// intelVendor discovers Intel Gaudi accelerators from the Gaudi resource and product label
+type intelVendor struct{}
+
+func (intelVendor) Name() string { return "intel" }
+
+func (intelVendor) IsAcceleratorResource(name corev1.ResourceName) bool {
+	n := string(name)
+	if n == constants.IntelGaudiResourceType {
+		return true
+	}
+	// The Intel GPU plugin exposes resources under gpu.intel.com/*; skip memory-only resources
+	return strings.HasPrefix(n, constants.IntelGPUResourcePrefix) && !strings.Contains(n, "memory")
+}
+
+func (v intelVendor) DiscoverClass(node *corev1.Node) *v1beta1.AcceleratorClass {
+	product := node.Labels[constants.IntelGaudiProductLabelKey]
+	if product == "" || !hasCapacity(node, constants.IntelGaudiResourceType) {
+		return nil
+	}
+
+	ac := newDiscoveredClass(v.Name(), product, constants.IntelGaudiResourceType,
+		map[string]string{constants.IntelGaudiProductLabelKey: product})
+	ac.Spec.Family = "gaudi"
+	return ac
+}
+
+// googleVendor discovers Cloud TPUs from the labels of GKE TPU node pools
+type googleVendor struct{}
+
+func (googleVendor) Name() string { return "google" }
+
+func (googleVendor) IsAcceleratorResource(name corev1.ResourceName) bool {
+	return string(name) == constants.GoogleTPUResourceType
+}
+
+func (v googleVendor) DiscoverClass(node *corev1.Node) *v1beta1.AcceleratorClass {
+	accelerator := node.Labels[constants.GoogleTPUAcceleratorLabelKey]
+	if accelerator == "" {
+		return nil
+	}
+
+	ac := newDiscoveredClass(v.Name(), accelerator, constants.GoogleTPUResourceType,
+		map[string]string{constants.GoogleTPUAcceleratorLabelKey: accelerator})
+	ac.Spec.Family = "tpu"
+	if topology := node.Labels[constants.GoogleTPUTopologyLabelKey]; topology != "" {
+		ac.Spec.Capabilities.Features = []string{"topology-" + topology}
+	}
+	return ac
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -12,18 +14,24 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/auditsink"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerhealth"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/finalizer"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/sharding"
 	"github.com/sgl-project/ome/pkg/modelagent"
+	"github.com/sgl-project/ome/pkg/tracing"
 )
 
 // +kubebuilder:rbac:groups=ome.io,resources=basemodels,verbs=get;list;watch;create;update;patch;delete
@@ -40,6 +48,18 @@ type BaseModelReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// Audit records the lifecycle transitions of the models, nothing if nil
+	Audit *auditsink.Recorder
+	// Shard is the partition of the models reconciled, all of them if zero
+	Shard sharding.Shard
+	// Recorder records an event on the models whose finalizer is forcibly removed, nothing if nil
+	Recorder record.EventRecorder
+	// FinalizerMaxWait is how long the deletion of a model waits for the model agents to clear it from the nodes
+	// before its finalizer is forcibly removed, forever if zero
+	FinalizerMaxWait time.Duration
+	// MetadataImage is the ome-agent image of the Jobs extracting the metadata of the models stored in PVCs, none
+	// launched if empty
+	MetadataImage string
 }
 
 // ClusterBaseModelReconciler reconciles ClusterBaseModel objects
@@ -47,6 +67,18 @@ type ClusterBaseModelReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// Audit records the lifecycle transitions of the models, nothing if nil
+	Audit *auditsink.Recorder
+	// Shard is the partition of the models reconciled, all of them if zero
+	Shard sharding.Shard
+	// Recorder records an event on the models whose finalizer is forcibly removed, nothing if nil
+	Recorder record.EventRecorder
+	// FinalizerMaxWait is how long the deletion of a model waits for the model agents to clear it from the nodes
+	// before its finalizer is forcibly removed, forever if zero
+	FinalizerMaxWait time.Duration
+	// MetadataImage is the ome-agent image of the Jobs extracting the metadata of the models stored in PVCs, none
+	// launched if empty
+	MetadataImage string
 }
 
 // Reconcile handles BaseModel reconciliation
@@ -66,20 +98,21 @@ func (r *BaseModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	log.Info("Reconciling BaseModel")
 
-	// Handle deletion
-	if !baseModel.DeletionTimestamp.IsZero() {
-		log.Info("Handling BaseModel deletion")
-		return r.handleDeletion(ctx, baseModel)
+	// Add the finalizer, or handle the deletion
+	outcome, result, err := modelFinalizer(r.Client, constants.BaseModelFinalizer, r.FinalizerMaxWait, r.Recorder).Finalize(ctx, r.Client, baseModel)
+	if err != nil {
+		log.Error(err, "Failed to finalize BaseModel")
+		return result, err
+	}
+	if outcome != finalizer.NotDeleting {
+		recordModelDeletion(r.Audit, baseModel, constants.BaseModel, constants.BaseModelFinalizer, outcome, r.FinalizerMaxWait)
+		return result, nil
 	}
 
-	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(baseModel, constants.BaseModelFinalizer) {
-		log.Info("Adding finalizer to BaseModel")
-		controllerutil.AddFinalizer(baseModel, constants.BaseModelFinalizer)
-		if err := r.Update(ctx, baseModel); err != nil {
-			log.Error(err, "Failed to add finalizer")
-			return ctrl.Result{}, err
-		}
+	// Extract the metadata of a model stored in a PVC, which the model agents don't download
+	if err := reconcileMetadataJob(ctx, r.Client, r.Scheme, r.MetadataImage, baseModel, &baseModel.Spec, false); err != nil {
+		log.Error(err, "Failed to launch the metadata Job of BaseModel")
+		return ctrl.Result{}, err
 	}
 
 	// Update status based on ConfigMaps
@@ -113,20 +146,21 @@ func (r *ClusterBaseModelReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	log.Info("Reconciling ClusterBaseModel")
 
-	// Handle deletion
-	if !clusterBaseModel.DeletionTimestamp.IsZero() {
-		log.Info("Handling ClusterBaseModel deletion")
-		return r.handleDeletion(ctx, clusterBaseModel)
+	// Add the finalizer, or handle the deletion
+	outcome, result, err := modelFinalizer(r.Client, constants.ClusterBaseModelFinalizer, r.FinalizerMaxWait, r.Recorder).Finalize(ctx, r.Client, clusterBaseModel)
+	if err != nil {
+		log.Error(err, "Failed to finalize ClusterBaseModel")
+		return result, err
+	}
+	if outcome != finalizer.NotDeleting {
+		recordModelDeletion(r.Audit, clusterBaseModel, constants.ClusterBaseModel, constants.ClusterBaseModelFinalizer, outcome, r.FinalizerMaxWait)
+		return result, nil
 	}
 
-	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(clusterBaseModel, constants.ClusterBaseModelFinalizer) {
-		log.Info("Adding finalizer to ClusterBaseModel")
-		controllerutil.AddFinalizer(clusterBaseModel, constants.ClusterBaseModelFinalizer)
-		if err := r.Update(ctx, clusterBaseModel); err != nil {
-			log.Error(err, "Failed to add finalizer")
-			return ctrl.Result{}, err
-		}
+	// Extract the metadata of a model stored in a PVC, which the model agents don't download
+	if err := reconcileMetadataJob(ctx, r.Client, r.Scheme, r.MetadataImage, clusterBaseModel, &clusterBaseModel.Spec, true); err != nil {
+		log.Error(err, "Failed to launch the metadata Job of ClusterBaseModel")
+		return ctrl.Result{}, err
 	}
 
 	// Update status based on ConfigMaps
@@ -143,108 +177,120 @@ func (r *ClusterBaseModelReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return ctrl.Result{}, nil
 }
 
-// handleDeletion handles BaseModel deletion
-func (r *BaseModelReconciler) handleDeletion(ctx context.Context, baseModel *v1beta1.BaseModel) (ctrl.Result, error) {
-	return handleModelDeletion(ctx, r.Client, baseModel, constants.BaseModelFinalizer)
-}
-
-// handleDeletion handles ClusterBaseModel deletion
-func (r *ClusterBaseModelReconciler) handleDeletion(ctx context.Context, clusterBaseModel *v1beta1.ClusterBaseModel) (ctrl.Result, error) {
-	return handleModelDeletion(ctx, r.Client, clusterBaseModel, constants.ClusterBaseModelFinalizer)
+// modelFinalizer returns the finalizer of the models, removed once the model agents cleared the model from the nodes
+func modelFinalizer(kubeClient client.Client, name string, maxWait time.Duration, recorder record.EventRecorder) *finalizer.Finalizer {
+	return &finalizer.Finalizer{
+		Name: name,
+		Steps: []finalizer.Step{{
+			Name: "ClearedFromNodes",
+			Cleanup: func(ctx context.Context, obj client.Object) (bool, error) {
+				return modelClearedFromNodes(ctx, kubeClient, obj)
+			},
+		}},
+		MaxWait:      maxWait,
+		RequeueAfter: 30 * time.Second,
+		Recorder:     recorder,
+	}
 }
 
-// handleModelDeletion is a shared utility function for handling model deletion
-func handleModelDeletion(ctx context.Context, kubeClient client.Client, obj client.Object, finalizer string) (ctrl.Result, error) {
+// modelClearedFromNodes reports whether every model agent cleared the model being deleted from its node, or marked
+// it as deleted
+func modelClearedFromNodes(ctx context.Context, kubeClient client.Client, obj client.Object) (bool, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	if controllerutil.ContainsFinalizer(obj, finalizer) {
-		// Before removing the finalizer, make sure all entries are cleared from ConfigMaps
-		// This prevents orphaned models when nodes are down or agents aren't running
+	// Make sure all entries are cleared from ConfigMaps before removing the finalizer
+	// This prevents orphaned models when nodes are down or agents aren't running
 
-		// Determine model name, namespace and if it's cluster-scoped
-		modelName := obj.GetName()
-		var modelNamespace string
-		var isClusterScope bool
+	// Determine model name, namespace and if it's cluster-scoped
+	modelName := obj.GetName()
+	var modelNamespace string
+	var isClusterScope bool
 
-		// Set namespace and scope based on object type
-		switch typedObj := obj.(type) {
-		case *v1beta1.BaseModel:
-			modelNamespace = typedObj.Namespace
-			isClusterScope = false
-		case *v1beta1.ClusterBaseModel:
-			modelNamespace = ""
-			isClusterScope = true
-		default:
-			log.Error(fmt.Errorf("unknown model type"), "Invalid model type for deletion handler")
-			return ctrl.Result{}, fmt.Errorf("unknown model type for deletion")
-		}
+	// Set namespace and scope based on object type
+	switch typedObj := obj.(type) {
+	case *v1beta1.BaseModel:
+		modelNamespace = typedObj.Namespace
+		isClusterScope = false
+	case *v1beta1.ClusterBaseModel:
+		modelNamespace = ""
+		isClusterScope = true
+	default:
+		return false, fmt.Errorf("unknown model type %T for deletion", obj)
+	}
 
-		// Get the model's ConfigMap key
-		modelKey := constants.GetModelConfigMapKey(modelNamespace, modelName, isClusterScope)
+	// Get the model's ConfigMap key
+	modelKey := constants.GetModelConfigMapKey(modelNamespace, modelName, isClusterScope)
 
-		// List all ConfigMaps with model status label in the ome namespace
-		configMaps := &corev1.ConfigMapList{}
-		listOpts := []client.ListOption{
-			client.InNamespace(constants.OMENamespace),
-			client.MatchingLabels{constants.ModelStatusConfigMapLabel: "true"},
-		}
+	// List all ConfigMaps with model status label in the ome namespace
+	configMaps := &corev1.ConfigMapList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(constants.OMENamespace),
+		client.MatchingLabels{constants.ModelStatusConfigMapLabel: "true"},
+	}
 
-		if err := kubeClient.List(ctx, configMaps, listOpts...); err != nil {
-			log.Error(err, "Failed to list ConfigMaps during model deletion")
-			return ctrl.Result{RequeueAfter: time.Second * 10}, err
-		}
+	if err := kubeClient.List(ctx, configMaps, listOpts...); err != nil {
+		log.Error(err, "Failed to list ConfigMaps during model deletion")
+		return false, err
+	}
 
-		// Check if any ConfigMap still has an entry for this model that is not marked as deleted
-		var modelsNotDeleted []string
-		nodesWithModel := 0
-
-		for _, configMap := range configMaps.Items {
-			// Check if the model exists in this ConfigMap
-			if data, exists := configMap.Data[modelKey]; exists {
-				nodesWithModel++
-
-				// Check if it's already marked for deletion
-				var modelEntry modelagent.ModelEntry
-				if err := json.Unmarshal([]byte(data), &modelEntry); err == nil {
-					// If model entry is present but not marked as deleted, add it to the list
-					if modelEntry.Status != modelagent.ModelStatusDeleted {
-						modelsNotDeleted = append(modelsNotDeleted, configMap.Name)
-					}
-				} else {
-					// Can't parse the entry, consider it not deleted for safety
+	// Check if any ConfigMap still has an entry for this model that is not marked as deleted
+	var modelsNotDeleted []string
+	nodesWithModel := 0
+
+	for _, configMap := range configMaps.Items {
+		// Check if the model exists in this ConfigMap
+		if data, exists := configMap.Data[modelKey]; exists {
+			nodesWithModel++
+
+			// Check if it's already marked for deletion
+			var modelEntry modelagent.ModelEntry
+			if err := json.Unmarshal([]byte(data), &modelEntry); err == nil {
+				// If model entry is present but not marked as deleted, add it to the list
+				if modelEntry.Status != modelagent.ModelStatusDeleted {
 					modelsNotDeleted = append(modelsNotDeleted, configMap.Name)
 				}
+			} else {
+				// Can't parse the entry, consider it not deleted for safety
+				modelsNotDeleted = append(modelsNotDeleted, configMap.Name)
 			}
 		}
+	}
 
-		modelInfo := modelName
-		if !isClusterScope {
-			modelInfo = modelNamespace + "/" + modelName
-		}
+	modelInfo := modelName
+	if !isClusterScope {
+		modelInfo = modelNamespace + "/" + modelName
+	}
 
-		// If models are still present in ConfigMaps and not deleted, requeue
-		if len(modelsNotDeleted) > 0 {
-			log.Info("Waiting for model to be cleared from ConfigMaps",
-				"model", modelInfo,
-				"nodesWithModel", nodesWithModel,
-				"nodesNotDeleted", len(modelsNotDeleted),
-				"nodes", modelsNotDeleted)
+	// If models are still present in ConfigMaps and not deleted, wait
+	if len(modelsNotDeleted) > 0 {
+		log.Info("Waiting for model to be cleared from ConfigMaps",
+			"model", modelInfo,
+			"nodesWithModel", nodesWithModel,
+			"nodesNotDeleted", len(modelsNotDeleted),
+			"nodes", modelsNotDeleted)
 
-			// Requeue to check again later
-			return ctrl.Result{RequeueAfter: time.Second * 30}, nil
-		}
+		// Checked again by the finalizer later
+		return false, nil
+	}
 
-		log.Info("All model entries have been cleared or marked as deleted", "model", modelInfo)
+	log.Info("All model entries have been cleared or marked as deleted", "model", modelInfo)
+	return true, nil
+}
 
-		// All entries are either cleared or marked for deletion, safe to remove finalizer
-		controllerutil.RemoveFinalizer(obj, finalizer)
-		if err := kubeClient.Update(ctx, obj); err != nil {
-			log.Error(err, "Failed to remove finalizer", "model", modelInfo)
-			return ctrl.Result{}, err
-		}
-		log.Info("Finalizer removed, deletion complete", "model", modelInfo)
+// recordModelDeletion records the audit event of the removal of the finalizer of the model
+func recordModelDeletion(audit *auditsink.Recorder, obj client.Object, modelKind, finalizerName string, outcome finalizer.Outcome, maxWait time.Duration) {
+	message := "Model cleared from all nodes, finalizer removed"
+	switch outcome {
+	case finalizer.Removed:
+	case finalizer.ForciblyRemoved:
+		message = fmt.Sprintf("Model not cleared from all nodes within %s, finalizer forcibly removed", maxWait)
+	default:
+		return
 	}
-	return ctrl.Result{}, nil
+	audit.RecordObject(obj, modelKind, auditsink.EventDeleted, strings.ToLower(modelKind), message, map[string]string{
+		"finalizer":         finalizerName,
+		"deletionRequested": obj.GetDeletionTimestamp().UTC().Format(time.RFC3339),
+	})
 }
 
 // updateModelStatus updates BaseModel status based on ConfigMap data
@@ -403,6 +449,9 @@ func updateModelSpecWithConfig(ctx context.Context, kubeClient client.Client, lo
 
 // SetupWithManager sets up the BaseModel controller with the Manager
 func (r *BaseModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Count the models of the shard at every scrape of the metrics endpoint
+	statesShard = r.Shard
+	baseModelStates.SetReader(mgr.GetClient())
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.BaseModel{}).
 		Watches(
@@ -419,11 +468,15 @@ func (r *BaseModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}),
 			builder.WithPredicates(createNodeDeletionPredicate()),
 		).
-		Complete(r)
+		Complete(sharding.Reconciler(r.Shard, tracing.Reconciler("basemodel", controllermetrics.Reconciler("basemodel",
+			controllerhealth.Reconciler("basemodel", &v1beta1.BaseModel{}, r)))))
 }
 
 // SetupWithManager sets up the ClusterBaseModel controller with the Manager
 func (r *ClusterBaseModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Count the models of the shard at every scrape of the metrics endpoint
+	statesShard = r.Shard
+	baseModelStates.SetReader(mgr.GetClient())
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.ClusterBaseModel{}).
 		Watches(
@@ -440,7 +493,8 @@ func (r *ClusterBaseModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}),
 			builder.WithPredicates(createNodeDeletionPredicate()),
 		).
-		Complete(r)
+		Complete(sharding.Reconciler(r.Shard, tracing.Reconciler("clusterbasemodel", controllermetrics.Reconciler("clusterbasemodel",
+			controllerhealth.Reconciler("clusterbasemodel", &v1beta1.ClusterBaseModel{}, r)))))
 }
 
 // createNodeDeletionPredicate creates a predicate that only triggers on Node deletions
@@ -624,12 +678,12 @@ func (r *ClusterBaseModelReconciler) updateModelSpecWithRetry(ctx context.Contex
 
 // updateStatusWithRetry updates ClusterBaseModel status with retry logic for resource conflicts
 func (r *ClusterBaseModelReconciler) updateStatusWithRetry(ctx context.Context, clusterBaseModel *v1beta1.ClusterBaseModel, nodesReady, nodesFailed []string) error {
-	return updateModelStatusWithRetry(ctx, r.Client, r.Log, clusterBaseModel, nodesReady, nodesFailed, "ClusterBaseModel")
+	return updateModelStatusWithRetry(ctx, r.Client, r.Log, r.Audit, clusterBaseModel, nodesReady, nodesFailed, "ClusterBaseModel")
 }
 
 // updateStatusWithRetry updates BaseModel status with retry logic for resource conflicts
 func (r *BaseModelReconciler) updateStatusWithRetry(ctx context.Context, baseModel *v1beta1.BaseModel, nodesReady, nodesFailed []string) error {
-	return updateModelStatusWithRetry(ctx, r.Client, r.Log, baseModel, nodesReady, nodesFailed, "BaseModel")
+	return updateModelStatusWithRetry(ctx, r.Client, r.Log, r.Audit, baseModel, nodesReady, nodesFailed, "BaseModel")
 }
 
 // updateModelSpecWithRetry updates BaseModel spec with retry logic for resource conflicts
@@ -672,7 +726,7 @@ func retryUpdate(ctx context.Context, kubeClient client.Client, log logr.Logger,
 }
 
 // updateModelStatusWithRetry is a shared utility function for updating model status with retry logic
-func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, log logr.Logger, obj client.Object, nodesReady, nodesFailed []string, modelType string) error {
+func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, log logr.Logger, audit *auditsink.Recorder, obj client.Object, nodesReady, nodesFailed []string, modelType string) error {
 	updateFunc := func(ctx context.Context, client client.Client, obj client.Object) error {
 		// Get current status and update it
 		var currentNodesReady, currentNodesFailed []string
@@ -728,6 +782,9 @@ func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, l
 				"nodesReady", len(nodesReady),
 				"nodesFailed", len(nodesFailed),
 				"state", newState)
+			if currentState != newState {
+				recordLifecycleTransition(audit, obj, modelType, currentState, newState, len(nodesReady), len(nodesFailed))
+			}
 		}
 		return nil
 	}
@@ -735,6 +792,25 @@ func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, l
 	return retryUpdate(ctx, kubeClient, log, obj, "status", updateFunc)
 }
 
+// recordLifecycleTransition records the audit event of a model that became ready or failed
+func recordLifecycleTransition(audit *auditsink.Recorder, obj client.Object, modelType string, from, to v1beta1.LifeCycleState, nodesReady, nodesFailed int) {
+	var eventType auditsink.EventType
+	switch to {
+	case v1beta1.LifeCycleStateReady:
+		eventType = auditsink.EventReady
+	case v1beta1.LifeCycleStateFailed:
+		eventType = auditsink.EventFailed
+	default:
+		return
+	}
+	audit.RecordObject(obj, modelType, eventType, strings.ToLower(modelType),
+		fmt.Sprintf("%s state changed from %s to %s", modelType, from, to), map[string]string{
+			"previousState": string(from),
+			"nodesReady":    strconv.Itoa(nodesReady),
+			"nodesFailed":   strconv.Itoa(nodesFailed),
+		})
+}
+
 // retrySpecUpdate is a shared utility function for retrying spec updates with conflict resolution
 func retrySpecUpdate(ctx context.Context, kubeClient client.Client, log logr.Logger, obj client.Object, config *modelagent.ModelConfig, updateFunc func(context.Context, client.Client, client.Object, *modelagent.ModelConfig) error) error {
 	wrappedUpdateFunc := func(ctx context.Context, client client.Client, obj client.Object) error {
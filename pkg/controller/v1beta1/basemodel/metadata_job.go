@@ -0,0 +1,201 @@
+package basemodel
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create
+
+const (
+	// metadataServiceAccountName is the service account of the metadata Jobs, and the name of the ClusterRole
+	// allowing it to patch the models (see config/model-metadata)
+	metadataServiceAccountName = "ome-model-metadata"
+	// metadataModelPath is where the metadata Jobs mount the model
+	metadataModelPath = "/model"
+	// metadataJobTTL is how long a finished metadata Job is kept. A Job that failed to extract the metadata is
+	// created again once deleted.
+	metadataJobTTL int32 = 3600
+	// metadataJobBackoffLimit is the number of retries of a metadata Job
+	metadataJobBackoffLimit int32 = 2
+)
+
+// reconcileMetadataJob launches the Job extracting the metadata of a model stored in a PVC, unless the metadata was
+// extracted from the current storage URI of the model. The Job patches the metadata directly onto the model, and the
+// patch triggers the reconciliation of the model, so the Job itself isn't watched. The Job of a previous storage URI
+// is replaced.
+func reconcileMetadataJob(ctx context.Context, kubeClient client.Client, scheme *runtime.Scheme, image string,
+	obj client.Object, spec *v1beta1.BaseModelSpec, clusterScoped bool) error {
+	if image == "" || spec.Storage == nil || spec.Storage.StorageUri == nil {
+		return nil
+	}
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[constants.ModelMetadataExtractedAtAnnotationKey]; ok &&
+		annotations[constants.ModelMetadataStorageURIAnnotationKey] == *spec.Storage.StorageUri {
+		return nil
+	}
+	storageType, err := storage.GetStorageType(*spec.Storage.StorageUri)
+	if err != nil || storageType != storage.StorageTypePVC {
+		return nil
+	}
+	components, err := storage.ParsePVCStorageURI(*spec.Storage.StorageUri)
+	if err != nil {
+		return fmt.Errorf("failed to parse the PVC storage URI: %w", err)
+	}
+
+	// The Job runs in the namespace of the PVC, that of the BaseModel or the one of the URI of a ClusterBaseModel
+	namespace := obj.GetNamespace()
+	if clusterScoped {
+		namespace = components.Namespace
+		if namespace == "" {
+			namespace = constants.OMENamespace
+		}
+	}
+	if err := reconcileMetadataServiceAccount(ctx, kubeClient, namespace); err != nil {
+		return err
+	}
+
+	job := metadataJob(image, obj, spec, components, namespace, clusterScoped)
+	if err := controllerutil.SetControllerReference(obj, job, scheme); err != nil {
+		return fmt.Errorf("failed to set the owner of the metadata Job: %w", err)
+	}
+	existing := &batchv1.Job{}
+	err = kubeClient.Get(ctx, client.ObjectKeyFromObject(job), existing)
+	switch {
+	case err == nil && existing.Annotations[constants.ModelMetadataStorageURIAnnotationKey] == *spec.Storage.StorageUri:
+		return nil
+	case err == nil:
+		if err := kubeClient.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the metadata Job %s/%s of the previous storage URI: %w", job.Namespace, job.Name, err)
+		}
+	case !errors.IsNotFound(err):
+		return fmt.Errorf("failed to get the metadata Job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	if err := kubeClient.Create(ctx, job); err != nil {
+		// The Job of the previous storage URI may not be deleted yet, its creation is retried
+		return fmt.Errorf("failed to create the metadata Job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	return nil
+}
+
+// reconcileMetadataServiceAccount creates the service account of the metadata Jobs of a namespace, and binds it to
+// the ClusterRole allowing it to patch the models. The binding of the namespace of OME is deployed with OME.
+func reconcileMetadataServiceAccount(ctx context.Context, kubeClient client.Client, namespace string) error {
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metadataServiceAccountName,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/component": metadataServiceAccountName},
+		},
+	}
+	if err := createIfNotFound(ctx, kubeClient, serviceAccount); err != nil {
+		return fmt.Errorf("failed to create the service account %s/%s: %w", namespace, metadataServiceAccountName, err)
+	}
+	if namespace == constants.OMENamespace {
+		return nil
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   constants.TruncateNameWithMaxLength(metadataServiceAccountName+"-"+namespace, 63),
+			Labels: map[string]string{"app.kubernetes.io/component": metadataServiceAccountName},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     metadataServiceAccountName,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      metadataServiceAccountName,
+			Namespace: namespace,
+		}},
+	}
+	if err := createIfNotFound(ctx, kubeClient, binding); err != nil {
+		return fmt.Errorf("failed to create the ClusterRoleBinding %s: %w", binding.Name, err)
+	}
+	return nil
+}
+
+// createIfNotFound creates an object unless it exists
+func createIfNotFound(ctx context.Context, kubeClient client.Client, obj client.Object) error {
+	existing := obj.DeepCopyObject().(client.Object)
+	err := kubeClient.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	if err := kubeClient.Create(ctx, obj); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// metadataJob returns the Job running the model-metadata agent on the PVC of a model, annotated with the storage URI
+// of the model
+func metadataJob(image string, obj client.Object, spec *v1beta1.BaseModelSpec, components *storage.PVCStorageComponents, namespace string, clusterScoped bool) *batchv1.Job {
+	name := constants.TruncateNameWithMaxLength(obj.GetName()+"-model-metadata", 63)
+	args := []string{"model-metadata", "--model-path", metadataModelPath, "--basemodel-name", obj.GetName()}
+	if clusterScoped {
+		name = constants.TruncateNameWithMaxLength(obj.GetName()+"-cluster-model-metadata", 63)
+		args = append(args, "--cluster-scoped")
+	} else {
+		args = append(args, "--basemodel-namespace", obj.GetNamespace())
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/component": metadataServiceAccountName},
+			Annotations: map[string]string{
+				constants.ModelMetadataStorageURIAnnotationKey: *spec.Storage.StorageUri,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To(metadataJobBackoffLimit),
+			TTLSecondsAfterFinished: ptr.To(metadataJobTTL),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: metadataServiceAccountName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:  constants.AgentName,
+						Image: image,
+						Args:  args,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "model",
+							MountPath: metadataModelPath,
+							SubPath:   components.SubPath,
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "model",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: components.PVCName,
+								ReadOnly:  true,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
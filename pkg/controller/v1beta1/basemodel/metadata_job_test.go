@@ -0,0 +1,97 @@
+package basemodel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+func TestReconcileMetadataJob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(batchv1.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(rbacv1.AddToScheme(scheme)).To(gomega.Succeed())
+	ctx := context.Background()
+
+	baseModel := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "models", UID: "uid"},
+		Spec:       v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: stringPtr("pvc://weights/llama-3")}},
+	}
+	c := ctrlclientfake.NewClientBuilder().WithScheme(scheme).WithObjects(baseModel).Build()
+
+	// No Job is launched without an image
+	g.Expect(reconcileMetadataJob(ctx, c, scheme, "", baseModel, &baseModel.Spec, false)).To(gomega.Succeed())
+	jobs := &batchv1.JobList{}
+	g.Expect(c.List(ctx, jobs)).To(gomega.Succeed())
+	g.Expect(jobs.Items).To(gomega.BeEmpty())
+
+	g.Expect(reconcileMetadataJob(ctx, c, scheme, "ome-agent:v1", baseModel, &baseModel.Spec, false)).To(gomega.Succeed())
+	job := &batchv1.Job{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "models", Name: "llama-model-metadata"}, job)).To(gomega.Succeed())
+	pod := job.Spec.Template.Spec
+	g.Expect(pod.ServiceAccountName).To(gomega.Equal(metadataServiceAccountName))
+	g.Expect(pod.Containers[0].Args).To(gomega.Equal([]string{"model-metadata", "--model-path", "/model",
+		"--basemodel-name", "llama", "--basemodel-namespace", "models"}))
+	g.Expect(pod.Containers[0].VolumeMounts[0].SubPath).To(gomega.Equal("llama-3"))
+	g.Expect(pod.Volumes[0].PersistentVolumeClaim.ClaimName).To(gomega.Equal("weights"))
+	g.Expect(job.OwnerReferences[0].Name).To(gomega.Equal("llama"))
+	// The service account is allowed to patch the models
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "models", Name: metadataServiceAccountName}, &corev1.ServiceAccount{})).To(gomega.Succeed())
+	binding := &rbacv1.ClusterRoleBinding{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: "ome-model-metadata-models"}, binding)).To(gomega.Succeed())
+	g.Expect(binding.RoleRef.Name).To(gomega.Equal(metadataServiceAccountName))
+	g.Expect(binding.Subjects[0].Namespace).To(gomega.Equal("models"))
+
+	g.Expect(job.Annotations[constants.ModelMetadataStorageURIAnnotationKey]).To(gomega.Equal("pvc://weights/llama-3"))
+
+	// No Job is launched once the metadata is extracted from the storage URI
+	g.Expect(c.Delete(ctx, job)).To(gomega.Succeed())
+	baseModel.Annotations = map[string]string{
+		constants.ModelMetadataExtractedAtAnnotationKey: "2026-01-02T03:04:05Z",
+		constants.ModelMetadataStorageURIAnnotationKey:  "pvc://weights/llama-3",
+	}
+	g.Expect(reconcileMetadataJob(ctx, c, scheme, "ome-agent:v1", baseModel, &baseModel.Spec, false)).To(gomega.Succeed())
+	g.Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}))).To(gomega.BeTrue())
+
+	// The metadata is extracted again when the storage URI changes, replacing the Job of the previous one
+	previous := metadataJob("ome-agent:v1", baseModel, &baseModel.Spec, &storage.PVCStorageComponents{PVCName: "weights", SubPath: "llama-3"}, "models", false)
+	g.Expect(c.Create(ctx, previous)).To(gomega.Succeed())
+	baseModel.Spec.Storage.StorageUri = stringPtr("pvc://weights/llama-3.1")
+	g.Expect(reconcileMetadataJob(ctx, c, scheme, "ome-agent:v1", baseModel, &baseModel.Spec, false)).To(gomega.Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(previous), job)).To(gomega.Succeed())
+	g.Expect(job.Annotations[constants.ModelMetadataStorageURIAnnotationKey]).To(gomega.Equal("pvc://weights/llama-3.1"))
+	g.Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts[0].SubPath).To(gomega.Equal("llama-3.1"))
+
+	// The Job of a ClusterBaseModel runs in the namespace of its PVC
+	clusterBaseModel := &v1beta1.ClusterBaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", UID: "uid"},
+		Spec:       v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: stringPtr("pvc://shared:weights/llama-3")}},
+	}
+	g.Expect(reconcileMetadataJob(ctx, c, scheme, "ome-agent:v1", clusterBaseModel, &clusterBaseModel.Spec, true)).To(gomega.Succeed())
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "shared", Name: "llama-cluster-model-metadata"}, job)).To(gomega.Succeed())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(gomega.ContainElement("--cluster-scoped"))
+
+	// Models not stored in a PVC are left to the model agents
+	ociModel := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "oci", Namespace: "models"},
+		Spec:       v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: stringPtr("oci://n/ns/b/bucket/o/model")}},
+	}
+	g.Expect(reconcileMetadataJob(ctx, c, scheme, "ome-agent:v1", ociModel, &ociModel.Spec, false)).To(gomega.Succeed())
+	g.Expect(errors.IsNotFound(c.Get(ctx, types.NamespacedName{Namespace: "models", Name: "oci-model-metadata"}, &batchv1.Job{}))).To(gomega.BeTrue())
+}
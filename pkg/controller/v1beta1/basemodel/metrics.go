@@ -0,0 +1,67 @@
+package basemodel
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/sharding"
+)
+
+// stateUnknown is the state of the models whose status has no state yet
+const stateUnknown = "Unknown"
+
+var (
+	// baseModelStates reads the BaseModels and ClusterBaseModels from the cache of the manager once either controller
+	// is set up
+	baseModelStates = controllermetrics.NewStateCollector(
+		"ome_basemodel_states",
+		"Number of BaseModels and ClusterBaseModels by kind, namespace and lifecycle state (Creating, Importing, In_Transit, In_Training, Ready, Failed)",
+		[]string{"kind", "namespace", "state"},
+		countBaseModelStates,
+	)
+	// statesShard is the shard of the models counted, set with the reader. Every manager caches all the models, since
+	// it reads those of its InferenceServices.
+	statesShard sharding.Shard
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(baseModelStates)
+}
+
+// countBaseModelStates counts the BaseModels and ClusterBaseModels by lifecycle state
+func countBaseModelStates(ctx context.Context, reader client.Reader, counts *controllermetrics.StateCounts) error {
+	baseModels := &v1beta1.BaseModelList{}
+	if err := reader.List(ctx, baseModels); err != nil {
+		return err
+	}
+	for _, model := range baseModels.Items {
+		if !statesShard.Owns(types.NamespacedName{Namespace: model.Namespace, Name: model.Name}) {
+			continue
+		}
+		counts.Add("BaseModel", model.Namespace, lifeCycleState(model.Status))
+	}
+
+	clusterBaseModels := &v1beta1.ClusterBaseModelList{}
+	if err := reader.List(ctx, clusterBaseModels); err != nil {
+		return err
+	}
+	for _, model := range clusterBaseModels.Items {
+		if !statesShard.Owns(types.NamespacedName{Name: model.Name}) {
+			continue
+		}
+		counts.Add("ClusterBaseModel", "", lifeCycleState(model.Status))
+	}
+	return nil
+}
+
+func lifeCycleState(status v1beta1.ModelStatusSpec) string {
+	if status.State == "" {
+		return stateUnknown
+	}
+	return string(status.State)
+}
@@ -0,0 +1,60 @@
+package basemodel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/sharding"
+)
+
+func TestBaseModelStates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1beta1.BaseModel{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "serving"},
+			Status:     v1beta1.ModelStatusSpec{State: v1beta1.LifeCycleStateReady},
+		},
+		&v1beta1.BaseModel{
+			ObjectMeta: metav1.ObjectMeta{Name: "mistral", Namespace: "serving"},
+			Status:     v1beta1.ModelStatusSpec{State: v1beta1.LifeCycleStateReady},
+		},
+		&v1beta1.BaseModel{ObjectMeta: metav1.ObjectMeta{Name: "qwen", Namespace: "serving"}},
+		&v1beta1.ClusterBaseModel{
+			ObjectMeta: metav1.ObjectMeta{Name: "deepseek"},
+			Status:     v1beta1.ModelStatusSpec{State: v1beta1.LifeCycleStateFailed},
+		},
+	).Build()
+
+	collector := controllermetrics.NewStateCollector("test_basemodel_states", "test",
+		[]string{"kind", "namespace", "state"}, countBaseModelStates)
+	collector.SetReader(c)
+	g.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP test_basemodel_states test
+# TYPE test_basemodel_states gauge
+test_basemodel_states{kind="BaseModel",namespace="serving",state="Ready"} 2
+test_basemodel_states{kind="BaseModel",namespace="serving",state="Unknown"} 1
+test_basemodel_states{kind="ClusterBaseModel",namespace="",state="Failed"} 1
+`))).To(gomega.Succeed())
+
+	// A sharded manager counts the models of its own shard only
+	serving := sharding.Of(types.NamespacedName{Namespace: "serving"}, 2, sharding.KeyNamespace)
+	statesShard = sharding.Shard{Count: 2, Index: 1 - serving, Key: sharding.KeyNamespace}
+	defer func() { statesShard = sharding.Shard{} }()
+	expected := 0
+	if statesShard.Owns(types.NamespacedName{Name: "deepseek"}) {
+		expected = 1
+	}
+	g.Expect(testutil.CollectAndCount(collector)).To(gomega.Equal(expected))
+}
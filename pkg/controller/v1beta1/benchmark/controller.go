@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
@@ -27,7 +28,10 @@ import (
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/benchmark/reconcilers/job"
 	benchmarkutils "github.com/sgl-project/ome/pkg/controller/v1beta1/benchmark/utils"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerhealth"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/tracing"
 	"github.com/sgl-project/ome/pkg/utils/storage"
 )
 
@@ -37,7 +41,10 @@ const (
 	// Container and volume names
 	benchmarkCommand        = "genai-bench"
 	benchmarkSubcommand     = "benchmark"
+	omeAgentConfigPath      = "/ome-agent.yaml"
 	outputStorageVolumeName = "benchmark-output-storage"
+	datasetVolumeName       = "benchmark-dataset-storage"
+	candidateContainerName  = "candidate"
 
 	// Environment variable names
 	envEnableUI          = "ENABLE_UI"
@@ -53,6 +60,9 @@ const (
 	requeueAfterNotReady = time.Minute
 )
 
+// metricsReportTruncatedDetails explains the iterations missing from the metrics in status
+const metricsReportTruncatedDetails = "The metrics report exceeded the termination message limit, see the summary of the results for the metrics of every iteration"
+
 // +kubebuilder:rbac:groups=ome.io,resources=benchmarkjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ome.io,resources=benchmarkjobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ome.io,resources=benchmarkjobs/finalizers,verbs=get;update;patch
@@ -71,6 +81,16 @@ type BenchmarkJobReconciler struct {
 	Log       logr.Logger
 	Scheme    *runtime.Scheme
 	Recorder  record.EventRecorder
+	// InferenceServiceReader reads the InferenceServices the jobs target, the Client if nil
+	InferenceServiceReader client.Reader
+}
+
+// inferenceServiceReader returns the reader of the InferenceServices the jobs target
+func (r *BenchmarkJobReconciler) inferenceServiceReader() client.Reader {
+	if r.InferenceServiceReader != nil {
+		return r.InferenceServiceReader
+	}
+	return r.Client
 }
 
 // Reconcile is the entry point for the reconciliation logic.
@@ -108,8 +128,20 @@ func (r *BenchmarkJobReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	config, err := controllerconfig.NewBenchmarkJobConfig(r.Clientset)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Reject BenchmarkJobs the benchmark image can't run, e.g. comparative benchmarks without the metrics
+	// report they compare, before waiting for their endpoints
+	if _, _, err := benchmarkRunnerCommand(benchmarkJob, config); err != nil {
+		r.Recorder.Eventf(benchmarkJob, v1.EventTypeWarning, "UnsupportedBenchmark", err.Error())
+		return ctrl.Result{}, err
+	}
+
 	if benchmarkJob.Spec.Endpoint.InferenceService != nil {
-		isvc, err := benchmarkutils.GetInferenceService(ctx, r.Client, benchmarkJob.Spec.Endpoint.InferenceService)
+		isvc, err := benchmarkutils.GetInferenceService(ctx, r.inferenceServiceReader(), benchmarkJob.Spec.Endpoint.InferenceService)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
@@ -119,12 +151,18 @@ func (r *BenchmarkJobReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
-	// Build config and pod spec
-	config, err := controllerconfig.NewBenchmarkJobConfig(r.Clientset)
-	if err != nil {
-		return ctrl.Result{}, err
+	if comparison := benchmarkJob.Spec.Comparison; comparison != nil && comparison.Candidate.InferenceService != nil {
+		isvc, err := benchmarkutils.GetInferenceService(ctx, r.inferenceServiceReader(), comparison.Candidate.InferenceService)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !isvc.Status.IsReady() {
+			log.Info("Candidate InferenceService is not ready, re-queuing")
+			return ctrl.Result{RequeueAfter: requeueAfterNotReady}, nil
+		}
 	}
 
+	// Build pod spec
 	podSpec, err := r.createPodSpec(ctx, benchmarkJob, config)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -215,13 +253,25 @@ func (r *BenchmarkJobReconciler) createPodSpec(ctx context.Context, benchmarkJob
 
 	podSpec := r.buildBasePodSpec(container, volumes)
 
+	// Benchmark the candidate endpoint first in an init container of the same pod
+	if benchmarkJob.Spec.Comparison != nil {
+		if err := r.addCandidateContainer(ctx, benchmarkJob, benchmarkConfig, podSpec); err != nil {
+			return nil, err
+		}
+	}
+
 	// Add node selector for InferenceService base model if specified
 	if benchmarkJob.Spec.Endpoint.InferenceService != nil {
-		if err := r.addNodeSelectorFromInferenceService(ctx, benchmarkJob, podSpec); err != nil {
+		if err := r.addNodeSelectorFromInferenceService(ctx, benchmarkJob, benchmarkJob.Spec.Endpoint.InferenceService, podSpec); err != nil {
 			r.Log.Error(err, "Failed to add node selector from InferenceService, continuing without it")
 			// Don't fail the whole reconciliation, just log the error
 		}
 	}
+	if comparison := benchmarkJob.Spec.Comparison; comparison != nil && comparison.Candidate.InferenceService != nil {
+		if err := r.addNodeSelectorFromInferenceService(ctx, benchmarkJob, comparison.Candidate.InferenceService, podSpec); err != nil {
+			r.Log.Error(err, "Failed to add node selector from candidate InferenceService, continuing without it")
+		}
+	}
 
 	if benchmarkJob.Spec.PodOverride != nil {
 		return r.applyPodOverrides(podSpec, benchmarkJob.Spec.PodOverride)
@@ -229,10 +279,49 @@ func (r *BenchmarkJobReconciler) createPodSpec(ctx context.Context, benchmarkJob
 	return podSpec, nil
 }
 
+// addCandidateContainer adds an init container that runs the same benchmark against the candidate endpoint
+// of a comparative benchmark, storing its results next to the baseline results.
+func (r *BenchmarkJobReconciler) addCandidateContainer(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, config *controllerconfig.BenchmarkJobConfig, podSpec *v1.PodSpec) error {
+	candidate := benchmarkJob.Spec.Comparison.Candidate
+	container, err := r.buildContainer(ctx, benchmarkJob, config, candidateContainerName, candidate, candidateFolderName(benchmarkJob))
+	if err != nil {
+		return err
+	}
+
+	// Share the output and dataset volumes with the baseline container
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if mount.Name == outputStorageVolumeName || mount.Name == datasetVolumeName {
+			container.VolumeMounts = append(container.VolumeMounts, mount)
+		}
+	}
+
+	if candidate.InferenceService != nil {
+		vol, err := r.buildInferenceServiceVolume(ctx, candidate.InferenceService, container)
+		if err != nil {
+			return err
+		}
+		if vol != nil && !hasVolume(podSpec.Volumes, vol.Name) {
+			podSpec.Volumes = append(podSpec.Volumes, *vol)
+		}
+	}
+
+	podSpec.InitContainers = append(podSpec.InitContainers, *container)
+	return nil
+}
+
+// hasVolume reports whether a volume with the given name is already present.
+func hasVolume(volumes []v1.Volume, name string) bool {
+	for _, vol := range volumes {
+		if vol.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // addNodeSelectorFromInferenceService adds node affinity based on the InferenceService's base model
-func (r *BenchmarkJobReconciler) addNodeSelectorFromInferenceService(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, podSpec *v1.PodSpec) error {
-	ref := benchmarkJob.Spec.Endpoint.InferenceService
-	inferenceService, err := benchmarkutils.GetInferenceService(ctx, r.Client, ref)
+func (r *BenchmarkJobReconciler) addNodeSelectorFromInferenceService(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, ref *v1beta1.InferenceServiceReference, podSpec *v1.PodSpec) error {
+	inferenceService, err := benchmarkutils.GetInferenceService(ctx, r.inferenceServiceReader(), ref)
 	if err != nil {
 		return err
 	}
@@ -258,6 +347,11 @@ func (r *BenchmarkJobReconciler) addNodeSelectorFromInferenceService(ctx context
 
 // buildDefaultContainer creates the default benchmark container with resources and env vars
 func (r *BenchmarkJobReconciler) buildDefaultContainer(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, config *controllerconfig.BenchmarkJobConfig) (*v1.Container, error) {
+	return r.buildContainer(ctx, benchmarkJob, config, benchmarkJob.Name, benchmarkJob.Spec.Endpoint, resultFolderName(benchmarkJob))
+}
+
+// buildContainer creates a benchmark container that benchmarks the given endpoint and stores its results in folderName
+func (r *BenchmarkJobReconciler) buildContainer(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, config *controllerconfig.BenchmarkJobConfig, name string, endpoint v1beta1.EndpointSpec, folderName string) (*v1.Container, error) {
 	resources := v1.ResourceRequirements{
 		Requests: v1.ResourceList{
 			v1.ResourceCPU:    resource.MustParse(config.PodConfig.CPURequest),
@@ -282,13 +376,20 @@ func (r *BenchmarkJobReconciler) buildDefaultContainer(ctx context.Context, benc
 		})
 	}
 
-	cmd, args, err := r.buildBenchmarkCommand(ctx, benchmarkJob)
+	// Expose the dataset storage credentials of the referenced Secret
+	datasetEnv, err := benchmarkutils.BuildDatasetCredentialEnv(benchmarkJob.Spec.Dataset)
+	if err != nil {
+		return nil, err
+	}
+	env = append(env, datasetEnv...)
+
+	cmd, args, err := r.buildBenchmarkCommand(ctx, benchmarkJob, config, endpoint, folderName)
 	if err != nil {
 		return nil, err
 	}
 
 	return &v1.Container{
-		Name:      benchmarkJob.Name,
+		Name:      name,
 		Image:     config.PodConfig.Image,
 		Resources: resources,
 		Env:       env,
@@ -303,7 +404,7 @@ func (r *BenchmarkJobReconciler) buildVolumes(ctx context.Context, benchmarkJob
 
 	// Add InferenceService model volume if specified
 	if benchmarkJob.Spec.Endpoint.InferenceService != nil {
-		vol, err := r.buildInferenceServiceVolume(ctx, benchmarkJob, container)
+		vol, err := r.buildInferenceServiceVolume(ctx, benchmarkJob.Spec.Endpoint.InferenceService, container)
 		if err != nil {
 			return nil, err
 		}
@@ -322,13 +423,57 @@ func (r *BenchmarkJobReconciler) buildVolumes(ctx context.Context, benchmarkJob
 		container.VolumeMounts = append(container.VolumeMounts, *pvcMount)
 	}
 
+	// Add dataset volume if the dataset is stored on a PVC
+	datasetVol, datasetMount, err := r.buildDatasetVolume(ctx, benchmarkJob)
+	if err != nil {
+		return nil, err
+	}
+	if datasetVol != nil {
+		volumes = append(volumes, *datasetVol)
+		container.VolumeMounts = append(container.VolumeMounts, *datasetMount)
+	}
+
 	return volumes, nil
 }
 
+// buildDatasetVolume creates a read-only volume and mount for a PVC-backed dataset
+func (r *BenchmarkJobReconciler) buildDatasetVolume(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob) (*v1.Volume, *v1.VolumeMount, error) {
+	components, err := benchmarkutils.GetDatasetPVC(benchmarkJob.Spec.Dataset)
+	if err != nil || components == nil {
+		return nil, nil, err
+	}
+
+	// Verify PVC exists
+	pvc := &v1.PersistentVolumeClaim{}
+	if err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      components.PVCName,
+		Namespace: benchmarkJob.Namespace,
+	}, pvc); err != nil {
+		return nil, nil, fmt.Errorf("dataset PVC %s not found: %w", components.PVCName, err)
+	}
+
+	volume := &v1.Volume{
+		Name: datasetVolumeName,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				ClaimName: components.PVCName,
+				ReadOnly:  true,
+			},
+		},
+	}
+
+	mount := &v1.VolumeMount{
+		Name:      datasetVolumeName,
+		MountPath: benchmarkutils.DatasetMountPath,
+		ReadOnly:  true,
+	}
+
+	return volume, mount, nil
+}
+
 // buildInferenceServiceVolume creates volume for the base model from InferenceService
-func (r *BenchmarkJobReconciler) buildInferenceServiceVolume(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, container *v1.Container) (*v1.Volume, error) {
-	ref := benchmarkJob.Spec.Endpoint.InferenceService
-	inferenceService, err := benchmarkutils.GetInferenceService(ctx, r.Client, ref)
+func (r *BenchmarkJobReconciler) buildInferenceServiceVolume(ctx context.Context, ref *v1beta1.InferenceServiceReference, container *v1.Container) (*v1.Volume, error) {
+	inferenceService, err := benchmarkutils.GetInferenceService(ctx, r.inferenceServiceReader(), ref)
 	if err != nil {
 		return nil, err
 	}
@@ -417,14 +562,26 @@ func (r *BenchmarkJobReconciler) applyPodOverrides(podSpec *v1.PodSpec, override
 		return nil, err
 	}
 
+	// Merge init containers, keeping their names distinct from the main container
+	var initContainers []v1.Container
+	for i := range podSpec.InitContainers {
+		merged, err := r.mergeContainer(&podSpec.InitContainers[i], override)
+		if err != nil {
+			return nil, err
+		}
+		merged.Name = podSpec.InitContainers[i].Name
+		initContainers = append(initContainers, *merged)
+	}
+
 	// Create pod spec with merged container
 	basePodSpec := &v1.PodSpec{
-		Containers:    []v1.Container{*mergedContainer},
-		Volumes:       podSpec.Volumes,
-		Tolerations:   podSpec.Tolerations,
-		Affinity:      podSpec.Affinity,
-		NodeSelector:  podSpec.NodeSelector,
-		RestartPolicy: v1.RestartPolicyNever,
+		InitContainers: initContainers,
+		Containers:     []v1.Container{*mergedContainer},
+		Volumes:        podSpec.Volumes,
+		Tolerations:    podSpec.Tolerations,
+		Affinity:       podSpec.Affinity,
+		NodeSelector:   podSpec.NodeSelector,
+		RestartPolicy:  v1.RestartPolicyNever,
 	}
 
 	// Merge pod-level overrides
@@ -490,29 +647,33 @@ func (r *BenchmarkJobReconciler) mergePodSpec(base *v1.PodSpec, override *v1beta
 		return nil, err
 	}
 
-	// Preserve the merged container (strategic merge doesn't handle this well)
+	// Preserve the merged containers (strategic merge doesn't handle this well)
+	merged.InitContainers = base.InitContainers
 	merged.Containers = base.Containers
 	return &merged, nil
 }
 
-// buildBenchmarkCommand constructs the command line arguments for the benchmark container.
-func (r *BenchmarkJobReconciler) buildBenchmarkCommand(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob) ([]string, []string, error) {
-	command := []string{benchmarkCommand}
+// buildBenchmarkCommand constructs the command line arguments for a benchmark container
+// that benchmarks the given endpoint and stores its results in folderName.
+func (r *BenchmarkJobReconciler) buildBenchmarkCommand(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, config *controllerconfig.BenchmarkJobConfig, endpoint v1beta1.EndpointSpec, folderName string) ([]string, []string, error) {
+	command, args, err := benchmarkRunnerCommand(benchmarkJob, config)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	inferenceArgs, err := benchmarkutils.BuildInferenceServiceArgs(ctx, r.Client, benchmarkJob.Spec.Endpoint, benchmarkJob.Namespace)
+	inferenceArgs, err := benchmarkutils.BuildInferenceServiceArgs(ctx, r.Client, r.inferenceServiceReader(), endpoint, benchmarkJob.Namespace)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	args := []string{
-		benchmarkSubcommand,
+	args = append(args,
 		"--api-backend", inferenceArgs["--api-backend"],
 		"--api-base", inferenceArgs["--api-base"],
 		"--api-model-name", inferenceArgs["--api-model-name"],
 		"--task", benchmarkJob.Spec.Task,
 		"--max-time-per-run", strconv.Itoa(*benchmarkJob.Spec.MaxTimePerIteration),
 		"--max-requests-per-run", strconv.Itoa(*benchmarkJob.Spec.MaxRequestsPerIteration),
-	}
+	)
 
 	// Add optional args only if present
 	if v := inferenceArgs["--api-key"]; v != "" {
@@ -522,19 +683,48 @@ func (r *BenchmarkJobReconciler) buildBenchmarkCommand(ctx context.Context, benc
 		args = append(args, "--model-tokenizer", v)
 	}
 
+	// Add warmup phase, excluded from the recorded results
+	if v := benchmarkJob.Spec.WarmupDuration; v != nil {
+		args = append(args, "--warmup-duration", strconv.Itoa(*v))
+	}
+	if v := benchmarkJob.Spec.WarmupRequests; v != nil {
+		args = append(args, "--warmup-requests", strconv.Itoa(*v))
+	}
+
+	// Select whether token-level metrics are measured from stream events
+	args = append(args, benchmarkutils.BuildStreamingArgs(benchmarkJob.Spec.Task, benchmarkJob.Spec.Streaming)...)
+
 	// Add traffic scenarios
 	for _, scenario := range benchmarkJob.Spec.TrafficScenarios {
 		args = append(args, "--traffic-scenario", scenario)
 	}
 
-	// Add concurrency levels
-	for _, concurrency := range benchmarkJob.Spec.NumConcurrency {
-		args = append(args, "--num-concurrency", fmt.Sprintf("%d", concurrency))
+	// Add the load shape, which replaces the concurrency sweep when set
+	if benchmarkJob.Spec.TrafficSchedule != nil {
+		scheduleArgs, err := benchmarkutils.BuildTrafficScheduleArgs(benchmarkJob.Spec.TrafficSchedule)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build traffic schedule args: %w", err)
+		}
+		args = append(args, scheduleArgs...)
+	} else {
+		// Add concurrency levels
+		for _, concurrency := range benchmarkJob.Spec.NumConcurrency {
+			args = append(args, "--num-concurrency", fmt.Sprintf("%d", concurrency))
+		}
 	}
 
 	// Add experiment folder name
-	if benchmarkJob.Spec.ResultFolderName != nil {
-		args = append(args, "--experiment-folder-name", *benchmarkJob.Spec.ResultFolderName)
+	args = append(args, "--experiment-folder-name", folderName)
+
+	// Keep the raw per-request records next to the summary when asked
+	if v := benchmarkJob.Spec.SaveRequestRecords; v != nil && *v {
+		args = append(args, "--save-request-records")
+	}
+
+	// Report the latency breakdown through the termination message so it can be surfaced in status,
+	// which comparative benchmarks need to compare the endpoints
+	if reportsMetrics(&benchmarkJob.Spec) {
+		args = append(args, "--metrics-report-path", v1.TerminationMessagePathDefault)
 	}
 
 	// Add server metadata
@@ -547,6 +737,22 @@ func (r *BenchmarkJobReconciler) buildBenchmarkCommand(ctx context.Context, benc
 		)
 	}
 
+	// Add workload shape
+	workloadArgs, err := benchmarkutils.BuildWorkloadArgs(benchmarkJob.Spec.Workload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build workload args: %w", err)
+	}
+	args = append(args, workloadArgs...)
+
+	// Add dataset source
+	if benchmarkJob.Spec.Dataset != nil {
+		datasetArgs, err := benchmarkutils.BuildDatasetArgs(benchmarkJob.Spec.Dataset)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build dataset args: %w", err)
+		}
+		args = append(args, datasetArgs...)
+	}
+
 	storageArgs, err := benchmarkutils.BuildStorageArgs(benchmarkJob.Spec.OutputLocation)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to build storage args: %w", err)
@@ -556,6 +762,80 @@ func (r *BenchmarkJobReconciler) buildBenchmarkCommand(ctx context.Context, benc
 	return command, args, nil
 }
 
+// benchmarkRunnerCommand returns the command and leading arguments of the benchmark client configured for the
+// benchmark image. The benchmark subcommand of ome-agent accepts the arguments of genai-bench, but only supports
+// single-turn text-to-text benchmarks of OpenAI compatible endpoints storing their results in OCI or a PVC.
+func benchmarkRunnerCommand(benchmarkJob *v1beta1.BenchmarkJob, config *controllerconfig.BenchmarkJobConfig) ([]string, []string, error) {
+	switch config.Runner {
+	case "", controllerconfig.BenchmarkRunnerGenAIBench:
+		for _, feature := range requiredRunnerFeatures(&benchmarkJob.Spec) {
+			if !slices.Contains(config.Features, feature) {
+				return nil, nil, fmt.Errorf("benchmark image %s does not list the %s feature in the benchmarkjob config", config.PodConfig.Image, feature)
+			}
+		}
+		return []string{benchmarkCommand}, []string{benchmarkSubcommand}, nil
+	case controllerconfig.BenchmarkRunnerOMEAgent:
+		spec := benchmarkJob.Spec
+		if spec.Task != "text-to-text" {
+			return nil, nil, fmt.Errorf("benchmark runner %s does not support task %s", config.Runner, spec.Task)
+		}
+		if spec.Workload != nil && spec.Workload.Type != "" && spec.Workload.Type != v1beta1.SingleTurnWorkload {
+			return nil, nil, fmt.Errorf("benchmark runner %s does not support workload type %s", config.Runner, spec.Workload.Type)
+		}
+		if spec.Dataset != nil {
+			return nil, nil, fmt.Errorf("benchmark runner %s does not support datasets", config.Runner)
+		}
+		if spec.TrafficSchedule != nil {
+			return nil, nil, fmt.Errorf("benchmark runner %s does not support traffic schedules", config.Runner)
+		}
+		if spec.OutputLocation != nil && spec.OutputLocation.StorageUri != nil {
+			storageType, err := storage.GetStorageType(*spec.OutputLocation.StorageUri)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error determining storage type: %w", err)
+			}
+			if storageType != storage.StorageTypeOCI && storageType != storage.StorageTypePVC {
+				return nil, nil, fmt.Errorf("benchmark runner %s does not support %s output storage", config.Runner, storageType)
+			}
+		}
+		// The ome-agent image runs ome-agent as its entrypoint
+		return nil, []string{benchmarkSubcommand, "--config", omeAgentConfigPath}, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported benchmark runner: %s", config.Runner)
+	}
+}
+
+// requiredRunnerFeatures returns the optional features a genai-bench image needs to run the BenchmarkJob.
+func requiredRunnerFeatures(spec *v1beta1.BenchmarkJobSpec) []string {
+	var features []string
+	if spec.Workload != nil {
+		switch spec.Workload.Type {
+		case v1beta1.ConversationWorkload:
+			features = append(features, controllerconfig.BenchmarkFeatureConversation)
+		case v1beta1.ReplayWorkload:
+			features = append(features, controllerconfig.BenchmarkFeatureReplay)
+		}
+	}
+	if spec.WarmupDuration != nil || spec.WarmupRequests != nil {
+		features = append(features, controllerconfig.BenchmarkFeatureWarmup)
+	}
+	if spec.TrafficSchedule != nil {
+		features = append(features, controllerconfig.BenchmarkFeatureTrafficSchedule)
+	}
+	if benchmarkutils.UsesDatasetStorageFlags(spec.Dataset) {
+		features = append(features, controllerconfig.BenchmarkFeatureDatasetStorage)
+	}
+	if v := spec.SaveRequestRecords; v != nil && *v {
+		features = append(features, controllerconfig.BenchmarkFeatureRequestRecords)
+	}
+	if reportsMetrics(spec) {
+		features = append(features, controllerconfig.BenchmarkFeatureMetricsReport)
+	}
+	if len(benchmarkutils.BuildStreamingArgs(spec.Task, spec.Streaming)) > 0 {
+		features = append(features, controllerconfig.BenchmarkFeatureStreaming)
+	}
+	return features
+}
+
 // updateStatus updates the BenchmarkJob status based on the underlying Job's state.
 func (r *BenchmarkJobReconciler) updateStatus(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob) error {
 	k8sJob := &batchv1.Job{}
@@ -570,11 +850,82 @@ func (r *BenchmarkJobReconciler) updateStatus(ctx context.Context, benchmarkJob
 		return err
 	} else {
 		r.syncStatusFromJob(benchmarkJob, k8sJob)
+		if benchmarkJob.Status.State == stateCompleted && benchmarkJob.Status.Metrics == nil && reportsMetrics(&benchmarkJob.Spec) {
+			if err := r.collectMetrics(ctx, benchmarkJob, k8sJob); err != nil {
+				r.Log.Error(err, "Failed to collect benchmark metrics", "benchmarkJob", benchmarkJob.Name)
+			}
+		}
 	}
 
 	return r.Status().Update(ctx, benchmarkJob)
 }
 
+// collectMetrics reads the latency report from the termination message of the completed benchmark pod.
+// For comparative benchmarks it also reads the report of the candidate container and compares both.
+func (r *BenchmarkJobReconciler) collectMetrics(ctx context.Context, benchmarkJob *v1beta1.BenchmarkJob, k8sJob *batchv1.Job) error {
+	if k8sJob.Spec.Selector == nil {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(k8sJob.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid job selector: %w", err)
+	}
+
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(k8sJob.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list benchmark pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != v1.PodSucceeded {
+			continue
+		}
+		message := terminationMessage(pod.Status.ContainerStatuses)
+		if message == "" {
+			continue
+		}
+		metrics, truncated, err := benchmarkutils.ParseMetricsReport(message)
+		if err != nil {
+			return err
+		}
+		benchmarkJob.Status.Metrics = metrics
+
+		if comparison := benchmarkJob.Spec.Comparison; comparison != nil {
+			candidateMetrics, candidateTruncated, err := benchmarkutils.ParseMetricsReport(terminationMessage(pod.Status.InitContainerStatuses))
+			if err != nil {
+				return fmt.Errorf("invalid candidate metrics report: %w", err)
+			}
+			truncated = truncated || candidateTruncated
+			benchmarkJob.Status.Comparison = &v1beta1.ComparisonReport{
+				CandidateMetrics: candidateMetrics,
+				Iterations: benchmarkutils.CompareMetrics(metrics, candidateMetrics,
+					comparison.BaselineCostPerHour, comparison.CandidateCostPerHour),
+			}
+		}
+		if truncated {
+			benchmarkJob.Status.Details = metricsReportTruncatedDetails
+		}
+		return nil
+	}
+	return nil
+}
+
+// reportsMetrics reports whether the benchmark container reports its metrics through its termination message.
+func reportsMetrics(spec *v1beta1.BenchmarkJobSpec) bool {
+	v := spec.ReportMetrics
+	return (v != nil && *v) || spec.Comparison != nil
+}
+
+// terminationMessage returns the first non-empty termination message of the given container statuses.
+func terminationMessage(statuses []v1.ContainerStatus) string {
+	for _, cs := range statuses {
+		if cs.State.Terminated != nil && cs.State.Terminated.Message != "" {
+			return cs.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
 // setStatusPending sets the benchmark job status to pending (no underlying job exists yet).
 func (r *BenchmarkJobReconciler) setStatusPending(benchmarkJob *v1beta1.BenchmarkJob) {
 	if benchmarkJob.Status.State == statePending {
@@ -585,6 +936,9 @@ func (r *BenchmarkJobReconciler) setStatusPending(benchmarkJob *v1beta1.Benchmar
 	benchmarkJob.Status.StartTime = nil
 	benchmarkJob.Status.CompletionTime = nil
 	benchmarkJob.Status.FailureMessage = ""
+	benchmarkJob.Status.Results = nil
+	benchmarkJob.Status.Metrics = nil
+	benchmarkJob.Status.Comparison = nil
 	benchmarkJob.Status.LastReconcileTime = &now
 }
 
@@ -612,6 +966,30 @@ func (r *BenchmarkJobReconciler) syncStatusFromJob(benchmarkJob *v1beta1.Benchma
 		benchmarkJob.Status.CompletionTime = nil
 		benchmarkJob.Status.FailureMessage = ""
 	}
+
+	benchmarkJob.Status.Results = nil
+	benchmarkJob.Status.Metrics = nil
+	benchmarkJob.Status.Comparison = nil
+	if state == stateCompleted {
+		results, err := benchmarkutils.BuildResults(benchmarkJob.Spec.OutputLocation, resultFolderName(benchmarkJob), benchmarkJob.Spec.SaveRequestRecords)
+		if err != nil {
+			r.Log.Error(err, "Failed to resolve benchmark results location", "benchmarkJob", benchmarkJob.Name)
+		}
+		benchmarkJob.Status.Results = results
+	}
+}
+
+// resultFolderName returns the folder name that stores the benchmark results, defaulting to the job name.
+func resultFolderName(benchmarkJob *v1beta1.BenchmarkJob) string {
+	if name := benchmarkJob.Spec.ResultFolderName; name != nil && *name != "" {
+		return *name
+	}
+	return benchmarkJob.Name
+}
+
+// candidateFolderName returns the folder name that stores the results of the candidate endpoint.
+func candidateFolderName(benchmarkJob *v1beta1.BenchmarkJob) string {
+	return resultFolderName(benchmarkJob) + "-" + candidateContainerName
 }
 
 // parseJobStatus extracts the state, completion time, and failure message from a Job.
@@ -642,5 +1020,6 @@ func (r *BenchmarkJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.BenchmarkJob{}).
 		Owns(&batchv1.Job{}).
-		Complete(r)
+		Complete(tracing.Reconciler("benchmarkjob", controllermetrics.Reconciler("benchmarkjob",
+			controllerhealth.Reconciler("benchmarkjob", &v1beta1.BenchmarkJob{}, r))))
 }
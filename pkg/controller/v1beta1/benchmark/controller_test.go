@@ -2,10 +2,12 @@ package benchmark
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +23,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	benchmarkutils "github.com/sgl-project/ome/pkg/controller/v1beta1/benchmark/utils"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 )
 
@@ -38,14 +42,14 @@ func TestBenchmarkJobReconciler_Reconcile(t *testing.T) {
 	tests := []struct {
 		name           string
 		benchmarkJob   *v1beta1.BenchmarkJob
+		config         string
 		expectedResult ctrl.Result
-		expectedError  bool
+		expectedError  string
 	}{
 		{
 			name:           "benchmark job not found",
 			benchmarkJob:   nil,
 			expectedResult: ctrl.Result{},
-			expectedError:  false,
 		},
 		{
 			name: "benchmark job with deletion timestamp",
@@ -58,13 +62,41 @@ func TestBenchmarkJobReconciler_Reconcile(t *testing.T) {
 				},
 			},
 			expectedResult: ctrl.Result{},
-			expectedError:  false,
+		},
+		{
+			name: "comparative benchmark without the metrics report feature",
+			benchmarkJob: &v1beta1.BenchmarkJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-job",
+					Namespace:  "default",
+					Finalizers: []string{"benchmarkjob.finalizers"},
+				},
+				Spec: v1beta1.BenchmarkJobSpec{
+					Endpoint: v1beta1.EndpointSpec{
+						InferenceService: &v1beta1.InferenceServiceReference{Name: "stable", Namespace: "default"},
+					},
+					Comparison: &v1beta1.ComparisonSpec{
+						Candidate: v1beta1.EndpointSpec{
+							InferenceService: &v1beta1.InferenceServiceReference{Name: "canary", Namespace: "default"},
+						},
+					},
+				},
+			},
+			config:        `{"podConfig": {"image": "genai-bench:0.1.113"}, "features": ["requestRecords"]}`,
+			expectedError: "does not list the metricsReport feature",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			clientBuilder := cfake.NewClientBuilder().WithScheme(scheme)
+			clientset := kfake.NewSimpleClientset()
+			if tt.config != "" {
+				clientset = kfake.NewSimpleClientset(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: constants.BenchmarkJobConfigMapName, Namespace: constants.OMENamespace},
+					Data:       map[string]string{controllerconfig.BenchmarkJobConfigName: tt.config},
+				})
+			}
+			clientBuilder := cfake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&v1beta1.BenchmarkJob{})
 			if tt.benchmarkJob != nil {
 				clientBuilder = clientBuilder.WithObjects(tt.benchmarkJob)
 			}
@@ -72,7 +104,7 @@ func TestBenchmarkJobReconciler_Reconcile(t *testing.T) {
 
 			r := &BenchmarkJobReconciler{
 				Client:    client,
-				Clientset: kfake.NewSimpleClientset(),
+				Clientset: clientset,
 				Log:       zap.New(),
 				Scheme:    scheme,
 				Recorder:  record.NewFakeRecorder(10),
@@ -92,8 +124,9 @@ func TestBenchmarkJobReconciler_Reconcile(t *testing.T) {
 
 			result, err := r.Reconcile(context.Background(), req)
 
-			if tt.expectedError {
-				assert.Error(t, err)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedResult, result)
@@ -497,7 +530,7 @@ func TestBenchmarkJobReconciler_buildBenchmarkCommand(t *testing.T) {
 				Client: client,
 			}
 
-			command, args, err := r.buildBenchmarkCommand(context.TODO(), tt.benchmarkJob)
+			command, args, err := r.buildBenchmarkCommand(context.TODO(), tt.benchmarkJob, &controllerconfig.BenchmarkJobConfig{}, tt.benchmarkJob.Spec.Endpoint, tt.benchmarkJob.Name)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildBenchmarkCommand() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -516,6 +549,322 @@ func TestBenchmarkJobReconciler_buildBenchmarkCommand(t *testing.T) {
 	}
 }
 
+func TestBenchmarkJobReconciler_buildBenchmarkCommand_OptionalFlags(t *testing.T) {
+	newJob := func(mutate func(spec *v1beta1.BenchmarkJobSpec)) *v1beta1.BenchmarkJob {
+		job := &v1beta1.BenchmarkJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default"},
+			Spec: v1beta1.BenchmarkJobSpec{
+				Task:                    "text-to-text",
+				MaxTimePerIteration:     IntPtr(60),
+				MaxRequestsPerIteration: IntPtr(100),
+				Endpoint: v1beta1.EndpointSpec{
+					Endpoint: &v1beta1.Endpoint{URL: "http://llama:8080", APIFormat: "openai", ModelName: "llama"},
+				},
+				OutputLocation: &v1beta1.StorageSpec{StorageUri: StringPtr("pvc://results/benchmarks")},
+			},
+		}
+		if mutate != nil {
+			mutate(&job.Spec)
+		}
+		return job
+	}
+
+	tests := []struct {
+		name         string
+		benchmarkJob *v1beta1.BenchmarkJob
+		wantArgs     []string
+		unwantedArgs []string
+	}{
+		{
+			name:         "optional flags are not passed by default",
+			benchmarkJob: newJob(nil),
+			unwantedArgs: []string{"--save-request-records", "--metrics-report-path", "--stream", "--no-stream"},
+		},
+		{
+			name: "request records are saved when asked",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.SaveRequestRecords = ptr.To(true)
+			}),
+			wantArgs: []string{"--save-request-records"},
+		},
+		{
+			name: "metrics are reported when asked",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.ReportMetrics = ptr.To(true)
+			}),
+			wantArgs: []string{"--metrics-report-path", corev1.TerminationMessagePathDefault},
+		},
+		{
+			name: "requests are streamed by the benchmark client by default",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Streaming = ptr.To(true)
+			}),
+			unwantedArgs: []string{"--stream", "--no-stream"},
+		},
+		{
+			name: "requests are not streamed when asked",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Streaming = ptr.To(false)
+			}),
+			wantArgs: []string{"--no-stream"},
+		},
+		{
+			name: "comparative benchmarks report their metrics",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Comparison = &v1beta1.ComparisonSpec{Candidate: spec.Endpoint}
+			}),
+			wantArgs: []string{"--metrics-report-path"},
+		},
+	}
+
+	config := &controllerconfig.BenchmarkJobConfig{
+		Features: []string{
+			controllerconfig.BenchmarkFeatureRequestRecords,
+			controllerconfig.BenchmarkFeatureMetricsReport,
+			controllerconfig.BenchmarkFeatureStreaming,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &BenchmarkJobReconciler{Client: cfake.NewClientBuilder().Build()}
+			_, args, err := r.buildBenchmarkCommand(context.TODO(), tt.benchmarkJob, config, tt.benchmarkJob.Spec.Endpoint, tt.benchmarkJob.Name)
+			require.NoError(t, err)
+			for _, arg := range tt.wantArgs {
+				assert.Contains(t, args, arg)
+			}
+			for _, arg := range tt.unwantedArgs {
+				assert.NotContains(t, args, arg)
+			}
+		})
+	}
+}
+
+func TestBenchmarkRunnerCommand(t *testing.T) {
+	newJob := func(modify func(*v1beta1.BenchmarkJobSpec)) *v1beta1.BenchmarkJob {
+		job := &v1beta1.BenchmarkJob{
+			Spec: v1beta1.BenchmarkJobSpec{
+				Task: "text-to-text",
+				OutputLocation: &v1beta1.StorageSpec{
+					StorageUri: StringPtr("oci://n/my-namespace/b/my-bucket/o/results"),
+				},
+			},
+		}
+		if modify != nil {
+			modify(&job.Spec)
+		}
+		return job
+	}
+
+	tests := []struct {
+		name            string
+		runner          string
+		features        []string
+		benchmarkJob    *v1beta1.BenchmarkJob
+		expectedCommand []string
+		expectedArgs    []string
+		wantErr         string
+	}{
+		{
+			name:            "genai-bench by default",
+			benchmarkJob:    newJob(nil),
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name:         "ome-agent",
+			runner:       controllerconfig.BenchmarkRunnerOMEAgent,
+			benchmarkJob: newJob(nil),
+			expectedArgs: []string{"benchmark", "--config", "/ome-agent.yaml"},
+		},
+		{
+			name:   "ome-agent with PVC output",
+			runner: controllerconfig.BenchmarkRunnerOMEAgent,
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.OutputLocation.StorageUri = StringPtr("pvc://results-pvc/benchmarks")
+			}),
+			expectedArgs: []string{"benchmark", "--config", "/ome-agent.yaml"},
+		},
+		{
+			name:   "ome-agent with image task",
+			runner: controllerconfig.BenchmarkRunnerOMEAgent,
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Task = "image-text-to-text"
+			}),
+			wantErr: "does not support task image-text-to-text",
+		},
+		{
+			name:   "ome-agent with conversation workload",
+			runner: controllerconfig.BenchmarkRunnerOMEAgent,
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Workload = &v1beta1.WorkloadSpec{Type: v1beta1.ConversationWorkload}
+			}),
+			wantErr: "does not support workload type",
+		},
+		{
+			name:   "ome-agent with traffic schedule",
+			runner: controllerconfig.BenchmarkRunnerOMEAgent,
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.TrafficSchedule = &v1beta1.TrafficScheduleSpec{}
+			}),
+			wantErr: "does not support traffic schedules",
+		},
+		{
+			name:   "ome-agent with S3 output",
+			runner: controllerconfig.BenchmarkRunnerOMEAgent,
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.OutputLocation.StorageUri = StringPtr("s3://my-bucket/results")
+			}),
+			wantErr: "does not support S3 output storage",
+		},
+		{
+			name: "genai-bench with conversation workload",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Workload = &v1beta1.WorkloadSpec{Type: v1beta1.ConversationWorkload}
+			}),
+			features:        []string{controllerconfig.BenchmarkFeatureConversation},
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name: "genai-bench image without the conversation feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Workload = &v1beta1.WorkloadSpec{Type: v1beta1.ConversationWorkload}
+			}),
+			wantErr: "does not list the conversation feature",
+		},
+		{
+			name: "genai-bench image without the warmup feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.WarmupRequests = IntPtr(10)
+			}),
+			features: []string{controllerconfig.BenchmarkFeatureConversation},
+			wantErr:  "does not list the warmup feature",
+		},
+		{
+			name: "genai-bench image without the replay feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Workload = &v1beta1.WorkloadSpec{Type: v1beta1.ReplayWorkload}
+			}),
+			features: []string{controllerconfig.BenchmarkFeatureConversation, controllerconfig.BenchmarkFeatureWarmup},
+			wantErr:  "does not list the replay feature",
+		},
+		{
+			name: "genai-bench with traffic schedule",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.TrafficSchedule = &v1beta1.TrafficScheduleSpec{}
+			}),
+			features:        []string{controllerconfig.BenchmarkFeatureTrafficSchedule},
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name: "genai-bench image without the traffic schedule feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.TrafficSchedule = &v1beta1.TrafficScheduleSpec{}
+			}),
+			wantErr: "does not list the trafficSchedule feature",
+		},
+		{
+			name: "genai-bench with object storage dataset",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Dataset = &v1beta1.StorageSpec{StorageUri: StringPtr("s3://my-bucket/datasets/chat")}
+			}),
+			features:        []string{controllerconfig.BenchmarkFeatureDatasetStorage},
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name: "genai-bench image without the dataset storage feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Dataset = &v1beta1.StorageSpec{StorageUri: StringPtr("oci://n/my-namespace/b/my-bucket/o/datasets")}
+			}),
+			wantErr: "does not list the datasetStorage feature",
+		},
+		{
+			name: "genai-bench with Hugging Face dataset",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Dataset = &v1beta1.StorageSpec{StorageUri: StringPtr("hf://sonnet/prompts")}
+			}),
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name: "genai-bench image without the request records feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.SaveRequestRecords = ptr.To(true)
+			}),
+			wantErr: "does not list the requestRecords feature",
+		},
+		{
+			name: "genai-bench image without the request records feature not saving them",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.SaveRequestRecords = ptr.To(false)
+			}),
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name: "genai-bench reporting metrics",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.ReportMetrics = ptr.To(true)
+			}),
+			features:        []string{controllerconfig.BenchmarkFeatureMetricsReport},
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name: "genai-bench image without the metrics report feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.ReportMetrics = ptr.To(true)
+			}),
+			wantErr: "does not list the metricsReport feature",
+		},
+		{
+			name: "genai-bench image without the streaming feature",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Streaming = ptr.To(false)
+			}),
+			wantErr: "does not list the streaming feature",
+		},
+		{
+			name: "genai-bench image without the streaming feature streaming requests",
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.Streaming = ptr.To(true)
+			}),
+			expectedCommand: []string{"genai-bench"},
+			expectedArgs:    []string{"benchmark"},
+		},
+		{
+			name:   "ome-agent with warmup",
+			runner: controllerconfig.BenchmarkRunnerOMEAgent,
+			benchmarkJob: newJob(func(spec *v1beta1.BenchmarkJobSpec) {
+				spec.WarmupDuration = IntPtr(30)
+			}),
+			expectedArgs: []string{"benchmark", "--config", "/ome-agent.yaml"},
+		},
+		{
+			name:         "unknown runner",
+			runner:       "locust",
+			benchmarkJob: newJob(nil),
+			wantErr:      "unsupported benchmark runner: locust",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, args, err := benchmarkRunnerCommand(tt.benchmarkJob, &controllerconfig.BenchmarkJobConfig{Runner: tt.runner, Features: tt.features})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCommand, command)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
 func TestBenchmarkJobReconciler_addNodeSelectorFromInferenceService(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1beta1.AddToScheme(scheme)
@@ -697,7 +1046,7 @@ func TestBenchmarkJobReconciler_addNodeSelectorFromInferenceService(t *testing.T
 				},
 			}
 
-			err := r.addNodeSelectorFromInferenceService(context.TODO(), tt.benchmarkJob, podSpec)
+			err := r.addNodeSelectorFromInferenceService(context.TODO(), tt.benchmarkJob, tt.benchmarkJob.Spec.Endpoint.InferenceService, podSpec)
 
 			if tt.expectErr {
 				assert.Error(t, err)
@@ -1047,3 +1396,79 @@ func TestBenchmarkJobReconciler_updateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestBenchmarkJobReconciler_collectMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	benchmarkJob := &v1beta1.BenchmarkJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job",
+			Namespace: "default",
+		},
+	}
+	k8sJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job",
+			Namespace: "default",
+		},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"job-name": "test-job"},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{"job-name": "test-job"},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "test-job",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `{"iterations":[{"scenario":"D(100,100)","concurrency":1,"endToEndLatency":{"p50":"1.5s"}}]}`,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	client := cfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pod).
+		Build()
+
+	r := &BenchmarkJobReconciler{
+		Client: client,
+		Scheme: scheme,
+	}
+
+	err := r.collectMetrics(context.Background(), benchmarkJob, k8sJob)
+	assert.NoError(t, err)
+	assert.Equal(t, []v1beta1.IterationMetrics{
+		{
+			Scenario:    "D(100,100)",
+			Concurrency: 1,
+			EndToEndLatency: &v1beta1.LatencyDistribution{
+				P50: &metav1.Duration{Duration: 1500 * time.Millisecond},
+			},
+		},
+	}, benchmarkJob.Status.Metrics)
+	assert.Empty(t, benchmarkJob.Status.Details)
+
+	// A report cut at the termination message limit is reported in the details
+	pod.Status.ContainerStatuses[0].State.Terminated.Message = `{"iterations":[` + strings.Repeat(" ", benchmarkutils.MaxMetricsReportSize)
+	require.NoError(t, client.Status().Update(context.Background(), pod))
+	benchmarkJob.Status.Metrics = nil
+	require.NoError(t, r.collectMetrics(context.Background(), benchmarkJob, k8sJob))
+	assert.Nil(t, benchmarkJob.Status.Metrics)
+	assert.Equal(t, metricsReportTruncatedDetails, benchmarkJob.Status.Details)
+}
@@ -2,10 +2,17 @@ package benchmarkutils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
@@ -14,7 +21,7 @@ import (
 )
 
 // GetInferenceService fetches the InferenceService based on the provided InferenceServiceReference.
-func GetInferenceService(ctx context.Context, c client.Client, ref *v1beta1.InferenceServiceReference) (*v1beta1.InferenceService, error) {
+func GetInferenceService(ctx context.Context, c client.Reader, ref *v1beta1.InferenceServiceReference) (*v1beta1.InferenceService, error) {
 	if ref == nil {
 		return nil, fmt.Errorf("inferenceservice reference is nil")
 	}
@@ -44,14 +51,15 @@ func GetBaseModelName(isvc *v1beta1.InferenceService) string {
 
 // BuildInferenceServiceArgs constructs a map of arguments for the benchmark command
 // based on either a direct Endpoint or an InferenceService reference in the EndpointSpec.
-func BuildInferenceServiceArgs(ctx context.Context, c client.Client, endpointSpec v1beta1.EndpointSpec, namespace string) (map[string]string, error) {
+// The InferenceService is read with isvcReader and its model with c.
+func BuildInferenceServiceArgs(ctx context.Context, c client.Client, isvcReader client.Reader, endpointSpec v1beta1.EndpointSpec, namespace string) (map[string]string, error) {
 	if endpointSpec.Endpoint != nil {
 		return buildArgsFromEndpoint(endpointSpec.Endpoint), nil
 	}
 
 	if endpointSpec.InferenceService != nil {
 		ref := endpointSpec.InferenceService
-		inferenceService, err := GetInferenceService(ctx, c, ref)
+		inferenceService, err := GetInferenceService(ctx, isvcReader, ref)
 		if err != nil {
 			return nil, err
 		}
@@ -325,3 +333,494 @@ func buildGitHubArgs(uri string, params map[string]string) ([]string, error) {
 
 	return args, nil
 }
+
+// DatasetMountPath is the path where PVC-backed datasets are mounted in the benchmark container.
+const DatasetMountPath = "/dataset"
+
+// datasetBuilders maps storage types to their dataset argument builders
+var datasetBuilders = map[storage.StorageType]storageArgsBuilder{
+	storage.StorageTypeHuggingFace: buildHuggingFaceDatasetArgs,
+	storage.StorageTypePVC:         buildPVCDatasetArgs,
+	storage.StorageTypeLocal:       buildLocalDatasetArgs,
+	storage.StorageTypeOCI:         buildOCIDatasetArgs,
+	storage.StorageTypeS3:          buildS3DatasetArgs,
+	storage.StorageTypeAzure:       buildAzureDatasetArgs,
+	storage.StorageTypeGCS:         buildGCSDatasetArgs,
+}
+
+// BuildDatasetArgs builds command line arguments for the benchmark dataset.
+// Hugging Face, PVC and local datasets are resolved to a dataset path readable by the
+// benchmark pod, while object storage datasets are fetched by the benchmark client
+// using the credentials wired into the container by the controller.
+func BuildDatasetArgs(storageSpec *v1beta1.StorageSpec) ([]string, error) {
+	if storageSpec == nil {
+		return nil, fmt.Errorf("storageSpec cannot be nil")
+	}
+	if storageSpec.StorageUri == nil {
+		return nil, fmt.Errorf("storageUri cannot be nil")
+	}
+
+	storageType, err := storage.GetStorageType(*storageSpec.StorageUri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dataset URI: %v", err)
+	}
+
+	builder, ok := datasetBuilders[storageType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dataset storage type: %s", storageType)
+	}
+
+	var params map[string]string
+	if storageSpec.Parameters != nil {
+		params = *storageSpec.Parameters
+	}
+
+	args, err := builder(*storageSpec.StorageUri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	args = addParam(args, params, "dataset_config", "--dataset-config")
+	args = addParam(args, params, "prompt_column", "--dataset-prompt-column")
+	args = addParam(args, params, "image_column", "--dataset-image-column")
+
+	return args, nil
+}
+
+// UsesDatasetStorageFlags reports whether the dataset arguments include the --dataset-storage-* flags of
+// object storage datasets or the --dataset-revision flag of Hugging Face datasets not on the main branch.
+// Invalid dataset URIs report false and are rejected when the dataset arguments are built.
+func UsesDatasetStorageFlags(storageSpec *v1beta1.StorageSpec) bool {
+	if storageSpec == nil || storageSpec.StorageUri == nil {
+		return false
+	}
+
+	storageType, err := storage.GetStorageType(*storageSpec.StorageUri)
+	if err != nil {
+		return false
+	}
+	switch storageType {
+	case storage.StorageTypeOCI, storage.StorageTypeS3, storage.StorageTypeAzure, storage.StorageTypeGCS:
+		return true
+	case storage.StorageTypeHuggingFace:
+		components, err := storage.ParseHuggingFaceStorageURI(*storageSpec.StorageUri)
+		return err == nil && components.Branch != "main"
+	default:
+		return false
+	}
+}
+
+// datasetCredentialKeys maps storage types to the keys of the dataset Secret the benchmark client
+// reads from its environment to fetch the dataset
+var datasetCredentialKeys = map[storage.StorageType][]string{
+	storage.StorageTypeS3:    {"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"},
+	storage.StorageTypeAzure: {"AZURE_STORAGE_ACCOUNT_KEY", "AZURE_STORAGE_CONNECTION_STRING", "AZURE_STORAGE_SAS_TOKEN"},
+}
+
+// BuildDatasetCredentialEnv builds the environment variables exposing the dataset credentials of the
+// Secret named by the dataset key. Only the keys the benchmark client reads for the dataset storage type
+// are exposed, each optional so that a Secret holding a subset of them is accepted.
+func BuildDatasetCredentialEnv(storageSpec *v1beta1.StorageSpec) ([]v1.EnvVar, error) {
+	if storageSpec == nil || storageSpec.StorageUri == nil || storageSpec.StorageKey == nil || *storageSpec.StorageKey == "" {
+		return nil, nil
+	}
+
+	storageType, err := storage.GetStorageType(*storageSpec.StorageUri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dataset URI: %v", err)
+	}
+
+	var env []v1.EnvVar
+	for _, key := range datasetCredentialKeys[storageType] {
+		env = append(env, v1.EnvVar{
+			Name: key,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: *storageSpec.StorageKey},
+					Key:                  key,
+					Optional:             ptr.To(true),
+				},
+			},
+		})
+	}
+	return env, nil
+}
+
+// GetDatasetPVC returns the PVC components of the dataset when it is stored on a PVC, or nil otherwise.
+func GetDatasetPVC(storageSpec *v1beta1.StorageSpec) (*storage.PVCStorageComponents, error) {
+	if storageSpec == nil || storageSpec.StorageUri == nil {
+		return nil, nil
+	}
+
+	storageType, err := storage.GetStorageType(*storageSpec.StorageUri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dataset URI: %v", err)
+	}
+	if storageType != storage.StorageTypePVC {
+		return nil, nil
+	}
+
+	components, err := storage.ParsePVCStorageURI(*storageSpec.StorageUri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PVC dataset URI: %v", err)
+	}
+	return components, nil
+}
+
+func buildHuggingFaceDatasetArgs(uri string, _ map[string]string) ([]string, error) {
+	components, err := storage.ParseHuggingFaceStorageURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Hugging Face dataset URI: %v", err)
+	}
+
+	args := []string{"--dataset-path", components.ModelID}
+	if components.Branch != "main" {
+		args = append(args, "--dataset-revision", components.Branch)
+	}
+	return args, nil
+}
+
+func buildPVCDatasetArgs(uri string, _ map[string]string) ([]string, error) {
+	components, err := storage.ParsePVCStorageURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PVC dataset URI: %v", err)
+	}
+	return []string{"--dataset-path", path.Join(DatasetMountPath, components.SubPath)}, nil
+}
+
+func buildLocalDatasetArgs(uri string, _ map[string]string) ([]string, error) {
+	components, err := storage.ParseLocalStorageURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local dataset URI: %v", err)
+	}
+	return []string{"--dataset-path", components.Path}, nil
+}
+
+func buildOCIDatasetArgs(uri string, params map[string]string) ([]string, error) {
+	components, err := storage.ParseOCIStorageURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI dataset URI: %v", err)
+	}
+
+	args := []string{
+		"--dataset-storage-provider", "oci",
+		"--dataset-storage-namespace", components.Namespace,
+		"--dataset-storage-bucket", components.Bucket,
+		"--dataset-storage-prefix", components.Prefix,
+	}
+	args = addParam(args, params, "auth", "--dataset-storage-auth")
+	args = addParam(args, params, "region", "--dataset-storage-region")
+
+	return args, nil
+}
+
+func buildS3DatasetArgs(uri string, params map[string]string) ([]string, error) {
+	components, err := storage.ParseS3StorageURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 dataset URI: %v", err)
+	}
+
+	args := []string{
+		"--dataset-storage-provider", "aws",
+		"--dataset-storage-bucket", components.Bucket,
+	}
+	if components.Prefix != "" {
+		args = append(args, "--dataset-storage-prefix", components.Prefix)
+	}
+
+	// Region from params takes precedence over URI-derived region
+	if region, ok := params["aws_region"]; ok {
+		args = append(args, "--dataset-storage-region", region)
+	} else if components.Region != "" {
+		args = append(args, "--dataset-storage-region", components.Region)
+	}
+
+	return args, nil
+}
+
+func buildAzureDatasetArgs(uri string, params map[string]string) ([]string, error) {
+	components, err := storage.ParseAzureStorageURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure dataset URI: %v", err)
+	}
+
+	args := []string{
+		"--dataset-storage-provider", "azure",
+		"--dataset-storage-bucket", components.ContainerName,
+	}
+	if components.BlobPath != "" {
+		args = append(args, "--dataset-storage-prefix", components.BlobPath)
+	}
+
+	// Account name from params takes precedence
+	if accountName, ok := params["azure_account_name"]; ok {
+		args = append(args, "--dataset-storage-azure-account-name", accountName)
+	} else {
+		args = append(args, "--dataset-storage-azure-account-name", components.AccountName)
+	}
+
+	return args, nil
+}
+
+func buildGCSDatasetArgs(uri string, params map[string]string) ([]string, error) {
+	components, err := storage.ParseGCSStorageURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GCS dataset URI: %v", err)
+	}
+
+	args := []string{
+		"--dataset-storage-provider", "gcp",
+		"--dataset-storage-bucket", components.Bucket,
+	}
+	if components.Object != "" {
+		args = append(args, "--dataset-storage-prefix", components.Object)
+	}
+	args = addParam(args, params, "gcp_project_id", "--dataset-storage-gcp-project-id")
+
+	return args, nil
+}
+
+// historyModeFlags maps conversation history modes to their command line values
+var historyModeFlags = map[v1beta1.HistoryMode]string{
+	v1beta1.FullHistory:          "full",
+	v1beta1.SlidingWindowHistory: "sliding-window",
+}
+
+// BuildWorkloadArgs builds command line arguments for the benchmark workload.
+// Single-turn workloads are the genai-bench default and produce no arguments.
+func BuildWorkloadArgs(workload *v1beta1.WorkloadSpec) ([]string, error) {
+	if workload == nil {
+		return nil, nil
+	}
+
+	switch workload.Type {
+	case "", v1beta1.SingleTurnWorkload:
+		return nil, nil
+	case v1beta1.ConversationWorkload:
+		return buildConversationArgs(workload)
+	case v1beta1.ReplayWorkload:
+		return buildReplayArgs(workload.Replay), nil
+	default:
+		return nil, fmt.Errorf("unsupported workload type: %s", workload.Type)
+	}
+}
+
+func buildConversationArgs(workload *v1beta1.WorkloadSpec) ([]string, error) {
+	conversation := workload.Conversation
+	if conversation == nil {
+		return nil, fmt.Errorf("conversation must be specified for workload type %s", workload.Type)
+	}
+
+	args := []string{
+		"--workload-type", "conversation",
+		"--num-turns", strconv.Itoa(conversation.NumTurns),
+	}
+
+	historyMode := conversation.HistoryMode
+	if historyMode == "" {
+		historyMode = v1beta1.FullHistory
+	}
+	flag, ok := historyModeFlags[historyMode]
+	if !ok {
+		return nil, fmt.Errorf("unsupported history mode: %s", historyMode)
+	}
+	args = append(args, "--history-mode", flag)
+
+	if historyMode == v1beta1.SlidingWindowHistory {
+		if conversation.HistoryWindow == nil {
+			return nil, fmt.Errorf("historyWindow must be specified for history mode %s", historyMode)
+		}
+		args = append(args, "--history-window", strconv.Itoa(*conversation.HistoryWindow))
+	}
+
+	if conversation.SystemPrompt != "" {
+		args = append(args, "--system-prompt", conversation.SystemPrompt)
+	}
+
+	return args, nil
+}
+
+// buildReplayArgs builds the arguments of a trace replay. The trace itself is passed
+// through the dataset arguments.
+func buildReplayArgs(replay *v1beta1.ReplaySpec) []string {
+	args := []string{"--workload-type", "replay"}
+	if replay == nil {
+		return args
+	}
+	if replay.TimeScale != nil {
+		args = append(args, "--replay-time-scale", replay.TimeScale.AsDec().String())
+	}
+	if replay.MaxDuration != nil {
+		args = append(args, "--replay-max-duration", strconv.Itoa(*replay.MaxDuration))
+	}
+	return args
+}
+
+// BuildStreamingArgs builds the arguments that select how token-level metrics are measured.
+// Streaming only applies to tasks that generate text; embeddings tasks produce no arguments, and neither
+// does an unset or enabled streaming, as the benchmark client streams by default.
+func BuildStreamingArgs(task string, streaming *bool) []string {
+	if !strings.HasSuffix(task, "-to-text") || streaming == nil || *streaming {
+		return nil
+	}
+	return []string{"--no-stream"}
+}
+
+// phaseShapeFlags maps traffic phase shapes to their command line values
+var phaseShapeFlags = map[v1beta1.TrafficPhaseShape]string{
+	v1beta1.ConstantPhase: "constant",
+	v1beta1.RampPhase:     "ramp",
+}
+
+// BuildTrafficScheduleArgs builds command line arguments for a traffic schedule,
+// one --traffic-phase argument per phase in schedule order.
+func BuildTrafficScheduleArgs(schedule *v1beta1.TrafficScheduleSpec) ([]string, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+
+	var args []string
+	for i, phase := range schedule.Phases {
+		shape := phase.Shape
+		if shape == "" {
+			shape = v1beta1.ConstantPhase
+		}
+		flag, ok := phaseShapeFlags[shape]
+		if !ok {
+			return nil, fmt.Errorf("unsupported shape of traffic phase %d: %s", i, shape)
+		}
+
+		fields := []string{"shape=" + flag, "duration=" + strconv.Itoa(phase.Duration)}
+		switch {
+		case phase.RequestRate != nil:
+			fields = append(fields, "request-rate="+phase.RequestRate.AsDec().String())
+		case phase.Concurrency != nil:
+			fields = append(fields, "concurrency="+strconv.Itoa(*phase.Concurrency))
+		default:
+			return nil, fmt.Errorf("traffic phase %d must specify requestRate or concurrency", i)
+		}
+		if phase.Name != "" {
+			fields = append(fields, "name="+phase.Name)
+		}
+
+		args = append(args, "--traffic-phase", strings.Join(fields, ","))
+	}
+	return args, nil
+}
+
+const (
+	// ResultSummaryFileName is the name of the summary JSON file written to the result folder.
+	ResultSummaryFileName = "summary.json"
+	// ResultRecordsFileName is the name of the raw per-request records file written to the result folder.
+	ResultRecordsFileName = "requests.jsonl"
+)
+
+// BuildResults returns the storage URIs of the results exported to the output location
+// under the given result folder. The records are only reported when they are saved. GitHub
+// releases store the results as release assets, so only the release URI is reported for them.
+func BuildResults(outputLocation *v1beta1.StorageSpec, folderName string, saveRequestRecords *bool) (*v1beta1.BenchmarkResults, error) {
+	if outputLocation == nil || outputLocation.StorageUri == nil {
+		return nil, nil
+	}
+
+	uri := *outputLocation.StorageUri
+	storageType, err := storage.GetStorageType(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage URI: %v", err)
+	}
+	if storageType == storage.StorageTypeGitHub {
+		return &v1beta1.BenchmarkResults{Location: uri}, nil
+	}
+
+	location := strings.TrimSuffix(uri, "/") + "/" + folderName
+	results := &v1beta1.BenchmarkResults{
+		Location: location,
+		Summary:  location + "/" + ResultSummaryFileName,
+	}
+	if saveRequestRecords != nil && *saveRequestRecords {
+		results.Records = location + "/" + ResultRecordsFileName
+	}
+	return results, nil
+}
+
+// MaxMetricsReportSize is the size limit of a container termination message, which carries the metrics report.
+const MaxMetricsReportSize = 4096
+
+// metricsReport is the latency report written by the benchmark container to its termination message.
+type metricsReport struct {
+	Iterations []v1beta1.IterationMetrics `json:"iterations"`
+	// Truncated is set when the last iterations were left out to fit the report in the termination message
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ParseMetricsReport parses the latency report written by the benchmark container to its termination message.
+// It reports whether the report is truncated, either because the container left iterations out of it or because
+// the kubelet cut it at the size limit of the termination message, in which case no metrics are returned.
+func ParseMetricsReport(message string) ([]v1beta1.IterationMetrics, bool, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, false, nil
+	}
+
+	report := &metricsReport{}
+	if err := json.Unmarshal([]byte(message), report); err != nil {
+		if len(message) >= MaxMetricsReportSize {
+			return nil, true, nil
+		}
+		return nil, false, fmt.Errorf("invalid metrics report: %v", err)
+	}
+	return report.Iterations, report.Truncated, nil
+}
+
+// CompareMetrics computes the relative change of the candidate metrics against the baseline metrics for
+// every iteration measured on both endpoints, matched by scenario and concurrency. The cost per token is
+// only compared when the hourly cost of both endpoints is known.
+func CompareMetrics(baseline, candidate []v1beta1.IterationMetrics, baselineCost, candidateCost *resource.Quantity) []v1beta1.IterationComparison {
+	var comparisons []v1beta1.IterationComparison
+	for _, b := range baseline {
+		for _, c := range candidate {
+			if b.Scenario != c.Scenario || b.Concurrency != c.Concurrency {
+				continue
+			}
+			comparison := v1beta1.IterationComparison{
+				Scenario:                b.Scenario,
+				Concurrency:             b.Concurrency,
+				OutputThroughputChange:  percentChange(quantityValue(b.OutputThroughput), quantityValue(c.OutputThroughput)),
+				TimeToFirstTokenChange:  percentChange(medianSeconds(b.TimeToFirstToken), medianSeconds(c.TimeToFirstToken)),
+				InterTokenLatencyChange: percentChange(medianSeconds(b.InterTokenLatency), medianSeconds(c.InterTokenLatency)),
+				EndToEndLatencyChange:   percentChange(medianSeconds(b.EndToEndLatency), medianSeconds(c.EndToEndLatency)),
+			}
+			if bt, ct := quantityValue(b.OutputThroughput), quantityValue(c.OutputThroughput); bt > 0 && ct > 0 {
+				comparison.CostPerTokenChange = percentChange(quantityValue(baselineCost)/bt, quantityValue(candidateCost)/ct)
+			}
+			comparisons = append(comparisons, comparison)
+			break
+		}
+	}
+	return comparisons
+}
+
+// percentChange returns the relative change from baseline to candidate in percent,
+// or nil if either value is unknown.
+func percentChange(baseline, candidate float64) *resource.Quantity {
+	if baseline <= 0 || candidate <= 0 {
+		return nil
+	}
+	pct := (candidate - baseline) / baseline * 100
+	return resource.NewMilliQuantity(int64(math.Round(pct*1000)), resource.DecimalSI)
+}
+
+// quantityValue returns the value of a quantity, or 0 if it is not set.
+func quantityValue(q *resource.Quantity) float64 {
+	if q == nil {
+		return 0
+	}
+	return q.AsApproximateFloat64()
+}
+
+// medianSeconds returns the median of a latency distribution in seconds, or 0 if it is not set.
+func medianSeconds(dist *v1beta1.LatencyDistribution) float64 {
+	if dist == nil || dist.P50 == nil {
+		return 0
+	}
+	return dist.P50.Seconds()
+}
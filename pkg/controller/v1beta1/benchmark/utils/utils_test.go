@@ -3,10 +3,13 @@ package benchmarkutils
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -340,6 +343,581 @@ func TestBuildStorageArgs(t *testing.T) {
 	}
 }
 
+func TestBuildDatasetArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		storageSpec *v1beta1.StorageSpec
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name: "hugging face dataset",
+			storageSpec: &v1beta1.StorageSpec{
+				StorageUri: strPtr("hf://sonnet/prompts"),
+				Parameters: &map[string]string{
+					"prompt_column": "text",
+				},
+			},
+			want: []string{
+				"--dataset-path", "sonnet/prompts",
+				"--dataset-prompt-column", "text",
+			},
+		},
+		{
+			name: "hugging face dataset with revision",
+			storageSpec: &v1beta1.StorageSpec{
+				StorageUri: strPtr("hf://sonnet/prompts@v2"),
+			},
+			want: []string{
+				"--dataset-path", "sonnet/prompts",
+				"--dataset-revision", "v2",
+			},
+		},
+		{
+			name: "PVC dataset",
+			storageSpec: &v1beta1.StorageSpec{
+				StorageUri: strPtr("pvc://datasets/chat/prompts.jsonl"),
+			},
+			want: []string{"--dataset-path", "/dataset/chat/prompts.jsonl"},
+		},
+		{
+			name: "S3 dataset with region parameter",
+			storageSpec: &v1beta1.StorageSpec{
+				StorageUri: strPtr("s3://my-bucket/datasets/chat"),
+				Parameters: &map[string]string{
+					"aws_region": "us-west-2",
+				},
+			},
+			want: []string{
+				"--dataset-storage-provider", "aws",
+				"--dataset-storage-bucket", "my-bucket",
+				"--dataset-storage-prefix", "datasets/chat",
+				"--dataset-storage-region", "us-west-2",
+			},
+		},
+		{
+			name: "OCI dataset",
+			storageSpec: &v1beta1.StorageSpec{
+				StorageUri: strPtr("oci://n/my-namespace/b/my-bucket/o/datasets"),
+			},
+			want: []string{
+				"--dataset-storage-provider", "oci",
+				"--dataset-storage-namespace", "my-namespace",
+				"--dataset-storage-bucket", "my-bucket",
+				"--dataset-storage-prefix", "datasets",
+			},
+		},
+		{
+			name: "unsupported dataset storage type",
+			storageSpec: &v1beta1.StorageSpec{
+				StorageUri: strPtr("github://owner/repo"),
+			},
+			wantErr: true,
+		},
+		{
+			name:        "nil storage spec",
+			storageSpec: nil,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildDatasetArgs(tt.storageSpec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildDatasetArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUsesDatasetStorageFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		storageSpec *v1beta1.StorageSpec
+		want        bool
+	}{
+		{
+			name:        "hugging face dataset",
+			storageSpec: &v1beta1.StorageSpec{StorageUri: strPtr("hf://sonnet/prompts")},
+			want:        false,
+		},
+		{
+			name:        "hugging face dataset with revision",
+			storageSpec: &v1beta1.StorageSpec{StorageUri: strPtr("hf://sonnet/prompts@v2")},
+			want:        true,
+		},
+		{
+			name:        "PVC dataset",
+			storageSpec: &v1beta1.StorageSpec{StorageUri: strPtr("pvc://datasets/chat/prompts.jsonl")},
+			want:        false,
+		},
+		{
+			name:        "S3 dataset",
+			storageSpec: &v1beta1.StorageSpec{StorageUri: strPtr("s3://my-bucket/datasets/chat")},
+			want:        true,
+		},
+		{
+			name:        "no dataset",
+			storageSpec: nil,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UsesDatasetStorageFlags(tt.storageSpec))
+		})
+	}
+}
+
+func TestBuildDatasetCredentialEnv(t *testing.T) {
+	t.Run("S3 dataset exposes the AWS keys of the Secret", func(t *testing.T) {
+		env, err := BuildDatasetCredentialEnv(&v1beta1.StorageSpec{
+			StorageUri: strPtr("s3://my-bucket/datasets/chat"),
+			StorageKey: strPtr("dataset-credentials"),
+		})
+		assert.NoError(t, err)
+
+		var names []string
+		for _, e := range env {
+			names = append(names, e.Name)
+			assert.Equal(t, "dataset-credentials", e.ValueFrom.SecretKeyRef.Name)
+			assert.Equal(t, e.Name, e.ValueFrom.SecretKeyRef.Key)
+			assert.True(t, *e.ValueFrom.SecretKeyRef.Optional)
+		}
+		assert.Equal(t, []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"}, names)
+	})
+
+	t.Run("dataset without a key", func(t *testing.T) {
+		env, err := BuildDatasetCredentialEnv(&v1beta1.StorageSpec{StorageUri: strPtr("s3://my-bucket/datasets/chat")})
+		assert.NoError(t, err)
+		assert.Empty(t, env)
+	})
+
+	t.Run("storage type without credential keys", func(t *testing.T) {
+		env, err := BuildDatasetCredentialEnv(&v1beta1.StorageSpec{
+			StorageUri: strPtr("pvc://datasets/chat/prompts.jsonl"),
+			StorageKey: strPtr("dataset-credentials"),
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, env)
+	})
+}
+
+func TestBuildWorkloadArgs(t *testing.T) {
+	window := 4
+	maxDuration := 600
+	timeScale := resource.MustParse("0.5")
+	tests := []struct {
+		name     string
+		workload *v1beta1.WorkloadSpec
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "nil workload",
+			workload: nil,
+			want:     nil,
+		},
+		{
+			name: "single turn workload",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.SingleTurnWorkload,
+			},
+			want: nil,
+		},
+		{
+			name: "conversation with full history",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{
+					NumTurns:     5,
+					SystemPrompt: "You are a helpful assistant.",
+				},
+			},
+			want: []string{
+				"--workload-type", "conversation",
+				"--num-turns", "5",
+				"--history-mode", "full",
+				"--system-prompt", "You are a helpful assistant.",
+			},
+		},
+		{
+			name: "conversation with sliding window history",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{
+					NumTurns:      8,
+					HistoryMode:   v1beta1.SlidingWindowHistory,
+					HistoryWindow: &window,
+				},
+			},
+			want: []string{
+				"--workload-type", "conversation",
+				"--num-turns", "8",
+				"--history-mode", "sliding-window",
+				"--history-window", "4",
+			},
+		},
+		{
+			name: "conversation without conversation spec",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ConversationWorkload,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sliding window without window size",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{
+					NumTurns:    3,
+					HistoryMode: v1beta1.SlidingWindowHistory,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "replay with defaults",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ReplayWorkload,
+			},
+			want: []string{"--workload-type", "replay"},
+		},
+		{
+			name: "replay with time scale and max duration",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ReplayWorkload,
+				Replay: &v1beta1.ReplaySpec{
+					TimeScale:   &timeScale,
+					MaxDuration: &maxDuration,
+				},
+			},
+			want: []string{
+				"--workload-type", "replay",
+				"--replay-time-scale", "0.5",
+				"--replay-max-duration", "600",
+			},
+		},
+		{
+			name: "unsupported workload type",
+			workload: &v1beta1.WorkloadSpec{
+				Type: "Unknown",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildWorkloadArgs(tt.workload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildWorkloadArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildStreamingArgs(t *testing.T) {
+	enabled := true
+	disabled := false
+	tests := []struct {
+		name      string
+		task      string
+		streaming *bool
+		want      []string
+	}{
+		{
+			name: "text task leaves streaming to the benchmark client",
+			task: "text-to-text",
+			want: nil,
+		},
+		{
+			name:      "streaming enabled is the benchmark client default",
+			task:      "image-to-text",
+			streaming: &enabled,
+			want:      nil,
+		},
+		{
+			name:      "streaming disabled",
+			task:      "text-to-text",
+			streaming: &disabled,
+			want:      []string{"--no-stream"},
+		},
+		{
+			name:      "embeddings task",
+			task:      "text-to-embeddings",
+			streaming: &enabled,
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BuildStreamingArgs(tt.task, tt.streaming))
+		})
+	}
+}
+
+func TestBuildTrafficScheduleArgs(t *testing.T) {
+	concurrency := 32
+	rate := resource.MustParse("12.5")
+	tests := []struct {
+		name     string
+		schedule *v1beta1.TrafficScheduleSpec
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "nil schedule",
+			schedule: nil,
+			want:     nil,
+		},
+		{
+			name: "ramp and constant phases",
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{
+					{Name: "ramp-up", Duration: 120, Shape: v1beta1.RampPhase, RequestRate: &rate},
+					{Duration: 300, RequestRate: &rate},
+				},
+			},
+			want: []string{
+				"--traffic-phase", "shape=ramp,duration=120,request-rate=12.5,name=ramp-up",
+				"--traffic-phase", "shape=constant,duration=300,request-rate=12.5",
+			},
+		},
+		{
+			name: "concurrency phase",
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{
+					{Duration: 30, Shape: v1beta1.ConstantPhase, Concurrency: &concurrency},
+				},
+			},
+			want: []string{"--traffic-phase", "shape=constant,duration=30,concurrency=32"},
+		},
+		{
+			name: "phase without target",
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{{Duration: 30}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildTrafficScheduleArgs(tt.schedule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildTrafficScheduleArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildResults(t *testing.T) {
+	saveRecords := true
+	tests := []struct {
+		name               string
+		outputLocation     *v1beta1.StorageSpec
+		folderName         string
+		saveRequestRecords *bool
+		want               *v1beta1.BenchmarkResults
+		wantErr            bool
+	}{
+		{
+			name: "OCI output location",
+			outputLocation: &v1beta1.StorageSpec{
+				StorageUri: strPtr("oci://n/my-namespace/b/my-bucket/o/results/"),
+			},
+			folderName:         "llama-run",
+			saveRequestRecords: &saveRecords,
+			want: &v1beta1.BenchmarkResults{
+				Location: "oci://n/my-namespace/b/my-bucket/o/results/llama-run",
+				Summary:  "oci://n/my-namespace/b/my-bucket/o/results/llama-run/summary.json",
+				Records:  "oci://n/my-namespace/b/my-bucket/o/results/llama-run/requests.jsonl",
+			},
+		},
+		{
+			name: "PVC output location without request records",
+			outputLocation: &v1beta1.StorageSpec{
+				StorageUri: strPtr("pvc://benchmark-pvc/results"),
+			},
+			folderName: "run-1",
+			want: &v1beta1.BenchmarkResults{
+				Location: "pvc://benchmark-pvc/results/run-1",
+				Summary:  "pvc://benchmark-pvc/results/run-1/summary.json",
+			},
+		},
+		{
+			name: "GitHub output location",
+			outputLocation: &v1beta1.StorageSpec{
+				StorageUri: strPtr("github://owner/repo@v1.0.0"),
+			},
+			folderName: "run-1",
+			want:       &v1beta1.BenchmarkResults{Location: "github://owner/repo@v1.0.0"},
+		},
+		{
+			name:           "nil output location",
+			outputLocation: nil,
+			want:           nil,
+		},
+		{
+			name: "invalid output location",
+			outputLocation: &v1beta1.StorageSpec{
+				StorageUri: strPtr("invalid://results"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildResults(tt.outputLocation, tt.folderName, tt.saveRequestRecords)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseMetricsReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		want      []v1beta1.IterationMetrics
+		truncated bool
+		wantErr   bool
+	}{
+		{
+			name: "valid report",
+			message: `{"iterations":[{"scenario":"D(100,100)","concurrency":4,"numRequests":200,` +
+				`"timeToFirstToken":{"mean":"120ms","p99":"450ms","histogram":[{"upperBound":"100ms","count":80},{"upperBound":"500ms","count":120}]}}]}`,
+			want: []v1beta1.IterationMetrics{
+				{
+					Scenario:    "D(100,100)",
+					Concurrency: 4,
+					NumRequests: 200,
+					TimeToFirstToken: &v1beta1.LatencyDistribution{
+						Mean: &metav1.Duration{Duration: 120 * time.Millisecond},
+						P99:  &metav1.Duration{Duration: 450 * time.Millisecond},
+						Histogram: []v1beta1.HistogramBucket{
+							{UpperBound: metav1.Duration{Duration: 100 * time.Millisecond}, Count: 80},
+							{UpperBound: metav1.Duration{Duration: 500 * time.Millisecond}, Count: 120},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "report left out iterations",
+			message:   `{"iterations":[{"scenario":"D(100,100)","concurrency":1}],"truncated":true}`,
+			want:      []v1beta1.IterationMetrics{{Scenario: "D(100,100)", Concurrency: 1}},
+			truncated: true,
+		},
+		{
+			name:      "report cut at the termination message limit",
+			message:   `{"iterations":[` + strings.Repeat(`{"scenario":"D(100,100)","concurrency":1},`, 100),
+			want:      nil,
+			truncated: true,
+		},
+		{
+			name:    "empty message",
+			message: "",
+			want:    nil,
+		},
+		{
+			name:    "invalid report",
+			message: "Error: benchmark failed",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated, err := ParseMetricsReport(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMetricsReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.truncated, truncated)
+			}
+		})
+	}
+}
+
+func TestCompareMetrics(t *testing.T) {
+	throughput := func(v string) *resource.Quantity {
+		q := resource.MustParse(v)
+		return &q
+	}
+	median := func(d time.Duration) *v1beta1.LatencyDistribution {
+		return &v1beta1.LatencyDistribution{P50: &metav1.Duration{Duration: d}}
+	}
+
+	baseline := []v1beta1.IterationMetrics{
+		{
+			Scenario:         "D(100,100)",
+			Concurrency:      1,
+			OutputThroughput: throughput("100"),
+			TimeToFirstToken: median(200 * time.Millisecond),
+		},
+		{
+			Scenario:         "D(100,100)",
+			Concurrency:      8,
+			OutputThroughput: throughput("400"),
+		},
+	}
+	candidate := []v1beta1.IterationMetrics{
+		{
+			Scenario:         "D(100,100)",
+			Concurrency:      1,
+			OutputThroughput: throughput("120"),
+			TimeToFirstToken: median(150 * time.Millisecond),
+		},
+	}
+
+	t.Run("with cost", func(t *testing.T) {
+		got := CompareMetrics(baseline, candidate, throughput("10"), throughput("15"))
+		assert.Len(t, got, 1)
+		assert.Equal(t, "D(100,100)", got[0].Scenario)
+		assert.Equal(t, 1, got[0].Concurrency)
+		assert.Equal(t, int64(20000), got[0].OutputThroughputChange.MilliValue())
+		assert.Equal(t, int64(-25000), got[0].TimeToFirstTokenChange.MilliValue())
+		assert.Equal(t, int64(25000), got[0].CostPerTokenChange.MilliValue())
+		assert.Nil(t, got[0].InterTokenLatencyChange)
+		assert.Nil(t, got[0].EndToEndLatencyChange)
+	})
+
+	t.Run("without cost", func(t *testing.T) {
+		got := CompareMetrics(baseline, candidate, nil, nil)
+		assert.Len(t, got, 1)
+		assert.Nil(t, got[0].CostPerTokenChange)
+	})
+
+	t.Run("no matching iterations", func(t *testing.T) {
+		assert.Empty(t, CompareMetrics(baseline, nil, nil, nil))
+	})
+}
+
 func TestGetInferenceService(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = v1beta1.AddToScheme(scheme)
@@ -455,7 +1033,7 @@ func TestBuildInferenceServiceArgs(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			client := fake.NewClientBuilder().WithScheme(scheme).Build()
 
-			got, err := BuildInferenceServiceArgs(context.TODO(), client, tt.endpointSpec, tt.namespace)
+			got, err := BuildInferenceServiceArgs(context.TODO(), client, client, tt.endpointSpec, tt.namespace)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BuildInferenceServiceArgs() error = %v, wantErr %v", err, tt.wantErr)
 				return
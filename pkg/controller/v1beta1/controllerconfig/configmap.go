@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"text/template"
 
 	v1 "k8s.io/api/core/v1"
@@ -11,6 +14,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
 )
 
 const (
@@ -18,11 +22,28 @@ const (
 	DeployConfigName       = "deploy"
 	MultiNodeProberName    = "multinodeProber"
 	BenchmarkJobConfigName = "benchmarkjob"
+	RuntimeSelectorName    = "runtimeSelector"
+	PolicyConfigName       = "policies"
 
 	DefaultDomainTemplate = "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}"
 	DefaultIngressDomain  = "example.com"
 
 	DefaultUrlScheme = "http"
+
+	// Benchmark clients the BenchmarkJob controller can run
+	BenchmarkRunnerGenAIBench = "genai-bench"
+	BenchmarkRunnerOMEAgent   = "ome-agent"
+
+	// Optional BenchmarkJob features a genai-bench image declares in its features, as they need flags that
+	// older images don't accept
+	BenchmarkFeatureConversation    = "conversation"
+	BenchmarkFeatureWarmup          = "warmup"
+	BenchmarkFeatureReplay          = "replay"
+	BenchmarkFeatureTrafficSchedule = "trafficSchedule"
+	BenchmarkFeatureDatasetStorage  = "datasetStorage"
+	BenchmarkFeatureRequestRecords  = "requestRecords"
+	BenchmarkFeatureMetricsReport   = "metricsReport"
+	BenchmarkFeatureStreaming       = "streaming"
 )
 
 type SecretConfig struct {
@@ -34,6 +55,12 @@ type SecretConfig struct {
 type BenchmarkJobConfig struct {
 	// PodConfig contains all Pod Configuration
 	PodConfig PodConfig `json:"podConfig"`
+	// Runner is the benchmark client of the image, genai-bench (the default) or ome-agent
+	Runner string `json:"runner,omitempty"`
+	// Features lists the optional BenchmarkJob features the genai-bench image supports, see the BenchmarkFeature
+	// constants. BenchmarkJobs using a feature the image doesn't list fail to create their Job, rather than
+	// passing flags the image rejects.
+	Features []string `json:"features,omitempty"`
 }
 
 type PodConfig struct {
@@ -83,6 +110,43 @@ type MultiNodeProberConfig struct {
 	UnavailableThresholdSeconds int32  `json:"unavailableThresholdSeconds"`
 }
 
+// RuntimeSelectorConfig configures the weights of the runtime selector score plugins
+// +kubebuilder:object:generate=false
+type RuntimeSelectorConfig struct {
+	// ScorerWeights maps score plugin names (e.g. "ModelFormat", "ModelSize", "AcceleratorFit")
+	// to their weight. Plugins not listed keep their default weight, zero disables a plugin. Unknown
+	// plugin names are rejected.
+	ScorerWeights map[string]int64 `json:"scorerWeights,omitempty"`
+}
+
+// Actions taken when an InferenceService violates a policy
+const (
+	PolicyActionDeny = "Deny"
+	PolicyActionWarn = "Warn"
+)
+
+// PolicyConfig holds the admission policies cluster admins define for InferenceServices
+// +kubebuilder:object:generate=false
+type PolicyConfig struct {
+	// Policies are evaluated in order by the InferenceService validating webhook
+	Policies []Policy `json:"policies,omitempty"`
+}
+
+// Policy is a CEL rule every InferenceService in its namespaces must satisfy
+// +kubebuilder:object:generate=false
+type Policy struct {
+	// Name identifies the policy in violation messages
+	Name string `json:"name"`
+	// Namespaces the policy applies to, all namespaces if empty
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Expression is a CEL expression over `object`, the InferenceService, that is true when the policy holds
+	Expression string `json:"expression"`
+	// Message is reported when the policy is violated, defaults to the expression
+	Message string `json:"message,omitempty"`
+	// Action is Deny (the default) to reject violating InferenceServices or Warn to admit them with a warning
+	Action string `json:"action,omitempty"`
+}
+
 // +kubebuilder:object:generate=false
 type DeployConfig struct {
 	DefaultDeploymentMode string `json:"defaultDeploymentMode,omitempty"`
@@ -214,3 +278,54 @@ func NewBenchmarkJobConfig(clientset kubernetes.Interface) (*BenchmarkJobConfig,
 	}
 	return benchmarkJobConfig, nil
 }
+
+func NewPolicyConfig(clientset kubernetes.Interface) (*PolicyConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicyConfig(configMap)
+}
+
+// ParsePolicyConfig reads the policies of the inferenceservice ConfigMap
+func ParsePolicyConfig(configMap *v1.ConfigMap) (*PolicyConfig, error) {
+	policyConfig := &PolicyConfig{}
+	if err := getComponentConfig(PolicyConfigName, configMap, policyConfig); err != nil {
+		return nil, err
+	}
+	for i := range policyConfig.Policies {
+		policy := &policyConfig.Policies[i]
+		if policy.Name == "" || policy.Expression == "" {
+			return nil, fmt.Errorf("policy %d must have a name and an expression", i)
+		}
+		switch policy.Action {
+		case "":
+			policy.Action = PolicyActionDeny
+		case PolicyActionDeny, PolicyActionWarn:
+		default:
+			return nil, fmt.Errorf("invalid action %q for policy %s, must be %s or %s", policy.Action, policy.Name, PolicyActionDeny, PolicyActionWarn)
+		}
+	}
+	return policyConfig, nil
+}
+
+func NewRuntimeSelectorConfig(clientset kubernetes.Interface) (*RuntimeSelectorConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	runtimeSelectorConfig := &RuntimeSelectorConfig{}
+	if err := getComponentConfig(RuntimeSelectorName, configMap, runtimeSelectorConfig); err != nil {
+		return nil, err
+	}
+	scorers := runtimeselector.DefaultScorerWeights()
+	for name, weight := range runtimeSelectorConfig.ScorerWeights {
+		if _, ok := scorers[name]; !ok {
+			return nil, fmt.Errorf("unknown runtime selector scorer %s, must be one of %s", name, strings.Join(slices.Sorted(maps.Keys(scorers)), ", "))
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("invalid weight %d for runtime selector scorer %s, weights must not be negative", weight, name)
+		}
+	}
+	return runtimeSelectorConfig, nil
+}
@@ -0,0 +1,69 @@
+package controllerconfig
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ConfigMapCache caches the ConfigMaps with a given name, so that the admission webhooks don't read them from the API
+// server on every request. It is a manager Runnable; until it is started and synced, the ConfigMaps are read from the
+// API server.
+type ConfigMapCache struct {
+	clientset kubernetes.Interface
+	name      string
+	informer  cache.SharedIndexInformer
+	lister    corelisters.ConfigMapLister
+}
+
+// NewConfigMapCache returns a cache of the ConfigMaps named name in namespace, or in all namespaces if empty
+func NewConfigMapCache(clientset kubernetes.Interface, namespace, name string) *ConfigMapCache {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}))
+	configMaps := factory.Core().V1().ConfigMaps()
+	return &ConfigMapCache{
+		clientset: clientset,
+		name:      name,
+		informer:  configMaps.Informer(),
+		lister:    configMaps.Lister(),
+	}
+}
+
+// Get returns the ConfigMap of the namespace, or a NotFound error if it doesn't exist
+func (c *ConfigMapCache) Get(ctx context.Context, namespace string) (*v1.ConfigMap, error) {
+	if !c.informer.HasSynced() {
+		return c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, c.name, metav1.GetOptions{})
+	}
+	return c.lister.ConfigMaps(namespace).Get(c.name)
+}
+
+// AddEventHandler registers a handler notified of the changes of the cached ConfigMaps
+func (c *ConfigMapCache) AddEventHandler(handler cache.ResourceEventHandler) error {
+	_, err := c.informer.AddEventHandler(handler)
+	return err
+}
+
+// Start runs the informer until the context is done
+func (c *ConfigMapCache) Start(ctx context.Context) error {
+	go c.informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), c.informer.HasSynced) {
+		return fmt.Errorf("failed to sync the cache of the %s ConfigMaps", c.name)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection is false as the webhooks of every replica read the cache
+func (c *ConfigMapCache) NeedLeaderElection() bool {
+	return false
+}
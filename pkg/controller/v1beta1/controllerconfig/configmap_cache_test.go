@@ -0,0 +1,48 @@
+package controllerconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapCache(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ome-pod-mutator-config", Namespace: "tenant"},
+		Data:       map[string]string{"key": "before"},
+	})
+	configMaps := NewConfigMapCache(clientset, "", "ome-pod-mutator-config")
+
+	// The API server is read until the cache is synced
+	configMap, err := configMaps.Get(context.Background(), "tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "before", configMap.Data["key"])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = configMaps.Start(ctx)
+	}()
+	require.Eventually(t, configMaps.informer.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	_, err = clientset.CoreV1().ConfigMaps("tenant").Update(context.Background(), &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ome-pod-mutator-config", Namespace: "tenant"},
+		Data:       map[string]string{"key": "after"},
+	}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		configMap, err := configMaps.Get(context.Background(), "tenant")
+		return err == nil && configMap.Data["key"] == "after"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = configMaps.Get(context.Background(), "other")
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.False(t, configMaps.NeedLeaderElection())
+}
@@ -272,6 +272,161 @@ func TestNewDeployConfig(t *testing.T) {
 	}
 }
 
+func TestNewRuntimeSelectorConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		configMapData  map[string]string
+		expectedError  bool
+		validateConfig func(*testing.T, *RuntimeSelectorConfig)
+	}{
+		{
+			name: "valid config",
+			configMapData: map[string]string{
+				RuntimeSelectorName: `{
+					"scorerWeights": {"ModelFormat": 1, "AcceleratorFit": 20}
+				}`,
+			},
+			expectedError: false,
+			validateConfig: func(t *testing.T, cfg *RuntimeSelectorConfig) {
+				assert.Equal(t, map[string]int64{"ModelFormat": 1, "AcceleratorFit": 20}, cfg.ScorerWeights)
+			},
+		},
+		{
+			name: "negative weight",
+			configMapData: map[string]string{
+				RuntimeSelectorName: `{"scorerWeights": {"ModelSize": -1}}`,
+			},
+			expectedError: true,
+		},
+		{
+			name: "unknown scorer",
+			configMapData: map[string]string{
+				RuntimeSelectorName: `{"scorerWeights": {"ModelFormats": 1}}`,
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid json",
+			configMapData: map[string]string{
+				RuntimeSelectorName: `invalid json`,
+			},
+			expectedError: true,
+		},
+		{
+			name:          "empty config",
+			configMapData: map[string]string{},
+			expectedError: false,
+			validateConfig: func(t *testing.T, cfg *RuntimeSelectorConfig) {
+				assert.Empty(t, cfg.ScorerWeights)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewRuntimeSelectorConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, config)
+			if tt.validateConfig != nil {
+				tt.validateConfig(t, config)
+			}
+		})
+	}
+}
+
+func TestNewPolicyConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		configMapData  map[string]string
+		expectedError  bool
+		validateConfig func(*testing.T, *PolicyConfig)
+	}{
+		{
+			name: "valid config",
+			configMapData: map[string]string{
+				PolicyConfigName: `{
+					"policies": [
+						{"name": "prod-replicas", "namespaces": ["prod"], "expression": "object.spec.engine.minReplicas >= 2"},
+						{"name": "named-model", "expression": "has(object.spec.model)", "action": "Warn"}
+					]
+				}`,
+			},
+			validateConfig: func(t *testing.T, cfg *PolicyConfig) {
+				require.Len(t, cfg.Policies, 2)
+				assert.Equal(t, PolicyActionDeny, cfg.Policies[0].Action)
+				assert.Equal(t, []string{"prod"}, cfg.Policies[0].Namespaces)
+				assert.Equal(t, PolicyActionWarn, cfg.Policies[1].Action)
+			},
+		},
+		{
+			name: "missing expression",
+			configMapData: map[string]string{
+				PolicyConfigName: `{"policies": [{"name": "empty"}]}`,
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid action",
+			configMapData: map[string]string{
+				PolicyConfigName: `{"policies": [{"name": "audit", "expression": "true", "action": "Audit"}]}`,
+			},
+			expectedError: true,
+		},
+		{
+			name:          "empty config",
+			configMapData: map[string]string{},
+			validateConfig: func(t *testing.T, cfg *PolicyConfig) {
+				assert.Empty(t, cfg.Policies)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewPolicyConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, config)
+			if tt.validateConfig != nil {
+				tt.validateConfig(t, config)
+			}
+		})
+	}
+}
+
 func TestGetComponentConfig(t *testing.T) {
 	type testStruct struct {
 		Field string `json:"field"`
@@ -0,0 +1,202 @@
+// Package controllerhealth tracks the health of every controller of the manager, i.e. the sync state of the informer
+// of the kind it reconciles, its last successful reconcile and its streak of failed reconciles, and reports it on the
+// probe endpoints of the manager, so that operators can tell which controller is stuck.
+package controllerhealth
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Default is the tracker of the controllers of the manager
+var Default = NewTracker()
+
+// Informers gets the informers of the kinds the controllers reconcile, usually the cache of the manager
+type Informers interface {
+	GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error)
+}
+
+// Thresholds are the limits beyond which a controller is reported unhealthy by the liveness probe. A zero limit
+// is not enforced.
+type Thresholds struct {
+	// MaxErrorStreak is the number of consecutive failed reconciles of a controller
+	MaxErrorStreak int
+	// MaxReconcileDuration is the duration of a reconcile still running, e.g. blocked on a lock or a slow call
+	MaxReconcileDuration time.Duration
+}
+
+// Status is the health of a controller
+type Status struct {
+	// Name is the name of the controller
+	Name string `json:"name"`
+	// Kind is the kind of the objects the controller reconciles
+	Kind string `json:"kind"`
+	// InformerSynced reports whether the informer of the kind has synced
+	InformerSynced bool `json:"informerSynced"`
+	// LastSuccessfulReconcile is when the last reconcile that did not fail completed
+	LastSuccessfulReconcile *time.Time `json:"lastSuccessfulReconcile,omitempty"`
+	// LastFailedReconcile is when the last failed reconcile completed
+	LastFailedReconcile *time.Time `json:"lastFailedReconcile,omitempty"`
+	// LastError is the error of the last failed reconcile
+	LastError string `json:"lastError,omitempty"`
+	// ErrorStreak is the number of failed reconciles since the last successful one
+	ErrorStreak int `json:"errorStreak"`
+	// ActiveReconciles is the number of reconciles running
+	ActiveReconciles int `json:"activeReconciles"`
+	// LongestActiveReconcile is for how long the oldest reconcile running has been running
+	LongestActiveReconcile string `json:"longestActiveReconcile,omitempty"`
+	// Live reports whether the controller is within the thresholds of the liveness probe
+	Live bool `json:"live"`
+	// Ready reports whether the controller is ready to reconcile, i.e. whether its informer synced
+	Ready bool `json:"ready"`
+	// Reasons explains why the controller is not live or not ready
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Tracker tracks the health of the controllers from the reconciles recorded by Reconciler
+type Tracker struct {
+	mu          sync.Mutex
+	controllers map[string]*controller
+	now         func() time.Time
+}
+
+type controller struct {
+	obj         client.Object
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+	errorStreak int
+	nextID      uint64
+	active      map[uint64]time.Time
+}
+
+// NewTracker returns a tracker of no controller
+func NewTracker() *Tracker {
+	return &Tracker{controllers: map[string]*controller{}, now: time.Now}
+}
+
+// Reconciler returns a reconciler recording every reconcile of the controller in the default tracker. obj is the
+// kind the controller reconciles, whose informer sync state is reported.
+func Reconciler(controllerName string, obj client.Object, r reconcile.Reconciler) reconcile.Reconciler {
+	return Default.Reconciler(controllerName, obj, r)
+}
+
+// Reconciler returns a reconciler recording every reconcile of the controller in the tracker. obj is the kind the
+// controller reconciles, whose informer sync state is reported.
+func (t *Tracker) Reconciler(controllerName string, obj client.Object, r reconcile.Reconciler) reconcile.Reconciler {
+	t.register(controllerName, obj)
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		id := t.start(controllerName)
+		result, err := r.Reconcile(ctx, req)
+		t.finish(controllerName, id, err)
+		return result, err
+	})
+}
+
+func (t *Tracker) register(controllerName string, obj client.Object) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.controllers[controllerName] = &controller{obj: obj, active: map[uint64]time.Time{}}
+}
+
+func (t *Tracker) start(controllerName string) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := t.controllers[controllerName]
+	c.nextID++
+	c.active[c.nextID] = t.now()
+	return c.nextID
+}
+
+func (t *Tracker) finish(controllerName string, id uint64, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c := t.controllers[controllerName]
+	delete(c.active, id)
+	if err != nil {
+		c.lastFailure = t.now()
+		c.lastError = err.Error()
+		c.errorStreak++
+		return
+	}
+	c.lastSuccess = t.now()
+	c.errorStreak = 0
+}
+
+// Controllers returns the names of the tracked controllers, sorted
+func (t *Tracker) Controllers() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := make([]string, 0, len(t.controllers))
+	for name := range t.controllers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Status returns the health of the controller, checking the sync state of its informer in the informers
+func (t *Tracker) Status(ctx context.Context, controllerName string, informers Informers, thresholds Thresholds) (Status, error) {
+	t.mu.Lock()
+	c, ok := t.controllers[controllerName]
+	if !ok {
+		t.mu.Unlock()
+		return Status{}, fmt.Errorf("unknown controller %q", controllerName)
+	}
+	now := t.now()
+	status := Status{
+		Name:             controllerName,
+		Kind:             reflect.TypeOf(c.obj).Elem().Name(),
+		LastError:        c.lastError,
+		ErrorStreak:      c.errorStreak,
+		ActiveReconciles: len(c.active),
+	}
+	if !c.lastSuccess.IsZero() {
+		lastSuccess := c.lastSuccess
+		status.LastSuccessfulReconcile = &lastSuccess
+	}
+	if !c.lastFailure.IsZero() {
+		lastFailure := c.lastFailure
+		status.LastFailedReconcile = &lastFailure
+	}
+	var longest time.Duration
+	for _, started := range c.active {
+		longest = max(longest, now.Sub(started))
+	}
+	obj := c.obj
+	t.mu.Unlock()
+
+	if status.ActiveReconciles > 0 {
+		status.LongestActiveReconcile = longest.Round(time.Millisecond).String()
+	}
+
+	status.Live = true
+	if thresholds.MaxErrorStreak > 0 && status.ErrorStreak >= thresholds.MaxErrorStreak {
+		status.Live = false
+		status.Reasons = append(status.Reasons, fmt.Sprintf("%d consecutive failed reconciles", status.ErrorStreak))
+	}
+	if thresholds.MaxReconcileDuration > 0 && longest >= thresholds.MaxReconcileDuration {
+		status.Live = false
+		status.Reasons = append(status.Reasons, fmt.Sprintf("a reconcile has been running for %s", status.LongestActiveReconcile))
+	}
+
+	informer, err := informers.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+	switch {
+	case err != nil:
+		status.Reasons = append(status.Reasons, fmt.Sprintf("failed to get the %s informer: %v", status.Kind, err))
+	case informer.HasSynced():
+		status.InformerSynced = true
+	default:
+		status.Reasons = append(status.Reasons, fmt.Sprintf("the %s informer has not synced", status.Kind))
+	}
+	status.Ready = status.InformerSynced
+	return status, nil
+}
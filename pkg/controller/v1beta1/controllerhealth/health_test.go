@@ -0,0 +1,140 @@
+package controllerhealth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeInformer struct {
+	cache.Informer
+	synced bool
+}
+
+func (i *fakeInformer) HasSynced() bool {
+	return i.synced
+}
+
+type fakeInformers struct {
+	synced bool
+	err    error
+}
+
+func (f *fakeInformers) GetInformer(context.Context, client.Object, ...cache.InformerGetOption) (cache.Informer, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &fakeInformer{synced: f.synced}, nil
+}
+
+func newTestTracker(now *time.Time) *Tracker {
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestTrackerErrorStreak(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2025, 6, 12, 9, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(&now)
+
+	var err error
+	r := tracker.Reconciler("test", &corev1.ConfigMap{}, reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, err
+	}))
+	informers := &fakeInformers{synced: true}
+	thresholds := Thresholds{MaxErrorStreak: 2}
+
+	status, statusErr := tracker.Status(context.Background(), "test", informers, thresholds)
+	g.Expect(statusErr).NotTo(gomega.HaveOccurred())
+	g.Expect(status.Kind).To(gomega.Equal("ConfigMap"))
+	g.Expect(status.LastSuccessfulReconcile).To(gomega.BeNil())
+	g.Expect(status.Live).To(gomega.BeTrue())
+	g.Expect(status.Ready).To(gomega.BeTrue())
+
+	_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+	succeeded := now
+	now = now.Add(time.Minute)
+	err = errors.New("boom")
+	_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+	_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+
+	status, _ = tracker.Status(context.Background(), "test", informers, thresholds)
+	g.Expect(*status.LastSuccessfulReconcile).To(gomega.Equal(succeeded))
+	g.Expect(*status.LastFailedReconcile).To(gomega.Equal(now))
+	g.Expect(status.LastError).To(gomega.Equal("boom"))
+	g.Expect(status.ErrorStreak).To(gomega.Equal(2))
+	g.Expect(status.Live).To(gomega.BeFalse())
+	g.Expect(status.Reasons).To(gomega.ConsistOf("2 consecutive failed reconciles"))
+
+	err = nil
+	_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+	status, _ = tracker.Status(context.Background(), "test", informers, thresholds)
+	g.Expect(status.ErrorStreak).To(gomega.BeZero())
+	g.Expect(status.Live).To(gomega.BeTrue())
+	g.Expect(status.LastError).To(gomega.Equal("boom"))
+}
+
+func TestTrackerActiveReconciles(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	now := time.Date(2025, 6, 12, 9, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(&now)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := tracker.Reconciler("test", &corev1.ConfigMap{}, reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		close(started)
+		<-release
+		return reconcile.Result{}, nil
+	}))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = r.Reconcile(context.Background(), reconcile.Request{})
+	}()
+	<-started
+
+	tracker.mu.Lock()
+	now = now.Add(10 * time.Minute)
+	tracker.mu.Unlock()
+	status, err := tracker.Status(context.Background(), "test", &fakeInformers{synced: true}, Thresholds{MaxReconcileDuration: 5 * time.Minute})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(status.ActiveReconciles).To(gomega.Equal(1))
+	g.Expect(status.LongestActiveReconcile).To(gomega.Equal("10m0s"))
+	g.Expect(status.Live).To(gomega.BeFalse())
+
+	close(release)
+	<-done
+	status, _ = tracker.Status(context.Background(), "test", &fakeInformers{synced: true}, Thresholds{MaxReconcileDuration: 5 * time.Minute})
+	g.Expect(status.ActiveReconciles).To(gomega.BeZero())
+	g.Expect(status.Live).To(gomega.BeTrue())
+}
+
+func TestTrackerInformerSync(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tracker := NewTracker()
+	tracker.Reconciler("test", &corev1.ConfigMap{}, reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	}))
+
+	status, err := tracker.Status(context.Background(), "test", &fakeInformers{}, Thresholds{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(status.InformerSynced).To(gomega.BeFalse())
+	g.Expect(status.Ready).To(gomega.BeFalse())
+	g.Expect(status.Live).To(gomega.BeTrue())
+	g.Expect(status.Reasons).To(gomega.ConsistOf("the ConfigMap informer has not synced"))
+
+	status, _ = tracker.Status(context.Background(), "test", &fakeInformers{err: errors.New("no kind")}, Thresholds{})
+	g.Expect(status.Ready).To(gomega.BeFalse())
+	g.Expect(status.Reasons).To(gomega.ConsistOf("failed to get the ConfigMap informer: no kind"))
+
+	_, err = tracker.Status(context.Background(), "unknown", &fakeInformers{}, Thresholds{})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
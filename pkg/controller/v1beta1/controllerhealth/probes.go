@@ -0,0 +1,154 @@
+package controllerhealth
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Paths of the probes served by Probes, the same as those of controller-runtime
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// CheckPrefix prefixes the names of the checks of the controllers, e.g. controller-inferenceservice
+const CheckPrefix = "controller-"
+
+// probeShutdownTimeout bounds the shutdown of the probe server
+const probeShutdownTimeout = 10 * time.Second
+
+// Probes serves the liveness and readiness probes of the manager in place of those of controller-runtime. Besides
+// the checks added, it checks every controller of the tracker: a controller is live while it is within the
+// thresholds, and ready once the informer of the kind it reconciles synced. The requests accepting JSON get the
+// health of every controller along with the result of the checks.
+type Probes struct {
+	tracker    *Tracker
+	informers  Informers
+	thresholds Thresholds
+	liveness   map[string]healthz.Checker
+	readiness  map[string]healthz.Checker
+}
+
+// Report is the JSON body of a probe
+type Report struct {
+	// Status is ok if every check passed, else failed
+	Status string `json:"status"`
+	// Checks is the result of every check, ok or the reason it failed
+	Checks map[string]string `json:"checks"`
+	// Controllers is the health of every controller
+	Controllers []Status `json:"controllers"`
+}
+
+// NewProbes returns the probes of the controllers of the tracker, checking the sync state of their informers in the
+// informers
+func NewProbes(tracker *Tracker, informers Informers, thresholds Thresholds) *Probes {
+	return &Probes{
+		tracker:    tracker,
+		informers:  informers,
+		thresholds: thresholds,
+		liveness:   map[string]healthz.Checker{},
+		readiness:  map[string]healthz.Checker{},
+	}
+}
+
+// AddHealthzCheck adds a check to the liveness probe
+func (p *Probes) AddHealthzCheck(name string, check healthz.Checker) {
+	p.liveness[name] = check
+}
+
+// AddReadyzCheck adds a check to the readiness probe
+func (p *Probes) AddReadyzCheck(name string, check healthz.Checker) {
+	p.readiness[name] = check
+}
+
+// Handler returns the handler of the probes, serving the liveness probe on LivenessPath and the readiness probe on
+// ReadinessPath, and every check of a probe on its path followed by the name of the check
+func (p *Probes) Handler() http.Handler {
+	mux := http.NewServeMux()
+	live := http.StripPrefix(LivenessPath, p.probeHandler(p.liveness, func(status Status) bool { return status.Live }))
+	mux.Handle(LivenessPath, live)
+	mux.Handle(LivenessPath+"/", live)
+	ready := http.StripPrefix(ReadinessPath, p.probeHandler(p.readiness, func(status Status) bool { return status.Ready }))
+	mux.Handle(ReadinessPath, ready)
+	mux.Handle(ReadinessPath+"/", ready)
+	return mux
+}
+
+// Server returns the server of the probes on addr, to add to the manager
+func (p *Probes) Server(addr string) *manager.Server {
+	shutdownTimeout := probeShutdownTimeout
+	return &manager.Server{
+		Name:            "health probe",
+		Server:          &http.Server{Addr: addr, Handler: p.Handler(), ReadHeaderTimeout: 32 * time.Second},
+		ShutdownTimeout: &shutdownTimeout,
+	}
+}
+
+// probeHandler serves the checks along with a check of every controller passing if ok
+func (p *Probes) probeHandler(checks map[string]healthz.Checker, ok func(Status) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		all := make(map[string]healthz.Checker, len(checks))
+		for name, check := range checks {
+			all[name] = check
+		}
+		statuses := []Status{}
+		for _, name := range p.tracker.Controllers() {
+			status, err := p.tracker.Status(req.Context(), name, p.informers, p.thresholds)
+			if err != nil {
+				continue
+			}
+			statuses = append(statuses, status)
+			all[CheckPrefix+name] = func(*http.Request) error {
+				if !ok(status) {
+					return errors.New(strings.Join(status.Reasons, ", "))
+				}
+				return nil
+			}
+		}
+
+		if (req.URL.Path == "" || req.URL.Path == "/") && acceptsJSON(req) {
+			writeReport(w, req, all, statuses)
+			return
+		}
+		(&healthz.Handler{Checks: all}).ServeHTTP(w, req)
+	})
+}
+
+func writeReport(w http.ResponseWriter, req *http.Request, checks map[string]healthz.Checker, statuses []Status) {
+	report := Report{Status: "ok", Checks: make(map[string]string, len(checks)), Controllers: statuses}
+	for name, check := range checks {
+		if err := check(req); err != nil {
+			report.Status = "failed"
+			report.Checks[name] = err.Error()
+			continue
+		}
+		report.Checks[name] = "ok"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if report.Status != "ok" {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// acceptsJSON reports whether the request accepts a JSON response, i.e. whether it lists application/json in its
+// Accept header or asks for ?format=json
+func acceptsJSON(req *http.Request) bool {
+	if req.URL.Query().Get("format") == "json" {
+		return true
+	}
+	return slices.ContainsFunc(strings.Split(req.Header.Get("Accept"), ","), func(accept string) bool {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		return err == nil && mediaType == "application/json"
+	})
+}
@@ -0,0 +1,95 @@
+package controllerhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestProbes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tracker := NewTracker()
+	failing := tracker.Reconciler("failing", &corev1.ConfigMap{}, reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, errors.New("boom")
+	}))
+	tracker.Reconciler("healthy", &corev1.Secret{}, reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	}))
+	for range 3 {
+		_, _ = failing.Reconcile(context.Background(), reconcile.Request{})
+	}
+
+	informers := &fakeInformers{synced: false}
+	probes := NewProbes(tracker, informers, Thresholds{MaxErrorStreak: 3})
+	probes.AddHealthzCheck("healthz", func(*http.Request) error { return nil })
+	probes.AddReadyzCheck("readyz", func(*http.Request) error { return nil })
+	handler := probes.Handler()
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Text, like the probes of controller-runtime
+	w := serve(LivenessPath, nil)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("[-]controller-failing failed"))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("[+]controller-healthy ok"))
+	g.Expect(serve(LivenessPath+"/controller-healthy", nil).Code).To(gomega.Equal(http.StatusOK))
+	w = serve(LivenessPath+"/controller-failing", nil)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+	g.Expect(w.Body.String()).To(gomega.ContainSubstring("3 consecutive failed reconciles"))
+	g.Expect(serve(ReadinessPath+"/controller-healthy", nil).Code).To(gomega.Equal(http.StatusInternalServerError))
+
+	// JSON
+	w = serve(LivenessPath, http.Header{"Accept": {"application/json"}})
+	g.Expect(w.Code).To(gomega.Equal(http.StatusInternalServerError))
+	g.Expect(w.Header().Get("Content-Type")).To(gomega.Equal("application/json"))
+	var report Report
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(gomega.Succeed())
+	g.Expect(report.Status).To(gomega.Equal("failed"))
+	g.Expect(report.Checks).To(gomega.Equal(map[string]string{
+		"healthz":            "ok",
+		"controller-failing": "3 consecutive failed reconciles, the ConfigMap informer has not synced",
+		"controller-healthy": "ok",
+	}))
+	g.Expect(report.Controllers).To(gomega.HaveLen(2))
+	g.Expect(report.Controllers[0].Name).To(gomega.Equal("failing"))
+	g.Expect(report.Controllers[0].ErrorStreak).To(gomega.Equal(3))
+	g.Expect(report.Controllers[0].LastError).To(gomega.Equal("boom"))
+	g.Expect(report.Controllers[1].Name).To(gomega.Equal("healthy"))
+
+	informers.synced = true
+	w = serve(ReadinessPath+"?format=json", nil)
+	g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &report)).To(gomega.Succeed())
+	g.Expect(report.Status).To(gomega.Equal("ok"))
+	g.Expect(report.Checks).To(gomega.HaveKeyWithValue("readyz", "ok"))
+	g.Expect(report.Checks).To(gomega.HaveKeyWithValue("controller-failing", "ok"))
+}
+
+func TestAcceptsJSON(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for accept, expected := range map[string]bool{
+		"":                                   false,
+		"text/plain":                         false,
+		"application/json":                   true,
+		"text/plain, application/json;q=0.9": true,
+	} {
+		req := httptest.NewRequest(http.MethodGet, ReadinessPath, nil)
+		req.Header.Set("Accept", accept)
+		g.Expect(acceptsJSON(req)).To(gomega.Equal(expected), accept)
+	}
+}
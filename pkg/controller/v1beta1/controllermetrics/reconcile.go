@@ -0,0 +1,93 @@
+// Package controllermetrics reports the outcomes of the reconciles and the states of the custom resources of the
+// controllers on the metrics endpoint of the manager.
+package controllermetrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reconcile outcomes reported by the reconcileOutcomesTotal counter
+const (
+	OutcomeSuccess = "success"
+	OutcomeRequeue = "requeue"
+	OutcomeError   = "error"
+)
+
+// ReasonUnknown is the reason of the failed reconciles whose error has no reason
+const ReasonUnknown = "Unknown"
+
+var reconcileOutcomesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ome_controller_reconcile_outcomes_total",
+	Help: "Number of reconciles by controller, outcome (success, requeue, error) and reason of the failed reconciles",
+}, []string{"controller", "outcome", "reason"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reconcileOutcomesTotal)
+}
+
+// reasonError is an error of a reconcile with the reason it is counted under
+type reasonError struct {
+	reason string
+	err    error
+}
+
+func (e *reasonError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reasonError) Unwrap() error {
+	return e.err
+}
+
+// WithReason annotates the error of a reconcile with the reason it is counted under, e.g. the reason of the warning
+// event recorded for it. It returns nil for a nil error.
+func WithReason(reason string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &reasonError{reason: reason, err: err}
+}
+
+// Reason returns the reason a reconcile error is counted under: the reason it was annotated with, else the reason of
+// the failed Kubernetes API call, e.g. Conflict, else Unknown
+func Reason(err error) string {
+	var re *reasonError
+	if errors.As(err, &re) {
+		return re.reason
+	}
+	if reason := apierrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return string(metav1.StatusReasonTimeout)
+	}
+	return ReasonUnknown
+}
+
+// Reconciler returns a reconciler counting the outcome of every reconcile of the controller
+func Reconciler(controllerName string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		result, err := r.Reconcile(ctx, req)
+		RecordOutcome(controllerName, result, err)
+		return result, err
+	})
+}
+
+// RecordOutcome counts the outcome of a reconcile of the controller
+func RecordOutcome(controllerName string, result reconcile.Result, err error) {
+	switch {
+	case err != nil:
+		reconcileOutcomesTotal.WithLabelValues(controllerName, OutcomeError, Reason(err)).Inc()
+	case result.Requeue || result.RequeueAfter > 0:
+		reconcileOutcomesTotal.WithLabelValues(controllerName, OutcomeRequeue, "").Inc()
+	default:
+		reconcileOutcomesTotal.WithLabelValues(controllerName, OutcomeSuccess, "").Inc()
+	}
+}
@@ -0,0 +1,62 @@
+package controllermetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReason(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	conflict := apierrors.NewConflict(schema.GroupResource{Group: "ome.io", Resource: "inferenceservices"}, "llama", errors.New("modified"))
+	g.Expect(Reason(WithReason("RuntimeSelectionError", errors.New("no runtime")))).To(gomega.Equal("RuntimeSelectionError"))
+	g.Expect(Reason(fmt.Errorf("fails to reconcile ingress: %w", WithReason("IngressReconcileError", conflict)))).To(gomega.Equal("IngressReconcileError"))
+	g.Expect(Reason(fmt.Errorf("fails to update status: %w", conflict))).To(gomega.Equal("Conflict"))
+	g.Expect(Reason(fmt.Errorf("list failed: %w", context.DeadlineExceeded))).To(gomega.Equal("Timeout"))
+	g.Expect(Reason(errors.New("boom"))).To(gomega.Equal(ReasonUnknown))
+
+	g.Expect(WithReason("RuntimeSelectionError", nil)).To(gomega.BeNil())
+	g.Expect(errors.Is(WithReason("IngressReconcileError", conflict), conflict)).To(gomega.BeTrue())
+}
+
+func TestReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	results := []struct {
+		result reconcile.Result
+		err    error
+	}{
+		{reconcile.Result{}, nil},
+		{reconcile.Result{RequeueAfter: time.Second}, nil},
+		{reconcile.Result{}, WithReason("ModelReconcileError", errors.New("model not ready"))},
+		{reconcile.Result{}, errors.New("boom")},
+	}
+	calls := 0
+	r := Reconciler("test", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		calls++
+		return results[calls-1].result, results[calls-1].err
+	}))
+	for _, expected := range results {
+		result, err := r.Reconcile(context.Background(), reconcile.Request{})
+		g.Expect(result).To(gomega.Equal(expected.result))
+		g.Expect(err == expected.err).To(gomega.BeTrue())
+	}
+
+	for _, labels := range [][]string{
+		{"test", OutcomeSuccess, ""},
+		{"test", OutcomeRequeue, ""},
+		{"test", OutcomeError, "ModelReconcileError"},
+		{"test", OutcomeError, ReasonUnknown},
+	} {
+		g.Expect(testutil.ToFloat64(reconcileOutcomesTotal.WithLabelValues(labels...))).To(gomega.Equal(1.0), fmt.Sprint(labels))
+	}
+}
@@ -0,0 +1,88 @@
+package controllermetrics
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// listTimeout bounds the listing of the resources at a scrape, the cache answers it without calling the API server
+const listTimeout = 10 * time.Second
+
+var log = logf.Log.WithName("controllermetrics")
+
+// StateCounts counts resources by the label values of their state
+type StateCounts struct {
+	counts      map[string]float64
+	labelValues map[string][]string
+}
+
+// Add counts a resource with the label values
+func (s *StateCounts) Add(labelValues ...string) {
+	key := strings.Join(labelValues, "\x00")
+	if _, ok := s.counts[key]; !ok {
+		s.labelValues[key] = labelValues
+	}
+	s.counts[key]++
+}
+
+// CountFunc lists the resources of a kind with the reader and counts them by state
+type CountFunc func(ctx context.Context, reader client.Reader, counts *StateCounts) error
+
+// StateCollector is a gauge counting the resources of a kind by state. The resources are listed at every scrape,
+// so that the gauge never reports deleted resources.
+type StateCollector struct {
+	desc  *prometheus.Desc
+	count CountFunc
+
+	mu     sync.RWMutex
+	reader client.Reader
+}
+
+// NewStateCollector returns a collector reporting the gauge with the name and labels, whose values are counted by the
+// function. It reports nothing until it has a reader.
+func NewStateCollector(name, help string, labels []string, count CountFunc) *StateCollector {
+	return &StateCollector{
+		desc:  prometheus.NewDesc(name, help, labels, nil),
+		count: count,
+	}
+}
+
+// SetReader sets the reader the resources are listed with, usually the client of the manager reading from its cache
+func (c *StateCollector) SetReader(reader client.Reader) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reader = reader
+}
+
+// Describe implements prometheus.Collector
+func (c *StateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *StateCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	reader := c.reader
+	c.mu.RUnlock()
+	if reader == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+	counts := &StateCounts{counts: map[string]float64{}, labelValues: map[string][]string{}}
+	if err := c.count(ctx, reader, counts); err != nil {
+		// A failed scrape of the whole endpoint would also drop the controller-runtime metrics
+		log.Error(err, "Failed to count resources", "metric", c.desc.String())
+		return
+	}
+	for key, count := range counts.counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, count, counts.labelValues[key]...)
+	}
+}
@@ -0,0 +1,52 @@
+package controllermetrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func countPods(ctx context.Context, reader client.Reader, counts *StateCounts) error {
+	pods := &corev1.PodList{}
+	if err := reader.List(ctx, pods); err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		counts.Add(pod.Namespace, string(pod.Status.Phase))
+	}
+	return nil
+}
+
+func TestStateCollector(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	pod := func(namespace, name string, phase corev1.PodPhase) client.Object {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	collector := NewStateCollector("test_pods", "Number of pods by namespace and phase", []string{"namespace", "phase"}, countPods)
+	g.Expect(testutil.CollectAndCount(collector)).To(gomega.Equal(0))
+
+	collector.SetReader(fake.NewClientBuilder().WithObjects(
+		pod("serving", "llama-0", corev1.PodRunning),
+		pod("serving", "llama-1", corev1.PodRunning),
+		pod("serving", "llama-2", corev1.PodPending),
+		pod("batch", "bench-0", corev1.PodSucceeded),
+	).Build())
+	g.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP test_pods Number of pods by namespace and phase
+# TYPE test_pods gauge
+test_pods{namespace="batch",phase="Succeeded"} 1
+test_pods{namespace="serving",phase="Pending"} 1
+test_pods{namespace="serving",phase="Running"} 2
+`))).To(gomega.Succeed())
+}
@@ -0,0 +1,147 @@
+// Package eventrecorder wraps the event recorders of the controllers to drop the Warning events repeated for an object,
+// e.g. by a download failing at every reconcile, which would otherwise write thousands of identical events to etcd.
+package eventrecorder
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultWindow is the default window within which the identical Warning events of an object are dropped
+const DefaultWindow = 5 * time.Minute
+
+var eventsSuppressedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ome_controller_events_suppressed_total",
+	Help: "Number of Warning events dropped as duplicates of an event recorded for the same object, by reason",
+}, []string{"reason"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(eventsSuppressedTotal)
+}
+
+// Deduplicating is an event recorder recording a Warning event of an object only if no identical Warning event, i.e.
+// with the same reason and message, was recorded for the object within the window. The next event recorded once the
+// window elapsed tells how many were dropped. The Normal events are always recorded.
+type Deduplicating struct {
+	recorder record.EventRecorder
+	window   time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	events    map[eventKey]*recordedEvent
+	lastPrune time.Time
+}
+
+var _ record.EventRecorder = &Deduplicating{}
+
+type eventKey struct {
+	object  string
+	reason  string
+	message string
+}
+
+type recordedEvent struct {
+	recorded   time.Time
+	suppressed int
+}
+
+// NewDeduplicating returns the recorder dropping the duplicate Warning events of recorder within the window. It
+// returns recorder itself if the window is not positive.
+func NewDeduplicating(recorder record.EventRecorder, window time.Duration) record.EventRecorder {
+	if window <= 0 {
+		return recorder
+	}
+	return &Deduplicating{
+		recorder: recorder,
+		window:   window,
+		now:      time.Now,
+		events:   map[eventKey]*recordedEvent{},
+	}
+}
+
+// Event records the event unless it duplicates a Warning event of the object recorded within the window
+func (d *Deduplicating) Event(object runtime.Object, eventtype, reason, message string) {
+	if message, ok := d.admit(object, eventtype, reason, message); ok {
+		d.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// Eventf records the event unless it duplicates a Warning event of the object recorded within the window
+func (d *Deduplicating) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := d.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		d.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// AnnotatedEventf records the event unless it duplicates a Warning event of the object recorded within the window,
+// regardless of the annotations
+func (d *Deduplicating) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := d.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		d.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// admit reports whether the event is to be recorded, with its message telling how many duplicates were dropped since
+// it was last recorded
+func (d *Deduplicating) admit(object runtime.Object, eventtype, reason, message string) (string, bool) {
+	if eventtype != corev1.EventTypeWarning {
+		return message, true
+	}
+	key := eventKey{object: objectKey(object), reason: reason, message: message}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	d.prune(now)
+	event, ok := d.events[key]
+	if !ok {
+		d.events[key] = &recordedEvent{recorded: now}
+		return message, true
+	}
+	if now.Sub(event.recorded) < d.window {
+		event.suppressed++
+		eventsSuppressedTotal.WithLabelValues(reason).Inc()
+		return "", false
+	}
+	if event.suppressed > 0 {
+		message = fmt.Sprintf("%s (%d identical events dropped in the last %s)", message, event.suppressed, now.Sub(event.recorded).Round(time.Second))
+	}
+	event.recorded = now
+	event.suppressed = 0
+	return message, true
+}
+
+// prune forgets the events recorded before the window, at most once per window, so that the events of deleted
+// objects do not accumulate
+func (d *Deduplicating) prune(now time.Time) {
+	if now.Sub(d.lastPrune) < d.window {
+		return
+	}
+	d.lastPrune = now
+	for key, event := range d.events {
+		// The events with dropped duplicates are kept to report them, until recorded again or for another window
+		if now.Sub(event.recorded) >= d.window && (event.suppressed == 0 || now.Sub(event.recorded) >= 2*d.window) {
+			delete(d.events, key)
+		}
+	}
+}
+
+// objectKey identifies the object of an event, by UID if set, else by type, namespace and name
+func objectKey(object runtime.Object) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return fmt.Sprintf("%T", object)
+	}
+	if uid := accessor.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return fmt.Sprintf("%T/%s/%s", object, accessor.GetNamespace(), accessor.GetName())
+}
@@ -0,0 +1,73 @@
+package eventrecorder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func drain(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestDeduplicating(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(100)
+	now := time.Date(2025, 6, 12, 9, 0, 0, 0, time.UTC)
+	recorder := NewDeduplicating(fake, time.Minute).(*Deduplicating)
+	recorder.now = func() time.Time { return now }
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pod", UID: "uid-1"}}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other"}}
+
+	for range 3 {
+		recorder.Eventf(pod, corev1.EventTypeWarning, "DownloadFailed", "failed to download %s", "model")
+		recorder.Event(pod, corev1.EventTypeNormal, "Pulling", "pulling")
+	}
+	recorder.Event(pod, corev1.EventTypeWarning, "DownloadFailed", "failed to download weights")
+	recorder.Event(other, corev1.EventTypeWarning, "DownloadFailed", "failed to download model")
+	recorder.AnnotatedEventf(other, map[string]string{"a": "b"}, corev1.EventTypeWarning, "DownloadFailed", "failed to download model")
+	g.Expect(drain(fake)).To(gomega.Equal([]string{
+		"Warning DownloadFailed failed to download model",
+		"Normal Pulling pulling",
+		"Normal Pulling pulling",
+		"Normal Pulling pulling",
+		"Warning DownloadFailed failed to download weights",
+		"Warning DownloadFailed failed to download model",
+	}))
+
+	now = now.Add(30 * time.Second)
+	recorder.Event(pod, corev1.EventTypeWarning, "DownloadFailed", "failed to download model")
+	g.Expect(drain(fake)).To(gomega.BeEmpty())
+
+	now = now.Add(time.Minute)
+	recorder.Event(pod, corev1.EventTypeWarning, "DownloadFailed", "failed to download model")
+	recorder.Event(pod, corev1.EventTypeWarning, "DownloadFailed", "failed to download weights")
+	g.Expect(drain(fake)).To(gomega.Equal([]string{
+		"Warning DownloadFailed failed to download model (3 identical events dropped in the last 1m30s)",
+		"Warning DownloadFailed failed to download weights",
+	}))
+	// The event of the other pod is kept to report its dropped duplicate, until pruned after another window
+	g.Expect(recorder.events).To(gomega.HaveLen(3))
+	now = now.Add(time.Minute)
+	recorder.Event(pod, corev1.EventTypeWarning, "DownloadFailed", "failed to download model")
+	g.Expect(recorder.events).To(gomega.HaveLen(1))
+}
+
+func TestNewDeduplicatingDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fake := record.NewFakeRecorder(1)
+	g.Expect(NewDeduplicating(fake, 0)).To(gomega.BeIdenticalTo(fake))
+}
@@ -0,0 +1,155 @@
+// Package finalizer runs the cleanup of the objects of the controllers before they are deleted. A Finalizer registers
+// itself on the objects, runs its cleanup steps in order once an object is being deleted, and removes itself when they
+// completed, or forcibly once the deletion has been waiting for too long, so that a cleanup that cannot complete, e.g.
+// waiting for the model agents of nodes that are gone, does not block the deletion of the object and its namespace.
+package finalizer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DefaultMaxWait is the default duration after which the finalizers are forcibly removed from the objects being
+// deleted
+const DefaultMaxWait = 30 * time.Minute
+
+// defaultRequeueAfter is the default interval at which the steps of a pending cleanup are run again
+const defaultRequeueAfter = 10 * time.Second
+
+// ReasonForcedRemoval is the reason of the Warning event recorded when a finalizer is forcibly removed
+const ReasonForcedRemoval = "FinalizerForcedRemoval"
+
+var forcedRemovalsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ome_controller_finalizer_forced_removals_total",
+	Help: "Number of finalizers removed from the objects being deleted before their cleanup completed, by finalizer and step",
+}, []string{"finalizer", "step"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(forcedRemovalsTotal)
+}
+
+// Outcome is what Finalize did
+type Outcome int
+
+const (
+	// NotDeleting means the object is not being deleted. The finalizer is registered on it.
+	NotDeleting Outcome = iota
+	// Pending means the cleanup of the object being deleted is in progress
+	Pending
+	// Removed means the cleanup completed and the finalizer was removed
+	Removed
+	// ForciblyRemoved means the cleanup did not complete within the max wait and the finalizer was removed anyway
+	ForciblyRemoved
+	// Absent means the object is being deleted without the finalizer, e.g. it was removed by a previous reconcile
+	Absent
+)
+
+// Step is a cleanup step of the objects being deleted. It returns false while the cleanup is in progress, e.g. while
+// waiting for another component, and an error if it failed; both are retried.
+type Step struct {
+	// Name identifies the step in the logs, events and metrics
+	Name string
+	// Cleanup runs the step for the object
+	Cleanup func(ctx context.Context, obj client.Object) (bool, error)
+}
+
+// Finalizer is a finalizer of the objects of a controller
+type Finalizer struct {
+	// Name is the finalizer added to the objects
+	Name string
+	// Steps are run in order. A step is run once the previous ones completed; they are run again until all complete.
+	Steps []Step
+	// MaxWait is the duration since the deletion of an object was requested after which the finalizer is removed
+	// even if the steps did not complete. The finalizer is never forcibly removed if zero.
+	MaxWait time.Duration
+	// RequeueAfter is the interval at which the steps of a pending cleanup are run again, 10 seconds if zero
+	RequeueAfter time.Duration
+	// Recorder records a Warning event on the objects whose finalizer is forcibly removed, none if nil
+	Recorder record.EventRecorder
+
+	now func() time.Time
+}
+
+// Finalize registers the finalizer on the object if it is not being deleted, else runs the steps of the cleanup and
+// removes the finalizer once they completed or the max wait elapsed. The object is updated when the finalizer is
+// added or removed. The controller is expected to stop reconciling the object unless the outcome is NotDeleting, and
+// to return the result and error.
+func (f *Finalizer) Finalize(ctx context.Context, c client.Client, obj client.Object) (Outcome, ctrl.Result, error) {
+	if obj.GetDeletionTimestamp().IsZero() {
+		if controllerutil.AddFinalizer(obj, f.Name) {
+			if err := c.Update(ctx, obj); err != nil {
+				return NotDeleting, ctrl.Result{}, fmt.Errorf("failed to add finalizer %s: %w", f.Name, err)
+			}
+		}
+		return NotDeleting, ctrl.Result{}, nil
+	}
+	if !controllerutil.ContainsFinalizer(obj, f.Name) {
+		return Absent, ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("finalizer", f.Name)
+	for _, step := range f.Steps {
+		done, err := step.Cleanup(ctx, obj)
+		if done && err == nil {
+			continue
+		}
+		waited := f.currentTime().Sub(obj.GetDeletionTimestamp().Time)
+		if f.MaxWait > 0 && waited >= f.MaxWait {
+			return f.forceRemove(ctx, c, obj, step.Name, waited, err)
+		}
+		if err != nil {
+			log.Error(err, "Cleanup step failed", "step", step.Name)
+			return Pending, ctrl.Result{RequeueAfter: f.requeueAfter()}, err
+		}
+		log.Info("Waiting for cleanup step", "step", step.Name, "waited", waited.Round(time.Second).String())
+		return Pending, ctrl.Result{RequeueAfter: f.requeueAfter()}, nil
+	}
+
+	controllerutil.RemoveFinalizer(obj, f.Name)
+	if err := c.Update(ctx, obj); err != nil {
+		return Pending, ctrl.Result{}, fmt.Errorf("failed to remove finalizer %s: %w", f.Name, err)
+	}
+	return Removed, ctrl.Result{}, nil
+}
+
+// forceRemove removes the finalizer although the step did not complete
+func (f *Finalizer) forceRemove(ctx context.Context, c client.Client, obj client.Object, step string, waited time.Duration, stepErr error) (Outcome, ctrl.Result, error) {
+	controllerutil.RemoveFinalizer(obj, f.Name)
+	if err := c.Update(ctx, obj); err != nil {
+		return Pending, ctrl.Result{}, fmt.Errorf("failed to forcibly remove finalizer %s: %w", f.Name, err)
+	}
+
+	message := fmt.Sprintf("Removed finalizer %s after waiting %s for cleanup step %s to complete", f.Name, waited.Round(time.Second), step)
+	if stepErr != nil {
+		message = fmt.Sprintf("%s: %v", message, stepErr)
+	}
+	ctrl.LoggerFrom(ctx).Info("Forcibly removed finalizer", "finalizer", f.Name, "step", step, "waited", waited.Round(time.Second).String())
+	if f.Recorder != nil {
+		f.Recorder.Event(obj, corev1.EventTypeWarning, ReasonForcedRemoval, message)
+	}
+	forcedRemovalsTotal.WithLabelValues(f.Name, step).Inc()
+	return ForciblyRemoved, ctrl.Result{}, nil
+}
+
+func (f *Finalizer) currentTime() time.Time {
+	if f.now != nil {
+		return f.now()
+	}
+	return time.Now()
+}
+
+func (f *Finalizer) requeueAfter() time.Duration {
+	if f.RequeueAfter > 0 {
+		return f.RequeueAfter
+	}
+	return defaultRequeueAfter
+}
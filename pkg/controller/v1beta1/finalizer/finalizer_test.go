@@ -0,0 +1,115 @@
+package finalizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testFinalizer = "test.ome.io/finalizer"
+
+func TestFinalizeRegisters(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}}
+	c := fake.NewClientBuilder().WithObjects(cm).Build()
+	f := &Finalizer{Name: testFinalizer}
+
+	outcome, result, err := f.Finalize(context.Background(), c, cm)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(outcome).To(gomega.Equal(NotDeleting))
+	g.Expect(result.IsZero()).To(gomega.BeTrue())
+
+	updated := &corev1.ConfigMap{}
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), updated)).To(gomega.Succeed())
+	g.Expect(updated.Finalizers).To(gomega.ConsistOf(testFinalizer))
+}
+
+func TestFinalizeRunsStepsInOrder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm", Finalizers: []string{testFinalizer}}}
+	c := fake.NewClientBuilder().WithObjects(cm).Build()
+	g.Expect(c.Delete(context.Background(), cm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)).To(gomega.Succeed())
+	deleted := cm.DeletionTimestamp.Time
+
+	var ran []string
+	secondDone := false
+	f := &Finalizer{
+		Name: testFinalizer,
+		Steps: []Step{
+			{Name: "first", Cleanup: func(context.Context, client.Object) (bool, error) {
+				ran = append(ran, "first")
+				return true, nil
+			}},
+			{Name: "second", Cleanup: func(context.Context, client.Object) (bool, error) {
+				ran = append(ran, "second")
+				return secondDone, nil
+			}},
+		},
+		MaxWait:      time.Hour,
+		RequeueAfter: 30 * time.Second,
+		now:          func() time.Time { return deleted.Add(time.Minute) },
+	}
+
+	outcome, result, err := f.Finalize(context.Background(), c, cm)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(outcome).To(gomega.Equal(Pending))
+	g.Expect(result.RequeueAfter).To(gomega.Equal(30 * time.Second))
+	g.Expect(ran).To(gomega.Equal([]string{"first", "second"}))
+	g.Expect(cm.Finalizers).To(gomega.ConsistOf(testFinalizer))
+
+	secondDone = true
+	outcome, _, err = f.Finalize(context.Background(), c, cm)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(outcome).To(gomega.Equal(Removed))
+	g.Expect(cm.Finalizers).To(gomega.BeEmpty())
+
+	outcome, _, err = f.Finalize(context.Background(), c, cm)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(outcome).To(gomega.Equal(Absent))
+}
+
+func TestFinalizeForcesRemoval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm", Finalizers: []string{testFinalizer}}}
+	c := fake.NewClientBuilder().WithObjects(cm).Build()
+	g.Expect(c.Delete(context.Background(), cm)).To(gomega.Succeed())
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)).To(gomega.Succeed())
+	deleted := cm.DeletionTimestamp.Time
+
+	now := deleted.Add(time.Minute)
+	recorder := record.NewFakeRecorder(10)
+	f := &Finalizer{
+		Name: testFinalizer,
+		Steps: []Step{{Name: "agents", Cleanup: func(context.Context, client.Object) (bool, error) {
+			return false, errors.New("node-1 not cleared")
+		}}},
+		MaxWait:  10 * time.Minute,
+		Recorder: recorder,
+		now:      func() time.Time { return now },
+	}
+	before := testutil.ToFloat64(forcedRemovalsTotal.WithLabelValues(testFinalizer, "agents"))
+
+	outcome, result, err := f.Finalize(context.Background(), c, cm)
+	g.Expect(err).To(gomega.MatchError("node-1 not cleared"))
+	g.Expect(outcome).To(gomega.Equal(Pending))
+	g.Expect(result.RequeueAfter).To(gomega.Equal(defaultRequeueAfter))
+
+	now = deleted.Add(10 * time.Minute)
+	outcome, _, err = f.Finalize(context.Background(), c, cm)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(outcome).To(gomega.Equal(ForciblyRemoved))
+	g.Expect(cm.Finalizers).To(gomega.BeEmpty())
+	g.Expect(<-recorder.Events).To(gomega.Equal("Warning FinalizerForcedRemoval Removed finalizer test.ome.io/finalizer " +
+		"after waiting 10m0s for cleanup step agents to complete: node-1 not cleared"))
+	g.Expect(testutil.ToFloat64(forcedRemovalsTotal.WithLabelValues(testFinalizer, "agents")) - before).To(gomega.Equal(1.0))
+}
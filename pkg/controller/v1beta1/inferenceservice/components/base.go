@@ -1,21 +1,28 @@
 package components
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/sgl-project/ome/pkg/agentprogress"
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/rbac"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/sgl-project/ome/pkg/utils"
@@ -310,6 +317,46 @@ func isResourcesUnspecified(resources corev1.ResourceRequirements) bool {
 	return resources.Limits == nil && resources.Requests == nil && len(resources.Claims) == 0
 }
 
+// isModelDerivedResources reports whether the resources of the component in the InferenceService spec were derived
+// from the model metadata by the defaulting webhook rather than specified by the user
+func isModelDerivedResources(isvc *v1beta1.InferenceService, component v1beta1.ComponentType) bool {
+	_, derived := isvcutils.GetModelDerivedComponents(isvc.Annotations)[component]
+	return derived
+}
+
+// mergeModelDerivedResources merges the resources of the runtime and accelerator class that the container doesn't
+// set. The accelerator count derived from the model metadata is kept, rather than replaced by the quantity the
+// accelerator class lists per accelerator.
+func mergeModelDerivedResources(b *BaseComponentFields, container *corev1.Container) {
+	acceleratorClass := b.AcceleratorClass
+	if acceleratorClass != nil {
+		acceleratorClass = acceleratorClass.DeepCopy()
+		acceleratorClass.Resources = slices.DeleteFunc(acceleratorClass.Resources, func(r v1beta1.AcceleratorResource) bool {
+			_, requested := container.Resources.Requests[corev1.ResourceName(r.Name)]
+			_, limited := container.Resources.Limits[corev1.ResourceName(r.Name)]
+			return requested || limited
+		})
+	}
+	isvcutils.MergeResource(container, acceleratorClass, b.Runtime)
+}
+
+// ReconcileComponentRBAC reconciles the ServiceAccount and Role of a component. The pods that don't run as a
+// ServiceAccount of the user are given the one of the component, the Role is also bound to those of the user.
+func ReconcileComponentRBAC(b *BaseComponentFields, isvc *v1beta1.InferenceService, objectMeta metav1.ObjectMeta,
+	componentType v1beta1.ComponentType, podSpecs ...*corev1.PodSpec) error {
+	rbacReconciler := rbac.NewRBACReconciler(b.Client, b.Scheme, objectMeta, componentType, isvc)
+	for _, podSpec := range podSpecs {
+		switch {
+		case podSpec == nil:
+		case podSpec.ServiceAccountName == "":
+			podSpec.ServiceAccountName = rbacReconciler.GetServiceAccountName()
+		default:
+			rbacReconciler.WithUserServiceAccounts(podSpec.ServiceAccountName)
+		}
+	}
+	return rbacReconciler.Reconcile()
+}
+
 // MergeResources merges resource requests and limits from the runtime and accelerator class into the container
 func MergeResources(b *BaseComponentFields, container *corev1.Container) {
 	isvcutils.MergeResource(container, b.AcceleratorClass, b.Runtime)
@@ -326,6 +373,9 @@ func MergeEngineResources(b *BaseComponentFields, isvc *v1beta1.InferenceService
 			isResourcesUnspecified(isvc.Spec.Engine.Runner.Container.Resources)) {
 		b.Log.Info("Merging resources for engine container as user did not specify resources in InferenceService")
 		MergeResources(b, container)
+	} else if isvc.Spec.Engine != nil && isModelDerivedResources(isvc, v1beta1.EngineComponent) {
+		b.Log.Info("Merging resources for engine container around the resources derived from the model metadata")
+		mergeModelDerivedResources(b, container)
 	}
 }
 
@@ -340,6 +390,9 @@ func MergeDecoderResources(b *BaseComponentFields, isvc *v1beta1.InferenceServic
 			isResourcesUnspecified(isvc.Spec.Decoder.Runner.Container.Resources)) {
 		b.Log.Info("Merging resources for decoder container as user did not specify resources in InferenceService")
 		MergeResources(b, container)
+	} else if isvc.Spec.Decoder != nil && isModelDerivedResources(isvc, v1beta1.DecoderComponent) {
+		b.Log.Info("Merging resources for decoder container around the resources derived from the model metadata")
+		mergeModelDerivedResources(b, container)
 	}
 }
 
@@ -367,6 +420,38 @@ func UpdateDecoderAffinity(b *BaseComponentFields, isvc *v1beta1.InferenceServic
 	}
 }
 
+// UpdatePodSpecSchedulingHints injects the tolerations and runtime class of the accelerator class into the pod spec.
+// Tolerations are added alongside the user's tolerations; the runtime class is only set when the user didn't specify one.
+func UpdatePodSpecSchedulingHints(b *BaseComponentFields, podSpec *corev1.PodSpec) {
+	if b.AcceleratorClass == nil || b.AcceleratorClass.Scheduling == nil {
+		return
+	}
+	scheduling := b.AcceleratorClass.Scheduling
+
+	for i := range scheduling.Tolerations {
+		toleration := scheduling.Tolerations[i]
+		if !hasToleration(podSpec.Tolerations, &toleration) {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+
+	if podSpec.RuntimeClassName == nil && scheduling.RuntimeClassName != nil {
+		b.Log.Info("Setting runtime class from accelerator class as user did not specify runtime class in InferenceService",
+			"runtimeClassName", *scheduling.RuntimeClassName)
+		podSpec.RuntimeClassName = ptr.To(*scheduling.RuntimeClassName)
+	}
+}
+
+// hasToleration reports whether the tolerations already contain an equivalent toleration
+func hasToleration(tolerations []corev1.Toleration, toleration *corev1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].MatchToleration(toleration) {
+			return true
+		}
+	}
+	return false
+}
+
 // ProcessBaseAnnotations processes common annotations
 func ProcessBaseAnnotations(b *BaseComponentFields, isvc *v1beta1.InferenceService, annotations map[string]string) (map[string]string, error) {
 	// Add fine-tuned weight annotations if applicable
@@ -496,5 +581,41 @@ func UpdateComponentStatus(b *BaseComponentFields, isvc *v1beta1.InferenceServic
 	}
 	b.StatusManager.PropagateModelStatus(&isvc.Status, statusSpec, pods, rawDeployment)
 
+	if componentType == v1beta1.EngineComponent || componentType == v1beta1.DecoderComponent {
+		progress, err := reconcileAgentProgress(b, isvc, componentType, pods)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the agent progress of the %s pods", componentType)
+		}
+		b.StatusManager.PropagateAgentProgress(&isvc.Status, pods, progress)
+	}
+
 	return nil
 }
+
+// reconcileAgentProgress returns the progress the agents of the pods of a component published to its progress
+// ConfigMap, and removes the progress of the pods that are gone so that the ConfigMap doesn't grow with every rollout
+func reconcileAgentProgress(b *BaseComponentFields, isvc *v1beta1.InferenceService, componentType v1beta1.ComponentType, pods *corev1.PodList) (map[string]string, error) {
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: isvc.Namespace, Name: constants.AgentProgressConfigMapName(isvc.Name, string(componentType))}
+	if err := b.Client.Get(context.TODO(), key, configMap); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	stale := false
+	for dataKey := range configMap.Data {
+		current := slices.ContainsFunc(pods.Items, func(pod corev1.Pod) bool {
+			return strings.HasPrefix(dataKey, agentprogress.DataKey(pod.Name, ""))
+		})
+		if !current {
+			delete(configMap.Data, dataKey)
+			stale = true
+		}
+	}
+	// A conflict with the heartbeat of an agent leaves the removal to the next reconcile
+	if stale {
+		if err := b.Client.Update(context.TODO(), configMap); err != nil && !apierrors.IsConflict(err) {
+			return nil, err
+		}
+	}
+	return configMap.Data, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
 )
 
@@ -29,6 +30,7 @@ type ComponentBuilder struct {
 	acceleratorClass       *v1beta1.AcceleratorClassSpec
 	acceleratorClassName   string
 	logger                 logr.Logger
+	drift                  *drift.Tracker
 }
 
 // NewComponentBuilder creates a new component builder
@@ -86,6 +88,12 @@ func (b *ComponentBuilder) WithSupportedModelFormat(format *v1beta1.SupportedMod
 	return b
 }
 
+// WithDrift sets the tracker of the desired states of the children, to count their drift corrections
+func (b *ComponentBuilder) WithDrift(tracker *drift.Tracker) *ComponentBuilder {
+	b.drift = tracker
+	return b
+}
+
 // buildBaseFields creates the common base fields
 func (b *ComponentBuilder) buildBaseFields() BaseComponentFields {
 	return BaseComponentFields{
@@ -106,7 +114,7 @@ func (b *ComponentBuilder) buildBaseFields() BaseComponentFields {
 // BuildEngine creates an Engine component
 func (b *ComponentBuilder) BuildEngine(spec *v1beta1.EngineSpec) Component {
 	// For now, using the existing Engine constructor
-	return NewEngine(
+	return b.withDrift(NewEngine(
 		b.client,
 		b.clientset,
 		b.scheme,
@@ -120,13 +128,13 @@ func (b *ComponentBuilder) BuildEngine(spec *v1beta1.EngineSpec) Component {
 		b.supportedModelFormat,
 		b.acceleratorClass,
 		b.acceleratorClassName,
-	)
+	))
 }
 
 // BuildDecoder creates a Decoder component
 func (b *ComponentBuilder) BuildDecoder(spec *v1beta1.DecoderSpec) Component {
 	// For now, using the existing Decoder constructor
-	return NewDecoder(
+	return b.withDrift(NewDecoder(
 		b.client,
 		b.clientset,
 		b.scheme,
@@ -140,13 +148,13 @@ func (b *ComponentBuilder) BuildDecoder(spec *v1beta1.DecoderSpec) Component {
 		b.supportedModelFormat,
 		b.acceleratorClass,
 		b.acceleratorClassName,
-	)
+	))
 }
 
 // BuildRouter creates a Router component
 func (b *ComponentBuilder) BuildRouter(spec *v1beta1.RouterSpec) Component {
 	// For now, using the existing Router constructor (from router_v2.go)
-	return NewRouter(
+	return b.withDrift(NewRouter(
 		b.client,
 		b.clientset,
 		b.scheme,
@@ -157,7 +165,20 @@ func (b *ComponentBuilder) BuildRouter(spec *v1beta1.RouterSpec) Component {
 		spec,
 		b.runtime,
 		b.runtimeName,
-	)
+	))
+}
+
+// withDrift sets the drift tracker of the deployment reconciler of a component
+func (b *ComponentBuilder) withDrift(component Component) Component {
+	switch c := component.(type) {
+	case *Engine:
+		c.deploymentReconciler.Drift = b.drift
+	case *Decoder:
+		c.deploymentReconciler.Drift = b.drift
+	case *Router:
+		c.deploymentReconciler.Drift = b.drift
+	}
+	return component
 }
 
 // BuildCustomComponent creates a custom component with a strategy
@@ -176,6 +197,7 @@ type ComponentBuilderFactory struct {
 	clientset              kubernetes.Interface
 	scheme                 *runtime.Scheme
 	inferenceServiceConfig *controllerconfig.InferenceServicesConfig
+	drift                  *drift.Tracker
 }
 
 // NewComponentBuilderFactory creates a new factory
@@ -195,7 +217,13 @@ func NewComponentBuilderFactory(
 
 // NewBuilder creates a new component builder
 func (f *ComponentBuilderFactory) NewBuilder() *ComponentBuilder {
-	return NewComponentBuilder(f.client, f.clientset, f.scheme, f.inferenceServiceConfig)
+	return NewComponentBuilder(f.client, f.clientset, f.scheme, f.inferenceServiceConfig).WithDrift(f.drift)
+}
+
+// WithDrift sets the drift tracker of the builders
+func (f *ComponentBuilderFactory) WithDrift(tracker *drift.Tracker) *ComponentBuilderFactory {
+	f.drift = tracker
+	return f
 }
 
 // CreateEngineComponent is a convenience method to create an engine component
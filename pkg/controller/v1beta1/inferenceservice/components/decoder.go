@@ -116,6 +116,11 @@ func (d *Decoder) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, error)
 		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile worker pod spec")
 	}
 
+	// Reconcile RBAC resources (ServiceAccount, Role, RoleBinding) the ome-agent of the pods needs
+	if err := ReconcileComponentRBAC(&d.BaseComponentFields, isvc, objectMeta, v1beta1.DecoderComponent, podSpec, workerPodSpec); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile RBAC resources")
+	}
+
 	// Get worker size
 	size := d.getWorkerSize()
 
@@ -304,6 +309,7 @@ func (d *Decoder) reconcilePodSpec(isvc *v1beta1.InferenceService, objectMeta *m
 	UpdatePodSpecVolumes(&d.BaseComponentFields, isvc, podSpec, objectMeta)
 	UpdatePodSpecNodeSelector(&d.BaseComponentFields, isvc, podSpec, v1beta1.DecoderComponent)
 	UpdateDecoderAffinity(&d.BaseComponentFields, isvc, podSpec)
+	UpdatePodSpecSchedulingHints(&d.BaseComponentFields, podSpec)
 
 	d.Log.Info("Decoder PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return podSpec, nil
@@ -339,6 +345,7 @@ func (d *Decoder) reconcileWorkerPodSpec(isvc *v1beta1.InferenceService, objectM
 	UpdatePodSpecVolumes(&d.BaseComponentFields, isvc, workerPodSpec, objectMeta)
 	UpdatePodSpecNodeSelector(&d.BaseComponentFields, isvc, workerPodSpec, v1beta1.DecoderComponent)
 	UpdateDecoderAffinity(&d.BaseComponentFields, isvc, workerPodSpec)
+	UpdatePodSpecSchedulingHints(&d.BaseComponentFields, workerPodSpec)
 
 	d.Log.Info("Decoder Worker PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return workerPodSpec, nil
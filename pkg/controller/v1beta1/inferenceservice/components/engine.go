@@ -117,6 +117,11 @@ func (e *Engine) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, error)
 		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile worker pod spec")
 	}
 
+	// Reconcile RBAC resources (ServiceAccount, Role, RoleBinding) the ome-agent of the pods needs
+	if err := ReconcileComponentRBAC(&e.BaseComponentFields, isvc, objectMeta, v1beta1.EngineComponent, podSpec, workerPodSpec); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile RBAC resources")
+	}
+
 	// Get worker size
 	size := e.getWorkerSize()
 
@@ -307,6 +312,7 @@ func (e *Engine) reconcilePodSpec(isvc *v1beta1.InferenceService, objectMeta *me
 	UpdatePodSpecVolumes(&e.BaseComponentFields, isvc, podSpec, objectMeta)
 	UpdatePodSpecNodeSelector(&e.BaseComponentFields, isvc, podSpec, v1beta1.EngineComponent)
 	UpdateEngineAffinity(&e.BaseComponentFields, isvc, podSpec)
+	UpdatePodSpecSchedulingHints(&e.BaseComponentFields, podSpec)
 
 	e.Log.Info("Engine PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return podSpec, nil
@@ -342,6 +348,7 @@ func (e *Engine) reconcileWorkerPodSpec(isvc *v1beta1.InferenceService, objectMe
 	UpdatePodSpecVolumes(&e.BaseComponentFields, isvc, workerPodSpec, objectMeta)
 	UpdatePodSpecNodeSelector(&e.BaseComponentFields, isvc, workerPodSpec, v1beta1.EngineComponent)
 	UpdateEngineAffinity(&e.BaseComponentFields, isvc, workerPodSpec)
+	UpdatePodSpecSchedulingHints(&e.BaseComponentFields, workerPodSpec)
 	e.Log.Info("Engine Worker PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return workerPodSpec, nil
 }
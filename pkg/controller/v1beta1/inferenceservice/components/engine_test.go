@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -1051,6 +1052,7 @@ func TestEngineResourceMerging(t *testing.T) {
 		engineSpec            *v1beta1.EngineSpec
 		runtime               *v1beta1.ServingRuntimeSpec
 		acceleratorClass      *v1beta1.AcceleratorClassSpec
+		annotations           map[string]string
 		expectResourcesMerged bool
 		validateResources     func(*v1.Container)
 	}{
@@ -1155,6 +1157,54 @@ func TestEngineResourceMerging(t *testing.T) {
 				g.Expect(gpu.String()).To(gomega.Equal("2"))
 			},
 		},
+		{
+			name: "Model-derived resources - should keep the derived GPU count",
+			engineSpec: &v1beta1.EngineSpec{
+				Runner: &v1beta1.RunnerSpec{
+					Container: v1.Container{
+						Name:  "ome-container",
+						Image: "engine:latest",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceMemory:                 resource.MustParse("175Gi"),
+								v1.ResourceName("nvidia.com/gpu"): resource.MustParse("8"),
+							},
+							Limits: v1.ResourceList{
+								v1.ResourceMemory:                 resource.MustParse("175Gi"),
+								v1.ResourceName("nvidia.com/gpu"): resource.MustParse("8"),
+							},
+						},
+					},
+				},
+			},
+			acceleratorClass: &v1beta1.AcceleratorClassSpec{
+				Resources: []v1beta1.AcceleratorResource{
+					{
+						Name:     "nvidia.com/gpu",
+						Quantity: resource.MustParse("1"),
+					},
+					{
+						Name:     "rdma/hca",
+						Quantity: resource.MustParse("1"),
+					},
+				},
+			},
+			annotations: map[string]string{
+				constants.ModelDerivedResourcesAnnotationKey: "engine=5d41402abc4b2a76",
+			},
+			expectResourcesMerged: true,
+			validateResources: func(c *v1.Container) {
+				// Should keep the derived GPU count and add the resources the AC lists besides the GPUs
+				for _, resources := range []v1.ResourceList{c.Resources.Requests, c.Resources.Limits} {
+					gpu := resources[v1.ResourceName("nvidia.com/gpu")]
+					g.Expect(gpu.String()).To(gomega.Equal("8"))
+					memory := resources[v1.ResourceMemory]
+					g.Expect(memory.String()).To(gomega.Equal("175Gi"))
+					hca := resources[v1.ResourceName("rdma/hca")]
+					g.Expect(hca.String()).To(gomega.Equal("1"))
+				}
+			},
+		},
 		{
 			name: "No runner specified - should NOT merge",
 			engineSpec: &v1beta1.EngineSpec{
@@ -1194,8 +1244,9 @@ func TestEngineResourceMerging(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			isvc := &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-isvc",
-					Namespace: "default",
+					Name:        "test-isvc",
+					Namespace:   "default",
+					Annotations: tt.annotations,
 				},
 				Spec: v1beta1.InferenceServiceSpec{
 					Model:  &v1beta1.ModelRef{},
@@ -1418,6 +1469,99 @@ func TestEngineAffinityMerging(t *testing.T) {
 	}
 }
 
+func TestEngineSchedulingHints(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	gpuToleration := v1.Toleration{
+		Key:      "nvidia.com/gpu",
+		Operator: v1.TolerationOpExists,
+		Effect:   v1.TaintEffectNoSchedule,
+	}
+	acceleratorClass := &v1beta1.AcceleratorClassSpec{
+		Scheduling: &v1beta1.AcceleratorScheduling{
+			Tolerations:      []v1.Toleration{gpuToleration},
+			RuntimeClassName: ptr.To("nvidia"),
+		},
+	}
+
+	tests := []struct {
+		name                     string
+		podSpec                  v1beta1.PodSpec
+		expectedTolerations      []v1.Toleration
+		expectedRuntimeClassName string
+	}{
+		{
+			name: "Injects tolerations and runtime class",
+			podSpec: v1beta1.PodSpec{
+				Containers: []v1.Container{{Name: "ome-container", Image: "engine:latest"}},
+			},
+			expectedTolerations:      []v1.Toleration{gpuToleration},
+			expectedRuntimeClassName: "nvidia",
+		},
+		{
+			name: "Keeps user tolerations and runtime class without duplicates",
+			podSpec: v1beta1.PodSpec{
+				Containers:       []v1.Container{{Name: "ome-container", Image: "engine:latest"}},
+				RuntimeClassName: ptr.To("custom"),
+				Tolerations: []v1.Toleration{
+					{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "inference", Effect: v1.TaintEffectNoSchedule},
+					gpuToleration,
+				},
+			},
+			expectedTolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "inference", Effect: v1.TaintEffectNoSchedule},
+				gpuToleration,
+			},
+			expectedRuntimeClassName: "custom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engineSpec := &v1beta1.EngineSpec{PodSpec: tt.podSpec}
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-isvc",
+					Namespace: "default",
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Model:  &v1beta1.ModelRef{},
+					Engine: engineSpec,
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			g.Expect(v1.AddToScheme(scheme)).NotTo(gomega.HaveOccurred())
+			clientset := fake.NewClientset()
+			c := ctrlclientfake.NewClientBuilder().WithScheme(scheme).Build()
+
+			engine := NewEngine(
+				c,
+				clientset,
+				scheme,
+				&controllerconfig.InferenceServicesConfig{},
+				constants.RawDeployment,
+				nil, // baseModel
+				nil, // baseModelMeta
+				engineSpec,
+				nil, // runtime
+				"test-runtime",
+				nil, // supportedModelFormat
+				acceleratorClass,
+				"test-accel-class",
+			).(*Engine)
+
+			objectMeta := &metav1.ObjectMeta{Name: "test", Namespace: "default"}
+			podSpec, err := engine.reconcilePodSpec(isvc, objectMeta)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			g.Expect(podSpec.Tolerations).To(gomega.Equal(tt.expectedTolerations))
+			g.Expect(podSpec.RuntimeClassName).NotTo(gomega.BeNil())
+			g.Expect(*podSpec.RuntimeClassName).To(gomega.Equal(tt.expectedRuntimeClassName))
+		})
+	}
+}
+
 // Note: Worker resource and affinity tests are not included because MergeEngineResources and
 // UpdateEngineAffinity check isvc.Spec.Engine.Runner and isvc.Spec.Engine.PodSpec.Affinity,
 // not the worker-specific fields. This means the merging decision is based on the engine/leader
@@ -3,6 +3,8 @@ package inferenceservice
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sgl-project/ome/pkg/acceleratorclassselector"
 
@@ -16,6 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,22 +32,30 @@ import (
 	"knative.dev/pkg/network"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	v1beta1 "github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/auditsink"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerhealth"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/finalizer"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/external_service"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
 	multimodelconfig "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/sgl-project/ome/pkg/runtimeselector"
+	"github.com/sgl-project/ome/pkg/tracing"
 	"github.com/sgl-project/ome/pkg/utils"
 )
 
@@ -61,6 +72,7 @@ import (
 // +kubebuilder:rbac:groups=ome.io,resources=clusterbasemodels;basemodels/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ome.io,resources=inferenceservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/finalizers,verbs=get;list;watch;create;update;patch;delete
@@ -73,7 +85,7 @@ import (
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
@@ -101,6 +113,9 @@ const (
 	InferenceServiceNotReadyState InferenceServiceState = "InferenceServiceNotReady"
 )
 
+// inferenceServiceKind is the kind of the InferenceServices in their audit events
+const inferenceServiceKind = "InferenceService"
+
 // InferenceServiceReconciler reconciles an InferenceService object
 type InferenceServiceReconciler struct {
 	client.Client
@@ -112,8 +127,19 @@ type InferenceServiceReconciler struct {
 	StatusManager            *status.StatusReconciler
 	RuntimeSelector          runtimeselector.Selector
 	AcceleratorClassSelector acceleratorclassselector.Selector
+	// Audit records the rollouts and deletions of the InferenceServices, nothing if nil
+	Audit *auditsink.Recorder
+	// FinalizerMaxWait is how long the deletion of an InferenceService waits for its cleanup before its finalizer is
+	// forcibly removed, forever if zero
+	FinalizerMaxWait time.Duration
+	// drift remembers the desired states of the children of the InferenceServices to count their drift corrections,
+	// nothing is counted if nil
+	drift *drift.Tracker
 }
 
+// inferenceServiceFinalizer is the finalizer of the InferenceServices
+const inferenceServiceFinalizer = "inferenceservice.finalizers"
+
 func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	// Fetch the InferenceService instance
 	isvc := &v1beta1.InferenceService{}
@@ -121,10 +147,27 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		if apierrors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			r.drift.ForgetInferenceService(req.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
+	// Add the finalizer, or handle the deletion. Handled first so that a missing configuration does not block the
+	// deletion of the InferenceServices and their namespace.
+	isvcFinalizer := &finalizer.Finalizer{Name: inferenceServiceFinalizer, MaxWait: r.FinalizerMaxWait, Recorder: r.Recorder}
+	outcome, finalizeResult, err := isvcFinalizer.Finalize(ctx, r.Client, isvc)
+	if err != nil || outcome != finalizer.NotDeleting {
+		if outcome == finalizer.Removed || outcome == finalizer.ForciblyRemoved {
+			r.Audit.RecordObject(isvc, inferenceServiceKind, auditsink.EventDeleted, "inferenceservice",
+				"InferenceService deleted, finalizer removed", map[string]string{
+					"finalizer":         inferenceServiceFinalizer,
+					"deletionRequested": isvc.DeletionTimestamp.UTC().Format(time.RFC3339),
+				})
+		}
+		// Stop reconciliation as the item is being deleted
+		return finalizeResult, err
+	}
+
 	// get annotations from isvc
 	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
 		return !utils.Includes(constants.ServiceAnnotationDisallowedList, key)
@@ -132,41 +175,13 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	deployConfig, err := controllerconfig.NewDeployConfig(r.Clientset)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "fails to create DeployConfig")
+		return reconcile.Result{}, controllermetrics.WithReason("ConfigError", errors.Wrapf(err, "fails to create DeployConfig"))
 	}
 
 	// For backward compatibility with predictor-based architecture
 	deploymentMode := isvcutils.GetDeploymentMode(annotations, deployConfig)
 	r.Log.Info("Inference service deployment mode ", "namespace", isvc.Namespace, "inference service", isvc.Name, "deployment mode", deploymentMode)
 
-	// name of our custom finalizer
-	finalizerName := "inferenceservice.finalizers"
-
-	// examine DeletionTimestamp to determine if object is under deletion
-	if isvc.ObjectMeta.DeletionTimestamp.IsZero() {
-		// The object is not being deleted, so if it does not have our finalizer,
-		// then lets add the finalizer and update the object. This is equivalent
-		// registering our finalizer.
-		if !controllerutil.ContainsFinalizer(isvc, finalizerName) {
-			controllerutil.AddFinalizer(isvc, finalizerName)
-			if err := r.Update(context.Background(), isvc); err != nil {
-				return ctrl.Result{}, err
-			}
-		}
-	} else {
-		// The object is being deleted
-		if controllerutil.ContainsFinalizer(isvc, finalizerName) {
-			// remove our finalizer from the list and update it.
-			controllerutil.RemoveFinalizer(isvc, finalizerName)
-			if err := r.Update(context.Background(), isvc); err != nil {
-				return ctrl.Result{}, err
-			}
-		}
-
-		// Stop reconciliation as the item is being deleted
-		return ctrl.Result{}, nil
-	}
-
 	// Handle VirtualDeployment without actual reconciliation
 	if deploymentMode == constants.VirtualDeployment {
 		return r.handleVirtualDeployment(isvc)
@@ -188,19 +203,19 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	r.Log.Info("Reconciling inference service", "apiVersion", isvc.APIVersion, "namespace", isvc.Namespace, "isvc", isvc.Name)
 	isvcConfig, err := controllerconfig.NewInferenceServicesConfig(r.Clientset)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "fails to create InferenceServicesConfig")
+		return reconcile.Result{}, controllermetrics.WithReason("ConfigError", errors.Wrapf(err, "fails to create InferenceServicesConfig"))
 	}
 
 	modelConfigReconciler := multimodelconfig.NewModelConfigReconciler(r.Client, r.Clientset, r.Scheme)
 	result, err := modelConfigReconciler.Reconcile(ctx, isvc) // Added ctx
 	if err != nil {
-		return result, err
+		return result, controllermetrics.WithReason("ModelConfigReconcileError", err)
 	}
 
 	// Initialize ComponentBuilderFactory
 	// Note: isvcConfig is created a few lines above inside the Reconcile function
 	// for NewInferenceServicesConfig. We will use that existing isvcConfig.
-	componentBuilderFactory := components.NewComponentBuilderFactory(r.Client, r.Clientset, r.Scheme, isvcConfig)
+	componentBuilderFactory := components.NewComponentBuilderFactory(r.Client, r.Clientset, r.Scheme, isvcConfig).WithDrift(r.drift)
 
 	// Determine which components to reconcile based on the spec
 	var reconcilers []components.Component
@@ -209,7 +224,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err := r.migratePredictorToNewArchitecture(isvc); err != nil {
 		r.Log.Error(err, "Failed to migrate predictor spec", "namespace", isvc.Namespace, "inferenceService", isvc.Name)
 		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "PredictorMigrationError", err.Error())
-		return reconcile.Result{}, err
+		return reconcile.Result{}, controllermetrics.WithReason("PredictorMigrationError", err)
 	}
 
 	var ingressDeploymentMode constants.DeploymentModeType
@@ -219,7 +234,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		r.Log.Error(err, "Failed to reconcile base model", "Name", isvc.Name)
 		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "ModelReconcileError", err.Error())
-		return reconcile.Result{}, err
+		return reconcile.Result{}, controllermetrics.WithReason("ModelReconcileError", err)
 	}
 
 	// Step 2: Get runtime spec (either specified or auto-selected based on model)
@@ -227,37 +242,57 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	var rtName string
 	userSpecifiedRuntime := false
 
-	if isvc.Spec.Runtime != nil && isvc.Spec.Runtime.Name != "" {
-		// Validate specified runtime
-		rtName = isvc.Spec.Runtime.Name
+	if name, fromAnnotation := isvcutils.GetUserSpecifiedRuntime(isvc); name != "" {
+		// Validate specified runtime; an override annotation skips scoring but not compatibility checks
+		rtName = name
 		userSpecifiedRuntime = true
+		if fromAnnotation {
+			r.Log.Info("Using runtime override", "runtime", rtName, "model", isvc.Spec.Model.Name)
+		}
 		if err := r.RuntimeSelector.ValidateRuntime(ctx, rtName, baseModel, isvc); err != nil {
 			r.Log.Error(err, "Runtime validation failed", "runtime", rtName, "model", isvc.Spec.Model.Name)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "RuntimeValidationError",
 				"Runtime %s does not support model %s: %v", rtName, isvc.Spec.Model.Name, err)
-			return reconcile.Result{}, err
+			return reconcile.Result{}, controllermetrics.WithReason("RuntimeValidationError", err)
 		}
 
 		// Get the runtime spec using selector
-		rtSpec, _, err := r.RuntimeSelector.GetRuntime(ctx, rtName, isvc.Namespace)
+		rtSpec, isCluster, err := r.RuntimeSelector.GetRuntime(ctx, rtName, isvc.Namespace)
 		if err != nil {
 			r.Log.Error(err, "Failed to get runtime spec", "runtime", rtName)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "RuntimeFetchError", err.Error())
-			return reconcile.Result{}, err
+			return reconcile.Result{}, controllermetrics.WithReason("RuntimeFetchError", err)
 		}
 		rt = rtSpec
+		// The event is only recorded when the runtime differs from the one in the status
+		if isvc.Status.SetSelectedRuntime(v1beta1.RuntimeSelection{Name: rtName, Kind: runtimeselector.RuntimeKind(isCluster)}) {
+			source := "spec.runtime"
+			if fromAnnotation {
+				source = "the " + constants.RuntimeOverrideAnnotationKey + " annotation"
+			}
+			r.Recorder.Eventf(isvc, v1.EventTypeNormal, "RuntimeSelected",
+				"Using %s %s for model %s as specified by %s", runtimeselector.RuntimeKind(isCluster), rtName, isvc.Spec.Model.Name, source)
+		}
 	} else {
 		// Auto-select runtime
+		start := time.Now()
 		selection, err := r.RuntimeSelector.SelectRuntime(ctx, baseModel, isvc)
+		recordRuntimeSelectionMetrics(time.Since(start), baseModel.ModelFormat.Name, err)
 		if err != nil {
 			r.Log.Error(err, "Failed to auto-select runtime", "model", isvc.Spec.Model.Name)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "RuntimeSelectionError",
 				"Failed to find runtime for model %s: %v", isvc.Spec.Model.Name, err)
-			return reconcile.Result{}, err
+			return reconcile.Result{}, controllermetrics.WithReason("RuntimeSelectionError", err)
 		}
 		rt = selection.Spec
 		rtName = selection.Name
 		r.Log.Info("Auto-selected runtime", "runtime", rtName, "model", isvc.Spec.Model.Name)
+		if isvc.Status.SetSelectedRuntime(v1beta1.RuntimeSelection{Name: rtName, Kind: runtimeselector.RuntimeKind(selection.IsCluster)}) {
+			recordRuntimeSelected(selection)
+			r.Recorder.Eventf(isvc, v1.EventTypeNormal, "RuntimeSelected",
+				"Auto-selected %s %s for model %s with the highest score %d among compatible runtimes",
+				runtimeselector.RuntimeKind(selection.IsCluster), rtName, isvc.Spec.Model.Name, selection.Score)
+		}
 	}
 
 	// Step 3: Merge rt and isvc specs to get final engine, decoder, and router specs
@@ -265,7 +300,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		r.Log.Error(err, "Failed to merge specs", "Name", isvc.Name)
 		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "MergeSpecsError", err.Error())
-		return reconcile.Result{}, err
+		return reconcile.Result{}, controllermetrics.WithReason("MergeSpecsError", err)
 	}
 
 	// Step 4: Determine deployment modes based on merged specs
@@ -273,7 +308,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		r.Log.Error(err, "Failed to determine deployment modes", "Name", isvc.Name)
 		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "DeploymentModeError", err.Error())
-		return reconcile.Result{}, err
+		return reconcile.Result{}, controllermetrics.WithReason("DeploymentModeError", err)
 	}
 
 	// If both engine and decoder exist, it's PD-disaggregated
@@ -287,7 +322,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		if err != nil {
 			r.Log.Error(err, "Failed to get accelerator class for engine component", "Name", isvc.Name)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "AcceleratorClassError", "Failed to get accelerator class for engine: %v", err)
-			return reconcile.Result{}, err
+			return reconcile.Result{}, controllermetrics.WithReason("AcceleratorClassError", err)
 		}
 		var engineAC *v1beta1.AcceleratorClassSpec
 		if engineACObj == nil {
@@ -295,6 +330,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		} else {
 			engineAC = &engineACObj.Spec
 		}
+		isvc.Status.SetSelectedAccelerator(v1beta1.EngineComponent, acceleratorSelection(engineACObj, engineAcName, componentPodCount(mergedEngine.ComponentExtensionSpec, mergedEngine.Worker)))
 		engineSupportedModelFormats := r.RuntimeSelector.GetSupportedModelFormat(ctx, rt, baseModel, userSpecifiedRuntime)
 		r.Log.Info("Creating engine reconciler",
 			"deploymentMode", engineDeploymentMode,
@@ -321,7 +357,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		if err != nil {
 			r.Log.Error(err, "Failed to get accelerator class for decoder component", "Name", isvc.Name)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "AcceleratorClassError", "Failed to get accelerator class for decoder: %v", err)
-			return reconcile.Result{}, err
+			return reconcile.Result{}, controllermetrics.WithReason("AcceleratorClassError", err)
 		}
 		var decoderAC *v1beta1.AcceleratorClassSpec
 		if decoderACObj == nil {
@@ -329,6 +365,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		} else {
 			decoderAC = &decoderACObj.Spec
 		}
+		isvc.Status.SetSelectedAccelerator(v1beta1.DecoderComponent, acceleratorSelection(decoderACObj, decoderAcName, componentPodCount(mergedDecoder.ComponentExtensionSpec, mergedDecoder.Worker)))
 		decoderSupportedModelFormats := r.RuntimeSelector.GetSupportedModelFormat(ctx, rt, baseModel, userSpecifiedRuntime)
 		r.Log.Info("Creating decoder reconciler",
 			"deploymentMode", decoderDeploymentMode,
@@ -391,7 +428,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 				"component", fmt.Sprintf("%T", reconciler),
 				"namespace", isvc.Namespace,
 				"inferenceService", isvc.Name)
-			return result, err
+			return result, controllermetrics.WithReason("ComponentReconcileError", err)
 		}
 		if result.Requeue || result.RequeueAfter > 0 {
 			return result, nil
@@ -401,25 +438,25 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	// Now reconcile ingress and external service after components have created their services
 	ingressConfig, err := controllerconfig.NewIngressConfig(r.Clientset)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "fails to create IngressConfig")
+		return reconcile.Result{}, controllermetrics.WithReason("ConfigError", errors.Wrapf(err, "fails to create IngressConfig"))
 	}
 
 	// Resolve ingress config with annotation overrides
 	resolvedIngressConfig := isvcutils.ResolveIngressConfig(ingressConfig, isvc.Annotations)
 
 	// New architecture: ingress uses the determined ingress deployment mode
-	ingressReconciler := ingress.NewIngressReconciler(r.Client, r.Clientset, r.Scheme, resolvedIngressConfig, isvcConfig)
+	ingressReconciler := ingress.NewIngressReconciler(r.Client, r.Clientset, r.Scheme, resolvedIngressConfig, isvcConfig, r.drift)
 	r.Log.Info("Reconciling ingress for inference service", "isvc", isvc.Name)
 	if err := ingressReconciler.(*ingress.IngressReconciler).ReconcileWithDeploymentMode(ctx, isvc, ingressDeploymentMode); err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
+		return reconcile.Result{}, controllermetrics.WithReason("IngressReconcileError", errors.Wrapf(err, "fails to reconcile ingress"))
 	}
 
 	// Reconcile external service - creates a service with the inference service name
 	// when ingress is disabled to provide a stable endpoint
-	externalServiceReconciler := external_service.NewExternalServiceReconciler(r.Client, r.Clientset, r.Scheme, resolvedIngressConfig)
+	externalServiceReconciler := external_service.NewExternalServiceReconciler(r.Client, r.Clientset, r.Scheme, resolvedIngressConfig, r.drift)
 	r.Log.Info("Reconciling external service for inference service", "isvc", isvc.Name)
 	if err := externalServiceReconciler.Reconcile(ctx, isvc); err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile external service")
+		return reconcile.Result{}, controllermetrics.WithReason("ExternalServiceReconcileError", errors.Wrapf(err, "fails to reconcile external service"))
 	}
 
 	// Set Status.Address for external service and add ingress disable annotation when ingress is disabled
@@ -493,6 +530,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		r.StatusManager.PropagateCrossComponentStatus(&isvc.Status, componentList, v1beta1.RoutesReady)
 		r.StatusManager.PropagateCrossComponentStatus(&isvc.Status, componentList, v1beta1.LatestDeploymentReady)
 	}
+	isvc.Status.UpdateEstimatedHourlyCost()
 
 	if err = r.updateStatus(isvc, deploymentMode); err != nil {
 		r.Recorder.Event(isvc, v1.EventTypeWarning, "InternalError", err.Error())
@@ -578,9 +616,13 @@ func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1.Infere
 		if wasReady && !isReady { // Moved to NotReady State
 			r.Recorder.Eventf(desiredService, v1.EventTypeWarning, string(InferenceServiceNotReadyState),
 				fmt.Sprintf("InferenceService [%v] is no longer Ready", desiredService.GetName()))
+			r.Audit.RecordObject(desiredService, inferenceServiceKind, auditsink.EventNotReady, "inferenceservice",
+				readyConditionMessage(desiredService.Status), rolloutDetails(desiredService, deploymentMode))
 		} else if !wasReady && isReady { // Moved to Ready State
 			r.Recorder.Eventf(desiredService, v1.EventTypeNormal, string(InferenceServiceReadyState),
 				fmt.Sprintf("InferenceService [%v] is Ready", desiredService.GetName()))
+			r.Audit.RecordObject(desiredService, inferenceServiceKind, auditsink.EventReady, "inferenceservice",
+				"InferenceService rollout completed", rolloutDetails(desiredService, deploymentMode))
 		}
 	}
 	return nil
@@ -592,10 +634,64 @@ func inferenceServiceReadiness(status v1beta1.InferenceServiceStatus) bool {
 		status.GetCondition(knapis.ConditionReady).Status == v1.ConditionTrue
 }
 
+// readyConditionMessage returns the reason and message of the Ready condition of a not ready InferenceService
+func readyConditionMessage(status v1beta1.InferenceServiceStatus) string {
+	condition := status.GetCondition(knapis.ConditionReady)
+	if condition == nil {
+		return "InferenceService is no longer Ready"
+	}
+	return strings.TrimSpace(condition.Reason + " " + condition.Message)
+}
+
+// rolloutDetails describes the rollout of an InferenceService in its audit events
+func rolloutDetails(isvc *v1beta1.InferenceService, deploymentMode constants.DeploymentModeType) map[string]string {
+	details := map[string]string{"deploymentMode": string(deploymentMode)}
+	if isvc.Status.URL != nil {
+		details["url"] = isvc.Status.URL.String()
+	}
+	if isvc.Spec.Model != nil {
+		details["model"] = isvc.Spec.Model.Name
+	}
+	return details
+}
+
 func inferenceServiceStatusEqual(s1, s2 v1beta1.InferenceServiceStatus) bool {
 	return equality.Semantic.DeepEqual(s1, s2)
 }
 
+// acceleratorSelection describes the AcceleratorClass selected for a component, or returns nil if none was selected
+func acceleratorSelection(ac *v1beta1.AcceleratorClass, name string, pods int) *v1beta1.AcceleratorSelection {
+	if ac == nil {
+		return nil
+	}
+	selection := &v1beta1.AcceleratorSelection{
+		AcceleratorClass:    name,
+		NodeSelector:        ac.Spec.Discovery.NodeSelector,
+		EstimatedHourlyCost: acceleratorclassselector.EstimateHourlyCost(ac, pods),
+	}
+	if len(ac.Spec.Resources) > 0 {
+		selection.ResourceRequests = make(map[string]string, len(ac.Spec.Resources))
+		for _, r := range ac.Spec.Resources {
+			selection.ResourceRequests[r.Name] = r.Quantity.String()
+		}
+	}
+	return selection
+}
+
+// componentPodCount returns the number of pods of a component at its minimum replicas.
+// Each replica of a multi-node component runs a leader and its workers.
+func componentPodCount(extension v1beta1.ComponentExtensionSpec, worker *v1beta1.WorkerSpec) int {
+	replicas := 1
+	if extension.MinReplicas != nil {
+		replicas = *extension.MinReplicas
+	}
+	podsPerReplica := 1
+	if worker != nil && worker.Size != nil {
+		podsPerReplica += *worker.Size
+	}
+	return replicas * podsPerReplica
+}
+
 // ensureIngressDisableAnnotation adds the ome.io/ingress-disable-creation annotation to the InferenceService
 // This annotation is used by the cleanup logic to determine if external service should be kept
 func (r *InferenceServiceReconciler) ensureIngressDisableAnnotation(isvc *v1beta1.InferenceService) error {
@@ -621,12 +717,24 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 	// NEW: Initialize StatusReconciler
 	r.StatusManager = status.NewStatusReconciler()
 
-	// Initialize RuntimeSelector
-	r.RuntimeSelector = runtimeselector.New(mgr.GetClient())
+	// Initialize RuntimeSelector with the scorer weights configured in the inferenceservice ConfigMap
+	runtimeSelectorConfig, err := controllerconfig.NewRuntimeSelectorConfig(r.Clientset)
+	if err != nil {
+		return err
+	}
+	selectorConfig := runtimeselector.NewConfig(mgr.GetClient())
+	selectorConfig.ScorerWeights = runtimeSelectorConfig.ScorerWeights
+	selectorConfig.SelectionCache = runtimeselector.NewSelectionCache(runtimeselector.DefaultSelectionCacheSize)
+	r.RuntimeSelector = runtimeselector.NewWithConfig(selectorConfig)
 
 	// Initialize AcceleratorClassSelector
 	r.AcceleratorClassSelector = acceleratorclassselector.New(mgr.GetClient())
 
+	r.drift = drift.NewTracker()
+
+	// Count the InferenceServices of the cache at every scrape of the metrics endpoint
+	inferenceServiceConditions.SetReader(mgr.GetClient())
+
 	ksvcFound, err := utils.IsCrdAvailable(r.ClientConfig, knservingv1.SchemeGroupVersion.String(), constants.KnativeServiceKind)
 	if err != nil {
 		return err
@@ -652,6 +760,11 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		return err
 	}
 
+	httpRouteFound, err := utils.IsCrdAvailable(r.ClientConfig, gatewayapiv1.SchemeGroupVersion.String(), constants.HTTPRouteKind)
+	if err != nil {
+		return err
+	}
+
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.InferenceService{}).
 		Owns(&appsv1.Deployment{}).
@@ -660,7 +773,9 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		Owns(&v1.PersistentVolume{}).
 		Owns(&v1.PersistentVolumeClaim{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Owns(&policyv1.PodDisruptionBudget{})
+		Owns(&policyv1.PodDisruptionBudget{}).
+		// Watched so that the changes to the Ingress by someone else are reverted rather than kept until the next reconcile
+		Owns(&netv1.Ingress{})
 
 	if ksvcFound {
 		ctrlBuilder = ctrlBuilder.Owns(&knservingv1.Service{})
@@ -692,18 +807,27 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		r.Log.Info("The InferenceService controller won't watch networking.istio.io/v1beta1/VirtualService resources because the CRD is not available.")
 	}
 
-	// Add watches for ServingRuntime and ClusterServingRuntime to populate cache
+	if httpRouteFound && ingressConfig.EnableGatewayAPI {
+		ctrlBuilder = ctrlBuilder.Owns(&gatewayapiv1.HTTPRoute{})
+	} else {
+		r.Log.Info("The InferenceService controller won't watch gateway.networking.k8s.io/v1/HTTPRoute resources because the CRD is not available or the Gateway API is disabled.")
+	}
+
+	// Add watches for ServingRuntime, ClusterServingRuntime and AcceleratorClass to populate the cache
+	// and to drop cached runtime selections whenever the spec or capability labels of one changes
+	invalidateSelections := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		selectorConfig.SelectionCache.Invalidate()
+		return nil
+	})
+	specOrLabelsChanged := builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))
 	ctrlBuilder = ctrlBuilder.
-		Watches(&v1beta1.ServingRuntime{},
-			handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
-				return nil // Just populate cache
-			})).
-		Watches(&v1beta1.ClusterServingRuntime{},
-			handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
-				return nil // Just populate cache
-			}))
-
-	return ctrlBuilder.Complete(r)
+		Watches(&v1beta1.ServingRuntime{}, invalidateSelections, specOrLabelsChanged).
+		Watches(&v1beta1.ClusterServingRuntime{}, invalidateSelections, specOrLabelsChanged).
+		Watches(&v1beta1.AcceleratorClass{}, invalidateSelections, specOrLabelsChanged)
+
+	// The cache of a sharded manager only holds the InferenceServices of its shard, so there is nothing to skip
+	return ctrlBuilder.Complete(tracing.Reconciler("inferenceservice", controllermetrics.Reconciler("inferenceservice",
+		controllerhealth.Reconciler("inferenceservice", &v1beta1.InferenceService{}, r))))
 }
 
 func (r *InferenceServiceReconciler) setExternalServiceURL(ctx context.Context, isvc *v1beta1.InferenceService, ingressConfig *controllerconfig.IngressConfig) error {
@@ -0,0 +1,84 @@
+package inferenceservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+)
+
+// Runtime selection results reported by the runtimeSelectionDuration histogram
+const (
+	selectionResultSelected = "selected"
+	selectionResultNoMatch  = "no_match"
+	selectionResultError    = "error"
+)
+
+var (
+	runtimeSelectionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ome_runtime_selection_duration_seconds",
+		Help:    "Time taken to auto-select a runtime for an InferenceService by result (selected, no_match, error)",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 12),
+	}, []string{"result"})
+
+	runtimeSelectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ome_runtime_selections_total",
+		Help: "Number of times a runtime was auto-selected for an InferenceService in place of another or none",
+	}, []string{"runtime", "kind"})
+
+	runtimeSelectionNoMatchTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ome_runtime_selection_no_match_total",
+		Help: "Number of runtime selections that found no compatible runtime by model format",
+	}, []string{"model_format"})
+
+	// inferenceServiceConditions reads the InferenceServices from the cache of the manager once it is set up
+	inferenceServiceConditions = controllermetrics.NewStateCollector(
+		"ome_inferenceservice_conditions",
+		"Number of InferenceServices by namespace, condition type and status (True, False, Unknown)",
+		[]string{"namespace", "condition", "status"},
+		countInferenceServiceConditions,
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(runtimeSelectionDuration, runtimeSelectionsTotal, runtimeSelectionNoMatchTotal,
+		inferenceServiceConditions)
+}
+
+// countInferenceServiceConditions counts the InferenceServices by the status of each of their conditions
+func countInferenceServiceConditions(ctx context.Context, reader client.Reader, counts *controllermetrics.StateCounts) error {
+	isvcs := &v1beta1.InferenceServiceList{}
+	if err := reader.List(ctx, isvcs); err != nil {
+		return err
+	}
+	for _, isvc := range isvcs.Items {
+		for _, condition := range isvc.Status.Conditions {
+			counts.Add(isvc.Namespace, string(condition.Type), string(condition.Status))
+		}
+	}
+	return nil
+}
+
+// recordRuntimeSelectionMetrics publishes the outcome of an auto-selection that took the given duration
+func recordRuntimeSelectionMetrics(duration time.Duration, modelFormat string, err error) {
+	switch {
+	case err == nil:
+		runtimeSelectionDuration.WithLabelValues(selectionResultSelected).Observe(duration.Seconds())
+	case runtimeselector.IsNoRuntimeFoundError(err):
+		runtimeSelectionDuration.WithLabelValues(selectionResultNoMatch).Observe(duration.Seconds())
+		runtimeSelectionNoMatchTotal.WithLabelValues(modelFormat).Inc()
+	default:
+		runtimeSelectionDuration.WithLabelValues(selectionResultError).Observe(duration.Seconds())
+	}
+}
+
+// recordRuntimeSelected counts an auto-selected runtime that differs from the one in the status of the InferenceService
+func recordRuntimeSelected(selection *runtimeselector.RuntimeSelection) {
+	runtimeSelectionsTotal.WithLabelValues(selection.Name, runtimeselector.RuntimeKind(selection.IsCluster)).Inc()
+}
@@ -0,0 +1,74 @@
+package inferenceservice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllermetrics"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+)
+
+func TestRecordRuntimeSelectionMetrics(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	selected := runtimeSelectionsTotal.WithLabelValues("srt-llama", "ClusterServingRuntime")
+	noMatch := runtimeSelectionNoMatchTotal.WithLabelValues("safetensors")
+	selectedBefore := testutil.ToFloat64(selected)
+	noMatchBefore := testutil.ToFloat64(noMatch)
+
+	recordRuntimeSelectionMetrics(time.Millisecond, "safetensors", nil)
+	recordRuntimeSelectionMetrics(time.Millisecond, "safetensors", &runtimeselector.NoRuntimeFoundError{ModelFormat: "safetensors"})
+	recordRuntimeSelectionMetrics(time.Millisecond, "safetensors", errors.New("list failed"))
+	// Only a selection that differs from the status is counted
+	g.Expect(testutil.ToFloat64(selected)).To(gomega.Equal(selectedBefore))
+	recordRuntimeSelected(&runtimeselector.RuntimeSelection{Name: "srt-llama", IsCluster: true})
+
+	g.Expect(testutil.ToFloat64(selected)).To(gomega.Equal(selectedBefore + 1))
+	g.Expect(testutil.ToFloat64(noMatch)).To(gomega.Equal(noMatchBefore + 1))
+	g.Expect(testutil.CollectAndCount(runtimeSelectionDuration)).To(gomega.BeNumerically(">=", 3))
+}
+
+func TestInferenceServiceConditions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	isvc := func(name string, conditions ...apis.Condition) *v1beta1.InferenceService {
+		return &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "serving"},
+			Status:     v1beta1.InferenceServiceStatus{Status: duckv1.Status{Conditions: conditions}},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		isvc("llama",
+			apis.Condition{Type: v1beta1.IngressReady, Status: corev1.ConditionTrue},
+			apis.Condition{Type: apis.ConditionReady, Status: corev1.ConditionTrue}),
+		isvc("mistral",
+			apis.Condition{Type: v1beta1.IngressReady, Status: corev1.ConditionTrue},
+			apis.Condition{Type: apis.ConditionReady, Status: corev1.ConditionFalse}),
+		isvc("qwen"),
+	).Build()
+
+	collector := controllermetrics.NewStateCollector("test_inferenceservice_conditions", "test",
+		[]string{"namespace", "condition", "status"}, countInferenceServiceConditions)
+	collector.SetReader(c)
+	g.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP test_inferenceservice_conditions test
+# TYPE test_inferenceservice_conditions gauge
+test_inferenceservice_conditions{condition="IngressReady",namespace="serving",status="True"} 2
+test_inferenceservice_conditions{condition="Ready",namespace="serving",status="False"} 1
+test_inferenceservice_conditions{condition="Ready",namespace="serving",status="True"} 1
+`))).To(gomega.Succeed())
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	hpa "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/hpa"
 	keda "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
@@ -54,8 +55,9 @@ func NewAutoscalerReconciler(
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec *v1beta1.InferenceServiceSpec,
+	tracker *drift.Tracker,
 ) (*AutoscalerReconciler, error) {
-	as, err := createAutoscaler(client, scheme, componentMeta, inferenceServiceSpec)
+	as, err := createAutoscaler(client, scheme, componentMeta, inferenceServiceSpec, tracker)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +81,7 @@ func getAutoscalerClass(metadata metav1.ObjectMeta) constants.AutoscalerClassTyp
 func createAutoscaler(client client.Client,
 	scheme *runtime.Scheme, componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec *v1beta1.InferenceServiceSpec,
+	tracker *drift.Tracker,
 ) (Autoscaler, error) {
 	ac := getAutoscalerClass(componentMeta)
 
@@ -99,7 +102,7 @@ func createAutoscaler(client client.Client,
 		if err != nil {
 			return nil, fmt.Errorf("failed to delete existing HPA: %w", err)
 		}
-		return keda.NewKEDAReconciler(client, scheme, componentMeta, inferenceServiceSpec)
+		return keda.NewKEDAReconciler(client, scheme, componentMeta, inferenceServiceSpec, tracker)
 	default:
 		return nil, fmt.Errorf("unknown autoscaler class type: %v", ac)
 	}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/knative"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/multinode"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/multinodevllm"
@@ -26,6 +27,8 @@ type DeploymentReconciler struct {
 	Scheme        *runtime.Scheme
 	StatusManager *status.StatusReconciler
 	Log           logr.Logger
+	// Drift tracks the drift of the Services and ScaledObjects, nothing if nil
+	Drift *drift.Tracker
 }
 
 // ReconcileRawDeployment handles raw Kubernetes deployment
@@ -44,7 +47,7 @@ func (r *DeploymentReconciler) ReconcileRawDeployment(
 		KedaConfig: isvc.Spec.KedaConfig,
 	}
 
-	reconciler, err := raw.NewRawKubeReconciler(r.Client, r.Clientset, r.Scheme, objectMeta, inferenceServiceSpec, podSpec)
+	reconciler, err := raw.NewRawKubeReconciler(r.Client, r.Clientset, r.Scheme, objectMeta, inferenceServiceSpec, podSpec, r.Drift)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to create RawKubeReconciler for %s", componentType)
 	}
@@ -74,7 +77,7 @@ func (r *DeploymentReconciler) ReconcileMultiNodeDeployment(
 ) (ctrl.Result, error) {
 	r.Log.Info("Reconciling multi-node deployment", "component", componentType, "inferenceService", isvc.Name)
 
-	reconciler, err := multinode.NewMultiNodeReconciler(r.Client, r.Clientset, r.Scheme, objectMeta, componentSpec, leaderPodSpec, workerSize, workerPodSpec)
+	reconciler, err := multinode.NewMultiNodeReconciler(r.Client, r.Clientset, r.Scheme, objectMeta, componentSpec, leaderPodSpec, workerSize, workerPodSpec, r.Drift)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to create MultiNodeReconciler for %s", componentType)
 	}
@@ -0,0 +1,148 @@
+// Package drift detects the children of the InferenceServices changed by someone else than the controller, e.g. a
+// VirtualService, ScaledObject or Service edited by hand, and counts their corrections.
+//
+// The children are watched, so a change to one triggers a reconcile of its InferenceService, which updates the child
+// back to its desired state. An update is a drift correction when the desired state is the one the child was last
+// created with, updated to or found in: the desired state did not change, so the child did. The desired states are
+// remembered by the Tracker of the controller until their InferenceService is deleted, so the first update of a
+// child after a restart is not counted.
+package drift
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// Kinds of the children whose drift is counted
+const (
+	KindService        = "Service"
+	KindVirtualService = "VirtualService"
+	KindScaledObject   = "ScaledObject"
+	KindIngress        = "Ingress"
+	KindHTTPRoute      = "HTTPRoute"
+)
+
+var log = logf.Log.WithName("Drift")
+
+var driftCorrectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ome_inferenceservice_drift_corrections_total",
+	Help: "Number of children of the InferenceServices updated back to their desired state after being changed by someone else than the controller, by kind",
+}, []string{"kind"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(driftCorrectionsTotal)
+}
+
+type objectKey struct {
+	kind string
+	types.NamespacedName
+}
+
+// Tracker remembers the desired states of the children of the InferenceServices reconciled by a controller. A nil
+// Tracker tracks nothing.
+type Tracker struct {
+	mu sync.Mutex
+	// desired holds the hashes of the desired states of the children by InferenceService
+	desired map[types.NamespacedName]map[objectKey]uint64
+}
+
+// NewTracker returns a tracker remembering no desired state
+func NewTracker() *Tracker {
+	return &Tracker{desired: map[types.NamespacedName]map[objectKey]uint64{}}
+}
+
+// InSync records that the child of the InferenceService isvc is in the desired state, i.e. it was just created or
+// found in it. desiredState is the part of the desired state compared to the child.
+func (t *Tracker) InSync(isvc types.NamespacedName, kind string, key types.NamespacedName, desiredState any) {
+	if t == nil {
+		return
+	}
+	hash, ok := hashOf(desiredState)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.children(isvc)[objectKey{kind: kind, NamespacedName: key}] = hash
+}
+
+// Corrected records that the child of the InferenceService isvc was updated to the desired state, and reports
+// whether it was a drift correction, i.e. whether the child was last in the same desired state
+func (t *Tracker) Corrected(isvc types.NamespacedName, kind string, key types.NamespacedName, desiredState any) bool {
+	if t == nil {
+		return false
+	}
+	hash, ok := hashOf(desiredState)
+	if !ok {
+		return false
+	}
+	objKey := objectKey{kind: kind, NamespacedName: key}
+	t.mu.Lock()
+	children := t.children(isvc)
+	last, known := children[objKey]
+	children[objKey] = hash
+	t.mu.Unlock()
+
+	if !known || last != hash {
+		return false
+	}
+	driftCorrectionsTotal.WithLabelValues(kind).Inc()
+	log.Info("Corrected the drift of a child of an InferenceService", "kind", kind, "namespace", key.Namespace, "name", key.Name)
+	return true
+}
+
+// Forget forgets the desired state of the deleted child of the InferenceService isvc
+func (t *Tracker) Forget(isvc types.NamespacedName, kind string, key types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.desired[isvc], objectKey{kind: kind, NamespacedName: key})
+}
+
+// ForgetInferenceService forgets the desired states of the children of the deleted InferenceService isvc
+func (t *Tracker) ForgetInferenceService(isvc types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.desired, isvc)
+}
+
+// InferenceServiceOf returns the InferenceService of a child from the labels of its component
+func InferenceServiceOf(componentMeta metav1.ObjectMeta) types.NamespacedName {
+	return types.NamespacedName{Namespace: componentMeta.Namespace, Name: componentMeta.Labels[constants.InferenceServicePodLabelKey]}
+}
+
+// children returns the desired states of the children of the InferenceService, the lock held
+func (t *Tracker) children(isvc types.NamespacedName) map[objectKey]uint64 {
+	children, ok := t.desired[isvc]
+	if !ok {
+		children = map[objectKey]uint64{}
+		t.desired[isvc] = children
+	}
+	return children
+}
+
+// hashOf hashes the JSON of the desired state, which is built the same way at every reconcile
+func hashOf(desiredState any) (uint64, bool) {
+	data, err := json.Marshal(desiredState)
+	if err != nil {
+		log.Error(err, "Failed to hash the desired state of a child of an InferenceService")
+		return 0, false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return h.Sum64(), true
+}
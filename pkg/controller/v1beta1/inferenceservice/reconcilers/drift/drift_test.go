@@ -0,0 +1,48 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCorrected(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tracker := NewTracker()
+	isvc := types.NamespacedName{Namespace: "serving", Name: "llama"}
+	key := types.NamespacedName{Namespace: "serving", Name: "llama-engine"}
+	ports := []corev1.ServicePort{{Name: "http", Port: 8080}}
+	before := testutil.ToFloat64(driftCorrectionsTotal.WithLabelValues(KindService))
+
+	// Unknown desired state, e.g. after a restart of the manager
+	g.Expect(tracker.Corrected(isvc, KindService, key, ports)).To(gomega.BeFalse())
+	// Same desired state as the last update: the service was changed by someone else
+	g.Expect(tracker.Corrected(isvc, KindService, key, ports)).To(gomega.BeTrue())
+
+	tracker.InSync(isvc, KindService, key, ports)
+	g.Expect(tracker.Corrected(isvc, KindService, key, ports)).To(gomega.BeTrue())
+	// Another kind of the same name is tracked on its own
+	g.Expect(tracker.Corrected(isvc, KindVirtualService, key, ports)).To(gomega.BeFalse())
+
+	// New desired state: the update is not a correction
+	ports = []corev1.ServicePort{{Name: "http", Port: 8081}}
+	g.Expect(tracker.Corrected(isvc, KindService, key, ports)).To(gomega.BeFalse())
+
+	tracker.Forget(isvc, KindService, key)
+	g.Expect(tracker.Corrected(isvc, KindService, key, ports)).To(gomega.BeFalse())
+
+	// The children of a deleted InferenceService are forgotten
+	tracker.ForgetInferenceService(isvc)
+	g.Expect(tracker.desired).To(gomega.BeEmpty())
+	g.Expect(tracker.Corrected(isvc, KindService, key, ports)).To(gomega.BeFalse())
+
+	// A nil tracker tracks nothing
+	var none *Tracker
+	none.InSync(isvc, KindService, key, ports)
+	g.Expect(none.Corrected(isvc, KindService, key, ports)).To(gomega.BeFalse())
+
+	g.Expect(testutil.ToFloat64(driftCorrectionsTotal.WithLabelValues(KindService)) - before).To(gomega.Equal(2.0))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
 )
 
@@ -27,20 +28,24 @@ type ExternalServiceReconciler struct {
 	clientset     kubernetes.Interface
 	scheme        *runtime.Scheme
 	ingressConfig *controllerconfig.IngressConfig
+	drift         *drift.Tracker
 }
 
-// NewExternalServiceReconciler creates a new external service reconciler
+// NewExternalServiceReconciler creates a new external service reconciler. The drift of the service is tracked by
+// tracker, not at all if nil.
 func NewExternalServiceReconciler(
 	client client.Client,
 	clientset kubernetes.Interface,
 	scheme *runtime.Scheme,
 	ingressConfig *controllerconfig.IngressConfig,
+	tracker *drift.Tracker,
 ) *ExternalServiceReconciler {
 	return &ExternalServiceReconciler{
 		client:        client,
 		clientset:     clientset,
 		scheme:        scheme,
 		ingressConfig: ingressConfig,
+		drift:         tracker,
 	}
 }
 
@@ -51,10 +56,11 @@ func (r *ExternalServiceReconciler) Reconcile(ctx context.Context, isvc *v1beta1
 
 	// Get existing service
 	existingService := &corev1.Service{}
-	err := r.client.Get(ctx, client.ObjectKey{
+	key := client.ObjectKey{
 		Namespace: isvc.Namespace,
 		Name:      isvc.Name,
-	}, existingService)
+	}
+	err := r.client.Get(ctx, key, existingService)
 
 	serviceExists := err == nil
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -77,18 +83,23 @@ func (r *ExternalServiceReconciler) Reconcile(ctx context.Context, isvc *v1beta1
 				if err := r.client.Update(ctx, existingService); err != nil {
 					return errors.Wrapf(err, "failed to update external service for InferenceService %s", isvc.Name)
 				}
+				r.drift.Corrected(key, drift.KindService, key, driftState(&desiredService.Spec))
+			} else {
+				r.drift.InSync(key, drift.KindService, key, driftState(&desiredService.Spec))
 			}
 		} else {
 			// Create new service
 			if err := r.client.Create(ctx, desiredService); err != nil {
 				return errors.Wrapf(err, "failed to create external service for InferenceService %s", isvc.Name)
 			}
+			r.drift.InSync(key, drift.KindService, key, driftState(&desiredService.Spec))
 		}
 	} else if serviceExists {
 		// Delete existing service if it should no longer exist
 		if err := r.client.Delete(ctx, existingService); err != nil {
 			return errors.Wrapf(err, "failed to delete external service for InferenceService %s", isvc.Name)
 		}
+		r.drift.Forget(key, drift.KindService, key)
 	}
 
 	return nil
@@ -221,6 +232,11 @@ func (r *ExternalServiceReconciler) serviceSpecsEqual(spec1, spec2 *corev1.Servi
 		spec1.Type == spec2.Type
 }
 
+// driftState returns the fields of the service spec compared by serviceSpecsEqual
+func driftState(spec *corev1.ServiceSpec) any {
+	return []any{spec.Selector, spec.Ports, spec.Type}
+}
+
 // getDeploymentMode determines the deployment mode of the InferenceService
 func (r *ExternalServiceReconciler) getDeploymentMode(isvc *v1beta1.InferenceService) constants.DeploymentModeType {
 	// Check if this is a MultiNode deployment by looking for Leader/Worker specs
@@ -173,7 +173,7 @@ func TestExternalServiceReconciler_shouldCreateExternalService(t *testing.T) {
 			client := ctrlclient.NewClientBuilder().WithScheme(scheme).Build()
 			clientset := fake.NewSimpleClientset()
 
-			reconciler := NewExternalServiceReconciler(client, clientset, scheme, tt.ingressConfig)
+			reconciler := NewExternalServiceReconciler(client, clientset, scheme, tt.ingressConfig, nil)
 			result := reconciler.shouldCreateExternalService(tt.isvc)
 
 			assert.Equal(t, tt.expected, result, tt.description)
@@ -258,7 +258,7 @@ func TestExternalServiceReconciler_determineTargetSelector(t *testing.T) {
 			clientset := fake.NewSimpleClientset()
 			ingressConfig := &controllerconfig.IngressConfig{}
 
-			reconciler := NewExternalServiceReconciler(client, clientset, scheme, ingressConfig)
+			reconciler := NewExternalServiceReconciler(client, clientset, scheme, ingressConfig, nil)
 			result := reconciler.determineTargetSelector(tt.isvc)
 
 			assert.Equal(t, tt.expectedSelector, result, tt.description)
@@ -367,7 +367,7 @@ func TestExternalServiceReconciler_buildExternalService(t *testing.T) {
 			clientset := fake.NewSimpleClientset()
 			ingressConfig := &controllerconfig.IngressConfig{}
 
-			reconciler := NewExternalServiceReconciler(client, clientset, scheme, ingressConfig)
+			reconciler := NewExternalServiceReconciler(client, clientset, scheme, ingressConfig, nil)
 			service, err := reconciler.buildExternalService(context.TODO(), tt.isvc)
 
 			assert.NoError(t, err, "should not return error when building external service")
@@ -568,7 +568,7 @@ func TestExternalServiceReconciler_Reconcile(t *testing.T) {
 			client := ctrlclient.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
 			clientset := fake.NewSimpleClientset()
 
-			reconciler := NewExternalServiceReconciler(client, clientset, scheme, tt.ingressConfig)
+			reconciler := NewExternalServiceReconciler(client, clientset, scheme, tt.ingressConfig, nil)
 
 			err := reconciler.Reconcile(context.TODO(), tt.isvc)
 
@@ -695,7 +695,7 @@ func TestExternalServiceReconciler_getDeploymentMode(t *testing.T) {
 			clientset := fake.NewSimpleClientset()
 			ingressConfig := &controllerconfig.IngressConfig{}
 
-			reconciler := NewExternalServiceReconciler(client, clientset, scheme, ingressConfig)
+			reconciler := NewExternalServiceReconciler(client, clientset, scheme, ingressConfig, nil)
 			result := reconciler.getDeploymentMode(tt.isvc)
 
 			assert.Equal(t, tt.expectedMode, result, tt.description)
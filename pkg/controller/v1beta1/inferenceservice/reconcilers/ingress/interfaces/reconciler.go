@@ -8,6 +8,7 @@ import (
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 )
 
 // IngressStrategy defines the interface for different ingress reconciliation strategies
@@ -45,4 +46,5 @@ type ReconcilerOptions struct {
 	Scheme        *runtime.Scheme
 	IngressConfig *controllerconfig.IngressConfig
 	IsvcConfig    *controllerconfig.InferenceServicesConfig
+	Drift         *drift.Tracker
 }
@@ -0,0 +1,58 @@
+package ingress
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// Ingress programming results reported by the programmingDuration histogram
+const (
+	programmingResultSuccess = "success"
+	programmingResultError   = "error"
+)
+
+var (
+	programmingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ome_ingress_programming_duration_seconds",
+		Help:    "Time taken to create or update the ingress, VirtualService or HTTPRoute of an InferenceService by strategy (KubernetesIngress, GatewayAPI, Serverless) and result (success, error)",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"strategy", "result"})
+
+	readyLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ome_ingress_ready_latency_seconds",
+		Help:    "Time taken for the route of an InferenceService to become ready since it was created or last became not ready, by strategy",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 14),
+	}, []string{"strategy"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(programmingDuration, readyLatency)
+}
+
+// notReadySince returns since when the route of the InferenceService is not ready, and false if it is ready
+func notReadySince(isvc *v1beta1.InferenceService) (time.Time, bool) {
+	if isvc.Status.IsConditionReady(v1beta1.IngressReady) {
+		return time.Time{}, false
+	}
+	if condition := isvc.Status.GetCondition(v1beta1.IngressReady); condition != nil && !condition.LastTransitionTime.Inner.IsZero() {
+		return condition.LastTransitionTime.Inner.Time, true
+	}
+	return isvc.CreationTimestamp.Time, true
+}
+
+// recordProgrammingMetrics publishes the outcome of a programming of the route of the InferenceService by the strategy
+// that took the given duration. The ready latency is observed when the route became ready since the given time.
+func recordProgrammingMetrics(strategy string, duration time.Duration, isvc *v1beta1.InferenceService, notReady time.Time, wasNotReady bool, err error) {
+	if err != nil {
+		programmingDuration.WithLabelValues(strategy, programmingResultError).Observe(duration.Seconds())
+		return
+	}
+	programmingDuration.WithLabelValues(strategy, programmingResultSuccess).Observe(duration.Seconds())
+	if wasNotReady && !notReady.IsZero() && isvc.Status.IsConditionReady(v1beta1.IngressReady) {
+		readyLatency.WithLabelValues(strategy).Observe(time.Since(notReady).Seconds())
+	}
+}
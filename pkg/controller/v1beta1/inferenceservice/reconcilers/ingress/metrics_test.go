@@ -0,0 +1,40 @@
+package ingress
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestRecordProgrammingMetrics(t *testing.T) {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "serving", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute))},
+	}
+	notReady, wasNotReady := notReadySince(isvc)
+	assert.True(t, wasNotReady)
+	assert.Equal(t, isvc.CreationTimestamp.Time, notReady)
+
+	// A failed programming doesn't observe the ready latency
+	durationsBefore := testutil.CollectAndCount(programmingDuration)
+	readyBefore := testutil.CollectAndCount(readyLatency)
+	recordProgrammingMetrics("TestStrategy", time.Millisecond, isvc, notReady, wasNotReady, errors.New("conflict"))
+	assert.Equal(t, durationsBefore+1, testutil.CollectAndCount(programmingDuration))
+	assert.Equal(t, readyBefore, testutil.CollectAndCount(readyLatency))
+
+	isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{Type: v1beta1.IngressReady, Status: corev1.ConditionTrue})
+	recordProgrammingMetrics("TestStrategy", time.Millisecond, isvc, notReady, wasNotReady, nil)
+	assert.Equal(t, durationsBefore+2, testutil.CollectAndCount(programmingDuration))
+	assert.Equal(t, readyBefore+1, testutil.CollectAndCount(readyLatency))
+
+	notReady, wasNotReady = notReadySince(isvc)
+	assert.False(t, wasNotReady)
+	assert.True(t, notReady.IsZero())
+}
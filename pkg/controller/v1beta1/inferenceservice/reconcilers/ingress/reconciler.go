@@ -3,6 +3,7 @@ package ingress
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,6 +15,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/factory"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/interfaces"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
@@ -29,6 +31,7 @@ type IngressReconciler struct {
 	ingressConfig *controllerconfig.IngressConfig
 	isvcConfig    *controllerconfig.InferenceServicesConfig
 	factory       interfaces.StrategyFactory
+	drift         *drift.Tracker
 }
 
 // NewIngressReconciler creates a new main ingress reconciler
@@ -38,6 +41,7 @@ func NewIngressReconciler(
 	scheme *runtime.Scheme,
 	ingressConfig *controllerconfig.IngressConfig,
 	isvcConfig *controllerconfig.InferenceServicesConfig,
+	tracker *drift.Tracker,
 ) interfaces.Reconciler {
 	// Create factory
 	strategyFactory := factory.NewStrategyFactory(clientset)
@@ -49,6 +53,7 @@ func NewIngressReconciler(
 		ingressConfig: ingressConfig,
 		isvcConfig:    isvcConfig,
 		factory:       strategyFactory,
+		drift:         tracker,
 	}
 }
 
@@ -83,6 +88,7 @@ func (r *IngressReconciler) ReconcileWithDeploymentMode(ctx context.Context, isv
 		Scheme:        r.scheme,
 		IngressConfig: resolvedIngressConfig,
 		IsvcConfig:    r.isvcConfig,
+		Drift:         r.drift,
 	}
 
 	// Get the appropriate strategy
@@ -96,7 +102,11 @@ func (r *IngressReconciler) ReconcileWithDeploymentMode(ctx context.Context, isv
 		"isvc", isvc.Name)
 
 	// Execute the strategy
-	return strategy.Reconcile(ctx, isvc)
+	notReady, wasNotReady := notReadySince(isvc)
+	start := time.Now()
+	err = strategy.Reconcile(ctx, isvc)
+	recordProgrammingMetrics(strategy.GetName(), time.Since(start), isvc, notReady, wasNotReady, err)
+	return err
 }
 
 // Reconcile orchestrates the ingress reconciliation using the appropriate strategy
@@ -124,6 +124,7 @@ func TestIngressReconciler_Reconcile(t *testing.T) {
 				scheme,
 				tt.ingressConfig,
 				tt.isvcConfig,
+				nil,
 			).(*IngressReconciler)
 
 			// Add specific handling for disabled ingress creation test
@@ -381,6 +382,7 @@ func TestIngressReconciler_GetStrategy(t *testing.T) {
 				tt.opts.Scheme,
 				tt.opts.IngressConfig,
 				tt.opts.IsvcConfig,
+				nil,
 			)
 
 			strategy, err := reconciler.(*IngressReconciler).getStrategy(tt.deploymentMode, tt.opts)
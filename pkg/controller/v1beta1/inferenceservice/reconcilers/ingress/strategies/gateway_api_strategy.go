@@ -20,6 +20,7 @@ import (
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/builders"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/interfaces"
 )
@@ -38,6 +39,7 @@ type GatewayAPIStrategy struct {
 	domainService interfaces.DomainService
 	pathService   interfaces.PathService
 	builder       interfaces.HTTPRouteBuilder
+	drift         *drift.Tracker
 }
 
 // NewGatewayAPIStrategy creates a new Gateway API strategy
@@ -52,6 +54,7 @@ func NewGatewayAPIStrategy(opts interfaces.ReconcilerOptions, domainService inte
 		domainService: domainService,
 		pathService:   pathService,
 		builder:       builder,
+		drift:         opts.Drift,
 	}
 }
 
@@ -142,12 +145,14 @@ func (g *GatewayAPIStrategy) reconcileComponentHTTPRoute(ctx context.Context, is
 	}
 
 	existing := &gatewayapiv1.HTTPRoute{}
-	err = g.client.Get(ctx, types.NamespacedName{Name: httpRoute.Name, Namespace: isvc.Namespace}, existing)
+	key := types.NamespacedName{Name: httpRoute.Name, Namespace: isvc.Namespace}
+	err = g.client.Get(ctx, key, existing)
 	if err != nil {
 		if apierr.IsNotFound(err) {
 			if err := g.client.Create(ctx, httpRoute); err != nil {
 				return fmt.Errorf("failed to create %s HttpRoute %s: %w", componentType, httpRoute.Name, err)
 			}
+			g.drift.InSync(client.ObjectKeyFromObject(isvc), drift.KindHTTPRoute, key, httpRoute.Spec)
 		} else {
 			return err
 		}
@@ -162,6 +167,9 @@ func (g *GatewayAPIStrategy) reconcileComponentHTTPRoute(ctx context.Context, is
 			if err := g.client.Update(ctx, httpRoute); err != nil {
 				return fmt.Errorf("failed to update %s HttpRoute %s: %w", componentType, httpRoute.Name, err)
 			}
+			g.drift.Corrected(client.ObjectKeyFromObject(isvc), drift.KindHTTPRoute, key, httpRoute.Spec)
+		} else {
+			g.drift.InSync(client.ObjectKeyFromObject(isvc), drift.KindHTTPRoute, key, httpRoute.Spec)
 		}
 	}
 	return nil
@@ -18,6 +18,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/builders"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/interfaces"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
@@ -32,6 +33,7 @@ type KubernetesIngressStrategy struct {
 	domainService interfaces.DomainService
 	pathService   interfaces.PathService
 	builder       interfaces.IngressBuilder
+	drift         *drift.Tracker
 }
 
 // NewKubernetesIngressStrategy creates a new Kubernetes Ingress strategy
@@ -46,6 +48,7 @@ func NewKubernetesIngressStrategy(opts interfaces.ReconcilerOptions, domainServi
 		domainService: domainService,
 		pathService:   pathService,
 		builder:       builder,
+		drift:         opts.Drift,
 	}
 }
 
@@ -81,13 +84,17 @@ func (k *KubernetesIngressStrategy) Reconcile(ctx context.Context, isvc *v1beta1
 
 		// reconcile ingress
 		existingIngress := &netv1.Ingress{}
-		err = k.client.Get(ctx, types.NamespacedName{
+		key := types.NamespacedName{
 			Namespace: isvc.Namespace,
 			Name:      isvc.Name,
-		}, existingIngress)
+		}
+		err = k.client.Get(ctx, key, existingIngress)
 		if err != nil {
 			if apierr.IsNotFound(err) {
 				err = k.client.Create(ctx, ingress)
+				if err == nil {
+					k.drift.InSync(client.ObjectKeyFromObject(isvc), drift.KindIngress, key, ingress.Spec)
+				}
 			} else {
 				return err
 			}
@@ -96,6 +103,11 @@ func (k *KubernetesIngressStrategy) Reconcile(ctx context.Context, isvc *v1beta1
 				// Set ResourceVersion which is required for update operation
 				ingress.ResourceVersion = existingIngress.ResourceVersion
 				err = k.client.Update(ctx, ingress)
+				if err == nil {
+					k.drift.Corrected(client.ObjectKeyFromObject(isvc), drift.KindIngress, key, ingress.Spec)
+				}
+			} else {
+				k.drift.InSync(client.ObjectKeyFromObject(isvc), drift.KindIngress, key, ingress.Spec)
 			}
 		}
 		if err != nil {
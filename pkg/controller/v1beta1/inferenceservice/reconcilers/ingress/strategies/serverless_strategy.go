@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/builders"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/interfaces"
 )
@@ -46,6 +48,7 @@ type ServerlessStrategy struct {
 	domainService interfaces.DomainService
 	pathService   interfaces.PathService
 	builder       interfaces.VirtualServiceBuilder
+	drift         *drift.Tracker
 }
 
 // NewServerlessStrategy creates a new serverless strategy
@@ -61,6 +64,7 @@ func NewServerlessStrategy(opts interfaces.ReconcilerOptions, clientset kubernet
 		domainService: domainService,
 		pathService:   pathService,
 		builder:       builder,
+		drift:         opts.Drift,
 	}
 }
 
@@ -116,7 +120,8 @@ func (s *ServerlessStrategy) reconcileVirtualService(ctx context.Context, isvc *
 	}
 
 	existing := &istioclientv1beta1.VirtualService{}
-	getExistingErr := s.client.Get(ctx, types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}, existing)
+	key := types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}
+	getExistingErr := s.client.Get(ctx, key, existing)
 
 	if !disableIstioVirtualHost {
 		if desired == nil {
@@ -139,6 +144,7 @@ func (s *ServerlessStrategy) reconcileVirtualService(ctx context.Context, isvc *
 					log.Error(err, "Failed to create ingress", "namespace", virtualService.Namespace, "name", virtualService.Name)
 					return err
 				}
+				s.drift.InSync(client.ObjectKeyFromObject(isvc), drift.KindVirtualService, key, virtualServiceDriftState(virtualService))
 			}
 		} else {
 			if !s.routeSemanticEquals(virtualService, existing) {
@@ -151,6 +157,9 @@ func (s *ServerlessStrategy) reconcileVirtualService(ctx context.Context, isvc *
 					log.Error(err, "Failed to update ingress", "namespace", virtualService.Namespace, "name", virtualService.Name)
 					return err
 				}
+				s.drift.Corrected(client.ObjectKeyFromObject(isvc), drift.KindVirtualService, key, virtualServiceDriftState(virtualService))
+			} else {
+				s.drift.InSync(client.ObjectKeyFromObject(isvc), drift.KindVirtualService, key, virtualServiceDriftState(virtualService))
 			}
 		}
 	}
@@ -162,6 +171,7 @@ func (s *ServerlessStrategy) reconcileVirtualService(ctx context.Context, isvc *
 			if err := s.client.Delete(ctx, existing); err != nil {
 				return err
 			}
+			s.drift.Forget(client.ObjectKeyFromObject(isvc), drift.KindVirtualService, key)
 		}
 	} else if !apierr.IsNotFound(getExistingErr) {
 		return getExistingErr
@@ -376,3 +386,10 @@ func (s *ServerlessStrategy) routeSemanticEquals(desired, existing *istioclientv
 		equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, existing.ObjectMeta.Labels) &&
 		equality.Semantic.DeepEqual(desired.ObjectMeta.Annotations, existing.ObjectMeta.Annotations)
 }
+
+// virtualServiceDriftState returns the fields of the VirtualService compared by routeSemanticEquals, with the spec
+// marshaled deterministically
+func virtualServiceDriftState(virtualService *istioclientv1beta1.VirtualService) any {
+	spec, _ := proto.MarshalOptions{Deterministic: true}.Marshal(&virtualService.Spec)
+	return []any{spec, virtualService.Labels, virtualService.Annotations}
+}
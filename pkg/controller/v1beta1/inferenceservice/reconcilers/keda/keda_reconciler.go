@@ -16,6 +16,7 @@ import (
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
 )
 
@@ -27,14 +28,18 @@ type KEDAReconciler struct {
 	scheme       *runtime.Scheme
 	ScaledObject *kedav1.ScaledObject
 	componentExt *v1beta1.ComponentExtensionSpec
+	drift        *drift.Tracker
+	isvc         types.NamespacedName
 }
 
-// NewKEDAReconciler creates a new KEDAReconciler
+// NewKEDAReconciler creates a new KEDAReconciler. The drift of the ScaledObject is tracked by tracker, not at all if
+// nil.
 func NewKEDAReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec *v1beta1.InferenceServiceSpec,
+	tracker *drift.Tracker,
 ) (*KEDAReconciler, error) {
 
 	scaledObject := createScaledObject(componentMeta, *inferenceServiceSpec)
@@ -44,6 +49,8 @@ func NewKEDAReconciler(
 		scheme:       scheme,
 		ScaledObject: scaledObject,
 		componentExt: &inferenceServiceSpec.Predictor.ComponentExtensionSpec,
+		drift:        tracker,
+		isvc:         drift.InferenceServiceOf(componentMeta),
 	}, nil
 }
 
@@ -248,6 +255,11 @@ func semanticScaledObjectEquals(desired, existing *kedav1.ScaledObject) bool {
 	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) && !autoscalerClassChanged
 }
 
+// driftState returns the fields of the ScaledObject compared by semanticScaledObjectEquals
+func driftState(scaledObject *kedav1.ScaledObject) any {
+	return []any{scaledObject.Spec, scaledObject.Annotations[constants.AutoscalerClass]}
+}
+
 // shouldDeleteScaledObject determines if the ScaledObject should be deleted
 func shouldDeleteScaledObject(desired *kedav1.ScaledObject) bool {
 	desiredAutoscalerClass := desired.Annotations[constants.AutoscalerClass]
@@ -268,6 +280,7 @@ func (r *KEDAReconciler) Reconcile() (runtime.Object, error) {
 		return nil, err
 	}
 
+	key := types.NamespacedName{Namespace: r.ScaledObject.Namespace, Name: r.ScaledObject.Name}
 	var opErr error
 	switch checkResult {
 	case constants.CheckResultCreate:
@@ -280,6 +293,9 @@ func (r *KEDAReconciler) Reconcile() (runtime.Object, error) {
 	case constants.CheckResultDelete:
 		opErr = r.client.Delete(context.TODO(), r.ScaledObject)
 	default:
+		if existingScaledObject != nil {
+			r.drift.InSync(r.isvc, drift.KindScaledObject, key, driftState(r.ScaledObject))
+		}
 		return existingScaledObject, nil
 	}
 
@@ -287,6 +303,14 @@ func (r *KEDAReconciler) Reconcile() (runtime.Object, error) {
 		log.Error(opErr, "Failed to reconcile ScaledObject", "namespace", r.ScaledObject.Namespace, "name", r.ScaledObject.Name)
 		return nil, opErr
 	}
+	switch checkResult {
+	case constants.CheckResultCreate:
+		r.drift.InSync(r.isvc, drift.KindScaledObject, key, driftState(r.ScaledObject))
+	case constants.CheckResultUpdate:
+		r.drift.Corrected(r.isvc, drift.KindScaledObject, key, driftState(r.ScaledObject))
+	case constants.CheckResultDelete:
+		r.drift.Forget(r.isvc, drift.KindScaledObject, key)
+	}
 
 	return r.ScaledObject, nil
 }
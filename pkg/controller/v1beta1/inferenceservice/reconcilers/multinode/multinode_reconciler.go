@@ -14,6 +14,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/services"
 	raycluster "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/istiosidecar"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/lws"
@@ -36,7 +37,8 @@ func NewMultiNodeReconciler(client client.Client,
 	componentExt *v1beta1.ComponentExtensionSpec,
 	headPodSpec *corev1.PodSpec,
 	workerSize int,
-	workerPodSpec *corev1.PodSpec) (*MultiNodeReconciler, error) {
+	workerPodSpec *corev1.PodSpec,
+	tracker *drift.Tracker) (*MultiNodeReconciler, error) {
 
 	url, err := createRawURL(clientset, componentMeta)
 	if err != nil {
@@ -55,7 +57,7 @@ func NewMultiNodeReconciler(client client.Client,
 		LWS:          lws.NewLWSReconciler(client, scheme, headPodSpec, workerPodSpec, int32(workerSize), componentExt, componentMeta),
 		URL:          url,
 		IstioSidecar: raycluster.NewIstioSidecarReconciler(client, scheme, componentMeta, enabled),
-		Service:      service.NewServiceReconciler(client, scheme, componentMeta, componentExt, headPodSpec, selector),
+		Service:      service.NewServiceReconciler(client, scheme, componentMeta, componentExt, headPodSpec, selector, tracker),
 	}, nil
 }
 
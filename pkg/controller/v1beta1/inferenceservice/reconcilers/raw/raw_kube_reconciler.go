@@ -13,6 +13,7 @@ import (
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/deployment"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/services"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/pdb"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/service"
@@ -36,8 +37,9 @@ func NewRawKubeReconciler(client client.Client,
 	componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec *v1beta1.InferenceServiceSpec,
 	podSpec *corev1.PodSpec,
+	tracker *drift.Tracker,
 ) (*RawKubeReconciler, error) {
-	as, err := autoscaler.NewAutoscalerReconciler(client, clientset, scheme, componentMeta, inferenceServiceSpec)
+	as, err := autoscaler.NewAutoscalerReconciler(client, clientset, scheme, componentMeta, inferenceServiceSpec, tracker)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +58,7 @@ func NewRawKubeReconciler(client client.Client,
 		client:              client,
 		scheme:              scheme,
 		Deployment:          deployment.NewDeploymentReconciler(client, scheme, componentMeta, componentExt, podSpec),
-		Service:             service.NewServiceReconciler(client, scheme, componentMeta, componentExt, podSpec, nil),
+		Service:             service.NewServiceReconciler(client, scheme, componentMeta, componentExt, podSpec, nil, tracker),
 		Scaler:              as,
 		PodDisruptionBudget: pdb,
 		URL:                 url,
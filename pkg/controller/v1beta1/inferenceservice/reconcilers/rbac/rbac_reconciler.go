@@ -3,6 +3,7 @@ package rbac
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -15,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
 )
 
 // RBACReconciler reconciles RBAC resources for components
@@ -24,7 +26,9 @@ type RBACReconciler struct {
 	objectMeta       metav1.ObjectMeta
 	componentType    v1beta1.ComponentType
 	inferenceService *v1beta1.InferenceService
-	Log              logr.Logger
+	// userServiceAccounts are the ServiceAccounts of the user the pods of the component run as, bound to its Role too
+	userServiceAccounts []string
+	Log                 logr.Logger
 }
 
 // NewRBACReconciler creates a new RBAC reconciler
@@ -45,6 +49,43 @@ func NewRBACReconciler(
 	}
 }
 
+// WithUserServiceAccounts also binds the Role of the component to the ServiceAccounts the user runs its pods as
+func (r *RBACReconciler) WithUserServiceAccounts(names ...string) *RBACReconciler {
+	for _, name := range names {
+		if name != "" && name != r.GetServiceAccountName() && !slices.Contains(r.userServiceAccounts, name) {
+			r.userServiceAccounts = append(r.userServiceAccounts, name)
+		}
+	}
+	return r
+}
+
+// policyRules returns the permissions the pods of the component need, none for the components not listed.
+// The router discovers the engine and decoder pods, the ome-agent init containers of the engine and decoder pods
+// publish their progress to the progress ConfigMap of their component, and may not patch any other object.
+func (r *RBACReconciler) policyRules() []rbacv1.PolicyRule {
+	switch r.componentType {
+	case v1beta1.RouterComponent:
+		return []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		}
+	case v1beta1.EngineComponent, v1beta1.DecoderComponent:
+		return []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{r.GetProgressConfigMapName()},
+				Verbs:         []string{"get", "patch"},
+			},
+		}
+	default:
+		return nil
+	}
+}
+
 // Reconcile ensures the RBAC resources are created and configured correctly
 func (r *RBACReconciler) Reconcile() error {
 	r.Log.Info("Reconciling RBAC resources", "name", r.objectMeta.Name, "namespace", r.objectMeta.Namespace, "component", r.componentType)
@@ -72,8 +113,15 @@ func (r *RBACReconciler) Reconcile() error {
 		return fmt.Errorf("failed to reconcile ServiceAccount: %w", err)
 	}
 
-	// Only create Role and RoleBinding for Router component
-	if r.componentType == v1beta1.RouterComponent {
+	// The agents patch the progress ConfigMap, which the Role can't allow them to create
+	if r.componentType == v1beta1.EngineComponent || r.componentType == v1beta1.DecoderComponent {
+		if err := r.reconcileProgressConfigMap(); err != nil {
+			return fmt.Errorf("failed to reconcile progress ConfigMap: %w", err)
+		}
+	}
+
+	// Only create Role and RoleBinding for the components whose pods use the Kubernetes API
+	if rules := r.policyRules(); len(rules) > 0 {
 		roleName := serviceAccountName
 		// Create Role
 		role := &rbacv1.Role{
@@ -82,13 +130,7 @@ func (r *RBACReconciler) Reconcile() error {
 				Namespace: r.objectMeta.Namespace,
 				Labels:    r.objectMeta.Labels,
 			},
-			Rules: []rbacv1.PolicyRule{
-				{
-					APIGroups: []string{""},
-					Resources: []string{"pods"},
-					Verbs:     []string{"get", "list", "watch"},
-				},
-			},
+			Rules: rules,
 		}
 
 		// Set owner reference
@@ -123,6 +165,13 @@ func (r *RBACReconciler) Reconcile() error {
 				},
 			},
 		}
+		for _, name := range r.userServiceAccounts {
+			roleBinding.Subjects = append(roleBinding.Subjects, rbacv1.Subject{
+				Kind:      "ServiceAccount",
+				Name:      name,
+				Namespace: r.objectMeta.Namespace,
+			})
+		}
 
 		// Set owner reference
 		if len(r.objectMeta.OwnerReferences) > 0 {
@@ -146,6 +195,38 @@ func (r *RBACReconciler) GetServiceAccountName() string {
 	return fmt.Sprintf("%s-%s", r.inferenceService.Name, string(r.componentType))
 }
 
+// GetProgressConfigMapName returns the name of the ConfigMap the agents of the pods of the component publish their
+// progress to
+func (r *RBACReconciler) GetProgressConfigMapName() string {
+	return constants.AgentProgressConfigMapName(r.inferenceService.Name, string(r.componentType))
+}
+
+// reconcileProgressConfigMap creates the progress ConfigMap of the component. An existing one is left alone as its
+// data is the progress the agents published.
+func (r *RBACReconciler) reconcileProgressConfigMap() error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.GetProgressConfigMapName(),
+			Namespace: r.objectMeta.Namespace,
+			Labels:    r.objectMeta.Labels,
+		},
+	}
+	// The InferenceService controls the ConfigMap so that the progress the agents publish is reconciled
+	if err := controllerutil.SetControllerReference(r.inferenceService, configMap, r.scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference for ConfigMap: %w", err)
+	}
+
+	err := r.client.Get(context.Background(), client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+	r.Log.Info("Creating resource", "kind", "ConfigMap", "name", configMap.Name)
+	if err := r.client.Create(context.Background(), configMap); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
 // createOrUpdate creates or updates a Kubernetes resource
 func (r *RBACReconciler) createOrUpdate(obj client.Object) error {
 	key := client.ObjectKeyFromObject(obj)
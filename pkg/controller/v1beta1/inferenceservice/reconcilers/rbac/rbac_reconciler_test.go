@@ -359,6 +359,65 @@ func TestRBACReconciler_Reconcile_NonRouterComponent(t *testing.T) {
 	assert.True(t, apierrors.IsNotFound(err), "RoleBinding should not exist for non-router component")
 }
 
+func TestRBACReconciler_Reconcile_AgentComponents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	objectMeta := metav1.ObjectMeta{Name: testServiceName, Namespace: testNamespace}
+	inferenceService := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: testInferenceService, Namespace: testNamespace, UID: "test-uid"},
+	}
+
+	for _, componentType := range []v1beta1.ComponentType{v1beta1.EngineComponent, v1beta1.DecoderComponent} {
+		t.Run(string(componentType), func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			reconciler := NewRBACReconciler(fakeClient, scheme, objectMeta, componentType, inferenceService).
+				WithUserServiceAccounts("", "model-reader", "model-reader")
+			require.NoError(t, reconciler.Reconcile())
+			expectedServiceAccountName := testInferenceService + "-" + string(componentType)
+
+			// The ome-agent init containers patch their progress onto the progress ConfigMap, and only it
+			expectedConfigMapName := testInferenceService + "-" + string(componentType) + "-progress"
+			role := &rbacv1.Role{}
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{
+				Name:      expectedServiceAccountName,
+				Namespace: testNamespace,
+			}, role))
+			assert.Equal(t, []rbacv1.PolicyRule{{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{expectedConfigMapName},
+				Verbs:         []string{"get", "patch"},
+			}}, role.Rules)
+
+			// The progress ConfigMap is created, and the progress published to it is kept
+			configMap := &corev1.ConfigMap{}
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{
+				Name:      expectedConfigMapName,
+				Namespace: testNamespace,
+			}, configMap))
+			assert.Equal(t, "test-uid", string(configMap.OwnerReferences[0].UID))
+			configMap.Data = map[string]string{"pod-0.model-init": `{"version":"v1"}`}
+			require.NoError(t, fakeClient.Update(context.Background(), configMap))
+			require.NoError(t, reconciler.Reconcile())
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(configMap), configMap))
+			assert.Equal(t, map[string]string{"pod-0.model-init": `{"version":"v1"}`}, configMap.Data)
+
+			// Both the ServiceAccount of the component and the one of the user are bound
+			rb := &rbacv1.RoleBinding{}
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{
+				Name:      expectedServiceAccountName,
+				Namespace: testNamespace,
+			}, rb))
+			assert.Equal(t, []rbacv1.Subject{
+				{Kind: "ServiceAccount", Name: expectedServiceAccountName, Namespace: testNamespace},
+				{Kind: "ServiceAccount", Name: "model-reader", Namespace: testNamespace},
+			}, rb.Subjects)
+		})
+	}
+}
+
 func TestRBACReconciler_Reconcile_Update(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, clientgoscheme.AddToScheme(scheme))
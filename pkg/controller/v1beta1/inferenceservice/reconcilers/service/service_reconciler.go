@@ -16,6 +16,7 @@ import (
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/drift"
 	"github.com/sgl-project/ome/pkg/utils"
 )
 
@@ -27,21 +28,27 @@ type ServiceReconciler struct {
 	scheme       *runtime.Scheme
 	Service      *corev1.Service
 	componentExt *v1beta1.ComponentExtensionSpec
+	drift        *drift.Tracker
+	isvc         types.NamespacedName
 }
 
-// NewServiceReconciler creates a new ServiceReconciler instance
+// NewServiceReconciler creates a new ServiceReconciler instance. The drift of the Service is tracked by tracker, not
+// at all if nil.
 func NewServiceReconciler(client client.Client,
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
 	podSpec *corev1.PodSpec,
 	Selector map[string]string,
+	tracker *drift.Tracker,
 ) *ServiceReconciler {
 	return &ServiceReconciler{
 		client:       client,
 		scheme:       scheme,
 		Service:      buildService(componentMeta, podSpec, Selector),
 		componentExt: componentExt,
+		drift:        tracker,
+		isvc:         drift.InferenceServiceOf(componentMeta),
 	}
 }
 
@@ -205,9 +212,15 @@ func semanticServiceEquals(desired, existing *corev1.Service) bool {
 		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector)
 }
 
+// driftState returns the fields of the service compared by semanticServiceEquals
+func driftState(service *corev1.Service) any {
+	return []any{service.Spec.Ports, service.Spec.Selector}
+}
+
 // handleReconcileAction performs the appropriate action based on the reconcile check result
 func (r *ServiceReconciler) handleReconcileAction(checkResult constants.CheckResultType, existingService *corev1.Service) (*corev1.Service, error) {
 	ctx := context.TODO()
+	key := types.NamespacedName{Namespace: r.Service.Namespace, Name: r.Service.Name}
 
 	switch checkResult {
 	case constants.CheckResultCreate:
@@ -216,14 +229,19 @@ func (r *ServiceReconciler) handleReconcileAction(checkResult constants.CheckRes
 			log.Error(err, "Failed to create Service", "namespace", r.Service.Namespace, "name", r.Service.Name)
 			return nil, err
 		}
+		r.drift.InSync(r.isvc, drift.KindService, key, driftState(r.Service))
 		return r.Service, nil
 	case constants.CheckResultUpdate:
 		if err := r.client.Update(ctx, r.Service); err != nil {
 			log.Error(err, "Failed to update Service", "namespace", r.Service.Namespace, "name", r.Service.Name)
 			return nil, err
 		}
+		r.drift.Corrected(r.isvc, drift.KindService, key, driftState(r.Service))
 		return r.Service, nil
 	default:
+		if existingService != nil {
+			r.drift.InSync(r.isvc, drift.KindService, key, driftState(r.Service))
+		}
 		return existingService, nil
 	}
 }
@@ -180,6 +180,16 @@ func (sr *StatusReconciler) PropagateModelStatus(
 	sr.checkContainerStatuses(status, firstPod, totalCopies)
 }
 
+// PropagateAgentProgress reports the progress the agents preparing the model of the first pod published to the
+// progress ConfigMap of the component, whose data is given
+func (sr *StatusReconciler) PropagateAgentProgress(status *v1beta1.InferenceServiceStatus, podList *v1.PodList, progress map[string]string) {
+	firstPod, err := sr.getFirstPod(podList)
+	if err != nil {
+		return
+	}
+	sr.propagateAgentProgress(status, firstPod, progress)
+}
+
 // UpdateModelRevisionStates updates the model revision states
 func (sr *StatusReconciler) UpdateModelRevisionStates(
 	status *v1beta1.InferenceServiceStatus,
@@ -9,6 +9,7 @@ import (
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	lwsspec "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
+	"github.com/sgl-project/ome/pkg/agentprogress"
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 )
@@ -321,6 +322,67 @@ func (sr *StatusReconciler) checkContainerStatuses(status *v1beta1.InferenceServ
 	}
 }
 
+// propagateAgentProgress reports the progress heartbeats the agents of the init containers of a pod publish to the
+// progress ConfigMap of its component as the ModelInitialized condition. The condition is Unknown with the phase and
+// percent complete of the agent at work, False with the error of an agent that failed and True once every agent
+// succeeded. Pods whose agents publish no progress leave the condition unset.
+func (sr *StatusReconciler) propagateAgentProgress(status *v1beta1.InferenceServiceStatus, pod *v1.Pod, progress map[string]string) {
+	var condition *apis.Condition
+	published := false
+	// Init containers run in order, the first one that didn't succeed is the one at work
+	for _, container := range pod.Spec.InitContainers {
+		if !publishesAgentProgress(container) {
+			continue
+		}
+		value, ok := progress[agentprogress.DataKey(pod.Name, container.Name)]
+		if !ok {
+			if condition == nil {
+				condition = &apis.Condition{
+					Status:  v1.ConditionUnknown,
+					Reason:  string(agentprogress.PhaseStarting),
+					Message: fmt.Sprintf("%s: waiting for its agent to start", container.Name),
+				}
+			}
+			continue
+		}
+		heartbeat, err := agentprogress.Parse([]byte(value))
+		if err != nil {
+			continue
+		}
+		published = true
+		if condition != nil || heartbeat.Phase == agentprogress.PhaseSucceeded {
+			continue
+		}
+		conditionStatus := v1.ConditionUnknown
+		if heartbeat.Phase == agentprogress.PhaseFailed {
+			conditionStatus = v1.ConditionFalse
+		}
+		condition = &apis.Condition{
+			Status:  conditionStatus,
+			Reason:  string(heartbeat.Phase),
+			Message: fmt.Sprintf("%s: %s", container.Name, heartbeat.Summary()),
+		}
+	}
+
+	if !published {
+		return
+	}
+	if condition == nil {
+		condition = &apis.Condition{Status: v1.ConditionTrue, Reason: string(agentprogress.PhaseSucceeded)}
+	}
+	sr.setCondition(status, v1beta1.ModelInitialized, condition)
+}
+
+// publishesAgentProgress returns whether a container runs an agent configured to publish its progress
+func publishesAgentProgress(container v1.Container) bool {
+	for _, env := range container.Env {
+		if env.Name == constants.AgentProgressContainerNameEnvVarKey {
+			return true
+		}
+	}
+	return false
+}
+
 // safeGetTerminationMessage safely extracts termination message from container status
 func (sr *StatusReconciler) safeGetTerminationMessage(cs v1.ContainerStatus) (message string, exitCode int32, hasTermination bool) {
 	if cs.State.Terminated != nil {
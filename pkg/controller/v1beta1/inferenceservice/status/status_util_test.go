@@ -922,6 +922,103 @@ func TestCheckContainerStatuses(t *testing.T) {
 	}
 }
 
+func TestPropagateAgentProgress(t *testing.T) {
+	publishing := func(name string) corev1.Container {
+		return corev1.Container{
+			Name: name,
+			Env:  []corev1.EnvVar{{Name: constants.AgentProgressContainerNameEnvVarKey, Value: name}},
+		}
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-engine-0"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				publishing(constants.ModelInitContainerName),
+				publishing(constants.FineTunedAdapterContainerName),
+				{Name: "other"},
+			},
+		},
+	}
+	modelInitKey := "llama-engine-0." + constants.ModelInitContainerName
+	adapterKey := "llama-engine-0." + constants.FineTunedAdapterContainerName
+
+	tests := []struct {
+		name              string
+		progress          map[string]string
+		expectedCondition *apis.Condition
+	}{
+		{
+			name: "no progress published",
+			progress: map[string]string{
+				"llama-engine-1." + constants.ModelInitContainerName: `{"version":"v1","agent":"enigma","phase":"Succeeded"}`,
+			},
+		},
+		{
+			name: "first agent at work",
+			progress: map[string]string{
+				modelInitKey: `{"version":"v1","agent":"enigma","phase":"Decrypting","completed":3,"total":8,"unit":"files","percent":37}`,
+			},
+			expectedCondition: &apis.Condition{
+				Status:  corev1.ConditionUnknown,
+				Reason:  "Decrypting",
+				Message: "model-init: Decrypting 37% (3/8 files)",
+			},
+		},
+		{
+			name: "second agent not started",
+			progress: map[string]string{
+				modelInitKey: `{"version":"v1","agent":"enigma","phase":"Succeeded"}`,
+			},
+			expectedCondition: &apis.Condition{
+				Status:  corev1.ConditionUnknown,
+				Reason:  "Starting",
+				Message: "fine-tuned-adapter: waiting for its agent to start",
+			},
+		},
+		{
+			name: "second agent failed",
+			progress: map[string]string{
+				modelInitKey: `{"version":"v1","agent":"enigma","phase":"Succeeded"}`,
+				adapterKey:   `{"version":"v1","agent":"fine-tuned-adapter","phase":"Failed","error":"object not found"}`,
+			},
+			expectedCondition: &apis.Condition{
+				Status:  corev1.ConditionFalse,
+				Reason:  "Failed",
+				Message: "fine-tuned-adapter: Failed: object not found",
+			},
+		},
+		{
+			name: "all agents succeeded",
+			progress: map[string]string{
+				modelInitKey: `{"version":"v1","agent":"enigma","phase":"Succeeded"}`,
+				adapterKey:   `{"version":"v1","agent":"fine-tuned-adapter","phase":"Succeeded"}`,
+			},
+			expectedCondition: &apis.Condition{
+				Status: corev1.ConditionTrue,
+				Reason: "Succeeded",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &v1beta1.InferenceServiceStatus{}
+			NewStatusReconciler().propagateAgentProgress(status, pod, tt.progress)
+
+			condition := status.GetCondition(v1beta1.ModelInitialized)
+			if tt.expectedCondition == nil {
+				assert.Nil(t, condition)
+				return
+			}
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, tt.expectedCondition.Status, condition.Status)
+				assert.Equal(t, tt.expectedCondition.Reason, condition.Reason)
+				assert.Equal(t, tt.expectedCondition.Message, condition.Message)
+			}
+		})
+	}
+}
+
 func TestSafeGetTerminationMessage(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"slices"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 )
@@ -38,6 +40,56 @@ func GetDeploymentMode(annotations map[string]string, deployConfig *controllerco
 	return constants.DeploymentModeType(deployConfig.DefaultDeploymentMode)
 }
 
+/*
+GetUserSpecifiedRuntime returns the name of the runtime the user picked for an InferenceService, either
+through spec.runtime or the runtime override annotation, and whether it came from the annotation.
+spec.runtime takes precedence. An empty name means the runtime is auto-selected.
+*/
+func GetUserSpecifiedRuntime(isvc *v1beta1.InferenceService) (string, bool) {
+	if isvc.Spec.Runtime != nil && isvc.Spec.Runtime.Name != "" {
+		return isvc.Spec.Runtime.Name, false
+	}
+	if name := strings.TrimSpace(isvc.Annotations[constants.RuntimeOverrideAnnotationKey]); name != "" {
+		return name, true
+	}
+	return "", false
+}
+
+/*
+GetModelDerivedComponents returns the components whose settings the defaulting webhook derived from the model
+metadata, each with the hash of the settings derived for it. The annotation lists them as component=hash pairs
+separated by commas.
+*/
+func GetModelDerivedComponents(annotations map[string]string) map[v1beta1.ComponentType]string {
+	derived := map[v1beta1.ComponentType]string{}
+	for _, pair := range strings.Split(annotations[constants.ModelDerivedResourcesAnnotationKey], ",") {
+		if component, hash, ok := strings.Cut(strings.TrimSpace(pair), "="); ok && hash != "" {
+			derived[v1beta1.ComponentType(component)] = hash
+		}
+	}
+	return derived
+}
+
+/*
+SetModelDerivedComponents records the components whose settings were derived from the model metadata in the
+annotations, or removes the annotation if there are none.
+*/
+func SetModelDerivedComponents(metadata *metav1.ObjectMeta, derived map[v1beta1.ComponentType]string) {
+	if len(derived) == 0 {
+		delete(metadata.Annotations, constants.ModelDerivedResourcesAnnotationKey)
+		return
+	}
+	pairs := make([]string, 0, len(derived))
+	for component, hash := range derived {
+		pairs = append(pairs, string(component)+"="+hash)
+	}
+	slices.Sort(pairs)
+	if metadata.Annotations == nil {
+		metadata.Annotations = map[string]string{}
+	}
+	metadata.Annotations[constants.ModelDerivedResourcesAnnotationKey] = strings.Join(pairs, ",")
+}
+
 func IsOriginalModelVolumeMountNecessary(annotations map[string]string) bool {
 	return annotations[constants.ModelInitInjectionKey] != "true" &&
 		annotations[constants.FTServingWithMergedWeightsAnnotationKey] != "true"
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 )
@@ -302,3 +304,46 @@ func TestGetDeploymentMode(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUserSpecifiedRuntime(t *testing.T) {
+	tests := []struct {
+		name               string
+		runtime            *v1beta1.ServingRuntimeRef
+		annotations        map[string]string
+		expectedName       string
+		expectedAnnotation bool
+	}{
+		{
+			name: "auto-selected",
+		},
+		{
+			name:         "spec runtime",
+			runtime:      &v1beta1.ServingRuntimeRef{Name: "srt-llama"},
+			expectedName: "srt-llama",
+		},
+		{
+			name:               "override annotation",
+			annotations:        map[string]string{constants.RuntimeOverrideAnnotationKey: " vllm-llama "},
+			expectedName:       "vllm-llama",
+			expectedAnnotation: true,
+		},
+		{
+			name:         "spec runtime takes precedence over annotation",
+			runtime:      &v1beta1.ServingRuntimeRef{Name: "srt-llama"},
+			annotations:  map[string]string{constants.RuntimeOverrideAnnotationKey: "vllm-llama"},
+			expectedName: "srt-llama",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       v1beta1.InferenceServiceSpec{Runtime: tt.runtime},
+			}
+			name, fromAnnotation := GetUserSpecifiedRuntime(isvc)
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedAnnotation, fromAnnotation)
+		})
+	}
+}
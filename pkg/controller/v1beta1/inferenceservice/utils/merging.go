@@ -50,9 +50,22 @@ func MergeRuntimeContainers(runtimeContainer *v1.Container, predictorContainer *
 	// Strategic merge patch will replace args but more useful behaviour here is to concatenate
 	mergedContainer.Args = append(append([]string{}, runtimeContainer.Args...), predictorContainer.Args...)
 
+	// A probe override may only tune the timing of the runtime probe, drop it if the runtime has no probe to tune
+	mergedContainer.StartupProbe = probeWithHandler(mergedContainer.StartupProbe)
+	mergedContainer.ReadinessProbe = probeWithHandler(mergedContainer.ReadinessProbe)
+	mergedContainer.LivenessProbe = probeWithHandler(mergedContainer.LivenessProbe)
+
 	return &mergedContainer, nil
 }
 
+// probeWithHandler returns the probe, or nil if it doesn't define how to probe the container
+func probeWithHandler(probe *v1.Probe) *v1.Probe {
+	if probe == nil || (probe.Exec == nil && probe.HTTPGet == nil && probe.TCPSocket == nil && probe.GRPC == nil) {
+		return nil
+	}
+	return probe
+}
+
 // mergeSpec merges two Kubernetes-style specs using strategic merge patch.
 // `runtimeInit` is the base (typically from a runtime default), and `override` comes from the user-defined resource.
 // Fields from `override` will overwrite corresponding fields in `runtimeInit`.
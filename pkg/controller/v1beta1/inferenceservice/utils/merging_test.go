@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestMergeArgs(t *testing.T) {
@@ -557,3 +560,35 @@ func TestOverrideKeyValueInSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeRuntimeContainersProbeTiming(t *testing.T) {
+	timingOnly := &v1.Container{
+		StartupProbe: &v1.Probe{PeriodSeconds: 10, FailureThreshold: 120},
+	}
+
+	t.Run("timing override keeps runtime handler", func(t *testing.T) {
+		runtimeContainer := &v1.Container{
+			Name: "ome-container",
+			StartupProbe: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(8080)},
+				},
+				PeriodSeconds:    6,
+				FailureThreshold: 150,
+			},
+		}
+
+		merged, err := MergeRuntimeContainers(runtimeContainer, timingOnly)
+		require.NoError(t, err)
+		require.NotNil(t, merged.StartupProbe)
+		assert.Equal(t, "/health", merged.StartupProbe.HTTPGet.Path)
+		assert.Equal(t, int32(10), merged.StartupProbe.PeriodSeconds)
+		assert.Equal(t, int32(120), merged.StartupProbe.FailureThreshold)
+	})
+
+	t.Run("timing override without runtime probe is dropped", func(t *testing.T) {
+		merged, err := MergeRuntimeContainers(&v1.Container{Name: "ome-container"}, timingOnly)
+		require.NoError(t, err)
+		assert.Nil(t, merged.StartupProbe)
+	})
+}
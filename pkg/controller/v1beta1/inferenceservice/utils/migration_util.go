@@ -11,12 +11,12 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	v1beta2 "github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 )
 
 // MigratePredictorToNewArchitecture migrates existing predictor resources to new engine/model architecture
-func MigratePredictorToNewArchitecture(ctx context.Context, c client.Client, log logr.Logger, isvc *v1beta2.InferenceService) error {
+func MigratePredictorToNewArchitecture(ctx context.Context, c client.Client, log logr.Logger, isvc *v1beta1.InferenceService) error {
 	// Check if predictor is being used and migration hasn't happened yet
 	if IsPredictorUsed(isvc) && isvc.Spec.Engine == nil && isvc.Spec.Model == nil {
 		log.Info("Migrating predictor spec to new architecture",
@@ -51,7 +51,7 @@ func MigratePredictorToNewArchitecture(ctx context.Context, c client.Client, log
 }
 
 // IsPredictorUsed checks if the Predictor field has any meaningful configuration
-func IsPredictorUsed(isvc *v1beta2.InferenceService) bool {
+func IsPredictorUsed(isvc *v1beta1.InferenceService) bool {
 	predictor := &isvc.Spec.Predictor
 
 	// Check if Model is defined in Predictor
@@ -79,10 +79,10 @@ func IsPredictorUsed(isvc *v1beta2.InferenceService) bool {
 }
 
 // MigratePredictor performs the actual migration from predictor to engine/model
-func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta2.InferenceService) error {
+func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta1.InferenceService) error {
 	// Migrate Model
 	if isvc.Spec.Predictor.Model != nil && isvc.Spec.Predictor.Model.BaseModel != nil {
-		isvc.Spec.Model = &v1beta2.ModelRef{
+		isvc.Spec.Model = &v1beta1.ModelRef{
 			Name:             *isvc.Spec.Predictor.Model.BaseModel,
 			FineTunedWeights: isvc.Spec.Predictor.Model.FineTunedWeights,
 		}
@@ -103,7 +103,7 @@ func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta2.Infere
 		if isvc.Spec.Predictor.Model.Runtime != nil {
 			runtimeKind := "ClusterServingRuntime"
 			runtimeAPIGroup := "ome.io"
-			isvc.Spec.Runtime = &v1beta2.ServingRuntimeRef{
+			isvc.Spec.Runtime = &v1beta1.ServingRuntimeRef{
 				Name:     *isvc.Spec.Predictor.Model.Runtime,
 				Kind:     &runtimeKind,
 				APIGroup: &runtimeAPIGroup,
@@ -112,7 +112,7 @@ func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta2.Infere
 	}
 
 	// Migrate Engine
-	isvc.Spec.Engine = &v1beta2.EngineSpec{
+	isvc.Spec.Engine = &v1beta1.EngineSpec{
 		PodSpec:                isvc.Spec.Predictor.PodSpec,
 		ComponentExtensionSpec: isvc.Spec.Predictor.ComponentExtensionSpec,
 	}
@@ -126,7 +126,7 @@ func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta2.Infere
 		for _, container := range isvc.Spec.Predictor.Containers {
 			if !runnerFound && (container.Name == "ome-container" || strings.Contains(strings.ToLower(container.Name), "ome")) {
 				// Migrate container from PredictorExtensionSpec to Runner
-				runnerSpec := &v1beta2.RunnerSpec{
+				runnerSpec := &v1beta1.RunnerSpec{
 					Container: container,
 				}
 
@@ -163,7 +163,7 @@ func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta2.Infere
 
 		// If no ome container found, use first container as Runner
 		if !runnerFound && len(isvc.Spec.Predictor.Containers) > 0 {
-			runnerSpec := &v1beta2.RunnerSpec{
+			runnerSpec := &v1beta1.RunnerSpec{
 				Container: isvc.Spec.Predictor.Containers[0],
 			}
 
@@ -197,7 +197,7 @@ func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta2.Infere
 		}
 	} else if isvc.Spec.Predictor.Model != nil {
 		// No containers in PodSpec, but we have Model spec with container configuration
-		runnerSpec := &v1beta2.RunnerSpec{
+		runnerSpec := &v1beta1.RunnerSpec{
 			Container: isvc.Spec.Predictor.Model.Container,
 		}
 
@@ -241,13 +241,13 @@ func MigratePredictor(ctx context.Context, c client.Client, isvc *v1beta2.Infere
 
 func DetermineModelKind(ctx context.Context, c client.Client, modelName string, namespace string) (string, error) {
 	// First, try to get ClusterBaseModel (cluster-scoped)
-	clusterBaseModelGetErr := c.Get(ctx, client.ObjectKey{Name: modelName}, &v1beta2.ClusterBaseModel{})
+	clusterBaseModelGetErr := c.Get(ctx, client.ObjectKey{Name: modelName}, &v1beta1.ClusterBaseModel{})
 	if clusterBaseModelGetErr == nil {
 		return "ClusterBaseModel", nil
 	}
 
 	// Try BaseModel (namespace-scoped) even if ClusterBaseModel lookup had an error
-	baseModelGetErr := c.Get(ctx, client.ObjectKey{Name: modelName, Namespace: namespace}, &v1beta2.BaseModel{})
+	baseModelGetErr := c.Get(ctx, client.ObjectKey{Name: modelName, Namespace: namespace}, &v1beta1.BaseModel{})
 	if baseModelGetErr == nil {
 		return "BaseModel", nil
 	}
@@ -14,7 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	v1beta2 "github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 )
 
@@ -23,15 +23,15 @@ func TestIsPredictorUsed(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		isvc     *v1beta2.InferenceService
+		isvc     *v1beta1.InferenceService
 		expected bool
 	}{
 		{
 			name: "Predictor with model and base model",
-			isvc: &v1beta2.InferenceService{
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
 						},
 					},
@@ -41,10 +41,10 @@ func TestIsPredictorUsed(t *testing.T) {
 		},
 		{
 			name: "Predictor with min replicas",
-			isvc: &v1beta2.InferenceService{
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						ComponentExtensionSpec: v1beta2.ComponentExtensionSpec{
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
 							MinReplicas: intPtr(2),
 						},
 					},
@@ -54,10 +54,10 @@ func TestIsPredictorUsed(t *testing.T) {
 		},
 		{
 			name: "Predictor with containers",
-			isvc: &v1beta2.InferenceService{
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						PodSpec: v1beta2.PodSpec{
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						PodSpec: v1beta1.PodSpec{
 							Containers: []v1.Container{
 								{
 									Name:  "test-container",
@@ -72,10 +72,10 @@ func TestIsPredictorUsed(t *testing.T) {
 		},
 		{
 			name: "Predictor with worker spec",
-			isvc: &v1beta2.InferenceService{
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Worker: &v1beta2.WorkerSpec{
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Worker: &v1beta1.WorkerSpec{
 							Size: intPtr(3),
 						},
 					},
@@ -85,19 +85,19 @@ func TestIsPredictorUsed(t *testing.T) {
 		},
 		{
 			name: "Empty predictor",
-			isvc: &v1beta2.InferenceService{
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{},
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{},
 				},
 			},
 			expected: false,
 		},
 		{
 			name: "Predictor with empty model",
-			isvc: &v1beta2.InferenceService{
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{},
+			isvc: &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{},
 					},
 				},
 			},
@@ -116,28 +116,28 @@ func TestIsPredictorUsed(t *testing.T) {
 func TestMigratePredictor(t *testing.T) {
 	tests := []struct {
 		name        string
-		isvc        *v1beta2.InferenceService
-		validate    func(*testing.T, *v1beta2.InferenceService)
+		isvc        *v1beta1.InferenceService
+		validate    func(*testing.T, *v1beta1.InferenceService)
 		expectError bool
 		errorMsg    string
 	}{
 		{
 			name: "Basic predictor with model",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel:        stringPtr("test-model"),
 							FineTunedWeights: []string{"weight1", "weight2"},
 						},
 					},
 				},
 			},
-			validate: func(t *testing.T, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				g.Expect(isvc.Spec.Model).NotTo(gomega.BeNil())
 				g.Expect(isvc.Spec.Model.Name).To(gomega.Equal("test-model"))
@@ -149,21 +149,21 @@ func TestMigratePredictor(t *testing.T) {
 		},
 		{
 			name: "Predictor with runtime",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
 							Runtime:   stringPtr("test-runtime"),
 						},
 					},
 				},
 			},
-			validate: func(t *testing.T, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				g.Expect(isvc.Spec.Runtime).NotTo(gomega.BeNil())
 				g.Expect(isvc.Spec.Runtime.Name).To(gomega.Equal("test-runtime"))
@@ -173,14 +173,14 @@ func TestMigratePredictor(t *testing.T) {
 		},
 		{
 			name: "Predictor with containers - ome-container",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						PodSpec: v1beta2.PodSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						PodSpec: v1beta1.PodSpec{
 							Containers: []v1.Container{
 								{
 									Name:  "ome-container",
@@ -192,9 +192,9 @@ func TestMigratePredictor(t *testing.T) {
 								},
 							},
 						},
-						Model: &v1beta2.ModelSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
-							PredictorExtensionSpec: v1beta2.PredictorExtensionSpec{
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
 								StorageUri:      stringPtr("gs://bucket/model"),
 								ProtocolVersion: protocolVersionPtr(constants.OpenInferenceProtocolV2),
 							},
@@ -202,7 +202,7 @@ func TestMigratePredictor(t *testing.T) {
 					},
 				},
 			},
-			validate: func(t *testing.T, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				g.Expect(isvc.Spec.Engine).NotTo(gomega.BeNil())
 				g.Expect(isvc.Spec.Engine.Runner).NotTo(gomega.BeNil())
@@ -226,14 +226,14 @@ func TestMigratePredictor(t *testing.T) {
 		},
 		{
 			name: "Predictor with containers - first container as runner",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						PodSpec: v1beta2.PodSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						PodSpec: v1beta1.PodSpec{
 							Containers: []v1.Container{
 								{
 									Name:  "predictor",
@@ -248,7 +248,7 @@ func TestMigratePredictor(t *testing.T) {
 					},
 				},
 			},
-			validate: func(t *testing.T, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				g.Expect(isvc.Spec.Engine.Runner).NotTo(gomega.BeNil())
 				g.Expect(isvc.Spec.Engine.Runner.Name).To(gomega.Equal("predictor"))
@@ -258,16 +258,16 @@ func TestMigratePredictor(t *testing.T) {
 		},
 		{
 			name: "Predictor with model container spec",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
-							PredictorExtensionSpec: v1beta2.PredictorExtensionSpec{
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
 								Container: v1.Container{
 									Name:  "model-container",
 									Image: "model:latest",
@@ -279,7 +279,7 @@ func TestMigratePredictor(t *testing.T) {
 					},
 				},
 			},
-			validate: func(t *testing.T, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				g.Expect(isvc.Spec.Engine.Runner).NotTo(gomega.BeNil())
 				g.Expect(isvc.Spec.Engine.Runner.Name).To(gomega.Equal("model-container"))
@@ -296,19 +296,19 @@ func TestMigratePredictor(t *testing.T) {
 		},
 		{
 			name: "Predictor with worker spec",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
 						},
-						Worker: &v1beta2.WorkerSpec{
+						Worker: &v1beta1.WorkerSpec{
 							Size: intPtr(3),
-							PodSpec: v1beta2.PodSpec{
+							PodSpec: v1beta1.PodSpec{
 								Containers: []v1.Container{
 									{
 										Name:  "worker",
@@ -320,7 +320,7 @@ func TestMigratePredictor(t *testing.T) {
 					},
 				},
 			},
-			validate: func(t *testing.T, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				g.Expect(isvc.Spec.Engine.Worker).NotTo(gomega.BeNil())
 				g.Expect(*isvc.Spec.Engine.Worker.Size).To(gomega.Equal(3))
@@ -330,24 +330,24 @@ func TestMigratePredictor(t *testing.T) {
 		},
 		{
 			name: "Predictor with component extension spec",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
 						},
-						ComponentExtensionSpec: v1beta2.ComponentExtensionSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
 							MinReplicas: intPtr(2),
 							MaxReplicas: 5,
 						},
 					},
 				},
 			},
-			validate: func(t *testing.T, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				g.Expect(isvc.Spec.Engine.MinReplicas).NotTo(gomega.BeNil())
 				g.Expect(*isvc.Spec.Engine.MinReplicas).To(gomega.Equal(2))
@@ -356,14 +356,14 @@ func TestMigratePredictor(t *testing.T) {
 		},
 		{
 			name: "Predictor with model but model not found",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("non-existent-model"),
 						},
 					},
@@ -379,13 +379,13 @@ func TestMigratePredictor(t *testing.T) {
 			g := gomega.NewGomegaWithT(t)
 			// Create a fake client with a ClusterBaseModel for testing
 			scheme := runtime.NewScheme()
-			_ = v1beta2.AddToScheme(scheme)
+			_ = v1beta1.AddToScheme(scheme)
 
 			// Create a ClusterBaseModel that will be found by DetermineModelKind
 			// Only create it if the test ISVC has a model reference AND we don't expect an error
 			var fakeClient client.Client
 			if !test.expectError && test.isvc.Spec.Predictor.Model != nil && test.isvc.Spec.Predictor.Model.BaseModel != nil {
-				clusterBaseModel := &v1beta2.ClusterBaseModel{
+				clusterBaseModel := &v1beta1.ClusterBaseModel{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: *test.isvc.Spec.Predictor.Model.BaseModel,
 					},
@@ -413,29 +413,29 @@ func TestMigratePredictor(t *testing.T) {
 
 func TestMigratePredictorToNewArchitecture(t *testing.T) {
 	scheme := runtime.NewScheme()
-	_ = v1beta2.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
 
 	tests := []struct {
 		name         string
-		isvc         *v1beta2.InferenceService
+		isvc         *v1beta1.InferenceService
 		existingObjs []client.Object
-		validate     func(*testing.T, client.Client, *v1beta2.InferenceService)
+		validate     func(*testing.T, client.Client, *v1beta1.InferenceService)
 		expectError  bool
 	}{
 		{
 			name: "Full migration with spec transformation",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
 						},
-						PodSpec: v1beta2.PodSpec{
+						PodSpec: v1beta1.PodSpec{
 							Containers: []v1.Container{
 								{
 									Name:  "predictor",
@@ -453,13 +453,13 @@ func TestMigratePredictorToNewArchitecture(t *testing.T) {
 						Namespace: "default",
 					},
 				},
-				&v1beta2.ClusterBaseModel{
+				&v1beta1.ClusterBaseModel{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-model",
 					},
 				},
 			},
-			validate: func(t *testing.T, c client.Client, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, c client.Client, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				// Check that migration happened
 				g.Expect(isvc.Spec.Model).NotTo(gomega.BeNil())
@@ -483,28 +483,28 @@ func TestMigratePredictorToNewArchitecture(t *testing.T) {
 		},
 		{
 			name: "No migration when engine already exists",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{
-						Model: &v1beta2.ModelSpec{
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						Model: &v1beta1.ModelSpec{
 							BaseModel: stringPtr("test-model"),
 						},
 					},
-					Engine: &v1beta2.EngineSpec{},
+					Engine: &v1beta1.EngineSpec{},
 				},
 			},
 			existingObjs: []client.Object{
-				&v1beta2.ClusterBaseModel{
+				&v1beta1.ClusterBaseModel{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-model",
 					},
 				},
 			},
-			validate: func(t *testing.T, c client.Client, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, c client.Client, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				// Should not have added deprecation warning
 				g.Expect(isvc.Annotations).To(gomega.BeNil())
@@ -512,16 +512,16 @@ func TestMigratePredictorToNewArchitecture(t *testing.T) {
 		},
 		{
 			name: "No migration when predictor not used",
-			isvc: &v1beta2.InferenceService{
+			isvc: &v1beta1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-isvc",
 					Namespace: "default",
 				},
-				Spec: v1beta2.InferenceServiceSpec{
-					Predictor: v1beta2.PredictorSpec{},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{},
 				},
 			},
-			validate: func(t *testing.T, c client.Client, isvc *v1beta2.InferenceService) {
+			validate: func(t *testing.T, c client.Client, isvc *v1beta1.InferenceService) {
 				g := gomega.NewGomegaWithT(t)
 				// Should not have any migration
 				g.Expect(isvc.Spec.Model).To(gomega.BeNil())
@@ -557,7 +557,7 @@ func TestMigratePredictorToNewArchitecture(t *testing.T) {
 
 func TestDetermineModelKind(t *testing.T) {
 	scheme := runtime.NewScheme()
-	_ = v1beta2.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
 
 	tests := []struct {
 		name         string
@@ -573,7 +573,7 @@ func TestDetermineModelKind(t *testing.T) {
 			modelName: "test-model",
 			namespace: "default",
 			existingObjs: []client.Object{
-				&v1beta2.ClusterBaseModel{
+				&v1beta1.ClusterBaseModel{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-model",
 					},
@@ -587,7 +587,7 @@ func TestDetermineModelKind(t *testing.T) {
 			modelName: "test-model",
 			namespace: "test-ns",
 			existingObjs: []client.Object{
-				&v1beta2.BaseModel{
+				&v1beta1.BaseModel{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "test-model",
 						Namespace: "test-ns",
@@ -602,7 +602,7 @@ func TestDetermineModelKind(t *testing.T) {
 			modelName: "test-model",
 			namespace: "default",
 			existingObjs: []client.Object{
-				&v1beta2.ClusterBaseModel{
+				&v1beta1.ClusterBaseModel{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-embed-model",
 					},
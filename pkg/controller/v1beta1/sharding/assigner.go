@@ -0,0 +1,79 @@
+package sharding
+
+import (
+	"context"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// Assigner labels the InferenceServices with no shard, or with the shard of a count that was reduced since, with the
+// shard of their key. It runs in the first shard and reads the metadata of the InferenceServices from a cache of its
+// own, since the cache of the manager only holds those of its shard.
+//
+// An assigned InferenceService keeps its shard when shards are added, and a label set by the user pins it to a shard.
+type Assigner struct {
+	Client client.Client
+	Shard  Shard
+	// reader reads the metadata of every InferenceService
+	reader client.Reader
+}
+
+// Reconcile labels an InferenceService with its shard unless it has a valid one
+func (a *Assigner) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	isvc := inferenceServiceMetadata()
+	if err := a.reader.Get(ctx, req.NamespacedName, isvc); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if _, ok := a.Shard.Assigned(isvc.GetLabels()); ok {
+		return reconcile.Result{}, nil
+	}
+
+	patch := client.MergeFrom(isvc.DeepCopy())
+	objectLabels := isvc.GetLabels()
+	if objectLabels == nil {
+		objectLabels = map[string]string{}
+	}
+	objectLabels[constants.InferenceServiceShardLabelKey] = strconv.Itoa(Of(req.NamespacedName, a.Shard.Count, a.Shard.Key))
+	isvc.SetLabels(objectLabels)
+	return reconcile.Result{}, client.IgnoreNotFound(a.Client.Patch(ctx, isvc, patch))
+}
+
+// SetupWithManager sets up the Assigner with the Manager
+func (a *Assigner) SetupWithManager(mgr ctrl.Manager) error {
+	metadataCache, err := cache.New(mgr.GetConfig(), cache.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return err
+	}
+	if err := mgr.Add(metadataCache); err != nil {
+		return err
+	}
+	a.reader = metadataCache
+
+	unassigned := predicate.NewTypedPredicateFuncs(func(isvc *metav1.PartialObjectMetadata) bool {
+		_, ok := a.Shard.Assigned(isvc.GetLabels())
+		return !ok
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("shardassigner").
+		WatchesRawSource(source.Kind(metadataCache, inferenceServiceMetadata(),
+			&handler.TypedEnqueueRequestForObject[*metav1.PartialObjectMetadata]{}, unassigned)).
+		Complete(a)
+}
+
+// inferenceServiceMetadata returns an empty metadata of an InferenceService
+func inferenceServiceMetadata() *metav1.PartialObjectMetadata {
+	isvc := &metav1.PartialObjectMetadata{}
+	isvc.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("InferenceService"))
+	return isvc
+}
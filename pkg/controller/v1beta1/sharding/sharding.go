@@ -0,0 +1,151 @@
+// Package sharding partitions the reconciles of the InferenceServices and models across manager replicas, so that
+// fleets too large for a single leader are reconciled by several, each electing its own leader.
+//
+// The InferenceServices are assigned to a shard by a label, set by the first shard, and every manager caches only
+// those of its own shard. The models are assigned by a hash of their key and filtered at reconcile time, since the
+// managers of every shard read the models of their InferenceServices.
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// Key is what the objects are assigned to a shard by
+type Key string
+
+const (
+	// KeyName assigns the objects by namespace and name, spreading the objects of a namespace across the shards
+	KeyName Key = "name"
+	// KeyNamespace assigns the objects by namespace, keeping the objects of a namespace on one shard. The
+	// cluster-scoped objects are assigned by name.
+	KeyNamespace Key = "namespace"
+)
+
+// Shard is the partition of the objects a manager replica reconciles. The zero value reconciles every object.
+type Shard struct {
+	// Count is the number of shards, sharding is disabled if not greater than 1
+	Count int
+	// Index is the shard of the replica, from 0 to Count-1
+	Index int
+	// Key is what the objects are assigned by, KeyName if empty
+	Key Key
+}
+
+// Enabled reports whether the objects are sharded
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Validate ensures the shard is valid
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("shard count must be >= 0, not %d", s.Count)
+	}
+	if s.Enabled() && (s.Index < 0 || s.Index >= s.Count) {
+		return fmt.Errorf("shard index must be between 0 and %d, not %d", s.Count-1, s.Index)
+	}
+	switch s.Key {
+	case "", KeyName, KeyNamespace:
+		return nil
+	default:
+		return fmt.Errorf("invalid shard key %q: expected %s or %s", s.Key, KeyName, KeyNamespace)
+	}
+}
+
+// Primary reports whether the replica runs the controllers that are not sharded, i.e. whether it is the first shard
+func (s Shard) Primary() bool {
+	return !s.Enabled() || s.Index == 0
+}
+
+// Owns reports whether the object is reconciled by the shard
+func (s Shard) Owns(key types.NamespacedName) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return Of(key, s.Count, s.Key) == s.Index
+}
+
+// Assigned returns the shard of the labels of an InferenceService, false if they hold none or one out of range
+func (s Shard) Assigned(objectLabels map[string]string) (int, bool) {
+	index, err := strconv.Atoi(objectLabels[constants.InferenceServiceShardLabelKey])
+	if err != nil || index < 0 || index >= s.Count {
+		return 0, false
+	}
+	return index, true
+}
+
+// CacheByObject returns the cache options of the manager restricting the InferenceServices to those labeled with the
+// shard, nil if sharding is disabled. The pods are restricted to those of the InferenceServices but for the first
+// shard, whose AcceleratorClass controller counts the accelerators requested by every pod.
+func (s Shard) CacheByObject() map[client.Object]cache.ByObject {
+	if !s.Enabled() {
+		return nil
+	}
+	byObject := map[client.Object]cache.ByObject{
+		&v1beta1.InferenceService{}: {
+			Label: labels.SelectorFromSet(labels.Set{constants.InferenceServiceShardLabelKey: strconv.Itoa(s.Index)}),
+		},
+	}
+	if !s.Primary() {
+		requirement, err := labels.NewRequirement(constants.InferenceServicePodLabelKey, selection.Exists, nil)
+		if err != nil {
+			panic(err)
+		}
+		byObject[&corev1.Pod{}] = cache.ByObject{Label: labels.NewSelector().Add(*requirement)}
+	}
+	return byObject
+}
+
+// LeaderElectionID returns the leader election ID of the shard, so that every shard elects its own leader
+func (s Shard) LeaderElectionID(id string) string {
+	if !s.Enabled() {
+		return id
+	}
+	return fmt.Sprintf("%s-shard-%d", id, s.Index)
+}
+
+func (s Shard) String() string {
+	if !s.Enabled() {
+		return "disabled"
+	}
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// Of returns the shard of the object among count shards
+func Of(key types.NamespacedName, count int, by Key) int {
+	h := fnv.New32a()
+	if by == KeyNamespace && key.Namespace != "" {
+		_, _ = h.Write([]byte(key.Namespace))
+	} else {
+		_, _ = h.Write([]byte(key.String()))
+	}
+	return int(h.Sum32() % uint32(count))
+}
+
+// Reconciler returns a reconciler skipping the requests for the models of the other shards. The requests of the
+// watches of the related objects are skipped too, since they are requests for the models.
+func Reconciler(shard Shard, r reconcile.Reconciler) reconcile.Reconciler {
+	if !shard.Enabled() {
+		return r
+	}
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if !shard.Owns(req.NamespacedName) {
+			return reconcile.Result{}, nil
+		}
+		return r.Reconcile(ctx, req)
+	})
+}
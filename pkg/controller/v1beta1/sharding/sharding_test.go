@@ -0,0 +1,148 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestShard(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(Shard{}.Validate()).To(gomega.Succeed())
+	g.Expect(Shard{Count: 4, Index: 3, Key: KeyNamespace}.Validate()).To(gomega.Succeed())
+	g.Expect(Shard{Count: 4, Index: 4}.Validate()).NotTo(gomega.Succeed())
+	g.Expect(Shard{Count: 4, Key: "label"}.Validate()).NotTo(gomega.Succeed())
+
+	g.Expect(Shard{}.LeaderElectionID("ome-lock")).To(gomega.Equal("ome-lock"))
+	g.Expect(Shard{Count: 4, Index: 2}.LeaderElectionID("ome-lock")).To(gomega.Equal("ome-lock-shard-2"))
+	g.Expect(Shard{}.Primary()).To(gomega.BeTrue())
+	g.Expect(Shard{Count: 4, Index: 1}.Primary()).To(gomega.BeFalse())
+
+	// Every object is owned by exactly one shard
+	shards := make([]Shard, 4)
+	for i := range shards {
+		shards[i] = Shard{Count: 4, Index: i}
+	}
+	owned := make([]int, 4)
+	for i := 0; i < 1000; i++ {
+		key := types.NamespacedName{Namespace: fmt.Sprintf("team-%d", i%10), Name: fmt.Sprintf("llama-%d", i)}
+		owners := 0
+		for _, shard := range shards {
+			if shard.Owns(key) {
+				owners++
+				owned[shard.Index]++
+			}
+		}
+		g.Expect(owners).To(gomega.Equal(1))
+	}
+	for _, count := range owned {
+		g.Expect(count).To(gomega.BeNumerically(">", 150))
+	}
+
+	// The objects of a namespace stay together when sharded by namespace
+	first := Of(types.NamespacedName{Namespace: "team-a", Name: "llama"}, 8, KeyNamespace)
+	for i := 0; i < 20; i++ {
+		g.Expect(Of(types.NamespacedName{Namespace: "team-a", Name: fmt.Sprintf("model-%d", i)}, 8, KeyNamespace)).To(gomega.Equal(first))
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var reconciled []types.NamespacedName
+	inner := reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+		reconciled = append(reconciled, req.NamespacedName)
+		return reconcile.Result{}, nil
+	})
+	shard := Shard{Count: 3, Index: 1}
+	r := Reconciler(shard, inner)
+	expected := 0
+	for i := 0; i < 30; i++ {
+		key := types.NamespacedName{Namespace: "serving", Name: fmt.Sprintf("isvc-%d", i)}
+		_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		if shard.Owns(key) {
+			expected++
+		}
+	}
+	g.Expect(reconciled).To(gomega.HaveLen(expected))
+	for _, key := range reconciled {
+		g.Expect(shard.Owns(key)).To(gomega.BeTrue())
+	}
+}
+
+func TestAssignedAndCacheByObject(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	shard := Shard{Count: 4, Index: 2}
+	index, ok := shard.Assigned(map[string]string{constants.InferenceServiceShardLabelKey: "3"})
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(index).To(gomega.Equal(3))
+	for _, value := range []string{"", "4", "-1", "two"} {
+		_, ok = shard.Assigned(map[string]string{constants.InferenceServiceShardLabelKey: value})
+		g.Expect(ok).To(gomega.BeFalse(), value)
+	}
+
+	g.Expect(Shard{}.CacheByObject()).To(gomega.BeNil())
+	byObject := shard.CacheByObject()
+	g.Expect(byObject).To(gomega.HaveLen(2))
+	for obj, options := range byObject {
+		switch obj.(type) {
+		case *v1beta1.InferenceService:
+			g.Expect(options.Label.String()).To(gomega.Equal(constants.InferenceServiceShardLabelKey + "=2"))
+		case *corev1.Pod:
+			g.Expect(options.Label.String()).To(gomega.Equal(constants.InferenceServicePodLabelKey))
+		default:
+			t.Errorf("unexpected cached object %T", obj)
+		}
+	}
+	// The first shard counts the accelerators of every pod
+	g.Expect(Shard{Count: 4}.CacheByObject()).To(gomega.HaveLen(1))
+}
+
+func TestAssigner(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	isvc := func(name string, objectLabels map[string]string) *v1beta1.InferenceService {
+		return &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "serving", Name: name, Labels: objectLabels}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		isvc("new", nil),
+		isvc("pinned", map[string]string{constants.InferenceServiceShardLabelKey: "1", "team": "a"}),
+		isvc("removed", map[string]string{constants.InferenceServiceShardLabelKey: "7", "team": "b"}),
+	).Build()
+	shard := Shard{Count: 4}
+	a := &Assigner{Client: c, Shard: shard, reader: c}
+
+	for _, name := range []string{"new", "pinned", "removed", "deleted"} {
+		_, err := a.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "serving", Name: name}})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+
+	assigned := func(name string) map[string]string {
+		got := &v1beta1.InferenceService{}
+		g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "serving", Name: name}, got)).To(gomega.Succeed())
+		return got.Labels
+	}
+	expected := func(name string) string {
+		return strconv.Itoa(Of(types.NamespacedName{Namespace: "serving", Name: name}, 4, KeyName))
+	}
+	g.Expect(assigned("new")).To(gomega.Equal(map[string]string{constants.InferenceServiceShardLabelKey: expected("new")}))
+	g.Expect(assigned("pinned")).To(gomega.Equal(map[string]string{constants.InferenceServiceShardLabelKey: "1", "team": "a"}))
+	g.Expect(assigned("removed")).To(gomega.Equal(map[string]string{constants.InferenceServiceShardLabelKey: expected("removed"), "team": "b"}))
+}
@@ -0,0 +1,329 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+// A batch download fetches several repositories concurrently, e.g. the models a bootstrap job seeds, and reports the
+// outcome of every repository instead of stopping at the first failure. The repositories are listed in a YAML or
+// JSON manifest:
+//
+//	repos:
+//	  - model_name: meta-llama/Llama-3.1-8B-Instruct
+//	    local_path: /models/llama-3.1-8b-instruct
+//	    ignore_patterns: ["original/*"]
+//	  - model_name: Qwen/Qwen2.5-7B-Instruct
+//	    revision: a09a35458c702b33eeacc393d103063234e8bc28
+//	    local_path: /models/qwen2.5-7b-instruct
+
+// DefaultMaxConcurrentRepos is the number of repositories a batch downloads at a time by default. Each of them
+// downloads its files with the MaxWorkers of the hub configuration.
+const DefaultMaxConcurrentRepos = 2
+
+// BatchRepo is a repository of a batch download
+type BatchRepo struct {
+	ModelName      string   `json:"model_name"`
+	Revision       string   `json:"revision,omitempty"`
+	RepoType       string   `json:"repo_type,omitempty"`
+	LocalPath      string   `json:"local_path"`
+	AllowPatterns  []string `json:"allow_patterns,omitempty"`
+	IgnorePatterns []string `json:"ignore_patterns,omitempty"`
+}
+
+// BatchManifest lists the repositories of a batch download
+type BatchManifest struct {
+	Repos []BatchRepo `json:"repos"`
+}
+
+// BatchStatus is the outcome of the download of a repository of a batch
+type BatchStatus string
+
+const (
+	// BatchStatusSucceeded means the repository was downloaded
+	BatchStatusSucceeded BatchStatus = "succeeded"
+	// BatchStatusFailed means the download of the repository failed or was never started
+	BatchStatusFailed BatchStatus = "failed"
+)
+
+// BatchRepoResult is the outcome of the download of a repository of a batch
+type BatchRepoResult struct {
+	ModelName string      `json:"modelName"`
+	Revision  string      `json:"revision,omitempty"`
+	LocalPath string      `json:"localPath"`
+	Status    BatchStatus `json:"status"`
+	// Files and Bytes count the files of the local path once downloaded, including the ones already present
+	Files int   `json:"files,omitempty"`
+	Bytes int64 `json:"bytes,omitempty"`
+	// DownloadedFiles and DownloadedBytes count what the download transferred, the bytes of resumed files included
+	DownloadedFiles int     `json:"downloadedFiles,omitempty"`
+	DownloadedBytes int64   `json:"downloadedBytes,omitempty"`
+	Duration        float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// BatchReport is the result of a batch download, its repositories are in the order of the manifest
+type BatchReport struct {
+	Repos    []BatchRepoResult `json:"repos"`
+	Duration float64           `json:"durationSeconds"`
+}
+
+// OK returns whether every repository of the batch was downloaded
+func (r *BatchReport) OK() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the repositories that failed to download
+func (r *BatchReport) Failures() []BatchRepoResult {
+	var failures []BatchRepoResult
+	for _, repo := range r.Repos {
+		if repo.Status != BatchStatusSucceeded {
+			failures = append(failures, repo)
+		}
+	}
+	return failures
+}
+
+// Totals returns the number of files and bytes of the repositories that were downloaded, including the ones that
+// were already present
+func (r *BatchReport) Totals() (int, int64) {
+	files, bytes := 0, int64(0)
+	for _, repo := range r.Repos {
+		files += repo.Files
+		bytes += repo.Bytes
+	}
+	return files, bytes
+}
+
+// DownloadedTotals returns the number of files and bytes the batch transferred
+func (r *BatchReport) DownloadedTotals() (int, int64) {
+	files, bytes := 0, int64(0)
+	for _, repo := range r.Repos {
+		files += repo.DownloadedFiles
+		bytes += repo.DownloadedBytes
+	}
+	return files, bytes
+}
+
+// transferStats counts the files and the bytes a download transferred, as opposed to the files it found present. It
+// is carried by the context of the download, and its methods are no-ops on a nil transferStats.
+type transferStats struct {
+	files atomic.Int64
+	bytes atomic.Int64
+}
+
+// transferStatsKey is the context key of the transferStats of a download
+type transferStatsKey struct{}
+
+// transferStatsFromContext returns the transferStats of the context, nil when there is none
+func transferStatsFromContext(ctx context.Context) *transferStats {
+	stats, _ := ctx.Value(transferStatsKey{}).(*transferStats)
+	return stats
+}
+
+// addFile counts a transferred file
+func (s *transferStats) addFile() {
+	if s != nil {
+		s.files.Add(1)
+	}
+}
+
+// addBytes counts transferred bytes
+func (s *transferStats) addBytes(n int64) {
+	if s != nil {
+		s.bytes.Add(n)
+	}
+}
+
+// LoadBatchManifest reads and validates a batch manifest
+func LoadBatchManifest(path string) (*BatchManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch manifest: %w", err)
+	}
+	manifest := &BatchManifest{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse batch manifest %s: %w", path, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid batch manifest %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// Validate checks that every repository has a name and its own local path
+func (m *BatchManifest) Validate() error {
+	if len(m.Repos) == 0 {
+		return fmt.Errorf("no repos")
+	}
+	localPaths := make(map[string]string, len(m.Repos))
+	for i, repo := range m.Repos {
+		if repo.ModelName == "" {
+			return fmt.Errorf("repos[%d]: model_name cannot be empty", i)
+		}
+		if repo.LocalPath == "" {
+			return fmt.Errorf("repos[%d]: local_path must be specified for %s", i, repo.ModelName)
+		}
+		localPath := filepath.Clean(repo.LocalPath)
+		if other, ok := localPaths[localPath]; ok {
+			return fmt.Errorf("repos[%d]: %s and %s share the local_path %s", i, other, repo.ModelName, repo.LocalPath)
+		}
+		localPaths[localPath] = repo.ModelName
+	}
+	return nil
+}
+
+// BatchSnapshotDownload downloads the snapshots of the repositories, maxConcurrentRepos at a time, with the settings
+// of the config. A repository that fails doesn't stop the others.
+func BatchSnapshotDownload(ctx context.Context, config *DownloadConfig, repos []BatchRepo, maxConcurrentRepos int) *BatchReport {
+	if maxConcurrentRepos <= 0 {
+		maxConcurrentRepos = DefaultMaxConcurrentRepos
+	}
+	start := time.Now()
+	report := &BatchReport{Repos: make([]BatchRepoResult, len(repos))}
+
+	var logger logging.Interface
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil {
+		logger = hubConfig.Logger
+		logger.Infof("Downloading %d repositories, %d at a time", len(repos), maxConcurrentRepos)
+	}
+
+	// Progress is reported across the batch as every repository completes
+	var mu sync.Mutex
+	done, failed := 0, 0
+	var files int
+	var bytes int64
+	completed := func(result BatchRepoResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if result.Status != BatchStatusSucceeded {
+			failed++
+		} else {
+			files += result.DownloadedFiles
+			bytes += result.DownloadedBytes
+		}
+		if logger == nil {
+			return
+		}
+		if result.Status != BatchStatusSucceeded {
+			logger.Warnf("[%d/%d] Failed to download %s: %s", done, len(repos), result.ModelName, result.Error)
+			return
+		}
+		logger.Infof("[%d/%d] Downloaded %s to %s (%d files, %s, of which %d files, %s transferred) in %s, "+
+			"%d files, %s transferred and %d failures so far",
+			done, len(repos), result.ModelName, result.LocalPath, result.Files, formatBytes(result.Bytes),
+			result.DownloadedFiles, formatBytes(result.DownloadedBytes),
+			time.Duration(result.Duration*float64(time.Second)).Round(time.Second), files, formatBytes(bytes), failed)
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, maxConcurrentRepos)
+	for i, repo := range repos {
+		wg.Add(1)
+		go func(i int, repo BatchRepo) {
+			defer wg.Done()
+			select {
+			case workers <- struct{}{}:
+				defer func() { <-workers }()
+				report.Repos[i] = downloadBatchRepo(ctx, config, repo)
+			case <-ctx.Done():
+				report.Repos[i] = BatchRepoResult{
+					ModelName: repo.ModelName,
+					Revision:  repo.Revision,
+					LocalPath: repo.LocalPath,
+					Status:    BatchStatusFailed,
+					Error:     ctx.Err().Error(),
+				}
+			}
+			completed(report.Repos[i])
+		}(i, repo)
+	}
+	wg.Wait()
+
+	report.Duration = time.Since(start).Seconds()
+	return report
+}
+
+// downloadBatchRepo downloads the snapshot of a repository of a batch
+func downloadBatchRepo(ctx context.Context, config *DownloadConfig, repo BatchRepo) (result BatchRepoResult) {
+	result = BatchRepoResult{
+		ModelName: repo.ModelName,
+		Revision:  repo.Revision,
+		LocalPath: repo.LocalPath,
+		Status:    BatchStatusFailed,
+	}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start).Seconds() }()
+
+	repoConfig := *config
+	repoConfig.RepoID = repo.ModelName
+	repoConfig.LocalDir = repo.LocalPath
+	repoConfig.AllowPatterns = repo.AllowPatterns
+	repoConfig.IgnorePatterns = repo.IgnorePatterns
+	if repo.Revision != "" {
+		repoConfig.Revision = repo.Revision
+	}
+	if repo.RepoType != "" {
+		repoConfig.RepoType = repo.RepoType
+	}
+
+	stats := &transferStats{}
+	path, err := SnapshotDownload(context.WithValue(ctx, transferStatsKey{}, stats), &repoConfig)
+	result.DownloadedFiles, result.DownloadedBytes = int(stats.files.Load()), stats.bytes.Load()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.LocalPath = path
+	result.Files, result.Bytes, err = countFiles(path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = BatchStatusSucceeded
+	return result
+}
+
+// countFiles returns the number and the size of the files of a downloaded snapshot, following the symlinks to the
+// blobs of the cache and skipping the metadata the hub client keeps under .cache
+func countFiles(localDir string) (int, int64, error) {
+	files, bytes := 0, int64(0)
+	err := filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == localDir && errors.Is(err, fs.ErrNotExist) {
+				// Nothing matched the patterns
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".cache" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		files++
+		bytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read downloaded snapshot: %w", err)
+	}
+	return files, bytes, nil
+}
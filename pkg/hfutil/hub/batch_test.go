@@ -0,0 +1,124 @@
+package hub
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSnapshotDownload(t *testing.T) {
+	history := &fakeRepoHistory{
+		head: lockedCommit,
+		contents: map[string]map[string]string{
+			lockedCommit: {
+				"config.json":       `{"model_type": "llama"}`,
+				"model.safetensors": "weights v1",
+				"README.md":         "# Model",
+			},
+		},
+	}
+	server := newFakeRepoHistory(t, history)
+	root := t.TempDir()
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{MaxWorkers: 2, MaxRetries: 0})
+	repos := []BatchRepo{
+		{ModelName: "org/model", LocalPath: filepath.Join(root, "full")},
+		{ModelName: "org/missing", LocalPath: filepath.Join(root, "missing")},
+		{ModelName: "org/model", LocalPath: filepath.Join(root, "weights"), AllowPatterns: []string{"*.safetensors"}},
+	}
+
+	report := BatchSnapshotDownload(ctx, &DownloadConfig{Revision: lockedCommit, Endpoint: server.URL}, repos, 2)
+
+	// A failed repository doesn't stop the others, the results are in the order of the manifest
+	require.Len(t, report.Repos, 3)
+	assert.False(t, report.OK())
+
+	assert.Equal(t, BatchStatusSucceeded, report.Repos[0].Status)
+	assert.Equal(t, 3, report.Repos[0].Files)
+	assert.EqualValues(t, 40, report.Repos[0].Bytes)
+	assert.FileExists(t, filepath.Join(root, "full", "README.md"))
+
+	assert.Equal(t, BatchStatusFailed, report.Repos[1].Status)
+	assert.NotEmpty(t, report.Repos[1].Error)
+	assert.Equal(t, []BatchRepoResult{report.Repos[1]}, report.Failures())
+
+	assert.Equal(t, BatchStatusSucceeded, report.Repos[2].Status)
+	assert.Equal(t, 1, report.Repos[2].Files)
+	assert.NoFileExists(t, filepath.Join(root, "weights", "config.json"))
+
+	files, bytes := report.Totals()
+	assert.Equal(t, 4, files)
+	assert.EqualValues(t, 50, bytes)
+	files, bytes = report.DownloadedTotals()
+	assert.Equal(t, 4, files)
+	assert.EqualValues(t, 50, bytes)
+
+	// The files already present are counted in the local directory, not in the transfers
+	report = BatchSnapshotDownload(ctx, &DownloadConfig{Revision: lockedCommit, Endpoint: server.URL}, repos[:1], 2)
+	require.True(t, report.OK())
+	assert.Equal(t, 3, report.Repos[0].Files)
+	assert.EqualValues(t, 40, report.Repos[0].Bytes)
+	assert.Zero(t, report.Repos[0].DownloadedFiles)
+	assert.Zero(t, report.Repos[0].DownloadedBytes)
+}
+
+func TestBatchSnapshotDownloadCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := BatchSnapshotDownload(ctx, &DownloadConfig{Endpoint: "http://127.0.0.1:1"}, []BatchRepo{
+		{ModelName: "org/a", LocalPath: t.TempDir()},
+		{ModelName: "org/b", LocalPath: t.TempDir()},
+	}, 1)
+
+	require.Len(t, report.Repos, 2)
+	for _, repo := range report.Repos {
+		assert.Equal(t, BatchStatusFailed, repo.Status)
+	}
+}
+
+func TestLoadBatchManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	manifest, err := LoadBatchManifest(write("manifest.yaml", `
+repos:
+  - model_name: org/a
+    local_path: /models/a
+    ignore_patterns: ["original/*"]
+  - model_name: org/b
+    revision: v1.0
+    repo_type: dataset
+    local_path: /models/b
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []BatchRepo{
+		{ModelName: "org/a", LocalPath: "/models/a", IgnorePatterns: []string{"original/*"}},
+		{ModelName: "org/b", Revision: "v1.0", RepoType: RepoTypeDataset, LocalPath: "/models/b"},
+	}, manifest.Repos)
+
+	// JSON is valid YAML
+	manifest, err = LoadBatchManifest(write("manifest.json", `{"repos": [{"model_name": "org/a", "local_path": "/models/a"}]}`))
+	require.NoError(t, err)
+	assert.Len(t, manifest.Repos, 1)
+
+	for name, content := range map[string]string{
+		"empty.yaml":         "repos: []",
+		"unknown.yaml":       "repos:\n  - model: org/a\n    local_path: /models/a",
+		"no-local-path.yaml": "repos:\n  - model_name: org/a",
+		"shared.yaml":        "repos:\n  - {model_name: org/a, local_path: /models/a}\n  - {model_name: org/b, local_path: /models/a/}",
+	} {
+		_, err := LoadBatchManifest(write(name, content))
+		assert.Error(t, err, name)
+	}
+
+	_, err = LoadBatchManifest(filepath.Join(dir, "absent.yaml"))
+	assert.Error(t, err)
+}
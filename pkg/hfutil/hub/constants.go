@@ -46,6 +46,7 @@ const (
 	DefaultRequestTimeout = 10 * time.Second
 	DefaultEtagTimeout    = 10 * time.Second
 	DownloadTimeout       = 2 * time.Hour // Increased from 10 minutes to 2 hours for large model files
+	UploadTimeout         = 2 * time.Hour // Uploads of large LFS files and commits of many files
 
 	// Download configuration
 	DefaultMaxWorkers    = 4                // Reduced to minimize concurrent API calls
@@ -53,6 +54,13 @@ const (
 	DefaultMaxRetries    = 10               // Increased for better 429 handling
 	DefaultRetryInterval = 15 * time.Second // Increased base interval
 
+	// Rate limiting
+	DefaultRateLimitPause = 30 * time.Second // Pause of the requests to a host rate limited without Retry-After
+
+	// Endpoint fallback
+	DefaultEndpointCooldown = 30 * time.Second // How long a failed endpoint is tried after the others
+	MaxEndpointCooldown     = 10 * time.Minute // Cooldown cap of endpoints that keep failing
+
 	// File size thresholds
 	LfsFileSizeThreshold = 10 * 1024 * 1024 // 10MB
 
@@ -77,6 +85,17 @@ const (
 	ApiDatasetsURL           = "%s/api/datasets/%s"
 	ApiSpacesURL             = "%s/api/spaces/%s"
 	ApiRepoTreeURL           = "%s/api/models/%s/tree/%s"
+	ApiPreuploadURL          = "%s/api/%ss/%s/preupload/%s"
+	ApiCommitURL             = "%s/api/%ss/%s/commit/%s"
+	LfsBatchURL              = "%s/%s.git/info/lfs/objects/batch"
+	ApiRepoCreateURL         = "%s/api/repos/create"
+	ApiRepoDeleteURL         = "%s/api/repos/delete"
+	ApiRepoMoveURL           = "%s/api/repos/move"
+	ApiRepoSettingsURL       = "%s/api/%ss/%s/settings"
+	ApiRepoRefsURL           = "%s/api/%ss/%s/refs"
+	ApiRepoBranchURL         = "%s/api/%ss/%s/branch/%s"
+	ApiRepoTagURL            = "%s/api/%ss/%s/tag/%s"
+	ApiRepoRevisionURL       = "%s/api/%ss/%s/revision/%s"
 
 	// File download constants
 	PytorchWeightsName    = "pytorch_model.bin"
@@ -94,6 +113,21 @@ const (
 	SafetensorsWeightsFilePattern = "model{suffix}.safetensors"
 	TF2WeightsFilePattern         = "tf_model{suffix}.h5"
 
+	// Upload constants
+	UploadModeLFS       = "lfs"
+	UploadModeRegular   = "regular"
+	PreuploadSampleSize = 512 // Bytes of each file the Hub inspects to choose its upload mode
+	PreuploadBatchSize  = 256 // Files announced per preupload request
+	LfsContentType      = "application/vnd.git-lfs+json"
+	NdjsonContentType   = "application/x-ndjson"
+
+	// Snapshot lockfile constants
+	LockModeUpdate      = "update" // Pin the snapshot to the current commit of the revision and write the lockfile
+	LockModeFrozen      = "frozen" // Download the files of the lockfile and fail if the repository drifted from it
+	SnapshotLockVersion = 1
+	SyncStateFile       = ".cache/huggingface/sync.lock.json"     // Files downloaded by snapshot syncs, in the local directory
+	SnapshotStateFile   = ".cache/huggingface/snapshot.lock.json" // Files of the last complete snapshot download, in the local directory
+
 	// Safetensors constants
 	SafetensorsSingleFile      = "model.safetensors"
 	SafetensorsIndexFile       = "model.safetensors.index.json"
@@ -106,6 +140,7 @@ var RepoTypes = []string{RepoTypeModel, RepoTypeDataset, RepoTypeSpace}
 // Environment variables
 const (
 	EnvHfToken              = "HF_TOKEN"
+	EnvHfTokenPath          = "HF_TOKEN_PATH"
 	EnvHfHome               = "HF_HOME"
 	EnvHuggingfaceHubCache  = "HUGGINGFACE_HUB_CACHE"
 	EnvHfHubCache           = "HF_HUB_CACHE"
@@ -150,7 +185,12 @@ func GetHfToken() string {
 	return os.Getenv(EnvHfToken)
 }
 
+// GetHfTokenFile returns the file of the HF token from environment
+func GetHfTokenFile() string {
+	return os.Getenv(EnvHfTokenPath)
+}
+
 // IsOfflineMode checks if offline mode is enabled
 func IsOfflineMode() bool {
-	return os.Getenv(EnvHfHubOffline) == "1" || os.Getenv(EnvHfHubOffline) == "true"
+	return isTrue(os.Getenv(EnvHfHubOffline))
 }
@@ -178,6 +178,8 @@ func TestIsOfflineMode(t *testing.T) {
 		{"set to 0", "0", false},
 		{"set to false", "false", false},
 		{"set to random", "random", false},
+		{"set to YES", "YES", true},
+		{"set to on", "on", true},
 	}
 
 	for _, tt := range tests {
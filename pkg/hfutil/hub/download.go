@@ -83,6 +83,20 @@ func DefaultDownloadOptions() *DownloadOptions {
 	}
 }
 
+// isOffline tells whether offline mode is enabled by HF_HUB_OFFLINE or the hub config of the context
+func isOffline(ctx context.Context) bool {
+	if IsOfflineMode() {
+		return true
+	}
+	hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig)
+	return ok && hubConfig.EnableOfflineMode
+}
+
+// cacheOnly tells whether a download must be resolved from the local directory or cache without network access
+func cacheOnly(ctx context.Context, config *DownloadConfig) bool {
+	return config.LocalFilesOnly || isOffline(ctx)
+}
+
 // HfHubDownload downloads a file from the Hugging Face Hub
 // This is the Go equivalent of the Python hf_hub_download function
 func HfHubDownload(ctx context.Context, config *DownloadConfig) (string, error) {
@@ -101,13 +115,24 @@ func HfHubDownload(ctx context.Context, config *DownloadConfig) (string, error)
 	if config.Revision == "" {
 		config.Revision = DefaultRevision
 	}
-	if config.Endpoint == "" {
-		config.Endpoint = DefaultEndpoint
-	}
 	if config.EtagTimeout == 0 {
 		config.EtagTimeout = DefaultEtagTimeout
 	}
 
+	// Offline downloads never reach the network
+	if cacheOnly(ctx, config) {
+		return hfHubLoadOffline(config)
+	}
+
+	return withEndpointFallback(ctx, config, hfHubDownloadFromEndpoint)
+}
+
+// hfHubDownloadFromEndpoint downloads a file from the endpoint of the download
+func hfHubDownloadFromEndpoint(ctx context.Context, config *DownloadConfig) (string, error) {
+	if config.Endpoint == "" {
+		config.Endpoint = DefaultEndpoint
+	}
+
 	// If local_dir is specified, download to local directory
 	if config.LocalDir != "" {
 		return hfHubDownloadToLocalDir(ctx, config)
@@ -121,6 +146,31 @@ func HfHubDownload(ctx context.Context, config *DownloadConfig) (string, error)
 	return hfHubDownloadToCacheDir(ctx, config)
 }
 
+// hfHubLoadOffline resolves a file from the local directory, or from the cache snapshot the revision was last
+// resolved to, without network access
+func hfHubLoadOffline(config *DownloadConfig) (string, error) {
+	if config.LocalDir != "" {
+		filePath := filepath.Join(config.LocalDir, config.Filename)
+		if FileExists(filePath) {
+			return filePath, nil
+		}
+		return "", NewOfflineEntryNotFoundError(config.RepoID, config.Revision, []string{config.Filename}, "local directory "+config.LocalDir)
+	}
+
+	if config.CacheDir == "" {
+		config.CacheDir = GetCacheDir()
+	}
+	if strings.Contains(config.Filename, "..") {
+		return "", fmt.Errorf("invalid filename: path traversal detected in %s", config.Filename)
+	}
+	storageFolder := filepath.Join(config.CacheDir, RepoFolderName(config.RepoID, config.RepoType))
+	relativeFilename := filepath.Join(strings.Split(config.Filename, "/")...)
+	if cachedPath := tryToLoadFromCache(config, storageFolder, relativeFilename); cachedPath != "" {
+		return cachedPath, nil
+	}
+	return "", NewOfflineEntryNotFoundError(config.RepoID, config.Revision, []string{config.Filename}, "cache "+storageFolder)
+}
+
 // hfHubDownloadToCacheDir downloads a file to the cache directory with symlinks
 func hfHubDownloadToCacheDir(ctx context.Context, config *DownloadConfig) (string, error) {
 	storageFolder := filepath.Join(config.CacheDir, RepoFolderName(config.RepoID, config.RepoType))
@@ -271,7 +321,7 @@ func hfHubDownloadToLocalDir(ctx context.Context, config *DownloadConfig) (strin
 }
 
 // downloadToTmpAndMove downloads a file to a temporary location and then moves it
-func downloadToTmpAndMove(ctx context.Context, config *DownloadConfig, metadata *FileMetadata, destPath string) error {
+func downloadToTmpAndMove(ctx context.Context, config *DownloadConfig, metadata *FileMetadata, destPath string) (err error) {
 	incompletePath := destPath + ".incomplete"
 
 	// Return early if file already exists and force_download is false
@@ -279,6 +329,14 @@ func downloadToTmpAndMove(ctx context.Context, config *DownloadConfig, metadata
 		return nil
 	}
 
+	start := time.Now()
+	defer func() {
+		metricsFromContext(ctx).observeFile(config.RepoID, time.Since(start), err)
+		if err == nil {
+			transferStatsFromContext(ctx).addFile()
+		}
+	}()
+
 	// Remove incomplete file if force_download is true
 	if config.ForceDownload && FileExists(incompletePath) {
 		err := os.Remove(incompletePath)
@@ -408,6 +466,8 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 
 	// Wrap the file writer with progress reporting
 	var progressWriter io.Writer = NewSimpleProgressWriter(file, progress)
+	metrics := metricsFromContext(ctx)
+	stats := transferStatsFromContext(ctx)
 
 	// Retry loop for HTTP download
 	var lastErr error
@@ -444,7 +504,10 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 		}
 
 		// Use pooled client with download timeout
-		client := NewHTTPClientWithTimeout(DownloadTimeout)
+		client, err := newHTTPClient(ctx, DownloadTimeout)
+		if err != nil {
+			return err
+		}
 
 		resp, err := client.Do(req)
 		if err != nil {
@@ -454,6 +517,7 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 			if attempt == maxRetries {
 				return fmt.Errorf("failed to perform request after %d attempts: %w", maxRetries+1, lastErr)
 			}
+			metrics.observeRetry(config.RepoID, retryReasonNetwork)
 
 			// Wait with exponential backoff before retrying
 			delay := exponentialBackoff(attempt+1, retryInterval)
@@ -489,6 +553,7 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 			if !retryableHTTPError(nil, resp.StatusCode) || attempt == maxRetries {
 				return lastErr
 			}
+			metrics.observeRetry(config.RepoID, retryReason(resp.StatusCode))
 
 			// Calculate delay based on response type
 			var delay time.Duration
@@ -515,7 +580,9 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 		}
 
 		// Download successful - copy response body to file with context awareness
-		_, err = copyWithContext(ctx, progressWriter, resp.Body)
+		written, err := copyWithContext(ctx, progressWriter, resp.Body)
+		metrics.addBytes(config.RepoID, written)
+		stats.addBytes(written)
 		if err != nil {
 			lastErr = err
 
@@ -528,6 +595,7 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 			if attempt == maxRetries {
 				return fmt.Errorf("failed to write file after %d attempts: %w", maxRetries+1, lastErr)
 			}
+			metrics.observeRetry(config.RepoID, retryReasonInterrupted)
 
 			// Reset file position for retry
 			if _, err := file.Seek(resumeSize, 0); err != nil {
@@ -588,6 +656,9 @@ func GetHfFileMetadata(ctx context.Context, repoID, filename string, opts ...Dow
 	for _, opt := range opts {
 		opt(config)
 	}
+	if cacheOnly(ctx, config) {
+		return nil, NewOfflineModeIsEnabledError("Cannot fetch file metadata since offline mode is enabled")
+	}
 
 	// Construct URL
 	url, err := HfHubURL(config.RepoID, config.Filename, config)
@@ -609,7 +680,10 @@ func GetHfFileMetadata(ctx context.Context, repoID, filename string, opts ...Dow
 	req.Header.Set("Accept-Encoding", "identity") // Prevent compression
 
 	// Use pooled client with custom timeout
-	client := NewHTTPClientWithTimeout(config.EtagTimeout)
+	client, err := newHTTPClient(ctx, config.EtagTimeout)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -729,7 +803,10 @@ func getMetadataOrCatchError(ctx context.Context, config *DownloadConfig, storag
 		}
 
 		// Use pooled client with custom timeout
-		client := NewHTTPClientWithTimeout(config.EtagTimeout)
+		client, err := newHTTPClient(ctx, config.EtagTimeout)
+		if err != nil {
+			return nil, err
+		}
 
 		resp, err := client.Do(req)
 		if err != nil {
@@ -691,6 +691,92 @@ func (r *blockingReader) Read(p []byte) (n int, err error) {
 	return 0, io.EOF
 }
 
+func TestHfHubDownloadOffline(t *testing.T) {
+	// Offline downloads must not reach the Hub
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s in offline mode", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	commit := "abc123def456789012345678901234567890abcd"
+	cacheDir := t.TempDir()
+	storageFolder := filepath.Join(cacheDir, RepoFolderName("org/model", RepoTypeModel))
+	require.NoError(t, CacheCommitHashForRevision(storageFolder, "main", commit))
+	pointerPath, err := GetPointerPath(storageFolder, commit, "config.json")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(pointerPath), 0o755))
+	require.NoError(t, os.WriteFile(pointerPath, []byte("{}"), 0o644))
+
+	localDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "config.json"), []byte("{}"), 0o644))
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		config   *DownloadConfig
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "cached revision",
+			ctx:      context.Background(),
+			config:   &DownloadConfig{RepoID: "org/model", Filename: "config.json", CacheDir: cacheDir, LocalFilesOnly: true},
+			expected: pointerPath,
+		},
+		{
+			name:     "cached commit with offline hub config",
+			ctx:      context.WithValue(context.Background(), HubConfigKey, &HubConfig{EnableOfflineMode: true}),
+			config:   &DownloadConfig{RepoID: "org/model", Filename: "config.json", Revision: commit, CacheDir: cacheDir},
+			expected: pointerPath,
+		},
+		{
+			name:     "local directory",
+			ctx:      context.Background(),
+			config:   &DownloadConfig{RepoID: "org/model", Filename: "config.json", LocalDir: localDir, LocalFilesOnly: true},
+			expected: filepath.Join(localDir, "config.json"),
+		},
+		{
+			name:   "file not cached",
+			ctx:    context.Background(),
+			config: &DownloadConfig{RepoID: "org/model", Filename: "tokenizer.json", CacheDir: cacheDir, LocalFilesOnly: true},
+			errMsg: "Cannot find file 'tokenizer.json' of repository 'org/model' at revision 'main' in cache",
+		},
+		{
+			name:   "revision not cached",
+			ctx:    context.Background(),
+			config: &DownloadConfig{RepoID: "org/model", Filename: "config.json", Revision: "v2", CacheDir: cacheDir, LocalFilesOnly: true},
+			errMsg: "at revision 'v2'",
+		},
+		{
+			name:   "file not in local directory",
+			ctx:    context.Background(),
+			config: &DownloadConfig{RepoID: "org/model", Filename: "model.safetensors", LocalDir: localDir, LocalFilesOnly: true},
+			errMsg: "in local directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Endpoint = server.URL
+			path, err := HfHubDownload(tt.ctx, tt.config)
+			if tt.errMsg != "" {
+				var notFound *LocalEntryNotFoundError
+				require.ErrorAs(t, err, &notFound)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+
+	t.Run("metadata", func(t *testing.T) {
+		_, err := GetHfFileMetadata(context.WithValue(context.Background(), HubConfigKey, &HubConfig{EnableOfflineMode: true}), "org/model", "config.json")
+		var offlineErr *OfflineModeIsEnabledError
+		assert.ErrorAs(t, err, &offlineErr)
+	})
+}
+
 // Helper functions
 
 func createMockHubServer(t *testing.T) *httptest.Server {
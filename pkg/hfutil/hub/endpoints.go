@@ -0,0 +1,156 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Downloads of a Hub configuration with several endpoints, e.g. an internal mirror followed by huggingface.co, try
+// the endpoints in order for each file and fall back to the next endpoint when one fails. Endpoints that were
+// unreachable or failed with a server error are tried last until their cooldown expires, so that an unavailable
+// mirror doesn't slow down every file.
+
+// endpointHealth tracks the endpoints that failed recently
+type endpointHealth struct {
+	mu       sync.Mutex
+	failures map[string]endpointFailure
+}
+
+// endpointFailure records the consecutive failures of an endpoint and until when it is considered unhealthy
+type endpointFailure struct {
+	count int
+	until time.Time
+}
+
+// endpointsHealth is shared by all downloads, like the HTTP transport
+var endpointsHealth = &endpointHealth{failures: map[string]endpointFailure{}}
+
+// order returns the healthy endpoints in their configured order, followed by the unhealthy ones from the one that
+// recovers first
+func (h *endpointHealth) order(endpoints []string, now time.Time) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var healthy, unhealthy []string
+	for _, endpoint := range endpoints {
+		if failure, ok := h.failures[endpoint]; ok && now.Before(failure.until) {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return h.failures[unhealthy[i]].until.Before(h.failures[unhealthy[j]].until)
+	})
+	return append(healthy, unhealthy...)
+}
+
+// markFailed makes an endpoint unhealthy for the cooldown, doubled for each consecutive failure up to
+// MaxEndpointCooldown
+func (h *endpointHealth) markFailed(endpoint string, cooldown time.Duration, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	failure := h.failures[endpoint]
+	failure.count++
+	for i := 1; i < failure.count && cooldown < MaxEndpointCooldown; i++ {
+		cooldown *= 2
+	}
+	failure.until = now.Add(min(cooldown, MaxEndpointCooldown))
+	h.failures[endpoint] = failure
+}
+
+// markHealthy forgets the failures of an endpoint
+func (h *endpointHealth) markHealthy(endpoint string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.failures, endpoint)
+}
+
+// downloadEndpoints returns the endpoints a download tries in order. The endpoints of the Hub configuration are used
+// unless the download targets another endpoint.
+func downloadEndpoints(ctx context.Context, config *DownloadConfig) []string {
+	hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig)
+	if !ok || len(hubConfig.Endpoints) == 0 {
+		return []string{config.Endpoint}
+	}
+	if config.Endpoint != "" && config.Endpoint != hubConfig.Endpoint && !slices.Contains(hubConfig.Endpoints, config.Endpoint) {
+		return []string{config.Endpoint}
+	}
+	return endpointsHealth.order(hubConfig.Endpoints, time.Now())
+}
+
+// withEndpointFallback runs a download against each endpoint of the download in order until one succeeds. The
+// endpoints before the last one are tried without retries, so that the download quickly falls back from an
+// unavailable endpoint.
+func withEndpointFallback[T any](ctx context.Context, config *DownloadConfig, download func(context.Context, *DownloadConfig) (T, error)) (T, error) {
+	endpoints := downloadEndpoints(ctx, config)
+	if len(endpoints) == 1 {
+		return download(ctx, config)
+	}
+
+	hubConfig := ctx.Value(HubConfigKey).(*HubConfig)
+	cooldown := hubConfig.EndpointCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultEndpointCooldown
+	}
+	noRetries := *hubConfig
+	noRetries.MaxRetries = 0
+	noRetriesCtx := context.WithValue(ctx, HubConfigKey, &noRetries)
+
+	var result T
+	var err error
+	for i, endpoint := range endpoints {
+		attempt := *config
+		attempt.Endpoint = endpoint
+		attemptCtx := noRetriesCtx
+		if i == len(endpoints)-1 {
+			attemptCtx = ctx
+		}
+
+		result, err = download(attemptCtx, &attempt)
+		if err == nil {
+			endpointsHealth.markHealthy(endpoint)
+			return result, nil
+		}
+		var validationErr *ValidationError
+		if ctx.Err() != nil || errors.As(err, &validationErr) {
+			return result, err
+		}
+
+		if endpointUnhealthy(err) {
+			endpointsHealth.markFailed(endpoint, cooldown, time.Now())
+		}
+		if hubConfig.Logger != nil && i < len(endpoints)-1 {
+			hubConfig.Logger.
+				WithField("endpoint", endpoint).
+				WithField("fallback", endpoints[i+1]).
+				WithField("repo_id", config.RepoID).
+				WithField("filename", config.Filename).
+				WithError(err).
+				Warn("Hub endpoint failed, falling back to the next endpoint")
+		}
+	}
+	return result, err
+}
+
+// endpointUnhealthy reports whether a failed download means that its endpoint is unhealthy: the endpoint couldn't be
+// reached or failed with a server error. An endpoint answering with a client error, e.g. a mirror without the file
+// or a 401 for a token it doesn't accept, still serves the other downloads.
+func endpointUnhealthy(err error) bool {
+	var statusErr interface{ httpStatusCode() int }
+	if errors.As(err, &statusErr) {
+		return statusErr.httpStatusCode() >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,145 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHfHubDownloadEndpointFallback(t *testing.T) {
+	hub := newFakeRepoHistory(t, &fakeRepoHistory{
+		head:     lockedCommit,
+		contents: map[string]map[string]string{lockedCommit: {"config.json": `{"model_type": "llama"}`}},
+	})
+	var mirrorRequests atomic.Int32
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorRequests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(mirror.Close)
+	t.Cleanup(func() {
+		endpointsHealth.markHealthy(mirror.URL)
+		endpointsHealth.markHealthy(hub.URL)
+	})
+
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{
+		Endpoint:         mirror.URL,
+		Endpoints:        []string{mirror.URL, hub.URL},
+		EndpointCooldown: time.Minute,
+		MaxRetries:       3,
+	})
+	download := func() (string, error) {
+		return HfHubDownload(ctx, &DownloadConfig{
+			RepoID:   "org/model",
+			Filename: "config.json",
+			Revision: lockedCommit,
+			LocalDir: t.TempDir(),
+		})
+	}
+
+	// The unavailable mirror is tried once, without retries, before falling back to the Hub
+	path, err := download()
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"model_type": "llama"}`, string(content))
+	assert.Equal(t, int32(1), mirrorRequests.Load())
+
+	// The mirror is skipped while it cools down
+	_, err = download()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), mirrorRequests.Load())
+
+	// Downloads targeting another endpoint don't fall back
+	_, err = HfHubDownload(ctx, &DownloadConfig{
+		RepoID:   "org/model",
+		Filename: "config.json",
+		Revision: lockedCommit,
+		Endpoint: "http://127.0.0.1:1",
+		LocalDir: filepath.Join(t.TempDir(), "other"),
+	})
+	assert.Error(t, err)
+}
+
+func TestListRepoFilesEndpointFallback(t *testing.T) {
+	var hosts []string
+	hub := httptest.NewServer(treeHandler(t, &hosts))
+	t.Cleanup(hub.Close)
+	mirror := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(mirror.Close)
+	t.Cleanup(func() {
+		endpointsHealth.markHealthy(mirror.URL)
+		endpointsHealth.markHealthy(hub.URL)
+	})
+
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{Endpoints: []string{mirror.URL, hub.URL}})
+	files, err := ListRepoFiles(ctx, &DownloadConfig{RepoID: "org/model"})
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Len(t, hosts, 1)
+	// A mirror answering with a client error is still healthy
+	assert.Equal(t, []string{mirror.URL, hub.URL}, endpointsHealth.order([]string{mirror.URL, hub.URL}, time.Now()))
+}
+
+func TestEndpointUnhealthy(t *testing.T) {
+	assert.True(t, endpointUnhealthy(NewHTTPError("HTTP 503", http.StatusServiceUnavailable, nil)))
+	assert.True(t, endpointUnhealthy(fmt.Errorf("failed to perform request: %w", &url.Error{Op: "Get", URL: "https://mirror", Err: errors.New("connection refused")})))
+	assert.True(t, endpointUnhealthy(io.ErrUnexpectedEOF))
+	assert.False(t, endpointUnhealthy(NewEntryNotFoundError("org/model", RepoTypeModel, "main", "config.json", nil)))
+	assert.False(t, endpointUnhealthy(NewRepositoryNotFoundError("org/model", RepoTypeModel, nil)))
+	assert.False(t, endpointUnhealthy(NewRateLimitError(&http.Response{StatusCode: http.StatusTooManyRequests}, 0)))
+	assert.False(t, endpointUnhealthy(errors.New("no space left on device")))
+}
+
+func TestEndpointHealth(t *testing.T) {
+	health := &endpointHealth{failures: map[string]endpointFailure{}}
+	endpoints := []string{"https://mirror-a", "https://mirror-b", "https://huggingface.co"}
+	now := time.Now()
+
+	assert.Equal(t, endpoints, health.order(endpoints, now))
+
+	health.markFailed("https://mirror-a", time.Minute, now)
+	health.markFailed("https://mirror-b", time.Minute, now)
+	health.markFailed("https://mirror-a", time.Minute, now)
+	assert.Equal(t, []string{"https://huggingface.co", "https://mirror-b", "https://mirror-a"}, health.order(endpoints, now))
+	assert.Equal(t, now.Add(2*time.Minute), health.failures["https://mirror-a"].until)
+
+	// Failed endpoints are tried in order again once their cooldown expires
+	assert.Equal(t, []string{"https://mirror-b", "https://huggingface.co", "https://mirror-a"}, health.order(endpoints, now.Add(90*time.Second)))
+
+	for i := 0; i < 10; i++ {
+		health.markFailed("https://mirror-b", time.Minute, now)
+	}
+	assert.Equal(t, now.Add(MaxEndpointCooldown), health.failures["https://mirror-b"].until)
+
+	health.markHealthy("https://mirror-a")
+	health.markHealthy("https://mirror-b")
+	assert.Equal(t, endpoints, health.order(endpoints, now))
+}
+
+func TestWithEndpoints(t *testing.T) {
+	config, err := NewHubConfig(WithEndpoints("https://mirror.internal", DefaultEndpoint), WithEndpointCooldown(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, "https://mirror.internal", config.Endpoint)
+	assert.Equal(t, []string{"https://mirror.internal", DefaultEndpoint}, config.Endpoints)
+	assert.Equal(t, time.Minute, config.EndpointCooldown)
+
+	_, err = NewHubConfig(WithEndpoints())
+	assert.Error(t, err)
+	_, err = NewHubConfig(WithEndpoints("https://mirror.internal", ""))
+	assert.Error(t, err)
+	_, err = NewHubConfig(WithEndpointCooldown(0))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package hub
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -42,6 +43,11 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 }
 
+// httpStatusCode is the status of the response, promoted to the errors embedding HTTPError
+func (e *HTTPError) httpStatusCode() int {
+	return e.StatusCode
+}
+
 // RepositoryNotFoundError is raised when a repository is not found
 type RepositoryNotFoundError struct {
 	*HTTPError
@@ -184,6 +190,23 @@ func NewLocalEntryNotFoundError(path string) *LocalEntryNotFoundError {
 	}
 }
 
+// NewOfflineEntryNotFoundError returns the error of files that are not in the local directory or cache of an
+// offline download, location being the directory that was searched. No files means the whole snapshot.
+func NewOfflineEntryNotFoundError(repoID, revision string, files []string, location string) *LocalEntryNotFoundError {
+	entries := "the files"
+	if len(files) == 1 {
+		entries = fmt.Sprintf("file '%s'", files[0])
+	} else if len(files) > 1 {
+		entries = fmt.Sprintf("files '%s'", strings.Join(files, "', '"))
+	}
+	return &LocalEntryNotFoundError{
+		HubError: &HubError{Message: fmt.Sprintf(
+			"Cannot find %s of repository '%s' at revision '%s' in %s and offline mode is enabled: "+
+				"download them once with network access or disable offline mode (%s)", entries, repoID, revision, location, EnvHfHubOffline)},
+		Path: strings.Join(files, ", "),
+	}
+}
+
 // BadRequestError is raised for HTTP 400 errors
 type BadRequestError struct {
 	*HTTPError
@@ -219,6 +242,21 @@ func NewOfflineModeIsEnabledError(message string) *OfflineModeIsEnabledError {
 	}
 }
 
+// LockfileDriftError is raised when a repository no longer matches the lockfile of a frozen snapshot download
+type LockfileDriftError struct {
+	*HubError
+	LockFile string
+	Changes  []string
+}
+
+func NewLockfileDriftError(lockFile, revision string, changes []string) *LockfileDriftError {
+	return &LockfileDriftError{
+		HubError: &HubError{Message: fmt.Sprintf("revision %s drifted from lockfile %s: %s", revision, lockFile, strings.Join(changes, ", "))},
+		LockFile: lockFile,
+		Changes:  changes,
+	}
+}
+
 // ValidationError represents a validation error
 type ValidationError struct {
 	*HubError
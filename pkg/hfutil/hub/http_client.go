@@ -1,8 +1,14 @@
 package hub
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"sync"
 	"time"
 )
@@ -19,6 +25,9 @@ func GetHTTPClient() *http.Client {
 	clientOnce.Do(func() {
 		// Configure transport with connection pooling and HTTP/2 support
 		transport := &http.Transport{
+			// Honor HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+			Proxy: http.ProxyFromEnvironment,
+
 			// Connection pooling settings
 			MaxIdleConns:        100,              // Maximum idle connections across all hosts
 			MaxIdleConnsPerHost: 10,               // Maximum idle connections per host
@@ -58,7 +67,114 @@ func GetHTTPClient() *http.Client {
 // This uses the same transport (connection pooling) but with a custom timeout
 func NewHTTPClientWithTimeout(timeout time.Duration) *http.Client {
 	return &http.Client{
-		Transport: GetHTTPClient().Transport,
+		Transport: &tokenFileTransport{base: &rateLimitedTransport{base: GetHTTPClient().Transport}},
 		Timeout:   timeout,
 	}
 }
+
+// TransportOptions configures how the Hub is reached from networks that need a proxy or a custom CA
+type TransportOptions struct {
+	// ProxyURL is the HTTP(S) proxy requests go through. The proxy of the environment (HTTPS_PROXY, NO_PROXY...)
+	// is used when it is empty. Credentials of authenticated proxies are part of the URL.
+	ProxyURL string
+	// CABundle is a PEM file of CA certificates trusted in addition to the system ones
+	CABundle string
+	// InsecureSkipVerify disables the verification of the certificates of the Hub
+	InsecureSkipVerify bool
+}
+
+// transports caches the transports of the transport options, so that clients with the same options share their
+// connection pool
+var transports sync.Map
+
+// transportOptions returns the transport options of the Hub configuration of the context
+func transportOptions(ctx context.Context) TransportOptions {
+	hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig)
+	if !ok {
+		return TransportOptions{}
+	}
+	return TransportOptions{
+		ProxyURL:           hubConfig.ProxyURL,
+		CABundle:           hubConfig.CABundle,
+		InsecureSkipVerify: hubConfig.InsecureSkipVerify,
+	}
+}
+
+// newHTTPClient creates an HTTP client with a specific timeout that reaches the Hub through the proxy and with
+// the TLS settings of the Hub configuration of the context
+func newHTTPClient(ctx context.Context, timeout time.Duration) (*http.Client, error) {
+	options := transportOptions(ctx)
+	if options == (TransportOptions{}) {
+		return NewHTTPClientWithTimeout(timeout), nil
+	}
+	transport, err := GetTransport(options)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &tokenFileTransport{base: &rateLimitedTransport{base: transport}},
+		Timeout:   timeout,
+	}, nil
+}
+
+// GetTransport returns the shared transport of the transport options
+func GetTransport(options TransportOptions) (*http.Transport, error) {
+	if transport, ok := transports.Load(options); ok {
+		return transport.(*http.Transport), nil
+	}
+
+	transport := GetHTTPClient().Transport.(*http.Transport).Clone()
+	if options.ProxyURL != "" {
+		proxyURL, err := parseProxyURL(options.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if options.CABundle != "" || options.InsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			// #nosec G402 -- skipping the verification is an explicit opt-in of the configuration
+			InsecureSkipVerify: options.InsecureSkipVerify,
+		}
+		if options.CABundle != "" {
+			rootCAs, err := loadCABundle(options.CABundle)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = rootCAs
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	actual, _ := transports.LoadOrStore(options, transport)
+	return actual.(*http.Transport), nil
+}
+
+// parseProxyURL parses the URL of an HTTP(S) proxy
+func parseProxyURL(proxy string) (*url.URL, error) {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if (proxyURL.Scheme != "http" && proxyURL.Scheme != "https") || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %s: expected http(s)://[user:password@]host[:port]", proxyURL.Redacted())
+	}
+	return proxyURL, nil
+}
+
+// loadCABundle returns the system certificate pool with the certificates of a PEM bundle added to it
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificate found in CA bundle %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,214 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// A snapshot lockfile records the commit a snapshot download resolved its revision to, along with the size and
+// etag of every downloaded file. Frozen downloads pin the snapshot to that commit, so they download the same
+// content however the revision moved, and fail if the files of the revision changed since the lockfile was written.
+
+// SnapshotLock is the content of a snapshot lockfile
+type SnapshotLock struct {
+	Version  int          `json:"version"`
+	RepoID   string       `json:"repo_id"`
+	RepoType string       `json:"repo_type"`
+	Revision string       `json:"revision"`
+	Commit   string       `json:"commit"`
+	Files    []LockedFile `json:"files"`
+}
+
+// LockedFile is a file of a snapshot lockfile
+type LockedFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	Etag string `json:"etag"`
+}
+
+// NewSnapshotLock returns the lockfile of the files of a snapshot downloaded at commit
+func NewSnapshotLock(config *DownloadConfig, revision, commit string, files []RepoFile) *SnapshotLock {
+	lock := &SnapshotLock{
+		Version:  SnapshotLockVersion,
+		RepoID:   config.RepoID,
+		RepoType: downloadRepoType(config),
+		Revision: revision,
+		Commit:   commit,
+		Files:    make([]LockedFile, 0, len(files)),
+	}
+	for _, file := range files {
+		lock.Files = append(lock.Files, LockedFile{Path: file.Path, Size: file.Size, Etag: file.Etag()})
+	}
+	sort.Slice(lock.Files, func(i, j int) bool { return lock.Files[i].Path < lock.Files[j].Path })
+	return lock
+}
+
+// ReadSnapshotLock reads a snapshot lockfile
+func ReadSnapshotLock(path string) (*SnapshotLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	lock := &SnapshotLock{}
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile %s: %w", path, err)
+	}
+	if lock.Version != SnapshotLockVersion {
+		return nil, fmt.Errorf("unsupported lockfile version %d in %s, expected %d", lock.Version, path, SnapshotLockVersion)
+	}
+	if !IsCommitHash(lock.Commit) {
+		return nil, fmt.Errorf("invalid commit %q in lockfile %s", lock.Commit, path)
+	}
+	return lock, nil
+}
+
+// Write writes the lockfile atomically, so that an interrupted write doesn't leave a truncated lockfile
+func (l *SnapshotLock) Write(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create lockfile directory: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	return nil
+}
+
+// Diff describes how the files differ from the files of the lockfile, e.g. "modified config.json (etag a1b2 -> c3d4)"
+func (l *SnapshotLock) Diff(files []RepoFile) []string {
+	current := make(map[string]RepoFile, len(files))
+	for _, file := range files {
+		current[file.Path] = file
+	}
+
+	var changes []string
+	locked := make(map[string]bool, len(l.Files))
+	for _, lockedFile := range l.Files {
+		locked[lockedFile.Path] = true
+		file, ok := current[lockedFile.Path]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("removed %s", lockedFile.Path))
+		case file.Etag() != lockedFile.Etag:
+			changes = append(changes, fmt.Sprintf("modified %s (etag %s -> %s)", lockedFile.Path, lockedFile.Etag, file.Etag()))
+		case file.Size != lockedFile.Size:
+			changes = append(changes, fmt.Sprintf("modified %s (size %d -> %d)", lockedFile.Path, lockedFile.Size, file.Size))
+		}
+	}
+	for _, file := range files {
+		if !locked[file.Path] {
+			changes = append(changes, fmt.Sprintf("added %s", file.Path))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// pinSnapshot returns the config of a snapshot download pinned to a commit, along with the lockfile it is pinned
+// by in frozen mode. In update mode the commit is the current commit of the revision and the lockfile is nil.
+func pinSnapshot(ctx context.Context, config *DownloadConfig) (*DownloadConfig, *SnapshotLock, error) {
+	pinned := *config
+	if config.LockMode != LockModeFrozen {
+		if config.LockMode != "" && config.LockMode != LockModeUpdate {
+			return nil, nil, NewValidationError("lock_mode", config.LockMode, fmt.Sprintf("invalid lock mode, accepted modes are: %s, %s", LockModeUpdate, LockModeFrozen))
+		}
+		commit, err := resolveRevision(ctx, config, downloadRevision(config))
+		if err != nil {
+			return nil, nil, err
+		}
+		pinned.Revision = commit
+		return &pinned, nil, nil
+	}
+
+	lock, err := ReadSnapshotLock(config.LockFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lock.RepoID != config.RepoID || lock.RepoType != downloadRepoType(config) {
+		return nil, nil, NewValidationError("lock_file", config.LockFile, fmt.Sprintf("lockfile is for %s repository %s", lock.RepoType, lock.RepoID))
+	}
+	if config.Revision != "" && config.Revision != DefaultRevision && config.Revision != lock.Revision && config.Revision != lock.Commit {
+		return nil, nil, NewValidationError("revision", config.Revision, fmt.Sprintf("lockfile is for revision %s", lock.Revision))
+	}
+
+	// Files of the revision that changed since the lockfile was written would be silently ignored by downloading
+	// the locked commit, so the download fails instead
+	commit, err := resolveRevision(ctx, config, lock.Revision)
+	if err != nil {
+		return nil, nil, err
+	}
+	if commit != lock.Commit {
+		current := *config
+		current.Revision = commit
+		files, err := ListRepoFiles(ctx, &current)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list repository files: %w", err)
+		}
+		if changes := lock.Diff(FilterByPatterns(files, config.AllowPatterns, config.IgnorePatterns)); len(changes) > 0 {
+			return nil, nil, NewLockfileDriftError(config.LockFile, lock.Revision, changes)
+		}
+	}
+
+	pinned.Revision = lock.Commit
+	return &pinned, lock, nil
+}
+
+// resolveRevision returns the commit a revision of the repository currently points to
+func resolveRevision(ctx context.Context, config *DownloadConfig, revision string) (string, error) {
+	if IsCommitHash(revision) {
+		return revision, nil
+	}
+	requestConfig := &UploadConfig{
+		RepoID:   config.RepoID,
+		RepoType: config.RepoType,
+		Token:    config.Token,
+		Headers:  config.Headers,
+		Endpoint: config.Endpoint,
+	}
+	apiURL := fmt.Sprintf(ApiRepoRevisionURL, uploadEndpoint(requestConfig), uploadRepoType(requestConfig), config.RepoID, url.PathEscape(revision))
+
+	var result struct {
+		SHA string `json:"sha"`
+	}
+	if err := doHubJSONRequest(ctx, requestConfig, http.MethodGet, apiURL, "application/json", nil, &result); err != nil {
+		var notFound *RepositoryNotFoundError
+		if errors.As(err, &notFound) {
+			return "", NewRevisionNotFoundError(config.RepoID, downloadRepoType(config), revision, notFound.Response)
+		}
+		return "", fmt.Errorf("failed to resolve revision %s: %w", revision, err)
+	}
+	if !IsCommitHash(result.SHA) {
+		return "", fmt.Errorf("failed to resolve revision %s: invalid commit %q", revision, result.SHA)
+	}
+	return result.SHA, nil
+}
+
+// downloadRepoType returns the repository type of the download
+func downloadRepoType(config *DownloadConfig) string {
+	if config.RepoType == "" {
+		return RepoTypeModel
+	}
+	return config.RepoType
+}
+
+// downloadRevision returns the revision of the download
+func downloadRevision(config *DownloadConfig) string {
+	if config.Revision == "" {
+		return DefaultRevision
+	}
+	return config.Revision
+}
@@ -0,0 +1,235 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lockedCommit  = "1111111111111111111111111111111111111111"
+	currentCommit = "2222222222222222222222222222222222222222"
+)
+
+// fakeRepoHistory serves the revision, tree and file APIs of a model repository whose main branch can move
+type fakeRepoHistory struct {
+	mu   sync.Mutex
+	head string
+	// contents are the files of each commit
+	contents map[string]map[string]string
+	// resolved are the commits files were downloaded from
+	resolved map[string]bool
+}
+
+func newFakeRepoHistory(t *testing.T, history *fakeRepoHistory) *httptest.Server {
+	history.resolved = map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		history.mu.Lock()
+		defer history.mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/models/org/model/revision/main":
+			_ = json.NewEncoder(w).Encode(map[string]string{"sha": history.head})
+
+		case strings.HasPrefix(r.URL.Path, "/api/models/org/model/tree/"):
+			commit := strings.TrimPrefix(r.URL.Path, "/api/models/org/model/tree/")
+			files := []RepoFile{}
+			for name, content := range history.contents[commit] {
+				files = append(files, RepoFile{Path: name, Size: int64(len(content)), Type: "file", OID: blobOID(content)})
+			}
+			_ = json.NewEncoder(w).Encode(files)
+
+		case strings.HasPrefix(r.URL.Path, "/org/model/resolve/"):
+			commit, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/org/model/resolve/"), "/")
+			content, ok := history.contents[commit][name]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			history.resolved[commit] = true
+			w.Header().Set(HuggingfaceHeaderXRepoCommit, commit)
+			w.Header().Set("ETag", fmt.Sprintf("%q", blobOID(content)))
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(content))
+			}
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// blobOID returns a stand-in for the Git object ID of the content
+func blobOID(content string) string {
+	return fmt.Sprintf("%040x", len(content)*7919+int(content[0]))
+}
+
+func TestSnapshotDownloadLockfile(t *testing.T) {
+	history := &fakeRepoHistory{
+		head: lockedCommit,
+		contents: map[string]map[string]string{
+			lockedCommit: {
+				"config.json":       `{"model_type": "llama"}`,
+				"model.safetensors": "weights v1",
+				"README.md":         "# Model",
+			},
+		},
+	}
+	server := newFakeRepoHistory(t, history)
+	lockFile := filepath.Join(t.TempDir(), "model.lock.json")
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{MaxWorkers: 2, MaxRetries: 0})
+
+	// Update mode pins the snapshot to the current commit of main and writes the lockfile
+	_, err := SnapshotDownload(ctx, &DownloadConfig{
+		RepoID:        "org/model",
+		Revision:      "main",
+		Endpoint:      server.URL,
+		LocalDir:      t.TempDir(),
+		AllowPatterns: []string{"*.json", "*.safetensors"},
+		LockFile:      lockFile,
+		LockMode:      LockModeUpdate,
+	})
+	require.NoError(t, err)
+
+	lock, err := ReadSnapshotLock(lockFile)
+	require.NoError(t, err)
+	assert.Equal(t, &SnapshotLock{
+		Version:  SnapshotLockVersion,
+		RepoID:   "org/model",
+		RepoType: RepoTypeModel,
+		Revision: "main",
+		Commit:   lockedCommit,
+		Files: []LockedFile{
+			{Path: "config.json", Size: 23, Etag: blobOID(`{"model_type": "llama"}`)},
+			{Path: "model.safetensors", Size: 10, Etag: blobOID("weights v1")},
+		},
+	}, lock)
+
+	// main moves, changing only a file outside of the patterns: the locked commit is downloaded
+	history.mu.Lock()
+	history.head = currentCommit
+	history.contents[currentCommit] = map[string]string{
+		"config.json":       `{"model_type": "llama"}`,
+		"model.safetensors": "weights v1",
+		"README.md":         "# Model v2",
+	}
+	history.resolved = map[string]bool{}
+	history.mu.Unlock()
+
+	frozen := &DownloadConfig{
+		RepoID:        "org/model",
+		Revision:      "main",
+		Endpoint:      server.URL,
+		LocalDir:      t.TempDir(),
+		AllowPatterns: []string{"*.json", "*.safetensors"},
+		LockFile:      lockFile,
+		LockMode:      LockModeFrozen,
+	}
+	localDir, err := SnapshotDownload(ctx, frozen)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{lockedCommit: true}, history.resolved)
+	weights, err := os.ReadFile(filepath.Join(localDir, "model.safetensors"))
+	require.NoError(t, err)
+	assert.Equal(t, "weights v1", string(weights))
+
+	// main moves again, changing the weights: the frozen download fails instead of ignoring the new weights
+	history.mu.Lock()
+	history.contents[currentCommit]["model.safetensors"] = "new weights v2"
+	history.mu.Unlock()
+
+	frozen.LocalDir = t.TempDir()
+	_, err = SnapshotDownload(ctx, frozen)
+	var driftErr *LockfileDriftError
+	require.ErrorAs(t, err, &driftErr)
+	assert.Equal(t, []string{fmt.Sprintf("modified model.safetensors (etag %s -> %s)", blobOID("weights v1"), blobOID("new weights v2"))}, driftErr.Changes)
+
+	// Changing the patterns of a frozen download also drifts from the lockfile
+	frozen.AllowPatterns = []string{"*"}
+	history.mu.Lock()
+	history.head = lockedCommit
+	history.mu.Unlock()
+	_, err = SnapshotDownload(ctx, frozen)
+	require.ErrorAs(t, err, &driftErr)
+	assert.Equal(t, []string{"added README.md"}, driftErr.Changes)
+}
+
+func TestSnapshotDownloadLockfileErrors(t *testing.T) {
+	server := newFakeRepoHistory(t, &fakeRepoHistory{head: lockedCommit})
+	lockFile := filepath.Join(t.TempDir(), "model.lock.json")
+	require.NoError(t, (&SnapshotLock{Version: SnapshotLockVersion, RepoID: "org/other", RepoType: RepoTypeModel, Revision: "main", Commit: lockedCommit}).Write(lockFile))
+
+	tests := []struct {
+		name   string
+		config *DownloadConfig
+		errMsg string
+	}{
+		{
+			name:   "missing lockfile",
+			config: &DownloadConfig{RepoID: "org/model", LockFile: filepath.Join(t.TempDir(), "missing.json"), LockMode: LockModeFrozen},
+			errMsg: "failed to read lockfile",
+		},
+		{
+			name:   "lockfile of another repository",
+			config: &DownloadConfig{RepoID: "org/model", LockFile: lockFile, LockMode: LockModeFrozen},
+			errMsg: "lockfile is for model repository org/other",
+		},
+		{
+			name:   "invalid lock mode",
+			config: &DownloadConfig{RepoID: "org/model", LockFile: lockFile, LockMode: "strict"},
+			errMsg: "invalid lock mode",
+		},
+		{
+			name:   "unknown revision",
+			config: &DownloadConfig{RepoID: "org/model", Revision: "v2", LockFile: lockFile},
+			errMsg: "v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Endpoint = server.URL
+			tt.config.LocalDir = t.TempDir()
+			ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{MaxWorkers: 1, MaxRetries: 0})
+			_, err := SnapshotDownload(ctx, tt.config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestSnapshotLockDiff(t *testing.T) {
+	lock := &SnapshotLock{Files: []LockedFile{
+		{Path: "config.json", Size: 10, Etag: "aaa"},
+		{Path: "model.safetensors", Size: 100, Etag: "bbb"},
+		{Path: "tokenizer.json", Size: 20, Etag: "ccc"},
+	}}
+
+	assert.Empty(t, lock.Diff([]RepoFile{
+		{Path: "config.json", Size: 10, OID: "aaa"},
+		{Path: "model.safetensors", Size: 100, OID: "pointer", LFS: &LFSInfo{OID: "bbb", Size: 100}},
+		{Path: "tokenizer.json", Size: 20, OID: "ccc"},
+	}))
+	assert.Equal(t, []string{
+		"added generation_config.json",
+		"modified config.json (size 10 -> 12)",
+		"modified model.safetensors (etag bbb -> ddd)",
+		"removed tokenizer.json",
+	}, lock.Diff([]RepoFile{
+		{Path: "config.json", Size: 12, OID: "aaa"},
+		{Path: "model.safetensors", Size: 100, LFS: &LFSInfo{OID: "ddd", Size: 100}},
+		{Path: "generation_config.json", Size: 5, OID: "eee"},
+	}))
+}
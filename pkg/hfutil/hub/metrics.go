@@ -0,0 +1,149 @@
+package hub
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sgl-project/ome/pkg/utils/metrics"
+)
+
+// Retry reasons of the downloads
+const (
+	retryReasonNetwork     = "network"
+	retryReasonRateLimit   = "rate_limit"
+	retryReasonHTTPError   = "http_error"
+	retryReasonInterrupted = "interrupted"
+)
+
+// Metrics records the transfers of the hub client as Prometheus metrics. All its methods are no-ops on a nil
+// Metrics, so the download paths don't check whether metrics are enabled.
+type Metrics struct {
+	filesTotal       *prometheus.CounterVec
+	bytesTotal       *prometheus.CounterVec
+	retriesTotal     *prometheus.CounterVec
+	fileDuration     *prometheus.HistogramVec
+	snapshotDuration *prometheus.HistogramVec
+	rateLimitPauses  *prometheus.CounterVec
+	rateLimitWait    *prometheus.CounterVec
+}
+
+// NewMetrics creates the transfer metrics and registers them, the default registerer is used when nil. The
+// metrics already registered by another client are shared, so several clients can use the same registerer.
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	m := &Metrics{}
+	var err error
+	if m.filesTotal, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hf_hub_files_downloaded_total",
+		Help: "Number of files downloaded from the Hugging Face Hub",
+	}, []string{"repo", "result"})); err != nil {
+		return nil, err
+	}
+	if m.bytesTotal, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hf_hub_downloaded_bytes_total",
+		Help: "Number of bytes received from the Hugging Face Hub, including the ones of the attempts that were retried",
+	}, []string{"repo"})); err != nil {
+		return nil, err
+	}
+	if m.retriesTotal, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hf_hub_download_retries_total",
+		Help: "Number of retried file downloads from the Hugging Face Hub",
+	}, []string{"repo", "reason"})); err != nil {
+		return nil, err
+	}
+	if m.fileDuration, err = metrics.RegisterCollector(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hf_hub_file_download_duration_seconds",
+		Help:    "Duration of the file downloads from the Hugging Face Hub",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 16), // From 100ms to ~55m
+	}, []string{"repo"})); err != nil {
+		return nil, err
+	}
+	if m.snapshotDuration, err = metrics.RegisterCollector(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hf_hub_snapshot_download_duration_seconds",
+		Help:    "Duration of the snapshot downloads from the Hugging Face Hub",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 16), // From 1s to ~9h
+	}, []string{"repo", "result"})); err != nil {
+		return nil, err
+	}
+	if m.rateLimitPauses, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hf_hub_rate_limit_pauses_total",
+		Help: "Number of times the requests to a host were paused by its rate limit",
+	}, []string{"host", "status"})); err != nil {
+		return nil, err
+	}
+	if m.rateLimitWait, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hf_hub_rate_limit_pause_seconds_total",
+		Help: "Duration of the pauses of the requests to a host caused by its rate limit",
+	}, []string{"host"})); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// metricsFromContext returns the metrics of the HubConfig of the context, nil when there is none
+func metricsFromContext(ctx context.Context) *Metrics {
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
+		return hubConfig.Metrics
+	}
+	return nil
+}
+
+func (m *Metrics) observeFile(repoID string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.filesTotal.WithLabelValues(repoID, metricsResult(err)).Inc()
+	if err == nil {
+		m.fileDuration.WithLabelValues(repoID).Observe(duration.Seconds())
+	}
+}
+
+func (m *Metrics) addBytes(repoID string, n int64) {
+	if m == nil || n <= 0 {
+		return
+	}
+	m.bytesTotal.WithLabelValues(repoID).Add(float64(n))
+}
+
+func (m *Metrics) observeRetry(repoID, reason string) {
+	if m == nil {
+		return
+	}
+	m.retriesTotal.WithLabelValues(repoID, reason).Inc()
+}
+
+func (m *Metrics) observeSnapshot(repoID string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.snapshotDuration.WithLabelValues(repoID, metricsResult(err)).Observe(duration.Seconds())
+}
+
+func (m *Metrics) observeRateLimit(event RateLimitEvent) {
+	if m == nil {
+		return
+	}
+	m.rateLimitPauses.WithLabelValues(event.Host, strconv.Itoa(event.StatusCode)).Inc()
+	m.rateLimitWait.WithLabelValues(event.Host).Add(event.Wait.Seconds())
+}
+
+func metricsResult(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// retryReason returns the retry reason of an HTTP status
+func retryReason(statusCode int) string {
+	if statusCode == 429 {
+		return retryReasonRateLimit
+	}
+	return retryReasonHTTPError
+}
@@ -0,0 +1,81 @@
+package hub
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRecordDownloads(t *testing.T) {
+	content := "test file content"
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte(content))
+		}
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	hubConfig, err := NewHubConfig(
+		WithRetryConfig(3, time.Millisecond),
+		WithProgressBars(false),
+		WithMetrics(registry),
+	)
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), HubConfigKey, hubConfig)
+
+	config := &DownloadConfig{RepoID: "org/model", Filename: "config.json"}
+	metadata := &FileMetadata{Location: server.URL + "/config.json", Size: int64(len(content))}
+	require.NoError(t, downloadToTmpAndMove(ctx, config, metadata, filepath.Join(t.TempDir(), "config.json")))
+
+	metrics := hubConfig.Metrics
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.filesTotal.WithLabelValues("org/model", "success")))
+	assert.Equal(t, float64(len(content)), testutil.ToFloat64(metrics.bytesTotal.WithLabelValues("org/model")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.retriesTotal.WithLabelValues("org/model", retryReasonHTTPError)))
+	assert.Equal(t, "rate_limit", retryReason(http.StatusTooManyRequests))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.fileDuration))
+
+	// A file already downloaded isn't counted again
+	destPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, downloadToTmpAndMove(ctx, config, metadata, destPath))
+	require.NoError(t, downloadToTmpAndMove(ctx, config, metadata, destPath))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.filesTotal.WithLabelValues("org/model", "success")))
+}
+
+func TestMetricsShareRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first, err := NewMetrics(registry)
+	require.NoError(t, err)
+	second, err := NewMetrics(registry)
+	require.NoError(t, err)
+
+	first.addBytes("org/model", 10)
+	second.addBytes("org/model", 5)
+	assert.Equal(t, 15.0, testutil.ToFloat64(first.bytesTotal.WithLabelValues("org/model")))
+}
+
+func TestNilMetrics(t *testing.T) {
+	var metrics *Metrics
+	assert.NotPanics(t, func() {
+		metrics.observeFile("org/model", time.Second, nil)
+		metrics.addBytes("org/model", 10)
+		metrics.observeRetry("org/model", retryReasonNetwork)
+		metrics.observeSnapshot("org/model", time.Second, nil)
+	})
+	assert.Nil(t, metricsFromContext(context.Background()))
+}
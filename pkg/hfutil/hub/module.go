@@ -82,6 +82,59 @@ func (c *HubClient) SnapshotDownload(ctx context.Context, repoID, localDir strin
 	return result, err
 }
 
+// SyncSnapshot brings a local directory in sync with a revision of a repository, downloading only the files that
+// changed since the previous sync
+func (c *HubClient) SyncSnapshot(ctx context.Context, repoID, localDir string, opts ...DownloadOption) (*SyncResult, error) {
+	config := c.config.ToDownloadConfig()
+	config.RepoID = repoID
+	config.LocalDir = localDir
+
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("failed to apply download option: %w", err)
+		}
+	}
+
+	// Add hub config to context for progress reporting
+	ctx = context.WithValue(ctx, HubConfigKey, c.config)
+
+	return SyncSnapshot(ctx, config)
+}
+
+// VerifySnapshot re-computes the digests of the files of a local directory and compares them with the files of a
+// revision of a repository
+func (c *HubClient) VerifySnapshot(ctx context.Context, repoID, localDir string, opts ...DownloadOption) (*VerifyReport, error) {
+	config := c.config.ToDownloadConfig()
+	config.RepoID = repoID
+	config.LocalDir = localDir
+
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("failed to apply download option: %w", err)
+		}
+	}
+
+	ctx = context.WithValue(ctx, HubConfigKey, c.config)
+
+	return VerifySnapshot(ctx, config)
+}
+
+// BatchSnapshotDownload downloads the snapshots of several repositories concurrently and reports the outcome of each
+// of them
+func (c *HubClient) BatchSnapshotDownload(ctx context.Context, repos []BatchRepo, maxConcurrentRepos int, opts ...DownloadOption) (*BatchReport, error) {
+	config := c.config.ToDownloadConfig()
+
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("failed to apply download option: %w", err)
+		}
+	}
+
+	ctx = context.WithValue(ctx, HubConfigKey, c.config)
+
+	return BatchSnapshotDownload(ctx, config, repos, maxConcurrentRepos), nil
+}
+
 // ListFiles lists all files in a repository
 func (c *HubClient) ListFiles(ctx context.Context, repoID string, opts ...DownloadOption) ([]RepoFile, error) {
 	config := c.config.ToDownloadConfig()
@@ -100,6 +153,185 @@ func (c *HubClient) ListFiles(ctx context.Context, repoID string, opts ...Downlo
 	return files, err
 }
 
+// ModelInfo returns the metadata of a model repository, e.g. its siblings, tags and gated status
+func (c *HubClient) ModelInfo(ctx context.Context, repoID string, opts ...DownloadOption) (*ModelInfo, error) {
+	config, err := c.infoConfig(repoID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return GetModelInfo(context.WithValue(ctx, HubConfigKey, c.config), config)
+}
+
+// DatasetInfo returns the metadata of a dataset repository, e.g. its siblings, tags and gated status
+func (c *HubClient) DatasetInfo(ctx context.Context, repoID string, opts ...DownloadOption) (*DatasetInfo, error) {
+	config, err := c.infoConfig(repoID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return GetDatasetInfo(context.WithValue(ctx, HubConfigKey, c.config), config)
+}
+
+// infoConfig returns the configuration of the metadata requests of a repository with the options applied. The
+// metadata is of the default branch unless a revision is given.
+func (c *HubClient) infoConfig(repoID string, opts []DownloadOption) (*DownloadConfig, error) {
+	config := c.config.ToDownloadConfig()
+	config.RepoID = repoID
+	config.Revision = ""
+
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("failed to apply download option: %w", err)
+		}
+	}
+	return config, nil
+}
+
+// CreateCommit creates a commit with the given operations in a repository
+func (c *HubClient) CreateCommit(ctx context.Context, repoID string, operations []CommitOperation, opts ...UploadOption) (*CommitInfo, error) {
+	config, err := c.uploadConfig(repoID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add hub config to context for retries and progress reporting
+	ctx = context.WithValue(ctx, HubConfigKey, c.config)
+
+	return CreateCommit(ctx, config, operations)
+}
+
+// UploadFile uploads a local file to pathInRepo in a repository
+func (c *HubClient) UploadFile(ctx context.Context, repoID, localPath, pathInRepo string, opts ...UploadOption) (*CommitInfo, error) {
+	config, err := c.uploadConfig(repoID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add hub config to context for retries and progress reporting
+	ctx = context.WithValue(ctx, HubConfigKey, c.config)
+
+	return UploadFile(ctx, config, localPath, pathInRepo)
+}
+
+// UploadFolder uploads the files of a local folder to a repository in a single commit
+func (c *HubClient) UploadFolder(ctx context.Context, repoID, folderPath string, opts ...UploadOption) (*CommitInfo, error) {
+	config, err := c.uploadConfig(repoID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add hub config to context for retries and progress reporting
+	ctx = context.WithValue(ctx, HubConfigKey, c.config)
+
+	return UploadFolder(ctx, config, folderPath)
+}
+
+// CreateRepo creates a repository and returns its URL
+func (c *HubClient) CreateRepo(ctx context.Context, repoID string, opts ...RepoOption) (string, error) {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return "", err
+	}
+	return CreateRepo(context.WithValue(ctx, HubConfigKey, c.config), config)
+}
+
+// DeleteRepo deletes a repository
+func (c *HubClient) DeleteRepo(ctx context.Context, repoID string, opts ...RepoOption) error {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return err
+	}
+	return DeleteRepo(context.WithValue(ctx, HubConfigKey, c.config), config)
+}
+
+// UpdateRepoVisibility makes a repository private or public
+func (c *HubClient) UpdateRepoVisibility(ctx context.Context, repoID string, private bool, opts ...RepoOption) error {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return err
+	}
+	return UpdateRepoVisibility(context.WithValue(ctx, HubConfigKey, c.config), config, private)
+}
+
+// MoveRepo renames a repository or moves it to another namespace
+func (c *HubClient) MoveRepo(ctx context.Context, fromRepoID, toRepoID string, opts ...RepoOption) error {
+	config, err := c.repoConfig(fromRepoID, opts)
+	if err != nil {
+		return err
+	}
+	return MoveRepo(context.WithValue(ctx, HubConfigKey, c.config), config, toRepoID)
+}
+
+// ListRefs lists the branches and tags of a repository
+func (c *HubClient) ListRefs(ctx context.Context, repoID string, opts ...RepoOption) (*GitRefs, error) {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ListRepoRefs(context.WithValue(ctx, HubConfigKey, c.config), config)
+}
+
+// CreateBranch creates a branch in a repository
+func (c *HubClient) CreateBranch(ctx context.Context, repoID, branch string, opts ...RepoOption) error {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return err
+	}
+	return CreateBranch(context.WithValue(ctx, HubConfigKey, c.config), config, branch)
+}
+
+// DeleteBranch deletes a branch of a repository
+func (c *HubClient) DeleteBranch(ctx context.Context, repoID, branch string, opts ...RepoOption) error {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return err
+	}
+	return DeleteBranch(context.WithValue(ctx, HubConfigKey, c.config), config, branch)
+}
+
+// CreateTag creates a tag in a repository
+func (c *HubClient) CreateTag(ctx context.Context, repoID, tag string, opts ...RepoOption) error {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return err
+	}
+	return CreateTag(context.WithValue(ctx, HubConfigKey, c.config), config, tag)
+}
+
+// DeleteTag deletes a tag of a repository
+func (c *HubClient) DeleteTag(ctx context.Context, repoID, tag string, opts ...RepoOption) error {
+	config, err := c.repoConfig(repoID, opts)
+	if err != nil {
+		return err
+	}
+	return DeleteTag(context.WithValue(ctx, HubConfigKey, c.config), config, tag)
+}
+
+// repoConfig returns the repository management configuration of the client for a repository with the options applied
+func (c *HubClient) repoConfig(repoID string, opts []RepoOption) (*RepoConfig, error) {
+	config := c.config.ToRepoConfig()
+	config.RepoID = repoID
+
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("failed to apply repository option: %w", err)
+		}
+	}
+	return config, nil
+}
+
+// uploadConfig returns the upload configuration of the client for a repository with the options applied
+func (c *HubClient) uploadConfig(repoID string, opts []UploadOption) (*UploadConfig, error) {
+	config := c.config.ToUploadConfig()
+	config.RepoID = repoID
+
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("failed to apply upload option: %w", err)
+		}
+	}
+	return config, nil
+}
+
 // GetConfig returns the client configuration
 func (c *HubClient) GetConfig() *HubConfig {
 	return c.config
@@ -132,6 +364,14 @@ func WithRepoType(repoType string) DownloadOption {
 	}
 }
 
+// WithLocalDir downloads the file into a local directory instead of the cache
+func WithLocalDir(localDir string) DownloadOption {
+	return func(config *DownloadConfig) error {
+		config.LocalDir = localDir
+		return nil
+	}
+}
+
 // WithForceDownload enables force download mode
 func WithForceDownload(force bool) DownloadOption {
 	return func(config *DownloadConfig) error {
@@ -165,6 +405,174 @@ func WithDownloadToken(token string) DownloadOption {
 	}
 }
 
+// WithLockFile locks a snapshot download with a lockfile. LockModeUpdate pins the snapshot to the current commit
+// of the revision and writes the lockfile, LockModeFrozen downloads the locked files and fails on drift.
+func WithLockFile(lockFile, mode string) DownloadOption {
+	return func(config *DownloadConfig) error {
+		if mode != LockModeUpdate && mode != LockModeFrozen {
+			return fmt.Errorf("invalid lock mode %q: expected %s or %s", mode, LockModeUpdate, LockModeFrozen)
+		}
+		config.LockFile = lockFile
+		config.LockMode = mode
+		return nil
+	}
+}
+
+// WithDeleteRemovedFiles makes a snapshot sync delete the files that were removed upstream since the previous sync
+func WithDeleteRemovedFiles(deleteRemoved bool) DownloadOption {
+	return func(config *DownloadConfig) error {
+		config.DeleteRemovedFiles = deleteRemoved
+		return nil
+	}
+}
+
+// UploadOption represents an option for upload operations
+type UploadOption func(*UploadConfig) error
+
+// WithUploadRevision sets the branch the upload commits to
+func WithUploadRevision(revision string) UploadOption {
+	return func(config *UploadConfig) error {
+		config.Revision = revision
+		return nil
+	}
+}
+
+// WithUploadRepoType sets the repository type for the upload
+func WithUploadRepoType(repoType string) UploadOption {
+	return func(config *UploadConfig) error {
+		config.RepoType = repoType
+		return nil
+	}
+}
+
+// WithUploadToken sets the authentication token for the upload
+func WithUploadToken(token string) UploadOption {
+	return func(config *UploadConfig) error {
+		config.Token = token
+		return nil
+	}
+}
+
+// WithCommitMessage sets the summary and description of the commit
+func WithCommitMessage(message, description string) UploadOption {
+	return func(config *UploadConfig) error {
+		config.CommitMessage = message
+		config.CommitDescription = description
+		return nil
+	}
+}
+
+// WithParentCommit makes the commit fail if the revision moved past the given commit hash
+func WithParentCommit(commitHash string) UploadOption {
+	return func(config *UploadConfig) error {
+		if !IsCommitHash(commitHash) {
+			return fmt.Errorf("invalid parent commit %q: expected a 40 character commit hash", commitHash)
+		}
+		config.ParentCommit = commitHash
+		return nil
+	}
+}
+
+// WithCreatePR opens a pull request with the commit instead of committing to the revision
+func WithCreatePR(createPR bool) UploadOption {
+	return func(config *UploadConfig) error {
+		config.CreatePR = createPR
+		return nil
+	}
+}
+
+// WithPathInRepo sets the folder of the repository a folder upload is uploaded to
+func WithPathInRepo(pathInRepo string) UploadOption {
+	return func(config *UploadConfig) error {
+		config.PathInRepo = pathInRepo
+		return nil
+	}
+}
+
+// WithUploadPatterns sets allow and ignore patterns for filtering the files of a folder upload
+func WithUploadPatterns(allowPatterns, ignorePatterns []string) UploadOption {
+	return func(config *UploadConfig) error {
+		config.AllowPatterns = allowPatterns
+		config.IgnorePatterns = ignorePatterns
+		return nil
+	}
+}
+
+// WithDeletePatterns deletes the remote files of a folder upload that match the patterns and are not uploaded
+func WithDeletePatterns(deletePatterns []string) UploadOption {
+	return func(config *UploadConfig) error {
+		config.DeletePatterns = deletePatterns
+		return nil
+	}
+}
+
+// RepoOption represents an option for repository management operations
+type RepoOption func(*RepoConfig) error
+
+// WithManagedRepoType sets the type of the managed repository
+func WithManagedRepoType(repoType string) RepoOption {
+	return func(config *RepoConfig) error {
+		config.RepoType = repoType
+		return nil
+	}
+}
+
+// WithManagedRepoToken sets the authentication token for the repository management operation
+func WithManagedRepoToken(token string) RepoOption {
+	return func(config *RepoConfig) error {
+		config.Token = token
+		return nil
+	}
+}
+
+// WithPrivate creates a private repository
+func WithPrivate(private bool) RepoOption {
+	return func(config *RepoConfig) error {
+		config.Private = private
+		return nil
+	}
+}
+
+// WithSpaceSDK sets the SDK of a created space
+func WithSpaceSDK(sdk string) RepoOption {
+	return func(config *RepoConfig) error {
+		config.SpaceSDK = sdk
+		return nil
+	}
+}
+
+// WithExistOK makes the creation of a repository, branch or tag that already exists succeed
+func WithExistOK(existOK bool) RepoOption {
+	return func(config *RepoConfig) error {
+		config.ExistOK = existOK
+		return nil
+	}
+}
+
+// WithMissingOK makes the deletion of a repository that doesn't exist succeed
+func WithMissingOK(missingOK bool) RepoOption {
+	return func(config *RepoConfig) error {
+		config.MissingOK = missingOK
+		return nil
+	}
+}
+
+// WithRefRevision sets the revision a created branch starts from or a created tag points to
+func WithRefRevision(revision string) RepoOption {
+	return func(config *RepoConfig) error {
+		config.Revision = revision
+		return nil
+	}
+}
+
+// WithTagMessage annotates a created tag with a message
+func WithTagMessage(message string) RepoOption {
+	return func(config *RepoConfig) error {
+		config.TagMessage = message
+		return nil
+	}
+}
+
 // Module provides the fx module for dependency injection
 var Module = fx.Provide(
 	func(v *viper.Viper, params HubClientParams) (*HubClient, error) {
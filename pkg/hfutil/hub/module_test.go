@@ -204,6 +204,11 @@ func TestDownloadOptions(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, RepoTypeDataset, config.RepoType)
 
+	// Test WithLocalDir
+	err = WithLocalDir("/tmp/model")(config)
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/model", config.LocalDir)
+
 	// Test WithForceDownload
 	err = WithForceDownload(true)(config)
 	assert.NoError(t, err)
@@ -0,0 +1,184 @@
+package hub
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Requests of all the download workers share their rate limit: when the Hub rate limits one request, every request
+// to the same host is paused until the limit resets, instead of each worker retrying on its own and extending the
+// limit. Responses announcing an exhausted quota pause the requests before the Hub rejects them.
+
+// RateLimitEvent describes a pause of the requests to a host caused by its rate limit
+type RateLimitEvent struct {
+	// Host is the host whose requests are paused
+	Host string
+	// URL is the URL of the request whose response caused the pause
+	URL string
+	// StatusCode is the status of the response, http.StatusTooManyRequests unless the quota is exhausted
+	StatusCode int
+	// Wait is how long the requests are paused
+	Wait time.Duration
+	// Remaining and Limit are the quota of the rate limit headers, -1 when unknown
+	Remaining int
+	Limit     int
+}
+
+// rateLimitScheduler pauses the requests to the hosts that are rate limited
+type rateLimitScheduler struct {
+	mu          sync.Mutex
+	pausedUntil map[string]time.Time
+}
+
+// rateLimits is shared by all clients, like the HTTP transport
+var rateLimits = &rateLimitScheduler{pausedUntil: map[string]time.Time{}}
+
+// wait blocks until the requests to the host are no longer paused. Waiters are released with a jitter of up to a
+// tenth of the remaining pause, so that they don't all hit the host at the same time.
+func (s *rateLimitScheduler) wait(ctx context.Context, host string) error {
+	for {
+		s.mu.Lock()
+		remaining := time.Until(s.pausedUntil[host])
+		s.mu.Unlock()
+		if remaining <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(remaining + rand.N(remaining/10+1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// pause pauses the requests to the host for d, unless they are already paused for longer
+func (s *rateLimitScheduler) pause(host string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until := time.Now().Add(d); until.After(s.pausedUntil[host]) {
+		s.pausedUntil[host] = until
+	}
+}
+
+// rateLimitInfo is the rate limit state of a response
+type rateLimitInfo struct {
+	retryAfter time.Duration
+	remaining  int
+	limit      int
+	reset      time.Duration
+}
+
+// parseRateLimit parses the Retry-After header along with the RateLimit header of the IETF draft used by the Hub,
+// e.g. "api";r=0;t=120, and the X-RateLimit-* headers
+func parseRateLimit(resp *http.Response) rateLimitInfo {
+	info := rateLimitInfo{retryAfter: parseRetryAfter(resp), remaining: -1, limit: -1}
+
+	if header := resp.Header.Get("RateLimit"); header != "" {
+		for _, param := range strings.Split(header, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "r":
+				info.remaining = n
+			case "t":
+				info.reset = time.Duration(n) * time.Second
+			}
+		}
+	}
+	if n, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil && info.remaining < 0 {
+		info.remaining = n
+	}
+	if n, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		info.limit = n
+	}
+	if n, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && info.reset == 0 {
+		// The reset is either a delay in seconds or a Unix timestamp
+		if n > 1_000_000_000 {
+			info.reset = time.Until(time.Unix(n, 0))
+		} else {
+			info.reset = time.Duration(n) * time.Second
+		}
+	}
+	return info
+}
+
+// observe pauses the requests to the host of a rate limited response, or of a response announcing an exhausted
+// quota, and reports the pause in the logs, the metrics and to the rate limit handler
+func (s *rateLimitScheduler) observe(resp *http.Response) {
+	info := parseRateLimit(resp)
+	var wait time.Duration
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait = info.retryAfter
+		if wait <= 0 {
+			wait = info.reset
+		}
+		if wait <= 0 {
+			wait = DefaultRateLimitPause
+		}
+	case info.remaining == 0 && info.reset > 0:
+		wait = info.reset
+	default:
+		return
+	}
+
+	host := resp.Request.URL.Host
+	s.pause(host, wait)
+
+	event := RateLimitEvent{
+		Host:       host,
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Wait:       wait,
+		Remaining:  info.remaining,
+		Limit:      info.limit,
+	}
+	if hubConfig, ok := resp.Request.Context().Value(HubConfigKey).(*HubConfig); ok {
+		if hubConfig.Logger != nil {
+			hubConfig.Logger.
+				WithField("host", event.Host).
+				WithField("status", event.StatusCode).
+				WithField("wait", event.Wait.String()).
+				WithField("remaining", event.Remaining).
+				WithField("limit", event.Limit).
+				Warn("Hub rate limit reached, pausing all requests to the host")
+		}
+		hubConfig.Metrics.observeRateLimit(event)
+		if hubConfig.OnRateLimit != nil {
+			hubConfig.OnRateLimit(event)
+		}
+	}
+}
+
+// rateLimitedTransport sends requests once the rate limit of their host allows it and records the rate limit of
+// the responses
+type rateLimitedTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rateLimits.wait(req.Context(), req.URL.Host); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	rateLimits.observe(resp)
+	return resp, nil
+}
@@ -0,0 +1,165 @@
+package hub
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected rateLimitInfo
+	}{
+		{
+			name:     "no headers",
+			expected: rateLimitInfo{remaining: -1, limit: -1},
+		},
+		{
+			name:     "retry after",
+			headers:  map[string]string{"Retry-After": "12"},
+			expected: rateLimitInfo{retryAfter: 12 * time.Second, remaining: -1, limit: -1},
+		},
+		{
+			name:     "IETF draft header",
+			headers:  map[string]string{"RateLimit": `"api";r=0;t=120`, "RateLimit-Policy": `"fixed window";"api";q=1000;w=300`},
+			expected: rateLimitInfo{remaining: 0, limit: -1, reset: 120 * time.Second},
+		},
+		{
+			name:     "x-ratelimit headers",
+			headers:  map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Limit": "500", "X-RateLimit-Reset": "60"},
+			expected: rateLimitInfo{remaining: 5, limit: 500, reset: 60 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			for key, value := range tt.headers {
+				resp.Header.Set(key, value)
+			}
+			assert.Equal(t, tt.expected, parseRateLimit(resp))
+		})
+	}
+
+	t.Run("x-ratelimit reset timestamp", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+		reset := parseRateLimit(resp).reset
+		assert.Greater(t, reset, 58*time.Second)
+		assert.LessOrEqual(t, reset, time.Minute)
+	})
+}
+
+// rateLimitedServer returns 429 to the first request and records when the requests are received
+func rateLimitedServer(t *testing.T, headers map[string]string, status int) (*httptest.Server, func() []time.Time) {
+	var mu sync.Mutex
+	var received []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, time.Now())
+		if len(received) == 1 {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(status)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() {
+		serverURL, _ := url.Parse(server.URL)
+		rateLimits.mu.Lock()
+		delete(rateLimits.pausedUntil, serverURL.Host)
+		rateLimits.mu.Unlock()
+	})
+	return server, func() []time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]time.Time(nil), received...)
+	}
+}
+
+func TestRateLimitPausesAllWorkers(t *testing.T) {
+	server, received := rateLimitedServer(t, map[string]string{"Retry-After": "1"}, http.StatusTooManyRequests)
+
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	var events []RateLimitEvent
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{
+		OnRateLimit: func(event RateLimitEvent) { events = append(events, event) },
+		Metrics:     metrics,
+	})
+	get := func() int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/models/org/model", nil)
+		require.NoError(t, err)
+		resp, err := NewHTTPClientWithTimeout(10 * time.Second).Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// The rate limited response pauses the requests of the other workers too
+	assert.Equal(t, http.StatusTooManyRequests, get())
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, http.StatusOK, get())
+		}()
+	}
+	wg.Wait()
+
+	times := received()
+	require.Len(t, times, 5)
+	for _, receivedAt := range times[1:] {
+		assert.GreaterOrEqual(t, receivedAt.Sub(times[0]), 900*time.Millisecond)
+	}
+	require.Len(t, events, 1)
+	assert.Equal(t, http.StatusTooManyRequests, events[0].StatusCode)
+	assert.Equal(t, time.Second, events[0].Wait)
+	host := events[0].Host
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.rateLimitPauses.WithLabelValues(host, "429")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.rateLimitWait.WithLabelValues(host)))
+}
+
+func TestRateLimitExhaustedQuota(t *testing.T) {
+	server, received := rateLimitedServer(t, map[string]string{"RateLimit": `"api";r=0;t=1`}, http.StatusOK)
+
+	client := NewHTTPClientWithTimeout(10 * time.Second)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// The request after the one that exhausted the quota waits for the quota to reset
+	times := received()
+	require.Len(t, times, 2)
+	assert.GreaterOrEqual(t, times[1].Sub(times[0]), 900*time.Millisecond)
+}
+
+func TestRateLimitWaitCancelled(t *testing.T) {
+	scheduler := &rateLimitScheduler{pausedUntil: map[string]time.Time{}}
+	scheduler.pause("huggingface.co", time.Hour)
+	scheduler.pause("huggingface.co", time.Second)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), scheduler.pausedUntil["huggingface.co"], time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, scheduler.wait(ctx, "huggingface.co"), context.DeadlineExceeded)
+	assert.NoError(t, scheduler.wait(context.Background(), "cdn.huggingface.co"))
+}
@@ -3,18 +3,32 @@ package hub
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 )
 
 // RepoFile represents a file in a repository
 type RepoFile struct {
-	Path string `json:"path"`
-	Size int64  `json:"size"`
-	Type string `json:"type"` // "file" or "directory"
+	Path string   `json:"path"`
+	Size int64    `json:"size"`
+	Type string   `json:"type"`          // "file" or "directory"
+	OID  string   `json:"oid,omitempty"` // Git object ID
+	LFS  *LFSInfo `json:"lfs,omitempty"`
+}
+
+// Etag returns the etag the Hub serves the file with, the SHA256 of LFS files and the Git object ID of others
+func (f RepoFile) Etag() string {
+	if f.LFS != nil {
+		return f.LFS.OID
+	}
+	return f.OID
 }
 
 // ListRepoFiles lists all files in a repository
@@ -22,7 +36,15 @@ func ListRepoFiles(ctx context.Context, config *DownloadConfig) ([]RepoFile, err
 	if config.RepoID == "" {
 		return nil, fmt.Errorf("repo_id cannot be empty")
 	}
+	if cacheOnly(ctx, config) {
+		return nil, NewOfflineModeIsEnabledError("Cannot list repository files since offline mode is enabled")
+	}
+
+	return withEndpointFallback(ctx, config, listRepoFilesFromEndpoint)
+}
 
+// listRepoFilesFromEndpoint lists all files in a repository of the endpoint of the download
+func listRepoFilesFromEndpoint(ctx context.Context, config *DownloadConfig) ([]RepoFile, error) {
 	// Set defaults
 	repoType := config.RepoType
 	if repoType == "" {
@@ -76,7 +98,10 @@ func ListRepoFiles(ctx context.Context, config *DownloadConfig) ([]RepoFile, err
 		}
 
 		// Use pooled client with timeout
-		client := NewHTTPClientWithTimeout(DefaultRequestTimeout)
+		client, err := newHTTPClient(ctx, DefaultRequestTimeout)
+		if err != nil {
+			return nil, err
+		}
 
 		resp, err := client.Do(req)
 		if err != nil {
@@ -163,20 +188,19 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 		return "", fmt.Errorf("local_dir must be specified for snapshot download")
 	}
 
-	// Get concurrency configuration from context (HubConfig)
-	var maxWorkers int = 4 // default
-	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
-		maxWorkers = hubConfig.MaxWorkers
-	}
-	// Use MaxWorkers from config if available
-	if config.MaxWorkers > 0 {
-		maxWorkers = config.MaxWorkers
+	// Offline snapshots are the files already in the local directory
+	if cacheOnly(ctx, config) {
+		return snapshotFromLocalDir(config)
 	}
 
-	// Check if progress is enabled
-	enableProgress := true
-	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
-		enableProgress = hubConfig.ShouldEnableProgress()
+	// Pin the snapshot to a commit when it is locked
+	revision := downloadRevision(config)
+	var lock *SnapshotLock
+	if config.LockFile != "" {
+		var err error
+		if config, lock, err = pinSnapshot(ctx, config); err != nil {
+			return "", err
+		}
 	}
 
 	// List all files in the repository
@@ -187,7 +211,6 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 
 	// Filter files (exclude directories) and calculate totals
 	var filesToDownload []RepoFile
-	var totalSize int64
 	for _, file := range files {
 		if file.Type == "file" {
 			// Apply pattern filtering if specified
@@ -195,10 +218,64 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 				continue
 			}
 			filesToDownload = append(filesToDownload, file)
-			totalSize += file.Size
 		}
 	}
 
+	// The locked commit no longer matches the lockfile if its history was rewritten or the patterns changed
+	if lock != nil {
+		if changes := lock.Diff(filesToDownload); len(changes) > 0 {
+			return "", NewLockfileDriftError(config.LockFile, lock.Commit, changes)
+		}
+	}
+
+	// The local directory only holds a complete snapshot once every file is downloaded
+	statePath := filepath.Join(config.LocalDir, SnapshotStateFile)
+	if err := os.Remove(statePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to reset snapshot state: %w", err)
+	}
+
+	if err := downloadFiles(ctx, config, filesToDownload); err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		return config.LocalDir, err
+	}
+
+	snapshot := NewSnapshotLock(config, revision, config.Revision, filesToDownload)
+	if config.LockFile != "" && lock == nil {
+		if err := updateSnapshotLock(ctx, snapshot, config.LockFile); err != nil {
+			return config.LocalDir, err
+		}
+	}
+	if err := snapshot.Write(statePath); err != nil {
+		return config.LocalDir, fmt.Errorf("failed to write snapshot state: %w", err)
+	}
+
+	return config.LocalDir, nil
+}
+
+// downloadFiles downloads the files of a snapshot with a pool of workers, reporting the overall progress
+func downloadFiles(ctx context.Context, config *DownloadConfig, filesToDownload []RepoFile) (err error) {
+	// Get concurrency configuration from context (HubConfig)
+	var maxWorkers int = 4 // default
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
+		maxWorkers = hubConfig.MaxWorkers
+	}
+	// Use MaxWorkers from config if available
+	if config.MaxWorkers > 0 {
+		maxWorkers = config.MaxWorkers
+	}
+
+	// Check if progress is enabled
+	enableProgress := true
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
+		enableProgress = hubConfig.ShouldEnableProgress()
+	}
+
+	var totalSize int64
+	for _, file := range filesToDownload {
+		totalSize += file.Size
+	}
 	fileCount := len(filesToDownload)
 
 	// Create overall progress for snapshot download
@@ -206,6 +283,9 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 
 	// Track download timing
 	startTime := time.Now()
+	defer func() {
+		metricsFromContext(ctx).observeSnapshot(config.RepoID, time.Since(startTime), err)
+	}()
 
 	// Create channels for task distribution and result collection
 	taskChan := make(chan downloadTask, fileCount)
@@ -278,7 +358,7 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 
 	// Check if context was cancelled
 	if ctx.Err() != nil {
-		return "", ctx.Err()
+		return ctx.Err()
 	}
 
 	// Report results
@@ -307,12 +387,101 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 		}
 
 		// Return error with details but include the local directory
-		return config.LocalDir, fmt.Errorf("failed to download %d out of %d files", totalErrors, fileCount)
+		return fmt.Errorf("failed to download %d out of %d files", totalErrors, fileCount)
 	}
 
+	return nil
+}
+
+// updateSnapshotLock writes the lockfile of a snapshot download, logging how the snapshot changed since the
+// previous lockfile
+func updateSnapshotLock(ctx context.Context, lock *SnapshotLock, lockFile string) error {
+	if previous, err := ReadSnapshotLock(lockFile); err == nil && previous.Commit != lock.Commit {
+		if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil {
+			files := make([]RepoFile, 0, len(lock.Files))
+			for _, file := range lock.Files {
+				files = append(files, RepoFile{Path: file.Path, Size: file.Size, Type: "file", OID: file.Etag})
+			}
+			hubConfig.Logger.
+				WithField("lock_file", lockFile).
+				WithField("previous_commit", previous.Commit).
+				WithField("commit", lock.Commit).
+				WithField("changes", previous.Diff(files)).
+				Info("Updating snapshot lockfile")
+		}
+	}
+	return lock.Write(lockFile)
+}
+
+// snapshotFromLocalDir returns the local directory of an offline snapshot download after checking that it holds
+// the snapshot: every file of the lockfile of a frozen download, or else every file matching the patterns of the last
+// complete snapshot download or sync of the revision into the directory
+func snapshotFromLocalDir(config *DownloadConfig) (string, error) {
+	revision := downloadRevision(config)
+	location := "local directory " + config.LocalDir
+
+	if config.LockFile != "" && config.LockMode == LockModeFrozen {
+		lock, err := ReadSnapshotLock(config.LockFile)
+		if err != nil {
+			return "", err
+		}
+		var missing []string
+		for _, file := range lock.Files {
+			if size, err := GetFileSize(filepath.Join(config.LocalDir, file.Path)); err != nil || size != file.Size {
+				missing = append(missing, file.Path)
+			}
+		}
+		if len(missing) > 0 {
+			return "", NewOfflineEntryNotFoundError(config.RepoID, lock.Commit, missing, location)
+		}
+		return config.LocalDir, nil
+	}
+
+	// A directory without the state of a complete download may hold the files of an interrupted one
+	state := completedSnapshot(config, revision)
+	if state == nil {
+		return "", NewOfflineEntryNotFoundError(config.RepoID, revision, nil, location)
+	}
+	matched := 0
+	var missing []string
+	for _, file := range state.Files {
+		if ShouldIgnoreFile(file.Path, config.AllowPatterns, config.IgnorePatterns) {
+			continue
+		}
+		matched++
+		if size, err := GetFileSize(filepath.Join(config.LocalDir, file.Path)); err != nil || size != file.Size {
+			missing = append(missing, file.Path)
+		}
+	}
+	if matched == 0 || len(missing) > 0 {
+		return "", NewOfflineEntryNotFoundError(config.RepoID, revision, missing, location)
+	}
 	return config.LocalDir, nil
 }
 
+// completedSnapshot returns the state of the last complete snapshot download or sync of the revision into the local
+// directory, nil if there is none
+func completedSnapshot(config *DownloadConfig, revision string) *SnapshotLock {
+	for _, stateFile := range []string{SnapshotStateFile, SyncStateFile} {
+		data, err := os.ReadFile(filepath.Join(config.LocalDir, stateFile))
+		if err != nil {
+			continue
+		}
+		state := &SnapshotLock{}
+		if err := json.Unmarshal(data, state); err != nil || state.Version != SnapshotLockVersion {
+			continue
+		}
+		if state.RepoID != config.RepoID || state.RepoType != downloadRepoType(config) {
+			continue
+		}
+		if revision != state.Revision && revision != state.Commit {
+			continue
+		}
+		return state
+	}
+	return nil
+}
+
 // downloadWorker is a worker goroutine that processes download tasks
 func downloadWorker(ctx context.Context, workerID int, taskChan <-chan downloadTask, resultChan chan<- downloadResult) {
 	for {
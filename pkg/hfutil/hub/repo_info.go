@@ -0,0 +1,81 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetModelInfo returns the metadata of a model repository at config.Revision, or at its default branch if the
+// revision is empty. The siblings include the size and LFS digest of every file.
+func GetModelInfo(ctx context.Context, config *DownloadConfig) (*ModelInfo, error) {
+	info := &ModelInfo{}
+	if err := getRepoInfo(ctx, config, ApiModelsURL, RepoTypeModel, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetDatasetInfo returns the metadata of a dataset repository at config.Revision, or at its default branch if the
+// revision is empty. The siblings include the size and LFS digest of every file.
+func GetDatasetInfo(ctx context.Context, config *DownloadConfig) (*DatasetInfo, error) {
+	info := &DatasetInfo{}
+	if err := getRepoInfo(ctx, config, ApiDatasetsURL, RepoTypeDataset, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetRepoInfo returns the metadata shared by the repositories of every type
+func GetRepoInfo(ctx context.Context, config *DownloadConfig) (*RepoInfo, error) {
+	template := ApiModelsURL
+	switch downloadRepoType(config) {
+	case RepoTypeModel:
+	case RepoTypeDataset:
+		template = ApiDatasetsURL
+	case RepoTypeSpace:
+		template = ApiSpacesURL
+	default:
+		return nil, fmt.Errorf("invalid repo type: %s", config.RepoType)
+	}
+	info := &RepoInfo{}
+	if err := getRepoInfo(ctx, config, template, downloadRepoType(config), info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// getRepoInfo decodes the metadata of a repository from the repository API of the template
+func getRepoInfo(ctx context.Context, config *DownloadConfig, template, repoType string, info interface{}) error {
+	if config.RepoID == "" {
+		return fmt.Errorf("repo_id cannot be empty")
+	}
+	if cacheOnly(ctx, config) {
+		return NewOfflineModeIsEnabledError("Cannot get repository metadata since offline mode is enabled")
+	}
+
+	requestConfig := &UploadConfig{
+		RepoID:   config.RepoID,
+		RepoType: repoType,
+		Token:    config.Token,
+		Headers:  config.Headers,
+		Endpoint: config.Endpoint,
+	}
+	apiURL := fmt.Sprintf(template, uploadEndpoint(requestConfig), config.RepoID)
+	if config.Revision != "" {
+		apiURL += "/revision/" + url.PathEscape(config.Revision)
+	}
+	// blobs adds the size and LFS metadata of the siblings
+	apiURL += "?blobs=true"
+
+	if err := doHubJSONRequest(ctx, requestConfig, http.MethodGet, apiURL, "application/json", nil, info); err != nil {
+		var notFound *RepositoryNotFoundError
+		if errors.As(err, &notFound) && notFound.Response != nil && notFound.Response.Header.Get("X-Error-Code") == "RevisionNotFound" {
+			return NewRevisionNotFoundError(config.RepoID, repoType, config.Revision, notFound.Response)
+		}
+		return fmt.Errorf("failed to get the metadata of %s repository %s: %w", repoType, config.RepoID, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modelInfoResponse = `{
+	"_id": "621ffdc036468d709f174338",
+	"id": "org/model",
+	"author": "org",
+	"sha": "0123456789abcdef0123456789abcdef01234567",
+	"lastModified": "2024-09-25T14:30:00.000Z",
+	"private": false,
+	"disabled": false,
+	"gated": "manual",
+	"downloads": 1200,
+	"likes": 42,
+	"tags": ["transformers", "safetensors", "llama", "text-generation"],
+	"pipeline_tag": "text-generation",
+	"library_name": "transformers",
+	"cardData": {"license": "llama3.2"},
+	"config": {"architectures": ["LlamaForCausalLM"], "model_type": "llama"},
+	"safetensors": {"parameters": {"BF16": 1235814400}, "total": 1235814400},
+	"siblings": [
+		{"rfilename": "config.json", "blobId": "aaaa", "size": 877},
+		{"rfilename": "model.safetensors", "blobId": "bbbb", "size": 2471645608,
+		 "lfs": {"sha256": "cccc", "size": 2471645608, "pointerSize": 135}}
+	]
+}`
+
+func TestGetModelInfo(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		_, _ = w.Write([]byte(modelInfoResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	info, err := GetModelInfo(context.Background(), &DownloadConfig{RepoID: "org/model", Revision: "v1.0", Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/models/org/model/revision/v1.0?blobs=true"}, paths)
+
+	assert.Equal(t, "org/model", info.ID)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", *info.SHA)
+	assert.Equal(t, 2024, info.LastModified.Year())
+	assert.Equal(t, GatedManual, info.Gated)
+	assert.Contains(t, info.Tags, "text-generation")
+	assert.Equal(t, "llama3.2", info.CardData["license"])
+	assert.Equal(t, "llama", info.Config["model_type"])
+	assert.Equal(t, int64(1235814400), info.Safetensors.Total)
+	require.Len(t, info.Siblings, 2)
+	assert.Equal(t, int64(877), *info.Siblings[0].Size)
+	assert.Nil(t, info.Siblings[0].LFS)
+	assert.Equal(t, &SiblingLFSInfo{SHA256: "cccc", Size: 2471645608, PointerSize: 135}, info.Siblings[1].LFS)
+}
+
+func TestGetDatasetInfo(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": "org/data", "gated": false, "tags": ["task_categories:text-generation"], "paperswithcode_id": "squad", "siblings": [{"rfilename": "train.parquet", "size": 10}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	info, err := GetDatasetInfo(context.Background(), &DownloadConfig{RepoID: "org/data", Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/datasets/org/data"}, paths)
+	assert.Equal(t, GatedStatus(""), info.Gated)
+	assert.Equal(t, "squad", *info.PapersWithCodeID)
+	assert.Equal(t, "train.parquet", info.Siblings[0].RFilename)
+}
+
+func TestGetRepoInfoErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/models/org/model/revision/missing" {
+			w.Header().Set("X-Error-Code", "RevisionNotFound")
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "not found"}`))
+	}))
+	t.Cleanup(server.Close)
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{MaxRetries: 0})
+
+	_, err := GetRepoInfo(ctx, &DownloadConfig{RepoID: "org/missing", Endpoint: server.URL})
+	var notFound *RepositoryNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	_, err = GetModelInfo(ctx, &DownloadConfig{RepoID: "org/model", Revision: "missing", Endpoint: server.URL})
+	var revisionNotFound *RevisionNotFoundError
+	assert.ErrorAs(t, err, &revisionNotFound)
+
+	_, err = GetRepoInfo(ctx, &DownloadConfig{RepoID: "org/model", RepoType: "collection", Endpoint: server.URL})
+	assert.ErrorContains(t, err, "invalid repo type")
+	_, err = GetModelInfo(ctx, &DownloadConfig{Endpoint: server.URL})
+	assert.ErrorContains(t, err, "repo_id cannot be empty")
+
+	offlineCtx := context.WithValue(ctx, HubConfigKey, &HubConfig{EnableOfflineMode: true})
+	_, err = GetModelInfo(offlineCtx, &DownloadConfig{RepoID: "org/model", Endpoint: server.URL})
+	var offlineErr *OfflineModeIsEnabledError
+	assert.ErrorAs(t, err, &offlineErr)
+}
+
+func TestGatedStatusUnmarshal(t *testing.T) {
+	for data, expected := range map[string]GatedStatus{
+		`false`:  "",
+		`true`:   GatedAuto,
+		`null`:   "",
+		`"auto"`: GatedAuto,
+	} {
+		var gated GatedStatus
+		require.NoError(t, json.Unmarshal([]byte(data), &gated))
+		assert.Equal(t, expected, gated, data)
+	}
+	var gated GatedStatus
+	assert.Error(t, json.Unmarshal([]byte(`1`), &gated))
+}
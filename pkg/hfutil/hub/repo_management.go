@@ -0,0 +1,284 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SpaceSDKs are the SDKs a space can be created with
+var SpaceSDKs = []string{"gradio", "streamlit", "docker", "static"}
+
+// RepoConfig contains configuration for repository management operations
+type RepoConfig struct {
+	// Repository information
+	RepoID   string
+	RepoType string
+
+	// Authentication
+	Token string
+
+	// Network configuration
+	Headers  map[string]string
+	Endpoint string
+
+	// Private creates a private repository
+	Private bool
+	// SpaceSDK is the SDK of a created space, it is required for spaces
+	SpaceSDK string
+	// ExistOK makes the creation of a repository, branch or tag that already exists succeed
+	ExistOK bool
+	// MissingOK makes the deletion of a repository that doesn't exist succeed
+	MissingOK bool
+
+	// Revision is the revision a branch starts from or a tag points to, the default branch if empty
+	Revision string
+	// TagMessage is the message of an annotated tag
+	TagMessage string
+}
+
+// GitRef is a branch or tag of a repository
+type GitRef struct {
+	Name         string `json:"name"`
+	Ref          string `json:"ref"`
+	TargetCommit string `json:"targetCommit"`
+}
+
+// GitRefs contains the branches and tags of a repository
+type GitRefs struct {
+	Branches []GitRef `json:"branches"`
+	Tags     []GitRef `json:"tags"`
+}
+
+// CreateRepo creates a repository and returns its URL
+func CreateRepo(ctx context.Context, config *RepoConfig) (string, error) {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return "", err
+	}
+	namespace, name := splitRepoID(config.RepoID)
+	payload := map[string]interface{}{"name": name, "private": config.Private}
+	if namespace != "" {
+		payload["organization"] = namespace
+	}
+	if repoType := repoConfigType(config); repoType != RepoTypeModel {
+		payload["type"] = repoType
+	}
+	if repoConfigType(config) == RepoTypeSpace {
+		if !slices.Contains(SpaceSDKs, config.SpaceSDK) {
+			return "", NewValidationError("space_sdk", config.SpaceSDK, fmt.Sprintf("invalid space SDK, accepted SDKs are: %v", SpaceSDKs))
+		}
+		payload["sdk"] = config.SpaceSDK
+	}
+
+	var result struct {
+		URL string `json:"url"`
+	}
+	requestConfig := config.requestConfig()
+	err := doHubJSONRequest(ctx, requestConfig, http.MethodPost, fmt.Sprintf(ApiRepoCreateURL, uploadEndpoint(requestConfig)), "application/json", payload, &result)
+	if err != nil {
+		if !config.ExistOK || !isConflict(err) {
+			return "", fmt.Errorf("failed to create repository %s: %w", config.RepoID, err)
+		}
+		result.URL = repoURL(config)
+	}
+	return result.URL, nil
+}
+
+// DeleteRepo deletes a repository. This cannot be undone.
+func DeleteRepo(ctx context.Context, config *RepoConfig) error {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return err
+	}
+	namespace, name := splitRepoID(config.RepoID)
+	payload := map[string]interface{}{"name": name}
+	if namespace != "" {
+		payload["organization"] = namespace
+	}
+	if repoType := repoConfigType(config); repoType != RepoTypeModel {
+		payload["type"] = repoType
+	}
+
+	requestConfig := config.requestConfig()
+	err := doHubJSONRequest(ctx, requestConfig, http.MethodDelete, fmt.Sprintf(ApiRepoDeleteURL, uploadEndpoint(requestConfig)), "application/json", payload, nil)
+	var notFound *RepositoryNotFoundError
+	if err != nil && !(config.MissingOK && errors.As(err, &notFound)) {
+		return fmt.Errorf("failed to delete repository %s: %w", config.RepoID, err)
+	}
+	return nil
+}
+
+// UpdateRepoVisibility makes a repository private or public
+func UpdateRepoVisibility(ctx context.Context, config *RepoConfig, private bool) error {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return err
+	}
+	if err := doRepoRequest(ctx, config, http.MethodPut, ApiRepoSettingsURL, "", map[string]bool{"private": private}, nil); err != nil {
+		return fmt.Errorf("failed to update the visibility of repository %s: %w", config.RepoID, err)
+	}
+	return nil
+}
+
+// MoveRepo renames a repository or moves it to another namespace. Both repository ids include their namespace.
+func MoveRepo(ctx context.Context, config *RepoConfig, toRepoID string) error {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return err
+	}
+	for _, repoID := range []string{config.RepoID, toRepoID} {
+		if namespace, _ := splitRepoID(repoID); namespace == "" {
+			return NewValidationError("repo_id", repoID, "repository ids of a move must have the form namespace/name")
+		}
+	}
+
+	payload := map[string]string{"fromRepo": config.RepoID, "toRepo": toRepoID, "type": repoConfigType(config)}
+	requestConfig := config.requestConfig()
+	if err := doHubJSONRequest(ctx, requestConfig, http.MethodPost, fmt.Sprintf(ApiRepoMoveURL, uploadEndpoint(requestConfig)), "application/json", payload, nil); err != nil {
+		return fmt.Errorf("failed to move repository %s to %s: %w", config.RepoID, toRepoID, err)
+	}
+	return nil
+}
+
+// ListRepoRefs lists the branches and tags of a repository
+func ListRepoRefs(ctx context.Context, config *RepoConfig) (*GitRefs, error) {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return nil, err
+	}
+	refs := &GitRefs{}
+	if err := doRepoRequest(ctx, config, http.MethodGet, ApiRepoRefsURL, "", nil, refs); err != nil {
+		return nil, fmt.Errorf("failed to list the refs of repository %s: %w", config.RepoID, err)
+	}
+	return refs, nil
+}
+
+// CreateBranch creates a branch from config.Revision, or from the default branch if it is empty
+func CreateBranch(ctx context.Context, config *RepoConfig, branch string) error {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return err
+	}
+	if branch == "" {
+		return NewValidationError("branch", branch, "branch cannot be empty")
+	}
+	// The branch starts from the default branch if the request has no body
+	var payload interface{}
+	if config.Revision != "" {
+		payload = map[string]string{"startingPoint": config.Revision}
+	}
+	err := doRepoRequest(ctx, config, http.MethodPost, ApiRepoBranchURL, branch, payload, nil)
+	if err != nil && !(config.ExistOK && isConflict(err)) {
+		return fmt.Errorf("failed to create branch %s in repository %s: %w", branch, config.RepoID, err)
+	}
+	return nil
+}
+
+// DeleteBranch deletes a branch
+func DeleteBranch(ctx context.Context, config *RepoConfig, branch string) error {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return err
+	}
+	if branch == "" {
+		return NewValidationError("branch", branch, "branch cannot be empty")
+	}
+	if err := doRepoRequest(ctx, config, http.MethodDelete, ApiRepoBranchURL, branch, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete branch %s in repository %s: %w", branch, config.RepoID, err)
+	}
+	return nil
+}
+
+// CreateTag tags config.Revision, or the default branch if it is empty. The tag is annotated with
+// config.TagMessage if it is set.
+func CreateTag(ctx context.Context, config *RepoConfig, tag string) error {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return err
+	}
+	if tag == "" {
+		return NewValidationError("tag", tag, "tag cannot be empty")
+	}
+	revision := config.Revision
+	if revision == "" {
+		revision = DefaultRevision
+	}
+	payload := map[string]string{"tag": tag}
+	if config.TagMessage != "" {
+		payload["message"] = config.TagMessage
+	}
+	err := doRepoRequest(ctx, config, http.MethodPost, ApiRepoTagURL, revision, payload, nil)
+	if err != nil && !(config.ExistOK && isConflict(err)) {
+		return fmt.Errorf("failed to create tag %s in repository %s: %w", tag, config.RepoID, err)
+	}
+	return nil
+}
+
+// DeleteTag deletes a tag
+func DeleteTag(ctx context.Context, config *RepoConfig, tag string) error {
+	if err := validateRepoConfig(ctx, config); err != nil {
+		return err
+	}
+	if tag == "" {
+		return NewValidationError("tag", tag, "tag cannot be empty")
+	}
+	if err := doRepoRequest(ctx, config, http.MethodDelete, ApiRepoTagURL, tag, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete tag %s in repository %s: %w", tag, config.RepoID, err)
+	}
+	return nil
+}
+
+// validateRepoConfig checks the repository of a management operation and that the Hub can be reached
+func validateRepoConfig(ctx context.Context, config *RepoConfig) error {
+	return validateUploadConfig(ctx, config.requestConfig())
+}
+
+// requestConfig returns the configuration of the Hub API requests of the operation
+func (c *RepoConfig) requestConfig() *UploadConfig {
+	return &UploadConfig{
+		RepoID:   c.RepoID,
+		RepoType: c.RepoType,
+		Token:    c.Token,
+		Headers:  c.Headers,
+		Endpoint: c.Endpoint,
+	}
+}
+
+// doRepoRequest sends a request to a repository API. The template takes the endpoint, repository type and
+// id, followed by ref if it is not empty.
+func doRepoRequest(ctx context.Context, config *RepoConfig, method, template, ref string, payload, result interface{}) error {
+	requestConfig := config.requestConfig()
+	args := []interface{}{uploadEndpoint(requestConfig), uploadRepoType(requestConfig), config.RepoID}
+	if ref != "" {
+		args = append(args, url.PathEscape(ref))
+	}
+	return doHubJSONRequest(ctx, requestConfig, method, fmt.Sprintf(template, args...), "application/json", payload, result)
+}
+
+// repoConfigType returns the repository type of the operation
+func repoConfigType(config *RepoConfig) string {
+	if config.RepoType == "" {
+		return RepoTypeModel
+	}
+	return config.RepoType
+}
+
+// repoURL returns the URL of a repository on the Hub
+func repoURL(config *RepoConfig) string {
+	endpoint := uploadEndpoint(config.requestConfig())
+	if repoType := repoConfigType(config); repoType != RepoTypeModel {
+		return fmt.Sprintf("%s/%ss/%s", endpoint, repoType, config.RepoID)
+	}
+	return fmt.Sprintf("%s/%s", endpoint, config.RepoID)
+}
+
+// splitRepoID splits a repository id into its namespace, empty for the namespace of the token, and name
+func splitRepoID(repoID string) (string, string) {
+	if namespace, name, ok := strings.Cut(repoID, "/"); ok {
+		return namespace, name
+	}
+	return "", repoID
+}
+
+// isConflict tells whether err is the conflict the Hub returns for repositories, branches and tags that exist
+func isConflict(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict
+}
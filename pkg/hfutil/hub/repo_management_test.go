@@ -0,0 +1,236 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedRequest is a request received by the fake Hub of the repository management tests
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// newRepoServer starts a fake Hub that records the requests and answers them with the given status and body
+func newRepoServer(t *testing.T, status int, response string) (*httptest.Server, *[]recordedRequest) {
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		request := recordedRequest{Method: r.Method, Path: r.URL.EscapedPath()}
+		if len(body) > 0 {
+			require.NoError(t, json.Unmarshal(body, &request.Body))
+		}
+		requests = append(requests, request)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestRepoManagement(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		run      func(ctx context.Context, config *RepoConfig) error
+		expected recordedRequest
+	}{
+		{
+			name:     "create private model",
+			response: `{"url": "https://huggingface.co/org/model"}`,
+			run: func(ctx context.Context, config *RepoConfig) error {
+				config.Private = true
+				url, err := CreateRepo(ctx, config)
+				assert.Equal(t, "https://huggingface.co/org/model", url)
+				return err
+			},
+			expected: recordedRequest{
+				Method: http.MethodPost,
+				Path:   "/api/repos/create",
+				Body:   map[string]interface{}{"name": "model", "organization": "org", "private": true},
+			},
+		},
+		{
+			name: "create space",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				config.RepoType = RepoTypeSpace
+				config.SpaceSDK = "gradio"
+				_, err := CreateRepo(ctx, config)
+				return err
+			},
+			expected: recordedRequest{
+				Method: http.MethodPost,
+				Path:   "/api/repos/create",
+				Body:   map[string]interface{}{"name": "model", "organization": "org", "private": false, "type": "space", "sdk": "gradio"},
+			},
+		},
+		{
+			name: "delete dataset",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				config.RepoType = RepoTypeDataset
+				return DeleteRepo(ctx, config)
+			},
+			expected: recordedRequest{
+				Method: http.MethodDelete,
+				Path:   "/api/repos/delete",
+				Body:   map[string]interface{}{"name": "model", "organization": "org", "type": "dataset"},
+			},
+		},
+		{
+			name: "update visibility",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				return UpdateRepoVisibility(ctx, config, false)
+			},
+			expected: recordedRequest{
+				Method: http.MethodPut,
+				Path:   "/api/models/org/model/settings",
+				Body:   map[string]interface{}{"private": false},
+			},
+		},
+		{
+			name: "move",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				return MoveRepo(ctx, config, "archive/model")
+			},
+			expected: recordedRequest{
+				Method: http.MethodPost,
+				Path:   "/api/repos/move",
+				Body:   map[string]interface{}{"fromRepo": "org/model", "toRepo": "archive/model", "type": "model"},
+			},
+		},
+		{
+			name: "create branch from default branch",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				return CreateBranch(ctx, config, "checkpoints")
+			},
+			expected: recordedRequest{Method: http.MethodPost, Path: "/api/models/org/model/branch/checkpoints"},
+		},
+		{
+			name: "create branch from revision",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				config.Revision = "v1.0"
+				return CreateBranch(ctx, config, "release/v1")
+			},
+			expected: recordedRequest{
+				Method: http.MethodPost,
+				Path:   "/api/models/org/model/branch/release%2Fv1",
+				Body:   map[string]interface{}{"startingPoint": "v1.0"},
+			},
+		},
+		{
+			name: "delete branch",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				return DeleteBranch(ctx, config, "checkpoints")
+			},
+			expected: recordedRequest{Method: http.MethodDelete, Path: "/api/models/org/model/branch/checkpoints"},
+		},
+		{
+			name: "create annotated tag",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				config.Revision = "checkpoints"
+				config.TagMessage = "Step 1000"
+				return CreateTag(ctx, config, "step-1000")
+			},
+			expected: recordedRequest{
+				Method: http.MethodPost,
+				Path:   "/api/models/org/model/tag/checkpoints",
+				Body:   map[string]interface{}{"tag": "step-1000", "message": "Step 1000"},
+			},
+		},
+		{
+			name: "delete tag",
+			run: func(ctx context.Context, config *RepoConfig) error {
+				return DeleteTag(ctx, config, "step-1000")
+			},
+			expected: recordedRequest{Method: http.MethodDelete, Path: "/api/models/org/model/tag/step-1000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := tt.response
+			if response == "" {
+				response = "{}"
+			}
+			server, requests := newRepoServer(t, http.StatusOK, response)
+			config := &RepoConfig{RepoID: "org/model", Token: "hf_write", Endpoint: server.URL}
+
+			require.NoError(t, tt.run(context.Background(), config))
+			require.Len(t, *requests, 1)
+			assert.Equal(t, tt.expected, (*requests)[0])
+		})
+	}
+}
+
+func TestListRepoRefs(t *testing.T) {
+	server, requests := newRepoServer(t, http.StatusOK, `{
+		"branches": [{"name": "main", "ref": "refs/heads/main", "targetCommit": "0123456789abcdef0123456789abcdef01234567"}],
+		"tags": [{"name": "v1.0", "ref": "refs/tags/v1.0", "targetCommit": "fedcba9876543210fedcba9876543210fedcba98"}],
+		"converts": []
+	}`)
+
+	refs, err := ListRepoRefs(context.Background(), &RepoConfig{RepoID: "org/model", Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, &GitRefs{
+		Branches: []GitRef{{Name: "main", Ref: "refs/heads/main", TargetCommit: "0123456789abcdef0123456789abcdef01234567"}},
+		Tags:     []GitRef{{Name: "v1.0", Ref: "refs/tags/v1.0", TargetCommit: "fedcba9876543210fedcba9876543210fedcba98"}},
+	}, refs)
+	assert.Equal(t, "/api/models/org/model/refs", (*requests)[0].Path)
+}
+
+func TestRepoManagementConflicts(t *testing.T) {
+	server, _ := newRepoServer(t, http.StatusConflict, `{"error": "You already created this model repo"}`)
+
+	config := &RepoConfig{RepoID: "org/model", Endpoint: server.URL}
+	_, err := CreateRepo(context.Background(), config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "You already created this model repo")
+	assert.Error(t, CreateBranch(context.Background(), config, "checkpoints"))
+	assert.Error(t, CreateTag(context.Background(), config, "v1.0"))
+
+	config.ExistOK = true
+	url, err := CreateRepo(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/org/model", url)
+	assert.NoError(t, CreateBranch(context.Background(), config, "checkpoints"))
+	assert.NoError(t, CreateTag(context.Background(), config, "v1.0"))
+}
+
+func TestDeleteRepoMissing(t *testing.T) {
+	server, _ := newRepoServer(t, http.StatusNotFound, `{"error": "Repository not found"}`)
+
+	config := &RepoConfig{RepoID: "org/model", Endpoint: server.URL}
+	err := DeleteRepo(context.Background(), config)
+	var notFound *RepositoryNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	config.MissingOK = true
+	assert.NoError(t, DeleteRepo(context.Background(), config))
+}
+
+func TestRepoManagementValidation(t *testing.T) {
+	server, requests := newRepoServer(t, http.StatusOK, "{}")
+	ctx := context.Background()
+
+	_, err := CreateRepo(ctx, &RepoConfig{Endpoint: server.URL})
+	assert.ErrorContains(t, err, "repo_id cannot be empty")
+	_, err = CreateRepo(ctx, &RepoConfig{RepoID: "org/space", RepoType: RepoTypeSpace, Endpoint: server.URL})
+	assert.ErrorContains(t, err, "invalid space SDK")
+	assert.ErrorContains(t, MoveRepo(ctx, &RepoConfig{RepoID: "org/model", Endpoint: server.URL}, "model"), "namespace/name")
+	assert.ErrorContains(t, CreateBranch(ctx, &RepoConfig{RepoID: "org/model", Endpoint: server.URL}, ""), "branch cannot be empty")
+	assert.ErrorContains(t, DeleteTag(ctx, &RepoConfig{RepoID: "org/model", Endpoint: server.URL}, ""), "tag cannot be empty")
+
+	offlineCtx := context.WithValue(ctx, HubConfigKey, &HubConfig{EnableOfflineMode: true})
+	assert.ErrorContains(t, DeleteRepo(offlineCtx, &RepoConfig{RepoID: "org/model", Endpoint: server.URL}), "offline mode")
+
+	assert.Empty(t, *requests)
+}
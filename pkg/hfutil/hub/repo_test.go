@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -190,6 +191,74 @@ func TestSnapshotDownloadValidation(t *testing.T) {
 	}
 }
 
+func TestSnapshotDownloadOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s in offline mode", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{EnableOfflineMode: true})
+
+	localDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(localDir, "original"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "config.json"), []byte("{}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "original", "consolidated.pth"), []byte("weights"), 0o644))
+
+	// The files of an interrupted download are not a snapshot
+	var notFound *LocalEntryNotFoundError
+	_, err := SnapshotDownload(ctx, &DownloadConfig{RepoID: "org/model", LocalDir: localDir, Endpoint: server.URL})
+	require.ErrorAs(t, err, &notFound)
+
+	files := []RepoFile{{Path: "config.json", Size: 2, Type: "file"}, {Path: "original/consolidated.pth", Size: 7, Type: "file"}}
+	require.NoError(t, NewSnapshotLock(&DownloadConfig{RepoID: "org/model"}, "main", "main", files).Write(filepath.Join(localDir, SnapshotStateFile)))
+	path, err := SnapshotDownload(ctx, &DownloadConfig{RepoID: "org/model", LocalDir: localDir, Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, localDir, path)
+
+	_, err = SnapshotDownload(ctx, &DownloadConfig{RepoID: "org/model", LocalDir: localDir, Endpoint: server.URL, AllowPatterns: []string{"*.safetensors"}})
+	require.ErrorAs(t, err, &notFound)
+	assert.Contains(t, err.Error(), "Cannot find the files of repository 'org/model'")
+
+	_, err = SnapshotDownload(ctx, &DownloadConfig{RepoID: "org/model", Revision: "v2", LocalDir: localDir, Endpoint: server.URL})
+	require.ErrorAs(t, err, &notFound)
+
+	// A file of the snapshot that is missing or truncated is reported
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "original", "consolidated.pth"), []byte("wei"), 0o644))
+	_, err = SnapshotDownload(ctx, &DownloadConfig{RepoID: "org/model", LocalDir: localDir, Endpoint: server.URL})
+	require.ErrorAs(t, err, &notFound)
+	assert.Contains(t, err.Error(), "original/consolidated.pth")
+	_, err = SnapshotDownload(ctx, &DownloadConfig{RepoID: "org/model", LocalDir: localDir, Endpoint: server.URL, AllowPatterns: []string{"*.json"}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "original", "consolidated.pth"), []byte("weights"), 0o644))
+
+	_, err = SnapshotDownload(ctx, &DownloadConfig{RepoID: "org/model", LocalDir: filepath.Join(localDir, "missing"), Endpoint: server.URL})
+	require.ErrorAs(t, err, &notFound)
+
+	// Frozen downloads check the files of the lockfile
+	lockFile := filepath.Join(t.TempDir(), "model.lock.json")
+	lock := &SnapshotLock{
+		Version:  SnapshotLockVersion,
+		RepoID:   "org/model",
+		RepoType: RepoTypeModel,
+		Revision: "main",
+		Commit:   "abc123def456789012345678901234567890abcd",
+		Files:    []LockedFile{{Path: "config.json", Size: 2}, {Path: "original/consolidated.pth", Size: 7}},
+	}
+	require.NoError(t, lock.Write(lockFile))
+	frozen := &DownloadConfig{RepoID: "org/model", LocalDir: localDir, Endpoint: server.URL, LockFile: lockFile, LockMode: LockModeFrozen}
+	_, err = SnapshotDownload(ctx, frozen)
+	require.NoError(t, err)
+
+	lock.Files = append(lock.Files, LockedFile{Path: "tokenizer.json", Size: 10})
+	require.NoError(t, lock.Write(lockFile))
+	_, err = SnapshotDownload(ctx, frozen)
+	require.ErrorAs(t, err, &notFound)
+	assert.Contains(t, err.Error(), "file 'tokenizer.json'")
+
+	_, err = ListRepoFiles(ctx, &DownloadConfig{RepoID: "org/model", Endpoint: server.URL})
+	var offlineErr *OfflineModeIsEnabledError
+	assert.ErrorAs(t, err, &offlineErr)
+}
+
 func TestFilterByPatterns(t *testing.T) {
 	files := []RepoFile{
 		{Path: "config.json", Type: "file"},
@@ -529,6 +598,8 @@ func TestSnapshotDownloadConcurrent(t *testing.T) {
 			filePath := filepath.Join(tmpDir, file.Path)
 			assert.True(t, FileExists(filePath), "File %s should exist", file.Path)
 		}
+		// The complete snapshot is recorded for offline downloads
+		assert.True(t, FileExists(filepath.Join(tmpDir, SnapshotStateFile)))
 	})
 
 	t.Run("partial failure handling", func(t *testing.T) {
@@ -589,6 +660,7 @@ func TestSnapshotDownloadConcurrent(t *testing.T) {
 		// Should return error but still provide the directory
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to download")
+		assert.False(t, FileExists(filepath.Join(tmpDir, SnapshotStateFile)))
 		assert.Equal(t, tmpDir, result)
 
 		// Verify successful files were downloaded
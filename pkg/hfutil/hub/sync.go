@@ -0,0 +1,190 @@
+package hub
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// A snapshot sync keeps a local directory in sync with a revision that moves, e.g. a branch an agent tracks. It
+// records the files it downloaded in the SyncStateFile of the local directory, so that the next sync only downloads
+// the files that were added or changed upstream since, and can delete the files that were removed upstream.
+
+// SyncResult describes how a snapshot sync changed the local directory
+type SyncResult struct {
+	// Commit is the commit the local directory is in sync with
+	Commit string
+	// Added and Updated are the files that were downloaded
+	Added   []string
+	Updated []string
+	// Removed are the files downloaded by a previous sync that were removed upstream. They are deleted from the
+	// local directory when config.DeleteRemovedFiles is set.
+	Removed []string
+	// Unchanged is the number of files that were already up to date
+	Unchanged int
+}
+
+// SyncSnapshot brings the local directory of the config in sync with the files of its revision that match its
+// patterns, downloading only the files that are new or changed
+func SyncSnapshot(ctx context.Context, config *DownloadConfig) (*SyncResult, error) {
+	if config.RepoID == "" {
+		return nil, fmt.Errorf("repo_id cannot be empty")
+	}
+	if config.LocalDir == "" {
+		return nil, fmt.Errorf("local_dir must be specified for snapshot sync")
+	}
+	if cacheOnly(ctx, config) {
+		return nil, NewOfflineModeIsEnabledError("Cannot sync snapshot since offline mode is enabled")
+	}
+
+	revision := downloadRevision(config)
+	commit, err := resolveRevision(ctx, config, revision)
+	if err != nil {
+		return nil, err
+	}
+	pinned := *config
+	pinned.Revision = commit
+	files, err := ListRepoFiles(ctx, &pinned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %w", err)
+	}
+	files = FilterByPatterns(files, config.AllowPatterns, config.IgnorePatterns)
+
+	statePath := filepath.Join(config.LocalDir, SyncStateFile)
+	previous := readSyncState(ctx, &pinned, statePath)
+
+	synced := map[string]LockedFile{}
+	if previous != nil {
+		for _, file := range previous.Files {
+			synced[file.Path] = file
+		}
+	}
+
+	result := &SyncResult{Commit: commit}
+	var changed []RepoFile
+	upstream := make(map[string]bool, len(files))
+	for _, file := range files {
+		upstream[file.Path] = true
+		switch syncedFile(config.LocalDir, file, synced) {
+		case syncUnchanged:
+			result.Unchanged++
+		case syncAdded:
+			result.Added = append(result.Added, file.Path)
+			changed = append(changed, file)
+		case syncUpdated:
+			result.Updated = append(result.Updated, file.Path)
+			changed = append(changed, file)
+		}
+	}
+
+	// Files whose content changed may keep their size, so they are downloaded again whatever is on disk
+	if len(changed) > 0 {
+		pinned.ForceDownload = true
+		if err := downloadFiles(ctx, &pinned, changed); err != nil {
+			return nil, err
+		}
+	}
+
+	if previous != nil {
+		for _, file := range previous.Files {
+			if upstream[file.Path] {
+				continue
+			}
+			result.Removed = append(result.Removed, file.Path)
+			if config.DeleteRemovedFiles {
+				if err := os.Remove(filepath.Join(config.LocalDir, file.Path)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return nil, fmt.Errorf("failed to delete removed file %s: %w", file.Path, err)
+				}
+			}
+		}
+	}
+
+	if err := NewSnapshotLock(config, revision, commit, files).Write(statePath); err != nil {
+		return nil, fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return result, nil
+}
+
+// syncStatus is how a file of the revision differs from the local directory
+type syncStatus int
+
+const (
+	syncUnchanged syncStatus = iota
+	syncAdded
+	syncUpdated
+)
+
+// syncedFile compares a file of the revision with the local directory. Files recorded by the previous sync are
+// compared with their recorded etag, the other local files with their content.
+func syncedFile(localDir string, file RepoFile, synced map[string]LockedFile) syncStatus {
+	localPath := filepath.Join(localDir, file.Path)
+	size, err := GetFileSize(localPath)
+	if err != nil {
+		return syncAdded
+	}
+	if size != file.Size {
+		return syncUpdated
+	}
+	if locked, ok := synced[file.Path]; ok {
+		if locked.Etag == file.Etag() && locked.Size == file.Size {
+			return syncUnchanged
+		}
+		return syncUpdated
+	}
+
+	// The etag of LFS files is the SHA256 of their content, and the one of regular files their git blob id
+	if file.LFS != nil {
+		if VerifyChecksum(localPath, file.LFS.OID) == nil {
+			return syncUnchanged
+		}
+	} else if oid, err := gitBlobOID(localPath); err == nil && oid == file.OID {
+		return syncUnchanged
+	}
+	return syncUpdated
+}
+
+// readSyncState returns the state of the previous sync of the repository into the local directory, nil if there is
+// none. Unreadable states are ignored, so that every file is compared with its content.
+func readSyncState(ctx context.Context, config *DownloadConfig, statePath string) *SnapshotLock {
+	state, err := ReadSnapshotLock(statePath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil {
+				hubConfig.Logger.WithField("state", statePath).WithError(err).Warn("Ignoring unreadable sync state")
+			}
+		}
+		return nil
+	}
+	// Files synced from another repository are not ours to delete
+	if state.RepoID != config.RepoID || state.RepoType != downloadRepoType(config) {
+		return nil
+	}
+	return state
+}
+
+// gitBlobOID returns the git blob id of a file
+func gitBlobOID(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha1.New() // #nosec G401 -- git object ids are SHA-1
+	hash.Write([]byte("blob " + strconv.FormatInt(info.Size(), 10) + "\x00"))
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
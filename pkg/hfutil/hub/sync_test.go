@@ -0,0 +1,127 @@
+package hub
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSnapshot(t *testing.T) {
+	history := &fakeRepoHistory{
+		head: lockedCommit,
+		contents: map[string]map[string]string{
+			lockedCommit: {
+				"config.json":       `{"model_type": "llama"}`,
+				"model.safetensors": "weights v1",
+				"tokenizer.json":    `{"version": "1.0"}`,
+				"README.md":         "# Model",
+			},
+		},
+	}
+	server := newFakeRepoHistory(t, history)
+	localDir := t.TempDir()
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{MaxWorkers: 2, MaxRetries: 0})
+	config := &DownloadConfig{
+		RepoID:             "org/model",
+		Revision:           "main",
+		Endpoint:           server.URL,
+		LocalDir:           localDir,
+		AllowPatterns:      []string{"*.json", "*.safetensors"},
+		DeleteRemovedFiles: true,
+	}
+
+	// The first sync downloads every file matching the patterns
+	result, err := SyncSnapshot(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, lockedCommit, result.Commit)
+	assert.ElementsMatch(t, []string{"config.json", "model.safetensors", "tokenizer.json"}, result.Added)
+	assert.Empty(t, result.Updated)
+	assert.Empty(t, result.Removed)
+	assert.Zero(t, result.Unchanged)
+	assert.NoFileExists(t, filepath.Join(localDir, "README.md"))
+
+	// main moves: a file changes, one is removed and one is added
+	history.mu.Lock()
+	history.head = currentCommit
+	history.contents[currentCommit] = map[string]string{
+		"config.json":            `{"model_type": "llama"}`,
+		"model.safetensors":      "weights v2, retrained",
+		"generation_config.json": `{"do_sample": true}`,
+		"README.md":              "# Model v2",
+	}
+	history.mu.Unlock()
+
+	result, err = SyncSnapshot(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, currentCommit, result.Commit)
+	assert.Equal(t, []string{"generation_config.json"}, result.Added)
+	assert.Equal(t, []string{"model.safetensors"}, result.Updated)
+	assert.Equal(t, []string{"tokenizer.json"}, result.Removed)
+	assert.Equal(t, 1, result.Unchanged)
+
+	content, err := os.ReadFile(filepath.Join(localDir, "model.safetensors"))
+	require.NoError(t, err)
+	assert.Equal(t, "weights v2, retrained", string(content))
+	assert.FileExists(t, filepath.Join(localDir, "generation_config.json"))
+	assert.NoFileExists(t, filepath.Join(localDir, "tokenizer.json"))
+
+	state, err := ReadSnapshotLock(filepath.Join(localDir, SyncStateFile))
+	require.NoError(t, err)
+	assert.Equal(t, currentCommit, state.Commit)
+	assert.Len(t, state.Files, 3)
+
+	// Nothing changed upstream: nothing is downloaded
+	result, err = SyncSnapshot(ctx, config)
+	require.NoError(t, err)
+	assert.Empty(t, result.Added)
+	assert.Empty(t, result.Updated)
+	assert.Empty(t, result.Removed)
+	assert.Equal(t, 3, result.Unchanged)
+}
+
+func TestSyncSnapshotKeepsRemovedFiles(t *testing.T) {
+	history := &fakeRepoHistory{
+		head: lockedCommit,
+		contents: map[string]map[string]string{
+			lockedCommit: {"config.json": `{"model_type": "llama"}`, "old.json": `{"old": true}`},
+		},
+	}
+	server := newFakeRepoHistory(t, history)
+	localDir := t.TempDir()
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{MaxWorkers: 1, MaxRetries: 0})
+	config := &DownloadConfig{RepoID: "org/model", Revision: "main", Endpoint: server.URL, LocalDir: localDir}
+
+	_, err := SyncSnapshot(ctx, config)
+	require.NoError(t, err)
+
+	history.mu.Lock()
+	history.head = currentCommit
+	history.contents[currentCommit] = map[string]string{"config.json": `{"model_type": "llama"}`}
+	history.mu.Unlock()
+
+	result, err := SyncSnapshot(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old.json"}, result.Removed)
+	assert.FileExists(t, filepath.Join(localDir, "old.json"))
+}
+
+func TestSyncSnapshotOfflineMode(t *testing.T) {
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{EnableOfflineMode: true})
+	_, err := SyncSnapshot(ctx, &DownloadConfig{RepoID: "org/model", LocalDir: t.TempDir()})
+	var offlineErr *OfflineModeIsEnabledError
+	assert.ErrorAs(t, err, &offlineErr)
+}
+
+func TestGitBlobOID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello world\n"), 0644))
+
+	// git hash-object hello.txt
+	oid, err := gitBlobOID(path)
+	require.NoError(t, err)
+	assert.Equal(t, "3b18e512dba79e4c8300dd08aeb37f8e728b8dad", oid)
+}
@@ -0,0 +1,134 @@
+package hub
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Hub configuration with a token file, e.g. a projected Kubernetes Secret, reads its token from the file instead
+// of holding it, so that rotating the token doesn't require restarting long-running agents. The file is checked on
+// every request and read again when it changes: requests still carrying a previous token of the file, e.g. the files
+// of a snapshot download started before the rotation, are sent with the current one.
+
+// maxRotatedTokens is the number of previous tokens of a token file that are replaced in the requests
+const maxRotatedTokens = 4
+
+// tokenFile is a token read from a file, read again when the file changes
+type tokenFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	token   string
+	loaded  bool
+	// rotated are the previous tokens of the file, from the oldest
+	rotated []string
+}
+
+// tokenFiles are the token files read by all clients, by path
+var tokenFiles sync.Map
+
+// getTokenFile returns the shared token file of a path
+func getTokenFile(path string) *tokenFile {
+	file, _ := tokenFiles.LoadOrStore(path, &tokenFile{path: path})
+	return file.(*tokenFile)
+}
+
+// ReadTokenFile returns the token of a token file, read again only when the file changed since it was last read
+func ReadTokenFile(path string) (string, error) {
+	return getTokenFile(path).current()
+}
+
+// current returns the token of the file
+func (f *tokenFile) current() (string, error) {
+	// Stat follows the symlinks that kubelet swaps when a Secret is updated
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.loaded && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", f.path)
+	}
+	if f.loaded && token != f.token {
+		f.rotated = append(f.rotated, f.token)
+		if len(f.rotated) > maxRotatedTokens {
+			f.rotated = f.rotated[1:]
+		}
+	}
+	f.token = token
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.loaded = true
+	return token, nil
+}
+
+// isRotated tells whether a token is a previous token of the file
+func (f *tokenFile) isRotated(token string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Contains(f.rotated, token)
+}
+
+// GetToken returns the token of the Hub requests: the token of the token file when it is set and readable, or else
+// the token
+func (c *HubConfig) GetToken() string {
+	if c.TokenFile == "" {
+		return c.Token
+	}
+	token, err := ReadTokenFile(c.TokenFile)
+	if err != nil {
+		if c.Logger != nil && !errors.Is(err, fs.ErrNotExist) {
+			c.Logger.WithField("token_file", c.TokenFile).WithError(err).Warn("Falling back to the configured token")
+		}
+		return c.Token
+	}
+	return token
+}
+
+// tokenFileTransport sends the requests carrying a previous token of the token file of their Hub configuration with
+// its current token
+type tokenFileTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *tokenFileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	hubConfig, ok := req.Context().Value(HubConfigKey).(*HubConfig)
+	if !ok || hubConfig.TokenFile == "" {
+		return t.base.RoundTrip(req)
+	}
+	token, found := strings.CutPrefix(req.Header.Get(AuthorizationHeader), "Bearer ")
+	if !found {
+		return t.base.RoundTrip(req)
+	}
+
+	file := getTokenFile(hubConfig.TokenFile)
+	current, err := file.current()
+	// Tokens that don't come from the file, e.g. the token of a download option, are sent as is
+	if err != nil || current == token || !file.isRotated(token) {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(AuthorizationHeader, "Bearer "+current)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,101 @@
+package hub
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("hf_first\n"), 0o600))
+
+	token, err := ReadTokenFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hf_first", token)
+
+	// Rotation is picked up as soon as the file changes
+	require.NoError(t, os.WriteFile(path, []byte("hf_second_token\n"), 0o600))
+	token, err = ReadTokenFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hf_second_token", token)
+	assert.True(t, getTokenFile(path).isRotated("hf_first"))
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+	_, err = ReadTokenFile(path)
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = ReadTokenFile(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to read token file")
+}
+
+func TestHubConfigTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("hf_first\n"), 0o600))
+
+	config, err := NewHubConfig(WithTokenFile(path), WithToken("hf_static"))
+	require.NoError(t, err)
+	// An explicit token replaces the token file
+	assert.Empty(t, config.TokenFile)
+	assert.Equal(t, "hf_static", config.GetToken())
+
+	config, err = NewHubConfig(WithToken("hf_static"), WithTokenFile(path))
+	require.NoError(t, err)
+	assert.Equal(t, "hf_first", config.GetToken())
+	assert.Equal(t, "hf_first", config.ToDownloadConfig().Token)
+	assert.Equal(t, "Bearer hf_first", config.ToDownloadConfig().Headers[AuthorizationHeader])
+	assert.Equal(t, "hf_first", config.ToUploadConfig().Token)
+
+	// A missing token file means no token of the file
+	config.TokenFile = filepath.Join(t.TempDir(), "missing")
+	assert.Equal(t, "hf_static", config.GetToken())
+	assert.NoError(t, config.ValidateConfig())
+
+	// An unreadable one is invalid
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+	config.TokenFile = path
+	assert.Equal(t, "hf_static", config.GetToken())
+	assert.ErrorContains(t, config.ValidateConfig(), "is empty")
+
+	_, err = NewHubConfig(WithTokenFile(""))
+	assert.ErrorContains(t, err, "token file cannot be empty")
+}
+
+func TestTokenFileTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("hf_first\n"), 0o600))
+
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get(AuthorizationHeader))
+	}))
+	defer server.Close()
+
+	hubConfig := &HubConfig{TokenFile: path}
+	ctx := context.WithValue(context.Background(), HubConfigKey, hubConfig)
+	client := NewHTTPClientWithTimeout(DefaultRequestTimeout)
+	send := func(token string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set(AuthorizationHeader, "Bearer "+token)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// A download started before the rotation carries the previous token
+	token := hubConfig.GetToken()
+	send(token)
+	require.NoError(t, os.WriteFile(path, []byte("hf_rotated_token\n"), 0o600))
+	send(token)
+	// Tokens that don't come from the file are sent as is
+	send("hf_download_option")
+
+	assert.Equal(t, []string{"Bearer hf_first", "Bearer hf_rotated_token", "Bearer hf_download_option"}, authorizations)
+}
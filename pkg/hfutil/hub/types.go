@@ -1,6 +1,8 @@
 package hub
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -60,16 +62,74 @@ type RepoInfo struct {
 	PipelineTag  *string       `json:"pipeline_tag,omitempty"`
 	LibraryName  *string       `json:"library_name,omitempty"`
 	ModelType    *string       `json:"model_type,omitempty"`
-	Gated        *string       `json:"gated,omitempty"` // "auto", "manual", or false
+	Gated        GatedStatus   `json:"gated,omitempty"` // "auto", "manual", or empty if not gated
 	Siblings     []RepoSibling `json:"siblings,omitempty"`
 }
 
+// GatedStatus is the approval mode of the access requests of a gated repository, empty if it isn't gated
+type GatedStatus string
+
+const (
+	GatedAuto   GatedStatus = "auto"
+	GatedManual GatedStatus = "manual"
+)
+
+// UnmarshalJSON decodes the gated status of the Hub, which is false for repositories that aren't gated
+func (g *GatedStatus) UnmarshalJSON(data []byte) error {
+	var gated interface{}
+	if err := json.Unmarshal(data, &gated); err != nil {
+		return err
+	}
+	switch value := gated.(type) {
+	case string:
+		*g = GatedStatus(value)
+	case bool:
+		*g = ""
+		if value {
+			*g = GatedAuto
+		}
+	case nil:
+		*g = ""
+	default:
+		return fmt.Errorf("invalid gated status %s", data)
+	}
+	return nil
+}
+
 // RepoSibling contains basic information about a file in a repository
 type RepoSibling struct {
-	RFilename string   `json:"rfilename"`        // Relative filename
-	Size      *int64   `json:"size,omitempty"`   // File size in bytes
-	BlobID    *string  `json:"blobId,omitempty"` // Git object ID
-	LFS       *LFSInfo `json:"lfs,omitempty"`    // LFS metadata if applicable
+	RFilename string          `json:"rfilename"`        // Relative filename
+	Size      *int64          `json:"size,omitempty"`   // File size in bytes
+	BlobID    *string         `json:"blobId,omitempty"` // Git object ID
+	LFS       *SiblingLFSInfo `json:"lfs,omitempty"`    // LFS metadata if applicable
+}
+
+// SiblingLFSInfo contains the LFS metadata of a file of the repository metadata
+type SiblingLFSInfo struct {
+	SHA256      string `json:"sha256"`      // SHA256 hash of the file
+	Size        int64  `json:"size"`        // Size in bytes
+	PointerSize int    `json:"pointerSize"` // Size of the LFS pointer file
+}
+
+// ModelInfo contains the metadata of a model repository
+type ModelInfo struct {
+	RepoInfo
+	CardData    map[string]interface{} `json:"cardData,omitempty"` // Metadata of the model card
+	Config      map[string]interface{} `json:"config,omitempty"`   // Subset of config.json, e.g. the architectures
+	Safetensors *SafetensorsInfo       `json:"safetensors,omitempty"`
+}
+
+// SafetensorsInfo contains the parameter counts of the safetensors weights of a model
+type SafetensorsInfo struct {
+	Parameters map[string]int64 `json:"parameters"` // Parameter count by dtype
+	Total      int64            `json:"total"`
+}
+
+// DatasetInfo contains the metadata of a dataset repository
+type DatasetInfo struct {
+	RepoInfo
+	CardData         map[string]interface{} `json:"cardData,omitempty"` // Metadata of the dataset card
+	PapersWithCodeID *string                `json:"paperswithcode_id,omitempty"`
 }
 
 // DownloadConfig contains configuration for downloads
@@ -105,6 +165,13 @@ type DownloadConfig struct {
 	// Pattern filtering (for snapshots)
 	AllowPatterns  []string
 	IgnorePatterns []string
+
+	// Lockfile of the snapshot (for snapshots), see LockModeUpdate and LockModeFrozen
+	LockFile string
+	LockMode string
+
+	// DeleteRemovedFiles deletes the files a sync downloaded that were removed upstream (for snapshot syncs)
+	DeleteRemovedFiles bool
 }
 
 // SnapshotDownloadResult contains the result of a snapshot download
@@ -114,9 +114,9 @@ func TestRepoInfo(t *testing.T) {
 			RFilename: "model.bin",
 			Size:      int64Ptr(1024 * 1024),
 			BlobID:    stringPtr("blob456"),
-			LFS: &LFSInfo{
-				OID:  "sha256:def789",
-				Size: 1024 * 1024,
+			LFS: &SiblingLFSInfo{
+				SHA256: "def789",
+				Size:   1024 * 1024,
 			},
 		},
 	}
@@ -135,7 +135,7 @@ func TestRepoInfo(t *testing.T) {
 		PipelineTag:  stringPtr("text-generation"),
 		LibraryName:  stringPtr("transformers"),
 		ModelType:    stringPtr("gpt2"),
-		Gated:        stringPtr("false"),
+		Gated:        GatedManual,
 		Siblings:     siblings,
 	}
 
@@ -151,7 +151,7 @@ func TestRepoInfo(t *testing.T) {
 	assert.Equal(t, "text-generation", *repo.PipelineTag)
 	assert.Equal(t, "transformers", *repo.LibraryName)
 	assert.Equal(t, "gpt2", *repo.ModelType)
-	assert.Equal(t, "false", *repo.Gated)
+	assert.Equal(t, GatedManual, repo.Gated)
 	assert.Len(t, repo.Siblings, 2)
 	assert.Equal(t, "config.json", repo.Siblings[0].RFilename)
 	assert.Equal(t, "model.bin", repo.Siblings[1].RFilename)
@@ -177,9 +177,9 @@ func TestRepoSibling(t *testing.T) {
 				RFilename: "model.bin",
 				Size:      int64Ptr(1024 * 1024),
 				BlobID:    stringPtr("blob456"),
-				LFS: &LFSInfo{
-					OID:  "sha256:abc123",
-					Size: 1024 * 1024,
+				LFS: &SiblingLFSInfo{
+					SHA256: "abc123",
+					Size:   1024 * 1024,
 				},
 			},
 		},
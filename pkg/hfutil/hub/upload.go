@@ -0,0 +1,874 @@
+package hub
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Uploads follow the commit API of huggingface_hub: the files to add are announced with a preupload request
+// that tells which of them the Hub stores with Git LFS, their content is uploaded with the Git LFS batch API,
+// and a single commit then references the LFS objects and inlines the content of the regular files.
+
+// CommitOperation is a change made by a commit, either a CommitOperationAdd or a CommitOperationDelete
+type CommitOperation interface {
+	pathInRepo() string
+}
+
+// CommitOperationAdd adds a file to the repository or replaces it
+type CommitOperationAdd struct {
+	// PathInRepo is the path of the file in the repository
+	PathInRepo string
+	// LocalPath is the local file to upload, Content is uploaded instead if it is empty
+	LocalPath string
+	Content   []byte
+}
+
+func (op *CommitOperationAdd) pathInRepo() string { return op.PathInRepo }
+
+// CommitOperationDelete deletes a file from the repository, or a folder if PathInRepo ends with a slash
+type CommitOperationDelete struct {
+	PathInRepo string
+}
+
+func (op *CommitOperationDelete) pathInRepo() string { return op.PathInRepo }
+
+// CommitInfo contains information about a commit created on the Hub
+type CommitInfo struct {
+	CommitURL      string `json:"commitUrl"`
+	CommitOID      string `json:"commitOid"`
+	PullRequestURL string `json:"pullRequestUrl,omitempty"`
+	// IgnoredFiles are the added files the Hub ignored, e.g. because of the .gitignore of the repository
+	IgnoredFiles []string `json:"-"`
+}
+
+// UploadConfig contains configuration for uploads
+type UploadConfig struct {
+	// Repository information
+	RepoID   string
+	RepoType string
+	Revision string
+
+	// Authentication
+	Token string
+
+	// Network configuration
+	Headers  map[string]string
+	Endpoint string
+
+	// Commit information
+	CommitMessage     string
+	CommitDescription string
+	// ParentCommit makes the commit fail if the revision moved past this commit hash
+	ParentCommit string
+	// CreatePR opens a pull request with the commit instead of committing to the revision
+	CreatePR bool
+
+	// Concurrent LFS uploads
+	MaxWorkers int
+
+	// Folder uploads
+	PathInRepo     string
+	AllowPatterns  []string
+	IgnorePatterns []string
+	// DeletePatterns deletes the files under PathInRepo that match them and are not uploaded
+	DeletePatterns []string
+}
+
+// uploadFile is a file to add along with the information the Hub needs to upload it
+type uploadFile struct {
+	op         *CommitOperationAdd
+	size       int64
+	sha256     string
+	sample     []byte
+	uploadMode string
+	ignored    bool
+}
+
+// uploadReader reads the content of a file to upload, the LFS multipart upload reads it in parts
+type uploadReader interface {
+	io.Reader
+	io.ReaderAt
+	io.Closer
+}
+
+// contentReader reads the in-memory content of a file to upload
+type contentReader struct {
+	*bytes.Reader
+}
+
+func (contentReader) Close() error { return nil }
+
+// open returns a reader for the content to upload
+func (op *CommitOperationAdd) open() (uploadReader, error) {
+	if op.LocalPath == "" {
+		return contentReader{bytes.NewReader(op.Content)}, nil
+	}
+	return os.Open(op.LocalPath)
+}
+
+// CreateCommit creates a commit with the given operations, whose paths in the repository are normalized.
+// Files are uploaded with Git LFS or inlined in the commit as the Hub asks, LFS files the Hub already
+// stores are not uploaded again.
+func CreateCommit(ctx context.Context, config *UploadConfig, operations []CommitOperation) (*CommitInfo, error) {
+	if err := validateUploadConfig(ctx, config); err != nil {
+		return nil, err
+	}
+	if config.CommitMessage == "" {
+		return nil, NewValidationError("commit_message", config.CommitMessage, "commit message cannot be empty")
+	}
+	if len(operations) == 0 {
+		return nil, NewValidationError("operations", operations, "a commit needs at least one operation")
+	}
+
+	// The operations of the caller are left as they are, their paths are cleaned in copies
+	var files []*uploadFile
+	cleanOperations := make([]CommitOperation, 0, len(operations))
+	for _, operation := range operations {
+		cleanPath, err := validatePathInRepo(operation.pathInRepo())
+		if err != nil {
+			return nil, err
+		}
+		switch op := operation.(type) {
+		case *CommitOperationAdd:
+			add := *op
+			add.PathInRepo = cleanPath
+			file, err := prepareUploadFile(&add)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+			cleanOperations = append(cleanOperations, &add)
+		case *CommitOperationDelete:
+			cleanOperations = append(cleanOperations, &CommitOperationDelete{PathInRepo: cleanPath})
+		default:
+			return nil, fmt.Errorf("unsupported commit operation %T", operation)
+		}
+	}
+
+	if err := preuploadFiles(ctx, config, files); err != nil {
+		return nil, err
+	}
+	if err := uploadLFSFiles(ctx, config, files); err != nil {
+		return nil, err
+	}
+	return commit(ctx, config, cleanOperations, files)
+}
+
+// UploadFile uploads a local file to pathInRepo in a single commit
+func UploadFile(ctx context.Context, config *UploadConfig, localPath, pathInRepo string) (*CommitInfo, error) {
+	commitConfig := *config
+	if commitConfig.CommitMessage == "" {
+		commitConfig.CommitMessage = fmt.Sprintf("Upload %s with huggingface-hub-go", pathInRepo)
+	}
+	return CreateCommit(ctx, &commitConfig, []CommitOperation{
+		&CommitOperationAdd{PathInRepo: pathInRepo, LocalPath: localPath},
+	})
+}
+
+// UploadFolder uploads the files of a local folder to config.PathInRepo in a single commit. Files are filtered
+// with the allow and ignore patterns of the config, .git folders are never uploaded.
+func UploadFolder(ctx context.Context, config *UploadConfig, folderPath string) (*CommitInfo, error) {
+	commitConfig := *config
+	if commitConfig.CommitMessage == "" {
+		commitConfig.CommitMessage = "Upload folder using huggingface-hub-go"
+	}
+	pathInRepo := strings.Trim(config.PathInRepo, "/")
+
+	var operations []CommitOperation
+	uploaded := make(map[string]bool)
+	err := filepath.WalkDir(folderPath, func(localPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(folderPath, localPath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if entry.IsDir() {
+			if entry.Name() == ".git" || relPath == ".cache/huggingface" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || ShouldIgnoreFile(relPath, config.AllowPatterns, config.IgnorePatterns) {
+			return nil
+		}
+		operations = append(operations, &CommitOperationAdd{PathInRepo: path.Join(pathInRepo, relPath), LocalPath: localPath})
+		uploaded[relPath] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list folder %s: %w", folderPath, err)
+	}
+
+	if len(config.DeletePatterns) > 0 {
+		deletes, err := deleteOperations(ctx, config, pathInRepo, uploaded)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, deletes...)
+	}
+	return CreateCommit(ctx, &commitConfig, operations)
+}
+
+// deleteOperations returns the deletions of the remote files under pathInRepo that match the delete patterns
+// of the config and are not part of the upload
+func deleteOperations(ctx context.Context, config *UploadConfig, pathInRepo string, uploaded map[string]bool) ([]CommitOperation, error) {
+	remoteFiles, err := ListRepoFiles(ctx, &DownloadConfig{
+		RepoID:   config.RepoID,
+		RepoType: config.RepoType,
+		Revision: config.Revision,
+		Token:    config.Token,
+		Headers:  config.Headers,
+		Endpoint: config.Endpoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files to delete: %w", err)
+	}
+
+	var operations []CommitOperation
+	for _, file := range remoteFiles {
+		if file.Type != "file" {
+			continue
+		}
+		relPath := file.Path
+		if pathInRepo != "" {
+			var ok bool
+			if relPath, ok = strings.CutPrefix(file.Path, pathInRepo+"/"); !ok {
+				continue
+			}
+		}
+		if !uploaded[relPath] && MatchesPattern(relPath, config.DeletePatterns) {
+			operations = append(operations, &CommitOperationDelete{PathInRepo: file.Path})
+		}
+	}
+	return operations, nil
+}
+
+// validateUploadConfig checks the repository of the upload and that the Hub can be reached
+func validateUploadConfig(ctx context.Context, config *UploadConfig) error {
+	if config.RepoID == "" {
+		return NewValidationError("repo_id", config.RepoID, "repo_id cannot be empty")
+	}
+	if config.RepoType != "" && !isValidRepoType(config.RepoType) {
+		return NewValidationError("repo_type", config.RepoType, fmt.Sprintf("invalid repo type, accepted types are: %v", RepoTypes))
+	}
+	if isOffline(ctx) {
+		return NewOfflineModeIsEnabledError("cannot upload files when offline mode is enabled")
+	}
+	return nil
+}
+
+// validatePathInRepo normalizes a path in the repository and rejects paths outside of it or inside .git
+func validatePathInRepo(pathInRepo string) (string, error) {
+	// "a/../../x" is only outside of the repository once cleaned
+	cleanPath := path.Clean(strings.TrimLeft(pathInRepo, "/"))
+	if cleanPath == "." || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return "", NewValidationError("path_in_repo", pathInRepo, "path must be a file or folder inside the repository")
+	}
+	for _, part := range strings.Split(cleanPath, "/") {
+		if part == ".git" {
+			return "", NewValidationError("path_in_repo", pathInRepo, "cannot commit to a .git folder")
+		}
+	}
+	// A trailing slash deletes a folder
+	if strings.HasSuffix(pathInRepo, "/") {
+		cleanPath += "/"
+	}
+	return cleanPath, nil
+}
+
+// prepareUploadFile computes the size, SHA256 and sample of a file to add
+func prepareUploadFile(op *CommitOperationAdd) (*uploadFile, error) {
+	reader, err := op.open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", op.PathInRepo, err)
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash %s: %w", op.PathInRepo, err)
+	}
+	sample := make([]byte, min(size, PreuploadSampleSize))
+	if _, err := reader.ReadAt(sample, 0); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read %s: %w", op.PathInRepo, err)
+	}
+
+	return &uploadFile{
+		op:     op,
+		size:   size,
+		sha256: hex.EncodeToString(hasher.Sum(nil)),
+		sample: sample,
+	}, nil
+}
+
+// preuploadFiles asks the Hub which files are stored with Git LFS and which ones it ignores
+func preuploadFiles(ctx context.Context, config *UploadConfig, files []*uploadFile) error {
+	type preuploadFile struct {
+		Path         string `json:"path"`
+		Sample       string `json:"sample,omitempty"`
+		Size         int64  `json:"size,omitempty"`
+		UploadMode   string `json:"uploadMode,omitempty"`
+		ShouldIgnore bool   `json:"shouldIgnore,omitempty"`
+	}
+	type preuploadPayload struct {
+		Files []preuploadFile `json:"files"`
+	}
+
+	apiURL := uploadAPIURL(ApiPreuploadURL, config)
+	for start := 0; start < len(files); start += PreuploadBatchSize {
+		batch := files[start:min(start+PreuploadBatchSize, len(files))]
+		payload := preuploadPayload{}
+		for _, file := range batch {
+			payload.Files = append(payload.Files, preuploadFile{
+				Path:   file.op.PathInRepo,
+				Sample: base64.StdEncoding.EncodeToString(file.sample),
+				Size:   file.size,
+			})
+		}
+
+		var result preuploadPayload
+		if err := doHubJSONRequest(ctx, config, http.MethodPost, apiURL, "application/json", payload, &result); err != nil {
+			return fmt.Errorf("preupload failed: %w", err)
+		}
+		modes := make(map[string]preuploadFile, len(result.Files))
+		for _, file := range result.Files {
+			modes[file.Path] = file
+		}
+		for _, file := range batch {
+			mode, ok := modes[file.op.PathInRepo]
+			if !ok {
+				return fmt.Errorf("preupload failed: no upload mode returned for %s", file.op.PathInRepo)
+			}
+			file.uploadMode = mode.UploadMode
+			file.ignored = mode.ShouldIgnore
+		}
+	}
+	return nil
+}
+
+// lfsAction is an action the Git LFS batch API asks the client to perform on an object
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+// lfsBatchObject is an object of a Git LFS batch request or response
+type lfsBatchObject struct {
+	OID     string `json:"oid"`
+	Size    int64  `json:"size"`
+	Actions *struct {
+		Upload *lfsAction `json:"upload,omitempty"`
+		Verify *lfsAction `json:"verify,omitempty"`
+	} `json:"actions,omitempty"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// uploadLFSFiles uploads the content of the LFS files the Hub doesn't store yet
+func uploadLFSFiles(ctx context.Context, config *UploadConfig, files []*uploadFile) error {
+	// Files with the same content are stored once
+	byOID := make(map[string]*uploadFile)
+	var objects []lfsBatchObject
+	for _, file := range files {
+		if file.ignored || file.uploadMode != UploadModeLFS {
+			continue
+		}
+		if _, ok := byOID[file.sha256]; !ok {
+			byOID[file.sha256] = file
+			objects = append(objects, lfsBatchObject{OID: file.sha256, Size: file.size})
+		}
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	type lfsBatchRef struct {
+		Name string `json:"name"`
+	}
+	type lfsBatchRequest struct {
+		Operation string           `json:"operation"`
+		Transfers []string         `json:"transfers"`
+		Objects   []lfsBatchObject `json:"objects"`
+		HashAlgo  string           `json:"hash_algo"`
+		Ref       *lfsBatchRef     `json:"ref,omitempty"`
+	}
+	type lfsBatchResponse struct {
+		Objects []lfsBatchObject `json:"objects"`
+	}
+
+	request := lfsBatchRequest{
+		Operation: "upload",
+		Transfers: []string{"basic", "multipart"},
+		Objects:   objects,
+		HashAlgo:  "sha256",
+	}
+	if config.Revision != "" {
+		request.Ref = &lfsBatchRef{Name: config.Revision}
+	}
+	repoPath := config.RepoID
+	if prefix, ok := RepoTypesURLPrefixes[uploadRepoType(config)]; ok {
+		repoPath = prefix + repoPath
+	}
+	var response lfsBatchResponse
+	if err := doHubJSONRequest(ctx, config, http.MethodPost, fmt.Sprintf(LfsBatchURL, uploadEndpoint(config), repoPath), LfsContentType, request, &response); err != nil {
+		return fmt.Errorf("LFS batch request failed: %w", err)
+	}
+
+	var pending []lfsBatchObject
+	for _, object := range response.Objects {
+		if object.Error != nil {
+			return fmt.Errorf("LFS upload of %s refused: %d %s", byOID[object.OID].op.PathInRepo, object.Error.Code, object.Error.Message)
+		}
+		// Objects without an upload action are already stored on the Hub
+		if object.Actions != nil && object.Actions.Upload != nil {
+			pending = append(pending, object)
+		}
+	}
+
+	maxWorkers := config.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = DefaultMaxWorkers
+	}
+	enableProgress := true
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
+		enableProgress = hubConfig.ShouldEnableProgress()
+	}
+
+	// Upload the objects concurrently and report the first failure
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	semaphore := make(chan struct{}, maxWorkers)
+	for _, object := range pending {
+		file, ok := byOID[object.OID]
+		if !ok {
+			return fmt.Errorf("LFS batch response contains unknown object %s", object.OID)
+		}
+		wg.Add(1)
+		go func(object lfsBatchObject, file *uploadFile) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if err := uploadLFSObject(ctx, config, object, file, enableProgress); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to upload %s: %w", file.op.PathInRepo, err)
+				}
+				mu.Unlock()
+			}
+		}(object, file)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// uploadLFSObject uploads the content of a file in one request or in parts, then asks the Hub to verify it
+func uploadLFSObject(ctx context.Context, config *UploadConfig, object lfsBatchObject, file *uploadFile, enableProgress bool) error {
+	reader, err := file.op.open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	progress := NewProgress(fmt.Sprintf("Uploading %s", file.op.PathInRepo), file.size, enableProgress)
+	defer progress.Finish()
+
+	upload := object.Actions.Upload
+	if chunkSize, ok := upload.Header["chunk_size"]; ok {
+		err = uploadLFSMultipart(ctx, upload, chunkSize, object.OID, reader, file.size, progress)
+	} else {
+		err = uploadLFSPart(ctx, upload.Href, reader, 0, file.size, progress, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	if verify := object.Actions.Verify; verify != nil {
+		verifyConfig := *config
+		verifyConfig.Headers = mergeHeaders(config.Headers, verify.Header)
+		if err := doHubJSONRequest(ctx, &verifyConfig, http.MethodPost, verify.Href, LfsContentType, lfsBatchObject{OID: object.OID, Size: file.size}, nil); err != nil {
+			return fmt.Errorf("LFS verification failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// uploadLFSMultipart uploads the content in parts of chunkSize bytes to the part URLs of the upload action,
+// keyed by part number, and completes the upload with the ETags of the parts
+func uploadLFSMultipart(ctx context.Context, upload *lfsAction, chunkSize string, oid string, reader io.ReaderAt, size int64, progress Progress) error {
+	partSize, err := strconv.ParseInt(chunkSize, 10, 64)
+	if err != nil || partSize <= 0 {
+		return fmt.Errorf("invalid LFS chunk size %q", chunkSize)
+	}
+
+	var partNumbers []int
+	for key := range upload.Header {
+		if number, err := strconv.Atoi(key); err == nil {
+			partNumbers = append(partNumbers, number)
+		}
+	}
+	sort.Ints(partNumbers)
+	if expected := int((size + partSize - 1) / partSize); len(partNumbers) != expected {
+		return fmt.Errorf("LFS upload expects %d parts of %d bytes, got %d part URLs", expected, partSize, len(partNumbers))
+	}
+
+	type completedPart struct {
+		PartNumber int    `json:"partNumber"`
+		ETag       string `json:"etag"`
+	}
+	completion := struct {
+		OID   string          `json:"oid"`
+		Parts []completedPart `json:"parts"`
+	}{OID: oid}
+	for i, number := range partNumbers {
+		offset := int64(i) * partSize
+		var etag string
+		if err := uploadLFSPart(ctx, upload.Header[strconv.Itoa(number)], reader, offset, min(partSize, size-offset), progress, &etag); err != nil {
+			return fmt.Errorf("part %d: %w", number, err)
+		}
+		completion.Parts = append(completion.Parts, completedPart{PartNumber: number, ETag: etag})
+	}
+
+	body, err := json.Marshal(completion)
+	if err != nil {
+		return err
+	}
+	resp, err := doUploadRequest(ctx, DefaultRequestTimeout, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, upload.Href, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", LfsContentType)
+		req.Header.Set("Content-Type", LfsContentType)
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return NewHTTPError(fmt.Sprintf("failed to complete multipart upload: %s", hubErrorMessage(resp)), resp.StatusCode, resp)
+	}
+	return nil
+}
+
+// uploadLFSPart uploads length bytes of the content from offset to the presigned URL. The ETag of the
+// stored part is returned in etag if it is not nil.
+func uploadLFSPart(ctx context.Context, href string, reader io.ReaderAt, offset, length int64, progress Progress, etag *string) error {
+	// Progress is only reported by the first attempt, retries would count the uploaded bytes again
+	reportProgress := true
+	resp, err := doUploadRequest(ctx, UploadTimeout, func() (*http.Request, error) {
+		var body io.Reader = io.NewSectionReader(reader, offset, length)
+		if reportProgress {
+			reportProgress = false
+			body = io.TeeReader(body, NewSimpleProgressWriter(io.Discard, progress))
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, href, body)
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = length
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return NewHTTPError(fmt.Sprintf("upload failed: %s", hubErrorMessage(resp)), resp.StatusCode, resp)
+	}
+	if etag != nil {
+		*etag = resp.Header.Get("ETag")
+	}
+	return nil
+}
+
+// commit creates the commit that adds the uploaded files and applies the deletions
+func commit(ctx context.Context, config *UploadConfig, operations []CommitOperation, files []*uploadFile) (*CommitInfo, error) {
+	type commitLine struct {
+		Key   string      `json:"key"`
+		Value interface{} `json:"value"`
+	}
+	header := map[string]string{"summary": config.CommitMessage, "description": config.CommitDescription}
+	if config.ParentCommit != "" {
+		header["parentCommit"] = config.ParentCommit
+	}
+	lines := []commitLine{{Key: "header", Value: header}}
+
+	info := &CommitInfo{}
+	for _, file := range files {
+		switch {
+		case file.ignored:
+			info.IgnoredFiles = append(info.IgnoredFiles, file.op.PathInRepo)
+		case file.uploadMode == UploadModeLFS:
+			lines = append(lines, commitLine{Key: "lfsFile", Value: map[string]string{
+				"path": file.op.PathInRepo,
+				"algo": "sha256",
+				"oid":  file.sha256,
+			}})
+		default:
+			content, err := readUploadContent(file.op)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, commitLine{Key: "file", Value: map[string]string{
+				"content":  base64.StdEncoding.EncodeToString(content),
+				"path":     file.op.PathInRepo,
+				"encoding": "base64",
+			}})
+		}
+	}
+	for _, operation := range operations {
+		if op, ok := operation.(*CommitOperationDelete); ok {
+			key := "deletedFile"
+			if strings.HasSuffix(op.PathInRepo, "/") {
+				key = "deletedFolder"
+			}
+			lines = append(lines, commitLine{Key: key, Value: map[string]string{"path": op.PathInRepo}})
+		}
+	}
+	if len(lines) == 1 {
+		return nil, NewValidationError("operations", operations, "all files were ignored by the repository, nothing to commit")
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return nil, fmt.Errorf("failed to encode commit: %w", err)
+		}
+	}
+
+	resp, err := doUploadRequest(ctx, UploadTimeout, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadAPIURL(ApiCommitURL, config), bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+		setUploadHeaders(req, config)
+		req.Header.Set("Content-Type", NdjsonContentType)
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("commit failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("commit failed: %w", handleUploadHTTPError(resp, config))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(info); err != nil {
+		return nil, fmt.Errorf("failed to decode commit response: %w", err)
+	}
+	return info, nil
+}
+
+// readUploadContent reads the whole content of a regular file, which the commit inlines
+func readUploadContent(op *CommitOperationAdd) ([]byte, error) {
+	if op.LocalPath == "" {
+		return op.Content, nil
+	}
+	content, err := os.ReadFile(op.LocalPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", op.PathInRepo, err)
+	}
+	return content, nil
+}
+
+// uploadRepoType returns the repository type of the upload, models by default
+func uploadRepoType(config *UploadConfig) string {
+	if config.RepoType == "" {
+		return RepoTypeModel
+	}
+	return config.RepoType
+}
+
+// uploadEndpoint returns the Hub endpoint of the upload
+func uploadEndpoint(config *UploadConfig) string {
+	if config.Endpoint == "" {
+		return DefaultEndpoint
+	}
+	return config.Endpoint
+}
+
+// uploadAPIURL returns the URL of a repository API that takes a revision, with the pull request flag
+func uploadAPIURL(template string, config *UploadConfig) string {
+	revision := config.Revision
+	if revision == "" {
+		revision = DefaultRevision
+	}
+	apiURL := fmt.Sprintf(template, uploadEndpoint(config), uploadRepoType(config), config.RepoID, url.PathEscape(revision))
+	if config.CreatePR {
+		apiURL += "?create_pr=1"
+	}
+	return apiURL
+}
+
+// setUploadHeaders sets the authentication, user agent and extra headers of Hub API requests
+func setUploadHeaders(req *http.Request, config *UploadConfig) {
+	for k, v := range BuildHeaders(config.Token, "huggingface-hub-go/1.0.0", config.Headers) {
+		req.Header.Set(k, v)
+	}
+}
+
+// mergeHeaders returns the headers with the extra headers added
+func mergeHeaders(headers, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(headers)+len(extra))
+	for k, v := range headers {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// doHubJSONRequest sends payload as JSON of the content type to a Hub API, without a body if payload is nil,
+// and decodes the response into result if it is not nil. The Git LFS APIs require their own JSON media type.
+func doHubJSONRequest(ctx context.Context, config *UploadConfig, method, apiURL, contentType string, payload, result interface{}) error {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	resp, err := doUploadRequest(ctx, DefaultRequestTimeout, func() (*http.Request, error) {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, apiURL, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+		setUploadHeaders(req, config)
+		req.Header.Set("Accept", contentType)
+		if body != nil {
+			req.Header.Set("Content-Type", contentType)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return handleUploadHTTPError(resp, config)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// doUploadRequest performs the request built by newRequest, retrying network errors, rate limits and server
+// errors with exponential backoff like the downloads do. The caller closes the body of the response.
+func doUploadRequest(ctx context.Context, timeout time.Duration, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	maxRetries := 3                   // default
+	retryInterval := 10 * time.Second // default
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
+		maxRetries = hubConfig.MaxRetries
+		retryInterval = hubConfig.RetryInterval
+	}
+	client, err := newHTTPClient(ctx, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if attempt >= maxRetries {
+				return nil, fmt.Errorf("failed to perform request: %w", err)
+			}
+			delay = exponentialBackoffWithJitter(attempt+1, retryInterval, 60*time.Second)
+		case resp.StatusCode == http.StatusTooManyRequests && attempt < maxRetries:
+			delay = parseRetryAfter(resp)
+			if delay == 0 {
+				delay = exponentialBackoffWithJitter(attempt+1, retryInterval, 300*time.Second) // Max 5 minutes
+			}
+			resp.Body.Close()
+		case resp.StatusCode >= 500 && attempt < maxRetries:
+			delay = exponentialBackoffWithJitter(attempt+1, retryInterval, 60*time.Second)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// handleUploadHTTPError converts HTTP errors of upload requests to Hub errors with the message of the Hub
+func handleUploadHTTPError(resp *http.Response, config *UploadConfig) error {
+	message := hubErrorMessage(resp)
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusNotFound:
+		return NewRepositoryNotFoundError(config.RepoID, uploadRepoType(config), resp)
+	case http.StatusBadRequest:
+		return NewBadRequestError(message, resp)
+	case http.StatusForbidden:
+		return NewHTTPError(fmt.Sprintf("no write access to %s: %s", config.RepoID, message), resp.StatusCode, resp)
+	case http.StatusTooManyRequests:
+		return NewRateLimitError(resp, parseRetryAfter(resp))
+	default:
+		return NewHTTPError(message, resp.StatusCode, resp)
+	}
+}
+
+// hubErrorMessage returns the error message of a Hub response, from the X-Error-Message header or the JSON body
+func hubErrorMessage(resp *http.Response) string {
+	if message := resp.Header.Get("X-Error-Message"); message != "" {
+		return message
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil || len(body) == 0 {
+		return http.StatusText(resp.StatusCode)
+	}
+	var hubError struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &hubError) == nil && hubError.Error != "" {
+		return hubError.Error
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,432 @@
+package hub
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHub implements the preupload, Git LFS and commit APIs of the Hub for a single model repository
+type fakeHub struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu sync.Mutex
+	// lfsObjects holds the content of the LFS objects by SHA256, stored ones are not uploaded again
+	lfsObjects map[string][]byte
+	parts      map[string]map[string][]byte
+	verified   []string
+	// commitLines are the lines of the last commit
+	commitLines []map[string]interface{}
+	commitURL   string
+	// remoteFiles are listed by the tree API
+	remoteFiles []RepoFile
+	authHeaders []string
+}
+
+func newFakeHub(t *testing.T) *fakeHub {
+	hub := &fakeHub{t: t, lfsObjects: map[string][]byte{}, parts: map[string]map[string][]byte{}}
+	hub.server = httptest.NewServer(http.HandlerFunc(hub.handle))
+	t.Cleanup(hub.server.Close)
+	return hub
+}
+
+func (h *fakeHub) handle(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	body, err := io.ReadAll(r.Body)
+	require.NoError(h.t, err)
+
+	switch {
+	case r.URL.Path == "/api/models/org/model/preupload/main":
+		h.authHeaders = append(h.authHeaders, r.Header.Get(AuthorizationHeader))
+		var payload struct {
+			Files []struct {
+				Path   string `json:"path"`
+				Sample string `json:"sample"`
+				Size   int64  `json:"size"`
+			} `json:"files"`
+		}
+		require.NoError(h.t, json.Unmarshal(body, &payload))
+		var files []map[string]interface{}
+		for _, file := range payload.Files {
+			mode := UploadModeRegular
+			if strings.HasSuffix(file.Path, ".safetensors") {
+				mode = UploadModeLFS
+			}
+			files = append(files, map[string]interface{}{
+				"path":         file.Path,
+				"uploadMode":   mode,
+				"shouldIgnore": strings.HasPrefix(file.Path, "logs/"),
+			})
+		}
+		writeJSON(w, map[string]interface{}{"files": files})
+
+	case r.URL.Path == "/org/model.git/info/lfs/objects/batch":
+		assert.Equal(h.t, LfsContentType, r.Header.Get("Content-Type"))
+		var request struct {
+			Operation string           `json:"operation"`
+			Objects   []lfsBatchObject `json:"objects"`
+		}
+		require.NoError(h.t, json.Unmarshal(body, &request))
+		assert.Equal(h.t, "upload", request.Operation)
+		var objects []map[string]interface{}
+		for _, object := range request.Objects {
+			response := map[string]interface{}{"oid": object.OID, "size": object.Size}
+			if _, stored := h.lfsObjects[object.OID]; !stored {
+				upload := map[string]interface{}{"href": h.server.URL + "/upload/" + object.OID}
+				// Objects over 20 bytes are uploaded in parts of 16 bytes
+				if object.Size > 20 {
+					header := map[string]string{"chunk_size": "16"}
+					for part := int64(1); part <= (object.Size+15)/16; part++ {
+						header[fmt.Sprint(part)] = fmt.Sprintf("%s/parts/%s/%d", h.server.URL, object.OID, part)
+					}
+					upload = map[string]interface{}{"href": h.server.URL + "/complete/" + object.OID, "header": header}
+				}
+				response["actions"] = map[string]interface{}{
+					"upload": upload,
+					"verify": map[string]interface{}{"href": h.server.URL + "/verify/" + object.OID},
+				}
+			}
+			objects = append(objects, response)
+		}
+		writeJSON(w, map[string]interface{}{"objects": objects})
+
+	case strings.HasPrefix(r.URL.Path, "/upload/"):
+		h.lfsObjects[strings.TrimPrefix(r.URL.Path, "/upload/")] = body
+
+	case strings.HasPrefix(r.URL.Path, "/parts/"):
+		oidAndPart := strings.Split(strings.TrimPrefix(r.URL.Path, "/parts/"), "/")
+		if h.parts[oidAndPart[0]] == nil {
+			h.parts[oidAndPart[0]] = map[string][]byte{}
+		}
+		h.parts[oidAndPart[0]][oidAndPart[1]] = body
+		w.Header().Set("ETag", "etag-"+oidAndPart[1])
+
+	case strings.HasPrefix(r.URL.Path, "/complete/"):
+		oid := strings.TrimPrefix(r.URL.Path, "/complete/")
+		var completion struct {
+			Parts []struct {
+				PartNumber int    `json:"partNumber"`
+				ETag       string `json:"etag"`
+			} `json:"parts"`
+		}
+		require.NoError(h.t, json.Unmarshal(body, &completion))
+		var content []byte
+		for _, part := range completion.Parts {
+			assert.Equal(h.t, fmt.Sprintf("etag-%d", part.PartNumber), part.ETag)
+			content = append(content, h.parts[oid][fmt.Sprint(part.PartNumber)]...)
+		}
+		h.lfsObjects[oid] = content
+
+	case strings.HasPrefix(r.URL.Path, "/verify/"):
+		h.verified = append(h.verified, strings.TrimPrefix(r.URL.Path, "/verify/"))
+
+	case r.URL.Path == "/api/models/org/model/commit/main":
+		assert.Equal(h.t, NdjsonContentType, r.Header.Get("Content-Type"))
+		h.commitLines = nil
+		scanner := bufio.NewScanner(strings.NewReader(string(body)))
+		for scanner.Scan() {
+			var line map[string]interface{}
+			require.NoError(h.t, json.Unmarshal(scanner.Bytes(), &line))
+			h.commitLines = append(h.commitLines, line)
+		}
+		h.commitURL = r.URL.String()
+		writeJSON(w, map[string]interface{}{
+			"commitUrl":  h.server.URL + "/org/model/commit/0123456789abcdef0123456789abcdef01234567",
+			"commitOid":  "0123456789abcdef0123456789abcdef01234567",
+			"success":    true,
+			"unexpected": "fields are ignored",
+		})
+
+	case r.URL.Path == "/api/models/org/model/tree/main":
+		writeJSON(w, h.remoteFiles)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// commitFiles returns the value of the commit lines by key and path
+func (h *fakeHub) commitFiles() map[string]map[string]interface{} {
+	files := map[string]map[string]interface{}{}
+	for _, line := range h.commitLines[1:] {
+		value := line["value"].(map[string]interface{})
+		files[line["key"].(string)+":"+value["path"].(string)] = value
+	}
+	return files
+}
+
+func TestCreateCommit(t *testing.T) {
+	hub := newFakeHub(t)
+	storedContent := []byte("already stored")
+	hub.lfsObjects[sha256Hex(storedContent)] = storedContent
+
+	dir := t.TempDir()
+	smallWeights := []byte("small weights")
+	largeWeights := []byte(strings.Repeat("0123456789", 5))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "small.safetensors"), smallWeights, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "large.safetensors"), largeWeights, 0o644))
+
+	config := &UploadConfig{
+		RepoID:            "org/model",
+		Token:             "hf_write",
+		Endpoint:          hub.server.URL,
+		CommitMessage:     "Add checkpoint 100",
+		CommitDescription: "Step 100 of the finance fine tuning",
+		ParentCommit:      "fedcba9876543210fedcba9876543210fedcba98",
+	}
+	configAdd := &CommitOperationAdd{PathInRepo: "/config.json", Content: []byte(`{"model_type": "llama"}`)}
+	info, err := CreateCommit(context.Background(), config, []CommitOperation{
+		configAdd,
+		&CommitOperationAdd{PathInRepo: "checkpoint/small.safetensors", LocalPath: filepath.Join(dir, "small.safetensors")},
+		&CommitOperationAdd{PathInRepo: "checkpoint/large.safetensors", LocalPath: filepath.Join(dir, "large.safetensors")},
+		&CommitOperationAdd{PathInRepo: "checkpoint/stored.safetensors", Content: storedContent},
+		&CommitOperationAdd{PathInRepo: "logs/train.log", Content: []byte("loss 0.1")},
+		&CommitOperationDelete{PathInRepo: "checkpoint/old.safetensors"},
+		&CommitOperationDelete{PathInRepo: "checkpoint-50/"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", info.CommitOID)
+	assert.Equal(t, []string{"logs/train.log"}, info.IgnoredFiles)
+	// The operations of the caller are not modified
+	assert.Equal(t, "/config.json", configAdd.PathInRepo)
+	assert.Equal(t, []string{"Bearer hf_write"}, hub.authHeaders)
+
+	// LFS files are uploaded in one request or in parts and verified, stored ones are skipped
+	assert.Equal(t, smallWeights, hub.lfsObjects[sha256Hex(smallWeights)])
+	assert.Equal(t, largeWeights, hub.lfsObjects[sha256Hex(largeWeights)])
+	assert.Len(t, hub.parts[sha256Hex(largeWeights)], 4)
+	sort.Strings(hub.verified)
+	expectedVerified := []string{sha256Hex(smallWeights), sha256Hex(largeWeights)}
+	sort.Strings(expectedVerified)
+	assert.Equal(t, expectedVerified, hub.verified)
+
+	require.NotEmpty(t, hub.commitLines)
+	assert.Equal(t, map[string]interface{}{
+		"key": "header",
+		"value": map[string]interface{}{
+			"summary":      "Add checkpoint 100",
+			"description":  "Step 100 of the finance fine tuning",
+			"parentCommit": "fedcba9876543210fedcba9876543210fedcba98",
+		},
+	}, hub.commitLines[0])
+	assert.Equal(t, map[string]map[string]interface{}{
+		"file:config.json": {
+			"path":     "config.json",
+			"content":  base64.StdEncoding.EncodeToString([]byte(`{"model_type": "llama"}`)),
+			"encoding": "base64",
+		},
+		"lfsFile:checkpoint/small.safetensors":   {"path": "checkpoint/small.safetensors", "algo": "sha256", "oid": sha256Hex(smallWeights)},
+		"lfsFile:checkpoint/large.safetensors":   {"path": "checkpoint/large.safetensors", "algo": "sha256", "oid": sha256Hex(largeWeights)},
+		"lfsFile:checkpoint/stored.safetensors":  {"path": "checkpoint/stored.safetensors", "algo": "sha256", "oid": sha256Hex(storedContent)},
+		"deletedFile:checkpoint/old.safetensors": {"path": "checkpoint/old.safetensors"},
+		"deletedFolder:checkpoint-50/":           {"path": "checkpoint-50/"},
+	}, hub.commitFiles())
+}
+
+func TestCreateCommitPullRequest(t *testing.T) {
+	hub := newFakeHub(t)
+	_, err := CreateCommit(context.Background(), &UploadConfig{
+		RepoID:        "org/model",
+		Endpoint:      hub.server.URL,
+		CommitMessage: "Update config",
+		CreatePR:      true,
+	}, []CommitOperation{&CommitOperationAdd{PathInRepo: "config.json", Content: []byte("{}")}})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/models/org/model/commit/main?create_pr=1", hub.commitURL)
+}
+
+func TestCreateCommitErrors(t *testing.T) {
+	hub := newFakeHub(t)
+	add := []CommitOperation{&CommitOperationAdd{PathInRepo: "config.json", Content: []byte("{}")}}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		config     *UploadConfig
+		operations []CommitOperation
+		errMsg     string
+	}{
+		{
+			name:       "missing repo id",
+			config:     &UploadConfig{Endpoint: hub.server.URL, CommitMessage: "Upload"},
+			operations: add,
+			errMsg:     "repo_id cannot be empty",
+		},
+		{
+			name:       "missing commit message",
+			config:     &UploadConfig{RepoID: "org/model", Endpoint: hub.server.URL},
+			operations: add,
+			errMsg:     "commit message cannot be empty",
+		},
+		{
+			name:       "no operations",
+			config:     &UploadConfig{RepoID: "org/model", Endpoint: hub.server.URL, CommitMessage: "Upload"},
+			operations: nil,
+			errMsg:     "at least one operation",
+		},
+		{
+			name:       "path outside of the repository",
+			config:     &UploadConfig{RepoID: "org/model", Endpoint: hub.server.URL, CommitMessage: "Upload"},
+			operations: []CommitOperation{&CommitOperationAdd{PathInRepo: "../config.json", Content: []byte("{}")}},
+			errMsg:     "inside the repository",
+		},
+		{
+			name:       "path in .git",
+			config:     &UploadConfig{RepoID: "org/model", Endpoint: hub.server.URL, CommitMessage: "Upload"},
+			operations: []CommitOperation{&CommitOperationDelete{PathInRepo: "sub/.git/config"}},
+			errMsg:     ".git folder",
+		},
+		{
+			name:       "offline mode",
+			ctx:        context.WithValue(context.Background(), HubConfigKey, &HubConfig{EnableOfflineMode: true}),
+			config:     &UploadConfig{RepoID: "org/model", Endpoint: hub.server.URL, CommitMessage: "Upload"},
+			operations: add,
+			errMsg:     "offline mode",
+		},
+		{
+			name:       "unknown repository",
+			config:     &UploadConfig{RepoID: "org/missing", Endpoint: hub.server.URL, CommitMessage: "Upload"},
+			operations: add,
+			errMsg:     "Repository 'org/missing' not found",
+		},
+		{
+			name:       "only ignored files",
+			config:     &UploadConfig{RepoID: "org/model", Endpoint: hub.server.URL, CommitMessage: "Upload"},
+			operations: []CommitOperation{&CommitOperationAdd{PathInRepo: "logs/train.log", Content: []byte("loss 0.1")}},
+			errMsg:     "nothing to commit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			_, err := CreateCommit(ctx, tt.config, tt.operations)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	hub := newFakeHub(t)
+	localPath := filepath.Join(t.TempDir(), "adapter_config.json")
+	require.NoError(t, os.WriteFile(localPath, []byte(`{"r": 16}`), 0o644))
+
+	_, err := UploadFile(context.Background(), &UploadConfig{RepoID: "org/model", Endpoint: hub.server.URL}, localPath, "adapters/finance/adapter_config.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Upload adapters/finance/adapter_config.json with huggingface-hub-go", hub.commitLines[0]["value"].(map[string]interface{})["summary"])
+	assert.Contains(t, hub.commitFiles(), "file:adapters/finance/adapter_config.json")
+}
+
+func TestUploadFolder(t *testing.T) {
+	hub := newFakeHub(t)
+	hub.remoteFiles = []RepoFile{
+		{Path: "checkpoint", Type: "directory"},
+		{Path: "checkpoint/model-00001.safetensors", Type: "file"},
+		{Path: "checkpoint/model-00002.safetensors", Type: "file"},
+		{Path: "checkpoint/README.md", Type: "file"},
+		{Path: "other/model-00002.safetensors", Type: "file"},
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"model-00001.safetensors": "weights",
+		"config.json":             "{}",
+		"optimizer.pt":            "optimizer state",
+		".git/HEAD":               "ref: refs/heads/main",
+		"nested/tokenizer.json":   "{}",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	_, err := UploadFolder(context.Background(), &UploadConfig{
+		RepoID:         "org/model",
+		Endpoint:       hub.server.URL,
+		PathInRepo:     "checkpoint",
+		IgnorePatterns: []string{"*.pt"},
+		DeletePatterns: []string{"*.safetensors"},
+	}, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Upload folder using huggingface-hub-go", hub.commitLines[0]["value"].(map[string]interface{})["summary"])
+	files := hub.commitFiles()
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{
+		"lfsFile:checkpoint/model-00001.safetensors",
+		"file:checkpoint/config.json",
+		"file:checkpoint/nested/tokenizer.json",
+		"deletedFile:checkpoint/model-00002.safetensors",
+	}, keys)
+}
+
+func TestValidatePathInRepo(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{path: "config.json", expected: "config.json"},
+		{path: "/checkpoint/model.safetensors", expected: "checkpoint/model.safetensors"},
+		{path: "./config.json", expected: "config.json"},
+		{path: "checkpoint/", expected: "checkpoint/"},
+		{path: "", wantErr: true},
+		{path: "..", wantErr: true},
+		{path: "../config.json", wantErr: true},
+		{path: "a/../../config.json", wantErr: true},
+		{path: "a/../config.json", expected: "config.json"},
+		{path: "..config.json", expected: "..config.json"},
+		{path: "checkpoint//model.safetensors", expected: "checkpoint/model.safetensors"},
+		{path: "/", wantErr: true},
+		{path: "checkpoint/../.git/config", wantErr: true},
+		{path: ".git/config", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			cleanPath, err := validatePathInRepo(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cleanPath)
+		})
+	}
+}
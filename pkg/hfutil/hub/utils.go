@@ -282,20 +282,19 @@ func copyFile(src, dst string) error {
 
 // Pattern matching for allow/ignore patterns
 
-// MatchesPattern checks if a filename matches any of the given glob patterns
+// MatchesPattern checks if a filename matches any of the given glob patterns. Patterns follow the fnmatch
+// semantics of huggingface_hub: "*" also matches "/", so "*.bin" matches "onnx/model.bin", and a pattern
+// ending with "/" matches the files of that folder.
 func MatchesPattern(filename string, patterns []string) bool {
 	if len(patterns) == 0 {
 		return false
 	}
 
 	for _, pattern := range patterns {
-		matched, err := filepath.Match(pattern, filename)
-		if err == nil && matched {
-			return true
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "*"
 		}
-
-		// Also check if the pattern matches the path components
-		if strings.Contains(filename, pattern) {
+		if expr, err := globToRegexp(pattern); err == nil && expr.MatchString(filename) {
 			return true
 		}
 	}
@@ -303,6 +302,48 @@ func MatchesPattern(filename string, patterns []string) bool {
 	return false
 }
 
+// globToRegexp translates an fnmatch pattern to a regular expression matching whole paths
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		case '[':
+			// A character class ends at the next "]" that isn't its first character, an unclosed "[" is literal
+			end := i + 1
+			if end < len(pattern) && pattern[end] == '!' {
+				end++
+			}
+			if end < len(pattern) && pattern[end] == ']' {
+				end++
+			}
+			for end < len(pattern) && pattern[end] != ']' {
+				end++
+			}
+			if end >= len(pattern) {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := strings.ReplaceAll(pattern[i+1:end], `\`, `\\`)
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			} else if strings.HasPrefix(class, "^") {
+				class = `\` + class
+			}
+			expr.WriteString("[" + class + "]")
+			i = end
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
+
 // ShouldIgnoreFile determines if a file should be ignored based on patterns
 func ShouldIgnoreFile(filename string, allowPatterns, ignorePatterns []string) bool {
 	// If allow patterns are specified, file must match at least one
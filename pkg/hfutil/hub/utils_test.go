@@ -427,10 +427,16 @@ func TestMatchesPattern(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "substring match",
+			name:     "substring doesn't match",
 			filename: "path/to/config.json",
 			patterns: []string{"config"},
-			expected: true,
+			expected: false,
+		},
+		{
+			name:     "literal path matches itself only",
+			filename: "path/to/config.json",
+			patterns: []string{"to/config.json"},
+			expected: false,
 		},
 		{
 			name:     "multiple patterns one match",
@@ -438,6 +444,48 @@ func TestMatchesPattern(t *testing.T) {
 			patterns: []string{"*.json", "*.bin", "*.txt"},
 			expected: true,
 		},
+		{
+			name:     "wildcard matches nested files",
+			filename: "onnx/decoder/model.bin",
+			patterns: []string{"*.bin"},
+			expected: true,
+		},
+		{
+			name:     "folder pattern",
+			filename: "onnx/decoder/model.onnx_data",
+			patterns: []string{"onnx/"},
+			expected: true,
+		},
+		{
+			name:     "folder pattern doesn't match other folders",
+			filename: "original/onnx/model.onnx",
+			patterns: []string{"onnx/"},
+			expected: false,
+		},
+		{
+			name:     "single character and class",
+			filename: "model-00001-of-00002.safetensors",
+			patterns: []string{"model-0000[0-9]-of-0000?.safetensors"},
+			expected: true,
+		},
+		{
+			name:     "negated class",
+			filename: "model-00001-of-00002.safetensors",
+			patterns: []string{"model-0000[!1]-of-*"},
+			expected: false,
+		},
+		{
+			name:     "regular expression characters are literal",
+			filename: "model+v1.bin",
+			patterns: []string{"model+v?.bin"},
+			expected: true,
+		},
+		{
+			name:     "invalid class is ignored",
+			filename: "model.bin",
+			patterns: []string{"[z-a]*"},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
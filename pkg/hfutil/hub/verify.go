@@ -0,0 +1,267 @@
+package hub
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A snapshot verification re-computes the digests of the files of a local directory and compares them with the
+// ones the Hub lists for a revision, e.g. after a disk corruption is suspected. Nothing is downloaded or deleted.
+
+// VerifyStatus is the outcome of the verification of a file
+type VerifyStatus string
+
+const (
+	// VerifyStatusOK means the local file matches the file of the revision
+	VerifyStatusOK VerifyStatus = "ok"
+	// VerifyStatusMissing means the file of the revision is not in the local directory
+	VerifyStatusMissing VerifyStatus = "missing"
+	// VerifyStatusSizeMismatch means the local file doesn't have the size of the file of the revision
+	VerifyStatusSizeMismatch VerifyStatus = "size_mismatch"
+	// VerifyStatusDigestMismatch means the content of the local file differs from the file of the revision
+	VerifyStatusDigestMismatch VerifyStatus = "digest_mismatch"
+	// VerifyStatusError means the local file couldn't be read
+	VerifyStatusError VerifyStatus = "error"
+)
+
+// FileVerification is the verification of a file of the revision
+type FileVerification struct {
+	Path   string       `json:"path"`
+	Status VerifyStatus `json:"status"`
+	// Algorithm is sha256 for LFS files and git-sha1, their Git blob ID, for the others
+	Algorithm      string `json:"algorithm"`
+	ExpectedDigest string `json:"expectedDigest"`
+	ActualDigest   string `json:"actualDigest,omitempty"`
+	ExpectedSize   int64  `json:"expectedSize"`
+	ActualSize     int64  `json:"actualSize,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// VerifyReport is the result of a snapshot verification
+type VerifyReport struct {
+	RepoID   string `json:"repoId"`
+	Revision string `json:"revision"`
+	// Commit is the commit the revision resolved to
+	Commit   string             `json:"commit"`
+	LocalDir string             `json:"localDir"`
+	Files    []FileVerification `json:"files"`
+	// Extra are the local files that are not in the revision, they don't fail the verification
+	Extra []string `json:"extra,omitempty"`
+}
+
+// OK returns whether every file of the revision is intact in the local directory
+func (r *VerifyReport) OK() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures returns the verifications of the files that are missing or corrupted
+func (r *VerifyReport) Failures() []FileVerification {
+	var failures []FileVerification
+	for _, file := range r.Files {
+		if file.Status != VerifyStatusOK {
+			failures = append(failures, file)
+		}
+	}
+	return failures
+}
+
+// VerifySnapshot verifies the files of the local directory of the config against the files of its revision that
+// match its patterns. It returns an error when the revision can't be listed, and a report otherwise.
+func VerifySnapshot(ctx context.Context, config *DownloadConfig) (*VerifyReport, error) {
+	if config.RepoID == "" {
+		return nil, fmt.Errorf("repo_id cannot be empty")
+	}
+	if config.LocalDir == "" {
+		return nil, fmt.Errorf("local_dir must be specified for snapshot verification")
+	}
+	if info, err := os.Stat(config.LocalDir); err != nil {
+		return nil, fmt.Errorf("failed to read local_dir: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("local_dir %s is not a directory", config.LocalDir)
+	}
+	if cacheOnly(ctx, config) {
+		return nil, NewOfflineModeIsEnabledError("Cannot verify snapshot since offline mode is enabled")
+	}
+
+	revision := downloadRevision(config)
+	commit, err := resolveRevision(ctx, config, revision)
+	if err != nil {
+		return nil, err
+	}
+	pinned := *config
+	pinned.Revision = commit
+	files, err := ListRepoFiles(ctx, &pinned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %w", err)
+	}
+
+	var expected []RepoFile
+	for _, file := range files {
+		if file.Type == "file" && !ShouldIgnoreFile(file.Path, config.AllowPatterns, config.IgnorePatterns) {
+			expected = append(expected, file)
+		}
+	}
+
+	report := &VerifyReport{
+		RepoID:   config.RepoID,
+		Revision: revision,
+		Commit:   commit,
+		LocalDir: config.LocalDir,
+		Files:    make([]FileVerification, len(expected)),
+	}
+
+	// Files are hashed in parallel by as many workers as downloads use
+	maxWorkers := 4
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.MaxWorkers > 0 {
+		maxWorkers = hubConfig.MaxWorkers
+	}
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, maxWorkers)
+	for i, file := range expected {
+		wg.Add(1)
+		go func(i int, file RepoFile) {
+			defer wg.Done()
+			select {
+			case workers <- struct{}{}:
+				defer func() { <-workers }()
+			case <-ctx.Done():
+				return
+			}
+			report.Files[i] = verifyFile(config.LocalDir, file)
+		}(i, file)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	extra, err := extraFiles(config.LocalDir, expected, config.AllowPatterns, config.IgnorePatterns)
+	if err != nil {
+		return nil, err
+	}
+	report.Extra = extra
+
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil {
+		hubConfig.Logger.
+			WithField("repo", config.RepoID).
+			WithField("commit", commit).
+			WithField("files", len(report.Files)).
+			WithField("failures", len(report.Failures())).
+			Info("Verified snapshot")
+	}
+	return report, nil
+}
+
+// verifyFile compares a local file with a file of the revision
+func verifyFile(localDir string, file RepoFile) FileVerification {
+	result := FileVerification{
+		Path:           file.Path,
+		Algorithm:      "git-sha1",
+		ExpectedDigest: file.OID,
+		ExpectedSize:   file.Size,
+	}
+	if file.LFS != nil {
+		result.Algorithm = "sha256"
+		result.ExpectedDigest = file.LFS.OID
+	}
+
+	localPath := filepath.Join(localDir, file.Path)
+	size, err := GetFileSize(localPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			result.Status = VerifyStatusMissing
+		} else {
+			result.Status = VerifyStatusError
+			result.Error = err.Error()
+		}
+		return result
+	}
+	result.ActualSize = size
+	if size != file.Size {
+		result.Status = VerifyStatusSizeMismatch
+		return result
+	}
+
+	var digest string
+	if file.LFS != nil {
+		digest, err = sha256File(localPath)
+	} else {
+		digest, err = gitBlobOID(localPath)
+	}
+	if err != nil {
+		result.Status = VerifyStatusError
+		result.Error = err.Error()
+		return result
+	}
+	result.ActualDigest = digest
+	if digest != result.ExpectedDigest {
+		result.Status = VerifyStatusDigestMismatch
+		return result
+	}
+	result.Status = VerifyStatusOK
+	return result
+}
+
+// extraFiles lists the local files matching the patterns that are not in the revision. The metadata the hub client
+// keeps in the local directory, under .cache, is skipped.
+func extraFiles(localDir string, expected []RepoFile, allowPatterns, ignorePatterns []string) ([]string, error) {
+	known := make(map[string]bool, len(expected))
+	for _, file := range expected {
+		known[file.Path] = true
+	}
+
+	var extra []string
+	err := filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == ".cache" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(rel, ".incomplete") {
+			return nil
+		}
+		if !known[rel] && !ShouldIgnoreFile(rel, allowPatterns, ignorePatterns) {
+			extra = append(extra, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk local_dir: %w", err)
+	}
+	sort.Strings(extra)
+	return extra, nil
+}
+
+// sha256File returns the SHA256 of a file, the etag of LFS files
+func sha256File(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
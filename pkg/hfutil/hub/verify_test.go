@@ -0,0 +1,118 @@
+package hub
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitOID returns the Git blob ID of the content
+func gitOID(content string) string {
+	hash := sha1.New() // #nosec G401 -- git object ids are SHA-1
+	fmt.Fprintf(hash, "blob %d\x00%s", len(content), content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func newVerifyServer(t *testing.T, files []RepoFile) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/models/org/model/revision/main":
+			_ = json.NewEncoder(w).Encode(map[string]string{"sha": lockedCommit})
+		case "/api/models/org/model/tree/" + lockedCommit:
+			_ = json.NewEncoder(w).Encode(files)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVerifySnapshot(t *testing.T) {
+	config := `{"model_type": "llama"}`
+	weights := "weights v1"
+	server := newVerifyServer(t, []RepoFile{
+		{Path: "config.json", Size: int64(len(config)), Type: "file", OID: gitOID(config)},
+		{Path: "model.safetensors", Size: int64(len(weights)), Type: "file", LFS: &LFSInfo{OID: sha256Hex([]byte(weights)), Size: int64(len(weights))}},
+		{Path: "tokenizer.json", Size: 2, Type: "file", OID: gitOID("{}")},
+		{Path: "README.md", Size: 7, Type: "file", OID: gitOID("# Model")},
+		{Path: "onnx", Type: "directory"},
+	})
+
+	localDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "config.json"), []byte(config), 0644))
+	// Same size, different content
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "model.safetensors"), []byte("weights v2"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "notes.json"), []byte("{}"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(localDir, ".cache", "huggingface"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, ".cache", "huggingface", "sync.lock.json"), []byte("{}"), 0644))
+
+	ctx := context.WithValue(context.Background(), HubConfigKey, &HubConfig{MaxWorkers: 2})
+	report, err := VerifySnapshot(ctx, &DownloadConfig{
+		RepoID:         "org/model",
+		Endpoint:       server.URL,
+		LocalDir:       localDir,
+		IgnorePatterns: []string{"*.md"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "main", report.Revision)
+	assert.Equal(t, lockedCommit, report.Commit)
+	assert.False(t, report.OK())
+	require.Len(t, report.Files, 3)
+
+	statuses := map[string]VerifyStatus{}
+	for _, file := range report.Files {
+		statuses[file.Path] = file.Status
+	}
+	assert.Equal(t, map[string]VerifyStatus{
+		"config.json":       VerifyStatusOK,
+		"model.safetensors": VerifyStatusDigestMismatch,
+		"tokenizer.json":    VerifyStatusMissing,
+	}, statuses)
+
+	failures := report.Failures()
+	require.Len(t, failures, 2)
+	for _, failure := range failures {
+		if failure.Path == "model.safetensors" {
+			assert.Equal(t, "sha256", failure.Algorithm)
+			assert.Equal(t, sha256Hex([]byte("weights v2")), failure.ActualDigest)
+		}
+	}
+	assert.Equal(t, []string{"notes.json"}, report.Extra)
+}
+
+func TestVerifySnapshotSizeMismatch(t *testing.T) {
+	server := newVerifyServer(t, []RepoFile{
+		{Path: "config.json", Size: 2, Type: "file", OID: gitOID("{}")},
+	})
+	localDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "config.json"), []byte("{\"truncated\""), 0644))
+
+	report, err := VerifySnapshot(context.Background(), &DownloadConfig{RepoID: "org/model", Endpoint: server.URL, LocalDir: localDir})
+	require.NoError(t, err)
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, VerifyStatusSizeMismatch, report.Files[0].Status)
+	assert.Empty(t, report.Files[0].ActualDigest)
+}
+
+func TestVerifySnapshotValidation(t *testing.T) {
+	_, err := VerifySnapshot(context.Background(), &DownloadConfig{LocalDir: t.TempDir()})
+	assert.Error(t, err)
+
+	_, err = VerifySnapshot(context.Background(), &DownloadConfig{RepoID: "org/model"})
+	assert.Error(t, err)
+
+	_, err = VerifySnapshot(context.Background(), &DownloadConfig{RepoID: "org/model", LocalDir: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+}
@@ -75,6 +75,11 @@ func (c *BaichuanConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *BaichuanConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *BaichuanConfig) GetContextLength() int {
 	if c.ModelMaxLength > 0 {
@@ -88,11 +93,6 @@ func (c *BaichuanConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *BaichuanConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for Baichuan base models
 func (c *BaichuanConfig) HasVision() bool {
 	return false
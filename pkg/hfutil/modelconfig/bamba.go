@@ -0,0 +1,178 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// BambaConfig defines the configuration for Bamba models, which replace the attention of most of their layers with
+// Mamba2 mixers
+type BambaConfig struct {
+	BaseModelConfig
+
+	// Model dimensions
+	HiddenSize            int `json:"hidden_size"`
+	IntermediateSize      int `json:"intermediate_size"`
+	NumHiddenLayers       int `json:"num_hidden_layers"`
+	NumAttentionHeads     int `json:"num_attention_heads"`
+	NumKeyValueHeads      int `json:"num_key_value_heads"`
+	MaxPositionEmbeddings int `json:"max_position_embeddings"`
+	VocabSize             int `json:"vocab_size"`
+
+	// AttnLayerIndices lists the attention layers, the other layers are Mamba2 layers
+	AttnLayerIndices []int `json:"attn_layer_indices"`
+	AttnRotaryEmb    int   `json:"attn_rotary_emb"`
+
+	// Mamba2 specific parameters
+	MambaNHeads    int  `json:"mamba_n_heads"`
+	MambaDHead     int  `json:"mamba_d_head"`
+	MambaNGroups   int  `json:"mamba_n_groups"`
+	MambaDState    int  `json:"mamba_d_state"`
+	MambaDConv     int  `json:"mamba_d_conv"`
+	MambaExpand    int  `json:"mamba_expand"`
+	MambaChunkSize int  `json:"mamba_chunk_size"`
+	MambaConvBias  bool `json:"mamba_conv_bias"`
+	MambaProjBias  bool `json:"mamba_proj_bias"`
+
+	// Special tokens
+	BosTokenId int `json:"bos_token_id"`
+	EosTokenId int `json:"eos_token_id"`
+	PadTokenId int `json:"pad_token_id"`
+
+	// Misc options
+	HiddenAct         string  `json:"hidden_act"`
+	RmsNormEps        float64 `json:"rms_norm_eps"`
+	TieWordEmbeddings bool    `json:"tie_word_embeddings"`
+	UseCache          bool    `json:"use_cache"`
+	InitializerRange  float64 `json:"initializer_range"`
+}
+
+// LoadBambaConfig loads a Bamba model configuration from a JSON file
+func LoadBambaConfig(configPath string) (*BambaConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Bamba config file '%s': %w", configPath, err)
+	}
+
+	var config BambaConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Bamba config JSON from '%s': %w", configPath, err)
+	}
+
+	config.ConfigPath = configPath
+
+	// Default values of transformers' BambaConfig
+	if config.NumKeyValueHeads == 0 {
+		config.NumKeyValueHeads = config.NumAttentionHeads
+	}
+	if config.MambaExpand == 0 {
+		config.MambaExpand = 2
+	}
+	if config.MambaNGroups == 0 {
+		config.MambaNGroups = 1
+	}
+	if config.MambaDConv == 0 {
+		config.MambaDConv = 4
+	}
+	if config.MambaNHeads == 0 && config.MambaDHead > 0 {
+		config.MambaNHeads = config.MambaExpand * config.HiddenSize / config.MambaDHead
+	}
+
+	// Validate the configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Bamba configuration in '%s': %w", configPath, err)
+	}
+
+	return &config, nil
+}
+
+// Validate checks if the Bamba configuration is valid
+func (c *BambaConfig) Validate() error {
+	if c.HiddenSize <= 0 {
+		return fmt.Errorf("hidden_size must be positive, got %d", c.HiddenSize)
+	}
+	if c.NumHiddenLayers <= 0 {
+		return fmt.Errorf("num_hidden_layers must be positive, got %d", c.NumHiddenLayers)
+	}
+	if c.NumAttentionHeads <= 0 {
+		return fmt.Errorf("num_attention_heads must be positive, got %d", c.NumAttentionHeads)
+	}
+	if c.MambaNHeads <= 0 {
+		return fmt.Errorf("mamba_n_heads must be positive, got %d", c.MambaNHeads)
+	}
+	if c.MambaDState <= 0 {
+		return fmt.Errorf("mamba_d_state must be positive, got %d", c.MambaDState)
+	}
+	if c.VocabSize <= 0 {
+		return fmt.Errorf("vocab_size must be positive, got %d", c.VocabSize)
+	}
+	for _, index := range c.AttnLayerIndices {
+		if index < 0 || index >= c.NumHiddenLayers {
+			return fmt.Errorf("attention layer index %d is out of the %d layers", index, c.NumHiddenLayers)
+		}
+	}
+	return nil
+}
+
+// Implementation of the HuggingFaceModel interface
+
+// GetParameterCount returns the total number of parameters in the model
+func (c *BambaConfig) GetParameterCount() int64 {
+	// First try to get parameter count from safetensors files
+	count, err := FindAndParseSafetensors(c.ConfigPath)
+	if err == nil {
+		return count
+	}
+
+	// Log the error
+	fmt.Printf("Warning: failed to get parameter count from safetensors: %v\n", err)
+
+	// Fallback: estimate based on architecture
+	hidden := int64(c.HiddenSize)
+	headDim := int64(c.HiddenSize / c.NumAttentionHeads)
+	attentionLayers := len(c.AttnLayerIndices)
+
+	// Mixers, grouped-query attention or Mamba2
+	params := int64(attentionLayers) * (2*hidden*int64(c.NumAttentionHeads)*headDim + 2*hidden*int64(c.NumKeyValueHeads)*headDim)
+	params += int64(c.NumHiddenLayers-attentionLayers) * mamba2LayerParams(c.HiddenSize, c.MambaExpand*c.HiddenSize,
+		c.MambaDState, c.MambaDConv, c.MambaNGroups, c.MambaNHeads)
+	// Dense MLPs
+	params += int64(c.NumHiddenLayers) * int64(3*c.HiddenSize*c.IntermediateSize)
+	// Embeddings
+	params += int64(c.VocabSize) * hidden
+	if !c.TieWordEmbeddings {
+		params += int64(c.VocabSize) * hidden
+	}
+	return params
+}
+
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *BambaConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
+// GetArchitecture returns the model architecture
+func (c *BambaConfig) GetArchitecture() string {
+	if len(c.Architectures) > 0 {
+		return c.Architectures[0]
+	}
+	return "BambaForCausalLM"
+}
+
+// GetContextLength returns the maximum context length
+func (c *BambaConfig) GetContextLength() int {
+	return c.MaxPositionEmbeddings
+}
+
+// GetModelSizeBytes returns the estimated size of the model in bytes
+func (c *BambaConfig) GetModelSizeBytes() int64 {
+	return EstimateModelSizeBytes(c.GetParameterCount(), c.GetTorchDtype())
+}
+
+// Register the Bamba model handler
+func init() {
+	RegisterModelLoader("bamba", func(configPath string) (HuggingFaceModel, error) {
+		return LoadBambaConfig(configPath)
+	})
+}
@@ -0,0 +1,33 @@
+package modelconfig
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBambaConfig(t *testing.T) {
+	configPath := filepath.Join("testdata", "bamba_9b.json")
+
+	// Load the config
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load Bamba config: %v", err)
+	}
+
+	bambaConfig, ok := config.(*BambaConfig)
+	if !ok {
+		t.Fatalf("Failed to convert to BambaConfig, got %T", config)
+	}
+	if len(bambaConfig.AttnLayerIndices) != 3 || bambaConfig.MambaNHeads != 128 {
+		t.Errorf("Expected 3 attention layers and 128 Mamba heads, got %v and %d",
+			bambaConfig.AttnLayerIndices, bambaConfig.MambaNHeads)
+	}
+	if config.GetArchitecture() != "BambaForCausalLM" {
+		t.Errorf("Incorrect architecture, expected 'BambaForCausalLM', got '%s'", config.GetArchitecture())
+	}
+
+	// Estimated from the architecture, Bamba-9B
+	if paramCount := config.GetParameterCount(); paramCount < 9_500_000_000 || paramCount > 10_000_000_000 {
+		t.Errorf("Expected about 9.8B parameters, got %s", FormatParamCount(paramCount))
+	}
+}
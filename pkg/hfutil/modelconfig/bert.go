@@ -99,6 +99,11 @@ func (c *BertConfig) GetParameterCount() int64 {
 			c.HiddenSize*c.HiddenSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *BertConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *BertConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -109,11 +114,6 @@ func (c *BertConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *BertConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for BERT models
 func (c *BertConfig) HasVision() bool {
 	return false
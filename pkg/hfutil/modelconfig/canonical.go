@@ -0,0 +1,131 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// CanonicalConfigSchemaVersion is the version of the schema of CanonicalConfig. It changes when fields are renamed
+// or removed, not when fields are added.
+const CanonicalConfigSchemaVersion = "v1"
+
+// CanonicalConfig is the normalized metadata document of a model. Its fields have the same names and units
+// whatever the family of the model and the quirks of its config.json, e.g. legacy keys or the nested config of
+// multimodal models, so the document can be stored in ConfigMaps and statuses and compared across models.
+type CanonicalConfig struct {
+	SchemaVersion      string    `json:"schema_version"`
+	ModelType          string    `json:"model_type"`
+	Architecture       string    `json:"architecture"`
+	ContextLength      int       `json:"context_length"`
+	ParameterCount     string    `json:"parameter_count"`
+	ActiveParameters   string    `json:"active_parameter_count,omitempty"`
+	NumExperts         int       `json:"num_experts,omitempty"`
+	HasVision          bool      `json:"has_vision"`
+	MaxImageTokens     int       `json:"max_image_tokens,omitempty"`
+	IsEmbedding        bool      `json:"is_embedding"`
+	Task               ModelTask `json:"task"`
+	Pooling            string    `json:"pooling,omitempty"`
+	EmbeddingDimension int       `json:"embedding_dimension,omitempty"`
+	MaxSeqLength       int       `json:"max_seq_length,omitempty"`
+	TransformerVersion string    `json:"transformers_version"`
+	TorchDtype         string    `json:"torch_dtype"`
+	QuantizationType   string    `json:"quantization_type,omitempty"`
+	BitsPerWeight      float64   `json:"bits_per_weight,omitempty"`
+	ModelSizeBytes     int64     `json:"model_size_bytes"`
+
+	// Architecture of the language model, nil when the config has no transformer dimensions, e.g. for diffusers
+	// pipelines
+	ArchitectureConfig *CanonicalArchitecture `json:"architecture_config,omitempty"`
+}
+
+// CanonicalArchitecture holds the architecture fields of a config, with the defaults transformers fills set and
+// the rope scaling type under rope_type
+type CanonicalArchitecture struct {
+	HiddenSize            int                `json:"hidden_size"`
+	NumHiddenLayers       int                `json:"num_hidden_layers"`
+	NumAttentionHeads     int                `json:"num_attention_heads"`
+	NumKeyValueHeads      int                `json:"num_key_value_heads"`
+	HeadDim               int                `json:"head_dim"`
+	KVLoraRank            int                `json:"kv_lora_rank,omitempty"`
+	IntermediateSize      int                `json:"intermediate_size,omitempty"`
+	VocabSize             int                `json:"vocab_size,omitempty"`
+	MaxPositionEmbeddings int                `json:"max_position_embeddings,omitempty"`
+	RopeTheta             float64            `json:"rope_theta,omitempty"`
+	RopeScaling           *RopeScalingConfig `json:"rope_scaling,omitempty"`
+	NumExpertsPerTok      int                `json:"num_experts_per_tok,omitempty"`
+}
+
+// Canonicalize builds the normalized metadata document of a loaded model
+func Canonicalize(model HuggingFaceModel) *CanonicalConfig {
+	config := &CanonicalConfig{
+		SchemaVersion:      CanonicalConfigSchemaVersion,
+		ModelType:          model.GetModelType(),
+		Architecture:       model.GetArchitecture(),
+		ContextLength:      model.GetContextLength(),
+		ParameterCount:     FormatParamCount(model.GetParameterCount()),
+		NumExperts:         model.GetNumExperts(),
+		HasVision:          model.HasVision(),
+		IsEmbedding:        model.IsEmbedding(),
+		Task:               GetModelTask(model),
+		TransformerVersion: model.GetTransformerVersion(),
+		TorchDtype:         canonicalDtype(model.GetTorchDtype()),
+		QuantizationType:   model.GetQuantizationType(),
+		BitsPerWeight:      model.GetBitsPerWeight(),
+		ModelSizeBytes:     ModelSizeOnDisk(model),
+	}
+
+	// Only MoE models use fewer parameters per token than they have
+	if config.NumExperts > 0 {
+		config.ActiveParameters = FormatParamCount(model.GetActiveParameterCount())
+	}
+
+	// Image token budget of vision-language models
+	if vm, ok := model.(HuggingFaceVisionModel); ok {
+		if tower := vm.GetVisionTower(); tower != nil {
+			config.MaxImageTokens = tower.MaxImageTokens
+		}
+	}
+
+	// Pooling and dimension of the embeddings of sentence-transformers models
+	if ec := GetEmbeddingConfig(model); ec != nil {
+		config.Pooling, config.EmbeddingDimension, config.MaxSeqLength = ec.Pooling, ec.EmbeddingDimension, ec.MaxSeqLength
+	}
+
+	if arch, err := GetArchitectureConfig(model); err == nil && arch.NumHiddenLayers > 0 {
+		arch.Normalize()
+		config.ArchitectureConfig = &CanonicalArchitecture{
+			HiddenSize:            arch.HiddenSize,
+			NumHiddenLayers:       arch.NumHiddenLayers,
+			NumAttentionHeads:     arch.NumAttentionHeads,
+			NumKeyValueHeads:      arch.NumKeyValueHeads,
+			HeadDim:               arch.HeadDim,
+			KVLoraRank:            arch.KVLoraRank,
+			IntermediateSize:      arch.IntermediateSize,
+			VocabSize:             arch.VocabSize,
+			MaxPositionEmbeddings: arch.MaxPositionEmbeddings,
+			NumExpertsPerTok:      arch.NumExpertsPerTok,
+		}
+		if arch.RopeTheta != nil {
+			config.ArchitectureConfig.RopeTheta = *arch.RopeTheta
+		}
+		if arch.RopeScaling != nil {
+			// The type is saved under rope_type only
+			scaling := *arch.RopeScaling
+			scaling.Type = ""
+			config.ArchitectureConfig.RopeScaling = &scaling
+		}
+	}
+	return config
+}
+
+// Marshal serializes the document to JSON. The fields are always written in the same order, so the documents of
+// the same model are byte for byte equal.
+func (c *CanonicalConfig) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// canonicalDtype returns the torch data type without its "torch." prefix, in lower case, e.g. "bfloat16" for
+// "torch.BFloat16"
+func canonicalDtype(dtype string) string {
+	return strings.TrimPrefix(strings.ToLower(dtype), "torch.")
+}
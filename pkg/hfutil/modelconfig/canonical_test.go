@@ -0,0 +1,96 @@
+package modelconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	model, err := LoadModelConfig(filepath.Join("testdata", "llama3_1.json"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	config := Canonicalize(model)
+	if config.SchemaVersion != CanonicalConfigSchemaVersion {
+		t.Errorf("Expected schema version %s, got %s", CanonicalConfigSchemaVersion, config.SchemaVersion)
+	}
+	if config.Task != ModelTaskGeneration || config.TorchDtype != "bfloat16" {
+		t.Errorf("Expected a bfloat16 generation model, got a %s %s model", config.TorchDtype, config.Task)
+	}
+	arch := config.ArchitectureConfig
+	if arch == nil {
+		t.Fatal("Expected the architecture config")
+	}
+	if arch.NumKeyValueHeads != 8 || arch.HeadDim != 128 {
+		t.Errorf("Expected 8 key-value heads of 128 dims, got %d of %d dims", arch.NumKeyValueHeads, arch.HeadDim)
+	}
+	if arch.RopeScaling == nil || arch.RopeScaling.RopeType != "llama3" || arch.RopeScaling.Type != "" {
+		t.Errorf("Expected llama3 rope scaling under rope_type only, got %+v", arch.RopeScaling)
+	}
+
+	first, err := config.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	second, err := Canonicalize(model).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("Expected the same document for the same model, got\n%s\n%s", first, second)
+	}
+}
+
+func TestCanonicalizeConfigQuirks(t *testing.T) {
+	// Legacy rope scaling type, a torch-prefixed dtype and GPT-2 style keys
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "some_model",
+		"architectures": ["SomeModelForCausalLM"],
+		"n_embd": 2048,
+		"n_layer": 24,
+		"n_head": 16,
+		"max_position_embeddings": 16384,
+		"rope_scaling": {"type": "Linear", "factor": 4.0},
+		"torch_dtype": "torch.BFloat16"
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	model, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	data, err := Canonicalize(model).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var document struct {
+		TorchDtype         string `json:"torch_dtype"`
+		ArchitectureConfig struct {
+			HiddenSize       int                        `json:"hidden_size"`
+			NumKeyValueHeads int                        `json:"num_key_value_heads"`
+			RopeScaling      map[string]json.RawMessage `json:"rope_scaling"`
+		} `json:"architecture_config"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Failed to parse document: %v", err)
+	}
+	if document.TorchDtype != "bfloat16" {
+		t.Errorf("Expected torch_dtype bfloat16, got %q", document.TorchDtype)
+	}
+	if document.ArchitectureConfig.HiddenSize != 2048 || document.ArchitectureConfig.NumKeyValueHeads != 16 {
+		t.Errorf("Expected hidden size 2048 and 16 key-value heads, got %s", data)
+	}
+	if string(document.ArchitectureConfig.RopeScaling["rope_type"]) != `"linear"` {
+		t.Errorf("Expected rope_type linear, got %s", data)
+	}
+	if _, ok := document.ArchitectureConfig.RopeScaling["type"]; ok {
+		t.Errorf("Expected no legacy type key, got %s", data)
+	}
+}
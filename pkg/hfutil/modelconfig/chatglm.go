@@ -97,6 +97,11 @@ func (c *ChatGLMConfig) GetParameterCount() int64 {
 			c.NumLayers*2*c.HiddenSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *ChatGLMConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *ChatGLMConfig) GetContextLength() int {
 	return c.SeqLength
@@ -111,11 +116,6 @@ func (c *ChatGLMConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), dtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *ChatGLMConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for ChatGLM base models
 func (c *ChatGLMConfig) HasVision() bool {
 	return false
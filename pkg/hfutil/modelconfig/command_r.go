@@ -87,6 +87,11 @@ func (c *CommandRConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *CommandRConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *CommandRConfig) GetContextLength() int {
 	// Command-R models are known for their long context capabilities
@@ -100,11 +105,6 @@ func (c *CommandRConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *CommandRConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for Command-R base models
 func (c *CommandRConfig) HasVision() bool {
 	return false
@@ -120,6 +120,18 @@ func (c *DBRXConfig) GetParameterCount() int64 {
 	return totalParams
 }
 
+// GetNumExperts returns the number of experts of each layer
+func (c *DBRXConfig) GetNumExperts() int {
+	return c.FFNConfig.MoENExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, with the moe_top_k experts a token
+// is routed to. DBRX uses 36B of its 132B parameters.
+func (c *DBRXConfig) GetActiveParameterCount() int64 {
+	return activeMoEParams(c.GetParameterCount(), c.NLayers, c.FFNConfig.MoENExperts, c.FFNConfig.MoETopK,
+		int64(3*c.DModel*c.FFNConfig.FFNHiddenSize))
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *DBRXConfig) GetContextLength() int {
 	return c.MaxSeqLen
@@ -130,11 +142,6 @@ func (c *DBRXConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *DBRXConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for DBRX base models
 func (c *DBRXConfig) HasVision() bool {
 	return false
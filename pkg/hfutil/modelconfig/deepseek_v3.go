@@ -64,9 +64,6 @@ type DeepseekV3Config struct {
 	// RoPE scaling
 	RopeScaling RopeScalingConfig `json:"rope_scaling"`
 
-	// Quantization settings
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
-
 	// Misc options
 	TieWordEmbeddings bool    `json:"tie_word_embeddings"`
 	UseCache          bool    `json:"use_cache"`
@@ -141,19 +138,25 @@ func (c *DeepseekV3Config) GetParameterCount() int64 {
 	return 685_000_000_000 // 685B parameters
 }
 
+// GetNumExperts returns the number of routed experts of each MoE layer
+func (c *DeepseekV3Config) GetNumExperts() int {
+	return c.NumRoutedExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token: the first_k_dense_replace dense
+// layers, the shared experts and the num_experts_per_tok routed experts of the other layers, including the
+// multi-token prediction layers saved with the model
+func (c *DeepseekV3Config) GetActiveParameterCount() int64 {
+	moeLayers := c.NumHiddenLayers + c.NumNextnPredictLayers - c.FirstKDenseReplace
+	return activeMoEParams(c.GetParameterCount(), moeLayers, c.NumRoutedExperts, c.NumExpertsPerTok,
+		int64(3*c.HiddenSize*c.MoeIntermediateSize))
+}
+
 // GetTransformerVersion returns the transformers library version
 func (c *DeepseekV3Config) GetTransformerVersion() string {
 	return c.BaseModelConfig.TransformerVersion
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *DeepseekV3Config) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // GetArchitecture returns the model architecture
 func (c *DeepseekV3Config) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
@@ -100,6 +100,17 @@ func TestDeepseekV3ModelMetadata(t *testing.T) {
 		t.Errorf("Incorrect parameter count, expected 685B, got %d", paramCount)
 	}
 
+	// The 3 first layers are dense, the 58 others and the multi-token prediction layer route each token to 8 of
+	// their 256 experts
+	if config.GetNumExperts() != 256 {
+		t.Errorf("Incorrect number of experts, expected 256, got %d", config.GetNumExperts())
+	}
+	expectedActive := int64(685_000_000_000) - 59*248*3*7168*2048
+	if activeCount := config.GetActiveParameterCount(); activeCount != expectedActive {
+		t.Errorf("Incorrect active parameter count, expected %s, got %s",
+			FormatParamCount(expectedActive), FormatParamCount(activeCount))
+	}
+
 	// Test model size
 	modelSizeBytes := config.GetModelSizeBytes()
 	expectedSizeBytes := int64(685_000_000_000) * 2 // bfloat16 is 2 bytes per parameter
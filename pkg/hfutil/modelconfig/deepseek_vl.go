@@ -154,6 +154,25 @@ func (c *DeepSeekVLConfig) GetParameterCount() int64 {
 	return totalParams
 }
 
+// GetNumExperts returns the number of routed experts of each MoE layer of the language model, 0 for the dense models
+func (c *DeepSeekVLConfig) GetNumExperts() int {
+	if c.LanguageConfig == nil {
+		return 0
+	}
+	return c.LanguageConfig.NRoutedExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token. For the DeepSeek V2 language models,
+// it includes the shared experts and the num_experts_per_tok routed experts.
+func (c *DeepSeekVLConfig) GetActiveParameterCount() int64 {
+	lc := c.LanguageConfig
+	if lc == nil {
+		return c.GetParameterCount()
+	}
+	return activeMoEParams(c.GetParameterCount(), lc.NumHiddenLayers, lc.NRoutedExperts, lc.NumExpertsPerTok,
+		int64(3*lc.HiddenSize*lc.MoeIntermediateSize))
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *DeepSeekVLConfig) GetContextLength() int {
 	if c.LanguageConfig != nil {
@@ -171,11 +190,6 @@ func (c *DeepSeekVLConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), dtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *DeepSeekVLConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns true for DeepSeek VL models
 func (c *DeepSeekVLConfig) HasVision() bool {
 	return true
@@ -0,0 +1,254 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// ModelTask is the task a model is served for, which decides the API a runtime exposes for it
+type ModelTask string
+
+const (
+	// ModelTaskGeneration models generate text, or images, audio and video
+	ModelTaskGeneration ModelTask = "generation"
+	// ModelTaskEmbedding models encode their inputs into embedding vectors
+	ModelTaskEmbedding ModelTask = "embedding"
+	// ModelTaskRerank models are cross-encoders scoring the relevance of a document to a query
+	ModelTaskRerank ModelTask = "rerank"
+)
+
+// Pooling modes of the sentence-transformers Pooling module
+const (
+	PoolingCLS          = "cls"
+	PoolingMean         = "mean"
+	PoolingMax          = "max"
+	PoolingMeanSqrtLen  = "mean_sqrt_len_tokens"
+	PoolingWeightedMean = "weightedmean"
+	PoolingLastToken    = "lasttoken"
+)
+
+// Types of the sentence-transformers modules read from modules.json
+const (
+	sentenceTransformersTransformerModule = "sentence_transformers.models.Transformer"
+	sentenceTransformersPoolingModule     = "sentence_transformers.models.Pooling"
+	sentenceTransformersDenseModule       = "sentence_transformers.models.Dense"
+	sentenceTransformersNormalizeModule   = "sentence_transformers.models.Normalize"
+)
+
+// encoderModelTypes are the model types of the encoder-only models, whose sequence classification heads score
+// query-document pairs rather than reward completions
+var encoderModelTypes = map[string]bool{
+	"bert":        true,
+	"camembert":   true,
+	"deberta":     true,
+	"deberta-v2":  true,
+	"distilbert":  true,
+	"electra":     true,
+	"modernbert":  true,
+	"mpnet":       true,
+	"new":         true, // GTE v1.5
+	"nomic_bert":  true,
+	"roberta":     true,
+	"xlm-roberta": true,
+}
+
+// EmbeddingConfig is the configuration of a sentence-transformers embedding model, such as the BGE and GTE models,
+// read from modules.json and the configs of its modules
+type EmbeddingConfig struct {
+	// Pooling of the token embeddings into the embedding of the input, e.g. "cls", "mean" or "lasttoken"
+	Pooling string
+
+	// EmbeddingDimension is the size of the embeddings, after the Dense modules projecting them
+	EmbeddingDimension int
+
+	// MaxSeqLength is the number of tokens the inputs are truncated to, 0 when unset
+	MaxSeqLength int
+
+	// Normalize is true when the embeddings are normalized to unit length
+	Normalize bool
+
+	// SimilarityFnName is the similarity function of the embeddings, e.g. "cosine" or "dot"
+	SimilarityFnName string
+
+	// Internal fields
+	ConfigPath string
+}
+
+// sentenceTransformersModule is an entry of modules.json
+type sentenceTransformersModule struct {
+	Idx  int    `json:"idx"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// poolingConfig is the config of the sentence-transformers Pooling module
+type poolingConfig struct {
+	WordEmbeddingDimension       int    `json:"word_embedding_dimension"`
+	PoolingMode                  string `json:"pooling_mode"`
+	PoolingModeCLSToken          bool   `json:"pooling_mode_cls_token"`
+	PoolingModeMeanTokens        bool   `json:"pooling_mode_mean_tokens"`
+	PoolingModeMaxTokens         bool   `json:"pooling_mode_max_tokens"`
+	PoolingModeMeanSqrtLenTokens bool   `json:"pooling_mode_mean_sqrt_len_tokens"`
+	PoolingModeWeightedMean      bool   `json:"pooling_mode_weightedmean_tokens"`
+	PoolingModeLastToken         bool   `json:"pooling_mode_lasttoken"`
+}
+
+// mode returns the pooling mode, saved as a set of flags of which the first set one is used
+func (c *poolingConfig) mode() string {
+	switch {
+	case c.PoolingMode != "":
+		return c.PoolingMode
+	case c.PoolingModeCLSToken:
+		return PoolingCLS
+	case c.PoolingModeMeanTokens:
+		return PoolingMean
+	case c.PoolingModeMaxTokens:
+		return PoolingMax
+	case c.PoolingModeMeanSqrtLenTokens:
+		return PoolingMeanSqrtLen
+	case c.PoolingModeWeightedMean:
+		return PoolingWeightedMean
+	case c.PoolingModeLastToken:
+		return PoolingLastToken
+	}
+	return ""
+}
+
+// LoadEmbeddingConfig loads the sentence-transformers configuration of the model in a directory. The error wraps
+// os.ErrNotExist when the directory has no modules.json, which only sentence-transformers models have.
+func LoadEmbeddingConfig(modelDir string) (*EmbeddingConfig, error) {
+	configPath := filepath.Join(modelDir, constants.SentenceTransformersModulesFileName)
+	var modules []sentenceTransformersModule
+	if err := readJSONFile(configPath, &modules); err != nil {
+		return nil, fmt.Errorf("failed to load sentence-transformers modules: %w", err)
+	}
+
+	config := &EmbeddingConfig{ConfigPath: configPath}
+	for _, module := range modules {
+		moduleDir := filepath.Join(modelDir, module.Path)
+		switch module.Type {
+		case sentenceTransformersTransformerModule:
+			var bertConfig struct {
+				MaxSeqLength int `json:"max_seq_length"`
+			}
+			err := readJSONFile(filepath.Join(moduleDir, constants.SentenceBertConfigFileName), &bertConfig)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			config.MaxSeqLength = bertConfig.MaxSeqLength
+		case sentenceTransformersPoolingModule:
+			var pooling poolingConfig
+			if err := readJSONFile(filepath.Join(moduleDir, "config.json"), &pooling); err != nil {
+				return nil, err
+			}
+			config.Pooling = pooling.mode()
+			config.EmbeddingDimension = pooling.WordEmbeddingDimension
+		case sentenceTransformersDenseModule:
+			var dense struct {
+				OutFeatures int `json:"out_features"`
+			}
+			if err := readJSONFile(filepath.Join(moduleDir, "config.json"), &dense); err != nil {
+				return nil, err
+			}
+			if dense.OutFeatures > 0 {
+				config.EmbeddingDimension = dense.OutFeatures
+			}
+		case sentenceTransformersNormalizeModule:
+			config.Normalize = true
+		}
+	}
+
+	var stConfig struct {
+		SimilarityFnName string `json:"similarity_fn_name"`
+	}
+	err := readJSONFile(filepath.Join(modelDir, constants.SentenceTransformersConfigFileName), &stConfig)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	config.SimilarityFnName = stConfig.SimilarityFnName
+	return config, nil
+}
+
+// readJSONFile parses a JSON file into v. The error wraps os.ErrNotExist when the file does not exist.
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+	if err := json.Unmarshal(SanitizeJSONBytes(data), v); err != nil {
+		return fmt.Errorf("failed to parse JSON from '%s': %w", path, err)
+	}
+	return nil
+}
+
+// GetEmbeddingConfig returns the sentence-transformers configuration saved next to the config of a model, or nil
+// if the model has none
+func GetEmbeddingConfig(model HuggingFaceModel) *EmbeddingConfig {
+	cf, ok := model.(interface{ configFile() string })
+	if !ok || cf.configFile() == "" {
+		return nil
+	}
+	config, err := LoadEmbeddingConfig(filepath.Dir(cf.configFile()))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("Warning: failed to load sentence-transformers config: %v\n", err)
+		}
+		return nil
+	}
+	return config
+}
+
+// GetModelTask classifies a model as a generation, embedding or rerank model. Rerankers are cross-encoders: encoder
+// models, or sentence-transformers CrossEncoder models, with a sequence classification head of a single label.
+// Embedding models either say so in their config or are sentence-transformers models.
+func GetModelTask(model HuggingFaceModel) ModelTask {
+	if isReranker(model) {
+		return ModelTaskRerank
+	}
+	if model.IsEmbedding() || GetEmbeddingConfig(model) != nil {
+		return ModelTaskEmbedding
+	}
+	return ModelTaskGeneration
+}
+
+// isReranker returns true if the model scores query-document pairs with a single label classification head
+func isReranker(model HuggingFaceModel) bool {
+	if !strings.HasSuffix(model.GetArchitecture(), "ForSequenceClassification") {
+		return false
+	}
+	cf, ok := model.(interface{ configFile() string })
+	if !ok || cf.configFile() == "" {
+		return false
+	}
+
+	var head struct {
+		NumLabels int               `json:"num_labels"`
+		ID2Label  map[string]string `json:"id2label"`
+		// Set by sentence-transformers for the CrossEncoder models it saves
+		SentenceTransformers json.RawMessage `json:"sentence_transformers"`
+		CEActivationFunction string          `json:"sbert_ce_default_activation_function"`
+	}
+	if err := readJSONFile(cf.configFile(), &head); err != nil {
+		return false
+	}
+	// transformers defaults to two labels
+	numLabels := head.NumLabels
+	if numLabels == 0 {
+		numLabels = len(head.ID2Label)
+	}
+	if numLabels == 0 {
+		numLabels = 2
+	}
+	if numLabels != 1 {
+		return false
+	}
+	crossEncoder := len(head.SentenceTransformers) > 0 || head.CEActivationFunction != ""
+	return crossEncoder || encoderModelTypes[strings.ToLower(model.GetModelType())]
+}
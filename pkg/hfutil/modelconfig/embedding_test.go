@@ -0,0 +1,109 @@
+package modelconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSentenceTransformersModel writes a BGE-style sentence-transformers model using CLS pooling, and returns the
+// path of its config.json
+func writeSentenceTransformersModel(t *testing.T, dir string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "bge_large.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test config: %v", err)
+	}
+	for _, sub := range []string{"1_Pooling", "2_Dense"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", sub, err)
+		}
+	}
+	writeTokenizerFiles(t, dir, map[string]string{
+		"config.json": string(data),
+		"modules.json": `[
+			{"idx": 0, "name": "0", "path": "", "type": "sentence_transformers.models.Transformer"},
+			{"idx": 1, "name": "1", "path": "1_Pooling", "type": "sentence_transformers.models.Pooling"},
+			{"idx": 2, "name": "2", "path": "2_Dense", "type": "sentence_transformers.models.Dense"},
+			{"idx": 3, "name": "3", "path": "3_Normalize", "type": "sentence_transformers.models.Normalize"}
+		]`,
+		"sentence_bert_config.json":         `{"max_seq_length": 512, "do_lower_case": true}`,
+		"config_sentence_transformers.json": `{"similarity_fn_name": "cosine"}`,
+		"1_Pooling/config.json":             `{"word_embedding_dimension": 1024, "pooling_mode_cls_token": true, "pooling_mode_mean_tokens": false}`,
+		"2_Dense/config.json":               `{"in_features": 1024, "out_features": 768, "bias": true}`,
+	})
+	return filepath.Join(dir, "config.json")
+}
+
+func TestLoadEmbeddingConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeSentenceTransformersModel(t, dir)
+
+	config, err := LoadEmbeddingConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadEmbeddingConfig failed: %v", err)
+	}
+	if config.Pooling != PoolingCLS {
+		t.Errorf("Expected CLS pooling, got %q", config.Pooling)
+	}
+	if config.EmbeddingDimension != 768 {
+		t.Errorf("Expected the dimension of the Dense module 768, got %d", config.EmbeddingDimension)
+	}
+	if config.MaxSeqLength != 512 {
+		t.Errorf("Expected max sequence length 512, got %d", config.MaxSeqLength)
+	}
+	if !config.Normalize {
+		t.Error("Expected normalized embeddings")
+	}
+	if config.SimilarityFnName != "cosine" {
+		t.Errorf("Expected cosine similarity, got %q", config.SimilarityFnName)
+	}
+
+	if _, err := LoadEmbeddingConfig(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist without modules.json, got %v", err)
+	}
+}
+
+func TestGetModelTask(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		dir := t.TempDir()
+		writeTokenizerFiles(t, dir, map[string]string{"config.json": content})
+		return filepath.Join(dir, "config.json")
+	}
+
+	testCases := []struct {
+		name       string
+		configPath string
+		expected   ModelTask
+	}{
+		{"causal language model", filepath.Join("testdata", "llama3_2_1b.json"), ModelTaskGeneration},
+		{"embedding model", filepath.Join("testdata", "e5_mistral_7b.json"), ModelTaskEmbedding},
+		{"sentence-transformers model", writeSentenceTransformersModel(t, t.TempDir()), ModelTaskEmbedding},
+		{"reward model", filepath.Join("testdata", "skywork_reward_llama.json"), ModelTaskGeneration},
+		{"encoder reranker", writeConfig(t, `{
+			"model_type": "xlm-roberta",
+			"architectures": ["XLMRobertaForSequenceClassification"],
+			"id2label": {"0": "LABEL_0"},
+			"hidden_size": 1024,
+			"num_hidden_layers": 24
+		}`), ModelTaskRerank},
+		{"cross-encoder", writeConfig(t, `{
+			"model_type": "qwen2",
+			"architectures": ["Qwen2ForSequenceClassification"],
+			"num_labels": 1,
+			"sentence_transformers": {"activation_fn": "torch.nn.modules.activation.Sigmoid"}
+		}`), ModelTaskRerank},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			model, err := LoadModelConfig(tc.configPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if task := GetModelTask(model); task != tc.expected {
+				t.Errorf("Expected task %q, got %q", tc.expected, task)
+			}
+		})
+	}
+}
@@ -92,6 +92,11 @@ func (c *ExaoneConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumLayers, c.IntermediateSize, vocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *ExaoneConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *ExaoneConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -102,11 +107,6 @@ func (c *ExaoneConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *ExaoneConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for ExaONE base models
 func (c *ExaoneConfig) HasVision() bool {
 	return false
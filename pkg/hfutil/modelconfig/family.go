@@ -0,0 +1,51 @@
+package modelconfig
+
+import (
+	"strings"
+)
+
+// architectureHeadSuffixes are the model head suffixes of Hugging Face architecture class names,
+// longest first so that e.g. "ForCausalLM" is stripped before "Model".
+var architectureHeadSuffixes = []string{
+	"ForConditionalGeneration",
+	"ForSequenceClassification",
+	"ForTokenClassification",
+	"ForQuestionAnswering",
+	"ForCausalLM",
+	"ForMaskedLM",
+	"LMHeadModel",
+	"Model",
+}
+
+// normalizeFamily lowercases a model type or architecture stem and drops separators,
+// so that e.g. "DeepseekV3" and "deepseek_v3" compare equal.
+func normalizeFamily(s string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(s))
+}
+
+// GetArchitectureFamily returns the model family of a Hugging Face architecture class name, e.g.
+// "qwen2" for "Qwen2ForCausalLM". The family is the registered model_type whose name matches the
+// architecture once its head suffix is removed; for model types without a registered loader, the
+// lowercased architecture name without its head suffix is returned so new families can still be matched.
+func GetArchitectureFamily(architecture string) string {
+	stem := architecture
+	for _, suffix := range architectureHeadSuffixes {
+		if strings.HasSuffix(stem, suffix) && len(stem) > len(suffix) {
+			stem = strings.TrimSuffix(stem, suffix)
+			break
+		}
+	}
+	if stem == "" {
+		return ""
+	}
+
+	normalized := normalizeFamily(stem)
+	modelLoadersMu.RLock()
+	defer modelLoadersMu.RUnlock()
+	for modelType := range modelLoaders {
+		if normalizeFamily(modelType) == normalized {
+			return modelType
+		}
+	}
+	return strings.ToLower(stem)
+}
@@ -0,0 +1,31 @@
+package modelconfig
+
+import (
+	"testing"
+)
+
+func TestGetArchitectureFamily(t *testing.T) {
+	testCases := []struct {
+		architecture string
+		expected     string
+	}{
+		{"LlamaForCausalLM", "llama"},
+		{"Qwen2ForCausalLM", "qwen2"},
+		{"Qwen2_5_VLForConditionalGeneration", "qwen2_5_vl"},
+		{"DeepseekV3ForCausalLM", "deepseek_v3"},
+		{"Phi3VForCausalLM", "phi3_v"},
+		{"ChatGLMModel", "chatglm"},
+		{"BertModel", "bert"},
+		{"Qwen3NextForCausalLM", "qwen3next"},
+		{"Model", "model"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.architecture, func(t *testing.T) {
+			if got := GetArchitectureFamily(tc.architecture); got != tc.expected {
+				t.Errorf("GetArchitectureFamily(%q) = %q, want %q", tc.architecture, got, tc.expected)
+			}
+		})
+	}
+}
@@ -117,6 +117,11 @@ func (c *GemmaConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *GemmaConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *GemmaConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -127,11 +132,6 @@ func (c *GemmaConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *GemmaConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for Gemma base models
 func (c *GemmaConfig) HasVision() bool {
 	return false
@@ -108,6 +108,11 @@ func (c *Gemma3Config) GetParameterCount() int64 {
 	return textParams + visionParams
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Gemma3Config) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Gemma3Config) GetContextLength() int {
 	// For Gemma3, if RoPE scaling is used, calculate extended context
@@ -133,11 +138,6 @@ func (c *Gemma3Config) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Gemma3Config) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns true since Gemma3 is a multimodal vision model
 func (c *Gemma3Config) HasVision() bool {
 	return true
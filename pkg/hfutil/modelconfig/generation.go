@@ -0,0 +1,108 @@
+package modelconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// TokenIDs is a list of token ids, saved either as a single id or as a list
+type TokenIDs []int
+
+// UnmarshalJSON reads a single token id or a list of them
+func (ids *TokenIDs) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var id int
+	if err := json.Unmarshal(data, &id); err == nil {
+		*ids = TokenIDs{id}
+		return nil
+	}
+	var list []int
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("token ids are neither an id nor a list of ids: %w", err)
+	}
+	*ids = list
+	return nil
+}
+
+// GenerationConfig is the default generation configuration of a Hugging Face model, read from
+// generation_config.json. The parameters missing from the file are nil, so that the runtime keeps its own
+// defaults for them.
+type GenerationConfig struct {
+	MaxNewTokens      *int     `json:"max_new_tokens,omitempty"`
+	MaxLength         *int     `json:"max_length,omitempty"`
+	DoSample          *bool    `json:"do_sample,omitempty"`
+	Temperature       *float64 `json:"temperature,omitempty"`
+	TopP              *float64 `json:"top_p,omitempty"`
+	TopK              *int     `json:"top_k,omitempty"`
+	MinP              *float64 `json:"min_p,omitempty"`
+	RepetitionPenalty *float64 `json:"repetition_penalty,omitempty"`
+
+	BosTokenID TokenIDs `json:"bos_token_id,omitempty"`
+	// EosTokenID lists the tokens that stop the generation
+	EosTokenID TokenIDs `json:"eos_token_id,omitempty"`
+	PadTokenID TokenIDs `json:"pad_token_id,omitempty"`
+
+	TransformerVersion string `json:"transformers_version"`
+
+	// Internal fields (not in JSON)
+	ConfigPath string `json:"-"`
+}
+
+// LoadGenerationConfig loads the generation configuration of the model in a directory. The error wraps
+// os.ErrNotExist when the directory has no generation_config.json, which is optional.
+func LoadGenerationConfig(modelDir string) (*GenerationConfig, error) {
+	configPath := filepath.Join(modelDir, constants.GenerationConfigFileName)
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generation config '%s': %w", configPath, err)
+	}
+
+	var config GenerationConfig
+	if err := json.Unmarshal(SanitizeJSONBytes(data), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse generation config JSON from '%s': %w", configPath, err)
+	}
+	config.ConfigPath = configPath
+	return &config, nil
+}
+
+// GetStopTokenIDs returns the ids of the tokens that stop the generation
+func (c *GenerationConfig) GetStopTokenIDs() []int {
+	return c.EosTokenID
+}
+
+// SamplingDefaults returns the sampling parameters set by the model, keyed by their name in the OpenAI-compatible
+// APIs of the runtimes. A model that disables sampling with do_sample gets greedy decoding, temperature 0, since
+// the runtimes sample by default.
+func (c *GenerationConfig) SamplingDefaults() map[string]any {
+	defaults := map[string]any{}
+	if c.MaxNewTokens != nil {
+		defaults["max_tokens"] = *c.MaxNewTokens
+	}
+	if c.DoSample != nil && !*c.DoSample {
+		defaults["temperature"] = 0.0
+	} else {
+		if c.Temperature != nil {
+			defaults["temperature"] = *c.Temperature
+		}
+		if c.TopP != nil {
+			defaults["top_p"] = *c.TopP
+		}
+		if c.TopK != nil {
+			defaults["top_k"] = *c.TopK
+		}
+		if c.MinP != nil {
+			defaults["min_p"] = *c.MinP
+		}
+	}
+	if c.RepetitionPenalty != nil {
+		defaults["repetition_penalty"] = *c.RepetitionPenalty
+	}
+	return defaults
+}
@@ -0,0 +1,68 @@
+package modelconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadGenerationConfig(t *testing.T) {
+	dir := t.TempDir()
+	configJSON := `{
+		"bos_token_id": 128000,
+		"do_sample": true,
+		"eos_token_id": [128001, 128008, 128009],
+		"temperature": 0.6,
+		"top_p": 0.9,
+		"max_new_tokens": 2048,
+		"transformers_version": "4.45.0.dev0"
+	}`
+	if err := os.WriteFile(filepath.Join(dir, "generation_config.json"), []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write generation config: %v", err)
+	}
+
+	config, err := LoadGenerationConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadGenerationConfig failed: %v", err)
+	}
+	if !reflect.DeepEqual(config.GetStopTokenIDs(), []int{128001, 128008, 128009}) {
+		t.Errorf("Unexpected stop token ids %v", config.GetStopTokenIDs())
+	}
+	if !reflect.DeepEqual(config.BosTokenID, TokenIDs{128000}) {
+		t.Errorf("Expected a single bos token id, got %v", config.BosTokenID)
+	}
+	if config.TopK != nil {
+		t.Errorf("Expected top_k to be unset, got %d", *config.TopK)
+	}
+
+	expected := map[string]any{"max_tokens": 2048, "temperature": 0.6, "top_p": 0.9}
+	if defaults := config.SamplingDefaults(); !reflect.DeepEqual(defaults, expected) {
+		t.Errorf("Expected sampling defaults %v, got %v", expected, defaults)
+	}
+}
+
+func TestGenerationConfigGreedy(t *testing.T) {
+	dir := t.TempDir()
+	configJSON := `{"do_sample": false, "temperature": 0.7, "repetition_penalty": 1.05, "eos_token_id": 2}`
+	if err := os.WriteFile(filepath.Join(dir, "generation_config.json"), []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write generation config: %v", err)
+	}
+
+	config, err := LoadGenerationConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadGenerationConfig failed: %v", err)
+	}
+	expected := map[string]any{"temperature": 0.0, "repetition_penalty": 1.05}
+	if defaults := config.SamplingDefaults(); !reflect.DeepEqual(defaults, expected) {
+		t.Errorf("Expected greedy sampling defaults %v, got %v", expected, defaults)
+	}
+	if !reflect.DeepEqual(config.GetStopTokenIDs(), []int{2}) {
+		t.Errorf("Unexpected stop token ids %v", config.GetStopTokenIDs())
+	}
+
+	if _, err := LoadGenerationConfig(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist without generation_config.json, got %v", err)
+	}
+}
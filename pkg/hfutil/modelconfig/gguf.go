@@ -0,0 +1,620 @@
+package modelconfig
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GGUF is the single-file format of llama.cpp. Its header holds typed key/value metadata followed by the
+// shape of every tensor, which is all that is read here: the tensor data is never loaded.
+// See https://github.com/ggml-org/ggml/blob/master/docs/gguf.md
+
+const (
+	ggufMagic = "GGUF"
+	// maxGGUFStringLength bounds the strings of the header, chat templates being the longest ones in practice
+	maxGGUFStringLength = 16 << 20
+	// maxGGUFTensorDims is the maximum number of dimensions of a GGML tensor
+	maxGGUFTensorDims = 4
+)
+
+// Types of the GGUF metadata values
+const (
+	ggufTypeUint8 uint32 = iota
+	ggufTypeInt8
+	ggufTypeUint16
+	ggufTypeInt16
+	ggufTypeUint32
+	ggufTypeInt32
+	ggufTypeFloat32
+	ggufTypeBool
+	ggufTypeString
+	ggufTypeArray
+	ggufTypeUint64
+	ggufTypeInt64
+	ggufTypeFloat64
+)
+
+// ggufFileTypes maps the general.file_type of a GGUF file to the name llama.cpp gives to its quantization
+var ggufFileTypes = map[uint64]string{
+	0: "F32", 1: "F16", 2: "Q4_0", 3: "Q4_1", 7: "Q8_0", 8: "Q5_0", 9: "Q5_1",
+	10: "Q2_K", 11: "Q3_K_S", 12: "Q3_K_M", 13: "Q3_K_L", 14: "Q4_K_S", 15: "Q4_K_M",
+	16: "Q5_K_S", 17: "Q5_K_M", 18: "Q6_K", 19: "IQ2_XXS", 20: "IQ2_XS", 21: "Q2_K_S",
+	22: "IQ3_XS", 23: "IQ3_XXS", 24: "IQ1_S", 25: "IQ4_NL", 26: "IQ3_S", 27: "IQ3_M",
+	28: "IQ2_S", 29: "IQ2_M", 30: "IQ4_XS", 31: "IQ1_M", 32: "BF16", 36: "TQ1_0", 37: "TQ2_0",
+}
+
+// ggufUnquantizedDtypes maps the unquantized file types to their torch data type
+var ggufUnquantizedDtypes = map[string]string{
+	"F32":  "float32",
+	"F16":  "float16",
+	"BF16": "bfloat16",
+}
+
+// ggufArchitectures maps the general.architecture of llama.cpp to the Hugging Face architecture it converts
+var ggufArchitectures = map[string]string{
+	"llama":      "LlamaForCausalLM",
+	"llama4":     "Llama4ForConditionalGeneration",
+	"qwen2":      "Qwen2ForCausalLM",
+	"qwen2moe":   "Qwen2MoeForCausalLM",
+	"qwen3":      "Qwen3ForCausalLM",
+	"qwen3moe":   "Qwen3MoeForCausalLM",
+	"gemma":      "GemmaForCausalLM",
+	"gemma2":     "Gemma2ForCausalLM",
+	"gemma3":     "Gemma3ForCausalLM",
+	"phi2":       "PhiForCausalLM",
+	"phi3":       "Phi3ForCausalLM",
+	"deepseek2":  "DeepseekV2ForCausalLM",
+	"command-r":  "CohereForCausalLM",
+	"starcoder2": "Starcoder2ForCausalLM",
+	"gpt-oss":    "GptOssForCausalLM",
+	"bert":       "BertModel",
+	"nomic-bert": "NomicBertModel",
+}
+
+// ggufShardPattern matches the files of a GGUF model split by llama.cpp, e.g. model-00001-of-00003.gguf
+var ggufShardPattern = regexp.MustCompile(`^(.*)-(\d{5})-of-(\d{5})\.gguf$`)
+
+// GGUFArray is a metadata array of a GGUF file. Only its length is kept, e.g. the size of the vocabulary
+// for tokenizer.ggml.tokens.
+type GGUFArray struct {
+	Type uint32
+	Len  uint64
+}
+
+// GGUFTensorInfo is the shape of a tensor of a GGUF file
+type GGUFTensorInfo struct {
+	Name       string
+	Dimensions []uint64
+	Type       uint32
+}
+
+// Elements returns the number of parameters of the tensor
+func (t GGUFTensorInfo) Elements() int64 {
+	elements := int64(1)
+	for _, dim := range t.Dimensions {
+		elements *= int64(dim)
+	}
+	return elements
+}
+
+// GGUFFile is the header of a GGUF file
+type GGUFFile struct {
+	Version uint32
+	// Metadata holds the values by key: unsigned integers as uint64, signed ones as int64, floats as float64,
+	// and arrays as GGUFArray
+	Metadata map[string]any
+	Tensors  []GGUFTensorInfo
+}
+
+// StringValue returns a string metadata value, empty when missing
+func (f *GGUFFile) StringValue(key string) string {
+	s, _ := f.Metadata[key].(string)
+	return s
+}
+
+// UintValue returns an integer metadata value
+func (f *GGUFFile) UintValue(key string) (uint64, bool) {
+	switch v := f.Metadata[key].(type) {
+	case uint64:
+		return v, true
+	case int64:
+		if v >= 0 {
+			return uint64(v), true
+		}
+	}
+	return 0, false
+}
+
+// ParameterCount returns the number of parameters of the tensors of the file
+func (f *GGUFFile) ParameterCount() int64 {
+	var total int64
+	for _, tensor := range f.Tensors {
+		total += tensor.Elements()
+	}
+	return total
+}
+
+// ParseGGUF reads the header of a GGUF file
+func ParseGGUF(path string) (*GGUFFile, error) {
+	if path == "" {
+		return nil, fmt.Errorf("GGUF file path cannot be empty")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GGUF file '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	gguf, err := readGGUF(bufio.NewReaderSize(file, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GGUF file '%s': %w", path, err)
+	}
+	return gguf, nil
+}
+
+// ggufReader reads the little-endian values of a GGUF header
+type ggufReader struct {
+	r   io.Reader
+	buf [8]byte
+}
+
+func readGGUF(r io.Reader) (*GGUFFile, error) {
+	reader := &ggufReader{r: r}
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, fmt.Errorf("failed to read magic: %w", err)
+	}
+	if string(magic) != ggufMagic {
+		return nil, errors.New("not a GGUF file")
+	}
+
+	version, err := reader.uint32()
+	if err != nil {
+		return nil, err
+	}
+	// Version 1 used 32-bit lengths and was replaced in 2023
+	if version < 2 {
+		return nil, fmt.Errorf("unsupported GGUF version %d", version)
+	}
+
+	tensorCount, err := reader.uint64()
+	if err != nil {
+		return nil, err
+	}
+	kvCount, err := reader.uint64()
+	if err != nil {
+		return nil, err
+	}
+
+	gguf := &GGUFFile{Version: version, Metadata: make(map[string]any)}
+	for i := uint64(0); i < kvCount; i++ {
+		key, err := reader.string()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata key: %w", err)
+		}
+		valueType, err := reader.uint32()
+		if err != nil {
+			return nil, err
+		}
+		value, err := reader.value(valueType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata %s: %w", key, err)
+		}
+		gguf.Metadata[key] = value
+	}
+
+	for i := uint64(0); i < tensorCount; i++ {
+		name, err := reader.string()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tensor name: %w", err)
+		}
+		nDims, err := reader.uint32()
+		if err != nil {
+			return nil, err
+		}
+		if nDims > maxGGUFTensorDims {
+			return nil, fmt.Errorf("tensor %s has %d dimensions", name, nDims)
+		}
+		tensor := GGUFTensorInfo{Name: name, Dimensions: make([]uint64, nDims)}
+		for d := range tensor.Dimensions {
+			if tensor.Dimensions[d], err = reader.uint64(); err != nil {
+				return nil, err
+			}
+		}
+		if tensor.Type, err = reader.uint32(); err != nil {
+			return nil, err
+		}
+		// The offset of the tensor data isn't needed
+		if _, err := reader.uint64(); err != nil {
+			return nil, err
+		}
+		gguf.Tensors = append(gguf.Tensors, tensor)
+	}
+	return gguf, nil
+}
+
+func (g *ggufReader) read(n int) ([]byte, error) {
+	if _, err := io.ReadFull(g.r, g.buf[:n]); err != nil {
+		return nil, err
+	}
+	return g.buf[:n], nil
+}
+
+func (g *ggufReader) uint32() (uint32, error) {
+	b, err := g.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (g *ggufReader) uint64() (uint64, error) {
+	b, err := g.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (g *ggufReader) string() (string, error) {
+	length, err := g.uint64()
+	if err != nil {
+		return "", err
+	}
+	if length > maxGGUFStringLength {
+		return "", fmt.Errorf("string of %d bytes exceeds the limit", length)
+	}
+	s := make([]byte, length)
+	if _, err := io.ReadFull(g.r, s); err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// value reads a metadata value of a type. The elements of arrays are skipped.
+func (g *ggufReader) value(valueType uint32) (any, error) {
+	switch valueType {
+	case ggufTypeUint8, ggufTypeInt8, ggufTypeBool:
+		b, err := g.read(1)
+		if err != nil {
+			return nil, err
+		}
+		switch valueType {
+		case ggufTypeUint8:
+			return uint64(b[0]), nil
+		case ggufTypeInt8:
+			return int64(int8(b[0])), nil
+		}
+		return b[0] != 0, nil
+	case ggufTypeUint16, ggufTypeInt16:
+		b, err := g.read(2)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint16(b)
+		if valueType == ggufTypeInt16 {
+			return int64(int16(v)), nil
+		}
+		return uint64(v), nil
+	case ggufTypeUint32, ggufTypeInt32, ggufTypeFloat32:
+		v, err := g.uint32()
+		if err != nil {
+			return nil, err
+		}
+		switch valueType {
+		case ggufTypeInt32:
+			return int64(int32(v)), nil
+		case ggufTypeFloat32:
+			return float64(math.Float32frombits(v)), nil
+		}
+		return uint64(v), nil
+	case ggufTypeUint64, ggufTypeInt64, ggufTypeFloat64:
+		v, err := g.uint64()
+		if err != nil {
+			return nil, err
+		}
+		switch valueType {
+		case ggufTypeInt64:
+			return int64(v), nil
+		case ggufTypeFloat64:
+			return math.Float64frombits(v), nil
+		}
+		return v, nil
+	case ggufTypeString:
+		return g.string()
+	case ggufTypeArray:
+		elemType, err := g.uint32()
+		if err != nil {
+			return nil, err
+		}
+		length, err := g.uint64()
+		if err != nil {
+			return nil, err
+		}
+		if err := g.skipArray(elemType, length); err != nil {
+			return nil, err
+		}
+		return GGUFArray{Type: elemType, Len: length}, nil
+	default:
+		return nil, fmt.Errorf("unknown value type %d", valueType)
+	}
+}
+
+// skipArray skips the elements of an array
+func (g *ggufReader) skipArray(elemType uint32, length uint64) error {
+	var size uint64
+	switch elemType {
+	case ggufTypeUint8, ggufTypeInt8, ggufTypeBool:
+		size = 1
+	case ggufTypeUint16, ggufTypeInt16:
+		size = 2
+	case ggufTypeUint32, ggufTypeInt32, ggufTypeFloat32:
+		size = 4
+	case ggufTypeUint64, ggufTypeInt64, ggufTypeFloat64:
+		size = 8
+	case ggufTypeString:
+		for i := uint64(0); i < length; i++ {
+			n, err := g.uint64()
+			if err != nil {
+				return err
+			}
+			if n > maxGGUFStringLength {
+				return fmt.Errorf("string of %d bytes exceeds the limit", n)
+			}
+			if _, err := io.CopyN(io.Discard, g.r, int64(n)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		// Nested arrays have variable sizes
+		for i := uint64(0); i < length; i++ {
+			if _, err := g.value(elemType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if length > math.MaxInt64/size {
+		return fmt.Errorf("array of %d elements exceeds the limit", length)
+	}
+	n := int64(length * size)
+	if copied, err := io.CopyN(io.Discard, g.r, n); err != nil {
+		return fmt.Errorf("array truncated after %d of %d bytes: %w", copied, n, err)
+	}
+	return nil
+}
+
+// GGUFModelConfig is the configuration of a model stored as GGUF, read from the header of its file
+type GGUFModelConfig struct {
+	BaseModelConfig
+
+	// Version is the version of the GGUF format
+	Version uint32
+	// Name is the general.name of the model
+	Name string
+	// FileType is the quantization of the weights, e.g. Q4_K_M, or F16 for unquantized ones
+	FileType string
+
+	parameterCount int64
+	// expertParameterCount is the number of parameters of the routed experts of MoE models
+	expertParameterCount int64
+	numExperts           int
+	numExpertsPerTok     int
+	contextLength        int
+	sizeBytes            int64
+	hasVision            bool
+	isEmbedding          bool
+	dimensions           ModelDimensions
+}
+
+// LoadGGUFModelConfig loads the configuration of a GGUF model. The parameters and the size of every shard are
+// counted when the model is split.
+func LoadGGUFModelConfig(path string) (*GGUFModelConfig, error) {
+	shards, err := ggufShards(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gguf, err := ParseGGUF(shards[0])
+	if err != nil {
+		return nil, err
+	}
+	arch := gguf.StringValue("general.architecture")
+	if arch == "" {
+		return nil, fmt.Errorf("general.architecture is missing in GGUF file '%s'", shards[0])
+	}
+
+	config := &GGUFModelConfig{
+		Version:              gguf.Version,
+		Name:                 gguf.StringValue("general.name"),
+		parameterCount:       gguf.ParameterCount(),
+		expertParameterCount: ggufExpertParameterCount(gguf),
+		numExperts:           ggufInt(gguf, arch+".expert_count"),
+		numExpertsPerTok:     ggufInt(gguf, arch+".expert_used_count"),
+	}
+	config.ConfigPath = shards[0]
+	config.ModelType = arch
+	if architecture, ok := ggufArchitectures[arch]; ok {
+		config.Architectures = []string{architecture}
+	} else {
+		config.Architectures = []string{arch}
+	}
+	if fileType, ok := gguf.UintValue("general.file_type"); ok {
+		config.FileType = ggufFileTypes[fileType]
+		config.TorchDtype = ggufUnquantizedDtypes[config.FileType]
+	}
+	if contextLength, ok := gguf.UintValue(arch + ".context_length"); ok {
+		config.contextLength = int(contextLength)
+	}
+	config.dimensions = ModelDimensions{
+		HiddenSize:        ggufInt(gguf, arch+".embedding_length"),
+		NumHiddenLayers:   ggufInt(gguf, arch+".block_count"),
+		NumAttentionHeads: ggufInt(gguf, arch+".attention.head_count"),
+		NumKeyValueHeads:  ggufInt(gguf, arch+".attention.head_count_kv"),
+		HeadDim:           ggufInt(gguf, arch+".attention.key_length"),
+		KVLoraRank:        ggufInt(gguf, arch+".attention.kv_lora_rank"),
+		QKRopeHeadDim:     ggufInt(gguf, arch+".rope.dimension_count"),
+	}
+	if tokens, ok := gguf.Metadata["tokenizer.ggml.tokens"].(GGUFArray); ok {
+		config.dimensions.VocabSize = int(tokens.Len)
+	}
+	_, hasPooling := gguf.Metadata[arch+".pooling_type"]
+	config.isEmbedding = hasPooling || strings.Contains(arch, "bert")
+
+	for i, shard := range shards {
+		info, err := os.Stat(shard)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GGUF shard '%s': %w", shard, err)
+		}
+		config.sizeBytes += info.Size()
+		if i == 0 {
+			continue
+		}
+		shardGGUF, err := ParseGGUF(shard)
+		if err != nil {
+			return nil, err
+		}
+		config.parameterCount += shardGGUF.ParameterCount()
+		config.expertParameterCount += ggufExpertParameterCount(shardGGUF)
+	}
+
+	// The vision encoder of multimodal models is a separate projector file
+	projectors, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*mmproj*.gguf"))
+	config.hasVision = len(projectors) > 0
+
+	return config, nil
+}
+
+// ggufInt returns an integer metadata value, 0 when it is missing or is an array, e.g. the per-layer key-value
+// heads of some architectures
+func ggufInt(gguf *GGUFFile, key string) int {
+	v, _ := gguf.UintValue(key)
+	return int(v)
+}
+
+// ggufExpertParameterCount counts the parameters of the routed experts, which llama.cpp stacks in the ffn_*_exps
+// tensors of every layer
+func ggufExpertParameterCount(gguf *GGUFFile) int64 {
+	var total int64
+	for _, tensor := range gguf.Tensors {
+		if strings.Contains(tensor.Name, "_exps.") {
+			total += tensor.Elements()
+		}
+	}
+	return total
+}
+
+// ggufShards returns the files of a GGUF model, the file itself unless it is a shard of a split model
+func ggufShards(path string) ([]string, error) {
+	match := ggufShardPattern.FindStringSubmatch(filepath.Base(path))
+	if match == nil {
+		return []string{path}, nil
+	}
+	count, err := strconv.Atoi(match[3])
+	if err != nil || count == 0 {
+		return nil, fmt.Errorf("invalid GGUF shard name '%s'", path)
+	}
+	shards := make([]string, count)
+	for i := range shards {
+		shards[i] = filepath.Join(filepath.Dir(path), fmt.Sprintf("%s-%05d-of-%s.gguf", match[1], i+1, match[3]))
+	}
+	return shards, nil
+}
+
+// FindGGUFFile returns the GGUF model of a directory, the first shard of a split model. Vision projectors
+// (mmproj) are not models. When the directory holds several models, e.g. several quantizations, the first one
+// by name is returned.
+func FindGGUFFile(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list directory '%s': %w", dir, err)
+	}
+
+	var candidates []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(strings.ToLower(name), ".gguf") || strings.Contains(strings.ToLower(name), "mmproj") {
+			continue
+		}
+		if match := ggufShardPattern.FindStringSubmatch(name); match != nil && match[2] != "00001" {
+			continue
+		}
+		candidates = append(candidates, name)
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no .gguf file found in directory '%s'", dir)
+	}
+	sort.Strings(candidates)
+	return filepath.Join(dir, candidates[0]), nil
+}
+
+// IsGGUFFile returns whether a path names a GGUF file
+func IsGGUFFile(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".gguf")
+}
+
+func (c *GGUFModelConfig) GetParameterCount() int64 {
+	return c.parameterCount
+}
+
+// GetNumExperts returns the expert_count of MoE models, 0 for dense models
+func (c *GGUFModelConfig) GetNumExperts() int {
+	return c.numExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, with the expert_used_count
+// experts a token is routed to
+func (c *GGUFModelConfig) GetActiveParameterCount() int64 {
+	if c.numExperts <= 0 || c.numExpertsPerTok <= 0 || c.expertParameterCount == 0 {
+		return c.parameterCount
+	}
+	inactive := c.expertParameterCount / int64(c.numExperts) * int64(c.numExperts-c.numExpertsPerTok)
+	return c.parameterCount - inactive
+}
+
+// GetQuantizationType returns the llama.cpp quantization of the weights, empty when they are not quantized
+func (c *GGUFModelConfig) GetQuantizationType() string {
+	if _, unquantized := ggufUnquantizedDtypes[c.FileType]; unquantized {
+		return ""
+	}
+	return c.FileType
+}
+
+func (c *GGUFModelConfig) GetContextLength() int {
+	return c.contextLength
+}
+
+// GetBitsPerWeight returns the average number of bits per weight of the files of the model, which includes the
+// block scales of the llama.cpp quantizations. It is 0 when the weights are not quantized.
+func (c *GGUFModelConfig) GetBitsPerWeight() float64 {
+	if c.GetQuantizationType() == "" || c.parameterCount == 0 {
+		return 0
+	}
+	return float64(c.sizeBytes) * 8 / float64(c.parameterCount)
+}
+
+// GetModelSizeBytes returns the size of the files of the model
+func (c *GGUFModelConfig) GetModelSizeBytes() int64 {
+	return c.sizeBytes
+}
+
+func (c *GGUFModelConfig) HasVision() bool {
+	return c.hasVision
+}
+
+func (c *GGUFModelConfig) IsEmbedding() bool {
+	return c.isEmbedding
+}
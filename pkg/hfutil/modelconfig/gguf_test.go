@@ -0,0 +1,228 @@
+package modelconfig
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ggufKV is a metadata entry of a test GGUF file
+type ggufKV struct {
+	key   string
+	value any
+}
+
+// writeGGUF writes a GGUF header with the metadata and the tensors, followed by no tensor data
+func writeGGUF(t *testing.T, path string, kvs []ggufKV, tensors []GGUFTensorInfo) {
+	t.Helper()
+	var buf bytes.Buffer
+	le := binary.LittleEndian
+	writeString := func(s string) {
+		_ = binary.Write(&buf, le, uint64(len(s)))
+		buf.WriteString(s)
+	}
+
+	buf.WriteString(ggufMagic)
+	_ = binary.Write(&buf, le, uint32(3))
+	_ = binary.Write(&buf, le, uint64(len(tensors)))
+	_ = binary.Write(&buf, le, uint64(len(kvs)))
+	for _, kv := range kvs {
+		writeString(kv.key)
+		switch v := kv.value.(type) {
+		case string:
+			_ = binary.Write(&buf, le, ggufTypeString)
+			writeString(v)
+		case uint32:
+			_ = binary.Write(&buf, le, ggufTypeUint32)
+			_ = binary.Write(&buf, le, v)
+		case uint64:
+			_ = binary.Write(&buf, le, ggufTypeUint64)
+			_ = binary.Write(&buf, le, v)
+		case float32:
+			_ = binary.Write(&buf, le, ggufTypeFloat32)
+			_ = binary.Write(&buf, le, v)
+		case []string:
+			_ = binary.Write(&buf, le, ggufTypeArray)
+			_ = binary.Write(&buf, le, ggufTypeString)
+			_ = binary.Write(&buf, le, uint64(len(v)))
+			for _, s := range v {
+				writeString(s)
+			}
+		case []int32:
+			_ = binary.Write(&buf, le, ggufTypeArray)
+			_ = binary.Write(&buf, le, ggufTypeInt32)
+			_ = binary.Write(&buf, le, uint64(len(v)))
+			_ = binary.Write(&buf, le, v)
+		default:
+			t.Fatalf("unsupported test value %T", v)
+		}
+	}
+	for _, tensor := range tensors {
+		writeString(tensor.Name)
+		_ = binary.Write(&buf, le, uint32(len(tensor.Dimensions)))
+		_ = binary.Write(&buf, le, tensor.Dimensions)
+		_ = binary.Write(&buf, le, tensor.Type)
+		_ = binary.Write(&buf, le, uint64(0))
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestLoadGGUFModelConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "model.gguf")
+	writeGGUF(t, path, []ggufKV{
+		{"general.architecture", "llama"},
+		{"general.name", "Llama 3.2 1B Instruct"},
+		{"general.file_type", uint32(15)},
+		{"llama.context_length", uint32(131072)},
+		{"llama.rope.freq_base", float32(500000)},
+		{"tokenizer.ggml.tokens", []string{"<s>", "</s>", "hello"}},
+		{"tokenizer.ggml.token_type", []int32{3, 3, 1}},
+	}, []GGUFTensorInfo{
+		{Name: "token_embd.weight", Dimensions: []uint64{2048, 128256}, Type: 12},
+		{Name: "blk.0.attn_norm.weight", Dimensions: []uint64{2048}, Type: 0},
+	})
+
+	model, err := LoadModelConfig(path)
+	if err != nil {
+		t.Fatalf("LoadModelConfig failed: %v", err)
+	}
+	config, ok := model.(*GGUFModelConfig)
+	if !ok {
+		t.Fatalf("expected *GGUFModelConfig, got %T", model)
+	}
+
+	if config.GetModelType() != "llama" {
+		t.Errorf("expected model type llama, got %s", config.GetModelType())
+	}
+	if config.GetArchitecture() != "LlamaForCausalLM" {
+		t.Errorf("expected architecture LlamaForCausalLM, got %s", config.GetArchitecture())
+	}
+	if config.Name != "Llama 3.2 1B Instruct" {
+		t.Errorf("unexpected name %s", config.Name)
+	}
+	if config.GetQuantizationType() != "Q4_K_M" {
+		t.Errorf("expected quantization Q4_K_M, got %s", config.GetQuantizationType())
+	}
+	if config.GetTorchDtype() != "" {
+		t.Errorf("expected no torch dtype for quantized weights, got %s", config.GetTorchDtype())
+	}
+	if config.GetContextLength() != 131072 {
+		t.Errorf("expected context length 131072, got %d", config.GetContextLength())
+	}
+	if expected := int64(2048*128256 + 2048); config.GetParameterCount() != expected {
+		t.Errorf("expected %d parameters, got %d", expected, config.GetParameterCount())
+	}
+	info, _ := os.Stat(path)
+	if config.GetModelSizeBytes() != info.Size() {
+		t.Errorf("expected size %d, got %d", info.Size(), config.GetModelSizeBytes())
+	}
+	if config.HasVision() || config.IsEmbedding() {
+		t.Errorf("unexpected capabilities: vision=%v embedding=%v", config.HasVision(), config.IsEmbedding())
+	}
+}
+
+func TestLoadGGUFModelConfigMoE(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gguf")
+	writeGGUF(t, path, []ggufKV{
+		{"general.architecture", "qwen3moe"},
+		{"general.file_type", uint32(1)},
+		{"qwen3moe.expert_count", uint32(128)},
+		{"qwen3moe.expert_used_count", uint32(8)},
+	}, []GGUFTensorInfo{
+		{Name: "token_embd.weight", Dimensions: []uint64{2048, 1000}, Type: 1},
+		{Name: "blk.0.ffn_gate_exps.weight", Dimensions: []uint64{2048, 768, 128}, Type: 1},
+		{Name: "blk.0.ffn_up_exps.weight", Dimensions: []uint64{2048, 768, 128}, Type: 1},
+		{Name: "blk.0.ffn_down_exps.weight", Dimensions: []uint64{768, 2048, 128}, Type: 1},
+	})
+
+	model, err := LoadModelConfig(path)
+	if err != nil {
+		t.Fatalf("LoadModelConfig failed: %v", err)
+	}
+	if model.GetNumExperts() != 128 {
+		t.Errorf("expected 128 experts, got %d", model.GetNumExperts())
+	}
+	expectedTotal := int64(2048*1000 + 3*2048*768*128)
+	if model.GetParameterCount() != expectedTotal {
+		t.Errorf("expected %d parameters, got %d", expectedTotal, model.GetParameterCount())
+	}
+	if expected := int64(2048*1000 + 3*2048*768*8); model.GetActiveParameterCount() != expected {
+		t.Errorf("expected %d active parameters, got %d", expected, model.GetActiveParameterCount())
+	}
+}
+
+func TestLoadGGUFModelConfigSharded(t *testing.T) {
+	dir := t.TempDir()
+	writeGGUF(t, filepath.Join(dir, "qwen3-00001-of-00002.gguf"), []ggufKV{
+		{"general.architecture", "qwen3"},
+		{"general.file_type", uint32(1)},
+		{"qwen3.context_length", uint32(40960)},
+		{"split.count", uint32(2)},
+	}, []GGUFTensorInfo{{Name: "token_embd.weight", Dimensions: []uint64{10, 10}, Type: 1}})
+	writeGGUF(t, filepath.Join(dir, "qwen3-00002-of-00002.gguf"), []ggufKV{
+		{"split.count", uint32(2)},
+	}, []GGUFTensorInfo{{Name: "output.weight", Dimensions: []uint64{10, 5}, Type: 1}})
+	writeGGUF(t, filepath.Join(dir, "mmproj-qwen3-f16.gguf"), []ggufKV{
+		{"general.architecture", "clip"},
+	}, nil)
+
+	path, err := FindGGUFFile(dir)
+	if err != nil {
+		t.Fatalf("FindGGUFFile failed: %v", err)
+	}
+	if filepath.Base(path) != "qwen3-00001-of-00002.gguf" {
+		t.Fatalf("expected the first shard, got %s", path)
+	}
+
+	config, err := LoadGGUFModelConfig(filepath.Join(dir, "qwen3-00002-of-00002.gguf"))
+	if err != nil {
+		t.Fatalf("LoadGGUFModelConfig failed: %v", err)
+	}
+	if config.GetParameterCount() != 150 {
+		t.Errorf("expected 150 parameters across shards, got %d", config.GetParameterCount())
+	}
+	if config.GetTorchDtype() != "float16" || config.GetQuantizationType() != "" {
+		t.Errorf("expected unquantized float16 weights, got dtype=%s quantization=%s", config.GetTorchDtype(), config.GetQuantizationType())
+	}
+	if !config.HasVision() {
+		t.Error("expected vision from the mmproj projector")
+	}
+}
+
+func TestParseGGUFErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	notGGUF := filepath.Join(dir, "config.gguf")
+	if err := os.WriteFile(notGGUF, []byte(`{"model_type": "llama"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseGGUF(notGGUF); err == nil {
+		t.Error("expected an error for a file without the GGUF magic")
+	}
+
+	truncated := filepath.Join(dir, "truncated.gguf")
+	writeGGUF(t, truncated, []ggufKV{{"general.architecture", "llama"}}, nil)
+	data, _ := os.ReadFile(truncated)
+	if err := os.WriteFile(truncated, data[:len(data)-3], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseGGUF(truncated); err == nil {
+		t.Error("expected an error for a truncated header")
+	}
+
+	noArch := filepath.Join(dir, "noarch.gguf")
+	writeGGUF(t, noArch, []ggufKV{{"general.name", "test"}}, nil)
+	if _, err := LoadGGUFModelConfig(noArch); err == nil {
+		t.Error("expected an error without general.architecture")
+	}
+
+	if _, err := FindGGUFFile(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without GGUF files")
+	}
+}
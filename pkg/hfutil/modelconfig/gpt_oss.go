@@ -151,6 +151,22 @@ func (c *GptOssConfig) GetParameterCount() int64 {
 	return c.estimateGptOssParams()
 }
 
+// GetNumExperts returns the number of experts of each layer
+func (c *GptOssConfig) GetNumExperts() int {
+	return c.NumLocalExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, with the experts a token is
+// routed to
+func (c *GptOssConfig) GetActiveParameterCount() int64 {
+	expertsPerTok := c.NumExpertsPerTok
+	if expertsPerTok <= 0 {
+		expertsPerTok = c.ExpertsPerToken
+	}
+	return activeMoEParams(c.GetParameterCount(), c.NumHiddenLayers, c.NumLocalExperts, expertsPerTok,
+		int64(3*c.HiddenSize*c.IntermediateSize))
+}
+
 // estimateGptOssParams estimates parameters for GPT-OSS MoE architecture
 func (c *GptOssConfig) estimateGptOssParams() int64 {
 	// Basic transformer parameters (shared layers)
@@ -194,6 +210,14 @@ func (c *GptOssConfig) GetQuantizationType() string {
 	return ""
 }
 
+// GetBitsPerWeight returns the number of bits of the quantized weights, 4 for the MXFP4 checkpoints
+func (c *GptOssConfig) GetBitsPerWeight() float64 {
+	if c.QuantizationConfig != nil {
+		return (&QuantizationConfig{QuantMethod: c.QuantizationConfig.QuantMethod}).BitsPerWeight()
+	}
+	return 0
+}
+
 // GetArchitecture returns the model architecture
 func (c *GptOssConfig) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
@@ -17,12 +17,23 @@ type HuggingFaceModel interface {
 	// GetParameterCount returns the total number of parameters in the model
 	GetParameterCount() int64
 
+	// GetActiveParameterCount returns the number of parameters used for each token: the shared layers and the
+	// experts a token is routed to for Mixture-of-Experts models, all the parameters for dense models
+	GetActiveParameterCount() int64
+
+	// GetNumExperts returns the number of routed experts of each layer of Mixture-of-Experts models, 0 for dense
+	// models
+	GetNumExperts() int
+
 	// GetTransformerVersion returns the transformers library version used for this model
 	GetTransformerVersion() string
 
 	// GetQuantizationType returns the quantization method used (if any)
 	GetQuantizationType() string
 
+	// GetBitsPerWeight returns the number of bits of the quantized weights, 0 when they are not quantized
+	GetBitsPerWeight() float64
+
 	// GetArchitecture returns the model architecture (e.g., "LlamaForCausalLM")
 	GetArchitecture() string
 
@@ -51,6 +62,12 @@ type HuggingFaceDiffusionModel interface {
 	GetDiffusionModel() *DiffusionPipelineSpec
 }
 
+// HuggingFaceVisionModel represents multimodal models that expose their vision tower.
+type HuggingFaceVisionModel interface {
+	HuggingFaceModel
+	GetVisionTower() *VisionTowerSpec
+}
+
 // AutoMap defines the mapping of model classes for custom Hugging Face models
 // This is used when models require custom code (e.g., models with "trust_remote_code=True")
 type AutoMap struct {
@@ -67,6 +84,9 @@ type BaseModelConfig struct {
 	TorchDtype         string   `json:"torch_dtype"`
 	TransformerVersion string   `json:"transformers_version"`
 
+	// Quantization config of pre-quantized checkpoints (optional)
+	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
+
 	// Internal fields (not in JSON)
 	ConfigPath string `json:"-"`
 }
@@ -91,6 +111,26 @@ func (c *BaseModelConfig) GetTorchDtype() string {
 	return c.TorchDtype
 }
 
+// configFile returns the path of the config file the model was loaded from
+func (c *BaseModelConfig) configFile() string {
+	return c.ConfigPath
+}
+
+// GetQuantizationType returns the quant_method of the quantization config, empty when the model is not quantized
+func (c *BaseModelConfig) GetQuantizationType() string {
+	return c.QuantizationConfig.Method()
+}
+
+// GetBitsPerWeight returns the number of bits of the weights of the quantization config
+func (c *BaseModelConfig) GetBitsPerWeight() float64 {
+	return c.QuantizationConfig.BitsPerWeight()
+}
+
+// GetNumExperts returns 0, most models are dense
+func (c *BaseModelConfig) GetNumExperts() int {
+	return 0
+}
+
 // Default implementation for HasVision - most models don't have vision capabilities
 func (c *BaseModelConfig) HasVision() bool {
 	return false
@@ -102,12 +142,68 @@ func (c *BaseModelConfig) IsEmbedding() bool {
 	return false
 }
 
-// QuantizationConfig defines the structure for quantization settings
+// QuantizationConfig defines the structure for quantization settings. It covers the quantization_config of
+// the FP8, GPTQ, AWQ and bitsandbytes checkpoints.
 type QuantizationConfig struct {
-	ActivationScheme string `json:"activation_scheme"`
-	Format           string `json:"fmt"`
-	QuantMethod      string `json:"quant_method"`
-	WeightBlockSize  []int  `json:"weight_block_size"`
+	ActivationScheme    string   `json:"activation_scheme"`
+	Format              string   `json:"fmt"`
+	QuantMethod         string   `json:"quant_method"`
+	WeightBlockSize     []int    `json:"weight_block_size"`
+	ActivationScaleUb   float64  `json:"activation_scale_ub"`
+	ModulesToNotConvert []string `json:"modules_to_not_convert"`
+
+	// GPTQ and AWQ settings
+	Bits      int    `json:"bits"`
+	WBit      int    `json:"w_bit"` // AWQ checkpoints exported by AutoAWQ
+	GroupSize int    `json:"group_size"`
+	Version   string `json:"version"`
+
+	// bitsandbytes settings
+	LoadIn4Bit       bool   `json:"load_in_4bit"`
+	LoadIn8Bit       bool   `json:"load_in_8bit"`
+	BnB4BitQuantType string `json:"bnb_4bit_quant_type"`
+}
+
+// Method returns the quantization method, empty for a nil config
+func (q *QuantizationConfig) Method() string {
+	if q == nil {
+		return ""
+	}
+	if q.QuantMethod == "" && (q.LoadIn4Bit || q.LoadIn8Bit) {
+		// Checkpoints saved before transformers recorded quant_method for bitsandbytes
+		return "bitsandbytes"
+	}
+	return q.QuantMethod
+}
+
+// BitsPerWeight returns the number of bits of the quantized weights, 0 for a nil config or when it can't be
+// determined. The group scales and zero points are not accounted for.
+func (q *QuantizationConfig) BitsPerWeight() float64 {
+	if q == nil {
+		return 0
+	}
+	switch {
+	case q.Bits > 0:
+		return float64(q.Bits)
+	case q.WBit > 0:
+		return float64(q.WBit)
+	case q.LoadIn4Bit:
+		return 4
+	case q.LoadIn8Bit:
+		return 8
+	}
+
+	method := strings.ToLower(q.Method())
+	switch {
+	case strings.Contains(method, "fp8"), strings.Contains(strings.ToLower(q.Format), "e4m3"):
+		return 8
+	case strings.Contains(method, "fp4"):
+		return 4
+	case method == "bitsandbytes":
+		// Defaults of transformers when neither load_in_4bit nor load_in_8bit is set
+		return 8
+	}
+	return 0
 }
 
 // RopeScalingConfig defines the structure for RoPE (Rotary Position Embedding) scaling
@@ -122,6 +218,10 @@ type RopeScalingConfig struct {
 	MScale                        float64 `json:"mscale,omitempty"`
 	MScaleAllDim                  float64 `json:"mscale_all_dim,omitempty"`
 	OriginalMaxPositionEmbeddings int     `json:"original_max_position_embeddings"`
+
+	// LongRoPE rescaling factors of the short and long contexts
+	ShortFactor []float64 `json:"short_factor,omitempty"`
+	LongFactor  []float64 `json:"long_factor,omitempty"`
 }
 
 // DtypeSizeBytes maps torch data types to their size in bytes per parameter
@@ -217,6 +317,15 @@ func EstimateModelSizeBytes(paramCount int64, dtype string) int64 {
 	return int64(float64(paramCount) * sizePerParam)
 }
 
+// EstimateQuantizedModelSizeBytes estimates the size in bytes of a model whose weights may be quantized: the
+// bits per weight are used when they are known, the data type otherwise.
+func EstimateQuantizedModelSizeBytes(paramCount int64, dtype string, bitsPerWeight float64) int64 {
+	if bitsPerWeight <= 0 {
+		return EstimateModelSizeBytes(paramCount, dtype)
+	}
+	return int64(float64(paramCount) * bitsPerWeight / 8)
+}
+
 // Map of model keys to model loader functions with thread-safe access.
 // For transformer-style config.json, the key is model_type.
 // For diffusion model_index.json, the key is the pipeline class name.
@@ -245,13 +354,15 @@ type GenericModelConfig struct {
 	// MoE fields (populated from top-level or nested config)
 	NRoutedExperts      int `json:"n_routed_experts"`
 	NSharedExperts      int `json:"n_shared_experts"`
+	NumExpertsPerTok    int `json:"num_experts_per_tok"`
 	MoeIntermediateSize int `json:"moe_intermediate_size"`
 
-	// Quantization config (optional)
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
-
 	// Set during loading when vision sub-config is detected
 	hasVisionConfig bool
+
+	// Keys of the config renamed to the ones of the generic config, set for the model types registered with
+	// RegisterGenericModelLoader
+	fieldMapping GenericFieldMapping
 }
 
 // GetParameterCount attempts to get parameter count from safetensors, falls back to estimation
@@ -276,6 +387,22 @@ func (c *GenericModelConfig) GetParameterCount() int64 {
 	return 0
 }
 
+// GetNumExperts returns the number of routed experts of the MoE models, 0 for the dense models
+func (c *GenericModelConfig) GetNumExperts() int {
+	return c.NRoutedExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token. For the MoE models, it includes
+// the shared experts and the num_experts_per_tok routed experts.
+func (c *GenericModelConfig) GetActiveParameterCount() int64 {
+	moeIntermediateSize := c.MoeIntermediateSize
+	if moeIntermediateSize == 0 {
+		moeIntermediateSize = c.IntermediateSize
+	}
+	return activeMoEParams(c.GetParameterCount(), c.NumHiddenLayers, c.NRoutedExperts, c.NumExpertsPerTok,
+		int64(3*c.HiddenSize*moeIntermediateSize))
+}
+
 // estimateGenericParams provides a rough parameter estimate for transformer models
 func estimateGenericParams(hiddenSize, numLayers, intermediateSize, vocabSize int) int64 {
 	if intermediateSize == 0 {
@@ -319,6 +446,20 @@ func estimateMoEParams(hiddenSize, numLayers, intermediateSize, moeIntermediateS
 	return params
 }
 
+// activeMoEParams returns the number of parameters of a Mixture-of-Experts model of totalParams parameters used for
+// each token, leaving out the routed experts a token is not sent to. expertParams is the size of one routed
+// expert. It returns totalParams when the experts are unknown.
+func activeMoEParams(totalParams int64, moeLayers, numExperts, expertsPerTok int, expertParams int64) int64 {
+	if numExperts <= 0 || expertsPerTok <= 0 || expertsPerTok >= numExperts {
+		return totalParams
+	}
+	inactive := int64(moeLayers) * int64(numExperts-expertsPerTok) * expertParams
+	if inactive <= 0 || inactive >= totalParams {
+		return totalParams
+	}
+	return totalParams - inactive
+}
+
 // nestedLLMConfigKeys lists the JSON keys under which multimodal models
 // commonly store their language/LLM sub-configuration.
 var nestedLLMConfigKeys = []string{"text_config", "llm_config", "language_config"}
@@ -352,6 +493,7 @@ func probeNestedConfig(data []byte, config *GenericModelConfig) {
 			NumLocalExperts     int `json:"num_local_experts"`
 			NumExperts          int `json:"num_experts"`
 			NSharedExperts      int `json:"n_shared_experts"`
+			NumExpertsPerTok    int `json:"num_experts_per_tok"`
 			MoeIntermediateSize int `json:"moe_intermediate_size"`
 		}
 		if err := json.Unmarshal(sub, &nested); err != nil {
@@ -397,6 +539,9 @@ func probeNestedConfig(data []byte, config *GenericModelConfig) {
 		if config.NSharedExperts == 0 {
 			config.NSharedExperts = nested.NSharedExperts
 		}
+		if config.NumExpertsPerTok == 0 {
+			config.NumExpertsPerTok = nested.NumExpertsPerTok
+		}
 		if config.MoeIntermediateSize == 0 {
 			config.MoeIntermediateSize = nested.MoeIntermediateSize
 		}
@@ -426,13 +571,6 @@ func probeNestedConfig(data []byte, config *GenericModelConfig) {
 	}
 }
 
-func (c *GenericModelConfig) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 func (c *GenericModelConfig) GetContextLength() int {
 	if c.MaxPositionEmbeddings > 0 {
 		return c.MaxPositionEmbeddings
@@ -536,6 +674,11 @@ func (c *GenericDiffusionModelConfig) GetParameterCount() int64 {
 	return total
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *GenericDiffusionModelConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 func (c *GenericDiffusionModelConfig) GetQuantizationType() string {
 	// Not supported. Doesn't seem to be standardized in HF.
 	return ""
@@ -582,9 +725,79 @@ func (c *GenericDiffusionModelConfig) IsEmbedding() bool {
 	return false
 }
 
+// GenericFieldMapping maps the keys the generic config reads, e.g. "hidden_size", to the keys a model family saves
+// them under, e.g. "d_model"
+type GenericFieldMapping map[string]string
+
+// genericFieldAliases are the keys other model families commonly save the fields of the generic config under, e.g.
+// the GPT-2 style n_embd and n_layer. The first key present fills a field the config doesn't set.
+var genericFieldAliases = map[string][]string{
+	"hidden_size":             {"n_embd", "d_model"},
+	"num_hidden_layers":       {"n_layer", "n_layers", "num_layers"},
+	"num_attention_heads":     {"n_head", "n_heads", "num_heads"},
+	"intermediate_size":       {"n_inner", "ffn_hidden_size", "d_ff"},
+	"max_position_embeddings": {"n_positions", "seq_length", "max_seq_len"},
+}
+
+// renameConfigFields renames the keys of a config to the keys of the generic config, with the mapping of its model
+// type first and the common aliases second. Keys the config already sets are left as they are.
+func renameConfigFields(data []byte, mapping GenericFieldMapping) []byte {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return data
+	}
+
+	renamed := false
+	rename := func(field, key string) bool {
+		if _, ok := raw[field]; ok {
+			return true
+		}
+		value, ok := raw[key]
+		if ok {
+			raw[field] = value
+			renamed = true
+		}
+		return ok
+	}
+	for field, key := range mapping {
+		rename(field, key)
+	}
+	for field, keys := range genericFieldAliases {
+		for _, key := range keys {
+			if rename(field, key) {
+				break
+			}
+		}
+	}
+
+	if !renamed {
+		return data
+	}
+	renamedData, err := json.Marshal(raw)
+	if err != nil {
+		return data
+	}
+	return renamedData
+}
+
+// RegisterGenericModelLoader registers a loader for a model type that reads its config with the generic config,
+// after renaming its keys with a mapping. It lets model families without a dedicated parser, e.g. out-of-tree or
+// newly released ones, be loaded with their fields under the names the generic config reads.
+func RegisterGenericModelLoader(modelType string, mapping GenericFieldMapping) {
+	RegisterModelLoader(modelType, func(configPath string) (HuggingFaceModel, error) {
+		return loadGenericModelConfigWithMapping(configPath, mapping)
+	})
+}
+
 // loadGenericModelConfig loads a generic model configuration as a fallback.
 // It also handles diffusers model_index.json files when model_type is absent.
 func loadGenericModelConfig(configPath string) (HuggingFaceModel, error) {
+	return loadGenericModelConfigWithMapping(configPath, nil)
+}
+
+// loadGenericModelConfigWithMapping loads a generic model configuration, renaming the keys of the config with a
+// mapping
+func loadGenericModelConfigWithMapping(configPath string, mapping GenericFieldMapping) (HuggingFaceModel, error) {
 	if filepath.Base(configPath) == "model_index.json" {
 		pipeline, err := LoadDiffusionPipelineSpec(configPath)
 		if err != nil {
@@ -610,7 +823,7 @@ func loadGenericModelConfig(configPath string) (HuggingFaceModel, error) {
 
 	// Sanitize JSON to handle non-standard values like Infinity, -Infinity, NaN
 	// which are valid in Python but not in standard JSON
-	data = SanitizeJSONBytes(data)
+	data = renameConfigFields(SanitizeJSONBytes(data), mapping)
 
 	var config GenericModelConfig
 	if err := json.Unmarshal(data, &config); err != nil {
@@ -618,6 +831,7 @@ func loadGenericModelConfig(configPath string) (HuggingFaceModel, error) {
 	}
 
 	config.ConfigPath = configPath
+	config.fieldMapping = mapping
 
 	// Probe nested sub-configs (text_config, llm_config, language_config)
 	// to fill zero-valued fields for multimodal models
@@ -627,8 +841,10 @@ func loadGenericModelConfig(configPath string) (HuggingFaceModel, error) {
 }
 
 // RegisterModelLoader safely registers a model loader function for a given model key.
-// For transformer-style config.json, the key is model_type.
+// For transformer-style config.json, the key is model_type, or the architecture for configs without model_type.
 // For diffusion model_index.json, the key is the pipeline class name.
+// Loaders registered by other packages take precedence over the generic fallback, and replace the built-in loader
+// of a model key they are registered for.
 func RegisterModelLoader(modelKey string, loader func(string) (HuggingFaceModel, error)) {
 	if modelKey == "" {
 		panic("model key cannot be empty")
@@ -684,7 +900,7 @@ func SanitizeJSONBytes(data []byte) []byte {
 	return []byte(s)
 }
 
-// LoadModelConfig loads a model configuration from a config.json or model_index.json file
+// LoadModelConfig loads a model configuration from a config.json or model_index.json file, or from a GGUF file
 // and returns the appropriate model implementation based on the model key:
 // - transformer config.json: "model_type"
 // - diffusion model_index.json: "_class_name" (pipeline class name)
@@ -708,6 +924,11 @@ func LoadModelConfig(configPath string) (HuggingFaceModel, error) {
 		return nil, fmt.Errorf("config path cannot be empty")
 	}
 
+	// GGUF models carry their configuration in the header of their weights
+	if IsGGUFFile(configPath) {
+		return LoadGGUFModelConfig(configPath)
+	}
+
 	// Read the config file to determine model type
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -720,7 +941,8 @@ func LoadModelConfig(configPath string) (HuggingFaceModel, error) {
 
 	// Extract the model_type field
 	var baseConfig struct {
-		ModelType string `json:"model_type"`
+		ModelType     string   `json:"model_type"`
+		Architectures []string `json:"architectures"`
 	}
 
 	if err := json.Unmarshal(data, &baseConfig); err != nil {
@@ -739,8 +961,13 @@ func LoadModelConfig(configPath string) (HuggingFaceModel, error) {
 		}
 	}
 
+	if modelKey == "" && len(baseConfig.Architectures) > 0 {
+		// Configs saved without model_type, e.g. by remote code, are looked up by their architecture
+		modelKey = baseConfig.Architectures[0]
+	}
+
 	if modelKey == "" {
-		return nil, fmt.Errorf("model_type or _class_name field is missing or empty in config file '%s'", configPath)
+		return nil, fmt.Errorf("model_type, _class_name or architectures field is missing or empty in config file '%s'", configPath)
 	}
 
 	// Load using registered model loaders (thread-safe access)
@@ -753,7 +980,7 @@ func LoadModelConfig(configPath string) (HuggingFaceModel, error) {
 		// Log a warning but still return useful data
 		log.Printf("Warning: model type '%s' is not fully supported, using generic config. "+
 			"Parameter count will be estimated from safetensors or architecture.",
-			baseConfig.ModelType)
+			modelKey)
 
 		return loadGenericModelConfig(configPath)
 	}
@@ -529,3 +529,187 @@ func TestGenericContextLengthMaxSequenceLength(t *testing.T) {
 		t.Error("Expected HasVision() to return false for non-multimodal config")
 	}
 }
+
+func TestQuantizationConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		config         *QuantizationConfig
+		expectedMethod string
+		expectedBits   float64
+	}{
+		{"not quantized", nil, "", 0},
+		{"gptq", &QuantizationConfig{QuantMethod: "gptq", Bits: 4, GroupSize: 128}, "gptq", 4},
+		{"awq", &QuantizationConfig{QuantMethod: "awq", WBit: 4}, "awq", 4},
+		{"bitsandbytes 4-bit", &QuantizationConfig{QuantMethod: "bitsandbytes", LoadIn4Bit: true, BnB4BitQuantType: "nf4"}, "bitsandbytes", 4},
+		{"bitsandbytes without quant_method", &QuantizationConfig{LoadIn8Bit: true}, "bitsandbytes", 8},
+		{"fp8", &QuantizationConfig{QuantMethod: "fp8", Format: "e4m3"}, "fp8", 8},
+		{"fbgemm fp8", &QuantizationConfig{QuantMethod: "fbgemm_fp8"}, "fbgemm_fp8", 8},
+		{"mxfp4", &QuantizationConfig{QuantMethod: "mxfp4"}, "mxfp4", 4},
+		{"unknown bits", &QuantizationConfig{QuantMethod: "compressed-tensors"}, "compressed-tensors", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if method := tc.config.Method(); method != tc.expectedMethod {
+				t.Errorf("Expected method %q but got %q", tc.expectedMethod, method)
+			}
+			if bits := tc.config.BitsPerWeight(); bits != tc.expectedBits {
+				t.Errorf("Expected %v bits per weight but got %v", tc.expectedBits, bits)
+			}
+		})
+	}
+}
+
+func TestLoadQuantizedModelConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"architectures": ["MistralForCausalLM"],
+		"model_type": "mistral",
+		"torch_dtype": "float16",
+		"hidden_size": 4096,
+		"num_hidden_layers": 32,
+		"num_attention_heads": 32,
+		"num_key_value_heads": 8,
+		"intermediate_size": 14336,
+		"max_position_embeddings": 32768,
+		"vocab_size": 32000,
+		"quantization_config": {
+			"bits": 4,
+			"group_size": 128,
+			"desc_act": false,
+			"quant_method": "gptq"
+		}
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.GetQuantizationType() != "gptq" {
+		t.Errorf("Expected quantization type 'gptq', got '%s'", config.GetQuantizationType())
+	}
+	if config.GetBitsPerWeight() != 4 {
+		t.Errorf("Expected 4 bits per weight, got %v", config.GetBitsPerWeight())
+	}
+
+	if size := EstimateQuantizedModelSizeBytes(7_000_000_000, "float16", config.GetBitsPerWeight()); size != 3_500_000_000 {
+		t.Errorf("Expected a quantized size of 3.5GB, got %d", size)
+	}
+	if size := EstimateQuantizedModelSizeBytes(7_000_000_000, "float16", 0); size != 14_000_000_000 {
+		t.Errorf("Expected an unquantized size of 14GB, got %d", size)
+	}
+}
+
+func TestGenericFieldAliases(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "gpt2_like",
+		"architectures": ["GPT2LikeLMHeadModel"],
+		"n_embd": 768,
+		"n_layer": 12,
+		"n_head": 12,
+		"n_positions": 1024,
+		"vocab_size": 50257
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	generic, ok := config.(*GenericModelConfig)
+	if !ok {
+		t.Fatalf("Expected a generic config, got %T", config)
+	}
+	if generic.HiddenSize != 768 || generic.NumHiddenLayers != 12 || generic.NumAttentionHeads != 12 {
+		t.Errorf("Expected 12 layers of 768 dims and 12 heads, got %d layers of %d dims and %d heads",
+			generic.NumHiddenLayers, generic.HiddenSize, generic.NumAttentionHeads)
+	}
+	if config.GetContextLength() != 1024 {
+		t.Errorf("Expected context length 1024 from n_positions, got %d", config.GetContextLength())
+	}
+}
+
+func TestRegisterGenericModelLoader(t *testing.T) {
+	RegisterGenericModelLoader("custom_lm", GenericFieldMapping{
+		"hidden_size":             "embed_dim",
+		"num_hidden_layers":       "depth",
+		"num_attention_heads":     "attn_heads",
+		"max_position_embeddings": "context_window",
+	})
+	t.Cleanup(func() {
+		modelLoadersMu.Lock()
+		delete(modelLoaders, "custom_lm")
+		modelLoadersMu.Unlock()
+	})
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "custom_lm",
+		"architectures": ["CustomLMForCausalLM"],
+		"embed_dim": 2048,
+		"depth": 24,
+		"attn_heads": 16,
+		"context_window": 65536,
+		"vocab_size": 32000
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.GetContextLength() != 65536 {
+		t.Errorf("Expected context length 65536, got %d", config.GetContextLength())
+	}
+	if config.GetParameterCount() <= 0 {
+		t.Errorf("Expected a parameter count estimated from the mapped fields, got %d", config.GetParameterCount())
+	}
+	dims, err := GetModelDimensions(config)
+	if err != nil {
+		t.Fatalf("GetModelDimensions failed: %v", err)
+	}
+	if dims.NumHiddenLayers != 24 || dims.HeadDim != 128 {
+		t.Errorf("Expected 24 layers with 128 dims per head, got %d layers with %d dims", dims.NumHiddenLayers, dims.HeadDim)
+	}
+}
+
+func TestLoadModelConfigByArchitecture(t *testing.T) {
+	RegisterModelLoader("RemoteCodeForCausalLM", func(configPath string) (HuggingFaceModel, error) {
+		return LoadLlamaConfig(configPath)
+	})
+	t.Cleanup(func() {
+		modelLoadersMu.Lock()
+		delete(modelLoaders, "RemoteCodeForCausalLM")
+		modelLoadersMu.Unlock()
+	})
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"architectures": ["RemoteCodeForCausalLM"],
+		"hidden_size": 2048,
+		"num_hidden_layers": 16,
+		"num_attention_heads": 32,
+		"num_key_value_heads": 8,
+		"max_position_embeddings": 8192,
+		"vocab_size": 32000
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if _, ok := config.(*LlamaConfig); !ok {
+		t.Errorf("Expected the loader registered for the architecture, got %T", config)
+	}
+}
@@ -79,6 +79,11 @@ func (c *InternLMConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *InternLMConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *InternLMConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -89,11 +94,6 @@ func (c *InternLMConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *InternLMConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for InternLM base models
 func (c *InternLMConfig) HasVision() bool {
 	return false
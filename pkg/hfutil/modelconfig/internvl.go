@@ -0,0 +1,201 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// InternVLTextConfig defines the language model configuration of InternVL
+type InternVLTextConfig struct {
+	Architectures         []string `json:"architectures,omitempty"`
+	ModelType             string   `json:"model_type"`
+	HiddenSize            int      `json:"hidden_size"`
+	IntermediateSize      int      `json:"intermediate_size"`
+	NumHiddenLayers       int      `json:"num_hidden_layers"`
+	NumAttentionHeads     int      `json:"num_attention_heads"`
+	NumKeyValueHeads      int      `json:"num_key_value_heads"`
+	MaxPositionEmbeddings int      `json:"max_position_embeddings"`
+	VocabSize             int      `json:"vocab_size"`
+	TorchDtype            string   `json:"torch_dtype,omitempty"`
+}
+
+// InternVLVisionConfig defines the InternViT vision encoder configuration. The transformers checkpoints save the
+// image and patch sizes as [height, width].
+type InternVLVisionConfig struct {
+	ModelType         string     `json:"model_type"`
+	ImageSize         visionSize `json:"image_size"`
+	PatchSize         visionSize `json:"patch_size"`
+	HiddenSize        int        `json:"hidden_size"`
+	IntermediateSize  int        `json:"intermediate_size"`
+	NumHiddenLayers   int        `json:"num_hidden_layers"`
+	NumAttentionHeads int        `json:"num_attention_heads"`
+}
+
+// InternVLConfig defines the configuration for InternVL multimodal models, both the remote code checkpoints
+// (internvl_chat), which keep the language model under llm_config, and the transformers ones (internvl), which
+// keep it under text_config
+type InternVLConfig struct {
+	BaseModelConfig
+
+	// Model components
+	LLMConfig    *InternVLTextConfig  `json:"llm_config,omitempty"`
+	TextConfig   *InternVLTextConfig  `json:"text_config,omitempty"`
+	VisionConfig InternVLVisionConfig `json:"vision_config"`
+
+	// Pixel shuffle of the vision features, 0.5 merges 2x2 patches into a token
+	DownsampleRatio float64 `json:"downsample_ratio"`
+
+	// Dynamic tiling of the images into tiles of force_image_size pixels
+	ForceImageSize   int  `json:"force_image_size,omitempty"`
+	DynamicImageSize bool `json:"dynamic_image_size"`
+	MinDynamicPatch  int  `json:"min_dynamic_patch,omitempty"`
+	MaxDynamicPatch  int  `json:"max_dynamic_patch,omitempty"`
+	UseThumbnail     bool `json:"use_thumbnail"`
+
+	// Special tokens
+	ImageSeqLength int `json:"image_seq_length,omitempty"`
+	ImageTokenId   int `json:"image_token_id,omitempty"`
+
+	// Chat template of the remote code checkpoints
+	Template string `json:"template,omitempty"`
+}
+
+// defaultInternVLMaxTiles is the max_dynamic_patch InternVL tiles the images to by default
+const defaultInternVLMaxTiles = 12
+
+// LoadInternVLConfig loads an InternVL model configuration from a JSON file
+func LoadInternVLConfig(configPath string) (*InternVLConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read InternVL config file '%s': %w", configPath, err)
+	}
+
+	var config InternVLConfig
+	if err := json.Unmarshal(SanitizeJSONBytes(data), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse InternVL config JSON from '%s': %w", configPath, err)
+	}
+
+	config.ConfigPath = configPath
+	return &config, nil
+}
+
+// textConfig returns the language model configuration of either layout
+func (c *InternVLConfig) textConfig() InternVLTextConfig {
+	if c.LLMConfig != nil {
+		return *c.LLMConfig
+	}
+	if c.TextConfig != nil {
+		return *c.TextConfig
+	}
+	return InternVLTextConfig{}
+}
+
+// Implementation of HuggingFaceModel interface
+
+// GetParameterCount returns the total number of parameters in the model
+func (c *InternVLConfig) GetParameterCount() int64 {
+	// First try to get parameter count from safetensors files
+	count, err := FindAndParseSafetensors(c.ConfigPath)
+	if err == nil {
+		return count
+	}
+
+	// Log the error
+	fmt.Printf("Warning: failed to get parameter count from safetensors: %v\n", err)
+
+	tc := c.textConfig()
+	vc := c.VisionConfig
+	totalParams := estimateModelParams(tc.HiddenSize, tc.NumHiddenLayers, tc.IntermediateSize, tc.VocabSize)
+
+	// InternViT parameters
+	totalParams += int64(vc.NumHiddenLayers) * int64(4*vc.HiddenSize*vc.HiddenSize+2*vc.HiddenSize*vc.IntermediateSize)
+
+	// MLP projector from the pixel shuffled vision features to the language model
+	if c.DownsampleRatio > 0 {
+		projectorInput := int64(float64(vc.HiddenSize) / (c.DownsampleRatio * c.DownsampleRatio))
+		totalParams += projectorInput*int64(tc.HiddenSize) + int64(tc.HiddenSize*tc.HiddenSize)
+	}
+
+	return totalParams
+}
+
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *InternVLConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
+// GetArchitecture returns the model architecture
+func (c *InternVLConfig) GetArchitecture() string {
+	if len(c.Architectures) > 0 {
+		return c.Architectures[0]
+	}
+	return "InternVLChatModel"
+}
+
+// GetContextLength returns the maximum context length supported by the model
+func (c *InternVLConfig) GetContextLength() int {
+	return c.textConfig().MaxPositionEmbeddings
+}
+
+// GetTorchDtype returns the torch data type used by the model
+func (c *InternVLConfig) GetTorchDtype() string {
+	if c.TorchDtype != "" {
+		return c.TorchDtype
+	}
+	return c.textConfig().TorchDtype
+}
+
+// GetModelSizeBytes returns the estimated size of the model in bytes
+func (c *InternVLConfig) GetModelSizeBytes() int64 {
+	return EstimateModelSizeBytes(c.GetParameterCount(), c.GetTorchDtype())
+}
+
+// HasVision returns true for InternVL models
+func (c *InternVLConfig) HasVision() bool {
+	return true
+}
+
+// IsEmbedding returns false since this is not an embedding model
+func (c *InternVLConfig) IsEmbedding() bool {
+	return false
+}
+
+// GetVisionTower returns the vision encoder and the number of tokens of the images. InternVL splits the images into
+// up to max_dynamic_patch tiles, plus a thumbnail of the whole image, and pixel shuffles the features of each tile.
+func (c *InternVLConfig) GetVisionTower() *VisionTowerSpec {
+	vc := c.VisionConfig
+	spec := &VisionTowerSpec{
+		ModelType:       vc.ModelType,
+		ImageSize:       firstNonZero(c.ForceImageSize, int(vc.ImageSize)),
+		PatchSize:       int(vc.PatchSize),
+		HiddenSize:      vc.HiddenSize,
+		NumHiddenLayers: vc.NumHiddenLayers,
+		ImageTokens:     c.ImageSeqLength,
+	}
+	if spec.ImageTokens == 0 && spec.ImageSize > 0 && spec.PatchSize > 0 && c.DownsampleRatio > 0 {
+		side := float64(spec.ImageSize/spec.PatchSize) * c.DownsampleRatio
+		spec.ImageTokens = int(side * side)
+	}
+
+	// The transformers processor always tiles the images, the remote code only with dynamic_image_size
+	tiles := 1
+	if c.DynamicImageSize || c.ModelType == "internvl" {
+		tiles = firstNonZero(c.MaxDynamicPatch, defaultInternVLMaxTiles)
+		if c.UseThumbnail || c.ModelType == "internvl" {
+			tiles++
+		}
+	}
+	spec.MaxImageTokens = spec.ImageTokens * tiles
+	return spec
+}
+
+// Register the InternVL model handlers
+func init() {
+	RegisterModelLoader("internvl_chat", func(configPath string) (HuggingFaceModel, error) {
+		return LoadInternVLConfig(configPath)
+	})
+	RegisterModelLoader("internvl", func(configPath string) (HuggingFaceModel, error) {
+		return LoadInternVLConfig(configPath)
+	})
+}
@@ -0,0 +1,90 @@
+package modelconfig
+
+import (
+	"testing"
+)
+
+func TestLoadInternVLConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		configPath     string
+		expectedType   string
+		expectedArch   string
+		minParams      int64
+		maxParams      int64
+		contextLength  int
+		maxImageTokens int
+	}{
+		{
+			// 12 tiles of 448x448 pixels and a thumbnail, 256 tokens each
+			name:           "InternVL2.5 8B remote code",
+			configPath:     "testdata/internvl2_5_8b.json",
+			expectedType:   "internvl_chat",
+			expectedArch:   "InternVLChatModel",
+			minParams:      6_000_000_000,
+			maxParams:      9_000_000_000,
+			contextLength:  32768,
+			maxImageTokens: 13 * 256,
+		},
+		{
+			name:           "InternVL3 1B transformers",
+			configPath:     "testdata/internvl3_1b_hf.json",
+			expectedType:   "internvl",
+			expectedArch:   "InternVLForConditionalGeneration",
+			minParams:      500_000_000,
+			maxParams:      1_500_000_000,
+			contextLength:  32768,
+			maxImageTokens: 13 * 256,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := LoadModelConfig(tt.configPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			config, ok := model.(*InternVLConfig)
+			if !ok {
+				t.Fatalf("Expected *InternVLConfig, got %T", model)
+			}
+
+			if config.GetModelType() != tt.expectedType {
+				t.Errorf("Expected model type %s, got %s", tt.expectedType, config.GetModelType())
+			}
+			if config.GetArchitecture() != tt.expectedArch {
+				t.Errorf("Expected architecture %s, got %s", tt.expectedArch, config.GetArchitecture())
+			}
+			if paramCount := config.GetParameterCount(); paramCount < tt.minParams || paramCount > tt.maxParams {
+				t.Errorf("Parameter count %d is outside expected range [%d, %d]",
+					paramCount, tt.minParams, tt.maxParams)
+			}
+			if config.GetContextLength() != tt.contextLength {
+				t.Errorf("Expected context length %d, got %d", tt.contextLength, config.GetContextLength())
+			}
+			if config.GetTorchDtype() != "bfloat16" {
+				t.Errorf("Expected torch dtype bfloat16, got %s", config.GetTorchDtype())
+			}
+			if !config.HasVision() {
+				t.Error("Expected HasVision() to return true")
+			}
+
+			tower := config.GetVisionTower()
+			if tower.ImageSize != 448 || tower.PatchSize != 14 || tower.ImageTokens != 256 {
+				t.Errorf("Unexpected vision tower %+v", tower)
+			}
+			if tower.MaxImageTokens != tt.maxImageTokens {
+				t.Errorf("Expected %d image tokens, got %d", tt.maxImageTokens, tower.MaxImageTokens)
+			}
+
+			// The dimensions of the language model are read from its nested config
+			if _, err := GetModelDimensions(config); err != nil {
+				t.Errorf("GetModelDimensions failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestInternVLConfigInterface(t *testing.T) {
+	var _ HuggingFaceVisionModel = (*InternVLConfig)(nil)
+}
@@ -0,0 +1,225 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// JambaConfig defines the configuration for Jamba models, which interleave Mamba layers with attention layers and
+// dense MLPs with MoE layers
+type JambaConfig struct {
+	BaseModelConfig
+
+	// Model dimensions
+	HiddenSize            int `json:"hidden_size"`
+	IntermediateSize      int `json:"intermediate_size"`
+	NumHiddenLayers       int `json:"num_hidden_layers"`
+	NumAttentionHeads     int `json:"num_attention_heads"`
+	NumKeyValueHeads      int `json:"num_key_value_heads"`
+	MaxPositionEmbeddings int `json:"max_position_embeddings"`
+	VocabSize             int `json:"vocab_size"`
+
+	// Layout of the layers: layer i is an attention layer when i % attn_layer_period == attn_layer_offset, a Mamba
+	// layer otherwise, and has an MoE when i % expert_layer_period == expert_layer_offset
+	AttnLayerPeriod   int `json:"attn_layer_period"`
+	AttnLayerOffset   int `json:"attn_layer_offset"`
+	ExpertLayerPeriod int `json:"expert_layer_period"`
+	ExpertLayerOffset int `json:"expert_layer_offset"`
+
+	// MoE specific parameters
+	NumExperts        int     `json:"num_experts"`
+	NumExpertsPerTok  int     `json:"num_experts_per_tok"`
+	RouterAuxLossCoef float64 `json:"router_aux_loss_coef"`
+
+	// Mamba specific parameters
+	MambaDState    int  `json:"mamba_d_state"`
+	MambaDConv     int  `json:"mamba_d_conv"`
+	MambaExpand    int  `json:"mamba_expand"`
+	MambaDtRank    int  `json:"mamba_dt_rank"`
+	MambaConvBias  bool `json:"mamba_conv_bias"`
+	MambaProjBias  bool `json:"mamba_proj_bias"`
+	UseMambaKernel bool `json:"use_mamba_kernels"`
+
+	// Special tokens
+	BosTokenId int `json:"bos_token_id"`
+	EosTokenId int `json:"eos_token_id"`
+	PadTokenId int `json:"pad_token_id"`
+
+	// Attention related
+	HiddenAct        string      `json:"hidden_act"`
+	RmsNormEps       float64     `json:"rms_norm_eps"`
+	SlidingWindow    interface{} `json:"sliding_window"`
+	AttentionDropout float64     `json:"attention_dropout"`
+
+	// Misc options
+	TieWordEmbeddings bool    `json:"tie_word_embeddings"`
+	UseCache          bool    `json:"use_cache"`
+	InitializerRange  float64 `json:"initializer_range"`
+}
+
+// LoadJambaConfig loads a Jamba model configuration from a JSON file
+func LoadJambaConfig(configPath string) (*JambaConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Jamba config file '%s': %w", configPath, err)
+	}
+
+	var config JambaConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Jamba config JSON from '%s': %w", configPath, err)
+	}
+
+	config.ConfigPath = configPath
+
+	// Default values of transformers' JambaConfig
+	if config.NumKeyValueHeads == 0 {
+		config.NumKeyValueHeads = config.NumAttentionHeads
+	}
+	if config.AttnLayerPeriod == 0 {
+		config.AttnLayerPeriod = 8
+	}
+	if config.ExpertLayerPeriod == 0 {
+		config.ExpertLayerPeriod = 2
+	}
+	if config.MambaExpand == 0 {
+		config.MambaExpand = 2
+	}
+	if config.MambaDConv == 0 {
+		config.MambaDConv = 4
+	}
+	if config.MambaDtRank == 0 {
+		config.MambaDtRank = (config.HiddenSize + 15) / 16
+	}
+
+	// Validate the configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Jamba configuration in '%s': %w", configPath, err)
+	}
+
+	return &config, nil
+}
+
+// Validate checks if the Jamba configuration is valid
+func (c *JambaConfig) Validate() error {
+	if c.HiddenSize <= 0 {
+		return fmt.Errorf("hidden_size must be positive, got %d", c.HiddenSize)
+	}
+	if c.NumHiddenLayers <= 0 {
+		return fmt.Errorf("num_hidden_layers must be positive, got %d", c.NumHiddenLayers)
+	}
+	if c.NumAttentionHeads <= 0 {
+		return fmt.Errorf("num_attention_heads must be positive, got %d", c.NumAttentionHeads)
+	}
+	if c.MambaDState <= 0 {
+		return fmt.Errorf("mamba_d_state must be positive, got %d", c.MambaDState)
+	}
+	if c.VocabSize <= 0 {
+		return fmt.Errorf("vocab_size must be positive, got %d", c.VocabSize)
+	}
+	if c.AttnLayerOffset >= c.AttnLayerPeriod {
+		return fmt.Errorf("attn_layer_offset %d must be less than attn_layer_period %d", c.AttnLayerOffset, c.AttnLayerPeriod)
+	}
+	if c.ExpertLayerOffset >= c.ExpertLayerPeriod {
+		return fmt.Errorf("expert_layer_offset %d must be less than expert_layer_period %d", c.ExpertLayerOffset, c.ExpertLayerPeriod)
+	}
+	return nil
+}
+
+// countLayers returns the number of layers i for which i % period == offset
+func countLayers(numLayers, period, offset int) int {
+	count := 0
+	for i := 0; i < numLayers; i++ {
+		if i%period == offset {
+			count++
+		}
+	}
+	return count
+}
+
+// attentionLayers returns the number of attention layers, the other layers are Mamba layers
+func (c *JambaConfig) attentionLayers() int {
+	return countLayers(c.NumHiddenLayers, c.AttnLayerPeriod, c.AttnLayerOffset)
+}
+
+// moeLayers returns the number of layers with an MoE, the other layers have a dense MLP
+func (c *JambaConfig) moeLayers() int {
+	if c.NumExperts <= 1 {
+		return 0
+	}
+	return countLayers(c.NumHiddenLayers, c.ExpertLayerPeriod, c.ExpertLayerOffset)
+}
+
+// Implementation of the HuggingFaceModel interface
+
+// GetParameterCount returns the total number of parameters in the model
+func (c *JambaConfig) GetParameterCount() int64 {
+	// First try to get parameter count from safetensors files
+	count, err := FindAndParseSafetensors(c.ConfigPath)
+	if err == nil {
+		return count
+	}
+
+	// Log the error
+	fmt.Printf("Warning: failed to get parameter count from safetensors: %v\n", err)
+
+	// Fallback: estimate based on architecture
+	hidden := int64(c.HiddenSize)
+	headDim := int64(c.HiddenSize / c.NumAttentionHeads)
+	attentionLayers, moeLayers := c.attentionLayers(), c.moeLayers()
+	mlpParams := int64(3 * c.HiddenSize * c.IntermediateSize)
+
+	// Mixers, grouped-query attention or Mamba
+	params := int64(attentionLayers) * (2*hidden*int64(c.NumAttentionHeads)*headDim + 2*hidden*int64(c.NumKeyValueHeads)*headDim)
+	params += int64(c.NumHiddenLayers-attentionLayers) *
+		mambaLayerParams(c.HiddenSize, c.MambaExpand*c.HiddenSize, c.MambaDState, c.MambaDConv, c.MambaDtRank)
+	// Feed-forward, the experts and their router or a dense MLP
+	params += int64(moeLayers) * (int64(c.NumExperts)*mlpParams + hidden*int64(c.NumExperts))
+	params += int64(c.NumHiddenLayers-moeLayers) * mlpParams
+	// Embeddings
+	params += int64(c.VocabSize) * hidden
+	if !c.TieWordEmbeddings {
+		params += int64(c.VocabSize) * hidden
+	}
+	return params
+}
+
+// GetNumExperts returns the number of experts of the MoE layers
+func (c *JambaConfig) GetNumExperts() int {
+	if c.NumExperts <= 1 {
+		return 0
+	}
+	return c.NumExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, with the num_experts_per_tok
+// experts a token is routed to in the MoE layers. Jamba v0.1 uses 12B of its 52B parameters.
+func (c *JambaConfig) GetActiveParameterCount() int64 {
+	return activeMoEParams(c.GetParameterCount(), c.moeLayers(), c.NumExperts, c.NumExpertsPerTok,
+		int64(3*c.HiddenSize*c.IntermediateSize))
+}
+
+// GetArchitecture returns the model architecture
+func (c *JambaConfig) GetArchitecture() string {
+	if len(c.Architectures) > 0 {
+		return c.Architectures[0]
+	}
+	return "JambaForCausalLM"
+}
+
+// GetContextLength returns the maximum context length
+func (c *JambaConfig) GetContextLength() int {
+	return c.MaxPositionEmbeddings
+}
+
+// GetModelSizeBytes returns the estimated size of the model in bytes
+func (c *JambaConfig) GetModelSizeBytes() int64 {
+	return EstimateModelSizeBytes(c.GetParameterCount(), c.GetTorchDtype())
+}
+
+// Register the Jamba model handler
+func init() {
+	RegisterModelLoader("jamba", func(configPath string) (HuggingFaceModel, error) {
+		return LoadJambaConfig(configPath)
+	})
+}
@@ -0,0 +1,43 @@
+package modelconfig
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestJambaConfig(t *testing.T) {
+	configPath := filepath.Join("testdata", "jamba_v0.1.json")
+
+	// Load the config
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load Jamba config: %v", err)
+	}
+
+	jambaConfig, ok := config.(*JambaConfig)
+	if !ok {
+		t.Fatalf("Failed to convert to JambaConfig, got %T", config)
+	}
+
+	// One attention layer every 8 layers, one MoE every 2 layers
+	if layers := jambaConfig.attentionLayers(); layers != 4 {
+		t.Errorf("Expected 4 attention layers, got %d", layers)
+	}
+	if layers := jambaConfig.moeLayers(); layers != 16 {
+		t.Errorf("Expected 16 MoE layers, got %d", layers)
+	}
+	if config.GetNumExperts() != 16 {
+		t.Errorf("Expected 16 experts, got %d", config.GetNumExperts())
+	}
+	if config.GetContextLength() != 262144 {
+		t.Errorf("Incorrect context length, expected 262144, got %d", config.GetContextLength())
+	}
+
+	// Estimated from the architecture, Jamba v0.1 has 52B parameters of which 12B are active
+	if paramCount := config.GetParameterCount(); paramCount < 51_000_000_000 || paramCount > 53_000_000_000 {
+		t.Errorf("Expected about 52B parameters, got %s", FormatParamCount(paramCount))
+	}
+	if active := config.GetActiveParameterCount(); active < 11_500_000_000 || active > 12_500_000_000 {
+		t.Errorf("Expected about 12B active parameters, got %s", FormatParamCount(active))
+	}
+}
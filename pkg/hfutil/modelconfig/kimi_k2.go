@@ -60,9 +60,6 @@ type KimiK2Config struct {
 	// RoPE scaling (YARN type for Kimi-K2)
 	RopeScaling RopeScalingConfig `json:"rope_scaling"`
 
-	// Quantization settings
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
-
 	// Misc options
 	TieWordEmbeddings bool    `json:"tie_word_embeddings"`
 	UseCache          bool    `json:"use_cache"`
@@ -136,19 +133,24 @@ func (c *KimiK2Config) GetParameterCount() int64 {
 	return 1_500_000_000_000 // 1.5T parameters
 }
 
+// GetNumExperts returns the number of routed experts of each MoE layer
+func (c *KimiK2Config) GetNumExperts() int {
+	return c.NumRoutedExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, laid out as DeepSeek V3: the
+// first_k_dense_replace dense layers, the shared experts and the num_experts_per_tok routed experts of the others
+func (c *KimiK2Config) GetActiveParameterCount() int64 {
+	moeLayers := c.NumHiddenLayers + c.NumNextnPredictLayers - c.FirstKDenseReplace
+	return activeMoEParams(c.GetParameterCount(), moeLayers, c.NumRoutedExperts, c.NumExpertsPerTok,
+		int64(3*c.HiddenSize*c.MoeIntermediateSize))
+}
+
 // GetTransformerVersion returns the transformers library version
 func (c *KimiK2Config) GetTransformerVersion() string {
 	return c.BaseModelConfig.TransformerVersion
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *KimiK2Config) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // GetArchitecture returns the model architecture
 func (c *KimiK2Config) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
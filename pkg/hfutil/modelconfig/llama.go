@@ -38,13 +38,6 @@ type LlamaConfig struct {
 	// RoPE scaling for Llama-3 and Llama-3.1
 	RopeScaling RopeScalingConfig `json:"rope_scaling"`
 
-	// Quantization config for FP8 models like Llama-3.1-405B
-	QuantizationConfig *struct {
-		ActivationScaleUb   float64  `json:"activation_scale_ub"`
-		ModulesToNotConvert []string `json:"modules_to_not_convert"`
-		QuantMethod         string   `json:"quant_method"`
-	} `json:"quantization_config,omitempty"`
-
 	// Misc options
 	InitializerRange  float64 `json:"initializer_range"`
 	UseCache          bool    `json:"use_cache"`
@@ -137,6 +130,11 @@ func (c *LlamaConfig) GetParameterCount() int64 {
 	return estimateParamsFromArchitecture(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *LlamaConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // Helper function to estimate parameters from model architecture
 func estimateParamsFromArchitecture(hiddenSize, numLayers, intermediateSize int) int64 {
 	// If intermediateSize is 0, use the common ratio for Llama models
@@ -167,14 +165,6 @@ func (c *LlamaConfig) GetTransformerVersion() string {
 	return c.TransformerVersion
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *LlamaConfig) GetQuantizationType() string {
-	if c.QuantizationConfig != nil {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return "" // Base Llama models don't have quantization by default
-}
-
 // GetArchitecture returns the model architecture
 func (c *LlamaConfig) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
@@ -201,6 +201,24 @@ func (c *Llama4Config) GetParameterCount() int64 {
 		c.TextConfig.IntermediateSize)
 }
 
+// GetNumExperts returns the number of routed experts of each MoE layer
+func (c *Llama4Config) GetNumExperts() int {
+	return c.TextConfig.NumLocalExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token: the shared expert and the
+// num_experts_per_tok routed experts of every interleave_moe_layer_step layer. Both Scout and Maverick use 17B
+// parameters.
+func (c *Llama4Config) GetActiveParameterCount() int64 {
+	tc := c.TextConfig
+	moeLayers := tc.NumHiddenLayers
+	if tc.InterleaveStep > 1 {
+		moeLayers /= tc.InterleaveStep
+	}
+	return activeMoEParams(c.GetParameterCount(), moeLayers, tc.NumLocalExperts, tc.NumExpertsPerTok,
+		int64(3*tc.HiddenSize*tc.IntermediateSize))
+}
+
 func (c *Llama4Config) GetTransformerVersion() string {
 	return c.TransformerVersion
 }
@@ -212,6 +230,22 @@ func (c *Llama4Config) GetQuantizationType() string {
 	return ""
 }
 
+// GetBitsPerWeight returns the number of bits of the weights of the compressed-tensors config groups
+func (c *Llama4Config) GetBitsPerWeight() float64 {
+	if c.QuantizationConfig == nil {
+		return 0
+	}
+	if group0, exists := c.QuantizationConfig.ConfigGroups["group_0"]; exists && group0.Weights != nil {
+		return float64(group0.Weights.NumBits)
+	}
+	return 0
+}
+
+// configFile returns the path of the config file the model was loaded from
+func (c *Llama4Config) configFile() string {
+	return c.ConfigPath
+}
+
 func (c *Llama4Config) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
 		return c.Architectures[0]
@@ -45,6 +45,12 @@ func TestLlamaConfig(t *testing.T) {
 			FormatParamCount(expectedCount), FormatParamCount(paramCount))
 	}
 
+	// Dense models use all their parameters for each token
+	if config.GetNumExperts() != 0 || config.GetActiveParameterCount() != paramCount {
+		t.Errorf("Expected a dense model, got %d experts and %s active parameters",
+			config.GetNumExperts(), FormatParamCount(config.GetActiveParameterCount()))
+	}
+
 	// Test GetModelSizeBytes
 	modelSize := config.GetModelSizeBytes()
 	// Expected size for bfloat16 (2 bytes per parameter)
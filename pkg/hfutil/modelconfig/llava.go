@@ -33,7 +33,9 @@ type LLaVAVisionConfig struct {
 	VocabSize         int    `json:"vocab_size,omitempty"`
 }
 
-// LLaVAConfig defines the configuration for LLaVA multimodal models
+// LLaVAConfig defines the configuration for LLaVA multimodal models. It also covers LLaVA-NeXT (llava_next), and
+// Pixtral and Mistral 3 (mistral3), which pair a pixtral vision encoder with a Mistral language model in the same
+// layout.
 type LLaVAConfig struct {
 	BaseModelConfig
 
@@ -53,6 +55,11 @@ type LLaVAConfig struct {
 	VisionFeatureLayer          int    `json:"vision_feature_layer"`
 	VisionFeatureSelectStrategy string `json:"vision_feature_select_strategy"`
 
+	// ImageGridPinpoints lists the resolutions LLaVA-NeXT tiles the images to, as [height, width]
+	ImageGridPinpoints [][]int `json:"image_grid_pinpoints,omitempty"`
+	// SpatialMergeSize is the side of the squares of patches Mistral 3 merges into a token
+	SpatialMergeSize int `json:"spatial_merge_size,omitempty"`
+
 	// Other settings
 	TieWordEmbeddings bool `json:"tie_word_embeddings"`
 	VocabSize         int  `json:"vocab_size"`
@@ -90,28 +97,21 @@ func (c *LLaVAConfig) GetParameterCount() int64 {
 	totalParams := int64(0)
 
 	// Language model parameters
-	// LLaVA typically uses Vicuna/Llama as the language model
-	if c.TextConfig.ModelType == "llama" {
-		// Try to load the actual text model config if possible
-		textParams := int64(0)
-		if c.TextConfig.HiddenSize > 0 {
-			textParams = estimateModelParams(
-				c.TextConfig.HiddenSize,
-				c.TextConfig.NumHiddenLayers,
-				c.TextConfig.IntermediateSize,
-				c.TextConfig.VocabSize,
-			)
-		} else {
-			// Estimate based on vocab size (typical LLaVA uses 7B or 13B Llama)
-			if c.TextConfig.VocabSize >= 32000 {
-				textParams = 7_000_000_000 // Default to 7B
-			}
-		}
-		totalParams += textParams
+	// LLaVA typically uses Vicuna/Llama as the language model, Pixtral and Mistral 3 use Mistral
+	if c.TextConfig.HiddenSize > 0 {
+		totalParams += estimateModelParams(
+			c.TextConfig.HiddenSize,
+			c.TextConfig.NumHiddenLayers,
+			c.TextConfig.IntermediateSize,
+			c.TextConfig.VocabSize,
+		)
+	} else if (c.TextConfig.ModelType == "llama" || c.TextConfig.ModelType == "mistral") && c.TextConfig.VocabSize >= 32000 {
+		// Estimate based on vocab size (typical LLaVA uses 7B or 13B Llama, LLaVA-NeXT 7B Mistral)
+		totalParams += 7_000_000_000 // Default to 7B
 	}
 
-	// Vision encoder parameters (CLIP ViT)
-	if c.VisionConfig.ModelType == "clip_vision_model" {
+	// Vision encoder parameters (CLIP ViT or Pixtral)
+	if c.VisionConfig.ModelType == "clip_vision_model" || c.VisionConfig.ModelType == "pixtral" {
 		// CLIP ViT-L/14 has ~304M parameters
 		visionParams := int64(
 			// Patch embedding
@@ -136,13 +136,19 @@ func (c *LLaVAConfig) GetParameterCount() int64 {
 	}
 
 	// Known configurations
-	if c.VisionConfig.NumHiddenLayers == 24 && c.TextConfig.VocabSize >= 32000 {
+	if c.VisionConfig.ModelType == "clip_vision_model" && c.TextConfig.HiddenSize == 0 &&
+		c.VisionConfig.NumHiddenLayers == 24 && c.TextConfig.VocabSize >= 32000 {
 		return 7_500_000_000 // LLaVA 1.5 7B (7B Vicuna + ~300M CLIP + projector)
 	}
 
 	return totalParams
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *LLaVAConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *LLaVAConfig) GetContextLength() int {
 	return c.TextConfig.MaxPositionEmbeddings
@@ -157,11 +163,6 @@ func (c *LLaVAConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), dtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *LLaVAConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns true for LLaVA models
 func (c *LLaVAConfig) HasVision() bool {
 	return true
@@ -172,9 +173,58 @@ func (c *LLaVAConfig) IsEmbedding() bool {
 	return false
 }
 
+// GetVisionTower returns the vision encoder and the number of tokens of the images
+func (c *LLaVAConfig) GetVisionTower() *VisionTowerSpec {
+	vc := c.VisionConfig
+	spec := &VisionTowerSpec{
+		ModelType:       vc.ModelType,
+		ImageSize:       vc.ImageSize,
+		PatchSize:       vc.PatchSize,
+		HiddenSize:      vc.HiddenSize,
+		NumHiddenLayers: vc.NumHiddenLayers,
+	}
+	if vc.ImageSize <= 0 || vc.PatchSize <= 0 {
+		return spec
+	}
+
+	side := vc.ImageSize / vc.PatchSize
+	if vc.ModelType == "pixtral" {
+		// Pixtral encodes the images at their resolution up to image_size, ending each row of patches with an
+		// [IMG_BREAK] token
+		if c.SpatialMergeSize > 1 {
+			side /= c.SpatialMergeSize
+		}
+		spec.ImageTokens = side*side + side
+		spec.MaxImageTokens = spec.ImageTokens
+		return spec
+	}
+
+	spec.ImageTokens = side * side
+	if c.VisionFeatureSelectStrategy == "full" {
+		spec.ImageTokens++ // The CLS token is kept
+	}
+	spec.MaxImageTokens = spec.ImageTokens
+	// LLaVA-NeXT adds the tiles of the best fitting pinpoint resolution to the resized image, ending each row of
+	// patches with a newline token
+	for _, pinpoint := range c.ImageGridPinpoints {
+		if len(pinpoint) != 2 {
+			continue
+		}
+		rows, cols := pinpoint[0]/vc.PatchSize, pinpoint[1]/vc.PatchSize
+		spec.MaxImageTokens = max(spec.MaxImageTokens, spec.ImageTokens+rows*cols+rows)
+	}
+	return spec
+}
+
 // Register the LLaVA model handler
 func init() {
 	RegisterModelLoader("llava", func(configPath string) (HuggingFaceModel, error) {
 		return LoadLLaVAConfig(configPath)
 	})
+	RegisterModelLoader("llava_next", func(configPath string) (HuggingFaceModel, error) {
+		return LoadLLaVAConfig(configPath)
+	})
+	RegisterModelLoader("mistral3", func(configPath string) (HuggingFaceModel, error) {
+		return LoadLLaVAConfig(configPath)
+	})
 }
@@ -12,14 +12,46 @@ func TestLoadLLaVAConfig(t *testing.T) {
 		minParams    int64
 		maxParams    int64
 		hasVision    bool
+		// maxImageTokens is the image token budget of the vision tower
+		maxImageTokens int
 	}{
 		{
-			name:         "LLaVA 1.5 7B HF",
-			configPath:   "testdata/llava_1.5_7b_hf.json",
-			expectedType: "llava",
-			minParams:    7_000_000_000,
-			maxParams:    8_000_000_000,
-			hasVision:    true,
+			name:           "LLaVA 1.5 7B HF",
+			configPath:     "testdata/llava_1.5_7b_hf.json",
+			expectedType:   "llava",
+			minParams:      7_000_000_000,
+			maxParams:      8_000_000_000,
+			hasVision:      true,
+			maxImageTokens: 576,
+		},
+		{
+			// 576 tokens of the resized image, and 48x48 tokens plus 48 newlines of the 672x672 tiles
+			name:           "LLaVA-NeXT Mistral 7B",
+			configPath:     "testdata/llava_next_mistral_7b.json",
+			expectedType:   "llava_next",
+			minParams:      7_000_000_000,
+			maxParams:      8_000_000_000,
+			hasVision:      true,
+			maxImageTokens: 576 + 48*48 + 48,
+		},
+		{
+			name:           "Pixtral 12B",
+			configPath:     "testdata/pixtral_12b.json",
+			expectedType:   "llava",
+			minParams:      10_000_000_000,
+			maxParams:      13_000_000_000,
+			hasVision:      true,
+			maxImageTokens: 64*64 + 64,
+		},
+		{
+			// 110x110 patches of 14 pixels, merged 2x2
+			name:           "Mistral Small 3.1 24B",
+			configPath:     "testdata/mistral_small_3_1_24b.json",
+			expectedType:   "mistral3",
+			minParams:      15_000_000_000,
+			maxParams:      25_000_000_000,
+			hasVision:      true,
+			maxImageTokens: 55*55 + 55,
 		},
 	}
 
@@ -57,6 +89,10 @@ func TestLoadLLaVAConfig(t *testing.T) {
 			if config.GetModelSizeBytes() <= 0 {
 				t.Error("GetModelSizeBytes() returned non-positive value")
 			}
+
+			if tokens := config.GetVisionTower().MaxImageTokens; tokens != tt.maxImageTokens {
+				t.Errorf("Expected %d image tokens, got %d", tt.maxImageTokens, tokens)
+			}
 		})
 	}
 }
@@ -64,4 +100,5 @@ func TestLoadLLaVAConfig(t *testing.T) {
 func TestLLaVAConfigInterface(t *testing.T) {
 	// Test that LLaVAConfig implements HuggingFaceModel interface
 	var _ HuggingFaceModel = (*LLaVAConfig)(nil)
+	var _ HuggingFaceVisionModel = (*LLaVAConfig)(nil)
 }
@@ -0,0 +1,329 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// MambaConfig defines the configuration for Mamba and Falcon Mamba models, state space models without attention
+type MambaConfig struct {
+	BaseModelConfig
+
+	// Model dimensions
+	HiddenSize       int `json:"hidden_size"`
+	IntermediateSize int `json:"intermediate_size"`
+	NumHiddenLayers  int `json:"num_hidden_layers"`
+	VocabSize        int `json:"vocab_size"`
+
+	// State space parameters
+	StateSize    int         `json:"state_size"`
+	Expand       int         `json:"expand"`
+	ConvKernel   int         `json:"conv_kernel"`
+	TimeStepRank interface{} `json:"time_step_rank"` // A number or "auto"
+	UseBias      bool        `json:"use_bias"`
+	UseConvBias  bool        `json:"use_conv_bias"`
+
+	// Special tokens
+	BosTokenId int `json:"bos_token_id"`
+	EosTokenId int `json:"eos_token_id"`
+	PadTokenId int `json:"pad_token_id"`
+
+	// Misc options
+	HiddenAct         string  `json:"hidden_act"`
+	LayerNormEpsilon  float64 `json:"layer_norm_epsilon"`
+	ResidualInFp32    bool    `json:"residual_in_fp32"`
+	TieWordEmbeddings *bool   `json:"tie_word_embeddings"`
+	UseCache          bool    `json:"use_cache"`
+	InitializerRange  float64 `json:"initializer_range"`
+}
+
+// LoadMambaConfig loads a Mamba or Falcon Mamba model configuration from a JSON file
+func LoadMambaConfig(configPath string) (*MambaConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Mamba config file '%s': %w", configPath, err)
+	}
+
+	var config MambaConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Mamba config JSON from '%s': %w", configPath, err)
+	}
+
+	config.ConfigPath = configPath
+
+	// Default values of transformers' MambaConfig
+	if config.Expand == 0 {
+		config.Expand = 2
+	}
+	if config.IntermediateSize == 0 {
+		config.IntermediateSize = config.Expand * config.HiddenSize
+	}
+	if config.ConvKernel == 0 {
+		config.ConvKernel = 4
+	}
+
+	// Validate the configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Mamba configuration in '%s': %w", configPath, err)
+	}
+
+	return &config, nil
+}
+
+// Validate checks if the Mamba configuration is valid
+func (c *MambaConfig) Validate() error {
+	if c.HiddenSize <= 0 {
+		return fmt.Errorf("hidden_size must be positive, got %d", c.HiddenSize)
+	}
+	if c.NumHiddenLayers <= 0 {
+		return fmt.Errorf("num_hidden_layers must be positive, got %d", c.NumHiddenLayers)
+	}
+	if c.StateSize <= 0 {
+		return fmt.Errorf("state_size must be positive, got %d", c.StateSize)
+	}
+	if c.VocabSize <= 0 {
+		return fmt.Errorf("vocab_size must be positive, got %d", c.VocabSize)
+	}
+	return nil
+}
+
+// dtRank returns the rank of the projection of the time steps, hidden_size / 16 rounded up when "auto"
+func (c *MambaConfig) dtRank() int {
+	if rank, ok := c.TimeStepRank.(float64); ok && rank > 0 {
+		return int(rank)
+	}
+	return (c.HiddenSize + 15) / 16
+}
+
+// Implementation of the HuggingFaceModel interface
+
+// GetParameterCount returns the total number of parameters in the model
+func (c *MambaConfig) GetParameterCount() int64 {
+	// First try to get parameter count from safetensors files
+	count, err := FindAndParseSafetensors(c.ConfigPath)
+	if err == nil {
+		return count
+	}
+
+	// Log the error
+	fmt.Printf("Warning: failed to get parameter count from safetensors: %v\n", err)
+
+	// Fallback: estimate based on architecture, the embeddings are tied unless told otherwise
+	params := int64(c.NumHiddenLayers)*mambaLayerParams(c.HiddenSize, c.IntermediateSize, c.StateSize, c.ConvKernel, c.dtRank()) +
+		int64(c.VocabSize)*int64(c.HiddenSize)
+	if c.TieWordEmbeddings != nil && !*c.TieWordEmbeddings {
+		params += int64(c.VocabSize) * int64(c.HiddenSize)
+	}
+	return params
+}
+
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *MambaConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
+// GetArchitecture returns the model architecture
+func (c *MambaConfig) GetArchitecture() string {
+	if len(c.Architectures) > 0 {
+		return c.Architectures[0]
+	}
+	if c.ModelType == "falcon_mamba" {
+		return "FalconMambaForCausalLM"
+	}
+	return "MambaForCausalLM"
+}
+
+// GetContextLength returns 0 since state space models have no maximum context length: the state of a sequence
+// doesn't grow with its tokens
+func (c *MambaConfig) GetContextLength() int {
+	return 0
+}
+
+// GetModelSizeBytes returns the estimated size of the model in bytes
+func (c *MambaConfig) GetModelSizeBytes() int64 {
+	return EstimateModelSizeBytes(c.GetParameterCount(), c.GetTorchDtype())
+}
+
+// Mamba2Config defines the configuration for Mamba2 models, whose state space layers split their channels into
+// heads and share their input projections across groups of heads
+type Mamba2Config struct {
+	BaseModelConfig
+
+	// Model dimensions
+	HiddenSize      int `json:"hidden_size"`
+	NumHiddenLayers int `json:"num_hidden_layers"`
+	VocabSize       int `json:"vocab_size"`
+
+	// State space parameters
+	NumHeads    int  `json:"num_heads"`
+	HeadDim     int  `json:"head_dim"`
+	NGroups     int  `json:"n_groups"`
+	StateSize   int  `json:"state_size"`
+	Expand      int  `json:"expand"`
+	ConvKernel  int  `json:"conv_kernel"`
+	ChunkSize   int  `json:"chunk_size"`
+	UseBias     bool `json:"use_bias"`
+	UseConvBias bool `json:"use_conv_bias"`
+
+	// Special tokens
+	BosTokenId int `json:"bos_token_id"`
+	EosTokenId int `json:"eos_token_id"`
+	PadTokenId int `json:"pad_token_id"`
+
+	// Misc options
+	HiddenAct         string  `json:"hidden_act"`
+	LayerNormEpsilon  float64 `json:"layer_norm_epsilon"`
+	RmsNorm           bool    `json:"rms_norm"`
+	ResidualInFp32    bool    `json:"residual_in_fp32"`
+	TieWordEmbeddings bool    `json:"tie_word_embeddings"`
+	UseCache          bool    `json:"use_cache"`
+	InitializerRange  float64 `json:"initializer_range"`
+}
+
+// LoadMamba2Config loads a Mamba2 model configuration from a JSON file
+func LoadMamba2Config(configPath string) (*Mamba2Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Mamba2 config file '%s': %w", configPath, err)
+	}
+
+	// Sanitize the JSON data, time_step_limit may hold Infinity
+	data = SanitizeJSONBytes(data)
+
+	var config Mamba2Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse Mamba2 config JSON from '%s': %w", configPath, err)
+	}
+
+	config.ConfigPath = configPath
+
+	// Default values of transformers' Mamba2Config
+	if config.Expand == 0 {
+		config.Expand = 2
+	}
+	if config.NGroups == 0 {
+		config.NGroups = 1
+	}
+	if config.ConvKernel == 0 {
+		config.ConvKernel = 4
+	}
+
+	// Validate the configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Mamba2 configuration in '%s': %w", configPath, err)
+	}
+
+	return &config, nil
+}
+
+// Validate checks if the Mamba2 configuration is valid
+func (c *Mamba2Config) Validate() error {
+	if c.HiddenSize <= 0 {
+		return fmt.Errorf("hidden_size must be positive, got %d", c.HiddenSize)
+	}
+	if c.NumHiddenLayers <= 0 {
+		return fmt.Errorf("num_hidden_layers must be positive, got %d", c.NumHiddenLayers)
+	}
+	if c.NumHeads <= 0 {
+		return fmt.Errorf("num_heads must be positive, got %d", c.NumHeads)
+	}
+	if c.StateSize <= 0 {
+		return fmt.Errorf("state_size must be positive, got %d", c.StateSize)
+	}
+	if c.VocabSize <= 0 {
+		return fmt.Errorf("vocab_size must be positive, got %d", c.VocabSize)
+	}
+	if c.NumHeads%c.NGroups != 0 {
+		return fmt.Errorf("%d heads can't be split into %d groups", c.NumHeads, c.NGroups)
+	}
+	return nil
+}
+
+// Implementation of the HuggingFaceModel interface
+
+// GetParameterCount returns the total number of parameters in the model
+func (c *Mamba2Config) GetParameterCount() int64 {
+	// First try to get parameter count from safetensors files
+	count, err := FindAndParseSafetensors(c.ConfigPath)
+	if err == nil {
+		return count
+	}
+
+	// Log the error
+	fmt.Printf("Warning: failed to get parameter count from safetensors: %v\n", err)
+
+	// Fallback: estimate based on architecture
+	inner := c.Expand * c.HiddenSize
+	params := int64(c.NumHiddenLayers)*mamba2LayerParams(c.HiddenSize, inner, c.StateSize, c.ConvKernel, c.NGroups, c.NumHeads) +
+		int64(c.VocabSize)*int64(c.HiddenSize)
+	if !c.TieWordEmbeddings {
+		params += int64(c.VocabSize) * int64(c.HiddenSize)
+	}
+	return params
+}
+
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Mamba2Config) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
+// GetArchitecture returns the model architecture
+func (c *Mamba2Config) GetArchitecture() string {
+	if len(c.Architectures) > 0 {
+		return c.Architectures[0]
+	}
+	return "Mamba2ForCausalLM"
+}
+
+// GetContextLength returns 0 since state space models have no maximum context length: the state of a sequence
+// doesn't grow with its tokens
+func (c *Mamba2Config) GetContextLength() int {
+	return 0
+}
+
+// GetModelSizeBytes returns the estimated size of the model in bytes
+func (c *Mamba2Config) GetModelSizeBytes() int64 {
+	return EstimateModelSizeBytes(c.GetParameterCount(), c.GetTorchDtype())
+}
+
+// mambaLayerParams returns the number of parameters of a Mamba layer: the input projection to the channels and the
+// gate, the convolution, the projections of the time steps and of the B and C matrices, A, D, the output projection
+// and the norm
+func mambaLayerParams(hiddenSize, innerSize, stateSize, convKernel, dtRank int) int64 {
+	hidden, inner, state := int64(hiddenSize), int64(innerSize), int64(stateSize)
+	return hidden*2*inner + // in_proj
+		inner*int64(convKernel) + inner + // conv1d
+		inner*(int64(dtRank)+2*state) + // x_proj
+		int64(dtRank)*inner + inner + // dt_proj
+		inner*state + inner + // A_log, D
+		inner*hidden + // out_proj
+		hidden // norm
+}
+
+// mamba2LayerParams returns the number of parameters of a Mamba2 layer: the input projection to the channels, the
+// gate, the B and C matrices of each group and the time step of each head, the convolution of the channels and of
+// B and C, the time step bias, A and D of each head, the gated norm, the output projection and the norm
+func mamba2LayerParams(hiddenSize, innerSize, stateSize, convKernel, numGroups, numHeads int) int64 {
+	hidden, inner := int64(hiddenSize), int64(innerSize)
+	convChannels := inner + 2*int64(numGroups)*int64(stateSize)
+	return hidden*(inner+convChannels+int64(numHeads)) + // in_proj
+		convChannels*int64(convKernel) + convChannels + // conv1d
+		3*int64(numHeads) + // dt_bias, A_log, D
+		inner + // gated norm
+		inner*hidden + // out_proj
+		hidden // norm
+}
+
+// Register the Mamba model handlers
+func init() {
+	RegisterModelLoader("mamba", func(configPath string) (HuggingFaceModel, error) {
+		return LoadMambaConfig(configPath)
+	})
+	RegisterModelLoader("falcon_mamba", func(configPath string) (HuggingFaceModel, error) {
+		return LoadMambaConfig(configPath)
+	})
+	RegisterModelLoader("mamba2", func(configPath string) (HuggingFaceModel, error) {
+		return LoadMamba2Config(configPath)
+	})
+}
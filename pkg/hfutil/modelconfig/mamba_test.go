@@ -0,0 +1,112 @@
+package modelconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMambaConfig(t *testing.T) {
+	configPath := filepath.Join("testdata", "mamba_2.8b.json")
+
+	// Load the config
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load Mamba config: %v", err)
+	}
+
+	mambaConfig, ok := config.(*MambaConfig)
+	if !ok {
+		t.Fatalf("Failed to convert to MambaConfig, got %T", config)
+	}
+	if mambaConfig.StateSize != 16 || mambaConfig.IntermediateSize != 5120 || mambaConfig.dtRank() != 160 {
+		t.Errorf("Expected a state size of 16, 5120 channels and a time step rank of 160, got %d, %d and %d",
+			mambaConfig.StateSize, mambaConfig.IntermediateSize, mambaConfig.dtRank())
+	}
+	if config.GetArchitecture() != "MambaForCausalLM" {
+		t.Errorf("Incorrect architecture, expected 'MambaForCausalLM', got '%s'", config.GetArchitecture())
+	}
+
+	// State space models have no maximum context length
+	if config.GetContextLength() != 0 {
+		t.Errorf("Expected no context length, got %d", config.GetContextLength())
+	}
+
+	// Estimated from the architecture, Mamba-2.8B
+	if paramCount := config.GetParameterCount(); paramCount < 2_700_000_000 || paramCount > 2_850_000_000 {
+		t.Errorf("Expected about 2.8B parameters, got %s", FormatParamCount(paramCount))
+	}
+	if config.GetNumExperts() != 0 {
+		t.Errorf("Expected a dense model, got %d experts", config.GetNumExperts())
+	}
+}
+
+func TestMambaConfigDefaults(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "falcon_mamba",
+		"hidden_size": 4096,
+		"num_hidden_layers": 64,
+		"state_size": 16,
+		"time_step_rank": "auto",
+		"vocab_size": 65024
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load Falcon Mamba config: %v", err)
+	}
+	mambaConfig := config.(*MambaConfig)
+	if mambaConfig.IntermediateSize != 8192 || mambaConfig.ConvKernel != 4 || mambaConfig.dtRank() != 256 {
+		t.Errorf("Expected 8192 channels, a kernel of 4 and a time step rank of 256, got %d, %d and %d",
+			mambaConfig.IntermediateSize, mambaConfig.ConvKernel, mambaConfig.dtRank())
+	}
+	if config.GetArchitecture() != "FalconMambaForCausalLM" {
+		t.Errorf("Incorrect architecture, expected 'FalconMambaForCausalLM', got '%s'", config.GetArchitecture())
+	}
+}
+
+func TestMamba2Config(t *testing.T) {
+	configPath := filepath.Join("testdata", "mamba2_codestral_7b.json")
+
+	// Load the config
+	config, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load Mamba2 config: %v", err)
+	}
+
+	mamba2Config, ok := config.(*Mamba2Config)
+	if !ok {
+		t.Fatalf("Failed to convert to Mamba2Config, got %T", config)
+	}
+	if mamba2Config.NumHeads != 128 || mamba2Config.NGroups != 8 || mamba2Config.StateSize != 128 {
+		t.Errorf("Expected 128 heads in 8 groups with a state size of 128, got %d heads in %d groups with %d",
+			mamba2Config.NumHeads, mamba2Config.NGroups, mamba2Config.StateSize)
+	}
+
+	// Estimated from the architecture, Mamba-Codestral-7B
+	if paramCount := config.GetParameterCount(); paramCount < 7_200_000_000 || paramCount > 7_400_000_000 {
+		t.Errorf("Expected about 7.3B parameters, got %s", FormatParamCount(paramCount))
+	}
+
+	// The heads must be split evenly into the groups
+	configPath = filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "mamba2",
+		"hidden_size": 4096,
+		"num_hidden_layers": 64,
+		"num_heads": 128,
+		"n_groups": 3,
+		"state_size": 128,
+		"vocab_size": 32768
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if _, err := LoadModelConfig(configPath); err == nil {
+		t.Error("Expected an error for heads that can't be split into the groups")
+	}
+}
@@ -0,0 +1,333 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ModelDimensions holds the transformer dimensions the serving memory of a model is computed from
+type ModelDimensions struct {
+	HiddenSize        int
+	NumHiddenLayers   int
+	NumAttentionHeads int
+	NumKeyValueHeads  int
+	HeadDim           int
+	VocabSize         int
+
+	// Multi-head latent attention (DeepSeek V2/V3, Kimi K2) caches a compressed latent and the rotary part of the
+	// keys instead of the keys and values of every head
+	KVLoraRank    int
+	QKRopeHeadDim int
+
+	// State space (Mamba) layers keep a state of a fixed size per sequence instead of a KV cache. NumSSMLayers of
+	// the NumHiddenLayers are state space layers, all of them for Mamba and some of them for hybrid models like
+	// Jamba, the others are attention layers.
+	NumSSMLayers  int
+	SSMStateSize  int // d_state, the size of the state of each channel
+	SSMInnerSize  int // d_inner, the number of channels, expand * hidden_size
+	SSMConvKernel int // d_conv, the width of the convolution over the last tokens
+	SSMNumGroups  int // The groups of heads sharing their B and C matrices of Mamba2, 0 for Mamba
+}
+
+// rawModelDimensions lists the keys under which the config.json files of the supported families store the
+// dimensions, the first non-zero one wins
+type rawModelDimensions struct {
+	HiddenSize     int `json:"hidden_size"`
+	DModel         int `json:"d_model"`
+	NEmbd          int `json:"n_embd"`
+	NumHiddenLayer int `json:"num_hidden_layers"`
+	NumLayers      int `json:"num_layers"`
+	NLayers        int `json:"n_layers"`
+	NLayer         int `json:"n_layer"`
+	NumHeads       int `json:"num_attention_heads"`
+	NHeads         int `json:"n_heads"`
+	NHead          int `json:"n_head"`
+	NumKVHeads     int `json:"num_key_value_heads"`
+	MultiQueryNum  int `json:"multi_query_group_num"` // ChatGLM
+	HeadDim        int `json:"head_dim"`
+	KVChannels     int `json:"kv_channels"` // ChatGLM
+	KVLoraRank     int `json:"kv_lora_rank"`
+	QKRopeHeadDim  int `json:"qk_rope_head_dim"`
+	VocabSize      int `json:"vocab_size"`
+	PaddedVocab    int `json:"padded_vocab_size"` // ChatGLM
+	AttnConfig     struct {
+		KVNHeads int `json:"kv_n_heads"` // DBRX
+	} `json:"attn_config"`
+
+	// State space layers
+	StateSize    int `json:"state_size"`    // Mamba, Mamba2
+	MambaDState  int `json:"mamba_d_state"` // Jamba, Bamba
+	Expand       int `json:"expand"`
+	MambaExpand  int `json:"mamba_expand"`
+	ConvKernel   int `json:"conv_kernel"`
+	MambaDConv   int `json:"mamba_d_conv"`
+	NGroups      int `json:"n_groups"`
+	MambaNGroups int `json:"mamba_n_groups"`
+
+	// Attention layers of the hybrid models
+	AttnLayerPeriod  int      `json:"attn_layer_period"` // Jamba
+	AttnLayerOffset  int      `json:"attn_layer_offset"`
+	AttnLayerIndices []int    `json:"attn_layer_indices"` // Bamba
+	LayerTypes       []string `json:"layer_types"`        // Granite 4.0 hybrids
+}
+
+func (r *rawModelDimensions) dimensions() ModelDimensions {
+	dims := ModelDimensions{
+		HiddenSize:        firstNonZero(r.HiddenSize, r.DModel, r.NEmbd),
+		NumHiddenLayers:   firstNonZero(r.NumHiddenLayer, r.NumLayers, r.NLayers, r.NLayer),
+		NumAttentionHeads: firstNonZero(r.NumHeads, r.NHeads, r.NHead),
+		NumKeyValueHeads:  firstNonZero(r.NumKVHeads, r.MultiQueryNum, r.AttnConfig.KVNHeads),
+		HeadDim:           firstNonZero(r.HeadDim, r.KVChannels),
+		VocabSize:         firstNonZero(r.VocabSize, r.PaddedVocab),
+		KVLoraRank:        r.KVLoraRank,
+		QKRopeHeadDim:     r.QKRopeHeadDim,
+	}
+	if stateSize := firstNonZero(r.StateSize, r.MambaDState); stateSize > 0 {
+		dims.NumSSMLayers = r.ssmLayers(dims.NumHiddenLayers)
+		dims.SSMStateSize = stateSize
+		dims.SSMInnerSize = firstNonZero(r.Expand, r.MambaExpand, 2) * dims.HiddenSize
+		dims.SSMConvKernel = firstNonZero(r.ConvKernel, r.MambaDConv, 4)
+		dims.SSMNumGroups = firstNonZero(r.NGroups, r.MambaNGroups)
+	}
+	return dims
+}
+
+// ssmLayers returns the number of state space layers of a model with a state size: the layers that aren't listed
+// as attention layers by a hybrid model, all the layers of a pure state space model
+func (r *rawModelDimensions) ssmLayers(numLayers int) int {
+	switch {
+	case len(r.AttnLayerIndices) > 0:
+		return numLayers - len(r.AttnLayerIndices)
+	case r.AttnLayerPeriod > 0:
+		return numLayers - countLayers(numLayers, r.AttnLayerPeriod, r.AttnLayerOffset)
+	case len(r.LayerTypes) > 0:
+		count := 0
+		for _, layerType := range r.LayerTypes {
+			if layerType == "mamba" {
+				count++
+			}
+		}
+		return count
+	}
+	return numLayers
+}
+
+func firstNonZero(values ...int) int {
+	for _, v := range values {
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+// LoadModelDimensions reads the transformer dimensions of a config.json. The dimensions of multimodal models are
+// read from their nested language model config (text_config, llm_config or language_config).
+func LoadModelDimensions(configPath string) (*ModelDimensions, error) {
+	return loadModelDimensions(configPath, nil)
+}
+
+// loadModelDimensions reads the transformer dimensions of a config.json, renaming its keys with a mapping and the
+// common aliases
+func loadModelDimensions(configPath string, mapping GenericFieldMapping) (*ModelDimensions, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", configPath, err)
+	}
+
+	data = renameConfigFields(SanitizeJSONBytes(data), mapping)
+	var raw rawModelDimensions
+	if err := json.Unmarshal(languageModelConfig(data), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config JSON from '%s': %w", configPath, err)
+	}
+	dims := raw.dimensions()
+
+	if err := dims.complete(); err != nil {
+		return nil, fmt.Errorf("invalid model dimensions in '%s': %w", configPath, err)
+	}
+	return &dims, nil
+}
+
+// languageModelConfig returns the config of the language model of a config.json: the config itself, or the nested
+// config of the multimodal models whose top level has no attention layers
+func languageModelConfig(data []byte) []byte {
+	var raw rawModelDimensions
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return data
+	}
+	if dims := raw.dimensions(); dims.NumHiddenLayers != 0 && dims.NumAttentionHeads != 0 {
+		return data
+	}
+
+	var nested map[string]json.RawMessage
+	if err := json.Unmarshal(data, &nested); err != nil {
+		return data
+	}
+	for _, key := range nestedLLMConfigKeys {
+		sub, ok := nested[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(sub, &raw); err == nil {
+			return sub
+		}
+	}
+	return data
+}
+
+// normalize fills the dimensions derived from the others
+func (d *ModelDimensions) normalize() {
+	if d.NumKeyValueHeads == 0 {
+		d.NumKeyValueHeads = d.NumAttentionHeads // Multi-head attention
+	}
+	if d.HeadDim == 0 && d.NumAttentionHeads > 0 {
+		d.HeadDim = d.HiddenSize / d.NumAttentionHeads
+	}
+}
+
+// complete fills the dimensions derived from the others and checks the required ones are set. The missing
+// dimension is returned as a ConfigWarning.
+func (d *ModelDimensions) complete() error {
+	if d.NumHiddenLayers <= 0 {
+		return ConfigWarning{Field: "num_hidden_layers", Message: fmt.Sprintf("must be positive, got %d", d.NumHiddenLayers)}
+	}
+	if d.NumAttentionHeads <= 0 && d.AttentionLayers() > 0 {
+		return ConfigWarning{Field: "num_attention_heads", Message: fmt.Sprintf("must be positive, got %d", d.NumAttentionHeads)}
+	}
+	d.normalize()
+	if d.HeadDim <= 0 && d.KVLoraRank <= 0 && d.AttentionLayers() > 0 {
+		return ConfigWarning{Field: "head_dim", Message: fmt.Sprintf("must be positive, got %d", d.HeadDim)}
+	}
+	return nil
+}
+
+// AttentionLayers returns the number of layers with a KV cache, the layers that aren't state space layers
+func (d *ModelDimensions) AttentionLayers() int {
+	return d.NumHiddenLayers - d.NumSSMLayers
+}
+
+// GetModelDimensions returns the transformer dimensions of a loaded model
+func GetModelDimensions(model HuggingFaceModel) (*ModelDimensions, error) {
+	if gguf, ok := model.(*GGUFModelConfig); ok {
+		dims := gguf.dimensions
+		if err := dims.complete(); err != nil {
+			return nil, fmt.Errorf("invalid model dimensions in '%s': %w", gguf.ConfigPath, err)
+		}
+		return &dims, nil
+	}
+
+	if generic, ok := model.(*GenericModelConfig); ok && generic.fieldMapping != nil {
+		return loadModelDimensions(generic.ConfigPath, generic.fieldMapping)
+	}
+
+	configFile, ok := model.(interface{ configFile() string })
+	if !ok || configFile.configFile() == "" {
+		return nil, fmt.Errorf("no config file to read the dimensions of %s model from", model.GetModelType())
+	}
+	return LoadModelDimensions(configFile.configFile())
+}
+
+// KVCacheBytesPerToken returns the size of the KV cache of a token on one of the tensor parallel ranks. The
+// key-value heads are split across the ranks, and replicated when there are fewer heads than ranks, while the
+// latent of multi-head latent attention is replicated on every rank.
+func (d *ModelDimensions) KVCacheBytesPerToken(dtype string, tensorParallel int) int64 {
+	sizePerValue := cacheDtypeSize(dtype)
+
+	var valuesPerLayer int
+	if d.KVLoraRank > 0 {
+		valuesPerLayer = d.KVLoraRank + d.QKRopeHeadDim
+	} else {
+		kvHeads := (d.NumKeyValueHeads + tensorParallel - 1) / tensorParallel
+		valuesPerLayer = 2 * kvHeads * d.HeadDim // Keys and values
+	}
+	return int64(float64(d.AttentionLayers()*valuesPerLayer) * sizePerValue)
+}
+
+// SSMStateBytesPerSequence returns the size of the state of the state space layers of a sequence on one of the
+// tensor parallel ranks: the inputs of the convolution over the last d_conv-1 tokens and the d_state values of
+// every channel. The channels are split across the ranks, like the groups of Mamba2, which are replicated when
+// there are fewer groups than ranks.
+func (d *ModelDimensions) SSMStateBytesPerSequence(dtype string, tensorParallel int) int64 {
+	if d.NumSSMLayers <= 0 {
+		return 0
+	}
+	sizePerValue := cacheDtypeSize(dtype)
+
+	inner := (d.SSMInnerSize + tensorParallel - 1) / tensorParallel
+	groups := (d.SSMNumGroups + tensorParallel - 1) / tensorParallel
+	convChannels := inner + 2*groups*d.SSMStateSize // Mamba2 convolves B and C with the channels
+	valuesPerLayer := convChannels*(d.SSMConvKernel-1) + inner*d.SSMStateSize
+	return int64(float64(d.NumSSMLayers*valuesPerLayer) * sizePerValue)
+}
+
+// cacheDtypeSize returns the size of a value of the KV cache or the state stored as dtype
+func cacheDtypeSize(dtype string) float64 {
+	sizePerValue, ok := DtypeSizeBytes[strings.ToLower(dtype)]
+	if !ok {
+		sizePerValue = 2.0 // default to bfloat16
+	}
+	return sizePerValue
+}
+
+// ServingMemoryEstimate is the GPU memory needed on each tensor parallel rank to serve a model
+type ServingMemoryEstimate struct {
+	// WeightsBytes is the size of the shard of the weights
+	WeightsBytes int64
+	// KVCacheBytes is the size of the KV cache of the full context of every sequence of the batch
+	KVCacheBytes int64
+	// SSMStateBytes is the size of the state of the state space layers of every sequence of the batch, which
+	// doesn't grow with the context
+	SSMStateBytes int64
+	// TotalBytes is the sum of the weights, the KV cache and the state. The activations, the CUDA graphs and the memory
+	// reserved by the serving runtime come on top of it, so it is a lower bound of the memory of a GPU.
+	TotalBytes int64
+}
+
+// EstimateServingMemory estimates the GPU memory needed on each GPU to serve a model with batchSize sequences of
+// contextLen tokens over tensorParallel GPUs. The KV cache is stored as dtype, the data type of the model when
+// empty, and the state of the state space layers of Mamba and hybrid models as the data type of the model. It
+// returns an error for the configurations that can't be served: a context longer than the one of the model, or
+// attention heads that can't be split across the GPUs.
+func EstimateServingMemory(model HuggingFaceModel, contextLen, batchSize int, dtype string, tensorParallel int) (*ServingMemoryEstimate, error) {
+	if contextLen <= 0 {
+		return nil, fmt.Errorf("context length must be positive, got %d", contextLen)
+	}
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	if tensorParallel <= 0 {
+		return nil, fmt.Errorf("tensor parallel size must be positive, got %d", tensorParallel)
+	}
+	if maxContextLen := model.GetContextLength(); maxContextLen > 0 && contextLen > maxContextLen {
+		return nil, fmt.Errorf("context length %d exceeds the maximum context length %d of the model", contextLen, maxContextLen)
+	}
+
+	dims, err := GetModelDimensions(model)
+	if err != nil {
+		return nil, err
+	}
+	if dims.AttentionLayers() > 0 && dims.NumAttentionHeads%tensorParallel != 0 {
+		return nil, fmt.Errorf("%d attention heads can't be split across tensor parallel size %d", dims.NumAttentionHeads, tensorParallel)
+	}
+
+	if dtype == "" {
+		dtype = model.GetTorchDtype()
+	}
+	if _, ok := DtypeSizeBytes[strings.ToLower(dtype)]; !ok && dtype != "" {
+		return nil, fmt.Errorf("unsupported KV cache data type %q", dtype)
+	}
+
+	weights, ok := safetensorsSize(model)
+	if !ok {
+		weights = EstimateQuantizedModelSizeBytes(model.GetParameterCount(), model.GetTorchDtype(), model.GetBitsPerWeight())
+	}
+	estimate := &ServingMemoryEstimate{
+		WeightsBytes:  weights / int64(tensorParallel),
+		KVCacheBytes:  dims.KVCacheBytesPerToken(dtype, tensorParallel) * int64(contextLen) * int64(batchSize),
+		SSMStateBytes: dims.SSMStateBytesPerSequence(model.GetTorchDtype(), tensorParallel) * int64(batchSize),
+	}
+	estimate.TotalBytes = estimate.WeightsBytes + estimate.KVCacheBytes + estimate.SSMStateBytes
+	return estimate, nil
+}
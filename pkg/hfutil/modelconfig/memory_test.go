@@ -0,0 +1,217 @@
+package modelconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEstimateServingMemory(t *testing.T) {
+	model, err := LoadModelConfig(filepath.Join("testdata", "llama3_2_1b.json"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	estimate, err := EstimateServingMemory(model, 8192, 4, "", 2)
+	if err != nil {
+		t.Fatalf("EstimateServingMemory failed: %v", err)
+	}
+
+	// 16 layers * (keys + values) * 4 of the 8 key-value heads * 64 dims * 2 bytes of bfloat16
+	expectedKVCache := int64(16*2*4*64*2) * 8192 * 4
+	if estimate.KVCacheBytes != expectedKVCache {
+		t.Errorf("Expected KV cache of %d bytes, got %d", expectedKVCache, estimate.KVCacheBytes)
+	}
+	expectedWeights := EstimateModelSizeBytes(model.GetParameterCount(), "bfloat16") / 2
+	if estimate.WeightsBytes != expectedWeights {
+		t.Errorf("Expected weights of %d bytes, got %d", expectedWeights, estimate.WeightsBytes)
+	}
+	if estimate.TotalBytes != expectedKVCache+expectedWeights {
+		t.Errorf("Expected total of %d bytes, got %d", expectedKVCache+expectedWeights, estimate.TotalBytes)
+	}
+
+	// An FP8 KV cache halves the KV cache
+	fp8, err := EstimateServingMemory(model, 8192, 4, "fp8", 2)
+	if err != nil {
+		t.Fatalf("EstimateServingMemory failed: %v", err)
+	}
+	if fp8.KVCacheBytes != expectedKVCache/2 {
+		t.Errorf("Expected FP8 KV cache of %d bytes, got %d", expectedKVCache/2, fp8.KVCacheBytes)
+	}
+
+	// The key-value heads are replicated when there are more ranks than heads
+	tp16, err := EstimateServingMemory(model, 1024, 1, "", 16)
+	if err != nil {
+		t.Fatalf("EstimateServingMemory failed: %v", err)
+	}
+	if expected := int64(16*2*1*64*2) * 1024; tp16.KVCacheBytes != expected {
+		t.Errorf("Expected KV cache of %d bytes with replicated heads, got %d", expected, tp16.KVCacheBytes)
+	}
+}
+
+func TestEstimateServingMemoryInvalid(t *testing.T) {
+	model, err := LoadModelConfig(filepath.Join("testdata", "llama3_2_1b.json"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	testCases := []struct {
+		name           string
+		contextLen     int
+		batchSize      int
+		dtype          string
+		tensorParallel int
+	}{
+		{"context longer than the model", 262144, 1, "", 1},
+		{"heads not divisible", 4096, 1, "", 3},
+		{"no batch", 4096, 0, "", 1},
+		{"no tensor parallel", 4096, 1, "", 0},
+		{"unknown dtype", 4096, 1, "fp3", 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := EstimateServingMemory(model, tc.contextLen, tc.batchSize, tc.dtype, tc.tensorParallel); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestModelDimensionsMLA(t *testing.T) {
+	dims, err := LoadModelDimensions(filepath.Join("testdata", "deepseek_v3.json"))
+	if err != nil {
+		t.Fatalf("LoadModelDimensions failed: %v", err)
+	}
+
+	// The latent is cached once per layer and replicated on every rank: 61 layers * (512 + 64) * 1 byte of FP8
+	for _, tensorParallel := range []int{1, 8} {
+		if perToken := dims.KVCacheBytesPerToken("fp8", tensorParallel); perToken != 61*576 {
+			t.Errorf("Expected %d bytes per token with tensor parallel size %d, got %d", 61*576, tensorParallel, perToken)
+		}
+	}
+}
+
+func TestModelDimensionsNested(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "multimodal",
+		"text_config": {
+			"hidden_size": 4096,
+			"num_hidden_layers": 32,
+			"num_attention_heads": 32,
+			"num_key_value_heads": 8,
+			"vocab_size": 32000
+		},
+		"vision_config": {
+			"hidden_size": 1024,
+			"num_hidden_layers": 24
+		}
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	dims, err := LoadModelDimensions(configPath)
+	if err != nil {
+		t.Fatalf("LoadModelDimensions failed: %v", err)
+	}
+	if dims.NumHiddenLayers != 32 || dims.NumKeyValueHeads != 8 || dims.HeadDim != 128 || dims.VocabSize != 32000 {
+		t.Errorf("Unexpected dimensions %+v", dims)
+	}
+}
+
+func TestModelDimensionsAliases(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "custom",
+		"hidden_size": 2048,
+		"num_layers": 24,
+		"num_heads": 16,
+		"vocab_size": 50000
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	// The common aliases apply to the configs of the model types without a registered mapping
+	dims, err := LoadModelDimensions(configPath)
+	if err != nil {
+		t.Fatalf("LoadModelDimensions failed: %v", err)
+	}
+	if dims.NumHiddenLayers != 24 || dims.NumAttentionHeads != 16 || dims.HeadDim != 128 {
+		t.Errorf("Unexpected dimensions %+v", dims)
+	}
+}
+
+func TestModelDimensionsGGUF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gguf")
+	writeGGUF(t, path, []ggufKV{
+		{"general.architecture", "llama"},
+		{"general.file_type", uint32(15)},
+		{"llama.context_length", uint32(131072)},
+		{"llama.embedding_length", uint32(2048)},
+		{"llama.block_count", uint32(16)},
+		{"llama.attention.head_count", uint32(32)},
+		{"llama.attention.head_count_kv", uint32(8)},
+	}, nil)
+
+	model, err := LoadModelConfig(path)
+	if err != nil {
+		t.Fatalf("LoadModelConfig failed: %v", err)
+	}
+	dims, err := GetModelDimensions(model)
+	if err != nil {
+		t.Fatalf("GetModelDimensions failed: %v", err)
+	}
+	if perToken := dims.KVCacheBytesPerToken("float16", 1); perToken != 16*2*8*64*2 {
+		t.Errorf("Expected %d bytes per token, got %d", 16*2*8*64*2, perToken)
+	}
+}
+
+func TestEstimateServingMemorySSM(t *testing.T) {
+	// Mamba keeps no KV cache, only the state of each sequence, stored as the float32 of the model
+	mamba, err := LoadModelConfig(filepath.Join("testdata", "mamba_2.8b.json"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	estimate, err := EstimateServingMemory(mamba, 65536, 8, "", 1)
+	if err != nil {
+		t.Fatalf("EstimateServingMemory failed: %v", err)
+	}
+	if estimate.KVCacheBytes != 0 {
+		t.Errorf("Expected no KV cache, got %d bytes", estimate.KVCacheBytes)
+	}
+	// 64 layers * 5120 channels * (3 convolution inputs + 16 states) * 4 bytes
+	if expected := int64(64*5120*(3+16)*4) * 8; estimate.SSMStateBytes != expected {
+		t.Errorf("Expected state of %d bytes, got %d", expected, estimate.SSMStateBytes)
+	}
+	if estimate.TotalBytes != estimate.WeightsBytes+estimate.SSMStateBytes {
+		t.Errorf("Expected the total to include the state, got %+v", estimate)
+	}
+
+	// Mamba2 convolves the B and C matrices of its groups with the channels, both are split across the ranks
+	dims, err := LoadModelDimensions(filepath.Join("testdata", "mamba2_codestral_7b.json"))
+	if err != nil {
+		t.Fatalf("LoadModelDimensions failed: %v", err)
+	}
+	// 64 layers * ((4096 channels + 2 * 4 groups * 128) * 3 + 4096 channels * 128 states) * 2 bytes
+	if perSequence := dims.SSMStateBytesPerSequence("bfloat16", 2); perSequence != 64*((4096+2*4*128)*3+4096*128)*2 {
+		t.Errorf("Expected %d bytes per sequence, got %d", 64*((4096+2*4*128)*3+4096*128)*2, perSequence)
+	}
+
+	// Jamba only caches the keys and values of its 4 attention layers, its 28 Mamba layers keep a state
+	jamba, err := LoadModelConfig(filepath.Join("testdata", "jamba_v0.1.json"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	estimate, err = EstimateServingMemory(jamba, 131072, 1, "", 1)
+	if err != nil {
+		t.Fatalf("EstimateServingMemory failed: %v", err)
+	}
+	if expected := int64(4*2*8*128*2) * 131072; estimate.KVCacheBytes != expected {
+		t.Errorf("Expected KV cache of %d bytes, got %d", expected, estimate.KVCacheBytes)
+	}
+	if expected := int64(28 * 8192 * (3 + 16) * 2); estimate.SSMStateBytes != expected {
+		t.Errorf("Expected state of %d bytes, got %d", expected, estimate.SSMStateBytes)
+	}
+}
@@ -85,6 +85,11 @@ func (c *MiniCPMConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *MiniCPMConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *MiniCPMConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -95,11 +100,6 @@ func (c *MiniCPMConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *MiniCPMConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for base MiniCPM models
 func (c *MiniCPMConfig) HasVision() bool {
 	return false
@@ -104,13 +104,13 @@ func (c *MistralConfig) GetParameterCount() int64 {
 	return 0
 }
 
-func (c *MistralConfig) GetTransformerVersion() string {
-	return c.TransformerVersion
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *MistralConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
 }
 
-func (c *MistralConfig) GetQuantizationType() string {
-	// Mistral doesn't have quantization in this config
-	return ""
+func (c *MistralConfig) GetTransformerVersion() string {
+	return c.TransformerVersion
 }
 
 func (c *MistralConfig) GetArchitecture() string {
@@ -112,16 +112,23 @@ func (c *MixtralConfig) GetParameterCount() int64 {
 	return 46_700_000_000 // 46.7B for Mixtral-8x7B
 }
 
+// GetNumExperts returns the number of experts of each layer
+func (c *MixtralConfig) GetNumExperts() int {
+	return c.NumLocalExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, with the num_experts_per_tok
+// experts a token is routed to. Mixtral-8x7B uses 12.9B of its 46.7B parameters.
+func (c *MixtralConfig) GetActiveParameterCount() int64 {
+	return activeMoEParams(c.GetParameterCount(), c.NumHiddenLayers, c.NumLocalExperts, c.NumExpertsPerTok,
+		int64(3*c.HiddenSize*c.IntermediateSize))
+}
+
 // GetTransformerVersion returns the transformers library version
 func (c *MixtralConfig) GetTransformerVersion() string {
 	return c.TransformerVersion
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *MixtralConfig) GetQuantizationType() string {
-	return "" // No quantization config for Mixtral by default
-}
-
 // GetArchitecture returns the model architecture
 func (c *MixtralConfig) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
@@ -61,6 +61,16 @@ func TestMixtralConfig(t *testing.T) {
 		t.Errorf("Incorrect model size, expected %s, got %s",
 			FormatSize(expectedSize), FormatSize(modelSize))
 	}
+
+	// Each token is routed to 2 of the 8 experts of every layer
+	if config.GetNumExperts() != 8 {
+		t.Errorf("Incorrect number of experts, expected 8, got %d", config.GetNumExperts())
+	}
+	expectedActive := expectedCount - 32*6*3*4096*14336
+	if activeCount := config.GetActiveParameterCount(); activeCount != expectedActive {
+		t.Errorf("Incorrect active parameter count, expected %s, got %s",
+			FormatParamCount(expectedActive), FormatParamCount(activeCount))
+	}
 }
 
 func TestLoadModelWithMixtral(t *testing.T) {
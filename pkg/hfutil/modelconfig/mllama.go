@@ -128,6 +128,11 @@ func (c *MLlamaConfig) GetParameterCount() int64 {
 	return textParams + visionParams
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *MLlamaConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // Helper function to estimate text model parameters
 func estimateTextParams(hiddenSize, layers, intermediateSize int) int64 {
 	// This is a rough estimate; actual count could vary
@@ -145,11 +150,6 @@ func (c *MLlamaConfig) GetTransformerVersion() string {
 	return c.TransformerVersion
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *MLlamaConfig) GetQuantizationType() string {
-	return "" // MLlama models don't have quantization by default
-}
-
 // GetArchitecture returns the model architecture
 func (c *MLlamaConfig) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
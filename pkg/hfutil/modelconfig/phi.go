@@ -72,6 +72,11 @@ func (c *PhiModelConfig) GetParameterCount() int64 {
 	return 0
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *PhiModelConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetTransformerVersion returns the transformers library version
 func (c *PhiModelConfig) GetTransformerVersion() string {
 	return c.TransformersVersion
@@ -83,6 +88,21 @@ func (c *PhiModelConfig) GetQuantizationType() string {
 	return ""
 }
 
+// GetBitsPerWeight returns 0 since the Phi config has no quantization config
+func (c *PhiModelConfig) GetBitsPerWeight() float64 {
+	return 0
+}
+
+// GetNumExperts returns 0 since Phi models are dense
+func (c *PhiModelConfig) GetNumExperts() int {
+	return 0
+}
+
+// configFile returns the path of the config file the model was loaded from
+func (c *PhiModelConfig) configFile() string {
+	return c.ConfigPath
+}
+
 // GetArchitecture returns the model architecture
 func (c *PhiModelConfig) GetArchitecture() string {
 	if len(c.Architectures) > 0 {
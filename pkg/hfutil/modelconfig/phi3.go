@@ -93,6 +93,11 @@ func (c *Phi3Config) GetParameterCount() int64 {
 	return 0
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Phi3Config) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Phi3Config) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -103,11 +108,6 @@ func (c *Phi3Config) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Phi3Config) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false since this is not a multimodal vision model
 func (c *Phi3Config) HasVision() bool {
 	return false
@@ -82,6 +82,11 @@ func (c *Phi3VConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Phi3VConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Phi3VConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -92,11 +97,6 @@ func (c *Phi3VConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Phi3VConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns true since this is a multimodal vision model
 func (c *Phi3VConfig) HasVision() bool {
 	return c.ImgProcessor != nil
@@ -104,6 +104,11 @@ func (c *Phi3SmallConfig) GetParameterCount() int64 {
 	return 0
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Phi3SmallConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Phi3SmallConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -114,11 +119,6 @@ func (c *Phi3SmallConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Phi3SmallConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false since this is not a multimodal vision model
 func (c *Phi3SmallConfig) HasVision() bool {
 	return false
@@ -106,6 +106,18 @@ func (c *PhiMoEConfig) GetParameterCount() int64 {
 	return 0
 }
 
+// GetNumExperts returns the number of experts of each layer
+func (c *PhiMoEConfig) GetNumExperts() int {
+	return c.NumLocalExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, with the num_experts_per_tok
+// experts a token is routed to. Phi-3.5-MoE uses 6.6B of its 42B parameters.
+func (c *PhiMoEConfig) GetActiveParameterCount() int64 {
+	return activeMoEParams(c.GetParameterCount(), c.NumHiddenLayers, c.NumLocalExperts, c.NumExpertsPerTok,
+		int64(3*c.HiddenSize*c.IntermediateSize))
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *PhiMoEConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -116,11 +128,6 @@ func (c *PhiMoEConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *PhiMoEConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false since this is not a multimodal vision model
 func (c *PhiMoEConfig) HasVision() bool {
 	return false
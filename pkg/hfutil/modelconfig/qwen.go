@@ -48,9 +48,6 @@ type QwenConfig struct {
 	FP32           bool   `json:"fp32"`
 	OnnxSafe       *bool  `json:"onnx_safe"`
 	TokenizerClass string `json:"tokenizer_class"`
-
-	// Quantization
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
 }
 
 // LoadQwenConfig loads a Qwen v1 model configuration from a JSON file
@@ -95,6 +92,11 @@ func (c *QwenConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *QwenConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *QwenConfig) GetContextLength() int {
 	// Qwen v1 uses seq_length as the primary context length field
@@ -110,14 +112,6 @@ func (c *QwenConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *QwenConfig) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // HasVision returns false for Qwen v1 base models
 func (c *QwenConfig) HasVision() bool {
 	return false // Base Qwen v1 models don't have vision capabilities
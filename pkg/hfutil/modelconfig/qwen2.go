@@ -39,9 +39,6 @@ type Qwen2Config struct {
 	TieWordEmbeddings bool `json:"tie_word_embeddings"`
 	UseCache          bool `json:"use_cache"`
 	UseSlidingWindow  bool `json:"use_sliding_window"`
-
-	// Quantization
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
 }
 
 // LoadQwen2Config loads a Qwen2 model configuration from a JSON file
@@ -86,6 +83,11 @@ func (c *Qwen2Config) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Qwen2Config) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Qwen2Config) GetContextLength() int {
 	// Use the seq_length field if available as it's more specific for Qwen2
@@ -100,14 +102,6 @@ func (c *Qwen2Config) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Qwen2Config) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // HasVision returns false for Qwen2 base models
 func (c *Qwen2Config) HasVision() bool {
 	return false // Base Qwen2 models don't have vision capabilities
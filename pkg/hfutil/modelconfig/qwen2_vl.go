@@ -75,9 +75,6 @@ type Qwen2VLConfig struct {
 	TieWordEmbeddings bool `json:"tie_word_embeddings"`
 	UseCache          bool `json:"use_cache"`
 	UseSlidingWindow  bool `json:"use_sliding_window"`
-
-	// Quantization
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
 }
 
 // LoadQwen2VLConfig loads a Qwen2-VL model configuration from a JSON file
@@ -131,6 +128,11 @@ func (c *Qwen2VLConfig) GetParameterCount() int64 {
 	return languageParams + visionParams
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Qwen2VLConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Qwen2VLConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -141,14 +143,6 @@ func (c *Qwen2VLConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Qwen2VLConfig) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // HasVision returns true for Qwen2-VL models
 func (c *Qwen2VLConfig) HasVision() bool {
 	return true // Qwen2-VL models have vision capabilities
@@ -159,6 +153,24 @@ func (c *Qwen2VLConfig) IsEmbedding() bool {
 	return false
 }
 
+// GetVisionTower returns the vision encoder and the image token budget. Qwen2-VL encodes the images at their
+// resolution, merging every spatial_merge_size x spatial_merge_size patches into a token, so the budget is set by
+// the max_pixels of the image processor.
+func (c *Qwen2VLConfig) GetVisionTower() *VisionTowerSpec {
+	vc := c.VisionConfig
+	spec := &VisionTowerSpec{
+		PatchSize:       vc.PatchSize,
+		HiddenSize:      firstNonZero(vc.EmbedDim, vc.HiddenSize),
+		NumHiddenLayers: vc.Depth,
+	}
+	if vc.PatchSize <= 0 {
+		return spec
+	}
+	tokenSide := vc.PatchSize * max(vc.SpatialMergeSize, 1)
+	spec.MaxImageTokens = loadMaxPixels(c.ConfigPath) / (tokenSide * tokenSide)
+	return spec
+}
+
 // Register the Qwen2-VL and Qwen2.5-VL model handlers
 func init() {
 	RegisterModelLoader("qwen2_vl", func(configPath string) (HuggingFaceModel, error) {
@@ -2,6 +2,8 @@ package modelconfig
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -168,3 +170,37 @@ func TestQwen2_5VLSpecificFields(t *testing.T) {
 		t.Errorf("Expected VisionConfig.HiddenAct to be 'silu', got '%s'", vc.HiddenAct)
 	}
 }
+
+func TestQwen2VLVisionTower(t *testing.T) {
+	config, err := LoadQwen2VLConfig("testdata/qwen2_vl_7b.json")
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Without preprocessor_config.json, the default max_pixels of the image processor gives 1280 tokens
+	tower := config.GetVisionTower()
+	if tower.MaxImageTokens != 1280 {
+		t.Errorf("Expected 1280 image tokens, got %d", tower.MaxImageTokens)
+	}
+	if tower.PatchSize != 14 || tower.ImageTokens != 0 {
+		t.Errorf("Unexpected vision tower %+v", tower)
+	}
+
+	// Each token covers 28x28 pixels
+	dir := t.TempDir()
+	data, err := os.ReadFile("testdata/qwen2_vl_7b.json")
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	writeTokenizerFiles(t, dir, map[string]string{
+		"config.json":              string(data),
+		"preprocessor_config.json": `{"min_pixels": 3136, "max_pixels": 12845056, "patch_size": 14}`,
+	})
+	config, err = LoadQwen2VLConfig(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if tokens := config.GetVisionTower().MaxImageTokens; tokens != 16384 {
+		t.Errorf("Expected 16384 image tokens, got %d", tokens)
+	}
+}
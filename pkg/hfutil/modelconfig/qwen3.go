@@ -45,9 +45,6 @@ type Qwen3Config struct {
 
 	// Embedding config
 	SimilarityFnName string `json:"similarity_fn_name"`
-
-	// Quantization
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
 }
 
 // LoadQwen3Config loads a Qwen3 model configuration from a JSON file
@@ -90,6 +87,11 @@ func (c *Qwen3Config) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *Qwen3Config) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Qwen3Config) GetContextLength() int {
 	// Use the seq_length field if available as it's more specific for Qwen3
@@ -104,14 +106,6 @@ func (c *Qwen3Config) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Qwen3Config) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // HasVision returns false for Qwen3 base models
 func (c *Qwen3Config) HasVision() bool {
 	return false // Base Qwen3 models don't have vision capabilities
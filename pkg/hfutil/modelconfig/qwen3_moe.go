@@ -59,9 +59,6 @@ type Qwen3MoeConfig struct {
 	// Misc options
 	TieWordEmbeddings bool `json:"tie_word_embeddings"`
 	UseCache          bool `json:"use_cache"`
-
-	// Quantization
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
 }
 
 // LoadQwen3MoeConfig loads a Qwen3Moe model configuration from a JSON file
@@ -93,6 +90,18 @@ func (c *Qwen3MoeConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetNumExperts returns the number of experts of each MoE layer
+func (c *Qwen3MoeConfig) GetNumExperts() int {
+	return c.NumExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token, with the num_experts_per_tok
+// experts a token is routed to in the layers not listed in mlp_only_layers
+func (c *Qwen3MoeConfig) GetActiveParameterCount() int64 {
+	return activeMoEParams(c.GetParameterCount(), c.NumHiddenLayers-len(c.MlpOnlyLayers), c.NumExperts,
+		c.NumExpertsPerTok, int64(3*c.HiddenSize*c.MoeIntermediateSize))
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *Qwen3MoeConfig) GetContextLength() int {
 	if c.SeqLength > 0 {
@@ -106,14 +115,6 @@ func (c *Qwen3MoeConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *Qwen3MoeConfig) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // HasVision returns false for Qwen3Moe base models
 func (c *Qwen3MoeConfig) HasVision() bool {
 	return false
@@ -16,9 +16,6 @@ type Qwen3VLConfig struct {
 	VisionConfig       Qwen3VLVisionConfig `json:"vision_config"`
 	VisionStartTokenId int                 `json:"vision_start_token_id"`
 	VisionEndTokenId   int                 `json:"vision_end_token_id"`
-
-	// Quantization
-	QuantizationConfig *QuantizationConfig `json:"quantization_config,omitempty"`
 }
 
 // Qwen3VLTextConfig represents the text transformer configuration.
@@ -137,6 +134,19 @@ func (c *Qwen3VLConfig) GetParameterCount() int64 {
 	return languageParams + visionParams
 }
 
+// GetNumExperts returns the number of experts of each MoE layer of the language model, 0 for the dense models
+func (c *Qwen3VLConfig) GetNumExperts() int {
+	return c.TextConfig.NumExperts
+}
+
+// GetActiveParameterCount returns the number of parameters used for each token. For the MoE models, it includes the
+// num_experts_per_tok experts a token is routed to in the layers not listed in mlp_only_layers.
+func (c *Qwen3VLConfig) GetActiveParameterCount() int64 {
+	tc := c.TextConfig
+	return activeMoEParams(c.GetParameterCount(), tc.NumHiddenLayers-len(tc.MlpOnlyLayers), tc.NumExperts,
+		tc.NumExpertsPerTok, int64(3*tc.HiddenSize*tc.MoeIntermediateSize))
+}
+
 // GetContextLength returns the maximum context length supported by the model.
 func (c *Qwen3VLConfig) GetContextLength() int {
 	return c.TextConfig.MaxPositionEmbeddings
@@ -147,14 +157,6 @@ func (c *Qwen3VLConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any).
-func (c *Qwen3VLConfig) GetQuantizationType() string {
-	if c.QuantizationConfig != nil && c.QuantizationConfig.QuantMethod != "" {
-		return c.QuantizationConfig.QuantMethod
-	}
-	return ""
-}
-
 // HasVision returns true for Qwen3-VL models.
 func (c *Qwen3VLConfig) HasVision() bool {
 	return true
@@ -0,0 +1,250 @@
+package modelconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+)
+
+// hubURIPrefix is the prefix of the Hugging Face model URIs, hf://{model-id}[@{branch}]
+const hubURIPrefix = "hf://"
+
+// remoteMetadataFileNames are the optional files fetched along with the config of a remote model: the safetensors
+// index, the tokenizer, generation and preprocessor configs, and the sentence-transformers configs
+var remoteMetadataFileNames = []string{
+	hub.SafetensorsIndexFile,
+	constants.TokenizerConfigFileName,
+	constants.SpecialTokensMapFileName,
+	constants.TokenizerFileName,
+	constants.ChatTemplateFileName,
+	constants.LegacyChatTemplateFileName,
+	constants.GenerationConfigFileName,
+	constants.PreprocessorConfigFileName,
+	constants.SentenceTransformersConfigFileName,
+	constants.SentenceTransformersModulesFileName,
+	constants.SentenceBertConfigFileName,
+}
+
+// ObjectStorage reads the objects of a storage by URI. The providers of pkg/storage implement it.
+type ObjectStorage interface {
+	Exists(ctx context.Context, uri string) (bool, error)
+	Get(ctx context.Context, uri string) (io.ReadCloser, error)
+}
+
+// RemoteConfigOption configures how LoadModelConfigFromURI fetches the config files
+type RemoteConfigOption func(*remoteConfigOptions)
+
+type remoteConfigOptions struct {
+	hubClient *hub.HubClient
+	storage   ObjectStorage
+	localDir  string
+}
+
+// WithHubClient sets the client the files of hf:// models are fetched with, a client of the default hub config
+// otherwise
+func WithHubClient(client *hub.HubClient) RemoteConfigOption {
+	return func(o *remoteConfigOptions) {
+		o.hubClient = client
+	}
+}
+
+// WithStorage sets the storage the files of the models of the other URIs, e.g. oci:// or s3://, are read from
+func WithStorage(storage ObjectStorage) RemoteConfigOption {
+	return func(o *remoteConfigOptions) {
+		o.storage = storage
+	}
+}
+
+// WithLocalDir sets the directory the config files are fetched into. It defaults to a new directory under the
+// temporary directory for every load. The files are fetched again on every load, so that a directory reused across
+// loads doesn't serve the files of an earlier revision. The loaded model reads some of them lazily, e.g. the
+// safetensors index for its parameter count, so the directory must outlive the model.
+func WithLocalDir(localDir string) RemoteConfigOption {
+	return func(o *remoteConfigOptions) {
+		o.localDir = localDir
+	}
+}
+
+// remoteFileFetcher fetches a file of a remote model into a local directory. It returns false when the model has no
+// such file.
+type remoteFileFetcher func(ctx context.Context, name, localDir string) (bool, error)
+
+// LoadModelConfigFromURI loads the configuration of the model at a URI without downloading its weights. Only
+// config.json, or the model_index.json of diffusers pipelines, and the optional metadata files next to it are
+// fetched, so that the model can be inspected before any weights land on disk. Parameter counts are estimated from
+// the architecture and sizes are read from the safetensors index, as the weights aren't available.
+//
+// hf:// URIs are fetched from the Hugging Face Hub. Other URIs are read from the storage set with WithStorage, and
+// paths without a scheme are loaded from the local filesystem.
+func LoadModelConfigFromURI(ctx context.Context, uri string, opts ...RemoteConfigOption) (HuggingFaceModel, error) {
+	var options remoteConfigOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if !strings.Contains(uri, "://") {
+		return LoadModelConfig(filepath.Join(uri, "config.json"))
+	}
+
+	fetch, err := options.fetcher(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	localDir := options.localDir
+	if localDir == "" {
+		if localDir, err = os.MkdirTemp("", "ome-model-config-"); err != nil {
+			return nil, fmt.Errorf("failed to create a directory for the config of model '%s': %w", uri, err)
+		}
+	} else if err := os.MkdirAll(localDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory '%s': %w", localDir, err)
+	}
+
+	configPath := ""
+	for _, name := range []string{"config.json", "model_index.json"} {
+		found, err := fetch(ctx, name, localDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s of model '%s': %w", name, uri, err)
+		}
+		if found {
+			configPath = filepath.Join(localDir, name)
+			break
+		}
+	}
+	if configPath == "" {
+		return nil, fmt.Errorf("model '%s' has neither config.json nor model_index.json", uri)
+	}
+
+	for _, name := range remoteMetadataFileNames {
+		found, err := fetch(ctx, name, localDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s of model '%s': %w", name, uri, err)
+		}
+		if !found {
+			// A file left by an earlier load of the model may have been removed since
+			_ = os.Remove(filepath.Join(localDir, name))
+		}
+	}
+	if err := fetchSentenceTransformersModules(ctx, fetch, localDir); err != nil {
+		return nil, fmt.Errorf("failed to fetch the sentence-transformers modules of model '%s': %w", uri, err)
+	}
+
+	return LoadModelConfig(configPath)
+}
+
+// fetchSentenceTransformersModules fetches the configs of the modules listed in modules.json, if it was fetched
+func fetchSentenceTransformersModules(ctx context.Context, fetch remoteFileFetcher, localDir string) error {
+	var modules []sentenceTransformersModule
+	err := readJSONFile(filepath.Join(localDir, constants.SentenceTransformersModulesFileName), &modules)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, module := range modules {
+		var name string
+		switch {
+		case module.Path == "":
+			continue
+		case !filepath.IsLocal(filepath.FromSlash(module.Path)):
+			// modules.json comes with the model, don't let it write outside of the directory
+			return fmt.Errorf("path '%s' of module %s is not within the model", module.Path, module.Name)
+		case module.Type == sentenceTransformersTransformerModule:
+			name = path.Join(module.Path, constants.SentenceBertConfigFileName)
+		case module.Type == sentenceTransformersPoolingModule || module.Type == sentenceTransformersDenseModule:
+			name = path.Join(module.Path, "config.json")
+		default:
+			continue
+		}
+		if err := os.MkdirAll(filepath.Join(localDir, filepath.FromSlash(module.Path)), 0755); err != nil {
+			return err
+		}
+		if _, err := fetch(ctx, name, localDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetcher returns the fetcher of the files of the model at a URI
+func (o *remoteConfigOptions) fetcher(uri string) (remoteFileFetcher, error) {
+	if strings.HasPrefix(uri, hubURIPrefix) {
+		repoID, revision, _ := strings.Cut(strings.TrimPrefix(uri, hubURIPrefix), "@")
+		if repoID == "" {
+			return nil, fmt.Errorf("invalid Hugging Face model URI '%s': missing model ID", uri)
+		}
+		if revision == "" {
+			revision = hub.DefaultRevision
+		}
+
+		client := o.hubClient
+		if client == nil {
+			config, err := hub.NewHubConfig()
+			if err != nil {
+				return nil, fmt.Errorf("failed to create hub config: %w", err)
+			}
+			if client, err = hub.NewHubClient(config); err != nil {
+				return nil, fmt.Errorf("failed to create hub client: %w", err)
+			}
+		}
+		return hubFileFetcher(client, repoID, revision), nil
+	}
+
+	if o.storage == nil {
+		return nil, fmt.Errorf("no storage to read model '%s' from", uri)
+	}
+	return storageFileFetcher(o.storage, strings.TrimSuffix(uri, "/")), nil
+}
+
+// hubFileFetcher fetches the files of a revision of a Hugging Face Hub repository
+func hubFileFetcher(client *hub.HubClient, repoID, revision string) remoteFileFetcher {
+	return func(ctx context.Context, name, localDir string) (bool, error) {
+		// Download keeps a file already in the local directory, which may be of another revision
+		if err := os.Remove(filepath.Join(localDir, filepath.FromSlash(name))); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, err
+		}
+		_, err := client.Download(ctx, repoID, name, hub.WithRevision(revision), hub.WithLocalDir(localDir))
+		var notFound *hub.EntryNotFoundError
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+}
+
+// storageFileFetcher fetches the objects under the URI of a model in a storage
+func storageFileFetcher(storage ObjectStorage, modelURI string) remoteFileFetcher {
+	return func(ctx context.Context, name, localDir string) (bool, error) {
+		uri := modelURI + "/" + name
+		exists, err := storage.Exists(ctx, uri)
+		if err != nil || !exists {
+			return false, err
+		}
+
+		reader, err := storage.Get(ctx, uri)
+		if err != nil {
+			return false, err
+		}
+		defer reader.Close()
+
+		file, err := os.Create(filepath.Join(localDir, filepath.FromSlash(name)))
+		if err != nil {
+			return false, err
+		}
+		if _, err := io.Copy(file, reader); err != nil {
+			file.Close()
+			return false, err
+		}
+		return true, file.Close()
+	}
+}
@@ -0,0 +1,158 @@
+package modelconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+)
+
+// newMockHubServer serves the files of the main revision of a repository like the Hugging Face Hub
+func newMockHubServer(t *testing.T, repoID string, files map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := strings.CutPrefix(r.URL.Path, "/"+repoID+"/resolve/main/")
+		content, exists := files[name]
+		if !ok || !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(hub.HuggingfaceHeaderXRepoCommit, "abc123def456789012345678901234567890abcd")
+		w.Header().Set("ETag", fmt.Sprintf(`"%x"`, len(content)))
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(content))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// mapStorage is an object storage of the objects of a map
+type mapStorage map[string]string
+
+func (s mapStorage) Exists(_ context.Context, uri string) (bool, error) {
+	_, ok := s[uri]
+	return ok, nil
+}
+
+func (s mapStorage) Get(_ context.Context, uri string) (io.ReadCloser, error) {
+	content, ok := s[uri]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func TestLoadModelConfigFromURI_Hub(t *testing.T) {
+	config, err := os.ReadFile(filepath.Join("testdata", "llama3_2_1b.json"))
+	if err != nil {
+		t.Fatalf("Failed to read test config: %v", err)
+	}
+	files := map[string]string{
+		"config.json":                      string(config),
+		"tokenizer_config.json":            `{"tokenizer_class": "PreTrainedTokenizerFast", "model_max_length": 131072}`,
+		"model.safetensors.index.json":     `{"metadata": {"total_size": 2471645184}, "weight_map": {"lm_head.weight": "model-00001-of-00002.safetensors"}}`,
+		"model-00001-of-00002.safetensors": "weights",
+	}
+	server := newMockHubServer(t, "meta-llama/Llama-3.2-1B", files)
+	hubConfig, err := hub.NewHubConfig(hub.WithEndpoint(server.URL), hub.WithCacheDir(t.TempDir()))
+	if err != nil {
+		t.Fatalf("Failed to create hub config: %v", err)
+	}
+	client, err := hub.NewHubClient(hubConfig)
+	if err != nil {
+		t.Fatalf("Failed to create hub client: %v", err)
+	}
+
+	localDir := t.TempDir()
+	model, err := LoadModelConfigFromURI(context.Background(), "hf://meta-llama/Llama-3.2-1B",
+		WithHubClient(client), WithLocalDir(localDir))
+	if err != nil {
+		t.Fatalf("LoadModelConfigFromURI failed: %v", err)
+	}
+	if model.GetModelType() != "llama" || model.GetContextLength() != 131072 {
+		t.Errorf("Expected a llama model of 131072 tokens, got %s of %d tokens", model.GetModelType(), model.GetContextLength())
+	}
+
+	for _, name := range []string{"tokenizer_config.json", "model.safetensors.index.json"} {
+		if _, err := os.Stat(filepath.Join(localDir, name)); err != nil {
+			t.Errorf("Expected %s to be fetched: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(localDir, "model-00001-of-00002.safetensors")); !os.IsNotExist(err) {
+		t.Errorf("Expected the weights not to be fetched, got %v", err)
+	}
+
+	// A new revision of the files is fetched into the same directory
+	files["tokenizer_config.json"] = `{"tokenizer_class": "PreTrainedTokenizerFast", "model_max_length": 8192}`
+	if _, err := LoadModelConfigFromURI(context.Background(), "hf://meta-llama/Llama-3.2-1B",
+		WithHubClient(client), WithLocalDir(localDir)); err != nil {
+		t.Fatalf("LoadModelConfigFromURI failed: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(localDir, "tokenizer_config.json")); string(content) != files["tokenizer_config.json"] {
+		t.Errorf("Expected the new tokenizer config to be fetched, got %s", content)
+	}
+
+	if _, err := LoadModelConfigFromURI(context.Background(), "hf://meta-llama/Missing",
+		WithHubClient(client), WithLocalDir(t.TempDir())); err == nil {
+		t.Error("Expected an error for a repository without config")
+	}
+}
+
+func TestLoadModelConfigFromURI_Storage(t *testing.T) {
+	modelDir := t.TempDir()
+	writeSentenceTransformersModel(t, modelDir)
+	storage := mapStorage{}
+	err := filepath.Walk(modelDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, _ := filepath.Rel(modelDir, path)
+		storage["s3://models/bge-large/"+filepath.ToSlash(name)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to fill the storage: %v", err)
+	}
+
+	model, err := LoadModelConfigFromURI(context.Background(), "s3://models/bge-large/",
+		WithStorage(storage), WithLocalDir(t.TempDir()))
+	if err != nil {
+		t.Fatalf("LoadModelConfigFromURI failed: %v", err)
+	}
+	if task := GetModelTask(model); task != ModelTaskEmbedding {
+		t.Errorf("Expected an embedding model, got %q", task)
+	}
+	embedding := GetEmbeddingConfig(model)
+	if embedding == nil || embedding.Pooling != PoolingCLS || embedding.EmbeddingDimension != 768 {
+		t.Errorf("Expected CLS pooling of 768 dimensions, got %+v", embedding)
+	}
+
+	if _, err := LoadModelConfigFromURI(context.Background(), "s3://models/bge-large"); err == nil {
+		t.Error("Expected an error without a storage")
+	}
+
+	// A module path of modules.json must not leave the directory of the model
+	storage["s3://models/bge-large/modules.json"] = `[{"idx": 1, "name": "1", "path": "../../escaped", "type": "sentence_transformers.models.Pooling"}]`
+	localDir := filepath.Join(t.TempDir(), "model")
+	_, err = LoadModelConfigFromURI(context.Background(), "s3://models/bge-large", WithStorage(storage), WithLocalDir(localDir))
+	if err == nil || !strings.Contains(err.Error(), "is not within the model") {
+		t.Errorf("Expected an error for a module outside of the model, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(localDir, "..", "..", "escaped")); !os.IsNotExist(err) {
+		t.Errorf("Expected no directory outside of the model, got %v", err)
+	}
+}
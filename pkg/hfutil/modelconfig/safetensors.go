@@ -8,6 +8,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -140,43 +141,73 @@ func ParseSafetensors(path string) (int64, error) {
 	return total, nil
 }
 
-// ParseSafetensorsIndex parses a model.safetensors.index.json file for sharded models
-func ParseSafetensorsIndex(indexPath string) (int64, error) {
+// SafetensorsIndex is the model.safetensors.index.json of a sharded model
+type SafetensorsIndex struct {
+	Metadata struct {
+		// TotalSize is the size of the tensors of all the shards, without their headers
+		TotalSize int64 `json:"total_size"`
+	} `json:"metadata"`
+	// WeightMap maps the name of every tensor to the shard holding it
+	WeightMap map[string]string `json:"weight_map"`
+
+	// Internal fields (not in JSON)
+	IndexPath string `json:"-"`
+}
+
+// LoadSafetensorsIndex loads a model.safetensors.index.json file
+func LoadSafetensorsIndex(indexPath string) (*SafetensorsIndex, error) {
 	if indexPath == "" {
-		return 0, fmt.Errorf("index path cannot be empty")
+		return nil, fmt.Errorf("index path cannot be empty")
 	}
 
 	data, err := os.ReadFile(indexPath)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read safetensors index file '%s': %w", indexPath, err)
-	}
-
-	var index struct {
-		Weight_map map[string]string `json:"weight_map"`
+		return nil, fmt.Errorf("failed to read safetensors index file '%s': %w", indexPath, err)
 	}
 
+	var index SafetensorsIndex
 	if err := json.Unmarshal(data, &index); err != nil {
-		return 0, fmt.Errorf("failed to parse safetensors index JSON from '%s': %w", indexPath, err)
+		return nil, fmt.Errorf("failed to parse safetensors index JSON from '%s': %w", indexPath, err)
 	}
+	index.IndexPath = indexPath
 
-	if len(index.Weight_map) == 0 {
-		return 0, fmt.Errorf("no weight mappings found in safetensors index '%s'", indexPath)
+	if len(index.WeightMap) == 0 {
+		return nil, fmt.Errorf("no weight mappings found in safetensors index '%s'", indexPath)
 	}
-
-	// Get unique shard files
-	shardFiles := make(map[string]bool)
-	for _, shard := range index.Weight_map {
+	for _, shard := range index.WeightMap {
 		if shard == "" {
-			return 0, fmt.Errorf("empty shard filename found in index '%s'", indexPath)
+			return nil, fmt.Errorf("empty shard filename found in index '%s'", indexPath)
 		}
-		shardFiles[shard] = true
+	}
+	return &index, nil
+}
+
+// Shards returns the sorted file names of the shards of the index
+func (i *SafetensorsIndex) Shards() []string {
+	seen := make(map[string]bool)
+	var shards []string
+	for _, shard := range i.WeightMap {
+		if !seen[shard] {
+			seen[shard] = true
+			shards = append(shards, shard)
+		}
+	}
+	sort.Strings(shards)
+	return shards
+}
+
+// ParseSafetensorsIndex parses a model.safetensors.index.json file for sharded models
+func ParseSafetensorsIndex(indexPath string) (int64, error) {
+	index, err := LoadSafetensorsIndex(indexPath)
+	if err != nil {
+		return 0, err
 	}
 
 	dir := filepath.Dir(indexPath)
 	var total int64 = 0
 
 	// Parse each shard file
-	for shard := range shardFiles {
+	for _, shard := range index.Shards() {
 		shardPath := filepath.Join(dir, shard)
 		count, err := ParseSafetensors(shardPath)
 		if err != nil {
@@ -193,3 +224,88 @@ func ParseSafetensorsIndex(indexPath string) (int64, error) {
 
 	return total, nil
 }
+
+// FindSafetensorsSize returns the size on disk of the safetensors files of the model of a config file: the shards
+// of model.safetensors.index.json when there is one, every .safetensors file of the directory otherwise. When the
+// shards are not all downloaded yet, the total_size of the index is returned instead.
+func FindSafetensorsSize(configPath string) (int64, error) {
+	if configPath == "" {
+		return 0, fmt.Errorf("config path cannot be empty")
+	}
+	dir := filepath.Dir(configPath)
+
+	indexPath := filepath.Join(dir, "model.safetensors.index.json")
+	if _, err := os.Stat(indexPath); err == nil {
+		index, err := LoadSafetensorsIndex(indexPath)
+		if err != nil {
+			return 0, err
+		}
+		var total int64
+		for _, shard := range index.Shards() {
+			size, err := fileSize(filepath.Join(dir, shard))
+			if err != nil {
+				if index.Metadata.TotalSize > 0 {
+					return index.Metadata.TotalSize, nil
+				}
+				return 0, fmt.Errorf("failed to get the size of shard '%s' referenced in index '%s': %w", shard, indexPath, err)
+			}
+			total += size
+		}
+		return total, nil
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list directory '%s': %w", dir, err)
+	}
+	var total int64
+	found := false
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".safetensors") {
+			continue
+		}
+		size, err := fileSize(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return 0, err
+		}
+		total += size
+		found = true
+	}
+	if !found {
+		return 0, fmt.Errorf("no .safetensors files found in directory '%s'", dir)
+	}
+	return total, nil
+}
+
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat '%s': %w", path, err)
+	}
+	return info.Size(), nil
+}
+
+// ModelSizeOnDisk returns the size of the weights of a loaded model: the size of its safetensors files when they
+// are next to its config file, the estimate of GetModelSizeBytes otherwise
+func ModelSizeOnDisk(model HuggingFaceModel) int64 {
+	if size, ok := safetensorsSize(model); ok {
+		return size
+	}
+	return model.GetModelSizeBytes()
+}
+
+// safetensorsSize returns the size of the safetensors files next to the config file of a model, if any
+func safetensorsSize(model HuggingFaceModel) (int64, bool) {
+	if _, ok := model.(*GGUFModelConfig); ok {
+		return 0, false // GetModelSizeBytes is already the size of the GGUF files
+	}
+	configFile, ok := model.(interface{ configFile() string })
+	if !ok || configFile.configFile() == "" {
+		return 0, false
+	}
+	size, err := FindSafetensorsSize(configFile.configFile())
+	if err != nil || size <= 0 {
+		return 0, false
+	}
+	return size, true
+}
@@ -0,0 +1,87 @@
+package modelconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindSafetensorsSizeSharded(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	writeTokenizerFiles(t, dir, map[string]string{
+		"config.json": `{
+			"model_type": "llama",
+			"architectures": ["LlamaForCausalLM"],
+			"hidden_size": 64,
+			"intermediate_size": 128,
+			"num_hidden_layers": 2,
+			"num_attention_heads": 4,
+			"num_key_value_heads": 4,
+			"max_position_embeddings": 2048,
+			"vocab_size": 1000,
+			"torch_dtype": "bfloat16"
+		}`,
+		"model.safetensors.index.json": `{
+			"metadata": {"total_size": 5000},
+			"weight_map": {
+				"embed.weight": "model-00001-of-00002.safetensors",
+				"layer.0.weight": "model-00001-of-00002.safetensors",
+				"lm_head.weight": "model-00002-of-00002.safetensors"
+			}
+		}`,
+	})
+
+	index, err := LoadSafetensorsIndex(filepath.Join(dir, "model.safetensors.index.json"))
+	if err != nil {
+		t.Fatalf("LoadSafetensorsIndex failed: %v", err)
+	}
+	shards := index.Shards()
+	if len(shards) != 2 || shards[0] != "model-00001-of-00002.safetensors" {
+		t.Errorf("Expected the 2 shards in order, got %v", shards)
+	}
+
+	// The shards are not downloaded yet: the total_size of the index is used
+	size, err := FindSafetensorsSize(configPath)
+	if err != nil {
+		t.Fatalf("FindSafetensorsSize failed: %v", err)
+	}
+	if size != 5000 {
+		t.Errorf("Expected the total_size of the index, got %d", size)
+	}
+
+	for name, n := range map[string]int{"model-00001-of-00002.safetensors": 3000, "model-00002-of-00002.safetensors": 2100} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, n), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	size, err = FindSafetensorsSize(configPath)
+	if err != nil {
+		t.Fatalf("FindSafetensorsSize failed: %v", err)
+	}
+	if size != 5100 {
+		t.Errorf("Expected the size of the shards on disk, got %d", size)
+	}
+
+	model, err := LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadModelConfig failed: %v", err)
+	}
+	if got := ModelSizeOnDisk(model); got != 5100 {
+		t.Errorf("Expected model size on disk 5100, got %d", got)
+	}
+}
+
+func TestFindSafetensorsSizeErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := FindSafetensorsSize(filepath.Join(dir, "config.json")); err == nil {
+		t.Error("Expected an error without safetensors files")
+	}
+
+	writeTokenizerFiles(t, dir, map[string]string{
+		"model.safetensors.index.json": `{"weight_map": {"embed.weight": "model-00001-of-00002.safetensors"}}`,
+	})
+	if _, err := FindSafetensorsSize(filepath.Join(dir, "config.json")); err == nil {
+		t.Error("Expected an error for missing shards without total_size")
+	}
+}
@@ -0,0 +1,286 @@
+package modelconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// maxTokenizerModelMaxLength is the largest model_max_length taken as a limit, transformers saves
+// VERY_LARGE_INTEGER (1e30) when the tokenizer has none
+const maxTokenizerModelMaxLength = 1 << 40
+
+// SpecialToken is a special token of a tokenizer, saved either as its content or as an AddedToken object
+type SpecialToken string
+
+// UnmarshalJSON reads the content of a special token saved as a string or as an AddedToken object
+func (t *SpecialToken) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var content string
+	if err := json.Unmarshal(data, &content); err == nil {
+		*t = SpecialToken(content)
+		return nil
+	}
+	var addedToken struct {
+		Content string `json:"content"`
+	}
+	if err := json.Unmarshal(data, &addedToken); err != nil {
+		return fmt.Errorf("special token is neither a string nor an AddedToken: %w", err)
+	}
+	*t = SpecialToken(addedToken.Content)
+	return nil
+}
+
+// ChatTemplates holds the chat templates of a tokenizer by name. A single template is saved under "default".
+type ChatTemplates map[string]string
+
+// UnmarshalJSON reads a single chat template or a list of named ones
+func (c *ChatTemplates) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var template string
+	if err := json.Unmarshal(data, &template); err == nil {
+		if template != "" {
+			*c = ChatTemplates{"default": template}
+		}
+		return nil
+	}
+	var named []struct {
+		Name     string `json:"name"`
+		Template string `json:"template"`
+	}
+	if err := json.Unmarshal(data, &named); err != nil {
+		return fmt.Errorf("chat_template is neither a string nor a list of named templates: %w", err)
+	}
+	templates := ChatTemplates{}
+	for _, t := range named {
+		templates[t.Name] = t.Template
+	}
+	*c = templates
+	return nil
+}
+
+// TokenizerConfig is the configuration of the tokenizer of a Hugging Face model, read from tokenizer_config.json
+// and completed with special_tokens_map.json, the chat template files and tokenizer.json
+type TokenizerConfig struct {
+	TokenizerClass string        `json:"tokenizer_class"`
+	ModelMaxLength float64       `json:"model_max_length"`
+	ChatTemplate   ChatTemplates `json:"chat_template"`
+
+	BosToken                SpecialToken   `json:"bos_token"`
+	EosToken                SpecialToken   `json:"eos_token"`
+	PadToken                SpecialToken   `json:"pad_token"`
+	UnkToken                SpecialToken   `json:"unk_token"`
+	AdditionalSpecialTokens []SpecialToken `json:"additional_special_tokens"`
+	AddBosToken             *bool          `json:"add_bos_token,omitempty"`
+	AddEosToken             *bool          `json:"add_eos_token,omitempty"`
+
+	// AddedTokensDecoder maps the ids of the added tokens to their content
+	AddedTokensDecoder map[string]struct {
+		Content string `json:"content"`
+		Special bool   `json:"special"`
+	} `json:"added_tokens_decoder"`
+
+	// Internal fields (not in JSON)
+	ConfigPath string `json:"-"`
+	vocabSize  int
+}
+
+// LoadTokenizerConfig loads the tokenizer configuration of the model in a directory. The error wraps
+// os.ErrNotExist when the directory has no tokenizer_config.json.
+func LoadTokenizerConfig(modelDir string) (*TokenizerConfig, error) {
+	configPath := filepath.Join(modelDir, constants.TokenizerConfigFileName)
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokenizer config '%s': %w", configPath, err)
+	}
+
+	var config TokenizerConfig
+	if err := json.Unmarshal(SanitizeJSONBytes(data), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse tokenizer config JSON from '%s': %w", configPath, err)
+	}
+	config.ConfigPath = configPath
+
+	if err := config.loadSpecialTokensMap(modelDir); err != nil {
+		return nil, err
+	}
+	if err := config.loadChatTemplate(modelDir); err != nil {
+		return nil, err
+	}
+	if err := config.loadVocabSize(modelDir); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// loadSpecialTokensMap fills the special tokens missing from tokenizer_config.json with the ones of
+// special_tokens_map.json, which older tokenizers only save there
+func (c *TokenizerConfig) loadSpecialTokensMap(modelDir string) error {
+	path := filepath.Join(modelDir, constants.SpecialTokensMapFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read special tokens map '%s': %w", path, err)
+	}
+
+	var tokens struct {
+		BosToken                SpecialToken   `json:"bos_token"`
+		EosToken                SpecialToken   `json:"eos_token"`
+		PadToken                SpecialToken   `json:"pad_token"`
+		UnkToken                SpecialToken   `json:"unk_token"`
+		AdditionalSpecialTokens []SpecialToken `json:"additional_special_tokens"`
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return fmt.Errorf("failed to parse special tokens map JSON from '%s': %w", path, err)
+	}
+	for _, token := range []struct {
+		dst *SpecialToken
+		src SpecialToken
+	}{
+		{&c.BosToken, tokens.BosToken},
+		{&c.EosToken, tokens.EosToken},
+		{&c.PadToken, tokens.PadToken},
+		{&c.UnkToken, tokens.UnkToken},
+	} {
+		if *token.dst == "" {
+			*token.dst = token.src
+		}
+	}
+	if len(c.AdditionalSpecialTokens) == 0 {
+		c.AdditionalSpecialTokens = tokens.AdditionalSpecialTokens
+	}
+	return nil
+}
+
+// loadChatTemplate reads the chat template saved next to tokenizer_config.json when it has none
+func (c *TokenizerConfig) loadChatTemplate(modelDir string) error {
+	if len(c.ChatTemplate) > 0 {
+		return nil
+	}
+
+	path := filepath.Join(modelDir, constants.ChatTemplateFileName)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if len(data) > 0 {
+			c.ChatTemplate = ChatTemplates{"default": string(data)}
+		}
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read chat template '%s': %w", path, err)
+	}
+
+	path = filepath.Join(modelDir, constants.LegacyChatTemplateFileName)
+	data, err = os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read chat template '%s': %w", path, err)
+	}
+	var legacy struct {
+		ChatTemplate ChatTemplates `json:"chat_template"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return fmt.Errorf("failed to parse chat template JSON from '%s': %w", path, err)
+	}
+	c.ChatTemplate = legacy.ChatTemplate
+	return nil
+}
+
+// loadVocabSize computes the size of the vocabulary from the largest token id of tokenizer.json and of the added
+// tokens. It is left to 0 without tokenizer.json, e.g. for the sentencepiece tokenizers.
+func (c *TokenizerConfig) loadVocabSize(modelDir string) error {
+	path := filepath.Join(modelDir, constants.TokenizerFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read tokenizer '%s': %w", path, err)
+	}
+
+	var tokenizer struct {
+		AddedTokens []struct {
+			ID int `json:"id"`
+		} `json:"added_tokens"`
+		Model struct {
+			Vocab json.RawMessage `json:"vocab"`
+		} `json:"model"`
+	}
+	if err := json.Unmarshal(data, &tokenizer); err != nil {
+		return fmt.Errorf("failed to parse tokenizer JSON from '%s': %w", path, err)
+	}
+
+	maxID := -1
+	vocab := bytes.TrimSpace(tokenizer.Model.Vocab)
+	switch {
+	case bytes.HasPrefix(vocab, []byte("{")):
+		// BPE and WordPiece map the tokens to their ids
+		var ids map[string]int
+		if err := json.Unmarshal(vocab, &ids); err != nil {
+			return fmt.Errorf("failed to parse vocabulary of '%s': %w", path, err)
+		}
+		for _, id := range ids {
+			maxID = max(maxID, id)
+		}
+	case bytes.HasPrefix(vocab, []byte("[")):
+		// Unigram lists the tokens with their scores, by id
+		var pieces []json.RawMessage
+		if err := json.Unmarshal(vocab, &pieces); err != nil {
+			return fmt.Errorf("failed to parse vocabulary of '%s': %w", path, err)
+		}
+		maxID = len(pieces) - 1
+	}
+	for _, token := range tokenizer.AddedTokens {
+		maxID = max(maxID, token.ID)
+	}
+	for id := range c.AddedTokensDecoder {
+		if n, err := strconv.Atoi(id); err == nil {
+			maxID = max(maxID, n)
+		}
+	}
+
+	c.vocabSize = maxID + 1
+	return nil
+}
+
+// HasChatTemplate returns true if the tokenizer has a chat template, which chat completions need
+func (c *TokenizerConfig) HasChatTemplate() bool {
+	return len(c.ChatTemplate) > 0
+}
+
+// GetModelMaxLength returns the maximum length of the inputs of the tokenizer, 0 when it has none
+func (c *TokenizerConfig) GetModelMaxLength() int {
+	if c.ModelMaxLength <= 0 || c.ModelMaxLength > maxTokenizerModelMaxLength {
+		return 0
+	}
+	return int(c.ModelMaxLength)
+}
+
+// GetVocabSize returns the number of tokens of the tokenizer including the added ones, 0 when unknown
+func (c *TokenizerConfig) GetVocabSize() int {
+	return c.vocabSize
+}
+
+// CheckVocabSize checks the tokens of the tokenizer fit in the embeddings of a model of vocabSize tokens. The
+// embeddings may be larger than the vocabulary, since models commonly pad them to a multiple of 64 or 128.
+func (c *TokenizerConfig) CheckVocabSize(vocabSize int) error {
+	if c.vocabSize == 0 || vocabSize <= 0 {
+		return nil
+	}
+	if c.vocabSize > vocabSize {
+		return fmt.Errorf("tokenizer has %d tokens but the model vocab_size is %d", c.vocabSize, vocabSize)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package modelconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTokenizerFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestLoadTokenizerConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTokenizerFiles(t, dir, map[string]string{
+		"tokenizer_config.json": `{
+			"tokenizer_class": "PreTrainedTokenizerFast",
+			"model_max_length": 131072,
+			"bos_token": "<|begin_of_text|>",
+			"eos_token": {"content": "<|eot_id|>", "lstrip": false, "special": true},
+			"chat_template": [
+				{"name": "default", "template": "{{ messages }}"},
+				{"name": "tool_use", "template": "{{ tools }}"}
+			],
+			"added_tokens_decoder": {
+				"5": {"content": "<|begin_of_text|>", "special": true},
+				"6": {"content": "<|eot_id|>", "special": true}
+			}
+		}`,
+		"special_tokens_map.json": `{
+			"bos_token": "<s>",
+			"pad_token": {"content": "<|pad|>"}
+		}`,
+		"tokenizer.json": `{
+			"added_tokens": [{"id": 5, "content": "<|begin_of_text|>"}, {"id": 6, "content": "<|eot_id|>"}],
+			"model": {"type": "BPE", "vocab": {"a": 0, "b": 1, "c": 2, "ab": 3, "bc": 4}}
+		}`,
+	})
+
+	config, err := LoadTokenizerConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadTokenizerConfig failed: %v", err)
+	}
+
+	if config.BosToken != "<|begin_of_text|>" {
+		t.Errorf("Expected the bos_token of tokenizer_config.json, got %q", config.BosToken)
+	}
+	if config.EosToken != "<|eot_id|>" {
+		t.Errorf("Expected the content of the eos_token AddedToken, got %q", config.EosToken)
+	}
+	if config.PadToken != "<|pad|>" {
+		t.Errorf("Expected the pad_token of special_tokens_map.json, got %q", config.PadToken)
+	}
+	if !config.HasChatTemplate() || config.ChatTemplate["tool_use"] != "{{ tools }}" {
+		t.Errorf("Expected the named chat templates, got %v", config.ChatTemplate)
+	}
+	if config.GetModelMaxLength() != 131072 {
+		t.Errorf("Expected model max length 131072, got %d", config.GetModelMaxLength())
+	}
+	if config.GetVocabSize() != 7 {
+		t.Errorf("Expected 7 tokens, got %d", config.GetVocabSize())
+	}
+
+	if err := config.CheckVocabSize(128); err != nil {
+		t.Errorf("Expected padded embeddings to fit the tokenizer, got %v", err)
+	}
+	if err := config.CheckVocabSize(6); err == nil {
+		t.Error("Expected an error when the tokenizer has more tokens than the model")
+	}
+}
+
+func TestLoadTokenizerConfigSeparateChatTemplate(t *testing.T) {
+	dir := t.TempDir()
+	writeTokenizerFiles(t, dir, map[string]string{
+		"tokenizer_config.json": `{"model_max_length": 1000000000000000019884624838656, "eos_token": "</s>"}`,
+		"chat_template.jinja":   "{% for message in messages %}{{ message.content }}{% endfor %}",
+		"tokenizer.json":        `{"model": {"type": "Unigram", "vocab": [["<unk>", 0.0], ["a", -1.5], ["b", -2.0]]}}`,
+	})
+
+	config, err := LoadTokenizerConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadTokenizerConfig failed: %v", err)
+	}
+	if !config.HasChatTemplate() {
+		t.Error("Expected the chat template of chat_template.jinja")
+	}
+	if config.GetModelMaxLength() != 0 {
+		t.Errorf("Expected no model max length, got %d", config.GetModelMaxLength())
+	}
+	if config.GetVocabSize() != 3 {
+		t.Errorf("Expected 3 tokens, got %d", config.GetVocabSize())
+	}
+
+	legacyDir := t.TempDir()
+	writeTokenizerFiles(t, legacyDir, map[string]string{
+		"tokenizer_config.json": `{}`,
+		"chat_template.json":    `{"chat_template": "{{ messages }}"}`,
+	})
+	legacy, err := LoadTokenizerConfig(legacyDir)
+	if err != nil {
+		t.Fatalf("LoadTokenizerConfig failed: %v", err)
+	}
+	if !legacy.HasChatTemplate() {
+		t.Error("Expected the chat template of chat_template.json")
+	}
+	if legacy.GetVocabSize() != 0 || legacy.CheckVocabSize(10) != nil {
+		t.Error("Expected an unknown vocabulary size without tokenizer.json")
+	}
+}
+
+func TestLoadTokenizerConfigErrors(t *testing.T) {
+	if _, err := LoadTokenizerConfig(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist without tokenizer_config.json, got %v", err)
+	}
+
+	dir := t.TempDir()
+	writeTokenizerFiles(t, dir, map[string]string{
+		"tokenizer_config.json": `{"eos_token": 42}`,
+	})
+	if _, err := LoadTokenizerConfig(dir); err == nil {
+		t.Error("Expected an error for an invalid special token")
+	}
+}
@@ -0,0 +1,248 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// defaultRopeType is the rope_type of the unscaled rotary embeddings
+const defaultRopeType = "default"
+
+// ropeScalingTypesWithFactor are the rope_scaling types that scale the positions by their factor
+var ropeScalingTypesWithFactor = map[string]bool{
+	"linear":  true,
+	"dynamic": true,
+	"yarn":    true,
+	"llama3":  true,
+}
+
+// ConfigWarning is an inconsistency found in the configuration of a model. The model may still load, but its
+// runtime may reject it or serve it with different settings than intended.
+type ConfigWarning struct {
+	// Field is the config field the warning is about, e.g. "num_key_value_heads"
+	Field string
+	// Message describes the inconsistency
+	Message string
+}
+
+// String returns the field and the message of the warning
+func (w ConfigWarning) String() string {
+	return w.Field + ": " + w.Message
+}
+
+// Error returns the field and the message of the warning, for the config fields that fail a computation
+func (w ConfigWarning) Error() string {
+	return w.String()
+}
+
+// ArchitectureConfig holds the architecture fields of a config.json that are checked for consistency: the
+// transformer dimensions, the position embeddings and the experts
+type ArchitectureConfig struct {
+	ModelDimensions
+
+	IntermediateSize      int
+	MaxPositionEmbeddings int
+
+	// RopeTheta is the base of the rotary embeddings, nil when unset
+	RopeTheta   *float64
+	RopeScaling *RopeScalingConfig
+
+	NumExperts       int
+	NumExpertsPerTok int
+
+	// Internal fields
+	ConfigPath string
+}
+
+// rawArchitectureConfig lists the keys the architecture fields are read from in addition to the dimensions
+type rawArchitectureConfig struct {
+	rawModelDimensions
+	IntermediateSize      int                `json:"intermediate_size"`
+	MaxPositionEmbeddings int                `json:"max_position_embeddings"`
+	RopeTheta             *float64           `json:"rope_theta"`
+	RopeScaling           *RopeScalingConfig `json:"rope_scaling"`
+	// transformers v5 saves the rotary embedding settings together under rope_parameters
+	RopeParameters *struct {
+		RopeScalingConfig
+		RopeTheta *float64 `json:"rope_theta"`
+	} `json:"rope_parameters"`
+	NumLocalExperts  int `json:"num_local_experts"`
+	NumExperts       int `json:"num_experts"`
+	NRoutedExperts   int `json:"n_routed_experts"`
+	NumExpertsPerTok int `json:"num_experts_per_tok"`
+}
+
+// LoadArchitectureConfig reads the architecture fields of a config.json as they are saved, without the defaults
+// Normalize fills. The fields of multimodal models are read from their nested language model config.
+func LoadArchitectureConfig(configPath string) (*ArchitectureConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", configPath, err)
+	}
+
+	data = languageModelConfig(SanitizeJSONBytes(data))
+	var raw rawArchitectureConfig
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config JSON from '%s': %w", configPath, err)
+	}
+
+	config := &ArchitectureConfig{
+		ModelDimensions:       raw.dimensions(),
+		IntermediateSize:      raw.IntermediateSize,
+		MaxPositionEmbeddings: raw.MaxPositionEmbeddings,
+		RopeTheta:             raw.RopeTheta,
+		RopeScaling:           raw.RopeScaling,
+		NumExperts:            firstNonZero(raw.NumLocalExperts, raw.NumExperts, raw.NRoutedExperts),
+		NumExpertsPerTok:      raw.NumExpertsPerTok,
+		ConfigPath:            configPath,
+	}
+	if p := raw.RopeParameters; p != nil {
+		if config.RopeTheta == nil {
+			config.RopeTheta = p.RopeTheta
+		}
+		if config.RopeScaling == nil {
+			scaling := p.RopeScalingConfig
+			config.RopeScaling = &scaling
+		}
+	}
+	return config, nil
+}
+
+// GetArchitectureConfig returns the architecture fields of a loaded model
+func GetArchitectureConfig(model HuggingFaceModel) (*ArchitectureConfig, error) {
+	if gguf, ok := model.(*GGUFModelConfig); ok {
+		return &ArchitectureConfig{
+			ModelDimensions:       gguf.dimensions,
+			MaxPositionEmbeddings: gguf.GetContextLength(),
+			NumExperts:            gguf.numExperts,
+			NumExpertsPerTok:      gguf.numExpertsPerTok,
+			ConfigPath:            gguf.ConfigPath,
+		}, nil
+	}
+
+	configFile, ok := model.(interface{ configFile() string })
+	if !ok || configFile.configFile() == "" {
+		return nil, fmt.Errorf("no config file to read the architecture of %s model from", model.GetModelType())
+	}
+	return LoadArchitectureConfig(configFile.configFile())
+}
+
+// ropeType returns the type of the rope scaling, saved under "type" by the older checkpoints
+func (c *ArchitectureConfig) ropeType() string {
+	if c.RopeScaling == nil {
+		return ""
+	}
+	ropeType := c.RopeScaling.RopeType
+	if ropeType == "" {
+		ropeType = c.RopeScaling.Type
+	}
+	return strings.ToLower(ropeType)
+}
+
+// Validate returns the inconsistencies between the fields of the config. The checks only cover the fields that
+// are set: a missing field is reported when another field depends on it.
+func (c *ArchitectureConfig) Validate() []ConfigWarning {
+	var warnings []ConfigWarning
+	warn := func(field, format string, args ...any) {
+		warnings = append(warnings, ConfigWarning{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Attention heads
+	heads, kvHeads := c.NumAttentionHeads, c.NumKeyValueHeads
+	if c.HiddenSize > 0 && heads > 0 && c.HeadDim == 0 && c.HiddenSize%heads != 0 && c.KVLoraRank == 0 {
+		warn("num_attention_heads", "hidden_size %d is not divisible by %d attention heads and head_dim is not set",
+			c.HiddenSize, heads)
+	}
+	if heads > 0 && kvHeads > heads {
+		warn("num_key_value_heads", "%d key-value heads are more than the %d attention heads", kvHeads, heads)
+	} else if heads > 0 && kvHeads > 0 && heads%kvHeads != 0 {
+		warn("num_key_value_heads", "%d attention heads can't be grouped over %d key-value heads", heads, kvHeads)
+	}
+
+	// Rotary embeddings
+	if c.RopeTheta != nil && *c.RopeTheta <= 0 {
+		warn("rope_theta", "must be positive, got %g", *c.RopeTheta)
+	}
+	if c.RopeScaling != nil {
+		ropeType := c.ropeType()
+		switch {
+		case ropeType == "":
+			warn("rope_scaling", "rope_type is missing")
+		case ropeScalingTypesWithFactor[ropeType] && c.RopeScaling.Factor <= 0:
+			warn("rope_scaling", "%s scaling needs a positive factor, got %g", ropeType, c.RopeScaling.Factor)
+		}
+		if ropeType == "llama3" && (c.RopeScaling.LowFreqFactor <= 0 || c.RopeScaling.HighFreqFactor <= 0 ||
+			c.RopeScaling.OriginalMaxPositionEmbeddings <= 0) {
+			warn("rope_scaling", "llama3 scaling needs low_freq_factor, high_freq_factor and original_max_position_embeddings")
+		}
+		if ropeType == "longrope" && (len(c.RopeScaling.ShortFactor) == 0 || len(c.RopeScaling.LongFactor) == 0) {
+			warn("rope_scaling", "longrope scaling needs short_factor and long_factor")
+		}
+		if original := c.RopeScaling.OriginalMaxPositionEmbeddings; original > 0 && c.MaxPositionEmbeddings > 0 &&
+			original > c.MaxPositionEmbeddings {
+			warn("rope_scaling", "original_max_position_embeddings %d is larger than max_position_embeddings %d",
+				original, c.MaxPositionEmbeddings)
+		}
+	}
+
+	// Experts
+	if c.NumExperts > 0 && c.NumExpertsPerTok > c.NumExperts {
+		warn("num_experts_per_tok", "%d experts per token are more than the %d experts", c.NumExpertsPerTok, c.NumExperts)
+	}
+	return warnings
+}
+
+// Normalize fills the fields left out of the config with the values transformers defaults them to: the key-value
+// heads of multi-head attention, the head dimension, and the type and original context of the rope scaling
+func (c *ArchitectureConfig) Normalize() {
+	c.ModelDimensions.normalize()
+	if c.RopeScaling == nil {
+		return
+	}
+	c.RopeScaling.RopeType = c.ropeType()
+	if c.RopeScaling.RopeType == "" {
+		c.RopeScaling.RopeType = defaultRopeType
+	}
+	if c.RopeScaling.RopeType == "yarn" && c.RopeScaling.OriginalMaxPositionEmbeddings == 0 {
+		c.RopeScaling.OriginalMaxPositionEmbeddings = c.MaxPositionEmbeddings
+	}
+}
+
+// ValidateModel returns the inconsistencies of the config of a loaded model, including the ones between its
+// architecture fields and the context length and data type it reports
+func ValidateModel(model HuggingFaceModel) ([]ConfigWarning, error) {
+	config, err := GetArchitectureConfig(model)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := config.Validate()
+	// The state of a sequence of a state space model doesn't grow with its tokens, it has no maximum context length
+	stateSpace := config.NumSSMLayers > 0 && config.AttentionLayers() == 0
+	if model.GetContextLength() <= 0 && !stateSpace {
+		warnings = append(warnings, ConfigWarning{Field: "max_position_embeddings", Message: "the context length of the model is unknown"})
+	}
+	if dtype := model.GetTorchDtype(); dtype != "" {
+		if _, ok := DtypeSizeBytes[strings.ToLower(dtype)]; !ok {
+			warnings = append(warnings, ConfigWarning{Field: "torch_dtype", Message: fmt.Sprintf("unknown data type %q", dtype)})
+			return warnings, nil
+		}
+	}
+
+	// The memory of a sequence of the full context is estimated from the dimensions, which must all be set
+	contextLen := max(model.GetContextLength(), 1)
+	if _, err := EstimateServingMemory(model, contextLen, 1, "", 1); err != nil {
+		var missing ConfigWarning
+		if !errors.As(err, &missing) {
+			return nil, err
+		}
+		warnings = append(warnings, ConfigWarning{
+			Field:   missing.Field,
+			Message: fmt.Sprintf("%s, the serving memory of the model can't be estimated", missing.Message),
+		})
+	}
+	return warnings, nil
+}
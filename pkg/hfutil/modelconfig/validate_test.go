@@ -0,0 +1,207 @@
+package modelconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateTestdata(t *testing.T) {
+	configs, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatalf("Failed to list test configs: %v", err)
+	}
+	for _, configPath := range configs {
+		if strings.HasPrefix(filepath.Base(configPath), "config_") {
+			continue
+		}
+		t.Run(filepath.Base(configPath), func(t *testing.T) {
+			config, err := LoadArchitectureConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadArchitectureConfig failed: %v", err)
+			}
+			if warnings := config.Validate(); len(warnings) > 0 {
+				t.Errorf("Expected no warnings for a released model, got %v", warnings)
+			}
+		})
+	}
+}
+
+func TestValidateInconsistentConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		field  string
+	}{
+		{
+			name:   "heads not dividing hidden size",
+			config: `{"hidden_size": 4100, "num_hidden_layers": 2, "num_attention_heads": 32}`,
+			field:  "num_attention_heads",
+		},
+		{
+			name:   "more key-value heads than heads",
+			config: `{"hidden_size": 4096, "num_hidden_layers": 2, "num_attention_heads": 8, "num_key_value_heads": 16}`,
+			field:  "num_key_value_heads",
+		},
+		{
+			name:   "heads not grouped over key-value heads",
+			config: `{"hidden_size": 4096, "num_hidden_layers": 2, "num_attention_heads": 32, "num_key_value_heads": 6}`,
+			field:  "num_key_value_heads",
+		},
+		{
+			name:   "zero rope theta",
+			config: `{"hidden_size": 4096, "num_hidden_layers": 2, "num_attention_heads": 32, "rope_theta": 0}`,
+			field:  "rope_theta",
+		},
+		{
+			name:   "rope scaling without type",
+			config: `{"hidden_size": 4096, "num_hidden_layers": 2, "num_attention_heads": 32, "rope_scaling": {"factor": 4.0}}`,
+			field:  "rope_scaling",
+		},
+		{
+			name:   "yarn scaling without factor",
+			config: `{"hidden_size": 4096, "num_hidden_layers": 2, "num_attention_heads": 32, "rope_scaling": {"rope_type": "yarn"}}`,
+			field:  "rope_scaling",
+		},
+		{
+			name: "llama3 scaling without frequency factors",
+			config: `{"hidden_size": 4096, "num_hidden_layers": 2, "num_attention_heads": 32, "max_position_embeddings": 131072,
+				"rope_scaling": {"rope_type": "llama3", "factor": 8.0, "original_max_position_embeddings": 8192}}`,
+			field: "rope_scaling",
+		},
+		{
+			name:   "more experts per token than experts",
+			config: `{"hidden_size": 4096, "num_hidden_layers": 2, "num_attention_heads": 32, "num_local_experts": 8, "num_experts_per_tok": 16}`,
+			field:  "num_experts_per_tok",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(tc.config), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+			config, err := LoadArchitectureConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadArchitectureConfig failed: %v", err)
+			}
+			warnings := config.Validate()
+			if len(warnings) != 1 || warnings[0].Field != tc.field {
+				t.Errorf("Expected a single warning on %s, got %v", tc.field, warnings)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"hidden_size": 4096,
+		"num_hidden_layers": 32,
+		"num_attention_heads": 32,
+		"max_position_embeddings": 32768,
+		"rope_scaling": {"type": "YaRN", "factor": 4.0}
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := LoadArchitectureConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadArchitectureConfig failed: %v", err)
+	}
+	config.Normalize()
+
+	if config.NumKeyValueHeads != 32 || config.HeadDim != 128 {
+		t.Errorf("Expected multi-head attention with 128 dims per head, got %d key-value heads of %d dims",
+			config.NumKeyValueHeads, config.HeadDim)
+	}
+	if config.RopeScaling.RopeType != "yarn" {
+		t.Errorf("Expected rope_type yarn, got %q", config.RopeScaling.RopeType)
+	}
+	if config.RopeScaling.OriginalMaxPositionEmbeddings != 32768 {
+		t.Errorf("Expected original_max_position_embeddings 32768, got %d", config.RopeScaling.OriginalMaxPositionEmbeddings)
+	}
+	if warnings := config.Validate(); len(warnings) > 0 {
+		t.Errorf("Expected no warnings after normalization, got %v", warnings)
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	model, err := LoadModelConfig(filepath.Join("testdata", "llama3_1.json"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	warnings, err := ValidateModel(model)
+	if err != nil {
+		t.Fatalf("ValidateModel failed: %v", err)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	configJSON := `{
+		"model_type": "some_model",
+		"architectures": ["SomeModelForCausalLM"],
+		"hidden_size": 4096,
+		"num_hidden_layers": 32,
+		"num_attention_heads": 32,
+		"torch_dtype": "float12"
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	model, err = LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	warnings, err = ValidateModel(model)
+	if err != nil {
+		t.Fatalf("ValidateModel failed: %v", err)
+	}
+	if len(warnings) != 2 || warnings[0].Field != "max_position_embeddings" || warnings[1].Field != "torch_dtype" {
+		t.Errorf("Expected warnings on the context length and the data type, got %v", warnings)
+	}
+
+	// State space models have no maximum context length
+	for _, name := range []string{"mamba_2.8b.json", "mamba2_codestral_7b.json"} {
+		model, err = LoadModelConfig(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		warnings, err = ValidateModel(model)
+		if err != nil {
+			t.Fatalf("ValidateModel of %s failed: %v", name, err)
+		}
+		if len(warnings) > 0 {
+			t.Errorf("Expected no warnings for %s, got %v", name, warnings)
+		}
+	}
+
+	// The serving memory is estimated from the dimensions
+	configJSON = `{
+		"model_type": "some_model",
+		"architectures": ["SomeModelForCausalLM"],
+		"hidden_size": 4096,
+		"num_hidden_layers": 32,
+		"max_position_embeddings": 8192,
+		"torch_dtype": "bfloat16"
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	model, err = LoadModelConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	warnings, err = ValidateModel(model)
+	if err != nil {
+		t.Fatalf("ValidateModel failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Field != "num_attention_heads" ||
+		!strings.Contains(warnings[0].Message, "serving memory") {
+		t.Errorf("Expected a warning on the attention heads, got %v", warnings)
+	}
+}
@@ -0,0 +1,73 @@
+package modelconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// VisionTowerSpec captures the vision encoder of multimodal models and the number of tokens the images take in the
+// context of the language model.
+type VisionTowerSpec struct {
+	// ModelType is the model_type of the vision config when it has one, e.g. clip_vision_model or pixtral
+	ModelType       string
+	ImageSize       int
+	PatchSize       int
+	HiddenSize      int
+	NumHiddenLayers int
+
+	// ImageTokens is the number of tokens of an image of ImageSize pixels, or of one tile for the models splitting
+	// the images into tiles. It is 0 for the models encoding the images at their own resolution.
+	ImageTokens int
+	// MaxImageTokens is the image token budget: the largest number of tokens an image can take, with all its tiles
+	// for the tiling models and at the largest resolution of the image processor for the dynamic resolution ones
+	MaxImageTokens int
+}
+
+// visionSize is an image or patch size, saved either as the side of a square or as [height, width]
+type visionSize int
+
+// UnmarshalJSON reads a side or the height of a [height, width] size
+func (s *visionSize) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var side int
+	if err := json.Unmarshal(data, &side); err == nil {
+		*s = visionSize(side)
+		return nil
+	}
+	var dims []int
+	if err := json.Unmarshal(data, &dims); err != nil || len(dims) == 0 {
+		return fmt.Errorf("size is neither an integer nor [height, width]: %s", data)
+	}
+	*s = visionSize(dims[0])
+	return nil
+}
+
+// defaultMaxPixels is the max_pixels of the transformers image processor of Qwen2-VL, 1280 tokens of 28x28 pixels
+const defaultMaxPixels = 28 * 28 * 1280
+
+// loadMaxPixels reads the largest number of pixels the image processor of a model resizes the images to from the
+// preprocessor_config.json next to its config file, defaultMaxPixels when it has none
+func loadMaxPixels(configPath string) int {
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(configPath), constants.PreprocessorConfigFileName))
+	if err != nil {
+		return defaultMaxPixels
+	}
+	var preprocessor struct {
+		MaxPixels int `json:"max_pixels"`
+		Size      struct {
+			MaxPixels   int `json:"max_pixels"`
+			LongestEdge int `json:"longest_edge"`
+		} `json:"size"`
+	}
+	if err := json.Unmarshal(SanitizeJSONBytes(data), &preprocessor); err != nil {
+		return defaultMaxPixels
+	}
+	return firstNonZero(preprocessor.MaxPixels, preprocessor.Size.MaxPixels, preprocessor.Size.LongestEdge, defaultMaxPixels)
+}
@@ -76,6 +76,11 @@ func (c *XverseConfig) GetParameterCount() int64 {
 	return estimateModelParams(c.HiddenSize, c.NumHiddenLayers, c.IntermediateSize, c.VocabSize)
 }
 
+// GetActiveParameterCount returns the total number of parameters, which are all used for each token
+func (c *XverseConfig) GetActiveParameterCount() int64 {
+	return c.GetParameterCount()
+}
+
 // GetContextLength returns the maximum context length supported by the model
 func (c *XverseConfig) GetContextLength() int {
 	return c.MaxPositionEmbeddings
@@ -86,11 +91,6 @@ func (c *XverseConfig) GetModelSizeBytes() int64 {
 	return EstimateModelSizeBytes(c.GetParameterCount(), c.TorchDtype)
 }
 
-// GetQuantizationType returns the quantization method used (if any)
-func (c *XverseConfig) GetQuantizationType() string {
-	return "" // No quantization by default
-}
-
 // HasVision returns false for XVERSE base models
 func (c *XverseConfig) HasVision() bool {
 	return false
@@ -39,6 +39,11 @@ type Config struct {
 	// which can cause disk space usage issues.
 	DisableConsoleOutput bool `mapstructure:"disableConsoleOutput"`
 
+	// Sampling caps the entries logged per second with the same level and message.
+	//
+	// Sampling is disabled if not set.
+	Sampling *SamplingConfig `mapstructure:"sampling"`
+
 	// Logger contains various knobs of lumberjack logging functionality.
 	lumberjack.Logger `mapstructure:",squash"`
 }
@@ -57,6 +62,11 @@ func (c *Config) Validate() error {
 	if c.MaxAge < 0 {
 		return fmt.Errorf("maxage days must be >= 0, not %d", c.MaxAge)
 	}
+	if c.Sampling != nil {
+		if err := c.Sampling.Validate(); err != nil {
+			return fmt.Errorf("invalid sampling: %w", err)
+		}
+	}
 	if err := c.Level.Validate(); err != nil {
 		return fmt.Errorf("invalid level: %w", err)
 	}
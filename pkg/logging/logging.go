@@ -29,14 +29,22 @@ const RequestLoggerKey = "request-logger"
 // NewLogger takes a logging config and returns a new Zap logger that writes to
 // the log file pointed to by the config with the options applied and stdout.
 func NewLogger(config *Config) (*zap.Logger, error) {
+	logger, _, err := NewLoggerWithLevel(config)
+	return logger, err
+}
+
+// NewLoggerWithLevel is NewLogger also returning the level of the logger, which
+// can be changed at runtime, e.g. with LevelHandler.
+func NewLoggerWithLevel(config *Config) (*zap.Logger, zap.AtomicLevel, error) {
 	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid logging config: %w", err)
+		return nil, zap.AtomicLevel{}, fmt.Errorf("invalid logging config: %w", err)
 	}
 
-	encoder, level, err := constructEncoderAndLevel(config)
+	encoder, zapLevel, err := constructEncoderAndLevel(config)
 	if err != nil {
-		return nil, fmt.Errorf("constructing log encoder and level: %w", err)
+		return nil, zap.AtomicLevel{}, fmt.Errorf("constructing log encoder and level: %w", err)
 	}
+	level := zap.NewAtomicLevelAt(zapLevel)
 
 	logFile := zapcore.AddSync(&config.Logger)
 	logCore := zapcore.NewCore(encoder, logFile, level)
@@ -49,9 +57,12 @@ func NewLogger(config *Config) (*zap.Logger, error) {
 		consoleCore := zapcore.NewCore(encoder, console, level)
 		core = zapcore.NewTee(logCore, consoleCore)
 	}
+	if config.Sampling != nil {
+		core = config.Sampling.wrapCore(core)
+	}
 
 	// Add caller information with proper skip level to show actual source file, not zap internal files
-	return zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)), nil
+	return zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)), level, nil
 }
 
 func constructEncoderAndLevel(config *Config) (zapcore.Encoder, zapcore.Level, error) {
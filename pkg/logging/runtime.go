@@ -0,0 +1,182 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// LevelPath is where the binaries serve LevelHandler.
+const LevelPath = "/debug/loglevel"
+
+// DefaultLevelAddress is the loopback address LevelHandler is served at by default.
+const DefaultLevelAddress = "127.0.0.1:8090"
+
+// FlagOptions are the logging knobs of the binaries configured with flags rather
+// than a Config, i.e. the controller manager and the model agent.
+type FlagOptions struct {
+	// File is the log file written in addition to the console, rotated by
+	// size. No file is written if empty.
+	File string
+	// FileMaxSize is the size in megabytes of the log file before it is rotated.
+	FileMaxSize int
+	// FileMaxBackups is the number of rotated log files kept, all if 0.
+	FileMaxBackups int
+	// FileMaxAge is the number of days rotated log files are kept, forever if 0.
+	FileMaxAge int
+	// Sampling enables DefaultSampling.
+	Sampling bool
+	// LevelEndpoint enables LevelHandler on LevelPath.
+	LevelEndpoint bool
+	// LevelAddress is the loopback address LevelHandler is served at.
+	LevelAddress string
+}
+
+// DefaultFlagOptions returns the default values of the logging flags.
+func DefaultFlagOptions() FlagOptions {
+	return FlagOptions{
+		FileMaxSize:    100,
+		FileMaxBackups: 3,
+		LevelAddress:   DefaultLevelAddress,
+	}
+}
+
+// BindFlags binds the logging flags to the flag set.
+func (o *FlagOptions) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.File, "log-file", o.File,
+		"The log file written in addition to the console, rotated by size and on SIGHUP. No file is written if empty.")
+	fs.IntVar(&o.FileMaxSize, "log-file-max-size", o.FileMaxSize, "The size in megabytes of the log file before it is rotated.")
+	fs.IntVar(&o.FileMaxBackups, "log-file-max-backups", o.FileMaxBackups, "The number of rotated log files kept, all if 0.")
+	fs.IntVar(&o.FileMaxAge, "log-file-max-age", o.FileMaxAge, "The number of days rotated log files are kept, forever if 0.")
+	fs.BoolVar(&o.Sampling, "log-sampling", o.Sampling,
+		"If set, only the first 100 entries per second with the same level and message are logged, then every 100th. "+
+			"The production logs of the controller manager are always sampled.")
+	fs.BoolVar(&o.LevelEndpoint, "log-level-endpoint", o.LevelEndpoint,
+		"If set, the log level is reported on GET and changed on PUT of "+LevelPath+" at --log-level-bind-address.")
+	fs.StringVar(&o.LevelAddress, "log-level-bind-address", o.LevelAddress,
+		"The loopback address the log level endpoint binds to. It doesn't authenticate its requests, so it can't bind to other addresses.")
+}
+
+// FileWriter returns the writer of the log file, or nil if no file is written.
+func (o *FlagOptions) FileWriter() *lumberjack.Logger {
+	if o.File == "" {
+		return nil
+	}
+	return &lumberjack.Logger{
+		Filename:   o.File,
+		MaxSize:    o.FileMaxSize,
+		MaxBackups: o.FileMaxBackups,
+		MaxAge:     o.FileMaxAge,
+	}
+}
+
+// ZapOptions returns the zap options of the flags, i.e. the sampling.
+func (o *FlagOptions) ZapOptions() []zap.Option {
+	if !o.Sampling {
+		return nil
+	}
+	return []zap.Option{DefaultSampling.Option()}
+}
+
+// ControllerRuntimeZapOptions returns the zap options of the flags for a logger built by controller-runtime, which
+// already samples like DefaultSampling in production mode, so that the entries are sampled once. The levels of logr
+// verbosity below Debug are never sampled, as zap can't sample them.
+func (o *FlagOptions) ControllerRuntimeZapOptions(development bool, level zapcore.LevelEnabler) []zap.Option {
+	if !development || (level != nil && level.Enabled(zapcore.DebugLevel-1)) {
+		return nil
+	}
+	return o.ZapOptions()
+}
+
+// NewAtomicLevel returns a level that can be changed at runtime starting at the
+// lowest level the enabler enables, Info if nil. An atomic level is returned as
+// is, so that changing the returned level changes the loggers using it.
+func NewAtomicLevel(enabler zapcore.LevelEnabler) zap.AtomicLevel {
+	switch l := enabler.(type) {
+	case nil:
+		return zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	case zap.AtomicLevel:
+		return l
+	case *zap.AtomicLevel:
+		return *l
+	}
+	// The levels of controller-runtime go below Debug for the verbosity of logr
+	for l := zapcore.Level(-128); l < zapcore.FatalLevel; l++ {
+		if enabler.Enabled(l) {
+			return zap.NewAtomicLevelAt(l)
+		}
+	}
+	return zap.NewAtomicLevelAt(zapcore.FatalLevel)
+}
+
+// LevelHandler returns a handler reporting the level on GET and changing it on
+// PUT, with {"level":"debug"} as body or level=debug as form, without restarting
+// the binary. onChange, if not nil, is called on every change.
+func LevelHandler(level zap.AtomicLevel, onChange func(from, to zapcore.Level)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		from := level.Level()
+		level.ServeHTTP(w, r)
+		if to := level.Level(); to != from && onChange != nil {
+			onChange(from, to)
+		}
+	})
+}
+
+// ServeLevel serves the handler, i.e. LevelHandler, on LevelPath at the address until the context is done. The
+// handler doesn't authenticate its requests, so the address must be a loopback address, reachable from the pod or
+// with kubectl port-forward alone.
+func ServeLevel(ctx context.Context, address string, handler http.Handler) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid log level address %q: %w", address, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("log level address %q is not a loopback address", address)
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on log level address %q: %w", address, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(LevelPath, handler)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// OnSIGHUP calls fn on every SIGHUP until the context is done, e.g. to rotate a
+// log file after logrotate moved it or to restore the level changed with
+// LevelHandler.
+func OnSIGHUP(ctx context.Context, fn func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-signals:
+				fn()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"context"
+	"flag"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFlagOptions(t *testing.T) {
+	opts := DefaultFlagOptions()
+	assert.Nil(t, opts.FileWriter())
+	assert.Empty(t, opts.ZapOptions())
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.BindFlags(fs)
+	path := filepath.Join(t.TempDir(), "manager.log")
+	require.NoError(t, fs.Parse([]string{"--log-file=" + path, "--log-file-max-age=7", "--log-sampling"}))
+
+	file := opts.FileWriter()
+	require.NotNil(t, file)
+	assert.Equal(t, path, file.Filename)
+	assert.Equal(t, 100, file.MaxSize)
+	assert.Equal(t, 7, file.MaxAge)
+	assert.Len(t, opts.ZapOptions(), 1)
+	assert.Equal(t, DefaultLevelAddress, opts.LevelAddress)
+}
+
+func TestControllerRuntimeZapOptions(t *testing.T) {
+	opts := DefaultFlagOptions()
+	opts.Sampling = true
+	// controller-runtime samples the production logs already
+	assert.Empty(t, opts.ControllerRuntimeZapOptions(false, zapcore.InfoLevel))
+	assert.Len(t, opts.ControllerRuntimeZapOptions(true, zapcore.DebugLevel), 1)
+	assert.Len(t, opts.ControllerRuntimeZapOptions(true, nil), 1)
+	// zap can't sample the levels of logr verbosity
+	assert.Empty(t, opts.ControllerRuntimeZapOptions(true, zapcore.Level(-3)))
+
+	opts.Sampling = false
+	assert.Empty(t, opts.ControllerRuntimeZapOptions(true, zapcore.DebugLevel))
+}
+
+func TestServeLevel(t *testing.T) {
+	handler := LevelHandler(zap.NewAtomicLevelAt(zapcore.InfoLevel), nil)
+	assert.ErrorContains(t, ServeLevel(context.Background(), ":8090", handler), "not a loopback address")
+	assert.ErrorContains(t, ServeLevel(context.Background(), "10.0.0.1:8090", handler), "not a loopback address")
+	assert.ErrorContains(t, ServeLevel(context.Background(), "127.0.0.1", handler), "invalid log level address")
+
+	// Find a free port
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- ServeLevel(ctx, address, handler) }()
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + address + LevelPath)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode == http.StatusOK && strings.Contains(string(body), `"info"`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-served)
+}
+
+func TestNewAtomicLevel(t *testing.T) {
+	assert.Equal(t, zapcore.InfoLevel, NewAtomicLevel(nil).Level())
+	assert.Equal(t, zapcore.Level(-3), NewAtomicLevel(zapcore.Level(-3)).Level())
+
+	atomic := zap.NewAtomicLevelAt(zapcore.WarnLevel)
+	NewAtomicLevel(&atomic).SetLevel(zapcore.ErrorLevel)
+	assert.Equal(t, zapcore.ErrorLevel, atomic.Level())
+}
+
+func TestLevelHandler(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	var changes []zapcore.Level
+	handler := LevelHandler(level, func(from, to zapcore.Level) { changes = append(changes, from, to) })
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LevelPath, nil))
+	assert.JSONEq(t, `{"level":"info"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, LevelPath, strings.NewReader(`{"level":"debug"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, zapcore.DebugLevel, level.Level())
+	assert.Equal(t, []zapcore.Level{zapcore.InfoLevel, zapcore.DebugLevel}, changes)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, LevelPath, strings.NewReader(`{"level":"verbose"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Len(t, changes, 2)
+}
+
+func TestNewLoggerWithLevel(t *testing.T) {
+	config := &Config{Level: LevelWarn, DisableConsoleOutput: true, Sampling: &DefaultSampling}
+	config.Filename = filepath.Join(t.TempDir(), "app.log")
+	logger, level, err := NewLoggerWithLevel(config)
+	require.NoError(t, err)
+	assert.False(t, logger.Core().Enabled(zapcore.InfoLevel))
+	level.SetLevel(zapcore.InfoLevel)
+	assert.True(t, logger.Core().Enabled(zapcore.InfoLevel))
+}
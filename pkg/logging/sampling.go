@@ -0,0 +1,193 @@
+package logging
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultSampling logs the first 100 entries per second with the same level and
+// message, then every 100th, like the production config of zap.
+var DefaultSampling = SamplingConfig{Initial: 100, Thereafter: 100, Tick: time.Second}
+
+// SamplingConfig caps the entries logged with the same level and message. In
+// every tick, the first Initial entries are logged, then every Thereafter-th.
+type SamplingConfig struct {
+	Initial    int           `mapstructure:"initial"`
+	Thereafter int           `mapstructure:"thereafter"`
+	Tick       time.Duration `mapstructure:"tick"`
+}
+
+// Validate ensures the sampling config is valid.
+func (s *SamplingConfig) Validate() error {
+	if s.Initial < 0 {
+		return fmt.Errorf("initial must be >= 0, not %d", s.Initial)
+	}
+	if s.Thereafter < 0 {
+		return fmt.Errorf("thereafter must be >= 0, not %d", s.Thereafter)
+	}
+	if s.Tick < 0 {
+		return fmt.Errorf("tick must be >= 0, not %s", s.Tick)
+	}
+	return nil
+}
+
+// Option returns the zap option sampling the entries of a logger.
+func (s SamplingConfig) Option() zap.Option {
+	return zap.WrapCore(s.wrapCore)
+}
+
+func (s SamplingConfig) wrapCore(core zapcore.Core) zapcore.Core {
+	tick := s.Tick
+	if tick == 0 {
+		tick = time.Second
+	}
+	return zapcore.NewSamplerWithOptions(core, tick, s.Initial, s.Thereafter)
+}
+
+// RateLimited returns a logger logging at most burst entries with the same
+// message, or format for the f variants, per interval, for hot paths like the
+// progress of a download or a watch event handler. The first entry logged after
+// a suppression carries the number of suppressed entries in the "suppressed"
+// field. Fatal entries are never suppressed.
+func RateLimited(l Interface, burst int, interval time.Duration) Interface {
+	return rateLimited{
+		Interface: l,
+		limiter: &messageLimiter{
+			burst:    burst,
+			interval: interval,
+			now:      time.Now,
+			windows:  map[string]*limitWindow{},
+		},
+	}
+}
+
+// maxLimitWindows bounds the messages a rate limited logger tracks before it
+// forgets those whose interval elapsed
+const maxLimitWindows = 1024
+
+type limitWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+type messageLimiter struct {
+	mu       sync.Mutex
+	burst    int
+	interval time.Duration
+	now      func() time.Time
+	windows  map[string]*limitWindow
+}
+
+// allow reports whether an entry with the message can be logged and, if so, the
+// number of entries suppressed since the last one
+func (m *messageLimiter) allow(msg string) (bool, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	w, ok := m.windows[msg]
+	if !ok || now.Sub(w.start) >= m.interval {
+		if !ok && len(m.windows) >= maxLimitWindows {
+			m.forgetElapsed(now)
+		}
+		suppressed := 0
+		if ok {
+			suppressed = w.suppressed
+		}
+		m.windows[msg] = &limitWindow{start: now, count: 1}
+		return true, suppressed
+	}
+	if w.count < m.burst {
+		w.count++
+		return true, 0
+	}
+	w.suppressed++
+	return false, 0
+}
+
+func (m *messageLimiter) forgetElapsed(now time.Time) {
+	for msg, w := range m.windows {
+		if now.Sub(w.start) >= m.interval {
+			delete(m.windows, msg)
+		}
+	}
+}
+
+type rateLimited struct {
+	Interface
+	limiter *messageLimiter
+}
+
+// logger returns the logger an entry with the message is written to, or nil if
+// the entry is suppressed
+func (l rateLimited) logger(msg string) Interface {
+	ok, suppressed := l.limiter.allow(msg)
+	if !ok {
+		return nil
+	}
+	if suppressed > 0 {
+		return l.Interface.WithField("suppressed", suppressed)
+	}
+	return l.Interface
+}
+
+func (l rateLimited) WithField(key string, value interface{}) Interface {
+	return rateLimited{Interface: l.Interface.WithField(key, value), limiter: l.limiter}
+}
+
+func (l rateLimited) WithError(err error) Interface {
+	return rateLimited{Interface: l.Interface.WithError(err), limiter: l.limiter}
+}
+
+func (l rateLimited) Debug(msg string) {
+	if logger := l.logger(msg); logger != nil {
+		logger.Debug(msg)
+	}
+}
+
+func (l rateLimited) Info(msg string) {
+	if logger := l.logger(msg); logger != nil {
+		logger.Info(msg)
+	}
+}
+
+func (l rateLimited) Warn(msg string) {
+	if logger := l.logger(msg); logger != nil {
+		logger.Warn(msg)
+	}
+}
+
+func (l rateLimited) Error(msg string) {
+	if logger := l.logger(msg); logger != nil {
+		logger.Error(msg)
+	}
+}
+
+func (l rateLimited) Debugf(format string, args ...interface{}) {
+	if logger := l.logger(format); logger != nil {
+		logger.Debugf(format, args...)
+	}
+}
+
+func (l rateLimited) Infof(format string, args ...interface{}) {
+	if logger := l.logger(format); logger != nil {
+		logger.Infof(format, args...)
+	}
+}
+
+func (l rateLimited) Warnf(format string, args ...interface{}) {
+	if logger := l.logger(format); logger != nil {
+		logger.Warnf(format, args...)
+	}
+}
+
+func (l rateLimited) Errorf(format string, args ...interface{}) {
+	if logger := l.logger(format); logger != nil {
+		logger.Errorf(format, args...)
+	}
+}
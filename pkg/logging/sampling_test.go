@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRateLimited(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := RateLimited(ForZap(zap.New(core)), 2, time.Minute)
+	now := time.Now()
+	logger.(rateLimited).limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		logger.Info("download progress")
+		logger.WithField("shard", i).Infof("downloaded shard %d", i)
+	}
+	logger.Warn("another message")
+	require.Equal(t, 5, logs.Len())
+
+	now = now.Add(time.Minute)
+	logger.Info("download progress")
+	entries := logs.FilterMessage("download progress").All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, int64(3), entries[2].ContextMap()["suppressed"])
+	assert.Equal(t, 2, logs.FilterMessageSnippet("downloaded shard").Len())
+}
+
+func TestSamplingConfig(t *testing.T) {
+	require.Error(t, (&SamplingConfig{Initial: -1}).Validate())
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core, SamplingConfig{Initial: 2, Thereafter: 3, Tick: time.Minute}.Option())
+	for i := 0; i < 8; i++ {
+		logger.Info("watch event")
+	}
+	// The first 2, then the 5th and the 8th
+	assert.Equal(t, 4, logs.Len())
+}
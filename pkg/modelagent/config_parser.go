@@ -2,10 +2,10 @@ package modelagent
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"go.uber.org/zap"
@@ -84,7 +84,14 @@ func (p *ModelConfigParser) ParseModelConfig(modelDir string, baseModel *v1beta1
 		configPath, findErr := p.findConfigFile(modelDir)
 		if findErr != nil {
 			p.logger.Infof("Config file not found: %v", findErr)
-		} else {
+
+			// llama.cpp models carry their configuration in the header of their GGUF file
+			if ggufPath, ggufErr := modelconfig.FindGGUFFile(modelDir); ggufErr == nil {
+				p.logger.Infof("Found GGUF file at: %s", ggufPath)
+				configPath, findErr = ggufPath, nil
+			}
+		}
+		if findErr == nil {
 			p.logger.Infof("Found config file at: %s", configPath)
 
 			// Parse the config.json file using the hfutil.model_config module
@@ -101,7 +108,7 @@ func (p *ModelConfigParser) ParseModelConfig(modelDir string, baseModel *v1beta1
 	}
 
 	if !hasMetadata {
-		return nil, fmt.Errorf("no model_index.json or config.json found in %s", modelDir)
+		return nil, fmt.Errorf("no model_index.json, config.json or GGUF file found in %s", modelDir)
 	}
 
 	// Update BaseModel or ClusterBaseModel if provided
@@ -300,6 +307,15 @@ func (p *ModelConfigParser) extractModelMetadataFromHF(hfModel modelconfig.Huggi
 			Version: &diffusionModel.DiffusersVersion,
 		}
 		metadata.DiffusionPipeline = convertDiffusionPipelineSpec(diffusionModel)
+	} else if gguf, ok := hfModel.(*modelconfig.GGUFModelConfig); ok {
+		version := strconv.FormatUint(uint64(gguf.Version), 10)
+		metadata.ModelFormat = v1beta1.ModelFormat{
+			Name:    "gguf",
+			Version: &version,
+		}
+		metadata.ModelFramework = &v1beta1.ModelFrameworkSpec{
+			Name: "llama.cpp",
+		}
 	} else {
 		// Set the model format (most models use SafeTensors)
 		version := "1.0.0"
@@ -333,38 +349,30 @@ func (p *ModelConfigParser) extractModelMetadataFromHF(hfModel modelconfig.Huggi
 		case strings.Contains(strings.ToLower(quantType), "fp8"):
 			metadata.Quantization = v1beta1.ModelQuantizationFP8
 			p.logger.Infof("Setting quantization to FP8")
+		case strings.Contains(strings.ToLower(quantType), "awq"):
+			metadata.Quantization = v1beta1.ModelQuantizationAWQ
+			p.logger.Infof("Setting quantization to AWQ")
+		case strings.Contains(strings.ToLower(quantType), "gptq"):
+			metadata.Quantization = v1beta1.ModelQuantizationGPTQ
+			p.logger.Infof("Setting quantization to GPTQ")
+		case strings.HasPrefix(quantType, "Q4_") || strings.HasPrefix(quantType, "IQ4_"):
+			// llama.cpp 4-bit quantizations, e.g. Q4_K_M
+			metadata.Quantization = v1beta1.ModelQuantizationINT4
+			p.logger.Infof("Setting quantization to INT4")
+		case hfModel.GetBitsPerWeight() == 4:
+			// Other 4-bit integer quantizations, e.g. bitsandbytes load_in_4bit
+			metadata.Quantization = v1beta1.ModelQuantizationINT4
+			p.logger.Infof("Setting quantization to INT4")
 		}
 	}
 
-	// Get the model size in bytes
-	modelSizeBytes := hfModel.GetModelSizeBytes()
-	if modelSizeBytes > 0 {
+	// Normalized metadata document of the model for status
+	canonical := modelconfig.Canonicalize(hfModel)
+	if canonical.ModelSizeBytes > 0 {
 		p.logger.Infof("Model size in bytes: %d (%.2f GB)",
-			modelSizeBytes, float64(modelSizeBytes)/1000000000.0)
-	}
-
-	// Get the raw JSON configuration for status
-	configJSON, err := json.Marshal(struct {
-		ModelType          string `json:"model_type"`
-		Architecture       string `json:"architecture"`
-		ContextLength      int    `json:"context_length"`
-		ParameterCount     string `json:"parameter_count"`
-		HasVision          bool   `json:"has_vision"`
-		IsEmbedding        bool   `json:"is_embedding"`
-		TransformerVersion string `json:"transformers_version"`
-		TorchDtype         string `json:"torch_dtype"`
-		ModelSizeBytes     int64  `json:"model_size_bytes"`
-	}{
-		ModelType:          hfModel.GetModelType(),
-		Architecture:       hfModel.GetArchitecture(),
-		ContextLength:      hfModel.GetContextLength(),
-		ParameterCount:     modelconfig.FormatParamCount(hfModel.GetParameterCount()),
-		HasVision:          hfModel.HasVision(),
-		IsEmbedding:        hfModel.IsEmbedding(),
-		TransformerVersion: hfModel.GetTransformerVersion(),
-		TorchDtype:         hfModel.GetTorchDtype(),
-		ModelSizeBytes:     modelSizeBytes,
-	})
+			canonical.ModelSizeBytes, float64(canonical.ModelSizeBytes)/1000000000.0)
+	}
+	configJSON, err := canonical.Marshal()
 	if err == nil {
 		metadata.ModelConfiguration = configJSON
 	} else {
@@ -572,8 +580,14 @@ func (p *ModelConfigParser) determineModelCapabilitiesFromHF(hfModel modelconfig
 		return append(capabilities, string(v1beta1.ModelCapabilityAudioToText))
 	}
 
+	// Check for rerank capability, cross-encoders scoring query-document pairs
+	task := modelconfig.GetModelTask(hfModel)
+	if task == modelconfig.ModelTaskRerank {
+		return append(capabilities, string(v1beta1.ModelCapabilityRerank))
+	}
+
 	// Check for text embedding capability
-	if hfModel.IsEmbedding() ||
+	if task == modelconfig.ModelTaskEmbedding ||
 		strings.Contains(normalizedArchitecture, "embedding") ||
 		strings.Contains(normalizedArchitecture, "sentence") ||
 		strings.Contains(normalizedModelType, "bert") ||
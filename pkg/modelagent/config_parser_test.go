@@ -20,9 +20,12 @@ type mockHuggingFaceModel struct {
 	modelType          string
 	architecture       string
 	parameterCount     int64
+	activeParameters   int64
+	numExperts         int
 	contextLength      int
 	transformerVersion string
 	quantizationType   string
+	bitsPerWeight      float64
 	torchDtype         string
 	modelSizeBytes     int64
 	hasVision          bool
@@ -39,16 +42,19 @@ func (m *mockDiffusionModel) GetDiffusionModel() *modelconfig.DiffusionPipelineS
 }
 
 // Implement all methods of the HuggingFaceModel interface
-func (m *mockHuggingFaceModel) GetModelType() string          { return m.modelType }
-func (m *mockHuggingFaceModel) GetArchitecture() string       { return m.architecture }
-func (m *mockHuggingFaceModel) GetParameterCount() int64      { return m.parameterCount }
-func (m *mockHuggingFaceModel) GetContextLength() int         { return m.contextLength }
-func (m *mockHuggingFaceModel) GetTransformerVersion() string { return m.transformerVersion }
-func (m *mockHuggingFaceModel) GetQuantizationType() string   { return m.quantizationType }
-func (m *mockHuggingFaceModel) GetTorchDtype() string         { return m.torchDtype }
-func (m *mockHuggingFaceModel) GetModelSizeBytes() int64      { return m.modelSizeBytes }
-func (m *mockHuggingFaceModel) HasVision() bool               { return m.hasVision }
-func (m *mockHuggingFaceModel) IsEmbedding() bool             { return m.isEmbedding }
+func (m *mockHuggingFaceModel) GetModelType() string           { return m.modelType }
+func (m *mockHuggingFaceModel) GetArchitecture() string        { return m.architecture }
+func (m *mockHuggingFaceModel) GetParameterCount() int64       { return m.parameterCount }
+func (m *mockHuggingFaceModel) GetActiveParameterCount() int64 { return m.activeParameters }
+func (m *mockHuggingFaceModel) GetNumExperts() int             { return m.numExperts }
+func (m *mockHuggingFaceModel) GetContextLength() int          { return m.contextLength }
+func (m *mockHuggingFaceModel) GetTransformerVersion() string  { return m.transformerVersion }
+func (m *mockHuggingFaceModel) GetQuantizationType() string    { return m.quantizationType }
+func (m *mockHuggingFaceModel) GetBitsPerWeight() float64      { return m.bitsPerWeight }
+func (m *mockHuggingFaceModel) GetTorchDtype() string          { return m.torchDtype }
+func (m *mockHuggingFaceModel) GetModelSizeBytes() int64       { return m.modelSizeBytes }
+func (m *mockHuggingFaceModel) HasVision() bool                { return m.hasVision }
+func (m *mockHuggingFaceModel) IsEmbedding() bool              { return m.isEmbedding }
 
 // Define a helper function to create a mock model with default values
 func createDefaultMockModel() *mockHuggingFaceModel {
@@ -145,6 +151,90 @@ func TestExtractModelMetadataFromHF(t *testing.T) {
 			expectedCapability:   string(v1beta1.ModelCapabilityTextToText),
 			expectedQuantization: v1beta1.ModelQuantizationFP8,
 		},
+		{
+			name: "AWQ Quantized Model",
+			mockModel: &mockHuggingFaceModel{
+				modelType:          "qwen2",
+				architecture:       "Qwen2ForCausalLM",
+				parameterCount:     7000000000, // 7B
+				contextLength:      32768,
+				transformerVersion: "4.41.0",
+				quantizationType:   "awq",
+				torchDtype:         "float16",
+				modelSizeBytes:     5000000000,
+				hasVision:          false,
+			},
+			expectedMetadata: func(metadata ModelMetadata) bool {
+				return metadata.ModelType == "qwen2" &&
+					metadata.ModelArchitecture == "Qwen2ForCausalLM"
+			},
+			expectedCapability:   string(v1beta1.ModelCapabilityTextToText),
+			expectedQuantization: v1beta1.ModelQuantizationAWQ,
+		},
+		{
+			name: "GPTQ Quantized Model",
+			mockModel: &mockHuggingFaceModel{
+				modelType:          "llama",
+				architecture:       "LlamaForCausalLM",
+				parameterCount:     13000000000, // 13B
+				contextLength:      4096,
+				transformerVersion: "4.38.0",
+				quantizationType:   "gptq",
+				torchDtype:         "float16",
+				modelSizeBytes:     7000000000,
+				hasVision:          false,
+			},
+			expectedMetadata: func(metadata ModelMetadata) bool {
+				return metadata.ModelType == "llama" &&
+					metadata.ModelArchitecture == "LlamaForCausalLM"
+			},
+			expectedCapability:   string(v1beta1.ModelCapabilityTextToText),
+			expectedQuantization: v1beta1.ModelQuantizationGPTQ,
+		},
+		{
+			name: "BitsAndBytes 4-bit Quantized Model",
+			mockModel: &mockHuggingFaceModel{
+				modelType:          "mistral",
+				architecture:       "MistralForCausalLM",
+				parameterCount:     7000000000,
+				contextLength:      32768,
+				transformerVersion: "4.40.0",
+				quantizationType:   "bitsandbytes",
+				bitsPerWeight:      4,
+				torchDtype:         "float16",
+				modelSizeBytes:     14000000000,
+			},
+			expectedMetadata: func(metadata ModelMetadata) bool {
+				return metadata.ModelType == "mistral"
+			},
+			expectedCapability:   string(v1beta1.ModelCapabilityTextToText),
+			expectedQuantization: v1beta1.ModelQuantizationINT4,
+		},
+		{
+			name: "MoE Model",
+			mockModel: &mockHuggingFaceModel{
+				modelType:          "mixtral",
+				architecture:       "MixtralForCausalLM",
+				parameterCount:     46700000000,
+				activeParameters:   12900000000,
+				numExperts:         8,
+				contextLength:      32768,
+				transformerVersion: "4.36.0",
+				torchDtype:         "bfloat16",
+				modelSizeBytes:     93400000000,
+			},
+			expectedMetadata: func(metadata ModelMetadata) bool {
+				var configData map[string]interface{}
+				if err := json.Unmarshal(metadata.ModelConfiguration, &configData); err != nil {
+					return false
+				}
+				return metadata.ModelParameterSize == "46.7B" &&
+					configData["active_parameter_count"] == "12.9B" &&
+					configData["num_experts"] == float64(8)
+			},
+			expectedCapability:   string(v1beta1.ModelCapabilityTextToText),
+			expectedQuantization: "",
+		},
 		{
 			name: "Vision Model",
 			mockModel: &mockHuggingFaceModel{
@@ -771,6 +861,67 @@ func TestParseModelConfig_PrefersModelIndex(t *testing.T) {
 	assert.True(t, loadCalled, "loadModelConfig should be called when model_index.json is present")
 }
 
+func TestParseModelConfig_GGUF(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"model.gguf", "mmproj.gguf", "params.json"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte("test"), 0644))
+	}
+
+	logger, _ := zap.NewDevelopment()
+	var loadedPath string
+	parser := &ModelConfigParser{
+		logger: logger.Sugar(),
+		loadModelConfig: func(configPath string) (modelconfig.HuggingFaceModel, error) {
+			loadedPath = configPath
+			model := &modelconfig.GGUFModelConfig{Version: 3, FileType: "Q4_K_M"}
+			model.ModelType = "llama"
+			model.Architectures = []string{"LlamaForCausalLM"}
+			return model, nil
+		},
+	}
+
+	metadata, err := parser.ParseModelConfig(tempDir, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tempDir, "model.gguf"), loadedPath)
+	if assert.NotNil(t, metadata) {
+		assert.Equal(t, "gguf", metadata.ModelFormat.Name)
+		if assert.NotNil(t, metadata.ModelFormat.Version) {
+			assert.Equal(t, "3", *metadata.ModelFormat.Version)
+		}
+		if assert.NotNil(t, metadata.ModelFramework) {
+			assert.Equal(t, "llama.cpp", metadata.ModelFramework.Name)
+		}
+		assert.Equal(t, v1beta1.ModelQuantizationINT4, metadata.Quantization)
+		assert.Equal(t, "LlamaForCausalLM", metadata.ModelArchitecture)
+	}
+}
+
+func TestParseModelConfig_Reranker(t *testing.T) {
+	tempDir := t.TempDir()
+	config := `{
+		"model_type": "xlm-roberta",
+		"architectures": ["XLMRobertaForSequenceClassification"],
+		"id2label": {"0": "LABEL_0"},
+		"hidden_size": 1024,
+		"num_hidden_layers": 24,
+		"max_position_embeddings": 8194
+	}`
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, DefaultConfigFileName), []byte(config), 0644))
+
+	logger, _ := zap.NewDevelopment()
+	parser := NewModelConfigParser(nil, logger.Sugar())
+
+	metadata, err := parser.ParseModelConfig(tempDir, nil, nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, metadata) {
+		assert.Equal(t, []string{string(v1beta1.ModelCapabilityRerank)}, metadata.ModelCapabilities)
+
+		var configData map[string]interface{}
+		assert.NoError(t, json.Unmarshal(metadata.ModelConfiguration, &configData))
+		assert.Equal(t, "rerank", configData["task"])
+	}
+}
+
 func TestFormatParamCount(t *testing.T) {
 	testCases := []struct {
 		input    int64
@@ -2,6 +2,7 @@ package modelagent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/oracle/oci-go-sdk/v65/objectstorage"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
@@ -21,7 +23,10 @@ import (
 	omev1beta1lister "github.com/sgl-project/ome/pkg/client/listers/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/modelcar"
+	"github.com/sgl-project/ome/pkg/modelscope"
 	"github.com/sgl-project/ome/pkg/ociobjectstore"
+	"github.com/sgl-project/ome/pkg/ollama"
 	"github.com/sgl-project/ome/pkg/principals"
 	"github.com/sgl-project/ome/pkg/utils"
 	"github.com/sgl-project/ome/pkg/utils/storage"
@@ -379,6 +384,27 @@ func (s *Gopher) processTask(task *GopherTask) error {
 				// Error is already logged and metrics recorded in the method
 				return err
 			}
+		case storage.StorageTypeModelScope:
+			s.logger.Infof("Starting ModelScope download for model %s", modelInfo)
+
+			if err := s.processModelScopeModel(ctx, task, baseModelSpec, modelInfo, modelType, namespace, name); err != nil {
+				// Error is already logged and metrics recorded in the method
+				return err
+			}
+		case storage.StorageTypeOllama:
+			s.logger.Infof("Starting Ollama registry download for model %s", modelInfo)
+
+			if err := s.processOllamaModel(ctx, task, baseModelSpec, modelInfo, modelType, namespace, name); err != nil {
+				// Error is already logged and metrics recorded in the method
+				return err
+			}
+		case storage.StorageTypeModelCar:
+			s.logger.Infof("Starting model image download for model %s", modelInfo)
+
+			if err := s.processModelCarModel(ctx, task, baseModelSpec, modelInfo, modelType, namespace, name); err != nil {
+				// Error is already logged and metrics recorded in the method
+				return err
+			}
 		case storage.StorageTypePVC:
 			s.logger.Infof("Skipping PVC storage type for model %s (handled by BaseModel controller)", modelInfo)
 			// PVC storage is handled entirely by the BaseModel controller
@@ -488,6 +514,42 @@ func (s *Gopher) processTask(task *GopherTask) error {
 			} else {
 				s.logger.Infof("no need to delete parent model artifact directory %s: %s", parentName, parentDir)
 			}
+		case storage.StorageTypeModelScope:
+			s.logger.Infof("Removing ModelScope model %s", modelInfo)
+			destPath := getDestPath(&baseModelSpec, s.modelRootDir)
+			isSkippingDeletion, _, _, _ := s.isSkippingArtifactDeletion(ctx, task, destPath, false)
+			if !isSkippingDeletion {
+				err = s.deleteModel(destPath, task)
+				if err != nil {
+					s.logger.Errorf("Failed to delete ModelScope model %s: %v", modelInfo, err)
+					return err
+				}
+				s.logger.Infof("Successfully deleted ModelScope model %s", modelInfo)
+			}
+		case storage.StorageTypeOllama:
+			s.logger.Infof("Removing Ollama registry model %s", modelInfo)
+			destPath := getDestPath(&baseModelSpec, s.modelRootDir)
+			isSkippingDeletion, _, _, _ := s.isSkippingArtifactDeletion(ctx, task, destPath, false)
+			if !isSkippingDeletion {
+				err = s.deleteModel(destPath, task)
+				if err != nil {
+					s.logger.Errorf("Failed to delete Ollama registry model %s: %v", modelInfo, err)
+					return err
+				}
+				s.logger.Infof("Successfully deleted Ollama registry model %s", modelInfo)
+			}
+		case storage.StorageTypeModelCar:
+			s.logger.Infof("Removing model image %s", modelInfo)
+			destPath := getDestPath(&baseModelSpec, s.modelRootDir)
+			isSkippingDeletion, _, _, _ := s.isSkippingArtifactDeletion(ctx, task, destPath, false)
+			if !isSkippingDeletion {
+				err = s.deleteModel(destPath, task)
+				if err != nil {
+					s.logger.Errorf("Failed to delete model image %s: %v", modelInfo, err)
+					return err
+				}
+				s.logger.Infof("Successfully deleted model image %s", modelInfo)
+			}
 		case storage.StorageTypeLocal:
 			s.logger.Infof("Skipping deletion for local storage model %s (local files should not be deleted)", modelInfo)
 			// For local storage, we should NOT delete the actual files
@@ -1037,14 +1099,13 @@ func (s *Gopher) processHuggingFaceModel(ctx context.Context, task *GopherTask,
 		if hfToken != "" {
 			s.logger.Infof("Using authentication token for HuggingFace model %s", modelInfo)
 			config.Token = hfToken
+			// The token of the model replaces the token file of the agent
+			config.TokenFile = ""
 		}
 
 		// Create progress handler for tracking download progress
 		// Uses single worker with atomic pointer to avoid fire-and-forget goroutines
 		// that can cause race conditions and "context canceled" errors
-		var lastBytes atomic.Uint64
-		var lastTimeNano atomic.Int64
-		lastTimeNano.Store(time.Now().UnixNano())
 		progressThrottle := 30 * time.Second // Update ConfigMap every 30 seconds
 		const progressFlushTimeout = 5 * time.Second
 
@@ -1100,17 +1161,6 @@ func (s *Gopher) processHuggingFaceModel(ctx context.Context, task *GopherTask,
 		progressHandler := func(update xet.ProgressUpdate) {
 			now := time.Now()
 
-			// Calculate speed (bytes per second) using atomic variables for thread safety
-			prevBytes := lastBytes.Load()
-			prevTimeNano := lastTimeNano.Load()
-			var speedBytesPerSec float64
-			elapsed := float64(now.UnixNano()-prevTimeNano) / float64(time.Second)
-			if elapsed > 0 && update.CompletedBytes > prevBytes {
-				speedBytesPerSec = float64(update.CompletedBytes-prevBytes) / elapsed
-			}
-			lastBytes.Store(update.CompletedBytes)
-			lastTimeNano.Store(now.UnixNano())
-
 			// Store latest progress atomically (non-blocking, lock-free)
 			// Worker will periodically flush this to ConfigMap
 			latestProgress.Store(&DownloadProgress{
@@ -1119,7 +1169,8 @@ func (s *Gopher) processHuggingFaceModel(ctx context.Context, task *GopherTask,
 				CompletedBytes:   update.CompletedBytes,
 				TotalFiles:       update.TotalFiles,
 				CompletedFiles:   update.CompletedFiles,
-				SpeedBytesPerSec: speedBytesPerSec,
+				SpeedBytesPerSec: float64(update.BytesPerSecond),
+				DedupRatio:       update.DedupRatio(),
 				LastUpdated:      now.Format(time.RFC3339),
 			})
 		}
@@ -1269,6 +1320,327 @@ func (s *Gopher) processLocalStorageModel(ctx context.Context, task *GopherTask,
 	return nil
 }
 
+// processModelScopeModel downloads a model snapshot from the ModelScope hub.
+// The token is read like the one of Hugging Face models, from the storage key secret or the token parameter.
+func (s *Gopher) processModelScopeModel(ctx context.Context, task *GopherTask, baseModelSpec v1beta1.BaseModelSpec,
+	modelInfo, modelType, namespace, name string) error {
+	msComponents, err := storage.ParseModelScopeStorageURI(*baseModelSpec.Storage.StorageUri)
+	if err != nil {
+		s.logger.Errorf("Failed to parse ModelScope URI for model %s: %v", modelInfo, err)
+		s.metrics.RecordFailedDownload(modelType, namespace, name, "invalid_ms_uri")
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	destPath := getDestPath(&baseModelSpec, s.modelRootDir)
+	s.logger.Infof("Downloading ModelScope model %s (revision: %s) to %s",
+		msComponents.ModelID, msComponents.Revision, destPath)
+
+	// Progress is stored on every completed file and flushed to the ConfigMap periodically
+	progressThrottle := 30 * time.Second
+	const progressFlushTimeout = 5 * time.Second
+	var latestProgress atomic.Pointer[DownloadProgress]
+	flushProgress := func(timeout time.Duration) {
+		p := latestProgress.Swap(nil)
+		if p == nil {
+			return
+		}
+		flushCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		progressOp := &ConfigMapProgressOp{
+			Progress:         p,
+			BaseModel:        task.BaseModel,
+			ClusterBaseModel: task.ClusterBaseModel,
+		}
+		if err := s.configMapReconciler.ReconcileModelProgress(flushCtx, progressOp); err != nil {
+			s.logger.Warnf("Failed to update download progress for %s: %v", modelInfo, err)
+		}
+	}
+	stopWorker := make(chan struct{})
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		ticker := time.NewTicker(progressThrottle)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				flushProgress(progressThrottle)
+			case <-stopWorker:
+				flushProgress(progressFlushTimeout)
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(stopWorker)
+		<-workerDone
+	}()
+
+	opts := []modelscope.Option{
+		modelscope.WithLogger(logging.ForZap(s.logger.Desugar())),
+		modelscope.WithRetryConfig(s.downloadRetry, modelscope.DefaultRetryInterval),
+		modelscope.WithProgress(func(progress modelscope.Progress) {
+			latestProgress.Store(&DownloadProgress{
+				Phase:          xet.ProgressPhaseDownloading.String(),
+				TotalBytes:     uint64(progress.TotalBytes),
+				CompletedBytes: uint64(progress.CompletedBytes),
+				TotalFiles:     uint32(progress.TotalFiles),
+				CompletedFiles: uint32(progress.CompletedFiles),
+				LastUpdated:    time.Now().Format(time.RFC3339),
+			})
+		}),
+	}
+	if s.concurrency > 0 {
+		opts = append(opts, modelscope.WithMaxWorkers(s.concurrency))
+	}
+	if token := s.getHuggingFaceToken(task, baseModelSpec, modelInfo); token != "" {
+		s.logger.Infof("Using authentication token for ModelScope model %s", modelInfo)
+		opts = append(opts, modelscope.WithToken(token))
+	}
+	client, err := modelscope.NewClient(opts...)
+	if err != nil {
+		s.logger.Errorf("Failed to create ModelScope client for model %s: %v", modelInfo, err)
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	_, err = client.SnapshotDownload(ctx, modelscope.SnapshotRequest{
+		ModelID:  msComponents.ModelID,
+		Revision: msComponents.Revision,
+		LocalDir: destPath,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			s.logger.Infof("Download cancelled for model %s: %v", modelInfo, ctx.Err())
+			return ctx.Err()
+		}
+		errorType := "ms_download_error"
+		if errors.Is(err, modelscope.ErrChecksumMismatch) {
+			errorType = "checksum_verification_error"
+		}
+		s.logger.Errorf("Failed to download ModelScope model %s: %v", modelInfo, err)
+		s.metrics.RecordFailedDownload(modelType, namespace, name, errorType)
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	var baseModel *v1beta1.BaseModel
+	var clusterBaseModel *v1beta1.ClusterBaseModel
+	if task.BaseModel != nil {
+		baseModel = task.BaseModel
+	} else if task.ClusterBaseModel != nil {
+		clusterBaseModel = task.ClusterBaseModel
+	}
+
+	if err := s.safeParseAndUpdateModelConfig(destPath, baseModel, clusterBaseModel, nil); err != nil {
+		s.logger.Errorf("Failed to parse and update model config: %v", err)
+	}
+
+	s.logger.Infof("Successfully downloaded ModelScope model %s to %s", modelInfo, destPath)
+	return nil
+}
+
+// processOllamaModel pulls a GGUF model from an Ollama or OCI artifact registry.
+// The blobs of the manifest are written under the destination path with the file names of their media types,
+// e.g. model.gguf, and the blobs already pulled are verified and kept when the download is retried.
+func (s *Gopher) processOllamaModel(ctx context.Context, task *GopherTask, baseModelSpec v1beta1.BaseModelSpec,
+	modelInfo, modelType, namespace, name string) error {
+	ref, err := ollama.ParseReference(*baseModelSpec.Storage.StorageUri)
+	if err != nil {
+		s.logger.Errorf("Failed to parse Ollama URI for model %s: %v", modelInfo, err)
+		s.metrics.RecordFailedDownload(modelType, namespace, name, "invalid_ollama_uri")
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	destPath := getDestPath(&baseModelSpec, s.modelRootDir)
+	s.logger.Infof("Pulling Ollama model %s to %s", ref, destPath)
+
+	// The blobs are few and large, the progress is reported once each of them is pulled
+	const progressFlushTimeout = 5 * time.Second
+	progressHandler := func(progress ollama.Progress) {
+		progressOp := &ConfigMapProgressOp{
+			Progress: &DownloadProgress{
+				Phase:          xet.ProgressPhaseDownloading.String(),
+				TotalBytes:     uint64(progress.TotalBytes),
+				CompletedBytes: uint64(progress.CompletedBytes),
+				TotalFiles:     uint32(progress.TotalFiles),
+				CompletedFiles: uint32(progress.CompletedFiles),
+				LastUpdated:    time.Now().Format(time.RFC3339),
+			},
+			BaseModel:        task.BaseModel,
+			ClusterBaseModel: task.ClusterBaseModel,
+		}
+		flushCtx, cancel := context.WithTimeout(ctx, progressFlushTimeout)
+		defer cancel()
+		if err := s.configMapReconciler.ReconcileModelProgress(flushCtx, progressOp); err != nil {
+			s.logger.Warnf("Failed to update download progress for %s: %v", modelInfo, err)
+		}
+	}
+
+	client, err := ollama.NewClient(
+		ollama.WithLogger(logging.ForZap(s.logger.Desugar())),
+		ollama.WithRetryConfig(s.downloadRetry, ollama.DefaultRetryInterval),
+		ollama.WithProgress(progressHandler),
+	)
+	if err != nil {
+		s.logger.Errorf("Failed to create Ollama registry client for model %s: %v", modelInfo, err)
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	if _, err := client.Pull(ctx, ref, destPath); err != nil {
+		if ctx.Err() != nil {
+			s.logger.Infof("Download cancelled for model %s: %v", modelInfo, ctx.Err())
+			return ctx.Err()
+		}
+		errorType := "ollama_download_error"
+		if errors.Is(err, ollama.ErrDigestMismatch) {
+			errorType = "digest_verification_error"
+		}
+		s.logger.Errorf("Failed to pull Ollama model %s: %v", modelInfo, err)
+		s.metrics.RecordFailedDownload(modelType, namespace, name, errorType)
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	var baseModel *v1beta1.BaseModel
+	var clusterBaseModel *v1beta1.ClusterBaseModel
+	if task.BaseModel != nil {
+		baseModel = task.BaseModel
+	} else if task.ClusterBaseModel != nil {
+		clusterBaseModel = task.ClusterBaseModel
+	}
+
+	if err := s.safeParseAndUpdateModelConfig(destPath, baseModel, clusterBaseModel, nil); err != nil {
+		s.logger.Errorf("Failed to parse and update model config: %v", err)
+	}
+
+	s.logger.Infof("Successfully pulled Ollama model %s to %s", modelInfo, destPath)
+	return nil
+}
+
+// processModelCarModel pulls a model image (modelcar) from an OCI registry.
+// The model files of the image are extracted to the destination path, and pulling the image the path already holds
+// does nothing. The registry credentials are read from the secret of the storage key.
+func (s *Gopher) processModelCarModel(ctx context.Context, task *GopherTask, baseModelSpec v1beta1.BaseModelSpec,
+	modelInfo, modelType, namespace, name string) error {
+	ref, err := modelcar.ParseReference(*baseModelSpec.Storage.StorageUri)
+	if err != nil {
+		s.logger.Errorf("Failed to parse model image URI for model %s: %v", modelInfo, err)
+		s.metrics.RecordFailedDownload(modelType, namespace, name, "invalid_modelcar_uri")
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	destPath := getDestPath(&baseModelSpec, s.modelRootDir)
+	s.logger.Infof("Pulling model image %s to %s", ref, destPath)
+
+	// The layers are few and large, the progress is reported once each of them is pulled
+	const progressFlushTimeout = 5 * time.Second
+	progressHandler := func(progress modelcar.Progress) {
+		progressOp := &ConfigMapProgressOp{
+			Progress: &DownloadProgress{
+				Phase:          xet.ProgressPhaseDownloading.String(),
+				TotalBytes:     uint64(progress.TotalBytes),
+				CompletedBytes: uint64(progress.CompletedBytes),
+				TotalFiles:     uint32(progress.TotalLayers),
+				CompletedFiles: uint32(progress.CompletedLayers),
+				LastUpdated:    time.Now().Format(time.RFC3339),
+			},
+			BaseModel:        task.BaseModel,
+			ClusterBaseModel: task.ClusterBaseModel,
+		}
+		flushCtx, cancel := context.WithTimeout(ctx, progressFlushTimeout)
+		defer cancel()
+		if err := s.configMapReconciler.ReconcileModelProgress(flushCtx, progressOp); err != nil {
+			s.logger.Warnf("Failed to update download progress for %s: %v", modelInfo, err)
+		}
+	}
+
+	opts := []modelcar.Option{
+		modelcar.WithLogger(logging.ForZap(s.logger.Desugar())),
+		modelcar.WithRetryConfig(s.downloadRetry, modelcar.DefaultRetryInterval),
+		modelcar.WithProgress(progressHandler),
+	}
+	if baseModelSpec.Storage.Parameters != nil && (*baseModelSpec.Storage.Parameters)["plainHTTP"] == "true" {
+		opts = append(opts, modelcar.WithPlainHTTP(true))
+	}
+	username, password := s.getModelCarCredentials(task, baseModelSpec, ref, modelInfo)
+	if username != "" {
+		opts = append(opts, modelcar.WithBasicAuth(username, password))
+	}
+	client, err := modelcar.NewClient(opts...)
+	if err != nil {
+		s.logger.Errorf("Failed to create registry client for model %s: %v", modelInfo, err)
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	if _, err := client.Pull(ctx, ref, destPath); err != nil {
+		if ctx.Err() != nil {
+			s.logger.Infof("Download cancelled for model %s: %v", modelInfo, ctx.Err())
+			return ctx.Err()
+		}
+		errorType := "modelcar_download_error"
+		if errors.Is(err, modelcar.ErrDigestMismatch) {
+			errorType = "digest_verification_error"
+		}
+		s.logger.Errorf("Failed to pull model image %s: %v", modelInfo, err)
+		s.metrics.RecordFailedDownload(modelType, namespace, name, errorType)
+		s.markModelOnNodeFailed(task)
+		return err
+	}
+
+	var baseModel *v1beta1.BaseModel
+	var clusterBaseModel *v1beta1.ClusterBaseModel
+	if task.BaseModel != nil {
+		baseModel = task.BaseModel
+	} else if task.ClusterBaseModel != nil {
+		clusterBaseModel = task.ClusterBaseModel
+	}
+
+	if err := s.safeParseAndUpdateModelConfig(destPath, baseModel, clusterBaseModel, nil); err != nil {
+		s.logger.Errorf("Failed to parse and update model config: %v", err)
+	}
+
+	s.logger.Infof("Successfully pulled model image %s to %s", modelInfo, destPath)
+	return nil
+}
+
+// getModelCarCredentials retrieves the registry credentials of a model image from the secret of its storage key,
+// either an image pull secret (kubernetes.io/dockerconfigjson) or a secret with username and password keys.
+func (s *Gopher) getModelCarCredentials(task *GopherTask, baseModelSpec v1beta1.BaseModelSpec, ref modelcar.Reference, modelInfo string) (string, string) {
+	if baseModelSpec.Storage.StorageKey == nil || *baseModelSpec.Storage.StorageKey == "" {
+		return "", ""
+	}
+	if s.kubeClient == nil {
+		s.logger.Warnf("Cannot fetch registry credentials: Kubernetes client not initialized")
+		return "", ""
+	}
+
+	namespace := "ome"
+	if task.BaseModel != nil {
+		namespace = task.BaseModel.Namespace
+	}
+	secretName := *baseModelSpec.Storage.StorageKey
+	secret, err := s.kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		s.logger.Warnf("Failed to retrieve secret %s in namespace %s for registry credentials: %v", secretName, namespace, err)
+		return "", ""
+	}
+
+	if dockerConfig, exists := secret.Data[corev1.DockerConfigJsonKey]; exists {
+		username, password, err := modelcar.DockerConfigCredentials(dockerConfig, ref.Registry)
+		if err != nil {
+			s.logger.Warnf("Failed to read registry credentials of secret %s for model %s: %v", secretName, modelInfo, err)
+		}
+		return username, password
+	}
+	return string(secret.Data["username"]), string(secret.Data["password"])
+}
+
 // for unit test
 var fetchAttributeFromHfModelMetaData = FetchAttributeFromHfModelMetaData
 
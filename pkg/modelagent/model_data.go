@@ -79,13 +79,14 @@ type Artifact struct {
 
 // DownloadProgress tracks the progress of a model download
 type DownloadProgress struct {
-	Phase            string  `json:"phase"`            // Scanning, Downloading, Finalizing
-	TotalBytes       uint64  `json:"totalBytes"`       // Total bytes to download
-	CompletedBytes   uint64  `json:"completedBytes"`   // Bytes downloaded so far
-	TotalFiles       uint32  `json:"totalFiles"`       // Total number of files
-	CompletedFiles   uint32  `json:"completedFiles"`   // Files downloaded so far
-	SpeedBytesPerSec float64 `json:"speedBytesPerSec"` // Current download speed
-	LastUpdated      string  `json:"lastUpdated"`      // RFC3339 timestamp of last update
+	Phase            string  `json:"phase"`                // Scanning, Downloading, Finalizing
+	TotalBytes       uint64  `json:"totalBytes"`           // Total bytes to download
+	CompletedBytes   uint64  `json:"completedBytes"`       // Bytes downloaded so far
+	TotalFiles       uint32  `json:"totalFiles"`           // Total number of files
+	CompletedFiles   uint32  `json:"completedFiles"`       // Files downloaded so far
+	SpeedBytesPerSec float64 `json:"speedBytesPerSec"`     // Current download speed
+	DedupRatio       float64 `json:"dedupRatio,omitempty"` // Share of the completed bytes not transferred
+	LastUpdated      string  `json:"lastUpdated"`          // RFC3339 timestamp of last update
 }
 
 // Percentage returns the download progress as a percentage (0-100)
@@ -0,0 +1,546 @@
+package modelcar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/utils/download"
+)
+
+// ErrDigestMismatch is returned when a pulled layer doesn't match the digest of its manifest
+var ErrDigestMismatch = errors.New("blob digest mismatch")
+
+// bodyFunc returns a new body of a request and its size, so that the request can be sent again
+type bodyFunc func() (io.ReadCloser, int64, error)
+
+// Client pushes model images to OCI registries and pulls them
+type Client struct {
+	config *Config
+
+	mu sync.Mutex
+	// Authorization headers answering the challenges of the registries, by registry and repository
+	auths map[string]string
+}
+
+// NewClient creates a registry client
+func NewClient(opts ...Option) (*Client, error) {
+	config, err := NewConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{config: config, auths: map[string]string{}}, nil
+}
+
+// Export builds the image of a model directory and pushes it, returning the image pushed
+func (c *Client) Export(ctx context.Context, dir string, ref Reference, annotations map[string]string) (*Image, error) {
+	c.config.Logger.Infof("Building image of %s", dir)
+	image, err := BuildImage(dir, c.config.LayerSizeThreshold, annotations)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Push(ctx, ref, image); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// Push uploads the layers and the config of an image missing from the registry, then its manifest
+func (c *Client) Push(ctx context.Context, ref Reference, image *Image) error {
+	if ref.Digest != "" && ref.Digest != image.Digest() {
+		return fmt.Errorf("image digest %s doesn't match the digest of %s", image.Digest(), ref)
+	}
+
+	progress := Progress{TotalBytes: image.Manifest.TotalSize(), TotalLayers: len(image.Layers)}
+	c.config.Logger.Infof("Pushing %s: %d layers, %d bytes", ref, progress.TotalLayers, progress.TotalBytes)
+
+	for _, layer := range image.Layers {
+		if err := c.uploadBlob(ctx, ref, layer.Descriptor, layerBody(layer)); err != nil {
+			return fmt.Errorf("failed to push layer %s of %s: %w", layer.Digest, ref, err)
+		}
+		progress.CompletedBytes += layer.Size
+		progress.CompletedLayers++
+		c.reportProgress(progress)
+	}
+	if err := c.uploadBlob(ctx, ref, image.Manifest.Config, bytesBody(image.RawConfig)); err != nil {
+		return fmt.Errorf("failed to push config of %s: %w", ref, err)
+	}
+
+	apiCtx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+	resp, err := c.do(apiCtx, ref, http.MethodPut, c.registryURL(ref, "manifests/"+ref.Reference()),
+		http.Header{"Content-Type": {MediaTypeOCIManifest}}, bytesBody(image.RawManifest))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return download.NewHTTPError(resp)
+	}
+	c.config.Logger.Infof("Pushed %s@%s", ref, image.Digest())
+	return nil
+}
+
+// uploadBlob uploads a blob unless the registry already has it, retrying the interrupted uploads
+func (c *Client) uploadBlob(ctx context.Context, ref Reference, blob Descriptor, body bodyFunc) error {
+	exists, err := c.blobExists(ctx, ref, blob)
+	if err != nil {
+		return err
+	}
+	if exists {
+		c.config.Logger.Debugf("Blob %s already exists in %s", blob.Digest, ref)
+		return nil
+	}
+
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			c.config.Logger.Warnf("Retrying upload of %s (attempt %d/%d): %v", blob.Digest, attempt, c.config.MaxRetries, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.config.RetryInterval):
+			}
+		}
+
+		err = c.putBlob(ctx, ref, blob, body)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	return err
+}
+
+func (c *Client) blobExists(ctx context.Context, ref Reference, blob Descriptor) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+	resp, err := c.do(ctx, ref, http.MethodHead, c.registryURL(ref, "blobs/"+blob.Digest), nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, download.NewHTTPError(resp)
+	}
+}
+
+// putBlob uploads a blob monolithically: the upload session is started, then the blob is sent in a single request
+func (c *Client) putBlob(ctx context.Context, ref Reference, blob Descriptor, body bodyFunc) error {
+	startCtx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+	uploadsURL := c.registryURL(ref, "blobs/uploads/")
+	resp, err := c.do(startCtx, ref, http.MethodPost, uploadsURL, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return download.NewHTTPError(resp)
+	}
+
+	base, err := url.Parse(uploadsURL)
+	if err != nil {
+		return err
+	}
+	// The location of the upload session may be relative and already have query parameters
+	location, err := base.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("invalid upload location %q: %w", resp.Header.Get("Location"), err)
+	}
+	query := location.Query()
+	query.Set("digest", blob.Digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, ref, http.MethodPut, location.String(),
+		http.Header{"Content-Type": {"application/octet-stream"}}, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return download.NewHTTPError(resp)
+	}
+	return nil
+}
+
+// Pull extracts the model files of an image to a directory and returns its manifest. Pulling the image the
+// directory already holds does nothing.
+func (c *Client) Pull(ctx context.Context, ref Reference, localDir string) (*Manifest, error) {
+	if localDir == "" {
+		return nil, errors.New("local directory cannot be empty")
+	}
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", localDir, err)
+	}
+
+	manifest, raw, err := c.FetchManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	markerPath := filepath.Join(localDir, ManifestFileName)
+	if existing, err := os.ReadFile(markerPath); err == nil && bytes.Equal(existing, raw) {
+		c.config.Logger.Infof("%s is already pulled to %s", ref, localDir)
+		return manifest, nil
+	}
+	// A pull interrupted after this point leaves no marker, the next one extracts every layer again
+	if err := os.Remove(markerPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	progress := Progress{TotalBytes: manifest.TotalSize(), TotalLayers: len(manifest.Layers)}
+	c.config.Logger.Infof("Pulling %s: %d layers, %d bytes", ref, progress.TotalLayers, progress.TotalBytes)
+
+	for _, layer := range manifest.Layers {
+		if err := c.pullLayer(ctx, ref, layer, localDir); err != nil {
+			return nil, fmt.Errorf("failed to pull layer %s of %s: %w", layer.Digest, ref, err)
+		}
+		progress.CompletedBytes += layer.Size
+		progress.CompletedLayers++
+		c.reportProgress(progress)
+	}
+
+	// The manifest is written last, its presence marks a complete pull
+	if err := download.WriteFile(markerPath, raw); err != nil {
+		return nil, err
+	}
+	c.config.Logger.Infof("Pulled %s to %s", ref, localDir)
+	return manifest, nil
+}
+
+// FetchManifest returns the manifest of an image and its raw content
+func (c *Client) FetchManifest(ctx context.Context, ref Reference) (*Manifest, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+
+	resp, err := c.do(ctx, ref, http.MethodGet, c.registryURL(ref, "manifests/"+ref.Reference()), http.Header{
+		"Accept": {MediaTypeOCIManifest + ", " + MediaTypeDockerManifest},
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil, fmt.Errorf("image %s not found", ref)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, download.NewHTTPError(resp)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read manifest of %s: %w", ref, err)
+	}
+	if ref.Digest != "" && digestOf(raw) != ref.Digest {
+		return nil, nil, fmt.Errorf("%w: manifest of %s has digest %s", ErrDigestMismatch, ref, digestOf(raw))
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse manifest of %s: %w", ref, err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, nil, fmt.Errorf("manifest of %s has no layers, image indexes aren't supported", ref)
+	}
+	for _, layer := range manifest.Layers {
+		if !strings.HasPrefix(layer.Digest, "sha256:") {
+			return nil, nil, fmt.Errorf("unsupported digest %s in manifest of %s", layer.Digest, ref)
+		}
+		switch layer.MediaType {
+		case MediaTypeOCILayer, MediaTypeOCILayerGzip, MediaTypeDockerLayer:
+		default:
+			return nil, nil, fmt.Errorf("unsupported layer media type %s in manifest of %s", layer.MediaType, ref)
+		}
+	}
+	return &manifest, raw, nil
+}
+
+// pullLayer extracts the model files of a layer, retrying the interrupted downloads
+func (c *Client) pullLayer(ctx context.Context, ref Reference, layer Descriptor, localDir string) error {
+	var err error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			c.config.Logger.Warnf("Retrying download of %s (attempt %d/%d): %v", layer.Digest, attempt, c.config.MaxRetries, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.config.RetryInterval):
+			}
+		}
+
+		err = c.extractLayer(ctx, ref, layer, localDir)
+		if err == nil || ctx.Err() != nil || errors.Is(err, ErrDigestMismatch) {
+			break
+		}
+	}
+	return err
+}
+
+// extractLayer streams a layer, extracting its model files as it is downloaded, and verifies its digest
+func (c *Client) extractLayer(ctx context.Context, ref Reference, layer Descriptor, localDir string) error {
+	resp, err := c.do(ctx, ref, http.MethodGet, c.registryURL(ref, "blobs/"+layer.Digest), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return download.NewHTTPError(resp)
+	}
+
+	hasher := sha256.New()
+	stream := io.TeeReader(resp.Body, hasher)
+	var content io.Reader = stream
+	if layer.MediaType != MediaTypeOCILayer {
+		gz, err := gzip.NewReader(stream)
+		if err != nil {
+			return fmt.Errorf("failed to decompress layer: %w", err)
+		}
+		defer gz.Close()
+		content = gz
+	}
+
+	if err := extractModelFiles(tar.NewReader(content), localDir); err != nil {
+		return err
+	}
+	// The padding after the end of the archive is part of the digest
+	if _, err := io.Copy(io.Discard, stream); err != nil {
+		return err
+	}
+	return checkDigest(hasher, layer.Digest)
+}
+
+// extractModelFiles writes the regular files of a tar below ModelDir to a directory, ignoring the other entries
+func extractModelFiles(tr *tar.Reader, localDir string) error {
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read layer: %w", err)
+		}
+
+		name := path.Clean("/" + header.Name)
+		rel, ok := strings.CutPrefix(name, "/"+ModelDir+"/")
+		if !ok || strings.HasPrefix(path.Base(rel), ".wh.") {
+			continue
+		}
+		dest := filepath.Join(localDir, filepath.FromSlash(rel))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return err
+			}
+			if err := download.WriteFromReader(dest, tr); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", rel, err)
+			}
+		}
+	}
+}
+
+// do sends a registry request, answering the authentication challenges of the registries
+func (c *Client) do(ctx context.Context, ref Reference, method, rawURL string, header http.Header, body bodyFunc) (*http.Response, error) {
+	authKey := ref.Registry + "/" + ref.Repository
+	c.mu.Lock()
+	auth := c.auths[authKey]
+	c.mu.Unlock()
+	if auth == "" && c.config.Token != "" {
+		auth = "Bearer " + c.config.Token
+	}
+
+	resp, err := c.send(ctx, method, rawURL, header, body, auth)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	auth, err = c.authenticate(ctx, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate to %s: %w", ref.Registry, err)
+	}
+	c.mu.Lock()
+	c.auths[authKey] = auth
+	c.mu.Unlock()
+	return c.send(ctx, method, rawURL, header, body, auth)
+}
+
+func (c *Client) send(ctx context.Context, method, rawURL string, header http.Header, body bodyFunc, auth string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		if req.Body, req.ContentLength, err = body(); err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			rc, _, err := body()
+			return rc, err
+		}
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	return c.config.HTTPClient.Do(req)
+}
+
+// authenticate returns the authorization header answering a challenge, obtaining a bearer token from the token
+// service of the registry with the configured credentials for the bearer challenges
+func (c *Client) authenticate(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	if strings.EqualFold(scheme, "Basic") {
+		if c.config.Username == "" {
+			return "", errors.New("registry requires a username and password")
+		}
+		return basicAuth(c.config.Username, c.config.Password), nil
+	}
+	if !strings.EqualFold(scheme, "Bearer") || params["realm"] == "" {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", params["realm"], err)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	var auth string
+	switch {
+	case c.config.Username != "":
+		auth = basicAuth(c.config.Username, c.config.Password)
+	case c.config.Token != "":
+		auth = "Bearer " + c.config.Token
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+	resp, err := c.send(ctx, http.MethodGet, tokenURL.String(), nil, nil, auth)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", download.NewHTTPError(resp)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if body.Token != "" {
+		return "Bearer " + body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return "Bearer " + body.AccessToken, nil
+	}
+	return "", errors.New("token response has no token")
+}
+
+func (c *Client) registryURL(ref Reference, path string) string {
+	scheme := "https"
+	if c.config.PlainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Registry, ref.Repository, path)
+}
+
+func (c *Client) reportProgress(progress Progress) {
+	if c.config.OnProgress != nil {
+		c.config.OnProgress(progress)
+	}
+}
+
+// layerBody streams the tar of a layer as it is written from the model files
+func layerBody(layer *Layer) bodyFunc {
+	return func() (io.ReadCloser, int64, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			_, err := layer.WriteTo(pw)
+			pw.CloseWithError(err)
+		}()
+		return pr, layer.Size, nil
+	}
+}
+
+func bytesBody(data []byte) bodyFunc {
+	return func() (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+	}
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g. `Bearer realm="https://auth",service="registry"`
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = strings.TrimPrefix(strings.TrimSpace(value[end+2:]), ",")
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(value)
+		}
+	}
+	return scheme, params
+}
+
+func checkDigest(hasher hash.Hash, digest string) error {
+	actual := "sha256:" + hex.EncodeToString(hasher.Sum(nil))
+	if actual != digest {
+		return fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, digest, actual)
+	}
+	return nil
+}
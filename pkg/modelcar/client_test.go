@@ -0,0 +1,281 @@
+package modelcar
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry stores the blobs and manifests pushed to it
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+	username  string
+	password  string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.username != "" {
+		if username, password, ok := r.BasicAuth(); !ok || username != f.username || password != f.password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	const prefix = "/v2/acme/llama/"
+	resource := strings.TrimPrefix(r.URL.Path, prefix)
+	switch {
+	case r.Method == http.MethodPost && resource == "blobs/uploads/":
+		w.Header().Set("Location", "/v2/acme/llama/blobs/uploads/session?state=abc")
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPut && resource == "blobs/uploads/session":
+		data, _ := io.ReadAll(r.Body)
+		if r.URL.Query().Get("state") != "abc" || digestOf(data) != r.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.blobs[digestOf(data)] = data
+		f.uploads++
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(resource, "blobs/"):
+		blob, ok := f.blobs[strings.TrimPrefix(resource, "blobs/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(blob)
+	case r.Method == http.MethodPut && strings.HasPrefix(resource, "manifests/"):
+		data, _ := io.ReadAll(r.Body)
+		f.manifests[strings.TrimPrefix(resource, "manifests/")] = data
+		f.manifests[digestOf(data)] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(resource, "manifests/"):
+		manifest, ok := f.manifests[strings.TrimPrefix(resource, "manifests/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", MediaTypeOCIManifest)
+		_, _ = w.Write(manifest)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	opts = append([]Option{WithPlainHTTP(true), WithRetryConfig(1, time.Millisecond)}, opts...)
+	client, err := NewClient(opts...)
+	require.NoError(t, err)
+	return client
+}
+
+func testReference(server *httptest.Server) Reference {
+	return Reference{Registry: strings.TrimPrefix(server.URL, "http://"), Repository: "acme/llama", Tag: "v1"}
+}
+
+func writeModel(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.json":                      `{"model_type":"llama"}`,
+		"tokenizer.json":                   `{}`,
+		"model-00001-of-00002.safetensors": strings.Repeat("a", 4096),
+		"model-00002-of-00002.safetensors": strings.Repeat("b", 2048),
+		"original/params.json":             `{"dim":2048}`,
+		".cache/huggingface/download":      "ignored",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestBuildImage(t *testing.T) {
+	dir := writeModel(t)
+	image, err := BuildImage(dir, 1024, map[string]string{"org.opencontainers.image.source": "hf://meta-llama/Llama-3.2-1B"})
+	require.NoError(t, err)
+
+	// The small files share the first layer, each shard gets its own
+	require.Len(t, image.Layers, 3)
+	assert.Equal(t, []string{"config.json", "original/params.json", "tokenizer.json"}, image.Layers[0].files)
+	assert.Equal(t, "model-00001-of-00002.safetensors", image.Manifest.Layers[1].Annotations[AnnotationTitle])
+	assert.Equal(t, "model-00002-of-00002.safetensors", image.Manifest.Layers[2].Annotations[AnnotationTitle])
+
+	var buf bytes.Buffer
+	_, err = image.Layers[0].WriteTo(&buf)
+	require.NoError(t, err)
+	var names []string
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+	assert.Equal(t, []string{"models/", "models/config.json", "models/original/", "models/original/params.json", "models/tokenizer.json"}, names)
+
+	// Building the image again gives the same digest
+	again, err := BuildImage(dir, 1024, map[string]string{"org.opencontainers.image.source": "hf://meta-llama/Llama-3.2-1B"})
+	require.NoError(t, err)
+	assert.Equal(t, image.Digest(), again.Digest())
+
+	_, err = BuildImage(t.TempDir(), 1024, nil)
+	assert.ErrorContains(t, err, "has no files")
+}
+
+func TestExportAndPull(t *testing.T) {
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	var updates []Progress
+	client := newTestClient(t, WithLayerSizeThreshold(1024), WithProgress(func(p Progress) { updates = append(updates, p) }))
+	source := writeModel(t)
+	image, err := client.Export(context.Background(), source, testReference(server), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, registry.uploads)
+	require.Len(t, updates, 3)
+	assert.Equal(t, image.Manifest.TotalSize(), updates[2].CompletedBytes)
+
+	// Pushing the image again only pushes its manifest
+	_, err = client.Export(context.Background(), source, testReference(server), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, registry.uploads)
+
+	dest := t.TempDir()
+	ref := testReference(server)
+	ref.Digest = image.Digest()
+	manifest, err := newTestClient(t).Pull(context.Background(), ref, dest)
+	require.NoError(t, err)
+	assert.Len(t, manifest.Layers, 3)
+	for _, name := range []string{"config.json", "tokenizer.json", "model-00001-of-00002.safetensors", "model-00002-of-00002.safetensors", "original/params.json"} {
+		expected, err := os.ReadFile(filepath.Join(source, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		actual, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, name)
+	}
+	assert.NoDirExists(t, filepath.Join(dest, ".cache"))
+	assert.FileExists(t, filepath.Join(dest, ManifestFileName))
+
+	// The model directory of a pulled image exports to the same image
+	again, err := BuildImage(dest, 1024, nil)
+	require.NoError(t, err)
+	assert.Equal(t, image.Digest(), again.Digest())
+}
+
+func TestPullRejectsCorruptedLayer(t *testing.T) {
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	image, err := newTestClient(t).Export(context.Background(), writeModel(t), testReference(server), nil)
+	require.NoError(t, err)
+	registry.blobs[image.Manifest.Layers[0].Digest] = append(registry.blobs[image.Manifest.Layers[0].Digest], 0)
+
+	dest := t.TempDir()
+	_, err = newTestClient(t).Pull(context.Background(), testReference(server), dest)
+	assert.ErrorIs(t, err, ErrDigestMismatch)
+	assert.NoFileExists(t, filepath.Join(dest, ManifestFileName))
+}
+
+func TestPullAnswersBasicChallenge(t *testing.T) {
+	registry := newFakeRegistry()
+	registry.username, registry.password = "robot", "secret"
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	client := newTestClient(t, WithBasicAuth("robot", "secret"))
+	_, err := client.Export(context.Background(), writeModel(t), testReference(server), nil)
+	require.NoError(t, err)
+	_, err = client.Pull(context.Background(), testReference(server), t.TempDir())
+	require.NoError(t, err)
+
+	_, _, err = newTestClient(t).FetchManifest(context.Background(), testReference(server))
+	assert.ErrorContains(t, err, "requires a username and password")
+}
+
+func TestExtractModelFilesSkipsUnsafeEntries(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, header := range []*tar.Header{
+		{Name: "models/../../escape.txt", Typeflag: tar.TypeReg, Size: 1},
+		{Name: "etc/passwd", Typeflag: tar.TypeReg, Size: 1},
+		{Name: "models/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+		{Name: "models/.wh.old.bin", Typeflag: tar.TypeReg, Size: 1},
+		{Name: "./models/config.json", Typeflag: tar.TypeReg, Size: 1},
+	} {
+		require.NoError(t, tw.WriteHeader(header))
+		if header.Size > 0 {
+			_, err := tw.Write([]byte("x"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	root := t.TempDir()
+	dest := filepath.Join(root, "model")
+	require.NoError(t, extractModelFiles(tar.NewReader(&buf), dest))
+	entries, err := os.ReadDir(dest)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "config.json", entries[0].Name())
+	assert.NoFileExists(t, filepath.Join(root, "escape.txt"))
+}
+
+func TestDockerConfigCredentials(t *testing.T) {
+	config, err := json.Marshal(map[string]any{"auths": map[string]any{
+		"https://ghcr.io":      map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte("robot:secret"))},
+		"registry.example.com": map[string]string{"username": "user", "password": "pass"},
+	}})
+	require.NoError(t, err)
+
+	for registry, expected := range map[string][2]string{
+		"ghcr.io":              {"robot", "secret"},
+		"registry.example.com": {"user", "pass"},
+		"docker.io":            {"", ""},
+	} {
+		username, password, err := DockerConfigCredentials(config, registry)
+		require.NoError(t, err)
+		assert.Equal(t, expected, [2]string{username, password}, fmt.Sprint(registry))
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("modelcar://ghcr.io/acme/llama:v1")
+	require.NoError(t, err)
+	assert.Equal(t, Reference{Registry: "ghcr.io", Repository: "acme/llama", Tag: "v1"}, ref)
+	assert.Equal(t, "v1", ref.Reference())
+
+	ref, err = ParseReference("ghcr.io/acme/llama@sha256:" + strings.Repeat("0", 64))
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:"+strings.Repeat("0", 64), ref.Reference())
+	assert.Equal(t, "ghcr.io/acme/llama@sha256:"+strings.Repeat("0", 64), ref.String())
+}
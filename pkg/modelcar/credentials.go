@@ -0,0 +1,54 @@
+package modelcar
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// dockerConfig is the content of a kubernetes.io/dockerconfigjson secret or of a docker config.json
+type dockerConfig struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	} `json:"auths"`
+}
+
+// DockerConfigCredentials returns the username and password of a registry in a docker config, e.g. the
+// .dockerconfigjson of an image pull secret. It returns empty credentials when the config has none for the registry.
+func DockerConfigCredentials(data []byte, registry string) (string, string, error) {
+	var config dockerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", "", fmt.Errorf("invalid docker config: %w", err)
+	}
+	for server, auth := range config.Auths {
+		if normalizeRegistry(server) != normalizeRegistry(registry) {
+			continue
+		}
+		if auth.Username != "" {
+			return auth.Username, auth.Password, nil
+		}
+		if auth.Auth == "" {
+			return "", "", nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid auth of registry %s: %w", server, err)
+		}
+		username, password, found := strings.Cut(string(decoded), ":")
+		if !found {
+			return "", "", fmt.Errorf("invalid auth of registry %s: expected username:password", server)
+		}
+		return username, password, nil
+	}
+	return "", "", nil
+}
+
+// normalizeRegistry strips the scheme and path of the server of a docker config, e.g. https://index.docker.io/v1/
+func normalizeRegistry(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server, _, _ = strings.Cut(server, "/")
+	return strings.ToLower(server)
+}
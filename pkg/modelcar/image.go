@@ -0,0 +1,291 @@
+package modelcar
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Media types of the manifests, configs and layers of model images
+const (
+	MediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeOCIConfig      = "application/vnd.oci.image.config.v1+json"
+	MediaTypeOCILayer       = "application/vnd.oci.image.layer.v1.tar"
+	MediaTypeOCILayerGzip   = "application/vnd.oci.image.layer.v1.tar+gzip"
+	MediaTypeDockerLayer    = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+
+	// AnnotationTitle names the model file of a layer holding a single one
+	AnnotationTitle = "org.opencontainers.image.title"
+)
+
+const (
+	// ModelDir is the directory of the image holding the model files, as in the modelcars of KServe, so that the
+	// images can also run as modelcar sidecars or be mounted as image volumes
+	ModelDir = "models"
+	// ManifestFileName is the file the manifest of a pulled image is written to, its presence marks a complete pull
+	ManifestFileName = ".modelcar.json"
+)
+
+// layerModTime is the modification time of the files of the layers, fixed so that exporting the same model twice
+// builds the same image
+var layerModTime = time.Unix(0, 0).UTC()
+
+// Descriptor references a blob of a manifest
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest lists the blobs of a model image
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// TotalSize returns the size of the layers of the manifest
+func (m *Manifest) TotalSize() int64 {
+	var total int64
+	for _, layer := range m.Layers {
+		total += layer.Size
+	}
+	return total
+}
+
+// imageConfig is the OCI image config of a model image. The model files don't depend on the platform.
+type imageConfig struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	RootFS       struct {
+		Type    string   `json:"type"`
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+// Image is a model image built from a model directory, whose layers are written from the model files on demand
+type Image struct {
+	Manifest    Manifest
+	RawManifest []byte
+	RawConfig   []byte
+	Layers      []*Layer
+}
+
+// Digest returns the digest of the manifest of the image
+func (i *Image) Digest() string {
+	return digestOf(i.RawManifest)
+}
+
+// Layer is an uncompressed tar layer holding model files below ModelDir
+type Layer struct {
+	Descriptor
+	root  string
+	files []string
+}
+
+// modelFile is a file of the model directory, by path relative to it
+type modelFile struct {
+	path string
+	size int64
+}
+
+// BuildImage builds the image of a model directory. The files smaller than the threshold share the first layer and
+// every other file gets a layer of its own, e.g. a safetensors shard. Building it reads every file to compute the
+// digests of the layers.
+func BuildImage(dir string, threshold int64, annotations map[string]string) (*Image, error) {
+	if threshold <= 0 {
+		threshold = DefaultLayerSizeThreshold
+	}
+	files, err := listModelFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("model directory %s has no files", dir)
+	}
+
+	var small []string
+	var layers []*Layer
+	for _, file := range files {
+		if file.size < threshold {
+			small = append(small, file.path)
+			continue
+		}
+		layers = append(layers, &Layer{
+			root:       dir,
+			files:      []string{file.path},
+			Descriptor: Descriptor{Annotations: map[string]string{AnnotationTitle: file.path}},
+		})
+	}
+	if len(small) > 0 {
+		layers = append([]*Layer{{root: dir, files: small}}, layers...)
+	}
+
+	config := imageConfig{Architecture: "amd64", OS: "linux"}
+	config.RootFS.Type = "layers"
+	for _, layer := range layers {
+		hasher := sha256.New()
+		size, err := layer.WriteTo(hasher)
+		if err != nil {
+			return nil, err
+		}
+		layer.MediaType = MediaTypeOCILayer
+		layer.Digest = "sha256:" + hex.EncodeToString(hasher.Sum(nil))
+		layer.Size = size
+		// The layers are uncompressed, their digests are their diff IDs
+		config.RootFS.DiffIDs = append(config.RootFS.DiffIDs, layer.Digest)
+	}
+
+	rawConfig, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal image config: %w", err)
+	}
+	image := &Image{
+		RawConfig: rawConfig,
+		Layers:    layers,
+		Manifest: Manifest{
+			SchemaVersion: 2,
+			MediaType:     MediaTypeOCIManifest,
+			Config:        Descriptor{MediaType: MediaTypeOCIConfig, Digest: digestOf(rawConfig), Size: int64(len(rawConfig))},
+			Annotations:   annotations,
+		},
+	}
+	for _, layer := range layers {
+		image.Manifest.Layers = append(image.Manifest.Layers, layer.Descriptor)
+	}
+	if image.RawManifest, err = json.Marshal(image.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to marshal image manifest: %w", err)
+	}
+	return image, nil
+}
+
+// WriteTo writes the tar of the layer, the same bytes every time, and returns its size
+func (l *Layer) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	tw := tar.NewWriter(counter)
+
+	dirs := map[string]bool{}
+	writeDir := func(name string) error {
+		if dirs[name] {
+			return nil
+		}
+		dirs[name] = true
+		return tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     name + "/",
+			Mode:     0755,
+			ModTime:  layerModTime,
+			Format:   tar.FormatPAX,
+		})
+	}
+
+	for _, file := range l.files {
+		name := path.Join(ModelDir, file)
+		// The parent directories come first, for the tools extracting the layer in order
+		parents := strings.Split(path.Dir(name), "/")
+		for i := range parents {
+			if err := writeDir(strings.Join(parents[:i+1], "/")); err != nil {
+				return counter.n, err
+			}
+		}
+		if err := writeFile(tw, filepath.Join(l.root, filepath.FromSlash(file)), name); err != nil {
+			return counter.n, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return counter.n, err
+	}
+	return counter.n, nil
+}
+
+func writeFile(tw *tar.Writer, source, name string) error {
+	f, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     info.Size(),
+		Mode:     0644,
+		ModTime:  layerModTime,
+		Format:   tar.FormatPAX,
+	}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	return nil
+}
+
+// listModelFiles lists the regular files of a model directory sorted by path, skipping the caches of the downloads
+// and the manifest of a pulled image
+func listModelFiles(dir string) ([]modelFile, error) {
+	var files []modelFile
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == ".cache" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || rel == ManifestFileName {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, modelFile{path: rel, size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list model directory %s: %w", dir, err)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,52 @@
+package modelcar
+
+import (
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+// Reference identifies a model image of a registry
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses a modelcar:// storage URI, or an image reference without the scheme, e.g.
+// ghcr.io/acme/llama-3.2-1b:v1, into a model image reference
+func ParseReference(uri string) (Reference, error) {
+	if !strings.HasPrefix(uri, storage.ModelCarStoragePrefix) {
+		uri = storage.ModelCarStoragePrefix + uri
+	}
+	components, err := storage.ParseModelCarStorageURI(uri)
+	if err != nil {
+		return Reference{}, err
+	}
+	return Reference{
+		Registry:   components.Registry,
+		Repository: components.Repository,
+		Tag:        components.Tag,
+		Digest:     components.Digest,
+	}, nil
+}
+
+// Reference returns the tag or digest of the manifest in the registry API, the digest if both are set
+func (r Reference) Reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
@@ -0,0 +1,364 @@
+package modelscope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+	"github.com/sgl-project/ome/pkg/utils/download"
+)
+
+// ErrChecksumMismatch is returned when a downloaded file doesn't match the SHA256 listed by the hub
+var ErrChecksumMismatch = errors.New("file checksum mismatch")
+
+// FileInfo is a file of a model repository
+type FileInfo struct {
+	Name     string `json:"Name"`
+	Path     string `json:"Path"`
+	Type     string `json:"Type"`
+	Size     int64  `json:"Size"`
+	Sha256   string `json:"Sha256"`
+	Revision string `json:"Revision"`
+	IsLFS    bool   `json:"IsLFS"`
+}
+
+// IsDir returns whether the entry is a directory of the repository
+func (f FileInfo) IsDir() bool {
+	return f.Type == "tree"
+}
+
+// apiResponse is the envelope of the responses of the ModelScope API
+type apiResponse struct {
+	Code    int             `json:"Code"`
+	Message string          `json:"Message"`
+	Success bool            `json:"Success"`
+	Data    json.RawMessage `json:"Data"`
+}
+
+// SnapshotRequest selects the files of a model downloaded by a snapshot
+type SnapshotRequest struct {
+	ModelID  string
+	Revision string
+	LocalDir string
+	// AllowPatterns and IgnorePatterns filter the files like the patterns of Hugging Face snapshots
+	AllowPatterns  []string
+	IgnorePatterns []string
+}
+
+// Client downloads models from the ModelScope hub
+type Client struct {
+	config *Config
+}
+
+// NewClient creates a ModelScope client
+func NewClient(opts ...Option) (*Client, error) {
+	config, err := NewConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{config: config}, nil
+}
+
+// ListFiles lists the files of a model at a revision, recursively
+func (c *Client) ListFiles(ctx context.Context, modelID, revision string) ([]FileInfo, error) {
+	if revision == "" {
+		revision = DefaultRevision
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+
+	query := url.Values{"Revision": {revision}, "Recursive": {"True"}}
+	resp, err := c.get(ctx, c.modelURL(modelID, "repo/files", query), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("model %s not found at revision %s", modelID, revision)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, download.NewHTTPError(resp)
+	}
+
+	var envelope apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse file list of %s: %w", modelID, err)
+	}
+	if !envelope.Success {
+		return nil, fmt.Errorf("failed to list files of %s at revision %s: %s", modelID, revision, envelope.Message)
+	}
+	var data struct {
+		Files []FileInfo `json:"Files"`
+	}
+	if err := json.Unmarshal(envelope.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse file list of %s: %w", modelID, err)
+	}
+
+	files := make([]FileInfo, 0, len(data.Files))
+	for _, file := range data.Files {
+		if !file.IsDir() {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// DownloadFile downloads a file of a model to a directory and returns its local path
+func (c *Client) DownloadFile(ctx context.Context, modelID, revision, filePath, localDir string) (string, error) {
+	files, err := c.ListFiles(ctx, modelID, revision)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		if file.Path == filePath {
+			return c.downloadFile(ctx, modelID, revision, file, localDir)
+		}
+	}
+	return "", fmt.Errorf("file %s not found in model %s", filePath, modelID)
+}
+
+// SnapshotDownload downloads the files of a model to a directory and returns the directory. The files already
+// downloaded are kept, the interrupted ones are resumed.
+func (c *Client) SnapshotDownload(ctx context.Context, req SnapshotRequest) (string, error) {
+	if req.ModelID == "" {
+		return "", errors.New("model ID cannot be empty")
+	}
+	if req.LocalDir == "" {
+		return "", errors.New("local directory cannot be empty")
+	}
+	if req.Revision == "" {
+		req.Revision = DefaultRevision
+	}
+
+	files, err := c.ListFiles(ctx, req.ModelID, req.Revision)
+	if err != nil {
+		return "", err
+	}
+	selected := files[:0]
+	for _, file := range files {
+		if len(req.AllowPatterns) > 0 && !hub.MatchesPattern(file.Path, req.AllowPatterns) {
+			continue
+		}
+		if hub.MatchesPattern(file.Path, req.IgnorePatterns) {
+			continue
+		}
+		selected = append(selected, file)
+	}
+
+	progress := Progress{TotalFiles: len(selected)}
+	for _, file := range selected {
+		progress.TotalBytes += file.Size
+	}
+	c.config.Logger.Infof("Downloading %s@%s: %d files, %d bytes", req.ModelID, req.Revision, progress.TotalFiles, progress.TotalBytes)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	workers := make(chan struct{}, c.config.MaxWorkers)
+	for _, file := range selected {
+		wg.Add(1)
+		go func(file FileInfo) {
+			defer wg.Done()
+			select {
+			case workers <- struct{}{}:
+				defer func() { <-workers }()
+			case <-ctx.Done():
+				return
+			}
+
+			_, err := c.downloadFile(ctx, req.ModelID, req.Revision, file, req.LocalDir)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to download %s of %s: %w", file.Path, req.ModelID, err)
+					cancel()
+				}
+				return
+			}
+			progress.CompletedFiles++
+			progress.CompletedBytes += file.Size
+			progress.CurrentFile = file.Path
+			c.reportProgress(progress)
+		}(file)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	c.config.Logger.Infof("Downloaded %s@%s to %s", req.ModelID, req.Revision, req.LocalDir)
+	return req.LocalDir, nil
+}
+
+// downloadFile downloads a file unless the local directory already holds it, resuming the interrupted downloads
+func (c *Client) downloadFile(ctx context.Context, modelID, revision string, file FileInfo, localDir string) (string, error) {
+	dest := filepath.Join(localDir, filepath.FromSlash(file.Path))
+	// The path comes from the hub, it must not escape the model directory
+	if rel, err := filepath.Rel(localDir, dest); err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("invalid file path %s", file.Path)
+	}
+	if isComplete(dest, file) {
+		c.config.Logger.Debugf("%s is up to date", dest)
+		return dest, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", err
+	}
+
+	partial := dest + download.PartialSuffix
+	var err error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			c.config.Logger.Warnf("Resuming download of %s (attempt %d/%d): %v", file.Path, attempt, c.config.MaxRetries, err)
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(c.config.RetryInterval):
+			}
+		}
+
+		err = c.fetchFile(ctx, modelID, revision, file, partial)
+		if err == nil || ctx.Err() != nil || errors.Is(err, ErrChecksumMismatch) {
+			break
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return dest, os.Rename(partial, dest)
+}
+
+// fetchFile appends the missing bytes of a file to a partial file and verifies its checksum
+func (c *Client) fetchFile(ctx context.Context, modelID, revision string, file FileInfo, partial string) error {
+	out, err := os.OpenFile(partial, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	hasher := sha256.New()
+	offset, err := io.Copy(hasher, out)
+	if err != nil {
+		return err
+	}
+	if offset > file.Size {
+		offset = 0
+		hasher.Reset()
+		if err := out.Truncate(0); err != nil {
+			return err
+		}
+	}
+
+	if offset < file.Size || file.Size == 0 {
+		header := http.Header{}
+		if offset > 0 {
+			header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+		query := url.Values{"Revision": {revision}, "FilePath": {file.Path}}
+		resp, err := c.get(ctx, c.modelURL(modelID, "repo", query), header)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusPartialContent:
+		case http.StatusOK:
+			// The hub ignored the range, the file is downloaded again
+			if offset > 0 {
+				hasher.Reset()
+				if err := out.Truncate(0); err != nil {
+					return err
+				}
+				offset = 0
+			}
+		default:
+			return download.NewHTTPError(resp)
+		}
+
+		if _, err := out.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.Copy(io.MultiWriter(out, hasher), resp.Body); err != nil {
+			return err
+		}
+	}
+
+	// The hub only lists the SHA256 of the LFS files
+	if file.Sha256 != "" {
+		if actual := hex.EncodeToString(hasher.Sum(nil)); !strings.EqualFold(actual, file.Sha256) {
+			_ = os.Remove(partial)
+			return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, file.Path, file.Sha256, actual)
+		}
+	}
+	return out.Sync()
+}
+
+func (c *Client) get(ctx context.Context, rawURL string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+	return c.config.HTTPClient.Do(req)
+}
+
+func (c *Client) modelURL(modelID, path string, query url.Values) string {
+	return fmt.Sprintf("%s/api/v1/models/%s/%s?%s", c.config.Endpoint, modelID, path, query.Encode())
+}
+
+func (c *Client) reportProgress(progress Progress) {
+	if c.config.OnProgress != nil {
+		c.config.OnProgress(progress)
+	}
+}
+
+// isComplete returns whether a local file holds a file of the repository
+func isComplete(path string, file FileInfo) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() != file.Size {
+		return false
+	}
+	if file.Sha256 == "" {
+		return true
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return false
+	}
+	return strings.EqualFold(hex.EncodeToString(hasher.Sum(nil)), file.Sha256)
+}
@@ -0,0 +1,254 @@
+package modelscope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/utils/download"
+)
+
+func sha256Of(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// fakeHub serves the files of Qwen/Qwen2.5-0.5B, with the SHA256 of the LFS ones
+type fakeHub struct {
+	files     map[string][]byte
+	lfs       map[string]bool
+	token     string
+	rangeReqs atomic.Int32
+}
+
+func (f *fakeHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/v1/models/Qwen/Qwen2.5-0.5B/repo/files":
+		if r.URL.Query().Get("Revision") != "master" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"Code": 404, "Success": false, "Message": "revision not found"})
+			return
+		}
+		listing := []FileInfo{{Name: "onnx", Path: "onnx", Type: "tree"}}
+		for path, content := range f.files {
+			file := FileInfo{Name: filepath.Base(path), Path: path, Type: "blob", Size: int64(len(content))}
+			if f.lfs[path] {
+				file.IsLFS = true
+				file.Sha256 = sha256Of(content)
+			}
+			listing = append(listing, file)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Code":    200,
+			"Success": true,
+			"Data":    map[string]any{"Files": listing},
+		})
+	case "/api/v1/models/Qwen/Qwen2.5-0.5B/repo":
+		content, ok := f.files[r.URL.Query().Get("FilePath")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			f.rangeReqs.Add(1)
+			start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			if err != nil || start > len(content) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(content[start:])
+			return
+		}
+		_, _ = w.Write(content)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFakeHub() *fakeHub {
+	return &fakeHub{
+		files: map[string][]byte{
+			"config.json":       []byte(`{"model_type":"qwen2"}`),
+			"model.safetensors": []byte(strings.Repeat("weights", 1024)),
+			"onnx/model.onnx":   []byte("onnx"),
+		},
+		lfs: map[string]bool{"model.safetensors": true},
+	}
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, opts ...Option) *Client {
+	opts = append([]Option{WithEndpoint(server.URL), WithRetryConfig(1, time.Millisecond)}, opts...)
+	client, err := NewClient(opts...)
+	require.NoError(t, err)
+	return client
+}
+
+func TestListFiles(t *testing.T) {
+	server := httptest.NewServer(newFakeHub())
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	files, err := client.ListFiles(context.Background(), "Qwen/Qwen2.5-0.5B", "")
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+	for _, file := range files {
+		assert.False(t, file.IsDir())
+	}
+
+	_, err = client.ListFiles(context.Background(), "Qwen/Qwen2.5-0.5B", "v9")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revision not found")
+
+	_, err = client.ListFiles(context.Background(), "Qwen/missing", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestSnapshotDownload(t *testing.T) {
+	hub := newFakeHub()
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var last Progress
+	client := newTestClient(t, server, WithProgress(func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		last = p
+	}))
+	dir := t.TempDir()
+
+	path, err := client.SnapshotDownload(context.Background(), SnapshotRequest{
+		ModelID:        "Qwen/Qwen2.5-0.5B",
+		LocalDir:       dir,
+		IgnorePatterns: []string{"onnx/"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dir, path)
+
+	for _, name := range []string{"config.json", "model.safetensors"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, hub.files[name], content)
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "onnx", "model.onnx"))
+	assert.Equal(t, 2, last.CompletedFiles)
+	assert.Equal(t, last.TotalBytes, last.CompletedBytes)
+}
+
+func TestSnapshotDownloadFilePaths(t *testing.T) {
+	hub := newFakeHub()
+	hub.files = map[string][]byte{"..config.json": []byte(`{}`)}
+	server := httptest.NewServer(hub)
+	defer server.Close()
+	client := newTestClient(t, server)
+	dir := t.TempDir()
+
+	// A name starting with dots stays in the model directory
+	_, err := client.SnapshotDownload(context.Background(), SnapshotRequest{ModelID: "Qwen/Qwen2.5-0.5B", LocalDir: dir})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "..config.json"))
+
+	hub.files = map[string][]byte{"../escaped.json": []byte(`{}`)}
+	_, err = client.SnapshotDownload(context.Background(), SnapshotRequest{ModelID: "Qwen/Qwen2.5-0.5B", LocalDir: dir})
+	require.ErrorContains(t, err, "invalid file path ../escaped.json")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "escaped.json"))
+}
+
+func TestSnapshotDownloadResumesPartialFile(t *testing.T) {
+	hub := newFakeHub()
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	dir := t.TempDir()
+	weights := hub.files["model.safetensors"]
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.safetensors"+download.PartialSuffix), weights[:100], 0644))
+
+	_, err := newTestClient(t, server).SnapshotDownload(context.Background(), SnapshotRequest{
+		ModelID:       "Qwen/Qwen2.5-0.5B",
+		LocalDir:      dir,
+		AllowPatterns: []string{"*.safetensors"},
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+	require.NoError(t, err)
+	assert.Equal(t, weights, content)
+	assert.Equal(t, int32(1), hub.rangeReqs.Load())
+	assert.NoFileExists(t, filepath.Join(dir, "config.json"))
+}
+
+func TestSnapshotDownloadRejectsCorruptedFile(t *testing.T) {
+	hub := newFakeHub()
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	dir := t.TempDir()
+	// The partial file doesn't hold the start of the file, the checksum of the resumed download fails
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.safetensors"+download.PartialSuffix), []byte("corrupted"), 0644))
+
+	_, err := newTestClient(t, server).SnapshotDownload(context.Background(), SnapshotRequest{
+		ModelID:       "Qwen/Qwen2.5-0.5B",
+		LocalDir:      dir,
+		AllowPatterns: []string{"model.safetensors"},
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.NoFileExists(t, filepath.Join(dir, "model.safetensors"))
+	assert.NoFileExists(t, filepath.Join(dir, "model.safetensors"+download.PartialSuffix))
+}
+
+func TestDownloadFileWithToken(t *testing.T) {
+	hub := newFakeHub()
+	hub.token = "ms-token"
+	server := httptest.NewServer(hub)
+	defer server.Close()
+	dir := t.TempDir()
+
+	_, err := newTestClient(t, server).DownloadFile(context.Background(), "Qwen/Qwen2.5-0.5B", "", "config.json", dir)
+	require.Error(t, err)
+	var httpErr *download.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+
+	path, err := newTestClient(t, server, WithToken("ms-token")).DownloadFile(context.Background(), "Qwen/Qwen2.5-0.5B", "", "onnx/model.onnx", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "onnx", "model.onnx"), path)
+}
+
+func TestConfigDefaults(t *testing.T) {
+	t.Setenv(EnvDomain, "modelscope.ai")
+	t.Setenv(EnvToken, "env-token")
+
+	config, err := NewConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://modelscope.ai", config.Endpoint)
+	assert.Equal(t, "env-token", config.Token)
+	assert.Equal(t, DefaultMaxWorkers, config.MaxWorkers)
+
+	_, err = NewConfig(WithEndpoint("modelscope.cn"))
+	assert.Error(t, err)
+	_, err = NewConfig(WithMaxWorkers(0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+package modelscope
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+const (
+	// DefaultEndpoint is the ModelScope hub, MODELSCOPE_DOMAIN overrides it like in the modelscope SDK
+	DefaultEndpoint = "https://www.modelscope.cn"
+	// DefaultRevision is the revision of the models when none is requested
+	DefaultRevision = "master"
+	// DefaultRequestTimeout is the timeout of the API requests, file downloads are only bounded by their context
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultMaxRetries is the number of times an interrupted file download is resumed
+	DefaultMaxRetries = 5
+	// DefaultRetryInterval is the wait before resuming an interrupted file download
+	DefaultRetryInterval = 2 * time.Second
+	// DefaultMaxWorkers is the number of files a snapshot downloads in parallel
+	DefaultMaxWorkers = 8
+	// DefaultUserAgent is the user agent of the hub requests
+	DefaultUserAgent = "ome-modelscope-go/1.0.0"
+)
+
+// Environment variables of the modelscope SDK
+const (
+	EnvDomain = "MODELSCOPE_DOMAIN"
+	EnvToken  = "MODELSCOPE_API_TOKEN"
+)
+
+// Progress reports the files downloaded by a snapshot
+type Progress struct {
+	TotalBytes     int64
+	CompletedBytes int64
+	TotalFiles     int
+	CompletedFiles int
+	CurrentFile    string
+}
+
+// Config represents the configuration of the ModelScope client
+type Config struct {
+	Logger         logging.Interface
+	Endpoint       string        `mapstructure:"endpoint"`
+	Token          string        `mapstructure:"token"`
+	UserAgent      string        `mapstructure:"user_agent"`
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	MaxRetries     int           `mapstructure:"max_retries"`
+	RetryInterval  time.Duration `mapstructure:"retry_interval"`
+	MaxWorkers     int           `mapstructure:"max_workers"`
+	HTTPClient     *http.Client
+	// OnProgress is called as the files of a snapshot are downloaded
+	OnProgress func(Progress)
+}
+
+// Option configures the ModelScope client
+type Option func(*Config) error
+
+func defaultConfig() *Config {
+	endpoint := DefaultEndpoint
+	if domain := os.Getenv(EnvDomain); domain != "" {
+		endpoint = domain
+		if !strings.Contains(domain, "://") {
+			endpoint = "https://" + domain
+		}
+	}
+	return &Config{
+		Logger:         logging.Discard(),
+		Endpoint:       endpoint,
+		Token:          os.Getenv(EnvToken),
+		UserAgent:      DefaultUserAgent,
+		RequestTimeout: DefaultRequestTimeout,
+		MaxRetries:     DefaultMaxRetries,
+		RetryInterval:  DefaultRetryInterval,
+		MaxWorkers:     DefaultMaxWorkers,
+	}
+}
+
+// NewConfig creates a configuration from the default one and the options
+func NewConfig(opts ...Option) (*Config, error) {
+	config := defaultConfig()
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, err
+		}
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{}
+	}
+	return config, nil
+}
+
+// WithLogger sets the logger of the client
+func WithLogger(logger logging.Interface) Option {
+	return func(c *Config) error {
+		if logger == nil {
+			return errors.New("invalid logger nil")
+		}
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithEndpoint sets the ModelScope hub, e.g. a private deployment or the international site
+func WithEndpoint(endpoint string) Option {
+	return func(c *Config) error {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("endpoint must be an absolute URL")
+		}
+		c.Endpoint = strings.TrimSuffix(endpoint, "/")
+		return nil
+	}
+}
+
+// WithToken sets the access token of the requests, needed for private and gated models
+func WithToken(token string) Option {
+	return func(c *Config) error {
+		c.Token = token
+		return nil
+	}
+}
+
+// WithUserAgent sets the user agent of the hub requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) error {
+		if userAgent == "" {
+			return errors.New("user agent cannot be empty")
+		}
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithRequestTimeout sets the timeout of the API requests
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Config) error {
+		if timeout <= 0 {
+			return errors.New("request timeout must be positive")
+		}
+		c.RequestTimeout = timeout
+		return nil
+	}
+}
+
+// WithRetryConfig sets how many times and after which wait an interrupted file download is resumed
+func WithRetryConfig(maxRetries int, retryInterval time.Duration) Option {
+	return func(c *Config) error {
+		if maxRetries < 0 {
+			return errors.New("max retries cannot be negative")
+		}
+		if retryInterval < 0 {
+			return errors.New("retry interval cannot be negative")
+		}
+		c.MaxRetries = maxRetries
+		c.RetryInterval = retryInterval
+		return nil
+	}
+}
+
+// WithMaxWorkers sets the number of files a snapshot downloads in parallel
+func WithMaxWorkers(maxWorkers int) Option {
+	return func(c *Config) error {
+		if maxWorkers <= 0 {
+			return errors.New("max workers must be positive")
+		}
+		c.MaxWorkers = maxWorkers
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client of the requests, e.g. to use a proxy or custom CAs
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) error {
+		if client == nil {
+			return errors.New("invalid HTTP client nil")
+		}
+		c.HTTPClient = client
+		return nil
+	}
+}
+
+// WithProgress sets the function called as the files of a snapshot are downloaded
+func WithProgress(handler func(Progress)) Option {
+	return func(c *Config) error {
+		c.OnProgress = handler
+		return nil
+	}
+}
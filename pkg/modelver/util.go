@@ -228,3 +228,77 @@ func parseNumeric(s, field string) (uint64, error) {
 	}
 	return strconv.ParseUint(s, 10, 64)
 }
+
+// Constraint is a version range made of comparisons that must all hold, e.g. ">=0.4.3 <0.5"
+type Constraint struct {
+	comparisons []comparison
+}
+
+type comparison struct {
+	operator string
+	version  Version
+}
+
+// constraintOperators lists the supported operators, two-character operators first so they are matched before their prefixes
+var constraintOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseConstraint parses a version range made of space or comma separated comparisons, e.g. ">=0.4.3 <0.5"
+// or ">= 0.4.3, < 0.5". Supported operators are =, !=, >, >=, < and <=; a bare version means =.
+func ParseConstraint(s string) (Constraint, error) {
+	var c Constraint
+	tokens := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		operator := "="
+		for _, op := range constraintOperators {
+			if strings.HasPrefix(token, op) {
+				operator = op
+				token = strings.TrimPrefix(token, op)
+				break
+			}
+		}
+		// Allow a space between the operator and the version
+		if token == "" {
+			if i+1 == len(tokens) {
+				return Constraint{}, fmt.Errorf("missing version after %q in constraint %q", operator, s)
+			}
+			i++
+			token = tokens[i]
+		}
+		v, err := Parse(token)
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid version %q in constraint %q: %w", token, s, err)
+		}
+		c.comparisons = append(c.comparisons, comparison{operator: operator, version: v})
+	}
+	if len(c.comparisons) == 0 {
+		return Constraint{}, errors.New("version constraint empty")
+	}
+	return c, nil
+}
+
+// Check reports whether a version satisfies every comparison of the constraint
+func (c Constraint) Check(v Version) bool {
+	for _, cmp := range c.comparisons {
+		result := CompareVersion(v, cmp.version)
+		var ok bool
+		switch cmp.operator {
+		case ">=":
+			ok = result >= 0
+		case "<=":
+			ok = result <= 0
+		case ">":
+			ok = result > 0
+		case "<":
+			ok = result < 0
+		case "!=":
+			ok = result != 0
+		default:
+			ok = result == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
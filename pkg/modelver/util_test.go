@@ -140,3 +140,39 @@ func TestVersionComparison(t *testing.T) {
 		})
 	}
 }
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{name: "within range", constraint: ">=0.4.3 <0.5", version: "0.4.6", expected: true},
+		{name: "lower bound inclusive", constraint: ">=0.4.3 <0.5", version: "0.4.3", expected: true},
+		{name: "below range", constraint: ">=0.4.3 <0.5", version: "0.4.2", expected: false},
+		{name: "upper bound exclusive", constraint: ">=0.4.3 <0.5", version: "0.5.0", expected: false},
+		{name: "spaces and commas", constraint: ">= 0.4.3, < 0.5", version: "0.4.9", expected: true},
+		{name: "bare version is exact", constraint: "0.4.6", version: "0.4.6", expected: true},
+		{name: "not equal", constraint: "!=0.4.6", version: "0.4.6", expected: false},
+		{name: "greater than", constraint: ">0.4", version: "0.4.1", expected: true},
+		{name: "less than or equal", constraint: "<=v1.2", version: "v1.2.0", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			assert.NoError(t, err)
+			v, err := Parse(tt.version)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, c.Check(v))
+		})
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, constraint := range []string{"", " , ", ">=", ">=0.4.x", "~0.4"} {
+		_, err := ParseConstraint(constraint)
+		assert.Error(t, err, "constraint %q", constraint)
+	}
+}
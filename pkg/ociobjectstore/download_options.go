@@ -87,6 +87,15 @@ func WithTailOverlap(enabled bool) DownloadOption {
 	}
 }
 
+// WithResume keeps the temporary file of a failed multipart download, so that downloading the object again only
+// downloads the parts that were not written.
+func WithResume(enabled bool) DownloadOption {
+	return func(opts *DownloadOptions) error {
+		opts.Resume = enabled
+		return nil
+	}
+}
+
 // applyDownloadOptions applies a list of functional options to create final DownloadOptions.
 // If no options are provided, it returns the default options.
 func applyDownloadOptions(opts ...DownloadOption) (DownloadOptions, error) {
@@ -0,0 +1,113 @@
+package ociobjectstore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+)
+
+// downloadProgress records the parts of an object written to the temporary file of its multipart download, so that
+// an interrupted download resumes with the parts that were not written. The first line of the progress file
+// identifies the object and the part size, the others are the numbers of the written parts.
+type downloadProgress struct {
+	path string
+	file *os.File
+	// done holds the parts written by a previous download of the same object
+	done map[int]bool
+}
+
+// downloadIdentity identifies a version of an object downloaded in parts of partSize
+func downloadIdentity(summary *objectstorage.ObjectSummary, partSize int) string {
+	md5 := ""
+	if summary.Md5 != nil {
+		md5 = *summary.Md5
+	}
+	return fmt.Sprintf("size=%d part=%d md5=%s", *summary.Size, partSize, md5)
+}
+
+// openDownloadProgress opens the progress file at path. The parts it records are resumed when resume is set and it
+// was written for the same identity, otherwise it is started afresh.
+func openDownloadProgress(path, identity string, resume bool) (*downloadProgress, error) {
+	progress := &downloadProgress{path: path, done: map[int]bool{}}
+	if resume {
+		progress.done = readDownloadProgress(path, identity)
+	}
+	if len(progress.done) > 0 {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		progress.file = file
+		return progress, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintln(file, identity); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	progress.file = file
+	return progress, nil
+}
+
+// readDownloadProgress returns the parts recorded in the progress file at path, none if it is missing or was written
+// for another identity
+func readDownloadProgress(path, identity string) map[int]bool {
+	done := map[int]bool{}
+	file, err := os.Open(path)
+	if err != nil {
+		return done
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() || scanner.Text() != identity {
+		return done
+	}
+	for scanner.Scan() {
+		// A line cut by an interruption is not a written part
+		if partNum, err := strconv.Atoi(scanner.Text()); err == nil {
+			done[partNum] = true
+		}
+	}
+	return done
+}
+
+// record records that a part was written to the temporary file
+func (p *downloadProgress) record(partNum int) error {
+	if _, err := fmt.Fprintln(p.file, partNum); err != nil {
+		return err
+	}
+	return p.file.Sync()
+}
+
+// close closes the progress file, and removes it unless keep is set
+func (p *downloadProgress) close(keep bool) {
+	_ = p.file.Close()
+	if !keep {
+		_ = os.Remove(p.path)
+	}
+}
+
+// skipDoneParts forwards the parts that were not written
+func skipDoneParts(parts chan *PrepareDownloadPart, done map[int]bool) chan *PrepareDownloadPart {
+	if len(done) == 0 {
+		return parts
+	}
+	remaining := make(chan *PrepareDownloadPart)
+	go func() {
+		defer close(remaining)
+		for part := range parts {
+			if !done[part.partNum] {
+				remaining <- part
+			}
+		}
+	}()
+	return remaining
+}
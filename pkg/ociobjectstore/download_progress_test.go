@@ -0,0 +1,51 @@
+package ociobjectstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.safetensors.temp.progress")
+	identity := downloadIdentity(&objectstorage.ObjectSummary{Size: common.Int64(100), Md5: common.String("abc==")}, 10)
+	assert.Equal(t, "size=100 part=10 md5=abc==", identity)
+
+	progress, err := openDownloadProgress(path, identity, true)
+	require.NoError(t, err)
+	assert.Empty(t, progress.done)
+	require.NoError(t, progress.record(0))
+	require.NoError(t, progress.record(3))
+	progress.close(true)
+
+	// The recorded parts are resumed
+	progress, err = openDownloadProgress(path, identity, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{0: true, 3: true}, progress.done)
+	require.NoError(t, progress.record(1))
+	progress.close(true)
+	assert.Equal(t, map[int]bool{0: true, 1: true, 3: true}, readDownloadProgress(path, identity))
+
+	// Another version of the object, or a download that doesn't resume, starts afresh
+	assert.Empty(t, readDownloadProgress(path, "size=100 part=10 md5=def=="))
+	progress, err = openDownloadProgress(path, identity, false)
+	require.NoError(t, err)
+	assert.Empty(t, progress.done)
+	progress.close(false)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSkipDoneParts(t *testing.T) {
+	source := ObjectURI{Namespace: "ns", BucketName: "bucket", ObjectName: "model"}
+	var partNums []int
+	for part := range skipDoneParts(splitToParts(4, 10, 40, source), map[int]bool{1: true, 2: true}) {
+		partNums = append(partNums, part.partNum)
+	}
+	assert.Equal(t, []int{0, 3}, partNums)
+}
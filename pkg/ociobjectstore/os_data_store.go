@@ -49,6 +49,8 @@ type DownloadOptions struct {
 	StripPrefix     bool   // If true, remove a specified prefix from the object path
 	PrefixToStrip   string // The prefix to strip when StripPrefix is true
 	UseBaseNameOnly bool   // If true, download using only the object's base name
+
+	Resume bool // If true, an interrupted multipart download resumes from the parts it already wrote
 }
 
 const (
@@ -13,6 +13,8 @@ import (
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+
+	"github.com/sgl-project/ome/pkg/logging"
 )
 
 type ChunkUnit int
@@ -20,6 +22,8 @@ type ChunkUnit int
 const (
 	MB             ChunkUnit = 1000000
 	maxPartRetries int       = 3
+	// partLogInterval is the interval between the entries logged for the parts of a transfer
+	partLogInterval = 10 * time.Second
 )
 
 // PrepareDownloadPart holds just the info needed to construct a GetObjectRequest at download time
@@ -110,9 +114,6 @@ func (cds *OCIOSDataStore) MultipartDownload(source ObjectURI, target string, op
 		totalParts++
 	}
 
-	prepareDownloadParts := splitToParts(totalParts, partSize, objectSize, source)
-	downloadedParts := cds.multipartDownload(context.Background(), threads, prepareDownloadParts)
-
 	targetFilePath := ComputeTargetFilePath(source, target, &downloadOpts)
 	tempTargetFilePath := targetFilePath + ".temp"
 
@@ -122,14 +123,41 @@ func (cds *OCIOSDataStore) MultipartDownload(source ObjectURI, target string, op
 		return fmt.Errorf("failed to create target directory %s: %v", targetDir, err)
 	}
 
-	// Clean up any existing temporary file
-	os.Remove(tempTargetFilePath)
+	// Resume the parts written to the temporary file by an interrupted download of the object
+	progress, err := openDownloadProgress(tempTargetFilePath+".progress", downloadIdentity(objectSummary, partSize), downloadOpts.Resume)
+	if err != nil {
+		return fmt.Errorf("failed to open the download progress: %v", err)
+	}
+	var tmpFile *os.File
+	if _, statErr := os.Stat(tempTargetFilePath); len(progress.done) > 0 && statErr == nil {
+		cds.logger.Infof("[%s] Resuming multipart download, %d of %d parts already downloaded",
+			source.ObjectName, len(progress.done), totalParts)
+		tmpFile, err = os.OpenFile(tempTargetFilePath, os.O_RDWR, 0644)
+	} else {
+		progress.done = map[int]bool{}
+		// Clean up any existing temporary file
+		os.Remove(tempTargetFilePath)
 
-	// Create a new temporary file
-	tmpFile, err := os.Create(tempTargetFilePath)
+		// Create a new temporary file
+		tmpFile, err = os.Create(tempTargetFilePath)
+	}
 	if err != nil {
+		progress.close(false)
 		return err
 	}
+	// The temporary file and the progress are kept for the next download when resuming
+	downloadFailed := func() {
+		progress.close(downloadOpts.Resume)
+		if downloadOpts.Resume {
+			return
+		}
+		if err := os.Remove(tempTargetFilePath); err != nil {
+			cds.logger.Warnf("[%s] Failed to clean up temporary file after error: %v", source.ObjectName, err)
+		}
+	}
+
+	prepareDownloadParts := skipDoneParts(splitToParts(totalParts, partSize, objectSize, source), progress.done)
+	downloadedParts := cds.multipartDownload(context.Background(), threads, prepareDownloadParts)
 
 	// Use a file closure flag to avoid double-closing the file
 	fileClosed := false
@@ -146,10 +174,7 @@ func (cds *OCIOSDataStore) MultipartDownload(source ObjectURI, target string, op
 	startTime := time.Now()
 	for part := range downloadedParts {
 		if part.err != nil {
-			err := os.Remove(tempTargetFilePath)
-			if err != nil {
-				cds.logger.Warnf("[%s] Failed to clean up temporary file after error: %v", source.ObjectName, err)
-			}
+			downloadFailed()
 			return fmt.Errorf("error downloading part %d: %v", part.partNum, part.err)
 		}
 
@@ -163,7 +188,7 @@ func (cds *OCIOSDataStore) MultipartDownload(source ObjectURI, target string, op
 		// Copy data from temp file to final file at correct offset using streaming
 		_, err = tmpFile.Seek(part.offset, 0)
 		if err != nil {
-			os.Remove(tempTargetFilePath)
+			downloadFailed()
 			return fmt.Errorf("failed to seek to offset %d for part %d: %v", part.offset, part.partNum, err)
 		}
 
@@ -173,7 +198,7 @@ func (cds *OCIOSDataStore) MultipartDownload(source ObjectURI, target string, op
 		BufferPool.Put(buf)
 
 		if err != nil {
-			os.Remove(tempTargetFilePath)
+			downloadFailed()
 			return fmt.Errorf("failed to copy part %d data at offset %d: %v", part.partNum, part.offset, err)
 		}
 
@@ -182,12 +207,25 @@ func (cds *OCIOSDataStore) MultipartDownload(source ObjectURI, target string, op
 		if err != nil {
 			cds.logger.Warnf("[%s] Failed to remove temporary file for part %d: %v", source.ObjectName, part.partNum, err)
 		}
+
+		// The part is only recorded once it is on disk
+		if downloadOpts.Resume {
+			if err := tmpFile.Sync(); err != nil {
+				downloadFailed()
+				return fmt.Errorf("failed to sync part %d to disk: %v", part.partNum, err)
+			}
+			if err := progress.record(part.partNum); err != nil {
+				cds.logger.Warnf("[%s] Failed to record the download of part %d: %v", source.ObjectName, part.partNum, err)
+			}
+		}
 	}
 
 	// Ensure all data is flushed to disk
 	if err := tmpFile.Sync(); err != nil {
+		downloadFailed()
 		return fmt.Errorf("failed to sync temporary file to disk: %v", err)
 	}
+	progress.close(false)
 
 	// Close the file explicitly before renaming
 	if err := tmpFile.Close(); err != nil {
@@ -269,9 +307,11 @@ func (cds *OCIOSDataStore) multipartDownload(ctx context.Context, downloadThread
 	var wg sync.WaitGroup
 	wg.Add(downloadThreads)
 
+	// A large object has thousands of parts, only some are logged
+	partLog := logging.RateLimited(cds.logger, 1, partLogInterval)
 	for i := 0; i < downloadThreads; i++ {
 		go func() {
-			cds.downloadFilePart(ctx, prepareDownloadParts, result)
+			cds.downloadFilePart(ctx, prepareDownloadParts, result, partLog)
 			wg.Done()
 		}()
 	}
@@ -285,7 +325,7 @@ func (cds *OCIOSDataStore) multipartDownload(ctx context.Context, downloadThread
 }
 
 // downloadFilePart wraps objectStorage GetObject API call
-func (cds *OCIOSDataStore) downloadFilePart(ctx context.Context, prepareDownloadParts chan *PrepareDownloadPart, result chan *DownloadedPart) {
+func (cds *OCIOSDataStore) downloadFilePart(ctx context.Context, prepareDownloadParts chan *PrepareDownloadPart, result chan *DownloadedPart, partLog logging.Interface) {
 	for part := range prepareDownloadParts {
 		var lastErr error
 		var tempFilePath string
@@ -349,7 +389,7 @@ func (cds *OCIOSDataStore) downloadFilePart(ctx context.Context, prepareDownload
 		duration := time.Since(start)
 		speedMBs := float64(size) / 1024.0 / 1024.0 / duration.Seconds()
 		if lastErr == nil {
-			cds.logger.Debugf("[Chunk %d] Downloaded %d bytes in %.2fs (%.2f MB/s) for file %s", part.partNum, size, duration.Seconds(), speedMBs, part.object)
+			partLog.Debugf("[Chunk %d] Downloaded %d bytes in %.2fs (%.2f MB/s) for file %s", part.partNum, size, duration.Seconds(), speedMBs, part.object)
 		}
 
 		if lastErr != nil {
@@ -8,6 +8,8 @@ import (
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/objectstorage/transfer"
+
+	"github.com/sgl-project/ome/pkg/logging"
 )
 
 const (
@@ -74,9 +76,11 @@ func (cds *OCIOSDataStore) MultipartFileUpload(filePath string, target ObjectURI
 		cds.logger.Warnf("Failed to adjust metadata for file upload: %v", err)
 	}
 
+	// A large file has thousands of parts, only some are logged
+	partLog := logging.RateLimited(cds.logger, 1, partLogInterval)
 	callBack := func(multiPartUploadPart transfer.MultiPartUploadPart) {
 		if multiPartUploadPart.Err == nil {
-			cds.logger.Infof("Part: %d / %d is uploaded for object %s.", multiPartUploadPart.PartNum, multiPartUploadPart.TotalParts, target.ObjectName)
+			partLog.Infof("Part: %d / %d is uploaded for object %s.", multiPartUploadPart.PartNum, multiPartUploadPart.TotalParts, target.ObjectName)
 			// refer following fmt to get each part opc-md5 res.
 			// fmt.Printf("and this part opcMD5(64BasedEncoding) is: %s.\n", *multiPartUploadPart.OpcMD5 )
 		}
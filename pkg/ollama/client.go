@@ -0,0 +1,370 @@
+package ollama
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/utils/download"
+)
+
+// ErrDigestMismatch is returned when a downloaded blob doesn't match the digest of its manifest
+var ErrDigestMismatch = errors.New("blob digest mismatch")
+
+// Client pulls models from Ollama and OCI artifact registries
+type Client struct {
+	config *Config
+
+	mu sync.Mutex
+	// Bearer tokens obtained from the token services of the registries, by registry and repository
+	tokens map[string]string
+}
+
+// NewClient creates a registry client
+func NewClient(opts ...Option) (*Client, error) {
+	config, err := NewConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{config: config, tokens: map[string]string{}}, nil
+}
+
+// Pull downloads the manifest and the blobs of a model to a directory and returns the manifest. The blobs already
+// downloaded are verified and kept, the interrupted ones are resumed.
+func (c *Client) Pull(ctx context.Context, ref Reference, localDir string) (*Manifest, error) {
+	if localDir == "" {
+		return nil, errors.New("local directory cannot be empty")
+	}
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", localDir, err)
+	}
+
+	manifest, raw, err := c.FetchManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	files := manifest.Files()
+	progress := Progress{TotalBytes: manifest.TotalSize(), TotalFiles: len(files)}
+	c.config.Logger.Infof("Pulling %s: %d files, %d bytes", ref, len(files), progress.TotalBytes)
+
+	for _, file := range files {
+		progress.CurrentFile = file.Name
+		if err := c.downloadBlob(ctx, ref, file, filepath.Join(localDir, file.Name)); err != nil {
+			return nil, fmt.Errorf("failed to download %s of %s: %w", file.Name, ref, err)
+		}
+		progress.CompletedBytes += file.Size
+		progress.CompletedFiles++
+		c.reportProgress(progress)
+	}
+
+	// The manifest is written last, its presence marks a complete pull
+	if err := download.WriteFile(filepath.Join(localDir, ManifestFileName), raw); err != nil {
+		return nil, err
+	}
+	c.config.Logger.Infof("Pulled %s to %s", ref, localDir)
+	return manifest, nil
+}
+
+// FetchManifest returns the manifest of a model and its raw content
+func (c *Client) FetchManifest(ctx context.Context, ref Reference) (*Manifest, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+
+	manifestURL := c.registryURL(ref, "manifests/"+ref.Tag)
+	resp, err := c.do(ctx, ref, http.MethodGet, manifestURL, http.Header{
+		"Accept": {MediaTypeDockerManifest + ", " + MediaTypeOCIManifest},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil, fmt.Errorf("model %s not found", ref)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, download.NewHTTPError(resp)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read manifest of %s: %w", ref, err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse manifest of %s: %w", ref, err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, nil, fmt.Errorf("manifest of %s has no layers", ref)
+	}
+	for _, blob := range append([]Descriptor{manifest.Config}, manifest.Layers...) {
+		if blob.Digest != "" && !strings.HasPrefix(blob.Digest, "sha256:") {
+			return nil, nil, fmt.Errorf("unsupported digest %s in manifest of %s", blob.Digest, ref)
+		}
+	}
+	return &manifest, raw, nil
+}
+
+// downloadBlob downloads a blob to a file unless the file already holds it, resuming the interrupted downloads
+func (c *Client) downloadBlob(ctx context.Context, ref Reference, blob BlobFile, dest string) error {
+	if ok, err := verifyFile(dest, blob.Descriptor); err == nil && ok {
+		c.config.Logger.Debugf("%s is up to date", dest)
+		return nil
+	}
+
+	partial := dest + download.PartialSuffix
+	var err error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			c.config.Logger.Warnf("Resuming download of %s (attempt %d/%d): %v", blob.Name, attempt, c.config.MaxRetries, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.config.RetryInterval):
+			}
+		}
+
+		err = c.fetchBlob(ctx, ref, blob.Descriptor, partial)
+		if err == nil || ctx.Err() != nil || errors.Is(err, ErrDigestMismatch) {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(partial, dest)
+}
+
+// fetchBlob appends the missing bytes of a blob to a partial file and verifies its digest
+func (c *Client) fetchBlob(ctx context.Context, ref Reference, blob Descriptor, partial string) error {
+	file, err := os.OpenFile(partial, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	offset, err := io.Copy(hasher, file)
+	if err != nil {
+		return err
+	}
+	if offset > blob.Size {
+		offset = 0
+		hasher.Reset()
+		if err := file.Truncate(0); err != nil {
+			return err
+		}
+	}
+
+	if offset < blob.Size {
+		header := http.Header{}
+		if offset > 0 {
+			header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+		resp, err := c.do(ctx, ref, http.MethodGet, c.registryURL(ref, "blobs/"+blob.Digest), header)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusPartialContent:
+		case http.StatusOK:
+			// The registry ignored the range, the blob is downloaded again
+			if offset > 0 {
+				hasher.Reset()
+				if err := file.Truncate(0); err != nil {
+					return err
+				}
+				offset = 0
+			}
+		default:
+			return download.NewHTTPError(resp)
+		}
+
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.Copy(io.MultiWriter(file, hasher), resp.Body); err != nil {
+			return err
+		}
+	}
+
+	if err := checkDigest(hasher, blob.Digest); err != nil {
+		// The partial content is corrupted, the next attempt starts over
+		_ = os.Remove(partial)
+		return err
+	}
+	return file.Sync()
+}
+
+// do sends a registry request, answering the bearer token challenges of the registries
+func (c *Client) do(ctx context.Context, ref Reference, method, rawURL string, header http.Header) (*http.Response, error) {
+	tokenKey := ref.Registry + "/" + ref.Repository()
+	c.mu.Lock()
+	token := c.tokens[tokenKey]
+	c.mu.Unlock()
+	if token == "" {
+		token = c.config.Token
+	}
+
+	resp, err := c.send(ctx, method, rawURL, header, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	token, err = c.fetchToken(ctx, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate to %s: %w", ref.Registry, err)
+	}
+	c.mu.Lock()
+	c.tokens[tokenKey] = token
+	c.mu.Unlock()
+	return c.send(ctx, method, rawURL, header, token)
+}
+
+func (c *Client) send(ctx context.Context, method, rawURL string, header http.Header, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.config.HTTPClient.Do(req)
+}
+
+// fetchToken exchanges the configured token for a bearer token of the service of a challenge
+func (c *Client) fetchToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	if !strings.EqualFold(scheme, "Bearer") || params["realm"] == "" {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", params["realm"], err)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+	defer cancel()
+	resp, err := c.send(ctx, http.MethodGet, tokenURL.String(), nil, c.config.Token)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", download.NewHTTPError(resp)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", errors.New("token response has no token")
+}
+
+func (c *Client) registryURL(ref Reference, path string) string {
+	scheme := "https"
+	if c.config.PlainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Registry, ref.Repository(), path)
+}
+
+func (c *Client) reportProgress(progress Progress) {
+	if c.config.OnProgress != nil {
+		c.config.OnProgress(progress)
+	}
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g. `Bearer realm="https://auth",service="registry"`
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = strings.TrimPrefix(strings.TrimSpace(value[end+2:]), ",")
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(value)
+		}
+	}
+	return scheme, params
+}
+
+// verifyFile returns whether a file holds a blob
+func verifyFile(path string, blob Descriptor) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if info.Size() != blob.Size {
+		return false, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return false, err
+	}
+	return checkDigest(hasher, blob.Digest) == nil, nil
+}
+
+func checkDigest(hasher hash.Hash, digest string) error {
+	actual := "sha256:" + hex.EncodeToString(hasher.Sum(nil))
+	if actual != digest {
+		return fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, digest, actual)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package ollama
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/utils/download"
+)
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// fakeRegistry serves a model whose blobs support range requests
+type fakeRegistry struct {
+	manifest  []byte
+	blobs     map[string][]byte
+	token     string
+	rangeReqs atomic.Int32
+}
+
+func newFakeRegistry(t *testing.T, model, params []byte) *fakeRegistry {
+	config := []byte(`{"model_format":"gguf","model_family":"llama"}`)
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeDockerManifest,
+		Config:        Descriptor{MediaType: "application/vnd.docker.container.image.v1+json", Digest: digestOf(config), Size: int64(len(config))},
+		Layers: []Descriptor{
+			{MediaType: MediaTypeModel, Digest: digestOf(model), Size: int64(len(model))},
+			{MediaType: MediaTypeParams, Digest: digestOf(params), Size: int64(len(params))},
+		},
+	}
+	raw, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	return &fakeRegistry{
+		manifest: raw,
+		blobs: map[string][]byte{
+			digestOf(config): config,
+			digestOf(model):  model,
+			digestOf(params): params,
+		},
+	}
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": f.token})
+		return
+	}
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="registry",scope="repository:library/llama:pull"`, r.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/v2/library/llama/manifests/latest":
+		w.Header().Set("Content-Type", MediaTypeDockerManifest)
+		_, _ = w.Write(f.manifest)
+	case strings.HasPrefix(r.URL.Path, "/v2/library/llama/blobs/"):
+		blob, ok := f.blobs[strings.TrimPrefix(r.URL.Path, "/v2/library/llama/blobs/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			f.rangeReqs.Add(1)
+			start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			if err != nil || start > len(blob) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(blob)-1, len(blob)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(blob[start:])
+			return
+		}
+		_, _ = w.Write(blob)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	opts = append([]Option{WithPlainHTTP(true), WithRetryConfig(1, time.Millisecond)}, opts...)
+	client, err := NewClient(opts...)
+	require.NoError(t, err)
+	return client
+}
+
+func testReference(server *httptest.Server) Reference {
+	return Reference{
+		Registry:  strings.TrimPrefix(server.URL, "http://"),
+		Namespace: "library",
+		Model:     "llama",
+		Tag:       "latest",
+	}
+}
+
+func TestPull(t *testing.T) {
+	model := []byte(strings.Repeat("gguf", 1024))
+	params := []byte(`{"temperature":0.7}`)
+	registry := newFakeRegistry(t, model, params)
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	var updates []Progress
+	client := newTestClient(t, WithProgress(func(p Progress) { updates = append(updates, p) }))
+	dir := t.TempDir()
+
+	manifest, err := client.Pull(context.Background(), testReference(server), dir)
+	require.NoError(t, err)
+	assert.Len(t, manifest.Layers, 2)
+
+	content, err := os.ReadFile(filepath.Join(dir, "model.gguf"))
+	require.NoError(t, err)
+	assert.Equal(t, model, content)
+	content, err = os.ReadFile(filepath.Join(dir, "params.json"))
+	require.NoError(t, err)
+	assert.Equal(t, params, content)
+	assert.FileExists(t, filepath.Join(dir, ConfigFileName))
+	assert.FileExists(t, filepath.Join(dir, ManifestFileName))
+	assert.NoFileExists(t, filepath.Join(dir, "model.gguf"+download.PartialSuffix))
+
+	require.Len(t, updates, 3)
+	last := updates[len(updates)-1]
+	assert.Equal(t, 3, last.CompletedFiles)
+	assert.Equal(t, manifest.TotalSize(), last.CompletedBytes)
+}
+
+func TestPullResumesPartialBlob(t *testing.T) {
+	model := []byte(strings.Repeat("gguf", 1024))
+	registry := newFakeRegistry(t, model, []byte(`{}`))
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.gguf"+download.PartialSuffix), model[:1000], 0644))
+
+	_, err := newTestClient(t).Pull(context.Background(), testReference(server), dir)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "model.gguf"))
+	require.NoError(t, err)
+	assert.Equal(t, model, content)
+	assert.Equal(t, int32(1), registry.rangeReqs.Load())
+}
+
+func TestPullRejectsCorruptedBlob(t *testing.T) {
+	model := []byte(strings.Repeat("gguf", 1024))
+	registry := newFakeRegistry(t, model, []byte(`{}`))
+	// The registry serves other content than the digest of the manifest
+	registry.blobs[digestOf(model)] = []byte(strings.Repeat("fake", 1024))
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	dir := t.TempDir()
+	_, err := newTestClient(t).Pull(context.Background(), testReference(server), dir)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrDigestMismatch)
+	assert.NoFileExists(t, filepath.Join(dir, "model.gguf"))
+	assert.NoFileExists(t, filepath.Join(dir, "model.gguf"+download.PartialSuffix))
+	assert.NoFileExists(t, filepath.Join(dir, ManifestFileName))
+}
+
+func TestPullAnswersTokenChallenge(t *testing.T) {
+	registry := newFakeRegistry(t, []byte("model"), []byte(`{}`))
+	registry.token = "registry-token"
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	dir := t.TempDir()
+	_, err := newTestClient(t).Pull(context.Background(), testReference(server), dir)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "model.gguf"))
+}
+
+func TestFetchManifestNotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, _, err := newTestClient(t).FetchManifest(context.Background(), testReference(server))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestManifestFiles(t *testing.T) {
+	manifest := Manifest{
+		Config: Descriptor{Digest: "sha256:c0", Size: 1},
+		Layers: []Descriptor{
+			{MediaType: MediaTypeModel, Digest: "sha256:aaaaaaaaaaaaaaaa", Size: 10},
+			{MediaType: MediaTypeLicense, Digest: "sha256:bbbbbbbbbbbbbbbb", Size: 2},
+			{MediaType: MediaTypeLicense, Digest: "sha256:cccccccccccccccc", Size: 3},
+			{MediaType: "application/octet-stream", Digest: "sha256:dd", Size: 4, Annotations: map[string]string{AnnotationTitle: "../../weights.gguf"}},
+			{MediaType: "application/octet-stream", Digest: "sha256:ee", Size: 5},
+		},
+	}
+
+	var names []string
+	for _, file := range manifest.Files() {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{ConfigFileName, "model.gguf", "LICENSE", "LICENSE-cccccccccccc", "weights.gguf", "sha256-ee"}, names)
+	assert.Equal(t, int64(25), manifest.TotalSize())
+}
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("ollama://llama3.2:3b")
+	require.NoError(t, err)
+	assert.Equal(t, Reference{Registry: "registry.ollama.ai", Namespace: "library", Model: "llama3.2", Tag: "3b"}, ref)
+	assert.Equal(t, "registry.ollama.ai/library/llama3.2:3b", ref.String())
+
+	_, err = ParseReference("hf://meta-llama/Llama-3.2-3B")
+	assert.Error(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry",scope="repository:a/b:pull"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry",
+		"scope":   "repository:a/b:pull",
+	}, params)
+}
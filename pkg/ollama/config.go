@@ -0,0 +1,154 @@
+package ollama
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/logging"
+)
+
+const (
+	// DefaultRequestTimeout is the timeout of the registry API requests, blob downloads are only bounded by their context
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultMaxRetries is the number of times an interrupted blob download is resumed
+	DefaultMaxRetries = 5
+	// DefaultRetryInterval is the wait before resuming an interrupted blob download
+	DefaultRetryInterval = 2 * time.Second
+	// DefaultUserAgent is the user agent of the registry requests
+	DefaultUserAgent = "ome-ollama-go/1.0.0"
+)
+
+// Progress reports the blobs downloaded by a pull
+type Progress struct {
+	TotalBytes     int64
+	CompletedBytes int64
+	TotalFiles     int
+	CompletedFiles int
+	CurrentFile    string
+}
+
+// Config represents the configuration of the registry client
+type Config struct {
+	Logger         logging.Interface
+	Token          string        `mapstructure:"token"`
+	UserAgent      string        `mapstructure:"user_agent"`
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	MaxRetries     int           `mapstructure:"max_retries"`
+	RetryInterval  time.Duration `mapstructure:"retry_interval"`
+	// PlainHTTP talks to the registries over HTTP instead of HTTPS, e.g. to reach a local mirror
+	PlainHTTP  bool `mapstructure:"plain_http"`
+	HTTPClient *http.Client
+	// OnProgress is called as the blobs of a pull are downloaded
+	OnProgress func(Progress)
+}
+
+// Option configures the registry client
+type Option func(*Config) error
+
+func defaultConfig() *Config {
+	return &Config{
+		Logger:         logging.Discard(),
+		UserAgent:      DefaultUserAgent,
+		RequestTimeout: DefaultRequestTimeout,
+		MaxRetries:     DefaultMaxRetries,
+		RetryInterval:  DefaultRetryInterval,
+	}
+}
+
+// NewConfig creates a configuration from the default one and the options
+func NewConfig(opts ...Option) (*Config, error) {
+	config := defaultConfig()
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, err
+		}
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{}
+	}
+	return config, nil
+}
+
+// WithLogger sets the logger of the client
+func WithLogger(logger logging.Interface) Option {
+	return func(c *Config) error {
+		if logger == nil {
+			return errors.New("invalid logger nil")
+		}
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithToken sets the bearer token sent to the registries, or exchanged for one when they challenge the requests
+func WithToken(token string) Option {
+	return func(c *Config) error {
+		c.Token = token
+		return nil
+	}
+}
+
+// WithUserAgent sets the user agent of the registry requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) error {
+		if userAgent == "" {
+			return errors.New("user agent cannot be empty")
+		}
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithRequestTimeout sets the timeout of the registry API requests
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Config) error {
+		if timeout <= 0 {
+			return errors.New("request timeout must be positive")
+		}
+		c.RequestTimeout = timeout
+		return nil
+	}
+}
+
+// WithRetryConfig sets how many times and after which wait an interrupted blob download is resumed
+func WithRetryConfig(maxRetries int, retryInterval time.Duration) Option {
+	return func(c *Config) error {
+		if maxRetries < 0 {
+			return errors.New("max retries cannot be negative")
+		}
+		if retryInterval < 0 {
+			return errors.New("retry interval cannot be negative")
+		}
+		c.MaxRetries = maxRetries
+		c.RetryInterval = retryInterval
+		return nil
+	}
+}
+
+// WithPlainHTTP makes the client talk to the registries over HTTP
+func WithPlainHTTP(enabled bool) Option {
+	return func(c *Config) error {
+		c.PlainHTTP = enabled
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client of the requests, e.g. to use a proxy or custom CAs
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) error {
+		if client == nil {
+			return errors.New("invalid HTTP client nil")
+		}
+		c.HTTPClient = client
+		return nil
+	}
+}
+
+// WithProgress sets the function called as the blobs of a pull are downloaded
+func WithProgress(handler func(Progress)) Option {
+	return func(c *Config) error {
+		c.OnProgress = handler
+		return nil
+	}
+}
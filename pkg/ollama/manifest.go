@@ -0,0 +1,120 @@
+package ollama
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Media types of the manifests and layers of the registries
+const (
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+
+	MediaTypeModel     = "application/vnd.ollama.image.model"
+	MediaTypeProjector = "application/vnd.ollama.image.projector"
+	MediaTypeAdapter   = "application/vnd.ollama.image.adapter"
+	MediaTypeTemplate  = "application/vnd.ollama.image.template"
+	MediaTypeSystem    = "application/vnd.ollama.image.system"
+	MediaTypeParams    = "application/vnd.ollama.image.params"
+	MediaTypeLicense   = "application/vnd.ollama.image.license"
+	MediaTypeMessages  = "application/vnd.ollama.image.messages"
+
+	// AnnotationTitle names the file of a layer of a generic OCI artifact
+	AnnotationTitle = "org.opencontainers.image.title"
+)
+
+const (
+	// ManifestFileName is the file the manifest of a pulled model is written to
+	ManifestFileName = "manifest.json"
+	// ConfigFileName is the file the config blob of a pulled model is written to. It isn't named config.json, which
+	// the model agent parses as a Hugging Face model config.
+	ConfigFileName = "ollama_config.json"
+)
+
+// layerFileNames maps the Ollama layer media types to the files they are written to
+var layerFileNames = map[string]string{
+	MediaTypeModel:     "model.gguf",
+	MediaTypeProjector: "mmproj.gguf",
+	MediaTypeAdapter:   "adapter.gguf",
+	MediaTypeTemplate:  "template",
+	MediaTypeSystem:    "system",
+	MediaTypeParams:    "params.json",
+	MediaTypeLicense:   "LICENSE",
+	MediaTypeMessages:  "messages.json",
+}
+
+// Descriptor references a blob of a manifest
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest lists the blobs of a model
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+// TotalSize returns the size of the blobs of the manifest
+func (m *Manifest) TotalSize() int64 {
+	total := m.Config.Size
+	for _, layer := range m.Layers {
+		total += layer.Size
+	}
+	return total
+}
+
+// Files maps the blobs of the manifest to the files they are written to, in download order
+func (m *Manifest) Files() []BlobFile {
+	files := make([]BlobFile, 0, len(m.Layers)+1)
+	used := map[string]bool{}
+	if m.Config.Digest != "" {
+		files = append(files, BlobFile{Descriptor: m.Config, Name: ConfigFileName})
+		used[ConfigFileName] = true
+	}
+	for _, layer := range m.Layers {
+		name := layerFileName(layer)
+		// Layers of the same type, e.g. several licenses, are told apart by their digest
+		if used[name] {
+			name = fmt.Sprintf("%s-%s", name, shortDigest(layer.Digest))
+		}
+		used[name] = true
+		files = append(files, BlobFile{Descriptor: layer, Name: name})
+	}
+	return files
+}
+
+// BlobFile is a blob of a manifest and the file it is written to
+type BlobFile struct {
+	Descriptor
+	Name string
+}
+
+func layerFileName(layer Descriptor) string {
+	if title := layer.Annotations[AnnotationTitle]; title != "" {
+		// The title comes from the registry, it must not escape the model directory
+		if name := filepath.Base(filepath.Clean("/" + title)); name != "/" && name != "." {
+			return name
+		}
+	}
+	if name, ok := layerFileNames[layer.MediaType]; ok {
+		return name
+	}
+	return strings.Replace(layer.Digest, ":", "-", 1)
+}
+
+func shortDigest(digest string) string {
+	_, hex, found := strings.Cut(digest, ":")
+	if !found {
+		hex = digest
+	}
+	if len(hex) > 12 {
+		hex = hex[:12]
+	}
+	return hex
+}
@@ -0,0 +1,38 @@
+package ollama
+
+import (
+	"fmt"
+
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+// Reference identifies a model of a registry
+type Reference struct {
+	Registry  string
+	Namespace string
+	Model     string
+	Tag       string
+}
+
+// ParseReference parses an ollama:// storage URI into a model reference
+func ParseReference(uri string) (Reference, error) {
+	components, err := storage.ParseOllamaStorageURI(uri)
+	if err != nil {
+		return Reference{}, err
+	}
+	return Reference{
+		Registry:  components.Registry,
+		Namespace: components.Namespace,
+		Model:     components.Model,
+		Tag:       components.Tag,
+	}, nil
+}
+
+// Repository returns the repository of the model in the registry API
+func (r Reference) Repository() string {
+	return r.Namespace + "/" + r.Model
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository(), r.Tag)
+}
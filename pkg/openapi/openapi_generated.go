@@ -26,6 +26,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorPerformance":     schema_pkg_apis_ome_v1beta1_AcceleratorPerformance(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorRequirements":    schema_pkg_apis_ome_v1beta1_AcceleratorRequirements(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorResource":        schema_pkg_apis_ome_v1beta1_AcceleratorResource(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorScheduling":      schema_pkg_apis_ome_v1beta1_AcceleratorScheduling(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorSelection":       schema_pkg_apis_ome_v1beta1_AcceleratorSelection(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorSelector":        schema_pkg_apis_ome_v1beta1_AcceleratorSelector(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.BaseModel":                  schema_pkg_apis_ome_v1beta1_BaseModel(ref),
@@ -35,12 +36,16 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.BenchmarkJobList":           schema_pkg_apis_ome_v1beta1_BenchmarkJobList(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.BenchmarkJobSpec":           schema_pkg_apis_ome_v1beta1_BenchmarkJobSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.BenchmarkJobStatus":         schema_pkg_apis_ome_v1beta1_BenchmarkJobStatus(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.BenchmarkResults":           schema_pkg_apis_ome_v1beta1_BenchmarkResults(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ClusterBaseModel":           schema_pkg_apis_ome_v1beta1_ClusterBaseModel(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ClusterBaseModelList":       schema_pkg_apis_ome_v1beta1_ClusterBaseModelList(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ClusterServingRuntime":      schema_pkg_apis_ome_v1beta1_ClusterServingRuntime(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ClusterServingRuntimeList":  schema_pkg_apis_ome_v1beta1_ClusterServingRuntimeList(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComparisonReport":           schema_pkg_apis_ome_v1beta1_ComparisonReport(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComparisonSpec":             schema_pkg_apis_ome_v1beta1_ComparisonSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComponentExtensionSpec":     schema_pkg_apis_ome_v1beta1_ComponentExtensionSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComponentStatusSpec":        schema_pkg_apis_ome_v1beta1_ComponentStatusSpec(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ConversationSpec":           schema_pkg_apis_ome_v1beta1_ConversationSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DecoderSpec":                schema_pkg_apis_ome_v1beta1_DecoderSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DiffusionComponentSpec":     schema_pkg_apis_ome_v1beta1_DiffusionComponentSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DiffusionPipelineSpec":      schema_pkg_apis_ome_v1beta1_DiffusionPipelineSpec(ref),
@@ -51,14 +56,21 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.FineTunedWeight":            schema_pkg_apis_ome_v1beta1_FineTunedWeight(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.FineTunedWeightList":        schema_pkg_apis_ome_v1beta1_FineTunedWeightList(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.FineTunedWeightSpec":        schema_pkg_apis_ome_v1beta1_FineTunedWeightSpec(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.HistogramBucket":            schema_pkg_apis_ome_v1beta1_HistogramBucket(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.HuggingFaceSecretReference": schema_pkg_apis_ome_v1beta1_HuggingFaceSecretReference(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.InferenceService":           schema_pkg_apis_ome_v1beta1_InferenceService(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.InferenceServiceList":       schema_pkg_apis_ome_v1beta1_InferenceServiceList(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.InferenceServiceReference":  schema_pkg_apis_ome_v1beta1_InferenceServiceReference(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.InferenceServiceSpec":       schema_pkg_apis_ome_v1beta1_InferenceServiceSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.InferenceServiceStatus":     schema_pkg_apis_ome_v1beta1_InferenceServiceStatus(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationComparison":        schema_pkg_apis_ome_v1beta1_IterationComparison(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationMetrics":           schema_pkg_apis_ome_v1beta1_IterationMetrics(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.KedaConfig":                 schema_pkg_apis_ome_v1beta1_KedaConfig(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.LatencyDistribution":        schema_pkg_apis_ome_v1beta1_LatencyDistribution(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.LeaderSpec":                 schema_pkg_apis_ome_v1beta1_LeaderSpec(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.MIGProfile":                 schema_pkg_apis_ome_v1beta1_MIGProfile(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.MIGProfileStatus":           schema_pkg_apis_ome_v1beta1_MIGProfileStatus(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelConfigWarning":         schema_pkg_apis_ome_v1beta1_ModelConfigWarning(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelCopies":                schema_pkg_apis_ome_v1beta1_ModelCopies(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelExtensionSpec":         schema_pkg_apis_ome_v1beta1_ModelExtensionSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelFormat":                schema_pkg_apis_ome_v1beta1_ModelFormat(ref),
@@ -74,8 +86,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.PodSpec":                    schema_pkg_apis_ome_v1beta1_PodSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.PredictorExtensionSpec":     schema_pkg_apis_ome_v1beta1_PredictorExtensionSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.PredictorSpec":              schema_pkg_apis_ome_v1beta1_PredictorSpec(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ReplaySpec":                 schema_pkg_apis_ome_v1beta1_ReplaySpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RouterSpec":                 schema_pkg_apis_ome_v1beta1_RouterSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RunnerSpec":                 schema_pkg_apis_ome_v1beta1_RunnerSpec(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeEngineVersion":       schema_pkg_apis_ome_v1beta1_RuntimeEngineVersion(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeSelection":           schema_pkg_apis_ome_v1beta1_RuntimeSelection(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeVersionConstraint":   schema_pkg_apis_ome_v1beta1_RuntimeVersionConstraint(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ScalerAuthenticationRef":    schema_pkg_apis_ome_v1beta1_ScalerAuthenticationRef(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ServiceMetadata":            schema_pkg_apis_ome_v1beta1_ServiceMetadata(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ServingRuntime":             schema_pkg_apis_ome_v1beta1_ServingRuntime(ref),
@@ -88,8 +104,11 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.SupportedModelFormat":       schema_pkg_apis_ome_v1beta1_SupportedModelFormat(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.SupportedRuntime":           schema_pkg_apis_ome_v1beta1_SupportedRuntime(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.TensorParallelismConfig":    schema_pkg_apis_ome_v1beta1_TensorParallelismConfig(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.TrafficPhase":               schema_pkg_apis_ome_v1beta1_TrafficPhase(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.TrafficScheduleSpec":        schema_pkg_apis_ome_v1beta1_TrafficScheduleSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.WorkerPodSpec":              schema_pkg_apis_ome_v1beta1_WorkerPodSpec(ref),
 		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.WorkerSpec":                 schema_pkg_apis_ome_v1beta1_WorkerSpec(ref),
+		"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.WorkloadSpec":               schema_pkg_apis_ome_v1beta1_WorkloadSpec(ref),
 	}
 }
 
@@ -321,6 +340,34 @@ func schema_pkg_apis_ome_v1beta1_AcceleratorClassSpec(ref common.ReferenceCallba
 							},
 						},
 					},
+					"migProfiles": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "MIGProfiles describes the Multi-Instance GPU (MIG) profiles this accelerator can be partitioned into. Each profile can be referenced as a sub-class named \"<class>/<profile>\" (e.g., nvidia-a100-80gb/1g.10gb) wherever an AcceleratorClass name is accepted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.MIGProfile"),
+									},
+								},
+							},
+						},
+					},
+					"scheduling": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheduling hints injected into the pods of InferenceService components that use this accelerator class",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorScheduling"),
+						},
+					},
 					"integration": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Integration with external systems",
@@ -338,7 +385,7 @@ func schema_pkg_apis_ome_v1beta1_AcceleratorClassSpec(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorCapabilities", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorCost", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorDiscovery", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorIntegration", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorResource"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorCapabilities", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorCost", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorDiscovery", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorIntegration", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorResource", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorScheduling", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.MIGProfile"},
 	}
 }
 
@@ -382,6 +429,20 @@ func schema_pkg_apis_ome_v1beta1_AcceleratorClassStatus(ref common.ReferenceCall
 							Format:      "int32",
 						},
 					},
+					"allocatableAccelerators": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Accelerators that can be allocated to pods, excluding those reserved for system daemons",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"usedAccelerators": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Accelerators requested by running and pending pods",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"lastUpdated": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Last update time",
@@ -414,11 +475,33 @@ func schema_pkg_apis_ome_v1beta1_AcceleratorClassStatus(ref common.ReferenceCall
 							Format:      "int32",
 						},
 					},
+					"migProfiles": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "MIGProfiles reports the capacity of each MIG profile of the accelerator, in instances of the profile",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.MIGProfileStatus"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.MIGProfileStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -823,7 +906,7 @@ func schema_pkg_apis_ome_v1beta1_AcceleratorRequirements(ref common.ReferenceCal
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "AcceleratorClasses lists the names of AcceleratorClasses this runtime supports If empty, the runtime supports any accelerator",
+							Description: "AcceleratorClasses lists the names of AcceleratorClasses this runtime supports",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
@@ -938,6 +1021,47 @@ func schema_pkg_apis_ome_v1beta1_AcceleratorResource(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_ome_v1beta1_AcceleratorScheduling(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AcceleratorScheduling describes how pods are scheduled onto nodes with this accelerator. Node affinity is taken from Discovery.Affinity.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tolerations": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations added to pods, typically for taints on accelerator nodes (e.g., nvidia.com/gpu:NoSchedule)",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"runtimeClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RuntimeClassName set on pods that don't specify one (e.g., nvidia)",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Toleration"},
+	}
+}
+
 func schema_pkg_apis_ome_v1beta1_AcceleratorSelection(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -992,10 +1116,18 @@ func schema_pkg_apis_ome_v1beta1_AcceleratorSelection(ref common.ReferenceCallba
 							},
 						},
 					},
+					"estimatedHourlyCost": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedHourlyCost of the accelerators of the component at its minimum replicas, based on the cost of the AcceleratorClass",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
 				},
 				Required: []string{"acceleratorClass"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -1165,7 +1297,7 @@ func schema_pkg_apis_ome_v1beta1_BaseModelSpec(ref common.ReferenceCallback) com
 					},
 					"quantization": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Quantization defines the quantization scheme applied to the model weights, such as \"fp8\", \"fbgemm_fp8\", or \"int4\". This influences runtime compatibility and performance.",
+							Description: "Quantization defines the quantization scheme applied to the model weights, such as \"fp8\", \"fbgemm_fp8\", \"int4\", \"awq\" or \"gptq\". This influences runtime compatibility and performance.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1295,6 +1427,25 @@ func schema_pkg_apis_ome_v1beta1_BaseModelSpec(ref common.ReferenceCallback) com
 							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DiffusionPipelineSpec"),
 						},
 					},
+					"runtimeVersionConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "RuntimeVersionConstraints restricts the engine versions of the runtimes that can serve the model, e.g. to keep a model off an engine release that breaks it",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeVersionConstraint"),
+									},
+								},
+							},
+						},
+					},
 					"additionalMetadata": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Additional metadata for the model",
@@ -1316,7 +1467,7 @@ func schema_pkg_apis_ome_v1beta1_BaseModelSpec(ref common.ReferenceCallback) com
 			},
 		},
 		Dependencies: []string{
-			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DiffusionPipelineSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelFormat", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelFrameworkSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.StorageSpec", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DiffusionPipelineSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelFormat", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelFrameworkSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeVersionConstraint", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.StorageSpec", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1436,6 +1587,12 @@ func schema_pkg_apis_ome_v1beta1_BenchmarkJobSpec(ref common.ReferenceCallback)
 							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EndpointSpec"),
 						},
 					},
+					"comparison": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Comparison benchmarks a candidate endpoint with the same traffic as Endpoint, which acts as the baseline, and reports the relative change of the candidate in status (A/B mode).",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComparisonSpec"),
+						},
+					},
 					"serviceMetadata": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ServiceMetadata records metadata about the backend model server or service being benchmarked. This includes details such as server engine, version, and GPU configuration for filtering experiments.",
@@ -1490,6 +1647,12 @@ func schema_pkg_apis_ome_v1beta1_BenchmarkJobSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"trafficSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TrafficSchedule describes phases of request rate or concurrency over time, e.g. to test autoscaler behavior and burst handling. When set, the schedule replaces the NumConcurrency sweep and every traffic scenario is run through all phases of the schedule.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.TrafficScheduleSpec"),
+						},
+					},
 					"maxTimePerIteration": {
 						SchemaProps: spec.SchemaProps{
 							Description: "MaxTimePerIteration specifies the maximum time (in minutes) for a single iteration. Each iteration runs for a specific combination of TrafficScenarios and NumConcurrency.",
@@ -1504,6 +1667,20 @@ func schema_pkg_apis_ome_v1beta1_BenchmarkJobSpec(ref common.ReferenceCallback)
 							Format:      "int32",
 						},
 					},
+					"warmupDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WarmupDuration specifies the maximum time (in seconds) of the warmup phase that runs before the benchmark. Warmup requests prime compile and prefix caches and are excluded from the recorded results.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"warmupRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WarmupRequests specifies the maximum number of requests sent during the warmup phase. The warmup phase ends when either WarmupDuration or WarmupRequests is reached.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"additionalRequestParams": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AdditionalRequestParams contains additional request parameters as a map.",
@@ -1520,9 +1697,22 @@ func schema_pkg_apis_ome_v1beta1_BenchmarkJobSpec(ref common.ReferenceCallback)
 							},
 						},
 					},
+					"streaming": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Streaming controls whether requests are sent as streaming requests, for tasks that generate text. With streaming, the benchmark client counts output tokens and measures the time to first token and the inter-token latency from the stream events, for both OpenAI-compatible and engine-native protocols. Without streaming, token counts are taken from the usage reported in the response. If not set, the default of the benchmark client applies, which is to stream.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"workload": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workload describes the shape of the requests sent to the endpoint. If not provided, every request is an independent single prompt.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.WorkloadSpec"),
+						},
+					},
 					"dataset": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Dataset is the dataset used for benchmarking. It is optional and only required for tasks other than \"text-to-<output-modality>\".",
+							Description: "Dataset is the dataset used for benchmarking. It is optional and only required for tasks other than \"text-to-<output-modality>\", or for \"Replay\" workloads, where it holds the recorded request trace. The dataset can be pulled from any supported storage URI, e.g. hf://{dataset-id}[@{revision}], pvc://{pvc-name}/{sub-path}, s3://{bucket}/{prefix}, oci://n/{namespace}/b/{bucket}/o/{prefix}, az://{account}/{container}/{path} or gs://{bucket}/{object}. Credentials are read from the Secret named by the storage key, which must reside in the same namespace as the BenchmarkJob.",
 							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.StorageSpec"),
 						},
 					},
@@ -1534,11 +1724,25 @@ func schema_pkg_apis_ome_v1beta1_BenchmarkJobSpec(ref common.ReferenceCallback)
 					},
 					"resultFolderName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ResultFolderName specifies the name of the folder that stores the benchmark result. A default name will be assigned if not specified.",
+							Description: "ResultFolderName specifies the name of the folder that stores the benchmark result. Defaults to the name of the BenchmarkJob if not specified.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"saveRequestRecords": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SaveRequestRecords exports the raw record of every request next to the summary of the results. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"reportMetrics": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReportMetrics reports the latency metrics of every iteration in the status of the BenchmarkJob. The report is passed through the termination message of the benchmark container, which is limited to 4096 bytes, so histograms and then the last iterations may be left out of the status; the summary of the results always holds all of them. Comparative benchmarks always report their metrics. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"podOverride": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Pod defines the pod configuration for the benchmark job. This is optional, if not provided, default values will be used.",
@@ -1550,7 +1754,7 @@ func schema_pkg_apis_ome_v1beta1_BenchmarkJobSpec(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EndpointSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.HuggingFaceSecretReference", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.PodOverride", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ServiceMetadata", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.StorageSpec"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComparisonSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EndpointSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.HuggingFaceSecretReference", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.PodOverride", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ServiceMetadata", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.StorageSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.TrafficScheduleSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.WorkloadSpec"},
 	}
 }
 
@@ -1601,12 +1805,79 @@ func schema_pkg_apis_ome_v1beta1_BenchmarkJobStatus(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"results": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results points to the benchmark results exported to the output location. It is set once the benchmark job has completed successfully.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.BenchmarkResults"),
+						},
+					},
+					"metrics": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Metrics holds the latency breakdown of every iteration, one entry per combination of traffic scenario and concurrency level. It is set once the benchmark job has completed successfully.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationMetrics"),
+									},
+								},
+							},
+						},
+					},
+					"comparison": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Comparison reports how the candidate endpoint performed relative to the baseline endpoint. It is only set for comparative benchmarks once the benchmark job has completed successfully.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComparisonReport"),
+						},
+					},
 				},
 				Required: []string{"state"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.BenchmarkResults", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComparisonReport", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationMetrics", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_BenchmarkResults(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BenchmarkResults describes where the results of a completed benchmark job were exported.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"location": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Location is the storage URI of the folder that holds the benchmark results.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"summary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Summary is the storage URI of the summary JSON file with the aggregated metrics of every iteration.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"records": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Records is the storage URI of the raw per-request records, set when they are saved.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"location"},
+			},
+		},
 	}
 }
 
@@ -1802,45 +2073,133 @@ func schema_pkg_apis_ome_v1beta1_ClusterServingRuntimeList(ref common.ReferenceC
 	}
 }
 
-func schema_pkg_apis_ome_v1beta1_ComponentExtensionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_ome_v1beta1_ComparisonReport(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "ComponentExtensionSpec defines the deployment configuration for a given InferenceService component",
+				Description: "ComparisonReport is the delta report of a comparative (A/B) benchmark.",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"minReplicas": {
+					"candidateMetrics": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Minimum number of replicas, defaults to 1 but can be set to 0 to enable scale-to-zero.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "CandidateMetrics holds the metrics of every iteration measured against the candidate endpoint.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationMetrics"),
+									},
+								},
+							},
 						},
 					},
-					"maxReplicas": {
+					"iterations": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Maximum number of replicas for autoscaling.",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Iterations holds the relative change of the candidate against the baseline for every iteration.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationComparison"),
+									},
+								},
+							},
 						},
 					},
-					"scaleTarget": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationComparison", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.IterationMetrics"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_ComparisonSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ComparisonSpec defines the candidate endpoint of a comparative (A/B) benchmark.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"candidate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ScaleTarget specifies the integer target value of the metric type the Autoscaler watches for. concurrency and rps targets are supported by Knative Pod Autoscaler (https://knative.dev/docs/serving/autoscaling/autoscaling-targets/).",
-							Type:        []string{"integer"},
-							Format:      "int32",
+							Description: "Candidate is the endpoint compared against the baseline endpoint, e.g. a canary InferenceService. When the candidate is an InferenceService, its base model must be ready on the node running the benchmark.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EndpointSpec"),
 						},
 					},
-					"scaleMetric": {
+					"baselineCostPerHour": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "BaselineCostPerHour is the hourly cost of serving the baseline endpoint. Together with CandidateCostPerHour it is used to compare the cost per token.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
 						},
 					},
-					"containerConcurrency": {
+					"candidateCostPerHour": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).",
-							Type:        []string{"integer"},
+							Description: "CandidateCostPerHour is the hourly cost of serving the candidate endpoint.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"candidate"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EndpointSpec", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_ComponentExtensionSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ComponentExtensionSpec defines the deployment configuration for a given InferenceService component",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"minReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Minimum number of replicas, defaults to 1 but can be set to 0 to enable scale-to-zero.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Maximum number of replicas for autoscaling.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleTarget": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleTarget specifies the integer target value of the metric type the Autoscaler watches for. concurrency and rps targets are supported by Knative Pod Autoscaler (https://knative.dev/docs/serving/autoscaling/autoscaling-targets/).",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleMetric": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleMetric defines the scaling metric type watched by autoscaler possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"containerConcurrency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).",
+							Type:        []string{"integer"},
 							Format:      "int64",
 						},
 					},
@@ -2007,6 +2366,49 @@ func schema_pkg_apis_ome_v1beta1_ComponentStatusSpec(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_ome_v1beta1_ConversationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConversationSpec configures multi-turn chat sessions so benchmarks reflect realistic chat serving behavior, including prompt growth and KV-cache reuse across turns.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"numTurns": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumTurns is the number of user turns in each conversation.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"historyMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HistoryMode controls how previous turns are resent with each request. Defaults to \"Full\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"historyWindow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HistoryWindow is the number of previous turns kept in the context. It is required when HistoryMode is \"SlidingWindow\".",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"systemPrompt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SystemPrompt is prepended to every conversation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"numTurns"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_ome_v1beta1_DecoderSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3632,6 +4034,36 @@ func schema_pkg_apis_ome_v1beta1_FineTunedWeightSpec(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_ome_v1beta1_HistogramBucket(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HistogramBucket is a single bucket of a latency histogram.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"upperBound": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpperBound is the inclusive upper bound of the bucket.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of requests whose latency falls into the bucket.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"upperBound", "count"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_ome_v1beta1_HuggingFaceSecretReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3817,6 +4249,25 @@ func schema_pkg_apis_ome_v1beta1_InferenceServiceSpec(ref common.ReferenceCallba
 							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ServingRuntimeRef"),
 						},
 					},
+					"runtimeVersionConstraints": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "RuntimeVersionConstraints restricts the engine versions of the runtimes that can be selected or referenced for this InferenceService, in addition to the constraints of the model",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeVersionConstraint"),
+									},
+								},
+							},
+						},
+					},
 					"router": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Router defines the router spec",
@@ -3839,7 +4290,7 @@ func schema_pkg_apis_ome_v1beta1_InferenceServiceSpec(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorSelector", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DecoderSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EngineSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.KedaConfig", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelRef", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.PredictorSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RouterSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ServingRuntimeRef"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorSelector", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DecoderSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EngineSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.KedaConfig", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelRef", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.PredictorSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RouterSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeVersionConstraint", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ServingRuntimeRef"},
 	}
 }
 
@@ -3927,11 +4378,148 @@ func schema_pkg_apis_ome_v1beta1_InferenceServiceStatus(ref common.ReferenceCall
 							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelStatus"),
 						},
 					},
+					"estimatedHourlyCost": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EstimatedHourlyCost is the sum of the estimated hourly costs of the accelerators selected for the components",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"selectedRuntime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SelectedRuntime shows which runtime serves the model",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeSelection"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComponentStatusSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelStatus", "knative.dev/pkg/apis.Condition", "knative.dev/pkg/apis.URL", "knative.dev/pkg/apis/duck/v1.Addressable"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ComponentStatusSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelStatus", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeSelection", "k8s.io/apimachinery/pkg/api/resource.Quantity", "knative.dev/pkg/apis.Condition", "knative.dev/pkg/apis.URL", "knative.dev/pkg/apis/duck/v1.Addressable"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_IterationComparison(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IterationComparison contains the relative change, in percent, of the candidate endpoint against the baseline endpoint for a single iteration. Positive values mean the candidate measured higher.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"scenario": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scenario is the traffic scenario of the iteration.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"concurrency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Concurrency is the number of concurrent requests of the iteration.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"outputThroughputChange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OutputThroughputChange is the relative change of the output token throughput.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"timeToFirstTokenChange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeToFirstTokenChange is the relative change of the median time to first token.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"interTokenLatencyChange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InterTokenLatencyChange is the relative change of the median inter-token latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"endToEndLatencyChange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndToEndLatencyChange is the relative change of the median end-to-end latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"costPerTokenChange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CostPerTokenChange is the relative change of the cost per output token. It is only set when the hourly cost of both endpoints is known.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"scenario", "concurrency"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_IterationMetrics(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IterationMetrics contains the latency metrics measured for a single benchmark iteration.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"scenario": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scenario is the traffic scenario of the iteration.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"concurrency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Concurrency is the number of concurrent requests of the iteration.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"numRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NumRequests is the number of requests completed during the iteration.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"outputThroughput": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OutputThroughput is the number of output tokens generated per second.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"timeToFirstToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeToFirstToken is the distribution of the time to first token (TTFT).",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.LatencyDistribution"),
+						},
+					},
+					"interTokenLatency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InterTokenLatency is the distribution of the latency between consecutive output tokens.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.LatencyDistribution"),
+						},
+					},
+					"endToEndLatency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndToEndLatency is the distribution of the end-to-end request latency.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.LatencyDistribution"),
+						},
+					},
+				},
+				Required: []string{"scenario", "concurrency"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.LatencyDistribution", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -3998,6 +4586,76 @@ func schema_pkg_apis_ome_v1beta1_KedaConfig(ref common.ReferenceCallback) common
 	}
 }
 
+func schema_pkg_apis_ome_v1beta1_LatencyDistribution(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LatencyDistribution summarizes a latency distribution with its mean, percentiles and histogram.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mean": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mean is the mean latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"p50": {
+						SchemaProps: spec.SchemaProps{
+							Description: "P50 is the median latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"p90": {
+						SchemaProps: spec.SchemaProps{
+							Description: "P90 is the 90th percentile latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"p95": {
+						SchemaProps: spec.SchemaProps{
+							Description: "P95 is the 95th percentile latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"p99": {
+						SchemaProps: spec.SchemaProps{
+							Description: "P99 is the 99th percentile latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"max": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Max is the maximum latency.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"histogram": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Histogram is the number of requests in each latency bucket, ordered by upper bound.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.HistogramBucket"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.HistogramBucket", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_ome_v1beta1_LeaderSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4461,29 +5119,155 @@ func schema_pkg_apis_ome_v1beta1_LeaderSpec(ref common.ReferenceCallback) common
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Description: "ResourceClaims defines which ResourceClaims must be allocated and reserved before the Pod is allowed to start. The resources will be made available to those containers which consume them by name.\n\nThis is an alpha field and requires enabling the DynamicResourceAllocation feature gate.\n\nThis field is immutable.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("k8s.io/api/core/v1.PodResourceClaim"),
-									},
-								},
-							},
+							Description: "ResourceClaims defines which ResourceClaims must be allocated and reserved before the Pod is allowed to start. The resources will be made available to those containers which consume them by name.\n\nThis is an alpha field and requires enabling the DynamicResourceAllocation feature gate.\n\nThis field is immutable.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.PodResourceClaim"),
+									},
+								},
+							},
+						},
+					},
+					"runner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Runner container override for customizing the main container This is essentially a container spec that can override the default container Provides fine-grained control over the container that executes the leader node's coordination logic.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RunnerSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RunnerSpec", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_MIGProfile(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MIGProfile describes a Multi-Instance GPU profile, a fractional GPU with dedicated memory and compute slices",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the profile (e.g., 1g.10gb, 3g.40gb)",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceName is the extended resource exposed for this profile (e.g., nvidia.com/mig-1g.10gb)",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"memoryGB": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Memory capacity of a single instance in GB",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"computeSlices": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ComputeSlices is the number of compute slices of a single instance, out of the seven slices of a GPU",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name", "resourceName", "computeSlices"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_MIGProfileStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MIGProfileStatus reports the capacity of a MIG profile, in instances of the profile",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the profile",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"totalInstances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total number of instances of the profile in the cluster",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"allocatableInstances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Instances that can be allocated to pods",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"usedInstances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Instances requested by running and pending pods",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"availableInstances": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Instances that are not allocated",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_ModelConfigWarning(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ModelConfigWarning is an inconsistency found in the configuration of a model, such as attention heads that don't divide the hidden size. The model may still load, but runtimes may reject it.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"field": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field is the configuration field the warning is about, e.g. \"num_key_value_heads\"",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"runner": {
+					"message": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Runner container override for customizing the main container This is essentially a container spec that can override the default container Provides fine-grained control over the container that executes the leader node's coordination logic.",
-							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RunnerSpec"),
+							Description: "Message describes the inconsistency",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 				},
+				Required: []string{"field", "message"},
 			},
 		},
-		Dependencies: []string{
-			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RunnerSpec", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -5202,10 +5986,31 @@ func schema_pkg_apis_ome_v1beta1_ModelStatusSpec(ref common.ReferenceCallback) c
 							},
 						},
 					},
+					"configWarnings": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigWarnings lists the inconsistencies the metadata extraction found in the configuration of the model",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelConfigWarning"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"state"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelConfigWarning"},
 	}
 }
 
@@ -6794,6 +7599,34 @@ func schema_pkg_apis_ome_v1beta1_PredictorSpec(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_pkg_apis_ome_v1beta1_ReplaySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ReplaySpec configures the replay of a recorded request trace. The trace is read from the dataset and records, for every request, its arrival timestamp, prompt and output lengths, and whether it was streamed. Requests are sent at the recorded arrival times, which is far more representative of production traffic than synthetic arrival processes.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timeScale": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeScale scales the recorded inter-arrival times, e.g. 0.5 replays the trace twice as fast. Defaults to 1.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"maxDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDuration is the maximum duration of the trace to replay, in seconds, starting from the first request. If not set, the whole trace is replayed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_pkg_apis_ome_v1beta1_RouterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -7701,6 +8534,95 @@ func schema_pkg_apis_ome_v1beta1_RunnerSpec(ref common.ReferenceCallback) common
 	}
 }
 
+func schema_pkg_apis_ome_v1beta1_RuntimeEngineVersion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuntimeEngineVersion identifies the inference engine of a runtime and its version",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the inference engine, e.g. \"sglang\" or \"vllm\"",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version of the inference engine, e.g. \"0.4.6\"",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "version"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_RuntimeSelection(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuntimeSelection shows what runtime was selected",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the runtime",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the runtime, ServingRuntime or ClusterServingRuntime",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "kind"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_RuntimeVersionConstraint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuntimeVersionConstraint restricts the engine version of a runtime",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"engine": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Engine is the name of the inference engine the constraint applies to, e.g. \"sglang\". Runtimes of other engines are not restricted. If empty, the constraint applies to every runtime.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is a version range made of space or comma separated comparisons that must all hold, e.g. \">=0.4.3 <0.5\". Supported operators are =, !=, >, >=, < and <=; a bare version means =.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"version"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_ome_v1beta1_ScalerAuthenticationRef(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -8354,11 +9276,17 @@ func schema_pkg_apis_ome_v1beta1_ServingRuntimeSpec(ref common.ReferenceCallback
 							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorRequirements"),
 						},
 					},
+					"engineVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EngineVersion is the inference engine and version this runtime runs, e.g. sglang 0.4.6. It is matched against the runtime version constraints of models and InferenceServices.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeEngineVersion"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorRequirements", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DecoderSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EngineSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelSizeRangeSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RouterSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.SupportedModelFormat", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.WorkerPodSpec", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume"},
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.AcceleratorRequirements", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.DecoderSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.EngineSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ModelSizeRangeSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RouterSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RuntimeEngineVersion", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.SupportedModelFormat", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.WorkerPodSpec", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.Volume"},
 	}
 }
 
@@ -8513,9 +9441,29 @@ func schema_pkg_apis_ome_v1beta1_SupportedModelFormat(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"supportedArchitectures": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SupportedArchitectures lists the model architectures this format can serve. Entries are architecture names such as \"LlamaForCausalLM\", glob patterns such as \"Qwen3*ForCausalLM\", or architecture families known to the model config parser such as \"family:qwen2\" or \"family:qwen*\". When set, it takes precedence over ModelArchitecture.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 					"quantization": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Quantization of the model, e.g., \"fp8\", \"fbgemm_fp8\", \"int4\"",
+							Description: "Quantization of the model, e.g., \"fp8\", \"fbgemm_fp8\", \"int4\", \"awq\", \"gptq\"",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -8626,6 +9574,92 @@ func schema_pkg_apis_ome_v1beta1_TensorParallelismConfig(ref common.ReferenceCal
 	}
 }
 
+func schema_pkg_apis_ome_v1beta1_TrafficPhase(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TrafficPhase defines the load of a single phase of a traffic schedule. Exactly one of RequestRate or Concurrency must be set, and all phases of a schedule must use the same one.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is an optional name of the phase used in the results.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the duration of the phase, in seconds.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"shape": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Shape is the shape of the load within the phase. Defaults to \"Constant\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"requestRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestRate is the target number of requests per second of the phase.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"concurrency": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Concurrency is the target number of concurrent requests of the phase.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"duration"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_ome_v1beta1_TrafficScheduleSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TrafficScheduleSpec defines a load shape as an ordered list of phases. Steps are expressed as consecutive constant phases, and spikes as a short constant phase with a higher target surrounded by phases with a lower target.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phases": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Phases are run in order.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.TrafficPhase"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"phases"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.TrafficPhase"},
+	}
+}
+
 func schema_pkg_apis_ome_v1beta1_WorkerPodSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -9315,3 +10349,37 @@ func schema_pkg_apis_ome_v1beta1_WorkerSpec(ref common.ReferenceCallback) common
 			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.RunnerSpec", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
+
+func schema_pkg_apis_ome_v1beta1_WorkloadSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkloadSpec defines the shape of the traffic generated by a BenchmarkJob.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type specifies the workload type. Defaults to \"SingleTurn\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conversation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conversation configures multi-turn chat sessions. It is required when Type is \"Conversation\" and only supported for the \"text-to-text\" task.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ConversationSpec"),
+						},
+					},
+					"replay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replay configures the replay of a recorded request trace read from the dataset. It is only used when Type is \"Replay\" and only supported for the \"text-to-text\" task.",
+							Ref:         ref("github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ReplaySpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ConversationSpec", "github.com/sgl-project/ome/pkg/apis/ome/v1beta1.ReplaySpec"},
+	}
+}
@@ -0,0 +1,47 @@
+package runtimeselector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/hfutil/modelconfig"
+)
+
+// ArchitectureFamilyPrefix marks a SupportedArchitectures entry that matches the architecture family
+// (the Hugging Face model_type, e.g. "qwen2") rather than the architecture class name.
+const ArchitectureFamilyPrefix = "family:"
+
+// MatchesArchitecture reports whether a model architecture matches any of the SupportedArchitectures
+// entries of a supported model format. Entries are architecture names, glob patterns on the
+// architecture name, or "family:" glob patterns on the architecture family.
+func MatchesArchitecture(supported []string, architecture string) bool {
+	family := ""
+	for _, entry := range supported {
+		if pattern, ok := strings.CutPrefix(entry, ArchitectureFamilyPrefix); ok {
+			if family == "" {
+				family = modelconfig.GetArchitectureFamily(architecture)
+			}
+			if matched, _ := path.Match(strings.ToLower(pattern), family); matched {
+				return true
+			}
+			continue
+		}
+		if matched, _ := path.Match(entry, architecture); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateArchitecturePattern returns an error if a SupportedArchitectures entry is empty or not a valid pattern.
+func ValidateArchitecturePattern(entry string) error {
+	pattern := strings.TrimPrefix(entry, ArchitectureFamilyPrefix)
+	if pattern == "" {
+		return fmt.Errorf("supported architecture %q must not be empty", entry)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("supported architecture %q is not a valid pattern: %w", entry, err)
+	}
+	return nil
+}
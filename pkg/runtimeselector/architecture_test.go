@@ -0,0 +1,123 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestMatchesArchitecture(t *testing.T) {
+	tests := []struct {
+		name         string
+		supported    []string
+		architecture string
+		want         bool
+	}{
+		{
+			name:         "exact name",
+			supported:    []string{"LlamaForCausalLM"},
+			architecture: "LlamaForCausalLM",
+			want:         true,
+		},
+		{
+			name:         "glob on name",
+			supported:    []string{"Qwen3*ForCausalLM"},
+			architecture: "Qwen3MoeForCausalLM",
+			want:         true,
+		},
+		{
+			name:         "family",
+			supported:    []string{"family:qwen2"},
+			architecture: "Qwen2ForCausalLM",
+			want:         true,
+		},
+		{
+			name:         "family glob with registered separators",
+			supported:    []string{"family:deepseek*"},
+			architecture: "DeepseekV3ForCausalLM",
+			want:         true,
+		},
+		{
+			name:         "family of unregistered model type",
+			supported:    []string{"family:newarch"},
+			architecture: "NewArchForCausalLM",
+			want:         true,
+		},
+		{
+			name:         "no match",
+			supported:    []string{"LlamaForCausalLM", "family:mistral"},
+			architecture: "Qwen2ForCausalLM",
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchesArchitecture(tt.supported, tt.architecture))
+		})
+	}
+}
+
+func TestValidateArchitecturePattern(t *testing.T) {
+	assert.NoError(t, ValidateArchitecturePattern("LlamaForCausalLM"))
+	assert.NoError(t, ValidateArchitecturePattern("family:qwen*"))
+	assert.Error(t, ValidateArchitecturePattern(""))
+	assert.Error(t, ValidateArchitecturePattern("family:"))
+	assert.Error(t, ValidateArchitecturePattern("Qwen[3ForCausalLM"))
+}
+
+func TestSelectRuntime_SupportedArchitectures(t *testing.T) {
+	fakeClient := createFakeClient()
+	selector := New(fakeClient)
+	ctx := context.Background()
+
+	runtimes := []*v1beta1.ClusterServingRuntime{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-llama"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{
+						ModelFormat:            &v1beta1.ModelFormat{Name: "safetensors", Weight: 10},
+						SupportedArchitectures: []string{"LlamaForCausalLM"},
+						AutoSelect:             ptr(true),
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-qwen"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{
+						ModelFormat:            &v1beta1.ModelFormat{Name: "safetensors", Weight: 10},
+						SupportedArchitectures: []string{"family:qwen*"},
+						AutoSelect:             ptr(true),
+					},
+				},
+			},
+		},
+	}
+	for _, rt := range runtimes {
+		require.NoError(t, fakeClient.Create(ctx, rt))
+	}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	model := &v1beta1.BaseModelSpec{
+		ModelFormat:       v1beta1.ModelFormat{Name: "safetensors"},
+		ModelArchitecture: ptr("Qwen2ForCausalLM"),
+	}
+	matches, err := selector.GetCompatibleRuntimes(ctx, model, isvc, "default")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "rt-qwen", matches[0].Name)
+
+	model.ModelArchitecture = ptr("MistralForCausalLM")
+	matches, err = selector.GetCompatibleRuntimes(ctx, model, isvc, "default")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
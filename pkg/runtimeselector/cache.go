@@ -0,0 +1,123 @@
+package runtimeselector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// DefaultSelectionCacheSize is the default number of selections kept by a SelectionCache.
+const DefaultSelectionCacheSize = 1024
+
+// SelectionCache caches SelectRuntime decisions so that reconciling an unchanged InferenceService doesn't
+// re-list and re-score every runtime. Entries are keyed by the model, the selection constraints of the
+// InferenceService and the runtime generation, which Invalidate advances whenever a ServingRuntime,
+// ClusterServingRuntime or AcceleratorClass changes.
+type SelectionCache struct {
+	mu         sync.RWMutex
+	generation uint64
+	entries    map[string]RuntimeSelection
+	maxEntries int
+}
+
+// NewSelectionCache creates a SelectionCache holding at most maxEntries selections.
+func NewSelectionCache(maxEntries int) *SelectionCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultSelectionCacheSize
+	}
+	return &SelectionCache{
+		entries:    make(map[string]RuntimeSelection),
+		maxEntries: maxEntries,
+	}
+}
+
+// Invalidate drops every cached selection and advances the runtime generation, so that selections
+// computed before the call are not cached afterwards.
+func (c *SelectionCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = make(map[string]RuntimeSelection)
+}
+
+// Generation returns the current runtime generation.
+func (c *SelectionCache) Generation() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// Len returns the number of cached selections.
+func (c *SelectionCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// get returns a copy of the cached selection for the key, so callers can't modify the cached spec.
+func (c *SelectionCache) get(key string) (*RuntimeSelection, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	selection, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	selection.Spec = selection.Spec.DeepCopy()
+	return &selection, true
+}
+
+// add caches a selection computed at the given runtime generation. Selections computed before the
+// last Invalidate are dropped since they may be based on runtimes that have changed since.
+func (c *SelectionCache) add(key string, generation uint64, selection *RuntimeSelection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if len(c.entries) >= c.maxEntries {
+		// Selections are cheap to recompute, so start over rather than tracking recency
+		c.entries = make(map[string]RuntimeSelection)
+	}
+	entry := *selection
+	entry.Spec = selection.Spec.DeepCopy()
+	c.entries[key] = entry
+}
+
+// selectionConstraints are the fields of an InferenceService that affect which runtime is selected.
+type selectionConstraints struct {
+	Namespace                  string
+	AcceleratorClassAnnotation string
+	AcceleratorSelector        *v1beta1.AcceleratorSelector
+	EngineAcceleratorClass     *string
+	DecoderAcceleratorClass    *string
+	RuntimeVersionConstraints  []v1beta1.RuntimeVersionConstraint
+}
+
+// selectionCacheKey returns the cache key of a selection for a model and InferenceService.
+func selectionCacheKey(model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) (string, error) {
+	constraints := selectionConstraints{
+		Namespace:                  isvc.Namespace,
+		AcceleratorClassAnnotation: isvc.Annotations["ome.io/accelerator-class"],
+		AcceleratorSelector:        isvc.Spec.AcceleratorSelector,
+		RuntimeVersionConstraints:  isvc.Spec.RuntimeVersionConstraints,
+	}
+	if isvc.Spec.Engine != nil && isvc.Spec.Engine.AcceleratorOverride != nil {
+		constraints.EngineAcceleratorClass = isvc.Spec.Engine.AcceleratorOverride.AcceleratorClass
+	}
+	if isvc.Spec.Decoder != nil && isvc.Spec.Decoder.AcceleratorOverride != nil {
+		constraints.DecoderAcceleratorClass = isvc.Spec.Decoder.AcceleratorOverride.AcceleratorClass
+	}
+
+	data, err := json.Marshal(struct {
+		Model       *v1beta1.BaseModelSpec
+		Constraints selectionConstraints
+	}{model, constraints})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,75 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func pytorchClusterRuntime(name string, weight int64) *v1beta1.ClusterServingRuntime {
+	spec := pytorchRuntime()
+	spec.SupportedModelFormats[0].ModelFormat.Weight = weight
+	return &v1beta1.ClusterServingRuntime{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: *spec}
+}
+
+func TestSelectRuntime_SelectionCache(t *testing.T) {
+	fakeClient := createFakeClient()
+	config := NewConfig(fakeClient)
+	config.SelectionCache = NewSelectionCache(DefaultSelectionCacheSize)
+	selector := NewWithConfig(config)
+	ctx := context.Background()
+
+	require.NoError(t, fakeClient.Create(ctx, pytorchClusterRuntime("rt-low", 1)))
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "pytorch"}}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	selection, err := selector.SelectRuntime(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-low", selection.Name)
+	assert.Equal(t, 1, config.SelectionCache.Len())
+
+	// Modifying the returned spec must not leak into the cache
+	selection.Spec.SupportedModelFormats = nil
+
+	// A better runtime is ignored until the cache is invalidated
+	require.NoError(t, fakeClient.Create(ctx, pytorchClusterRuntime("rt-high", 50)))
+	selection, err = selector.SelectRuntime(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-low", selection.Name)
+	assert.Len(t, selection.Spec.SupportedModelFormats, 1)
+
+	// Different constraints are cached separately
+	constrained := isvc.DeepCopy()
+	constrained.Spec.AcceleratorSelector = &v1beta1.AcceleratorSelector{Policy: v1beta1.BestFitPolicy}
+	selection, err = selector.SelectRuntime(ctx, model, constrained)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-high", selection.Name)
+	assert.Equal(t, 2, config.SelectionCache.Len())
+
+	config.SelectionCache.Invalidate()
+	assert.Equal(t, 0, config.SelectionCache.Len())
+	selection, err = selector.SelectRuntime(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-high", selection.Name)
+}
+
+func TestSelectionCache_DropsStaleSelections(t *testing.T) {
+	cache := NewSelectionCache(1)
+	generation := cache.Generation()
+	cache.Invalidate()
+
+	// A selection computed before the invalidation is not cached
+	cache.add("a", generation, &RuntimeSelection{Name: "rt", Spec: pytorchRuntime()})
+	assert.Equal(t, 0, cache.Len())
+
+	cache.add("a", cache.Generation(), &RuntimeSelection{Name: "rt", Spec: pytorchRuntime()})
+	cache.add("b", cache.Generation(), &RuntimeSelection{Name: "rt", Spec: pytorchRuntime()})
+	assert.Equal(t, 1, cache.Len())
+	_, ok := cache.get("b")
+	assert.True(t, ok)
+}
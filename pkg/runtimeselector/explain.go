@@ -0,0 +1,124 @@
+package runtimeselector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// SelectionExplanation reports how runtime selection evaluated every runtime for a model.
+type SelectionExplanation struct {
+	// Selected is the name of the runtime that would be selected, empty if no runtime is compatible
+	Selected string
+
+	// Runtimes lists the compatible runtimes in selection order, followed by the rejected runtimes
+	Runtimes []RuntimeExplanation
+}
+
+// RuntimeExplanation describes the outcome of evaluating a single runtime.
+type RuntimeExplanation struct {
+	// Name is the name of the runtime
+	Name string
+
+	// IsCluster indicates if this is a ClusterServingRuntime
+	IsCluster bool
+
+	// Score is the total weighted score, zero for rejected runtimes
+	Score int64
+
+	// Scores holds the weighted score of each score plugin
+	Scores map[string]int64
+
+	// RejectionReason explains why the runtime was rejected, empty for compatible runtimes
+	RejectionReason string
+}
+
+type runtimeKey struct {
+	name      string
+	isCluster bool
+}
+
+// Explain evaluates every runtime visible to the InferenceService without selecting one.
+func (s *defaultSelector) Explain(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) (*SelectionExplanation, error) {
+	if err := s.validateModel(model); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.fetcher.FetchRuntimes(ctx, isvc.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runtimes: %w", err)
+	}
+
+	var namespaceMatches, clusterMatches []RuntimeMatch
+	var rejected []RuntimeExplanation
+	explanations := make(map[runtimeKey]RuntimeExplanation)
+
+	for _, runtime := range collection.NamespaceRuntimes {
+		match, explanation := s.explainRuntime(ctx, &runtime.Spec, model, isvc, runtime.Name, runtime.Labels, false)
+		if match == nil {
+			rejected = append(rejected, explanation)
+			continue
+		}
+		namespaceMatches = append(namespaceMatches, *match)
+		explanations[runtimeKey{name: runtime.Name}] = explanation
+	}
+
+	for _, runtime := range collection.ClusterRuntimes {
+		match, explanation := s.explainRuntime(ctx, &runtime.Spec, model, isvc, runtime.Name, runtime.Labels, true)
+		if match == nil {
+			rejected = append(rejected, explanation)
+			continue
+		}
+		clusterMatches = append(clusterMatches, *match)
+		explanations[runtimeKey{name: runtime.Name, isCluster: true}] = explanation
+	}
+
+	// Compatible runtimes are listed in the same order SelectRuntime ranks them
+	s.sortMatches(namespaceMatches, model)
+	s.sortMatches(clusterMatches, model)
+
+	result := &SelectionExplanation{}
+	for _, match := range append(namespaceMatches, clusterMatches...) {
+		result.Runtimes = append(result.Runtimes, explanations[runtimeKey{name: match.Name, isCluster: match.IsCluster}])
+	}
+	if len(result.Runtimes) > 0 {
+		result.Selected = result.Runtimes[0].Name
+	}
+
+	sort.SliceStable(rejected, func(i, j int) bool { return rejected[i].Name < rejected[j].Name })
+	result.Runtimes = append(result.Runtimes, rejected...)
+	return result, nil
+}
+
+// Lines formats the explanation as one human-readable line per runtime.
+func (e *SelectionExplanation) Lines() []string {
+	lines := make([]string, 0, len(e.Runtimes))
+	for i, rt := range e.Runtimes {
+		kind := RuntimeKind(rt.IsCluster)
+		if rt.RejectionReason != "" {
+			lines = append(lines, fmt.Sprintf("%s %s: rejected: %s", kind, rt.Name, rt.RejectionReason))
+			continue
+		}
+
+		names := make([]string, 0, len(rt.Scores))
+		for name := range rt.Scores {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%s=%d", name, rt.Scores[name]))
+		}
+
+		line := fmt.Sprintf("%s %s: score %d (%s)", kind, rt.Name, rt.Score, strings.Join(parts, ", "))
+		// The selected runtime is always listed first
+		if i == 0 && e.Selected != "" {
+			line += " [selected]"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
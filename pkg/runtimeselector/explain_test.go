@@ -0,0 +1,89 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestExplain(t *testing.T) {
+	fakeClient := createFakeClient()
+	selector := New(fakeClient)
+	ctx := context.Background()
+
+	runtimes := []*v1beta1.ServingRuntime{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-low", Namespace: "default"},
+			Spec:       *pytorchRuntime(),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-high", Namespace: "default"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "pytorch", Weight: 10}, Priority: ptr(int32(2)), AutoSelect: ptr(true)},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-disabled", Namespace: "default"},
+			Spec:       v1beta1.ServingRuntimeSpec{Disabled: ptr(true)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-onnx", Namespace: "default"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "onnx", Weight: 5}, AutoSelect: ptr(true)},
+				},
+			},
+		},
+	}
+	for _, rt := range runtimes {
+		require.NoError(t, fakeClient.Create(ctx, rt))
+	}
+
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "pytorch"}}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	explanation, err := selector.Explain(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-high", explanation.Selected)
+	require.Len(t, explanation.Runtimes, 4)
+
+	assert.Equal(t, "rt-high", explanation.Runtimes[0].Name)
+	assert.Equal(t, int64(20), explanation.Runtimes[0].Score)
+	assert.Equal(t, map[string]int64{ModelFormatScorerName: 20}, explanation.Runtimes[0].Scores)
+	assert.Equal(t, "rt-low", explanation.Runtimes[1].Name)
+	assert.Equal(t, "rt-disabled", explanation.Runtimes[2].Name)
+	assert.Equal(t, "runtime is disabled", explanation.Runtimes[2].RejectionReason)
+	assert.Equal(t, "rt-onnx", explanation.Runtimes[3].Name)
+	assert.NotEmpty(t, explanation.Runtimes[3].RejectionReason)
+
+	// The selection explained must be the one SelectRuntime makes
+	selection, err := selector.SelectRuntime(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, explanation.Selected, selection.Name)
+
+	lines := explanation.Lines()
+	require.Len(t, lines, 4)
+	assert.Equal(t, "ServingRuntime rt-high: score 20 (ModelFormat=20) [selected]", lines[0])
+	assert.Equal(t, "ServingRuntime rt-low: score 10 (ModelFormat=10)", lines[1])
+	assert.Equal(t, "ServingRuntime rt-disabled: rejected: runtime is disabled", lines[2])
+}
+
+func TestExplain_NoCompatibleRuntime(t *testing.T) {
+	selector := New(createFakeClient())
+
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "pytorch"}}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	explanation, err := selector.Explain(context.Background(), model, isvc)
+	require.NoError(t, err)
+	assert.Empty(t, explanation.Selected)
+	assert.Empty(t, explanation.Runtimes)
+	assert.Empty(t, explanation.Lines())
+}
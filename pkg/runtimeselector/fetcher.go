@@ -63,6 +63,12 @@ func (f *DefaultRuntimeFetcher) FetchRuntimes(ctx context.Context, namespace str
 // It first checks namespace-scoped runtimes, then cluster-scoped ones.
 // Returns the runtime spec, whether it's a cluster runtime, and any error.
 func (f *DefaultRuntimeFetcher) GetRuntime(ctx context.Context, name string, namespace string) (*v1beta1.ServingRuntimeSpec, bool, error) {
+	spec, _, isCluster, err := f.GetRuntimeWithLabels(ctx, name, namespace)
+	return spec, isCluster, err
+}
+
+// GetRuntimeWithLabels fetches a specific runtime by name like GetRuntime, and also returns its labels.
+func (f *DefaultRuntimeFetcher) GetRuntimeWithLabels(ctx context.Context, name string, namespace string) (*v1beta1.ServingRuntimeSpec, map[string]string, bool, error) {
 	logger := log.FromContext(ctx)
 
 	// First, try to get namespace-scoped runtime
@@ -70,11 +76,11 @@ func (f *DefaultRuntimeFetcher) GetRuntime(ctx context.Context, name string, nam
 	err := f.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, runtime)
 	if err == nil {
 		logger.V(1).Info("Found namespace-scoped runtime", "name", name, "namespace", namespace)
-		return &runtime.Spec, false, nil
+		return &runtime.Spec, runtime.Labels, false, nil
 	}
 
 	if !errors.IsNotFound(err) {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	// If not found, try cluster-scoped runtime
@@ -82,17 +88,17 @@ func (f *DefaultRuntimeFetcher) GetRuntime(ctx context.Context, name string, nam
 	err = f.client.Get(ctx, client.ObjectKey{Name: name}, clusterRuntime)
 	if err == nil {
 		logger.V(1).Info("Found cluster-scoped runtime", "name", name)
-		return &clusterRuntime.Spec, true, nil
+		return &clusterRuntime.Spec, clusterRuntime.Labels, true, nil
 	}
 
 	if errors.IsNotFound(err) {
-		return nil, false, &RuntimeNotFoundError{
+		return nil, nil, false, &RuntimeNotFoundError{
 			RuntimeName: name,
 			Namespace:   namespace,
 		}
 	}
 
-	return nil, false, err
+	return nil, nil, false, err
 }
 
 // sortServingRuntimeList sorts a list of ServingRuntimes by creation timestamp (desc) and name (asc).
@@ -110,9 +110,9 @@ func (m *DefaultRuntimeMatcher) GetCompatibilityDetails(runtime *v1beta1.Serving
 
 	// Check model size compatibility
 	if model.ModelParameterSize != nil && runtime.ModelSizeRange != nil {
-		modelSize := parseModelSize(*model.ModelParameterSize)
-		minSize := parseModelSize(*runtime.ModelSizeRange.Min)
-		maxSize := parseModelSize(*runtime.ModelSizeRange.Max)
+		modelSize := ParseModelSize(*model.ModelParameterSize)
+		minSize := ParseModelSize(*runtime.ModelSizeRange.Min)
+		maxSize := ParseModelSize(*runtime.ModelSizeRange.Max)
 
 		if modelSize < minSize || modelSize > maxSize {
 			report.IncompatibilityReasons = append(report.IncompatibilityReasons,
@@ -188,7 +188,12 @@ func (m *DefaultRuntimeMatcher) evaluateFormatMatch(model *v1beta1.BaseModelSpec
 	}
 
 	// Check architecture
-	if model.ModelArchitecture != nil && format.ModelArchitecture != nil {
+	if len(format.SupportedArchitectures) > 0 {
+		match.ArchitectureMatch = model.ModelArchitecture != nil && MatchesArchitecture(format.SupportedArchitectures, *model.ModelArchitecture)
+		if !match.ArchitectureMatch {
+			match.Reasons = append(match.Reasons, "architecture not in supported architectures")
+		}
+	} else if model.ModelArchitecture != nil && format.ModelArchitecture != nil {
 		match.ArchitectureMatch = *model.ModelArchitecture == *format.ModelArchitecture
 		if !match.ArchitectureMatch {
 			match.Reasons = append(match.Reasons,
@@ -305,7 +310,11 @@ func (m *DefaultRuntimeMatcher) compareSupportedModelFormats(model *v1beta1.Base
 	}
 
 	// Check architecture
-	if model.ModelArchitecture != nil && format.ModelArchitecture != nil {
+	if len(format.SupportedArchitectures) > 0 {
+		if model.ModelArchitecture == nil || !MatchesArchitecture(format.SupportedArchitectures, *model.ModelArchitecture) {
+			return false
+		}
+	} else if model.ModelArchitecture != nil && format.ModelArchitecture != nil {
 		if *model.ModelArchitecture != *format.ModelArchitecture {
 			return false
 		}
@@ -374,7 +383,15 @@ func (m *DefaultRuntimeMatcher) getFormatMismatchReason(model *v1beta1.BaseModel
 	}
 
 	// Check architecture mismatch
-	if model.ModelArchitecture != nil && format.ModelArchitecture != nil {
+	if len(format.SupportedArchitectures) > 0 {
+		if model.ModelArchitecture == nil {
+			reasons = append(reasons, fmt.Sprintf("model has no architecture but runtime requires one of [%s]",
+				strings.Join(format.SupportedArchitectures, ", ")))
+		} else if !MatchesArchitecture(format.SupportedArchitectures, *model.ModelArchitecture) {
+			reasons = append(reasons, fmt.Sprintf("architecture %s not in supported architectures [%s]",
+				*model.ModelArchitecture, strings.Join(format.SupportedArchitectures, ", ")))
+		}
+	} else if model.ModelArchitecture != nil && format.ModelArchitecture != nil {
 		if *model.ModelArchitecture != *format.ModelArchitecture {
 			reasons = append(reasons, fmt.Sprintf("architecture mismatch (model=%s, runtime=%s)",
 				*model.ModelArchitecture, *format.ModelArchitecture))
@@ -623,9 +640,9 @@ func (m *DefaultRuntimeMatcher) checkModelSize(runtime *v1beta1.ServingRuntimeSp
 		return nil
 	}
 
-	modelSize := parseModelSize(*model.ModelParameterSize)
-	minSize := parseModelSize(*runtime.ModelSizeRange.Min)
-	maxSize := parseModelSize(*runtime.ModelSizeRange.Max)
+	modelSize := ParseModelSize(*model.ModelParameterSize)
+	minSize := ParseModelSize(*runtime.ModelSizeRange.Min)
+	maxSize := ParseModelSize(*runtime.ModelSizeRange.Max)
 
 	if modelSize < minSize || modelSize > maxSize {
 		return &RuntimeCompatibilityError{
@@ -650,8 +667,8 @@ func getRuntimeSelectorOperator(operator *v1beta1.RuntimeSelectorOperator) strin
 	return string(*operator)
 }
 
-// parseModelSize converts a model size string (e.g., "7B", "13B", "70B") to a float64 value.
-func parseModelSize(sizeStr string) float64 {
+// ParseModelSize converts a model size string (e.g., "7B", "13B", "70B") to a float64 value.
+func ParseModelSize(sizeStr string) float64 {
 	var multiplier float64 = 1
 
 	switch {
@@ -664,6 +681,9 @@ func parseModelSize(sizeStr string) float64 {
 	case strings.HasSuffix(sizeStr, "M"):
 		multiplier = 1_000_000
 		sizeStr = strings.TrimSuffix(sizeStr, "M")
+	case strings.HasSuffix(sizeStr, "K"):
+		multiplier = 1_000
+		sizeStr = strings.TrimSuffix(sizeStr, "K")
 	}
 
 	size, err := strconv.ParseFloat(sizeStr, 64)
@@ -0,0 +1,266 @@
+package runtimeselector
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// Names of the built-in plugins. Scorer names are the keys used to configure scorer weights.
+const (
+	QuantizationFilterName   = "Quantization"
+	TaskFilterName           = "Task"
+	RuntimeVersionFilterName = "RuntimeVersion"
+	CompatibilityFilterName  = "Compatibility"
+	AutoSelectFilterName     = "AutoSelect"
+	ModelFormatFilterName    = "ModelFormatMatch"
+	ModelFormatScorerName    = "ModelFormat"
+	ModelSizeScorerName      = "ModelSize"
+	AcceleratorFitScorerName = "AcceleratorFit"
+)
+
+// MaxPluginScore is the highest score a normalized scorer plugin returns.
+const MaxPluginScore int64 = 100
+
+// Candidate is a runtime being evaluated by the plugin pipeline for a model.
+type Candidate struct {
+	// Name is the name of the runtime
+	Name string
+
+	// Spec is the runtime specification
+	Spec *v1beta1.ServingRuntimeSpec
+
+	// IsCluster indicates if this is a ClusterServingRuntime
+	IsCluster bool
+
+	// Labels are the labels of the runtime, used for capability labels
+	Labels map[string]string
+
+	// Model is the model being served
+	Model *v1beta1.BaseModelSpec
+
+	// InferenceService is the service requesting the runtime, may be nil
+	InferenceService *v1beta1.InferenceService
+
+	// Report is the compatibility report filled in by the compatibility filter
+	Report *CompatibilityReport
+}
+
+// FilterPlugin removes runtimes that cannot serve a model.
+type FilterPlugin interface {
+	// Name returns the name of the plugin
+	Name() string
+
+	// Filter returns an empty string if the candidate passes, or the reason it was rejected.
+	Filter(ctx context.Context, candidate *Candidate) (string, error)
+}
+
+// ScorePlugin ranks runtimes that passed all filters. Higher scores indicate better matches.
+type ScorePlugin interface {
+	// Name returns the name of the plugin, used as the key for its configured weight
+	Name() string
+
+	// Score returns the score of the candidate
+	Score(ctx context.Context, candidate *Candidate) (int64, error)
+}
+
+// WeightedScorer is a ScorePlugin with the weight applied to its score.
+type WeightedScorer struct {
+	ScorePlugin
+	Weight int64
+}
+
+// Pipeline runs the filter plugins in order and sums the weighted scores of the score plugins.
+type Pipeline struct {
+	Filters []FilterPlugin
+	Scorers []WeightedScorer
+}
+
+// DefaultScorerWeights returns the weights of the built-in scorers. The model format scorer
+// alone produces the historical selection scores; the other scorers are opt-in.
+func DefaultScorerWeights() map[string]int64 {
+	return map[string]int64{
+		ModelFormatScorerName:    1,
+		ModelSizeScorerName:      0,
+		AcceleratorFitScorerName: 0,
+	}
+}
+
+// NewDefaultPipeline builds the pipeline of built-in plugins. Weights in config.ScorerWeights
+// override the defaults; scorers with a weight of zero are skipped.
+func NewDefaultPipeline(config *Config, matcher RuntimeMatcher, scorer RuntimeScorer) *Pipeline {
+	weights := DefaultScorerWeights()
+	for name, weight := range config.ScorerWeights {
+		weights[name] = weight
+	}
+
+	pipeline := &Pipeline{
+		Filters: []FilterPlugin{
+			&quantizationFilter{},
+			&taskFilter{},
+			&runtimeVersionFilter{},
+			&compatibilityFilter{matcher: matcher},
+			&autoSelectFilter{},
+			&modelFormatFilter{scorer: scorer},
+		},
+	}
+	for _, plugin := range []ScorePlugin{
+		&modelFormatScorer{scorer: scorer},
+		&modelSizeScorer{},
+		&acceleratorFitScorer{},
+	} {
+		if weight := weights[plugin.Name()]; weight > 0 {
+			pipeline.Scorers = append(pipeline.Scorers, WeightedScorer{ScorePlugin: plugin, Weight: weight})
+		}
+	}
+	return pipeline
+}
+
+// Filter returns an empty string if the candidate passes all filters, or the first rejection reason.
+func (p *Pipeline) Filter(ctx context.Context, candidate *Candidate) (string, error) {
+	for _, f := range p.Filters {
+		reason, err := f.Filter(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("filter %s failed: %w", f.Name(), err)
+		}
+		if reason != "" {
+			return reason, nil
+		}
+	}
+	return "", nil
+}
+
+// Score returns the weighted sum of the scores of the candidate.
+func (p *Pipeline) Score(ctx context.Context, candidate *Candidate) (int64, error) {
+	total, _, err := p.ScoreDetails(ctx, candidate)
+	return total, err
+}
+
+// ScoreDetails returns the weighted sum of the scores of the candidate along with the
+// weighted score of each score plugin.
+func (p *Pipeline) ScoreDetails(ctx context.Context, candidate *Candidate) (int64, map[string]int64, error) {
+	var total int64
+	scores := make(map[string]int64, len(p.Scorers))
+	for _, s := range p.Scorers {
+		score, err := s.Score(ctx, candidate)
+		if err != nil {
+			return 0, nil, fmt.Errorf("scorer %s failed: %w", s.Name(), err)
+		}
+		scores[s.Name()] = s.Weight * score
+		total += s.Weight * score
+	}
+	return total, scores, nil
+}
+
+// compatibilityFilter rejects runtimes the matcher reports as incompatible with the model.
+// A model quantization the runtime supports through its capability labels is not matched per format.
+type compatibilityFilter struct {
+	matcher RuntimeMatcher
+}
+
+func (f *compatibilityFilter) Name() string { return CompatibilityFilterName }
+
+func (f *compatibilityFilter) Filter(_ context.Context, c *Candidate) (string, error) {
+	report, err := f.matcher.GetCompatibilityDetails(c.Spec, modelForFormatMatching(c.Labels, c.Model), c.InferenceService, c.Name)
+	if err != nil {
+		return "", err
+	}
+	c.Report = report
+	if !report.IsCompatible {
+		if len(report.IncompatibilityReasons) > 0 {
+			return report.IncompatibilityReasons[0], nil
+		}
+		return "runtime is not compatible with the model", nil
+	}
+	return "", nil
+}
+
+// autoSelectFilter rejects runtimes without any auto-selectable model format.
+type autoSelectFilter struct{}
+
+func (f *autoSelectFilter) Name() string { return AutoSelectFilterName }
+
+func (f *autoSelectFilter) Filter(_ context.Context, c *Candidate) (string, error) {
+	for i := range c.Spec.SupportedModelFormats {
+		if c.Spec.SupportedModelFormats[i].IsAutoSelectEnabled() {
+			return "", nil
+		}
+	}
+	return "runtime does not have auto-select enabled", nil
+}
+
+// modelFormatFilter rejects runtimes with no auto-selectable format matching the model, regardless
+// of the weight configured for the model format scorer.
+type modelFormatFilter struct {
+	scorer RuntimeScorer
+}
+
+func (f *modelFormatFilter) Name() string { return ModelFormatFilterName }
+
+func (f *modelFormatFilter) Filter(_ context.Context, c *Candidate) (string, error) {
+	score, err := f.scorer.CalculateScore(c.Spec, c.Model)
+	if err != nil {
+		return "", err
+	}
+	if score <= 0 {
+		return "no auto-selectable model format matches the model", nil
+	}
+	return "", nil
+}
+
+// modelFormatScorer scores runtimes by model format, framework and priority using the RuntimeScorer.
+type modelFormatScorer struct {
+	scorer RuntimeScorer
+}
+
+func (s *modelFormatScorer) Name() string { return ModelFormatScorerName }
+
+func (s *modelFormatScorer) Score(_ context.Context, c *Candidate) (int64, error) {
+	return s.scorer.CalculateScore(c.Spec, c.Model)
+}
+
+// modelSizeScorer prefers runtimes whose model size range is centered on the model size.
+// Runtimes without a size range, or models without a size, get half of MaxPluginScore.
+type modelSizeScorer struct{}
+
+func (s *modelSizeScorer) Name() string { return ModelSizeScorerName }
+
+func (s *modelSizeScorer) Score(_ context.Context, c *Candidate) (int64, error) {
+	sizeRange := c.Spec.ModelSizeRange
+	if c.Model.ModelParameterSize == nil || sizeRange == nil || sizeRange.Min == nil || sizeRange.Max == nil {
+		return MaxPluginScore / 2, nil
+	}
+
+	modelSize := ParseModelSize(*c.Model.ModelParameterSize)
+	minSize := ParseModelSize(*sizeRange.Min)
+	maxSize := ParseModelSize(*sizeRange.Max)
+	if maxSize <= minSize || modelSize < minSize || modelSize > maxSize {
+		return MaxPluginScore / 2, nil
+	}
+
+	mid := (minSize + maxSize) / 2
+	distance := math.Abs(modelSize-mid) / ((maxSize - minSize) / 2)
+	return int64(math.Round(float64(MaxPluginScore) * (1 - distance/2))), nil
+}
+
+// acceleratorFitScorer prefers runtimes that declare support for the accelerator class requested
+// by the InferenceService. Runtimes without accelerator requirements get half of MaxPluginScore.
+type acceleratorFitScorer struct{}
+
+func (s *acceleratorFitScorer) Name() string { return AcceleratorFitScorerName }
+
+func (s *acceleratorFitScorer) Score(_ context.Context, c *Candidate) (int64, error) {
+	if c.Spec.AcceleratorRequirements == nil || len(c.Spec.AcceleratorRequirements.AcceleratorClasses) == 0 {
+		return MaxPluginScore / 2, nil
+	}
+	if c.InferenceService == nil || c.InferenceService.Spec.AcceleratorSelector == nil ||
+		c.InferenceService.Spec.AcceleratorSelector.AcceleratorClass == nil {
+		return MaxPluginScore / 2, nil
+	}
+	if c.Spec.SupportsAcceleratorClass(*c.InferenceService.Spec.AcceleratorSelector.AcceleratorClass) {
+		return MaxPluginScore, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,149 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func pytorchRuntime(acceleratorClasses ...string) *v1beta1.ServingRuntimeSpec {
+	spec := &v1beta1.ServingRuntimeSpec{
+		SupportedModelFormats: []v1beta1.SupportedModelFormat{
+			{
+				ModelFormat: &v1beta1.ModelFormat{Name: "pytorch", Weight: 10},
+				AutoSelect:  ptr(true),
+			},
+		},
+	}
+	if len(acceleratorClasses) > 0 {
+		spec.AcceleratorRequirements = &v1beta1.AcceleratorRequirements{AcceleratorClasses: acceleratorClasses}
+	}
+	return spec
+}
+
+func TestNewDefaultPipeline_ScorerWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights map[string]int64
+		want    map[string]int64
+	}{
+		{
+			name:    "defaults",
+			weights: nil,
+			want:    map[string]int64{ModelFormatScorerName: 1},
+		},
+		{
+			name:    "enable accelerator fit",
+			weights: map[string]int64{AcceleratorFitScorerName: 20},
+			want:    map[string]int64{ModelFormatScorerName: 1, AcceleratorFitScorerName: 20},
+		},
+		{
+			name:    "disable model format",
+			weights: map[string]int64{ModelFormatScorerName: 0, ModelSizeScorerName: 2},
+			want:    map[string]int64{ModelSizeScorerName: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig(fake.NewClientBuilder().Build())
+			config.ScorerWeights = tt.weights
+			pipeline := NewDefaultPipeline(config, NewDefaultRuntimeMatcher(config), NewDefaultRuntimeScorer(config))
+
+			got := map[string]int64{}
+			for _, s := range pipeline.Scorers {
+				got[s.Name()] = s.Weight
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Len(t, pipeline.Filters, 6)
+		})
+	}
+}
+
+func TestPipeline_Filter(t *testing.T) {
+	config := NewConfig(fake.NewClientBuilder().Build())
+	pipeline := NewDefaultPipeline(config, NewDefaultRuntimeMatcher(config), NewDefaultRuntimeScorer(config))
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "pytorch"}}
+
+	candidate := &Candidate{Name: "rt", Spec: pytorchRuntime(), Model: model}
+	reason, err := pipeline.Filter(context.TODO(), candidate)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.NotNil(t, candidate.Report)
+
+	noAutoSelect := pytorchRuntime()
+	noAutoSelect.SupportedModelFormats[0].AutoSelect = ptr(false)
+	reason, err = pipeline.Filter(context.TODO(), &Candidate{Name: "rt", Spec: noAutoSelect, Model: model})
+	require.NoError(t, err)
+	assert.Equal(t, "runtime does not have auto-select enabled", reason)
+}
+
+func TestPipeline_AcceleratorFitOutranksModelFormat(t *testing.T) {
+	config := NewConfig(fake.NewClientBuilder().Build())
+	config.ScorerWeights = map[string]int64{AcceleratorFitScorerName: 10}
+	pipeline := NewDefaultPipeline(config, NewDefaultRuntimeMatcher(config), NewDefaultRuntimeScorer(config))
+
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "pytorch"}}
+	isvc := &v1beta1.InferenceService{
+		Spec: v1beta1.InferenceServiceSpec{
+			AcceleratorSelector: &v1beta1.AcceleratorSelector{AcceleratorClass: ptr("nvidia-h100")},
+		},
+	}
+
+	// A higher format weight alone would win, but the accelerator fit weight dominates
+	generic := pytorchRuntime("nvidia-a100")
+	generic.SupportedModelFormats[0].ModelFormat.Weight = 50
+	fit := pytorchRuntime("nvidia-h100")
+
+	genericScore, err := pipeline.Score(context.TODO(), &Candidate{Name: "generic", Spec: generic, Model: model, InferenceService: isvc})
+	require.NoError(t, err)
+	fitScore, err := pipeline.Score(context.TODO(), &Candidate{Name: "fit", Spec: fit, Model: model, InferenceService: isvc})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(50), genericScore)
+	assert.Equal(t, int64(10+10*MaxPluginScore), fitScore)
+}
+
+func TestModelSizeScorer(t *testing.T) {
+	tests := []struct {
+		name      string
+		modelSize *string
+		sizeRange *v1beta1.ModelSizeRangeSpec
+		want      int64
+	}{
+		{
+			name:      "no size range",
+			modelSize: ptr("7B"),
+			want:      MaxPluginScore / 2,
+		},
+		{
+			name:      "centered in range",
+			modelSize: ptr("10B"),
+			sizeRange: &v1beta1.ModelSizeRangeSpec{Min: ptr("5B"), Max: ptr("15B")},
+			want:      MaxPluginScore,
+		},
+		{
+			name:      "at range edge",
+			modelSize: ptr("15B"),
+			sizeRange: &v1beta1.ModelSizeRangeSpec{Min: ptr("5B"), Max: ptr("15B")},
+			want:      MaxPluginScore / 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := pytorchRuntime()
+			spec.ModelSizeRange = tt.sizeRange
+			model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "pytorch"}, ModelParameterSize: tt.modelSize}
+
+			got, err := (&modelSizeScorer{}).Score(context.TODO(), &Candidate{Spec: spec, Model: model})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
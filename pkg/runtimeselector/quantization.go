@@ -0,0 +1,68 @@
+package runtimeselector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// runtimeQuantizations returns the quantizations a runtime declares it can load through
+// constants.RuntimeQuantizationLabelPrefix labels, or nil if the runtime declares none.
+func runtimeQuantizations(labels map[string]string) map[v1beta1.ModelQuantization]bool {
+	var supported map[v1beta1.ModelQuantization]bool
+	for key, value := range labels {
+		if !strings.HasPrefix(key, constants.RuntimeQuantizationLabelPrefix) {
+			continue
+		}
+		if supported == nil {
+			supported = make(map[v1beta1.ModelQuantization]bool)
+		}
+		quantization := v1beta1.ModelQuantization(strings.ToLower(strings.TrimPrefix(key, constants.RuntimeQuantizationLabelPrefix)))
+		supported[quantization] = strings.EqualFold(value, "true")
+	}
+	return supported
+}
+
+// supportsQuantizationByLabel reports whether the runtime declares through its capability labels
+// that it can load models with the given quantization.
+func supportsQuantizationByLabel(labels map[string]string, quantization *v1beta1.ModelQuantization) bool {
+	if quantization == nil {
+		return false
+	}
+	return runtimeQuantizations(labels)[v1beta1.ModelQuantization(strings.ToLower(string(*quantization)))]
+}
+
+// modelForFormatMatching returns the model to match against the supported formats of a runtime. A quantization the
+// runtime loads for all of its formats through its capability labels doesn't need to be listed by the formats.
+func modelForFormatMatching(labels map[string]string, model *v1beta1.BaseModelSpec) *v1beta1.BaseModelSpec {
+	if !supportsQuantizationByLabel(labels, model.Quantization) {
+		return model
+	}
+	model = model.DeepCopy()
+	model.Quantization = nil
+	return model
+}
+
+// quantizationFilter rejects runtimes whose quantization capability labels don't include the
+// quantization of the model. Runtimes without capability labels are left to the per-format
+// quantization matching of the compatibility filter.
+type quantizationFilter struct{}
+
+func (f *quantizationFilter) Name() string { return QuantizationFilterName }
+
+func (f *quantizationFilter) Filter(_ context.Context, c *Candidate) (string, error) {
+	if c.Model.Quantization == nil {
+		return "", nil
+	}
+	supported := runtimeQuantizations(c.Labels)
+	if supported == nil {
+		return "", nil
+	}
+	if !supportsQuantizationByLabel(c.Labels, c.Model.Quantization) {
+		return fmt.Sprintf("runtime cannot load %s quantized models", *c.Model.Quantization), nil
+	}
+	return "", nil
+}
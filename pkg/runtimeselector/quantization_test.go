@@ -0,0 +1,151 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestQuantizationFilter(t *testing.T) {
+	awq := v1beta1.ModelQuantizationAWQ
+
+	tests := []struct {
+		name         string
+		quantization *v1beta1.ModelQuantization
+		labels       map[string]string
+		wantRejected bool
+	}{
+		{
+			name:         "unquantized model",
+			quantization: nil,
+			labels:       map[string]string{constants.RuntimeQuantizationLabelPrefix + "fp8": "true"},
+		},
+		{
+			name:         "runtime without capability labels",
+			quantization: &awq,
+			labels:       map[string]string{"app": "sglang"},
+		},
+		{
+			name:         "runtime supports quantization",
+			quantization: &awq,
+			labels:       map[string]string{constants.RuntimeQuantizationLabelPrefix + "awq": "true"},
+		},
+		{
+			name:         "runtime supports other quantizations",
+			quantization: &awq,
+			labels:       map[string]string{constants.RuntimeQuantizationLabelPrefix + "fp8": "true"},
+			wantRejected: true,
+		},
+		{
+			name:         "runtime explicitly disables quantization",
+			quantization: &awq,
+			labels:       map[string]string{constants.RuntimeQuantizationLabelPrefix + "awq": "false"},
+			wantRejected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}, Quantization: tt.quantization}
+			reason, err := (&quantizationFilter{}).Filter(context.TODO(), &Candidate{Spec: pytorchRuntime(), Labels: tt.labels, Model: model})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRejected, reason != "")
+		})
+	}
+}
+
+func TestSelectRuntime_QuantizationCapabilityLabels(t *testing.T) {
+	fakeClient := createFakeClient()
+	selector := New(fakeClient)
+	ctx := context.Background()
+
+	safetensors := func() v1beta1.ServingRuntimeSpec {
+		return v1beta1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1beta1.SupportedModelFormat{
+				{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 10}, AutoSelect: ptr(true)},
+			},
+		}
+	}
+	runtimes := []*v1beta1.ClusterServingRuntime{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-unlabeled"},
+			Spec:       safetensors(),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "rt-fp8",
+				Labels: map[string]string{constants.RuntimeQuantizationLabelPrefix + "fp8": "true"},
+			},
+			Spec: safetensors(),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "rt-awq",
+				Labels: map[string]string{
+					constants.RuntimeQuantizationLabelPrefix + "awq":  "true",
+					constants.RuntimeQuantizationLabelPrefix + "gptq": "true",
+				},
+			},
+			Spec: safetensors(),
+		},
+	}
+	for _, rt := range runtimes {
+		require.NoError(t, fakeClient.Create(ctx, rt))
+	}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	awqModel := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}, Quantization: ptr(v1beta1.ModelQuantizationAWQ)}
+	matches, err := selector.GetCompatibleRuntimes(ctx, awqModel, isvc, "default")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "rt-awq", matches[0].Name)
+
+	// Unquantized models are not restricted by the capability labels
+	plainModel := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}}
+	matches, err = selector.GetCompatibleRuntimes(ctx, plainModel, isvc, "default")
+	require.NoError(t, err)
+	assert.Len(t, matches, 3)
+}
+
+func TestValidateRuntime_QuantizationCapabilityLabels(t *testing.T) {
+	fakeClient := createFakeClient()
+	selector := New(fakeClient)
+	ctx := context.Background()
+
+	// The formats don't list the quantization, the capability labels decide
+	spec := v1beta1.ServingRuntimeSpec{
+		SupportedModelFormats: []v1beta1.SupportedModelFormat{
+			{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 10}, AutoSelect: ptr(true)},
+		},
+	}
+	require.NoError(t, fakeClient.Create(ctx, &v1beta1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "rt-fp8",
+			Labels: map[string]string{constants.RuntimeQuantizationLabelPrefix + "fp8": "true"},
+		},
+		Spec: spec,
+	}))
+	require.NoError(t, fakeClient.Create(ctx, &v1beta1.ServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rt-awq",
+			Namespace: "default",
+			Labels:    map[string]string{constants.RuntimeQuantizationLabelPrefix + "awq": "true"},
+		},
+		Spec: spec,
+	}))
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+	awqModel := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}, Quantization: ptr(v1beta1.ModelQuantizationAWQ)}
+
+	assert.NoError(t, selector.ValidateRuntime(ctx, "rt-awq", awqModel, isvc))
+
+	err := selector.ValidateRuntime(ctx, "rt-fp8", awqModel, isvc)
+	var compatErr *RuntimeCompatibilityError
+	require.ErrorAs(t, err, &compatErr)
+	assert.Contains(t, compatErr.Reason, "awq")
+}
@@ -170,9 +170,9 @@ func (s *DefaultRuntimeScorer) calculateSizeScore(runtime RuntimeMatch, model *v
 		return 0
 	}
 
-	modelSize := parseModelSize(*model.ModelParameterSize)
-	minSize := parseModelSize(*runtime.Spec.ModelSizeRange.Min)
-	maxSize := parseModelSize(*runtime.Spec.ModelSizeRange.Max)
+	modelSize := ParseModelSize(*model.ModelParameterSize)
+	minSize := ParseModelSize(*runtime.Spec.ModelSizeRange.Min)
+	maxSize := ParseModelSize(*runtime.Spec.ModelSizeRange.Max)
 
 	// Calculate distance from the model size to the range boundaries
 	minDiff := math.Abs(minSize - modelSize)
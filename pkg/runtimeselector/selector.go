@@ -13,10 +13,11 @@ import (
 
 // defaultSelector is the default implementation of the Selector interface.
 type defaultSelector struct {
-	config  *Config
-	fetcher RuntimeFetcher
-	matcher RuntimeMatcher
-	scorer  RuntimeScorer
+	config   *Config
+	fetcher  RuntimeFetcher
+	matcher  RuntimeMatcher
+	scorer   RuntimeScorer
+	pipeline *Pipeline
 }
 
 // New creates a new Selector with default implementations.
@@ -27,11 +28,14 @@ func New(client client.Client) Selector {
 
 // NewWithConfig creates a new Selector with the provided configuration.
 func NewWithConfig(config *Config) Selector {
+	matcher := NewDefaultRuntimeMatcher(config)
+	scorer := NewDefaultRuntimeScorer(config)
 	return &defaultSelector{
-		config:  config,
-		fetcher: NewDefaultRuntimeFetcher(config.Client),
-		matcher: NewDefaultRuntimeMatcher(config),
-		scorer:  NewDefaultRuntimeScorer(config),
+		config:   config,
+		fetcher:  NewDefaultRuntimeFetcher(config.Client),
+		matcher:  matcher,
+		scorer:   scorer,
+		pipeline: NewDefaultPipeline(config, matcher, scorer),
 	}
 }
 
@@ -48,6 +52,23 @@ func (s *defaultSelector) SelectRuntime(ctx context.Context, model *v1beta1.Base
 		return nil, err
 	}
 
+	// Reuse the previous decision if neither the inputs nor the runtimes changed since
+	cache := s.config.SelectionCache
+	var cacheKey string
+	var generation uint64
+	if cache != nil {
+		generation = cache.Generation()
+		key, err := selectionCacheKey(model, isvc)
+		if err != nil {
+			logger.Error(err, "Failed to compute runtime selection cache key")
+			cache = nil
+		} else if selection, ok := cache.get(key); ok {
+			logger.V(1).Info("Using cached runtime selection", "runtime", selection.Name, "score", selection.Score)
+			return selection, nil
+		}
+		cacheKey = key
+	}
+
 	// Get all compatible runtimes
 	matches, err := s.GetCompatibleRuntimes(ctx, model, isvc, namespace)
 	if err != nil {
@@ -97,6 +118,9 @@ func (s *defaultSelector) SelectRuntime(ctx context.Context, model *v1beta1.Base
 		"score", best.Score,
 		"isCluster", best.IsCluster)
 
+	if cache != nil {
+		cache.add(cacheKey, generation, &best.RuntimeSelection)
+	}
 	return &best.RuntimeSelection, nil
 }
 
@@ -120,14 +144,14 @@ func (s *defaultSelector) GetCompatibleRuntimes(ctx context.Context, model *v1be
 
 	// Process namespace-scoped runtimes
 	for _, runtime := range collection.NamespaceRuntimes {
-		if match := s.evaluateRuntime(ctx, &runtime.Spec, model, isvc, runtime.Name, false); match != nil {
+		if match := s.evaluateRuntime(ctx, &runtime.Spec, model, isvc, runtime.Name, runtime.Labels, false); match != nil {
 			namespaceMatches = append(namespaceMatches, *match)
 		}
 	}
 
 	// Process cluster-scoped runtimes
 	for _, runtime := range collection.ClusterRuntimes {
-		if match := s.evaluateRuntime(ctx, &runtime.Spec, model, isvc, runtime.Name, true); match != nil {
+		if match := s.evaluateRuntime(ctx, &runtime.Spec, model, isvc, runtime.Name, runtime.Labels, true); match != nil {
 			clusterMatches = append(clusterMatches, *match)
 		}
 	}
@@ -161,7 +185,7 @@ func (s *defaultSelector) ValidateRuntime(ctx context.Context, runtimeName strin
 	}
 
 	// Get the specific runtime
-	runtimeSpec, isCluster, err := s.fetcher.GetRuntime(ctx, runtimeName, namespace)
+	runtimeSpec, labels, isCluster, err := s.fetcher.GetRuntimeWithLabels(ctx, runtimeName, namespace)
 	if err != nil {
 		return err
 	}
@@ -174,15 +198,37 @@ func (s *defaultSelector) ValidateRuntime(ctx context.Context, runtimeName strin
 		}
 	}
 
+	// Check the runtime version constraints of the model and the InferenceService
+	if reason := checkRuntimeVersion(runtimeSpec, runtimeVersionConstraints(model, isvc)); reason != "" {
+		return &RuntimeCompatibilityError{
+			RuntimeName: runtimeName,
+			ModelName:   getModelName(model),
+			ModelFormat: model.ModelFormat.Name,
+			Reason:      reason,
+		}
+	}
+
+	// Check the quantization capability labels of the runtime, as auto-selection does
+	candidate := &Candidate{Name: runtimeName, Spec: runtimeSpec, IsCluster: isCluster, Labels: labels, Model: model, InferenceService: isvc}
+	if reason, _ := (&quantizationFilter{}).Filter(ctx, candidate); reason != "" {
+		return &RuntimeCompatibilityError{
+			RuntimeName: runtimeName,
+			ModelName:   getModelName(model),
+			ModelFormat: model.ModelFormat.Name,
+			Reason:      reason,
+		}
+	}
+
 	// Check compatibility
-	compatible, err := s.matcher.IsCompatible(runtimeSpec, model, isvc, runtimeName)
+	formatModel := modelForFormatMatching(labels, model)
+	compatible, err := s.matcher.IsCompatible(runtimeSpec, formatModel, isvc, runtimeName)
 	if err != nil {
 		return err
 	}
 
 	if !compatible {
 		// Get detailed compatibility report for better error message
-		report, _ := s.matcher.GetCompatibilityDetails(runtimeSpec, model, isvc, runtimeName)
+		report, _ := s.matcher.GetCompatibilityDetails(runtimeSpec, formatModel, isvc, runtimeName)
 
 		reason := "incompatible model format"
 		if report != nil && len(report.IncompatibilityReasons) > 0 {
@@ -215,55 +261,47 @@ func (s *defaultSelector) ValidateRuntime(ctx context.Context, runtimeName strin
 }
 
 // evaluateRuntime evaluates a single runtime for compatibility and scoring.
-func (s *defaultSelector) evaluateRuntime(ctx context.Context, spec *v1beta1.ServingRuntimeSpec, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, name string, isCluster bool) *RuntimeMatch {
+func (s *defaultSelector) evaluateRuntime(ctx context.Context, spec *v1beta1.ServingRuntimeSpec, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, name string, labels map[string]string, isCluster bool) *RuntimeMatch {
+	match, _ := s.explainRuntime(ctx, spec, model, isvc, name, labels, isCluster)
+	return match
+}
+
+// explainRuntime evaluates a single runtime and returns the match, or nil if the runtime is rejected,
+// together with the explanation of the outcome.
+func (s *defaultSelector) explainRuntime(ctx context.Context, spec *v1beta1.ServingRuntimeSpec, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, name string, labels map[string]string, isCluster bool) (*RuntimeMatch, RuntimeExplanation) {
 	logger := log.FromContext(ctx)
+	explanation := RuntimeExplanation{Name: name, IsCluster: isCluster}
 
 	// Skip disabled runtimes
 	if spec.IsDisabled() {
 		logger.V(2).Info("Skipping disabled runtime", "runtime", name)
-		return nil
+		explanation.RejectionReason = "runtime is disabled"
+		return nil, explanation
 	}
 
-	// Check basic compatibility (mimics RuntimeSupportsModel)
-	report, err := s.matcher.GetCompatibilityDetails(spec, model, isvc, name)
+	// Run the filter plugins (quantization, compatibility, auto-select and model format match)
+	candidate := &Candidate{Name: name, Spec: spec, IsCluster: isCluster, Labels: labels, Model: model, InferenceService: isvc}
+	reason, err := s.pipeline.Filter(ctx, candidate)
 	if err != nil {
-		logger.Error(err, "Failed to get compatibility details", "runtime", name)
-		return nil
+		logger.Error(err, "Failed to filter runtime", "runtime", name)
+		explanation.RejectionReason = err.Error()
+		return nil, explanation
 	}
-
-	if !report.IsCompatible {
-		logger.V(2).Info("Runtime not compatible",
-			"runtime", name,
-			"reasons", report.IncompatibilityReasons)
-		return nil
+	if reason != "" {
+		logger.V(2).Info("Runtime filtered out", "runtime", name, "reason", reason)
+		explanation.RejectionReason = reason
+		return nil, explanation
 	}
 
-	// Check if runtime has auto-select enabled for at least one supported format
-	hasAutoSelect := false
-	for _, format := range spec.SupportedModelFormats {
-		if format.AutoSelect != nil && *format.AutoSelect {
-			hasAutoSelect = true
-			break
-		}
-	}
-
-	if !hasAutoSelect {
-		logger.V(2).Info("Runtime does not have auto-select enabled", "runtime", name)
-		return nil
-	}
-
-	// Calculate score
-	score, err := s.scorer.CalculateScore(spec, model)
+	// Calculate the weighted score of the score plugins
+	score, scores, err := s.pipeline.ScoreDetails(ctx, candidate)
 	if err != nil {
 		logger.Error(err, "Failed to calculate score", "runtime", name)
-		return nil
-	}
-
-	// Skip runtimes with score <= 0 (indicates no format/framework match or autoselect is false)
-	if score <= 0 {
-		logger.V(2).Info("Runtime has non-positive score", "runtime", name, "score", score)
-		return nil
+		explanation.RejectionReason = err.Error()
+		return nil, explanation
 	}
+	explanation.Score = score
+	explanation.Scores = scores
 
 	return &RuntimeMatch{
 		RuntimeSelection: RuntimeSelection{
@@ -272,8 +310,8 @@ func (s *defaultSelector) evaluateRuntime(ctx context.Context, spec *v1beta1.Ser
 			Score:     score,
 			IsCluster: isCluster,
 		},
-		MatchDetails: report.MatchDetails,
-	}
+		MatchDetails: candidate.Report.MatchDetails,
+	}, explanation
 }
 
 // sortMatches sorts runtime matches by score and other criteria.
@@ -109,6 +109,11 @@ func TestParseModelSize(t *testing.T) {
 			sizeStr:  "350M",
 			expected: 350_000_000,
 		},
+		{
+			name:     "thousands",
+			sizeStr:  "125K",
+			expected: 125_000,
+		},
 		{
 			name:     "decimal billions",
 			sizeStr:  "1.5B",
@@ -123,7 +128,7 @@ func TestParseModelSize(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := parseModelSize(tt.sizeStr)
+			result := ParseModelSize(tt.sizeStr)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
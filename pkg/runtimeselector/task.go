@@ -0,0 +1,60 @@
+package runtimeselector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/hfutil/modelconfig"
+)
+
+// ModelTask returns the task of a model from the capabilities recorded by the model agent. Models without
+// embedding or rerank capabilities are generation models.
+func ModelTask(model *v1beta1.BaseModelSpec) modelconfig.ModelTask {
+	for _, capability := range model.ModelCapabilities {
+		switch v1beta1.ModelCapability(strings.ToUpper(capability)) {
+		case v1beta1.ModelCapabilityRerank, v1beta1.ModelCapabilityTextRerank:
+			return modelconfig.ModelTaskRerank
+		case v1beta1.ModelCapabilityEmbedding, v1beta1.ModelCapabilityTextEmbeddings:
+			return modelconfig.ModelTaskEmbedding
+		}
+	}
+	return modelconfig.ModelTaskGeneration
+}
+
+// runtimeTasks returns the tasks a runtime declares it can serve through constants.RuntimeTaskLabelPrefix
+// labels, or nil if the runtime declares none.
+func runtimeTasks(labels map[string]string) map[modelconfig.ModelTask]bool {
+	var supported map[modelconfig.ModelTask]bool
+	for key, value := range labels {
+		if !strings.HasPrefix(key, constants.RuntimeTaskLabelPrefix) {
+			continue
+		}
+		if supported == nil {
+			supported = make(map[modelconfig.ModelTask]bool)
+		}
+		task := modelconfig.ModelTask(strings.ToLower(strings.TrimPrefix(key, constants.RuntimeTaskLabelPrefix)))
+		supported[task] = strings.EqualFold(value, "true")
+	}
+	return supported
+}
+
+// taskFilter rejects runtimes whose task capability labels don't include the task of the model, so that
+// embedding and rerank models are not served by generation runtimes and the other way around. Runtimes
+// without task labels are left to the other filters.
+type taskFilter struct{}
+
+func (f *taskFilter) Name() string { return TaskFilterName }
+
+func (f *taskFilter) Filter(_ context.Context, c *Candidate) (string, error) {
+	supported := runtimeTasks(c.Labels)
+	if supported == nil {
+		return "", nil
+	}
+	if task := ModelTask(c.Model); !supported[task] {
+		return fmt.Sprintf("runtime cannot serve %s models", task), nil
+	}
+	return "", nil
+}
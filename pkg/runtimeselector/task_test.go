@@ -0,0 +1,85 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/hfutil/modelconfig"
+)
+
+func TestModelTask(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities []string
+		want         modelconfig.ModelTask
+	}{
+		{"no capabilities", nil, modelconfig.ModelTaskGeneration},
+		{"text generation", []string{string(v1beta1.ModelCapabilityTextToText)}, modelconfig.ModelTaskGeneration},
+		{"embedding", []string{string(v1beta1.ModelCapabilityEmbedding)}, modelconfig.ModelTaskEmbedding},
+		{"text embeddings", []string{string(v1beta1.ModelCapabilityTextEmbeddings)}, modelconfig.ModelTaskEmbedding},
+		{"rerank", []string{string(v1beta1.ModelCapabilityRerank)}, modelconfig.ModelTaskRerank},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ModelTask(&v1beta1.BaseModelSpec{ModelCapabilities: tt.capabilities}))
+		})
+	}
+}
+
+func TestTaskFilter(t *testing.T) {
+	embedding := []string{string(v1beta1.ModelCapabilityEmbedding)}
+
+	tests := []struct {
+		name         string
+		capabilities []string
+		labels       map[string]string
+		wantRejected bool
+	}{
+		{
+			name:         "runtime without task labels",
+			capabilities: embedding,
+			labels:       map[string]string{"app": "sglang"},
+		},
+		{
+			name:         "runtime serves embedding models",
+			capabilities: embedding,
+			labels:       map[string]string{constants.RuntimeTaskLabelPrefix + "embedding": "true"},
+		},
+		{
+			name:         "generation runtime",
+			capabilities: embedding,
+			labels:       map[string]string{constants.RuntimeTaskLabelPrefix + "generation": "true"},
+			wantRejected: true,
+		},
+		{
+			name:         "generation model on embedding runtime",
+			capabilities: []string{string(v1beta1.ModelCapabilityTextToText)},
+			labels:       map[string]string{constants.RuntimeTaskLabelPrefix + "embedding": "true"},
+			wantRejected: true,
+		},
+		{
+			name:         "runtime explicitly disables rerank",
+			capabilities: []string{string(v1beta1.ModelCapabilityRerank)},
+			labels: map[string]string{
+				constants.RuntimeTaskLabelPrefix + "embedding": "true",
+				constants.RuntimeTaskLabelPrefix + "rerank":    "false",
+			},
+			wantRejected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}, ModelCapabilities: tt.capabilities}
+			reason, err := (&taskFilter{}).Filter(context.TODO(), &Candidate{Spec: pytorchRuntime(), Labels: tt.labels, Model: model})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRejected, reason != "")
+		})
+	}
+}
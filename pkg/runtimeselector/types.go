@@ -33,6 +33,10 @@ type Selector interface {
 	// if userSpecifiedRuntime is true, the function will consider all supportedModelFormats in the runtime
 	// if userSpecifiedRuntime is false, the function will only consider supportedModelFormats with autoSelect enabled
 	GetSupportedModelFormat(ctx context.Context, runtime *v1beta1.ServingRuntimeSpec, model *v1beta1.BaseModelSpec, userSpecifiedRuntime bool) *v1beta1.SupportedModelFormat
+
+	// Explain evaluates every runtime visible to the InferenceService without selecting one.
+	// It reports the score of each compatible runtime and the rejection reason of every other runtime.
+	Explain(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) (*SelectionExplanation, error)
 }
 
 // RuntimeSelection represents the selected runtime with metadata.
@@ -50,6 +54,14 @@ type RuntimeSelection struct {
 	IsCluster bool
 }
 
+// RuntimeKind returns the kind of a runtime, ClusterServingRuntime or ServingRuntime.
+func RuntimeKind(isCluster bool) string {
+	if isCluster {
+		return "ClusterServingRuntime"
+	}
+	return "ServingRuntime"
+}
+
 // RuntimeMatch represents a runtime that matches the model with detailed scoring information.
 type RuntimeMatch struct {
 	// Embedded RuntimeSelection provides basic runtime info
@@ -101,6 +113,9 @@ type RuntimeFetcher interface {
 	// GetRuntime fetches a specific runtime by name.
 	// It first checks namespace-scoped runtimes, then cluster-scoped ones.
 	GetRuntime(ctx context.Context, name string, namespace string) (*v1beta1.ServingRuntimeSpec, bool, error)
+
+	// GetRuntimeWithLabels fetches a specific runtime by name like GetRuntime, and also returns its labels.
+	GetRuntimeWithLabels(ctx context.Context, name string, namespace string) (*v1beta1.ServingRuntimeSpec, map[string]string, bool, error)
 }
 
 // RuntimeCollection holds both namespace and cluster scoped runtimes.
@@ -169,6 +184,14 @@ type Config struct {
 
 	// ModelFrameworkWeight is the default weight for model framework matching
 	ModelFrameworkWeight int64
+
+	// ScorerWeights overrides the weights of the score plugins by plugin name.
+	// Plugins not listed keep their default weight, a weight of zero disables the plugin.
+	ScorerWeights map[string]int64
+
+	// SelectionCache caches SelectRuntime decisions when set. The owner of the cache must call
+	// Invalidate whenever a ServingRuntime, ClusterServingRuntime or AcceleratorClass changes.
+	SelectionCache *SelectionCache
 }
 
 // NewConfig creates a new Config with default values.
@@ -0,0 +1,65 @@
+package runtimeselector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	modelVer "github.com/sgl-project/ome/pkg/modelver"
+)
+
+// runtimeVersionConstraints returns the runtime version constraints of the model and the InferenceService.
+func runtimeVersionConstraints(model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) []v1beta1.RuntimeVersionConstraint {
+	constraints := append([]v1beta1.RuntimeVersionConstraint{}, model.RuntimeVersionConstraints...)
+	if isvc != nil {
+		constraints = append(constraints, isvc.Spec.RuntimeVersionConstraints...)
+	}
+	return constraints
+}
+
+// checkRuntimeVersion returns an empty string if the engine version of the runtime satisfies every
+// constraint that applies to its engine, or the reason it doesn't. Runtimes that don't declare an
+// engine version can't be checked and don't satisfy any constraint.
+func checkRuntimeVersion(spec *v1beta1.ServingRuntimeSpec, constraints []v1beta1.RuntimeVersionConstraint) string {
+	for _, constraint := range constraints {
+		if spec.EngineVersion == nil {
+			return fmt.Sprintf("runtime does not declare an engine version to check against %s", formatVersionConstraint(constraint))
+		}
+		if constraint.Engine != "" && !strings.EqualFold(constraint.Engine, spec.EngineVersion.Name) {
+			continue
+		}
+
+		c, err := modelVer.ParseConstraint(constraint.Version)
+		if err != nil {
+			return fmt.Sprintf("invalid runtime version constraint: %v", err)
+		}
+		version, err := modelVer.Parse(spec.EngineVersion.Version)
+		if err != nil {
+			return fmt.Sprintf("invalid engine version %q: %v", spec.EngineVersion.Version, err)
+		}
+		if !c.Check(version) {
+			return fmt.Sprintf("engine version %s %s does not satisfy %s",
+				spec.EngineVersion.Name, spec.EngineVersion.Version, formatVersionConstraint(constraint))
+		}
+	}
+	return ""
+}
+
+// formatVersionConstraint formats a constraint the way users write it, e.g. "sglang >=0.4.3 <0.5".
+func formatVersionConstraint(constraint v1beta1.RuntimeVersionConstraint) string {
+	if constraint.Engine == "" {
+		return constraint.Version
+	}
+	return constraint.Engine + " " + constraint.Version
+}
+
+// runtimeVersionFilter rejects runtimes whose engine version doesn't satisfy the runtime version
+// constraints of the model or the InferenceService.
+type runtimeVersionFilter struct{}
+
+func (f *runtimeVersionFilter) Name() string { return RuntimeVersionFilterName }
+
+func (f *runtimeVersionFilter) Filter(_ context.Context, c *Candidate) (string, error) {
+	return checkRuntimeVersion(c.Spec, runtimeVersionConstraints(c.Model, c.InferenceService)), nil
+}
@@ -0,0 +1,97 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestCheckRuntimeVersion(t *testing.T) {
+	sglang := &v1beta1.RuntimeEngineVersion{Name: "sglang", Version: "0.4.6"}
+
+	tests := []struct {
+		name          string
+		engineVersion *v1beta1.RuntimeEngineVersion
+		constraints   []v1beta1.RuntimeVersionConstraint
+		wantRejected  bool
+	}{
+		{
+			name:          "no constraints",
+			engineVersion: nil,
+		},
+		{
+			name:          "version in range",
+			engineVersion: sglang,
+			constraints:   []v1beta1.RuntimeVersionConstraint{{Engine: "sglang", Version: ">=0.4.3 <0.5"}},
+		},
+		{
+			name:          "version out of range",
+			engineVersion: sglang,
+			constraints:   []v1beta1.RuntimeVersionConstraint{{Engine: "sglang", Version: ">=0.5"}},
+			wantRejected:  true,
+		},
+		{
+			name:          "constraint for another engine",
+			engineVersion: sglang,
+			constraints:   []v1beta1.RuntimeVersionConstraint{{Engine: "vllm", Version: ">=0.9"}},
+		},
+		{
+			name:          "constraint for any engine",
+			engineVersion: sglang,
+			constraints:   []v1beta1.RuntimeVersionConstraint{{Version: "<0.4"}},
+			wantRejected:  true,
+		},
+		{
+			name:          "runtime without engine version",
+			engineVersion: nil,
+			constraints:   []v1beta1.RuntimeVersionConstraint{{Engine: "sglang", Version: ">=0.4.3"}},
+			wantRejected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := pytorchRuntime()
+			spec.EngineVersion = tt.engineVersion
+			assert.Equal(t, tt.wantRejected, checkRuntimeVersion(spec, tt.constraints) != "")
+		})
+	}
+}
+
+func TestSelectRuntime_RuntimeVersionConstraints(t *testing.T) {
+	fakeClient := createFakeClient()
+	selector := New(fakeClient)
+	ctx := context.Background()
+
+	for name, version := range map[string]string{"srt-0-4-6": "0.4.6", "srt-0-5-0": "0.5.0"} {
+		rt := pytorchClusterRuntime(name, 10)
+		rt.Spec.EngineVersion = &v1beta1.RuntimeEngineVersion{Name: "sglang", Version: version}
+		require.NoError(t, fakeClient.Create(ctx, rt))
+	}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	// The model gates the breaking engine upgrade
+	model := &v1beta1.BaseModelSpec{
+		ModelFormat:               v1beta1.ModelFormat{Name: "pytorch"},
+		RuntimeVersionConstraints: []v1beta1.RuntimeVersionConstraint{{Engine: "sglang", Version: ">=0.4.3 <0.5"}},
+	}
+	matches, err := selector.GetCompatibleRuntimes(ctx, model, isvc, "default")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "srt-0-4-6", matches[0].Name)
+
+	err = selector.ValidateRuntime(ctx, "srt-0-5-0", model, isvc)
+	require.Error(t, err)
+	assert.True(t, IsRuntimeCompatibilityError(err))
+	assert.Contains(t, err.Error(), "engine version sglang 0.5.0 does not satisfy sglang >=0.4.3 <0.5")
+
+	// The InferenceService adds its own constraints
+	isvc.Spec.RuntimeVersionConstraints = []v1beta1.RuntimeVersionConstraint{{Engine: "sglang", Version: "!=0.4.6"}}
+	_, err = selector.SelectRuntime(ctx, model, isvc)
+	assert.True(t, IsNoRuntimeFoundError(err))
+}
@@ -161,4 +161,7 @@ const (
 	TypeGitHub      = utilstorage.StorageTypeGitHub
 	TypeHuggingFace = utilstorage.StorageTypeHuggingFace
 	TypeVendor      = utilstorage.StorageTypeVendor
+	TypeOllama      = utilstorage.StorageTypeOllama
+	TypeModelScope  = utilstorage.StorageTypeModelScope
+	TypeModelCar    = utilstorage.StorageTypeModelCar
 )
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Attributes of the reconcile spans
+const (
+	attrController   = attribute.Key("ome.controller")
+	attrReconcileID  = attribute.Key("ome.reconcile_id")
+	attrNamespace    = attribute.Key("k8s.namespace.name")
+	attrName         = attribute.Key("ome.object.name")
+	attrRequeue      = attribute.Key("ome.requeue")
+	attrRequeueAfter = attribute.Key("ome.requeue_after")
+)
+
+// Reconciler returns a reconciler running every reconcile of the controller in a span. The span carries the
+// reconcile ID controller-runtime logs, and the trace ID is added to the logger of the context.
+func Reconciler(controllerName string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ctx, span := Tracer().Start(ctx, "Reconcile "+controllerName,
+			trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(
+				attrController.String(controllerName),
+				attrReconcileID.String(string(controller.ReconcileIDFromContext(ctx))),
+				attrNamespace.String(req.Namespace),
+				attrName.String(req.Name),
+			))
+		defer span.End()
+		if span.SpanContext().IsValid() {
+			ctx = logf.IntoContext(ctx, logf.FromContext(ctx).WithValues("traceID", span.SpanContext().TraceID().String()))
+		}
+
+		result, err := r.Reconcile(ctx, req)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.SetAttributes(attrRequeue.Bool(result.Requeue), attrRequeueAfter.String(result.RequeueAfter.String()))
+		return result, err
+	})
+}
@@ -0,0 +1,98 @@
+// Package tracing sets up OpenTelemetry tracing for the controller manager and instruments its reconcile loops,
+// admission webhooks and Kubernetes API calls. The spans are exported with OTLP over gRPC and carry the reconcile
+// ID of controller-runtime or the UID of the admission request, so that a trace can be found from the logs.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sgl-project/ome/pkg/version"
+)
+
+// TracerName is the name of the tracer of the spans of OME
+const TracerName = "github.com/sgl-project/ome"
+
+// endpointEnvVar is the standard OTLP endpoint variable, read by the exporter when no endpoint is configured
+const endpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// Config defines where and how many traces are exported
+type Config struct {
+	// ServiceName is the service.name of the spans, e.g. ome-controller-manager
+	ServiceName string
+	// Endpoint is the host:port of the OTLP gRPC collector. Tracing is disabled when it is empty, unless the
+	// OTEL_EXPORTER_OTLP_ENDPOINT variable is set.
+	Endpoint string
+	// Insecure connects to the collector without TLS
+	Insecure bool
+	// SampleRatio is the fraction of the traces started by the manager that are sampled, between 0 and 1. The
+	// traces propagated by the API server follow its sampling decision.
+	SampleRatio float64
+}
+
+// Enabled returns whether the configuration exports traces
+func (c Config) Enabled() bool {
+	return c.Endpoint != "" || os.Getenv(endpointEnvVar) != ""
+}
+
+// Setup installs the global tracer provider and propagator and returns the function flushing the spans left on
+// shutdown. When tracing is disabled the spans are not recorded and the returned function does nothing.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if !config.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid trace sample ratio %v, must be between 0 and 1", config.SampleRatio)
+	}
+
+	var opts []otlptracegrpc.Option
+	if config.Endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+	}
+	if config.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+		semconv.ServiceVersion(version.GitVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the spans of OME, from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// WrapTransport instruments the requests of a transport, e.g. of the Kubernetes clients with rest.Config.Wrap, with
+// client spans propagating the trace context
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(rt)
+}
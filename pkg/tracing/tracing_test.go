@@ -0,0 +1,157 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// recordSpans installs a tracer provider recording the spans of the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		values[kv.Key] = kv.Value
+	}
+	return values
+}
+
+func TestReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	recorder := recordSpans(t)
+
+	reconciler := Reconciler("basemodel", reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if req.Name == "broken" {
+			return reconcile.Result{}, errors.New("storage unavailable")
+		}
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "models", Name: "llama"}}
+	result, err := reconciler.Reconcile(context.Background(), req)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.RequeueAfter).To(gomega.Equal(time.Minute))
+	req.Name = "broken"
+	_, err = reconciler.Reconcile(context.Background(), req)
+	g.Expect(err).To(gomega.MatchError("storage unavailable"))
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(gomega.HaveLen(2))
+	g.Expect(spans[0].Name()).To(gomega.Equal("Reconcile basemodel"))
+	g.Expect(attributes(spans[0])[attrName].AsString()).To(gomega.Equal("llama"))
+	g.Expect(attributes(spans[0])[attrRequeueAfter].AsString()).To(gomega.Equal("1m0s"))
+	g.Expect(spans[0].Status().Code).To(gomega.Equal(codes.Unset))
+	g.Expect(spans[1].Status().Code).To(gomega.Equal(codes.Error))
+	g.Expect(spans[1].Status().Description).To(gomega.Equal("storage unavailable"))
+}
+
+func TestAdmissionHandler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	recorder := recordSpans(t)
+
+	handler := &AdmissionHandler{
+		Webhook: "/validate-ome-io-v1beta1-basemodel",
+		Handler: admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+			return admission.Denied("spec.storage.storageUri is required")
+		}),
+	}
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       "705ab4f5-6393-11e8-b7cc-42010a800002",
+		Operation: admissionv1.Create,
+		Namespace: "models",
+		Name:      "llama",
+	}}
+	resp := handler.Handle(context.Background(), req)
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(gomega.HaveLen(1))
+	g.Expect(spans[0].Name()).To(gomega.Equal("Admit /validate-ome-io-v1beta1-basemodel"))
+	attrs := attributes(spans[0])
+	g.Expect(attrs[attrUID].AsString()).To(gomega.Equal("705ab4f5-6393-11e8-b7cc-42010a800002"))
+	g.Expect(attrs[attrOperation].AsString()).To(gomega.Equal("CREATE"))
+	g.Expect(attrs[attrAllowed].AsBool()).To(gomega.BeFalse())
+	g.Expect(attrs[attrCode].AsInt64()).To(gomega.Equal(int64(http.StatusForbidden)))
+	// A denial is the webhook working as intended, not an error
+	g.Expect(spans[0].Status().Code).To(gomega.Equal(codes.Unset))
+}
+
+// registeringServer records the handlers registered with it
+type registeringServer struct {
+	webhook.Server
+	hooks map[string]http.Handler
+}
+
+func (s *registeringServer) Register(path string, hook http.Handler) {
+	s.hooks[path] = hook
+}
+
+func TestWebhookServerContinuesPropagatedTrace(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	recorder := recordSpans(t)
+
+	wrapped := &registeringServer{hooks: map[string]http.Handler{}}
+	server := NewWebhookServer(wrapped)
+	validator := admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		return admission.Allowed("")
+	})
+	hook := &webhook.Admission{Handler: validator}
+	server.Register("/validate-ome-io-v1beta1-basemodel", hook)
+	g.Expect(hook.Handler).To(gomega.BeAssignableToTypeOf(&AdmissionHandler{}))
+
+	review := `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"42","operation":"CREATE","kind":{"group":"ome.io","version":"v1beta1","kind":"BaseModel"},"resource":{"group":"ome.io","version":"v1beta1","resource":"basemodels"},"object":{}}}`
+	req := httptest.NewRequest(http.MethodPost, "/validate-ome-io-v1beta1-basemodel", strings.NewReader(review))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	wrapped.hooks["/validate-ome-io-v1beta1-basemodel"].ServeHTTP(rec, req)
+	g.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+
+	spans := recorder.Ended()
+	g.Expect(spans).To(gomega.HaveLen(2))
+	for _, span := range spans {
+		g.Expect(span.SpanContext().TraceID().String()).To(gomega.Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+	}
+	g.Expect(spans[0].Name()).To(gomega.Equal("Admit /validate-ome-io-v1beta1-basemodel"))
+	g.Expect(spans[0].Parent().SpanID()).To(gomega.Equal(spans[1].SpanContext().SpanID()))
+}
+
+func TestSetupDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv(endpointEnvVar, "")
+	shutdown, err := Setup(context.Background(), Config{ServiceName: "ome-controller-manager"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(shutdown(context.Background())).To(gomega.Succeed())
+
+	_, err = Setup(context.Background(), Config{Endpoint: "otel-collector:4317", SampleRatio: 2})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid trace sample ratio")))
+}
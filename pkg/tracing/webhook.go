@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Attributes of the admission spans
+const (
+	attrWebhook   = attribute.Key("ome.webhook")
+	attrUID       = attribute.Key("ome.admission.uid")
+	attrOperation = attribute.Key("ome.admission.operation")
+	attrKind      = attribute.Key("ome.admission.kind")
+	attrUser      = attribute.Key("ome.admission.user")
+	attrAllowed   = attribute.Key("ome.admission.allowed")
+	attrCode      = attribute.Key("ome.admission.code")
+)
+
+// WebhookServer wraps a webhook server so that every webhook registered with it is traced: the requests get a
+// server span continuing the trace propagated by the API server, and the admission reviews a span of their own.
+type WebhookServer struct {
+	webhook.Server
+}
+
+// NewWebhookServer returns a webhook server tracing the webhooks registered with server
+func NewWebhookServer(server webhook.Server) *WebhookServer {
+	return &WebhookServer{Server: server}
+}
+
+// Register traces the hook and registers it with the wrapped server
+func (s *WebhookServer) Register(path string, hook http.Handler) {
+	if admissionWebhook, ok := hook.(*admission.Webhook); ok && admissionWebhook.Handler != nil {
+		admissionWebhook.Handler = &AdmissionHandler{Webhook: path, Handler: admissionWebhook.Handler}
+	}
+	s.Server.Register(path, otelhttp.NewHandler(hook, "webhook "+path))
+}
+
+// AdmissionHandler reviews the requests of the admission handler it wraps in a span carrying the UID of the request
+type AdmissionHandler struct {
+	// Webhook names the webhook in the spans, usually its path
+	Webhook string
+	Handler admission.Handler
+}
+
+// Handle reviews the request with the wrapped handler
+func (h *AdmissionHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ctx, span := Tracer().Start(ctx, "Admit "+h.Webhook,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attrWebhook.String(h.Webhook),
+			attrUID.String(string(req.UID)),
+			attrOperation.String(string(req.Operation)),
+			attrKind.String(req.Kind.Kind),
+			attrNamespace.String(req.Namespace),
+			attrName.String(req.Name),
+			attrUser.String(req.UserInfo.Username),
+		))
+	defer span.End()
+
+	resp := h.Handler.Handle(ctx, req)
+	span.SetAttributes(attrAllowed.Bool(resp.Allowed))
+	if resp.Result != nil {
+		span.SetAttributes(attrCode.Int(int(resp.Result.Code)))
+		if resp.Result.Code >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, resp.Result.Message)
+		}
+	}
+	return resp
+}
@@ -0,0 +1,59 @@
+package download
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// PartialSuffix marks the files being downloaded or written, which are renamed once complete
+const PartialSuffix = ".partial"
+
+// HTTPError is an unexpected response of a hub or a registry
+type HTTPError struct {
+	StatusCode int
+	URL        string
+	Message    string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("HTTP %d from %s: %s", e.StatusCode, e.URL, e.Message)
+	}
+	return fmt.Sprintf("HTTP %d from %s", e.StatusCode, e.URL)
+}
+
+// NewHTTPError returns the error of an unexpected response, with the beginning of its body as message
+func NewHTTPError(resp *http.Response) *HTTPError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		URL:        resp.Request.URL.Redacted(),
+		Message:    strings.TrimSpace(string(body)),
+	}
+}
+
+// WriteFromReader writes a file from a reader, replacing the file only once it is complete
+func WriteFromReader(dest string, r io.Reader) error {
+	tmp := dest + PartialSuffix
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dest)
+}
+
+// WriteFile writes a file, replacing the file only once it is complete
+func WriteFile(path string, data []byte) error {
+	return WriteFromReader(path, bytes.NewReader(data))
+}
@@ -0,0 +1,53 @@
+package download
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, strings.Repeat("x", 2000), http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/model")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	httpErr := NewHTTPError(resp)
+	assert.Equal(t, http.StatusForbidden, httpErr.StatusCode)
+	assert.Equal(t, server.URL+"/model", httpErr.URL)
+	assert.Len(t, httpErr.Message, 1024)
+	assert.Equal(t, "HTTP 404 from "+server.URL, (&HTTPError{StatusCode: http.StatusNotFound, URL: server.URL}).Error())
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+	require.NoError(t, WriteFile(path, []byte("new")))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	assert.NoFileExists(t, path+PartialSuffix)
+
+	// An interrupted write leaves the file untouched
+	require.Error(t, WriteFromReader(path, &failingReader{}))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+}
+
+type failingReader struct{}
+
+func (*failingReader) Read([]byte) (int, error) {
+	return 0, os.ErrClosed
+}
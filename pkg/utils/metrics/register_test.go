@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	opts := prometheus.CounterOpts{Name: "downloads_total", Help: "Number of downloads"}
+
+	first, err := RegisterCollector(registry, prometheus.NewCounterVec(opts, []string{"repo"}))
+	require.NoError(t, err)
+	second, err := RegisterCollector(registry, prometheus.NewCounterVec(opts, []string{"repo"}))
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	// A collector of another type with the same descriptors is an error
+	_, err = RegisterCollector(registry, prometheus.NewGaugeVec(prometheus.GaugeOpts(opts), []string{"repo"}))
+	assert.Error(t, err)
+}
@@ -26,6 +26,25 @@ const (
 	GitHubStoragePrefix = "github://"
 	// LocalStoragePrefix is the prefix for local filesystem storage URIs
 	LocalStoragePrefix = "local://"
+	// OllamaStoragePrefix is the prefix for Ollama registry model URIs
+	OllamaStoragePrefix = "ollama://"
+	// ModelScopeStoragePrefix is the prefix for ModelScope hub model URIs
+	ModelScopeStoragePrefix = "ms://"
+	// ModelCarStoragePrefix is the prefix for model images (modelcars) of OCI registries
+	ModelCarStoragePrefix = "modelcar://"
+)
+
+const (
+	// DefaultOllamaRegistry is the registry of Ollama URIs that don't name one
+	DefaultOllamaRegistry = "registry.ollama.ai"
+	// DefaultOllamaNamespace is the namespace of the official Ollama models
+	DefaultOllamaNamespace = "library"
+	// DefaultOllamaTag is the tag of Ollama URIs that don't name one
+	DefaultOllamaTag = "latest"
+	// DefaultModelScopeRevision is the revision of ModelScope URIs that don't name one
+	DefaultModelScopeRevision = "master"
+	// DefaultModelCarTag is the tag of modelcar URIs that don't name one
+	DefaultModelCarTag = "latest"
 )
 
 // StorageType is a string enum for storage type
@@ -50,6 +69,12 @@ const (
 	StorageTypeGitHub StorageType = "GITHUB"
 	// StorageTypeLocal is the value for local filesystem storage
 	StorageTypeLocal StorageType = "LOCAL"
+	// StorageTypeOllama is the value for Ollama and OCI artifact registry model storage
+	StorageTypeOllama StorageType = "OLLAMA"
+	// StorageTypeModelScope is the value for ModelScope hub model storage
+	StorageTypeModelScope StorageType = "MODELSCOPE"
+	// StorageTypeModelCar is the value for model images (modelcars) of OCI registries
+	StorageTypeModelCar StorageType = "MODELCAR"
 )
 
 // OCIStorageComponents represents the components of an OCI storage URI
@@ -112,6 +137,28 @@ type LocalStorageComponents struct {
 	Path string // Absolute or relative path to the model files
 }
 
+// OllamaStorageComponents represents the components of an Ollama registry model URI
+type OllamaStorageComponents struct {
+	Registry  string // Registry host, defaults to registry.ollama.ai
+	Namespace string // Defaults to library
+	Model     string
+	Tag       string // Defaults to latest
+}
+
+// ModelScopeStorageComponents represents the components of a ModelScope model URI
+type ModelScopeStorageComponents struct {
+	ModelID  string // {owner}/{name}
+	Revision string // Defaults to master
+}
+
+// ModelCarStorageComponents represents the components of a modelcar URI
+type ModelCarStorageComponents struct {
+	Registry   string // Registry host, with its port if any
+	Repository string
+	Tag        string // Defaults to latest unless a digest is given
+	Digest     string // Optional, e.g. sha256:...
+}
+
 // ParseOCIStorageURI parses an OCI storage URI and returns its components
 // Format: oci://n/{namespace}/b/{bucket}/o/{object_path}
 func ParseOCIStorageURI(uri string) (*OCIStorageComponents, error) {
@@ -137,6 +184,16 @@ func ValidateOCIStorageURI(uri string) error {
 	return err
 }
 
+// IsLegacyOCIStorageURI reports whether the URI uses one of the legacy OCI formats still accepted by
+// NewObjectURI, oci://{namespace}@{region}/{bucket}/{prefix} or oci://{bucket}/{prefix}, instead of
+// oci://n/{namespace}/b/{bucket}/o/{object_path}
+func IsLegacyOCIStorageURI(uri string) bool {
+	if !strings.HasPrefix(uri, OCIStoragePrefix) {
+		return false
+	}
+	return !strings.HasPrefix(strings.TrimPrefix(uri, OCIStoragePrefix), "n/")
+}
+
 // ParsePVCStorageURI parses a PVC storage URI and returns its components
 // Format: pvc://{pvc-name}/{sub-path} OR pvc://{namespace}:{pvc-name}/{sub-path}
 // When namespace is not specified, it should be inferred from the BaseModel's namespace
@@ -531,6 +588,150 @@ func ValidateLocalStorageURI(uri string) error {
 	return err
 }
 
+// ParseOllamaStorageURI parses an Ollama registry model URI and returns its components
+// Format: ollama://[{registry}/][{namespace}/]{model}[:{tag}]
+func ParseOllamaStorageURI(uri string) (*OllamaStorageComponents, error) {
+	if !strings.HasPrefix(uri, OllamaStoragePrefix) {
+		return nil, fmt.Errorf("invalid Ollama storage URI format: missing %s prefix", OllamaStoragePrefix)
+	}
+
+	// Remove prefix
+	path := strings.TrimPrefix(uri, OllamaStoragePrefix)
+	if path == "" {
+		return nil, fmt.Errorf("invalid Ollama storage URI format: missing model name")
+	}
+
+	components := &OllamaStorageComponents{
+		Registry:  DefaultOllamaRegistry,
+		Namespace: DefaultOllamaNamespace,
+		Tag:       DefaultOllamaTag,
+	}
+
+	parts := strings.Split(path, "/")
+	// Like image references, the first part is a registry when it looks like a host
+	if len(parts) > 1 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		components.Registry = parts[0]
+		parts = parts[1:]
+	}
+	switch len(parts) {
+	case 1:
+		components.Model = parts[0]
+	case 2:
+		components.Namespace = parts[0]
+		components.Model = parts[1]
+	default:
+		return nil, fmt.Errorf("invalid Ollama storage URI format: expected [registry/][namespace/]model[:tag]")
+	}
+
+	if idx := strings.LastIndex(components.Model, ":"); idx >= 0 {
+		components.Tag = components.Model[idx+1:]
+		components.Model = components.Model[:idx]
+		if components.Tag == "" {
+			return nil, fmt.Errorf("invalid Ollama storage URI format: tag cannot be empty")
+		}
+	}
+
+	if components.Namespace == "" || components.Model == "" {
+		return nil, fmt.Errorf("invalid Ollama storage URI format: namespace and model cannot be empty")
+	}
+
+	return components, nil
+}
+
+// ValidateOllamaStorageURI validates if the given URI matches Ollama registry model storage format
+func ValidateOllamaStorageURI(uri string) error {
+	_, err := ParseOllamaStorageURI(uri)
+	return err
+}
+
+// ParseModelCarStorageURI parses a modelcar URI and returns its components
+// Format: modelcar://{registry}/{repository}[:{tag}][@{digest}]
+func ParseModelCarStorageURI(uri string) (*ModelCarStorageComponents, error) {
+	if !strings.HasPrefix(uri, ModelCarStoragePrefix) {
+		return nil, fmt.Errorf("invalid modelcar storage URI format: missing %s prefix", ModelCarStoragePrefix)
+	}
+
+	// Remove prefix
+	path := strings.TrimPrefix(uri, ModelCarStoragePrefix)
+	registry, repository, found := strings.Cut(path, "/")
+	if !found || registry == "" || repository == "" {
+		return nil, fmt.Errorf("invalid modelcar storage URI format: expected {registry}/{repository}[:tag][@digest]")
+	}
+
+	components := &ModelCarStorageComponents{Registry: registry}
+	if idx := strings.Index(repository, "@"); idx >= 0 {
+		components.Digest = repository[idx+1:]
+		repository = repository[:idx]
+		if !strings.HasPrefix(components.Digest, "sha256:") || len(components.Digest) != len("sha256:")+64 {
+			return nil, fmt.Errorf("invalid modelcar storage URI format: digest must be sha256:{64 hex characters}")
+		}
+	}
+	// The tag follows the last path segment, registry ports are in the registry part
+	if idx := strings.LastIndex(repository, ":"); idx > strings.LastIndex(repository, "/") {
+		components.Tag = repository[idx+1:]
+		repository = repository[:idx]
+		if components.Tag == "" {
+			return nil, fmt.Errorf("invalid modelcar storage URI format: tag cannot be empty")
+		}
+	}
+	if components.Tag == "" && components.Digest == "" {
+		components.Tag = DefaultModelCarTag
+	}
+	if repository == "" || strings.HasSuffix(repository, "/") || strings.Contains(repository, "//") {
+		return nil, fmt.Errorf("invalid modelcar storage URI format: invalid repository %q", repository)
+	}
+	if repository != strings.ToLower(repository) {
+		return nil, fmt.Errorf("invalid modelcar storage URI format: repository %q must be lowercase", repository)
+	}
+	components.Repository = repository
+	return components, nil
+}
+
+// ValidateModelCarStorageURI validates if the given URI matches modelcar storage format
+func ValidateModelCarStorageURI(uri string) error {
+	_, err := ParseModelCarStorageURI(uri)
+	return err
+}
+
+// ParseModelScopeStorageURI parses a ModelScope model URI and returns its components
+// Format: ms://{owner}/{name}[@{revision}]
+func ParseModelScopeStorageURI(uri string) (*ModelScopeStorageComponents, error) {
+	if !strings.HasPrefix(uri, ModelScopeStoragePrefix) {
+		return nil, fmt.Errorf("invalid ModelScope storage URI format: missing %s prefix", ModelScopeStoragePrefix)
+	}
+
+	// Remove prefix
+	path := strings.TrimPrefix(uri, ModelScopeStoragePrefix)
+	if path == "" {
+		return nil, fmt.Errorf("invalid ModelScope storage URI format: missing model ID")
+	}
+
+	modelID, revision, found := strings.Cut(path, "@")
+	if !found {
+		revision = DefaultModelScopeRevision
+	}
+	if revision == "" {
+		return nil, fmt.Errorf("invalid ModelScope storage URI format: revision cannot be empty")
+	}
+
+	// ModelScope model IDs always name their owner
+	owner, name, found := strings.Cut(modelID, "/")
+	if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid ModelScope storage URI format: expected model ID {owner}/{name}")
+	}
+
+	return &ModelScopeStorageComponents{
+		ModelID:  modelID,
+		Revision: revision,
+	}, nil
+}
+
+// ValidateModelScopeStorageURI validates if the given URI matches ModelScope model storage format
+func ValidateModelScopeStorageURI(uri string) error {
+	_, err := ParseModelScopeStorageURI(uri)
+	return err
+}
+
 // GetStorageType determines the type of storage URI
 func GetStorageType(uri string) (StorageType, error) {
 	switch {
@@ -552,6 +753,12 @@ func GetStorageType(uri string) (StorageType, error) {
 		return StorageTypeGitHub, nil
 	case strings.HasPrefix(uri, LocalStoragePrefix):
 		return StorageTypeLocal, nil
+	case strings.HasPrefix(uri, OllamaStoragePrefix):
+		return StorageTypeOllama, nil
+	case strings.HasPrefix(uri, ModelScopeStoragePrefix):
+		return StorageTypeModelScope, nil
+	case strings.HasPrefix(uri, ModelCarStoragePrefix):
+		return StorageTypeModelCar, nil
 	default:
 		return "", fmt.Errorf("unknown storage type for URI: %s", uri)
 	}
@@ -583,6 +790,12 @@ func ValidateStorageURI(uri string) error {
 		return ValidateGitHubStorageURI(uri)
 	case StorageTypeLocal:
 		return ValidateLocalStorageURI(uri)
+	case StorageTypeOllama:
+		return ValidateOllamaStorageURI(uri)
+	case StorageTypeModelScope:
+		return ValidateModelScopeStorageURI(uri)
+	case StorageTypeModelCar:
+		return ValidateModelCarStorageURI(uri)
 	default:
 		return fmt.Errorf("unsupported storage type: %s", storageType)
 	}
@@ -154,6 +154,41 @@ func TestValidateOCIStorageURI(t *testing.T) {
 	}
 }
 
+func TestIsLegacyOCIStorageURI(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want bool
+	}{
+		{
+			name: "canonical format",
+			uri:  "oci://n/myns/b/mybucket/o/mypath",
+			want: false,
+		},
+		{
+			name: "namespace and region format",
+			uri:  "oci://myns@us-ashburn-1/mybucket/mypath",
+			want: true,
+		},
+		{
+			name: "bucket and prefix format",
+			uri:  "oci://mybucket/mypath",
+			want: true,
+		},
+		{
+			name: "other storage type",
+			uri:  "hf://meta-llama/Llama-3.1-8B",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsLegacyOCIStorageURI(tt.uri))
+		})
+	}
+}
+
 func TestParsePVCStorageURI(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -478,6 +513,21 @@ func TestGetStorageType(t *testing.T) {
 			uri:  "hf://meta-llama/Llama-3-70B-Instruct@experimental",
 			want: StorageTypeHuggingFace,
 		},
+		{
+			name: "modelscope storage",
+			uri:  "ms://Qwen/Qwen2.5-7B-Instruct",
+			want: StorageTypeModelScope,
+		},
+		{
+			name: "ollama storage",
+			uri:  "ollama://llama3.2:3b",
+			want: StorageTypeOllama,
+		},
+		{
+			name: "modelcar storage",
+			uri:  "modelcar://ghcr.io/acme/llama-3.2-1b:v1",
+			want: StorageTypeModelCar,
+		},
 		{
 			name: "s3 storage",
 			uri:  "s3://my-bucket/my-prefix",
@@ -554,6 +604,26 @@ func TestValidateStorageURI(t *testing.T) {
 			uri:     "vendor://openai/models/gpt-4",
 			wantErr: false,
 		},
+		{
+			name:    "valid modelscope uri",
+			uri:     "ms://Qwen/Qwen2.5-7B-Instruct@v1.0.0",
+			wantErr: false,
+		},
+		{
+			name:    "invalid modelscope uri - missing owner",
+			uri:     "ms://Qwen2.5-7B-Instruct",
+			wantErr: true,
+		},
+		{
+			name:    "valid ollama uri",
+			uri:     "ollama://registry.example.com/team/qwen2.5:7b",
+			wantErr: false,
+		},
+		{
+			name:    "invalid ollama uri - empty tag",
+			uri:     "ollama://llama3.2:",
+			wantErr: true,
+		},
 		{
 			name:    "valid hugging face uri - with model ID only",
 			uri:     "hf://meta-llama/Llama-3-70B-Instruct",
@@ -1275,3 +1345,274 @@ func TestParseGitHubStorageURI(t *testing.T) {
 		})
 	}
 }
+
+func TestParseOllamaStorageURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		want        *OllamaStorageComponents
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "model only",
+			uri:  "ollama://llama3.2",
+			want: &OllamaStorageComponents{
+				Registry:  "registry.ollama.ai",
+				Namespace: "library",
+				Model:     "llama3.2",
+				Tag:       "latest",
+			},
+		},
+		{
+			name: "model with tag",
+			uri:  "ollama://llama3.2:3b-instruct-q4_K_M",
+			want: &OllamaStorageComponents{
+				Registry:  "registry.ollama.ai",
+				Namespace: "library",
+				Model:     "llama3.2",
+				Tag:       "3b-instruct-q4_K_M",
+			},
+		},
+		{
+			name: "namespaced model",
+			uri:  "ollama://team/mistral:7b",
+			want: &OllamaStorageComponents{
+				Registry:  "registry.ollama.ai",
+				Namespace: "team",
+				Model:     "mistral",
+				Tag:       "7b",
+			},
+		},
+		{
+			name: "registry with port",
+			uri:  "ollama://localhost:5000/team/mistral",
+			want: &OllamaStorageComponents{
+				Registry:  "localhost:5000",
+				Namespace: "team",
+				Model:     "mistral",
+				Tag:       "latest",
+			},
+		},
+		{
+			name: "registry without namespace",
+			uri:  "ollama://registry.example.com/qwen2.5:7b",
+			want: &OllamaStorageComponents{
+				Registry:  "registry.example.com",
+				Namespace: "library",
+				Model:     "qwen2.5",
+				Tag:       "7b",
+			},
+		},
+		{
+			name:        "missing prefix",
+			uri:         "llama3.2",
+			wantErr:     true,
+			errContains: "missing ollama:// prefix",
+		},
+		{
+			name:        "only prefix",
+			uri:         "ollama://",
+			wantErr:     true,
+			errContains: "missing model name",
+		},
+		{
+			name:        "too many parts",
+			uri:         "ollama://registry.example.com/a/b/c",
+			wantErr:     true,
+			errContains: "expected [registry/][namespace/]model[:tag]",
+		},
+		{
+			name:        "empty tag",
+			uri:         "ollama://llama3.2:",
+			wantErr:     true,
+			errContains: "tag cannot be empty",
+		},
+		{
+			name:        "empty model",
+			uri:         "ollama://team/",
+			wantErr:     true,
+			errContains: "model cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOllamaStorageURI(tt.uri)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errContains != "" {
+					assert.Contains(t, err.Error(), tt.errContains)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseModelCarStorageURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		want        *ModelCarStorageComponents
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "repository only",
+			uri:  "modelcar://ghcr.io/acme/llama-3.2-1b",
+			want: &ModelCarStorageComponents{
+				Registry:   "ghcr.io",
+				Repository: "acme/llama-3.2-1b",
+				Tag:        "latest",
+			},
+		},
+		{
+			name: "registry with port and tag",
+			uri:  "modelcar://localhost:5000/models/llama:v1",
+			want: &ModelCarStorageComponents{
+				Registry:   "localhost:5000",
+				Repository: "models/llama",
+				Tag:        "v1",
+			},
+		},
+		{
+			name: "digest",
+			uri:  "modelcar://iad.ocir.io/tenancy/models/llama@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			want: &ModelCarStorageComponents{
+				Registry:   "iad.ocir.io",
+				Repository: "tenancy/models/llama",
+				Digest:     "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			},
+		},
+		{
+			name: "tag and digest",
+			uri:  "modelcar://ghcr.io/acme/llama:v1@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			want: &ModelCarStorageComponents{
+				Registry:   "ghcr.io",
+				Repository: "acme/llama",
+				Tag:        "v1",
+				Digest:     "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			},
+		},
+		{
+			name:        "missing prefix",
+			uri:         "ghcr.io/acme/llama",
+			wantErr:     true,
+			errContains: "missing modelcar:// prefix",
+		},
+		{
+			name:        "missing repository",
+			uri:         "modelcar://ghcr.io",
+			wantErr:     true,
+			errContains: "expected {registry}/{repository}",
+		},
+		{
+			name:        "empty tag",
+			uri:         "modelcar://ghcr.io/acme/llama:",
+			wantErr:     true,
+			errContains: "tag cannot be empty",
+		},
+		{
+			name:        "invalid digest",
+			uri:         "modelcar://ghcr.io/acme/llama@sha256:abc",
+			wantErr:     true,
+			errContains: "digest must be sha256",
+		},
+		{
+			name:        "uppercase repository",
+			uri:         "modelcar://ghcr.io/acme/Llama",
+			wantErr:     true,
+			errContains: "must be lowercase",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseModelCarStorageURI(tt.uri)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errContains != "" {
+					assert.Contains(t, err.Error(), tt.errContains)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseModelScopeStorageURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		want        *ModelScopeStorageComponents
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "model ID only",
+			uri:  "ms://Qwen/Qwen2.5-7B-Instruct",
+			want: &ModelScopeStorageComponents{
+				ModelID:  "Qwen/Qwen2.5-7B-Instruct",
+				Revision: "master",
+			},
+		},
+		{
+			name: "model ID and revision",
+			uri:  "ms://deepseek-ai/DeepSeek-R1@v1.0.0",
+			want: &ModelScopeStorageComponents{
+				ModelID:  "deepseek-ai/DeepSeek-R1",
+				Revision: "v1.0.0",
+			},
+		},
+		{
+			name:        "missing prefix",
+			uri:         "Qwen/Qwen2.5-7B-Instruct",
+			wantErr:     true,
+			errContains: "missing ms:// prefix",
+		},
+		{
+			name:        "only prefix",
+			uri:         "ms://",
+			wantErr:     true,
+			errContains: "missing model ID",
+		},
+		{
+			name:        "missing owner",
+			uri:         "ms://Qwen2.5-7B-Instruct",
+			wantErr:     true,
+			errContains: "expected model ID {owner}/{name}",
+		},
+		{
+			name:        "nested name",
+			uri:         "ms://Qwen/Qwen2.5/7B",
+			wantErr:     true,
+			errContains: "expected model ID {owner}/{name}",
+		},
+		{
+			name:        "empty revision",
+			uri:         "ms://Qwen/Qwen2.5-7B-Instruct@",
+			wantErr:     true,
+			errContains: "revision cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseModelScopeStorageURI(tt.uri)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errContains != "" {
+					assert.Contains(t, err.Error(), tt.errContains)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
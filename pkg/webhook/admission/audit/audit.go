@@ -0,0 +1,182 @@
+// Package audit instruments admission webhooks with Prometheus metrics on their decisions, latency and
+// validation rule hits, and optionally writes a structured audit log entry for every reviewed request and
+// records the admitted changes in the audit event stream.
+package audit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/auditsink"
+)
+
+var log = logf.Log.WithName("webhook-audit")
+
+// Server wraps a webhook server so that every admission webhook registered with it, including the webhooks
+// registered by the controller-runtime webhook builder, is instrumented
+type Server struct {
+	webhook.Server
+	// AuditLog enables the structured audit log entries
+	AuditLog bool
+	// Events records the admitted changes of the OME resources with the user who made them, nothing if nil
+	Events *auditsink.Recorder
+}
+
+// NewServer returns a webhook server that instruments the admission webhooks registered with server
+func NewServer(server webhook.Server, auditLog bool) *Server {
+	return &Server{Server: server, AuditLog: auditLog}
+}
+
+// validatingPathPrefix is the prefix of the paths of the validating webhooks
+const validatingPathPrefix = "/validate-"
+
+// Register instruments admission webhooks and registers the hook with the wrapped server.
+// Other handlers, e.g. conversion webhooks, are registered as they are.
+func (s *Server) Register(path string, hook http.Handler) {
+	if admissionWebhook, ok := hook.(*admission.Webhook); ok && admissionWebhook.Handler != nil {
+		handler := &Handler{Webhook: path, Handler: admissionWebhook.Handler, AuditLog: s.AuditLog}
+		// The mutating webhooks review a change before the validating webhooks, which may still reject it
+		if strings.HasPrefix(path, validatingPathPrefix) {
+			handler.Events = s.Events
+		}
+		admissionWebhook.Handler = handler
+	}
+	s.Server.Register(path, hook)
+}
+
+// Handler records the decision, latency and rule hits of the admission handler it wraps
+type Handler struct {
+	// Webhook names the webhook in the metrics and audit log, usually its path
+	Webhook  string
+	Handler  admission.Handler
+	AuditLog bool
+	// Events records the admitted changes, nothing if nil. It is only set for the validating webhooks.
+	Events *auditsink.Recorder
+}
+
+// Handle reviews the request with the wrapped handler and records its response
+func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	recorder := &ruleRecorder{}
+	start := time.Now()
+	resp := h.Handler.Handle(context.WithValue(ctx, ruleRecorderKey{}, recorder), req)
+	duration := time.Since(start)
+
+	decision := admissionDecision(resp)
+	admissionDecisionsTotal.WithLabelValues(h.Webhook, string(req.Operation), decision).Inc()
+	admissionDuration.WithLabelValues(h.Webhook, decision).Observe(duration.Seconds())
+	rules := recorder.list()
+	for _, rule := range rules {
+		admissionRuleHitsTotal.WithLabelValues(h.Webhook, rule).Inc()
+	}
+
+	if h.AuditLog {
+		logEntry(h.Webhook, req, resp, decision, rules, duration)
+	}
+	if h.Events != nil && resp.Allowed && !(req.DryRun != nil && *req.DryRun) && isOMEGroup(req.Kind.Group) {
+		h.Events.Record(changeEvent(h.Webhook, req))
+	}
+	return resp
+}
+
+// omeGroup is the API group of the resources whose admitted changes are recorded as audit events, along with its
+// subgroups, e.g. serving.ome.io
+const omeGroup = "ome.io"
+
+// isOMEGroup reports whether an API group is the OME group or one of its subgroups
+func isOMEGroup(group string) bool {
+	return group == omeGroup || strings.HasSuffix(group, "."+omeGroup)
+}
+
+// changeEvent returns the audit event of an admitted change: who did what to which resource
+func changeEvent(webhookName string, req admission.Request) auditsink.Event {
+	return auditsink.Event{
+		Time:       time.Now().UTC(),
+		Type:       auditsink.EventChangeRequested,
+		Kind:       req.Kind.Kind,
+		Namespace:  req.Namespace,
+		Name:       req.Name,
+		Actor:      req.UserInfo.Username,
+		Controller: webhookName,
+		Message:    string(req.Operation) + " admitted",
+		Details: map[string]string{
+			"operation":    string(req.Operation),
+			"admissionUID": string(req.UID),
+		},
+	}
+}
+
+// admissionDecision classifies a response. Malformed requests and failures of the webhook itself are
+// errors rather than denials, they don't say anything about the reviewed object.
+func admissionDecision(resp admission.Response) string {
+	if resp.Allowed {
+		return decisionAllowed
+	}
+	if resp.Result != nil && (resp.Result.Code == http.StatusBadRequest || resp.Result.Code >= http.StatusInternalServerError) {
+		return decisionErrored
+	}
+	return decisionDenied
+}
+
+// logEntry writes the audit log entry of a reviewed request: who did what to which object and the verdict
+func logEntry(webhookName string, req admission.Request, resp admission.Response, decision string, rules []string, duration time.Duration) {
+	keysAndValues := []interface{}{
+		"webhook", webhookName,
+		"uid", req.UID,
+		"user", req.UserInfo.Username,
+		"groups", req.UserInfo.Groups,
+		"operation", req.Operation,
+		"kind", req.Kind.Kind,
+		"namespace", req.Namespace,
+		"name", req.Name,
+		"dryRun", req.DryRun != nil && *req.DryRun,
+		"decision", decision,
+		"duration", duration.String(),
+	}
+	if resp.Result != nil && resp.Result.Message != "" {
+		keysAndValues = append(keysAndValues, "message", resp.Result.Message)
+	}
+	if len(rules) > 0 {
+		keysAndValues = append(keysAndValues, "rules", rules)
+	}
+	if len(resp.Warnings) > 0 {
+		keysAndValues = append(keysAndValues, "warnings", resp.Warnings)
+	}
+	log.Info("Admission request reviewed", keysAndValues...)
+}
+
+// ruleRecorderKey is the context key of the rule recorder of an instrumented request
+type ruleRecorderKey struct{}
+
+// ruleRecorder collects the validation rules hit while a request is reviewed
+type ruleRecorder struct {
+	mu    sync.Mutex
+	rules []string
+}
+
+func (r *ruleRecorder) add(rule string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rule)
+}
+
+func (r *ruleRecorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rules
+}
+
+// RecordRule records that a validation rule denied or warned about the request under review, e.g.
+// "storage" or "priority_conflict". Rules are reported per webhook, so names only need to be unique
+// within a webhook. It does nothing if the webhook is not instrumented.
+func RecordRule(ctx context.Context, rule string) {
+	if recorder, ok := ctx.Value(ruleRecorderKey{}).(*ruleRecorder); ok {
+		recorder.add(rule)
+	}
+}
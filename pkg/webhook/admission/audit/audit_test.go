@@ -0,0 +1,180 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/auditsink"
+)
+
+func TestHandler(t *testing.T) {
+	scenarios := map[string]struct {
+		response admission.Response
+		rules    []string
+		decision string
+	}{
+		"Allowed": {
+			response: admission.Allowed(""),
+			decision: decisionAllowed,
+		},
+		"AllowedWithWarning": {
+			response: admission.Allowed("").WithWarnings("spec.storage.key: secret default/hf-token does not exist"),
+			rules:    []string{"secret"},
+			decision: decisionAllowed,
+		},
+		"Denied": {
+			response: admission.Denied("spec.storage.storageUri is required"),
+			rules:    []string{"spec"},
+			decision: decisionDenied,
+		},
+		"BadRequest": {
+			response: admission.Errored(http.StatusBadRequest, errors.New("couldn't decode object")),
+			decision: decisionErrored,
+		},
+		"InternalError": {
+			response: admission.Errored(http.StatusInternalServerError, errors.New("list failed")),
+			decision: decisionErrored,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			webhookName := "/validate-" + name
+			handler := &Handler{
+				Webhook: webhookName,
+				Handler: admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+					for _, rule := range scenario.rules {
+						RecordRule(ctx, rule)
+					}
+					return scenario.response
+				}),
+				AuditLog: true,
+			}
+
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Name: "llama"}}
+			resp := handler.Handle(context.TODO(), req)
+			g.Expect(resp).To(gomega.Equal(scenario.response))
+
+			g.Expect(testutil.ToFloat64(admissionDecisionsTotal.WithLabelValues(webhookName, "CREATE", scenario.decision))).To(gomega.Equal(1.0))
+			for _, rule := range scenario.rules {
+				g.Expect(testutil.ToFloat64(admissionRuleHitsTotal.WithLabelValues(webhookName, rule))).To(gomega.Equal(1.0))
+			}
+		})
+	}
+}
+
+func TestHandlerRecordsAdmittedChanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var buf bytes.Buffer
+	events := auditsink.NewRecorder(auditsink.NewWriterSink(&buf), 0)
+	handler := &Handler{
+		Webhook: "/validate-ome-io-v1beta1-inferenceservice",
+		Handler: admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+			if req.Name == "denied" {
+				return admission.Denied("spec.model is required")
+			}
+			return admission.Allowed("")
+		}),
+		Events: events,
+	}
+
+	dryRun := true
+	request := func(group, name string, dryRun *bool) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "5c1e4d7b",
+			Kind:      metav1.GroupVersionKind{Group: group, Version: "v1beta1", Kind: "InferenceService"},
+			Operation: admissionv1.Update,
+			Namespace: "serving",
+			Name:      name,
+			UserInfo:  authenticationv1.UserInfo{Username: "alice@example.com"},
+			DryRun:    dryRun,
+		}}
+	}
+	handler.Handle(context.TODO(), request("ome.io", "llama", nil))
+	handler.Handle(context.TODO(), request("serving.ome.io", "bench", nil))
+	// Denied requests, dry runs and the changes of other resources are not recorded
+	handler.Handle(context.TODO(), request("ome.io", "denied", nil))
+	handler.Handle(context.TODO(), request("ome.io", "mistral", &dryRun))
+	handler.Handle(context.TODO(), request("", "qwen", nil))
+	handler.Handle(context.TODO(), request("notome.io", "phi", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(events.Start(ctx)).To(gomega.Succeed())
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	g.Expect(lines).To(gomega.HaveLen(2))
+	var event auditsink.Event
+	g.Expect(json.Unmarshal([]byte(lines[0]), &event)).To(gomega.Succeed())
+	g.Expect(event.Type).To(gomega.Equal(auditsink.EventChangeRequested))
+	g.Expect(event.Name).To(gomega.Equal("llama"))
+	g.Expect(event.Actor).To(gomega.Equal("alice@example.com"))
+	g.Expect(event.Details).To(gomega.HaveKeyWithValue("admissionUID", "5c1e4d7b"))
+	g.Expect(json.Unmarshal([]byte(lines[1]), &event)).To(gomega.Succeed())
+	g.Expect(event.Name).To(gomega.Equal("bench"))
+}
+
+func TestServerRecordsChangesOfValidatingWebhooks(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	events := auditsink.NewRecorder(auditsink.NewWriterSink(&bytes.Buffer{}), 0)
+	server := NewServer(webhook.NewServer(webhook.Options{}), false)
+	server.Events = events
+	allow := admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		return admission.Allowed("")
+	})
+
+	validating := &webhook.Admission{Handler: allow}
+	server.Register("/validate-ome-io-v1beta1-inferenceservice", validating)
+	g.Expect(validating.Handler.(*Handler).Events).To(gomega.BeIdenticalTo(events))
+	// A change reviewed by a mutating webhook may still be rejected by a validating one
+	mutating := &webhook.Admission{Handler: allow}
+	server.Register("/mutate-ome-io-v1beta1-inferenceservice", mutating)
+	g.Expect(mutating.Handler.(*Handler).Events).To(gomega.BeNil())
+}
+
+func TestRecordRuleWithoutInstrumentation(t *testing.T) {
+	// Validators are also called directly, e.g. in their unit tests
+	RecordRule(context.TODO(), "spec")
+}
+
+// registeringServer records the handlers registered with it
+type registeringServer struct {
+	webhook.Server
+	hooks map[string]http.Handler
+}
+
+func (s *registeringServer) Register(path string, hook http.Handler) {
+	s.hooks[path] = hook
+}
+
+func TestServerRegister(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	wrapped := &registeringServer{hooks: map[string]http.Handler{}}
+	server := NewServer(wrapped, false)
+
+	validator := admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		return admission.Allowed("")
+	})
+	server.Register("/validate-ome-io-v1beta1-basemodel", &webhook.Admission{Handler: validator})
+	conversion := http.NewServeMux()
+	server.Register("/convert", conversion)
+
+	hook, ok := wrapped.hooks["/validate-ome-io-v1beta1-basemodel"].(*webhook.Admission)
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(hook.Handler).To(gomega.BeAssignableToTypeOf(&Handler{}))
+	g.Expect(hook.Handler.(*Handler).Webhook).To(gomega.Equal("/validate-ome-io-v1beta1-basemodel"))
+	g.Expect(wrapped.hooks["/convert"]).To(gomega.BeIdenticalTo(conversion))
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Admission decisions reported by the webhook metrics and audit log
+const (
+	decisionAllowed = "allowed"
+	decisionDenied  = "denied"
+	decisionErrored = "errored"
+)
+
+var (
+	admissionDecisionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ome_webhook_admission_decisions_total",
+		Help: "Number of admission requests reviewed by webhook, operation and decision (allowed, denied, errored)",
+	}, []string{"webhook", "operation", "decision"})
+
+	admissionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ome_webhook_admission_duration_seconds",
+		Help:    "Time taken by a webhook to review an admission request by decision",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 12),
+	}, []string{"webhook", "decision"})
+
+	admissionRuleHitsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ome_webhook_admission_rule_hits_total",
+		Help: "Number of times a validation rule of a webhook denied or warned about an admission request",
+	}, []string{"webhook", "rule"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(admissionDecisionsTotal, admissionDuration, admissionRuleHitsTotal)
+}
@@ -0,0 +1,248 @@
+package basemodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/utils/storage"
+	"github.com/sgl-project/ome/pkg/webhook/admission/audit"
+	"github.com/sgl-project/ome/pkg/webhook/admission/deprecation"
+)
+
+var log = logf.Log.WithName(constants.BaseModelValidatorWebhookName)
+
+const (
+	MissingStorageURIError         = "spec.storage.storageUri is required"
+	InvalidStorageURIError         = "invalid spec.storage.storageUri %q: %s"
+	InvalidStoragePathError        = "invalid spec.storage.path %q: must be an absolute path"
+	InvalidStorageKeyError         = "invalid spec.storage.key %q: %s"
+	InvalidSecretKeyParameterError = "invalid spec.storage.parameters.secretKey %q: %s"
+	InvalidModelParameterSizeError = "invalid spec.modelParameterSize %q: expected a parameter count such as 7B, 1.5T or 350M"
+	InvalidMaxTokensError          = "invalid spec.maxTokens %d: must be greater than 0"
+	ImmutableStorageError          = "spec.storage.%s cannot be changed once the model is Ready, create a new model instead"
+	MissingSecretWarning           = "spec.storage.key: secret %s/%s does not exist, the model agent can't authenticate to the storage until it is created"
+	MissingSecretKeyWarning        = "spec.storage.parameters.secretKey: secret %s/%s has no key %q"
+)
+
+// secretKeyParameter names the key of the storage secret that holds the credentials
+const secretKeyParameter = "secretKey"
+
+// modelParameterSizeRegexp matches the parameter counts written by the model metadata extraction, e.g. 7B or 1.5T
+var modelParameterSizeRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[KMBT]?$`)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ome-io-v1beta1-basemodel,mutating=false,failurePolicy=fail,groups=ome.io,resources=basemodels,versions=v1beta1,name=basemodel.ome-webhook-server.validator
+
+// BaseModelValidator validates the storage, secret reference and size fields of BaseModels
+type BaseModelValidator struct {
+	Clientset kubernetes.Interface
+	Decoder   admission.Decoder
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ome-io-v1beta1-clusterbasemodel,mutating=false,failurePolicy=fail,groups=ome.io,resources=clusterbasemodels,versions=v1beta1,name=clusterbasemodel.ome-webhook-server.validator
+
+// ClusterBaseModelValidator validates the storage, secret reference and size fields of ClusterBaseModels
+type ClusterBaseModelValidator struct {
+	Clientset kubernetes.Interface
+	Decoder   admission.Decoder
+}
+
+// Handle validates the incoming request
+func (v *BaseModelValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	baseModel := &v1beta1.BaseModel{}
+	if err := v.Decoder.Decode(req, baseModel); err != nil {
+		log.Error(err, "Failed to decode base model", "name", req.Name, "namespace", req.Namespace)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var old *modelState
+	if req.Operation == admissionv1.Update {
+		oldBaseModel := &v1beta1.BaseModel{}
+		if err := v.Decoder.DecodeRaw(req.OldObject, oldBaseModel); err != nil {
+			log.Error(err, "Failed to decode old base model", "name", req.Name, "namespace", req.Namespace)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		old = &modelState{spec: &oldBaseModel.Spec, state: oldBaseModel.Status.State}
+	}
+	// BaseModels read their secrets from their own namespace
+	return validateModel(ctx, v.Clientset, baseModel.Namespace, &baseModel.Spec, old)
+}
+
+// Handle validates the incoming request
+func (v *ClusterBaseModelValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	clusterBaseModel := &v1beta1.ClusterBaseModel{}
+	if err := v.Decoder.Decode(req, clusterBaseModel); err != nil {
+		log.Error(err, "Failed to decode cluster base model", "name", req.Name)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var old *modelState
+	if req.Operation == admissionv1.Update {
+		oldClusterBaseModel := &v1beta1.ClusterBaseModel{}
+		if err := v.Decoder.DecodeRaw(req.OldObject, oldClusterBaseModel); err != nil {
+			log.Error(err, "Failed to decode old cluster base model", "name", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		old = &modelState{spec: &oldClusterBaseModel.Spec, state: oldClusterBaseModel.Status.State}
+	}
+	// ClusterBaseModels read their secrets from the OME namespace
+	return validateModel(ctx, v.Clientset, constants.OMENamespace, &clusterBaseModel.Spec, old)
+}
+
+// modelState is the spec and lifecycle state of a model before an update
+type modelState struct {
+	spec  *v1beta1.BaseModelSpec
+	state v1beta1.LifeCycleState
+}
+
+// validateModel returns the admission response for a model spec. old is nil on create.
+func validateModel(ctx context.Context, clientset kubernetes.Interface, secretNamespace string, spec *v1beta1.BaseModelSpec, old *modelState) admission.Response {
+	warnings := deprecationWarnings(spec)
+
+	// Only updates that change the spec are validated, so that the controllers can still update the
+	// metadata of models created before this webhook existed
+	if old != nil && equality.Semantic.DeepEqual(old.spec, spec) {
+		return admission.Allowed("").WithWarnings(warnings...)
+	}
+
+	if err := validateBaseModelSpec(spec); err != nil {
+		audit.RecordRule(ctx, "spec")
+		return admission.Denied(err.Error()).WithWarnings(warnings...)
+	}
+	if old != nil && old.state == v1beta1.LifeCycleStateReady {
+		if err := validateStorageImmutable(old.spec.Storage, spec.Storage); err != nil {
+			audit.RecordRule(ctx, "immutable_storage")
+			return admission.Denied(err.Error()).WithWarnings(warnings...)
+		}
+	}
+
+	if missingSecret := secretWarnings(ctx, clientset, secretNamespace, spec.Storage); len(missingSecret) > 0 {
+		audit.RecordRule(ctx, "secret")
+		warnings = append(warnings, missingSecret...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// validateBaseModelSpec validates the storage, secret reference and size fields of the model
+func validateBaseModelSpec(spec *v1beta1.BaseModelSpec) error {
+	if err := ValidateStorage(spec.Storage); err != nil {
+		return err
+	}
+	if spec.ModelParameterSize != nil && !modelParameterSizeRegexp.MatchString(*spec.ModelParameterSize) {
+		return fmt.Errorf(InvalidModelParameterSizeError, *spec.ModelParameterSize)
+	}
+	if spec.MaxTokens != nil && *spec.MaxTokens <= 0 {
+		return fmt.Errorf(InvalidMaxTokensError, *spec.MaxTokens)
+	}
+	return nil
+}
+
+// ValidateStorage checks that the storage URI is supported and the path and secret reference are well formed
+func ValidateStorage(storageSpec *v1beta1.StorageSpec) error {
+	if storageSpec == nil || ptr.Deref(storageSpec.StorageUri, "") == "" {
+		return errors.New(MissingStorageURIError)
+	}
+
+	// Legacy OCI URIs are still accepted by the model agent, StorageWarnings warns about them
+	uri := *storageSpec.StorageUri
+	if !storage.IsLegacyOCIStorageURI(uri) {
+		if err := storage.ValidateStorageURI(uri); err != nil {
+			return fmt.Errorf(InvalidStorageURIError, uri, err.Error())
+		}
+	}
+
+	if storageSpec.Path != nil && !filepath.IsAbs(*storageSpec.Path) {
+		return fmt.Errorf(InvalidStoragePathError, *storageSpec.Path)
+	}
+
+	if key := ptr.Deref(storageSpec.StorageKey, ""); key != "" {
+		if errs := validation.IsDNS1123Subdomain(key); len(errs) > 0 {
+			return fmt.Errorf(InvalidStorageKeyError, key, strings.Join(errs, ", "))
+		}
+	}
+	if secretKey := storageParameter(storageSpec, secretKeyParameter); secretKey != "" {
+		if errs := validation.IsConfigMapKey(secretKey); len(errs) > 0 {
+			return fmt.Errorf(InvalidSecretKeyParameterError, secretKey, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// validateStorageImmutable checks that the source and the node path of the model don't change. Nodes that
+// already downloaded the model would keep serving the old weights.
+func validateStorageImmutable(oldStorage, newStorage *v1beta1.StorageSpec) error {
+	if oldStorage == nil {
+		return nil
+	}
+	if ptr.Deref(oldStorage.StorageUri, "") != ptr.Deref(newStorage.StorageUri, "") {
+		return fmt.Errorf(ImmutableStorageError, "storageUri")
+	}
+	if ptr.Deref(oldStorage.Path, "") != ptr.Deref(newStorage.Path, "") {
+		return fmt.Errorf(ImmutableStorageError, "path")
+	}
+	return nil
+}
+
+// secretWarnings warns when the secret referenced by the storage doesn't exist or lacks the configured key.
+// A missing secret is not an error since it is often created after the model.
+func secretWarnings(ctx context.Context, clientset kubernetes.Interface, namespace string, storageSpec *v1beta1.StorageSpec) []string {
+	key := ptr.Deref(storageSpec.StorageKey, "")
+	if clientset == nil || key == "" {
+		return nil
+	}
+
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, key, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return []string{fmt.Sprintf(MissingSecretWarning, namespace, key)}
+	}
+	if err != nil {
+		log.Error(err, "Failed to get storage secret", "namespace", namespace, "name", key)
+		return nil
+	}
+
+	if secretKey := storageParameter(storageSpec, secretKeyParameter); secretKey != "" {
+		if _, ok := secret.Data[secretKey]; !ok {
+			return []string{fmt.Sprintf(MissingSecretKeyWarning, namespace, key, secretKey)}
+		}
+	}
+	return nil
+}
+
+// storageParameter returns the value of a storage parameter, or an empty string if it is not set
+func storageParameter(storageSpec *v1beta1.StorageSpec, name string) string {
+	if storageSpec.Parameters == nil {
+		return ""
+	}
+	return (*storageSpec.Parameters)[name]
+}
+
+// deprecationWarnings returns a warning for every legacy storage URI and model capability the model uses
+func deprecationWarnings(spec *v1beta1.BaseModelSpec) []string {
+	warnings := StorageWarnings(spec.Storage)
+	warnings = append(warnings, deprecation.ModelCapabilityWarnings("spec.modelCapabilities", spec.ModelCapabilities)...)
+	return warnings
+}
+
+// StorageWarnings returns a warning if the storage uses a legacy storage URI format
+func StorageWarnings(storageSpec *v1beta1.StorageSpec) []string {
+	if storageSpec == nil {
+		return nil
+	}
+	return deprecation.StorageURIWarnings("spec.storage.storageUri", storageSpec.StorageUri)
+}
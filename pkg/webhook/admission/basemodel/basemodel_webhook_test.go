@@ -0,0 +1,210 @@
+package basemodel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func newBaseModel(spec v1beta1.BaseModelSpec, state v1beta1.LifeCycleState) *v1beta1.BaseModel {
+	return &v1beta1.BaseModel{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "BaseModel"},
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       spec,
+		Status:     v1beta1.ModelStatusSpec{State: state},
+	}
+}
+
+func rawExtension(g *gomega.WithT, obj runtime.Object) runtime.RawExtension {
+	raw, err := json.Marshal(obj)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	return runtime.RawExtension{Raw: raw}
+}
+
+func TestBaseModelValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	validator := &BaseModelValidator{
+		Clientset: fake.NewSimpleClientset(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("secret")},
+		}),
+		Decoder: admission.NewDecoder(scheme),
+	}
+
+	hfStorage := &v1beta1.StorageSpec{StorageUri: ptr.To("hf://meta-llama/Llama-3.1-8B"), Path: ptr.To("/raid/models/llama")}
+
+	scenarios := map[string]struct {
+		spec     v1beta1.BaseModelSpec
+		old      *v1beta1.BaseModel
+		allowed  bool
+		message  string
+		warnings gomega.OmegaMatcher
+	}{
+		"Valid": {
+			spec: v1beta1.BaseModelSpec{
+				Storage:            &v1beta1.StorageSpec{StorageUri: ptr.To("oci://n/myns/b/models/o/llama"), StorageKey: ptr.To("hf-token")},
+				ModelCapabilities:  []string{string(v1beta1.ModelCapabilityTextToText)},
+				ModelParameterSize: ptr.To("8.03B"),
+				MaxTokens:          ptr.To(int32(131072)),
+			},
+			allowed:  true,
+			warnings: gomega.BeEmpty(),
+		},
+		"LegacyStorageURIAndCapability": {
+			spec: v1beta1.BaseModelSpec{
+				Storage:           &v1beta1.StorageSpec{StorageUri: ptr.To("oci://myns@us-ashburn-1/models/llama")},
+				ModelCapabilities: []string{string(v1beta1.ModelCapabilityTextGeneration)},
+			},
+			allowed: true,
+			warnings: gomega.Equal([]string{
+				`spec.storage.storageUri: storage URI format of "oci://myns@us-ashburn-1/models/llama" is deprecated and will be removed in a future release, use oci://n/{namespace}/b/{bucket}/o/{object_path} instead`,
+				`spec.modelCapabilities[0]: value "TEXT_GENERATION" is deprecated and will be removed in a future release, use TEXT_TO_TEXT instead`,
+			}),
+		},
+		"MissingStorage": {
+			spec:    v1beta1.BaseModelSpec{},
+			message: MissingStorageURIError,
+		},
+		"UnknownStorageType": {
+			spec:    v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: ptr.To("ftp://models/llama")}},
+			message: `invalid spec.storage.storageUri "ftp://models/llama"`,
+		},
+		"RelativePath": {
+			spec:    v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: hfStorage.StorageUri, Path: ptr.To("models/llama")}},
+			message: fmt.Sprintf(InvalidStoragePathError, "models/llama"),
+		},
+		"InvalidSecretName": {
+			spec:    v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: hfStorage.StorageUri, StorageKey: ptr.To("HF_Token")}},
+			message: `invalid spec.storage.key "HF_Token"`,
+		},
+		"InvalidSecretKeyParameter": {
+			spec: v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{
+				StorageUri: hfStorage.StorageUri,
+				StorageKey: ptr.To("hf-token"),
+				Parameters: &map[string]string{"secretKey": "hf token"},
+			}},
+			message: `invalid spec.storage.parameters.secretKey "hf token"`,
+		},
+		"MissingSecret": {
+			spec:     v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: hfStorage.StorageUri, StorageKey: ptr.To("other-token")}},
+			allowed:  true,
+			warnings: gomega.Equal([]string{fmt.Sprintf(MissingSecretWarning, "default", "other-token")}),
+		},
+		"MissingSecretKey": {
+			spec: v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{
+				StorageUri: hfStorage.StorageUri,
+				StorageKey: ptr.To("hf-token"),
+				Parameters: &map[string]string{"secretKey": "hf-token"},
+			}},
+			allowed:  true,
+			warnings: gomega.Equal([]string{fmt.Sprintf(MissingSecretKeyWarning, "default", "hf-token", "hf-token")}),
+		},
+		"InvalidModelParameterSize": {
+			spec:    v1beta1.BaseModelSpec{Storage: hfStorage, ModelParameterSize: ptr.To("8 billion")},
+			message: fmt.Sprintf(InvalidModelParameterSizeError, "8 billion"),
+		},
+		"InvalidMaxTokens": {
+			spec:    v1beta1.BaseModelSpec{Storage: hfStorage, MaxTokens: ptr.To(int32(0))},
+			message: fmt.Sprintf(InvalidMaxTokensError, 0),
+		},
+		"StorageChangedAfterReady": {
+			spec:    v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: ptr.To("hf://meta-llama/Llama-3.1-70B"), Path: hfStorage.Path}},
+			old:     newBaseModel(v1beta1.BaseModelSpec{Storage: hfStorage}, v1beta1.LifeCycleStateReady),
+			message: fmt.Sprintf(ImmutableStorageError, "storageUri"),
+		},
+		"PathChangedAfterReady": {
+			spec:    v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: hfStorage.StorageUri, Path: ptr.To("/mnt/models/llama")}},
+			old:     newBaseModel(v1beta1.BaseModelSpec{Storage: hfStorage}, v1beta1.LifeCycleStateReady),
+			message: fmt.Sprintf(ImmutableStorageError, "path"),
+		},
+		"StorageChangedBeforeReady": {
+			spec:     v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: ptr.To("hf://meta-llama/Llama-3.1-70B"), Path: hfStorage.Path}},
+			old:      newBaseModel(v1beta1.BaseModelSpec{Storage: hfStorage}, v1beta1.LifeCycleStateInTransit),
+			allowed:  true,
+			warnings: gomega.BeEmpty(),
+		},
+		"NodeSelectorChangedAfterReady": {
+			spec: v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{
+				StorageUri:   hfStorage.StorageUri,
+				Path:         hfStorage.Path,
+				NodeSelector: map[string]string{"node.kubernetes.io/instance-type": "BM.GPU.H100.8"},
+			}},
+			old:      newBaseModel(v1beta1.BaseModelSpec{Storage: hfStorage}, v1beta1.LifeCycleStateReady),
+			allowed:  true,
+			warnings: gomega.BeEmpty(),
+		},
+		"UnchangedInvalidSpec": {
+			spec:     v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: ptr.To("ftp://models/llama")}},
+			old:      newBaseModel(v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{StorageUri: ptr.To("ftp://models/llama")}}, v1beta1.LifeCycleStateFailed),
+			allowed:  true,
+			warnings: gomega.BeEmpty(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    rawExtension(g, newBaseModel(scenario.spec, "")),
+				},
+			}
+			if scenario.old != nil {
+				req.Operation = admissionv1.Update
+				req.OldObject = rawExtension(g, scenario.old)
+			}
+
+			response := validator.Handle(context.TODO(), req)
+			g.Expect(response.Allowed).To(gomega.Equal(scenario.allowed))
+			if !scenario.allowed {
+				g.Expect(response.Result.Message).To(gomega.ContainSubstring(scenario.message))
+				return
+			}
+			g.Expect(response.Warnings).To(scenario.warnings)
+		})
+	}
+}
+
+func TestClusterBaseModelValidator(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	validator := &ClusterBaseModelValidator{
+		Clientset: fake.NewSimpleClientset(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: constants.OMENamespace},
+		}),
+		Decoder: admission.NewDecoder(scheme),
+	}
+
+	clusterBaseModel := &v1beta1.ClusterBaseModel{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "ClusterBaseModel"},
+		ObjectMeta: metav1.ObjectMeta{Name: "llama"},
+		Spec: v1beta1.BaseModelSpec{
+			Storage: &v1beta1.StorageSpec{StorageUri: ptr.To("hf://meta-llama/Llama-3.1-8B"), StorageKey: ptr.To("hf-token")},
+		},
+	}
+	response := validator.Handle(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    rawExtension(g, clusterBaseModel),
+		},
+	})
+	// The secrets of cluster models are read from the OME namespace
+	g.Expect(response.Allowed).To(gomega.BeTrue())
+	g.Expect(response.Warnings).To(gomega.BeEmpty())
+}
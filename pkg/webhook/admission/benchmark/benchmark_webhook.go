@@ -5,23 +5,29 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	v1beta1 "github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 	storageutil "github.com/sgl-project/ome/pkg/utils/storage"
+	"github.com/sgl-project/ome/pkg/webhook/admission/audit"
 )
 
 var log = logf.Log.WithName(constants.BenchmarkJobValidatorWebhookName)
 
 // BenchmarkJobValidator validates BenchmarkJob objects.
 type BenchmarkJobValidator struct {
-	Client  client.Client
-	Decoder admission.Decoder
+	Client client.Client
+	// Clientset reads the benchmarkjob config, the features of the benchmark image are not checked when nil
+	Clientset kubernetes.Interface
+	Decoder   admission.Decoder
 }
 
 // +kubebuilder:webhook:path=/validate-ome-io-benchmark-job,mutating=false,failurePolicy=fail,groups=serving.ome.io,resources=benchmarkjobs,,verbs=create;update,versions=v1beta1,name=benchmarkjob.ome-webhook-server.validator,sideEffects=None,admissionReviewVersions=v1
@@ -36,6 +42,7 @@ func (v *BenchmarkJobValidator) Handle(ctx context.Context, req admission.Reques
 	}
 
 	if err := v.validateBenchmarkJob(ctx, benchmarkJob); err != nil {
+		audit.RecordRule(ctx, "spec")
 		log.Error(err, "Validation failed for BenchmarkJob", "namespace", benchmarkJob.Namespace, "name", benchmarkJob.Name)
 		return admission.Denied(err.Error())
 	}
@@ -50,21 +57,49 @@ func (v *BenchmarkJobValidator) validateBenchmarkJob(ctx context.Context, benchm
 		return fmt.Errorf("invalid endpoint: %w", err)
 	}
 
+	// Validate Comparison
+	if err := v.validateComparison(benchmarkJob.Spec.Comparison); err != nil {
+		return fmt.Errorf("invalid comparison: %w", err)
+	}
+	if err := v.validateComparisonFeatures(benchmarkJob.Spec.Comparison); err != nil {
+		return fmt.Errorf("invalid comparison: %w", err)
+	}
+
 	// Validate Traffic Scenarios
 	if err := v.validateTrafficScenarios(benchmarkJob.Spec.Task, benchmarkJob.Spec.TrafficScenarios); err != nil {
 		return fmt.Errorf("invalid traffic scenarios: %w", err)
 	}
 
+	// Validate Traffic Schedule
+	if err := v.validateTrafficSchedule(benchmarkJob.Spec.TrafficSchedule, benchmarkJob.Spec.NumConcurrency); err != nil {
+		return fmt.Errorf("invalid traffic schedule: %w", err)
+	}
+
+	// Validate Warmup
+	if err := v.validateWarmup(benchmarkJob.Spec.WarmupDuration, benchmarkJob.Spec.WarmupRequests); err != nil {
+		return fmt.Errorf("invalid warmup: %w", err)
+	}
+
 	// Validate Additional Request Parameters
 	if err := v.validateAdditionalRequestParams(benchmarkJob.Spec.AdditionalRequestParams); err != nil {
 		return fmt.Errorf("invalid additional request parameters: %w", err)
 	}
 
+	// Validate Workload
+	if err := v.validateWorkload(benchmarkJob.Spec.Task, benchmarkJob.Spec.Workload, benchmarkJob.Spec.Dataset); err != nil {
+		return fmt.Errorf("invalid workload: %w", err)
+	}
+
 	// Validate Storage
 	if err := v.validateStorage(benchmarkJob.Spec.OutputLocation); err != nil {
 		return fmt.Errorf("invalid storage: %w", err)
 	}
 
+	// Validate Dataset
+	if err := v.validateStorage(benchmarkJob.Spec.Dataset); err != nil {
+		return fmt.Errorf("invalid dataset: %w", err)
+	}
+
 	return nil
 }
 
@@ -78,6 +113,42 @@ func (v *BenchmarkJobValidator) validateEndpoint(endpoint v1beta1.EndpointSpec)
 	return nil
 }
 
+func (v *BenchmarkJobValidator) validateComparison(comparison *v1beta1.ComparisonSpec) error {
+	if comparison == nil {
+		return nil
+	}
+	if err := v.validateEndpoint(comparison.Candidate); err != nil {
+		return fmt.Errorf("invalid candidate endpoint: %w", err)
+	}
+	if cost := comparison.BaselineCostPerHour; cost != nil && cost.Sign() < 0 {
+		return fmt.Errorf("baselineCostPerHour must not be negative, got %s", cost.String())
+	}
+	if cost := comparison.CandidateCostPerHour; cost != nil && cost.Sign() < 0 {
+		return fmt.Errorf("candidateCostPerHour must not be negative, got %s", cost.String())
+	}
+	return nil
+}
+
+// validateComparisonFeatures rejects comparative benchmarks when the configured genai-bench image doesn't
+// report the metrics they compare.
+func (v *BenchmarkJobValidator) validateComparisonFeatures(comparison *v1beta1.ComparisonSpec) error {
+	if comparison == nil || v.Clientset == nil {
+		return nil
+	}
+	config, err := controllerconfig.NewBenchmarkJobConfig(v.Clientset)
+	if err != nil {
+		return fmt.Errorf("failed to read the benchmarkjob config: %w", err)
+	}
+	if config.Runner != "" && config.Runner != controllerconfig.BenchmarkRunnerGenAIBench {
+		return nil
+	}
+	if !slices.Contains(config.Features, controllerconfig.BenchmarkFeatureMetricsReport) {
+		return fmt.Errorf("benchmark image %s does not list the %s feature in the benchmarkjob config, which comparisons need",
+			config.PodConfig.Image, controllerconfig.BenchmarkFeatureMetricsReport)
+	}
+	return nil
+}
+
 func (v *BenchmarkJobValidator) validateTrafficScenarios(task string, scenarios []string) error {
 	// Define default scenarios for each task
 	defaultScenarios := map[string][]string{
@@ -153,6 +224,126 @@ func validateScenario(task string, scenario string) error {
 	return nil
 }
 
+func (v *BenchmarkJobValidator) validateTrafficSchedule(schedule *v1beta1.TrafficScheduleSpec, numConcurrency []int) error {
+	if schedule == nil {
+		return nil
+	}
+	if len(numConcurrency) > 0 {
+		return fmt.Errorf("trafficSchedule and numConcurrency cannot be specified together")
+	}
+	if len(schedule.Phases) == 0 {
+		return fmt.Errorf("at least one phase must be specified")
+	}
+
+	byRate := schedule.Phases[0].RequestRate != nil
+	for i, phase := range schedule.Phases {
+		if phase.Duration < 1 {
+			return fmt.Errorf("duration of phase %d must be at least 1, got %d", i, phase.Duration)
+		}
+		switch phase.Shape {
+		case "", v1beta1.ConstantPhase, v1beta1.RampPhase:
+		default:
+			return fmt.Errorf("unsupported shape of phase %d: %s", i, phase.Shape)
+		}
+		if (phase.RequestRate == nil) == (phase.Concurrency == nil) {
+			return fmt.Errorf("exactly one of requestRate or concurrency must be specified for phase %d", i)
+		}
+		if (phase.RequestRate != nil) != byRate {
+			return fmt.Errorf("all phases must specify the same one of requestRate or concurrency")
+		}
+		if phase.RequestRate != nil && phase.RequestRate.Sign() <= 0 {
+			return fmt.Errorf("requestRate of phase %d must be positive, got %s", i, phase.RequestRate.String())
+		}
+		if phase.Concurrency != nil && *phase.Concurrency < 1 {
+			return fmt.Errorf("concurrency of phase %d must be at least 1, got %d", i, *phase.Concurrency)
+		}
+	}
+	return nil
+}
+
+func (v *BenchmarkJobValidator) validateWarmup(duration, requests *int) error {
+	if duration != nil && *duration < 0 {
+		return fmt.Errorf("warmupDuration must not be negative, got %d", *duration)
+	}
+	if requests != nil && *requests < 0 {
+		return fmt.Errorf("warmupRequests must not be negative, got %d", *requests)
+	}
+	return nil
+}
+
+func (v *BenchmarkJobValidator) validateWorkload(task string, workload *v1beta1.WorkloadSpec, dataset *v1beta1.StorageSpec) error {
+	if workload == nil {
+		return nil
+	}
+
+	switch workload.Type {
+	case "", v1beta1.SingleTurnWorkload:
+		if workload.Conversation != nil {
+			return fmt.Errorf("conversation can only be specified for workload type %s", v1beta1.ConversationWorkload)
+		}
+		if workload.Replay != nil {
+			return fmt.Errorf("replay can only be specified for workload type %s", v1beta1.ReplayWorkload)
+		}
+		return nil
+	case v1beta1.ConversationWorkload, v1beta1.ReplayWorkload:
+	default:
+		return fmt.Errorf("unsupported workload type: %s", workload.Type)
+	}
+
+	if task != "text-to-text" {
+		return fmt.Errorf("workload type %s is only supported for the text-to-text task, got %s", workload.Type, task)
+	}
+
+	if workload.Type == v1beta1.ReplayWorkload {
+		if workload.Conversation != nil {
+			return fmt.Errorf("conversation can only be specified for workload type %s", v1beta1.ConversationWorkload)
+		}
+		return v.validateReplay(workload.Replay, dataset)
+	}
+	if workload.Replay != nil {
+		return fmt.Errorf("replay can only be specified for workload type %s", v1beta1.ReplayWorkload)
+	}
+
+	conversation := workload.Conversation
+	if conversation == nil {
+		return fmt.Errorf("conversation must be specified for workload type %s", workload.Type)
+	}
+	if conversation.NumTurns < 1 {
+		return fmt.Errorf("numTurns must be at least 1, got %d", conversation.NumTurns)
+	}
+
+	switch conversation.HistoryMode {
+	case "", v1beta1.FullHistory:
+		if conversation.HistoryWindow != nil {
+			return fmt.Errorf("historyWindow can only be specified for history mode %s", v1beta1.SlidingWindowHistory)
+		}
+	case v1beta1.SlidingWindowHistory:
+		if conversation.HistoryWindow == nil || *conversation.HistoryWindow < 1 {
+			return fmt.Errorf("historyWindow must be at least 1 for history mode %s", v1beta1.SlidingWindowHistory)
+		}
+	default:
+		return fmt.Errorf("unsupported history mode: %s", conversation.HistoryMode)
+	}
+
+	return nil
+}
+
+func (v *BenchmarkJobValidator) validateReplay(replay *v1beta1.ReplaySpec, dataset *v1beta1.StorageSpec) error {
+	if dataset == nil {
+		return fmt.Errorf("dataset must point to the request trace for workload type %s", v1beta1.ReplayWorkload)
+	}
+	if replay == nil {
+		return nil
+	}
+	if replay.TimeScale != nil && replay.TimeScale.Sign() <= 0 {
+		return fmt.Errorf("timeScale must be positive, got %s", replay.TimeScale.String())
+	}
+	if replay.MaxDuration != nil && *replay.MaxDuration < 1 {
+		return fmt.Errorf("maxDuration must be at least 1, got %d", *replay.MaxDuration)
+	}
+	return nil
+}
+
 func (v *BenchmarkJobValidator) validateAdditionalRequestParams(params map[string]string) error {
 	for key, value := range params {
 		switch key {
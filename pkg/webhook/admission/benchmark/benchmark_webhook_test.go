@@ -5,9 +5,14 @@ import (
 	"testing"
 
 	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 )
 
 func TestValidateBenchmarkJob(t *testing.T) {
@@ -169,6 +174,107 @@ func TestValidateEndpoint(t *testing.T) {
 	}
 }
 
+func TestValidateComparison(t *testing.T) {
+	cost := resource.MustParse("2.5")
+	negativeCost := resource.MustParse("-1")
+	candidate := v1beta1.EndpointSpec{
+		InferenceService: &v1beta1.InferenceServiceReference{
+			Name:      "candidate-isvc",
+			Namespace: "default",
+		},
+	}
+	scenarios := map[string]struct {
+		comparison *v1beta1.ComparisonSpec
+		expected   gomega.OmegaMatcher
+	}{
+		"No comparison": {
+			comparison: nil,
+			expected:   gomega.BeNil(),
+		},
+		"Valid comparison": {
+			comparison: &v1beta1.ComparisonSpec{
+				Candidate:            candidate,
+				BaselineCostPerHour:  &cost,
+				CandidateCostPerHour: &cost,
+			},
+			expected: gomega.BeNil(),
+		},
+		"Missing candidate endpoint": {
+			comparison: &v1beta1.ComparisonSpec{},
+			expected:   gomega.HaveOccurred(),
+		},
+		"Negative candidate cost": {
+			comparison: &v1beta1.ComparisonSpec{
+				Candidate:            candidate,
+				CandidateCostPerHour: &negativeCost,
+			},
+			expected: gomega.HaveOccurred(),
+		},
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	validator := &BenchmarkJobValidator{}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := validator.validateComparison(scenario.comparison)
+			g.Expect(err).To(scenario.expected)
+		})
+	}
+}
+
+func TestValidateComparisonFeatures(t *testing.T) {
+	comparison := &v1beta1.ComparisonSpec{
+		Candidate: v1beta1.EndpointSpec{
+			InferenceService: &v1beta1.InferenceServiceReference{
+				Name:      "candidate-isvc",
+				Namespace: "default",
+			},
+		},
+	}
+	scenarios := map[string]struct {
+		comparison *v1beta1.ComparisonSpec
+		config     string
+		expected   gomega.OmegaMatcher
+	}{
+		"No comparison": {
+			comparison: nil,
+			config:     `{"podConfig": {"image": "genai-bench:0.1.113"}}`,
+			expected:   gomega.BeNil(),
+		},
+		"Image listing the metrics report feature": {
+			comparison: comparison,
+			config:     `{"podConfig": {"image": "genai-bench:0.1.113"}, "features": ["metricsReport"]}`,
+			expected:   gomega.BeNil(),
+		},
+		"Image without the metrics report feature": {
+			comparison: comparison,
+			config:     `{"podConfig": {"image": "genai-bench:0.1.113"}, "features": ["warmup"]}`,
+			expected:   gomega.MatchError(gomega.ContainSubstring("does not list the metricsReport feature")),
+		},
+		"ome-agent runner": {
+			comparison: comparison,
+			config:     `{"podConfig": {"image": "ome-agent:v0.1.5"}, "runner": "ome-agent"}`,
+			expected:   gomega.BeNil(),
+		},
+	}
+
+	g := gomega.NewGomegaWithT(t)
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			validator := &BenchmarkJobValidator{
+				Clientset: fake.NewSimpleClientset(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: constants.BenchmarkJobConfigMapName, Namespace: constants.OMENamespace},
+					Data:       map[string]string{controllerconfig.BenchmarkJobConfigName: scenario.config},
+				}),
+			}
+			err := validator.validateComparisonFeatures(scenario.comparison)
+			g.Expect(err).To(scenario.expected)
+		})
+	}
+}
+
 func TestValidateTrafficScenarios(t *testing.T) {
 	scenarios := map[string]struct {
 		task      string
@@ -213,6 +319,232 @@ func TestValidateTrafficScenarios(t *testing.T) {
 	}
 }
 
+func TestValidateTrafficSchedule(t *testing.T) {
+	rate := resource.MustParse("20")
+	zeroRate := resource.MustParse("0")
+	scenarios := map[string]struct {
+		schedule       *v1beta1.TrafficScheduleSpec
+		numConcurrency []int
+		expected       gomega.OmegaMatcher
+	}{
+		"No schedule": {
+			schedule: nil,
+			expected: gomega.BeNil(),
+		},
+		"Valid spike schedule": {
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{
+					{Duration: 300, Concurrency: intPtr(4)},
+					{Duration: 30, Concurrency: intPtr(64)},
+					{Duration: 300, Shape: v1beta1.RampPhase, Concurrency: intPtr(4)},
+				},
+			},
+			expected: gomega.BeNil(),
+		},
+		"Schedule with numConcurrency": {
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{{Duration: 60, RequestRate: &rate}},
+			},
+			numConcurrency: []int{1, 2},
+			expected:       gomega.HaveOccurred(),
+		},
+		"Phase without target": {
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{{Duration: 60}},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+		"Phases with mixed targets": {
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{
+					{Duration: 60, RequestRate: &rate},
+					{Duration: 60, Concurrency: intPtr(8)},
+				},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+		"Zero request rate": {
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{{Duration: 60, RequestRate: &zeroRate}},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+		"Zero duration": {
+			schedule: &v1beta1.TrafficScheduleSpec{
+				Phases: []v1beta1.TrafficPhase{{Duration: 0, RequestRate: &rate}},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	validator := &BenchmarkJobValidator{}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := validator.validateTrafficSchedule(scenario.schedule, scenario.numConcurrency)
+			g.Expect(err).To(scenario.expected)
+		})
+	}
+}
+
+func TestValidateWarmup(t *testing.T) {
+	scenarios := map[string]struct {
+		duration *int
+		requests *int
+		expected gomega.OmegaMatcher
+	}{
+		"No warmup": {
+			expected: gomega.BeNil(),
+		},
+		"Valid warmup": {
+			duration: intPtr(30),
+			requests: intPtr(50),
+			expected: gomega.BeNil(),
+		},
+		"Negative warmup duration": {
+			duration: intPtr(-1),
+			expected: gomega.HaveOccurred(),
+		},
+		"Negative warmup requests": {
+			requests: intPtr(-10),
+			expected: gomega.HaveOccurred(),
+		},
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	validator := &BenchmarkJobValidator{}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := validator.validateWarmup(scenario.duration, scenario.requests)
+			g.Expect(err).To(scenario.expected)
+		})
+	}
+}
+
+func TestValidateWorkload(t *testing.T) {
+	window := 2
+	timeScale := resource.MustParse("0.5")
+	zeroTimeScale := resource.MustParse("0")
+	trace := &v1beta1.StorageSpec{StorageUri: ptr("pvc://traces/production.jsonl")}
+	scenarios := map[string]struct {
+		task     string
+		workload *v1beta1.WorkloadSpec
+		dataset  *v1beta1.StorageSpec
+		expected gomega.OmegaMatcher
+	}{
+		"Nil workload": {
+			task:     "text-to-text",
+			workload: nil,
+			expected: gomega.BeNil(),
+		},
+		"Single turn workload": {
+			task:     "image-to-text",
+			workload: &v1beta1.WorkloadSpec{Type: v1beta1.SingleTurnWorkload},
+			expected: gomega.BeNil(),
+		},
+		"Valid conversation workload": {
+			task: "text-to-text",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{
+					NumTurns:      4,
+					HistoryMode:   v1beta1.SlidingWindowHistory,
+					HistoryWindow: &window,
+				},
+			},
+			expected: gomega.BeNil(),
+		},
+		"Conversation workload for non text-to-text task": {
+			task: "text-to-embeddings",
+			workload: &v1beta1.WorkloadSpec{
+				Type:         v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{NumTurns: 4},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+		"Conversation workload without conversation": {
+			task:     "text-to-text",
+			workload: &v1beta1.WorkloadSpec{Type: v1beta1.ConversationWorkload},
+			expected: gomega.HaveOccurred(),
+		},
+		"Conversation set on single turn workload": {
+			task: "text-to-text",
+			workload: &v1beta1.WorkloadSpec{
+				Type:         v1beta1.SingleTurnWorkload,
+				Conversation: &v1beta1.ConversationSpec{NumTurns: 4},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+		"Sliding window without window size": {
+			task: "text-to-text",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{
+					NumTurns:    4,
+					HistoryMode: v1beta1.SlidingWindowHistory,
+				},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+		"Window size with full history": {
+			task: "text-to-text",
+			workload: &v1beta1.WorkloadSpec{
+				Type: v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{
+					NumTurns:      4,
+					HistoryWindow: &window,
+				},
+			},
+			expected: gomega.HaveOccurred(),
+		},
+		"Valid replay workload": {
+			task: "text-to-text",
+			workload: &v1beta1.WorkloadSpec{
+				Type:   v1beta1.ReplayWorkload,
+				Replay: &v1beta1.ReplaySpec{TimeScale: &timeScale},
+			},
+			dataset:  trace,
+			expected: gomega.BeNil(),
+		},
+		"Replay workload without trace": {
+			task:     "text-to-text",
+			workload: &v1beta1.WorkloadSpec{Type: v1beta1.ReplayWorkload},
+			expected: gomega.HaveOccurred(),
+		},
+		"Replay workload with zero time scale": {
+			task: "text-to-text",
+			workload: &v1beta1.WorkloadSpec{
+				Type:   v1beta1.ReplayWorkload,
+				Replay: &v1beta1.ReplaySpec{TimeScale: &zeroTimeScale},
+			},
+			dataset:  trace,
+			expected: gomega.HaveOccurred(),
+		},
+		"Replay set on conversation workload": {
+			task: "text-to-text",
+			workload: &v1beta1.WorkloadSpec{
+				Type:         v1beta1.ConversationWorkload,
+				Conversation: &v1beta1.ConversationSpec{NumTurns: 4},
+				Replay:       &v1beta1.ReplaySpec{},
+			},
+			dataset:  trace,
+			expected: gomega.HaveOccurred(),
+		},
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	validator := &BenchmarkJobValidator{}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := validator.validateWorkload(scenario.task, scenario.workload, scenario.dataset)
+			g.Expect(err).To(scenario.expected)
+		})
+	}
+}
+
 func TestValidateAdditionalRequestParams(t *testing.T) {
 	scenarios := map[string]struct {
 		params   map[string]string
@@ -306,3 +638,8 @@ func TestValidateStorage(t *testing.T) {
 func ptr(s string) *string {
 	return &s
 }
+
+// intPtr returns a pointer to the int value
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,54 @@
+package deprecation
+
+import (
+	"fmt"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/utils/storage"
+)
+
+// The admission webhooks return these warnings instead of rejecting the resource, so users see what to migrate
+// in the kubectl output before the deprecated fields and formats are removed. Every message starts with the path
+// of the offending field so that the warnings can be matched by tooling.
+const (
+	fieldWarningFormat      = "%s: field is deprecated and will be removed in a future release, use %s instead"
+	valueWarningFormat      = "%s: value %q is deprecated and will be removed in a future release, use %s instead"
+	storageURIWarningFormat = "%s: storage URI format of %q is deprecated and will be removed in a future release, use oci://n/{namespace}/b/{bucket}/o/{object_path} instead"
+)
+
+// FieldWarning returns the warning for a deprecated field that is replaced by another field
+func FieldWarning(field, replacement string) string {
+	return fmt.Sprintf(fieldWarningFormat, field, replacement)
+}
+
+// ValueWarning returns the warning for a deprecated value of a field
+func ValueWarning(field, value, replacement string) string {
+	return fmt.Sprintf(valueWarningFormat, field, value, replacement)
+}
+
+// StorageURIWarnings returns a warning if the storage URI of the field uses a legacy format
+func StorageURIWarnings(field string, uri *string) []string {
+	if uri == nil || !storage.IsLegacyOCIStorageURI(*uri) {
+		return nil
+	}
+	return []string{fmt.Sprintf(storageURIWarningFormat, field, *uri)}
+}
+
+// PodSpecWarnings returns the warnings for the deprecated fields of the pod spec at the field path
+func PodSpecWarnings(field string, podSpec *v1beta1.PodSpec) []string {
+	if podSpec == nil || podSpec.DeprecatedServiceAccount == "" {
+		return nil
+	}
+	return []string{FieldWarning(field+".serviceAccount", field+".serviceAccountName")}
+}
+
+// ModelCapabilityWarnings returns a warning for every legacy capability in the model capabilities of the field
+func ModelCapabilityWarnings(field string, capabilities []string) []string {
+	var warnings []string
+	for i, capability := range capabilities {
+		if replacement, ok := v1beta1.LegacyModelCapabilities[v1beta1.ModelCapability(capability)]; ok {
+			warnings = append(warnings, ValueWarning(fmt.Sprintf("%s[%d]", field, i), capability, string(replacement)))
+		}
+	}
+	return warnings
+}
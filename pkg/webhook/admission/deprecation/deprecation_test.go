@@ -0,0 +1,58 @@
+package deprecation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestStorageURIWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  *string
+		want []string
+	}{
+		{
+			name: "no uri",
+			uri:  nil,
+		},
+		{
+			name: "canonical oci uri",
+			uri:  ptr.To("oci://n/myns/b/mybucket/o/models/llama"),
+		},
+		{
+			name: "legacy oci uri",
+			uri:  ptr.To("oci://myns@us-ashburn-1/mybucket/models/llama"),
+			want: []string{`spec.storage.storageUri: storage URI format of "oci://myns@us-ashburn-1/mybucket/models/llama" is deprecated and will be removed in a future release, use oci://n/{namespace}/b/{bucket}/o/{object_path} instead`},
+		},
+		{
+			name: "other storage type",
+			uri:  ptr.To("hf://meta-llama/Llama-3.1-8B"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StorageURIWarnings("spec.storage.storageUri", tt.uri))
+		})
+	}
+}
+
+func TestPodSpecWarnings(t *testing.T) {
+	assert.Nil(t, PodSpecWarnings("spec.engine", nil))
+	assert.Nil(t, PodSpecWarnings("spec.engine", &v1beta1.PodSpec{ServiceAccountName: "sa"}))
+	assert.Equal(t,
+		[]string{"spec.engine.serviceAccount: field is deprecated and will be removed in a future release, use spec.engine.serviceAccountName instead"},
+		PodSpecWarnings("spec.engine", &v1beta1.PodSpec{DeprecatedServiceAccount: "sa"}))
+}
+
+func TestModelCapabilityWarnings(t *testing.T) {
+	warnings := ModelCapabilityWarnings("spec.modelCapabilities", []string{"TEXT_TO_TEXT", "TEXT_EMBEDDINGS", "VISION"})
+	assert.Equal(t, []string{
+		`spec.modelCapabilities[1]: value "TEXT_EMBEDDINGS" is deprecated and will be removed in a future release, use EMBEDDING instead`,
+		`spec.modelCapabilities[2]: value "VISION" is deprecated and will be removed in a future release, use IMAGE_TEXT_TO_TEXT instead`,
+	}, warnings)
+}
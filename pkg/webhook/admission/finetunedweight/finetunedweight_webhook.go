@@ -0,0 +1,160 @@
+package finetunedweight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/webhook/admission/audit"
+	"github.com/sgl-project/ome/pkg/webhook/admission/basemodel"
+)
+
+var log = logf.Log.WithName(constants.FineTunedWeightValidatorWebhookName)
+
+const (
+	MissingBaseModelRefError            = "spec.baseModelRef.name is required"
+	BaseModelNotFoundError              = "spec.baseModelRef: %s %s not found"
+	MissingModelTypeError               = "spec.modelType is required"
+	InvalidConfigurationError           = "invalid spec.configuration: %s"
+	IncompatibleArchitectureError       = "spec.configuration.%s %q doesn't match %q of %s %s"
+	UnknownBaseModelArchitectureWarning = "spec.configuration.%s: %q can't be checked against %s %s, which doesn't declare its %s"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ome-io-v1beta1-finetunedweight,mutating=false,failurePolicy=fail,groups=ome.io,resources=finetunedweights,versions=v1beta1,name=finetunedweight.ome-webhook-server.validator
+
+// FineTunedWeightValidator validates the base model reference, architecture metadata and storage of FineTunedWeights
+type FineTunedWeightValidator struct {
+	Client  client.Client
+	Decoder admission.Decoder
+}
+
+// Handle validates the incoming request
+func (v *FineTunedWeightValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	fineTunedWeight := &v1beta1.FineTunedWeight{}
+	if err := v.Decoder.Decode(req, fineTunedWeight); err != nil {
+		log.Error(err, "Failed to decode fine tuned weight", "name", req.Name)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	warnings := basemodel.StorageWarnings(fineTunedWeight.Spec.Storage)
+
+	// Only updates that change the spec are validated, so that the controllers can still update the
+	// metadata of weights created before this webhook existed
+	if req.Operation == admissionv1.Update {
+		oldFineTunedWeight := &v1beta1.FineTunedWeight{}
+		if err := v.Decoder.DecodeRaw(req.OldObject, oldFineTunedWeight); err != nil {
+			log.Error(err, "Failed to decode old fine tuned weight", "name", req.Name)
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(oldFineTunedWeight.Spec, fineTunedWeight.Spec) {
+			return admission.Allowed("").WithWarnings(warnings...)
+		}
+	}
+
+	if err := validateFineTunedWeightSpec(&fineTunedWeight.Spec); err != nil {
+		audit.RecordRule(ctx, "spec")
+		return admission.Denied(err.Error()).WithWarnings(warnings...)
+	}
+
+	baseModel, kind, err := v.getBaseModel(ctx, fineTunedWeight.Spec.BaseModelRef)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			audit.RecordRule(ctx, "base_model_ref")
+			return admission.Denied(fmt.Sprintf(BaseModelNotFoundError, kind, baseModelRefName(fineTunedWeight.Spec.BaseModelRef))).WithWarnings(warnings...)
+		}
+		log.Error(err, "Failed to get base model", "name", req.Name, "kind", kind)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	architectureWarnings, err := validateArchitecture(&fineTunedWeight.Spec, baseModel, kind, baseModelRefName(fineTunedWeight.Spec.BaseModelRef))
+	warnings = append(warnings, architectureWarnings...)
+	if err != nil || len(architectureWarnings) > 0 {
+		audit.RecordRule(ctx, "architecture")
+	}
+	if err != nil {
+		return admission.Denied(err.Error()).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// validateFineTunedWeightSpec validates the fields of the weight that don't depend on the base model
+func validateFineTunedWeightSpec(spec *v1beta1.FineTunedWeightSpec) error {
+	if ptr.Deref(spec.BaseModelRef.Name, "") == "" {
+		return errors.New(MissingBaseModelRefError)
+	}
+	if ptr.Deref(spec.ModelType, "") == "" {
+		return errors.New(MissingModelTypeError)
+	}
+	return basemodel.ValidateStorage(spec.Storage)
+}
+
+// getBaseModel returns the spec of the referenced base model and its kind. A reference with a namespace
+// names a BaseModel in that namespace, a reference without one names a ClusterBaseModel.
+func (v *FineTunedWeightValidator) getBaseModel(ctx context.Context, ref v1beta1.ObjectReference) (*v1beta1.BaseModelSpec, string, error) {
+	if namespace := ptr.Deref(ref.Namespace, ""); namespace != "" {
+		baseModel := &v1beta1.BaseModel{}
+		if err := v.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *ref.Name}, baseModel); err != nil {
+			return nil, "BaseModel", err
+		}
+		return &baseModel.Spec, "BaseModel", nil
+	}
+	clusterBaseModel := &v1beta1.ClusterBaseModel{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: *ref.Name}, clusterBaseModel); err != nil {
+		return nil, "ClusterBaseModel", err
+	}
+	return &clusterBaseModel.Spec, "ClusterBaseModel", nil
+}
+
+// validateArchitecture checks that the architecture the weight was trained on, if its configuration declares it,
+// is the architecture of the base model. The engine would otherwise fail to load the weight onto the model.
+// Base models that don't declare their architecture yet, e.g. before the metadata extraction ran, only get a warning.
+func validateArchitecture(spec *v1beta1.FineTunedWeightSpec, baseModel *v1beta1.BaseModelSpec, kind, name string) ([]string, error) {
+	var warnings []string
+	checks := []struct {
+		key       string
+		field     string
+		baseValue *string
+		equal     func(a, b string) bool
+	}{
+		{constants.FineTunedWeightModelArchitectureConfigKey, "modelArchitecture", baseModel.ModelArchitecture, func(a, b string) bool { return a == b }},
+		{constants.FineTunedWeightModelTypeConfigKey, "modelType", baseModel.ModelType, strings.EqualFold},
+	}
+	for _, check := range checks {
+		value, err := isvcutils.GetValueFromRawExtension(spec.Configuration, check.key)
+		if err != nil {
+			return warnings, fmt.Errorf(InvalidConfigurationError, err.Error())
+		}
+		weightValue, ok := value.(string)
+		if !ok || weightValue == "" {
+			continue
+		}
+		if check.baseValue == nil || *check.baseValue == "" {
+			warnings = append(warnings, fmt.Sprintf(UnknownBaseModelArchitectureWarning, check.key, weightValue, kind, name, check.field))
+			continue
+		}
+		if !check.equal(weightValue, *check.baseValue) {
+			return warnings, fmt.Errorf(IncompatibleArchitectureError, check.key, weightValue, *check.baseValue, kind, name)
+		}
+	}
+	return warnings, nil
+}
+
+// baseModelRefName returns the namespaced name of the referenced base model for messages
+func baseModelRefName(ref v1beta1.ObjectReference) string {
+	if namespace := ptr.Deref(ref.Namespace, ""); namespace != "" {
+		return namespace + "/" + ptr.Deref(ref.Name, "")
+	}
+	return ptr.Deref(ref.Name, "")
+}
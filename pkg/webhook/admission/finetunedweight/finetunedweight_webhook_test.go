@@ -0,0 +1,150 @@
+package finetunedweight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/webhook/admission/basemodel"
+)
+
+func TestFineTunedWeightValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&v1beta1.ClusterBaseModel{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama-3-1-8b"},
+			Spec:       v1beta1.BaseModelSpec{ModelArchitecture: ptr.To("LlamaForCausalLM"), ModelType: ptr.To("llama")},
+		},
+		&v1beta1.BaseModel{
+			ObjectMeta: metav1.ObjectMeta{Name: "qwen", Namespace: "team-a"},
+		},
+	).Build()
+	validator := &FineTunedWeightValidator{Client: fakeClient, Decoder: admission.NewDecoder(scheme)}
+
+	storage := &v1beta1.StorageSpec{StorageUri: ptr.To("oci://n/myns/b/adapters/o/llama-finance-lora")}
+	newSpec := func(name string, namespace *string, configuration string) v1beta1.FineTunedWeightSpec {
+		spec := v1beta1.FineTunedWeightSpec{
+			BaseModelRef: v1beta1.ObjectReference{Name: ptr.To(name), Namespace: namespace},
+			ModelType:    ptr.To("LoRA"),
+			Storage:      storage,
+		}
+		if configuration != "" {
+			spec.Configuration = runtime.RawExtension{Raw: []byte(configuration)}
+		}
+		return spec
+	}
+
+	scenarios := map[string]struct {
+		spec     v1beta1.FineTunedWeightSpec
+		old      *v1beta1.FineTunedWeightSpec
+		allowed  bool
+		message  string
+		warnings gomega.OmegaMatcher
+	}{
+		"CompatibleClusterBaseModel": {
+			spec:     newSpec("llama-3-1-8b", nil, `{"model_architecture": "LlamaForCausalLM", "model_type": "Llama"}`),
+			allowed:  true,
+			warnings: gomega.BeEmpty(),
+		},
+		"NoArchitectureMetadata": {
+			spec:     newSpec("llama-3-1-8b", nil, `{"merged_weights": true}`),
+			allowed:  true,
+			warnings: gomega.BeEmpty(),
+		},
+		"MissingBaseModelRef": {
+			spec:    v1beta1.FineTunedWeightSpec{ModelType: ptr.To("LoRA"), Storage: storage},
+			message: MissingBaseModelRefError,
+		},
+		"MissingModelType": {
+			spec:    v1beta1.FineTunedWeightSpec{BaseModelRef: v1beta1.ObjectReference{Name: ptr.To("llama-3-1-8b")}, Storage: storage},
+			message: MissingModelTypeError,
+		},
+		"InvalidStorageURI": {
+			spec: v1beta1.FineTunedWeightSpec{
+				BaseModelRef: v1beta1.ObjectReference{Name: ptr.To("llama-3-1-8b")},
+				ModelType:    ptr.To("LoRA"),
+				Storage:      &v1beta1.StorageSpec{StorageUri: ptr.To("oci://n/myns/b/adapters")},
+			},
+			message: `invalid spec.storage.storageUri "oci://n/myns/b/adapters"`,
+		},
+		"ClusterBaseModelNotFound": {
+			spec:    newSpec("mistral-7b", nil, ""),
+			message: fmt.Sprintf(BaseModelNotFoundError, "ClusterBaseModel", "mistral-7b"),
+		},
+		"BaseModelInOtherNamespace": {
+			spec:    newSpec("qwen", ptr.To("team-b"), ""),
+			message: fmt.Sprintf(BaseModelNotFoundError, "BaseModel", "team-b/qwen"),
+		},
+		"IncompatibleArchitecture": {
+			spec:    newSpec("llama-3-1-8b", nil, `{"model_architecture": "Qwen2ForCausalLM"}`),
+			message: fmt.Sprintf(IncompatibleArchitectureError, "model_architecture", "Qwen2ForCausalLM", "LlamaForCausalLM", "ClusterBaseModel", "llama-3-1-8b"),
+		},
+		"BaseModelWithoutArchitecture": {
+			spec:    newSpec("qwen", ptr.To("team-a"), `{"model_architecture": "Qwen2ForCausalLM"}`),
+			allowed: true,
+			warnings: gomega.Equal([]string{
+				fmt.Sprintf(UnknownBaseModelArchitectureWarning, "model_architecture", "Qwen2ForCausalLM", "BaseModel", "team-a/qwen", "modelArchitecture"),
+			}),
+		},
+		"LegacyStorageURI": {
+			spec: v1beta1.FineTunedWeightSpec{
+				BaseModelRef: v1beta1.ObjectReference{Name: ptr.To("llama-3-1-8b")},
+				ModelType:    ptr.To("LoRA"),
+				Storage:      &v1beta1.StorageSpec{StorageUri: ptr.To("oci://myns@us-ashburn-1/adapters/llama-finance-lora")},
+			},
+			allowed:  true,
+			warnings: gomega.Equal(basemodel.StorageWarnings(&v1beta1.StorageSpec{StorageUri: ptr.To("oci://myns@us-ashburn-1/adapters/llama-finance-lora")})),
+		},
+		"UnchangedSpecOfMissingBaseModel": {
+			spec:     newSpec("mistral-7b", nil, ""),
+			old:      ptr.To(newSpec("mistral-7b", nil, "")),
+			allowed:  true,
+			warnings: gomega.BeEmpty(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			newObject := func(spec v1beta1.FineTunedWeightSpec) runtime.RawExtension {
+				raw, err := json.Marshal(&v1beta1.FineTunedWeight{
+					TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "FineTunedWeight"},
+					ObjectMeta: metav1.ObjectMeta{Name: "llama-finance-lora"},
+					Spec:       spec,
+				})
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				return runtime.RawExtension{Raw: raw}
+			}
+
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    newObject(scenario.spec),
+				},
+			}
+			if scenario.old != nil {
+				req.Operation = admissionv1.Update
+				req.OldObject = newObject(*scenario.old)
+			}
+
+			response := validator.Handle(context.TODO(), req)
+			g.Expect(response.Allowed).To(gomega.Equal(scenario.allowed))
+			if !scenario.allowed {
+				g.Expect(response.Result.Message).To(gomega.ContainSubstring(scenario.message))
+				return
+			}
+			g.Expect(response.Warnings).To(scenario.warnings)
+		})
+	}
+}
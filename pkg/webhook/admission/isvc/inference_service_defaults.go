@@ -127,6 +127,9 @@ func DefaultInferenceService(ctx context.Context, c client.Client, isvc *v1beta1
 	if isvc.Spec.Router != nil {
 		defaultRouter(isvc.Spec.Router)
 	}
+
+	// Size the engine and decoder pods for the referenced model where the user didn't
+	defaultFromModelMetadata(ctx, c, isvc)
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -18,6 +19,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
 )
 
 // =============================================================================
@@ -1410,3 +1412,281 @@ func TestDefault(t *testing.T) {
 		assert.Contains(t, err.Error(), "expected an InferenceService object but got")
 	})
 }
+
+func TestDefaultFromModelMetadata(t *testing.T) {
+	baseModel := func(name, size string, quantization *v1beta1.ModelQuantization) *v1beta1.ClusterBaseModel {
+		return &v1beta1.ClusterBaseModel{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1beta1.BaseModelSpec{
+				ModelFormat:        v1beta1.ModelFormat{Name: "safetensors"},
+				ModelParameterSize: stringPtr(size),
+				Quantization:       quantization,
+			},
+		}
+	}
+	awq := v1beta1.ModelQuantizationAWQ
+	a100 := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-a100-40gb"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Capabilities: v1beta1.AcceleratorCapabilities{MemoryGB: resourceQuantityPtr("40Gi")},
+		},
+	}
+	mi300x := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "amd-mi300x"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Capabilities: v1beta1.AcceleratorCapabilities{MemoryGB: resourceQuantityPtr("192Gi")},
+			Resources: []v1beta1.AcceleratorResource{
+				{Name: "rdma/ib", Quantity: resource.MustParse("1")},
+				{Name: constants.AMDGPUResourceType, Quantity: resource.MustParse("1")},
+			},
+		},
+	}
+	servingRuntime := func(name string, engine *v1beta1.EngineSpec) *v1beta1.ClusterServingRuntime {
+		return &v1beta1.ClusterServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 1}, AutoSelect: boolPtr(true)},
+					{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 1}, Quantization: &awq, AutoSelect: boolPtr(true)},
+				},
+				AcceleratorRequirements: &v1beta1.AcceleratorRequirements{AcceleratorClasses: []string{"nvidia-a100-40gb", "amd-mi300x"}},
+				EngineConfig:            engine,
+			},
+		}
+	}
+	srt := servingRuntime("srt", &v1beta1.EngineSpec{})
+	isvcForModel := func(model string, engine *v1beta1.EngineSpec) *v1beta1.InferenceService {
+		isvc := createBasicInferenceService("test-isvc", "default")
+		isvc.Spec.Model = &v1beta1.ModelRef{Name: model}
+		isvc.Spec.Engine = engine
+		return isvc
+	}
+
+	tests := []struct {
+		name                 string
+		isvc                 *v1beta1.InferenceService
+		wantGPUs             int64
+		wantMemory           string
+		wantSharedMemory     string
+		wantFailureThreshold int32
+		wantDerived          bool
+	}{
+		{
+			name:                 "small model fits on one GPU",
+			isvc:                 isvcForModel("llama-7b", &v1beta1.EngineSpec{}),
+			wantGPUs:             1,
+			wantMemory:           "17Gi",
+			wantSharedMemory:     "8Gi",
+			wantFailureThreshold: 104,
+			wantDerived:          true,
+		},
+		{
+			name:                 "large model is split across GPUs",
+			isvc:                 isvcForModel("llama-70b", &v1beta1.EngineSpec{}),
+			wantGPUs:             4,
+			wantMemory:           "163Gi",
+			wantSharedMemory:     "32Gi",
+			wantFailureThreshold: 221,
+			wantDerived:          true,
+		},
+		{
+			name:                 "quantized model needs fewer GPUs",
+			isvc:                 isvcForModel("llama-70b-awq", &v1beta1.EngineSpec{}),
+			wantGPUs:             1,
+			wantMemory:           "41Gi",
+			wantSharedMemory:     "8Gi",
+			wantFailureThreshold: 123,
+			wantDerived:          true,
+		},
+		{
+			name: "GPU memory of the selected accelerator class",
+			isvc: func() *v1beta1.InferenceService {
+				isvc := isvcForModel("yi-34b", &v1beta1.EngineSpec{})
+				isvc.Spec.AcceleratorSelector = &v1beta1.AcceleratorSelector{AcceleratorClass: stringPtr("nvidia-a100-40gb")}
+				return isvc
+			}(),
+			wantGPUs:             2,
+			wantMemory:           "80Gi",
+			wantSharedMemory:     "16Gi",
+			wantFailureThreshold: 154,
+			wantDerived:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := createFakeClient(t,
+				baseModel("llama-7b", "7B", nil),
+				baseModel("llama-70b", "70B", nil),
+				baseModel("llama-70b-awq", "70B", &awq),
+				baseModel("yi-34b", "34B", nil),
+				a100,
+				srt,
+			)
+			require.NoError(t, DefaultInferenceService(context.Background(), c, tt.isvc, nil))
+
+			runner := tt.isvc.Spec.Engine.Runner
+			require.NotNil(t, runner)
+			gpus := runner.Resources.Limits[v1.ResourceName(constants.NvidiaGPUResourceType)]
+			assert.Equal(t, tt.wantGPUs, gpus.Value())
+			memory := runner.Resources.Requests[v1.ResourceMemory]
+			assert.Equal(t, tt.wantMemory, memory.String())
+
+			require.Len(t, tt.isvc.Spec.Engine.Volumes, 1)
+			assert.Equal(t, tt.wantSharedMemory, tt.isvc.Spec.Engine.Volumes[0].EmptyDir.SizeLimit.String())
+			require.Len(t, runner.VolumeMounts, 1)
+			assert.Equal(t, "/dev/shm", runner.VolumeMounts[0].MountPath)
+
+			require.NotNil(t, runner.StartupProbe)
+			assert.Equal(t, tt.wantFailureThreshold, runner.StartupProbe.FailureThreshold)
+			assert.Contains(t, utils.GetModelDerivedComponents(tt.isvc.Annotations), v1beta1.EngineComponent)
+		})
+	}
+
+	t.Run("user settings are kept", func(t *testing.T) {
+		userResources := v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("8")},
+		}
+		userProbe := &v1.Probe{PeriodSeconds: 5, FailureThreshold: 10}
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{
+			PodSpec: v1beta1.PodSpec{
+				Volumes: []v1.Volume{{Name: "dshm", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{Medium: v1.StorageMediumMemory}}}},
+			},
+			Runner: &v1beta1.RunnerSpec{Container: v1.Container{Resources: userResources, StartupProbe: userProbe}},
+		})
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil), srt)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.Equal(t, userResources, isvc.Spec.Engine.Runner.Resources)
+		assert.Equal(t, userProbe, isvc.Spec.Engine.Runner.StartupProbe)
+		assert.Len(t, isvc.Spec.Engine.Volumes, 1)
+		assert.Nil(t, isvc.Spec.Engine.Volumes[0].EmptyDir.SizeLimit)
+		assert.NotContains(t, isvc.Annotations, constants.ModelDerivedResourcesAnnotationKey)
+	})
+
+	t.Run("model without parameter size", func(t *testing.T) {
+		model := baseModel("unknown-size", "", nil)
+		model.Spec.ModelParameterSize = nil
+		isvc := isvcForModel("unknown-size", &v1beta1.EngineSpec{})
+		c := createFakeClient(t, model, srt)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.Nil(t, isvc.Spec.Engine.Runner)
+		assert.NotContains(t, isvc.Annotations, constants.ModelDerivedResourcesAnnotationKey)
+	})
+
+	t.Run("multi-node engine is left alone", func(t *testing.T) {
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{
+			Leader: &v1beta1.LeaderSpec{},
+			Worker: &v1beta1.WorkerSpec{Size: intPtr(1)},
+		})
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil), srt)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.Nil(t, isvc.Spec.Engine.Runner)
+		assert.Empty(t, isvc.Spec.Engine.Volumes)
+	})
+
+	t.Run("runtime sizes the engine", func(t *testing.T) {
+		tp8 := servingRuntime("srt-tp8", &v1beta1.EngineSpec{
+			Runner: &v1beta1.RunnerSpec{Container: v1.Container{
+				Args: []string{"--tp-size", "8"},
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("8")},
+				},
+			}},
+		})
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{})
+		isvc.Spec.Runtime = &v1beta1.ServingRuntimeRef{Name: "srt-tp8"}
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil), tp8)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.Nil(t, isvc.Spec.Engine.Runner)
+		assert.Empty(t, isvc.Spec.Engine.Volumes)
+		assert.NotContains(t, isvc.Annotations, constants.ModelDerivedResourcesAnnotationKey)
+	})
+
+	t.Run("no runtime for the model", func(t *testing.T) {
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{})
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil))
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.Nil(t, isvc.Spec.Engine.Runner)
+		assert.NotContains(t, isvc.Annotations, constants.ModelDerivedResourcesAnnotationKey)
+	})
+
+	t.Run("resource of the selected accelerator class", func(t *testing.T) {
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{})
+		isvc.Spec.AcceleratorSelector = &v1beta1.AcceleratorSelector{AcceleratorClass: stringPtr("amd-mi300x")}
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil), mi300x, srt)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		resources := isvc.Spec.Engine.Runner.Resources
+		gpus := resources.Limits[v1.ResourceName(constants.AMDGPUResourceType)]
+		assert.Equal(t, int64(1), gpus.Value())
+		assert.NotContains(t, resources.Limits, v1.ResourceName(constants.NvidiaGPUResourceType))
+		assert.NotContains(t, resources.Limits, v1.ResourceName("rdma/ib"))
+	})
+
+	t.Run("derived settings follow the runtime", func(t *testing.T) {
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{})
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil), srt)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+		require.Contains(t, utils.GetModelDerivedComponents(isvc.Annotations), v1beta1.EngineComponent)
+
+		// The runtime now sets the resources of the engine
+		sized := srt.DeepCopy()
+		sized.Spec.EngineConfig.Runner = &v1beta1.RunnerSpec{Container: v1.Container{
+			Resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("8")},
+			},
+		}}
+		c = createFakeClient(t, baseModel("llama-70b", "70B", nil), sized)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.True(t, isResourcesUnspecified(isvc.Spec.Engine.Runner.Resources))
+		assert.Nil(t, isvc.Spec.Engine.Runner.StartupProbe)
+		assert.Empty(t, isvc.Spec.Engine.Runner.VolumeMounts)
+		assert.Empty(t, isvc.Spec.Engine.Volumes)
+		assert.NotContains(t, isvc.Annotations, constants.ModelDerivedResourcesAnnotationKey)
+	})
+
+	t.Run("resources the user sets on an update are kept", func(t *testing.T) {
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{})
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil), srt)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+		require.Contains(t, utils.GetModelDerivedComponents(isvc.Annotations), v1beta1.EngineComponent)
+
+		// The update keeps the annotation, as kubectl apply does, and sets the resources of the engine
+		userResources := v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("8")},
+		}
+		isvc.Spec.Engine.Runner.Resources = userResources
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.Equal(t, userResources, isvc.Spec.Engine.Runner.Resources)
+		require.NotNil(t, isvc.Spec.Engine.Runner.StartupProbe)
+		require.Len(t, isvc.Spec.Engine.Volumes, 1)
+		assert.NotContains(t, isvc.Annotations, constants.ModelDerivedResourcesAnnotationKey)
+	})
+
+	t.Run("derived settings are derived again on an update", func(t *testing.T) {
+		isvc := isvcForModel("llama-70b", &v1beta1.EngineSpec{})
+		c := createFakeClient(t, baseModel("llama-70b", "70B", nil), srt)
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+		derived := isvc.Annotations[constants.ModelDerivedResourcesAnnotationKey]
+		resources := isvc.Spec.Engine.Runner.Resources.DeepCopy()
+
+		require.NoError(t, DefaultInferenceService(context.Background(), c, isvc, nil))
+
+		assert.Equal(t, *resources, isvc.Spec.Engine.Runner.Resources)
+		assert.Len(t, isvc.Spec.Engine.Volumes, 1)
+		assert.Len(t, isvc.Spec.Engine.Runner.VolumeMounts, 1)
+		assert.Equal(t, derived, isvc.Annotations[constants.ModelDerivedResourcesAnnotationKey])
+	})
+}
+
+func resourceQuantityPtr(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
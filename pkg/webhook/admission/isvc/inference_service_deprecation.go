@@ -0,0 +1,43 @@
+package isvc
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/webhook/admission/deprecation"
+)
+
+// deprecationWarnings returns a warning for every deprecated field and legacy storage URI the InferenceService uses
+func deprecationWarnings(isvc *v1beta1.InferenceService) admission.Warnings {
+	var warnings admission.Warnings
+
+	if isPredictorUsed(isvc) {
+		warnings = append(warnings, deprecation.FieldWarning("spec.predictor", "spec.engine and spec.model"))
+		if model := isvc.Spec.Predictor.Model; model != nil {
+			warnings = append(warnings, deprecation.StorageURIWarnings("spec.predictor.model.storageUri", model.StorageUri)...)
+		}
+	}
+
+	if engine := isvc.Spec.Engine; engine != nil {
+		warnings = append(warnings, deprecation.PodSpecWarnings("spec.engine", &engine.PodSpec)...)
+		if engine.Leader != nil {
+			warnings = append(warnings, deprecation.PodSpecWarnings("spec.engine.leader", &engine.Leader.PodSpec)...)
+		}
+		if engine.Worker != nil {
+			warnings = append(warnings, deprecation.PodSpecWarnings("spec.engine.worker", &engine.Worker.PodSpec)...)
+		}
+	}
+	if decoder := isvc.Spec.Decoder; decoder != nil {
+		warnings = append(warnings, deprecation.PodSpecWarnings("spec.decoder", &decoder.PodSpec)...)
+		if decoder.Leader != nil {
+			warnings = append(warnings, deprecation.PodSpecWarnings("spec.decoder.leader", &decoder.Leader.PodSpec)...)
+		}
+		if decoder.Worker != nil {
+			warnings = append(warnings, deprecation.PodSpecWarnings("spec.decoder.worker", &decoder.Worker.PodSpec)...)
+		}
+	}
+	if router := isvc.Spec.Router; router != nil {
+		warnings = append(warnings, deprecation.PodSpecWarnings("spec.router", &router.PodSpec)...)
+	}
+	return warnings
+}
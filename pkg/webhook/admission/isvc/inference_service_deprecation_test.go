@@ -0,0 +1,71 @@
+package isvc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+const predictorDeprecationWarning = "spec.predictor: field is deprecated and will be removed in a future release, use spec.engine and spec.model instead"
+
+func TestDeprecationWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1beta1.InferenceServiceSpec
+		want admission.Warnings
+	}{
+		{
+			name: "no deprecated fields",
+			spec: v1beta1.InferenceServiceSpec{
+				Model:  &v1beta1.ModelRef{Name: "llama"},
+				Engine: &v1beta1.EngineSpec{PodSpec: v1beta1.PodSpec{ServiceAccountName: "sa"}},
+			},
+		},
+		{
+			name: "predictor with legacy storage uri",
+			spec: v1beta1.InferenceServiceSpec{
+				Predictor: v1beta1.PredictorSpec{
+					Model: &v1beta1.ModelSpec{
+						BaseModel: stringPtr("llama"),
+						PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+							StorageUri: stringPtr("oci://myns@us-ashburn-1/models/llama"),
+						},
+					},
+				},
+			},
+			want: admission.Warnings{
+				predictorDeprecationWarning,
+				`spec.predictor.model.storageUri: storage URI format of "oci://myns@us-ashburn-1/models/llama" is deprecated and will be removed in a future release, use oci://n/{namespace}/b/{bucket}/o/{object_path} instead`,
+			},
+		},
+		{
+			name: "service account alias in engine worker and router",
+			spec: v1beta1.InferenceServiceSpec{
+				Model: &v1beta1.ModelRef{Name: "llama"},
+				Engine: &v1beta1.EngineSpec{
+					Leader: &v1beta1.LeaderSpec{},
+					Worker: &v1beta1.WorkerSpec{PodSpec: v1beta1.PodSpec{DeprecatedServiceAccount: "sa"}},
+				},
+				Router: &v1beta1.RouterSpec{PodSpec: v1beta1.PodSpec{DeprecatedServiceAccount: "sa"}},
+			},
+			want: admission.Warnings{
+				"spec.engine.worker.serviceAccount: field is deprecated and will be removed in a future release, use spec.engine.worker.serviceAccountName instead",
+				"spec.router.serviceAccount: field is deprecated and will be removed in a future release, use spec.router.serviceAccountName instead",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			assert.Equal(t, tt.want, deprecationWarnings(isvc))
+		})
+	}
+}
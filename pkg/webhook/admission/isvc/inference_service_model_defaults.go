@@ -0,0 +1,356 @@
+package isvc
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"slices"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sgl-project/ome/pkg/acceleratorclassselector"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+)
+
+const (
+	gib = 1024 * 1024 * 1024
+
+	// defaultGPUMemory is the memory assumed per GPU when no accelerator class with a known memory size is selected
+	defaultGPUMemory = 80 * gib
+	// maxGPUsPerPod caps the derived GPU count, larger models need a multi-node deployment
+	maxGPUsPerPod = 8
+	// gpuMemoryHeadroom leaves room for the KV cache and activations next to the model weights
+	gpuMemoryHeadroom = 1.25
+	// minModelMemory is the smallest host memory request derived for a model
+	minModelMemory = 16 * gib
+	// sharedMemoryPerGPU sizes /dev/shm for NCCL communication between the GPUs of a pod
+	sharedMemoryPerGPU = 8 * gib
+	// sharedMemoryVolumeName matches the /dev/shm volume of the serving runtimes so that the size limit merges into it
+	sharedMemoryVolumeName = "dshm"
+	sharedMemoryMountPath  = "/dev/shm"
+
+	// startupProbePeriodSeconds and the startup budget below size the startup probe to the time it takes to load the weights
+	startupProbePeriodSeconds = 10
+	startupBaseSeconds        = 900
+	startupSecondsPerGiB      = 10
+)
+
+// modelResourceProfile holds the pod settings derived from the metadata of a base model
+type modelResourceProfile struct {
+	gpus                    int64
+	memory                  resource.Quantity
+	sharedMemory            resource.Quantity
+	startupFailureThreshold int32
+}
+
+// bytesPerParameter returns the size of a model weight for the quantization of the model
+func bytesPerParameter(quantization *v1beta1.ModelQuantization) float64 {
+	if quantization == nil {
+		return 2
+	}
+	switch *quantization {
+	case v1beta1.ModelQuantizationFP8, v1beta1.ModelQuantizationFbgemmFP8:
+		return 1
+	case v1beta1.ModelQuantizationINT4, v1beta1.ModelQuantizationAWQ, v1beta1.ModelQuantizationGPTQ:
+		return 0.5
+	default:
+		return 2
+	}
+}
+
+// estimateModelResourceProfile derives the resources and startup probe timing a model needs from its parameter
+// size and quantization. It returns nil when the model doesn't declare its parameter size.
+func estimateModelResourceProfile(model *v1beta1.BaseModelSpec, gpuMemory int64) *modelResourceProfile {
+	if model.ModelParameterSize == nil {
+		return nil
+	}
+	parameters := runtimeselector.ParseModelSize(*model.ModelParameterSize)
+	if parameters <= 0 {
+		return nil
+	}
+	weights := parameters * bytesPerParameter(model.Quantization)
+
+	gpus := int64(1)
+	for gpus < maxGPUsPerPod && float64(gpus*gpuMemory) < weights*gpuMemoryHeadroom {
+		// Tensor parallelism splits the attention heads evenly, so keep the GPU count a power of two
+		gpus *= 2
+	}
+
+	memory := int64(math.Ceil(weights*gpuMemoryHeadroom/gib)) * gib
+	if memory < minModelMemory {
+		memory = minModelMemory
+	}
+
+	startupSeconds := startupBaseSeconds + startupSecondsPerGiB*math.Ceil(weights/gib)
+	return &modelResourceProfile{
+		gpus:                    gpus,
+		memory:                  *resource.NewQuantity(memory, resource.BinarySI),
+		sharedMemory:            *resource.NewQuantity(gpus*sharedMemoryPerGPU, resource.BinarySI),
+		startupFailureThreshold: int32(math.Ceil(startupSeconds / startupProbePeriodSeconds)),
+	}
+}
+
+// gpuMemory returns the memory of a GPU of the accelerator class,
+// or defaultGPUMemory if no class is selected or the class doesn't declare its memory.
+func gpuMemory(acceleratorClass *v1beta1.AcceleratorClass) int64 {
+	if acceleratorClass == nil {
+		return defaultGPUMemory
+	}
+	memory := acceleratorClass.Spec.Capabilities.MemoryGB
+	if memory == nil || memory.Value() < gib {
+		return defaultGPUMemory
+	}
+	return memory.Value()
+}
+
+// acceleratorResourceName returns the name of the resource that requests the GPUs of the accelerator class,
+// or nvidia.com/gpu if no class is selected or the class lists no resources.
+func acceleratorResourceName(acceleratorClass *v1beta1.AcceleratorClass) v1.ResourceName {
+	if r := acceleratorclassselector.AcceleratorResource(acceleratorClass); r != nil {
+		return v1.ResourceName(r.Name)
+	}
+	return v1.ResourceName(constants.NvidiaGPUResourceType)
+}
+
+// selectServingRuntime resolves the serving runtime the controller will use for the InferenceService,
+// the one it names or else the one auto-selected for the model. It returns nil if there is none yet.
+func selectServingRuntime(ctx context.Context, c client.Client, isvc *v1beta1.InferenceService, model *v1beta1.BaseModelSpec) *v1beta1.ServingRuntimeSpec {
+	selector := runtimeselector.New(c)
+	if name, _ := utils.GetUserSpecifiedRuntime(isvc); name != "" {
+		rt, _, err := selector.GetRuntime(ctx, name, isvc.Namespace)
+		if err != nil {
+			return nil
+		}
+		return rt
+	}
+	selection, err := selector.SelectRuntime(ctx, model, isvc)
+	if err != nil {
+		return nil
+	}
+	return selection.Spec
+}
+
+// defaultFromModelMetadata fills in the GPU count, memory, shared memory size and startup probe timing of the
+// engine and decoder from the metadata of the referenced base model. A component is only sized this way when
+// neither the InferenceService nor its serving runtime sets the resources of the component, since a runtime
+// that sets them also sizes its engine arguments (such as the tensor parallel size) for them.
+// Multi-node components are left alone since their resources depend on how the model is split across nodes.
+//
+// The settings derived on an earlier admission are derived again, so that they follow changes of the model,
+// the runtime and the accelerator class, unless the user changed them since. The annotation records the hash of
+// the settings derived for each component, a component whose settings no longer match it keeps them as the user's.
+func defaultFromModelMetadata(ctx context.Context, c client.Client, isvc *v1beta1.InferenceService) {
+	if c == nil || isvc.Spec.Model == nil || isvc.Spec.Model.Name == "" {
+		return
+	}
+	if isvc.Spec.Engine == nil && isvc.Spec.Decoder == nil {
+		return
+	}
+
+	previouslyDerived := utils.GetModelDerivedComponents(isvc.Annotations)
+	if engine := isvc.Spec.Engine; engine != nil && previouslyDerived[v1beta1.EngineComponent] != "" &&
+		previouslyDerived[v1beta1.EngineComponent] == modelResourceProfileHash(&engine.PodSpec, engine.Runner) {
+		clearModelResourceProfile(&engine.PodSpec, engine.Runner)
+	}
+	if decoder := isvc.Spec.Decoder; decoder != nil && previouslyDerived[v1beta1.DecoderComponent] != "" &&
+		previouslyDerived[v1beta1.DecoderComponent] == modelResourceProfileHash(&decoder.PodSpec, decoder.Runner) {
+		clearModelResourceProfile(&decoder.PodSpec, decoder.Runner)
+	}
+	derived := map[v1beta1.ComponentType]string{}
+	defer utils.SetModelDerivedComponents(&isvc.ObjectMeta, derived)
+
+	model, _, err := utils.GetBaseModel(c, isvc.Spec.Model.Name, isvc.Namespace)
+	if err != nil {
+		// A missing model is reported by the validating webhook
+		mutatorLogger.Info("Skipping model-derived defaults", "namespace", isvc.Namespace, "name", isvc.Name, "reason", err.Error())
+		return
+	}
+	rt := selectServingRuntime(ctx, c, isvc, model)
+	if rt == nil {
+		// The controller reports a missing or incompatible runtime
+		mutatorLogger.Info("Skipping model-derived defaults", "namespace", isvc.Namespace, "name", isvc.Name, "reason", "no serving runtime for the model")
+		return
+	}
+	acceleratorSelector := acceleratorclassselector.New(c)
+
+	if engine := isvc.Spec.Engine; engine != nil && engine.Leader == nil && engine.Worker == nil {
+		runtimeComponent := rt.EngineConfig
+		if runtimeComponent == nil {
+			runtimeComponent = &v1beta1.EngineSpec{}
+		}
+		if runtimeComponent.Leader == nil && runtimeComponent.Worker == nil &&
+			defaultComponentFromModel(ctx, acceleratorSelector, isvc, rt, model, v1beta1.EngineComponent,
+				&engine.PodSpec, &engine.Runner, runtimeComponent.Runner) {
+			derived[v1beta1.EngineComponent] = modelResourceProfileHash(&engine.PodSpec, engine.Runner)
+		}
+	}
+	if decoder := isvc.Spec.Decoder; decoder != nil && decoder.Leader == nil && decoder.Worker == nil {
+		runtimeComponent := rt.DecoderConfig
+		if runtimeComponent == nil {
+			runtimeComponent = &v1beta1.DecoderSpec{}
+		}
+		if runtimeComponent.Leader == nil && runtimeComponent.Worker == nil &&
+			defaultComponentFromModel(ctx, acceleratorSelector, isvc, rt, model, v1beta1.DecoderComponent,
+				&decoder.PodSpec, &decoder.Runner, runtimeComponent.Runner) {
+			derived[v1beta1.DecoderComponent] = modelResourceProfileHash(&decoder.PodSpec, decoder.Runner)
+		}
+	}
+}
+
+// defaultComponentFromModel sizes a single-node component for the model on the accelerator class selected for it.
+// It returns true if the settings were derived, false if the InferenceService or the runtime sets the resources.
+func defaultComponentFromModel(ctx context.Context, acceleratorSelector acceleratorclassselector.Selector,
+	isvc *v1beta1.InferenceService, rt *v1beta1.ServingRuntimeSpec, model *v1beta1.BaseModelSpec, component v1beta1.ComponentType,
+	podSpec *v1beta1.PodSpec, runner **v1beta1.RunnerSpec, runtimeRunner *v1beta1.RunnerSpec) bool {
+	if *runner != nil && !isResourcesUnspecified((*runner).Resources) {
+		return false
+	}
+	if runtimeSetsResources(rt, runtimeRunner) {
+		return false
+	}
+
+	// An accelerator class that can't be fetched is reported by the controller, size for the default GPU meanwhile
+	acceleratorClass, _, _ := acceleratorSelector.GetAcceleratorClass(ctx, isvc, rt, component)
+	profile := estimateModelResourceProfile(model, gpuMemory(acceleratorClass))
+	if profile == nil {
+		return false
+	}
+	applyModelResourceProfile(podSpec, runner, profile, acceleratorResourceName(acceleratorClass))
+	return true
+}
+
+// runtimeSetsResources reports whether the serving runtime sets the resources of the main container of a component,
+// either in the runner of the component or in the containers of the runtime pod spec
+func runtimeSetsResources(rt *v1beta1.ServingRuntimeSpec, runtimeRunner *v1beta1.RunnerSpec) bool {
+	if runtimeRunner != nil && !isResourcesUnspecified(runtimeRunner.Resources) {
+		return true
+	}
+	for _, container := range rt.ServingRuntimePodSpec.Containers {
+		if container.Name == constants.MainContainerName && !isResourcesUnspecified(container.Resources) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyModelResourceProfile sets the resources of the profile, and the shared memory size and startup probe
+// timing of the profile the component doesn't specify itself
+func applyModelResourceProfile(podSpec *v1beta1.PodSpec, runner **v1beta1.RunnerSpec, profile *modelResourceProfile, gpuResource v1.ResourceName) {
+	if *runner == nil {
+		*runner = &v1beta1.RunnerSpec{}
+	}
+	container := &(*runner).Container
+
+	gpus := *resource.NewQuantity(profile.gpus, resource.DecimalSI)
+	container.Resources = v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceMemory: profile.memory,
+			gpuResource:       gpus,
+		},
+		Limits: v1.ResourceList{
+			v1.ResourceMemory: profile.memory,
+			gpuResource:       gpus.DeepCopy(),
+		},
+	}
+
+	if !hasSharedMemory(podSpec, container) {
+		sizeLimit := profile.sharedMemory
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name: sharedMemoryVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{Medium: v1.StorageMediumMemory, SizeLimit: &sizeLimit},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+			Name:      sharedMemoryVolumeName,
+			MountPath: sharedMemoryMountPath,
+		})
+	}
+
+	// Only the timing is set, the probe handler comes from the serving runtime
+	if container.StartupProbe == nil {
+		container.StartupProbe = &v1.Probe{
+			PeriodSeconds:    startupProbePeriodSeconds,
+			FailureThreshold: profile.startupFailureThreshold,
+		}
+	}
+}
+
+// clearModelResourceProfile removes the settings an earlier admission derived from the model metadata:
+// the resources, a size-limited /dev/shm volume and a startup probe without a handler
+func clearModelResourceProfile(podSpec *v1beta1.PodSpec, runner *v1beta1.RunnerSpec) {
+	if runner == nil {
+		return
+	}
+	runner.Resources = v1.ResourceRequirements{}
+	if runner.StartupProbe != nil && runner.StartupProbe.ProbeHandler == (v1.ProbeHandler{}) {
+		runner.StartupProbe = nil
+	}
+
+	removed := false
+	podSpec.Volumes = slices.DeleteFunc(podSpec.Volumes, func(volume v1.Volume) bool {
+		derived := volume.Name == sharedMemoryVolumeName && volume.EmptyDir != nil && volume.EmptyDir.SizeLimit != nil
+		removed = removed || derived
+		return derived
+	})
+	if removed {
+		runner.VolumeMounts = slices.DeleteFunc(runner.VolumeMounts, func(mount v1.VolumeMount) bool {
+			return mount.Name == sharedMemoryVolumeName
+		})
+	}
+}
+
+// modelResourceProfileHash hashes the settings of a component that may have been derived from the model metadata:
+// the resources, the size-limited /dev/shm volume and a startup probe without a handler
+func modelResourceProfileHash(podSpec *v1beta1.PodSpec, runner *v1beta1.RunnerSpec) string {
+	if runner == nil {
+		return ""
+	}
+	profile := struct {
+		Resources    v1.ResourceRequirements `json:"resources"`
+		StartupProbe *v1.Probe               `json:"startupProbe,omitempty"`
+		SharedMemory []v1.Volume             `json:"sharedMemory,omitempty"`
+	}{Resources: runner.Resources}
+	if runner.StartupProbe != nil && runner.StartupProbe.ProbeHandler == (v1.ProbeHandler{}) {
+		profile.StartupProbe = runner.StartupProbe
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == sharedMemoryVolumeName && volume.EmptyDir != nil && volume.EmptyDir.SizeLimit != nil {
+			profile.SharedMemory = append(profile.SharedMemory, volume)
+		}
+	}
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return ""
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// isResourcesUnspecified checks if the resource requirements are unspecified
+func isResourcesUnspecified(resources v1.ResourceRequirements) bool {
+	return resources.Limits == nil && resources.Requests == nil && len(resources.Claims) == 0
+}
+
+// hasSharedMemory reports whether the component already configures a /dev/shm volume
+func hasSharedMemory(podSpec *v1beta1.PodSpec, container *v1.Container) bool {
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == sharedMemoryVolumeName {
+			return true
+		}
+	}
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == sharedMemoryMountPath {
+			return true
+		}
+	}
+	return false
+}
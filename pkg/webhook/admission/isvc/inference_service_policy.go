@@ -0,0 +1,201 @@
+package isvc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+// policyCostLimit bounds the evaluation cost of a policy expression so that a costly rule can't stall admission
+const policyCostLimit = 1000000
+
+var (
+	policyEnvOnce sync.Once
+	policyEnv     *cel.Env
+	policyEnvErr  error
+)
+
+// compiledPolicy is a policy with its compiled expression
+type compiledPolicy struct {
+	controllerconfig.Policy
+	program cel.Program
+}
+
+// PolicyStore keeps the policies of the inferenceservice ConfigMap compiled. They are compiled when the ConfigMap
+// changes, so that an invalid expression is reported when admins edit it, and only the programs of its current
+// version are kept.
+type PolicyStore struct {
+	configMaps *controllerconfig.ConfigMapCache
+
+	mu              sync.Mutex
+	resourceVersion string
+	policies        []compiledPolicy
+	err             error
+}
+
+// NewPolicyStore returns a PolicyStore reading the inferenceservice ConfigMap from an informer. It must be added to
+// the manager to start the informer.
+func NewPolicyStore(clientset kubernetes.Interface) (*PolicyStore, error) {
+	store := &PolicyStore{
+		configMaps: controllerconfig.NewConfigMapCache(clientset, constants.OMENamespace, constants.InferenceServiceConfigMapName),
+	}
+	err := store.configMaps.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if configMap, ok := obj.(*v1.ConfigMap); ok {
+				_, _ = store.load(configMap)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if configMap, ok := obj.(*v1.ConfigMap); ok {
+				_, _ = store.load(configMap)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Start runs the informer of the ConfigMap until the context is done
+func (s *PolicyStore) Start(ctx context.Context) error {
+	return s.configMaps.Start(ctx)
+}
+
+// NeedLeaderElection is false as the webhooks of every replica evaluate the policies
+func (s *PolicyStore) NeedLeaderElection() bool {
+	return false
+}
+
+// current returns the compiled policies of the current version of the ConfigMap
+func (s *PolicyStore) current(ctx context.Context) ([]compiledPolicy, error) {
+	configMap, err := s.configMaps.Get(ctx, constants.OMENamespace)
+	if err != nil {
+		return nil, err
+	}
+	return s.load(configMap)
+}
+
+// load compiles the policies of a version of the ConfigMap, unless they are already compiled
+func (s *PolicyStore) load(configMap *v1.ConfigMap) ([]compiledPolicy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if configMap.ResourceVersion != "" && configMap.ResourceVersion == s.resourceVersion {
+		return s.policies, s.err
+	}
+
+	s.resourceVersion = configMap.ResourceVersion
+	s.policies, s.err = nil, nil
+	policyConfig, err := controllerconfig.ParsePolicyConfig(configMap)
+	if err == nil {
+		s.policies, err = compilePolicies(policyConfig.Policies)
+	}
+	if err != nil {
+		s.err = err
+		validatorLogger.Error(err, "Invalid InferenceService policies, InferenceServices are rejected until they are fixed",
+			"configMap", constants.InferenceServiceConfigMapName, "resourceVersion", configMap.ResourceVersion)
+	}
+	return s.policies, s.err
+}
+
+// compilePolicies compiles the expressions of the policies, failing on the first invalid one
+func compilePolicies(policies []controllerconfig.Policy) ([]compiledPolicy, error) {
+	policyEnvOnce.Do(func() {
+		policyEnv, policyEnvErr = cel.NewEnv(cel.Variable("object", cel.DynType))
+	})
+	if policyEnvErr != nil {
+		return nil, policyEnvErr
+	}
+
+	compiled := make([]compiledPolicy, 0, len(policies))
+	for _, policy := range policies {
+		program, err := policyProgram(policy.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s: invalid expression: %w", policy.Name, err)
+		}
+		compiled = append(compiled, compiledPolicy{Policy: policy, program: program})
+	}
+	return compiled, nil
+}
+
+// policyProgram compiles a policy expression
+func policyProgram(expression string) (cel.Program, error) {
+	ast, issues := policyEnv.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to a bool, got %s", outputType)
+	}
+	return policyEnv.Program(ast, cel.CostLimit(policyCostLimit))
+}
+
+// evaluatePolicies evaluates the policies that apply to the namespace of the InferenceService. Violated Warn
+// policies are returned as warnings and violated Deny policies as a single error listing all of them.
+func evaluatePolicies(isvc *v1beta1.InferenceService, policies []compiledPolicy) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	if len(policies) == 0 {
+		return warnings, nil
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(isvc)
+	if err != nil {
+		return warnings, fmt.Errorf("failed to convert InferenceService for policy evaluation: %w", err)
+	}
+
+	var denied []string
+	for _, policy := range policies {
+		if len(policy.Namespaces) > 0 && !slices.Contains(policy.Namespaces, isvc.Namespace) {
+			continue
+		}
+		violation := policyViolation(policy, object)
+		if violation == "" {
+			continue
+		}
+		if policy.Action == controllerconfig.PolicyActionWarn {
+			warnings = append(warnings, "Policy violation: "+violation)
+		} else {
+			denied = append(denied, violation)
+		}
+	}
+
+	if len(denied) > 0 {
+		return warnings, fmt.Errorf("InferenceService %s violates policies: %s", isvc.Name, strings.Join(denied, "; "))
+	}
+	return warnings, nil
+}
+
+// policyViolation returns an empty string if the object satisfies the policy, or the violation message.
+// Policies that fail to evaluate are reported as violated so that a broken rule fails closed.
+func policyViolation(policy compiledPolicy, object map[string]interface{}) string {
+	out, _, err := policy.program.Eval(map[string]interface{}{"object": object})
+	if err != nil {
+		return fmt.Sprintf("%s: evaluation failed: %v", policy.Name, err)
+	}
+	allowed, ok := out.Value().(bool)
+	if !ok {
+		return fmt.Sprintf("%s: expression did not evaluate to a bool", policy.Name)
+	}
+	if allowed {
+		return ""
+	}
+
+	message := policy.Message
+	if message == "" {
+		message = fmt.Sprintf("expression %q is false", policy.Expression)
+	}
+	return policy.Name + ": " + message
+}
@@ -0,0 +1,141 @@
+package isvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+func TestEvaluatePolicies(t *testing.T) {
+	minReplicas := 1
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "prod"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Engine: &v1beta1.EngineSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &minReplicas},
+			},
+		},
+	}
+	minReplicasPolicy := controllerconfig.Policy{
+		Name:       "min-replicas",
+		Expression: "has(object.spec.engine.minReplicas) && object.spec.engine.minReplicas >= 2",
+		Message:    "production InferenceServices need at least 2 replicas",
+		Action:     controllerconfig.PolicyActionDeny,
+	}
+
+	tests := []struct {
+		name         string
+		policies     []controllerconfig.Policy
+		wantErr      string
+		wantWarnings int
+	}{
+		{
+			name: "no policies",
+		},
+		{
+			name: "satisfied policy",
+			policies: []controllerconfig.Policy{{
+				Name:       "has-engine",
+				Expression: "has(object.spec.engine)",
+				Action:     controllerconfig.PolicyActionDeny,
+			}},
+		},
+		{
+			name:     "denied",
+			policies: []controllerconfig.Policy{minReplicasPolicy},
+			wantErr:  "min-replicas: production InferenceServices need at least 2 replicas",
+		},
+		{
+			name: "warned",
+			policies: []controllerconfig.Policy{func() controllerconfig.Policy {
+				p := minReplicasPolicy
+				p.Action = controllerconfig.PolicyActionWarn
+				return p
+			}()},
+			wantWarnings: 1,
+		},
+		{
+			name: "policy scoped to other namespaces",
+			policies: []controllerconfig.Policy{func() controllerconfig.Policy {
+				p := minReplicasPolicy
+				p.Namespaces = []string{"staging"}
+				return p
+			}()},
+		},
+		{
+			name: "non bool expression fails closed",
+			policies: []controllerconfig.Policy{{
+				Name:       "not-bool",
+				Expression: "object.metadata.name",
+				Action:     controllerconfig.PolicyActionDeny,
+			}},
+			wantErr: "not-bool: expression did not evaluate to a bool",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := compilePolicies(tt.policies)
+			require.NoError(t, err)
+			warnings, err := evaluatePolicies(isvc, policies)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func TestPolicyStore(t *testing.T) {
+	ctx := context.Background()
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            constants.InferenceServiceConfigMapName,
+			Namespace:       constants.OMENamespace,
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{
+			controllerconfig.PolicyConfigName: `{"policies": [{"name": "has-engine", "expression": "has(object.spec.engine)"}]}`,
+		},
+	}
+	clientset := fake.NewSimpleClientset(configMap)
+	store, err := NewPolicyStore(clientset)
+	require.NoError(t, err)
+
+	// The policies are compiled once per version of the ConfigMap
+	policies, err := store.current(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, controllerconfig.PolicyActionDeny, policies[0].Action)
+	again, err := store.current(ctx)
+	require.NoError(t, err)
+	assert.Same(t, &policies[0], &again[0])
+
+	// An invalid expression is reported when the ConfigMap is loaded
+	configMap = configMap.DeepCopy()
+	configMap.ResourceVersion = "2"
+	configMap.Data[controllerconfig.PolicyConfigName] = `{"policies": [{"name": "broken", "expression": "object.spec.engine.minReplicas >="}]}`
+	_, err = store.load(configMap)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "policy broken: invalid expression")
+
+	// A non bool expression is rejected at load when its type is known
+	configMap = configMap.DeepCopy()
+	configMap.ResourceVersion = "3"
+	configMap.Data[controllerconfig.PolicyConfigName] = `{"policies": [{"name": "not-bool", "expression": "1 + 1"}]}`
+	_, err = store.load(configMap)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must evaluate to a bool")
+}
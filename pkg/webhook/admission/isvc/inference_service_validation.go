@@ -17,7 +17,9 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/modelver"
 	"github.com/sgl-project/ome/pkg/runtimeselector"
+	"github.com/sgl-project/ome/pkg/webhook/admission/audit"
 )
 
 // regular expressions for validation of isvc name
@@ -43,6 +45,8 @@ var (
 type InferenceServiceValidator struct {
 	Client          client.Client
 	RuntimeSelector runtimeselector.Selector
+	// Policies holds the policies of the inferenceservice ConfigMap, policies are not evaluated if nil
+	Policies *PolicyStore
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-ome-io-v1beta1-inferenceservice,mutating=false,failurePolicy=fail,groups=ome.io,resources=inferenceservices,versions=v1beta1,name=inferenceservice.ome-webhook-server.validator
@@ -88,27 +92,54 @@ func GetIntReference(number int) *int {
 }
 
 func (v *InferenceServiceValidator) validateInferenceService(ctx context.Context, isvc *v1beta1.InferenceService) (admission.Warnings, error) {
-	var allWarnings admission.Warnings
+	// Deprecated fields are still accepted, the warnings tell users what to migrate before they are removed
+	allWarnings := deprecationWarnings(isvc)
 
 	if err := validateInferenceServiceName(isvc); err != nil {
+		audit.RecordRule(ctx, "name")
 		return allWarnings, err
 	}
 
 	if err := validateInferenceServiceAutoscaler(isvc); err != nil {
+		audit.RecordRule(ctx, "autoscaler")
 		return allWarnings, err
 	}
 
 	if err := validateAutoscalerTargetUtilizationPercentage(isvc); err != nil {
+		audit.RecordRule(ctx, "target_utilization")
 		return allWarnings, err
 	}
 
 	// New validation logic for Engine/Decoder architecture
 	if err := validateEngineDecoderConfiguration(isvc); err != nil {
+		audit.RecordRule(ctx, "engine_decoder")
 		return allWarnings, err
 	}
 
+	if err := validateRuntimeVersionConstraints(isvc); err != nil {
+		audit.RecordRule(ctx, "runtime_version_constraints")
+		return allWarnings, err
+	}
+
+	// Evaluate the admission policies defined by cluster admins
+	if v.Policies != nil {
+		policies, err := v.Policies.current(ctx)
+		if err != nil {
+			return allWarnings, fmt.Errorf("failed to load InferenceService policies: %w", err)
+		}
+		warnings, err := evaluatePolicies(isvc, policies)
+		allWarnings = append(allWarnings, warnings...)
+		if err != nil || len(warnings) > 0 {
+			audit.RecordRule(ctx, "policy")
+		}
+		if err != nil {
+			return allWarnings, err
+		}
+	}
+
 	// Validate that referenced model exists (for new Engine architecture using isvc.Spec.Model)
 	if err := v.validateModelExists(ctx, isvc); err != nil {
+		audit.RecordRule(ctx, "model_exists")
 		return allWarnings, err
 	}
 
@@ -116,6 +147,7 @@ func (v *InferenceServiceValidator) validateInferenceService(ctx context.Context
 	if isvc.Spec.Engine != nil {
 		warnings, err := v.validateRuntimeAndModelResolution(ctx, isvc)
 		if err != nil {
+			audit.RecordRule(ctx, "runtime_resolution")
 			return allWarnings, err
 		}
 		allWarnings = append(allWarnings, warnings...)
@@ -346,6 +378,16 @@ func validateEngineDecoderConfiguration(isvc *v1beta1.InferenceService) error {
 	return nil
 }
 
+// validateRuntimeVersionConstraints checks that the runtime version constraints are valid version ranges
+func validateRuntimeVersionConstraints(isvc *v1beta1.InferenceService) error {
+	for _, constraint := range isvc.Spec.RuntimeVersionConstraints {
+		if _, err := modelver.ParseConstraint(constraint.Version); err != nil {
+			return fmt.Errorf("invalid runtime version constraint: %w", err)
+		}
+	}
+	return nil
+}
+
 // validateModelExists validates that the referenced model (BaseModel or ClusterBaseModel) exists
 func (v *InferenceServiceValidator) validateModelExists(ctx context.Context, isvc *v1beta1.InferenceService) error {
 	// Check new architecture model reference (isvc.Spec.Model)
@@ -369,6 +411,14 @@ func (v *InferenceServiceValidator) validateRuntimeAndModelResolution(ctx contex
 		return warnings, nil
 	}
 
+	// Rule 1: A runtime override annotation bypasses scoring, so its compatibility with the model is checked up front
+	if _, fromAnnotation := isvcutils.GetUserSpecifiedRuntime(isvc); fromAnnotation {
+		if isvc.Spec.Model == nil {
+			return warnings, fmt.Errorf("model reference is required when the %s annotation is set", constants.RuntimeOverrideAnnotationKey)
+		}
+		return v.resolveModelAndRuntime(ctx, isvc, warnings)
+	}
+
 	// Rule 2: If inference service does not have runtime defined in isvc.runtime
 	if isvc.Spec.Runtime == nil {
 		// Check if engine has full runner config
@@ -399,15 +449,30 @@ func (v *InferenceServiceValidator) resolveModelAndRuntime(ctx context.Context,
 	}
 
 	// Check runtime selection/validation
-	if isvc.Spec.Runtime != nil && isvc.Spec.Runtime.Name != "" {
+	if rtName, fromAnnotation := isvcutils.GetUserSpecifiedRuntime(isvc); rtName != "" {
 		// Validate specified runtime
-		if err := v.RuntimeSelector.ValidateRuntime(ctx, isvc.Spec.Runtime.Name, baseModel, isvc); err != nil {
+		if err := v.RuntimeSelector.ValidateRuntime(ctx, rtName, baseModel, isvc); err != nil {
+			if fromAnnotation {
+				return warnings, fmt.Errorf("runtime %s set by the %s annotation cannot serve model %s: %w",
+					rtName, constants.RuntimeOverrideAnnotationKey, isvc.Spec.Model.Name, err)
+			}
 			return warnings, fmt.Errorf("runtime %s does not support model %s: %w",
-				isvc.Spec.Runtime.Name, isvc.Spec.Model.Name, err)
+				rtName, isvc.Spec.Model.Name, err)
 		}
 		warnings = append(warnings, fmt.Sprintf("Runtime %s is valid for model %s",
-			isvc.Spec.Runtime.Name, isvc.Spec.Model.Name))
+			rtName, isvc.Spec.Model.Name))
 	} else {
+		// Report how every runtime was evaluated when the user asks for it, e.g. with a server-side dry run
+		if isvc.Annotations[constants.ExplainRuntimeSelectionAnnotationKey] == "true" {
+			explanation, err := v.RuntimeSelector.Explain(ctx, baseModel, isvc)
+			if err != nil {
+				return warnings, fmt.Errorf("failed to explain runtime selection for model %s: %w", isvc.Spec.Model.Name, err)
+			}
+			for _, line := range explanation.Lines() {
+				warnings = append(warnings, "Runtime selection: "+line)
+			}
+		}
+
 		// Check if runtime can be auto-selected
 		selection, err := v.RuntimeSelector.SelectRuntime(ctx, baseModel, isvc)
 		if err != nil {
@@ -72,7 +72,7 @@ func TestInferenceServiceValidator_ValidateCreate(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Nil(t, warnings)
+				assert.Equal(t, admission.Warnings{predictorDeprecationWarning}, warnings)
 			}
 		})
 	}
@@ -126,7 +126,7 @@ func TestInferenceServiceValidator_ValidateUpdate(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Nil(t, warnings)
+				assert.Equal(t, admission.Warnings{predictorDeprecationWarning}, warnings)
 			}
 		})
 	}
@@ -507,6 +507,48 @@ func TestInferenceService_EngineDecoderValidation(t *testing.T) {
 	}
 }
 
+func TestInferenceService_RuntimeVersionConstraintValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints []v1beta1.RuntimeVersionConstraint
+		wantErr     bool
+	}{
+		{
+			name:        "no constraints - should pass",
+			constraints: nil,
+		},
+		{
+			name:        "valid range - should pass",
+			constraints: []v1beta1.RuntimeVersionConstraint{{Engine: "sglang", Version: ">=0.4.3 <0.5"}},
+		},
+		{
+			name:        "malformed range - should fail",
+			constraints: []v1beta1.RuntimeVersionConstraint{{Engine: "sglang", Version: ">=0.4.x"}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-isvc",
+					Namespace: "default",
+				},
+				Spec: v1beta1.InferenceServiceSpec{RuntimeVersionConstraints: tt.constraints},
+			}
+
+			err := validateRuntimeVersionConstraints(isvc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid runtime version constraint")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestHasFullRunnerConfig(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1519,6 +1561,17 @@ func TestResolveModelAndRuntime_WarningHandling(t *testing.T) {
 		assert.Equal(t, "existing warning", warnings[0])
 		assert.Contains(t, warnings[1], "will be auto-selected")
 	})
+
+	t.Run("explain runtime selection", func(t *testing.T) {
+		explained := isvc.DeepCopy()
+		explained.Annotations = map[string]string{constants.ExplainRuntimeSelectionAnnotationKey: "true"}
+		warnings, err := validator.resolveModelAndRuntime(context.Background(), explained, admission.Warnings{})
+		assert.NoError(t, err)
+		assert.Len(t, warnings, 3)
+		assert.Equal(t, "Runtime selection: ClusterServingRuntime runtime-1: score 1 (ModelFormat=1) [selected]", warnings[0])
+		assert.Equal(t, "Runtime selection: ClusterServingRuntime runtime-2: score 1 (ModelFormat=1)", warnings[1])
+		assert.Contains(t, warnings[2], "Runtime runtime-1 will be auto-selected")
+	})
 }
 
 // Test namespace vs cluster model precedence
@@ -1604,6 +1657,111 @@ func TestResolveModelAndRuntime_NamespacePrecedence(t *testing.T) {
 	assert.Contains(t, warnings[0], "will be auto-selected for model test-model")
 }
 
+func TestValidateRuntimeAndModelResolution_RuntimeOverride(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+
+	model := &v1beta1.ClusterBaseModel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-model",
+		},
+		Spec: v1beta1.BaseModelSpec{
+			ModelArchitecture: stringPtr("LlamaForCausalLM"),
+			ModelFormat: v1beta1.ModelFormat{
+				Name:    "safetensors",
+				Version: stringPtr("1.0.0"),
+			},
+		},
+	}
+	newRuntime := func(name, architecture string) *v1beta1.ClusterServingRuntime {
+		return &v1beta1.ClusterServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{
+						ModelFormat: &v1beta1.ModelFormat{
+							Name:    "safetensors",
+							Version: stringPtr("1.0.0"),
+							Weight:  int64(1),
+						},
+						ModelArchitecture: stringPtr(architecture),
+						AutoSelect:        boolPtr(false),
+					},
+				},
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(model, newRuntime("llama-runtime", "LlamaForCausalLM"), newRuntime("qwen-runtime", "Qwen2ForCausalLM")).
+		Build()
+
+	validator := &InferenceServiceValidator{
+		Client:          fakeClient,
+		RuntimeSelector: runtimeselector.New(fakeClient),
+	}
+
+	tests := []struct {
+		name          string
+		override      string
+		modelRef      *v1beta1.ModelRef
+		expectedError string
+		expectedWarn  string
+	}{
+		{
+			name:         "compatible override is accepted without auto-select",
+			override:     "llama-runtime",
+			modelRef:     &v1beta1.ModelRef{Name: "test-model"},
+			expectedWarn: "Runtime llama-runtime is valid for model test-model",
+		},
+		{
+			name:          "incompatible override is rejected",
+			override:      "qwen-runtime",
+			modelRef:      &v1beta1.ModelRef{Name: "test-model"},
+			expectedError: "runtime qwen-runtime set by the ome.io/runtime-override annotation cannot serve model test-model",
+		},
+		{
+			name:          "missing override runtime is rejected",
+			override:      "missing-runtime",
+			modelRef:      &v1beta1.ModelRef{Name: "test-model"},
+			expectedError: "runtime missing-runtime set by the ome.io/runtime-override annotation cannot serve model test-model",
+		},
+		{
+			name:          "override requires a model",
+			override:      "llama-runtime",
+			expectedError: "model reference is required when the ome.io/runtime-override annotation is set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-isvc",
+					Namespace:   "default",
+					Annotations: map[string]string{constants.RuntimeOverrideAnnotationKey: tt.override},
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Model:  tt.modelRef,
+					Engine: &v1beta1.EngineSpec{},
+				},
+			}
+
+			warnings, err := validator.validateRuntimeAndModelResolution(context.Background(), isvc)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, warnings, tt.expectedWarn)
+		})
+	}
+}
+
 // =============================================================================
 // UTILITY TESTS
 // =============================================================================
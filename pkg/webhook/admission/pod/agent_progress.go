@@ -0,0 +1,31 @@
+package pod
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// injectAgentProgress configures an ome-agent container to publish its progress heartbeats to the progress ConfigMap
+// of the component of its pod, which the controller reads. The controller creates the ConfigMap and grants the
+// service account of the pod the permission to patch it, and only it. The pods that don't belong to a component of an
+// InferenceService have no progress ConfigMap and publish no progress.
+func injectAgentProgress(pod *v1.Pod, container *v1.Container) {
+	isvcName := pod.Labels[constants.InferenceServicePodLabelKey]
+	component := pod.Labels[constants.OMEComponentLabel]
+	if isvcName == "" || component == "" {
+		return
+	}
+	container.Env = append(container.Env,
+		v1.EnvVar{Name: constants.AgentProgressConfigMapEnvVarKey, Value: constants.AgentProgressConfigMapName(isvcName, component)},
+		v1.EnvVar{Name: constants.AgentProgressContainerNameEnvVarKey, Value: container.Name},
+		v1.EnvVar{
+			Name:      constants.AgentProgressPodNameEnvVarKey,
+			ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+		},
+		v1.EnvVar{
+			Name:      constants.AgentProgressPodNamespaceEnvVarKey,
+			ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+		},
+	)
+}
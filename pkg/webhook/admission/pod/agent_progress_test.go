@@ -0,0 +1,43 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestInjectAgentProgress(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+		constants.InferenceServicePodLabelKey: "llama",
+		constants.OMEComponentLabel:           "engine",
+	}}}
+	modelInit := &v1.Container{Name: constants.ModelInitContainerName}
+	fineTunedAdapter := &v1.Container{Name: constants.FineTunedAdapterContainerName}
+
+	injectAgentProgress(pod, modelInit)
+	injectAgentProgress(pod, fineTunedAdapter)
+
+	for _, container := range []*v1.Container{modelInit, fineTunedAdapter} {
+		// The progress is only published to the ConfigMap of the component
+		assert.Empty(t, container.VolumeMounts)
+
+		env := map[string]v1.EnvVar{}
+		for _, envVar := range container.Env {
+			env[envVar.Name] = envVar
+		}
+		assert.NotContains(t, env, "OME_AGENT_PROGRESS_DIR")
+		assert.Equal(t, "llama-engine-progress", env["OME_AGENT_PROGRESS_CONFIG_MAP"].Value)
+		assert.Equal(t, container.Name, env["OME_AGENT_PROGRESS_CONTAINER_NAME"].Value)
+		assert.Equal(t, "metadata.name", env["OME_AGENT_PROGRESS_POD_NAME"].ValueFrom.FieldRef.FieldPath)
+		assert.Equal(t, "metadata.namespace", env["OME_AGENT_PROGRESS_POD_NAMESPACE"].ValueFrom.FieldRef.FieldPath)
+	}
+
+	// A pod that isn't part of an InferenceService publishes no progress
+	other := &v1.Container{Name: constants.ModelInitContainerName}
+	injectAgentProgress(&v1.Pod{}, other)
+	assert.Empty(t, other.Env)
+}
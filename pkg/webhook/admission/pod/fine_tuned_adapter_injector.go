@@ -85,6 +85,7 @@ func (fa *FineTunedAdapterInjector) injectFineTunedAdapter(pod *v1.Pod) error {
 	}
 
 	initContainer := fa.createInitContainer(initEnvs, modelInitMounts, securityContext)
+	injectAgentProgress(pod, initContainer)
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
 	return nil
 }
@@ -19,6 +19,17 @@ const (
 type MetricsAggregator struct {
 	EnableMetricAggregation  string `json:"enableMetricAggregation"`
 	EnablePrometheusScraping string `json:"enablePrometheusScraping"`
+	// TLSCertFile and TLSKeyFile are the certificate and key files of the queue-proxy the aggregate metrics are
+	// served over TLS with, e.g. the certificates Knative mounts with system-internal-tls. Plain HTTP when empty.
+	TLSCertFile string `json:"tlsCertFile,omitempty"`
+	TLSKeyFile  string `json:"tlsKeyFile,omitempty"`
+	// ClientCAFile is the CA file the client certificates of the scrapers are verified with, none are when empty
+	ClientCAFile string `json:"clientCAFile,omitempty"`
+	// BearerTokenFile is the file of the token the scrapers must send, none is required when empty
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// CacheTTL is how long the aggregate metrics are served to the scrapes after they were merged, e.g. "2s".
+	// They are merged for every scrape when empty.
+	CacheTTL string `json:"cacheTTL,omitempty"`
 }
 
 func newMetricsAggregator(configMap *v1.ConfigMap) (*MetricsAggregator, error) { //nolint:unparam
@@ -34,7 +45,7 @@ func newMetricsAggregator(configMap *v1.ConfigMap) (*MetricsAggregator, error) {
 	return ma, nil
 }
 
-func setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) {
+func (ma *MetricsAggregator) setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) {
 	for i, container := range pod.Spec.Containers {
 		if container.Name == "queue-proxy" {
 			// The ome-container prometheus port/path is inherited from the ClusterServingRuntime YAML.
@@ -54,9 +65,30 @@ func setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) {
 			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.ContainerPrometheusMetricsPortEnvVarKey, Value: omeContainerPromPort})
 			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.ContainerPrometheusMetricsPathEnvVarKey, Value: omeContainerPromPath})
 
+			// The ome-container metrics are scraped over HTTPS when it serves them so
+			if scheme, ok := pod.ObjectMeta.Annotations[constants.ContainerPrometheusSchemeKey]; ok {
+				pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.ContainerPrometheusMetricsSchemeEnvVarKey, Value: scheme})
+			}
+			if caFile, ok := pod.ObjectMeta.Annotations[constants.ContainerPrometheusCAFileKey]; ok {
+				pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.ContainerPrometheusMetricsCAFileEnvVarKey, Value: caFile})
+			}
+
 			// Set the port that queue-proxy will use to expose the aggregate metrics.
 			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.QueueProxyAggregatePrometheusMetricsPortEnvVarKey, Value: strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)})
 
+			// Set how the aggregate metrics are served, who may scrape them and how long they are cached
+			for _, env := range []v1.EnvVar{
+				{Name: constants.AggregatePrometheusMetricsTLSCertFileEnvVarKey, Value: ma.TLSCertFile},
+				{Name: constants.AggregatePrometheusMetricsTLSKeyFileEnvVarKey, Value: ma.TLSKeyFile},
+				{Name: constants.AggregatePrometheusMetricsClientCAFileEnvVarKey, Value: ma.ClientCAFile},
+				{Name: constants.AggregatePrometheusMetricsTokenFileEnvVarKey, Value: ma.BearerTokenFile},
+				{Name: constants.AggregatePrometheusMetricsCacheTTLEnvVarKey, Value: ma.CacheTTL},
+			} {
+				if env.Value != "" {
+					pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, env)
+				}
+			}
+
 			pod.Spec.Containers[i].Ports = utils.AppendPortIfNotExists(pod.Spec.Containers[i].Ports, v1.ContainerPort{
 				Name:          constants.AggregateMetricsPortName,
 				ContainerPort: int32(constants.QueueProxyAggregatePrometheusMetricsPort),
@@ -79,7 +111,7 @@ func (ma *MetricsAggregator) InjectMetricsAggregator(pod *v1.Pod) error {
 		enableMetricAggregation = ma.EnableMetricAggregation
 	}
 	if enableMetricAggregation == "true" {
-		setMetricAggregationEnvVarsAndPorts(pod)
+		ma.setMetricAggregationEnvVarsAndPorts(pod)
 	}
 
 	// Handle setting the pod prometheus annotations
@@ -94,6 +126,10 @@ func (ma *MetricsAggregator) InjectMetricsAggregator(pod *v1.Pod) error {
 		podPromPort := constants.DefaultPodPrometheusPort
 		if enableMetricAggregation == "true" {
 			podPromPort = strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)
+			// The aggregate metrics are served over TLS when a certificate is set
+			if ma.TLSCertFile != "" {
+				pod.ObjectMeta.Annotations[constants.PrometheusSchemeAnnotationKey] = "https"
+			}
 		}
 		pod.ObjectMeta.Annotations[constants.PrometheusPortAnnotationKey] = podPromPort
 		pod.ObjectMeta.Annotations[constants.PrometheusPathAnnotationKey] = constants.DefaultPrometheusPath
@@ -307,3 +307,50 @@ func TestInjectMetricsAggregator(t *testing.T) {
 		}
 	}
 }
+
+func TestInjectMetricsAggregatorTLS(t *testing.T) {
+	cfgMap := v1.ConfigMap{Data: map[string]string{MetricsAggregatorConfigMapKeyName: `{
+		"enableMetricAggregation": "true",
+		"enablePrometheusScraping": "true",
+		"tlsCertFile": "/var/lib/knative/certs/tls.crt",
+		"tlsKeyFile": "/var/lib/knative/certs/tls.key",
+		"bearerTokenFile": "/var/run/secrets/metrics/token",
+		"cacheTTL": "2s"
+	}`}}
+	ma, err := newMetricsAggregator(&cfgMap)
+	if err != nil {
+		t.Fatalf("Error creating the metrics aggregator %v", err)
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployment",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.ContainerPrometheusSchemeKey: "https",
+				constants.ContainerPrometheusCAFileKey: "/etc/ome/ca.crt",
+			},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "sklearn"}, {Name: "queue-proxy"}}},
+	}
+
+	if err := ma.InjectMetricsAggregator(pod); err != nil {
+		t.Fatalf("InjectMetricsAggregator() error = %v", err)
+	}
+	expectedEnv := []v1.EnvVar{
+		{Name: constants.ContainerPrometheusMetricsPortEnvVarKey, Value: sklearnPrometheusPort},
+		{Name: constants.ContainerPrometheusMetricsPathEnvVarKey, Value: constants.DefaultPrometheusPath},
+		{Name: constants.ContainerPrometheusMetricsSchemeEnvVarKey, Value: "https"},
+		{Name: constants.ContainerPrometheusMetricsCAFileEnvVarKey, Value: "/etc/ome/ca.crt"},
+		{Name: constants.QueueProxyAggregatePrometheusMetricsPortEnvVarKey, Value: strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)},
+		{Name: constants.AggregatePrometheusMetricsTLSCertFileEnvVarKey, Value: "/var/lib/knative/certs/tls.crt"},
+		{Name: constants.AggregatePrometheusMetricsTLSKeyFileEnvVarKey, Value: "/var/lib/knative/certs/tls.key"},
+		{Name: constants.AggregatePrometheusMetricsTokenFileEnvVarKey, Value: "/var/run/secrets/metrics/token"},
+		{Name: constants.AggregatePrometheusMetricsCacheTTLEnvVarKey, Value: "2s"},
+	}
+	if diff, _ := kmp.SafeDiff(expectedEnv, pod.Spec.Containers[1].Env); diff != "" {
+		t.Errorf("Unexpected queue-proxy env (-want +got): %v", diff)
+	}
+	if scheme := pod.Annotations[constants.PrometheusSchemeAnnotationKey]; scheme != "https" {
+		t.Errorf("Expected the %s annotation to be https, got %q", constants.PrometheusSchemeAnnotationKey, scheme)
+	}
+}
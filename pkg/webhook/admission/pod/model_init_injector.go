@@ -79,6 +79,7 @@ func (mi *ModelInitInjector) injectModelInit(pod *v1.Pod) error {
 	}
 
 	initContainer := mi.createInitContainer(initEnvs, modelInitMounts, securityContext)
+	injectAgentProgress(pod, initContainer)
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
 	return nil
 }
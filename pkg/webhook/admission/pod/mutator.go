@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 )
 
 // +kubebuilder:webhook:path=/mutate-pods,mutating=true,failurePolicy=fail,groups="",resources=pods,verbs=create,versions=v1,name=inferenceservice.ome-webhook-server.pod-mutator,reinvocationPolicy=IfNeeded
@@ -25,6 +26,9 @@ type Mutator struct {
 	Client    client.Client
 	Clientset kubernetes.Interface
 	Decoder   admission.Decoder
+	// NamespaceConfigMaps caches the constants.PodMutatorNamespaceConfigMapName ConfigMaps of the namespaces,
+	// they are read from the API server if nil
+	NamespaceConfigMaps *controllerconfig.ConfigMapCache
 }
 
 // Handle decodes the incoming Pod and executes mutation logic.
@@ -54,6 +58,12 @@ func (mutator *Mutator) Handle(ctx context.Context, req admission.Request) admis
 	// For some reason pod namespace is always empty when coming to pod mutator, need to set from admission request
 	pod.Namespace = req.AdmissionRequest.Namespace
 
+	configMap, err = mutator.namespaceConfig(ctx, configMap, pod.Namespace)
+	if err != nil {
+		log.Error(err, "Failed to read namespace config map", "name", constants.PodMutatorNamespaceConfigMapName, "namespace", pod.Namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
 	if err := mutator.mutate(pod, configMap); err != nil {
 		log.Error(err, "Failed to mutate pod", "name", podName)
 		return admission.Errored(http.StatusInternalServerError, err)
@@ -0,0 +1,91 @@
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// namespaceConfigurableKeys are the injector configurations a namespace can override for its pods
+var namespaceConfigurableKeys = map[string]bool{
+	modelInitConfigMapKeyName:         true,
+	fineTunedAdapterConfigMapKeyName:  true,
+	servingSidecarConfigMapKeyName:    true,
+	MetricsAggregatorConfigMapKeyName: true,
+}
+
+// namespaceConfig returns the injector configuration for pods in the namespace: the cluster-wide configMap
+// with the overrides of the namespace's constants.PodMutatorNamespaceConfigMapName ConfigMap merged in.
+func (mutator *Mutator) namespaceConfig(ctx context.Context, configMap *v1.ConfigMap, namespace string) (*v1.ConfigMap, error) {
+	if namespace == "" || namespace == constants.OMENamespace {
+		return configMap, nil
+	}
+	var overrides *v1.ConfigMap
+	var err error
+	if mutator.NamespaceConfigMaps != nil {
+		overrides, err = mutator.NamespaceConfigMaps.Get(ctx, namespace)
+	} else {
+		overrides, err = mutator.Clientset.CoreV1().ConfigMaps(namespace).Get(ctx, constants.PodMutatorNamespaceConfigMapName, metav1.GetOptions{})
+	}
+	if apierrors.IsNotFound(err) {
+		return configMap, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mergeNamespaceConfig(configMap, overrides)
+}
+
+// mergeNamespaceConfig merges the injector configurations of the namespace ConfigMap into a copy of the
+// cluster-wide ConfigMap. Fields set by the namespace replace the cluster-wide values, nested objects are
+// merged field by field and lists are replaced as a whole.
+func mergeNamespaceConfig(configMap *v1.ConfigMap, overrides *v1.ConfigMap) (*v1.ConfigMap, error) {
+	merged := configMap.DeepCopy()
+	if merged.Data == nil {
+		merged.Data = map[string]string{}
+	}
+	for key, override := range overrides.Data {
+		if !namespaceConfigurableKeys[key] {
+			log.Info("Ignoring unsupported key in namespace pod mutator config", "namespace", overrides.Namespace, "key", key)
+			continue
+		}
+
+		var overrideConfig map[string]interface{}
+		if err := json.Unmarshal([]byte(override), &overrideConfig); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal %v json string of ConfigMap %s/%s: %w", key, overrides.Namespace, overrides.Name, err)
+		}
+		baseConfig := map[string]interface{}{}
+		if base, ok := merged.Data[key]; ok {
+			if err := json.Unmarshal([]byte(base), &baseConfig); err != nil {
+				return nil, fmt.Errorf("unable to unmarshal %v json string: %w", key, err)
+			}
+		}
+
+		mergedConfig, err := json.Marshal(mergeJSONObjects(baseConfig, overrideConfig))
+		if err != nil {
+			return nil, err
+		}
+		merged.Data[key] = string(mergedConfig)
+	}
+	return merged, nil
+}
+
+// mergeJSONObjects merges the override object into the base object, recursing into objects set in both
+func mergeJSONObjects(base, override map[string]interface{}) map[string]interface{} {
+	for key, value := range override {
+		baseObject, baseIsObject := base[key].(map[string]interface{})
+		overrideObject, overrideIsObject := value.(map[string]interface{})
+		if baseIsObject && overrideIsObject {
+			base[key] = mergeJSONObjects(baseObject, overrideObject)
+			continue
+		}
+		base[key] = value
+	}
+	return base
+}
@@ -0,0 +1,119 @@
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+func TestMergeNamespaceConfig(t *testing.T) {
+	clusterConfig := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data: map[string]string{
+			modelInitConfigMapKeyName: `{"image": "ome/model-init:v1", "compartmentId": "cluster-compartment", "authType": "InstancePrincipal", "vaultId": "cluster-vault",
+				"extraEnvVars": [{"name": "CLUSTER_ENV", "value": "1"}]}`,
+			"ingress": `{"ingressDomain": "example.com"}`,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   bool
+		verify    func(t *testing.T, merged *v1.ConfigMap)
+	}{
+		{
+			name: "overrides image and env of the model init container",
+			overrides: map[string]string{
+				modelInitConfigMapKeyName: `{"image": "registry.tenant.io/model-init:v2", "extraEnvVars": [{"name": "HTTPS_PROXY", "value": "http://proxy:3128"}]}`,
+			},
+			verify: func(t *testing.T, merged *v1.ConfigMap) {
+				injector := newModelInitInjector(merged)
+				assert.Equal(t, "registry.tenant.io/model-init:v2", injector.Image)
+				assert.Equal(t, "cluster-compartment", injector.CompartmentId)
+				require.NotNil(t, injector.ExtraEnvVars)
+				assert.Equal(t, []v1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy:3128"}}, *injector.ExtraEnvVars)
+			},
+		},
+		{
+			name: "adds volume defaults to a config the cluster doesn't set",
+			overrides: map[string]string{
+				servingSidecarConfigMapKeyName: `{"image": "ome/serving-sidecar:v1"}`,
+				modelInitConfigMapKeyName:      `{"extraVolumeMounts": [{"name": "ca-bundle", "mountPath": "/etc/ssl/certs"}]}`,
+			},
+			verify: func(t *testing.T, merged *v1.ConfigMap) {
+				assert.Equal(t, "ome/serving-sidecar:v1", newServingSidecarInjector(merged).Image)
+				injector := newModelInitInjector(merged)
+				require.NotNil(t, injector.ExtraVolumeMounts)
+				assert.Equal(t, []v1.VolumeMount{{Name: "ca-bundle", MountPath: "/etc/ssl/certs"}}, *injector.ExtraVolumeMounts)
+			},
+		},
+		{
+			name: "ignores keys the pod mutator doesn't use",
+			overrides: map[string]string{
+				"ingress": `{"ingressDomain": "tenant.io"}`,
+			},
+			verify: func(t *testing.T, merged *v1.ConfigMap) {
+				assert.Equal(t, clusterConfig.Data["ingress"], merged.Data["ingress"])
+			},
+		},
+		{
+			name: "invalid json",
+			overrides: map[string]string{
+				modelInitConfigMapKeyName: `{"image": `,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PodMutatorNamespaceConfigMapName, Namespace: "tenant"},
+				Data:       tt.overrides,
+			}
+			merged, err := mergeNamespaceConfig(clusterConfig, overrides)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.verify(t, merged)
+			// The cluster-wide ConfigMap is shared between requests and must not be modified
+			assert.Contains(t, clusterConfig.Data[modelInitConfigMapKeyName], "ome/model-init:v1")
+		})
+	}
+}
+
+func TestMutator_namespaceConfig(t *testing.T) {
+	clusterConfig := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data:       map[string]string{servingSidecarConfigMapKeyName: `{"image": "ome/serving-sidecar:v1"}`},
+	}
+	mutator := &Mutator{Clientset: fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PodMutatorNamespaceConfigMapName, Namespace: "tenant"},
+		Data:       map[string]string{servingSidecarConfigMapKeyName: `{"image": "tenant/serving-sidecar:v2"}`},
+	})}
+
+	merged, err := mutator.namespaceConfig(context.TODO(), clusterConfig, "tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant/serving-sidecar:v2", newServingSidecarInjector(merged).Image)
+
+	merged, err = mutator.namespaceConfig(context.TODO(), clusterConfig, "other")
+	require.NoError(t, err)
+	assert.Same(t, clusterConfig, merged)
+
+	// The namespace ConfigMaps are read from the cache when set
+	mutator.NamespaceConfigMaps = controllerconfig.NewConfigMapCache(mutator.Clientset, "", constants.PodMutatorNamespaceConfigMapName)
+	merged, err = mutator.namespaceConfig(context.TODO(), clusterConfig, "tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant/serving-sidecar:v2", newServingSidecarInjector(merged).Image)
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/sgl-project/ome/pkg/constants"
 )
@@ -27,6 +29,11 @@ type ServingSidecarInjector struct {
 	AuthType             string `json:"authType" validate:"required"`
 	Region               string `json:"region"`
 	RealmDomainComponent string `json:"realmDomainComponent"`
+	// EnableWarmup has the sidecar send warmup prompts to the engine before reporting ready, so that the pod only
+	// receives traffic once the engine is warm
+	EnableWarmup bool `json:"enableWarmup"`
+	// ReadinessPort is the port of the readiness endpoint of the sidecar, probed by the kubelet
+	ReadinessPort int32 `json:"readinessPort"`
 }
 
 // newServingSidecarInjector initializes a ServingSidecarInjector from a ConfigMap.
@@ -70,7 +77,7 @@ func (ss *ServingSidecarInjector) injectServingSidecar(pod *v1.Pod) error {
 	}
 
 	servingSidecarMounts := ss.getVolumeMounts(pod, fineTunedWeightFTStrategy)
-	initEnvs := ss.getServingSidecarEnvs(fineTunedWeightFTStrategy)
+	initEnvs := ss.getServingSidecarEnvs(fineTunedWeightFTStrategy, getMainContainerPort(pod))
 
 	securityContext, err := ss.getMainContainerSecurityContext(pod)
 	if err != nil {
@@ -145,7 +152,7 @@ func (ss *ServingSidecarInjector) getVolumeMounts(pod *v1.Pod, fineTunedWeightFT
 	return servingSidecarMounts
 }
 
-func (ss *ServingSidecarInjector) getServingSidecarEnvs(fineTunedWeightFTStrategy string) []v1.EnvVar {
+func (ss *ServingSidecarInjector) getServingSidecarEnvs(fineTunedWeightFTStrategy string, enginePort string) []v1.EnvVar {
 	envVars := []v1.EnvVar{
 		{Name: constants.AgentAuthTypeEnvVarKey, Value: ss.AuthType},
 		{Name: constants.AgentCompartmentIDEnvVarKey, Value: ss.CompartmentId},
@@ -153,11 +160,32 @@ func (ss *ServingSidecarInjector) getServingSidecarEnvs(fineTunedWeightFTStrateg
 		{Name: constants.AgentFineTunedWeightInfoFilePath, Value: constants.AgentFineTunedWeightInfoFilePath},
 		{Name: constants.AgentUnzippedFineTunedWeightDirectory, Value: filepath.Join(constants.ModelDefaultMountPathPrefix, fineTunedWeightFTStrategy)},
 		{Name: constants.AgentZippedFineTunedWeightDirectory, Value: constants.FineTunedWeightDownloadMountPath},
+		{Name: constants.AgentReadinessPortEnvVarKey, Value: strconv.Itoa(int(ss.readinessPort()))},
+		{Name: constants.AgentWarmupEnabledEnvVarKey, Value: strconv.FormatBool(ss.EnableWarmup)},
+		{Name: constants.AgentWarmupEngineURLEnvVarKey, Value: "http://127.0.0.1:" + enginePort},
 	}
 
 	return envVars
 }
 
+// readinessPort returns the port of the readiness endpoint of the sidecar
+func (ss *ServingSidecarInjector) readinessPort() int32 {
+	if ss.ReadinessPort > 0 {
+		return ss.ReadinessPort
+	}
+	return constants.ServingSidecarReadinessPort
+}
+
+// getMainContainerPort returns the first port of the main container, the port of the engine, 8080 if it has none
+func getMainContainerPort(pod *v1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == constants.MainContainerName && len(container.Ports) > 0 {
+			return strconv.Itoa(int(container.Ports[0].ContainerPort))
+		}
+	}
+	return constants.InferenceServiceDefaultHttpPort
+}
+
 // createServingSidecarContainer constructs the serving sidecar configuration.
 func (ss *ServingSidecarInjector) createServingSidecarContainer(envs []v1.EnvVar, mounts []v1.VolumeMount, securityContext *v1.SecurityContext) *v1.Container {
 	return &v1.Container{
@@ -167,6 +195,14 @@ func (ss *ServingSidecarInjector) createServingSidecarContainer(envs []v1.EnvVar
 		Env:                      envs,
 		VolumeMounts:             mounts,
 		Args:                     []string{"serving-agent", "--config", "/ome-agent.yaml", "--debug"},
+		// The sidecar reports ready once the fine-tuned weights are in place and the engine is warmed up
+		ReadinessProbe: &v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				HTTPGet: &v1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt32(ss.readinessPort())},
+			},
+			PeriodSeconds:    5,
+			FailureThreshold: 3,
+		},
 		Resources: v1.ResourceRequirements{
 			Limits: map[v1.ResourceName]resource.Quantity{
 				v1.ResourceCPU:    resource.MustParse(ss.CpuLimit),
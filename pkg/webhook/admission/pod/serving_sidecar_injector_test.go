@@ -0,0 +1,52 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestInjectServingSidecarReadiness(t *testing.T) {
+	ss := &ServingSidecarInjector{
+		Image:         "ome-agent:test",
+		MemoryRequest: "1Gi",
+		MemoryLimit:   "1Gi",
+		CpuRequest:    "1",
+		CpuLimit:      "1",
+		CompartmentId: "compartment",
+		AuthType:      "InstancePrincipal",
+		EnableWarmup:  true,
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			constants.ServingSidecarInjectionKey:   "true",
+			constants.FineTunedWeightFTStrategyKey: "lora",
+		}},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name:  constants.MainContainerName,
+			Ports: []v1.ContainerPort{{ContainerPort: 30000}},
+		}}},
+	}
+
+	require.NoError(t, ss.InjectServingSidecar(pod))
+	require.Len(t, pod.Spec.Containers, 2)
+	sidecar := pod.Spec.Containers[1]
+	env := map[string]string{}
+	for _, envVar := range sidecar.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "true", env[constants.AgentWarmupEnabledEnvVarKey])
+	assert.Equal(t, "http://127.0.0.1:30000", env[constants.AgentWarmupEngineURLEnvVarKey])
+	assert.Equal(t, "8089", env[constants.AgentReadinessPortEnvVarKey])
+
+	// The pod is ready once the sidecar is
+	require.NotNil(t, sidecar.ReadinessProbe)
+	assert.Equal(t, "/ready", sidecar.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, intstr.FromInt32(constants.ServingSidecarReadinessPort), sidecar.ReadinessProbe.HTTPGet.Port)
+}
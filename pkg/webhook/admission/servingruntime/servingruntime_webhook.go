@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"slices"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -13,14 +15,18 @@ import (
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/modelver"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+	"github.com/sgl-project/ome/pkg/webhook/admission/audit"
+	"github.com/sgl-project/ome/pkg/webhook/admission/deprecation"
 )
 
 var log = logf.Log.WithName(constants.ServingRuntimeValidatorWebhookName)
 
 const (
 	InvalidPriorityError                        = "same priority assigned for the model format %s"
-	InvalidPriorityServingRuntimeError          = "%s in the servingruntimes %s and %s in namespace %s"
-	InvalidPriorityClusterServingRuntimeError   = "%s in the clusterservingruntimes %s and %s"
+	InvalidPriorityServingRuntimeError          = "%s in the servingruntimes %s and %s in namespace %s, assign different priorities or make their model formats or size ranges disjoint"
+	InvalidPriorityClusterServingRuntimeError   = "%s in the clusterservingruntimes %s and %s, assign different priorities or make their model formats or size ranges disjoint"
 	PriorityIsNotSameError                      = "different priorities assigned for the model format %s"
 	PriorityIsNotSameServingRuntimeError        = "%s under the servingruntime %s"
 	PriorityIsNotSameClusterServingRuntimeError = "%s under the clusterservingruntime %s"
@@ -29,6 +35,8 @@ const (
 	MultiNodeConfigurationError                 = "for MultiNode deployment, both leader and worker must be defined and worker.size must be greater than 0"
 	RawDeploymentConfigurationError             = "for RawDeployment, leader and worker must not be defined"
 	UnknownAcceleratorClassError                = "unknown accelerator classes referenced in AcceleratorRequirements: %v"
+	InvalidSupportedArchitecturesError          = "invalid supportedArchitectures: %s"
+	InvalidEngineVersionError                   = "invalid engineVersion: %s"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-ome-io-v1beta1-clusterservingruntime,mutating=false,failurePolicy=fail,groups=ome.io,resources=clusterservingruntimes,versions=v1beta1,name=clusterservingruntime.ome-webhook-server.validator
@@ -51,6 +59,7 @@ func (sr *ServingRuntimeValidator) Handle(ctx context.Context, req admission.Req
 		log.Error(err, "Failed to decode serving runtime", "name", servingRuntime.Name, "namespace", servingRuntime.Namespace)
 		return admission.Errored(http.StatusBadRequest, err)
 	}
+	warnings := deprecationWarnings(&servingRuntime.Spec)
 
 	ExistingRuntimes := &v1beta1.ServingRuntimeList{}
 	if err := sr.Client.List(context.TODO(), ExistingRuntimes, client.InNamespace(servingRuntime.Namespace)); err != nil {
@@ -60,22 +69,37 @@ func (sr *ServingRuntimeValidator) Handle(ctx context.Context, req admission.Req
 
 	// Only validate for priority if the new serving runtime is not disabled
 	if servingRuntime.Spec.IsDisabled() {
-		return admission.Allowed("")
+		return admission.Allowed("").WithWarnings(warnings...)
 	}
 
 	// Validate the configuration based on engineConfig and decoderConfig
 	if err := validateServingRuntimeConfiguration(&servingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "configuration")
 		return admission.Denied(fmt.Sprintf(InvalidConfigurationError, err.Error()))
 	}
 
+	// Validate the supported architecture patterns of the model formats
+	if err := validateSupportedArchitectures(&servingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "supported_architectures")
+		return admission.Denied(fmt.Sprintf(InvalidSupportedArchitecturesError, err.Error()))
+	}
+
+	// Validate the engine version the runtime version constraints are checked against
+	if err := validateEngineVersion(&servingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "engine_version")
+		return admission.Denied(fmt.Sprintf(InvalidEngineVersionError, err.Error()))
+	}
+
 	// Validate that all referenced accelerator classes exist
 	if err := validateAcceleratorClasses(ctx, sr.Client, &servingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "accelerator_classes")
 		log.Info("Accelerator class validation failed", "name", servingRuntime.Name, "namespace", servingRuntime.Namespace, "error", err)
 		return admission.Denied(err.Error())
 	}
 
 	for i := range ExistingRuntimes.Items {
 		if err := validateModelFormatPrioritySame(&servingRuntime.Spec); err != nil {
+			audit.RecordRule(ctx, "format_priority")
 			return admission.Denied(fmt.Sprintf(PriorityIsNotSameServingRuntimeError, err.Error(), servingRuntime.Name))
 		}
 
@@ -84,10 +108,11 @@ func (sr *ServingRuntimeValidator) Handle(ctx context.Context, req admission.Req
 		}
 
 		if err := validateServingRuntimePriority(&servingRuntime.Spec, &ExistingRuntimes.Items[i].Spec, servingRuntime.Name, ExistingRuntimes.Items[i].Name); err != nil {
+			audit.RecordRule(ctx, "priority_conflict")
 			return admission.Denied(fmt.Sprintf(InvalidPriorityServingRuntimeError, err.Error(), ExistingRuntimes.Items[i].Name, servingRuntime.Name, servingRuntime.Namespace))
 		}
 	}
-	return admission.Allowed("")
+	return admission.Allowed("").WithWarnings(warnings...)
 }
 
 // Handle validates the incoming request
@@ -97,6 +122,7 @@ func (csr *ClusterServingRuntimeValidator) Handle(ctx context.Context, req admis
 		log.Error(err, "Failed to decode cluster serving runtime", "name", clusterServingRuntime.Name)
 		return admission.Errored(http.StatusBadRequest, err)
 	}
+	warnings := deprecationWarnings(&clusterServingRuntime.Spec)
 
 	ExistingRuntimes := &v1beta1.ClusterServingRuntimeList{}
 	if err := csr.Client.List(context.TODO(), ExistingRuntimes); err != nil {
@@ -106,22 +132,37 @@ func (csr *ClusterServingRuntimeValidator) Handle(ctx context.Context, req admis
 
 	// Only validate for priority if the new cluster serving runtime is not disabled
 	if clusterServingRuntime.Spec.IsDisabled() {
-		return admission.Allowed("")
+		return admission.Allowed("").WithWarnings(warnings...)
 	}
 
 	// Validate the configuration based on engineConfig and decoderConfig
 	if err := validateServingRuntimeConfiguration(&clusterServingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "configuration")
 		return admission.Denied(fmt.Sprintf(InvalidConfigurationError, err.Error()))
 	}
 
+	// Validate the supported architecture patterns of the model formats
+	if err := validateSupportedArchitectures(&clusterServingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "supported_architectures")
+		return admission.Denied(fmt.Sprintf(InvalidSupportedArchitecturesError, err.Error()))
+	}
+
+	// Validate the engine version the runtime version constraints are checked against
+	if err := validateEngineVersion(&clusterServingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "engine_version")
+		return admission.Denied(fmt.Sprintf(InvalidEngineVersionError, err.Error()))
+	}
+
 	// Validate that all referenced accelerator classes exist
 	if err := validateAcceleratorClasses(ctx, csr.Client, &clusterServingRuntime.Spec); err != nil {
+		audit.RecordRule(ctx, "accelerator_classes")
 		log.Info("Accelerator class validation failed", "name", clusterServingRuntime.Name, "error", err)
 		return admission.Denied(err.Error())
 	}
 
 	for i := range ExistingRuntimes.Items {
 		if err := validateModelFormatPrioritySame(&clusterServingRuntime.Spec); err != nil {
+			audit.RecordRule(ctx, "format_priority")
 			return admission.Denied(fmt.Sprintf(PriorityIsNotSameClusterServingRuntimeError, err.Error(), clusterServingRuntime.Name))
 		}
 
@@ -130,46 +171,148 @@ func (csr *ClusterServingRuntimeValidator) Handle(ctx context.Context, req admis
 		}
 
 		if err := validateServingRuntimePriority(&clusterServingRuntime.Spec, &ExistingRuntimes.Items[i].Spec, clusterServingRuntime.Name, ExistingRuntimes.Items[i].Name); err != nil {
+			audit.RecordRule(ctx, "priority_conflict")
 			return admission.Denied(fmt.Sprintf(InvalidPriorityClusterServingRuntimeError, err.Error(), ExistingRuntimes.Items[i].Name, clusterServingRuntime.Name))
 		}
 	}
-	return admission.Allowed("")
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// deprecationWarnings returns a warning for every deprecated field the serving runtime uses
+func deprecationWarnings(spec *v1beta1.ServingRuntimeSpec) []string {
+	var warnings []string
+	for i, format := range spec.SupportedModelFormats {
+		if format.ModelType != nil {
+			field := fmt.Sprintf("spec.supportedModelFormats[%d]", i)
+			warnings = append(warnings, deprecation.FieldWarning(field+".modelType", field+".supportedArchitectures"))
+		}
+	}
+	return warnings
+}
+
+// RuntimeConflictError is returned when two runtimes auto select overlapping models with the same priority,
+// which would leave the runtime selection for those models ambiguous
+type RuntimeConflictError struct {
+	ModelFormat string
+	Priority    int32
+	// Overlaps describes the architectures and model sizes both runtimes claim
+	Overlaps []string
+}
+
+func (e *RuntimeConflictError) Error() string {
+	msg := fmt.Sprintf(InvalidPriorityError, e.ModelFormat) + fmt.Sprintf(" (priority %d", e.Priority)
+	if len(e.Overlaps) > 0 {
+		msg += ", overlapping on " + strings.Join(e.Overlaps, " and ")
+	}
+	return msg + ")"
 }
 
+// areSupportedModelFormatsEqual reports whether two formats claim the same model format name, version,
+// quantization, framework and serialization format. Architectures are compared by architecturesOverlap.
 func areSupportedModelFormatsEqual(m1 v1beta1.SupportedModelFormat, m2 v1beta1.SupportedModelFormat) bool {
 	if strings.EqualFold(m1.Name, m2.Name) &&
 		((m1.Version == nil && m2.Version == nil) || (m1.Version != nil && m2.Version != nil && *m1.Version == *m2.Version)) &&
 		((m1.Quantization == nil && m2.Quantization == nil) || (m1.Quantization != nil && m2.Quantization != nil && *m1.Quantization == *m2.Quantization)) &&
 		((m1.ModelFramework == nil && m2.ModelFramework == nil) || (m1.ModelFramework != nil && m2.ModelFramework != nil && *m1.ModelFramework == *m2.ModelFramework)) &&
-		((m1.ModelFormat == nil && m2.ModelFormat == nil) || (m1.ModelFormat != nil && m2.ModelFormat != nil && *m1.ModelFormat == *m2.ModelFormat)) &&
-		((m1.ModelArchitecture == nil && m2.ModelArchitecture == nil) || (m1.ModelArchitecture != nil && m2.ModelArchitecture != nil && *m1.ModelArchitecture == *m2.ModelArchitecture)) {
+		((m1.ModelFormat == nil && m2.ModelFormat == nil) || (m1.ModelFormat != nil && m2.ModelFormat != nil && *m1.ModelFormat == *m2.ModelFormat)) {
 		return true
 	}
 	return false
 }
 
-func areModelSizeRangesEqual(range1 *v1beta1.ModelSizeRangeSpec, range2 *v1beta1.ModelSizeRangeSpec) bool {
-	if range1 == nil || range2 == nil {
-		return range1 == range2
+// architecturesOverlap reports whether a model architecture can match both formats, following the rules
+// of the runtime selector: SupportedArchitectures take precedence over ModelArchitecture, and a format
+// without either only matches models without an architecture. It also describes the overlap for errors.
+func architecturesOverlap(m1 v1beta1.SupportedModelFormat, m2 v1beta1.SupportedModelFormat) (string, bool) {
+	switch {
+	case len(m1.SupportedArchitectures) > 0 && len(m2.SupportedArchitectures) > 0:
+		return supportedArchitecturesOverlap(m1.SupportedArchitectures, m2.SupportedArchitectures)
+	case len(m1.SupportedArchitectures) > 0:
+		return architectureMatches(m1.SupportedArchitectures, m2.ModelArchitecture)
+	case len(m2.SupportedArchitectures) > 0:
+		return architectureMatches(m2.SupportedArchitectures, m1.ModelArchitecture)
+	case m1.ModelArchitecture == nil && m2.ModelArchitecture == nil:
+		return "", true
+	case m1.ModelArchitecture != nil && m2.ModelArchitecture != nil && *m1.ModelArchitecture == *m2.ModelArchitecture:
+		return "architecture " + *m1.ModelArchitecture, true
+	}
+	return "", false
+}
+
+// architectureMatches reports whether an architecture matches the SupportedArchitectures of the other format
+func architectureMatches(supported []string, architecture *string) (string, bool) {
+	if architecture == nil || !runtimeselector.MatchesArchitecture(supported, *architecture) {
+		return "", false
 	}
+	return "architecture " + *architecture, true
+}
 
-	// Compare Min values
-	if (range1.Min == nil) != (range2.Min == nil) {
-		return false
+// supportedArchitecturesOverlap reports whether two SupportedArchitectures lists can match the same architecture.
+// Patterns can't be intersected in general, so the lists overlap when they share an entry or when an
+// architecture name of one list matches a pattern of the other.
+func supportedArchitecturesOverlap(s1 []string, s2 []string) (string, bool) {
+	for _, entry := range s1 {
+		if slices.Contains(s2, entry) {
+			return "architectures matching " + entry, true
+		}
+	}
+	for _, lists := range [][2][]string{{s1, s2}, {s2, s1}} {
+		for _, entry := range lists[0] {
+			if isArchitectureName(entry) && runtimeselector.MatchesArchitecture(lists[1], entry) {
+				return "architecture " + entry, true
+			}
+		}
 	}
-	if range1.Min != nil && range2.Min != nil && *range1.Min != *range2.Min {
-		return false
+	return "", false
+}
+
+// isArchitectureName reports whether a SupportedArchitectures entry is a plain architecture name rather than a pattern
+func isArchitectureName(entry string) bool {
+	return !strings.HasPrefix(entry, runtimeselector.ArchitectureFamilyPrefix) && !strings.ContainsAny(entry, `*?[\`)
+}
+
+// modelSize is a bound of a model size range along with the size as written in the spec
+type modelSize struct {
+	value float64
+	text  string
+}
+
+// modelSizeRangesOverlap reports whether a model size falls into both ranges. A nil range, a missing
+// minimum or a missing maximum leave the range unbounded on that side. It also describes the overlap for errors.
+func modelSizeRangesOverlap(range1 *v1beta1.ModelSizeRangeSpec, range2 *v1beta1.ModelSizeRangeSpec) (string, bool) {
+	if range1 == nil && range2 == nil {
+		return "", true
 	}
 
-	// Compare Max values
-	if (range1.Max == nil) != (range2.Max == nil) {
-		return false
+	min1, max1 := modelSizeBounds(range1)
+	min2, max2 := modelSizeBounds(range2)
+	lower, upper := min1, max1
+	if min2.value > lower.value {
+		lower = min2
 	}
-	if range1.Max != nil && range2.Max != nil && *range1.Max != *range2.Max {
-		return false
+	if max2.value < upper.value {
+		upper = max2
 	}
+	if lower.value > upper.value {
+		return "", false
+	}
+	return fmt.Sprintf("model sizes %s to %s", lower.text, upper.text), true
+}
 
-	return true
+// modelSizeBounds returns the lower and upper bounds of a model size range
+func modelSizeBounds(sizeRange *v1beta1.ModelSizeRangeSpec) (modelSize, modelSize) {
+	lower := modelSize{value: 0, text: "0"}
+	upper := modelSize{value: math.Inf(1), text: "unbounded"}
+	if sizeRange == nil {
+		return lower, upper
+	}
+	if sizeRange.Min != nil {
+		lower = modelSize{value: runtimeselector.ParseModelSize(*sizeRange.Min), text: *sizeRange.Min}
+	}
+	if sizeRange.Max != nil {
+		upper = modelSize{value: runtimeselector.ParseModelSize(*sizeRange.Max), text: *sizeRange.Max}
+	}
+	return lower, upper
 }
 
 func validateServingRuntimeAnnotations(servingRuntime *v1beta1.ServingRuntimeSpec) error {
@@ -215,14 +358,31 @@ func validateServingRuntimePriority(newSpec *v1beta1.ServingRuntimeSpec, existin
 	if isTheProtocolSame {
 		for _, existingModelFormat := range existingSpec.SupportedModelFormats {
 			for _, newModelFormat := range newSpec.SupportedModelFormats {
-				// Only validate priority if auto select is true and model formats and size ranges are equal
-				if existingModelFormat.IsAutoSelectEnabled() && newModelFormat.IsAutoSelectEnabled() &&
-					areSupportedModelFormatsEqual(existingModelFormat, newModelFormat) &&
-					areModelSizeRangesEqual(existingSpec.ModelSizeRange, newSpec.ModelSizeRange) {
-					if existingModelFormat.Priority != nil && newModelFormat.Priority != nil && *existingModelFormat.Priority == *newModelFormat.Priority {
-						return fmt.Errorf(InvalidPriorityError, newModelFormat.Name)
+				// Only validate priority if auto select is true and both priorities are set
+				if !existingModelFormat.IsAutoSelectEnabled() || !newModelFormat.IsAutoSelectEnabled() ||
+					existingModelFormat.Priority == nil || newModelFormat.Priority == nil ||
+					*existingModelFormat.Priority != *newModelFormat.Priority {
+					continue
+				}
+				// The priority is ambiguous if a model can match both formats and fall into both size ranges
+				if !areSupportedModelFormatsEqual(existingModelFormat, newModelFormat) {
+					continue
+				}
+				architectureOverlap, ok := architecturesOverlap(existingModelFormat, newModelFormat)
+				if !ok {
+					continue
+				}
+				sizeOverlap, ok := modelSizeRangesOverlap(existingSpec.ModelSizeRange, newSpec.ModelSizeRange)
+				if !ok {
+					continue
+				}
+				conflict := &RuntimeConflictError{ModelFormat: newModelFormat.Name, Priority: *newModelFormat.Priority}
+				for _, overlap := range []string{architectureOverlap, sizeOverlap} {
+					if overlap != "" {
+						conflict.Overlaps = append(conflict.Overlaps, overlap)
 					}
 				}
+				return conflict
 			}
 		}
 	}
@@ -305,6 +465,33 @@ func validateServingRuntimeConfiguration(spec *v1beta1.ServingRuntimeSpec) error
 	return nil
 }
 
+// validateSupportedArchitectures checks that the SupportedArchitectures entries of every supported
+// model format are valid architecture names, glob patterns or family patterns
+func validateSupportedArchitectures(spec *v1beta1.ServingRuntimeSpec) error {
+	for _, format := range spec.SupportedModelFormats {
+		for _, entry := range format.SupportedArchitectures {
+			if err := runtimeselector.ValidateArchitecturePattern(entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateEngineVersion checks that the engine version of the runtime has a name and a parsable version
+func validateEngineVersion(spec *v1beta1.ServingRuntimeSpec) error {
+	if spec.EngineVersion == nil {
+		return nil
+	}
+	if spec.EngineVersion.Name == "" {
+		return errors.New("engine name must not be empty")
+	}
+	if _, err := modelver.Parse(spec.EngineVersion.Version); err != nil {
+		return fmt.Errorf("version %q of engine %s: %w", spec.EngineVersion.Version, spec.EngineVersion.Name, err)
+	}
+	return nil
+}
+
 func contains[T comparable](slice []T, element T) bool {
 	for _, item := range slice {
 		if item == element {
@@ -315,6 +502,7 @@ func contains[T comparable](slice []T, element T) bool {
 }
 
 // validateAcceleratorClasses checks that all accelerator classes referenced in the runtime spec exist.
+// MIG sub-classes referenced as "<class>/<profile>" must name a profile defined by the class.
 // This is a strict validation to ensure that:
 // 1. Typos in accelerator class names are caught early
 // 2. Runtime scheduling won't fail due to missing accelerator definitions
@@ -330,17 +518,19 @@ func validateAcceleratorClasses(ctx context.Context, c client.Client, spec *v1be
 		return fmt.Errorf("failed to list accelerator classes: %w", err)
 	}
 
-	// Build a set for O(1) lookup
-	existingClasses := make(map[string]bool, len(allClasses.Items))
-	for _, ac := range allClasses.Items {
-		existingClasses[ac.Name] = true
+	// Build a map for O(1) lookup
+	existingClasses := make(map[string]*v1beta1.AcceleratorClass, len(allClasses.Items))
+	for i := range allClasses.Items {
+		existingClasses[allClasses.Items[i].Name] = &allClasses.Items[i]
 	}
 
 	// Collect all missing classes to report them together
 	var missingClasses []string
-	for _, className := range spec.AcceleratorRequirements.AcceleratorClasses {
-		if !existingClasses[className] {
-			missingClasses = append(missingClasses, className)
+	for _, name := range spec.AcceleratorRequirements.AcceleratorClasses {
+		className, profile := v1beta1.ParseAcceleratorClassName(name)
+		ac, ok := existingClasses[className]
+		if !ok || (profile != "" && ac.Spec.GetMIGProfile(profile) == nil) {
+			missingClasses = append(missingClasses, name)
 		}
 	}
 
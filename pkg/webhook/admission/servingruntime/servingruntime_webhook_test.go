@@ -527,7 +527,7 @@ func TestValidateServingRuntimePriority(t *testing.T) {
 					},
 				},
 			},
-			expected: gomega.Equal(fmt.Errorf(InvalidPriorityError, "vllm")),
+			expected: gomega.Equal(&RuntimeConflictError{ModelFormat: "vllm", Priority: 1}),
 		},
 		"When model version is nil in both serving runtime and priority is not same then it should return nil": {
 			newServingRuntime: &v1beta1.ServingRuntime{
@@ -815,7 +815,7 @@ func TestValidateServingRuntimePriority(t *testing.T) {
 					},
 				},
 			},
-			expected: gomega.Equal(fmt.Errorf(InvalidPriorityError, "vllm")),
+			expected: gomega.Equal(&RuntimeConflictError{ModelFormat: "vllm", Priority: 1, Overlaps: []string{"architecture CohereForCausalLM"}}),
 		},
 		"When two serving runtime has the same supported model format but different architecture then it should return nil": {
 			newServingRuntime: &v1beta1.ServingRuntime{
@@ -1119,7 +1119,7 @@ func TestValidateServingRuntimePriority(t *testing.T) {
 					},
 				},
 			},
-			expected: gomega.Equal(fmt.Errorf(InvalidPriorityError, "vllm")),
+			expected: gomega.Equal(&RuntimeConflictError{ModelFormat: "vllm", Priority: 1}),
 		},
 		"When model version is different but priority is same then it should return nil": {
 			newServingRuntime: &v1beta1.ServingRuntime{
@@ -1443,6 +1443,75 @@ func TestValidateServingRuntimeAnnotations(t *testing.T) {
 	}
 }
 
+func TestValidateSupportedArchitectures(t *testing.T) {
+	scenarios := map[string]struct {
+		architectures []string
+		matcher       gomega.OmegaMatcher
+	}{
+		"When no supported architectures are set then it should return nil": {
+			architectures: nil,
+			matcher:       gomega.BeNil(),
+		},
+		"When names, glob and family patterns are set then it should return nil": {
+			architectures: []string{"LlamaForCausalLM", "Qwen3*ForCausalLM", "family:qwen*"},
+			matcher:       gomega.BeNil(),
+		},
+		"When a pattern is malformed then it should return an error": {
+			architectures: []string{"Qwen[2-3ForCausalLM"},
+			matcher:       gomega.HaveOccurred(),
+		},
+		"When a family pattern is empty then it should return an error": {
+			architectures: []string{"family:"},
+			matcher:       gomega.HaveOccurred(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			spec := v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{SupportedArchitectures: scenario.architectures},
+				},
+			}
+			err := validateSupportedArchitectures(&spec)
+			g.Expect(err).To(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateEngineVersion(t *testing.T) {
+	scenarios := map[string]struct {
+		engineVersion *v1beta1.RuntimeEngineVersion
+		matcher       gomega.OmegaMatcher
+	}{
+		"When no engine version is set then it should return nil": {
+			engineVersion: nil,
+			matcher:       gomega.BeNil(),
+		},
+		"When the engine version is valid then it should return nil": {
+			engineVersion: &v1beta1.RuntimeEngineVersion{Name: "sglang", Version: "0.4.6"},
+			matcher:       gomega.BeNil(),
+		},
+		"When the engine name is empty then it should return an error": {
+			engineVersion: &v1beta1.RuntimeEngineVersion{Version: "0.4.6"},
+			matcher:       gomega.HaveOccurred(),
+		},
+		"When the version is malformed then it should return an error": {
+			engineVersion: &v1beta1.RuntimeEngineVersion{Name: "sglang", Version: "latest"},
+			matcher:       gomega.HaveOccurred(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			err := validateEngineVersion(&v1beta1.ServingRuntimeSpec{EngineVersion: scenario.engineVersion})
+			g.Expect(err).To(scenario.matcher)
+		})
+	}
+}
+
 func TestValidateServingRuntimeConfiguration(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1716,92 +1785,139 @@ func TestValidateModelFormatPrioritySame(t *testing.T) {
 	}
 }
 
-func TestAreModelSizeRangesEqual(t *testing.T) {
+func TestValidateServingRuntimePriorityConflicts(t *testing.T) {
+	newSpec := func(format v1beta1.SupportedModelFormat, sizeRange *v1beta1.ModelSizeRangeSpec) *v1beta1.ServingRuntimeSpec {
+		format.Name = "safetensors"
+		format.AutoSelect = proto.Bool(true)
+		format.Priority = proto.Int32(2)
+		return &v1beta1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1beta1.SupportedModelFormat{format},
+			ProtocolVersions:      []constants.InferenceServiceProtocol{constants.OpenAIProtocol},
+			ModelSizeRange:        sizeRange,
+		}
+	}
+	llama := v1beta1.SupportedModelFormat{ModelArchitecture: proto.String("LlamaForCausalLM")}
+
+	scenarios := map[string]struct {
+		newSpec      *v1beta1.ServingRuntimeSpec
+		existingSpec *v1beta1.ServingRuntimeSpec
+		expected     gomega.OmegaMatcher
+	}{
+		"OverlappingSizeRanges": {
+			newSpec:      newSpec(llama, &v1beta1.ModelSizeRangeSpec{Min: proto.String("1B"), Max: proto.String("70B")}),
+			existingSpec: newSpec(llama, &v1beta1.ModelSizeRangeSpec{Min: proto.String("30B"), Max: proto.String("200B")}),
+			expected: gomega.Equal(&RuntimeConflictError{
+				ModelFormat: "safetensors",
+				Priority:    2,
+				Overlaps:    []string{"architecture LlamaForCausalLM", "model sizes 30B to 70B"},
+			}),
+		},
+		"UnboundedSizeRange": {
+			newSpec:      newSpec(llama, nil),
+			existingSpec: newSpec(llama, &v1beta1.ModelSizeRangeSpec{Min: proto.String("30B"), Max: proto.String("200B")}),
+			expected:     gomega.MatchError(gomega.ContainSubstring("model sizes 30B to 200B")),
+		},
+		"AdjacentSizeRanges": {
+			newSpec:      newSpec(llama, &v1beta1.ModelSizeRangeSpec{Max: proto.String("29B")}),
+			existingSpec: newSpec(llama, &v1beta1.ModelSizeRangeSpec{Min: proto.String("30B")}),
+			expected:     gomega.BeNil(),
+		},
+		"ArchitectureMatchesSupportedArchitectures": {
+			newSpec:      newSpec(llama, nil),
+			existingSpec: newSpec(v1beta1.SupportedModelFormat{SupportedArchitectures: []string{"Llama*"}}, nil),
+			expected:     gomega.MatchError(gomega.ContainSubstring("overlapping on architecture LlamaForCausalLM")),
+		},
+		"ArchitectureDoesNotMatchSupportedArchitectures": {
+			newSpec:      newSpec(llama, nil),
+			existingSpec: newSpec(v1beta1.SupportedModelFormat{SupportedArchitectures: []string{"Qwen*"}}, nil),
+			expected:     gomega.BeNil(),
+		},
+		"SharedSupportedArchitecturesPattern": {
+			newSpec:      newSpec(v1beta1.SupportedModelFormat{SupportedArchitectures: []string{"family:qwen*", "Llama*"}}, nil),
+			existingSpec: newSpec(v1beta1.SupportedModelFormat{SupportedArchitectures: []string{"Llama*"}}, nil),
+			expected:     gomega.MatchError(gomega.ContainSubstring("overlapping on architectures matching Llama*")),
+		},
+		"ArchitectureNameMatchesOtherPattern": {
+			newSpec:      newSpec(v1beta1.SupportedModelFormat{SupportedArchitectures: []string{"MistralForCausalLM"}}, nil),
+			existingSpec: newSpec(v1beta1.SupportedModelFormat{SupportedArchitectures: []string{"Mistral*"}}, nil),
+			expected:     gomega.MatchError(gomega.ContainSubstring("overlapping on architecture MistralForCausalLM")),
+		},
+		"FormatWithoutArchitecture": {
+			newSpec:      newSpec(v1beta1.SupportedModelFormat{}, nil),
+			existingSpec: newSpec(v1beta1.SupportedModelFormat{SupportedArchitectures: []string{"Llama*"}}, nil),
+			expected:     gomega.BeNil(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			err := validateServingRuntimePriority(scenario.newSpec, scenario.existingSpec, "new-runtime", "existing-runtime")
+			g.Expect(err).To(scenario.expected)
+		})
+	}
+}
+
+func TestModelSizeRangesOverlap(t *testing.T) {
 	testcases := []struct {
 		name     string
 		range1   *v1beta1.ModelSizeRangeSpec
 		range2   *v1beta1.ModelSizeRangeSpec
+		overlap  string
 		expected bool
 	}{
 		{
 			name:     "Both nil",
-			range1:   nil,
-			range2:   nil,
 			expected: true,
 		},
 		{
-			name:     "First nil, second not nil",
-			range1:   nil,
-			range2:   &v1beta1.ModelSizeRangeSpec{},
-			expected: false,
-		},
-		{
-			name:     "First not nil, second nil",
-			range1:   &v1beta1.ModelSizeRangeSpec{},
-			range2:   nil,
-			expected: false,
+			name:     "First nil",
+			range2:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("7B"), Max: stringPointer("70B")},
+			overlap:  "model sizes 7B to 70B",
+			expected: true,
 		},
 		{
 			name:     "Both empty",
 			range1:   &v1beta1.ModelSizeRangeSpec{},
 			range2:   &v1beta1.ModelSizeRangeSpec{},
+			overlap:  "model sizes 0 to unbounded",
 			expected: true,
 		},
 		{
-			name: "Different Min values",
-			range1: &v1beta1.ModelSizeRangeSpec{
-				Min: stringPointer("10"),
-			},
-			range2: &v1beta1.ModelSizeRangeSpec{
-				Min: stringPointer("20"),
-			},
-			expected: false,
+			name:     "Equal ranges",
+			range1:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("10B"), Max: stringPointer("100B")},
+			range2:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("10B"), Max: stringPointer("100B")},
+			overlap:  "model sizes 10B to 100B",
+			expected: true,
 		},
 		{
-			name: "Different Max values",
-			range1: &v1beta1.ModelSizeRangeSpec{
-				Max: stringPointer("100"),
-			},
-			range2: &v1beta1.ModelSizeRangeSpec{
-				Max: stringPointer("200"),
-			},
-			expected: false,
+			name:     "Nested ranges",
+			range1:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("1B"), Max: stringPointer("1T")},
+			range2:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("500M"), Max: stringPointer("8B")},
+			overlap:  "model sizes 1B to 8B",
+			expected: true,
 		},
 		{
-			name:   "First Min nil, second Min not nil",
-			range1: &v1beta1.ModelSizeRangeSpec{},
-			range2: &v1beta1.ModelSizeRangeSpec{
-				Min: stringPointer("10"),
-			},
-			expected: false,
+			name:     "Ranges sharing a bound",
+			range1:   &v1beta1.ModelSizeRangeSpec{Max: stringPointer("70B")},
+			range2:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("70B")},
+			overlap:  "model sizes 70B to 70B",
+			expected: true,
 		},
 		{
-			name:   "First Max nil, second Max not nil",
-			range1: &v1beta1.ModelSizeRangeSpec{},
-			range2: &v1beta1.ModelSizeRangeSpec{
-				Max: stringPointer("100"),
-			},
+			name:     "Disjoint ranges",
+			range1:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("100B"), Max: stringPointer("200B")},
+			range2:   &v1beta1.ModelSizeRangeSpec{Min: stringPointer("300B"), Max: stringPointer("600B")},
 			expected: false,
 		},
-		{
-			name: "Equal Min and Max values",
-			range1: &v1beta1.ModelSizeRangeSpec{
-				Min: stringPointer("10"),
-				Max: stringPointer("100"),
-			},
-			range2: &v1beta1.ModelSizeRangeSpec{
-				Min: stringPointer("10"),
-				Max: stringPointer("100"),
-			},
-			expected: true,
-		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			g := gomega.NewGomegaWithT(t)
-			result := areModelSizeRangesEqual(tc.range1, tc.range2)
-			g.Expect(result).To(gomega.Equal(tc.expected))
+			overlap, ok := modelSizeRangesOverlap(tc.range1, tc.range2)
+			g.Expect(ok).To(gomega.Equal(tc.expected))
+			g.Expect(overlap).To(gomega.Equal(tc.overlap))
 		})
 	}
 }
@@ -2103,6 +2219,11 @@ func TestValidateAcceleratorClasses(t *testing.T) {
 		},
 		&v1beta1.AcceleratorClass{
 			ObjectMeta: metav1.ObjectMeta{Name: "nvidia-a100-80gb"},
+			Spec: v1beta1.AcceleratorClassSpec{
+				MIGProfiles: []v1beta1.MIGProfile{
+					{Name: "1g.10gb", ResourceName: "nvidia.com/mig-1g.10gb", ComputeSlices: 1},
+				},
+			},
 		},
 	}
 
@@ -2183,6 +2304,25 @@ func TestValidateAcceleratorClasses(t *testing.T) {
 			expectError: true,
 			errorMsg:    "unknown accelerator classes",
 		},
+		{
+			name: "Valid MIG profile of accelerator class",
+			spec: &v1beta1.ServingRuntimeSpec{
+				AcceleratorRequirements: &v1beta1.AcceleratorRequirements{
+					AcceleratorClasses: []string{"nvidia-a100-80gb/1g.10gb"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Unknown MIG profile of accelerator class",
+			spec: &v1beta1.ServingRuntimeSpec{
+				AcceleratorRequirements: &v1beta1.AcceleratorRequirements{
+					AcceleratorClasses: []string{"nvidia-h100-80gb/1g.10gb"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "unknown accelerator classes",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -2199,3 +2339,37 @@ func TestValidateAcceleratorClasses(t *testing.T) {
 		})
 	}
 }
+
+func TestDeprecationWarnings(t *testing.T) {
+	testcases := map[string]struct {
+		spec     *v1beta1.ServingRuntimeSpec
+		expected gomega.OmegaMatcher
+	}{
+		"NoDeprecatedFields": {
+			spec: &v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{Name: "safetensors", SupportedArchitectures: []string{"family:llama"}},
+				},
+			},
+			expected: gomega.BeEmpty(),
+		},
+		"ModelType": {
+			spec: &v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{Name: "safetensors", SupportedArchitectures: []string{"family:qwen2"}},
+					{Name: "safetensors", ModelType: proto.String("llama")},
+				},
+			},
+			expected: gomega.Equal([]string{
+				"spec.supportedModelFormats[1].modelType: field is deprecated and will be removed in a future release, use spec.supportedModelFormats[1].supportedArchitectures instead",
+			}),
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(deprecationWarnings(tc.spec)).To(tc.expected)
+		})
+	}
+}
@@ -16,10 +16,12 @@ type DownloadConfig struct {
 	RepoType       string
 	Revision       string
 	CacheDir       string
+	MaxCacheSize   uint64
 	LocalDir       string
 	Filename       string
 	Endpoint       string
 	Token          string
+	TokenFile      string
 	MaxWorkers     int
 	AllowPatterns  []string
 	IgnorePatterns []string
@@ -29,6 +31,8 @@ type DownloadConfig struct {
 	ProxiesAuth    map[string]string
 	LocalFilesOnly bool
 	LogLevel       string // Optional: error, warn, info, debug, trace
+	// Metrics records the download as Prometheus metrics when set
+	Metrics *Metrics
 }
 
 // Global client for compatibility
@@ -64,6 +68,7 @@ func init() {
 	config := &Config{
 		Endpoint:               endpoint,
 		Token:                  token,
+		TokenFile:              os.Getenv("HF_TOKEN_PATH"),
 		CacheDir:               cacheDir,
 		MaxConcurrentDownloads: 4,
 		EnableDedup:            true,
@@ -85,12 +90,14 @@ func HfHubDownload(ctx context.Context, config *DownloadConfig) (string, error)
 
 	// Create client if needed
 	client := globalClient
-	if config.Token != "" || config.Endpoint != "" || config.CacheDir != "" {
+	if config.Token != "" || config.TokenFile != "" || config.Endpoint != "" || config.CacheDir != "" {
 		// Create a custom client with the provided config
 		xetConfig := &Config{
 			Endpoint:               config.Endpoint,
 			Token:                  config.Token,
+			TokenFile:              config.TokenFile,
 			CacheDir:               config.CacheDir,
+			MaxCacheSize:           config.MaxCacheSize,
 			MaxConcurrentDownloads: uint32(config.MaxWorkers),
 			EnableDedup:            true,
 		}
@@ -131,12 +138,17 @@ func HfHubDownload(ctx context.Context, config *DownloadConfig) (string, error)
 		req.Revision = "main"
 	}
 
+	start := time.Now()
+	var path string
+	var err error
 	// Use context-aware download if context is provided
 	if ctx != nil {
-		return client.DownloadFileWithContext(ctx, req)
+		path, err = client.DownloadFileWithContext(ctx, req)
+	} else {
+		path, err = client.DownloadFile(req)
 	}
-
-	return client.DownloadFile(req)
+	observeDownload(config, client, downloadKindFile, start, err)
+	return path, err
 }
 
 // SnapshotDownload provides compatibility with the existing snapshot download function
@@ -147,11 +159,13 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 
 	// Create client
 	client := globalClient
-	if config.Token != "" || config.Endpoint != "" || config.CacheDir != "" {
+	if config.Token != "" || config.TokenFile != "" || config.Endpoint != "" || config.CacheDir != "" {
 		xetConfig := &Config{
 			Endpoint:               config.Endpoint,
 			Token:                  config.Token,
+			TokenFile:              config.TokenFile,
 			CacheDir:               config.CacheDir,
+			MaxCacheSize:           config.MaxCacheSize,
 			MaxConcurrentDownloads: uint32(config.MaxWorkers),
 			EnableDedup:            true,
 		}
@@ -191,7 +205,10 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 		snapshotReq.RepoType = "models"
 	}
 
-	return client.DownloadSnapshotWithContext(ctx, snapshotReq)
+	start := time.Now()
+	path, err := client.DownloadSnapshotWithContext(ctx, snapshotReq)
+	observeDownload(config, client, downloadKindSnapshot, start, err)
+	return path, err
 }
 
 // SnapshotDownloadWithProgress downloads a model snapshot with progress callbacks.
@@ -211,7 +228,9 @@ func SnapshotDownloadWithProgress(
 	xetConfig := &Config{
 		Endpoint:               config.Endpoint,
 		Token:                  config.Token,
+		TokenFile:              config.TokenFile,
 		CacheDir:               config.CacheDir,
+		MaxCacheSize:           config.MaxCacheSize,
 		MaxConcurrentDownloads: uint32(config.MaxWorkers),
 		EnableDedup:            true,
 	}
@@ -255,7 +274,19 @@ func SnapshotDownloadWithProgress(
 		snapshotReq.RepoType = "models"
 	}
 
-	return client.DownloadSnapshotWithContext(ctx, snapshotReq)
+	start := time.Now()
+	path, err := client.DownloadSnapshotWithContext(ctx, snapshotReq)
+	observeDownload(config, client, downloadKindSnapshot, start, err)
+	return path, err
+}
+
+// observeDownload records a download in the metrics of its config
+func observeDownload(config *DownloadConfig, client *Client, kind string, start time.Time, err error) {
+	if client == globalClient {
+		config.Metrics.observeDownload(config.RepoID, kind, time.Since(start), err, nil)
+		return
+	}
+	config.Metrics.observeClientDownload(client, config.RepoID, kind, start, err)
 }
 
 // ListRepoFiles lists files in a repository (compatibility function)
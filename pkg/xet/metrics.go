@@ -0,0 +1,103 @@
+package xet
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sgl-project/ome/pkg/utils/metrics"
+)
+
+// Kinds of the downloads recorded by the metrics
+const (
+	downloadKindFile     = "file"
+	downloadKindSnapshot = "snapshot"
+)
+
+// Metrics records the downloads of the xet clients as Prometheus metrics. All its methods are no-ops on a nil
+// Metrics, so the download paths don't check whether metrics are enabled.
+type Metrics struct {
+	downloadsTotal   *prometheus.CounterVec
+	downloadDuration *prometheus.HistogramVec
+	filesTotal       *prometheus.CounterVec
+	bytesTotal       *prometheus.CounterVec
+	dedupSavedBytes  *prometheus.CounterVec
+}
+
+// NewMetrics creates the download metrics and registers them, the default registerer is used when nil. The
+// metrics already registered by another client are shared, so several clients can use the same registerer.
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	m := &Metrics{}
+	var err error
+	if m.downloadsTotal, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "xet_downloads_total",
+		Help: "Number of file and snapshot downloads from the Hugging Face Hub through xet",
+	}, []string{"repo", "kind", "result"})); err != nil {
+		return nil, err
+	}
+	if m.downloadDuration, err = metrics.RegisterCollector(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "xet_download_duration_seconds",
+		Help:    "Duration of the successful file and snapshot downloads through xet",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 18), // From 100ms to ~3.6h
+	}, []string{"repo", "kind"})); err != nil {
+		return nil, err
+	}
+	if m.filesTotal, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "xet_files_total",
+		Help: "Number of files of the downloads, by source: download or cache",
+	}, []string{"repo", "source"})); err != nil {
+		return nil, err
+	}
+	if m.bytesTotal, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "xet_bytes_total",
+		Help: "Number of bytes of the files of the downloads, by source: download or cache",
+	}, []string{"repo", "source"})); err != nil {
+		return nil, err
+	}
+	if m.dedupSavedBytes, err = metrics.RegisterCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "xet_dedup_saved_bytes_total",
+		Help: "Number of downloaded bytes served from deduplicated chunks instead of being fetched",
+	}, []string{"repo"})); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// observeDownload records a download. The cache statistics are only recorded when the download had a client of
+// its own, since the ones of a shared client count the downloads since its creation.
+func (m *Metrics) observeDownload(repoID, kind string, duration time.Duration, err error, stats *CacheStats) {
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.downloadsTotal.WithLabelValues(repoID, kind, "failure").Inc()
+	} else {
+		m.downloadsTotal.WithLabelValues(repoID, kind, "success").Inc()
+		m.downloadDuration.WithLabelValues(repoID, kind).Observe(duration.Seconds())
+	}
+
+	if stats == nil {
+		return
+	}
+	m.filesTotal.WithLabelValues(repoID, "download").Add(float64(stats.Misses))
+	m.filesTotal.WithLabelValues(repoID, "cache").Add(float64(stats.Hits))
+	m.bytesTotal.WithLabelValues(repoID, "download").Add(float64(stats.DownloadedBytes))
+	m.bytesTotal.WithLabelValues(repoID, "cache").Add(float64(stats.HitBytes))
+	m.dedupSavedBytes.WithLabelValues(repoID).Add(float64(stats.DedupSavedBytes))
+}
+
+// observeClientDownload records a download made with a client of its own
+func (m *Metrics) observeClientDownload(client *Client, repoID, kind string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	stats, statsErr := client.CacheStats()
+	if statsErr != nil {
+		stats = nil
+	}
+	m.observeDownload(repoID, kind, time.Since(start), err, stats)
+}
@@ -0,0 +1,56 @@
+package xet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsObserveDownload(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	metrics.observeDownload("org/model", downloadKindSnapshot, 3*time.Second, nil, &CacheStats{
+		Hits:            2,
+		Misses:          3,
+		HitBytes:        100,
+		DownloadedBytes: 900,
+		DedupSavedBytes: 400,
+	})
+	metrics.observeDownload("org/model", downloadKindFile, time.Second, errors.New("boom"), nil)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.downloadsTotal.WithLabelValues("org/model", downloadKindSnapshot, "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.downloadsTotal.WithLabelValues("org/model", downloadKindFile, "failure")))
+	assert.Equal(t, 3.0, testutil.ToFloat64(metrics.filesTotal.WithLabelValues("org/model", "download")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.filesTotal.WithLabelValues("org/model", "cache")))
+	assert.Equal(t, 900.0, testutil.ToFloat64(metrics.bytesTotal.WithLabelValues("org/model", "download")))
+	assert.Equal(t, 100.0, testutil.ToFloat64(metrics.bytesTotal.WithLabelValues("org/model", "cache")))
+	assert.Equal(t, 400.0, testutil.ToFloat64(metrics.dedupSavedBytes.WithLabelValues("org/model")))
+	// Only the successful downloads are timed
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.downloadDuration))
+}
+
+func TestMetricsShareRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first, err := NewConfig(WithMetrics(registry))
+	require.NoError(t, err)
+	second, err := NewConfig(WithMetrics(registry))
+	require.NoError(t, err)
+
+	first.ToDownloadConfig().Metrics.observeDownload("org/model", downloadKindFile, time.Second, nil, nil)
+	second.ToDownloadConfig().Metrics.observeDownload("org/model", downloadKindFile, time.Second, nil, nil)
+	assert.Equal(t, 2.0, testutil.ToFloat64(first.Metrics.downloadsTotal.WithLabelValues("org/model", downloadKindFile, "success")))
+}
+
+func TestNilMetrics(t *testing.T) {
+	var metrics *Metrics
+	assert.NotPanics(t, func() {
+		metrics.observeDownload("org/model", downloadKindFile, time.Second, nil, &CacheStats{Hits: 1})
+		metrics.observeClientDownload(nil, "org/model", downloadKindFile, time.Now(), nil)
+	})
+}
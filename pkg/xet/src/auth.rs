@@ -0,0 +1,121 @@
+use anyhow::{anyhow, Result};
+use std::path::{Path, PathBuf};
+use std::sync::{Arc, Mutex};
+use std::time::SystemTime;
+use tracing::warn;
+
+/// HF token of the Hub requests. A token file, e.g. a projected Kubernetes Secret, is checked on every request and
+/// read again when it changes, so that a rotated token is used without recreating the client.
+#[derive(Debug, Clone, Default)]
+pub struct HfToken {
+    token: Option<String>,
+    token_file: Option<PathBuf>,
+    cached: Arc<Mutex<Option<CachedToken>>>,
+}
+
+/// Token last read from the token file, with the state of the file it was read from
+#[derive(Debug)]
+struct CachedToken {
+    modified: Option<SystemTime>,
+    len: u64,
+    token: String,
+}
+
+impl HfToken {
+    pub fn new(token: Option<String>, token_file: Option<String>) -> Self {
+        Self {
+            token,
+            token_file: token_file.map(PathBuf::from),
+            cached: Arc::new(Mutex::new(None)),
+        }
+    }
+
+    /// Returns the current token: the one of the token file when it is set and readable, or else the token
+    pub fn current(&self) -> Option<String> {
+        let Some(ref path) = self.token_file else {
+            return self.token.clone();
+        };
+        let mut cached = self.cached.lock().unwrap_or_else(|e| e.into_inner());
+
+        match read_if_changed(path, cached.as_ref()) {
+            Ok(Some(read)) => *cached = Some(read),
+            Ok(None) => {}
+            // The last token read is kept while the file is unavailable
+            Err(err) => warn!("Failed to read token file {}: {}", path.display(), err),
+        }
+        cached
+            .as_ref()
+            .map(|c| c.token.clone())
+            .or_else(|| self.token.clone())
+    }
+
+    /// Adds the current token to a request
+    pub fn authorize(&self, builder: reqwest::RequestBuilder) -> reqwest::RequestBuilder {
+        match self.current() {
+            Some(token) => builder.bearer_auth(token),
+            None => builder,
+        }
+    }
+}
+
+/// Reads the token file unless it is unchanged since the cached token was read
+fn read_if_changed(path: &Path, cached: Option<&CachedToken>) -> Result<Option<CachedToken>> {
+    // metadata follows the symlinks that kubelet swaps when a Secret is updated
+    let metadata = std::fs::metadata(path)?;
+    let modified = metadata.modified().ok();
+    if let Some(c) = cached {
+        if c.modified == modified && c.len == metadata.len() {
+            return Ok(None);
+        }
+    }
+
+    let token = std::fs::read_to_string(path)?.trim().to_string();
+    if token.is_empty() {
+        return Err(anyhow!("the file is empty"));
+    }
+    Ok(Some(CachedToken {
+        modified,
+        len: metadata.len(),
+        token,
+    }))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_token_without_file() {
+        let token = HfToken::new(Some("hf_static".to_string()), None);
+        assert_eq!(token.current().as_deref(), Some("hf_static"));
+        assert_eq!(HfToken::default().current(), None);
+    }
+
+    #[test]
+    fn test_token_file_rotation() {
+        let path = std::env::temp_dir().join(format!("xet-token-{}", std::process::id()));
+        std::fs::write(&path, "hf_first\n").unwrap();
+
+        let token = HfToken::new(
+            Some("hf_static".to_string()),
+            Some(path.to_string_lossy().into_owned()),
+        );
+        assert_eq!(token.current().as_deref(), Some("hf_first"));
+
+        std::fs::write(&path, "hf_rotated_token\n").unwrap();
+        assert_eq!(token.current().as_deref(), Some("hf_rotated_token"));
+
+        // The last token read is kept while the file is unavailable
+        std::fs::remove_file(&path).unwrap();
+        assert_eq!(token.current().as_deref(), Some("hf_rotated_token"));
+    }
+
+    #[test]
+    fn test_missing_token_file_falls_back_to_token() {
+        let token = HfToken::new(
+            Some("hf_static".to_string()),
+            Some("/nonexistent/xet-token".to_string()),
+        );
+        assert_eq!(token.current().as_deref(), Some("hf_static"));
+    }
+}
@@ -0,0 +1,383 @@
+use anyhow::Result;
+use std::collections::HashMap;
+use std::fs;
+use std::path::{Path, PathBuf};
+use std::sync::atomic::{AtomicU64, Ordering};
+use std::sync::{Arc, Mutex};
+use std::time::SystemTime;
+use tracing::{debug, info, warn};
+
+/// Counters of the reuse of downloaded files, since the creation of the client
+#[derive(Debug, Default, Clone, Copy, PartialEq, Eq)]
+pub struct CacheStats {
+    /// Files found complete on disk instead of being downloaded
+    pub hits: u64,
+    pub misses: u64,
+    pub hit_bytes: u64,
+    pub downloaded_bytes: u64,
+    /// Downloaded bytes that XET served from deduplicated chunks instead of fetching them from CAS
+    pub dedup_saved_bytes: u64,
+    pub evicted_files: u64,
+    pub evicted_bytes: u64,
+}
+
+/// Cache of the files downloaded without a local directory. When a maximum size is set, the files used least
+/// recently are evicted once a download makes the cache grow past it.
+pub(crate) struct FileCache {
+    root: Option<PathBuf>,
+    max_size: u64,
+    // Size of the files of the cache directory, known once the directory was walked. Downloads and evictions update
+    // it, so that the directory is walked again only when the cache grows past its maximum size.
+    size: Mutex<Option<u64>>,
+    // Files being downloaded or reused, that eviction must not remove
+    in_use: Mutex<HashMap<PathBuf, usize>>,
+    // Evictions walk the whole cache, so they run one at a time
+    evicting: tokio::sync::Mutex<()>,
+    hits: AtomicU64,
+    misses: AtomicU64,
+    hit_bytes: AtomicU64,
+    downloaded_bytes: AtomicU64,
+    dedup_saved_bytes: AtomicU64,
+    evicted_files: AtomicU64,
+    evicted_bytes: AtomicU64,
+}
+
+/// Keeps a file from being evicted until it is dropped
+pub(crate) struct CachePin {
+    cache: Arc<FileCache>,
+    path: PathBuf,
+}
+
+impl Drop for CachePin {
+    fn drop(&mut self) {
+        let mut in_use = self.cache.in_use.lock().unwrap_or_else(|e| e.into_inner());
+        if let Some(count) = in_use.get_mut(&self.path) {
+            *count -= 1;
+            if *count == 0 {
+                in_use.remove(&self.path);
+            }
+        }
+    }
+}
+
+impl FileCache {
+    /// Creates the cache of a directory, a `max_size` of zero doesn't limit its size
+    pub fn new(root: Option<PathBuf>, max_size: u64) -> Self {
+        Self {
+            root,
+            max_size,
+            size: Mutex::new(None),
+            in_use: Mutex::new(HashMap::new()),
+            evicting: tokio::sync::Mutex::new(()),
+            hits: AtomicU64::new(0),
+            misses: AtomicU64::new(0),
+            hit_bytes: AtomicU64::new(0),
+            downloaded_bytes: AtomicU64::new(0),
+            dedup_saved_bytes: AtomicU64::new(0),
+            evicted_files: AtomicU64::new(0),
+            evicted_bytes: AtomicU64::new(0),
+        }
+    }
+
+    pub fn pin(self: &Arc<Self>, path: &Path) -> CachePin {
+        let mut in_use = self.in_use.lock().unwrap_or_else(|e| e.into_inner());
+        *in_use.entry(path.to_path_buf()).or_insert(0) += 1;
+        CachePin {
+            cache: self.clone(),
+            path: path.to_path_buf(),
+        }
+    }
+
+    /// Returns whether a path is a file of the cache directory, the only files eviction removes
+    pub fn contains(&self, path: &Path) -> bool {
+        self.root
+            .as_deref()
+            .map(|root| path.starts_with(root))
+            .unwrap_or(false)
+    }
+
+    /// Records the reuse of a file found on disk and marks it as recently used
+    pub fn record_hit(&self, path: &Path, bytes: u64) {
+        self.hits.fetch_add(1, Ordering::Relaxed);
+        self.hit_bytes.fetch_add(bytes, Ordering::Relaxed);
+        if self.contains(path) {
+            if let Err(err) = touch(path) {
+                debug!("Failed to mark {} as used: {}", path.display(), err);
+            }
+        }
+    }
+
+    /// Records a file downloaded to a path, of which only `transferred` bytes were fetched over the network. The
+    /// file replaced `replaced` bytes, those of an incomplete copy.
+    pub fn record_download(&self, path: &Path, bytes: u64, transferred: u64, replaced: u64) {
+        self.misses.fetch_add(1, Ordering::Relaxed);
+        self.downloaded_bytes.fetch_add(bytes, Ordering::Relaxed);
+        self.dedup_saved_bytes
+            .fetch_add(bytes.saturating_sub(transferred), Ordering::Relaxed);
+        if self.contains(path) {
+            let mut size = self.size.lock().unwrap_or_else(|e| e.into_inner());
+            if let Some(ref mut size) = *size {
+                *size = size.saturating_sub(replaced) + bytes;
+            }
+        }
+    }
+
+    pub fn stats(&self) -> CacheStats {
+        CacheStats {
+            hits: self.hits.load(Ordering::Relaxed),
+            misses: self.misses.load(Ordering::Relaxed),
+            hit_bytes: self.hit_bytes.load(Ordering::Relaxed),
+            downloaded_bytes: self.downloaded_bytes.load(Ordering::Relaxed),
+            dedup_saved_bytes: self.dedup_saved_bytes.load(Ordering::Relaxed),
+            evicted_files: self.evicted_files.load(Ordering::Relaxed),
+            evicted_bytes: self.evicted_bytes.load(Ordering::Relaxed),
+        }
+    }
+
+    /// Returns the size of the files of the cache directory, walking it the first time only
+    pub fn size(&self) -> u64 {
+        let Some(ref root) = self.root else {
+            return 0;
+        };
+        if let Some(size) = *self.size.lock().unwrap_or_else(|e| e.into_inner()) {
+            return size;
+        }
+
+        // The directory is walked without holding the lock, which downloads take
+        let walked = cached_files(root).iter().map(|f| f.size).sum();
+        *self
+            .size
+            .lock()
+            .unwrap_or_else(|e| e.into_inner())
+            .get_or_insert(walked)
+    }
+
+    /// Evicts the files used least recently until the cache fits in its maximum size
+    pub async fn enforce_limit(self: &Arc<Self>) -> Result<()> {
+        if self.root.is_none() || self.max_size == 0 {
+            return Ok(());
+        }
+        let cache = self.clone();
+        if tokio::task::spawn_blocking(move || cache.size()).await? <= self.max_size {
+            return Ok(());
+        }
+
+        let _evicting = self.evicting.lock().await;
+        let cache = self.clone();
+        tokio::task::spawn_blocking(move || cache.evict()).await?;
+        Ok(())
+    }
+
+    fn evict(&self) {
+        let Some(ref root) = self.root else {
+            return;
+        };
+        let mut files = cached_files(root);
+        let mut total: u64 = files.iter().map(|f| f.size).sum();
+
+        if total > self.max_size {
+            files.sort_by_key(|f| f.used);
+            for file in files {
+                if total <= self.max_size {
+                    break;
+                }
+                if self.is_in_use(&file.path) {
+                    continue;
+                }
+                match fs::remove_file(&file.path) {
+                    Ok(()) => {
+                        debug!(
+                            "[CACHE EVICT] {} ({} bytes)",
+                            file.path.display(),
+                            file.size
+                        );
+                        total -= file.size;
+                        self.evicted_files.fetch_add(1, Ordering::Relaxed);
+                        self.evicted_bytes.fetch_add(file.size, Ordering::Relaxed);
+                        remove_empty_parents(&file.path, root);
+                    }
+                    Err(err) => warn!("Failed to evict {}: {}", file.path.display(), err),
+                }
+            }
+
+            if total > self.max_size {
+                warn!(
+                    "Cache {} holds {} bytes in use, above its maximum size of {} bytes",
+                    root.display(),
+                    total,
+                    self.max_size
+                );
+            } else {
+                info!("Evicted cached files down to {} bytes", total);
+            }
+        }
+
+        // The walk also counts the files of failed downloads, which aren't recorded
+        *self.size.lock().unwrap_or_else(|e| e.into_inner()) = Some(total);
+    }
+
+    fn is_in_use(&self, path: &Path) -> bool {
+        let in_use = self.in_use.lock().unwrap_or_else(|e| e.into_inner());
+        in_use.contains_key(path)
+    }
+}
+
+struct CachedFile {
+    path: PathBuf,
+    size: u64,
+    used: SystemTime,
+}
+
+/// Lists the files under a directory with the time they were last used
+fn cached_files(root: &Path) -> Vec<CachedFile> {
+    let mut files = Vec::new();
+    let mut directories = vec![root.to_path_buf()];
+    while let Some(dir) = directories.pop() {
+        let Ok(entries) = fs::read_dir(&dir) else {
+            continue;
+        };
+        for entry in entries.flatten() {
+            let Ok(metadata) = entry.metadata() else {
+                continue;
+            };
+            if metadata.is_dir() {
+                directories.push(entry.path());
+            } else if metadata.is_file() {
+                files.push(CachedFile {
+                    path: entry.path(),
+                    size: metadata.len(),
+                    // Cache hits update the modification time, access times aren't kept on noatime mounts
+                    used: metadata.modified().unwrap_or(SystemTime::UNIX_EPOCH),
+                });
+            }
+        }
+    }
+    files
+}
+
+fn touch(path: &Path) -> std::io::Result<()> {
+    fs::File::options()
+        .write(true)
+        .open(path)?
+        .set_modified(SystemTime::now())
+}
+
+fn remove_empty_parents(path: &Path, root: &Path) {
+    let mut dir = path.parent();
+    while let Some(current) = dir {
+        if current == root || !current.starts_with(root) || fs::remove_dir(current).is_err() {
+            break;
+        }
+        dir = current.parent();
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::time::Duration;
+
+    fn temp_cache_dir(name: &str) -> PathBuf {
+        let dir = std::env::temp_dir().join(format!("xet-cache-{}-{}", name, std::process::id()));
+        let _ = fs::remove_dir_all(&dir);
+        fs::create_dir_all(&dir).unwrap();
+        dir
+    }
+
+    fn write_file(path: &Path, size: usize, age: Duration) {
+        fs::create_dir_all(path.parent().unwrap()).unwrap();
+        fs::write(path, vec![0u8; size]).unwrap();
+        fs::File::options()
+            .write(true)
+            .open(path)
+            .unwrap()
+            .set_modified(SystemTime::now() - age)
+            .unwrap();
+    }
+
+    #[test]
+    fn test_evict_least_recently_used() {
+        let root = temp_cache_dir("evict");
+        let oldest = root.join("org--old").join("main").join("model.bin");
+        let pinned = root.join("org--pinned").join("main").join("model.bin");
+        let recent = root.join("org--new").join("main").join("model.bin");
+        write_file(&oldest, 100, Duration::from_secs(300));
+        write_file(&pinned, 100, Duration::from_secs(200));
+        write_file(&recent, 100, Duration::from_secs(100));
+
+        let cache = Arc::new(FileCache::new(Some(root.clone()), 150));
+        let _pin = cache.pin(&pinned);
+        cache.evict();
+
+        assert!(!oldest.exists());
+        assert!(!root.join("org--old").exists());
+        assert!(pinned.exists());
+        assert!(!recent.exists());
+        assert_eq!(cache.size(), 100);
+        assert_eq!(cache.stats().evicted_files, 2);
+        assert_eq!(cache.stats().evicted_bytes, 200);
+
+        fs::remove_dir_all(&root).unwrap();
+    }
+
+    #[test]
+    fn test_cache_hit_marks_file_used() {
+        let root = temp_cache_dir("hit");
+        let used = root.join("org--model").join("main").join("config.json");
+        let unused = root.join("org--model").join("main").join("model.bin");
+        write_file(&used, 100, Duration::from_secs(300));
+        write_file(&unused, 100, Duration::from_secs(100));
+
+        let cache = FileCache::new(Some(root.clone()), 100);
+        cache.record_hit(&used, 100);
+        cache.record_download(
+            &root.join("org--other").join("main").join("model.bin"),
+            100,
+            40,
+            0,
+        );
+        cache.evict();
+
+        assert!(used.exists());
+        assert!(!unused.exists());
+        let stats = cache.stats();
+        assert_eq!(stats.hits, 1);
+        assert_eq!(stats.misses, 1);
+        assert_eq!(stats.dedup_saved_bytes, 60);
+
+        fs::remove_dir_all(&root).unwrap();
+    }
+
+    #[test]
+    fn test_size_recorded_without_walk() {
+        let root = temp_cache_dir("size");
+        write_file(
+            &root.join("org--model").join("main").join("config.json"),
+            100,
+            Duration::ZERO,
+        );
+
+        let cache = FileCache::new(Some(root.clone()), 1000);
+        assert_eq!(cache.size(), 100);
+
+        // A download replacing an incomplete copy grows the cache by the difference
+        let model = root.join("org--model").join("main").join("model.bin");
+        write_file(&model, 200, Duration::ZERO);
+        cache.record_download(&model, 200, 200, 50);
+        assert_eq!(cache.size(), 250);
+        // Downloads outside the cache directory, and files left by failed downloads, don't count
+        cache.record_download(Path::new("/models/model.bin"), 200, 200, 0);
+        write_file(
+            &root.join("org--model").join("main").join("failed.bin"),
+            30,
+            Duration::ZERO,
+        );
+        assert_eq!(cache.size(), 250);
+
+        // Evictions walk the cache again
+        cache.evict();
+        assert_eq!(cache.size(), 330);
+
+        fs::remove_dir_all(&root).unwrap();
+    }
+}
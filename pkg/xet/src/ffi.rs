@@ -5,7 +5,11 @@ use std::sync::Arc;
 
 use crate::error::{XetError, XetErrorCode};
 use crate::progress::XetProgressCallback;
-use crate::{block_on, DownloadOptions, OperationContext, SnapshotOptions, XetClient};
+use crate::transfer::TransferOptions;
+use crate::{
+    block_on, DownloadOptions, HfToken, NetworkConfig, OperationContext, SnapshotOptions,
+    UploadOptions, XetClient,
+};
 
 #[repr(C)]
 pub struct XetConfig {
@@ -14,6 +18,11 @@ pub struct XetConfig {
     pub cache_dir: *const c_char,
     pub max_concurrent_downloads: u32,
     pub enable_dedup: bool,
+    pub proxy_url: *const c_char,
+    pub ca_bundle: *const c_char,
+    pub insecure_skip_verify: bool,
+    pub token_file: *const c_char,
+    pub max_cache_size: u64,
 }
 
 #[repr(C)]
@@ -25,6 +34,42 @@ pub struct XetDownloadRequest {
     pub local_dir: *const c_char,
 }
 
+/// Transfer tuning of a download, zero fields keep the settings of the client
+#[repr(C)]
+pub struct XetTransferOptions {
+    pub max_concurrent: u32,
+    pub chunk_size: u64,
+    pub max_bytes_per_second: u64,
+}
+
+#[repr(C)]
+pub struct XetUploadRequest {
+    pub repo_id: *const c_char,
+    pub repo_type: *const c_char,
+    pub revision: *const c_char,
+    pub local_path: *const c_char,
+    pub path_in_repo: *const c_char,
+    pub commit_message: *const c_char,
+}
+
+#[repr(C)]
+pub struct XetCommitInfo {
+    pub commit_oid: *mut c_char,
+    pub commit_url: *mut c_char,
+}
+
+#[repr(C)]
+pub struct XetCacheStats {
+    pub hits: u64,
+    pub misses: u64,
+    pub hit_bytes: u64,
+    pub downloaded_bytes: u64,
+    pub dedup_saved_bytes: u64,
+    pub evicted_files: u64,
+    pub evicted_bytes: u64,
+    pub cache_size: u64,
+}
+
 #[repr(C)]
 pub struct XetFileInfoC {
     pub path: *mut c_char,
@@ -60,6 +105,20 @@ unsafe fn make_cancel_check(
     }))
 }
 
+// Helper to convert nullable C transfer options
+unsafe fn transfer_options_from_c(options: *const XetTransferOptions) -> TransferOptions {
+    if options.is_null() {
+        return TransferOptions::default();
+    }
+
+    let options = &*options;
+    TransferOptions::new(
+        options.max_concurrent,
+        options.chunk_size,
+        options.max_bytes_per_second,
+    )
+}
+
 // Helper to convert C string to Rust String
 unsafe fn c_str_to_string(s: *const c_char) -> Option<String> {
     if s.is_null() {
@@ -86,8 +145,16 @@ pub unsafe extern "C" fn xet_client_new(config: *const XetConfig) -> *mut XetCli
         let config = &*config;
 
         let endpoint = c_str_to_string(config.endpoint);
-        let token = c_str_to_string(config.token);
+        let token = HfToken::new(
+            c_str_to_string(config.token),
+            c_str_to_string(config.token_file),
+        );
         let cache_dir = c_str_to_string(config.cache_dir);
+        let network = NetworkConfig {
+            proxy_url: c_str_to_string(config.proxy_url),
+            ca_bundle: c_str_to_string(config.ca_bundle),
+            insecure_skip_verify: config.insecure_skip_verify,
+        };
         let max_concurrent = if config.max_concurrent_downloads > 0 {
             config.max_concurrent_downloads
         } else {
@@ -100,6 +167,8 @@ pub unsafe extern "C" fn xet_client_new(config: *const XetConfig) -> *mut XetCli
             cache_dir,
             max_concurrent,
             config.enable_dedup,
+            config.max_cache_size,
+            network,
         ) {
             Ok(client) => Box::into_raw(Box::new(client)),
             Err(_) => ptr::null_mut(),
@@ -150,6 +219,45 @@ pub unsafe extern "C" fn xet_client_set_progress_callback(
     ptr::null_mut()
 }
 
+/// Get the cache statistics of a client.
+///
+/// # Safety
+///
+/// Caller must ensure that:
+/// - `client` is a valid pointer returned by `xet_client_new`
+/// - `out_stats` is a valid pointer to an `XetCacheStats`
+#[no_mangle]
+pub unsafe extern "C" fn xet_client_cache_stats(
+    client: *mut XetClient,
+    out_stats: *mut XetCacheStats,
+) -> *mut XetError {
+    if client.is_null() || out_stats.is_null() {
+        return XetError::new(
+            XetErrorCode::InvalidConfig,
+            "Invalid parameters".to_string(),
+            None,
+        );
+    }
+
+    let client_ref = &*client;
+    match block_on(async { client_ref.cache_stats().await }) {
+        Ok((stats, cache_size)) => {
+            *out_stats = XetCacheStats {
+                hits: stats.hits,
+                misses: stats.misses,
+                hit_bytes: stats.hit_bytes,
+                downloaded_bytes: stats.downloaded_bytes,
+                dedup_saved_bytes: stats.dedup_saved_bytes,
+                evicted_files: stats.evicted_files,
+                evicted_bytes: stats.evicted_bytes,
+                cache_size,
+            };
+            ptr::null_mut()
+        }
+        Err(e) => XetError::from_anyhow(e),
+    }
+}
+
 /// List files in a repository.
 ///
 /// # Safety
@@ -229,6 +337,7 @@ pub unsafe extern "C" fn xet_list_files(
 pub unsafe extern "C" fn xet_download_file(
     client: *mut XetClient,
     request: *const XetDownloadRequest,
+    transfer_options: *const XetTransferOptions,
     cancel_token: *const XetCancellationToken,
     out_path: *mut *mut c_char,
 ) -> *mut XetError {
@@ -275,6 +384,7 @@ pub unsafe extern "C" fn xet_download_file(
         repo_type: repo_type.as_deref(),
         revision: revision.as_deref(),
         local_dir: local_dir.as_deref(),
+        transfer: unsafe { transfer_options_from_c(transfer_options) },
     };
     let context = OperationContext::new(cancel_check, progress);
 
@@ -310,6 +420,7 @@ pub unsafe extern "C" fn xet_download_snapshot(
     repo_type: *const c_char,
     revision: *const c_char,
     local_dir: *const c_char,
+    transfer_options: *const XetTransferOptions,
     cancel_token: *const XetCancellationToken,
     out_path: *mut *mut c_char,
 ) -> *mut XetError {
@@ -354,6 +465,7 @@ pub unsafe extern "C" fn xet_download_snapshot(
         local_dir: &local_dir,
         allow_patterns: None,
         ignore_patterns: None,
+        transfer: unsafe { transfer_options_from_c(transfer_options) },
     };
     let context = OperationContext::new(cancel_check, progress);
 
@@ -374,6 +486,125 @@ pub unsafe extern "C" fn xet_download_snapshot(
     }
 }
 
+/// Upload a file to a repository in one commit.
+///
+/// # Safety
+///
+/// Caller must ensure that:
+/// - All pointers are valid or null
+/// - Strings are valid UTF-8
+/// - `out_commit` must be freed with `xet_free_commit_info`
+#[no_mangle]
+pub unsafe extern "C" fn xet_upload_file(
+    client: *mut XetClient,
+    request: *const XetUploadRequest,
+    cancel_token: *const XetCancellationToken,
+    out_commit: *mut *mut XetCommitInfo,
+) -> *mut XetError {
+    unsafe { upload(client, request, cancel_token, out_commit, false) }
+}
+
+/// Upload the files of a local directory to a repository in one commit.
+///
+/// # Safety
+///
+/// Caller must ensure that:
+/// - All pointers are valid or null
+/// - Strings are valid UTF-8
+/// - `out_commit` must be freed with `xet_free_commit_info`
+#[no_mangle]
+pub unsafe extern "C" fn xet_upload_snapshot(
+    client: *mut XetClient,
+    request: *const XetUploadRequest,
+    cancel_token: *const XetCancellationToken,
+    out_commit: *mut *mut XetCommitInfo,
+) -> *mut XetError {
+    unsafe { upload(client, request, cancel_token, out_commit, true) }
+}
+
+// Shared implementation of the upload operations, `local_path` is a directory for snapshots
+unsafe fn upload(
+    client: *mut XetClient,
+    request: *const XetUploadRequest,
+    cancel_token: *const XetCancellationToken,
+    out_commit: *mut *mut XetCommitInfo,
+    snapshot: bool,
+) -> *mut XetError {
+    if client.is_null() || request.is_null() || out_commit.is_null() {
+        return XetError::new(
+            XetErrorCode::InvalidConfig,
+            "Invalid parameters".to_string(),
+            None,
+        );
+    }
+
+    let client_ref = unsafe { &*client };
+    let request_ref = unsafe { &*request };
+
+    let repo_id = match unsafe { c_str_to_string(request_ref.repo_id) } {
+        Some(s) => s,
+        None => {
+            return XetError::new(
+                XetErrorCode::InvalidConfig,
+                "Invalid repo_id".to_string(),
+                None,
+            );
+        }
+    };
+
+    let local_path = match unsafe { c_str_to_string(request_ref.local_path) } {
+        Some(s) => s,
+        None => {
+            return XetError::new(
+                XetErrorCode::InvalidConfig,
+                "Invalid local_path".to_string(),
+                None,
+            );
+        }
+    };
+
+    let repo_type = unsafe { c_str_to_string(request_ref.repo_type) };
+    let revision = unsafe { c_str_to_string(request_ref.revision) };
+    let path_in_repo = unsafe { c_str_to_string(request_ref.path_in_repo) };
+    let commit_message = unsafe { c_str_to_string(request_ref.commit_message) }
+        .unwrap_or_else(|| "Upload files with xet".to_string());
+
+    let cancel_check = unsafe { make_cancel_check(cancel_token) };
+    let options = UploadOptions {
+        repo_type: repo_type.as_deref(),
+        revision: revision.as_deref(),
+        path_in_repo: path_in_repo.as_deref(),
+        commit_message: &commit_message,
+    };
+    let context = OperationContext::new(cancel_check, None);
+
+    let result = block_on(async {
+        if snapshot {
+            client_ref
+                .upload_snapshot_with_options(&repo_id, &local_path, options, context)
+                .await
+        } else {
+            client_ref
+                .upload_file_with_options(&repo_id, &local_path, options, context)
+                .await
+        }
+    });
+
+    match result {
+        Ok(commit) => {
+            let info = Box::new(XetCommitInfo {
+                commit_oid: CString::new(commit.commit_oid).unwrap().into_raw(),
+                commit_url: CString::new(commit.commit_url).unwrap().into_raw(),
+            });
+            unsafe {
+                *out_commit = Box::into_raw(info);
+            }
+            ptr::null_mut()
+        }
+        Err(e) => XetError::from_anyhow(e),
+    }
+}
+
 /// Free a file list returned by `xet_list_files`.
 ///
 /// # Safety
@@ -400,3 +631,25 @@ pub unsafe extern "C" fn xet_free_file_list(list: *mut XetFileList) {
         }
     }
 }
+
+/// Free a commit returned by `xet_upload_file` or `xet_upload_snapshot`.
+///
+/// # Safety
+///
+/// Caller must ensure that:
+/// - `info` is either null or a valid pointer returned by an upload function
+/// - `info` is not used after calling this function
+#[no_mangle]
+pub unsafe extern "C" fn xet_free_commit_info(info: *mut XetCommitInfo) {
+    if !info.is_null() {
+        unsafe {
+            let info = Box::from_raw(info);
+            if !info.commit_oid.is_null() {
+                let _ = CString::from_raw(info.commit_oid);
+            }
+            if !info.commit_url.is_null() {
+                let _ = CString::from_raw(info.commit_url);
+            }
+        }
+    }
+}
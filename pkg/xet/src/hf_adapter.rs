@@ -1,24 +1,35 @@
+use crate::auth::HfToken;
+use crate::cache::{CacheStats, FileCache};
+use crate::network::NetworkConfig;
 use crate::progress::{OperationProgress, XetProgressPhase};
+use crate::transfer::TransferOptions;
 use crate::xet_integration::{parse_xet_file_data_from_headers, XetFileData, XetTokenManager};
-use anyhow::{anyhow, Result};
+use crate::xet_uploader::XetUploadedFile;
+use anyhow::{anyhow, Context, Result};
+use base64::engine::general_purpose::STANDARD as BASE64;
+use base64::Engine;
 use futures::stream::{self, StreamExt};
+use reqwest::header::{HeaderMap, LINK};
+use reqwest::StatusCode;
 use serde::Deserialize;
 use std::path::{Path, PathBuf};
 use std::sync::Arc;
 use std::time::Duration;
 use tokio::fs;
-use tokio::io::AsyncWriteExt;
+use tokio::io::{AsyncReadExt, AsyncWriteExt, BufWriter};
 use tokio::time::sleep;
-use tracing::{debug, info};
+use tracing::{debug, info, warn};
 
 #[derive(Clone)]
 pub struct HfAdapter {
     endpoint: String,
-    token: Option<String>,
+    token: HfToken,
     cache_dir: Option<PathBuf>,
+    cache: Arc<FileCache>,
     max_concurrent: usize,
     enable_dedup: bool,
     client: reqwest::Client,
+    network: NetworkConfig,
     xet_token_manager: Arc<tokio::sync::Mutex<XetTokenManager>>,
 }
 
@@ -43,6 +54,11 @@ struct HfTreeItem {
     xet_hash: Option<String>,
 }
 
+#[derive(Debug, Deserialize)]
+struct RevisionInfo {
+    sha: String,
+}
+
 const MAX_HTTP_RETRIES: usize = 3;
 const RETRY_BACKOFF_MS: u64 = 200;
 
@@ -110,35 +126,33 @@ impl HfAdapter {
 
     pub fn new(
         endpoint: String,
-        token: Option<String>,
+        token: HfToken,
         cache_dir: Option<String>,
         max_concurrent: usize,
         enable_dedup: bool,
+        max_cache_size: u64,
+        network: NetworkConfig,
     ) -> Result<Self> {
         let cache_dir = cache_dir.map(PathBuf::from);
+        let cache = Arc::new(FileCache::new(cache_dir.clone(), max_cache_size));
 
-        let mut headers = reqwest::header::HeaderMap::new();
-        if let Some(ref token) = token {
-            headers.insert(
-                reqwest::header::AUTHORIZATION,
-                reqwest::header::HeaderValue::from_str(&format!("Bearer {}", token))?,
-            );
-        }
+        // The token is added to each request, so that a rotated token file is picked up
+        let client = network.client_builder()?.build()?;
 
-        let client = reqwest::Client::builder()
-            .default_headers(headers)
-            .build()?;
-
-        let xet_token_manager =
-            Arc::new(tokio::sync::Mutex::new(XetTokenManager::new(token.clone())));
+        let xet_token_manager = Arc::new(tokio::sync::Mutex::new(XetTokenManager::new(
+            token.clone(),
+            &network,
+        )?));
 
         Ok(HfAdapter {
             endpoint,
             token,
             cache_dir,
+            cache,
             max_concurrent,
             enable_dedup,
             client,
+            network,
             xet_token_manager,
         })
     }
@@ -148,45 +162,92 @@ impl HfAdapter {
         repo_id: &str,
         revision: Option<&str>,
     ) -> Result<Vec<HfFileInfo>> {
+        let commit = self.resolve_revision(repo_id, None, revision).await?;
+        self.list_tree(repo_id, None, &commit).await
+    }
+
+    /// Resolves a branch or tag to the commit it points to, so that all the requests of an operation see the same
+    /// files even when the branch moves. Commit SHAs are returned as is.
+    pub async fn resolve_revision(
+        &self,
+        repo_id: &str,
+        repo_type: Option<&str>,
+        revision: Option<&str>,
+    ) -> Result<String> {
         let revision = revision.unwrap_or("main");
-        let mut all_files = Vec::new();
-        let mut directories_to_process = vec!["".to_string()]; // Start with root directory
+        if is_commit_sha(revision) {
+            return Ok(revision.to_string());
+        }
 
-        while let Some(current_path) = directories_to_process.pop() {
-            let url = if current_path.is_empty() {
-                format!("{}/api/models/{}/tree/{}", self.endpoint, repo_id, revision)
-            } else {
-                format!(
-                    "{}/api/models/{}/tree/{}/{}",
-                    self.endpoint, repo_id, revision, current_path
-                )
-            };
+        let url = format!(
+            "{}/api/{}/{}/revision/{}",
+            self.endpoint,
+            api_repo_type(repo_type)?,
+            repo_id,
+            revision.replace('/', "%2F")
+        );
+        let response = self
+            .send_with_retry(
+                || self.token.authorize(self.client.get(&url)),
+                "resolve revision",
+                |resp| resp.status().is_success() || resp.status() == StatusCode::NOT_FOUND,
+            )
+            .await?;
+        if response.status() == StatusCode::NOT_FOUND {
+            return Err(anyhow!(
+                "Revision {} not found in repository {}",
+                revision,
+                repo_id
+            ));
+        }
+
+        let info: RevisionInfo = response.json().await?;
+        debug!(
+            "Resolved revision {} of {} to {}",
+            revision, repo_id, info.sha
+        );
+        Ok(info.sha)
+    }
+
+    /// Lists the files of a commit. The tree is listed recursively and page by page, large repositories return
+    /// thousands of entries that the Hub never sends in a single response.
+    async fn list_tree(
+        &self,
+        repo_id: &str,
+        repo_type: Option<&str>,
+        commit: &str,
+    ) -> Result<Vec<HfFileInfo>> {
+        let mut all_files = Vec::new();
+        let mut next_url = Some(format!(
+            "{}/api/{}/{}/tree/{}?recursive=true",
+            self.endpoint,
+            api_repo_type(repo_type)?,
+            repo_id,
+            commit
+        ));
 
+        while let Some(url) = next_url.take() {
             // Make HTTP request to HF API
             let response = self
                 .send_with_retry(
-                    || self.client.get(&url),
+                    || self.token.authorize(self.client.get(&url)),
                     "list files",
                     |resp| resp.status().is_success(),
                 )
                 .await?;
+            next_url = next_page_url(response.headers());
 
             // Parse the HF API response
             let tree_items: Vec<HfTreeItem> = response.json().await?;
 
-            for item in tree_items {
-                if item.item_type == "file" {
-                    // Add file to results
-                    all_files.push(HfFileInfo {
-                        path: item.path,
-                        hash: item.oid.clone(), // Git OID
-                        size: item.size,
-                        xet_hash: item.xet_hash, // XET hash if available
-                    });
-                } else if item.item_type == "directory" {
-                    // Add directory to processing queue
-                    directories_to_process.push(item.path);
-                }
+            // Directories are listed along with their files
+            for item in tree_items.into_iter().filter(|i| i.item_type == "file") {
+                all_files.push(HfFileInfo {
+                    path: item.path,
+                    hash: item.oid.clone(), // Git OID
+                    size: item.size,
+                    xet_hash: item.xet_hash, // XET hash if available
+                });
             }
         }
 
@@ -201,17 +262,17 @@ impl HfAdapter {
         repo_type: Option<&str>,
         revision: Option<&str>,
         local_dir: Option<&str>,
+        transfer: TransferOptions,
         cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
         progress: Option<OperationProgress>,
     ) -> Result<String> {
-        let revision = revision.unwrap_or("main");
-
         if let Some(ref tracker) = progress {
             tracker.set_phase(XetProgressPhase::Scanning, true);
         }
 
         // First, get the file info to determine metadata
-        let files = self.list_files(repo_id, Some(revision)).await?;
+        let revision = self.resolve_revision(repo_id, repo_type, revision).await?;
+        let files = self.list_tree(repo_id, repo_type, &revision).await?;
         let file_info = files
             .iter()
             .find(|f| f.path == filename)
@@ -229,14 +290,18 @@ impl HfAdapter {
             .download_file_with_info(
                 repo_id,
                 repo_type,
-                revision,
+                &revision,
                 local_dir,
                 &file_info,
+                &transfer,
                 cancel_check,
                 progress.as_ref().map(|p| p.clone_for_tasks()),
             )
             .await?;
 
+        self.enforce_cache_limit(std::slice::from_ref(&output))
+            .await;
+
         if let Some(tracker) = progress {
             tracker.finalize();
         }
@@ -244,6 +309,27 @@ impl HfAdapter {
         Ok(output)
     }
 
+    /// Evicts cached files once downloads made the cache grow past its maximum size. The files just downloaded are
+    /// kept even when they are larger than the cache.
+    async fn enforce_cache_limit(&self, outputs: &[String]) {
+        let _pins: Vec<_> = outputs
+            .iter()
+            .map(Path::new)
+            .filter(|path| self.cache.contains(path))
+            .map(|path| self.cache.pin(path))
+            .collect();
+        if let Err(err) = self.cache.enforce_limit().await {
+            warn!("Failed to evict cached files: {}", err);
+        }
+    }
+
+    /// Returns the reuse counters of the downloaded files and the size of the cache directory
+    pub async fn cache_stats(&self) -> Result<(CacheStats, u64)> {
+        let cache = self.cache.clone();
+        let size = tokio::task::spawn_blocking(move || cache.size()).await?;
+        Ok((self.cache.stats(), size))
+    }
+
     #[allow(clippy::too_many_arguments)]
     pub async fn download_snapshot(
         &self,
@@ -253,16 +339,17 @@ impl HfAdapter {
         local_dir: &str,
         allow_patterns: Option<Vec<String>>,
         ignore_patterns: Option<Vec<String>>,
+        transfer: TransferOptions,
         cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
         progress: Option<OperationProgress>,
     ) -> Result<String> {
-        let revision = revision.unwrap_or("main");
         if let Some(ref tracker) = progress {
             tracker.set_phase(XetProgressPhase::Scanning, true);
         }
 
-        // List all files in the repository
-        let files = self.list_files(repo_id, Some(revision)).await?;
+        // List all files in the repository at the commit of the revision
+        let revision = self.resolve_revision(repo_id, repo_type, revision).await?;
+        let files = self.list_tree(repo_id, repo_type, &revision).await?;
 
         // Apply pattern filtering
         let filtered_files: Vec<_> = files
@@ -292,7 +379,11 @@ impl HfAdapter {
         fs::create_dir_all(local_dir).await?;
 
         // Download files in parallel with controlled concurrency
-        let max_concurrent = self.max_concurrent.max(1).min(filtered_files.len().max(1));
+        let max_concurrent = transfer
+            .max_concurrent
+            .unwrap_or(self.max_concurrent)
+            .max(1)
+            .min(filtered_files.len().max(1));
         let semaphore = Arc::new(tokio::sync::Semaphore::new(max_concurrent));
         let cancel_check = cancel_check.map(|c| c as Arc<_>);
         let progress_shared = progress.as_ref().map(|p| p.clone_for_tasks());
@@ -302,8 +393,9 @@ impl HfAdapter {
             let adapter = self.clone();
             let repo_id = repo_id.to_string();
             let repo_type = repo_type.map(|s| s.to_string());
-            let revision = revision.to_string();
+            let revision = revision.clone();
             let local_dir = local_dir.to_string();
+            let transfer = transfer.clone();
             let cancel_check = cancel_check.clone();
             let progress = progress_shared.clone();
 
@@ -321,6 +413,7 @@ impl HfAdapter {
                         &revision,
                         Some(&local_dir),
                         &file,
+                        &transfer,
                         cancel_check.clone(),
                         progress.as_ref().map(|p| p.clone_for_tasks()),
                     )
@@ -335,9 +428,10 @@ impl HfAdapter {
             .await;
 
         // Check for errors
-        for result in results {
-            result?;
-        }
+        let outputs = results.into_iter().collect::<Result<Vec<_>>>()?;
+
+        // The local directory may be within the cache directory
+        self.enforce_cache_limit(&outputs).await;
 
         if let Some(tracker) = progress {
             tracker.finalize();
@@ -354,6 +448,7 @@ impl HfAdapter {
         revision: &str,
         local_dir: Option<&str>,
         file_info: &HfFileInfo,
+        transfer: &TransferOptions,
         cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
         progress: Option<OperationProgress>,
     ) -> Result<String> {
@@ -372,12 +467,15 @@ impl HfAdapter {
         if let Some(parent) = destination.parent() {
             fs::create_dir_all(parent).await?;
         }
+        let _pin = self.cache.pin(&destination);
 
         // Check cache hit
+        let mut replaced = 0;
         if destination.exists() {
             if let Ok(metadata) = fs::metadata(&destination).await {
                 if metadata.len() == file_info.size {
                     debug!("[CACHE HIT] {} ({} bytes)", file_info.path, file_info.size);
+                    self.cache.record_hit(&destination, file_info.size);
                     if let Some(ref tracker) = progress {
                         tracker.ensure_file_entry(&file_info.path, file_info.size);
                         tracker.update_file_absolute(
@@ -389,6 +487,7 @@ impl HfAdapter {
                     }
                     return Ok(destination.to_string_lossy().to_string());
                 } else {
+                    replaced = metadata.len();
                     debug!(
                         "[CACHE MISS] {} - size mismatch (cached: {}, expected: {})",
                         file_info.path,
@@ -406,21 +505,15 @@ impl HfAdapter {
         );
 
         // Make a HEAD request without following redirects to capture XET headers
-        let no_redirect_client = reqwest::Client::builder()
+        let no_redirect_client = self
+            .network
+            .client_builder()?
             .redirect(reqwest::redirect::Policy::none())
             .build()?;
 
-        let auth_header = self.token.as_ref().map(|t| format!("Bearer {}", t));
-
         let head_response = self
             .send_with_retry(
-                || {
-                    let mut builder = no_redirect_client.head(&download_url);
-                    if let Some(ref auth) = auth_header {
-                        builder = builder.header(reqwest::header::AUTHORIZATION, auth.clone());
-                    }
-                    builder
-                },
+                || self.token.authorize(no_redirect_client.head(&download_url)),
                 "head request",
                 |resp| resp.status().is_success() || resp.status().is_redirection(),
             )
@@ -428,9 +521,14 @@ impl HfAdapter {
 
         let xet_file_data = parse_xet_file_data_from_headers(head_response.headers());
 
+        // CAS transfers can't use the proxy and TLS settings, nor be capped by the bandwidth of the request
+        let http_only = self.network.overrides_environment() || transfer.bandwidth.is_some();
+
         // Try XET download if available and enabled
         if let Some(xet_data) = xet_file_data {
-            if self.enable_dedup {
+            if self.enable_dedup && http_only {
+                debug!("[XET] Downloading over HTTP to apply the network and transfer settings");
+            } else if self.enable_dedup {
                 info!("[XET] File has XET support - hash: {}", xet_data.file_hash);
                 debug!("[XET] Refresh route: {}", xet_data.refresh_route);
 
@@ -445,7 +543,15 @@ impl HfAdapter {
                     )
                     .await
                 {
-                    Ok(path) => return Ok(path),
+                    Ok(transferred) => {
+                        self.cache.record_download(
+                            &destination,
+                            file_info.size,
+                            transferred,
+                            replaced,
+                        );
+                        return Ok(destination.to_string_lossy().to_string());
+                    }
                     Err(err) => {
                         debug!("[XET] Falling back to HTTP download: {err:?}");
                     }
@@ -464,7 +570,7 @@ impl HfAdapter {
         // Regular HTTP download (fallback or primary)
         let response = self
             .send_with_retry(
-                || self.client.get(&download_url),
+                || self.token.authorize(self.client.get(&download_url)),
                 "download request",
                 |resp| resp.status().is_success(),
             )
@@ -476,7 +582,8 @@ impl HfAdapter {
         }
 
         let mut stream = response.bytes_stream();
-        let mut file = fs::File::create(&destination).await?;
+        let mut file =
+            BufWriter::with_capacity(transfer.chunk_size(), fs::File::create(&destination).await?);
         let mut downloaded: u64 = 0;
 
         while let Some(chunk) = stream.next().await {
@@ -487,13 +594,17 @@ impl HfAdapter {
                 return Err(anyhow!("Download cancelled"));
             }
 
+            transfer.throttle(chunk.len()).await;
             file.write_all(&chunk).await?;
             if let Some(ref tracker) = progress {
+                tracker.update_file_transferred(&file_info.path, downloaded);
                 tracker.update_file_absolute(&file_info.path, downloaded, expected_total, false);
             }
         }
 
         file.flush().await?;
+        self.cache
+            .record_download(&destination, downloaded, downloaded, replaced);
 
         if let Some(ref tracker) = progress {
             tracker.update_file_absolute(&file_info.path, downloaded, expected_total, true);
@@ -510,7 +621,7 @@ impl HfAdapter {
         expected_size: u64,
         cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
         progress: Option<OperationProgress>,
-    ) -> Result<String> {
+    ) -> Result<u64> {
         use crate::xet_downloader::XetDownloader;
 
         if is_cancelled(&cancel_check) {
@@ -548,7 +659,7 @@ impl HfAdapter {
         }
 
         // Download using xet-core's FileDownloader
-        let _bytes_downloaded = xet_downloader
+        let transferred = xet_downloader
             .download_file(
                 &xet_file_data.file_hash,
                 dest_path,
@@ -562,7 +673,204 @@ impl HfAdapter {
             tracker.update_file_absolute(file_name, expected_size, expected_size, true);
         }
 
-        Ok(dest_path.to_string_lossy().to_string())
+        Ok(transferred)
+    }
+
+    /// Upload files to a repository in one commit. Files the Hub stores with LFS are uploaded to XET CAS, so that
+    /// only their chunks CAS doesn't already store are sent; the other files are sent in the commit.
+    pub async fn upload_files(
+        &self,
+        repo_id: &str,
+        repo_type: Option<&str>,
+        revision: Option<&str>,
+        files: Vec<HfUploadFile>,
+        commit_message: &str,
+        cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
+    ) -> Result<HfCommitInfo> {
+        if files.is_empty() {
+            return Err(anyhow!("No files to upload"));
+        }
+        let repo_type = api_repo_type(repo_type)?;
+        let revision = revision.unwrap_or("main");
+        let repo_url = format!("{}/api/{}/{}", self.endpoint, repo_type, repo_id);
+        let encoded_revision = revision.replace('/', "%2F");
+
+        // The Hub decides which files are stored with LFS
+        let mut preupload_files = Vec::with_capacity(files.len());
+        for file in &files {
+            let (size, sample) = read_sample(&file.local_path).await?;
+            preupload_files.push(serde_json::json!({
+                "path": file.path_in_repo,
+                "size": size,
+                "sample": BASE64.encode(sample),
+            }));
+        }
+        let preupload_url = format!("{}/preupload/{}", repo_url, encoded_revision);
+        let preupload_body = serde_json::json!({ "files": preupload_files });
+        let preupload: PreuploadResponse = self
+            .send_with_retry(
+                || {
+                    self.token
+                        .authorize(self.client.post(&preupload_url).json(&preupload_body))
+                },
+                "preupload request",
+                |resp| resp.status().is_success(),
+            )
+            .await?
+            .json()
+            .await?;
+
+        let mut lfs_files = Vec::new();
+        let mut regular_files = Vec::new();
+        for file in files {
+            let mode = preupload.files.iter().find(|f| f.path == file.path_in_repo);
+            match mode {
+                Some(mode) if mode.should_ignore => {
+                    debug!(
+                        "[UPLOAD] {} is ignored by the repository",
+                        file.path_in_repo
+                    )
+                }
+                Some(mode) if mode.upload_mode == "lfs" => lfs_files.push(file),
+                _ => regular_files.push(file),
+            }
+        }
+
+        let uploaded = if lfs_files.is_empty() {
+            Vec::new()
+        } else if self.network.overrides_environment() {
+            // Sending them to CAS would bypass the proxy and TLS settings
+            return Err(anyhow!(
+                "{} files are uploaded through XET CAS, which only uses the proxy and CA certificates of the \
+                 environment: set HTTPS_PROXY and SSL_CERT_FILE instead of proxy_url, ca_bundle and \
+                 insecure_skip_verify",
+                lfs_files.len()
+            ));
+        } else {
+            self.upload_with_xet(
+                &repo_url,
+                &encoded_revision,
+                &lfs_files,
+                cancel_check.clone(),
+            )
+            .await?
+        };
+
+        if is_cancelled(&cancel_check) {
+            return Err(anyhow!("Upload cancelled"));
+        }
+
+        let mut regular = Vec::with_capacity(regular_files.len());
+        for file in &regular_files {
+            let content = fs::read(&file.local_path)
+                .await
+                .with_context(|| format!("Failed to read {}", file.local_path.display()))?;
+            regular.push((file.path_in_repo.as_str(), content));
+        }
+        let lfs: Vec<_> = lfs_files
+            .iter()
+            .zip(&uploaded)
+            .map(|(file, uploaded)| (file.path_in_repo.as_str(), uploaded))
+            .collect();
+        let payload = commit_payload(commit_message, &regular, &lfs);
+
+        // Commits are not retried, so that a commit that timed out is not created twice
+        let commit_url = format!("{}/commit/{}", repo_url, encoded_revision);
+        let response = self
+            .token
+            .authorize(self.client.post(&commit_url))
+            .header(reqwest::header::CONTENT_TYPE, "application/x-ndjson")
+            .body(payload)
+            .send()
+            .await?;
+        if !response.status().is_success() {
+            let status = response.status();
+            let body = response.text().await.unwrap_or_default();
+            return Err(anyhow!("commit failed: HTTP {}: {}", status, body));
+        }
+        let commit: CommitResponse = response.json().await?;
+
+        info!(
+            "[UPLOAD] Committed {} files to {} ({} through XET)",
+            regular.len() + lfs.len(),
+            repo_id,
+            lfs.len()
+        );
+        Ok(HfCommitInfo {
+            commit_oid: commit.commit_oid,
+            commit_url: commit.commit_url,
+        })
+    }
+
+    /// Upload the files of a local directory to a repository in one commit, under `path_in_repo` if it is set
+    pub async fn upload_folder(
+        &self,
+        repo_id: &str,
+        repo_type: Option<&str>,
+        revision: Option<&str>,
+        local_dir: &str,
+        path_in_repo: Option<&str>,
+        commit_message: &str,
+        cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
+    ) -> Result<HfCommitInfo> {
+        let files = collect_upload_files(Path::new(local_dir), path_in_repo.unwrap_or(""))?;
+        self.upload_files(
+            repo_id,
+            repo_type,
+            revision,
+            files,
+            commit_message,
+            cancel_check,
+        )
+        .await
+    }
+
+    async fn upload_with_xet(
+        &self,
+        repo_url: &str,
+        encoded_revision: &str,
+        files: &[HfUploadFile],
+        cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
+    ) -> Result<Vec<XetUploadedFile>> {
+        use crate::xet_uploader::XetUploader;
+
+        // The write token route is refreshed like the read routes of downloads
+        let write_token = XetFileData {
+            file_hash: String::new(),
+            refresh_route: format!("{}/xet-write-token/{}", repo_url, encoded_revision),
+        };
+        let mut token_manager = self.xet_token_manager.lock().await;
+        let connection_info = token_manager
+            .refresh_xet_connection_info(&write_token)
+            .await
+            .context("Failed to get a XET write token, is XET enabled on the repository?")?;
+        drop(token_manager);
+
+        let uploader = XetUploader::new(
+            &connection_info,
+            &write_token,
+            self.xet_token_manager.clone(),
+        )
+        .await?;
+
+        let max_concurrent = self.max_concurrent.max(1);
+        let uploaded: Vec<XetUploadedFile> = stream::iter(files.iter().map(|file| {
+            let uploader = &uploader;
+            let cancel_check = cancel_check.clone();
+            async move {
+                uploader
+                    .upload_file(&file.local_path, &file.path_in_repo, cancel_check)
+                    .await
+            }
+        }))
+        .buffered(max_concurrent)
+        .collect::<Vec<Result<_>>>()
+        .await
+        .into_iter()
+        .collect::<Result<_>>()?;
+
+        uploader.finalize().await?;
+        Ok(uploaded)
     }
 }
 
@@ -590,9 +898,254 @@ fn determine_destination(
     PathBuf::from(filename)
 }
 
-fn is_cancelled(cancel_check: &Option<Arc<dyn Fn() -> bool + Send + Sync>>) -> bool {
+pub(crate) fn is_cancelled(cancel_check: &Option<Arc<dyn Fn() -> bool + Send + Sync>>) -> bool {
     cancel_check
         .as_ref()
         .map(|cancel| cancel())
         .unwrap_or(false)
 }
+
+/// File of an upload, with its path in the repository
+#[derive(Debug, Clone, PartialEq)]
+pub struct HfUploadFile {
+    pub local_path: PathBuf,
+    pub path_in_repo: String,
+}
+
+/// Commit created by an upload
+#[derive(Debug, Clone)]
+pub struct HfCommitInfo {
+    pub commit_oid: String,
+    pub commit_url: String,
+}
+
+#[derive(Debug, Deserialize)]
+struct PreuploadResponse {
+    files: Vec<PreuploadFile>,
+}
+
+#[derive(Debug, Deserialize)]
+#[serde(rename_all = "camelCase")]
+struct PreuploadFile {
+    path: String,
+    upload_mode: String,
+    #[serde(default)]
+    should_ignore: bool,
+}
+
+#[derive(Debug, Deserialize)]
+#[serde(rename_all = "camelCase")]
+struct CommitResponse {
+    commit_oid: String,
+    commit_url: String,
+}
+
+/// Number of leading bytes of a file the Hub uses to decide if it is stored with LFS
+const PREUPLOAD_SAMPLE_SIZE: usize = 512;
+
+/// Returns whether a revision is a full commit SHA rather than a branch or tag
+fn is_commit_sha(revision: &str) -> bool {
+    revision.len() == 40 && revision.bytes().all(|b| b.is_ascii_hexdigit())
+}
+
+/// Returns the URL of the next page of a paginated Hub response, from its `Link` header
+fn next_page_url(headers: &HeaderMap) -> Option<String> {
+    headers
+        .get_all(LINK)
+        .iter()
+        .filter_map(|value| value.to_str().ok())
+        .flat_map(|value| value.split(','))
+        .find(|link| link.split(';').skip(1).any(|p| p.trim() == "rel=\"next\""))
+        .and_then(|link| {
+            let url = link.split(';').next()?.trim();
+            url.strip_prefix('<')?.strip_suffix('>').map(str::to_string)
+        })
+}
+
+/// Returns the repository type segment of the Hub API URLs
+fn api_repo_type(repo_type: Option<&str>) -> Result<&'static str> {
+    match repo_type.unwrap_or("model") {
+        "model" | "models" => Ok("models"),
+        "dataset" | "datasets" => Ok("datasets"),
+        "space" | "spaces" => Ok("spaces"),
+        other => Err(anyhow!("Invalid repo type: {}", other)),
+    }
+}
+
+/// Returns the size of a file and its leading bytes
+async fn read_sample(path: &Path) -> Result<(u64, Vec<u8>)> {
+    let file = fs::File::open(path)
+        .await
+        .with_context(|| format!("Failed to open {}", path.display()))?;
+    let size = file.metadata().await?.len();
+    let mut sample = Vec::with_capacity(PREUPLOAD_SAMPLE_SIZE);
+    file.take(PREUPLOAD_SAMPLE_SIZE as u64)
+        .read_to_end(&mut sample)
+        .await?;
+    Ok((size, sample))
+}
+
+/// Returns the NDJSON payload of a commit: regular files are sent in the payload, LFS files by their SHA256
+fn commit_payload(
+    message: &str,
+    regular: &[(&str, Vec<u8>)],
+    lfs: &[(&str, &XetUploadedFile)],
+) -> String {
+    let mut lines = vec![serde_json::json!({
+        "key": "header",
+        "value": { "summary": message, "description": "" },
+    })];
+    for (path, content) in regular {
+        lines.push(serde_json::json!({
+            "key": "file",
+            "value": { "path": path, "content": BASE64.encode(content), "encoding": "base64" },
+        }));
+    }
+    for (path, uploaded) in lfs {
+        lines.push(serde_json::json!({
+            "key": "lfsFile",
+            "value": { "path": path, "algo": "sha256", "oid": uploaded.sha256, "size": uploaded.size },
+        }));
+    }
+    lines
+        .iter()
+        .map(|line| line.to_string())
+        .collect::<Vec<_>>()
+        .join("\n")
+}
+
+/// Returns the files of a local directory with their path in the repository, skipping the Git and Hub metadata
+fn collect_upload_files(local_dir: &Path, path_in_repo: &str) -> Result<Vec<HfUploadFile>> {
+    let mut files = Vec::new();
+    let mut directories = vec![local_dir.to_path_buf()];
+    while let Some(directory) = directories.pop() {
+        let entries = std::fs::read_dir(&directory)
+            .with_context(|| format!("Failed to read {}", directory.display()))?;
+        for entry in entries {
+            let path = entry?.path();
+            let relative = path
+                .strip_prefix(local_dir)?
+                .to_string_lossy()
+                .replace('\\', "/");
+            if path.is_dir() {
+                if !path.ends_with(".git") && relative != ".cache/huggingface" {
+                    directories.push(path);
+                }
+                continue;
+            }
+            let path_in_repo = if path_in_repo.is_empty() {
+                relative
+            } else {
+                format!("{}/{}", path_in_repo.trim_end_matches('/'), relative)
+            };
+            files.push(HfUploadFile {
+                local_path: path,
+                path_in_repo,
+            });
+        }
+    }
+    files.sort_by(|a, b| a.path_in_repo.cmp(&b.path_in_repo));
+    Ok(files)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_api_repo_type() {
+        assert_eq!(api_repo_type(None).unwrap(), "models");
+        assert_eq!(api_repo_type(Some("models")).unwrap(), "models");
+        assert_eq!(api_repo_type(Some("dataset")).unwrap(), "datasets");
+        assert!(api_repo_type(Some("repos")).is_err());
+    }
+
+    #[test]
+    fn test_is_commit_sha() {
+        assert!(is_commit_sha("0123456789abcdef0123456789abcdef01234567"));
+        assert!(!is_commit_sha("main"));
+        assert!(!is_commit_sha("0123456"));
+        assert!(!is_commit_sha(
+            "refs/pr/10123456789abcdef0123456789abcdef0123"
+        ));
+    }
+
+    #[test]
+    fn test_next_page_url() {
+        let mut headers = HeaderMap::new();
+        assert_eq!(next_page_url(&headers), None);
+
+        headers.insert(
+            LINK,
+            "<https://huggingface.co/api/models/org/model/tree/abc?recursive=true&cursor=xyz>; rel=\"next\""
+                .parse()
+                .unwrap(),
+        );
+        assert_eq!(
+            next_page_url(&headers).as_deref(),
+            Some("https://huggingface.co/api/models/org/model/tree/abc?recursive=true&cursor=xyz")
+        );
+
+        headers.insert(
+            LINK,
+            "<https://huggingface.co/prev>; rel=\"prev\""
+                .parse()
+                .unwrap(),
+        );
+        assert_eq!(next_page_url(&headers), None);
+    }
+
+    #[test]
+    fn test_commit_payload() {
+        let weights = XetUploadedFile {
+            xet_hash: "abc".to_string(),
+            sha256: "0123".to_string(),
+            size: 42,
+        };
+        let payload = commit_payload(
+            "Checkpoint 1000",
+            &[("config.json", b"{}".to_vec())],
+            &[("model.safetensors", &weights)],
+        );
+
+        let lines: Vec<serde_json::Value> = payload
+            .lines()
+            .map(|line| serde_json::from_str(line).unwrap())
+            .collect();
+        assert_eq!(lines.len(), 3);
+        assert_eq!(lines[0]["value"]["summary"], "Checkpoint 1000");
+        assert_eq!(lines[1]["key"], "file");
+        assert_eq!(lines[1]["value"]["content"], "e30=");
+        assert_eq!(lines[2]["key"], "lfsFile");
+        assert_eq!(lines[2]["value"]["oid"], "0123");
+        assert_eq!(lines[2]["value"]["size"], 42);
+    }
+
+    #[test]
+    fn test_collect_upload_files() {
+        let root = std::env::temp_dir().join(format!("xet-upload-{}", std::process::id()));
+        let _ = std::fs::remove_dir_all(&root);
+        for file in [
+            "config.json",
+            "weights/model.safetensors",
+            ".git/HEAD",
+            ".cache/huggingface/download.lock",
+        ] {
+            let path = root.join(file);
+            std::fs::create_dir_all(path.parent().unwrap()).unwrap();
+            std::fs::write(&path, "content").unwrap();
+        }
+
+        let files = collect_upload_files(&root, "checkpoint").unwrap();
+        let paths: Vec<_> = files.iter().map(|f| f.path_in_repo.as_str()).collect();
+        assert_eq!(
+            paths,
+            vec![
+                "checkpoint/config.json",
+                "checkpoint/weights/model.safetensors"
+            ]
+        );
+
+        std::fs::remove_dir_all(&root).unwrap();
+    }
+}
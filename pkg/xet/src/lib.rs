@@ -1,30 +1,41 @@
 // Module declarations - following hf_xet structure
+mod auth;
+mod cache;
 mod error;
 mod ffi;
 mod hf_adapter;
 mod logging;
+mod network;
 mod progress;
 mod runtime;
+mod transfer;
 mod xet_downloader;
 mod xet_integration;
+mod xet_uploader;
 
 // Public exports
+pub use auth::HfToken;
+pub use cache::CacheStats;
 pub use error::*;
 pub use ffi::*;
+pub use network::NetworkConfig;
 pub use progress::{XetProgressCallback, XetProgressPhase, XetProgressUpdate};
 
 // Re-export runtime utilities
 pub use runtime::{block_on, get_runtime};
 
 use anyhow::Result;
+use hf_adapter::HfCommitInfo;
 use progress::{OperationProgress, ProgressHandler};
 use std::os::raw::c_void;
 use std::sync::Arc;
+use transfer::TransferOptions;
 
 pub(crate) struct DownloadOptions<'a> {
     pub repo_type: Option<&'a str>,
     pub revision: Option<&'a str>,
     pub local_dir: Option<&'a str>,
+    pub transfer: TransferOptions,
 }
 
 pub(crate) struct SnapshotOptions<'a> {
@@ -33,6 +44,14 @@ pub(crate) struct SnapshotOptions<'a> {
     pub local_dir: &'a str,
     pub allow_patterns: Option<Vec<String>>,
     pub ignore_patterns: Option<Vec<String>>,
+    pub transfer: TransferOptions,
+}
+
+pub(crate) struct UploadOptions<'a> {
+    pub repo_type: Option<&'a str>,
+    pub revision: Option<&'a str>,
+    pub path_in_repo: Option<&'a str>,
+    pub commit_message: &'a str,
 }
 
 #[derive(Default)]
@@ -63,10 +82,12 @@ impl XetClient {
     /// Create a new XET client
     pub fn new(
         endpoint: Option<String>,
-        token: Option<String>,
+        token: HfToken,
         cache_dir: Option<String>,
         max_concurrent: u32,
         enable_dedup: bool,
+        max_cache_size: u64,
+        network: NetworkConfig,
     ) -> Result<Self> {
         // Initialize logging on first client creation
         crate::logging::init_logging();
@@ -78,6 +99,8 @@ impl XetClient {
             cache_dir,
             max_concurrent as usize,
             enable_dedup,
+            max_cache_size,
+            network,
         )?;
         Ok(Self {
             adapter,
@@ -98,6 +121,11 @@ impl XetClient {
         self.progress.new_operation()
     }
 
+    /// Reuse counters of the downloaded files, with the current size of the cache directory
+    pub async fn cache_stats(&self) -> Result<(CacheStats, u64)> {
+        self.adapter.cache_stats().await
+    }
+
     /// List files in a repository
     pub async fn list_files(
         &self,
@@ -123,6 +151,7 @@ impl XetClient {
                 repo_type,
                 revision,
                 local_dir,
+                transfer: TransferOptions::default(),
             },
             OperationContext::default(),
         )
@@ -148,6 +177,7 @@ impl XetClient {
                 options.repo_type,
                 options.revision,
                 options.local_dir,
+                options.transfer,
                 cancel_check,
                 progress,
             )
@@ -172,6 +202,7 @@ impl XetClient {
                 local_dir,
                 allow_patterns,
                 ignore_patterns,
+                transfer: TransferOptions::default(),
             },
             OperationContext::default(),
         )
@@ -197,11 +228,65 @@ impl XetClient {
                 options.local_dir,
                 options.allow_patterns,
                 options.ignore_patterns,
+                options.transfer,
                 cancel_check,
                 progress,
             )
             .await
     }
+
+    /// Upload a file to a repository, deduplicating its chunks with XET CAS
+    pub(crate) async fn upload_file_with_options(
+        &self,
+        repo_id: &str,
+        local_path: &str,
+        options: UploadOptions<'_>,
+        context: OperationContext,
+    ) -> Result<HfCommitInfo> {
+        let local_path = std::path::PathBuf::from(local_path);
+        let path_in_repo = match options.path_in_repo {
+            Some(path) => path.to_string(),
+            None => local_path
+                .file_name()
+                .map(|name| name.to_string_lossy().into_owned())
+                .ok_or_else(|| anyhow::anyhow!("Invalid local path {}", local_path.display()))?,
+        };
+
+        self.adapter
+            .upload_files(
+                repo_id,
+                options.repo_type,
+                options.revision,
+                vec![hf_adapter::HfUploadFile {
+                    local_path,
+                    path_in_repo,
+                }],
+                options.commit_message,
+                context.cancel_check,
+            )
+            .await
+    }
+
+    /// Upload the files of a local directory to a repository in one commit, deduplicating their chunks with XET CAS
+    pub(crate) async fn upload_snapshot_with_options(
+        &self,
+        repo_id: &str,
+        local_dir: &str,
+        options: UploadOptions<'_>,
+        context: OperationContext,
+    ) -> Result<HfCommitInfo> {
+        self.adapter
+            .upload_folder(
+                repo_id,
+                options.repo_type,
+                options.revision,
+                local_dir,
+                options.path_in_repo,
+                options.commit_message,
+                context.cancel_check,
+            )
+            .await
+    }
 }
 
 // Version check symbol for link-time verification
@@ -24,6 +24,11 @@ pub struct XetProgressUpdate {
     pub current_file: *const c_char,
     pub current_file_completed_bytes: u64,
     pub current_file_total_bytes: u64,
+    /// Bytes fetched over the network, the rest of the completed bytes were deduplicated by XET or the local cache
+    pub transferred_bytes: u64,
+    pub bytes_per_second: u64,
+    pub current_file_transferred_bytes: u64,
+    pub current_file_bytes_per_second: u64,
 }
 
 pub type XetProgressCallback = unsafe extern "C" fn(*const XetProgressUpdate, *mut c_void);
@@ -97,15 +102,27 @@ struct OperationProgressState {
     phase: XetProgressPhase,
     total_bytes: u64,
     completed_bytes: u64,
+    transferred_bytes: u64,
     files: HashMap<String, FileProgress>,
     total_files_hint: Option<usize>,
     last_emit: Option<Instant>,
+    rate: RateSample,
 }
 
 #[derive(Default)]
 struct FileProgress {
     total_bytes: u64,
     completed_bytes: u64,
+    transferred_bytes: u64,
+    started: Option<Instant>,
+}
+
+/// Completed bytes at the last speed measurement, the speed is measured again once a throttle interval elapsed
+#[derive(Default)]
+struct RateSample {
+    at: Option<Instant>,
+    completed_bytes: u64,
+    bytes_per_second: u64,
 }
 
 enum EmitFileInfo<'a> {
@@ -127,9 +144,11 @@ impl OperationProgress {
                 phase: XetProgressPhase::Scanning,
                 total_bytes: 0,
                 completed_bytes: 0,
+                transferred_bytes: 0,
                 files: HashMap::new(),
                 total_files_hint: None,
                 last_emit: None,
+                rate: RateSample::default(),
             })),
         }
     }
@@ -172,6 +191,18 @@ impl OperationProgress {
         if total_bytes > 0 {
             entry.total_bytes = entry.total_bytes.max(total_bytes);
         }
+        entry.started.get_or_insert_with(Instant::now);
+    }
+
+    /// Records the bytes of a file fetched over the network so far
+    pub fn update_file_transferred(&self, name: &str, transferred: u64) {
+        let mut state = self.inner.lock().expect("progress mutex poisoned");
+        let entry = state.files.entry(name.to_string()).or_default();
+        if transferred > entry.transferred_bytes {
+            let delta = transferred - entry.transferred_bytes;
+            entry.transferred_bytes = transferred;
+            state.transferred_bytes = state.transferred_bytes.saturating_add(delta);
+        }
     }
 
     pub fn update_file_absolute(&self, name: &str, completed: u64, total: u64, force: bool) {
@@ -180,6 +211,7 @@ impl OperationProgress {
         if total > 0 {
             entry.total_bytes = entry.total_bytes.max(total);
         }
+        entry.started.get_or_insert_with(Instant::now);
         if completed > entry.completed_bytes {
             let delta = completed - entry.completed_bytes;
             entry.completed_bytes = completed;
@@ -248,10 +280,14 @@ impl OperationProgress {
             .count()
             .min(u32::MAX as usize) as u32;
 
+        let bytes_per_second = state.measure_rate(now, self.throttle);
+
         let mut temp_storage: Option<CString> = None;
         let mut file_name_ptr = std::ptr::null();
         let mut file_completed = 0;
         let mut file_total = 0;
+        let mut file_transferred = 0;
+        let mut file_bytes_per_second = 0;
 
         if let EmitFileInfo::WithData {
             name,
@@ -265,6 +301,13 @@ impl OperationProgress {
             }
             file_completed = completed;
             file_total = total;
+            if let Some(entry) = state.files.get(name) {
+                file_transferred = entry.transferred_bytes;
+                file_bytes_per_second = entry
+                    .started
+                    .map(|started| per_second(completed, now.duration_since(started)))
+                    .unwrap_or(0);
+            }
         }
 
         let update = XetProgressUpdate {
@@ -276,6 +319,10 @@ impl OperationProgress {
             current_file: file_name_ptr,
             current_file_completed_bytes: file_completed,
             current_file_total_bytes: file_total,
+            transferred_bytes: state.transferred_bytes,
+            bytes_per_second,
+            current_file_transferred_bytes: file_transferred,
+            current_file_bytes_per_second: file_bytes_per_second,
         };
 
         state.last_emit = Some(now);
@@ -291,10 +338,40 @@ impl OperationProgress {
     }
 }
 
+impl OperationProgressState {
+    /// Returns the speed of the operation, measured over the last throttle interval
+    fn measure_rate(&mut self, now: Instant, interval: Duration) -> u64 {
+        match self.rate.at {
+            None => {
+                self.rate.at = Some(now);
+                self.rate.completed_bytes = self.completed_bytes;
+            }
+            Some(at) if now.duration_since(at) >= interval => {
+                let completed = self
+                    .completed_bytes
+                    .saturating_sub(self.rate.completed_bytes);
+                self.rate.bytes_per_second = per_second(completed, now.duration_since(at));
+                self.rate.at = Some(now);
+                self.rate.completed_bytes = self.completed_bytes;
+            }
+            Some(_) => {}
+        }
+        self.rate.bytes_per_second
+    }
+}
+
+fn per_second(bytes: u64, elapsed: Duration) -> u64 {
+    // Files completed at once, e.g. cache hits, have no meaningful speed
+    if elapsed < Duration::from_millis(1) {
+        return 0;
+    }
+    (bytes as f64 / elapsed.as_secs_f64()) as u64
+}
+
 #[cfg(test)]
 mod tests {
     use super::*;
-    use std::sync::atomic::{AtomicUsize, Ordering};
+    use std::sync::atomic::{AtomicU64, AtomicUsize, Ordering};
 
     extern "C" fn test_callback(update: *const XetProgressUpdate, counter: *mut c_void) {
         let counter = unsafe { &*(counter as *const AtomicUsize) };
@@ -319,4 +396,40 @@ mod tests {
 
         assert!(hits.load(Ordering::Relaxed) >= 3);
     }
+
+    extern "C" fn record_transferred(update: *const XetProgressUpdate, last: *mut c_void) {
+        let last = unsafe { &*(last as *const AtomicU64) };
+        let update = unsafe { &*update };
+        last.store(update.transferred_bytes, Ordering::Relaxed);
+    }
+
+    #[test]
+    fn test_progress_transferred_bytes() {
+        let handler = ProgressHandler::default();
+        let transferred = AtomicU64::new(0);
+        handler.configure(
+            Some(record_transferred),
+            &transferred as *const _ as *mut c_void,
+            0,
+        );
+        let progress = handler
+            .new_operation()
+            .expect("operation should be available");
+
+        // A cache hit completes without transferring bytes
+        progress.ensure_file_entry("cached", 100);
+        progress.update_file_absolute("cached", 100, 100, true);
+        progress.ensure_file_entry("file", 100);
+        progress.update_file_transferred("file", 40);
+        progress.update_file_transferred("file", 30);
+        progress.update_file_absolute("file", 100, 100, true);
+
+        assert_eq!(transferred.load(Ordering::Relaxed), 40);
+    }
+
+    #[test]
+    fn test_per_second() {
+        assert_eq!(per_second(100, Duration::ZERO), 0);
+        assert_eq!(per_second(100, Duration::from_secs(2)), 50);
+    }
 }
@@ -0,0 +1,114 @@
+use std::sync::{Arc, Mutex};
+use std::time::{Duration, Instant};
+use tokio::time::sleep;
+
+/// Size of the buffered writes of HTTP downloads when the request doesn't set one
+const DEFAULT_CHUNK_SIZE: usize = 64 * 1024;
+
+/// Longest idle period whose unused bandwidth can be spent in a burst
+const MAX_BURST: Duration = Duration::from_secs(1);
+
+/// Transfer tuning of a single download. The settings that are not set keep the ones of the client.
+#[derive(Clone, Default)]
+pub(crate) struct TransferOptions {
+    pub max_concurrent: Option<usize>,
+    pub chunk_size: Option<usize>,
+    pub bandwidth: Option<Arc<BandwidthLimiter>>,
+}
+
+impl TransferOptions {
+    /// Creates the options from the FFI values, where zero keeps the setting of the client
+    pub fn new(max_concurrent: u32, chunk_size: u64, max_bytes_per_second: u64) -> Self {
+        Self {
+            max_concurrent: (max_concurrent > 0).then_some(max_concurrent as usize),
+            chunk_size: (chunk_size > 0).then_some(chunk_size as usize),
+            bandwidth: (max_bytes_per_second > 0)
+                .then(|| Arc::new(BandwidthLimiter::new(max_bytes_per_second))),
+        }
+    }
+
+    pub fn chunk_size(&self) -> usize {
+        self.chunk_size.unwrap_or(DEFAULT_CHUNK_SIZE)
+    }
+
+    /// Waits until the bytes received fit in the bandwidth cap of the download
+    pub async fn throttle(&self, bytes: usize) {
+        if let Some(ref limiter) = self.bandwidth {
+            limiter.acquire(bytes as u64).await;
+        }
+    }
+}
+
+/// Caps the bandwidth shared by all the files of a download
+pub(crate) struct BandwidthLimiter {
+    bytes_per_second: u64,
+    state: Mutex<LimiterState>,
+}
+
+struct LimiterState {
+    started: Instant,
+    bytes: u64,
+}
+
+impl BandwidthLimiter {
+    pub fn new(bytes_per_second: u64) -> Self {
+        Self {
+            bytes_per_second: bytes_per_second.max(1),
+            state: Mutex::new(LimiterState {
+                started: Instant::now(),
+                bytes: 0,
+            }),
+        }
+    }
+
+    pub async fn acquire(&self, bytes: u64) {
+        let wait = {
+            let mut state = self.state.lock().unwrap_or_else(|e| e.into_inner());
+            let elapsed = state.started.elapsed();
+            // The bandwidth left unused while idle, e.g. while listing the files, isn't saved up
+            if elapsed > self.due(state.bytes) + MAX_BURST {
+                state.started = Instant::now();
+                state.bytes = 0;
+            }
+            state.bytes = state.bytes.saturating_add(bytes);
+            self.due(state.bytes)
+                .saturating_sub(state.started.elapsed())
+        };
+
+        if !wait.is_zero() {
+            sleep(wait).await;
+        }
+    }
+
+    /// Returns the time it takes to transfer the bytes at the capped bandwidth
+    fn due(&self, bytes: u64) -> Duration {
+        Duration::from_secs_f64(bytes as f64 / self.bytes_per_second as f64)
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_transfer_options_defaults() {
+        let options = TransferOptions::new(0, 0, 0);
+        assert!(options.max_concurrent.is_none());
+        assert!(options.bandwidth.is_none());
+        assert_eq!(options.chunk_size(), DEFAULT_CHUNK_SIZE);
+
+        let options = TransferOptions::new(16, 1024, 1_000_000);
+        assert_eq!(options.max_concurrent, Some(16));
+        assert_eq!(options.chunk_size(), 1024);
+        assert!(options.bandwidth.is_some());
+    }
+
+    #[tokio::test]
+    async fn test_bandwidth_limiter() {
+        let limiter = BandwidthLimiter::new(1000);
+        let start = Instant::now();
+        limiter.acquire(100).await;
+        limiter.acquire(100).await;
+        assert!(start.elapsed() >= Duration::from_millis(190));
+    }
+}
@@ -10,6 +10,7 @@ use progress_tracking::{
     TrackingProgressUpdater,
 };
 use std::path::Path;
+use std::sync::atomic::{AtomicU64, Ordering};
 use std::sync::Arc;
 use tokio::sync::Mutex;
 use tracing::{debug, info};
@@ -30,13 +31,13 @@ use crate::xet_integration::{XetConnectionInfo, XetFileData, XetTokenManager};
 /// This implements the `TokenRefresher` trait required by xet-core's auth system.
 /// When the CAS client detects that a token is about to expire, it calls the
 /// `refresh()` method to obtain fresh credentials.
-struct HfTokenRefresher {
+pub(crate) struct HfTokenRefresher {
     token_manager: Arc<Mutex<XetTokenManager>>,
     file_data: XetFileData,
 }
 
 impl HfTokenRefresher {
-    fn new(token_manager: Arc<Mutex<XetTokenManager>>, file_data: XetFileData) -> Self {
+    pub(crate) fn new(token_manager: Arc<Mutex<XetTokenManager>>, file_data: XetFileData) -> Self {
         Self {
             token_manager,
             file_data,
@@ -111,7 +112,9 @@ impl XetDownloader {
         Ok(Self { config, downloader })
     }
 
-    /// Download a file from XET CAS using its hash
+    /// Download a file from XET CAS using its hash.
+    ///
+    /// Returns the bytes fetched from CAS, the other bytes of the file came from deduplicated chunks.
     pub async fn download_file(
         &self,
         file_hash: &str,
@@ -132,10 +135,12 @@ impl XetDownloader {
         let output = OutputProvider::File(FileProvider::new(destination_path.to_path_buf()));
         let file_name_arc: Arc<str> = Arc::from(file_name.to_owned());
 
-        let progress_updater = progress.as_ref().map(|tracker| {
-            let bridge = Arc::new(ProgressBridge::new(tracker.clone_for_tasks()));
-            ItemProgressUpdater::new(bridge)
-        });
+        // The updater also counts the bytes fetched from CAS when progress isn't reported
+        let bridge = Arc::new(ProgressBridge::new(
+            progress.as_ref().map(|tracker| tracker.clone_for_tasks()),
+            file_name.to_owned(),
+        ));
+        let progress_updater = Some(ItemProgressUpdater::new(bridge.clone()));
 
         if let Some(ref tracker) = progress {
             tracker.ensure_file_entry(file_name, expected_size);
@@ -146,38 +151,54 @@ impl XetDownloader {
             .smudge_file_from_hash(&hash, file_name_arc, &output, None, progress_updater)
             .await?;
 
+        let transferred = bridge.transferred.load(Ordering::Relaxed);
         info!(
-            "Downloaded {} bytes from XET CAS to {:?}",
-            bytes_downloaded, destination_path
+            "Downloaded {} bytes from XET CAS to {:?}, {} bytes fetched",
+            bytes_downloaded, destination_path, transferred
         );
 
-        Ok(bytes_downloaded)
+        Ok(transferred)
     }
 }
 
 struct ProgressBridge {
-    progress: OperationProgress,
+    progress: Option<OperationProgress>,
+    // File of the updater: its transfer bytes are the chunks fetched from CAS for this file only
+    file_name: String,
+    transferred: AtomicU64,
 }
 
 impl ProgressBridge {
-    fn new(progress: OperationProgress) -> Self {
-        Self { progress }
+    fn new(progress: Option<OperationProgress>, file_name: String) -> Self {
+        Self {
+            progress,
+            file_name,
+            transferred: AtomicU64::new(0),
+        }
     }
 }
 
 #[async_trait]
 impl TrackingProgressUpdater for ProgressBridge {
     async fn register_updates(&self, updates: TrackerProgressUpdate) {
-        self.progress.apply_tracking_update(&updates);
+        self.transferred
+            .fetch_max(updates.total_transfer_bytes_completed, Ordering::Relaxed);
+        if let Some(ref progress) = self.progress {
+            progress
+                .update_file_transferred(&self.file_name, updates.total_transfer_bytes_completed);
+            progress.apply_tracking_update(&updates);
+        }
     }
 
     async fn flush(&self) {
-        self.progress.force_emit();
+        if let Some(ref progress) = self.progress {
+            progress.force_emit();
+        }
     }
 }
 
 /// Create XET configuration compatible with xet-core
-fn create_xet_config(
+pub(crate) fn create_xet_config(
     endpoint: String,
     token_info: Option<(String, u64)>,
     token_refresher: Option<Arc<dyn TokenRefresher>>,
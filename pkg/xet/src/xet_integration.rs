@@ -1,5 +1,7 @@
+use crate::auth::HfToken;
+use crate::network::NetworkConfig;
 use anyhow::{anyhow, Context, Result};
-use reqwest::header::{HeaderMap, HeaderValue};
+use reqwest::header::HeaderMap;
 use std::time::{SystemTime, UNIX_EPOCH};
 
 /// XET file metadata extracted from HuggingFace API responses
@@ -112,30 +114,19 @@ pub fn parse_xet_connection_info_from_headers(headers: &HeaderMap) -> Option<Xet
 /// XET token manager with automatic refresh
 pub struct XetTokenManager {
     client: reqwest::Client,
-    #[allow(dead_code)]
-    hf_token: Option<String>,
+    hf_token: HfToken,
     cached_connection_info: Option<(XetConnectionInfo, String)>, // (info, refresh_route)
 }
 
 impl XetTokenManager {
-    pub fn new(hf_token: Option<String>) -> Self {
-        let mut headers = HeaderMap::new();
-        if let Some(ref token) = hf_token {
-            if let Ok(header_value) = HeaderValue::from_str(&format!("Bearer {}", token)) {
-                headers.insert(reqwest::header::AUTHORIZATION, header_value);
-            }
-        }
-
-        let client = reqwest::Client::builder()
-            .default_headers(headers)
-            .build()
-            .unwrap_or_default();
+    pub fn new(hf_token: HfToken, network: &NetworkConfig) -> Result<Self> {
+        let client = network.client_builder()?.build()?;
 
-        Self {
+        Ok(Self {
             client,
             hf_token,
             cached_connection_info: None,
-        }
+        })
     }
 
     /// Check if the cached token is still valid
@@ -168,8 +159,8 @@ impl XetTokenManager {
         }
 
         let response = self
-            .client
-            .get(&file_data.refresh_route)
+            .hf_token
+            .authorize(self.client.get(&file_data.refresh_route))
             .send()
             .await
             .context("Failed to fetch XET connection info")?;
@@ -209,8 +200,8 @@ impl XetTokenManager {
         );
 
         let response = self
-            .client
-            .get(&url)
+            .hf_token
+            .authorize(self.client.get(&url))
             .send()
             .await
             .context("Failed to fetch XET token")?;
@@ -233,6 +224,7 @@ impl XetTokenManager {
 #[cfg(test)]
 mod tests {
     use super::*;
+    use reqwest::header::HeaderValue;
 
     #[test]
     fn test_parse_link_header() {
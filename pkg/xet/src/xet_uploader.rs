@@ -0,0 +1,115 @@
+// XET Core integration using FileUploadSession for deduplicated CAS uploads
+use anyhow::{anyhow, Context, Result};
+use sha2::{Digest, Sha256};
+use std::path::Path;
+use std::sync::Arc;
+use tokio::io::AsyncReadExt;
+use tokio::sync::Mutex;
+use tracing::info;
+use utils::auth::TokenRefresher;
+use xet_core_data::FileUploadSession;
+
+use crate::hf_adapter::is_cancelled;
+use crate::xet_downloader::{create_xet_config, HfTokenRefresher};
+use crate::xet_integration::{XetConnectionInfo, XetFileData, XetTokenManager};
+
+/// Size of the reads of the files fed to the chunker
+const UPLOAD_READ_SIZE: usize = 8 * 1024 * 1024;
+
+/// File uploaded to XET CAS
+#[derive(Debug, Clone)]
+pub struct XetUploadedFile {
+    /// XET hash of the file
+    pub xet_hash: String,
+    /// SHA256 of the file, the LFS oid of its commit
+    pub sha256: String,
+    pub size: u64,
+}
+
+/// XET Uploader that uses xet-core's FileUploadSession for CAS operations.
+///
+/// The session chunks the files and only uploads the chunks CAS doesn't already store, e.g. the unchanged parts of
+/// a checkpoint published again.
+pub struct XetUploader {
+    session: Arc<FileUploadSession>,
+}
+
+impl XetUploader {
+    /// Create a new XET uploader with write connection info from HuggingFace.
+    ///
+    /// # Arguments
+    /// * `connection_info` - Initial XET CAS write connection info (endpoint, token, expiration)
+    /// * `write_token` - XET metadata whose refresh route is the write token route of the repository
+    /// * `token_manager` - Shared token manager for refreshing tokens when they expire
+    pub async fn new(
+        connection_info: &XetConnectionInfo,
+        write_token: &XetFileData,
+        token_manager: Arc<Mutex<XetTokenManager>>,
+    ) -> Result<Self> {
+        let refresher: Arc<dyn TokenRefresher> =
+            Arc::new(HfTokenRefresher::new(token_manager, write_token.clone()));
+
+        let config = create_xet_config(
+            connection_info.endpoint.clone(),
+            Some((
+                connection_info.access_token.clone(),
+                connection_info.expiration_unix_epoch,
+            )),
+            Some(refresher),
+        )?;
+
+        let session = FileUploadSession::new(Arc::new(config), None).await?;
+        Ok(Self { session })
+    }
+
+    /// Chunk a file and upload its new chunks to XET CAS
+    pub async fn upload_file(
+        &self,
+        path: &Path,
+        file_name: &str,
+        cancel_check: Option<Arc<dyn Fn() -> bool + Send + Sync>>,
+    ) -> Result<XetUploadedFile> {
+        let mut file = tokio::fs::File::open(path)
+            .await
+            .with_context(|| format!("Failed to open {}", path.display()))?;
+        let size = file.metadata().await?.len();
+
+        let mut cleaner = self
+            .session
+            .start_clean(Some(Arc::from(file_name)), size)
+            .await;
+        let mut hasher = Sha256::new();
+        let mut buffer = vec![0u8; UPLOAD_READ_SIZE];
+        loop {
+            if is_cancelled(&cancel_check) {
+                return Err(anyhow!("Upload cancelled"));
+            }
+            let read = file.read(&mut buffer).await?;
+            if read == 0 {
+                break;
+            }
+            hasher.update(&buffer[..read]);
+            cleaner.add_data(&buffer[..read]).await?;
+        }
+        let (file_info, _metrics) = cleaner.finish().await?;
+
+        info!(
+            "Uploaded {} ({} bytes) to XET CAS as {}",
+            file_name,
+            size,
+            file_info.hash()
+        );
+
+        Ok(XetUploadedFile {
+            xet_hash: file_info.hash().to_string(),
+            sha256: format!("{:x}", hasher.finalize()),
+            size,
+        })
+    }
+
+    /// Upload the remaining chunks and the shards of the session. The files can be committed once it returns.
+    pub async fn finalize(self) -> Result<()> {
+        self.session.finalize().await?;
+        Ok(())
+    }
+}
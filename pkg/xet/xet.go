@@ -22,6 +22,7 @@ import (
 	"io"
 	"os"
 	"runtime/cgo"
+	"sync"
 	"time"
 	"unsafe"
 )
@@ -48,13 +49,17 @@ func goXetProgressCallback(update *C.XetProgressUpdate, userData unsafe.Pointer)
 	}
 
 	progress := ProgressUpdate{
-		Phase:                     ProgressPhase(int(update.phase)),
-		TotalBytes:                uint64(update.total_bytes),
-		CompletedBytes:            uint64(update.completed_bytes),
-		TotalFiles:                uint32(update.total_files),
-		CompletedFiles:            uint32(update.completed_files),
-		CurrentFileCompletedBytes: uint64(update.current_file_completed_bytes),
-		CurrentFileTotalBytes:     uint64(update.current_file_total_bytes),
+		Phase:                       ProgressPhase(int(update.phase)),
+		TotalBytes:                  uint64(update.total_bytes),
+		CompletedBytes:              uint64(update.completed_bytes),
+		TotalFiles:                  uint32(update.total_files),
+		CompletedFiles:              uint32(update.completed_files),
+		CurrentFileCompletedBytes:   uint64(update.current_file_completed_bytes),
+		CurrentFileTotalBytes:       uint64(update.current_file_total_bytes),
+		TransferredBytes:            uint64(update.transferred_bytes),
+		BytesPerSecond:              uint64(update.bytes_per_second),
+		CurrentFileTransferredBytes: uint64(update.current_file_transferred_bytes),
+		CurrentFileBytesPerSecond:   uint64(update.current_file_bytes_per_second),
 	}
 
 	if update.current_file != nil {
@@ -100,6 +105,10 @@ func (c *Client) SetProgressHandler(handler ProgressHandler, throttle time.Durat
 		c.progressHandle.Delete()
 		c.hasProgressCallback = false
 	}
+	if c.progressUpdates != nil {
+		c.progressUpdates.close()
+		c.progressUpdates = nil
+	}
 
 	if handler == nil {
 		return nil
@@ -150,11 +159,56 @@ func (c *Client) DisableProgress() error {
 	return c.SetProgressHandler(nil, 0)
 }
 
+// ProgressUpdates returns a channel receiving the progress updates of the operations of the client, in place of
+// the current progress handler. Updates are dropped while the buffer of the channel is full so that a slow consumer
+// never stalls the transfers. The channel is closed by DisableProgress, Close or when another handler is set.
+func (c *Client) ProgressUpdates(buffer int, throttle time.Duration) (<-chan ProgressUpdate, error) {
+	if buffer <= 0 {
+		buffer = 16
+	}
+
+	updates := &progressChannel{ch: make(chan ProgressUpdate, buffer)}
+	if err := c.SetProgressHandler(updates.send, throttle); err != nil {
+		return nil, err
+	}
+	c.progressUpdates = updates
+	return updates.ch, nil
+}
+
+// progressChannel forwards progress updates to a channel that can be closed while Rust still reports progress
+type progressChannel struct {
+	mu     sync.Mutex
+	ch     chan ProgressUpdate
+	closed bool
+}
+
+func (p *progressChannel) send(update ProgressUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.ch <- update:
+	default:
+	}
+}
+
+func (p *progressChannel) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.ch)
+	}
+}
+
 // Client represents an xet-core client for HF Hub operations
 type Client struct {
 	client              *C.XetClient
 	progressHandle      cgo.Handle
 	hasProgressCallback bool
+	progressUpdates     *progressChannel
 }
 
 type ProgressPhase int
@@ -188,6 +242,31 @@ type ProgressUpdate struct {
 	CurrentFile               string
 	CurrentFileCompletedBytes uint64
 	CurrentFileTotalBytes     uint64
+	// TransferredBytes are the completed bytes fetched over the network, the others were deduplicated by XET
+	// or found in the local cache
+	TransferredBytes            uint64
+	BytesPerSecond              uint64
+	CurrentFileTransferredBytes uint64
+	CurrentFileBytesPerSecond   uint64
+}
+
+// DedupRatio returns the share of the completed bytes that did not have to be transferred
+func (u ProgressUpdate) DedupRatio() float64 {
+	return dedupRatio(u.CompletedBytes, u.TransferredBytes)
+}
+
+// CurrentFileDedupRatio returns the share of the completed bytes of the current file that did not have to be
+// transferred
+func (u ProgressUpdate) CurrentFileDedupRatio() float64 {
+	return dedupRatio(u.CurrentFileCompletedBytes, u.CurrentFileTransferredBytes)
+}
+
+func dedupRatio(completed, transferred uint64) float64 {
+	// A file downloaded again after a failed XET attempt transfers more than it completes
+	if completed == 0 || transferred >= completed {
+		return 0
+	}
+	return 1 - float64(transferred)/float64(completed)
 }
 
 // ProgressHandler receives throttled progress updates from Rust.
@@ -197,6 +276,18 @@ type cancellationBridge struct {
 	ctx context.Context
 }
 
+// TransferOptions tunes the transfers of a single download. Zero values keep the settings of the client, so a
+// large snapshot can use more workers than the client default while a small fetch keeps it.
+type TransferOptions struct {
+	// MaxConcurrent is the number of files of a snapshot downloaded at once
+	MaxConcurrent uint32
+	// ChunkSize is the size of the buffered writes of HTTP downloads
+	ChunkSize uint64
+	// MaxBytesPerSecond caps the bandwidth of the downloads of the request. XET CAS transfers can't be capped, so
+	// the files are then downloaded over HTTP, without deduplication.
+	MaxBytesPerSecond uint64
+}
+
 // DownloadRequest represents a file download request
 type DownloadRequest struct {
 	RepoID   string
@@ -204,6 +295,7 @@ type DownloadRequest struct {
 	Revision string
 	Filename string
 	LocalDir string
+	TransferOptions
 }
 
 // SnapshotRequest represents a snapshot download request
@@ -214,6 +306,58 @@ type SnapshotRequest struct {
 	LocalDir       string
 	AllowPatterns  []string
 	IgnorePatterns []string
+	TransferOptions
+}
+
+// UploadRequest represents a file upload request
+type UploadRequest struct {
+	RepoID    string
+	RepoType  string
+	Revision  string
+	LocalPath string
+	// PathInRepo defaults to the name of the local file
+	PathInRepo    string
+	CommitMessage string
+}
+
+// UploadSnapshotRequest represents the upload of the files of a local directory in one commit
+type UploadSnapshotRequest struct {
+	RepoID   string
+	RepoType string
+	Revision string
+	LocalDir string
+	// PathInRepo is the directory of the files in the repository, the root when empty
+	PathInRepo    string
+	CommitMessage string
+}
+
+// CommitInfo describes the commit created by an upload
+type CommitInfo struct {
+	OID string
+	URL string
+}
+
+// CacheStats counts the reuse of the files downloaded by a client since its creation
+type CacheStats struct {
+	// Hits are the files found complete on disk instead of being downloaded
+	Hits            uint64
+	Misses          uint64
+	HitBytes        uint64
+	DownloadedBytes uint64
+	// DedupSavedBytes are the downloaded bytes XET served from deduplicated chunks instead of fetching them
+	DedupSavedBytes uint64
+	EvictedFiles    uint64
+	EvictedBytes    uint64
+	// CacheSize is the current size of the files in CacheDir
+	CacheSize uint64
+}
+
+// HitRatio returns the share of the files found on disk instead of being downloaded
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
 }
 
 // FileInfo represents file information
@@ -255,6 +399,8 @@ func NewClient(config *Config) (*Client, error) {
 	cConfig := C.XetConfig{
 		max_concurrent_downloads: C.uint32_t(config.MaxConcurrentDownloads),
 		enable_dedup:             C.bool(config.EnableDedup),
+		insecure_skip_verify:     C.bool(config.InsecureSkipVerify),
+		max_cache_size:           C.uint64_t(config.MaxCacheSize),
 	}
 
 	// Set string fields
@@ -270,12 +416,30 @@ func NewClient(config *Config) (*Client, error) {
 		cConfig.token = cToken
 	}
 
+	if config.TokenFile != "" {
+		cTokenFile := C.CString(config.TokenFile)
+		defer C.free(unsafe.Pointer(cTokenFile))
+		cConfig.token_file = cTokenFile
+	}
+
 	if config.CacheDir != "" {
 		cCacheDir := C.CString(config.CacheDir)
 		defer C.free(unsafe.Pointer(cCacheDir))
 		cConfig.cache_dir = cCacheDir
 	}
 
+	if config.ProxyURL != "" {
+		cProxyURL := C.CString(config.ProxyURL)
+		defer C.free(unsafe.Pointer(cProxyURL))
+		cConfig.proxy_url = cProxyURL
+	}
+
+	if config.CABundle != "" {
+		cCABundle := C.CString(config.CABundle)
+		defer C.free(unsafe.Pointer(cCABundle))
+		cConfig.ca_bundle = cCABundle
+	}
+
 	client := C.xet_client_new(&cConfig)
 	if client == nil {
 		return nil, fmt.Errorf("failed to create xet client")
@@ -295,6 +459,10 @@ func (c *Client) Close() error {
 		c.progressHandle.Delete()
 		c.hasProgressCallback = false
 	}
+	if c.progressUpdates != nil {
+		c.progressUpdates.close()
+		c.progressUpdates = nil
+	}
 
 	if c.client != nil {
 		C.xet_client_free(c.client)
@@ -344,6 +512,29 @@ func (c *Client) ListFiles(repoID string, revision string) ([]FileInfo, error) {
 	return files, nil
 }
 
+// CacheStats returns the cache statistics of the client
+func (c *Client) CacheStats() (*CacheStats, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("client is closed")
+	}
+
+	var cStats C.XetCacheStats
+	if errPtr := C.xet_client_cache_stats(c.client, &cStats); errPtr != nil {
+		return nil, convertError(errPtr)
+	}
+
+	return &CacheStats{
+		Hits:            uint64(cStats.hits),
+		Misses:          uint64(cStats.misses),
+		HitBytes:        uint64(cStats.hit_bytes),
+		DownloadedBytes: uint64(cStats.downloaded_bytes),
+		DedupSavedBytes: uint64(cStats.dedup_saved_bytes),
+		EvictedFiles:    uint64(cStats.evicted_files),
+		EvictedBytes:    uint64(cStats.evicted_bytes),
+		CacheSize:       uint64(cStats.cache_size),
+	}, nil
+}
+
 // DownloadFile downloads a single file from a repository
 func (c *Client) DownloadFile(req *DownloadRequest) (string, error) {
 	return c.DownloadFileWithContext(context.Background(), req)
@@ -360,6 +551,7 @@ func (c *Client) DownloadFileWithContext(ctx context.Context, req *DownloadReque
 
 	cReq := convertDownloadRequest(req)
 	defer freeDownloadRequest(&cReq)
+	cTransfer := convertTransferOptions(req.TransferOptions)
 
 	var outPath *C.char
 
@@ -374,7 +566,7 @@ func (c *Client) DownloadFileWithContext(ctx context.Context, req *DownloadReque
 		}
 	}
 
-	errPtr := C.xet_download_file(c.client, &cReq, cancelToken, &outPath)
+	errPtr := C.xet_download_file(c.client, &cReq, &cTransfer, cancelToken, &outPath)
 	if cancelHandle != 0 {
 		cancelHandle.Delete()
 	}
@@ -440,12 +632,14 @@ func (c *Client) DownloadSnapshotWithContext(ctx context.Context, req *SnapshotR
 		}
 	}
 
+	cTransfer := convertTransferOptions(req.TransferOptions)
 	errPtr := C.xet_download_snapshot(
 		c.client,
 		cRepoID,
 		cRepoType,
 		cRevision,
 		cLocalDir,
+		&cTransfer,
 		cancelToken,
 		&outPath,
 	)
@@ -462,8 +656,113 @@ func (c *Client) DownloadSnapshotWithContext(ctx context.Context, req *SnapshotR
 	return C.GoString(outPath), nil
 }
 
+// UploadFile uploads a file to a repository in one commit. Files the Hub stores with LFS are deduplicated with
+// xet: only their chunks that the CAS doesn't already store are uploaded.
+func (c *Client) UploadFile(req *UploadRequest) (*CommitInfo, error) {
+	return c.UploadFileWithContext(context.Background(), req)
+}
+
+// UploadFileWithContext uploads a file with cancellation support
+func (c *Client) UploadFileWithContext(ctx context.Context, req *UploadRequest) (*CommitInfo, error) {
+	if req == nil {
+		return nil, fmt.Errorf("upload request cannot be nil")
+	}
+	if req.LocalPath == "" {
+		return nil, fmt.Errorf("local path cannot be empty")
+	}
+	return c.upload(ctx, req.RepoID, req.RepoType, req.Revision, req.LocalPath, req.PathInRepo, req.CommitMessage, false)
+}
+
+// UploadSnapshot uploads the files of a local directory to a repository in one commit, e.g. a checkpoint. Files
+// the Hub stores with LFS are deduplicated with xet, so that publishing a checkpoint again only uploads the chunks
+// that changed.
+func (c *Client) UploadSnapshot(req *UploadSnapshotRequest) (*CommitInfo, error) {
+	return c.UploadSnapshotWithContext(context.Background(), req)
+}
+
+// UploadSnapshotWithContext uploads a snapshot with cancellation support
+func (c *Client) UploadSnapshotWithContext(ctx context.Context, req *UploadSnapshotRequest) (*CommitInfo, error) {
+	if req == nil {
+		return nil, fmt.Errorf("upload snapshot request cannot be nil")
+	}
+	if req.LocalDir == "" {
+		return nil, fmt.Errorf("local dir cannot be empty")
+	}
+	return c.upload(ctx, req.RepoID, req.RepoType, req.Revision, req.LocalDir, req.PathInRepo, req.CommitMessage, true)
+}
+
+// upload sends an upload request, of the files of the local directory of localPath for snapshots
+func (c *Client) upload(ctx context.Context, repoID, repoType, revision, localPath, pathInRepo, commitMessage string, snapshot bool) (*CommitInfo, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("client is closed")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if repoID == "" {
+		return nil, fmt.Errorf("repo_id cannot be empty")
+	}
+
+	cReq := C.XetUploadRequest{}
+	for _, field := range []struct {
+		value  string
+		target **C.char
+	}{
+		{repoID, &cReq.repo_id},
+		{repoType, &cReq.repo_type},
+		{revision, &cReq.revision},
+		{localPath, &cReq.local_path},
+		{pathInRepo, &cReq.path_in_repo},
+		{commitMessage, &cReq.commit_message},
+	} {
+		if field.value != "" {
+			*field.target = C.CString(field.value)
+			defer C.free(unsafe.Pointer(*field.target))
+		}
+	}
+
+	var cancelToken *C.XetCancellationToken
+	var cancelHandle cgo.Handle
+	if ctx.Done() != nil {
+		bridge := &cancellationBridge{ctx: ctx}
+		cancelHandle = cgo.NewHandle(bridge)
+		cancelToken = &C.XetCancellationToken{
+			callback:  (C.XetCancellationCallback)(C.goXetShouldCancel),
+			user_data: unsafe.Pointer(cancelHandle),
+		}
+	}
+
+	var outCommit *C.XetCommitInfo
+	var errPtr *C.XetError
+	if snapshot {
+		errPtr = C.xet_upload_snapshot(c.client, &cReq, cancelToken, &outCommit)
+	} else {
+		errPtr = C.xet_upload_file(c.client, &cReq, cancelToken, &outCommit)
+	}
+	if cancelHandle != 0 {
+		cancelHandle.Delete()
+	}
+	if errPtr != nil {
+		return nil, convertError(errPtr)
+	}
+	defer C.xet_free_commit_info(outCommit)
+
+	return &CommitInfo{
+		OID: C.GoString(outCommit.commit_oid),
+		URL: C.GoString(outCommit.commit_url),
+	}, nil
+}
+
 // Helper functions
 
+func convertTransferOptions(opts TransferOptions) C.XetTransferOptions {
+	return C.XetTransferOptions{
+		max_concurrent:       C.uint32_t(opts.MaxConcurrent),
+		chunk_size:           C.uint64_t(opts.ChunkSize),
+		max_bytes_per_second: C.uint64_t(opts.MaxBytesPerSecond),
+	}
+}
+
 func convertDownloadRequest(req *DownloadRequest) C.XetDownloadRequest {
 	cReq := C.XetDownloadRequest{}
 
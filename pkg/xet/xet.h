@@ -28,6 +28,10 @@ typedef struct {
     const char* current_file;
     uint64_t current_file_completed_bytes;
     uint64_t current_file_total_bytes;
+    uint64_t transferred_bytes;
+    uint64_t bytes_per_second;
+    uint64_t current_file_transferred_bytes;
+    uint64_t current_file_bytes_per_second;
 } XetProgressUpdate;
 
 typedef void (*XetProgressCallback)(const XetProgressUpdate* update, void* user_data);
@@ -46,6 +50,11 @@ typedef struct {
     const char* cache_dir;
     uint32_t max_concurrent_downloads;
     bool enable_dedup;
+    const char* proxy_url;
+    const char* ca_bundle;
+    bool insecure_skip_verify;
+    const char* token_file;
+    uint64_t max_cache_size;
 } XetConfig;
 
 // Download request structure
@@ -57,6 +66,13 @@ typedef struct {
     const char* local_dir;
 } XetDownloadRequest;
 
+// Transfer tuning of a download, zero fields keep the settings of the client
+typedef struct {
+    uint32_t max_concurrent;
+    uint64_t chunk_size;
+    uint64_t max_bytes_per_second;
+} XetTransferOptions;
+
 // Snapshot download request
 typedef struct {
     const char* repo_id;
@@ -69,6 +85,34 @@ typedef struct {
     size_t ignore_patterns_len;
 } XetSnapshotRequest;
 
+// Upload request, local_path is a directory for snapshot uploads
+typedef struct {
+    const char* repo_id;
+    const char* repo_type;
+    const char* revision;
+    const char* local_path;
+    const char* path_in_repo;
+    const char* commit_message;
+} XetUploadRequest;
+
+// Commit created by an upload
+typedef struct {
+    char* commit_oid;
+    char* commit_url;
+} XetCommitInfo;
+
+// Reuse counters of the downloaded files, cache_size is the size of the cache directory
+typedef struct {
+    uint64_t hits;
+    uint64_t misses;
+    uint64_t hit_bytes;
+    uint64_t downloaded_bytes;
+    uint64_t dedup_saved_bytes;
+    uint64_t evicted_files;
+    uint64_t evicted_bytes;
+    uint64_t cache_size;
+} XetCacheStats;
+
 // File information
 typedef struct {
     char* path;
@@ -102,6 +146,7 @@ XetError* xet_client_set_progress_callback(
     void* user_data,
     uint32_t throttle_ms
 );
+XetError* xet_client_cache_stats(XetClient* client, XetCacheStats* out_stats);
 
 // Repository operations
 XetError* xet_list_files(
@@ -115,6 +160,7 @@ XetError* xet_list_files(
 XetError* xet_download_file(
     XetClient* client,
     const XetDownloadRequest* request,
+    const XetTransferOptions* transfer_options,
     const XetCancellationToken* cancel_token,
     char** out_path
 );
@@ -125,13 +171,30 @@ XetError* xet_download_snapshot(
     const char* repo_type,
     const char* revision,
     const char* local_dir,
+    const XetTransferOptions* transfer_options,
     const XetCancellationToken* cancel_token,
     char** out_path
 );
 
+// Upload operations
+XetError* xet_upload_file(
+    XetClient* client,
+    const XetUploadRequest* request,
+    const XetCancellationToken* cancel_token,
+    XetCommitInfo** out_commit
+);
+
+XetError* xet_upload_snapshot(
+    XetClient* client,
+    const XetUploadRequest* request,
+    const XetCancellationToken* cancel_token,
+    XetCommitInfo** out_commit
+);
+
 // Memory management
 void xet_free_error(XetError* err);
 void xet_free_file_list(XetFileList* list);
+void xet_free_commit_info(XetCommitInfo* info);
 void xet_free_string(char* str);
 
 #ifdef __cplusplus
@@ -38,3 +38,80 @@ func TestProgressHelpersNilClient(t *testing.T) {
 		t.Fatal("expected error when disabling progress on uninitialized client")
 	}
 }
+
+func TestProgressDedupRatio(t *testing.T) {
+	update := ProgressUpdate{
+		CompletedBytes:              400,
+		TransferredBytes:            100,
+		CurrentFileCompletedBytes:   100,
+		CurrentFileTransferredBytes: 100,
+	}
+	if ratio := update.DedupRatio(); ratio != 0.75 {
+		t.Fatalf("expected dedup ratio 0.75, got %v", ratio)
+	}
+	if ratio := update.CurrentFileDedupRatio(); ratio != 0 {
+		t.Fatalf("expected no dedup for the current file, got %v", ratio)
+	}
+	if ratio := (ProgressUpdate{}).DedupRatio(); ratio != 0 {
+		t.Fatalf("expected no dedup without completed bytes, got %v", ratio)
+	}
+}
+
+func TestProgressChannel(t *testing.T) {
+	updates := &progressChannel{ch: make(chan ProgressUpdate, 1)}
+	updates.send(ProgressUpdate{CompletedBytes: 1})
+	// The buffer is full, the update is dropped instead of blocking
+	updates.send(ProgressUpdate{CompletedBytes: 2})
+	updates.close()
+	// Updates reported after the channel is closed are ignored
+	updates.send(ProgressUpdate{CompletedBytes: 3})
+	updates.close()
+
+	var received []uint64
+	for update := range updates.ch {
+		received = append(received, update.CompletedBytes)
+	}
+	if len(received) != 1 || received[0] != 1 {
+		t.Fatalf("expected only the first update, got %v", received)
+	}
+
+	var c *Client
+	if _, err := c.ProgressUpdates(0, 0); err == nil {
+		t.Fatal("expected error when subscribing to progress on nil client")
+	}
+}
+
+func TestCacheStatsHitRatio(t *testing.T) {
+	if ratio := (CacheStats{}).HitRatio(); ratio != 0 {
+		t.Fatalf("expected no hit ratio without downloads, got %v", ratio)
+	}
+	if ratio := (CacheStats{Hits: 3, Misses: 1}).HitRatio(); ratio != 0.75 {
+		t.Fatalf("expected hit ratio 0.75, got %v", ratio)
+	}
+
+	var c *Client
+	if _, err := c.CacheStats(); err == nil {
+		t.Fatal("expected error when reading cache stats of nil client")
+	}
+}
+
+func TestUploadValidation(t *testing.T) {
+	var c *Client
+	if _, err := c.UploadFile(&UploadRequest{RepoID: "org/model", LocalPath: "config.json"}); err == nil {
+		t.Fatal("expected error when uploading with nil client")
+	}
+
+	empty := &Client{}
+	if _, err := empty.UploadFile(nil); err == nil {
+		t.Fatal("expected error for nil upload request")
+	}
+	if _, err := empty.UploadFile(&UploadRequest{RepoID: "org/model"}); err == nil {
+		t.Fatal("expected error for upload without local path")
+	}
+	if _, err := empty.UploadSnapshot(&UploadSnapshotRequest{RepoID: "org/model"}); err == nil {
+		t.Fatal("expected error for snapshot upload without local dir")
+	}
+	if _, err := empty.UploadSnapshot(&UploadSnapshotRequest{RepoID: "org/model", LocalDir: "/tmp"}); err == nil {
+		t.Fatal("expected error when uploading with uninitialized client")
+	}
+}